@@ -0,0 +1,248 @@
+package main
+
+// loadgen fills Postgres with synthetic tenants and orders for benchmarking RLS policies, indexes and pagination
+//
+//	go run ./cmd/loadgen -tenants 50 -orders 20000 -days 180
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/database"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/secrets"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// loadgenDomain marks generated tenants and users so they are easy to find and remove
+const loadgenDomain = "loadgen.local"
+
+// options are the generator's command line settings
+type options struct {
+	tenants   int
+	orders    int
+	days      int
+	items     int
+	customers int
+	batch     int
+	seed      int64
+}
+
+func main() {
+	var opts options
+	flag.IntVar(&opts.tenants, "tenants", 10, "Number of tenants (restaurants) to create")
+	flag.IntVar(&opts.orders, "orders", 1000, "Orders per tenant")
+	flag.IntVar(&opts.days, "days", 90, "Days of history the orders are spread over, ending now")
+	flag.IntVar(&opts.items, "items", 30, "Menu items per tenant")
+	flag.IntVar(&opts.customers, "customers", 50, "Client users per tenant")
+	flag.IntVar(&opts.batch, "batch", repositories.BulkBatchSize, "Rows per INSERT batch")
+	flag.Int64Var(&opts.seed, "seed", time.Now().UnixNano(), "Random seed, for reproducible data sets")
+	flag.Parse()
+
+	if opts.tenants < 1 || opts.orders < 0 || opts.days < 1 || opts.items < 1 || opts.customers < 1 || opts.batch < 1 {
+		log.Fatal("tenants, days, items, customers and batch must be positive and orders can't be negative")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if cfg.Environment == "production" {
+		log.Fatal("loadgen refuses to write synthetic data to a production database")
+	}
+	if _, err := secrets.Load(context.Background(), cfg); err != nil {
+		log.Fatalf("Failed to load secrets: %v", err)
+	}
+
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// Every generated user shares one password hash; hashing per user would dominate the run time
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte("LoadGen123!"), bcrypt.MinCost)
+	if err != nil {
+		log.Fatalf("Failed to hash password: %v", err)
+	}
+
+	g := &generator{
+		db:           db,
+		opts:         opts,
+		rng:          rand.New(rand.NewSource(opts.seed)),
+		run:          time.Now().Format("20060102150405"),
+		passwordHash: string(passwordHash),
+		now:          time.Now(),
+	}
+
+	started := time.Now()
+	for i := 1; i <= opts.tenants; i++ {
+		if err := g.tenant(i); err != nil {
+			log.Fatalf("Failed to generate tenant %d: %v", i, err)
+		}
+	}
+
+	total := opts.tenants * opts.orders
+	elapsed := time.Since(started)
+	log.Printf("✓ Generated %d tenants and %d orders in %s (%.0f orders/s, seed %d)",
+		opts.tenants, total, elapsed.Round(time.Millisecond), float64(total)/elapsed.Seconds(), opts.seed)
+}
+
+// generator writes one run's tenants; run tags the run in generated emails
+type generator struct {
+	db           *gorm.DB
+	opts         options
+	rng          *rand.Rand
+	run          string
+	passwordHash string
+	now          time.Time
+}
+
+// tenant creates the n-th restaurant with its users, menu and orders
+func (g *generator) tenant(n int) error {
+	restaurant := models.Restaurant{
+		Name:       fmt.Sprintf("Load Test Restaurant %d", n),
+		Email:      fmt.Sprintf("restaurant-%s-%d@%s", g.run, n, loadgenDomain),
+		Status:     models.RestaurantStatusActive,
+		Visibility: models.RestaurantVisibilityPublic,
+	}
+	if err := g.db.Create(&restaurant).Error; err != nil {
+		return fmt.Errorf("failed to create restaurant: %w", err)
+	}
+
+	users := []models.User{{Email: fmt.Sprintf("admin-%s-%d@%s", g.run, n, loadgenDomain), Role: "Admin"}}
+	for i := 1; i <= g.opts.customers; i++ {
+		users = append(users, models.User{Email: fmt.Sprintf("client-%s-%d-%d@%s", g.run, n, i, loadgenDomain), Role: "Client"})
+	}
+	for i := range users {
+		users[i].RestaurantID = restaurant.ID
+		users[i].PasswordHash = g.passwordHash
+		users[i].IsActive = true
+	}
+	if err := repositories.CreateInBatches(g.db, &users, g.opts.batch); err != nil {
+		return fmt.Errorf("failed to create users: %w", err)
+	}
+	clients := users[1:]
+
+	category := models.MenuCategory{RestaurantID: restaurant.ID, Name: "Menu", IsActive: true}
+	if err := g.db.Create(&category).Error; err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+
+	menuItems := make([]models.MenuItem, g.opts.items)
+	for i := range menuItems {
+		menuItems[i] = models.MenuItem{
+			RestaurantID: restaurant.ID,
+			CategoryID:   category.ID,
+			Name:         fmt.Sprintf("Item %d", i+1),
+			Price:        money.Cents(300 + 50*g.rng.Intn(55)), // 3.00 - 30.00 in steps of 0.50
+			DisplayOrder: i,
+			IsAvailable:  true,
+			Tags:         []string{},
+		}
+	}
+	if err := repositories.CreateInBatches(g.db, &menuItems, g.opts.batch); err != nil {
+		return fmt.Errorf("failed to create menu items: %w", err)
+	}
+
+	// Orders and their items are inserted batch by batch so memory stays flat for large tenants
+	for written := 0; written < g.opts.orders; {
+		size := min(g.opts.batch, g.opts.orders-written)
+		orders := make([]models.Order, size)
+		for i := range orders {
+			orders[i] = g.order(restaurant.ID, clients, menuItems)
+		}
+		if err := repositories.CreateInBatches(g.db, &orders, g.opts.batch); err != nil {
+			return fmt.Errorf("failed to create orders: %w", err)
+		}
+		written += size
+	}
+
+	log.Printf("✓ Tenant %d/%d (restaurant %d): %d orders", n, g.opts.tenants, restaurant.ID, g.opts.orders)
+	return nil
+}
+
+// order builds a random order of the restaurant, placed at a realistic time
+func (g *generator) order(restaurantID uint, clients []models.User, menuItems []models.MenuItem) models.Order {
+	placedAt := g.placedAt()
+	fulfillments := []string{models.OrderFulfillmentPickup, models.OrderFulfillmentDineIn, models.OrderFulfillmentDelivery}
+	fulfillment := fulfillments[g.rng.Intn(len(fulfillments))]
+
+	status := models.OrderStatusCompleted
+	switch {
+	case g.now.Sub(placedAt) < time.Hour:
+		open := []string{models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusPreparing, models.OrderStatusReady}
+		status = open[g.rng.Intn(len(open))]
+	case g.rng.Intn(20) == 0:
+		status = models.OrderStatusCancelled
+	}
+
+	order := models.Order{
+		RestaurantID:    restaurantID,
+		UserID:          clients[g.rng.Intn(len(clients))].ID,
+		Status:          status,
+		Source:          models.OrderSourceInternal,
+		Channel:         fulfillment,
+		FulfillmentType: fulfillment,
+		CreatedAt:       placedAt,
+		UpdatedAt:       placedAt,
+	}
+
+	lines := 1 + g.rng.Intn(5)
+	for i := 0; i < lines; i++ {
+		menuItem := menuItems[g.rng.Intn(len(menuItems))]
+		quantity := 1 + g.rng.Intn(3)
+		order.OrderItems = append(order.OrderItems, models.OrderItem{
+			RestaurantID: restaurantID,
+			MenuItemID:   menuItem.ID,
+			Name:         menuItem.Name,
+			Quantity:     quantity,
+			Price:        menuItem.Price,
+			CreatedAt:    placedAt,
+			UpdatedAt:    placedAt,
+		})
+		order.TotalAmount += menuItem.Price.Times(quantity)
+	}
+
+	return order
+}
+
+// placedAt picks an order time within the history window: Fridays and Saturdays are busier, and most
+// orders fall around the lunch and dinner peaks
+func (g *generator) placedAt() time.Time {
+	for {
+		day := g.now.AddDate(0, 0, -g.rng.Intn(g.opts.days))
+		if weekday := day.Weekday(); weekday != time.Friday && weekday != time.Saturday && g.rng.Float64() < 0.3 {
+			continue
+		}
+
+		var hour float64
+		switch r := g.rng.Float64(); {
+		case r < 0.4:
+			hour = 12.5 + g.rng.NormFloat64()*0.75
+		case r < 0.9:
+			hour = 19.25 + g.rng.NormFloat64()
+		default:
+			hour = 10 + g.rng.Float64()*12
+		}
+		if hour < 0 || hour >= 24 {
+			continue
+		}
+
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+		placedAt := midnight.Add(time.Duration(hour * float64(time.Hour)))
+		if placedAt.After(g.now) {
+			// Later today hasn't happened yet; use the same time yesterday
+			placedAt = placedAt.AddDate(0, 0, -1)
+		}
+		return placedAt
+	}
+}
@@ -18,9 +18,14 @@ import (
 	"time"
 
 	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/database"
 	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/metrics"
+	"restaurant-backend/internal/pii"
 	"restaurant-backend/internal/router"
+	"restaurant-backend/internal/secrets"
+	"restaurant-backend/internal/tracing"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -31,6 +36,8 @@ func main() {
 	var migrateDown = flag.Bool("migrate-down", false, "Rollback last migration (down)")
 	var migrateStatus = flag.Bool("migrate-status", false, "Show migration status")
 	var bootstrap = flag.Bool("bootstrap", false, "Bootstrap platform organization and admin user")
+	var seedDemo = flag.Bool("seed-demo", false, "Seed a demo restaurant with 90 days of orders and reservations (non-production)")
+	var partitionOrders = flag.Bool("partition-orders", false, "Convert orders and order_items to monthly partitions (locks them while copying; run in a maintenance window)")
 	flag.Parse()
 
 	// Load configuration
@@ -52,6 +59,26 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	// Read the secrets held in Vault or SSM Parameter Store over the environment values
+	secretStore, err := secrets.Load(context.Background(), cfg)
+	if err != nil {
+		logger.Error("Failed to load secrets", zap.Error(err))
+		os.Exit(1)
+	}
+
+	// Initialize tracing before anything that creates spans (database, HTTP clients)
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg)
+	if err != nil {
+		logger.Error("Failed to initialize tracing", zap.Error(err))
+		os.Exit(1)
+	}
+
+	// Load the key customer contact data is encrypted with before anything reads or writes it
+	if err := pii.Setup(context.Background(), cfg); err != nil {
+		logger.Error("Failed to initialize PII encryption", zap.Error(err))
+		os.Exit(1)
+	}
+
 	// Initialize database connection
 	db, err := database.NewConnection(cfg)
 	if err != nil {
@@ -95,8 +122,55 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *seedDemo {
+		if err := database.SeedDemo(db, cfg); err != nil {
+			logger.Error("Failed to seed demo data", zap.Error(err))
+			os.Exit(1)
+		}
+		logger.Info("Demo seed completed successfully")
+		os.Exit(0)
+	}
+
+	// Export connection pool saturation alongside the runtime and queue capacity metrics
+	if sqlDB, err := db.DB(); err == nil {
+		metrics.RegisterDBStats(sqlDB, cfg.DBName)
+	}
+
+	// Build the dependency graph once; routes and background jobs share its services
+	deps := container.New(cfg, db)
+
+	if *partitionOrders {
+		if err := deps.OrderPartition.Partition(context.Background()); err != nil {
+			logger.Error("Failed to partition order tables", zap.Error(err))
+			os.Exit(1)
+		}
+		logger.Info("Order tables partitioned successfully")
+		os.Exit(0)
+	}
+
 	// Setup router
-	r := router.SetupRouter(cfg, db)
+	r := router.SetupRouter(deps)
+
+	// Start the background job scheduler (restaurant launches, integrity checks, invoices, delivery sync)
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go deps.Scheduler.Run(schedulerCtx)
+
+	// Load the rotated JWT signing keys and pick up rotations made on other instances
+	if err := deps.Auth.LoadSigningKeys(context.Background()); err != nil {
+		logger.Warn("Failed to load JWT signing keys, signing with the configured secret", zap.Error(err))
+	}
+	go deps.Auth.WatchSigningKeys(schedulerCtx, cfg.JWTKeyReloadInterval)
+
+	// Pick up JWT secret rotations in the secret store
+	if secretStore != nil {
+		go deps.Auth.WatchSigningSecret(schedulerCtx, cfg.SecretsRefreshInterval, func(ctx context.Context) (string, error) {
+			return secretStore.Get(ctx, secrets.JWTSecret)
+		})
+	}
+
+	// Keep the active sessions gauge current on every instance
+	go deps.Session.WatchActiveSessions(schedulerCtx, cfg.ActiveSessionsRefreshInterval)
 
 	// Configure server with graceful shutdown
 	srv := &http.Server{
@@ -121,6 +195,7 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 	logger.Info("Shutting down server...")
+	stopScheduler()
 
 	// The context is used to inform the server it has 5 seconds to finish
 	// the request it is currently handling
@@ -131,5 +206,10 @@ func main() {
 		logger.Error("Server forced to shutdown", zap.Error(err))
 	}
 
+	// Flush spans still buffered for export
+	if err := shutdownTracing(ctx); err != nil {
+		logger.Error("Failed to flush traces", zap.Error(err))
+	}
+
 	logger.Info("Server exiting")
 }
@@ -0,0 +1,248 @@
+package apperrors
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Code is a stable, machine-readable error identifier returned to API clients
+type Code string
+
+// Generic error codes
+const (
+	CodeValidationFailed Code = "VALIDATION_FAILED"
+	CodeBadRequest       Code = "BAD_REQUEST"
+	CodeUnauthorized     Code = "UNAUTHORIZED"
+	CodeForbidden        Code = "FORBIDDEN"
+	CodeNotFound         Code = "NOT_FOUND"
+	CodeConflict         Code = "CONFLICT"
+	CodeInternal         Code = "INTERNAL_ERROR"
+	CodeRequestTimeout   Code = "REQUEST_TIMEOUT"
+)
+
+// Domain error codes
+const (
+	CodeRestaurantNotFound       Code = "RESTAURANT_NOT_FOUND"
+	CodeOrganizationNotFound     Code = "ORGANIZATION_NOT_FOUND"
+	CodeLocationNotFound         Code = "LOCATION_NOT_FOUND"
+	CodeUserNotFound             Code = "USER_NOT_FOUND"
+	CodeCategoryNotFound         Code = "CATEGORY_NOT_FOUND"
+	CodeMenuItemNotFound         Code = "MENU_ITEM_NOT_FOUND"
+	CodeImageNotFound            Code = "IMAGE_NOT_FOUND"
+	CodeOrderNotFound            Code = "ORDER_NOT_FOUND"
+	CodeReservationNotFound      Code = "RESERVATION_NOT_FOUND"
+	CodeWebhookNotFound          Code = "WEBHOOK_NOT_FOUND"
+	CodeFileNotFound             Code = "FILE_NOT_FOUND"
+	CodeFindingNotFound          Code = "INTEGRITY_FINDING_NOT_FOUND"
+	CodeCustomerNotFound         Code = "CUSTOMER_NOT_FOUND"
+	CodeReviewNotFound           Code = "REVIEW_NOT_FOUND"
+	CodeInvoiceNotFound          Code = "INVOICE_NOT_FOUND"
+	CodeIntegrationNotFound      Code = "INTEGRATION_NOT_FOUND"
+	CodePrinterNotFound          Code = "PRINTER_NOT_FOUND"
+	CodePrintJobNotFound         Code = "PRINT_JOB_NOT_FOUND"
+	CodeCloseoutNotFound         Code = "CLOSEOUT_NOT_FOUND"
+	CodeOrderSplitNotFound       Code = "ORDER_SPLIT_NOT_FOUND"
+	CodeDeliveryZoneNotFound     Code = "DELIVERY_ZONE_NOT_FOUND"
+	CodeDriverNotFound           Code = "DRIVER_NOT_FOUND"
+	CodeDeliveryNotFound         Code = "DELIVERY_NOT_FOUND"
+	CodeTimeEntryNotFound        Code = "TIME_ENTRY_NOT_FOUND"
+	CodeFloorSectionNotFound     Code = "FLOOR_SECTION_NOT_FOUND"
+	CodeTableNotFound            Code = "TABLE_NOT_FOUND"
+	CodeTableSessionNotFound     Code = "TABLE_SESSION_NOT_FOUND"
+	CodePricingRuleNotFound      Code = "PRICING_RULE_NOT_FOUND"
+	CodeJobNotFound              Code = "SCHEDULED_JOB_NOT_FOUND"
+	CodeNotificationNotFound     Code = "NOTIFICATION_NOT_FOUND"
+	CodePushSubscriptionNotFound Code = "PUSH_SUBSCRIPTION_NOT_FOUND"
+	CodeSessionNotFound          Code = "SESSION_NOT_FOUND"
+	CodePermissionNotFound       Code = "PERMISSION_NOT_FOUND"
+	CodeInvitationNotFound       Code = "INVITATION_NOT_FOUND"
+	CodePrivateEventNotFound     Code = "PRIVATE_EVENT_NOT_FOUND"
+	CodeEventPackageNotFound     Code = "EVENT_PACKAGE_NOT_FOUND"
+	CodeDepositNotFound          Code = "DEPOSIT_NOT_FOUND"
+	CodeCartNotFound             Code = "CART_NOT_FOUND"
+	CodeIngredientNotFound       Code = "INGREDIENT_NOT_FOUND"
+	CodeSupplierNotFound         Code = "SUPPLIER_NOT_FOUND"
+	CodePurchaseOrderNotFound    Code = "PURCHASE_ORDER_NOT_FOUND"
+	CodePriceChangeNotFound      Code = "PRICE_CHANGE_NOT_FOUND"
+	CodePendingChangeNotFound    Code = "PENDING_CHANGE_NOT_FOUND"
+
+	CodeTableUnavailable     Code = "TABLE_UNAVAILABLE"
+	CodeMenuItemUnavailable  Code = "MENU_ITEM_UNAVAILABLE"
+	CodeRestaurantInactive   Code = "RESTAURANT_INACTIVE"
+	CodeRestaurantNotPublic  Code = "RESTAURANT_NOT_PUBLIC"
+	CodeEmailTaken           Code = "EMAIL_TAKEN"
+	CodeNameTaken            Code = "NAME_TAKEN"
+	CodeMenuNotEmpty         Code = "MENU_NOT_EMPTY"
+	CodeInvalidCredentials   Code = "INVALID_CREDENTIALS"
+	CodeInvalidToken         Code = "INVALID_TOKEN"
+	CodeInsufficientScope    Code = "INSUFFICIENT_PERMISSIONS"
+	CodeInvalidRole          Code = "INVALID_ROLE"
+	CodeInvalidPassword      Code = "INVALID_PASSWORD"
+	CodeInvalidTimeRange     Code = "INVALID_TIME_RANGE"
+	CodeTenantContextMissing Code = "TENANT_CONTEXT_MISSING"
+	CodeVersionConflict      Code = "VERSION_CONFLICT"
+	CodeBackupSyncFailed     Code = "BACKUP_SYNC_FAILED"
+	CodeInvalidTransition    Code = "INVALID_STATUS_TRANSITION"
+	CodeInvitationNotPending Code = "INVITATION_NOT_PENDING"
+	CodeEmailNotVerified     Code = "EMAIL_NOT_VERIFIED"
+	CodeTooManyRequests      Code = "TOO_MANY_REQUESTS"
+	CodeCustomerExists       Code = "CUSTOMER_EXISTS"
+	CodeReviewExists         Code = "REVIEW_EXISTS"
+	CodeOrderNotCompleted    Code = "ORDER_NOT_COMPLETED"
+	CodeInvalidPlan          Code = "INVALID_PLAN"
+	CodePlanLimitReached     Code = "PLAN_LIMIT_REACHED"
+	CodePlanDowngradeBlocked Code = "PLAN_DOWNGRADE_BLOCKED"
+	CodeSubscriptionInactive Code = "SUBSCRIPTION_INACTIVE"
+	CodeInvalidPeriod        Code = "INVALID_PERIOD"
+	CodeInvalidProvider      Code = "INVALID_PROVIDER"
+	CodeIntegrationExists    Code = "INTEGRATION_EXISTS"
+	CodeDeliverySyncFailed   Code = "DELIVERY_SYNC_FAILED"
+	CodePrinterInactive      Code = "PRINTER_INACTIVE"
+	CodeDayAlreadyClosed     Code = "DAY_ALREADY_CLOSED"
+	CodeDayNotOver           Code = "DAY_NOT_OVER"
+	CodeOrderSplitPaid       Code = "ORDER_SPLIT_PAID"
+	CodeOutsideOpeningHours  Code = "OUTSIDE_OPENING_HOURS"
+	CodeOrderSlotFull        Code = "ORDER_SLOT_FULL"
+	CodeOutsideDeliveryZone  Code = "OUTSIDE_DELIVERY_ZONE"
+	CodeBelowMinimumOrder    Code = "BELOW_MINIMUM_ORDER"
+	CodeDriverInactive       Code = "DRIVER_INACTIVE"
+	CodeDeliveryStarted      Code = "DELIVERY_STARTED"
+	CodeAlreadyClockedIn     Code = "ALREADY_CLOCKED_IN"
+	CodeNotClockedIn         Code = "NOT_CLOCKED_IN"
+	CodeTableNumberExists    Code = "TABLE_NUMBER_EXISTS"
+	CodeTableInactive        Code = "TABLE_INACTIVE"
+	CodeTableOccupied        Code = "TABLE_OCCUPIED"
+	CodeTableSessionClosed   Code = "TABLE_SESSION_CLOSED"
+	CodeRoundsInProgress     Code = "ROUNDS_IN_PROGRESS"
+	CodeCourseNotHeld        Code = "COURSE_NOT_HELD"
+	CodeCoursesHeld          Code = "COURSES_HELD"
+	CodeReorderMismatch      Code = "REORDER_MISMATCH"
+	CodeCategoryNotEmpty     Code = "CATEGORY_NOT_EMPTY"
+	CodeCategoryArchived     Code = "CATEGORY_ARCHIVED"
+	CodePushNotConfigured    Code = "PUSH_NOT_CONFIGURED"
+	CodeConsentRequired      Code = "CONSENT_REQUIRED"
+	CodeSSONotConfigured     Code = "SSO_NOT_CONFIGURED"
+	CodeSSOLoginFailed       Code = "SSO_LOGIN_FAILED"
+	CodeSSORoleNotMapped     Code = "SSO_ROLE_NOT_MAPPED"
+	CodeEventPackageInUse    Code = "EVENT_PACKAGE_IN_USE"
+	CodeDepositPaid          Code = "DEPOSIT_PAID"
+	CodePartyTooLarge        Code = "PARTY_TOO_LARGE"
+	CodeReservationSlotFull  Code = "RESERVATION_SLOT_FULL"
+	CodeOrderingPaused       Code = "ORDERING_PAUSED"
+	CodeChannelDisabled      Code = "CHANNEL_DISABLED"
+	CodeIngredientInUse      Code = "INGREDIENT_IN_USE"
+	CodeSupplierInUse        Code = "SUPPLIER_IN_USE"
+	CodeSupplierInactive     Code = "SUPPLIER_INACTIVE"
+	CodeNothingToPublish     Code = "NOTHING_TO_PUBLISH"
+)
+
+// Error is an error with an API error code and HTTP status
+// Message is safe to return to clients; the wrapped cause is only logged
+type Error struct {
+	Code    Code
+	Status  int
+	Message string
+	Details interface{}
+	Err     error
+}
+
+// Error returns the client-facing message
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the underlying cause, if any
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Response is the JSON error envelope returned by all endpoints
+type Response struct {
+	Error     string      `json:"error"`
+	Code      Code        `json:"code"`
+	Details   interface{} `json:"details,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+}
+
+// Response builds the JSON envelope for the error
+func (e *Error) Response() Response {
+	return Response{Error: e.Message, Code: e.Code, Details: e.Details}
+}
+
+// New creates an error with the given status, code and message
+func New(status int, code Code, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message}
+}
+
+// Wrap attaches a code, status and client-facing message to an underlying error
+func Wrap(err error, status int, code Code, message string) *Error {
+	return &Error{Code: code, Status: status, Message: message, Err: err}
+}
+
+// BadRequest creates a 400 error
+func BadRequest(code Code, message string) *Error {
+	return New(http.StatusBadRequest, code, message)
+}
+
+// Validation creates a 400 error for request binding/validation failures
+// Validator and JSON type errors are reported per field in Details (see FieldErrors)
+func Validation(err error) *Error {
+	appErr := Wrap(err, http.StatusBadRequest, CodeValidationFailed, validationMessage(err))
+	if fields := FieldErrors(err); len(fields) > 0 {
+		appErr.Details = fields
+	}
+	return appErr
+}
+
+// Unauthorized creates a 401 error
+func Unauthorized(code Code, message string) *Error {
+	return New(http.StatusUnauthorized, code, message)
+}
+
+// PaymentRequired creates a 402 error (subscription plan limits)
+func PaymentRequired(code Code, message string) *Error {
+	return New(http.StatusPaymentRequired, code, message)
+}
+
+// Forbidden creates a 403 error
+func Forbidden(code Code, message string) *Error {
+	return New(http.StatusForbidden, code, message)
+}
+
+// NotFound creates a 404 error
+func NotFound(code Code, message string) *Error {
+	return New(http.StatusNotFound, code, message)
+}
+
+// Conflict creates a 409 error
+func Conflict(code Code, message string) *Error {
+	return New(http.StatusConflict, code, message)
+}
+
+// Internal wraps an unexpected error as a 500; the cause is not exposed to clients
+func Internal(err error) *Error {
+	return Wrap(err, http.StatusInternalServerError, CodeInternal, "internal server error")
+}
+
+// From converts any error into an *Error, treating unknown errors as internal
+// Errors caused by an exceeded request deadline map to 504
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrRequestTimeout
+	}
+	return Internal(err)
+}
+
+// Common errors shared across handlers
+var (
+	ErrRestaurantContextMissing = New(http.StatusInternalServerError, CodeTenantContextMissing, "restaurant_id not found in context")
+	ErrUserContextMissing       = New(http.StatusInternalServerError, CodeTenantContextMissing, "user_id not found in context")
+	ErrInsufficientPermissions  = Forbidden(CodeInsufficientScope, "insufficient permissions")
+	ErrRequestTimeout           = New(http.StatusGatewayTimeout, CodeRequestTimeout, "request timed out")
+	ErrVersionConflict          = Conflict(CodeVersionConflict, "resource was modified by another request; reload and retry")
+)
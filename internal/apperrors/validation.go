@@ -0,0 +1,140 @@
+package apperrors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes why a single request field was rejected
+// Field is the JSON (or form) name, with the path for nested fields, e.g. "items[0].quantity"
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Field error codes; clients can rely on these instead of parsing messages
+const (
+	FieldRequired      = "required"
+	FieldInvalidEmail  = "invalid_email"
+	FieldInvalidURL    = "invalid_url"
+	FieldInvalidUUID   = "invalid_uuid"
+	FieldInvalidChoice = "invalid_choice"
+	FieldInvalidLength = "invalid_length"
+	FieldInvalidType   = "invalid_type"
+	FieldTooShort      = "too_short"
+	FieldTooLong       = "too_long"
+	FieldTooFew        = "too_few"
+	FieldTooMany       = "too_many"
+	FieldTooSmall      = "too_small"
+	FieldTooLarge      = "too_large"
+	FieldInvalid       = "invalid"
+)
+
+// FieldErrors converts binding errors into per-field errors
+// It understands validator errors and JSON type mismatches; other errors (malformed JSON, empty body) yield nil
+func FieldErrors(err error) []FieldError {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fields := make([]FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, fieldError(fe))
+		}
+		return fields
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Code:    FieldInvalidType,
+			Param:   typeErr.Type.String(),
+			Message: fmt.Sprintf("must be of type %s", typeErr.Type),
+		}}
+	}
+
+	return nil
+}
+
+// validationMessage is the top-level message for a binding error; field errors carry the specifics
+func validationMessage(err error) string {
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.Is(err, io.EOF):
+		return "request body is required"
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return "malformed JSON body"
+	case len(FieldErrors(err)) > 0:
+		return "request validation failed"
+	default:
+		return err.Error()
+	}
+}
+
+// fieldError translates one failed validator tag
+func fieldError(fe validator.FieldError) FieldError {
+	out := FieldError{Field: fieldPath(fe), Param: fe.Param()}
+
+	switch fe.Tag() {
+	case "required", "required_if", "required_unless", "required_with", "required_without":
+		out.Code, out.Param, out.Message = FieldRequired, "", "is required"
+	case "email":
+		out.Code, out.Message = FieldInvalidEmail, "must be a valid email address"
+	case "url", "http_url":
+		out.Code, out.Message = FieldInvalidURL, "must be a valid URL"
+	case "uuid", "uuid4":
+		out.Code, out.Message = FieldInvalidUUID, "must be a valid UUID"
+	case "oneof":
+		out.Code, out.Message = FieldInvalidChoice, "must be one of: "+strings.Join(strings.Fields(fe.Param()), ", ")
+	case "len":
+		out.Code, out.Message = FieldInvalidLength, fmt.Sprintf("must have length %s", fe.Param())
+	case "min", "gte":
+		out.Code, out.Message = boundError(fe.Kind(), true, fe.Param())
+	case "max", "lte":
+		out.Code, out.Message = boundError(fe.Kind(), false, fe.Param())
+	case "gt":
+		out.Code, out.Message = FieldTooSmall, fmt.Sprintf("must be greater than %s", fe.Param())
+	case "lt":
+		out.Code, out.Message = FieldTooLarge, fmt.Sprintf("must be less than %s", fe.Param())
+	default:
+		out.Code, out.Message = FieldInvalid, fmt.Sprintf("failed the %q check", fe.Tag())
+	}
+	return out
+}
+
+// boundError describes a failed min/max check by the kind of value: string length, element count or number
+func boundError(kind reflect.Kind, lower bool, param string) (string, string) {
+	switch kind {
+	case reflect.String:
+		if lower {
+			return FieldTooShort, fmt.Sprintf("must be at least %s characters", param)
+		}
+		return FieldTooLong, fmt.Sprintf("must be at most %s characters", param)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if lower {
+			return FieldTooFew, fmt.Sprintf("must contain at least %s items", param)
+		}
+		return FieldTooMany, fmt.Sprintf("must contain at most %s items", param)
+	default:
+		if lower {
+			return FieldTooSmall, fmt.Sprintf("must be at least %s", param)
+		}
+		return FieldTooLarge, fmt.Sprintf("must be at most %s", param)
+	}
+}
+
+// fieldPath is the field's path without the top-level struct name, e.g. "items[0].quantity"
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,6 +18,13 @@ type Config struct {
 	Environment string
 	LogLevel    string
 
+	// Fraction (0-1) of successful public (unauthenticated) requests that are logged; tenant requests
+	// and failures are always logged
+	LogPublicSampleRate float64
+
+	// Bearer token required to scrape /metrics; the endpoint is open when empty
+	MetricsToken string
+
 	// Database configuration
 	DBHost     string
 	DBPort     string
@@ -23,17 +33,58 @@ type Config struct {
 	DBName     string
 	DBSSLMode  string
 
+	// Database connection pool configuration
+	DBMaxOpenConns    int           // Upper bound on open connections (0 means unlimited)
+	DBMaxIdleConns    int           // Idle connections kept for reuse
+	DBConnMaxLifetime time.Duration // Connections are recycled after this age
+	DBConnMaxIdleTime time.Duration // Idle connections are closed after this long
+
+	// How often upcoming monthly partitions are created once the order tables are partitioned (--partition-orders)
+	OrderPartitionInterval time.Duration
+
+	// How often queued webhook deliveries are sent and failed ones retried
+	WebhookDeliveryInterval time.Duration
+
+	// Data archive: completed and cancelled orders and past reservations older than the restaurant's
+	// archive_after_days setting, or ArchiveAfterDays when that is 0 (0 keeps them), move to the archive tables
+	ArchiveAfterDays   int
+	ArchiveJobInterval time.Duration
+
+	// Read replicas (postgres:// URLs) for reads that tolerate replication lag: reports, exports and public
+	// menus. Writes always go to the primary; without replicas every query does
+	DBReplicaURLs []string
+
 	// AWS configuration
 	AWSRegion          string
 	AWSAccessKeyID     string
 	AWSSecretAccessKey string
 
 	// S3 configuration
-	S3BucketName string
+	S3BucketName   string
+	S3Endpoint     string // Custom endpoint (e.g., MinIO); empty uses AWS
+	S3UsePathStyle bool
+
+	// S3 backup configuration (per-tenant versioning retention and cross-region replication)
+	S3BackupRetentionDays     int    // Default days noncurrent object versions are kept
+	S3ReplicationRoleARN      string // IAM role S3 assumes to replicate objects
+	S3ReplicationBucketARN    string // Destination bucket in another region (premium tenants)
+	S3ReplicationStorageClass string // Storage class for replicas (e.g., STANDARD_IA)
+
+	// Storage configuration
+	StorageBackend       string // s3, minio, or local
+	LocalStoragePath     string
+	LocalStorageBaseURL  string
+	StorageSigningSecret string // Signs the expiring file URLs of the local backend; never the JWT secret, which rotates
 
 	// JWT configuration
-	JWTSecret     string
-	JWTExpiration int // in hours
+	JWTSecret            string
+	JWTExpiration        int           // in hours
+	JWTKeyReloadInterval time.Duration // How often rotated signing keys are reloaded from the database
+
+	// KAM impersonation ("act as restaurant") configuration
+	ImpersonationTokenTTL time.Duration // Lifetime of impersonation tokens
+	InvitationTTL         time.Duration // How long a user invitation's accept link works
+	EmailVerificationTTL  time.Duration // How long an email verification link works
 
 	// CORS configuration
 	CORSAllowedOrigins []string
@@ -47,6 +98,105 @@ type Config struct {
 	// Bootstrap configuration (for initial admin user)
 	BootstrapAdminEmail    string
 	BootstrapAdminPassword string
+
+	// Latency budget configuration (per-route-group request timeouts)
+	RequestTimeout       time.Duration // Default for all API routes
+	UploadRequestTimeout time.Duration // File uploads (images, avatars)
+	ReportRequestTimeout time.Duration // Dashboards and reports
+	ExportRequestTimeout time.Duration // CSV/XLSX exports of orders, reservations and customers
+	SlowRequestThreshold time.Duration // Requests slower than this are logged
+
+	// Tenant integrity checker configuration
+	IntegrityCheckInterval  time.Duration // How often the integrity scan runs
+	IntegrityAutoQuarantine bool          // Quarantine offending rows automatically when found
+
+	// Readiness probe configuration
+	ReadinessCheckTimeout time.Duration // Per-dependency timeout for /ready checks
+
+	// Usage metering and invoicing configuration (prices in cents of BillingCurrency)
+	BillingCurrency           string
+	BillingOrderFeeCents      int           // Per processed order
+	BillingEmailFeeCents      int           // Per 1000 emails sent
+	BillingStorageFeeCents    int           // Per GB of object storage
+	InvoiceGenerationInterval time.Duration // How often last month's draft invoices are generated
+
+	// Delivery platform integration configuration
+	DeliverySyncInterval time.Duration // How often menus are pushed and orders pulled
+	UberEatsAPIURL       string
+	DeliverooAPIURL      string
+
+	// External booking channel configuration (Google Reserve)
+	GoogleReserveAPIURL    string
+	GoogleReservePartnerID string // Our partner ID with Reserve with Google, part of the notification API paths
+
+	// Analytics rollup configuration (daily_restaurant_stats)
+	AnalyticsRollupInterval            time.Duration // How often recent days are rebuilt for every restaurant
+	AnalyticsIncrementalRollupInterval time.Duration // How often days with changed orders or reservations are rebuilt
+
+	// Restaurant health score configuration
+	HealthScoreInterval time.Duration // How often health scores and churn-risk flags are recomputed
+	HealthQuietDays     int           // Days without orders after which an active restaurant is flagged as quiet
+
+	// Menu recommendation configuration
+	MenuPairingInterval time.Duration // How often the "frequently ordered together" pairings are recomputed
+
+	// Public ordering cart configuration
+	CartTTL           time.Duration // Carts unchanged for this long expire and are deleted
+	CartRecoveryDelay time.Duration // Idle time after which the shopper of an abandoned cart is emailed a resume link
+	CartJobInterval   time.Duration // How often expired carts are deleted and recovery emails sent
+
+	// In-app notification configuration
+	NotificationRetention       time.Duration // Notifications older than this are deleted
+	NotificationCleanupInterval time.Duration // How often old notifications are deleted
+
+	// SMS configuration (Twilio); messages are only logged without an account SID
+	TwilioAccountSID        string
+	TwilioAuthToken         string
+	TwilioFromNumber        string // E.164 sender number
+	TwilioAPIURL            string
+	TwilioStatusCallbackURL string        // Public URL of the delivery status callback, empty to skip status updates
+	SMSReminderLeadTime     time.Duration // How long before a reservation the reminder is sent
+	SMSReminderInterval     time.Duration // How often due reservation reminders are sent
+
+	// Web push configuration (VAPID); push notifications are disabled without a key pair
+	VAPIDPublicKey                  string // URL-safe base64, handed to browsers to subscribe
+	VAPIDPrivateKey                 string
+	VAPIDSubject                    string        // Contact of the sender, a mailto: or https: URL
+	PushSubscriptionMaxAge          time.Duration // Subscriptions unused for this long are deleted
+	PushSubscriptionCleanupInterval time.Duration // How often expired and stale subscriptions are deleted
+
+	// PII encryption at rest (customer contact fields); the KMS ciphertext wins over the plain key
+	PIIEncryptionKey              string // Base64 of a 32-byte AES-256 key
+	PIIEncryptionKeyKMSCiphertext string // Base64 KMS ciphertext of the 32-byte data key, decrypted at startup
+
+	// Customer notification preferences configuration
+	UnsubscribeTokenSecret string // Signs email unsubscribe links; falls back to the JWT secret when empty
+
+	// Public content (SEO) configuration
+	PublicAPIURL string // Public URL of this API, linked from the sitemap index to the restaurant sitemaps
+
+	// Staff SSO (OpenID Connect) configuration; identity providers are configured per restaurant
+	SSOCallbackURL string        // Public URL of the SSO callback, registered as redirect URI at every IdP
+	SSOStateTTL    time.Duration // How long a started SSO login may take
+
+	// Login session configuration
+	SessionRetention              time.Duration // Ended (expired or revoked) sessions are deleted after this long
+	SessionCleanupInterval        time.Duration // How often ended sessions are deleted
+	ActiveSessionsRefreshInterval time.Duration // How often the active sessions gauge is refreshed
+
+	// Secret store configuration; DB_PASSWORD, JWT_SECRET, BREVO_API_KEY and the AWS keys are read from it at startup
+	SecretsProvider        string        // "env" (environment only), "vault" or "ssm"
+	SecretsRefreshInterval time.Duration // How often the JWT secret is re-read from the store to pick up rotations
+	VaultAddr              string
+	VaultToken             string
+	VaultSecretPath        string // KV v2 API path of the secret holding one key per variable
+	SSMParameterPrefix     string // Parameter Store path prefix; parameters are named after the variables
+
+	// OpenTelemetry tracing configuration (standard OTEL_* variables); tracing is off without an endpoint
+	OTelServiceName      string
+	OTelExporterEndpoint string  // OTLP/HTTP collector, e.g. "localhost:4318"
+	OTelExporterInsecure bool    // Send to the collector over plain HTTP
+	OTelSampleRatio      float64 // Fraction of new traces to record (0-1); sampled parents are always followed
 }
 
 // Load reads configuration from environment variables
@@ -69,37 +219,125 @@ func Load() (*Config, error) {
 	}
 
 	cfg := &Config{
-		ServerPort:             getEnv("SERVER_PORT", "8080"),
-		Environment:            getEnv("ENVIRONMENT", "development"),
-		LogLevel:               getEnv("LOG_LEVEL", "info"),
-		DBHost:                 getEnv("DB_HOST", "localhost"),
-		DBPort:                 getEnv("DB_PORT", "5432"),
-		DBUser:                 getEnv("DB_USER", "postgres"),
-		DBPassword:             getEnv("DB_PASSWORD", ""),
-		DBName:                 getEnv("DB_NAME", "restaurant_db"),
-		DBSSLMode:              getEnv("DB_SSL_MODE", "disable"),
-		AWSRegion:              getEnv("AWS_REGION", "us-east-1"),
-		AWSAccessKeyID:         getEnv("AWS_ACCESS_KEY_ID", ""),
-		AWSSecretAccessKey:     getEnv("AWS_SECRET_ACCESS_KEY", ""),
-		S3BucketName:           getEnv("S3_BUCKET_NAME", ""),
-		JWTSecret:              getEnv("JWT_SECRET", ""),
-		JWTExpiration:          getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
-		BrevoAPIKey:            getEnv("BREVO_API_KEY", ""),
-		BrevoSenderEmail:       getEnv("BREVO_SENDER_EMAIL", "noreply@restaurant-platform.local"),
-		BrevoSenderName:        getEnv("BREVO_SENDER_NAME", "Restaurant Platform"),
-		FrontendURL:            getEnv("FRONTEND_URL", "http://localhost:3000"),
-		BootstrapAdminEmail:    getEnv("BOOTSTRAP_ADMIN_EMAIL", "admin@platform.local"),
-		BootstrapAdminPassword: getEnv("BOOTSTRAP_ADMIN_PASSWORD", ""),
-	}
-
-	// Validate required fields
-	if cfg.DBPassword == "" {
-		return nil, fmt.Errorf("DB_PASSWORD is required")
-	}
-	if cfg.JWTSecret == "" && cfg.Environment == "production" {
-		return nil, fmt.Errorf("JWT_SECRET is required in production")
+		ServerPort:                         getEnv("SERVER_PORT", "8080"),
+		Environment:                        getEnv("ENVIRONMENT", "development"),
+		LogLevel:                           getEnv("LOG_LEVEL", "info"),
+		LogPublicSampleRate:                getEnvAsFloat("LOG_PUBLIC_SAMPLE_RATE", 1),
+		MetricsToken:                       getEnv("METRICS_TOKEN", ""),
+		DBHost:                             getEnv("DB_HOST", "localhost"),
+		DBPort:                             getEnv("DB_PORT", "5432"),
+		DBUser:                             getEnv("DB_USER", "postgres"),
+		DBPassword:                         getEnv("DB_PASSWORD", ""),
+		DBName:                             getEnv("DB_NAME", "restaurant_db"),
+		DBSSLMode:                          getEnv("DB_SSL_MODE", "disable"),
+		DBMaxOpenConns:                     getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:                     getEnvAsInt("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetime:                  getEnvAsDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute),
+		DBConnMaxIdleTime:                  getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute),
+		DBReplicaURLs:                      getEnvAsList("DB_REPLICA_URLS"),
+		OrderPartitionInterval:             getEnvAsDuration("ORDER_PARTITION_INTERVAL", 24*time.Hour),
+		WebhookDeliveryInterval:            getEnvAsDuration("WEBHOOK_DELIVERY_INTERVAL", 10*time.Second),
+		ArchiveAfterDays:                   getEnvAsInt("ARCHIVE_AFTER_DAYS", 0),
+		ArchiveJobInterval:                 getEnvAsDuration("ARCHIVE_JOB_INTERVAL", 24*time.Hour),
+		AWSRegion:                          getEnv("AWS_REGION", "us-east-1"),
+		AWSAccessKeyID:                     getEnv("AWS_ACCESS_KEY_ID", ""),
+		AWSSecretAccessKey:                 getEnv("AWS_SECRET_ACCESS_KEY", ""),
+		S3BucketName:                       getEnv("S3_BUCKET_NAME", ""),
+		S3Endpoint:                         getEnv("S3_ENDPOINT", ""),
+		S3UsePathStyle:                     getEnvAsBool("S3_USE_PATH_STYLE", false),
+		S3BackupRetentionDays:              getEnvAsInt("S3_BACKUP_RETENTION_DAYS", 30),
+		S3ReplicationRoleARN:               getEnv("S3_REPLICATION_ROLE_ARN", ""),
+		S3ReplicationBucketARN:             getEnv("S3_REPLICATION_BUCKET_ARN", ""),
+		S3ReplicationStorageClass:          getEnv("S3_REPLICATION_STORAGE_CLASS", "STANDARD_IA"),
+		StorageBackend:                     getEnv("STORAGE_BACKEND", "s3"),
+		LocalStoragePath:                   getEnv("LOCAL_STORAGE_PATH", "./uploads"),
+		StorageSigningSecret:               getEnv("STORAGE_SIGNING_SECRET", ""),
+		JWTSecret:                          getEnv("JWT_SECRET", ""),
+		JWTExpiration:                      getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
+		JWTKeyReloadInterval:               getEnvAsDuration("JWT_KEY_RELOAD_INTERVAL", time.Minute),
+		ImpersonationTokenTTL:              getEnvAsDuration("IMPERSONATION_TOKEN_TTL", 30*time.Minute),
+		InvitationTTL:                      getEnvAsDuration("INVITATION_TTL", 7*24*time.Hour),
+		EmailVerificationTTL:               getEnvAsDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour),
+		BrevoAPIKey:                        getEnv("BREVO_API_KEY", ""),
+		BrevoSenderEmail:                   getEnv("BREVO_SENDER_EMAIL", "noreply@restaurant-platform.local"),
+		BrevoSenderName:                    getEnv("BREVO_SENDER_NAME", "Restaurant Platform"),
+		FrontendURL:                        getEnv("FRONTEND_URL", "http://localhost:3000"),
+		BootstrapAdminEmail:                getEnv("BOOTSTRAP_ADMIN_EMAIL", "admin@platform.local"),
+		BootstrapAdminPassword:             getEnv("BOOTSTRAP_ADMIN_PASSWORD", ""),
+		RequestTimeout:                     getEnvAsDuration("REQUEST_TIMEOUT", 15*time.Second),
+		UploadRequestTimeout:               getEnvAsDuration("UPLOAD_REQUEST_TIMEOUT", 60*time.Second),
+		ReportRequestTimeout:               getEnvAsDuration("REPORT_REQUEST_TIMEOUT", 30*time.Second),
+		SlowRequestThreshold:               getEnvAsDuration("SLOW_REQUEST_THRESHOLD", time.Second),
+		IntegrityCheckInterval:             getEnvAsDuration("INTEGRITY_CHECK_INTERVAL", 24*time.Hour),
+		IntegrityAutoQuarantine:            getEnvAsBool("INTEGRITY_AUTO_QUARANTINE", false),
+		ReadinessCheckTimeout:              getEnvAsDuration("READINESS_CHECK_TIMEOUT", 2*time.Second),
+		BillingCurrency:                    getEnv("BILLING_CURRENCY", "USD"),
+		BillingOrderFeeCents:               getEnvAsInt("BILLING_ORDER_FEE_CENTS", 5),
+		BillingEmailFeeCents:               getEnvAsInt("BILLING_EMAIL_FEE_CENTS", 100),
+		BillingStorageFeeCents:             getEnvAsInt("BILLING_STORAGE_FEE_CENTS", 10),
+		InvoiceGenerationInterval:          getEnvAsDuration("INVOICE_GENERATION_INTERVAL", 6*time.Hour),
+		DeliverySyncInterval:               getEnvAsDuration("DELIVERY_SYNC_INTERVAL", time.Minute),
+		UberEatsAPIURL:                     getEnv("UBER_EATS_API_URL", "https://api.uber.com"),
+		DeliverooAPIURL:                    getEnv("DELIVEROO_API_URL", "https://api.developers.deliveroo.com"),
+		GoogleReserveAPIURL:                getEnv("GOOGLE_RESERVE_API_URL", "https://mapsbooking.googleapis.com"),
+		GoogleReservePartnerID:             getEnv("GOOGLE_RESERVE_PARTNER_ID", ""),
+		AnalyticsRollupInterval:            getEnvAsDuration("ANALYTICS_ROLLUP_INTERVAL", 24*time.Hour),
+		AnalyticsIncrementalRollupInterval: getEnvAsDuration("ANALYTICS_INCREMENTAL_ROLLUP_INTERVAL", time.Hour),
+		HealthScoreInterval:                getEnvAsDuration("HEALTH_SCORE_INTERVAL", 6*time.Hour),
+		HealthQuietDays:                    getEnvAsInt("HEALTH_QUIET_DAYS", 7),
+		MenuPairingInterval:                getEnvAsDuration("MENU_PAIRING_INTERVAL", 24*time.Hour),
+		CartTTL:                            getEnvAsDuration("CART_TTL", 7*24*time.Hour),
+		CartRecoveryDelay:                  getEnvAsDuration("CART_RECOVERY_DELAY", 2*time.Hour),
+		CartJobInterval:                    getEnvAsDuration("CART_JOB_INTERVAL", 15*time.Minute),
+		NotificationRetention:              getEnvAsDuration("NOTIFICATION_RETENTION", 30*24*time.Hour),
+		NotificationCleanupInterval:        getEnvAsDuration("NOTIFICATION_CLEANUP_INTERVAL", 24*time.Hour),
+		TwilioAccountSID:                   getEnv("TWILIO_ACCOUNT_SID", ""),
+		TwilioAuthToken:                    getEnv("TWILIO_AUTH_TOKEN", ""),
+		TwilioFromNumber:                   getEnv("TWILIO_FROM_NUMBER", ""),
+		TwilioAPIURL:                       getEnv("TWILIO_API_URL", "https://api.twilio.com"),
+		TwilioStatusCallbackURL:            getEnv("TWILIO_STATUS_CALLBACK_URL", ""),
+		SMSReminderLeadTime:                getEnvAsDuration("SMS_REMINDER_LEAD_TIME", 2*time.Hour),
+		SMSReminderInterval:                getEnvAsDuration("SMS_REMINDER_INTERVAL", 5*time.Minute),
+		VAPIDPublicKey:                     getEnv("VAPID_PUBLIC_KEY", ""),
+		VAPIDPrivateKey:                    getEnv("VAPID_PRIVATE_KEY", ""),
+		VAPIDSubject:                       getEnv("VAPID_SUBJECT", "mailto:noreply@restaurant-platform.local"),
+		PushSubscriptionMaxAge:             getEnvAsDuration("PUSH_SUBSCRIPTION_MAX_AGE", 90*24*time.Hour),
+		PushSubscriptionCleanupInterval:    getEnvAsDuration("PUSH_SUBSCRIPTION_CLEANUP_INTERVAL", 24*time.Hour),
+		PIIEncryptionKey:                   getEnv("PII_ENCRYPTION_KEY", ""),
+		PIIEncryptionKeyKMSCiphertext:      getEnv("PII_ENCRYPTION_KEY_KMS_CIPHERTEXT", ""),
+		UnsubscribeTokenSecret:             getEnv("UNSUBSCRIBE_TOKEN_SECRET", ""),
+		SSOStateTTL:                        getEnvAsDuration("SSO_STATE_TTL", 10*time.Minute),
+		SessionRetention:                   getEnvAsDuration("SESSION_RETENTION", 30*24*time.Hour),
+		SessionCleanupInterval:             getEnvAsDuration("SESSION_CLEANUP_INTERVAL", 24*time.Hour),
+		ActiveSessionsRefreshInterval:      getEnvAsDuration("ACTIVE_SESSIONS_REFRESH_INTERVAL", time.Minute),
+		SecretsProvider:                    getEnv("SECRETS_PROVIDER", "env"),
+		SecretsRefreshInterval:             getEnvAsDuration("SECRETS_REFRESH_INTERVAL", 5*time.Minute),
+		VaultAddr:                          getEnv("VAULT_ADDR", ""),
+		VaultToken:                         getEnv("VAULT_TOKEN", ""),
+		VaultSecretPath:                    getEnv("VAULT_SECRET_PATH", "secret/data/restaurant-backend"),
+		SSMParameterPrefix:                 getEnv("SSM_PARAMETER_PREFIX", "/restaurant-backend/"),
+		OTelServiceName:                    getEnv("OTEL_SERVICE_NAME", "restaurant-backend"),
+		OTelExporterEndpoint:               getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+		OTelExporterInsecure:               getEnvAsBool("OTEL_EXPORTER_OTLP_INSECURE", false),
+		OTelSampleRatio:                    getEnvAsFloat("OTEL_TRACES_SAMPLER_ARG", 1),
+	}
+
+	// Secrets held in a secret store are read and validated at startup by the secrets package
+	if cfg.SecretsProvider == "" || cfg.SecretsProvider == "env" {
+		if err := cfg.ValidateSecrets(); err != nil {
+			return nil, err
+		}
 	}
 
+	// Local storage serves files through this API, so default to the server address
+	cfg.LocalStorageBaseURL = getEnv("LOCAL_STORAGE_BASE_URL", fmt.Sprintf("http://localhost:%s", cfg.ServerPort))
+
+	// Restaurant sitemaps are served by this API
+	cfg.PublicAPIURL = getEnv("PUBLIC_API_URL", fmt.Sprintf("http://localhost:%s", cfg.ServerPort))
+
+	// The SSO callback is served by this API too
+	cfg.SSOCallbackURL = getEnv("SSO_CALLBACK_URL", fmt.Sprintf("http://localhost:%s/api/v1/auth/sso/callback", cfg.ServerPort))
+
 	// Parse CORS origins
 	corsOrigins := getEnv("CORS_ALLOWED_ORIGINS", "*")
 	if corsOrigins != "*" {
@@ -111,6 +349,25 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// ValidateSecrets checks the required secrets are set and derives the secrets that fall back to others
+func (c *Config) ValidateSecrets() error {
+	if c.DBPassword == "" {
+		return fmt.Errorf("DB_PASSWORD is required")
+	}
+	if c.JWTSecret == "" && c.Environment == "production" {
+		return fmt.Errorf("JWT_SECRET is required in production")
+	}
+	if c.StorageSigningSecret == "" && c.StorageBackend == "local" && c.Environment == "production" {
+		return fmt.Errorf("STORAGE_SIGNING_SECRET is required for local storage in production")
+	}
+
+	// Unsubscribe links are signed with the JWT secret unless they have their own
+	if c.UnsubscribeTokenSecret == "" {
+		c.UnsubscribeTokenSecret = c.JWTSecret
+	}
+	return nil
+}
+
 // getEnv retrieves an environment variable or returns a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -128,3 +385,53 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return intValue
 }
+
+// getEnvAsFloat retrieves an environment variable as a float or returns a default value
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return floatValue
+}
+
+// getEnvAsBool retrieves an environment variable as a boolean or returns a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return boolValue
+}
+
+// getEnvAsDuration retrieves an environment variable as a duration (e.g. "15s", "500ms") or returns a default value
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	value := getEnv(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return duration
+}
+
+// getEnvAsList retrieves a comma-separated environment variable as a list, skipping empty entries
+func getEnvAsList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, ""), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
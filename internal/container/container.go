@@ -0,0 +1,258 @@
+package container
+
+import (
+	"time"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/services"
+
+	"gorm.io/gorm"
+)
+
+// Container builds the application's dependency graph once: every repository and service exists a single time
+// and is shared by all routes and background jobs. Infrastructure (email, storage) can be replaced with options
+type Container struct {
+	Config *config.Config
+	DB     *gorm.DB
+	Repos  *Repositories
+
+	// Mailer sends transactional email: Brevo, or the log mailer in development without an API key
+	Mailer services.Mailer
+
+	// Storage is the configured file storage backend; nil when storage isn't configured
+	Storage services.Storage
+
+	Approval               *services.ApprovalService
+	Archive                *services.ArchiveService
+	Auth                   *services.AuthService
+	Billing                *services.BillingService
+	BookingChannel         *services.BookingChannelService
+	Cart                   *services.CartService
+	Category               *services.CategoryService
+	Changelog              *services.APIChangelogService
+	Closeout               *services.CloseoutService
+	Customer               *services.CustomerService
+	Dashboard              *services.DashboardService
+	Delivery               *services.DeliveryService
+	DeliveryZone           *services.DeliveryZoneService
+	Display                *services.DisplayService
+	EmailVerification      *services.EmailVerificationService
+	Driver                 *services.DriverService
+	Export                 *services.ExportService
+	FloorPlan              *services.FloorPlanService
+	Health                 *services.HealthService
+	Impersonation          *services.ImpersonationService
+	Integrity              *services.IntegrityService
+	Inventory              *services.InventoryService
+	Invitation             *services.InvitationService
+	KAM                    *services.KAMService
+	MenuClone              *services.MenuCloneService
+	MenuPairing            *services.MenuPairingService
+	MenuItem               *services.MenuItemService
+	MenuPrice              *services.MenuPriceService
+	MenuPublish            *services.MenuPublishService
+	MenuSearch             *services.MenuSearchService
+	Notification           *services.NotificationService
+	NotificationPreference *services.NotificationPreferenceService
+	Order                  *services.OrderService
+	OrderPartition         *services.OrderPartitionService
+	OrderSchedule          *services.OrderScheduleService
+	OrderSplit             *services.OrderSplitService
+	Organization           *services.OrganizationService
+	Permission             *services.PermissionService
+	Platform               *services.PlatformService
+	PlatformAnalytics      *services.PlatformAnalyticsService
+	PlatformSearch         *services.PlatformSearchService
+	PrepTime               *services.PrepTimeService
+	PricingRule            *services.PricingRuleService
+	Push                   *services.PushService
+	Print                  *services.PrintService
+	Privacy                *services.PrivacyService
+	PrivateEvent           *services.PrivateEventService
+	Profile                *services.ProfileService
+	PublicContent          *services.PublicContentService
+	Purchasing             *services.PurchasingService
+	Receipt                *services.ReceiptService
+	Reservation            *services.ReservationService
+	Restaurant             *services.RestaurantService
+	RestaurantDirectory    *services.RestaurantDirectoryService
+	RestaurantHealth       *services.RestaurantHealthService
+	Review                 *services.ReviewService
+	Settings               *services.RestaurantSettingsService
+	SMS                    *services.SMSService
+	Session                *services.SessionService
+	SSO                    *services.SSOService
+	Subscription           *services.SubscriptionService
+	TableSession           *services.TableSessionService
+	TimeEntry              *services.TimeEntryService
+	User                   *services.UserService
+	Waste                  *services.WasteService
+	Webhook                *services.WebhookService
+
+	// Scheduler runs the periodic background jobs, one instance per run
+	Scheduler *services.SchedulerService
+
+	// StorageBackup is only available on the S3 storage backend; nil otherwise
+	StorageBackup *services.StorageBackupService
+}
+
+// Option replaces a part of the container's infrastructure before the services are built
+type Option func(*Container)
+
+// WithMailer replaces the email implementation (e.g. a recording mailer in tests)
+func WithMailer(mailer services.Mailer) Option {
+	return func(c *Container) {
+		c.Mailer = mailer
+	}
+}
+
+// WithStorage replaces the file storage backend
+func WithStorage(storage services.Storage) Option {
+	return func(c *Container) {
+		c.Storage = storage
+	}
+}
+
+// New builds the container for the configuration and database connection
+func New(cfg *config.Config, db *gorm.DB, opts ...Option) *Container {
+	c := &Container{
+		Config: cfg,
+		DB:     db,
+		Repos:  NewRepositories(db),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.Mailer == nil {
+		c.Mailer = defaultMailer(cfg, c.Repos.Usage)
+	}
+	if c.Storage == nil {
+		// Storage is optional; features needing it are left out when it is not configured
+		if storage, err := services.NewStorage(cfg); err == nil {
+			c.Storage = storage
+		}
+	}
+
+	c.buildServices()
+	return c
+}
+
+// defaultMailer sends through Brevo, except in development without a Brevo API key where emails are only logged
+func defaultMailer(cfg *config.Config, usageRepo *repositories.UsageRepository) services.Mailer {
+	if cfg.BrevoAPIKey == "" && cfg.Environment != "production" {
+		return services.LogMailer{}
+	}
+	return services.NewEmailService(cfg, usageRepo)
+}
+
+// buildServices constructs the services in dependency order
+func (c *Container) buildServices() {
+	cfg, r := c.Config, c.Repos
+
+	c.Session = services.NewSessionService(r.UserSession, r.User)
+	c.Auth = services.NewAuthService(c.DB, cfg, r.User, r.JWTSigningKey, c.Session)
+	c.Changelog = services.NewAPIChangelogService(r.APIChangelog)
+	c.Impersonation = services.NewImpersonationService(c.Auth, r.User, r.Restaurant, r.AuditLog, cfg.ImpersonationTokenTTL)
+	c.Subscription = services.NewSubscriptionService(r.Subscription, r.MenuItem, r.User, r.Order)
+	c.Print = services.NewPrintService(r.Printer, r.PrintJob, r.Order, r.Restaurant)
+	c.Webhook = services.NewWebhookService(r.Webhook, r.Restaurant)
+	c.Settings = services.NewRestaurantSettingsService(r.Settings)
+	c.Notification = services.NewNotificationService(r.Notification)
+	c.NotificationPreference = services.NewNotificationPreferenceService(r.NotificationPreference, cfg.UnsubscribeTokenSecret, cfg.FrontendURL)
+	c.Push = services.NewPushService(r.PushSubscription, r.Restaurant, c.NotificationPreference, cfg.VAPIDPublicKey, cfg.VAPIDPrivateKey, cfg.VAPIDSubject)
+	c.SMS = services.NewSMSService(r.SMSMessage, r.Settings, r.Restaurant, c.NotificationPreference, services.NewSMSProvider(cfg), cfg.TwilioAuthToken, cfg.TwilioStatusCallbackURL)
+
+	c.Customer = services.NewCustomerService(r.Customer, r.User, r.Order, r.Reservation)
+	c.BookingChannel = services.NewBookingChannelService(r.BookingChannel, r.User, services.NewBookingChannelAdapters(cfg))
+	c.Reservation = services.NewReservationService(r.Reservation, r.Restaurant, r.Settings, r.OpeningHours, r.FloorPlan, r.PrivateEvent, c.Mailer, c.Customer, c.Notification, c.SMS, c.NotificationPreference, c.BookingChannel)
+	c.Receipt = services.NewReceiptService(r.Order, r.Restaurant, r.Settings, c.Mailer, c.NotificationPreference)
+	c.OrderSchedule = services.NewOrderScheduleService(r.Order, r.Settings, r.OpeningHours)
+	c.DeliveryZone = services.NewDeliveryZoneService(r.DeliveryZone)
+	c.PricingRule = services.NewPricingRuleService(r.PricingRule, r.Category, r.MenuItem, r.Settings, r.MenuItemPriceChange)
+	c.PrepTime = services.NewPrepTimeService(r.Order, r.MenuItem, r.KitchenLoad, r.Settings)
+	c.Cart = services.NewCartService(r.Cart, r.MenuItem, r.Restaurant, c.PricingRule, c.Mailer, c.NotificationPreference, cfg.FrontendURL, cfg.CartTTL)
+	c.Order = services.NewOrderService(r.Order, r.OrderItem, r.MenuItem, r.Restaurant, c.Customer, c.Print, c.Receipt, c.OrderSchedule, c.PrepTime, c.Cart, c.DeliveryZone, c.PricingRule, c.Notification, c.SMS, c.Push)
+	c.OrderSplit = services.NewOrderSplitService(r.Order, r.OrderItem, r.OrderSplit)
+	c.OrderPartition = services.NewOrderPartitionService(r.OrderPartition)
+	c.Archive = services.NewArchiveService(r.Archive, c.Settings)
+	c.Review = services.NewReviewService(r.Review, r.Order, r.Restaurant)
+	c.MenuClone = services.NewMenuCloneService(r.Restaurant, r.Category, c.Subscription)
+	c.MenuPairing = services.NewMenuPairingService(r.MenuItemPairing, r.MenuItem)
+	c.MenuPrice = services.NewMenuPriceService(r.MenuItemPriceChange, r.MenuItem, c.Webhook)
+	c.MenuItem = services.NewMenuItemService(r.MenuItem, c.Webhook, c.Notification, c.Settings)
+	c.Category = services.NewCategoryService(r.Category, r.MenuItem, c.Webhook, c.Settings)
+	c.Approval = services.NewApprovalService(r.PendingChange, r.MenuItem, r.Category, r.Order, c.Settings, c.Notification, c.MenuItem, c.Category, c.MenuPrice, c.Order)
+	c.MenuPublish = services.NewMenuPublishService(r.MenuPublication, r.MenuItem, c.Webhook)
+	c.MenuSearch = services.NewMenuSearchService(r.MenuItem, c.PricingRule)
+	c.Inventory = services.NewInventoryService(r.Ingredient, r.Supplier, r.MenuItem)
+	c.Purchasing = services.NewPurchasingService(r.Supplier, r.PurchaseOrder, r.Ingredient)
+	c.Waste = services.NewWasteService(r.Waste, r.Ingredient, r.MenuItem, c.Settings)
+
+	c.Restaurant = services.NewRestaurantService(r.Restaurant, r.User, c.Mailer)
+	c.Platform = services.NewPlatformService(r.Restaurant, r.User)
+	c.KAM = services.NewKAMService(r.Restaurant)
+	c.RestaurantDirectory = services.NewRestaurantDirectoryService(r.Restaurant, r.OpeningHours, r.Review, r.DeliveryZone, c.Settings)
+	c.PublicContent = services.NewPublicContentService(r.Restaurant, r.Category, r.MenuItem, r.OpeningHours, r.Review, c.Settings, cfg.FrontendURL, cfg.PublicAPIURL)
+	c.RestaurantHealth = services.NewRestaurantHealthService(r.RestaurantHealth, cfg.HealthQuietDays)
+	c.PlatformSearch = services.NewPlatformSearchService(r.Restaurant, r.User, r.Order, r.AuditLog)
+	c.PlatformAnalytics = services.NewPlatformAnalyticsService(r.PlatformReporting, r.User)
+	c.Organization = services.NewOrganizationService(r.Organization, r.Restaurant, r.User, r.Category, c.Subscription)
+	c.User = services.NewUserService(r.User, c.Subscription)
+	c.Permission = services.NewPermissionService(r.UserPermission, r.User, r.AuditLog)
+	c.EmailVerification = services.NewEmailVerificationService(r.EmailVerification, r.User, c.Auth, c.Mailer, cfg.EmailVerificationTTL)
+	c.Invitation = services.NewInvitationService(r.UserInvitation, r.User, r.Restaurant, c.Subscription, c.Auth, c.Mailer, cfg.FrontendURL, cfg.InvitationTTL)
+	c.SSO = services.NewSSOService(r.SSOConfig, r.User, r.Restaurant, c.Auth, c.Subscription, cfg.SSOCallbackURL, cfg.JWTSecret, cfg.SSOStateTTL)
+	c.Profile = services.NewProfileService(r.User)
+	c.Privacy = services.NewPrivacyService(r.Privacy, r.User, r.Customer, r.Order, r.Reservation, r.NotificationPreference, r.PushSubscription, r.AuditLog)
+
+	c.Export = services.NewExportService(r.Order, r.Reservation, r.Customer, r.Settings)
+	c.Dashboard = services.NewDashboardService(r.Order, r.Reservation, r.StorageBackup, r.TimeEntry, r.Settings, r.AuditLog, r.DailyStats, r.Customer, c.Inventory)
+	c.Closeout = services.NewCloseoutService(r.Closeout, r.Order, r.Settings)
+	c.TimeEntry = services.NewTimeEntryService(r.TimeEntry, r.User, r.Settings)
+	c.FloorPlan = services.NewFloorPlanService(r.FloorPlan, r.Reservation, r.TableSession)
+	c.PrivateEvent = services.NewPrivateEventService(r.PrivateEvent, r.MenuItem, r.Restaurant, r.Settings)
+	c.Display = services.NewDisplayService(r.Restaurant, r.Order, r.StorageBackup)
+	c.Driver = services.NewDriverService(r.Driver, r.DeliveryAssignment, r.Order, c.Order)
+	c.TableSession = services.NewTableSessionService(r.TableSession, r.FloorPlan, c.Order, c.Subscription)
+
+	c.Integrity = services.NewIntegrityService(r.Integrity, cfg.IntegrityAutoQuarantine)
+	c.Billing = services.NewBillingService(r.Usage, r.Invoice, r.Restaurant, r.Subscription, r.Order, c.Storage, services.NewBillingPrices(cfg))
+	c.Delivery = services.NewDeliveryService(r.DeliveryIntegration, r.MenuItem, r.Order, r.User, services.NewDeliveryAdapters(cfg), c.Notification, c.PrepTime, c.Subscription)
+	c.Health = services.NewHealthService(c.DB, c.Storage, cfg.ReadinessCheckTimeout)
+
+	c.Scheduler = services.NewSchedulerService(r.ScheduledJob)
+	c.Scheduler.Register(c.Restaurant.LaunchJob(time.Minute))
+	c.Scheduler.Register(c.Integrity.IntegrityJob(cfg.IntegrityCheckInterval))
+	c.Scheduler.Register(c.Billing.InvoiceJob(cfg.InvoiceGenerationInterval))
+	c.Scheduler.Register(c.Delivery.DeliverySyncJob(cfg.DeliverySyncInterval))
+	c.Scheduler.Register(c.Dashboard.RollupJob(cfg.AnalyticsRollupInterval))
+	c.Scheduler.Register(c.Dashboard.IncrementalRollupJob(cfg.AnalyticsIncrementalRollupInterval))
+	c.Scheduler.Register(c.RestaurantHealth.HealthJob(cfg.HealthScoreInterval))
+	c.Scheduler.Register(c.MenuPairing.PairingJob(cfg.MenuPairingInterval))
+	c.Scheduler.Register(c.MenuPrice.PriceChangeJob(time.Minute))
+	c.Scheduler.Register(c.Cart.CartJob(cfg.CartJobInterval, cfg.CartRecoveryDelay))
+	c.Scheduler.Register(c.Notification.CleanupJob(cfg.NotificationCleanupInterval, cfg.NotificationRetention))
+	c.Scheduler.Register(c.SMS.ReminderJob(cfg.SMSReminderInterval, cfg.SMSReminderLeadTime))
+	c.Scheduler.Register(c.Push.CleanupJob(cfg.PushSubscriptionCleanupInterval, cfg.PushSubscriptionMaxAge))
+	c.Scheduler.Register(c.Session.CleanupJob(cfg.SessionCleanupInterval, cfg.SessionRetention))
+	c.Scheduler.Register(c.OrderPartition.PartitionJob(cfg.OrderPartitionInterval))
+	c.Scheduler.Register(c.Archive.ArchiveJob(cfg.ArchiveJobInterval, cfg.ArchiveAfterDays))
+	c.Scheduler.Register(c.Webhook.DeliveryJob(cfg.WebhookDeliveryInterval))
+
+	// Backups and replication need the S3 API itself
+	if s3Service, ok := c.Storage.(*services.S3Service); ok && (cfg.StorageBackend == services.StorageBackendS3 || cfg.StorageBackend == "") {
+		c.StorageBackup = services.NewStorageBackupService(
+			r.StorageBackup,
+			r.Restaurant,
+			s3Service,
+			cfg.S3BackupRetentionDays,
+			&services.ReplicationTarget{
+				RoleARN:      cfg.S3ReplicationRoleARN,
+				BucketARN:    cfg.S3ReplicationBucketARN,
+				StorageClass: cfg.S3ReplicationStorageClass,
+			},
+		)
+	}
+}
@@ -0,0 +1,141 @@
+package container
+
+import (
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// Repositories holds the single instance of every repository
+type Repositories struct {
+	APIChangelog           *repositories.APIChangelogRepository
+	Archive                *repositories.ArchiveRepository
+	AuditLog               *repositories.AuditLogRepository
+	BookingChannel         *repositories.BookingChannelRepository
+	Cart                   *repositories.CartRepository
+	Category               *repositories.CategoryRepository
+	Closeout               *repositories.CloseoutRepository
+	Customer               *repositories.CustomerRepository
+	DailyStats             *repositories.DailyStatsRepository
+	DeliveryAssignment     *repositories.DeliveryAssignmentRepository
+	DeliveryIntegration    *repositories.DeliveryIntegrationRepository
+	DeliveryZone           *repositories.DeliveryZoneRepository
+	Driver                 *repositories.DriverRepository
+	FloorPlan              *repositories.FloorPlanRepository
+	Ingredient             *repositories.IngredientRepository
+	Integrity              *repositories.IntegrityRepository
+	Invoice                *repositories.InvoiceRepository
+	JWTSigningKey          *repositories.JWTSigningKeyRepository
+	KitchenLoad            *repositories.KitchenLoadRepository
+	MenuItem               *repositories.MenuItemRepository
+	MenuItemImage          *repositories.MenuItemImageRepository
+	MenuItemPairing        *repositories.MenuItemPairingRepository
+	MenuItemPriceChange    *repositories.MenuItemPriceChangeRepository
+	MenuPublication        *repositories.MenuPublicationRepository
+	Notification           *repositories.NotificationRepository
+	NotificationPreference *repositories.NotificationPreferenceRepository
+	OpeningHours           *repositories.OpeningHoursRepository
+	Order                  *repositories.OrderRepository
+	OrderItem              *repositories.OrderItemRepository
+	OrderPartition         *repositories.OrderPartitionRepository
+	OrderSplit             *repositories.OrderSplitRepository
+	Organization           *repositories.OrganizationRepository
+	PendingChange          *repositories.PendingChangeRepository
+	PlatformReporting      *repositories.PlatformReportingRepository
+	PricingRule            *repositories.PricingRuleRepository
+	Privacy                *repositories.PrivacyRepository
+	PrivateEvent           *repositories.PrivateEventRepository
+	PrintJob               *repositories.PrintJobRepository
+	Printer                *repositories.PrinterRepository
+	PurchaseOrder          *repositories.PurchaseOrderRepository
+	PushSubscription       *repositories.PushSubscriptionRepository
+	Reservation            *repositories.ReservationRepository
+	Restaurant             *repositories.RestaurantRepository
+	RestaurantHealth       *repositories.RestaurantHealthRepository
+	Settings               *repositories.RestaurantSettingsRepository
+	Review                 *repositories.ReviewRepository
+	ScheduledJob           *repositories.ScheduledJobRepository
+	SMSMessage             *repositories.SMSMessageRepository
+	SSOConfig              *repositories.SSOConfigRepository
+	StorageBackup          *repositories.StorageBackupRepository
+	Subscription           *repositories.SubscriptionRepository
+	Supplier               *repositories.SupplierRepository
+	TableSession           *repositories.TableSessionRepository
+	TimeEntry              *repositories.TimeEntryRepository
+	Usage                  *repositories.UsageRepository
+	User                   *repositories.UserRepository
+	UserSession            *repositories.UserSessionRepository
+	UserPermission         *repositories.UserPermissionRepository
+	UserInvitation         *repositories.UserInvitationRepository
+	EmailVerification      *repositories.EmailVerificationRepository
+	Waste                  *repositories.WasteRepository
+	Webhook                *repositories.WebhookRepository
+}
+
+// NewRepositories creates every repository on the database connection
+func NewRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		APIChangelog:           repositories.NewAPIChangelogRepository(db),
+		Archive:                repositories.NewArchiveRepository(db),
+		AuditLog:               repositories.NewAuditLogRepository(db),
+		BookingChannel:         repositories.NewBookingChannelRepository(db),
+		Cart:                   repositories.NewCartRepository(db),
+		Category:               repositories.NewCategoryRepository(db),
+		Closeout:               repositories.NewCloseoutRepository(db),
+		Customer:               repositories.NewCustomerRepository(db),
+		DailyStats:             repositories.NewDailyStatsRepository(db),
+		DeliveryAssignment:     repositories.NewDeliveryAssignmentRepository(db),
+		DeliveryIntegration:    repositories.NewDeliveryIntegrationRepository(db),
+		DeliveryZone:           repositories.NewDeliveryZoneRepository(db),
+		Driver:                 repositories.NewDriverRepository(db),
+		FloorPlan:              repositories.NewFloorPlanRepository(db),
+		Ingredient:             repositories.NewIngredientRepository(db),
+		Integrity:              repositories.NewIntegrityRepository(db),
+		Invoice:                repositories.NewInvoiceRepository(db),
+		JWTSigningKey:          repositories.NewJWTSigningKeyRepository(db),
+		KitchenLoad:            repositories.NewKitchenLoadRepository(db),
+		MenuItem:               repositories.NewMenuItemRepository(db),
+		MenuItemImage:          repositories.NewMenuItemImageRepository(db),
+		MenuItemPairing:        repositories.NewMenuItemPairingRepository(db),
+		MenuItemPriceChange:    repositories.NewMenuItemPriceChangeRepository(db),
+		MenuPublication:        repositories.NewMenuPublicationRepository(db),
+		Notification:           repositories.NewNotificationRepository(db),
+		NotificationPreference: repositories.NewNotificationPreferenceRepository(db),
+		OpeningHours:           repositories.NewOpeningHoursRepository(db),
+		Order:                  repositories.NewOrderRepository(db),
+		OrderItem:              repositories.NewOrderItemRepository(db),
+		OrderPartition:         repositories.NewOrderPartitionRepository(db),
+		OrderSplit:             repositories.NewOrderSplitRepository(db),
+		Organization:           repositories.NewOrganizationRepository(db),
+		PendingChange:          repositories.NewPendingChangeRepository(db),
+		PlatformReporting:      repositories.NewPlatformReportingRepository(db),
+		PricingRule:            repositories.NewPricingRuleRepository(db),
+		Privacy:                repositories.NewPrivacyRepository(db),
+		PrivateEvent:           repositories.NewPrivateEventRepository(db),
+		PrintJob:               repositories.NewPrintJobRepository(db),
+		Printer:                repositories.NewPrinterRepository(db),
+		PurchaseOrder:          repositories.NewPurchaseOrderRepository(db),
+		PushSubscription:       repositories.NewPushSubscriptionRepository(db),
+		Reservation:            repositories.NewReservationRepository(db),
+		Restaurant:             repositories.NewRestaurantRepository(db),
+		RestaurantHealth:       repositories.NewRestaurantHealthRepository(db),
+		Settings:               repositories.NewRestaurantSettingsRepository(db),
+		Review:                 repositories.NewReviewRepository(db),
+		ScheduledJob:           repositories.NewScheduledJobRepository(db),
+		SMSMessage:             repositories.NewSMSMessageRepository(db),
+		SSOConfig:              repositories.NewSSOConfigRepository(db),
+		StorageBackup:          repositories.NewStorageBackupRepository(db),
+		Subscription:           repositories.NewSubscriptionRepository(db),
+		Supplier:               repositories.NewSupplierRepository(db),
+		TableSession:           repositories.NewTableSessionRepository(db),
+		TimeEntry:              repositories.NewTimeEntryRepository(db),
+		Usage:                  repositories.NewUsageRepository(db),
+		User:                   repositories.NewUserRepository(db),
+		UserSession:            repositories.NewUserSessionRepository(db),
+		UserPermission:         repositories.NewUserPermissionRepository(db),
+		UserInvitation:         repositories.NewUserInvitationRepository(db),
+		EmailVerification:      repositories.NewEmailVerificationRepository(db),
+		Waste:                  repositories.NewWasteRepository(db),
+		Webhook:                repositories.NewWebhookRepository(db),
+	}
+}
@@ -2,58 +2,157 @@ package ctx
 
 import (
 	"context"
-
-	"restaurant-backend/internal/middleware"
 )
 
-// GetUserID returns the user ID from context if present
-func GetUserID(ctx context.Context) (uint, bool) {
+// tenantKey is the context key for the request Tenant
+// Unexported so only WithTenant can set it (string keys could collide)
+type tenantKey struct{}
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// routeKey is the context key for the matched route template
+type routeKey struct{}
+
+// platformReportingKey is the context key marking queries of platform-wide reports
+type platformReportingKey struct{}
+
+// readReplicaKey is the context key marking queries that may read from a replica
+type readReplicaKey struct{}
+
+// Tenant is the authenticated identity of a request
+// Set once by the auth middleware and read by handlers, services and the
+// database layer, which applies it to every query for RLS
+type Tenant struct {
+	UserID         uint
+	RestaurantID   uint
+	Role           string
+	Email          string
+	OrganizationID uint   // Only set for org-scoped (multi-location) users
+	ImpersonatorID uint   // KAM acting as the restaurant's admin; only set for impersonation tokens
+	SessionID      string // Login session the token belongs to; empty for tokens issued before sessions were tracked
+}
+
+// WithTenant returns a copy of parent carrying the tenant
+func WithTenant(parent context.Context, tenant Tenant) context.Context {
+	return context.WithValue(parent, tenantKey{}, tenant)
+}
+
+// GetTenant returns the tenant from context if present
+func GetTenant(ctx context.Context) (Tenant, bool) {
 	if ctx == nil {
-		return 0, false
-	}
-	v := ctx.Value(middleware.UserIDKey)
-	if v == nil {
-		return 0, false
+		return Tenant{}, false
 	}
-	uid, ok := v.(uint)
-	return uid, ok
+	tenant, ok := ctx.Value(tenantKey{}).(Tenant)
+	return tenant, ok
+}
+
+// GetUserID returns the user ID from context if present
+func GetUserID(ctx context.Context) (uint, bool) {
+	tenant, ok := GetTenant(ctx)
+	return tenant.UserID, ok
 }
 
 // GetRestaurantID returns the restaurant ID from context if present
 func GetRestaurantID(ctx context.Context) (uint, bool) {
-	if ctx == nil {
+	tenant, ok := GetTenant(ctx)
+	return tenant.RestaurantID, ok
+}
+
+// GetUserRole returns the user role from context if present
+func GetUserRole(ctx context.Context) (string, bool) {
+	tenant, ok := GetTenant(ctx)
+	return tenant.Role, ok
+}
+
+// GetUserEmail returns the user email from context if present
+func GetUserEmail(ctx context.Context) (string, bool) {
+	tenant, ok := GetTenant(ctx)
+	return tenant.Email, ok
+}
+
+// GetOrganizationID returns the organization ID from context if present (org-scoped users only)
+func GetOrganizationID(ctx context.Context) (uint, bool) {
+	tenant, ok := GetTenant(ctx)
+	if !ok || tenant.OrganizationID == 0 {
 		return 0, false
 	}
-	v := ctx.Value(middleware.RestaurantIDKey)
-	if v == nil {
+	return tenant.OrganizationID, true
+}
+
+// GetImpersonatorID returns the ID of the KAM impersonating the restaurant, if the request is impersonated
+func GetImpersonatorID(ctx context.Context) (uint, bool) {
+	tenant, ok := GetTenant(ctx)
+	if !ok || tenant.ImpersonatorID == 0 {
 		return 0, false
 	}
-	rid, ok := v.(uint)
-	return rid, ok
+	return tenant.ImpersonatorID, true
 }
 
-// GetUserRole returns the user role from context if present
-func GetUserRole(ctx context.Context) (string, bool) {
-	if ctx == nil {
+// GetSessionID returns the login session of the request's token, if it has one
+func GetSessionID(ctx context.Context) (string, bool) {
+	tenant, ok := GetTenant(ctx)
+	if !ok || tenant.SessionID == "" {
 		return "", false
 	}
-	v := ctx.Value(middleware.UserRoleKey)
-	if v == nil {
+	return tenant.SessionID, true
+}
+
+// WithRequestID returns a copy of parent carrying the request ID
+func WithRequestID(parent context.Context, requestID string) context.Context {
+	return context.WithValue(parent, requestIDKey{}, requestID)
+}
+
+// GetRequestID returns the request ID from context if present
+func GetRequestID(ctx context.Context) (string, bool) {
+	if ctx == nil {
 		return "", false
 	}
-	role, ok := v.(string)
-	return role, ok
+	requestID, ok := ctx.Value(requestIDKey{}).(string)
+	return requestID, ok && requestID != ""
 }
 
-// GetUserEmail returns the user email from context if present
-func GetUserEmail(ctx context.Context) (string, bool) {
+// WithRoute returns a copy of parent carrying the matched route template (e.g. "/api/v1/orders/:id")
+func WithRoute(parent context.Context, route string) context.Context {
+	return context.WithValue(parent, routeKey{}, route)
+}
+
+// GetRoute returns the route template from context if present
+func GetRoute(ctx context.Context) (string, bool) {
 	if ctx == nil {
 		return "", false
 	}
-	v := ctx.Value(middleware.UserEmailKey)
-	if v == nil {
-		return "", false
+	route, ok := ctx.Value(routeKey{}).(string)
+	return route, ok && route != ""
+}
+
+// WithPlatformReporting returns a copy of parent whose queries run as the read-only platform reporting
+// role, which reads every tenant's rows; the request tenant is not applied to them
+// Only platform-wide analytics may use it, after checking the caller is platform staff
+func WithPlatformReporting(parent context.Context) context.Context {
+	return context.WithValue(parent, platformReportingKey{}, true)
+}
+
+// IsPlatformReporting reports whether queries with the context run as the platform reporting role
+func IsPlatformReporting(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	reporting, _ := ctx.Value(platformReportingKey{}).(bool)
+	return reporting
+}
+
+// WithReadReplica marks queries with the returned context as tolerating replication lag, so the database layer
+// may route their reads to a read replica
+func WithReadReplica(parent context.Context) context.Context {
+	return context.WithValue(parent, readReplicaKey{}, true)
+}
+
+// UsesReadReplica reports whether reads with the context may be served by a read replica
+func UsesReadReplica(ctx context.Context) bool {
+	if ctx == nil {
+		return false
 	}
-	email, ok := v.(string)
-	return email, ok
+	replica, _ := ctx.Value(readReplicaKey{}).(bool)
+	return replica
 }
@@ -8,6 +8,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
 // NewConnection creates a new database connection using GORM
@@ -39,5 +40,37 @@ func NewConnection(cfg *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database handle: %w", err)
+	}
+
+	// Pool settings bound how many Postgres connections each instance holds,
+	// and recycling keeps connections from outliving failovers and proxy timeouts
+	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+
+	// Apply the request tenant to every query for RLS
+	if err := registerTenantCallbacks(db); err != nil {
+		return nil, err
+	}
+
+	// Serve lag-tolerant reads from the read replicas, if configured
+	if err := registerReplicas(db, cfg); err != nil {
+		return nil, err
+	}
+
+	// Count and time every query for Prometheus
+	if err := registerMetricsCallbacks(db); err != nil {
+		return nil, err
+	}
+
+	// Record a span per query under the request's trace (pool metrics are exported by the metrics package)
+	if err := db.Use(tracing.NewPlugin(tracing.WithoutMetrics(), tracing.WithDBName(cfg.DBName))); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
 	return db, nil
 }
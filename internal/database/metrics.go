@@ -0,0 +1,65 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/metrics"
+
+	"gorm.io/gorm"
+)
+
+// queryStartKey stores when the statement's callbacks started
+const queryStartKey = "metrics:query_start"
+
+// rawQueryTable labels statements without a model (Raw, Exec)
+const rawQueryTable = "raw"
+
+// registerMetricsCallbacks feeds db_queries_total and db_query_duration_seconds from every gorm operation
+// Writes are timed including their transaction, queries including their preloads
+func registerMetricsCallbacks(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := errors.Join(
+		cb.Create().Before("gorm:begin_transaction").Register("metrics:start", startQueryTimer),
+		cb.Create().After("gorm:commit_or_rollback_transaction").Register("metrics:record", recordQuery("create")),
+		cb.Update().Before("gorm:begin_transaction").Register("metrics:start", startQueryTimer),
+		cb.Update().After("gorm:commit_or_rollback_transaction").Register("metrics:record", recordQuery("update")),
+		cb.Delete().Before("gorm:begin_transaction").Register("metrics:start", startQueryTimer),
+		cb.Delete().After("gorm:commit_or_rollback_transaction").Register("metrics:record", recordQuery("delete")),
+		cb.Query().Before("gorm:query").Register("metrics:start", startQueryTimer),
+		cb.Query().After("gorm:after_query").Register("metrics:record", recordQuery("query")),
+		cb.Row().Before("gorm:row").Register("metrics:start", startQueryTimer),
+		cb.Row().After("gorm:row").Register("metrics:record", recordQuery("row")),
+		cb.Raw().Before("gorm:raw").Register("metrics:start", startQueryTimer),
+		cb.Raw().After("gorm:raw").Register("metrics:record", recordQuery("raw")),
+	); err != nil {
+		return fmt.Errorf("failed to register metrics callbacks: %w", err)
+	}
+	return nil
+}
+
+// startQueryTimer records the statement's start time
+func startQueryTimer(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+// recordQuery returns the callback that observes the statement's count and duration
+func recordQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.DryRun {
+			return
+		}
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, _ := value.(time.Time)
+
+		table := db.Statement.Table
+		if table == "" {
+			table = rawQueryTable
+		}
+		metrics.RecordDBQuery(operation, table, time.Since(start).Seconds())
+	}
+}
@@ -22,6 +22,82 @@ func RunMigrations(db *gorm.DB, cfg *config.Config) error {
 		migrations.NewEnableRLS(),
 		migrations.NewCreateRLSPolicies(),
 		migrations.NewAddUserFields(),
+		migrations.NewAddMenuItemNutrition(),
+		migrations.NewCreateWebhookEndpoints(),
+		migrations.NewAddRestaurantDisplayToken(),
+		migrations.NewCreateAuditLogs(),
+		migrations.NewCreateRestaurantSettings(),
+		migrations.NewCreateOrganizations(),
+		migrations.NewAddRestaurantVisibility(),
+		migrations.NewAddReservationOverlapConstraint(),
+		migrations.NewAddAuditLogRestaurant(),
+		migrations.NewCreateAPIChangelog(),
+		migrations.NewCreateIntegrityFindings(),
+		migrations.NewAddVersionColumns(),
+		migrations.NewCreateStorageBackupPolicies(),
+		migrations.NewAddOrderItemName(),
+		migrations.NewAddReservationTimeCheck(),
+		migrations.NewCreateOrderStatusChanges(),
+		migrations.NewCreateCustomers(),
+		migrations.NewCreateReviews(),
+		migrations.NewGrantAppRoleSequences(),
+		migrations.NewCreateSubscriptions(),
+		migrations.NewCreateBilling(),
+		migrations.NewCreateDeliveryIntegrations(),
+		migrations.NewCreatePrinters(),
+		migrations.NewAddSettingsTaxRate(),
+		migrations.NewCreateDailyCloseouts(),
+		migrations.NewCreateOrderSplits(),
+		migrations.NewAddOrderScheduling(),
+		migrations.NewCreateDeliveryZones(),
+		migrations.NewCreateDrivers(),
+		migrations.NewCreateTimeEntries(),
+		migrations.NewCreateFloorPlan(),
+		migrations.NewCreateTableSessions(),
+		migrations.NewAddOrderCourses(),
+		migrations.NewCreatePricingRules(),
+		migrations.NewAddChannelPrices(),
+		migrations.NewAddMenuSearch(),
+		migrations.NewAddCategoryArchiving(),
+		migrations.NewCreateScheduledJobs(),
+		migrations.NewCreateDailyRestaurantStats(),
+		migrations.NewCreatePlatformReportingRole(),
+		migrations.NewCreateRestaurantHealth(),
+		migrations.NewCreateNotifications(),
+		migrations.NewCreateSMSMessages(),
+		migrations.NewCreatePushSubscriptions(),
+		migrations.NewCreateNotificationPreferences(),
+		migrations.NewEncryptCustomerContacts(),
+		migrations.NewCreateJWTSigningKeys(),
+		migrations.NewCreateSSOConfigs(),
+		migrations.NewCreateUserSessions(),
+		migrations.NewCreateUserPermissions(),
+		migrations.NewCreateUserInvitations(),
+		migrations.NewCreateEmailVerifications(),
+		migrations.NewCreatePrivateEvents(),
+		migrations.NewAddReservationPacing(),
+		migrations.NewAddPrepTimeEstimates(),
+		migrations.NewAddKitchenCapacity(),
+		migrations.NewCreateMenuItemPairings(),
+		migrations.NewCreateCarts(),
+		migrations.NewAddRestaurantProfiles(),
+		migrations.NewCreateBookingChannels(),
+		migrations.NewCreateInventory(),
+		migrations.NewAddMarginThreshold(),
+		migrations.NewCreateWasteEntries(),
+		migrations.NewUseNumericMoney(),
+		migrations.NewCreateMenuItemPriceChanges(),
+		migrations.NewAddMenuPublishing(),
+		migrations.NewCreatePendingChanges(),
+		migrations.NewAddOrderIndexes(),
+		migrations.NewCreateArchives(),
+		migrations.NewCreateWebhookDeliveries(),
+		migrations.NewEncryptGuestContacts(),
+		migrations.NewEncryptPrivateEventContacts(),
+		migrations.NewUseNumericPricingRuleValue(),
+		migrations.NewUseDecimalCentsColumns(),
+		migrations.NewEncryptDeliveryAccessTokens(),
+		migrations.NewHashBookingChannelTokens(),
 		// Bootstrap is separate - use BootstrapPlatform() instead
 	}
 
@@ -48,6 +124,83 @@ func RunMigrationsDown(db *gorm.DB, cfg *config.Config) error {
 		migrations.NewSyncSequences(),
 		migrations.NewEnableRLS(),
 		migrations.NewCreateRLSPolicies(),
+		migrations.NewAddUserFields(),
+		migrations.NewAddMenuItemNutrition(),
+		migrations.NewCreateWebhookEndpoints(),
+		migrations.NewAddRestaurantDisplayToken(),
+		migrations.NewCreateAuditLogs(),
+		migrations.NewCreateRestaurantSettings(),
+		migrations.NewCreateOrganizations(),
+		migrations.NewAddRestaurantVisibility(),
+		migrations.NewAddReservationOverlapConstraint(),
+		migrations.NewAddAuditLogRestaurant(),
+		migrations.NewCreateAPIChangelog(),
+		migrations.NewCreateIntegrityFindings(),
+		migrations.NewAddVersionColumns(),
+		migrations.NewCreateStorageBackupPolicies(),
+		migrations.NewAddOrderItemName(),
+		migrations.NewAddReservationTimeCheck(),
+		migrations.NewCreateOrderStatusChanges(),
+		migrations.NewCreateCustomers(),
+		migrations.NewCreateReviews(),
+		migrations.NewGrantAppRoleSequences(),
+		migrations.NewCreateSubscriptions(),
+		migrations.NewCreateBilling(),
+		migrations.NewCreateDeliveryIntegrations(),
+		migrations.NewCreatePrinters(),
+		migrations.NewAddSettingsTaxRate(),
+		migrations.NewCreateDailyCloseouts(),
+		migrations.NewCreateOrderSplits(),
+		migrations.NewAddOrderScheduling(),
+		migrations.NewCreateDeliveryZones(),
+		migrations.NewCreateDrivers(),
+		migrations.NewCreateTimeEntries(),
+		migrations.NewCreateFloorPlan(),
+		migrations.NewCreateTableSessions(),
+		migrations.NewAddOrderCourses(),
+		migrations.NewCreatePricingRules(),
+		migrations.NewAddChannelPrices(),
+		migrations.NewAddMenuSearch(),
+		migrations.NewAddCategoryArchiving(),
+		migrations.NewCreateScheduledJobs(),
+		migrations.NewCreateDailyRestaurantStats(),
+		migrations.NewCreatePlatformReportingRole(),
+		migrations.NewCreateRestaurantHealth(),
+		migrations.NewCreateNotifications(),
+		migrations.NewCreateSMSMessages(),
+		migrations.NewCreatePushSubscriptions(),
+		migrations.NewCreateNotificationPreferences(),
+		migrations.NewEncryptCustomerContacts(),
+		migrations.NewCreateJWTSigningKeys(),
+		migrations.NewCreateSSOConfigs(),
+		migrations.NewCreateUserSessions(),
+		migrations.NewCreateUserPermissions(),
+		migrations.NewCreateUserInvitations(),
+		migrations.NewCreateEmailVerifications(),
+		migrations.NewCreatePrivateEvents(),
+		migrations.NewAddReservationPacing(),
+		migrations.NewAddPrepTimeEstimates(),
+		migrations.NewAddKitchenCapacity(),
+		migrations.NewCreateMenuItemPairings(),
+		migrations.NewCreateCarts(),
+		migrations.NewAddRestaurantProfiles(),
+		migrations.NewCreateBookingChannels(),
+		migrations.NewCreateInventory(),
+		migrations.NewAddMarginThreshold(),
+		migrations.NewCreateWasteEntries(),
+		migrations.NewUseNumericMoney(),
+		migrations.NewCreateMenuItemPriceChanges(),
+		migrations.NewAddMenuPublishing(),
+		migrations.NewCreatePendingChanges(),
+		migrations.NewAddOrderIndexes(),
+		migrations.NewCreateArchives(),
+		migrations.NewCreateWebhookDeliveries(),
+		migrations.NewEncryptGuestContacts(),
+		migrations.NewEncryptPrivateEventContacts(),
+		migrations.NewUseNumericPricingRuleValue(),
+		migrations.NewUseDecimalCentsColumns(),
+		migrations.NewEncryptDeliveryAccessTokens(),
+		migrations.NewHashBookingChannelTokens(),
 	}
 
 	runner := migrations.NewRunner(db, migrationList)
@@ -72,6 +225,82 @@ func ShowMigrationStatus(db *gorm.DB, cfg *config.Config) error {
 		migrations.NewEnableRLS(),
 		migrations.NewCreateRLSPolicies(),
 		migrations.NewAddUserFields(),
+		migrations.NewAddMenuItemNutrition(),
+		migrations.NewCreateWebhookEndpoints(),
+		migrations.NewAddRestaurantDisplayToken(),
+		migrations.NewCreateAuditLogs(),
+		migrations.NewCreateRestaurantSettings(),
+		migrations.NewCreateOrganizations(),
+		migrations.NewAddRestaurantVisibility(),
+		migrations.NewAddReservationOverlapConstraint(),
+		migrations.NewAddAuditLogRestaurant(),
+		migrations.NewCreateAPIChangelog(),
+		migrations.NewCreateIntegrityFindings(),
+		migrations.NewAddVersionColumns(),
+		migrations.NewCreateStorageBackupPolicies(),
+		migrations.NewAddOrderItemName(),
+		migrations.NewAddReservationTimeCheck(),
+		migrations.NewCreateOrderStatusChanges(),
+		migrations.NewCreateCustomers(),
+		migrations.NewCreateReviews(),
+		migrations.NewGrantAppRoleSequences(),
+		migrations.NewCreateSubscriptions(),
+		migrations.NewCreateBilling(),
+		migrations.NewCreateDeliveryIntegrations(),
+		migrations.NewCreatePrinters(),
+		migrations.NewAddSettingsTaxRate(),
+		migrations.NewCreateDailyCloseouts(),
+		migrations.NewCreateOrderSplits(),
+		migrations.NewAddOrderScheduling(),
+		migrations.NewCreateDeliveryZones(),
+		migrations.NewCreateDrivers(),
+		migrations.NewCreateTimeEntries(),
+		migrations.NewCreateFloorPlan(),
+		migrations.NewCreateTableSessions(),
+		migrations.NewAddOrderCourses(),
+		migrations.NewCreatePricingRules(),
+		migrations.NewAddChannelPrices(),
+		migrations.NewAddMenuSearch(),
+		migrations.NewAddCategoryArchiving(),
+		migrations.NewCreateScheduledJobs(),
+		migrations.NewCreateDailyRestaurantStats(),
+		migrations.NewCreatePlatformReportingRole(),
+		migrations.NewCreateRestaurantHealth(),
+		migrations.NewCreateNotifications(),
+		migrations.NewCreateSMSMessages(),
+		migrations.NewCreatePushSubscriptions(),
+		migrations.NewCreateNotificationPreferences(),
+		migrations.NewEncryptCustomerContacts(),
+		migrations.NewCreateJWTSigningKeys(),
+		migrations.NewCreateSSOConfigs(),
+		migrations.NewCreateUserSessions(),
+		migrations.NewCreateUserPermissions(),
+		migrations.NewCreateUserInvitations(),
+		migrations.NewCreateEmailVerifications(),
+		migrations.NewCreatePrivateEvents(),
+		migrations.NewAddReservationPacing(),
+		migrations.NewAddPrepTimeEstimates(),
+		migrations.NewAddKitchenCapacity(),
+		migrations.NewCreateMenuItemPairings(),
+		migrations.NewCreateCarts(),
+		migrations.NewAddRestaurantProfiles(),
+		migrations.NewCreateBookingChannels(),
+		migrations.NewCreateInventory(),
+		migrations.NewAddMarginThreshold(),
+		migrations.NewCreateWasteEntries(),
+		migrations.NewUseNumericMoney(),
+		migrations.NewCreateMenuItemPriceChanges(),
+		migrations.NewAddMenuPublishing(),
+		migrations.NewCreatePendingChanges(),
+		migrations.NewAddOrderIndexes(),
+		migrations.NewCreateArchives(),
+		migrations.NewCreateWebhookDeliveries(),
+		migrations.NewEncryptGuestContacts(),
+		migrations.NewEncryptPrivateEventContacts(),
+		migrations.NewUseNumericPricingRuleValue(),
+		migrations.NewUseDecimalCentsColumns(),
+		migrations.NewEncryptDeliveryAccessTokens(),
+		migrations.NewHashBookingChannelTokens(),
 	}
 
 	runner := migrations.NewRunner(db, migrationList)
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddMenuItemNutrition migration
+type AddMenuItemNutrition struct {
+	BaseMigration
+}
+
+// NewAddMenuItemNutrition creates a new migration
+func NewAddMenuItemNutrition() *AddMenuItemNutrition {
+	return &AddMenuItemNutrition{
+		BaseMigration: BaseMigration{
+			version: 10,
+			name:    "add_menu_item_nutrition",
+		},
+	}
+}
+
+// Up adds optional nutrition columns to menu_items table
+func (m *AddMenuItemNutrition) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE menu_items
+		ADD COLUMN IF NOT EXISTS calories INTEGER,
+		ADD COLUMN IF NOT EXISTS protein_grams NUMERIC(8,2),
+		ADD COLUMN IF NOT EXISTS carbs_grams NUMERIC(8,2),
+		ADD COLUMN IF NOT EXISTS fat_grams NUMERIC(8,2)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add nutrition columns: %w", err)
+	}
+
+	return nil
+}
+
+// Down removes the nutrition columns
+func (m *AddMenuItemNutrition) Down(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE menu_items
+		DROP COLUMN IF EXISTS calories,
+		DROP COLUMN IF EXISTS protein_grams,
+		DROP COLUMN IF EXISTS carbs_grams,
+		DROP COLUMN IF EXISTS fat_grams
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop nutrition columns: %w", err)
+	}
+
+	return nil
+}
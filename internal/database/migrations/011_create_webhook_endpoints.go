@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateWebhookEndpoints migration creates the webhook_endpoints table and menu versioning
+type CreateWebhookEndpoints struct {
+	BaseMigration
+}
+
+// NewCreateWebhookEndpoints creates a new migration
+func NewCreateWebhookEndpoints() *CreateWebhookEndpoints {
+	return &CreateWebhookEndpoints{
+		BaseMigration: BaseMigration{
+			version: 11,
+			name:    "create_webhook_endpoints",
+		},
+	}
+}
+
+// Up creates the webhook_endpoints table with RLS and adds menu_version to restaurants
+func (m *CreateWebhookEndpoints) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS menu_version INTEGER NOT NULL DEFAULT 0
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add menu_version column: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.WebhookEndpoint{}); err != nil {
+		return fmt.Errorf("failed to migrate WebhookEndpoint: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE webhook_endpoints ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on webhook_endpoints: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_webhook_endpoints ON webhook_endpoints")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_webhook_endpoints ON webhook_endpoints FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for webhook_endpoints: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the webhook_endpoints table and menu_version column
+func (m *CreateWebhookEndpoints) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS webhook_endpoints CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop webhook_endpoints table: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE restaurants DROP COLUMN IF EXISTS menu_version`).Error; err != nil {
+		return fmt.Errorf("failed to drop menu_version column: %w", err)
+	}
+
+	return nil
+}
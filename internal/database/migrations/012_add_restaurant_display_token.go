@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddRestaurantDisplayToken migration
+type AddRestaurantDisplayToken struct {
+	BaseMigration
+}
+
+// NewAddRestaurantDisplayToken creates a new migration
+func NewAddRestaurantDisplayToken() *AddRestaurantDisplayToken {
+	return &AddRestaurantDisplayToken{
+		BaseMigration: BaseMigration{
+			version: 12,
+			name:    "add_restaurant_display_token",
+		},
+	}
+}
+
+// Up adds the display_token column used by the order status board
+func (m *AddRestaurantDisplayToken) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE restaurants ADD COLUMN IF NOT EXISTS display_token TEXT
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add display_token column: %w", err)
+	}
+
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_restaurants_display_token ON restaurants(display_token)`).Error; err != nil {
+		return fmt.Errorf("failed to create display_token index: %w", err)
+	}
+
+	return nil
+}
+
+// Down removes the display_token column
+func (m *AddRestaurantDisplayToken) Down(db *gorm.DB) error {
+	db.Exec(`DROP INDEX IF EXISTS idx_restaurants_display_token`)
+
+	if err := db.Exec(`ALTER TABLE restaurants DROP COLUMN IF EXISTS display_token`).Error; err != nil {
+		return fmt.Errorf("failed to drop display_token column: %w", err)
+	}
+
+	return nil
+}
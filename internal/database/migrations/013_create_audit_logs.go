@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateAuditLogs migration creates the audit_logs table and platform search policies
+type CreateAuditLogs struct {
+	BaseMigration
+}
+
+// NewCreateAuditLogs creates a new migration
+func NewCreateAuditLogs() *CreateAuditLogs {
+	return &CreateAuditLogs{
+		BaseMigration: BaseMigration{
+			version: 13,
+			name:    "create_audit_logs",
+		},
+	}
+}
+
+// platformSearchTables are the tenant tables KAMs may read across tenants for support search
+var platformSearchTables = []string{"users", "orders"}
+
+// Up creates the audit_logs table and read-only cross-tenant policies for platform KAMs
+func (m *CreateAuditLogs) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.AuditLog{}); err != nil {
+		return fmt.Errorf("failed to migrate AuditLog: %w", err)
+	}
+
+	// Permissive SELECT-only policy: combined (OR) with the isolate_* policies, so
+	// platform KAMs can read (never write) other tenants' rows
+	condition := "current_setting('app.current_restaurant', true)::INTEGER = 1 AND current_setting('app.current_user_role', true) = 'KAM'"
+	for _, table := range platformSearchTables {
+		policyName := fmt.Sprintf("platform_search_%s", table)
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", policyName, table))
+
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY %s ON %s FOR SELECT TO restaurant_app_user USING (%s)",
+			policyName,
+			table,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create platform search policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the platform search policies and the audit_logs table
+func (m *CreateAuditLogs) Down(db *gorm.DB) error {
+	for _, table := range platformSearchTables {
+		policyName := fmt.Sprintf("platform_search_%s", table)
+		if err := db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", policyName, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop platform search policy for %s: %w", table, err)
+		}
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS audit_logs CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop audit_logs table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateRestaurantSettings migration creates the restaurant_settings table
+type CreateRestaurantSettings struct {
+	BaseMigration
+}
+
+// NewCreateRestaurantSettings creates a new migration
+func NewCreateRestaurantSettings() *CreateRestaurantSettings {
+	return &CreateRestaurantSettings{
+		BaseMigration: BaseMigration{
+			version: 14,
+			name:    "create_restaurant_settings",
+		},
+	}
+}
+
+// Up creates the restaurant_settings table with RLS
+func (m *CreateRestaurantSettings) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.RestaurantSettings{}); err != nil {
+		return fmt.Errorf("failed to migrate RestaurantSettings: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE restaurant_settings ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on restaurant_settings: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_restaurant_settings ON restaurant_settings")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_restaurant_settings ON restaurant_settings FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for restaurant_settings: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the restaurant_settings table
+func (m *CreateRestaurantSettings) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS restaurant_settings CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop restaurant_settings table: %w", err)
+	}
+
+	return nil
+}
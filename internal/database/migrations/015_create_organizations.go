@@ -0,0 +1,109 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateOrganizations migration adds the organization layer for multi-location restaurants
+type CreateOrganizations struct {
+	BaseMigration
+}
+
+// NewCreateOrganizations creates a new migration
+func NewCreateOrganizations() *CreateOrganizations {
+	return &CreateOrganizations{
+		BaseMigration: BaseMigration{
+			version: 15,
+			name:    "create_organizations",
+		},
+	}
+}
+
+// organizationScopedTables are tenant tables org-scoped users may access across their locations
+var organizationScopedTables = []string{
+	"users",
+	"menu_categories",
+	"menu_items",
+	"menu_item_images",
+	"reservations",
+	"orders",
+	"order_items",
+	"restaurant_settings",
+}
+
+// Up creates the organizations table, links restaurants/users and adds org access policies
+func (m *CreateOrganizations) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Organization{}); err != nil {
+		return fmt.Errorf("failed to migrate Organization: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE restaurants
+		ADD COLUMN IF NOT EXISTS organization_id INTEGER REFERENCES organizations(id) ON DELETE SET NULL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add organization_id to restaurants: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE users
+		ADD COLUMN IF NOT EXISTS organization_id INTEGER REFERENCES organizations(id) ON DELETE SET NULL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add organization_id to users: %w", err)
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_restaurants_organization_id ON restaurants(organization_id)").Error; err != nil {
+		return fmt.Errorf("failed to create restaurants organization index: %w", err)
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_users_organization_id ON users(organization_id)").Error; err != nil {
+		return fmt.Errorf("failed to create users organization index: %w", err)
+	}
+
+	// Permissive policy: combined (OR) with the isolate_* policies, so org-scoped
+	// users can access rows of every location in their organization
+	condition := "restaurant_id IN (SELECT id FROM restaurants WHERE organization_id = NULLIF(current_setting('app.current_organization', true), '')::INTEGER)"
+	for _, table := range organizationScopedTables {
+		policyName := fmt.Sprintf("org_access_%s", table)
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", policyName, table))
+
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY %s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			policyName,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create organization policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the organization policies, columns and table
+func (m *CreateOrganizations) Down(db *gorm.DB) error {
+	for _, table := range organizationScopedTables {
+		policyName := fmt.Sprintf("org_access_%s", table)
+		if err := db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS %s ON %s", policyName, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop organization policy for %s: %w", table, err)
+		}
+	}
+
+	if err := db.Exec(`ALTER TABLE users DROP COLUMN IF EXISTS organization_id`).Error; err != nil {
+		return fmt.Errorf("failed to drop organization_id from users: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE restaurants DROP COLUMN IF EXISTS organization_id`).Error; err != nil {
+		return fmt.Errorf("failed to drop organization_id from restaurants: %w", err)
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS organizations CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop organizations table: %w", err)
+	}
+
+	return nil
+}
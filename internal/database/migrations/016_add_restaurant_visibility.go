@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddRestaurantVisibility migration
+type AddRestaurantVisibility struct {
+	BaseMigration
+}
+
+// NewAddRestaurantVisibility creates a new migration
+func NewAddRestaurantVisibility() *AddRestaurantVisibility {
+	return &AddRestaurantVisibility{
+		BaseMigration: BaseMigration{
+			version: 16,
+			name:    "add_restaurant_visibility",
+		},
+	}
+}
+
+// Up adds soft-launch visibility columns to restaurants table
+// Existing restaurants stay public; already-active ones are treated as launched
+func (m *AddRestaurantVisibility) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE restaurants
+		ADD COLUMN IF NOT EXISTS visibility VARCHAR(20) NOT NULL DEFAULT 'public',
+		ADD COLUMN IF NOT EXISTS go_live_at TIMESTAMPTZ,
+		ADD COLUMN IF NOT EXISTS launched_at TIMESTAMPTZ
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add visibility columns: %w", err)
+	}
+
+	if err := db.Exec(`
+		UPDATE restaurants SET launched_at = COALESCE(activated_at, created_at)
+		WHERE status = 'active' AND launched_at IS NULL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to backfill launched_at: %w", err)
+	}
+
+	if err := db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_restaurants_go_live_at ON restaurants(go_live_at)
+		WHERE visibility = 'hidden' AND go_live_at IS NOT NULL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create go_live_at index: %w", err)
+	}
+
+	return nil
+}
+
+// Down removes the visibility columns
+func (m *AddRestaurantVisibility) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_restaurants_go_live_at`).Error; err != nil {
+		return fmt.Errorf("failed to drop go_live_at index: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE restaurants
+		DROP COLUMN IF EXISTS visibility,
+		DROP COLUMN IF EXISTS go_live_at,
+		DROP COLUMN IF EXISTS launched_at
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop visibility columns: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddReservationOverlapConstraint migration prevents double-booking a table at the database level
+type AddReservationOverlapConstraint struct {
+	BaseMigration
+}
+
+// NewAddReservationOverlapConstraint creates a new migration
+func NewAddReservationOverlapConstraint() *AddReservationOverlapConstraint {
+	return &AddReservationOverlapConstraint{
+		BaseMigration: BaseMigration{
+			version: 17,
+			name:    "add_reservation_overlap_constraint",
+		},
+	}
+}
+
+// Up adds an exclusion constraint so no two non-cancelled reservations overlap for the same table
+// Replaces the race-prone read-then-insert check with an atomic guarantee
+func (m *AddReservationOverlapConstraint) Up(db *gorm.DB) error {
+	// btree_gist allows equality on scalar columns inside a GiST exclusion constraint
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS btree_gist").Error; err != nil {
+		return fmt.Errorf("failed to create btree_gist extension: %w", err)
+	}
+
+	db.Exec("ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_no_overlap")
+
+	// Half-open ranges [start, end) so back-to-back bookings don't conflict
+	if err := db.Exec(`
+		ALTER TABLE reservations
+		ADD CONSTRAINT reservations_no_overlap
+		EXCLUDE USING gist (
+			restaurant_id WITH =,
+			table_number WITH =,
+			tstzrange(start_time, end_time, '[)') WITH &&
+		) WHERE (status <> 'cancelled')
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add reservation overlap constraint (resolve existing overlapping reservations first): %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the exclusion constraint
+func (m *AddReservationOverlapConstraint) Down(db *gorm.DB) error {
+	if err := db.Exec("ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_no_overlap").Error; err != nil {
+		return fmt.Errorf("failed to drop reservation overlap constraint: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddAuditLogRestaurant migration
+type AddAuditLogRestaurant struct {
+	BaseMigration
+}
+
+// NewAddAuditLogRestaurant creates a new migration
+func NewAddAuditLogRestaurant() *AddAuditLogRestaurant {
+	return &AddAuditLogRestaurant{
+		BaseMigration: BaseMigration{
+			version: 18,
+			name:    "add_audit_log_restaurant",
+		},
+	}
+}
+
+// Up adds an optional restaurant reference to audit_logs for tenant-level actions
+func (m *AddAuditLogRestaurant) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE audit_logs
+		ADD COLUMN IF NOT EXISTS restaurant_id INTEGER REFERENCES restaurants(id) ON DELETE SET NULL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add restaurant_id to audit_logs: %w", err)
+	}
+
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_audit_logs_restaurant_id ON audit_logs(restaurant_id)`).Error; err != nil {
+		return fmt.Errorf("failed to create audit_logs restaurant index: %w", err)
+	}
+
+	return nil
+}
+
+// Down removes the restaurant reference from audit_logs
+func (m *AddAuditLogRestaurant) Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE audit_logs DROP COLUMN IF EXISTS restaurant_id`).Error; err != nil {
+		return fmt.Errorf("failed to drop restaurant_id from audit_logs: %w", err)
+	}
+
+	return nil
+}
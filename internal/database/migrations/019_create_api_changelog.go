@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateAPIChangelog migration
+type CreateAPIChangelog struct {
+	BaseMigration
+}
+
+// NewCreateAPIChangelog creates a new migration
+func NewCreateAPIChangelog() *CreateAPIChangelog {
+	return &CreateAPIChangelog{
+		BaseMigration: BaseMigration{
+			version: 19,
+			name:    "create_api_changelog",
+		},
+	}
+}
+
+// Up creates the platform-wide api_changelog_entries table
+func (m *CreateAPIChangelog) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.APIChangelogEntry{}); err != nil {
+		return fmt.Errorf("failed to migrate APIChangelogEntry: %w", err)
+	}
+
+	if err := db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_api_changelog_deprecations
+		ON api_changelog_entries(method, path)
+		WHERE change_type = 'deprecated'
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create deprecation index: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the api_changelog_entries table
+func (m *CreateAPIChangelog) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS api_changelog_entries CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop api_changelog_entries table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateIntegrityFindings migration
+type CreateIntegrityFindings struct {
+	BaseMigration
+}
+
+// NewCreateIntegrityFindings creates a new migration
+func NewCreateIntegrityFindings() *CreateIntegrityFindings {
+	return &CreateIntegrityFindings{
+		BaseMigration: BaseMigration{
+			version: 20,
+			name:    "create_integrity_findings",
+		},
+	}
+}
+
+// Up creates the platform-wide integrity_findings table
+func (m *CreateIntegrityFindings) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.IntegrityFinding{}); err != nil {
+		return fmt.Errorf("failed to migrate IntegrityFinding: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the integrity_findings table
+func (m *CreateIntegrityFindings) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS integrity_findings CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop integrity_findings table: %w", err)
+	}
+
+	return nil
+}
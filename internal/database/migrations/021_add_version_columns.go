@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// versionedTables are the tables using optimistic locking
+var versionedTables = []string{"menu_items", "menu_categories", "restaurants", "users"}
+
+// AddVersionColumns migration
+type AddVersionColumns struct {
+	BaseMigration
+}
+
+// NewAddVersionColumns creates a new migration
+func NewAddVersionColumns() *AddVersionColumns {
+	return &AddVersionColumns{
+		BaseMigration: BaseMigration{
+			version: 21,
+			name:    "add_version_columns",
+		},
+	}
+}
+
+// Up adds a version column used for optimistic locking to mutable tables
+func (m *AddVersionColumns) Up(db *gorm.DB) error {
+	for _, table := range versionedTables {
+		if err := db.Exec(fmt.Sprintf(
+			`ALTER TABLE %s ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1`, table,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to add version column to %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down removes the version columns
+func (m *AddVersionColumns) Down(db *gorm.DB) error {
+	for _, table := range versionedTables {
+		if err := db.Exec(fmt.Sprintf(`ALTER TABLE %s DROP COLUMN IF EXISTS version`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop version column from %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
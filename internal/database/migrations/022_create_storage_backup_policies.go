@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateStorageBackupPolicies migration
+type CreateStorageBackupPolicies struct {
+	BaseMigration
+}
+
+// NewCreateStorageBackupPolicies creates a new migration
+func NewCreateStorageBackupPolicies() *CreateStorageBackupPolicies {
+	return &CreateStorageBackupPolicies{
+		BaseMigration: BaseMigration{
+			version: 22,
+			name:    "create_storage_backup_policies",
+		},
+	}
+}
+
+// Up creates the platform-wide storage_backup_policies table
+func (m *CreateStorageBackupPolicies) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.StorageBackupPolicy{}); err != nil {
+		return fmt.Errorf("failed to migrate StorageBackupPolicy: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the storage_backup_policies table
+func (m *CreateStorageBackupPolicies) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS storage_backup_policies CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop storage_backup_policies table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddOrderItemName migration
+type AddOrderItemName struct {
+	BaseMigration
+}
+
+// NewAddOrderItemName creates a new migration
+func NewAddOrderItemName() *AddOrderItemName {
+	return &AddOrderItemName{
+		BaseMigration: BaseMigration{
+			version: 23,
+			name:    "add_order_item_name",
+		},
+	}
+}
+
+// Up adds a menu item name snapshot to order_items and backfills it from current menu items
+func (m *AddOrderItemName) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE order_items
+		ADD COLUMN IF NOT EXISTS name VARCHAR(255) NOT NULL DEFAULT ''
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add name to order_items: %w", err)
+	}
+
+	if err := db.Exec(`
+		UPDATE order_items oi
+		SET name = mi.name
+		FROM menu_items mi
+		WHERE mi.id = oi.menu_item_id AND oi.name = ''
+	`).Error; err != nil {
+		return fmt.Errorf("failed to backfill order item names: %w", err)
+	}
+
+	return nil
+}
+
+// Down removes the name snapshot from order_items
+func (m *AddOrderItemName) Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE order_items DROP COLUMN IF EXISTS name`).Error; err != nil {
+		return fmt.Errorf("failed to drop name from order_items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddReservationTimeCheck migration
+type AddReservationTimeCheck struct {
+	BaseMigration
+}
+
+// NewAddReservationTimeCheck creates a new migration
+func NewAddReservationTimeCheck() *AddReservationTimeCheck {
+	return &AddReservationTimeCheck{
+		BaseMigration: BaseMigration{
+			version: 24,
+			name:    "add_reservation_time_check",
+		},
+	}
+}
+
+// Up requires end_time to be after start_time
+// Empty ranges never overlap, so zero-length reservations would bypass reservations_no_overlap
+// NOT VALID skips checking legacy rows while enforcing the constraint for all new writes
+func (m *AddReservationTimeCheck) Up(db *gorm.DB) error {
+	db.Exec("ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_valid_time_range")
+
+	if err := db.Exec(`
+		ALTER TABLE reservations
+		ADD CONSTRAINT reservations_valid_time_range CHECK (end_time > start_time) NOT VALID
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add reservation time range check: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the time range check
+func (m *AddReservationTimeCheck) Down(db *gorm.DB) error {
+	if err := db.Exec("ALTER TABLE reservations DROP CONSTRAINT IF EXISTS reservations_valid_time_range").Error; err != nil {
+		return fmt.Errorf("failed to drop reservation time range check: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateOrderStatusChanges migration creates the order status history table
+type CreateOrderStatusChanges struct {
+	BaseMigration
+}
+
+// NewCreateOrderStatusChanges creates a new migration
+func NewCreateOrderStatusChanges() *CreateOrderStatusChanges {
+	return &CreateOrderStatusChanges{
+		BaseMigration: BaseMigration{
+			version: 25,
+			name:    "create_order_status_changes",
+		},
+	}
+}
+
+// Up creates the order_status_changes table with RLS
+func (m *CreateOrderStatusChanges) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.OrderStatusChange{}); err != nil {
+		return fmt.Errorf("failed to migrate OrderStatusChange: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE order_status_changes ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on order_status_changes: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_order_status_changes ON order_status_changes")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_order_status_changes ON order_status_changes FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for order_status_changes: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the order_status_changes table
+func (m *CreateOrderStatusChanges) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS order_status_changes CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop order_status_changes table: %w", err)
+	}
+	return nil
+}
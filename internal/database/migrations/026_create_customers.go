@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateCustomers migration creates the customers (CRM) table
+type CreateCustomers struct {
+	BaseMigration
+}
+
+// NewCreateCustomers creates a new migration
+func NewCreateCustomers() *CreateCustomers {
+	return &CreateCustomers{
+		BaseMigration: BaseMigration{
+			version: 26,
+			name:    "create_customers",
+		},
+	}
+}
+
+// Up creates the customers table with RLS and the per-restaurant deduplication indexes
+func (m *CreateCustomers) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Customer{}); err != nil {
+		return fmt.Errorf("failed to migrate Customer: %w", err)
+	}
+
+	indexes := []string{
+		`CREATE UNIQUE INDEX IF NOT EXISTS customers_restaurant_email_key ON customers (restaurant_id, lower(email)) WHERE email <> ''`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS customers_restaurant_phone_key ON customers (restaurant_id, phone) WHERE phone <> ''`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS customers_restaurant_user_key ON customers (restaurant_id, user_id) WHERE user_id IS NOT NULL`,
+	}
+	for _, index := range indexes {
+		if err := db.Exec(index).Error; err != nil {
+			return fmt.Errorf("failed to create customers index: %w", err)
+		}
+	}
+
+	if err := db.Exec("ALTER TABLE customers ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on customers: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_customers ON customers")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_customers ON customers FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for customers: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the customers table
+func (m *CreateCustomers) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS customers CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop customers table: %w", err)
+	}
+	return nil
+}
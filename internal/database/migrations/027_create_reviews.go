@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateReviews migration creates the reviews table and menu item rating aggregates
+type CreateReviews struct {
+	BaseMigration
+}
+
+// NewCreateReviews creates a new migration
+func NewCreateReviews() *CreateReviews {
+	return &CreateReviews{
+		BaseMigration: BaseMigration{
+			version: 27,
+			name:    "create_reviews",
+		},
+	}
+}
+
+// Up creates the reviews table with RLS and adds rating columns to menu_items
+func (m *CreateReviews) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE menu_items
+			ADD COLUMN IF NOT EXISTS rating_average DOUBLE PRECISION NOT NULL DEFAULT 0,
+			ADD COLUMN IF NOT EXISTS rating_count INTEGER NOT NULL DEFAULT 0
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add menu item rating columns: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.Review{}); err != nil {
+		return fmt.Errorf("failed to migrate Review: %w", err)
+	}
+
+	// One review per order and one per ordered menu item
+	if err := db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS reviews_order_menu_item_key ON reviews (order_id, COALESCE(menu_item_id, 0))
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create reviews index: %w", err)
+	}
+
+	db.Exec("ALTER TABLE reviews DROP CONSTRAINT IF EXISTS reviews_rating_range")
+	if err := db.Exec("ALTER TABLE reviews ADD CONSTRAINT reviews_rating_range CHECK (rating BETWEEN 1 AND 5)").Error; err != nil {
+		return fmt.Errorf("failed to add reviews rating check: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE reviews ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on reviews: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_reviews ON reviews")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_reviews ON reviews FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for reviews: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the reviews table and menu item rating columns
+func (m *CreateReviews) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS reviews CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop reviews table: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE menu_items DROP COLUMN IF EXISTS rating_average, DROP COLUMN IF EXISTS rating_count
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop menu item rating columns: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// GrantAppRoleSequences migration lets the RLS role insert rows
+// Tenant queries now always run as restaurant_app_user, which needs the ID sequences
+type GrantAppRoleSequences struct {
+	BaseMigration
+}
+
+// NewGrantAppRoleSequences creates a new migration
+func NewGrantAppRoleSequences() *GrantAppRoleSequences {
+	return &GrantAppRoleSequences{
+		BaseMigration: BaseMigration{
+			version: 28,
+			name:    "grant_app_role_sequences",
+		},
+	}
+}
+
+// Up grants sequence usage to restaurant_app_user, including sequences created later
+func (m *GrantAppRoleSequences) Up(db *gorm.DB) error {
+	if err := db.Exec("GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO restaurant_app_user").Error; err != nil {
+		return fmt.Errorf("failed to grant sequence permissions: %w", err)
+	}
+
+	if err := db.Exec("ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT USAGE, SELECT ON SEQUENCES TO restaurant_app_user").Error; err != nil {
+		return fmt.Errorf("failed to grant default sequence privileges: %w", err)
+	}
+
+	return nil
+}
+
+// Down revokes sequence usage from restaurant_app_user
+func (m *GrantAppRoleSequences) Down(db *gorm.DB) error {
+	if err := db.Exec("ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE USAGE, SELECT ON SEQUENCES FROM restaurant_app_user").Error; err != nil {
+		return fmt.Errorf("failed to revoke default sequence privileges: %w", err)
+	}
+
+	if err := db.Exec("REVOKE USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public FROM restaurant_app_user").Error; err != nil {
+		return fmt.Errorf("failed to revoke sequence permissions: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateSubscriptions migration creates the subscriptions table
+type CreateSubscriptions struct {
+	BaseMigration
+}
+
+// NewCreateSubscriptions creates a new migration
+func NewCreateSubscriptions() *CreateSubscriptions {
+	return &CreateSubscriptions{
+		BaseMigration: BaseMigration{
+			version: 29,
+			name:    "create_subscriptions",
+		},
+	}
+}
+
+// Up creates the subscriptions table with RLS and subscribes existing restaurants
+func (m *CreateSubscriptions) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Subscription{}); err != nil {
+		return fmt.Errorf("failed to migrate Subscription: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE subscriptions ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on subscriptions: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_subscriptions ON subscriptions")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_subscriptions ON subscriptions FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for subscriptions: %w", err)
+	}
+
+	// Grandfather existing restaurants onto the unlimited plan so enforcing limits doesn't block them
+	if err := db.Exec(`
+		INSERT INTO subscriptions (restaurant_id, plan, status, current_period_start, created_at, updated_at)
+		SELECT id, ?, ?, NOW(), NOW(), NOW() FROM restaurants WHERE id <> ?
+		ON CONFLICT (restaurant_id) DO NOTHING
+	`, models.PlanPremium, models.SubscriptionStatusActive, models.PlatformOrganizationID).Error; err != nil {
+		return fmt.Errorf("failed to backfill subscriptions: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the subscriptions table
+func (m *CreateSubscriptions) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS subscriptions CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop subscriptions table: %w", err)
+	}
+
+	return nil
+}
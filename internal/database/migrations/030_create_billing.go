@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateBilling migration creates the usage metering and invoice tables
+type CreateBilling struct {
+	BaseMigration
+}
+
+// NewCreateBilling creates a new migration
+func NewCreateBilling() *CreateBilling {
+	return &CreateBilling{
+		BaseMigration: BaseMigration{
+			version: 30,
+			name:    "create_billing",
+		},
+	}
+}
+
+// Up creates the platform-wide usage_records, invoices and invoice_lines tables
+func (m *CreateBilling) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.UsageRecord{}, &models.Invoice{}, &models.InvoiceLine{}); err != nil {
+		return fmt.Errorf("failed to migrate billing tables: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the billing tables
+func (m *CreateBilling) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS invoice_lines, invoices, usage_records CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop billing tables: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateDeliveryIntegrations migration creates the delivery_integrations table and order source columns
+type CreateDeliveryIntegrations struct {
+	BaseMigration
+}
+
+// NewCreateDeliveryIntegrations creates a new migration
+func NewCreateDeliveryIntegrations() *CreateDeliveryIntegrations {
+	return &CreateDeliveryIntegrations{
+		BaseMigration: BaseMigration{
+			version: 31,
+			name:    "create_delivery_integrations",
+		},
+	}
+}
+
+// Up creates the delivery_integrations table with RLS and adds source columns to orders
+func (m *CreateDeliveryIntegrations) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE orders
+			ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'internal',
+			ADD COLUMN IF NOT EXISTS external_id VARCHAR(100)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add order source columns: %w", err)
+	}
+
+	// An external order is imported at most once
+	if err := db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS orders_external_id_key ON orders (restaurant_id, source, external_id)
+		WHERE external_id IS NOT NULL AND external_id <> ''
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create orders external_id index: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.DeliveryIntegration{}); err != nil {
+		return fmt.Errorf("failed to migrate DeliveryIntegration: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE delivery_integrations ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on delivery_integrations: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_delivery_integrations ON delivery_integrations")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_delivery_integrations ON delivery_integrations FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for delivery_integrations: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the delivery_integrations table and order source columns
+func (m *CreateDeliveryIntegrations) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS delivery_integrations CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop delivery_integrations table: %w", err)
+	}
+
+	if err := db.Exec(`DROP INDEX IF EXISTS orders_external_id_key`).Error; err != nil {
+		return fmt.Errorf("failed to drop orders external_id index: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE orders DROP COLUMN IF EXISTS source, DROP COLUMN IF EXISTS external_id`).Error; err != nil {
+		return fmt.Errorf("failed to drop order source columns: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreatePrinters migration creates the printers and print_jobs tables
+type CreatePrinters struct {
+	BaseMigration
+}
+
+// NewCreatePrinters creates a new migration
+func NewCreatePrinters() *CreatePrinters {
+	return &CreatePrinters{
+		BaseMigration: BaseMigration{
+			version: 32,
+			name:    "create_printers",
+		},
+	}
+}
+
+// Up creates the printers and print_jobs tables with RLS
+func (m *CreatePrinters) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Printer{}, &models.PrintJob{}); err != nil {
+		return fmt.Errorf("failed to migrate printers: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"printers", "print_jobs"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the printers and print_jobs tables
+func (m *CreatePrinters) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS print_jobs CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop print_jobs table: %w", err)
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS printers CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop printers table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddSettingsTaxRate migration
+type AddSettingsTaxRate struct {
+	BaseMigration
+}
+
+// NewAddSettingsTaxRate creates a new migration
+func NewAddSettingsTaxRate() *AddSettingsTaxRate {
+	return &AddSettingsTaxRate{
+		BaseMigration: BaseMigration{
+			version: 33,
+			name:    "add_settings_tax_rate",
+		},
+	}
+}
+
+// Up adds the tax rate (percent included in menu prices) shown on receipts
+func (m *AddSettingsTaxRate) Up(db *gorm.DB) error {
+	if err := db.Exec(
+		`ALTER TABLE restaurant_settings ADD COLUMN IF NOT EXISTS tax_rate NUMERIC(5,2) NOT NULL DEFAULT 0`,
+	).Error; err != nil {
+		return fmt.Errorf("failed to add tax_rate column to restaurant_settings: %w", err)
+	}
+
+	return nil
+}
+
+// Down removes the tax rate column
+func (m *AddSettingsTaxRate) Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE restaurant_settings DROP COLUMN IF EXISTS tax_rate`).Error; err != nil {
+		return fmt.Errorf("failed to drop tax_rate column from restaurant_settings: %w", err)
+	}
+
+	return nil
+}
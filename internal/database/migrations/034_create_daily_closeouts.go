@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// closeoutTables are the tables holding immutable end-of-day reports
+var closeoutTables = []string{"daily_closeouts", "daily_closeout_payments"}
+
+// CreateDailyCloseouts migration creates the end-of-day (Z) report tables
+type CreateDailyCloseouts struct {
+	BaseMigration
+}
+
+// NewCreateDailyCloseouts creates a new migration
+func NewCreateDailyCloseouts() *CreateDailyCloseouts {
+	return &CreateDailyCloseouts{
+		BaseMigration: BaseMigration{
+			version: 34,
+			name:    "create_daily_closeouts",
+		},
+	}
+}
+
+// Up creates the closeout tables with RLS and makes their rows immutable
+func (m *CreateDailyCloseouts) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.DailyCloseout{}, &models.DailyCloseoutPayment{}); err != nil {
+		return fmt.Errorf("failed to migrate daily closeouts: %w", err)
+	}
+
+	// Closed days can't be changed or deleted, even by the table owner
+	if err := db.Exec(`
+		CREATE OR REPLACE FUNCTION reject_closeout_changes() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'daily closeouts are immutable';
+		END;
+		$$ LANGUAGE plpgsql
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create closeout immutability function: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range closeoutTables {
+		db.Exec(fmt.Sprintf("DROP TRIGGER IF EXISTS %s_immutable ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE TRIGGER %s_immutable BEFORE UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION reject_closeout_changes()",
+			table,
+			table,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create immutability trigger on %s: %w", table, err)
+		}
+
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the closeout tables
+func (m *CreateDailyCloseouts) Down(db *gorm.DB) error {
+	for _, table := range []string{"daily_closeout_payments", "daily_closeouts"} {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s CASCADE`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	if err := db.Exec(`DROP FUNCTION IF EXISTS reject_closeout_changes()`).Error; err != nil {
+		return fmt.Errorf("failed to drop closeout immutability function: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateOrderSplits migration adds seats to order items and creates the split bill tables
+type CreateOrderSplits struct {
+	BaseMigration
+}
+
+// NewCreateOrderSplits creates a new migration
+func NewCreateOrderSplits() *CreateOrderSplits {
+	return &CreateOrderSplits{
+		BaseMigration: BaseMigration{
+			version: 35,
+			name:    "create_order_splits",
+		},
+	}
+}
+
+// Up adds the seat column and creates the split tables with RLS
+func (m *CreateOrderSplits) Up(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE order_items ADD COLUMN IF NOT EXISTS seat INTEGER`).Error; err != nil {
+		return fmt.Errorf("failed to add seat column to order_items: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.OrderSplit{}, &models.OrderSplitItem{}); err != nil {
+		return fmt.Errorf("failed to migrate order splits: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"order_splits", "order_split_items"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the split tables and the seat column
+func (m *CreateOrderSplits) Down(db *gorm.DB) error {
+	for _, table := range []string{"order_split_items", "order_splits"} {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s CASCADE`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	if err := db.Exec(`ALTER TABLE order_items DROP COLUMN IF EXISTS seat`).Error; err != nil {
+		return fmt.Errorf("failed to drop seat column from order_items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AddOrderScheduling migration adds order-ahead pickup times, slot capacity and opening hours
+type AddOrderScheduling struct {
+	BaseMigration
+}
+
+// NewAddOrderScheduling creates a new migration
+func NewAddOrderScheduling() *AddOrderScheduling {
+	return &AddOrderScheduling{
+		BaseMigration: BaseMigration{
+			version: 36,
+			name:    "add_order_scheduling",
+		},
+	}
+}
+
+// Up adds the scheduling columns and creates the opening hours table with RLS
+func (m *AddOrderScheduling) Up(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE orders ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMPTZ`).Error; err != nil {
+		return fmt.Errorf("failed to add scheduled_for column to orders: %w", err)
+	}
+	if err := db.Exec(
+		`CREATE INDEX IF NOT EXISTS idx_orders_restaurant_scheduled_for ON orders (restaurant_id, scheduled_for) WHERE scheduled_for IS NOT NULL`,
+	).Error; err != nil {
+		return fmt.Errorf("failed to create scheduled orders index: %w", err)
+	}
+
+	if err := db.Exec(
+		`ALTER TABLE restaurant_settings ADD COLUMN IF NOT EXISTS order_slot_capacity INTEGER NOT NULL DEFAULT 0`,
+	).Error; err != nil {
+		return fmt.Errorf("failed to add order_slot_capacity column to restaurant_settings: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.OpeningHours{}); err != nil {
+		return fmt.Errorf("failed to migrate opening_hours: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE opening_hours ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on opening_hours: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_opening_hours ON opening_hours`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_opening_hours ON opening_hours FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for opening_hours: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the opening hours table and the scheduling columns
+func (m *AddOrderScheduling) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS opening_hours CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop opening_hours table: %w", err)
+	}
+	if err := db.Exec(`ALTER TABLE restaurant_settings DROP COLUMN IF EXISTS order_slot_capacity`).Error; err != nil {
+		return fmt.Errorf("failed to drop order_slot_capacity column from restaurant_settings: %w", err)
+	}
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_orders_restaurant_scheduled_for`).Error; err != nil {
+		return fmt.Errorf("failed to drop scheduled orders index: %w", err)
+	}
+	if err := db.Exec(`ALTER TABLE orders DROP COLUMN IF EXISTS scheduled_for`).Error; err != nil {
+		return fmt.Errorf("failed to drop scheduled_for column from orders: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateDeliveryZones migration creates delivery zones and adds delivery details to orders
+type CreateDeliveryZones struct {
+	BaseMigration
+}
+
+// NewCreateDeliveryZones creates a new migration
+func NewCreateDeliveryZones() *CreateDeliveryZones {
+	return &CreateDeliveryZones{
+		BaseMigration: BaseMigration{
+			version: 37,
+			name:    "create_delivery_zones",
+		},
+	}
+}
+
+// Up creates the delivery zones table with RLS and the order delivery columns
+func (m *CreateDeliveryZones) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.DeliveryZone{}); err != nil {
+		return fmt.Errorf("failed to migrate delivery_zones: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE delivery_zones ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on delivery_zones: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_delivery_zones ON delivery_zones`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_delivery_zones ON delivery_zones FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for delivery_zones: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE orders
+			ADD COLUMN IF NOT EXISTS fulfillment_type VARCHAR(20) NOT NULL DEFAULT 'pickup',
+			ADD COLUMN IF NOT EXISTS delivery_address VARCHAR(255),
+			ADD COLUMN IF NOT EXISTS delivery_lat DOUBLE PRECISION,
+			ADD COLUMN IF NOT EXISTS delivery_lng DOUBLE PRECISION,
+			ADD COLUMN IF NOT EXISTS delivery_zone_id BIGINT,
+			ADD COLUMN IF NOT EXISTS delivery_fee DOUBLE PRECISION NOT NULL DEFAULT 0
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add delivery columns to orders: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the order delivery columns and the delivery zones table
+func (m *CreateDeliveryZones) Down(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE orders
+			DROP COLUMN IF EXISTS fulfillment_type,
+			DROP COLUMN IF EXISTS delivery_address,
+			DROP COLUMN IF EXISTS delivery_lat,
+			DROP COLUMN IF EXISTS delivery_lng,
+			DROP COLUMN IF EXISTS delivery_zone_id,
+			DROP COLUMN IF EXISTS delivery_fee
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop delivery columns from orders: %w", err)
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS delivery_zones CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop delivery_zones table: %w", err)
+	}
+
+	return nil
+}
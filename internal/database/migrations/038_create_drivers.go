@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateDrivers migration creates the drivers and delivery assignments tables
+type CreateDrivers struct {
+	BaseMigration
+}
+
+// NewCreateDrivers creates a new migration
+func NewCreateDrivers() *CreateDrivers {
+	return &CreateDrivers{
+		BaseMigration: BaseMigration{
+			version: 38,
+			name:    "create_drivers",
+		},
+	}
+}
+
+// Up creates the driver tables with RLS
+func (m *CreateDrivers) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Driver{}, &models.DeliveryAssignment{}); err != nil {
+		return fmt.Errorf("failed to migrate drivers: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"drivers", "delivery_assignments"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the driver tables
+func (m *CreateDrivers) Down(db *gorm.DB) error {
+	for _, table := range []string{"delivery_assignments", "drivers"} {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s CASCADE`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateTimeEntries migration creates the staff rates and time entries tables
+type CreateTimeEntries struct {
+	BaseMigration
+}
+
+// NewCreateTimeEntries creates a new migration
+func NewCreateTimeEntries() *CreateTimeEntries {
+	return &CreateTimeEntries{
+		BaseMigration: BaseMigration{
+			version: 39,
+			name:    "create_time_entries",
+		},
+	}
+}
+
+// Up creates the time tracking tables with RLS
+func (m *CreateTimeEntries) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.StaffRate{}, &models.TimeEntry{}); err != nil {
+		return fmt.Errorf("failed to migrate time entries: %w", err)
+	}
+
+	// A staff member can only be clocked in once at a time
+	if err := db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_open
+		ON time_entries (user_id) WHERE clock_out_at IS NULL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create open time entry index: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"staff_rates", "time_entries"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the time tracking tables
+func (m *CreateTimeEntries) Down(db *gorm.DB) error {
+	for _, table := range []string{"time_entries", "staff_rates"} {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s CASCADE`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateFloorPlan migration creates the floor section and dining table tables
+type CreateFloorPlan struct {
+	BaseMigration
+}
+
+// NewCreateFloorPlan creates a new migration
+func NewCreateFloorPlan() *CreateFloorPlan {
+	return &CreateFloorPlan{
+		BaseMigration: BaseMigration{
+			version: 40,
+			name:    "create_floor_plan",
+		},
+	}
+}
+
+// Up creates the floor plan tables with RLS
+func (m *CreateFloorPlan) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.FloorSection{}, &models.DiningTable{}); err != nil {
+		return fmt.Errorf("failed to migrate floor plan: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"floor_sections", "dining_tables"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the floor plan tables
+func (m *CreateFloorPlan) Down(db *gorm.DB) error {
+	for _, table := range []string{"dining_tables", "floor_sections"} {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s CASCADE`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateTableSessions migration creates the table sessions table and links orders to their session
+type CreateTableSessions struct {
+	BaseMigration
+}
+
+// NewCreateTableSessions creates a new migration
+func NewCreateTableSessions() *CreateTableSessions {
+	return &CreateTableSessions{
+		BaseMigration: BaseMigration{
+			version: 41,
+			name:    "create_table_sessions",
+		},
+	}
+}
+
+// Up creates the table sessions table with RLS and the order session column
+func (m *CreateTableSessions) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS table_session_id BIGINT
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add table_session_id to orders: %w", err)
+	}
+	if err := db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_orders_table_session_id ON orders (table_session_id)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create order table session index: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.TableSession{}); err != nil {
+		return fmt.Errorf("failed to migrate table_sessions: %w", err)
+	}
+
+	// A table can only have one open check
+	if err := db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_table_sessions_open
+		ON table_sessions (restaurant_id, table_number) WHERE status = 'open'
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create open table session index: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE table_sessions ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on table_sessions: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_table_sessions ON table_sessions`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_table_sessions ON table_sessions FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for table_sessions: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the order session column and the table sessions table
+func (m *CreateTableSessions) Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE orders DROP COLUMN IF EXISTS table_session_id`).Error; err != nil {
+		return fmt.Errorf("failed to drop table_session_id from orders: %w", err)
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS table_sessions CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop table_sessions table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AddOrderCourses migration adds courses to order items and creates the course firings table
+type AddOrderCourses struct {
+	BaseMigration
+}
+
+// NewAddOrderCourses creates a new migration
+func NewAddOrderCourses() *AddOrderCourses {
+	return &AddOrderCourses{
+		BaseMigration: BaseMigration{
+			version: 42,
+			name:    "add_order_courses",
+		},
+	}
+}
+
+// Up adds the order item course columns and creates the course firings table with RLS
+func (m *AddOrderCourses) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE order_items
+		ADD COLUMN IF NOT EXISTS course INTEGER,
+		ADD COLUMN IF NOT EXISTS fired_at TIMESTAMPTZ
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add course columns to order_items: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.OrderCourseFiring{}); err != nil {
+		return fmt.Errorf("failed to migrate order_course_firings: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE order_course_firings ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on order_course_firings: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_order_course_firings ON order_course_firings`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_order_course_firings ON order_course_firings FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for order_course_firings: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the course firings table and the order item course columns
+func (m *AddOrderCourses) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS order_course_firings CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop order_course_firings table: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE order_items
+		DROP COLUMN IF EXISTS course,
+		DROP COLUMN IF EXISTS fired_at
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop course columns from order_items: %w", err)
+	}
+
+	return nil
+}
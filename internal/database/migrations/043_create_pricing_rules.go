@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreatePricingRules migration creates the pricing rules table and records the rule applied to order items
+type CreatePricingRules struct {
+	BaseMigration
+}
+
+// NewCreatePricingRules creates a new migration
+func NewCreatePricingRules() *CreatePricingRules {
+	return &CreatePricingRules{
+		BaseMigration: BaseMigration{
+			version: 43,
+			name:    "create_pricing_rules",
+		},
+	}
+}
+
+// Up creates the pricing rules table with RLS and the order item pricing rule column
+func (m *CreatePricingRules) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.PricingRule{}); err != nil {
+		return fmt.Errorf("failed to migrate pricing_rules: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE order_items ADD COLUMN IF NOT EXISTS pricing_rule_id BIGINT
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add pricing_rule_id to order_items: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE pricing_rules ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on pricing_rules: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_pricing_rules ON pricing_rules`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_pricing_rules ON pricing_rules FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for pricing_rules: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the order item pricing rule column and the pricing rules table
+func (m *CreatePricingRules) Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE order_items DROP COLUMN IF EXISTS pricing_rule_id`).Error; err != nil {
+		return fmt.Errorf("failed to drop pricing_rule_id from order_items: %w", err)
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS pricing_rules CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop pricing_rules table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddChannelPrices migration adds per-channel menu item prices and the sales channel of orders
+type AddChannelPrices struct {
+	BaseMigration
+}
+
+// NewAddChannelPrices creates a new migration
+func NewAddChannelPrices() *AddChannelPrices {
+	return &AddChannelPrices{
+		BaseMigration: BaseMigration{
+			version: 44,
+			name:    "add_channel_prices",
+		},
+	}
+}
+
+// Up adds the channel price columns and the order channel, backfilling the channel of existing orders
+func (m *AddChannelPrices) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE menu_items
+		ADD COLUMN IF NOT EXISTS dine_in_price DECIMAL,
+		ADD COLUMN IF NOT EXISTS pickup_price DECIMAL,
+		ADD COLUMN IF NOT EXISTS delivery_price DECIMAL,
+		ADD COLUMN IF NOT EXISTS third_party_price DECIMAL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add channel prices to menu_items: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE orders ADD COLUMN IF NOT EXISTS channel VARCHAR(20) NOT NULL DEFAULT ''
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add channel to orders: %w", err)
+	}
+
+	// Imported orders came from delivery platforms; the others were priced for their fulfillment type
+	if err := db.Exec(`
+		UPDATE orders
+		SET channel = CASE WHEN source <> 'internal' THEN 'third_party' ELSE fulfillment_type END
+		WHERE channel = ''
+	`).Error; err != nil {
+		return fmt.Errorf("failed to backfill order channels: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the order channel and the channel price columns
+func (m *AddChannelPrices) Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE orders DROP COLUMN IF EXISTS channel`).Error; err != nil {
+		return fmt.Errorf("failed to drop channel from orders: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE menu_items
+		DROP COLUMN IF EXISTS dine_in_price,
+		DROP COLUMN IF EXISTS pickup_price,
+		DROP COLUMN IF EXISTS delivery_price,
+		DROP COLUMN IF EXISTS third_party_price
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop channel prices from menu_items: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddMenuSearch migration adds menu item tags and the full-text and trigram search indexes
+type AddMenuSearch struct {
+	BaseMigration
+}
+
+// NewAddMenuSearch creates a new migration
+func NewAddMenuSearch() *AddMenuSearch {
+	return &AddMenuSearch{
+		BaseMigration: BaseMigration{
+			version: 45,
+			name:    "add_menu_search",
+		},
+	}
+}
+
+// Up adds the tags column, a generated search vector over names, tags and descriptions, and their indexes
+// The 'simple' configuration is used as menus are written in many languages
+func (m *AddMenuSearch) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add tags to menu_items: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE menu_items ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+			setweight(jsonb_to_tsvector('simple', coalesce(tags, '[]'::jsonb), '["string"]'), 'B') ||
+			setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+		) STORED
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add search_vector to menu_items: %w", err)
+	}
+
+	if err := db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_menu_items_search_vector ON menu_items USING GIN (search_vector)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create menu item search index: %w", err)
+	}
+
+	// Trigram similarity finds names despite typos when the full-text search has no match
+	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return fmt.Errorf("failed to create pg_trgm extension: %w", err)
+	}
+	if err := db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_menu_items_name_trgm ON menu_items USING GIN (name gin_trgm_ops)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create menu item name trigram index: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the search indexes, the search vector and the tags column
+func (m *AddMenuSearch) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_menu_items_name_trgm`).Error; err != nil {
+		return fmt.Errorf("failed to drop menu item name trigram index: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE menu_items
+		DROP COLUMN IF EXISTS search_vector,
+		DROP COLUMN IF EXISTS tags
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop search columns from menu_items: %w", err)
+	}
+
+	return nil
+}
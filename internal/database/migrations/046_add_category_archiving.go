@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddCategoryArchiving migration adds the archive timestamp of menu categories
+type AddCategoryArchiving struct {
+	BaseMigration
+}
+
+// NewAddCategoryArchiving creates a new migration
+func NewAddCategoryArchiving() *AddCategoryArchiving {
+	return &AddCategoryArchiving{
+		BaseMigration: BaseMigration{
+			version: 46,
+			name:    "add_category_archiving",
+		},
+	}
+}
+
+// Up adds menu_categories.archived_at
+func (m *AddCategoryArchiving) Up(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE menu_categories ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ`).Error; err != nil {
+		return fmt.Errorf("failed to add archived_at to menu_categories: %w", err)
+	}
+
+	if err := db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_menu_categories_archived_at ON menu_categories (archived_at)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create menu category archive index: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops menu_categories.archived_at
+func (m *AddCategoryArchiving) Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE menu_categories DROP COLUMN IF EXISTS archived_at`).Error; err != nil {
+		return fmt.Errorf("failed to drop archived_at from menu_categories: %w", err)
+	}
+
+	return nil
+}
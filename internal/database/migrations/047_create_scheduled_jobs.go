@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateScheduledJobs migration
+type CreateScheduledJobs struct {
+	BaseMigration
+}
+
+// NewCreateScheduledJobs creates a new migration
+func NewCreateScheduledJobs() *CreateScheduledJobs {
+	return &CreateScheduledJobs{
+		BaseMigration: BaseMigration{
+			version: 47,
+			name:    "create_scheduled_jobs",
+		},
+	}
+}
+
+// Up creates the platform-wide scheduled_jobs (schedule and lease per job) and job_runs (run history) tables
+func (m *CreateScheduledJobs) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.ScheduledJob{}, &models.JobRun{}); err != nil {
+		return fmt.Errorf("failed to migrate scheduled jobs: %w", err)
+	}
+
+	if err := db.Exec(`
+		CREATE INDEX IF NOT EXISTS idx_job_runs_job_started
+		ON job_runs(job_name, started_at DESC)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create job runs index: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the scheduled_jobs and job_runs tables
+func (m *CreateScheduledJobs) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS job_runs CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop job_runs table: %w", err)
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS scheduled_jobs CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop scheduled_jobs table: %w", err)
+	}
+
+	return nil
+}
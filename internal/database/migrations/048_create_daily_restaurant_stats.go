@@ -0,0 +1,104 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateDailyRestaurantStats migration creates the daily analytics rollups and backfills them
+type CreateDailyRestaurantStats struct {
+	BaseMigration
+}
+
+// NewCreateDailyRestaurantStats creates a new migration
+func NewCreateDailyRestaurantStats() *CreateDailyRestaurantStats {
+	return &CreateDailyRestaurantStats{
+		BaseMigration: BaseMigration{
+			version: 48,
+			name:    "create_daily_restaurant_stats",
+		},
+	}
+}
+
+// Up creates the daily_restaurant_stats table with RLS and rolls up every past day,
+// so historical analytics are served from rollups right away; the rollup jobs keep them current
+func (m *CreateDailyRestaurantStats) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.DailyRestaurantStats{}); err != nil {
+		return fmt.Errorf("failed to migrate daily_restaurant_stats: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE daily_restaurant_stats ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on daily_restaurant_stats: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_daily_restaurant_stats ON daily_restaurant_stats`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_daily_restaurant_stats ON daily_restaurant_stats FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for daily_restaurant_stats: %w", err)
+	}
+
+	// Days are cut in each restaurant's time zone; the current day is left to the live queries
+	if err := db.Exec(`
+		WITH zones AS (
+			SELECT r.id AS restaurant_id, COALESCE(s.time_zone, ?) AS tz
+			FROM restaurants r
+			LEFT JOIN restaurant_settings s ON s.restaurant_id = r.id
+		), order_days AS (
+			SELECT o.restaurant_id, (o.created_at AT TIME ZONE z.tz)::date AS stat_date,
+				COUNT(*) AS total_orders,
+				COUNT(*) FILTER (WHERE o.status = 'pending') AS pending_orders,
+				COUNT(*) FILTER (WHERE o.status = 'completed') AS completed_orders,
+				COUNT(*) FILTER (WHERE o.status = 'cancelled') AS cancelled_orders,
+				COALESCE(SUM(o.total_amount) FILTER (WHERE o.status = 'completed'), 0) AS total_revenue
+			FROM orders o
+			JOIN zones z ON z.restaurant_id = o.restaurant_id
+			WHERE (o.created_at AT TIME ZONE z.tz)::date < (NOW() AT TIME ZONE z.tz)::date
+			GROUP BY 1, 2
+		), reservation_days AS (
+			SELECT r.restaurant_id, (r.created_at AT TIME ZONE z.tz)::date AS stat_date,
+				COUNT(*) AS total_reservations,
+				COUNT(*) FILTER (WHERE r.status = 'pending') AS pending_reservations,
+				COUNT(*) FILTER (WHERE r.status = 'confirmed') AS confirmed_reservations,
+				COUNT(*) FILTER (WHERE r.status = 'completed') AS completed_reservations,
+				COUNT(*) FILTER (WHERE r.status = 'cancelled') AS cancelled_reservations
+			FROM reservations r
+			JOIN zones z ON z.restaurant_id = r.restaurant_id
+			WHERE (r.created_at AT TIME ZONE z.tz)::date < (NOW() AT TIME ZONE z.tz)::date
+			GROUP BY 1, 2
+		)
+		INSERT INTO daily_restaurant_stats (
+			restaurant_id, stat_date,
+			total_orders, pending_orders, completed_orders, cancelled_orders, total_revenue,
+			total_reservations, pending_reservations, confirmed_reservations, completed_reservations, cancelled_reservations,
+			computed_at
+		)
+		SELECT COALESCE(o.restaurant_id, r.restaurant_id), COALESCE(o.stat_date, r.stat_date),
+			COALESCE(o.total_orders, 0), COALESCE(o.pending_orders, 0), COALESCE(o.completed_orders, 0),
+			COALESCE(o.cancelled_orders, 0), COALESCE(o.total_revenue, 0),
+			COALESCE(r.total_reservations, 0), COALESCE(r.pending_reservations, 0), COALESCE(r.confirmed_reservations, 0),
+			COALESCE(r.completed_reservations, 0), COALESCE(r.cancelled_reservations, 0),
+			NOW()
+		FROM order_days o
+		FULL JOIN reservation_days r ON r.restaurant_id = o.restaurant_id AND r.stat_date = o.stat_date
+		ON CONFLICT (restaurant_id, stat_date) DO NOTHING
+	`, models.DefaultTimeZone).Error; err != nil {
+		return fmt.Errorf("failed to backfill daily_restaurant_stats: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the daily_restaurant_stats table
+func (m *CreateDailyRestaurantStats) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS daily_restaurant_stats CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop daily_restaurant_stats table: %w", err)
+	}
+	return nil
+}
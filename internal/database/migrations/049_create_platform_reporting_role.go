@@ -0,0 +1,106 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// CreatePlatformReportingRole migration creates the read-only role platform-wide analytics run as
+type CreatePlatformReportingRole struct {
+	BaseMigration
+}
+
+// NewCreatePlatformReportingRole creates a new migration
+func NewCreatePlatformReportingRole() *CreatePlatformReportingRole {
+	return &CreatePlatformReportingRole{
+		BaseMigration: BaseMigration{
+			version: 49,
+			name:    "create_platform_reporting_role",
+		},
+	}
+}
+
+// Up creates the platform_reporting role with SELECT on every table and a read-only policy on every
+// table with RLS, so reports read across tenants without the connection's owner privileges.
+// Tables that enable RLS later need their own platform_reporting_read policy to be reported on
+func (m *CreatePlatformReportingRole) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		DO $$
+		BEGIN
+			IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'platform_reporting') THEN
+				CREATE ROLE platform_reporting NOLOGIN;
+			END IF;
+		END
+		$$;
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create platform_reporting role: %w", err)
+	}
+
+	// The application switches to the role per statement, which requires membership
+	if err := db.Exec("GRANT platform_reporting TO CURRENT_USER").Error; err != nil {
+		return fmt.Errorf("failed to grant platform_reporting: %w", err)
+	}
+
+	if err := db.Exec("GRANT USAGE ON SCHEMA public TO platform_reporting").Error; err != nil {
+		return fmt.Errorf("failed to grant schema usage: %w", err)
+	}
+
+	if err := db.Exec("GRANT SELECT ON ALL TABLES IN SCHEMA public TO platform_reporting").Error; err != nil {
+		return fmt.Errorf("failed to grant table permissions: %w", err)
+	}
+
+	if err := db.Exec("ALTER DEFAULT PRIVILEGES IN SCHEMA public GRANT SELECT ON TABLES TO platform_reporting").Error; err != nil {
+		return fmt.Errorf("failed to grant default privileges: %w", err)
+	}
+
+	var tables []string
+	if err := db.Raw("SELECT tablename FROM pg_tables WHERE schemaname = 'public' AND rowsecurity ORDER BY tablename").
+		Scan(&tables).Error; err != nil {
+		return fmt.Errorf("failed to list RLS tables: %w", err)
+	}
+	for _, table := range tables {
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS platform_reporting_read ON %s", table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY platform_reporting_read ON %s FOR SELECT TO platform_reporting USING (true)",
+			table,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create reporting policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the reporting policies and the platform_reporting role
+func (m *CreatePlatformReportingRole) Down(db *gorm.DB) error {
+	var tables []string
+	if err := db.Raw(`
+		SELECT tablename FROM pg_policies
+		WHERE schemaname = 'public' AND policyname = 'platform_reporting_read'
+	`).Scan(&tables).Error; err != nil {
+		return fmt.Errorf("failed to list reporting policies: %w", err)
+	}
+	for _, table := range tables {
+		if err := db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS platform_reporting_read ON %s", table)).Error; err != nil {
+			return fmt.Errorf("failed to drop reporting policy for %s: %w", table, err)
+		}
+	}
+
+	if err := db.Exec(`
+		DO $$
+		BEGIN
+			IF EXISTS (SELECT FROM pg_roles WHERE rolname = 'platform_reporting') THEN
+				ALTER DEFAULT PRIVILEGES IN SCHEMA public REVOKE SELECT ON TABLES FROM platform_reporting;
+				REVOKE ALL ON ALL TABLES IN SCHEMA public FROM platform_reporting;
+				REVOKE USAGE ON SCHEMA public FROM platform_reporting;
+				DROP ROLE platform_reporting;
+			END IF;
+		END
+		$$;
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop platform_reporting role: %w", err)
+	}
+
+	return nil
+}
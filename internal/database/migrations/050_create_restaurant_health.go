@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateRestaurantHealth migration creates the restaurant health scores and tracks user logins
+type CreateRestaurantHealth struct {
+	BaseMigration
+}
+
+// NewCreateRestaurantHealth creates a new migration
+func NewCreateRestaurantHealth() *CreateRestaurantHealth {
+	return &CreateRestaurantHealth{
+		BaseMigration: BaseMigration{
+			version: 50,
+			name:    "create_restaurant_health",
+		},
+	}
+}
+
+// Up adds users.last_login_at and creates the restaurant_health table
+func (m *CreateRestaurantHealth) Up(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE users ADD COLUMN IF NOT EXISTS last_login_at TIMESTAMPTZ`).Error; err != nil {
+		return fmt.Errorf("failed to add users.last_login_at: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.RestaurantHealth{}); err != nil {
+		return fmt.Errorf("failed to migrate restaurant_health: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the restaurant_health table and users.last_login_at
+func (m *CreateRestaurantHealth) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS restaurant_health CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop restaurant_health table: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE users DROP COLUMN IF EXISTS last_login_at`).Error; err != nil {
+		return fmt.Errorf("failed to drop users.last_login_at: %w", err)
+	}
+
+	return nil
+}
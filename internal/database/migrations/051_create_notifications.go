@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateNotifications migration creates the in-app notifications table
+type CreateNotifications struct {
+	BaseMigration
+}
+
+// NewCreateNotifications creates a new migration
+func NewCreateNotifications() *CreateNotifications {
+	return &CreateNotifications{
+		BaseMigration: BaseMigration{
+			version: 51,
+			name:    "create_notifications",
+		},
+	}
+}
+
+// Up creates the notifications table with RLS
+func (m *CreateNotifications) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Notification{}); err != nil {
+		return fmt.Errorf("failed to migrate notifications: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE notifications ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on notifications: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_notifications ON notifications`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_notifications ON notifications FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for notifications: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the notifications table
+func (m *CreateNotifications) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS notifications CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop notifications table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateSMSMessages migration creates the SMS delivery log and the per-restaurant SMS opt-in settings
+type CreateSMSMessages struct {
+	BaseMigration
+}
+
+// NewCreateSMSMessages creates a new migration
+func NewCreateSMSMessages() *CreateSMSMessages {
+	return &CreateSMSMessages{
+		BaseMigration: BaseMigration{
+			version: 52,
+			name:    "create_sms_messages",
+		},
+	}
+}
+
+// Up creates the sms_messages table with RLS and adds the SMS opt-in columns to restaurant_settings
+// Every message type starts opted out
+func (m *CreateSMSMessages) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.SMSMessage{}); err != nil {
+		return fmt.Errorf("failed to migrate sms_messages: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE sms_messages ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on sms_messages: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_sms_messages ON sms_messages`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_sms_messages ON sms_messages FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for sms_messages: %w", err)
+	}
+
+	if err := db.Exec(`
+		ALTER TABLE restaurant_settings
+		ADD COLUMN IF NOT EXISTS sms_reservation_confirmation BOOLEAN NOT NULL DEFAULT false,
+		ADD COLUMN IF NOT EXISTS sms_reservation_reminder BOOLEAN NOT NULL DEFAULT false,
+		ADD COLUMN IF NOT EXISTS sms_order_ready BOOLEAN NOT NULL DEFAULT false
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add SMS settings columns: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the sms_messages table and the SMS opt-in columns
+func (m *CreateSMSMessages) Down(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE restaurant_settings
+		DROP COLUMN IF EXISTS sms_reservation_confirmation,
+		DROP COLUMN IF EXISTS sms_reservation_reminder,
+		DROP COLUMN IF EXISTS sms_order_ready
+	`).Error; err != nil {
+		return fmt.Errorf("failed to drop SMS settings columns: %w", err)
+	}
+
+	if err := db.Exec(`DROP TABLE IF EXISTS sms_messages CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop sms_messages table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreatePushSubscriptions migration creates the Web Push subscriptions table
+type CreatePushSubscriptions struct {
+	BaseMigration
+}
+
+// NewCreatePushSubscriptions creates a new migration
+func NewCreatePushSubscriptions() *CreatePushSubscriptions {
+	return &CreatePushSubscriptions{
+		BaseMigration: BaseMigration{
+			version: 53,
+			name:    "create_push_subscriptions",
+		},
+	}
+}
+
+// Up creates the push_subscriptions table with RLS
+func (m *CreatePushSubscriptions) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.PushSubscription{}); err != nil {
+		return fmt.Errorf("failed to migrate push_subscriptions: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE push_subscriptions ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on push_subscriptions: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_push_subscriptions ON push_subscriptions`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_push_subscriptions ON push_subscriptions FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for push_subscriptions: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the push_subscriptions table
+func (m *CreatePushSubscriptions) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS push_subscriptions CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop push_subscriptions table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateNotificationPreferences migration creates the customer notification preferences table
+type CreateNotificationPreferences struct {
+	BaseMigration
+}
+
+// NewCreateNotificationPreferences creates a new migration
+func NewCreateNotificationPreferences() *CreateNotificationPreferences {
+	return &CreateNotificationPreferences{
+		BaseMigration: BaseMigration{
+			version: 54,
+			name:    "create_notification_preferences",
+		},
+	}
+}
+
+// Up creates the notification_preferences table with RLS
+func (m *CreateNotificationPreferences) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.NotificationPreference{}); err != nil {
+		return fmt.Errorf("failed to migrate notification_preferences: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE notification_preferences ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on notification_preferences: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_notification_preferences ON notification_preferences`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_notification_preferences ON notification_preferences FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for notification_preferences: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the notification_preferences table
+func (m *CreateNotificationPreferences) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS notification_preferences CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop notification_preferences table: %w", err)
+	}
+	return nil
+}
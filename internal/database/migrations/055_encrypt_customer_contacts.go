@@ -0,0 +1,114 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// customerBackfillBatchSize is how many customers are encrypted per batch
+const customerBackfillBatchSize = 500
+
+// EncryptCustomerContacts migration encrypts the customers' email and phone and adds their lookup hashes
+type EncryptCustomerContacts struct {
+	BaseMigration
+}
+
+// NewEncryptCustomerContacts creates a new migration
+func NewEncryptCustomerContacts() *EncryptCustomerContacts {
+	return &EncryptCustomerContacts{
+		BaseMigration: BaseMigration{
+			version: 55,
+			name:    "encrypt_customer_contacts",
+		},
+	}
+}
+
+// Up widens the contact columns for ciphertext, adds the hash columns, encrypts existing rows
+// and moves the deduplication indexes onto the hashes
+func (m *EncryptCustomerContacts) Up(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE customers ALTER COLUMN email TYPE text, ALTER COLUMN phone TYPE text`).Error; err != nil {
+		return fmt.Errorf("failed to widen customer contact columns: %w", err)
+	}
+	if err := db.AutoMigrate(&models.Customer{}); err != nil {
+		return fmt.Errorf("failed to migrate Customer: %w", err)
+	}
+
+	// Rows are read through the PII serializer, which passes plaintext through, and written
+	// with explicit columns, which bypass it
+	var batch []models.Customer
+	if err := db.Where("(email <> '' AND email NOT LIKE 'enc:%') OR (phone <> '' AND phone NOT LIKE 'enc:%')").
+		FindInBatches(&batch, customerBackfillBatchSize, func(tx *gorm.DB, _ int) error {
+			for _, customer := range batch {
+				email, err := pii.Encrypt(customer.Email)
+				if err != nil {
+					return err
+				}
+				phone, err := pii.Encrypt(customer.Phone)
+				if err != nil {
+					return err
+				}
+				if err := tx.Model(&models.Customer{}).Where("id = ?", customer.ID).UpdateColumns(map[string]interface{}{
+					"email":      email,
+					"phone":      phone,
+					"email_hash": pii.HashEmail(customer.Email),
+					"phone_hash": pii.HashPhone(customer.Phone),
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error; err != nil {
+		return fmt.Errorf("failed to encrypt customer contacts: %w", err)
+	}
+
+	statements := []string{
+		`DROP INDEX IF EXISTS customers_restaurant_email_key`,
+		`DROP INDEX IF EXISTS customers_restaurant_phone_key`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS customers_restaurant_email_hash_key ON customers (restaurant_id, email_hash) WHERE email_hash <> ''`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS customers_restaurant_phone_hash_key ON customers (restaurant_id, phone_hash) WHERE phone_hash <> ''`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to update customers indexes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Down decrypts the contact columns and restores the plaintext deduplication indexes
+func (m *EncryptCustomerContacts) Down(db *gorm.DB) error {
+	var batch []models.Customer
+	if err := db.Where("email LIKE 'enc:%' OR phone LIKE 'enc:%'").
+		FindInBatches(&batch, customerBackfillBatchSize, func(tx *gorm.DB, _ int) error {
+			for _, customer := range batch {
+				if err := tx.Model(&models.Customer{}).Where("id = ?", customer.ID).UpdateColumns(map[string]interface{}{
+					"email": customer.Email,
+					"phone": customer.Phone,
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error; err != nil {
+		return fmt.Errorf("failed to decrypt customer contacts: %w", err)
+	}
+
+	statements := []string{
+		`DROP INDEX IF EXISTS customers_restaurant_email_hash_key`,
+		`DROP INDEX IF EXISTS customers_restaurant_phone_hash_key`,
+		`ALTER TABLE customers DROP COLUMN IF EXISTS email_hash, DROP COLUMN IF EXISTS phone_hash`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS customers_restaurant_email_key ON customers (restaurant_id, lower(email)) WHERE email <> ''`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS customers_restaurant_phone_key ON customers (restaurant_id, phone) WHERE phone <> ''`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to restore customers indexes: %w", err)
+		}
+	}
+	return nil
+}
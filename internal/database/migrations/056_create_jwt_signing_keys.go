@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateJWTSigningKeys migration creates the table of rotated JWT signing keys
+type CreateJWTSigningKeys struct {
+	BaseMigration
+}
+
+// NewCreateJWTSigningKeys creates a new migration
+func NewCreateJWTSigningKeys() *CreateJWTSigningKeys {
+	return &CreateJWTSigningKeys{
+		BaseMigration: BaseMigration{
+			version: 56,
+			name:    "create_jwt_signing_keys",
+		},
+	}
+}
+
+// Up creates the platform-wide jwt_signing_keys table
+func (m *CreateJWTSigningKeys) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.JWTSigningKey{}); err != nil {
+		return fmt.Errorf("failed to migrate jwt_signing_keys: %w", err)
+	}
+	return nil
+}
+
+// Down drops the jwt_signing_keys table; tokens signed with rotated keys stop validating
+func (m *CreateJWTSigningKeys) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS jwt_signing_keys CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop jwt_signing_keys table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateSSOConfigs migration creates the restaurant SSO (OIDC) configuration table
+type CreateSSOConfigs struct {
+	BaseMigration
+}
+
+// NewCreateSSOConfigs creates a new migration
+func NewCreateSSOConfigs() *CreateSSOConfigs {
+	return &CreateSSOConfigs{
+		BaseMigration: BaseMigration{
+			version: 57,
+			name:    "create_sso_configs",
+		},
+	}
+}
+
+// Up creates the sso_configs table with RLS
+func (m *CreateSSOConfigs) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.SSOConfig{}); err != nil {
+		return fmt.Errorf("failed to migrate sso_configs: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE sso_configs ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on sso_configs: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_sso_configs ON sso_configs`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_sso_configs ON sso_configs FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for sso_configs: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the sso_configs table
+func (m *CreateSSOConfigs) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS sso_configs CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop sso_configs table: %w", err)
+	}
+	return nil
+}
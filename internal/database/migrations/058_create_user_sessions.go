@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateUserSessions migration creates the table of user login sessions
+type CreateUserSessions struct {
+	BaseMigration
+}
+
+// NewCreateUserSessions creates a new migration
+func NewCreateUserSessions() *CreateUserSessions {
+	return &CreateUserSessions{
+		BaseMigration: BaseMigration{
+			version: 58,
+			name:    "create_user_sessions",
+		},
+	}
+}
+
+// Up creates the platform-wide user_sessions table
+func (m *CreateUserSessions) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.UserSession{}); err != nil {
+		return fmt.Errorf("failed to migrate user_sessions: %w", err)
+	}
+	return nil
+}
+
+// Down drops the user_sessions table; tokens of tracked sessions stop validating
+func (m *CreateUserSessions) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS user_sessions CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop user_sessions table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateUserPermissions migration creates the per-user permission overrides table
+type CreateUserPermissions struct {
+	BaseMigration
+}
+
+// NewCreateUserPermissions creates a new migration
+func NewCreateUserPermissions() *CreateUserPermissions {
+	return &CreateUserPermissions{
+		BaseMigration: BaseMigration{
+			version: 59,
+			name:    "create_user_permissions",
+		},
+	}
+}
+
+// Up creates the user_permissions table with RLS
+func (m *CreateUserPermissions) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.UserPermission{}); err != nil {
+		return fmt.Errorf("failed to migrate user_permissions: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE user_permissions ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on user_permissions: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_user_permissions ON user_permissions`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_user_permissions ON user_permissions FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for user_permissions: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the user_permissions table
+func (m *CreateUserPermissions) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS user_permissions CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop user_permissions table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateUserInvitations migration creates the user invitations table
+type CreateUserInvitations struct {
+	BaseMigration
+}
+
+// NewCreateUserInvitations creates a new migration
+func NewCreateUserInvitations() *CreateUserInvitations {
+	return &CreateUserInvitations{
+		BaseMigration: BaseMigration{
+			version: 60,
+			name:    "create_user_invitations",
+		},
+	}
+}
+
+// Up creates the user_invitations table with RLS
+func (m *CreateUserInvitations) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.UserInvitation{}); err != nil {
+		return fmt.Errorf("failed to migrate user_invitations: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE user_invitations ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on user_invitations: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec(`DROP POLICY IF EXISTS isolate_user_invitations ON user_invitations`)
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_user_invitations ON user_invitations FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for user_invitations: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the user_invitations table
+func (m *CreateUserInvitations) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS user_invitations CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop user_invitations table: %w", err)
+	}
+	return nil
+}
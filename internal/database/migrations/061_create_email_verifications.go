@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateEmailVerifications migration tracks unverified email addresses of self-registered users
+type CreateEmailVerifications struct {
+	BaseMigration
+}
+
+// NewCreateEmailVerifications creates a new migration
+func NewCreateEmailVerifications() *CreateEmailVerifications {
+	return &CreateEmailVerifications{
+		BaseMigration: BaseMigration{
+			version: 61,
+			name:    "create_email_verifications",
+		},
+	}
+}
+
+// Up adds users.email_verification_pending and creates the platform-wide email_verifications table
+// Existing users are treated as verified
+func (m *CreateEmailVerifications) Up(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verification_pending BOOLEAN NOT NULL DEFAULT false`).Error; err != nil {
+		return fmt.Errorf("failed to add users.email_verification_pending: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.EmailVerification{}); err != nil {
+		return fmt.Errorf("failed to migrate email_verifications: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the email_verifications table and users.email_verification_pending
+func (m *CreateEmailVerifications) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS email_verifications CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop email_verifications table: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE users DROP COLUMN IF EXISTS email_verification_pending`).Error; err != nil {
+		return fmt.Errorf("failed to drop users.email_verification_pending: %w", err)
+	}
+
+	return nil
+}
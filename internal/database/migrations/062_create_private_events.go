@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreatePrivateEvents migration creates the private event, package, table block and deposit tables
+type CreatePrivateEvents struct {
+	BaseMigration
+}
+
+// NewCreatePrivateEvents creates a new migration
+func NewCreatePrivateEvents() *CreatePrivateEvents {
+	return &CreatePrivateEvents{
+		BaseMigration: BaseMigration{
+			version: 62,
+			name:    "create_private_events",
+		},
+	}
+}
+
+// Up creates the private event tables with RLS
+func (m *CreatePrivateEvents) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(
+		&models.EventPackage{},
+		&models.PrivateEvent{},
+		&models.PrivateEventTable{},
+		&models.PrivateEventDeposit{},
+	); err != nil {
+		return fmt.Errorf("failed to migrate private events: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"event_packages", "private_events", "private_event_tables", "private_event_deposits"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the private event tables
+func (m *CreatePrivateEvents) Down(db *gorm.DB) error {
+	for _, table := range []string{"private_event_deposits", "private_event_tables", "private_events", "event_packages"} {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s CASCADE`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddReservationPacing migration adds the reservation pacing rules to the restaurant settings
+type AddReservationPacing struct {
+	BaseMigration
+}
+
+// NewAddReservationPacing creates a new migration
+func NewAddReservationPacing() *AddReservationPacing {
+	return &AddReservationPacing{
+		BaseMigration: BaseMigration{
+			version: 63,
+			name:    "add_reservation_pacing",
+		},
+	}
+}
+
+// reservationPacingColumns are the pacing columns with their definitions
+var reservationPacingColumns = []struct{ name, definition string }{
+	{"reservation_slot_minutes", "INTEGER NOT NULL DEFAULT 15"},
+	{"reservation_max_covers_per_slot", "INTEGER NOT NULL DEFAULT 0"},
+	{"reservation_max_party_size", "INTEGER NOT NULL DEFAULT 0"},
+	{"reservation_table_buffer_minutes", "INTEGER NOT NULL DEFAULT 0"},
+}
+
+// Up adds the pacing columns and an index for counting the covers of a slot
+func (m *AddReservationPacing) Up(db *gorm.DB) error {
+	for _, column := range reservationPacingColumns {
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE restaurant_settings ADD COLUMN IF NOT EXISTS %s %s", column.name, column.definition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to add %s column to restaurant_settings: %w", column.name, err)
+		}
+	}
+
+	if err := db.Exec(
+		`CREATE INDEX IF NOT EXISTS idx_reservations_restaurant_start_time ON reservations (restaurant_id, start_time) WHERE status <> 'cancelled'`,
+	).Error; err != nil {
+		return fmt.Errorf("failed to create reservation start time index: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the index and the pacing columns
+func (m *AddReservationPacing) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_reservations_restaurant_start_time`).Error; err != nil {
+		return fmt.Errorf("failed to drop reservation start time index: %w", err)
+	}
+	for _, column := range reservationPacingColumns {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE restaurant_settings DROP COLUMN IF EXISTS %s", column.name)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s column from restaurant_settings: %w", column.name, err)
+		}
+	}
+
+	return nil
+}
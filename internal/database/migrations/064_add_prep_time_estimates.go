@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AddPrepTimeEstimates migration adds the prep time estimates of orders, the learned prep times
+// of menu items and the hourly kitchen load factors
+type AddPrepTimeEstimates struct {
+	BaseMigration
+}
+
+// NewAddPrepTimeEstimates creates a new migration
+func NewAddPrepTimeEstimates() *AddPrepTimeEstimates {
+	return &AddPrepTimeEstimates{
+		BaseMigration: BaseMigration{
+			version: 64,
+			name:    "add_prep_time_estimates",
+		},
+	}
+}
+
+// prepTimeColumns are the added columns with their tables and definitions
+var prepTimeColumns = []struct{ table, name, definition string }{
+	{"orders", "estimated_prep_minutes", "INTEGER NOT NULL DEFAULT 0"},
+	{"orders", "estimated_ready_at", "TIMESTAMPTZ"},
+	{"orders", "prep_started_at", "TIMESTAMPTZ"},
+	{"menu_items", "avg_prep_seconds", "INTEGER NOT NULL DEFAULT 0"},
+	{"menu_items", "prep_samples", "INTEGER NOT NULL DEFAULT 0"},
+}
+
+// Up adds the estimate columns and creates the kitchen load factor table with RLS
+func (m *AddPrepTimeEstimates) Up(db *gorm.DB) error {
+	for _, column := range prepTimeColumns {
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", column.table, column.name, column.definition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to add %s column to %s: %w", column.name, column.table, err)
+		}
+	}
+
+	if err := db.AutoMigrate(&models.KitchenLoadFactor{}); err != nil {
+		return fmt.Errorf("failed to migrate kitchen load factors: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE kitchen_load_factors ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on kitchen_load_factors: %w", err)
+	}
+
+	db.Exec(`DROP POLICY IF EXISTS isolate_kitchen_load_factors ON kitchen_load_factors`)
+	if err := db.Exec(`
+		CREATE POLICY isolate_kitchen_load_factors ON kitchen_load_factors
+		FOR ALL
+		TO restaurant_app_user
+		USING (restaurant_id = current_setting('app.current_restaurant', true)::INTEGER)
+		WITH CHECK (restaurant_id = current_setting('app.current_restaurant', true)::INTEGER)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create policy for kitchen_load_factors: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the kitchen load factor table and the estimate columns
+func (m *AddPrepTimeEstimates) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS kitchen_load_factors CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop kitchen_load_factors table: %w", err)
+	}
+	for _, column := range prepTimeColumns {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s", column.table, column.name)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s column from %s: %w", column.name, column.table, err)
+		}
+	}
+
+	return nil
+}
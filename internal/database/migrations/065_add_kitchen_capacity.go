@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddKitchenCapacity migration adds the kitchen capacity settings and the throttle delay of orders
+type AddKitchenCapacity struct {
+	BaseMigration
+}
+
+// NewAddKitchenCapacity creates a new migration
+func NewAddKitchenCapacity() *AddKitchenCapacity {
+	return &AddKitchenCapacity{
+		BaseMigration: BaseMigration{
+			version: 65,
+			name:    "add_kitchen_capacity",
+		},
+	}
+}
+
+// kitchenCapacityColumns are the added columns with their tables and definitions
+var kitchenCapacityColumns = []struct{ table, name, definition string }{
+	{"restaurant_settings", "kitchen_max_open_orders", "INTEGER NOT NULL DEFAULT 0"},
+	{"restaurant_settings", "kitchen_max_items_in_flight", "INTEGER NOT NULL DEFAULT 0"},
+	{"restaurant_settings", "kitchen_overload_action", "VARCHAR(10) NOT NULL DEFAULT 'delay'"},
+	{"restaurant_settings", "kitchen_overload_delay_minutes", "INTEGER NOT NULL DEFAULT 15"},
+	{"orders", "throttle_delay_minutes", "INTEGER NOT NULL DEFAULT 0"},
+}
+
+// Up adds the capacity columns
+func (m *AddKitchenCapacity) Up(db *gorm.DB) error {
+	for _, column := range kitchenCapacityColumns {
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ADD COLUMN IF NOT EXISTS %s %s", column.table, column.name, column.definition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to add %s column to %s: %w", column.name, column.table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the capacity columns
+func (m *AddKitchenCapacity) Down(db *gorm.DB) error {
+	for _, column := range kitchenCapacityColumns {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s DROP COLUMN IF EXISTS %s", column.table, column.name)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s column from %s: %w", column.name, column.table, err)
+		}
+	}
+
+	return nil
+}
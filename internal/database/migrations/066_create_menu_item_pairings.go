@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateMenuItemPairings migration creates the menu item pairings table
+type CreateMenuItemPairings struct {
+	BaseMigration
+}
+
+// NewCreateMenuItemPairings creates a new migration
+func NewCreateMenuItemPairings() *CreateMenuItemPairings {
+	return &CreateMenuItemPairings{
+		BaseMigration: BaseMigration{
+			version: 66,
+			name:    "create_menu_item_pairings",
+		},
+	}
+}
+
+// Up creates the menu_item_pairings table with RLS
+func (m *CreateMenuItemPairings) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.MenuItemPairing{}); err != nil {
+		return fmt.Errorf("failed to migrate menu item pairings: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE menu_item_pairings ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on menu_item_pairings: %w", err)
+	}
+
+	db.Exec(`DROP POLICY IF EXISTS isolate_menu_item_pairings ON menu_item_pairings`)
+	if err := db.Exec(`
+		CREATE POLICY isolate_menu_item_pairings ON menu_item_pairings
+		FOR ALL
+		TO restaurant_app_user
+		USING (restaurant_id = current_setting('app.current_restaurant', true)::INTEGER)
+		WITH CHECK (restaurant_id = current_setting('app.current_restaurant', true)::INTEGER)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create policy for menu_item_pairings: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the menu_item_pairings table
+func (m *CreateMenuItemPairings) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS menu_item_pairings CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop menu_item_pairings table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateCarts migration creates the carts table and the cart recovery setting
+type CreateCarts struct {
+	BaseMigration
+}
+
+// NewCreateCarts creates a new migration
+func NewCreateCarts() *CreateCarts {
+	return &CreateCarts{
+		BaseMigration: BaseMigration{
+			version: 67,
+			name:    "create_carts",
+		},
+	}
+}
+
+// Up creates the carts table with RLS and adds the recovery email setting
+func (m *CreateCarts) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.Cart{}); err != nil {
+		return fmt.Errorf("failed to migrate carts: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE carts ENABLE ROW LEVEL SECURITY`).Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on carts: %w", err)
+	}
+
+	db.Exec(`DROP POLICY IF EXISTS isolate_carts ON carts`)
+	if err := db.Exec(`
+		CREATE POLICY isolate_carts ON carts
+		FOR ALL
+		TO restaurant_app_user
+		USING (restaurant_id = current_setting('app.current_restaurant', true)::INTEGER)
+		WITH CHECK (restaurant_id = current_setting('app.current_restaurant', true)::INTEGER)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create policy for carts: %w", err)
+	}
+
+	if err := db.Exec(
+		`ALTER TABLE restaurant_settings ADD COLUMN IF NOT EXISTS cart_recovery_email BOOLEAN NOT NULL DEFAULT FALSE`,
+	).Error; err != nil {
+		return fmt.Errorf("failed to add cart_recovery_email column to restaurant_settings: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the carts table and the recovery email setting
+func (m *CreateCarts) Down(db *gorm.DB) error {
+	if err := db.Exec(`ALTER TABLE restaurant_settings DROP COLUMN IF EXISTS cart_recovery_email`).Error; err != nil {
+		return fmt.Errorf("failed to drop cart_recovery_email column from restaurant_settings: %w", err)
+	}
+	if err := db.Exec(`DROP TABLE IF EXISTS carts CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop carts table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddRestaurantProfiles migration adds the public profile settings searched by the restaurant directory
+type AddRestaurantProfiles struct {
+	BaseMigration
+}
+
+// NewAddRestaurantProfiles creates a new migration
+func NewAddRestaurantProfiles() *AddRestaurantProfiles {
+	return &AddRestaurantProfiles{
+		BaseMigration: BaseMigration{
+			version: 68,
+			name:    "add_restaurant_profiles",
+		},
+	}
+}
+
+// restaurantProfileColumns are the added restaurant_settings columns with their definitions
+var restaurantProfileColumns = []struct{ name, definition string }{
+	{"city", "VARCHAR(100)"},
+	{"cuisines", "JSONB NOT NULL DEFAULT '[]'"},
+	{"latitude", "DOUBLE PRECISION"},
+	{"longitude", "DOUBLE PRECISION"},
+	{"photo_urls", "JSONB NOT NULL DEFAULT '[]'"},
+}
+
+// Up adds the profile columns and indexes the directory filters
+func (m *AddRestaurantProfiles) Up(db *gorm.DB) error {
+	for _, column := range restaurantProfileColumns {
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE restaurant_settings ADD COLUMN IF NOT EXISTS %s %s", column.name, column.definition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to add %s column to restaurant_settings: %w", column.name, err)
+		}
+	}
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_restaurant_settings_city ON restaurant_settings (LOWER(city))").Error; err != nil {
+		return fmt.Errorf("failed to create restaurant_settings city index: %w", err)
+	}
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_restaurant_settings_cuisines ON restaurant_settings USING GIN (cuisines)").Error; err != nil {
+		return fmt.Errorf("failed to create restaurant_settings cuisines index: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the profile columns and their indexes
+func (m *AddRestaurantProfiles) Down(db *gorm.DB) error {
+	for _, column := range restaurantProfileColumns {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE restaurant_settings DROP COLUMN IF EXISTS %s", column.name)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s column from restaurant_settings: %w", column.name, err)
+		}
+	}
+
+	return nil
+}
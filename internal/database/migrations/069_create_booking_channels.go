@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateBookingChannels migration creates the booking_channels table and reservation source columns
+type CreateBookingChannels struct {
+	BaseMigration
+}
+
+// NewCreateBookingChannels creates a new migration
+func NewCreateBookingChannels() *CreateBookingChannels {
+	return &CreateBookingChannels{
+		BaseMigration: BaseMigration{
+			version: 69,
+			name:    "create_booking_channels",
+		},
+	}
+}
+
+// Up creates the booking_channels table with RLS and adds source columns to reservations
+func (m *CreateBookingChannels) Up(db *gorm.DB) error {
+	if err := db.Exec(`
+		ALTER TABLE reservations
+			ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'direct',
+			ADD COLUMN IF NOT EXISTS external_id VARCHAR(100)
+	`).Error; err != nil {
+		return fmt.Errorf("failed to add reservation source columns: %w", err)
+	}
+
+	// A channel booking is written at most once
+	if err := db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS reservations_external_id_key ON reservations (restaurant_id, source, external_id)
+		WHERE external_id IS NOT NULL
+	`).Error; err != nil {
+		return fmt.Errorf("failed to create reservations external_id index: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.BookingChannel{}); err != nil {
+		return fmt.Errorf("failed to migrate BookingChannel: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE booking_channels ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on booking_channels: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_booking_channels ON booking_channels")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_booking_channels ON booking_channels FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for booking_channels: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the booking_channels table and reservation source columns
+func (m *CreateBookingChannels) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS booking_channels CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop booking_channels table: %w", err)
+	}
+
+	if err := db.Exec(`DROP INDEX IF EXISTS reservations_external_id_key`).Error; err != nil {
+		return fmt.Errorf("failed to drop reservations external_id index: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE reservations DROP COLUMN IF EXISTS source, DROP COLUMN IF EXISTS external_id`).Error; err != nil {
+		return fmt.Errorf("failed to drop reservation source columns: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateInventory migration creates the supplier, ingredient, recipe and purchase order tables
+type CreateInventory struct {
+	BaseMigration
+}
+
+// NewCreateInventory creates a new migration
+func NewCreateInventory() *CreateInventory {
+	return &CreateInventory{
+		BaseMigration: BaseMigration{
+			version: 70,
+			name:    "create_inventory",
+		},
+	}
+}
+
+// Up creates the inventory tables with RLS
+func (m *CreateInventory) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(
+		&models.Supplier{},
+		&models.Ingredient{},
+		&models.MenuItemIngredient{},
+		&models.PurchaseOrder{},
+		&models.PurchaseOrderLine{},
+	); err != nil {
+		return fmt.Errorf("failed to migrate inventory: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"suppliers", "ingredients", "menu_item_ingredients", "purchase_orders", "purchase_order_lines"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the inventory tables
+func (m *CreateInventory) Down(db *gorm.DB) error {
+	for _, table := range []string{"purchase_order_lines", "purchase_orders", "menu_item_ingredients", "ingredients", "suppliers"} {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s CASCADE`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	return nil
+}
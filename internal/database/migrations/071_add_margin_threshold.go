@@ -0,0 +1,42 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddMarginThreshold migration adds the margin below which the margin report flags menu items
+type AddMarginThreshold struct {
+	BaseMigration
+}
+
+// NewAddMarginThreshold creates a new migration
+func NewAddMarginThreshold() *AddMarginThreshold {
+	return &AddMarginThreshold{
+		BaseMigration: BaseMigration{
+			version: 71,
+			name:    "add_margin_threshold",
+		},
+	}
+}
+
+// Up adds the threshold column
+func (m *AddMarginThreshold) Up(db *gorm.DB) error {
+	if err := db.Exec(
+		"ALTER TABLE restaurant_settings ADD COLUMN IF NOT EXISTS min_margin_percent NUMERIC(5,2) NOT NULL DEFAULT 65",
+	).Error; err != nil {
+		return fmt.Errorf("failed to add min_margin_percent column to restaurant_settings: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the threshold column
+func (m *AddMarginThreshold) Down(db *gorm.DB) error {
+	if err := db.Exec("ALTER TABLE restaurant_settings DROP COLUMN IF EXISTS min_margin_percent").Error; err != nil {
+		return fmt.Errorf("failed to drop min_margin_percent column from restaurant_settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateWasteEntries migration creates the waste log tables
+type CreateWasteEntries struct {
+	BaseMigration
+}
+
+// NewCreateWasteEntries creates a new migration
+func NewCreateWasteEntries() *CreateWasteEntries {
+	return &CreateWasteEntries{
+		BaseMigration: BaseMigration{
+			version: 72,
+			name:    "create_waste_entries",
+		},
+	}
+}
+
+// Up creates the waste log tables with RLS
+func (m *CreateWasteEntries) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.WasteEntry{}, &models.WasteEntryLine{}); err != nil {
+		return fmt.Errorf("failed to migrate waste entries: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"waste_entries", "waste_entry_lines"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the waste log tables
+func (m *CreateWasteEntries) Down(db *gorm.DB) error {
+	for _, table := range []string{"waste_entry_lines", "waste_entries"} {
+		if err := db.Exec(fmt.Sprintf(`DROP TABLE IF EXISTS %s CASCADE`, table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	return nil
+}
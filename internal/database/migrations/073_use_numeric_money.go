@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// UseNumericMoney migration stores money amounts as NUMERIC(12,2), rounding existing amounts to cents,
+// so stored totals can't carry the binary fractions of float arithmetic
+type UseNumericMoney struct {
+	BaseMigration
+}
+
+// NewUseNumericMoney creates a new migration
+func NewUseNumericMoney() *UseNumericMoney {
+	return &UseNumericMoney{
+		BaseMigration: BaseMigration{
+			version: 73,
+			name:    "use_numeric_money",
+		},
+	}
+}
+
+// moneyColumns are the amount columns that were unconstrained decimals
+var moneyColumns = []struct{ table, name string }{
+	{"menu_items", "price"},
+	{"menu_items", "dine_in_price"},
+	{"menu_items", "pickup_price"},
+	{"menu_items", "delivery_price"},
+	{"menu_items", "third_party_price"},
+	{"orders", "total_amount"},
+	{"orders", "delivery_fee"},
+	{"order_items", "price"},
+	{"delivery_zones", "fee"},
+	{"delivery_zones", "minimum_order"},
+	{"customers", "total_spent"},
+}
+
+// Up converts the amount columns, rounding half away from zero like the application
+func (m *UseNumericMoney) Up(db *gorm.DB) error {
+	for _, column := range moneyColumns {
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ALTER COLUMN %s TYPE NUMERIC(12,2) USING ROUND(%s::NUMERIC, 2)", column.table, column.name, column.name,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to convert %s.%s to NUMERIC(12,2): %w", column.table, column.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Down restores the unconstrained decimal columns
+func (m *UseNumericMoney) Down(db *gorm.DB) error {
+	for _, column := range moneyColumns {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE DECIMAL", column.table, column.name)).Error; err != nil {
+			return fmt.Errorf("failed to convert %s.%s back to DECIMAL: %w", column.table, column.name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateMenuItemPriceChanges migration creates the menu item price history table
+type CreateMenuItemPriceChanges struct {
+	BaseMigration
+}
+
+// NewCreateMenuItemPriceChanges creates a new migration
+func NewCreateMenuItemPriceChanges() *CreateMenuItemPriceChanges {
+	return &CreateMenuItemPriceChanges{
+		BaseMigration: BaseMigration{
+			version: 74,
+			name:    "create_menu_item_price_changes",
+		},
+	}
+}
+
+// Up creates the menu_item_price_changes table with RLS
+func (m *CreateMenuItemPriceChanges) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.MenuItemPriceChange{}); err != nil {
+		return fmt.Errorf("failed to migrate menu item price changes: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE menu_item_price_changes ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on menu_item_price_changes: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_menu_item_price_changes ON menu_item_price_changes")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_menu_item_price_changes ON menu_item_price_changes FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for menu_item_price_changes: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the menu_item_price_changes table
+func (m *CreateMenuItemPriceChanges) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS menu_item_price_changes CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop menu_item_price_changes table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AddMenuPublishing migration adds draft states to categories and menu items and the menu publish history
+type AddMenuPublishing struct {
+	BaseMigration
+}
+
+// NewAddMenuPublishing creates a new migration
+func NewAddMenuPublishing() *AddMenuPublishing {
+	return &AddMenuPublishing{
+		BaseMigration: BaseMigration{
+			version: 75,
+			name:    "add_menu_publishing",
+		},
+	}
+}
+
+// Up adds the draft columns (existing menus are published), the setting and the menu_publications table with RLS
+func (m *AddMenuPublishing) Up(db *gorm.DB) error {
+	for _, table := range []string{"menu_categories", "menu_items"} {
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ADD COLUMN IF NOT EXISTS publish_status VARCHAR(20) NOT NULL DEFAULT 'published', ADD COLUMN IF NOT EXISTS draft_changes JSONB",
+			table,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to add draft columns to %s: %w", table, err)
+		}
+	}
+
+	if err := db.Exec(
+		"ALTER TABLE restaurant_settings ADD COLUMN IF NOT EXISTS menu_drafts_enabled BOOLEAN NOT NULL DEFAULT false",
+	).Error; err != nil {
+		return fmt.Errorf("failed to add menu_drafts_enabled column to restaurant_settings: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.MenuPublication{}); err != nil {
+		return fmt.Errorf("failed to migrate menu publications: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE menu_publications ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on menu_publications: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_menu_publications ON menu_publications")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_menu_publications ON menu_publications FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for menu_publications: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the menu_publications table and the draft columns
+func (m *AddMenuPublishing) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS menu_publications CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop menu_publications table: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE restaurant_settings DROP COLUMN IF EXISTS menu_drafts_enabled").Error; err != nil {
+		return fmt.Errorf("failed to drop menu_drafts_enabled column from restaurant_settings: %w", err)
+	}
+
+	for _, table := range []string{"menu_categories", "menu_items"} {
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s DROP COLUMN IF EXISTS publish_status, DROP COLUMN IF EXISTS draft_changes",
+			table,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to drop draft columns from %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
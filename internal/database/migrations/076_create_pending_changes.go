@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreatePendingChanges migration creates the approval queue of Staff changes and its settings
+type CreatePendingChanges struct {
+	BaseMigration
+}
+
+// NewCreatePendingChanges creates a new migration
+func NewCreatePendingChanges() *CreatePendingChanges {
+	return &CreatePendingChanges{
+		BaseMigration: BaseMigration{
+			version: 76,
+			name:    "create_pending_changes",
+		},
+	}
+}
+
+// Up adds the require_approval_* settings (off) and the pending_changes table with RLS
+func (m *CreatePendingChanges) Up(db *gorm.DB) error {
+	if err := db.Exec(
+		`ALTER TABLE restaurant_settings
+			ADD COLUMN IF NOT EXISTS require_approval_price_changes BOOLEAN NOT NULL DEFAULT false,
+			ADD COLUMN IF NOT EXISTS require_approval_deletions BOOLEAN NOT NULL DEFAULT false,
+			ADD COLUMN IF NOT EXISTS require_approval_cancellations BOOLEAN NOT NULL DEFAULT false`,
+	).Error; err != nil {
+		return fmt.Errorf("failed to add approval columns to restaurant_settings: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.PendingChange{}); err != nil {
+		return fmt.Errorf("failed to migrate pending changes: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE pending_changes ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on pending_changes: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_pending_changes ON pending_changes")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_pending_changes ON pending_changes FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for pending_changes: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the pending_changes table and the approval settings
+func (m *CreatePendingChanges) Down(db *gorm.DB) error {
+	if err := db.Exec(`DROP TABLE IF EXISTS pending_changes CASCADE`).Error; err != nil {
+		return fmt.Errorf("failed to drop pending_changes table: %w", err)
+	}
+
+	if err := db.Exec(
+		`ALTER TABLE restaurant_settings
+			DROP COLUMN IF EXISTS require_approval_price_changes,
+			DROP COLUMN IF EXISTS require_approval_deletions,
+			DROP COLUMN IF EXISTS require_approval_cancellations`,
+	).Error; err != nil {
+		return fmt.Errorf("failed to drop approval columns from restaurant_settings: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// AddOrderIndexes migration adds the composite indexes order listings, dashboards and the kitchen queue filter by
+type AddOrderIndexes struct {
+	BaseMigration
+}
+
+// NewAddOrderIndexes creates a new migration
+func NewAddOrderIndexes() *AddOrderIndexes {
+	return &AddOrderIndexes{
+		BaseMigration: BaseMigration{
+			version: 77,
+			name:    "add_order_indexes",
+		},
+	}
+}
+
+// orderIndexes are created concurrently, so writes to the (large) order tables aren't blocked while they build
+var orderIndexes = map[string]string{
+	"idx_orders_restaurant_created_at":      "orders (restaurant_id, created_at)",
+	"idx_orders_restaurant_status":          "orders (restaurant_id, status)",
+	"idx_orders_restaurant_open":            "orders (restaurant_id, created_at) WHERE status IN ('pending', 'confirmed', 'preparing', 'ready')",
+	"idx_order_items_restaurant_created_at": "order_items (restaurant_id, created_at)",
+}
+
+// Up creates the order indexes
+func (m *AddOrderIndexes) Up(db *gorm.DB) error {
+	for name, definition := range orderIndexes {
+		if err := db.Exec(fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s", name, definition)).Error; err != nil {
+			return fmt.Errorf("failed to create index %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the order indexes
+func (m *AddOrderIndexes) Down(db *gorm.DB) error {
+	for name := range orderIndexes {
+		if err := db.Exec(fmt.Sprintf("DROP INDEX IF EXISTS %s", name)).Error; err != nil {
+			return fmt.Errorf("failed to drop index %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
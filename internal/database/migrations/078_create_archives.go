@@ -0,0 +1,74 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateArchives migration creates the archive tables of old orders and reservations and the retention setting
+type CreateArchives struct {
+	BaseMigration
+}
+
+// NewCreateArchives creates a new migration
+func NewCreateArchives() *CreateArchives {
+	return &CreateArchives{
+		BaseMigration: BaseMigration{
+			version: 78,
+			name:    "create_archives",
+		},
+	}
+}
+
+// Up adds the archive_after_days setting (0, the platform default) and the archived_orders and
+// archived_reservations tables with RLS
+func (m *CreateArchives) Up(db *gorm.DB) error {
+	if err := db.Exec(
+		"ALTER TABLE restaurant_settings ADD COLUMN IF NOT EXISTS archive_after_days INTEGER NOT NULL DEFAULT 0",
+	).Error; err != nil {
+		return fmt.Errorf("failed to add archive_after_days to restaurant_settings: %w", err)
+	}
+
+	if err := db.AutoMigrate(&models.ArchivedOrder{}, &models.ArchivedReservation{}); err != nil {
+		return fmt.Errorf("failed to migrate archive tables: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	for _, table := range []string{"archived_orders", "archived_reservations"} {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+		}
+
+		db.Exec(fmt.Sprintf("DROP POLICY IF EXISTS isolate_%s ON %s", table, table))
+		if err := db.Exec(fmt.Sprintf(
+			"CREATE POLICY isolate_%s ON %s FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+			table,
+			table,
+			condition,
+			condition,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create policy for %s: %w", table, err)
+		}
+	}
+
+	return nil
+}
+
+// Down drops the archive tables and the retention setting
+// Archived rows are not moved back
+func (m *CreateArchives) Down(db *gorm.DB) error {
+	for _, table := range []string{"archived_reservations", "archived_orders"} {
+		if err := db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", table)).Error; err != nil {
+			return fmt.Errorf("failed to drop %s table: %w", table, err)
+		}
+	}
+
+	if err := db.Exec("ALTER TABLE restaurant_settings DROP COLUMN IF EXISTS archive_after_days").Error; err != nil {
+		return fmt.Errorf("failed to drop archive_after_days from restaurant_settings: %w", err)
+	}
+
+	return nil
+}
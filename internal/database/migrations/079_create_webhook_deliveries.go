@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CreateWebhookDeliveries migration creates the persistent queue of webhook deliveries
+type CreateWebhookDeliveries struct {
+	BaseMigration
+}
+
+// NewCreateWebhookDeliveries creates a new migration
+func NewCreateWebhookDeliveries() *CreateWebhookDeliveries {
+	return &CreateWebhookDeliveries{
+		BaseMigration: BaseMigration{
+			version: 79,
+			name:    "create_webhook_deliveries",
+		},
+	}
+}
+
+// Up creates the webhook_deliveries table with RLS
+func (m *CreateWebhookDeliveries) Up(db *gorm.DB) error {
+	if err := db.AutoMigrate(&models.WebhookDelivery{}); err != nil {
+		return fmt.Errorf("failed to migrate webhook deliveries: %w", err)
+	}
+
+	if err := db.Exec("ALTER TABLE webhook_deliveries ENABLE ROW LEVEL SECURITY").Error; err != nil {
+		return fmt.Errorf("failed to enable RLS on webhook_deliveries: %w", err)
+	}
+
+	condition := "restaurant_id = current_setting('app.current_restaurant', true)::INTEGER"
+	db.Exec("DROP POLICY IF EXISTS isolate_webhook_deliveries ON webhook_deliveries")
+	if err := db.Exec(fmt.Sprintf(
+		"CREATE POLICY isolate_webhook_deliveries ON webhook_deliveries FOR ALL TO restaurant_app_user USING (%s) WITH CHECK (%s)",
+		condition,
+		condition,
+	)).Error; err != nil {
+		return fmt.Errorf("failed to create policy for webhook_deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// Down drops the webhook_deliveries table
+func (m *CreateWebhookDeliveries) Down(db *gorm.DB) error {
+	if err := db.Exec("DROP TABLE IF EXISTS webhook_deliveries CASCADE").Error; err != nil {
+		return fmt.Errorf("failed to drop webhook_deliveries table: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,387 @@
+package migrations
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// contactBackfillBatchSize is how many rows are encrypted per batch
+const contactBackfillBatchSize = 500
+
+// encryptedContactColumn is a plaintext contact column encrypted by EncryptGuestContacts, with its lookup hash
+type encryptedContactColumn struct {
+	table      string
+	column     string
+	hashColumn string
+	hash       func(string) string
+}
+
+// guestContactColumns are the phone numbers of users, drivers and text messages, encrypted like the customers'
+var guestContactColumns = []encryptedContactColumn{
+	{table: "users", column: "phone", hashColumn: "phone_hash", hash: pii.HashPhone},
+	{table: "drivers", column: "phone", hashColumn: "phone_hash", hash: pii.HashPhone},
+	{table: "sms_messages", column: "to", hashColumn: "to_hash", hash: pii.HashPhone},
+}
+
+// EncryptGuestContacts migration encrypts the guest phone numbers kept outside the customers table and moves the
+// contact details of booking channel guests out of the reservation notes into encrypted columns
+type EncryptGuestContacts struct {
+	BaseMigration
+}
+
+// NewEncryptGuestContacts creates a new migration
+func NewEncryptGuestContacts() *EncryptGuestContacts {
+	return &EncryptGuestContacts{
+		BaseMigration: BaseMigration{
+			version: 80,
+			name:    "encrypt_guest_contacts",
+		},
+	}
+}
+
+// Up widens the phone columns for ciphertext, adds the hash and guest contact columns and encrypts existing rows
+func (m *EncryptGuestContacts) Up(db *gorm.DB) error {
+	for _, c := range guestContactColumns {
+		if err := db.Exec(fmt.Sprintf(
+			`ALTER TABLE %s ALTER COLUMN %q TYPE text, ADD COLUMN IF NOT EXISTS %s varchar(64)`,
+			c.table, c.column, c.hashColumn,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to add %s.%s: %w", c.table, c.hashColumn, err)
+		}
+		if err := db.Exec(fmt.Sprintf(
+			`CREATE INDEX IF NOT EXISTS idx_%s_%s ON %s (%s)`, c.table, c.hashColumn, c.table, c.hashColumn,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to index %s.%s: %w", c.table, c.hashColumn, err)
+		}
+		if err := encryptContactColumn(db, c); err != nil {
+			return fmt.Errorf("failed to encrypt %s.%s: %w", c.table, c.column, err)
+		}
+	}
+
+	statements := []string{
+		`ALTER TABLE reservations
+			ADD COLUMN IF NOT EXISTS guest_email text,
+			ADD COLUMN IF NOT EXISTS guest_phone text,
+			ADD COLUMN IF NOT EXISTS guest_email_hash varchar(64),
+			ADD COLUMN IF NOT EXISTS guest_phone_hash varchar(64)`,
+		`CREATE INDEX IF NOT EXISTS idx_reservations_guest_email_hash ON reservations (guest_email_hash)`,
+		`CREATE INDEX IF NOT EXISTS idx_reservations_guest_phone_hash ON reservations (guest_phone_hash)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to add reservation guest contact columns: %w", err)
+		}
+	}
+
+	if err := moveChannelGuestContacts(db); err != nil {
+		return fmt.Errorf("failed to move channel guest contacts: %w", err)
+	}
+	if err := moveArchivedChannelGuestContacts(db); err != nil {
+		return fmt.Errorf("failed to move archived channel guest contacts: %w", err)
+	}
+	return nil
+}
+
+// Down decrypts the phone columns, puts the channel guests' contact details back in the notes and drops the new columns
+func (m *EncryptGuestContacts) Down(db *gorm.DB) error {
+	for _, c := range guestContactColumns {
+		if err := decryptContactColumn(db, c); err != nil {
+			return fmt.Errorf("failed to decrypt %s.%s: %w", c.table, c.column, err)
+		}
+		if err := db.Exec(fmt.Sprintf(
+			`ALTER TABLE %s DROP COLUMN IF EXISTS %s, ALTER COLUMN %q TYPE varchar(20)`, c.table, c.hashColumn, c.column,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to restore %s.%s: %w", c.table, c.column, err)
+		}
+	}
+
+	if err := restoreChannelGuestNotes(db); err != nil {
+		return fmt.Errorf("failed to restore channel guest notes: %w", err)
+	}
+	if err := db.Exec(`ALTER TABLE reservations
+		DROP COLUMN IF EXISTS guest_email,
+		DROP COLUMN IF EXISTS guest_phone,
+		DROP COLUMN IF EXISTS guest_email_hash,
+		DROP COLUMN IF EXISTS guest_phone_hash`).Error; err != nil {
+		return fmt.Errorf("failed to drop reservation guest contact columns: %w", err)
+	}
+	return nil
+}
+
+// contactRow is a row's ID with one of its contact values
+type contactRow struct {
+	ID    uint
+	Value string
+}
+
+// encryptContactColumn encrypts the plaintext values of a column and fills in their lookup hashes
+// Values are read and written with explicit columns, which bypass the PII serializer
+func encryptContactColumn(db *gorm.DB, c encryptedContactColumn) error {
+	var lastID uint
+	for {
+		var rows []contactRow
+		if err := db.Raw(fmt.Sprintf(
+			`SELECT id, %[1]q AS value FROM %[2]s WHERE id > ? AND %[1]q <> '' AND %[1]q NOT LIKE 'enc:%%' ORDER BY id LIMIT ?`,
+			c.column, c.table,
+		), lastID, contactBackfillBatchSize).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			ciphertext, err := pii.Encrypt(row.Value)
+			if err != nil {
+				return err
+			}
+			if err := db.Exec(fmt.Sprintf(`UPDATE %s SET %q = ?, %s = ? WHERE id = ?`, c.table, c.column, c.hashColumn),
+				ciphertext, c.hash(row.Value), row.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < contactBackfillBatchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+// decryptContactColumn writes the encrypted values of a column back as plaintext
+func decryptContactColumn(db *gorm.DB, c encryptedContactColumn) error {
+	var lastID uint
+	for {
+		var rows []contactRow
+		if err := db.Raw(fmt.Sprintf(
+			`SELECT id, %[1]q AS value FROM %[2]s WHERE id > ? AND %[1]q LIKE 'enc:%%' ORDER BY id LIMIT ?`,
+			c.column, c.table,
+		), lastID, contactBackfillBatchSize).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			plaintext, err := pii.Decrypt(row.Value)
+			if err != nil {
+				return err
+			}
+			if err := db.Exec(fmt.Sprintf(`UPDATE %s SET %q = ? WHERE id = ?`, c.table, c.column),
+				plaintext, row.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < contactBackfillBatchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+// splitChannelGuestNotes takes the "Phone: " and "Email: " lines booking channel reservations had at the top of
+// their notes (after the "Guest: " line) out of the notes
+func splitChannelGuestNotes(notes string) (rest, email, phone string) {
+	lines := strings.Split(notes, "\n")
+	kept := make([]string, 0, len(lines))
+	header := true
+	for _, line := range lines {
+		switch {
+		case header && strings.HasPrefix(line, "Guest: "):
+			kept = append(kept, line)
+		case header && strings.HasPrefix(line, "Phone: "):
+			phone = strings.TrimPrefix(line, "Phone: ")
+		case header && strings.HasPrefix(line, "Email: "):
+			email = strings.TrimPrefix(line, "Email: ")
+		default:
+			header = false
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n"), email, phone
+}
+
+// channelNoteRow is a booking channel reservation's notes
+type channelNoteRow struct {
+	ID    uint
+	Notes string
+}
+
+// moveChannelGuestContacts moves the contact details of booking channel guests from the notes of their
+// reservations into the encrypted guest columns
+func moveChannelGuestContacts(db *gorm.DB) error {
+	var lastID uint
+	for {
+		var rows []channelNoteRow
+		if err := db.Raw(`
+			SELECT id, notes FROM reservations
+			WHERE id > ? AND source <> 'direct' AND (notes LIKE '%Phone: %' OR notes LIKE '%Email: %')
+			ORDER BY id LIMIT ?
+		`, lastID, contactBackfillBatchSize).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			notes, email, phone := splitChannelGuestNotes(row.Notes)
+			if email == "" && phone == "" {
+				continue
+			}
+			encryptedEmail, err := pii.Encrypt(email)
+			if err != nil {
+				return err
+			}
+			encryptedPhone, err := pii.Encrypt(phone)
+			if err != nil {
+				return err
+			}
+			if err := db.Exec(`
+				UPDATE reservations SET notes = ?, guest_email = ?, guest_phone = ?, guest_email_hash = ?, guest_phone_hash = ?
+				WHERE id = ?
+			`, notes, encryptedEmail, encryptedPhone, pii.HashEmail(email), pii.HashPhone(phone), row.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < contactBackfillBatchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+// moveArchivedChannelGuestContacts does the same for the snapshots of archived booking channel reservations
+func moveArchivedChannelGuestContacts(db *gorm.DB) error {
+	var lastID uint
+	for {
+		var rows []channelNoteRow
+		if err := db.Raw(`
+			SELECT id, data->>'notes' AS notes FROM archived_reservations
+			WHERE id > ? AND data->>'source' <> 'direct' AND (data->>'notes' LIKE '%Phone: %' OR data->>'notes' LIKE '%Email: %')
+			ORDER BY id LIMIT ?
+		`, lastID, contactBackfillBatchSize).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			notes, email, phone := splitChannelGuestNotes(row.Notes)
+			if email == "" && phone == "" {
+				continue
+			}
+			encryptedEmail, err := pii.Encrypt(email)
+			if err != nil {
+				return err
+			}
+			encryptedPhone, err := pii.Encrypt(phone)
+			if err != nil {
+				return err
+			}
+			patch, err := json.Marshal(map[string]string{
+				"notes":       notes,
+				"guest_email": encryptedEmail,
+				"guest_phone": encryptedPhone,
+			})
+			if err != nil {
+				return err
+			}
+			if err := db.Exec(`UPDATE archived_reservations SET data = data || ?::jsonb WHERE id = ?`,
+				string(patch), row.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < contactBackfillBatchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
+
+// channelGuestRow is a booking channel reservation's notes with its encrypted guest contact details
+type channelGuestRow struct {
+	ID         uint
+	Notes      string
+	GuestEmail string
+	GuestPhone string
+}
+
+// joinChannelGuestNotes puts a channel guest's contact details back in the notes, after the "Guest: " line
+// as channel bookings wrote them
+func joinChannelGuestNotes(row channelGuestRow) (string, error) {
+	email, err := pii.Decrypt(row.GuestEmail)
+	if err != nil {
+		return "", err
+	}
+	phone, err := pii.Decrypt(row.GuestPhone)
+	if err != nil {
+		return "", err
+	}
+
+	var lines []string
+	rest := row.Notes
+	if strings.HasPrefix(rest, "Guest: ") {
+		guest, after, _ := strings.Cut(rest, "\n")
+		lines = append(lines, guest)
+		rest = after
+	}
+	if phone != "" {
+		lines = append(lines, "Phone: "+phone)
+	}
+	if email != "" {
+		lines = append(lines, "Email: "+email)
+	}
+	if rest != "" {
+		lines = append(lines, rest)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// restoreChannelGuestNotes moves the contact details of booking channel guests back into the notes of their
+// reservations and archived reservations
+func restoreChannelGuestNotes(db *gorm.DB) error {
+	sources := []struct{ selectSQL, updateSQL string }{
+		{
+			selectSQL: `SELECT id, notes, COALESCE(guest_email, '') AS guest_email, COALESCE(guest_phone, '') AS guest_phone
+				FROM reservations
+				WHERE id > ? AND (COALESCE(guest_email, '') <> '' OR COALESCE(guest_phone, '') <> '')
+				ORDER BY id LIMIT ?`,
+			updateSQL: `UPDATE reservations SET notes = ? WHERE id = ?`,
+		},
+		{
+			selectSQL: `SELECT id, COALESCE(data->>'notes', '') AS notes, COALESCE(data->>'guest_email', '') AS guest_email,
+					COALESCE(data->>'guest_phone', '') AS guest_phone
+				FROM archived_reservations
+				WHERE id > ? AND (COALESCE(data->>'guest_email', '') <> '' OR COALESCE(data->>'guest_phone', '') <> '')
+				ORDER BY id LIMIT ?`,
+			updateSQL: `UPDATE archived_reservations
+				SET data = (data - 'guest_email' - 'guest_phone') || jsonb_build_object('notes', ?::text)
+				WHERE id = ?`,
+		},
+	}
+
+	for _, source := range sources {
+		var lastID uint
+		for {
+			var rows []channelGuestRow
+			if err := db.Raw(source.selectSQL, lastID, contactBackfillBatchSize).Scan(&rows).Error; err != nil {
+				return err
+			}
+
+			for _, row := range rows {
+				notes, err := joinChannelGuestNotes(row)
+				if err != nil {
+					return err
+				}
+				if err := db.Exec(source.updateSQL, notes, row.ID).Error; err != nil {
+					return err
+				}
+			}
+
+			if len(rows) < contactBackfillBatchSize {
+				break
+			}
+			lastID = rows[len(rows)-1].ID
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// privateEventContactColumns are the private event contact columns, encrypted like the customers'
+var privateEventContactColumns = []encryptedContactColumn{
+	{table: "private_events", column: "contact_email", hashColumn: "contact_email_hash", hash: pii.HashEmail},
+	{table: "private_events", column: "contact_phone", hashColumn: "contact_phone_hash", hash: pii.HashPhone},
+}
+
+// EncryptPrivateEventContacts migration encrypts the contact email and phone of private events and adds their lookup hashes
+type EncryptPrivateEventContacts struct {
+	BaseMigration
+}
+
+// NewEncryptPrivateEventContacts creates a new migration
+func NewEncryptPrivateEventContacts() *EncryptPrivateEventContacts {
+	return &EncryptPrivateEventContacts{
+		BaseMigration: BaseMigration{
+			version: 81,
+			name:    "encrypt_private_event_contacts",
+		},
+	}
+}
+
+// Up widens the contact columns for ciphertext, adds the hash columns and encrypts existing rows
+func (m *EncryptPrivateEventContacts) Up(db *gorm.DB) error {
+	statements := []string{
+		`ALTER TABLE private_events
+			ALTER COLUMN contact_email TYPE text,
+			ALTER COLUMN contact_phone TYPE text,
+			ADD COLUMN IF NOT EXISTS contact_email_hash varchar(64),
+			ADD COLUMN IF NOT EXISTS contact_phone_hash varchar(64)`,
+		`CREATE INDEX IF NOT EXISTS idx_private_events_contact_email_hash ON private_events (contact_email_hash)`,
+		`CREATE INDEX IF NOT EXISTS idx_private_events_contact_phone_hash ON private_events (contact_phone_hash)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to add private event contact hashes: %w", err)
+		}
+	}
+
+	for _, c := range privateEventContactColumns {
+		if err := encryptContactColumn(db, c); err != nil {
+			return fmt.Errorf("failed to encrypt %s.%s: %w", c.table, c.column, err)
+		}
+	}
+	return nil
+}
+
+// Down decrypts the contact columns and drops the hash columns
+func (m *EncryptPrivateEventContacts) Down(db *gorm.DB) error {
+	for _, c := range privateEventContactColumns {
+		if err := decryptContactColumn(db, c); err != nil {
+			return fmt.Errorf("failed to decrypt %s.%s: %w", c.table, c.column, err)
+		}
+	}
+
+	if err := db.Exec(`ALTER TABLE private_events
+		DROP COLUMN IF EXISTS contact_email_hash,
+		DROP COLUMN IF EXISTS contact_phone_hash,
+		ALTER COLUMN contact_email TYPE varchar(255),
+		ALTER COLUMN contact_phone TYPE varchar(50)`).Error; err != nil {
+		return fmt.Errorf("failed to restore private event contact columns: %w", err)
+	}
+	return nil
+}
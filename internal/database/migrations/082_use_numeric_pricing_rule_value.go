@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// UseNumericPricingRuleValue migration stores pricing rule values as NUMERIC(12,2), like the other money columns,
+// rounding existing values to two decimals (cents for amounts, hundredths of a percent for percentages)
+type UseNumericPricingRuleValue struct {
+	BaseMigration
+}
+
+// NewUseNumericPricingRuleValue creates a new migration
+func NewUseNumericPricingRuleValue() *UseNumericPricingRuleValue {
+	return &UseNumericPricingRuleValue{
+		BaseMigration: BaseMigration{
+			version: 82,
+			name:    "use_numeric_pricing_rule_value",
+		},
+	}
+}
+
+// Up converts the value column, rounding half away from zero like the application
+func (m *UseNumericPricingRuleValue) Up(db *gorm.DB) error {
+	if err := db.Exec("ALTER TABLE pricing_rules ALTER COLUMN value TYPE NUMERIC(12,2) USING ROUND(value::NUMERIC, 2)").Error; err != nil {
+		return fmt.Errorf("failed to convert pricing_rules.value to NUMERIC(12,2): %w", err)
+	}
+	return nil
+}
+
+// Down restores the double precision column
+func (m *UseNumericPricingRuleValue) Down(db *gorm.DB) error {
+	if err := db.Exec("ALTER TABLE pricing_rules ALTER COLUMN value TYPE DOUBLE PRECISION").Error; err != nil {
+		return fmt.Errorf("failed to convert pricing_rules.value back to DOUBLE PRECISION: %w", err)
+	}
+	return nil
+}
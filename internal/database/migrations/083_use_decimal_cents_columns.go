@@ -0,0 +1,119 @@
+package migrations
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// UseDecimalCentsColumns migration stores the amounts kept in integer cents as NUMERIC(12,2) decimals under names
+// without the _cents suffix, like the other money columns, so every amount is stored and served the same way
+// Databases created after the models changed already have the decimal columns and are left as they are
+type UseDecimalCentsColumns struct {
+	BaseMigration
+}
+
+// NewUseDecimalCentsColumns creates a new migration
+func NewUseDecimalCentsColumns() *UseDecimalCentsColumns {
+	return &UseDecimalCentsColumns{
+		BaseMigration: BaseMigration{
+			version: 83,
+			name:    "use_decimal_cents_columns",
+		},
+	}
+}
+
+// centsColumns are the integer cents columns with their decimal names
+var centsColumns = []struct{ table, cents, decimal string }{
+	{"order_splits", "amount_cents", "amount"},
+	{"order_split_items", "amount_cents", "amount"},
+	{"daily_closeouts", "gross_sales_cents", "gross_sales"},
+	{"daily_closeouts", "net_sales_cents", "net_sales"},
+	{"daily_closeouts", "tax_cents", "tax"},
+	{"daily_closeouts", "discount_cents", "discount"},
+	{"daily_closeouts", "refund_cents", "refund"},
+	{"daily_closeouts", "cancelled_cents", "cancelled"},
+	{"daily_closeout_payments", "amount_cents", "amount"},
+	{"staff_rates", "hourly_rate_cents", "hourly_rate"},
+	{"time_entries", "hourly_rate_cents", "hourly_rate"},
+	{"table_sessions", "total_cents", "total"},
+	{"table_sessions", "tip_cents", "tip"},
+	{"invoices", "total_cents", "total"},
+	{"invoice_lines", "unit_price_cents", "unit_price"},
+	{"invoice_lines", "amount_cents", "amount"},
+	{"event_packages", "price_per_guest_cents", "price_per_guest"},
+	{"private_events", "venue_fee_cents", "venue_fee"},
+	{"private_events", "subtotal_cents", "subtotal"},
+	{"private_events", "service_charge_cents", "service_charge"},
+	{"private_events", "tax_cents", "tax"},
+	{"private_events", "total_cents", "total"},
+	{"private_event_deposits", "amount_cents", "amount"},
+}
+
+// Up renames and converts the cents columns, and the unit prices of the private event custom menus
+// Changing a column type doesn't fire the closeout immutability triggers
+func (m *UseDecimalCentsColumns) Up(db *gorm.DB) error {
+	for _, column := range centsColumns {
+		if !db.Migrator().HasColumn(column.table, column.cents) {
+			continue
+		}
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s RENAME COLUMN %s TO %s", column.table, column.cents, column.decimal,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to rename %s.%s: %w", column.table, column.cents, err)
+		}
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ALTER COLUMN %s TYPE NUMERIC(12,2) USING %s / 100.0", column.table, column.decimal, column.decimal,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to convert %s.%s to NUMERIC(12,2): %w", column.table, column.decimal, err)
+		}
+	}
+
+	if err := renameCustomMenuPrice(db, "unit_price_cents", "unit_price", "ROUND((line->>'unit_price_cents')::NUMERIC / 100, 2)"); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Down restores the integer cents columns and custom menu prices
+func (m *UseDecimalCentsColumns) Down(db *gorm.DB) error {
+	if err := renameCustomMenuPrice(db, "unit_price", "unit_price_cents", "ROUND((line->>'unit_price')::NUMERIC * 100)::BIGINT"); err != nil {
+		return err
+	}
+
+	for _, column := range centsColumns {
+		if !db.Migrator().HasColumn(column.table, column.decimal) {
+			continue
+		}
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s ALTER COLUMN %s TYPE BIGINT USING ROUND(%s * 100)", column.table, column.decimal, column.decimal,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to convert %s.%s back to BIGINT: %w", column.table, column.decimal, err)
+		}
+		if err := db.Exec(fmt.Sprintf(
+			"ALTER TABLE %s RENAME COLUMN %s TO %s", column.table, column.decimal, column.cents,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to rename %s.%s back: %w", column.table, column.decimal, err)
+		}
+	}
+	return nil
+}
+
+// renameCustomMenuPrice replaces the from key of every private event custom menu line with the to key, set to
+// value (an expression of the line), keeping the lines in order
+func renameCustomMenuPrice(db *gorm.DB, from, to, value string) error {
+	if err := db.Exec(fmt.Sprintf(`
+		UPDATE private_events SET custom_menu = (
+			SELECT jsonb_agg(
+				CASE WHEN line->'%[1]s' IS NULL THEN line
+				ELSE (line - '%[1]s') || jsonb_build_object('%[2]s', %[3]s) END
+				ORDER BY position
+			)
+			FROM jsonb_array_elements(custom_menu) WITH ORDINALITY AS lines(line, position)
+		)
+		WHERE EXISTS (SELECT 1 FROM jsonb_array_elements(custom_menu) AS lines(line) WHERE line->'%[1]s' IS NOT NULL)
+	`, from, to, value)).Error; err != nil {
+		return fmt.Errorf("failed to rename the private event custom menu %s: %w", from, err)
+	}
+	return nil
+}
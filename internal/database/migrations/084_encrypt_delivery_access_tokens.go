@@ -0,0 +1,70 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// EncryptDeliveryAccessTokens migration encrypts the delivery platform access tokens with the PII key, like the SSO
+// client secrets
+type EncryptDeliveryAccessTokens struct {
+	BaseMigration
+}
+
+// NewEncryptDeliveryAccessTokens creates a new migration
+func NewEncryptDeliveryAccessTokens() *EncryptDeliveryAccessTokens {
+	return &EncryptDeliveryAccessTokens{
+		BaseMigration: BaseMigration{
+			version: 84,
+			name:    "encrypt_delivery_access_tokens",
+		},
+	}
+}
+
+// Up encrypts the existing access tokens
+func (m *EncryptDeliveryAccessTokens) Up(db *gorm.DB) error {
+	if err := encryptSecretColumn(db, "delivery_integrations", "access_token"); err != nil {
+		return fmt.Errorf("failed to encrypt delivery_integrations.access_token: %w", err)
+	}
+	return nil
+}
+
+// Down decrypts the access tokens
+func (m *EncryptDeliveryAccessTokens) Down(db *gorm.DB) error {
+	if err := decryptContactColumn(db, encryptedContactColumn{table: "delivery_integrations", column: "access_token"}); err != nil {
+		return fmt.Errorf("failed to decrypt delivery_integrations.access_token: %w", err)
+	}
+	return nil
+}
+
+// encryptSecretColumn encrypts the plaintext values of a text column that has no lookup hash, in batches
+func encryptSecretColumn(db *gorm.DB, table, column string) error {
+	var lastID uint
+	for {
+		var rows []contactRow
+		if err := db.Raw(fmt.Sprintf(
+			`SELECT id, %[1]q AS value FROM %[2]s WHERE id > ? AND %[1]q <> '' AND %[1]q NOT LIKE 'enc:%%' ORDER BY id LIMIT ?`,
+			column, table,
+		), lastID, contactBackfillBatchSize).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			ciphertext, err := pii.Encrypt(row.Value)
+			if err != nil {
+				return err
+			}
+			if err := db.Exec(fmt.Sprintf(`UPDATE %s SET %q = ? WHERE id = ?`, table, column), ciphertext, row.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < contactBackfillBatchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
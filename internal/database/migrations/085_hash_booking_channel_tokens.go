@@ -0,0 +1,98 @@
+package migrations
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// HashBookingChannelTokens migration replaces the booking channels' partner API tokens with their HMAC and encrypts
+// the channel access tokens with the PII key
+type HashBookingChannelTokens struct {
+	BaseMigration
+}
+
+// NewHashBookingChannelTokens creates a new migration
+func NewHashBookingChannelTokens() *HashBookingChannelTokens {
+	return &HashBookingChannelTokens{
+		BaseMigration: BaseMigration{
+			version: 85,
+			name:    "hash_booking_channel_tokens",
+		},
+	}
+}
+
+// Up renames the token column to token_hash, hashes the existing tokens and encrypts the access tokens
+// Databases created after the model changed already have token_hash and only get their access tokens encrypted
+func (m *HashBookingChannelTokens) Up(db *gorm.DB) error {
+	if db.Migrator().HasColumn("booking_channels", "token") {
+		statements := []string{
+			`ALTER TABLE booking_channels RENAME COLUMN token TO token_hash`,
+			`ALTER INDEX IF EXISTS idx_booking_channels_token RENAME TO idx_booking_channels_token_hash`,
+		}
+		for _, statement := range statements {
+			if err := db.Exec(statement).Error; err != nil {
+				return fmt.Errorf("failed to rename booking_channels.token: %w", err)
+			}
+		}
+		if err := hashBookingChannelTokens(db); err != nil {
+			return fmt.Errorf("failed to hash booking_channels.token_hash: %w", err)
+		}
+	}
+
+	if err := encryptSecretColumn(db, "booking_channels", "access_token"); err != nil {
+		return fmt.Errorf("failed to encrypt booking_channels.access_token: %w", err)
+	}
+	return nil
+}
+
+// Down decrypts the access tokens and restores the token column
+// The tokens can't be recovered from their hashes: the column keeps the hashes, so every channel's token must be
+// rotated afterwards
+func (m *HashBookingChannelTokens) Down(db *gorm.DB) error {
+	if err := decryptContactColumn(db, encryptedContactColumn{table: "booking_channels", column: "access_token"}); err != nil {
+		return fmt.Errorf("failed to decrypt booking_channels.access_token: %w", err)
+	}
+
+	if !db.Migrator().HasColumn("booking_channels", "token_hash") {
+		return nil
+	}
+	statements := []string{
+		`ALTER TABLE booking_channels RENAME COLUMN token_hash TO token`,
+		`ALTER INDEX IF EXISTS idx_booking_channels_token_hash RENAME TO idx_booking_channels_token`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to restore booking_channels.token: %w", err)
+		}
+	}
+	return nil
+}
+
+// hashBookingChannelTokens replaces the plaintext tokens of the renamed column with their hashes, in batches
+func hashBookingChannelTokens(db *gorm.DB) error {
+	var lastID uint
+	for {
+		var rows []contactRow
+		if err := db.Raw(
+			`SELECT id, token_hash AS value FROM booking_channels WHERE id > ? ORDER BY id LIMIT ?`,
+			lastID, contactBackfillBatchSize,
+		).Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if err := db.Exec(`UPDATE booking_channels SET token_hash = ? WHERE id = ?`,
+				pii.HashToken(row.Value), row.ID).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < contactBackfillBatchSize {
+			return nil
+		}
+		lastID = rows[len(rows)-1].ID
+	}
+}
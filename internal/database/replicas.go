@@ -0,0 +1,63 @@
+package database
+
+import (
+	"fmt"
+
+	"restaurant-backend/internal/config"
+	tenantctx "restaurant-backend/internal/ctx"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// replicaResolver names the resolver holding the read replicas
+// Only statements whose context opts in use it, so reads default to the primary and see their own writes
+const replicaResolver = "read_replicas"
+
+// registerReplicas routes the reads of statements marked with ctx.WithReadReplica to the configured replicas
+// Writes, locking reads and anything inside a transaction stay on the primary (dbresolver's own rules)
+func registerReplicas(db *gorm.DB, cfg *config.Config) error {
+	if len(cfg.DBReplicaURLs) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(cfg.DBReplicaURLs))
+	for _, url := range cfg.DBReplicaURLs {
+		replicas = append(replicas, postgres.Open(url))
+	}
+
+	// Registered under a name rather than globally, otherwise every read would go to a replica
+	resolver := dbresolver.Register(dbresolver.Config{Replicas: replicas}, replicaResolver).
+		SetMaxOpenConns(cfg.DBMaxOpenConns).
+		SetMaxIdleConns(cfg.DBMaxIdleConns).
+		SetConnMaxLifetime(cfg.DBConnMaxLifetime).
+		SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register read replicas: %w", err)
+	}
+
+	// The tenant session is applied on whichever connection is picked, so RLS holds on the replicas too
+	cb := db.Callback()
+	if err := cb.Query().Before("tenant:apply_session").Register("replica:route", routeToReplica); err != nil {
+		return fmt.Errorf("failed to register replica callback: %w", err)
+	}
+	if err := cb.Row().Before("tenant:apply_session").Register("replica:route", routeToReplica); err != nil {
+		return fmt.Errorf("failed to register replica callback: %w", err)
+	}
+	if err := cb.Raw().Before("tenant:apply_session").Register("replica:route", routeToReplica); err != nil {
+		return fmt.Errorf("failed to register replica callback: %w", err)
+	}
+
+	return nil
+}
+
+// routeToReplica re-resolves the statement's connection through the replica resolver when its context opts in
+func routeToReplica(db *gorm.DB) {
+	if db.Error != nil || db.DryRun || !tenantctx.UsesReadReplica(db.Statement.Context) {
+		return
+	}
+	if use, ok := dbresolver.Use(replicaResolver).(gorm.StatementModifier); ok {
+		use.ModifyStatement(db.Statement)
+	}
+}
@@ -0,0 +1,417 @@
+package database
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// Demo tenant identity; the restaurant email makes the seed idempotent
+const (
+	demoRestaurantEmail = "hello@demo-bistro.local"
+	demoUserDomain      = "demo-bistro.local"
+	demoPassword        = "DemoPass123!"
+	demoHistoryDays     = 90
+	demoClientCount     = 25
+	demoTableCount      = 12
+)
+
+// demoMenu is the demo restaurant's menu: category name and description, then its items
+var demoMenu = []struct {
+	Name        string
+	Description string
+	Items       []demoMenuItem
+}{
+	{"Starters", "Small plates to share", []demoMenuItem{
+		{"Bruschetta", "Grilled bread, tomato, basil, garlic", 7.50, []string{"vegan"}},
+		{"Calamari Fritti", "Fried squid with lemon aioli", 11.00, []string{"seafood"}},
+		{"Burrata", "Burrata, heirloom tomatoes, olive oil", 12.50, []string{"vegetarian"}},
+		{"Chicken Wings", "Spicy glazed wings, blue cheese dip", 10.00, []string{"spicy"}},
+	}},
+	{"Mains", "From the kitchen", []demoMenuItem{
+		{"Margherita Pizza", "Tomato, mozzarella, basil", 13.00, []string{"vegetarian", "pizza"}},
+		{"Diavola Pizza", "Tomato, mozzarella, spicy salami", 15.00, []string{"spicy", "pizza"}},
+		{"Tagliatelle Bolognese", "Fresh pasta, slow-cooked beef ragù", 16.50, []string{"pasta"}},
+		{"Mushroom Risotto", "Arborio rice, porcini, parmesan", 17.00, []string{"vegetarian", "gluten-free"}},
+		{"Grilled Salmon", "Salmon fillet, lemon butter, greens", 22.00, []string{"seafood", "gluten-free"}},
+		{"Bistro Burger", "Beef patty, cheddar, pickles, fries", 18.00, nil},
+	}},
+	{"Desserts", "Something sweet", []demoMenuItem{
+		{"Tiramisu", "Mascarpone, espresso, cocoa", 8.00, []string{"vegetarian"}},
+		{"Panna Cotta", "Vanilla cream, berry compote", 7.50, []string{"vegetarian", "gluten-free"}},
+		{"Affogato", "Vanilla gelato drowned in espresso", 6.00, []string{"vegetarian"}},
+	}},
+	{"Drinks", "Cold and hot drinks", []demoMenuItem{
+		{"Espresso", "Single shot", 2.80, []string{"coffee"}},
+		{"Cappuccino", "Espresso, steamed milk, foam", 3.80, []string{"coffee"}},
+		{"Fresh Lemonade", "Lemon, mint, sparkling water", 4.50, []string{"vegan"}},
+		{"House Red Wine", "Glass of Montepulciano", 7.00, []string{"wine"}},
+		{"Craft Beer", "Local pale ale, 0.4l", 6.00, []string{"beer"}},
+	}},
+}
+
+// demoMenuItem is a menu item of the demo menu
+type demoMenuItem struct {
+	Name        string
+	Description string
+	Price       float64
+	Tags        []string
+}
+
+// SeedDemo creates a demo restaurant with a menu, users of each role and 90 days of orders and reservations,
+// so dashboards and analytics can be exercised locally. Nothing happens if the demo restaurant already exists
+func SeedDemo(db *gorm.DB, cfg *config.Config) error {
+	if cfg.Environment == "production" {
+		return fmt.Errorf("demo data cannot be seeded in production")
+	}
+
+	var existing models.Restaurant
+	if err := db.Where("email = ?", demoRestaurantEmail).First(&existing).Error; err == nil {
+		log.Printf("✓ Demo restaurant already exists (id %d)", existing.ID)
+		return nil
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(demoPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return fmt.Errorf("failed to hash demo password: %w", err)
+	}
+
+	// A fixed seed gives every developer the same demo data
+	rng := rand.New(rand.NewSource(1))
+	now := time.Now()
+	start := now.AddDate(0, 0, -demoHistoryDays)
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		restaurant, err := seedDemoRestaurant(tx, start)
+		if err != nil {
+			return err
+		}
+
+		staff, clients, err := seedDemoUsers(tx, restaurant.ID, string(hashedPassword), start)
+		if err != nil {
+			return err
+		}
+
+		menuItems, err := seedDemoMenu(tx, restaurant.ID, start)
+		if err != nil {
+			return err
+		}
+
+		orders, err := seedDemoOrders(tx, rng, restaurant.ID, staff, clients, menuItems, start, now)
+		if err != nil {
+			return err
+		}
+
+		reservations, err := seedDemoReservations(tx, rng, restaurant.ID, clients, start, now)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("✓ Demo restaurant created (id %d): %d menu items, %d orders, %d reservations",
+			restaurant.ID, len(menuItems), orders, reservations)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✓ Demo users: admin@%s, staff@%s, client1@%s … client%d@%s (password %q)",
+		demoUserDomain, demoUserDomain, demoUserDomain, demoClientCount, demoUserDomain, demoPassword)
+	return nil
+}
+
+// seedDemoRestaurant creates the demo restaurant, live since start and assigned to the platform KAM if there is one
+func seedDemoRestaurant(tx *gorm.DB, start time.Time) (*models.Restaurant, error) {
+	restaurant := &models.Restaurant{
+		Name:         "Demo Bistro",
+		Description:  "Neighbourhood Italian bistro seeded for local development",
+		Address:      "1 Demo Street, Springfield",
+		Phone:        "+1 555 0100",
+		Email:        demoRestaurantEmail,
+		Status:       models.RestaurantStatusActive,
+		ContactName:  "Demo Owner",
+		ContactEmail: "owner@" + demoUserDomain,
+		Visibility:   models.RestaurantVisibilityPublic,
+		ActivatedAt:  &start,
+		LaunchedAt:   &start,
+		CreatedAt:    start,
+		UpdatedAt:    start,
+	}
+
+	var kam models.User
+	if err := tx.Where("restaurant_id = ? AND role = ?", models.PlatformOrganizationID, "KAM").First(&kam).Error; err == nil {
+		restaurant.KAMID = &kam.ID
+	}
+
+	if err := tx.Create(restaurant).Error; err != nil {
+		return nil, fmt.Errorf("failed to create demo restaurant: %w", err)
+	}
+	return restaurant, nil
+}
+
+// seedDemoUsers creates an admin, a staff member and the clients of the demo restaurant
+// Returns the staff member, who confirms and prepares the seeded orders, and the clients
+func seedDemoUsers(tx *gorm.DB, restaurantID uint, passwordHash string, start time.Time) (*models.User, []models.User, error) {
+	users := []models.User{
+		{Email: "admin@" + demoUserDomain, FirstName: "Ada", LastName: "Admin", Role: "Admin"},
+		{Email: "staff@" + demoUserDomain, FirstName: "Sam", LastName: "Staff", Role: "Staff"},
+	}
+	for i := 1; i <= demoClientCount; i++ {
+		users = append(users, models.User{
+			Email:     fmt.Sprintf("client%d@%s", i, demoUserDomain),
+			FirstName: "Client",
+			LastName:  fmt.Sprintf("%d", i),
+			Role:      "Client",
+		})
+	}
+	for i := range users {
+		users[i].RestaurantID = restaurantID
+		users[i].PasswordHash = passwordHash
+		users[i].IsActive = true
+		users[i].CreatedAt = start
+		users[i].UpdatedAt = start
+	}
+
+	if err := repositories.CreateInBatches(tx, &users, 0); err != nil {
+		return nil, nil, fmt.Errorf("failed to create demo users: %w", err)
+	}
+	return &users[1], users[2:], nil
+}
+
+// seedDemoMenu creates the demo categories and items, each item with a placeholder image
+func seedDemoMenu(tx *gorm.DB, restaurantID uint, start time.Time) ([]models.MenuItem, error) {
+	categories := make([]models.MenuCategory, len(demoMenu))
+	for categoryOrder, demoCategory := range demoMenu {
+		categories[categoryOrder] = models.MenuCategory{
+			RestaurantID: restaurantID,
+			Name:         demoCategory.Name,
+			Description:  demoCategory.Description,
+			DisplayOrder: categoryOrder,
+			IsActive:     true,
+			CreatedAt:    start,
+			UpdatedAt:    start,
+		}
+	}
+	if err := repositories.CreateInBatches(tx, &categories, 0); err != nil {
+		return nil, fmt.Errorf("failed to create demo categories: %w", err)
+	}
+
+	var menuItems []models.MenuItem
+	for categoryOrder, demoCategory := range demoMenu {
+		for itemOrder, demoItem := range demoCategory.Items {
+			tags := demoItem.Tags
+			if tags == nil {
+				tags = []string{}
+			}
+
+			menuItems = append(menuItems, models.MenuItem{
+				RestaurantID: restaurantID,
+				CategoryID:   categories[categoryOrder].ID,
+				Name:         demoItem.Name,
+				Description:  demoItem.Description,
+				Price:        money.FromFloat(demoItem.Price),
+				ImageURL:     fmt.Sprintf("https://picsum.photos/seed/%s/640/480", strings.ReplaceAll(strings.ToLower(demoItem.Name), " ", "-")),
+				DisplayOrder: itemOrder,
+				IsAvailable:  true,
+				Tags:         tags,
+				CreatedAt:    start,
+				UpdatedAt:    start,
+			})
+		}
+	}
+	if err := repositories.CreateInBatches(tx, &menuItems, 0); err != nil {
+		return nil, fmt.Errorf("failed to create demo menu items: %w", err)
+	}
+
+	images := make([]models.MenuItemImage, len(menuItems))
+	for i, item := range menuItems {
+		images[i] = models.MenuItemImage{
+			RestaurantID: restaurantID,
+			MenuItemID:   item.ID,
+			ImageURL:     item.ImageURL,
+			IsPrimary:    true,
+			CreatedAt:    start,
+			UpdatedAt:    start,
+		}
+	}
+	if err := repositories.CreateInBatches(tx, &images, 0); err != nil {
+		return nil, fmt.Errorf("failed to create demo menu item images: %w", err)
+	}
+
+	return menuItems, nil
+}
+
+// seedDemoOrders creates orders for every day from start until now with their items and status history
+// Past orders are completed (some cancelled); today's orders are spread over the open statuses
+func seedDemoOrders(tx *gorm.DB, rng *rand.Rand, restaurantID uint, staff *models.User, clients []models.User, menuItems []models.MenuItem, start, now time.Time) (int, error) {
+	fulfillments := []string{models.OrderFulfillmentPickup, models.OrderFulfillmentPickup, models.OrderFulfillmentDineIn, models.OrderFulfillmentDelivery}
+	lifecycle := []string{models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusPreparing, models.OrderStatusReady, models.OrderStatusCompleted}
+
+	// Orders (with their items) and their status changes are inserted in batches once all are built;
+	// orderChanges[i] is the status history of orders[i]
+	var orders []models.Order
+	var orderChanges [][]models.OrderStatusChange
+
+	for day := start; !day.After(now); day = day.AddDate(0, 0, 1) {
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+		perDay := 8 + rng.Intn(10)
+		if weekday := midnight.Weekday(); weekday == time.Friday || weekday == time.Saturday {
+			perDay += 8
+		}
+
+		for i := 0; i < perDay; i++ {
+			// Between 11:00 and 21:30
+			placedAt := midnight.Add(11*time.Hour + time.Duration(rng.Intn(630))*time.Minute)
+			if placedAt.After(now) {
+				continue
+			}
+
+			fulfillment := fulfillments[rng.Intn(len(fulfillments))]
+			order := models.Order{
+				RestaurantID:    restaurantID,
+				UserID:          clients[rng.Intn(len(clients))].ID,
+				Source:          models.OrderSourceInternal,
+				Channel:         fulfillment,
+				FulfillmentType: fulfillment,
+				CreatedAt:       placedAt,
+			}
+			if fulfillment == models.OrderFulfillmentDelivery {
+				order.DeliveryAddress = fmt.Sprintf("%d Demo Avenue, Springfield", 1+rng.Intn(200))
+				order.DeliveryFee = money.FromFloat(3.50)
+			}
+
+			var total money.Cents
+			lines := 1 + rng.Intn(4)
+			for j := 0; j < lines; j++ {
+				menuItem := menuItems[rng.Intn(len(menuItems))]
+				quantity := 1 + rng.Intn(3)
+				order.OrderItems = append(order.OrderItems, models.OrderItem{
+					RestaurantID: restaurantID,
+					MenuItemID:   menuItem.ID,
+					Name:         menuItem.Name,
+					Quantity:     quantity,
+					Price:        menuItem.Price,
+					CreatedAt:    placedAt,
+					UpdatedAt:    placedAt,
+				})
+				total += menuItem.Price.Times(quantity)
+			}
+			order.TotalAmount = total + order.DeliveryFee
+
+			// How far the order got: past orders finished, today's are still in the kitchen
+			reached := len(lifecycle) - 1
+			if midnight.Equal(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())) {
+				reached = rng.Intn(len(lifecycle))
+			}
+			cancelled := reached == len(lifecycle)-1 && rng.Intn(20) == 0
+
+			var changes []models.OrderStatusChange
+			changedAt := placedAt
+			order.Status = lifecycle[0]
+			for step := 1; step <= reached; step++ {
+				next := lifecycle[step]
+				if cancelled && step == 2 {
+					next = models.OrderStatusCancelled
+				}
+				changedAt = changedAt.Add(time.Duration(3+rng.Intn(12)) * time.Minute)
+				if changedAt.After(now) {
+					break
+				}
+				changes = append(changes, models.OrderStatusChange{
+					RestaurantID:    restaurantID,
+					FromStatus:      order.Status,
+					ToStatus:        next,
+					ChangedByUserID: staff.ID,
+					CreatedAt:       changedAt,
+				})
+				order.Status = next
+				if next == models.OrderStatusCancelled {
+					break
+				}
+			}
+			order.UpdatedAt = changedAt
+
+			orders = append(orders, order)
+			orderChanges = append(orderChanges, changes)
+		}
+	}
+
+	if err := repositories.CreateInBatches(tx, &orders, 0); err != nil {
+		return 0, fmt.Errorf("failed to create demo orders: %w", err)
+	}
+
+	var changes []models.OrderStatusChange
+	for i, order := range orders {
+		for _, change := range orderChanges[i] {
+			change.OrderID = order.ID
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) > 0 {
+		if err := repositories.CreateInBatches(tx, &changes, 0); err != nil {
+			return 0, fmt.Errorf("failed to create demo order status changes: %w", err)
+		}
+	}
+
+	return len(orders), nil
+}
+
+// seedDemoReservations creates dinner reservations from start until two weeks ahead
+// Past reservations are completed or cancelled; upcoming ones are pending or confirmed
+func seedDemoReservations(tx *gorm.DB, rng *rand.Rand, restaurantID uint, clients []models.User, start, now time.Time) (int, error) {
+	var reservations []models.Reservation
+	end := now.AddDate(0, 0, 14)
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+		tables := rng.Perm(demoTableCount)
+		perDay := 3 + rng.Intn(6)
+
+		for i := 0; i < perDay; i++ {
+			// Dinner seatings every 30 minutes from 17:30 to 21:00
+			startTime := midnight.Add(17*time.Hour + 30*time.Minute + time.Duration(rng.Intn(8))*30*time.Minute)
+
+			status := "completed"
+			switch {
+			case startTime.After(now) && rng.Intn(3) == 0:
+				status = "pending"
+			case startTime.After(now):
+				status = "confirmed"
+			case rng.Intn(10) == 0:
+				status = "cancelled"
+			}
+
+			createdAt := startTime.AddDate(0, 0, -(1 + rng.Intn(10)))
+			if createdAt.After(now) {
+				createdAt = now
+			}
+
+			reservations = append(reservations, models.Reservation{
+				RestaurantID:   restaurantID,
+				UserID:         clients[rng.Intn(len(clients))].ID,
+				TableNumber:    fmt.Sprintf("T%d", tables[i]+1),
+				StartTime:      startTime,
+				EndTime:        startTime.Add(2 * time.Hour),
+				NumberOfGuests: 2 + rng.Intn(5),
+				Status:         status,
+				CreatedAt:      createdAt,
+				UpdatedAt:      createdAt,
+			})
+		}
+	}
+
+	if err := repositories.CreateInBatches(tx, &reservations, 0); err != nil {
+		return 0, fmt.Errorf("failed to create demo reservations: %w", err)
+	}
+	return len(reservations), nil
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	tenantctx "restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// tenantAppRole is the role the RLS policies apply to (created by the RLS migration)
+const tenantAppRole = "restaurant_app_user"
+
+// reportingRole is the read-only role platform-wide reports run as; its policies let it read every
+// tenant's rows (created by the platform reporting migration)
+const reportingRole = "platform_reporting"
+
+// ErrReportingRoleUnavailable is returned for platform report queries when the reporting role can't be
+// used; they fail rather than run with the connection's own privileges
+var ErrReportingRoleUnavailable = errors.New("platform reporting role is not available")
+
+// tenantConnKey stores the connection pinned for a statement outside a transaction
+const tenantConnKey = "tenant:conn"
+
+// tenantSessionSQL applies every RLS input in a single round trip
+// set_config('role', ...) is equivalent to SET ROLE; $5 makes the settings transaction-local
+const tenantSessionSQL = `SELECT set_config('role', $1, $5),
+	set_config('app.current_restaurant', $2, $5),
+	set_config('app.current_organization', $3, $5),
+	set_config('app.current_user_role', $4, $5)`
+
+// tenantSession applies the request tenant from the statement context to the
+// Postgres session before every query, so RLS holds no matter which pooled
+// connection runs it. Statements without a tenant (migrations, schedulers,
+// public routes) clear the settings instead of inheriting a previous request's
+type tenantSession struct {
+	appRole       string // Empty when the RLS role is missing, settings are still applied
+	reportingRole string // Empty when the reporting role is missing; report queries then fail
+}
+
+// pinnedConn is a pooled connection held for the duration of one statement
+type pinnedConn struct {
+	conn *sql.Conn
+	pool gorm.ConnPool // Restored once the statement finishes
+}
+
+// registerTenantCallbacks installs the tenant session callbacks on every gorm operation
+func registerTenantCallbacks(db *gorm.DB) error {
+	ts := &tenantSession{}
+	for role, field := range map[string]*string{tenantAppRole: &ts.appRole, reportingRole: &ts.reportingRole} {
+		// Switching role requires the role to exist and the connecting user to be a member of it
+		var canSwitchRole bool
+		if err := db.Raw(
+			"SELECT COALESCE((SELECT pg_has_role(current_user, oid, 'MEMBER') FROM pg_roles WHERE rolname = ?), false)",
+			role,
+		).Scan(&canSwitchRole).Error; err != nil {
+			return fmt.Errorf("failed to check %s role: %w", role, err)
+		}
+		if canSwitchRole {
+			*field = role
+		}
+	}
+
+	cb := db.Callback()
+	// Writes run inside gorm's default transaction, so the settings are applied after it begins
+	// Preloads run after the main query on the same connection, so queries release it last
+	if err := errors.Join(
+		cb.Create().After("gorm:begin_transaction").Before("gorm:before_create").Register("tenant:apply_session", ts.apply),
+		cb.Create().After("gorm:commit_or_rollback_transaction").Register("tenant:release_conn", ts.release),
+		cb.Update().After("gorm:begin_transaction").Before("gorm:setup_reflect_value").Register("tenant:apply_session", ts.apply),
+		cb.Update().After("gorm:commit_or_rollback_transaction").Register("tenant:release_conn", ts.release),
+		cb.Delete().After("gorm:begin_transaction").Before("gorm:before_delete").Register("tenant:apply_session", ts.apply),
+		cb.Delete().After("gorm:commit_or_rollback_transaction").Register("tenant:release_conn", ts.release),
+		cb.Query().Before("gorm:query").Register("tenant:apply_session", ts.apply),
+		cb.Query().After("gorm:after_query").Register("tenant:release_conn", ts.release),
+		cb.Row().Before("gorm:row").Register("tenant:apply_session", ts.apply),
+		cb.Row().After("gorm:row").Register("tenant:release_conn", ts.release),
+		cb.Raw().Before("gorm:raw").Register("tenant:apply_session", ts.apply),
+		cb.Raw().After("gorm:raw").Register("tenant:release_conn", ts.release),
+	); err != nil {
+		return fmt.Errorf("failed to register tenant callbacks: %w", err)
+	}
+
+	return nil
+}
+
+// apply sets the tenant settings on the connection that will run the statement
+func (ts *tenantSession) apply(db *gorm.DB) {
+	if db.Error != nil || db.DryRun {
+		return
+	}
+
+	ctx := db.Statement.Context
+	if tenantctx.IsPlatformReporting(ctx) && ts.reportingRole == "" {
+		_ = db.AddError(ErrReportingRoleUnavailable)
+		return
+	}
+
+	local := false
+	switch pool := db.Statement.ConnPool.(type) {
+	case gorm.TxCommitter:
+		// Inside a transaction the settings end with it
+		local = true
+	case *sql.DB:
+		// Pin a connection so the settings and the statement share a session
+		conn, err := pool.Conn(ctx)
+		if err != nil {
+			_ = db.AddError(fmt.Errorf("failed to acquire connection: %w", err))
+			return
+		}
+		db.InstanceSet(tenantConnKey, &pinnedConn{conn: conn, pool: pool})
+		db.Statement.ConnPool = conn
+	}
+
+	if _, err := db.Statement.ConnPool.ExecContext(ctx, tenantSessionSQL, ts.settings(ctx, local)...); err != nil {
+		_ = db.AddError(fmt.Errorf("failed to set tenant session: %w", err))
+	}
+}
+
+// release returns the pinned connection (if any) to the pool
+func (ts *tenantSession) release(db *gorm.DB) {
+	value, ok := db.InstanceGet(tenantConnKey)
+	if !ok {
+		return
+	}
+	pinned, _ := value.(*pinnedConn)
+	if pinned == nil {
+		return
+	}
+	db.InstanceSet(tenantConnKey, (*pinnedConn)(nil))
+	db.Statement.ConnPool = pinned.pool
+
+	// Row() and Rows() results are read after the callbacks return, and closing
+	// a sql.Conn waits for its open rows, so release those in the background
+	switch db.Statement.Dest.(type) {
+	case *sql.Rows, *sql.Row:
+		go pinned.conn.Close()
+	default:
+		_ = pinned.conn.Close()
+	}
+}
+
+// settings returns the tenantSessionSQL arguments for the statement context
+func (ts *tenantSession) settings(ctx context.Context, local bool) []any {
+	role, restaurantID, organizationID, userRole := "none", "", "", ""
+	if tenantctx.IsPlatformReporting(ctx) {
+		// Reports read across tenants through the reporting role's policies, not the request tenant
+		return []any{ts.reportingRole, restaurantID, organizationID, userRole, local}
+	}
+	if tenant, ok := tenantctx.GetTenant(ctx); ok {
+		restaurantID = strconv.FormatUint(uint64(tenant.RestaurantID), 10)
+		organizationID = strconv.FormatUint(uint64(tenant.OrganizationID), 10)
+		userRole = tenant.Role
+
+		// Platform staff (KAMs) work across tenants; platform routes are guarded by role instead
+		if ts.appRole != "" && tenant.RestaurantID != models.PlatformOrganizationID {
+			role = ts.appRole
+		}
+	}
+	return []any{role, restaurantID, organizationID, userRole, local}
+}
@@ -16,3 +16,21 @@ type UpdateCategoryRequest struct {
 	DisplayOrder *int    `json:"display_order"`
 	IsActive     *bool   `json:"is_active"`
 }
+
+// UpdateCategoryAvailabilityRequest represents a bulk availability toggle for all items in a category
+type UpdateCategoryAvailabilityRequest struct {
+	IsAvailable *bool  `json:"is_available" binding:"required"`
+	Reason      string `json:"reason" binding:"max=255"` // e.g. "fryer broken"; recorded in the audit log
+}
+
+// ReorderRequest represents a drag-and-drop reorder: the IDs in their new display order
+type ReorderRequest struct {
+	IDs []uint `json:"ids" binding:"required,min=1,max=500,dive,min=1"`
+}
+
+// DeleteCategoryRequest says what happens to the items of a category being deleted. A category with items
+// can only be deleted by moving them to another category or by archiving it together with its items
+type DeleteCategoryRequest struct {
+	MoveItemsTo  *uint `form:"move_items_to" binding:"omitempty,min=1"`
+	ArchiveItems bool  `form:"archive_items"`
+}
@@ -1,24 +1,63 @@
 package dto
 
+import "restaurant-backend/internal/money"
+
 // CreateMenuItemRequest represents a menu item creation request
 type CreateMenuItemRequest struct {
-	CategoryID   uint    `json:"category_id" binding:"required"`
-	Name         string  `json:"name" binding:"required"`
-	Description  string  `json:"description"`
-	Price        float64 `json:"price" binding:"required,min=0"`
-	ImageURL     string  `json:"image_url"`
-	DisplayOrder int     `json:"display_order"`
-	IsAvailable  bool    `json:"is_available"`
+	CategoryID   uint        `json:"category_id" binding:"required"`
+	Name         string      `json:"name" binding:"required"`
+	Description  string      `json:"description"`
+	Price        money.Cents `json:"price" binding:"required,min=0"`
+	ImageURL     string      `json:"image_url"`
+	DisplayOrder int         `json:"display_order"`
+	IsAvailable  bool        `json:"is_available"`
+
+	// Optional nutrition information (per serving)
+	Calories     *int     `json:"calories" binding:"omitempty,min=0"`
+	ProteinGrams *float64 `json:"protein_grams" binding:"omitempty,min=0"`
+	CarbsGrams   *float64 `json:"carbs_grams" binding:"omitempty,min=0"`
+	FatGrams     *float64 `json:"fat_grams" binding:"omitempty,min=0"`
+
+	// Optional prices per sales channel
+	ChannelPrices *ChannelPrices `json:"channel_prices"`
+
+	// Optional search keywords
+	Tags []string `json:"tags" binding:"max=20,dive,max=50"`
+}
+
+// ChannelPrices overrides a menu item's price per sales channel; omitted channels pay the menu price
+type ChannelPrices struct {
+	DineIn     *money.Cents `json:"dine_in" binding:"omitempty,min=0"`
+	Pickup     *money.Cents `json:"pickup" binding:"omitempty,min=0"`
+	Delivery   *money.Cents `json:"delivery" binding:"omitempty,min=0"`
+	ThirdParty *money.Cents `json:"third_party" binding:"omitempty,min=0"`
 }
 
 // UpdateMenuItemRequest represents a menu item update request
 // All fields are optional (pointers) - only provided fields will be updated
 type UpdateMenuItemRequest struct {
-	Name         *string  `json:"name"`
-	Description  *string  `json:"description"`
-	Price        *float64 `json:"price"`
-	ImageURL     *string  `json:"image_url"`
-	DisplayOrder *int     `json:"display_order"`
-	IsAvailable  *bool    `json:"is_available"`
-	CategoryID   *uint    `json:"category_id"`
+	Name         *string      `json:"name"`
+	Description  *string      `json:"description"`
+	Price        *money.Cents `json:"price"`
+	ImageURL     *string      `json:"image_url"`
+	DisplayOrder *int         `json:"display_order"`
+	IsAvailable  *bool        `json:"is_available"`
+	CategoryID   *uint        `json:"category_id"`
+	Calories     *int         `json:"calories" binding:"omitempty,min=0"`
+	ProteinGrams *float64     `json:"protein_grams" binding:"omitempty,min=0"`
+	CarbsGrams   *float64     `json:"carbs_grams" binding:"omitempty,min=0"`
+	FatGrams     *float64     `json:"fat_grams" binding:"omitempty,min=0"`
+
+	// ChannelPrices replaces all channel prices when provided
+	ChannelPrices *ChannelPrices `json:"channel_prices"`
+
+	// Tags replaces the search keywords when provided; an empty list removes them
+	Tags *[]string `json:"tags" binding:"omitempty,max=20,dive,max=50"`
+}
+
+// BulkMenuItemAvailabilityRequest represents an availability toggle for many menu items at once ("86 list")
+type BulkMenuItemAvailabilityRequest struct {
+	MenuItemIDs []uint `json:"menu_item_ids" binding:"required,min=1,max=500,dive,min=1"`
+	IsAvailable *bool  `json:"is_available" binding:"required"`
+	Reason      string `json:"reason" binding:"max=255"` // e.g. "sold out"; recorded in the audit log
 }
@@ -1,18 +1,5 @@
 package dto
 
-// CreateUserDTO represents the data for creating a user
-type CreateUserDTO struct {
-	Email       string `json:"email" binding:"required,email"`
-	Password    string `json:"password" binding:"required,min=8"`
-	FirstName   string `json:"first_name" binding:"required"`
-	LastName    string `json:"last_name" binding:"required"`
-	Role        string `json:"role" binding:"required,oneof=Admin Staff Client"`
-	Phone       string `json:"phone,omitempty"`
-	Timezone    string `json:"timezone,omitempty"`
-	Language    string `json:"language,omitempty"`
-	Preferences string `json:"preferences,omitempty"` // JSON string
-}
-
 // UpdateUserDTO represents the data for updating a user
 type UpdateUserDTO struct {
 	FirstName   string `json:"first_name,omitempty"`
@@ -0,0 +1,166 @@
+// Package escpos builds ESC/POS command streams for thermal receipt and kitchen printers without external dependencies
+package escpos
+
+import (
+	"bytes"
+	"strings"
+	"unicode/utf8"
+)
+
+// ESC/POS control bytes
+const (
+	esc = 0x1b
+	gs  = 0x1d
+	lf  = 0x0a
+)
+
+// codePageWPC1252 selects the Windows-1252 character table (ESC t 16), which covers Latin-1
+const codePageWPC1252 = 16
+
+// Alignment values for Align
+const (
+	AlignLeft   = 0
+	AlignCenter = 1
+	AlignRight  = 2
+)
+
+// Common paper widths in characters of the default font
+const (
+	Width58mm = 32
+	Width80mm = 48
+)
+
+// Document is an ESC/POS command stream built line by line
+// Width is the number of characters per line, used to lay out columns and separators
+type Document struct {
+	buf   bytes.Buffer
+	width int
+}
+
+// New creates a document for a printer with the given line width, starting with printer initialization
+func New(width int) *Document {
+	if width <= 0 {
+		width = Width80mm
+	}
+	d := &Document{width: width}
+	d.buf.Write([]byte{esc, '@'})                  // Initialize printer
+	d.buf.Write([]byte{esc, 't', codePageWPC1252}) // Select character table
+	return d
+}
+
+// Width returns the number of characters per line
+func (d *Document) Width() int {
+	return d.width
+}
+
+// Align sets the alignment of the following lines
+func (d *Document) Align(alignment byte) {
+	d.buf.Write([]byte{esc, 'a', alignment})
+}
+
+// Bold turns emphasized printing on or off
+func (d *Document) Bold(on bool) {
+	d.buf.Write([]byte{esc, 'E', flag(on)})
+}
+
+// Large turns double width and height printing on or off; lines then fit half as many characters
+func (d *Document) Large(on bool) {
+	size := byte(0x00)
+	if on {
+		size = 0x11
+	}
+	d.buf.Write([]byte{gs, '!', size})
+}
+
+// Line prints a line of text, wrapping it at the line width
+func (d *Document) Line(text string) {
+	for _, line := range wrap(text, d.width) {
+		d.write(line)
+		d.buf.WriteByte(lf)
+	}
+}
+
+// Columns prints left and right aligned text on one line, truncating left if both don't fit
+func (d *Document) Columns(left, right string) {
+	space := d.width - utf8.RuneCountInString(right) - 1
+	if space < 1 {
+		d.Line(left + " " + right)
+		return
+	}
+	left = truncate(left, space)
+	padding := d.width - utf8.RuneCountInString(left) - utf8.RuneCountInString(right)
+	d.write(left + strings.Repeat(" ", padding) + right)
+	d.buf.WriteByte(lf)
+}
+
+// Separator prints a full-width dashed line
+func (d *Document) Separator() {
+	d.Line(strings.Repeat("-", d.width))
+}
+
+// Feed prints n empty lines
+func (d *Document) Feed(n int) {
+	d.buf.Write([]byte{esc, 'd', byte(n)})
+}
+
+// Cut feeds the paper past the cutter and partially cuts it
+func (d *Document) Cut() {
+	d.buf.Write([]byte{gs, 'V', 66, 3})
+}
+
+// Bytes returns the command stream
+func (d *Document) Bytes() []byte {
+	return d.buf.Bytes()
+}
+
+// write encodes text in the selected character table
+// Control characters become spaces and characters outside Latin-1 are replaced with '?'
+func (d *Document) write(text string) {
+	for _, r := range text {
+		switch {
+		case r < 32:
+			d.buf.WriteByte(' ')
+		case r < 256:
+			d.buf.WriteByte(byte(r))
+		default:
+			d.buf.WriteByte('?')
+		}
+	}
+}
+
+// wrap splits text into lines of at most width characters, breaking at spaces where possible
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		runes := []rune(paragraph)
+		for len(runes) > width {
+			cut := width
+			for i := width; i > 0; i-- {
+				if runes[i] == ' ' {
+					cut = i
+					break
+				}
+			}
+			lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+			runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+// truncate shortens text to at most width characters
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width])
+}
+
+func flag(on bool) byte {
+	if on {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"fmt"
+	"io"
+
+	"restaurant-backend/internal/money"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// MarshalMoney writes an amount as a number with two decimals, like the REST API
+func MarshalMoney(amount money.Cents) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, amount.String())
+	})
+}
+
+// UnmarshalMoney reads an amount from a number or a numeric string, rounding to whole cents
+func UnmarshalMoney(v interface{}) (money.Cents, error) {
+	switch v := v.(type) {
+	case string:
+		return money.Parse(v)
+	case float64:
+		return money.FromFloat(v), nil
+	case int64:
+		return money.Cents(v * 100), nil
+	case int:
+		return money.Cents(v * 100), nil
+	default:
+		return 0, fmt.Errorf("%T is not an amount", v)
+	}
+}
@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"restaurant-backend/internal/apperrors"
+	tenantctx "restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// Resolver serves the GraphQL read models from the same repositories the REST handlers read
+// Every query is scoped to the restaurant of the request's tenant context
+type Resolver struct {
+	restaurantRepo  *repositories.RestaurantRepository
+	categoryRepo    *repositories.CategoryRepository
+	menuItemRepo    *repositories.MenuItemRepository
+	orderRepo       *repositories.OrderRepository
+	reservationRepo *repositories.ReservationRepository
+}
+
+// NewResolver creates a new Resolver instance
+func NewResolver(
+	restaurantRepo *repositories.RestaurantRepository,
+	categoryRepo *repositories.CategoryRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	orderRepo *repositories.OrderRepository,
+	reservationRepo *repositories.ReservationRepository,
+) *Resolver {
+	return &Resolver{
+		restaurantRepo:  restaurantRepo,
+		categoryRepo:    categoryRepo,
+		menuItemRepo:    menuItemRepo,
+		orderRepo:       orderRepo,
+		reservationRepo: reservationRepo,
+	}
+}
+
+// requestRestaurantID returns the restaurant of the request's tenant context
+func requestRestaurantID(ctx context.Context) (uint, error) {
+	restaurantID, ok := tenantctx.GetRestaurantID(ctx)
+	if !ok {
+		return 0, apperrors.ErrRestaurantContextMissing
+	}
+	return restaurantID, nil
+}
+
+// notFoundAsNull resolves a record that doesn't exist (or belongs to another restaurant) as null
+func notFoundAsNull[T any](record *T, err error) (*T, error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, err
+}
@@ -0,0 +1,97 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.76
+
+import (
+	"context"
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"time"
+)
+
+// Restaurant is the resolver for the restaurant field.
+func (r *queryResolver) Restaurant(ctx context.Context) (*models.Restaurant, error) {
+	restaurantID, err := requestRestaurantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurant, err := r.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+	return restaurant, nil
+}
+
+// MenuItem is the resolver for the menuItem field.
+func (r *queryResolver) MenuItem(ctx context.Context, id uint) (*models.MenuItem, error) {
+	restaurantID, err := requestRestaurantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return notFoundAsNull(r.menuItemRepo.GetByIDForRestaurant(ctx, id, restaurantID))
+}
+
+// Orders is the resolver for the orders field.
+func (r *queryResolver) Orders(ctx context.Context, userID *uint) ([]models.Order, error) {
+	restaurantID, err := requestRestaurantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID != nil {
+		return r.orderRepo.GetByUserIDWithContext(ctx, restaurantID, *userID)
+	}
+	return r.orderRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// Order is the resolver for the order field.
+func (r *queryResolver) Order(ctx context.Context, id uint) (*models.Order, error) {
+	restaurantID, err := requestRestaurantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return notFoundAsNull(r.orderRepo.GetByIDForRestaurant(ctx, id, restaurantID))
+}
+
+// Reservations is the resolver for the reservations field.
+func (r *queryResolver) Reservations(ctx context.Context, date *string) ([]models.Reservation, error) {
+	restaurantID, err := requestRestaurantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if date != nil {
+		day, err := time.Parse("2006-01-02", *date)
+		if err != nil {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid date, expected YYYY-MM-DD")
+		}
+		return r.reservationRepo.GetByDateWithContext(ctx, restaurantID, day)
+	}
+	return r.reservationRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// Reservation is the resolver for the reservation field.
+func (r *queryResolver) Reservation(ctx context.Context, id uint) (*models.Reservation, error) {
+	restaurantID, err := requestRestaurantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return notFoundAsNull(r.reservationRepo.GetByIDForRestaurant(ctx, id, restaurantID))
+}
+
+// Categories is the resolver for the categories field.
+func (r *restaurantResolver) Categories(ctx context.Context, obj *models.Restaurant) ([]models.MenuCategory, error) {
+	return r.categoryRepo.GetMenuByRestaurantIDWithContext(ctx, obj.ID)
+}
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Restaurant returns RestaurantResolver implementation.
+func (r *Resolver) Restaurant() RestaurantResolver { return &restaurantResolver{r} }
+
+type queryResolver struct{ *Resolver }
+type restaurantResolver struct{ *Resolver }
@@ -0,0 +1,257 @@
+//go:build integration
+
+package graph_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/database"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/pii"
+	"restaurant-backend/internal/router"
+	"restaurant-backend/internal/services"
+
+	"gorm.io/gorm"
+)
+
+// tenantFixture is a restaurant with one record of every kind the GraphQL API serves
+type tenantFixture struct {
+	restaurant  models.Restaurant
+	admin       models.User
+	category    models.MenuCategory
+	menuItem    models.MenuItem
+	image       models.MenuItemImage
+	order       models.Order
+	reservation models.Reservation
+}
+
+// createTenantFixture creates a restaurant with its admin, menu, an order and a reservation, deleted after the test
+func createTenantFixture(t *testing.T, db *gorm.DB, name string) *tenantFixture {
+	t.Helper()
+	suffix := time.Now().UnixNano()
+	f := &tenantFixture{}
+
+	f.restaurant = models.Restaurant{
+		Name:       name,
+		Email:      fmt.Sprintf("graphql-%d@example.com", suffix),
+		Status:     models.RestaurantStatusActive,
+		Visibility: models.RestaurantVisibilityPublic,
+	}
+	if err := db.Create(&f.restaurant).Error; err != nil {
+		t.Fatalf("failed to create restaurant %s: %v", name, err)
+	}
+	restaurantID := f.restaurant.ID
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM order_items WHERE restaurant_id = ?", restaurantID)
+		db.Exec("DELETE FROM orders WHERE restaurant_id = ?", restaurantID)
+		db.Exec("DELETE FROM reservations WHERE restaurant_id = ?", restaurantID)
+		db.Exec("DELETE FROM menu_item_images WHERE restaurant_id = ?", restaurantID)
+		db.Exec("DELETE FROM menu_items WHERE restaurant_id = ?", restaurantID)
+		db.Exec("DELETE FROM menu_categories WHERE restaurant_id = ?", restaurantID)
+		db.Exec("DELETE FROM user_sessions WHERE user_id IN (SELECT id FROM users WHERE restaurant_id = ?)", restaurantID)
+		db.Exec("DELETE FROM users WHERE restaurant_id = ?", restaurantID)
+		db.Exec("DELETE FROM restaurants WHERE id = ?", restaurantID)
+	})
+
+	f.admin = models.User{
+		RestaurantID: restaurantID,
+		Email:        fmt.Sprintf("graphql-admin-%d@example.com", suffix),
+		PasswordHash: "-",
+		Role:         "Admin",
+		IsActive:     true,
+	}
+	if err := db.Create(&f.admin).Error; err != nil {
+		t.Fatalf("failed to create admin of %s: %v", name, err)
+	}
+
+	f.category = models.MenuCategory{RestaurantID: restaurantID, Name: name + " Mains", IsActive: true}
+	if err := db.Create(&f.category).Error; err != nil {
+		t.Fatalf("failed to create category of %s: %v", name, err)
+	}
+	f.menuItem = models.MenuItem{RestaurantID: restaurantID, CategoryID: f.category.ID, Name: name + " Burger", Price: 1250, IsAvailable: true}
+	if err := db.Create(&f.menuItem).Error; err != nil {
+		t.Fatalf("failed to create menu item of %s: %v", name, err)
+	}
+	f.image = models.MenuItemImage{RestaurantID: restaurantID, MenuItemID: f.menuItem.ID, ImageURL: "https://example.com/burger.jpg", IsPrimary: true}
+	if err := db.Create(&f.image).Error; err != nil {
+		t.Fatalf("failed to create menu item image of %s: %v", name, err)
+	}
+
+	f.order = models.Order{RestaurantID: restaurantID, UserID: f.admin.ID, TotalAmount: 1250}
+	if err := db.Create(&f.order).Error; err != nil {
+		t.Fatalf("failed to create order of %s: %v", name, err)
+	}
+	item := models.OrderItem{RestaurantID: restaurantID, OrderID: f.order.ID, MenuItemID: f.menuItem.ID, Quantity: 1, Price: 1250, Name: f.menuItem.Name}
+	if err := db.Create(&item).Error; err != nil {
+		t.Fatalf("failed to create order item of %s: %v", name, err)
+	}
+
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+	f.reservation = models.Reservation{
+		RestaurantID:   restaurantID,
+		UserID:         f.admin.ID,
+		TableNumber:    "T1",
+		StartTime:      start,
+		EndTime:        start.Add(90 * time.Minute),
+		NumberOfGuests: 2,
+	}
+	if err := db.Create(&f.reservation).Error; err != nil {
+		t.Fatalf("failed to create reservation of %s: %v", name, err)
+	}
+	return f
+}
+
+// graphQLResponse is the part of a GraphQL response the test reads
+type graphQLResponse struct {
+	Data struct {
+		Restaurant struct {
+			ID         string `json:"id"`
+			Categories []struct {
+				ID        string `json:"id"`
+				MenuItems []struct {
+					ID     string `json:"id"`
+					Images []struct {
+						ID string `json:"id"`
+					} `json:"images"`
+				} `json:"menuItems"`
+			} `json:"categories"`
+		} `json:"restaurant"`
+		Orders []struct {
+			ID string `json:"id"`
+		} `json:"orders"`
+		Reservations []struct {
+			ID string `json:"id"`
+		} `json:"reservations"`
+		Order       *struct{ ID string } `json:"order"`
+		Reservation *struct{ ID string } `json:"reservation"`
+		MenuItem    *struct{ ID string } `json:"menuItem"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// TestGraphQLTenantIsolation queries the GraphQL API through the router as the admin of one restaurant and checks
+// nothing of another restaurant comes back, neither in the lists nor when asked for by ID
+// Needs a migrated test database configured like the server (DB_* variables): go test -tags integration
+func TestGraphQLTenantIsolation(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := logger.Initialize(cfg.Environment); err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+	if err := pii.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("failed to initialize PII encryption: %v", err)
+	}
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+	if err := database.RunMigrations(db, cfg); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	deps := container.New(cfg, db)
+	engine := router.SetupRouter(deps)
+
+	own := createTenantFixture(t, db, "GraphQL Tenant A")
+	other := createTenantFixture(t, db, "GraphQL Tenant B")
+
+	login, err := deps.Auth.CompleteLogin(context.Background(), &own.admin, services.ClientInfo{IPAddress: "127.0.0.1", UserAgent: "graphql-test"})
+	if err != nil {
+		t.Fatalf("failed to log in as the admin of A: %v", err)
+	}
+
+	id := func(n uint) string { return strconv.FormatUint(uint64(n), 10) }
+	query := fmt.Sprintf(`{
+		restaurant { id categories { id menuItems { id images { id } } } }
+		orders { id }
+		reservations { id }
+		order(id: %q) { id }
+		reservation(id: %q) { id }
+		menuItem(id: %q) { id }
+	}`, id(other.order.ID), id(other.reservation.ID), id(other.menuItem.ID))
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		t.Fatalf("failed to encode the query: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+login.Token)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d: %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp graphQLResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode the response: %v", err)
+	}
+	if len(resp.Errors) > 0 {
+		t.Fatalf("got errors %+v", resp.Errors)
+	}
+
+	data := resp.Data
+	if data.Restaurant.ID != id(own.restaurant.ID) {
+		t.Errorf("restaurant: got %s, want %s", data.Restaurant.ID, id(own.restaurant.ID))
+	}
+	for _, category := range data.Restaurant.Categories {
+		if category.ID == id(other.category.ID) {
+			t.Errorf("restaurant categories include category %s of B", category.ID)
+		}
+		for _, item := range category.MenuItems {
+			if item.ID == id(other.menuItem.ID) {
+				t.Errorf("restaurant menu includes menu item %s of B", item.ID)
+			}
+			for _, image := range item.Images {
+				if image.ID == id(other.image.ID) {
+					t.Errorf("restaurant menu includes menu item image %s of B", image.ID)
+				}
+			}
+		}
+	}
+	if len(data.Restaurant.Categories) != 1 || data.Restaurant.Categories[0].ID != id(own.category.ID) {
+		t.Errorf("restaurant categories: got %+v, want only category %d of A", data.Restaurant.Categories, own.category.ID)
+	}
+
+	for _, order := range data.Orders {
+		if order.ID == id(other.order.ID) {
+			t.Errorf("orders include order %s of B", order.ID)
+		}
+	}
+	if len(data.Orders) != 1 || data.Orders[0].ID != id(own.order.ID) {
+		t.Errorf("orders: got %+v, want only order %d of A", data.Orders, own.order.ID)
+	}
+	for _, reservation := range data.Reservations {
+		if reservation.ID == id(other.reservation.ID) {
+			t.Errorf("reservations include reservation %s of B", reservation.ID)
+		}
+	}
+	if len(data.Reservations) != 1 || data.Reservations[0].ID != id(own.reservation.ID) {
+		t.Errorf("reservations: got %+v, want only reservation %d of A", data.Reservations, own.reservation.ID)
+	}
+
+	if data.Order != nil {
+		t.Errorf("order %d of B: got %+v, want null", other.order.ID, data.Order)
+	}
+	if data.Reservation != nil {
+		t.Errorf("reservation %d of B: got %+v, want null", other.reservation.ID, data.Reservation)
+	}
+	if data.MenuItem != nil {
+		t.Errorf("menu item %d of B: got %+v, want null", other.menuItem.ID, data.MenuItem)
+	}
+}
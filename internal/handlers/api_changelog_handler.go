@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIChangelogHandler handles API changelog and deprecation requests
+type APIChangelogHandler struct {
+	changelogService *services.APIChangelogService
+}
+
+// NewAPIChangelogHandler creates a new APIChangelogHandler instance
+func NewAPIChangelogHandler(changelogService *services.APIChangelogService) *APIChangelogHandler {
+	return &APIChangelogHandler{
+		changelogService: changelogService,
+	}
+}
+
+// GetChangelog handles getting the API changelog
+// @Summary Get API Changelog
+// @Description Get machine-readable API changelog entries and upcoming endpoint deprecations
+// @Tags changelog
+// @Produce json
+// @Param since query string false "Only entries published after this time (RFC 3339)"
+// @Success 200 {object} services.APIChangelog
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/changelog [get]
+func (h *APIChangelogHandler) GetChangelog(c *gin.Context) {
+	var since time.Time
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid since parameter, expected RFC 3339"))
+			return
+		}
+		since = parsed
+	}
+
+	changelog, err := h.changelogService.GetChangelog(c.Request.Context(), since)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changelog)
+}
+
+// CreateEntry handles publishing a changelog entry
+// @Summary Create API Changelog Entry
+// @Description Publish an API changelog entry. Deprecations (with method and path) add Deprecation/Sunset headers to the endpoint's responses
+// @Tags platform
+// @Accept json
+// @Produce json
+// @Param request body services.CreateChangelogEntryRequest true "Changelog entry"
+// @Success 201 {object} models.APIChangelogEntry
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/platform/changelog [post]
+func (h *APIChangelogHandler) CreateEntry(c *gin.Context) {
+	var req services.CreateChangelogEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	entry, err := h.changelogService.CreateEntry(c.Request.Context(), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// DeleteEntry handles removing a changelog entry
+// @Summary Delete API Changelog Entry
+// @Description Delete an API changelog entry
+// @Tags platform
+// @Param id path int true "Changelog entry ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/platform/changelog/{id} [delete]
+func (h *APIChangelogHandler) DeleteEntry(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid changelog entry ID"))
+		return
+	}
+
+	if err := h.changelogService.DeleteEntry(c.Request.Context(), uint(id)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ApprovalHandler handles the queue of Staff changes waiting for an Admin's approval
+type ApprovalHandler struct {
+	approvalService *services.ApprovalService
+}
+
+// NewApprovalHandler creates a new ApprovalHandler instance
+func NewApprovalHandler(approvalService *services.ApprovalService) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+	}
+}
+
+// submitForApproval queues the current user's change for approval when the restaurant requires it and responds
+// 202 with the pending change. It reports whether the request was handled (queued or failed)
+func submitForApproval(c *gin.Context, approvals *services.ApprovalService, restaurantID uint, changeType string, entityID uint, payload interface{}) bool {
+	change, err := approvals.Submit(c.Request.Context(), restaurantID, changeType, entityID, payload)
+	if err != nil {
+		_ = c.Error(err)
+		return true
+	}
+	if change == nil {
+		return false
+	}
+
+	c.JSON(http.StatusAccepted, change)
+	return true
+}
+
+// ListPendingChanges handles listing the changes waiting for approval
+// @Summary List Pending Changes
+// @Description List the Staff changes queued for approval (price changes, deletions and order cancellations, as required by the restaurant's require_approval_* settings), oldest first while pending. Staff users only see their own
+// @Tags approvals
+// @Produce json
+// @Param status query string false "pending, approved or rejected; all when omitted"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.PendingChangeList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/approvals [get]
+func (h *ApprovalHandler) ListPendingChanges(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", models.PendingChangeStatusPending, models.PendingChangeStatusApproved, models.PendingChangeStatusRejected:
+	default:
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid status parameter"))
+		return
+	}
+
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.approvalService.ListPendingChanges(c.Request.Context(), restaurantID, status, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ApproveChange handles approving a pending change
+// @Summary Approve Pending Change
+// @Description Approve a Staff change and make it; the requester is notified. If the change can no longer be made (e.g. the menu item was deleted or the order completed meanwhile) the error is returned and the change stays pending
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Param id path int true "Pending Change ID"
+// @Param request body services.ReviewPendingChangeRequest false "Review note"
+// @Success 200 {object} models.PendingChange
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/approvals/{id}/approve [post]
+func (h *ApprovalHandler) ApproveChange(c *gin.Context) {
+	h.review(c, h.approvalService.Approve)
+}
+
+// RejectChange handles rejecting a pending change
+// @Summary Reject Pending Change
+// @Description Reject a Staff change without making it; the requester is notified with the note
+// @Tags approvals
+// @Accept json
+// @Produce json
+// @Param id path int true "Pending Change ID"
+// @Param request body services.ReviewPendingChangeRequest false "Review note"
+// @Success 200 {object} models.PendingChange
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/approvals/{id}/reject [post]
+func (h *ApprovalHandler) RejectChange(c *gin.Context) {
+	h.review(c, h.approvalService.Reject)
+}
+
+// review binds a review of a pending change and records it with the given service method
+func (h *ApprovalHandler) review(c *gin.Context, resolve func(context.Context, uint, uint, uint, *services.ReviewPendingChangeRequest) (*models.PendingChange, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid pending change ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	// The body is optional
+	var req services.ReviewPendingChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	change, err := resolve(c.Request.Context(), restaurantID, userID, uint(id), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, change)
+}
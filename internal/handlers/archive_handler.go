@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ArchiveHandler handles reading the archived orders and reservations
+type ArchiveHandler struct {
+	archiveService *services.ArchiveService
+}
+
+// NewArchiveHandler creates a new ArchiveHandler instance
+func NewArchiveHandler(archiveService *services.ArchiveService) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiveService: archiveService,
+	}
+}
+
+// ListArchivedOrders handles listing archived orders
+// @Summary List Archived Orders
+// @Description List the completed and cancelled orders moved to the archive (older than archive_after_days in the restaurant settings) that were created between two dates in the restaurant's time zone, newest first. Each carries the full order, its items and status history in data. Defaults to the last year
+// @Tags archive
+// @Produce json
+// @Param from query string false "First date (YYYY-MM-DD)"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.ArchivedOrderList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/archive/orders [get]
+func (h *ArchiveHandler) ListArchivedOrders(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	orders, err := h.archiveService.ListOrders(c.Request.Context(), restaurantID, c.Query("from"), c.Query("to"), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// ListArchivedReservations handles listing archived reservations
+// @Summary List Archived Reservations
+// @Description List the reservations moved to the archive (ended longer than archive_after_days in the restaurant settings ago) that started between two dates in the restaurant's time zone, latest first. Each carries the full reservation in data. Defaults to the last year
+// @Tags archive
+// @Produce json
+// @Param from query string false "First date (YYYY-MM-DD)"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.ArchivedReservationList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/archive/reservations [get]
+func (h *ArchiveHandler) ListArchivedReservations(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	reservations, err := h.archiveService.ListReservations(c.Request.Context(), restaurantID, c.Query("from"), c.Query("to"), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservations)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/services"
 
@@ -11,13 +12,15 @@ import (
 
 // AuthHandler handles authentication requests
 type AuthHandler struct {
-	authService *services.AuthService
+	authService         *services.AuthService
+	verificationService *services.EmailVerificationService
 }
 
 // NewAuthHandler creates a new AuthHandler instance
-func NewAuthHandler(authService *services.AuthService) *AuthHandler {
+func NewAuthHandler(authService *services.AuthService, verificationService *services.EmailVerificationService) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
+		authService:         authService,
+		verificationService: verificationService,
 	}
 }
 
@@ -29,20 +32,21 @@ func NewAuthHandler(authService *services.AuthService) *AuthHandler {
 // @Produce json
 // @Param request body services.LoginRequest true "Login request"
 // @Success 200 {object} services.LoginResponse
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 401 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
 // @Router /api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req services.LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	// pass request context down to service for cancellation/traceability
-	response, err := h.authService.Login(c.Request.Context(), &req)
+	response, err := h.authService.Login(c.Request.Context(), &req, services.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()})
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -51,19 +55,19 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 // Register handles user registration
 // @Summary Register
-// @Description Register a new user (restaurant_id required except for KAM role)
+// @Description Register a new user (restaurant_id required except for KAM role). A verification link is emailed; login is refused until it's followed
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body services.RegisterRequest true "Register request"
 // @Success 201 {object} models.User
-// @Failure 400 {object} map[string]string
-// @Failure 409 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req services.RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
@@ -77,13 +81,65 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	user, err := h.authService.Register(c.Request.Context(), &req)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "user with this email already exists" {
-			statusCode = http.StatusConflict
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		_ = c.Error(err)
+		return
+	}
+
+	if err := h.verificationService.Send(c.Request.Context(), user); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, user)
 }
+
+// VerifyEmail handles redeeming an email verification link
+// @Summary Verify Email
+// @Description Verify a registered user's email address with the token from the emailed link and sign them in
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.VerifyEmailRequest true "Verification token"
+// @Success 200 {object} services.LoginResponse
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/auth/verify-email [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req services.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	response, err := h.verificationService.Verify(c.Request.Context(), req.Token, services.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResendVerification handles requesting a new email verification link
+// @Summary Resend Verification Email
+// @Description Email a new verification link to an unverified account; limited to one per minute and five per hour. Always accepted for unknown addresses
+// @Tags auth
+// @Accept json
+// @Param request body services.ResendVerificationRequest true "Email address"
+// @Success 202
+// @Failure 400 {object} apperrors.Response
+// @Failure 429 {object} apperrors.Response
+// @Router /api/v1/auth/verify-email/resend [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req services.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	if err := h.verificationService.Resend(c.Request.Context(), req.Email); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
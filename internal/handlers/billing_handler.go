@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BillingHandler handles platform usage metering and invoice requests
+type BillingHandler struct {
+	billingService *services.BillingService
+}
+
+// NewBillingHandler creates a new BillingHandler instance
+func NewBillingHandler(billingService *services.BillingService) *BillingHandler {
+	return &BillingHandler{
+		billingService: billingService,
+	}
+}
+
+// ListUsage handles listing a month's per-tenant usage
+// @Summary List Usage
+// @Description List metered usage (orders processed, emails sent, storage bytes) of every restaurant for a month. Orders and storage are measured when invoices are generated
+// @Tags platform
+// @Produce json
+// @Param period query string true "Billing period (YYYY-MM)"
+// @Success 200 {array} models.UsageRecord
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/platform/billing/usage [get]
+func (h *BillingHandler) ListUsage(c *gin.Context) {
+	periodStart, err := services.ParseBillingPeriod(c.Query("period"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	usage, err := h.billingService.ListUsage(c.Request.Context(), periodStart)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
+
+// GenerateInvoices handles generating a month's draft invoices
+// @Summary Generate Invoices
+// @Description Meter a month's usage and create a draft invoice per restaurant. Issued invoices are never changed; existing drafts are rebuilt only with regenerate
+// @Tags platform
+// @Accept json
+// @Produce json
+// @Param request body services.GenerateInvoicesRequest true "Billing period"
+// @Success 200 {object} services.InvoiceRunResult
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/platform/billing/invoices/generate [post]
+func (h *BillingHandler) GenerateInvoices(c *gin.Context) {
+	var req services.GenerateInvoicesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	periodStart, err := services.ParseBillingPeriod(req.Period)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	result, err := h.billingService.GenerateInvoices(c.Request.Context(), periodStart, req.Regenerate)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListInvoices handles listing invoices
+// @Summary List Invoices
+// @Description List invoices, optionally filtered by period, restaurant and status
+// @Tags platform
+// @Produce json
+// @Param period query string false "Billing period (YYYY-MM)"
+// @Param restaurant_id query int false "Restaurant ID"
+// @Param status query string false "Invoice status (draft, issued)"
+// @Success 200 {array} models.Invoice
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/platform/billing/invoices [get]
+func (h *BillingHandler) ListInvoices(c *gin.Context) {
+	filter, err := invoiceFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	invoices, err := h.billingService.ListInvoices(c.Request.Context(), filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoices)
+}
+
+// ExportInvoices handles exporting invoices as CSV
+// @Summary Export Invoices
+// @Description Export invoices as CSV (one row per invoice line), filtered like List Invoices
+// @Tags platform
+// @Produce text/csv
+// @Param period query string false "Billing period (YYYY-MM)"
+// @Param restaurant_id query int false "Restaurant ID"
+// @Param status query string false "Invoice status (draft, issued)"
+// @Success 200 {file} file
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/platform/billing/invoices/export [get]
+func (h *BillingHandler) ExportInvoices(c *gin.Context) {
+	filter, err := invoiceFilter(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	invoices, err := h.billingService.ListInvoices(c.Request.Context(), filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.billingService.WriteInvoicesCSV(&buf, invoices); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	filename := "invoices.csv"
+	if period := c.Query("period"); period != "" {
+		filename = fmt.Sprintf("invoices-%s.csv", period)
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// GetInvoice handles getting an invoice
+// @Summary Get Invoice
+// @Description Get an invoice with its lines
+// @Tags platform
+// @Produce json
+// @Param id path int true "Invoice ID"
+// @Success 200 {object} models.Invoice
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/platform/billing/invoices/{id} [get]
+func (h *BillingHandler) GetInvoice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid invoice ID"))
+		return
+	}
+
+	invoice, err := h.billingService.GetInvoice(c.Request.Context(), uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+// GetInvoicePDF handles downloading an invoice as PDF
+// @Summary Download Invoice PDF
+// @Description Render an invoice as a PDF document
+// @Tags platform
+// @Produce application/pdf
+// @Param id path int true "Invoice ID"
+// @Success 200 {file} file
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/platform/billing/invoices/{id}/pdf [get]
+func (h *BillingHandler) GetInvoicePDF(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid invoice ID"))
+		return
+	}
+
+	invoice, err := h.billingService.GetInvoice(c.Request.Context(), uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="invoice-%d.pdf"`, invoice.ID))
+	c.Data(http.StatusOK, "application/pdf", h.billingService.RenderInvoicePDF(invoice))
+}
+
+// IssueInvoice handles finalizing a draft invoice
+// @Summary Issue Invoice
+// @Description Finalize a draft invoice; issued invoices are no longer regenerated
+// @Tags platform
+// @Produce json
+// @Param id path int true "Invoice ID"
+// @Success 200 {object} models.Invoice
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/platform/billing/invoices/{id}/issue [post]
+func (h *BillingHandler) IssueInvoice(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid invoice ID"))
+		return
+	}
+
+	invoice, err := h.billingService.IssueInvoice(c.Request.Context(), uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+// invoiceFilter reads the invoice list filters from the query string
+func invoiceFilter(c *gin.Context) (repositories.InvoiceFilter, error) {
+	var filter repositories.InvoiceFilter
+	if period := c.Query("period"); period != "" {
+		periodStart, err := services.ParseBillingPeriod(period)
+		if err != nil {
+			return filter, err
+		}
+		filter.PeriodStart = &periodStart
+	}
+	if raw := c.Query("restaurant_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return filter, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID")
+		}
+		restaurantID := uint(id)
+		filter.RestaurantID = &restaurantID
+	}
+	if raw := c.Query("status"); raw != "" {
+		status := models.InvoiceStatus(raw)
+		filter.Status = &status
+	}
+	return filter, nil
+}
@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BookingChannelHandler handles external booking channel connections and the partner API the channels call
+type BookingChannelHandler struct {
+	channelService     *services.BookingChannelService
+	reservationService *services.ReservationService
+}
+
+// NewBookingChannelHandler creates a new BookingChannelHandler instance
+func NewBookingChannelHandler(channelService *services.BookingChannelService, reservationService *services.ReservationService) *BookingChannelHandler {
+	return &BookingChannelHandler{
+		channelService:     channelService,
+		reservationService: reservationService,
+	}
+}
+
+// ListChannels handles listing the restaurant's booking channels
+// @Summary List Booking Channels
+// @Description List the external booking channels (Google Reserve) the restaurant is connected to, with the last error pushing to them
+// @Tags integrations
+// @Produce json
+// @Success 200 {array} models.BookingChannel
+// @Router /api/v1/integrations/booking-channels [get]
+func (h *BookingChannelHandler) ListChannels(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	channels, err := h.channelService.ListChannels(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, channels)
+}
+
+// CreateChannel handles connecting a booking channel
+// @Summary Create Booking Channel
+// @Description Connect the restaurant to an external booking channel. The response contains the token the channel calls the partner API with, which is shown only once. Channel bookings become confirmed reservations with the provider as source
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param request body services.CreateBookingChannelRequest true "Booking channel"
+// @Success 201 {object} services.BookingChannelCredentials
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/integrations/booking-channels [post]
+func (h *BookingChannelHandler) CreateChannel(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.CreateBookingChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	credentials, err := h.channelService.CreateChannel(c.Request.Context(), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, credentials)
+}
+
+// UpdateChannel handles updating a booking channel
+// @Summary Update Booking Channel
+// @Description Update the merchant ID or access token of a booking channel, or disable it; a disabled channel's partner API calls are rejected
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path int true "Booking channel ID"
+// @Param request body services.UpdateBookingChannelRequest true "Booking channel updates"
+// @Success 200 {object} models.BookingChannel
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/integrations/booking-channels/{id} [put]
+func (h *BookingChannelHandler) UpdateChannel(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid booking channel ID"))
+		return
+	}
+
+	var req services.UpdateBookingChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	channel, err := h.channelService.UpdateChannel(c.Request.Context(), uint(id), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, channel)
+}
+
+// DeleteChannel handles disconnecting a booking channel
+// @Summary Delete Booking Channel
+// @Description Disconnect a booking channel; its reservations are kept
+// @Tags integrations
+// @Param id path int true "Booking channel ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/integrations/booking-channels/{id} [delete]
+func (h *BookingChannelHandler) DeleteChannel(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid booking channel ID"))
+		return
+	}
+
+	if err := h.channelService.DeleteChannel(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RotateChannelToken handles regenerating a booking channel's partner API token
+// @Summary Rotate Booking Channel Token
+// @Description Generate a new partner API token for a booking channel; calls with the previous token are rejected
+// @Tags integrations
+// @Produce json
+// @Param id path int true "Booking channel ID"
+// @Success 200 {object} services.BookingChannelCredentials
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/integrations/booking-channels/{id}/token [post]
+func (h *BookingChannelHandler) RotateChannelToken(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid booking channel ID"))
+		return
+	}
+
+	credentials, err := h.channelService.RotateToken(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, credentials)
+}
+
+// SearchChannelAvailability handles a booking channel searching reservation times
+// @Summary Search Availability (Booking Channel)
+// @Description List a day's reservation start times with whether the party can book then, as for the restaurant's own availability search. Authenticated by the booking channel token
+// @Tags booking-channels
+// @Produce json
+// @Param token path string true "Booking channel token"
+// @Param date query string true "Date (YYYY-MM-DD) in the restaurant's time zone"
+// @Param party_size query int true "Number of guests"
+// @Param duration_minutes query int false "Booking length in minutes" default(90)
+// @Success 200 {object} services.ReservationAvailability
+// @Failure 400 {object} apperrors.Response
+// @Failure 401 {object} apperrors.Response
+// @Router /api/v1/public/booking-channels/{token}/availability [get]
+func (h *BookingChannelHandler) SearchChannelAvailability(c *gin.Context) {
+	channel, err := h.channelService.Authenticate(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req services.AvailabilityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	availability, err := h.reservationService.SearchAvailability(c.Request.Context(), channel.RestaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// CreateChannelBooking handles a booking channel writing a booking
+// @Summary Create Booking (Booking Channel)
+// @Description Book a confirmed reservation at an available start time, seating the party at the smallest free table. Writes are idempotent by external_id: a retried write returns the existing reservation with 200. Authenticated by the booking channel token
+// @Tags booking-channels
+// @Accept json
+// @Produce json
+// @Param token path string true "Booking channel token"
+// @Param request body services.ChannelBookingRequest true "Booking"
+// @Success 201 {object} models.Reservation
+// @Success 200 {object} models.Reservation "Already booked"
+// @Failure 400 {object} apperrors.Response
+// @Failure 401 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response "No table is available at the requested time"
+// @Router /api/v1/public/booking-channels/{token}/bookings [post]
+func (h *BookingChannelHandler) CreateChannelBooking(c *gin.Context) {
+	channel, err := h.channelService.Authenticate(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var req services.ChannelBookingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	reservation, created, err := h.reservationService.CreateChannelBooking(c.Request.Context(), channel, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	c.JSON(status, reservation)
+}
+
+// GetChannelBooking handles a booking channel reading one of its bookings
+// @Summary Get Booking (Booking Channel)
+// @Description Get the reservation of a channel booking, e.g. to learn it was cancelled or completed. Authenticated by the booking channel token
+// @Tags booking-channels
+// @Produce json
+// @Param token path string true "Booking channel token"
+// @Param external_id path string true "The channel's booking ID"
+// @Success 200 {object} models.Reservation
+// @Failure 401 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/booking-channels/{token}/bookings/{external_id} [get]
+func (h *BookingChannelHandler) GetChannelBooking(c *gin.Context) {
+	channel, err := h.channelService.Authenticate(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	reservation, err := h.reservationService.GetChannelBooking(c.Request.Context(), channel, c.Param("external_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
+
+// CancelChannelBooking handles a booking channel cancelling one of its bookings
+// @Summary Cancel Booking (Booking Channel)
+// @Description Cancel the reservation of a channel booking; cancelling an already cancelled booking succeeds. Authenticated by the booking channel token
+// @Tags booking-channels
+// @Produce json
+// @Param token path string true "Booking channel token"
+// @Param external_id path string true "The channel's booking ID"
+// @Success 200 {object} models.Reservation
+// @Failure 401 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response "The reservation is completed"
+// @Router /api/v1/public/booking-channels/{token}/bookings/{external_id}/cancel [post]
+func (h *BookingChannelHandler) CancelChannelBooking(c *gin.Context) {
+	channel, err := h.channelService.Authenticate(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	reservation, err := h.reservationService.CancelChannelBooking(c.Request.Context(), channel, c.Param("external_id"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reservation)
+}
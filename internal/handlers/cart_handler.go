@@ -0,0 +1,248 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CartHandler handles the carts of the public ordering flow
+type CartHandler struct {
+	cartService *services.CartService
+}
+
+// NewCartHandler creates a new CartHandler instance
+func NewCartHandler(cartService *services.CartService) *CartHandler {
+	return &CartHandler{
+		cartService: cartService,
+	}
+}
+
+// CreateCartPublic handles creating an anonymous cart (public access)
+// @Summary Create Cart (Public)
+// @Description Create a cart for a restaurant's online ordering. Keep the returned token to resume the cart on any device until it expires; leave an email to be sent a reminder if the cart is abandoned (restaurants opt in). No authentication required
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Param request body services.CartRequest true "Cart contents"
+// @Success 201 {object} services.CartView
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/carts [post]
+func (h *CartHandler) CreateCartPublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	var req services.CartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	cart, err := h.cartService.CreateCart(c.Request.Context(), uint(restaurantID), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, cart)
+}
+
+// GetCartPublic handles getting a cart by its token (public access)
+// @Summary Get Cart (Public)
+// @Description Get a cart with its items priced at the current menu prices; items no longer available are listed but not counted in the subtotal. No authentication required
+// @Tags carts
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Param token path string true "Cart token"
+// @Success 200 {object} services.CartView
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/carts/{token} [get]
+func (h *CartHandler) GetCartPublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	cart, err := h.cartService.GetCart(c.Request.Context(), uint(restaurantID), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// UpdateCartPublic handles replacing the contents of a cart (public access)
+// @Summary Update Cart (Public)
+// @Description Replace the items, email and fulfillment type of a cart; this extends its expiry. No authentication required
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Param token path string true "Cart token"
+// @Param request body services.CartRequest true "Cart contents"
+// @Success 200 {object} services.CartView
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/carts/{token} [put]
+func (h *CartHandler) UpdateCartPublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	var req services.CartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	cart, err := h.cartService.UpdateCart(c.Request.Context(), uint(restaurantID), c.Param("token"), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// DeleteCartPublic handles deleting a cart (public access)
+// @Summary Delete Cart (Public)
+// @Description Delete a cart for good. No authentication required
+// @Tags carts
+// @Param restaurant_id path int true "Restaurant ID"
+// @Param token path string true "Cart token"
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/carts/{token} [delete]
+func (h *CartHandler) DeleteCartPublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	if err := h.cartService.DeleteCart(c.Request.Context(), uint(restaurantID), c.Param("token")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetMyCart handles getting the logged-in customer's cart
+// @Summary Get My Cart
+// @Description Get the logged-in customer's cart at the current restaurant, from whichever device it was saved
+// @Tags carts
+// @Produce json
+// @Success 200 {object} services.CartView
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/cart [get]
+func (h *CartHandler) GetMyCart(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	cart, err := h.cartService.GetCustomerCart(c.Request.Context(), restaurantID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// SaveMyCart handles replacing the contents of the logged-in customer's cart
+// @Summary Save My Cart
+// @Description Replace the items and fulfillment type of the logged-in customer's cart, creating it if needed
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param request body services.CartRequest true "Cart contents"
+// @Success 200 {object} services.CartView
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/cart [put]
+func (h *CartHandler) SaveMyCart(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.CartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	cart, err := h.cartService.SaveCustomerCart(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// ClaimCart handles moving an anonymous cart to the logged-in customer
+// @Summary Claim Cart
+// @Description Move an anonymous cart to the logged-in customer, e.g. after signing in at checkout. Its items are added to the customer's cart if they already have one
+// @Tags carts
+// @Accept json
+// @Produce json
+// @Param request body services.ClaimCartRequest true "Anonymous cart token"
+// @Success 200 {object} services.CartView
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/cart/claim [post]
+func (h *CartHandler) ClaimCart(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.ClaimCartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	cart, err := h.cartService.ClaimCart(c.Request.Context(), restaurantID, userID, req.Token)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/dto"
+	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
 	"restaurant-backend/internal/services"
 	"strconv"
@@ -15,13 +18,19 @@ import (
 type CategoryHandler struct {
 	categoryRepo    *repositories.CategoryRepository
 	categoryService *services.CategoryService
+	approvalService *services.ApprovalService
 }
 
 // NewCategoryHandler creates a new CategoryHandler instance
-func NewCategoryHandler(categoryRepo *repositories.CategoryRepository) *CategoryHandler {
+func NewCategoryHandler(
+	categoryRepo *repositories.CategoryRepository,
+	categoryService *services.CategoryService,
+	approvalService *services.ApprovalService,
+) *CategoryHandler {
 	return &CategoryHandler{
 		categoryRepo:    categoryRepo,
-		categoryService: services.NewCategoryService(categoryRepo),
+		categoryService: categoryService,
+		approvalService: approvalService,
 	}
 }
 
@@ -33,31 +42,27 @@ func NewCategoryHandler(categoryRepo *repositories.CategoryRepository) *Category
 // @Produce json
 // @Param request body dto.CreateCategoryRequest true "Category data"
 // @Success 201 {object} models.MenuCategory
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
 // @Router /api/v1/categories [post]
 func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 	// Bind request
 	var req dto.CreateCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	// Get restaurant ID from context (set by middleware)
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	// Create category using service
 	category, err := h.categoryService.CreateCategory(c.Request.Context(), &req, restaurantID)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "category name already taken" {
-			statusCode = http.StatusConflict
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -71,41 +76,54 @@ func (h *CategoryHandler) CreateCategory(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Category ID"
 // @Success 200 {object} models.MenuCategory
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/categories/{id} [get]
 func (h *CategoryHandler) GetCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid category ID"))
 		return
 	}
 
-	category, err := h.categoryRepo.GetByIDWithContext(c.Request.Context(), uint(id))
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	category, err := h.categoryRepo.GetByIDForRestaurant(c.Request.Context(), uint(id), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "category not found"})
+		_ = c.Error(apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found"))
 		return
 	}
 
+	setETag(c, category.Version)
 	c.JSON(http.StatusOK, category)
 }
 
 // ListCategories handles listing all categories for the restaurant
 // @Summary List Menu Categories
-// @Description List all menu categories for the restaurant
+// @Description List the menu categories for the restaurant; with archived=true the archived categories are listed instead
 // @Tags categories
 // @Produce json
+// @Param archived query bool false "List archived categories"
 // @Success 200 {array} models.MenuCategory
 // @Router /api/v1/categories [get]
 func (h *CategoryHandler) ListCategories(c *gin.Context) {
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
-	categories, err := h.categoryRepo.GetByRestaurantIDWithContext(c.Request.Context(), restaurantID)
+	list := h.categoryRepo.GetByRestaurantIDWithContext
+	if c.Query("archived") == "true" {
+		list = h.categoryRepo.GetArchivedByRestaurantIDWithContext
+	}
+
+	categories, err := list(c.Request.Context(), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -120,64 +138,266 @@ func (h *CategoryHandler) ListCategories(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Category ID"
 // @Param request body dto.UpdateCategoryRequest true "Category update data (only provided fields will be updated)"
+// @Param If-Match header string false "ETag from a previous read; rejects the update with 409 if the resource changed since"
 // @Success 200 {object} models.MenuCategory
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/categories/{id} [put]
 func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid category ID"))
 		return
 	}
 
 	// Bind update request
 	var req dto.UpdateCategoryRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	// Get restaurant ID from context (set by middleware)
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	// Update category using service (with ownership validation)
-	category, err := h.categoryService.UpdateCategory(c.Request.Context(), uint(id), &req, restaurantID)
+	category, err := h.categoryService.UpdateCategory(c.Request.Context(), uint(id), &req, restaurantID, expectedVersion)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "category not found" {
-			statusCode = http.StatusNotFound
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, category.Version)
 	c.JSON(http.StatusOK, category)
 }
 
 // DeleteCategory handles deleting a category
 // @Summary Delete Menu Category
-// @Description Delete a menu category
+// @Description Delete a menu category. A category that still has items needs move_items_to (items move to that category, then the category is deleted) or archive_items=true (the category is archived with its items instead); otherwise 409 is returned. When the restaurant requires approval of Staff deletions, the deletion is queued for an Admin and 202 is returned with the pending change
 // @Tags categories
+// @Produce json
 // @Param id path int true "Category ID"
-// @Success 204 "No Content"
-// @Failure 404 {object} map[string]string
+// @Param move_items_to query int false "Category ID to move the items to"
+// @Param archive_items query bool false "Archive the category and its items instead of deleting"
+// @Success 200 {object} services.CategoryDeleteResult
+// @Success 202 {object} models.PendingChange
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/categories/{id} [delete]
 func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid category ID"))
+		return
+	}
+
+	var req dto.DeleteCategoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	if submitForApproval(c, h.approvalService, restaurantID, models.PendingChangeTypeCategoryDeletion, uint(id), &req) {
+		return
+	}
+
+	result, err := h.categoryService.DeleteCategory(c.Request.Context(), uint(id), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	if err := h.categoryRepo.DeleteWithContext(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	c.JSON(http.StatusOK, result)
+}
+
+// ArchiveCategory handles archiving a category
+// @Summary Archive Menu Category
+// @Description Hide a category and its items from menus and ordering without deleting them
+// @Tags categories
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} models.MenuCategory
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/categories/{id}/archive [post]
+func (h *CategoryHandler) ArchiveCategory(c *gin.Context) {
+	h.setArchived(c, h.categoryService.ArchiveCategory)
+}
+
+// RestoreCategory handles restoring an archived category
+// @Summary Restore Menu Category
+// @Description Bring an archived category and its items back onto the menu
+// @Tags categories
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} models.MenuCategory
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/categories/{id}/restore [post]
+func (h *CategoryHandler) RestoreCategory(c *gin.Context) {
+	h.setArchived(c, h.categoryService.RestoreCategory)
+}
+
+// setArchived runs an archive or restore for the category in the path
+func (h *CategoryHandler) setArchived(c *gin.Context, apply func(context.Context, uint, uint) (*models.MenuCategory, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid category ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	category, err := apply(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, category.Version)
+	c.JSON(http.StatusOK, category)
+}
+
+// SetCategoryAvailability handles toggling availability for all items in a category
+// @Summary Set Category Item Availability
+// @Description Mark all menu items in a category as available or unavailable at once (e.g., equipment outage). The change is audit-logged
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param request body dto.UpdateCategoryAvailabilityRequest true "Availability data"
+// @Success 200 {object} services.CategoryAvailabilityResult
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/categories/{id}/availability [patch]
+func (h *CategoryHandler) SetCategoryAvailability(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid category ID"))
+		return
+	}
+
+	var req dto.UpdateCategoryAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+	role, _ := ctx.GetUserRole(c.Request.Context())
+
+	result, err := h.categoryService.SetCategoryAvailability(c.Request.Context(), uint(id), &req, restaurantID, services.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ReorderCategories handles drag-and-drop reordering of categories
+// @Summary Reorder Menu Categories
+// @Description Set the display order of all categories at once. ids must list every category of the restaurant exactly once, in the new order
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param request body dto.ReorderRequest true "Category IDs in display order"
+// @Success 200 {array} models.MenuCategory
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/categories/reorder [put]
+func (h *CategoryHandler) ReorderCategories(c *gin.Context) {
+	var req dto.ReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	categories, err := h.categoryService.ReorderCategories(c.Request.Context(), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// ReorderCategoryItems handles drag-and-drop reordering of the items in a category
+// @Summary Reorder Category Items
+// @Description Set the display order of all menu items in a category at once. ids must list every item in the category exactly once, in the new order
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param request body dto.ReorderRequest true "Menu item IDs in display order"
+// @Success 200 {array} models.MenuItem
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/categories/{id}/items/reorder [put]
+func (h *CategoryHandler) ReorderCategoryItems(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid category ID"))
+		return
+	}
+
+	var req dto.ReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	items, err := h.categoryService.ReorderCategoryItems(c.Request.Context(), uint(id), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	c.JSON(http.StatusOK, items)
 }
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CloseoutHandler handles end-of-day (Z) report requests
+type CloseoutHandler struct {
+	closeoutService *services.CloseoutService
+}
+
+// NewCloseoutHandler creates a new CloseoutHandler instance
+func NewCloseoutHandler(closeoutService *services.CloseoutService) *CloseoutHandler {
+	return &CloseoutHandler{
+		closeoutService: closeoutService,
+	}
+}
+
+// GetReport handles retrieving the Z-report of a business day
+// @Summary Get Z-Report
+// @Description Get the sales, tax, discounts, refunds, payment breakdown and order counts of a business day in the restaurant's time zone. Closed days return the stored closeout, open days the live numbers
+// @Tags reports
+// @Produce json
+// @Param date query string false "Business date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} services.ZReport
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/reports/z [get]
+func (h *CloseoutHandler) GetReport(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	report, err := h.closeoutService.GetReport(c.Request.Context(), restaurantID, c.Query("date"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// CloseDay handles closing a business day
+// @Summary Close Business Day
+// @Description Snapshot a business day that is over into an immutable closeout. A closed day's numbers can't change afterwards
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Param request body services.CloseDayRequest true "Business day"
+// @Success 201 {object} models.DailyCloseout
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/reports/z/close [post]
+func (h *CloseoutHandler) CloseDay(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.CloseDayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	closeout, err := h.closeoutService.CloseDay(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, closeout)
+}
+
+// ListCloseouts handles listing closed business days
+// @Summary List Closeouts
+// @Description List the closeouts of business days in a date range, newest first. Defaults to the last 30 days
+// @Tags reports
+// @Produce json
+// @Param from query string false "First business date (YYYY-MM-DD)"
+// @Param to query string false "Last business date (YYYY-MM-DD)"
+// @Success 200 {array} models.DailyCloseout
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/reports/z/closeouts [get]
+func (h *CloseoutHandler) ListCloseouts(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	closeouts, err := h.closeoutService.ListCloseouts(c.Request.Context(), restaurantID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, closeouts)
+}
+
+// GetCloseout handles retrieving a closeout
+// @Summary Get Closeout
+// @Description Get a closed business day's report
+// @Tags reports
+// @Produce json
+// @Param id path int true "Closeout ID"
+// @Success 200 {object} models.DailyCloseout
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/reports/z/closeouts/{id} [get]
+func (h *CloseoutHandler) GetCloseout(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid closeout ID"))
+		return
+	}
+
+	closeout, err := h.closeoutService.GetCloseout(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, closeout)
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CustomerHandler handles customer (CRM) requests
+type CustomerHandler struct {
+	customerService *services.CustomerService
+}
+
+// NewCustomerHandler creates a new CustomerHandler instance
+func NewCustomerHandler(customerService *services.CustomerService) *CustomerHandler {
+	return &CustomerHandler{
+		customerService: customerService,
+	}
+}
+
+// ListCustomers handles searching the customer directory
+// @Summary List Customers
+// @Description Search the restaurant's customers by name, email or phone, most recent visitors first
+// @Tags customers
+// @Produce json
+// @Param q query string false "Search term"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.CustomerList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/customers [get]
+func (h *CustomerHandler) ListCustomers(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid limit parameter"))
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid offset parameter"))
+		return
+	}
+
+	customers, err := h.customerService.SearchCustomers(c.Request.Context(), restaurantID, c.Query("q"), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, customers)
+}
+
+// CreateCustomer handles adding a customer
+// @Summary Create Customer
+// @Description Add a customer without an account (walk-in or phone booking); email and phone must be unique per restaurant
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param request body services.CustomerRequest true "Customer data"
+// @Success 201 {object} models.Customer
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/customers [post]
+func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
+	var req services.CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	customer, err := h.customerService.CreateCustomer(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, customer)
+}
+
+// GetCustomer handles getting a customer by ID
+// @Summary Get Customer
+// @Description Get a customer with their order and visit aggregates
+// @Tags customers
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} models.Customer
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/customers/{id} [get]
+func (h *CustomerHandler) GetCustomer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid customer ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	customer, err := h.customerService.GetCustomer(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, customer)
+}
+
+// UpdateCustomer handles updating a customer's contact details and notes
+// @Summary Update Customer
+// @Description Update a customer's name, contact details and staff notes
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Param request body services.CustomerRequest true "Customer data"
+// @Success 200 {object} models.Customer
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/customers/{id} [put]
+func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid customer ID"))
+		return
+	}
+
+	var req services.CustomerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	customer, err := h.customerService.UpdateCustomer(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, customer)
+}
+
+// GetCustomerHistory handles getting a customer's order and reservation history
+// @Summary Get Customer History
+// @Description Get a customer with all their orders and reservations at the restaurant
+// @Tags customers
+// @Produce json
+// @Param id path int true "Customer ID"
+// @Success 200 {object} services.CustomerHistory
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/customers/{id}/history [get]
+func (h *CustomerHandler) GetCustomerHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid customer ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	history, err := h.customerService.GetCustomerHistory(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -1,15 +1,22 @@
 package handlers
 
 import (
+	"io"
 	"net/http"
 	"strconv"
+	"time"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/metrics"
 	"restaurant-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
+// dashboardPollInterval is how often the dashboard SSE stream checks for new events
+const dashboardPollInterval = 3 * time.Second
+
 // DashboardHandler handles dashboard statistics requests
 type DashboardHandler struct {
 	dashboardService *services.DashboardService
@@ -29,13 +36,13 @@ func NewDashboardHandler(dashboardService *services.DashboardService) *Dashboard
 // @Produce json
 // @Param period query string false "Time period (today, week, month, year)" default(month)
 // @Success 200 {object} services.DashboardStats
-// @Failure 500 {object} map[string]string
+// @Failure 500 {object} apperrors.Response
 // @Router /api/v1/dashboard/stats [get]
 func (h *DashboardHandler) GetDashboardStats(c *gin.Context) {
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -44,7 +51,7 @@ func (h *DashboardHandler) GetDashboardStats(c *gin.Context) {
 
 	stats, err := h.dashboardService.GetDashboardStats(c.Request.Context(), restaurantID, period)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -58,14 +65,14 @@ func (h *DashboardHandler) GetDashboardStats(c *gin.Context) {
 // @Produce json
 // @Param limit query int false "Number of orders to retrieve (max 100)" default(10)
 // @Success 200 {array} models.Order
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 500 {object} apperrors.Response
 // @Router /api/v1/dashboard/recent-orders [get]
 func (h *DashboardHandler) GetRecentOrders(c *gin.Context) {
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -73,13 +80,13 @@ func (h *DashboardHandler) GetRecentOrders(c *gin.Context) {
 	limitStr := c.DefaultQuery("limit", "10")
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit parameter"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid limit parameter"))
 		return
 	}
 
 	orders, err := h.dashboardService.GetRecentOrders(c.Request.Context(), restaurantID, limit)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -88,29 +95,183 @@ func (h *DashboardHandler) GetRecentOrders(c *gin.Context) {
 
 // GetAnalytics handles retrieving analytics data
 // @Summary Get Analytics
-// @Description Get analytics data for a specific period
+// @Description Get analytics data for a specific period in the restaurant's time zone, with new vs returning customers, repeat rate and customer lifetime value for the period and the previous one. Admins also get the labor cost against revenue, per day or per week for a year
 // @Tags dashboard
 // @Produce json
 // @Param period query string false "Time period (today, week, month, year)" default(month)
 // @Success 200 {object} services.AnalyticsData
-// @Failure 500 {object} map[string]string
+// @Failure 500 {object} apperrors.Response
 // @Router /api/v1/dashboard/analytics [get]
 func (h *DashboardHandler) GetAnalytics(c *gin.Context) {
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	// Get period from query parameter (default to "month")
 	period := c.DefaultQuery("period", "month")
 
-	analytics, err := h.dashboardService.GetAnalytics(c.Request.Context(), restaurantID, period)
+	// Labor cost reveals wages, so it is only shown to admins
+	role, _ := ctx.GetUserRole(c.Request.Context())
+
+	analytics, err := h.dashboardService.GetAnalytics(c.Request.Context(), restaurantID, period, role == "Admin")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, analytics)
 }
+
+// GetAnalyticsBreakdown handles retrieving the revenue breakdown of a period
+// @Summary Get Analytics Breakdown
+// @Description Break the completed order revenue of a period down by top-selling items, menu categories, weekday and hour (heatmap), or get the average order value per day (per week for a year). Periods are cut in the restaurant's time zone
+// @Tags dashboard
+// @Produce json
+// @Param dimension query string true "items, categories, heatmap or order_value"
+// @Param period query string false "Time period (today, week, month, year)" default(month)
+// @Param limit query int false "Number of items for the items dimension (max 50)" default(10)
+// @Success 200 {object} services.AnalyticsBreakdown
+// @Failure 400 {object} apperrors.Response
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/dashboard/analytics/breakdown [get]
+func (h *DashboardHandler) GetAnalyticsBreakdown(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid limit parameter"))
+		return
+	}
+
+	breakdown, err := h.dashboardService.GetAnalyticsBreakdown(
+		c.Request.Context(),
+		restaurantID,
+		c.Query("dimension"),
+		c.DefaultQuery("period", "month"),
+		limit,
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
+}
+
+// GetLaborReport handles retrieving the labor cost against revenue
+// @Summary Get Labor Report
+// @Description Get worked hours, labor cost, revenue of completed orders and labor percentage per day or week (starting Monday) in the restaurant's time zone. Defaults to the last 7 days or 8 weeks
+// @Tags dashboard
+// @Produce json
+// @Param granularity query string false "day or week" default(day)
+// @Param from query string false "First date (YYYY-MM-DD)"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} services.LaborReport
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/dashboard/labor [get]
+func (h *DashboardHandler) GetLaborReport(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	report, err := h.dashboardService.GetLaborReport(
+		c.Request.Context(),
+		restaurantID,
+		c.Query("granularity"),
+		c.Query("from"),
+		c.Query("to"),
+	)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetMarginReport handles retrieving the theoretical food cost and margin of the menu
+// @Summary Get Margin Report
+// @Description Get the theoretical food cost and margin per menu item and category from the recipes at current ingredient costs. Items and categories with a margin percentage below the restaurant's min_margin_percent setting are flagged; items without a recipe aren't costed
+// @Tags dashboard
+// @Produce json
+// @Success 200 {object} services.MarginReport
+// @Router /api/v1/dashboard/margins [get]
+func (h *DashboardHandler) GetMarginReport(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	report, err := h.dashboardService.GetMarginReport(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// StreamDashboard handles streaming live dashboard updates via server-sent events
+// @Summary Stream Dashboard Updates
+// @Description Server-sent events stream emitting "new-order", "order-status", "new-reservation", "course-fired" and "menu-changed" events for the restaurant
+// @Tags dashboard
+// @Produce text/event-stream
+// @Success 200 {object} services.DashboardOrderEvent
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/dashboard/stream [get]
+func (h *DashboardHandler) StreamDashboard(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	// Only changes after the stream opens are sent; clients load the current state from /stats
+	cursor, err := h.dashboardService.CurrentCursor(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	closed := metrics.TrackRealtimeConnection(metrics.ChannelDashboard, h.dashboardService.StreamTier(c.Request.Context(), restaurantID))
+	defer closed()
+
+	// Send headers right away so clients know the stream is open before the first change
+	c.SSEvent("connected", gin.H{"restaurant_id": restaurantID})
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(dashboardPollInterval)
+	defer ticker.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-ticker.C:
+		}
+
+		events, err := h.dashboardService.GetEventsSince(c.Request.Context(), restaurantID, cursor)
+		if err != nil {
+			// Retry on the next tick; a cancelled request ends the loop above
+			return true
+		}
+		for _, event := range events {
+			c.SSEvent(event.Name, event.Data)
+		}
+		return true
+	})
+}
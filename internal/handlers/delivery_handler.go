@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeliveryHandler handles delivery platform integration requests
+type DeliveryHandler struct {
+	deliveryService *services.DeliveryService
+}
+
+// NewDeliveryHandler creates a new DeliveryHandler instance
+func NewDeliveryHandler(deliveryService *services.DeliveryService) *DeliveryHandler {
+	return &DeliveryHandler{
+		deliveryService: deliveryService,
+	}
+}
+
+// ListIntegrations handles listing the restaurant's delivery integrations
+// @Summary List Delivery Integrations
+// @Description List the delivery platforms (Uber Eats, Deliveroo) the restaurant is connected to, with their last sync state
+// @Tags integrations
+// @Produce json
+// @Success 200 {array} models.DeliveryIntegration
+// @Router /api/v1/integrations/delivery [get]
+func (h *DeliveryHandler) ListIntegrations(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	integrations, err := h.deliveryService.ListIntegrations(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, integrations)
+}
+
+// CreateIntegration handles connecting a delivery platform
+// @Summary Create Delivery Integration
+// @Description Connect the restaurant to a delivery platform. The menu is pushed and orders are pulled in the background; imported orders have the provider as source
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param request body services.CreateDeliveryIntegrationRequest true "Integration"
+// @Success 201 {object} models.DeliveryIntegration
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/integrations/delivery [post]
+func (h *DeliveryHandler) CreateIntegration(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.CreateDeliveryIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	integration, err := h.deliveryService.CreateIntegration(c.Request.Context(), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, integration)
+}
+
+// UpdateIntegration handles updating a delivery integration
+// @Summary Update Delivery Integration
+// @Description Update the store/brand IDs or access token of a delivery integration, or pause it
+// @Tags integrations
+// @Accept json
+// @Produce json
+// @Param id path int true "Integration ID"
+// @Param request body services.UpdateDeliveryIntegrationRequest true "Integration updates"
+// @Success 200 {object} models.DeliveryIntegration
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/integrations/delivery/{id} [put]
+func (h *DeliveryHandler) UpdateIntegration(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid integration ID"))
+		return
+	}
+
+	var req services.UpdateDeliveryIntegrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	integration, err := h.deliveryService.UpdateIntegration(c.Request.Context(), uint(id), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, integration)
+}
+
+// DeleteIntegration handles disconnecting a delivery platform
+// @Summary Delete Delivery Integration
+// @Description Disconnect a delivery platform; orders already imported are kept
+// @Tags integrations
+// @Param id path int true "Integration ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/integrations/delivery/{id} [delete]
+func (h *DeliveryHandler) DeleteIntegration(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid integration ID"))
+		return
+	}
+
+	if err := h.deliveryService.DeleteIntegration(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SyncMenu handles pushing the menu to a delivery platform
+// @Summary Push Menu
+// @Description Push the restaurant's menu to the delivery platform now, even if it is unchanged
+// @Tags integrations
+// @Produce json
+// @Param id path int true "Integration ID"
+// @Success 200 {object} models.DeliveryIntegration
+// @Failure 404 {object} apperrors.Response
+// @Failure 502 {object} apperrors.Response
+// @Router /api/v1/integrations/delivery/{id}/sync-menu [post]
+func (h *DeliveryHandler) SyncMenu(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid integration ID"))
+		return
+	}
+
+	integration, err := h.deliveryService.SyncMenu(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, integration)
+}
+
+// PullOrders handles importing a delivery platform's new orders
+// @Summary Pull Orders
+// @Description Import the orders placed on the delivery platform since the last pull. Orders already imported are skipped
+// @Tags integrations
+// @Produce json
+// @Param id path int true "Integration ID"
+// @Success 200 {object} services.OrderImportResult
+// @Failure 404 {object} apperrors.Response
+// @Failure 502 {object} apperrors.Response
+// @Router /api/v1/integrations/delivery/{id}/pull-orders [post]
+func (h *DeliveryHandler) PullOrders(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid integration ID"))
+		return
+	}
+
+	result, err := h.deliveryService.PullOrders(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
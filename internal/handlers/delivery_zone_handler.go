@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeliveryZoneHandler handles delivery zone and delivery address check requests
+type DeliveryZoneHandler struct {
+	zoneService    *services.DeliveryZoneService
+	restaurantRepo *repositories.RestaurantRepository
+}
+
+// NewDeliveryZoneHandler creates a new DeliveryZoneHandler instance
+func NewDeliveryZoneHandler(zoneService *services.DeliveryZoneService, restaurantRepo *repositories.RestaurantRepository) *DeliveryZoneHandler {
+	return &DeliveryZoneHandler{
+		zoneService:    zoneService,
+		restaurantRepo: restaurantRepo,
+	}
+}
+
+// ListZones handles listing the restaurant's delivery zones
+// @Summary List Delivery Zones
+// @Description List the restaurant's delivery zones in matching order (priority, then creation)
+// @Tags delivery-zones
+// @Produce json
+// @Success 200 {array} models.DeliveryZone
+// @Router /api/v1/delivery-zones [get]
+func (h *DeliveryZoneHandler) ListZones(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	zones, err := h.zoneService.ListZones(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zones)
+}
+
+// CreateZone handles creating a delivery zone
+// @Summary Create Delivery Zone
+// @Description Create a radius or polygon delivery zone with its delivery fee and minimum order value
+// @Tags delivery-zones
+// @Accept json
+// @Produce json
+// @Param request body services.DeliveryZoneRequest true "Delivery zone"
+// @Success 201 {object} models.DeliveryZone
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/delivery-zones [post]
+func (h *DeliveryZoneHandler) CreateZone(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.DeliveryZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	zone, err := h.zoneService.CreateZone(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, zone)
+}
+
+// UpdateZone handles replacing a delivery zone
+// @Summary Update Delivery Zone
+// @Description Replace a delivery zone's shape, fee, minimum order value and priority
+// @Tags delivery-zones
+// @Accept json
+// @Produce json
+// @Param id path int true "Delivery zone ID"
+// @Param request body services.DeliveryZoneRequest true "Delivery zone"
+// @Success 200 {object} models.DeliveryZone
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/delivery-zones/{id} [put]
+func (h *DeliveryZoneHandler) UpdateZone(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid delivery zone ID"))
+		return
+	}
+
+	var req services.DeliveryZoneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	zone, err := h.zoneService.UpdateZone(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, zone)
+}
+
+// DeleteZone handles deleting a delivery zone
+// @Summary Delete Delivery Zone
+// @Description Delete a delivery zone
+// @Tags delivery-zones
+// @Param id path int true "Delivery zone ID"
+// @Success 204
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/delivery-zones/{id} [delete]
+func (h *DeliveryZoneHandler) DeleteZone(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid delivery zone ID"))
+		return
+	}
+
+	if err := h.zoneService.DeleteZone(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CheckAddress handles checking whether the restaurant delivers to an address
+// @Summary Check Delivery Address
+// @Description Check an address (by coordinates) against the restaurant's delivery zones and return the applicable fee and minimum order value
+// @Tags delivery-zones
+// @Accept json
+// @Produce json
+// @Param request body services.CheckDeliveryRequest true "Address"
+// @Success 200 {object} services.DeliveryQuote
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/delivery-zones/check [post]
+func (h *DeliveryZoneHandler) CheckAddress(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	h.checkAddress(c, restaurantID)
+}
+
+// CheckAddressPublic handles checking a delivery address for the public storefront
+// @Summary Check Delivery Address (Public)
+// @Description Check an address (by coordinates) against a restaurant's delivery zones and return the applicable fee (no authentication required)
+// @Tags public-menu
+// @Accept json
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Param request body services.CheckDeliveryRequest true "Address"
+// @Success 200 {object} services.DeliveryQuote
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/delivery-zones/check [post]
+func (h *DeliveryZoneHandler) CheckAddressPublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	restaurant, err := h.restaurantRepo.GetByIDWithContext(c.Request.Context(), uint(restaurantID))
+	if err != nil || !restaurant.IsPubliclyVisible() {
+		_ = c.Error(apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found"))
+		return
+	}
+
+	h.checkAddress(c, uint(restaurantID))
+}
+
+// checkAddress quotes the delivery terms of the requested address
+func (h *DeliveryZoneHandler) checkAddress(c *gin.Context, restaurantID uint) {
+	var req services.CheckDeliveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	quote, err := h.zoneService.Quote(c.Request.Context(), restaurantID, *req.Latitude, *req.Longitude, req.Subtotal)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/metrics"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// displayPollInterval is how often the SSE stream re-checks the order board
+const displayPollInterval = 5 * time.Second
+
+// DisplayHandler handles the order status board (TV mode) requests
+type DisplayHandler struct {
+	displayService *services.DisplayService
+}
+
+// NewDisplayHandler creates a new DisplayHandler instance
+func NewDisplayHandler(displayService *services.DisplayService) *DisplayHandler {
+	return &DisplayHandler{
+		displayService: displayService,
+	}
+}
+
+// RotateDisplayToken handles generating a new display token (Admin only)
+// @Summary Rotate Display Token
+// @Description Generate a new token for the pickup-screen order board. The previous token stops working.
+// @Tags display
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /api/v1/display/token [post]
+func (h *DisplayHandler) RotateDisplayToken(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	token, err := h.displayService.RotateDisplayToken(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+// GetDisplayBoard handles getting the order board (public, token-protected)
+// @Summary Get Order Board
+// @Description List order numbers in preparing and ready states for a pickup screen
+// @Tags display
+// @Produce json
+// @Param token path string true "Display token"
+// @Success 200 {object} services.DisplayBoard
+// @Failure 401 {object} apperrors.Response
+// @Router /api/v1/public/display/{token} [get]
+func (h *DisplayHandler) GetDisplayBoard(c *gin.Context) {
+	board, err := h.displayService.GetBoard(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+// StreamDisplayBoard handles streaming order board updates via server-sent events
+// @Summary Stream Order Board
+// @Description Server-sent events stream emitting a "board" event whenever the order board changes
+// @Tags display
+// @Produce text/event-stream
+// @Param token path string true "Display token"
+// @Success 200 {object} services.DisplayBoard
+// @Failure 401 {object} apperrors.Response
+// @Router /api/v1/public/display/{token}/stream [get]
+func (h *DisplayHandler) StreamDisplayBoard(c *gin.Context) {
+	token := c.Param("token")
+
+	// Validate token before switching to streaming mode
+	board, err := h.displayService.GetBoard(c.Request.Context(), token)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	closed := metrics.TrackRealtimeConnection(metrics.ChannelDisplayBoard, h.displayService.TenantTier(c.Request.Context(), token))
+	defer closed()
+
+	ticker := time.NewTicker(displayPollInterval)
+	defer ticker.Stop()
+
+	var last *services.DisplayBoard
+	c.Stream(func(w io.Writer) bool {
+		if last == nil || !reflect.DeepEqual(board, last) {
+			c.SSEvent("board", board)
+			last = board
+		}
+
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-ticker.C:
+		}
+
+		next, err := h.displayService.GetBoard(c.Request.Context(), token)
+		if err != nil {
+			// Token was rotated or restaurant deactivated - close the stream
+			return false
+		}
+		board = next
+		return true
+	})
+}
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DriverHandler handles driver, delivery assignment and driver app requests
+type DriverHandler struct {
+	driverService *services.DriverService
+}
+
+// NewDriverHandler creates a new DriverHandler instance
+func NewDriverHandler(driverService *services.DriverService) *DriverHandler {
+	return &DriverHandler{
+		driverService: driverService,
+	}
+}
+
+// ListDrivers handles listing the restaurant's drivers
+// @Summary List Drivers
+// @Description List the restaurant's delivery drivers
+// @Tags deliveries
+// @Produce json
+// @Success 200 {array} models.Driver
+// @Router /api/v1/drivers [get]
+func (h *DriverHandler) ListDrivers(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	drivers, err := h.driverService.ListDrivers(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, drivers)
+}
+
+// CreateDriver handles registering a driver
+// @Summary Create Driver
+// @Description Register a delivery driver. The response contains the driver's token for the driver app; it is not shown again
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param request body services.CreateDriverRequest true "Driver"
+// @Success 201 {object} services.DriverCredentials
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/drivers [post]
+func (h *DriverHandler) CreateDriver(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.CreateDriverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	credentials, err := h.driverService.CreateDriver(c.Request.Context(), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, credentials)
+}
+
+// UpdateDriver handles updating a driver
+// @Summary Update Driver
+// @Description Update a driver's name, phone or active state. Inactive drivers can't sign in or get new deliveries
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param id path int true "Driver ID"
+// @Param request body services.UpdateDriverRequest true "Driver"
+// @Success 200 {object} models.Driver
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/drivers/{id} [put]
+func (h *DriverHandler) UpdateDriver(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid driver ID"))
+		return
+	}
+
+	var req services.UpdateDriverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	driver, err := h.driverService.UpdateDriver(c.Request.Context(), uint(id), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, driver)
+}
+
+// RotateDriverToken handles regenerating a driver's token
+// @Summary Rotate Driver Token
+// @Description Generate a new token for a driver, signing out the driver app using the previous one
+// @Tags deliveries
+// @Produce json
+// @Param id path int true "Driver ID"
+// @Success 200 {object} services.DriverCredentials
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/drivers/{id}/token [post]
+func (h *DriverHandler) RotateDriverToken(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid driver ID"))
+		return
+	}
+
+	credentials, err := h.driverService.RotateDriverToken(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, credentials)
+}
+
+// ListDeliveries handles listing delivery assignments
+// @Summary List Deliveries
+// @Description List the restaurant's latest 100 delivery assignments, optionally filtered by driver and status
+// @Tags deliveries
+// @Produce json
+// @Param driver_id query int false "Driver ID"
+// @Param status query string false "Delivery status (assigned, picked_up, delivered)"
+// @Success 200 {array} models.DeliveryAssignment
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/deliveries [get]
+func (h *DriverHandler) ListDeliveries(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var filter repositories.DeliveryAssignmentFilter
+	if raw := c.Query("driver_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid driver ID"))
+			return
+		}
+		driverID := uint(id)
+		filter.DriverID = &driverID
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+
+	deliveries, err := h.driverService.ListDeliveries(c.Request.Context(), restaurantID, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// GetOrderDriver handles getting the driver assignment of an order
+// @Summary Get Order Driver
+// @Description Get the driver assignment and delivery state of an order
+// @Tags deliveries
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} models.DeliveryAssignment
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/driver [get]
+func (h *DriverHandler) GetOrderDriver(c *gin.Context) {
+	restaurantID, orderID, ok := orderParams(c)
+	if !ok {
+		return
+	}
+
+	assignment, err := h.driverService.GetAssignment(c.Request.Context(), orderID, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// AssignDriver handles assigning a delivery order to a driver
+// @Summary Assign Driver
+// @Description Assign a delivery order to an active driver, or change its driver until the order is picked up
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body services.AssignDriverRequest true "Driver"
+// @Success 200 {object} models.DeliveryAssignment
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/driver [put]
+func (h *DriverHandler) AssignDriver(c *gin.Context) {
+	restaurantID, orderID, ok := orderParams(c)
+	if !ok {
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.AssignDriverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	assignment, err := h.driverService.AssignDriver(c.Request.Context(), orderID, restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
+
+// UnassignDriver handles removing the driver of an order
+// @Summary Unassign Driver
+// @Description Remove the driver of an order that has not been picked up
+// @Tags deliveries
+// @Param id path int true "Order ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/driver [delete]
+func (h *DriverHandler) UnassignDriver(c *gin.Context) {
+	restaurantID, orderID, ok := orderParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.driverService.UnassignDriver(c.Request.Context(), orderID, restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDriverDeliveries handles a driver listing their open deliveries
+// @Summary List Driver Deliveries
+// @Description List the open deliveries of the driver owning the token, oldest first, with address, customer contact and items
+// @Tags deliveries
+// @Produce json
+// @Param token path string true "Driver token"
+// @Success 200 {array} services.DriverDelivery
+// @Failure 401 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Router /api/v1/public/drivers/{token}/deliveries [get]
+func (h *DriverHandler) ListDriverDeliveries(c *gin.Context) {
+	deliveries, err := h.driverService.ListDriverDeliveries(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// UpdateDriverDelivery handles a driver updating the status of a delivery
+// @Summary Update Driver Delivery
+// @Description Mark an assigned delivery as picked up, or a picked up delivery as delivered. Delivering completes a ready order
+// @Tags deliveries
+// @Accept json
+// @Produce json
+// @Param token path string true "Driver token"
+// @Param id path int true "Delivery ID"
+// @Param request body services.UpdateDeliveryStatusRequest true "Status"
+// @Success 200 {object} models.DeliveryAssignment
+// @Failure 400 {object} apperrors.Response
+// @Failure 401 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/public/drivers/{token}/deliveries/{id} [post]
+func (h *DriverHandler) UpdateDriverDelivery(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid delivery ID"))
+		return
+	}
+
+	var req services.UpdateDeliveryStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	assignment, err := h.driverService.UpdateDriverDelivery(c.Request.Context(), c.Param("token"), uint(id), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignment)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"strconv"
+	"strings"
+
+	"restaurant-backend/internal/apperrors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag sets the ETag header for a versioned resource
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatchVersion parses the If-Match header into the version the client expects to update
+// Returns 0 when the header is absent or "*" (no precondition)
+func ifMatchVersion(c *gin.Context) (int, error) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	version, err := strconv.Atoi(strings.Trim(value, `"`))
+	if err != nil || version <= 0 {
+		return 0, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid If-Match header")
+	}
+	return version, nil
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ExportHandler handles CSV and XLSX exports of orders, reservations and customers
+type ExportHandler struct {
+	exportService *services.ExportService
+}
+
+// NewExportHandler creates a new ExportHandler instance
+func NewExportHandler(exportService *services.ExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportOrders handles exporting orders
+// @Summary Export Orders
+// @Description Export the restaurant's orders, one row per order, filtered like List Orders. The format is taken from the format parameter, else negotiated from the Accept header (CSV by default)
+// @Tags orders
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param user_id query int false "Filter by user ID"
+// @Param format query string false "csv or xlsx"
+// @Success 200 {file} file
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/orders/export [get]
+func (h *ExportHandler) ExportOrders(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	format, err := exportFormat(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var userID *uint
+	if param := c.Query("user_id"); param != "" {
+		id, err := strconv.ParseUint(param, 10, 32)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user_id parameter"))
+			return
+		}
+		value := uint(id)
+		userID = &value
+	}
+
+	streamExport(c, "orders", format, func(w io.Writer) error {
+		return h.exportService.ExportOrders(c.Request.Context(), restaurantID, userID, format, w)
+	})
+}
+
+// ExportReservations handles exporting reservations
+// @Summary Export Reservations
+// @Description Export the restaurant's reservations, filtered like List Reservations. The format is taken from the format parameter, else negotiated from the Accept header (CSV by default)
+// @Tags reservations
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param date query string false "Date filter (YYYY-MM-DD)"
+// @Param format query string false "csv or xlsx"
+// @Success 200 {file} file
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/reservations/export [get]
+func (h *ExportHandler) ExportReservations(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	format, err := exportFormat(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	var date *time.Time
+	if param := c.Query("date"); param != "" {
+		parsed, err := time.Parse(time.DateOnly, param)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "date must be YYYY-MM-DD"))
+			return
+		}
+		date = &parsed
+	}
+
+	streamExport(c, "reservations", format, func(w io.Writer) error {
+		return h.exportService.ExportReservations(c.Request.Context(), restaurantID, date, format, w)
+	})
+}
+
+// ExportCustomers handles exporting the customer directory
+// @Summary Export Customers
+// @Description Export the restaurant's customers, filtered like List Customers. The format is taken from the format parameter, else negotiated from the Accept header (CSV by default)
+// @Tags customers
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param q query string false "Search term"
+// @Param format query string false "csv or xlsx"
+// @Success 200 {file} file
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/customers/export [get]
+func (h *ExportHandler) ExportCustomers(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	format, err := exportFormat(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	streamExport(c, "customers", format, func(w io.Writer) error {
+		return h.exportService.ExportCustomers(c.Request.Context(), restaurantID, c.Query("q"), format, w)
+	})
+}
+
+// exportFormat picks the export format: the format parameter wins over the Accept header
+func exportFormat(c *gin.Context) (string, error) {
+	if format := c.Query("format"); format != "" {
+		return services.ParseExportFormat(format)
+	}
+	if c.NegotiateFormat(services.ExportContentTypeCSV, services.ExportContentTypeXLSX) == services.ExportContentTypeXLSX {
+		return services.ExportFormatXLSX, nil
+	}
+	return services.ExportFormatCSV, nil
+}
+
+// streamExport writes the export straight to the response as an attachment
+// Errors before the first byte are rendered as usual; once the file is partly sent they can only be logged
+func streamExport(c *gin.Context, name, format string, export func(w io.Writer) error) {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Content-Type", services.ExportContentType(format))
+
+	if err := export(c.Writer); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			_ = c.Error(err)
+			return
+		}
+		logger.WithContext(c.Request.Context()).Warn("Export failed after it started streaming",
+			zap.String("export", name),
+			zap.Error(err),
+		)
+		c.Abort()
+	}
+}
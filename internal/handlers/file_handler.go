@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"strings"
+
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FileHandler serves files stored by the local storage backend
+type FileHandler struct {
+	storage *services.LocalStorage
+}
+
+// NewFileHandler creates a new FileHandler instance
+func NewFileHandler(storage *services.LocalStorage) *FileHandler {
+	return &FileHandler{
+		storage: storage,
+	}
+}
+
+// ServeFile serves a locally stored file from a signed URL
+// @Summary Serve Local File
+// @Description Serve a file stored by the local storage backend using a signed, expiring URL
+// @Tags images
+// @Param key path string true "Object Key"
+// @Param expires query int true "Expiry (unix seconds)"
+// @Param signature query string true "URL signature"
+// @Success 200
+// @Failure 403 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/files/{key} [get]
+func (h *FileHandler) ServeFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	path, err := h.storage.OpenSignedFile(key, c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.File(path)
+}
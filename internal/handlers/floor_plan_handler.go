@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FloorPlanHandler handles floor plan and table map requests
+type FloorPlanHandler struct {
+	floorPlanService *services.FloorPlanService
+}
+
+// NewFloorPlanHandler creates a new FloorPlanHandler instance
+func NewFloorPlanHandler(floorPlanService *services.FloorPlanService) *FloorPlanHandler {
+	return &FloorPlanHandler{
+		floorPlanService: floorPlanService,
+	}
+}
+
+// GetFloorPlan handles retrieving the floor plan
+// @Summary Get Floor Plan
+// @Description Get the restaurant's floor sections with their tables
+// @Tags floor-plan
+// @Produce json
+// @Success 200 {array} models.FloorSection
+// @Router /api/v1/floor-plan [get]
+func (h *FloorPlanHandler) GetFloorPlan(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	sections, err := h.floorPlanService.GetFloorPlan(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sections)
+}
+
+// GetLiveFloorPlan handles retrieving the floor plan with live table status
+// @Summary Get Live Floor Plan
+// @Description Get the floor plan with each table's status for the host stand: occupied by a seated party (open check) or a reservation in progress, reserved by one starting within 2 hours, available or inactive
+// @Tags floor-plan
+// @Produce json
+// @Param at query string false "Point in time (RFC3339), defaults to now"
+// @Success 200 {object} services.LiveFloorPlan
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/floor-plan/live [get]
+func (h *FloorPlanHandler) GetLiveFloorPlan(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	at := time.Now()
+	if raw := c.Query("at"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid at, expected RFC3339"))
+			return
+		}
+		at = parsed
+	}
+
+	plan, err := h.floorPlanService.GetLiveFloorPlan(c.Request.Context(), restaurantID, at)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, plan)
+}
+
+// CreateSection handles creating a floor section
+// @Summary Create Floor Section
+// @Description Create a floor section (e.g. terrace, bar) to place tables on
+// @Tags floor-plan
+// @Accept json
+// @Produce json
+// @Param request body services.FloorSectionRequest true "Floor section"
+// @Success 201 {object} models.FloorSection
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/floor-plan/sections [post]
+func (h *FloorPlanHandler) CreateSection(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.FloorSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	section, err := h.floorPlanService.CreateSection(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, section)
+}
+
+// UpdateSection handles updating a floor section
+// @Summary Update Floor Section
+// @Description Rename, reorder or resize a floor section
+// @Tags floor-plan
+// @Accept json
+// @Produce json
+// @Param id path int true "Floor section ID"
+// @Param request body services.FloorSectionRequest true "Floor section"
+// @Success 200 {object} models.FloorSection
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/floor-plan/sections/{id} [put]
+func (h *FloorPlanHandler) UpdateSection(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid floor section ID"))
+		return
+	}
+
+	var req services.FloorSectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	section, err := h.floorPlanService.UpdateSection(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, section)
+}
+
+// DeleteSection handles deleting a floor section
+// @Summary Delete Floor Section
+// @Description Delete a floor section together with its tables
+// @Tags floor-plan
+// @Param id path int true "Floor section ID"
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/floor-plan/sections/{id} [delete]
+func (h *FloorPlanHandler) DeleteSection(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid floor section ID"))
+		return
+	}
+
+	if err := h.floorPlanService.DeleteSection(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateTable handles placing a table on the floor plan
+// @Summary Create Table
+// @Description Place a table on a floor section. Its number is what reservations refer to
+// @Tags floor-plan
+// @Accept json
+// @Produce json
+// @Param request body services.DiningTableRequest true "Table"
+// @Success 201 {object} models.DiningTable
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/floor-plan/tables [post]
+func (h *FloorPlanHandler) CreateTable(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.DiningTableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	table, err := h.floorPlanService.CreateTable(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, table)
+}
+
+// UpdateTable handles updating or moving a table
+// @Summary Update Table
+// @Description Update a table's number, seats, shape, position or section
+// @Tags floor-plan
+// @Accept json
+// @Produce json
+// @Param id path int true "Table ID"
+// @Param request body services.DiningTableRequest true "Table"
+// @Success 200 {object} models.DiningTable
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/floor-plan/tables/{id} [put]
+func (h *FloorPlanHandler) UpdateTable(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid table ID"))
+		return
+	}
+
+	var req services.DiningTableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	table, err := h.floorPlanService.UpdateTable(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, table)
+}
+
+// DeleteTable handles removing a table from the floor plan
+// @Summary Delete Table
+// @Description Remove a table from the floor plan
+// @Tags floor-plan
+// @Param id path int true "Table ID"
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/floor-plan/tables/{id} [delete]
+func (h *FloorPlanHandler) DeleteTable(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid table ID"))
+		return
+	}
+
+	if err := h.floorPlanService.DeleteTable(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/graph"
+	"restaurant-backend/internal/logger"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gin-gonic/gin"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+)
+
+// graphQLComplexityLimit bounds how many fields a single GraphQL query may select
+const graphQLComplexityLimit = 200
+
+// GraphQLHandler serves the GraphQL read API (menus, orders, reservations)
+type GraphQLHandler struct {
+	server *handler.Server
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler instance
+func NewGraphQLHandler(resolver *graph.Resolver) *GraphQLHandler {
+	server := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.Use(extension.Introspection{})
+	server.Use(extension.FixedComplexityLimit(graphQLComplexityLimit))
+	server.AroundFields(internalResolverErrors)
+	server.SetErrorPresenter(presentGraphQLError)
+
+	return &GraphQLHandler{
+		server: server,
+	}
+}
+
+// Query handles GraphQL queries
+// @Summary GraphQL Query
+// @Description Query the restaurant with its menu tree (categories, items, images), orders and reservations in one request. Queries are scoped to the caller's restaurant like the REST endpoints; see internal/graph/schema.graphqls for the schema. Errors carry the API error code in extensions.code
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body object true "GraphQL request (query, variables, operationName)"
+// @Success 200 {object} object
+// @Router /api/v1/graphql [post]
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	h.server.ServeHTTP(c.Writer, c.Request)
+}
+
+// internalResolverErrors turns resolver errors without an API code into internal errors, as the REST error
+// handler does, so their details are logged rather than returned
+func internalResolverErrors(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	res, err := next(ctx)
+	if err != nil {
+		return res, apperrors.From(err)
+	}
+	return res, nil
+}
+
+// presentGraphQLError reports resolver errors like the REST error envelope: the API message, with the code in
+// extensions.code. Query parse and validation errors are returned as they are
+func presentGraphQLError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var appErr *apperrors.Error
+	if !errors.As(err, &appErr) {
+		return gqlErr
+	}
+	if appErr.Status >= 500 {
+		cause := error(appErr)
+		if appErr.Err != nil {
+			cause = appErr.Err
+		}
+		logger.WithContext(ctx).Error("GraphQL query failed",
+			zap.String("path", gqlErr.Path.String()),
+			zap.String("code", string(appErr.Code)),
+			zap.Error(cause),
+		)
+	}
+
+	gqlErr.Message = appErr.Message
+	gqlErr.Extensions = map[string]interface{}{"code": appErr.Code}
+	return gqlErr
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler handles liveness and readiness probes
+type HealthHandler struct {
+	healthService *services.HealthService
+}
+
+// NewHealthHandler creates a new HealthHandler instance
+func NewHealthHandler(healthService *services.HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+// Health handles the liveness probe
+// @Summary Liveness Probe
+// @Description Report that the process is up. Does not check dependencies
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /health [get]
+func (h *HealthHandler) Health(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"service": "restaurant-backend",
+	})
+}
+
+// Ready handles the readiness probe
+// @Summary Readiness Probe
+// @Description Ping Postgres and object storage and report per-dependency status. Returns 503 when any configured dependency is unreachable
+// @Tags health
+// @Produce json
+// @Success 200 {object} services.ReadinessReport
+// @Failure 503 {object} services.ReadinessReport
+// @Router /ready [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.healthService.CheckReadiness(c.Request.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/services"
 
@@ -14,44 +15,44 @@ import (
 
 // ImageHandler handles image upload and download
 type ImageHandler struct {
-	s3Service *services.S3Service
+	storage services.Storage
 }
 
 // NewImageHandler creates a new ImageHandler instance
-func NewImageHandler(s3Service *services.S3Service) *ImageHandler {
+func NewImageHandler(storage services.Storage) *ImageHandler {
 	return &ImageHandler{
-		s3Service: s3Service,
+		storage: storage,
 	}
 }
 
 // UploadImage handles image upload
 // @Summary Upload Image
-// @Description Upload an image file to S3 with tenant isolation
+// @Description Upload an image file to storage with tenant isolation
 // @Tags images
 // @Accept multipart/form-data
 // @Produce json
 // @Param file formData file true "Image file"
 // @Success 201 {object} map[string]string
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
 // @Router /api/v1/images/upload [post]
 func (h *ImageHandler) UploadImage(c *gin.Context) {
 	// Get restaurant ID from request context (set by middleware)
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	// Get file from form
 	file, err := c.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeValidationFailed, "file is required"))
 		return
 	}
 
 	// Validate file size (max 10MB)
 	if file.Size > 10*1024*1024 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "file size exceeds 10MB limit"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeValidationFailed, "file size exceeds 10MB limit"))
 		return
 	}
 
@@ -65,14 +66,14 @@ func (h *ImageHandler) UploadImage(c *gin.Context) {
 		".webp": true,
 	}
 	if !allowedExts[ext] {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid file type. Allowed: jpg, jpeg, png, gif, webp"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid file type. Allowed: jpg, jpeg, png, gif, webp"))
 		return
 	}
 
 	// Open file
 	src, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to open file"})
+		_ = c.Error(apperrors.Internal(err))
 		return
 	}
 	defer src.Close()
@@ -90,10 +91,10 @@ func (h *ImageHandler) UploadImage(c *gin.Context) {
 		contentType = "image/webp"
 	}
 
-	// Upload to S3 using request context
-	key, err := h.s3Service.UploadFile(c.Request.Context(), restaurantID, file.Filename, contentType, src)
+	// Upload to storage using request context
+	key, err := h.storage.UploadFile(c.Request.Context(), restaurantID, file.Filename, contentType, src)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to upload file: %v", err)})
+		_ = c.Error(apperrors.Wrap(err, http.StatusInternalServerError, apperrors.CodeInternal, "failed to upload file"))
 		return
 	}
 
@@ -111,33 +112,33 @@ func (h *ImageHandler) UploadImage(c *gin.Context) {
 // @Produce json
 // @Param key path string true "S3 Object Key"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
 // @Router /api/v1/images/{key} [get]
 func (h *ImageHandler) GetImageURL(c *gin.Context) {
 	key := c.Param("key")
 	if key == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "key is required"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeValidationFailed, "key is required"))
 		return
 	}
 
 	// Get restaurant ID from request context for validation (ensure tenant can only access their own images)
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	// Validate that the key belongs to the restaurant
 	expectedPrefix := fmt.Sprintf("restaurant-%d/", restaurantID)
 	if len(key) < len(expectedPrefix) || key[:len(expectedPrefix)] != expectedPrefix {
-		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		_ = c.Error(apperrors.Forbidden(apperrors.CodeForbidden, "access denied"))
 		return
 	}
 
 	// Generate presigned URL (valid for 1 hour)
-	url, err := h.s3Service.GeneratePresignedURL(c.Request.Context(), key, time.Hour)
+	url, err := h.storage.GeneratePresignedURL(c.Request.Context(), key, time.Hour)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to generate URL"})
+		_ = c.Error(apperrors.Wrap(err, http.StatusInternalServerError, apperrors.CodeInternal, "failed to generate URL"))
 		return
 	}
 
@@ -149,37 +150,37 @@ func (h *ImageHandler) GetImageURL(c *gin.Context) {
 
 // DeleteImage handles image deletion
 // @Summary Delete Image
-// @Description Delete an image from S3
+// @Description Delete an image from storage
 // @Tags images
 // @Param key path string true "S3 Object Key"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 403 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
 // @Router /api/v1/images/{key} [delete]
 func (h *ImageHandler) DeleteImage(c *gin.Context) {
 	key := c.Param("key")
 	if key == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "key is required"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeValidationFailed, "key is required"))
 		return
 	}
 
 	// Get restaurant ID from request context for validation
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	// Validate that the key belongs to the restaurant
 	expectedPrefix := fmt.Sprintf("restaurant-%d/", restaurantID)
 	if len(key) < len(expectedPrefix) || key[:len(expectedPrefix)] != expectedPrefix {
-		c.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+		_ = c.Error(apperrors.Forbidden(apperrors.CodeForbidden, "access denied"))
 		return
 	}
 
-	// Delete from S3
-	if err := h.s3Service.DeleteFile(c.Request.Context(), key); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete file"})
+	// Delete from storage
+	if err := h.storage.DeleteFile(c.Request.Context(), key); err != nil {
+		_ = c.Error(apperrors.Wrap(err, http.StatusInternalServerError, apperrors.CodeInternal, "failed to delete file"))
 		return
 	}
 
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IntegrityHandler handles tenant integrity check requests
+type IntegrityHandler struct {
+	integrityService *services.IntegrityService
+}
+
+// NewIntegrityHandler creates a new IntegrityHandler instance
+func NewIntegrityHandler(integrityService *services.IntegrityService) *IntegrityHandler {
+	return &IntegrityHandler{
+		integrityService: integrityService,
+	}
+}
+
+// ListFindings handles listing integrity findings
+// @Summary List Integrity Findings
+// @Description List cross-tenant anomalies detected by the integrity checker
+// @Tags platform
+// @Produce json
+// @Param status query string false "Filter by status (open, quarantined, dismissed)"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.IntegrityFindingList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/platform/integrity/findings [get]
+func (h *IntegrityHandler) ListFindings(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid limit parameter"))
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid offset parameter"))
+		return
+	}
+
+	findings, err := h.integrityService.ListFindings(c.Request.Context(), c.Query("status"), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, findings)
+}
+
+// RunChecks handles running the integrity checks on demand
+// @Summary Run Integrity Checks
+// @Description Scan all tenants for integrity anomalies now instead of waiting for the scheduled run
+// @Tags platform
+// @Produce json
+// @Success 200 {object} services.IntegrityRunResult
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/platform/integrity/run [post]
+func (h *IntegrityHandler) RunChecks(c *gin.Context) {
+	result, err := h.integrityService.RunChecks(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// QuarantineFinding handles quarantining the row behind a finding
+// @Summary Quarantine Integrity Finding
+// @Description Remove the offending row from its table, keeping a JSON snapshot on the finding
+// @Tags platform
+// @Produce json
+// @Param id path int true "Finding ID"
+// @Success 200 {object} models.IntegrityFinding
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/platform/integrity/findings/{id}/quarantine [post]
+func (h *IntegrityHandler) QuarantineFinding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid finding ID"))
+		return
+	}
+
+	finding, err := h.integrityService.QuarantineFinding(c.Request.Context(), uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, finding)
+}
+
+// DismissFinding handles dismissing a finding
+// @Summary Dismiss Integrity Finding
+// @Description Mark a finding as reviewed without modifying the offending row
+// @Tags platform
+// @Produce json
+// @Param id path int true "Finding ID"
+// @Success 200 {object} models.IntegrityFinding
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/platform/integrity/findings/{id}/dismiss [post]
+func (h *IntegrityHandler) DismissFinding(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid finding ID"))
+		return
+	}
+
+	finding, err := h.integrityService.DismissFinding(c.Request.Context(), uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, finding)
+}
@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InventoryHandler handles ingredient, recipe and menu item costing requests
+type InventoryHandler struct {
+	inventoryService *services.InventoryService
+}
+
+// NewInventoryHandler creates a new InventoryHandler instance
+func NewInventoryHandler(inventoryService *services.InventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// ListIngredients handles listing the restaurant's ingredients
+// @Summary List Ingredients
+// @Description List the restaurant's ingredients with their stock and cost per unit
+// @Tags inventory
+// @Produce json
+// @Success 200 {array} models.Ingredient
+// @Router /api/v1/inventory/ingredients [get]
+func (h *InventoryHandler) ListIngredients(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	ingredients, err := h.inventoryService.ListIngredients(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredients)
+}
+
+// CreateIngredient handles creating an ingredient
+// @Summary Create Ingredient
+// @Description Create an ingredient with its opening stock and cost per unit; afterwards goods receipts maintain both
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param request body services.IngredientRequest true "Ingredient"
+// @Success 201 {object} models.Ingredient
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/inventory/ingredients [post]
+func (h *InventoryHandler) CreateIngredient(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.IngredientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	ingredient, err := h.inventoryService.CreateIngredient(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ingredient)
+}
+
+// UpdateIngredient handles updating an ingredient
+// @Summary Update Ingredient
+// @Description Rename an ingredient or change its unit or preferred supplier; stock and cost are left to goods receipts
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path int true "Ingredient ID"
+// @Param request body services.IngredientRequest true "Ingredient"
+// @Success 200 {object} models.Ingredient
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/inventory/ingredients/{id} [put]
+func (h *InventoryHandler) UpdateIngredient(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid ingredient ID"))
+		return
+	}
+
+	var req services.IngredientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	ingredient, err := h.inventoryService.UpdateIngredient(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredient)
+}
+
+// DeleteIngredient handles deleting an ingredient
+// @Summary Delete Ingredient
+// @Description Delete an ingredient that no recipe or purchase order uses
+// @Tags inventory
+// @Param id path int true "Ingredient ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/inventory/ingredients/{id} [delete]
+func (h *InventoryHandler) DeleteIngredient(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid ingredient ID"))
+		return
+	}
+
+	if err := h.inventoryService.DeleteIngredient(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetRecipe handles getting the recipe of a menu item
+// @Summary Get Recipe
+// @Description Get the ingredients one serving of a menu item uses, with its food cost at current ingredient costs
+// @Tags inventory
+// @Produce json
+// @Param menu_item_id path int true "Menu item ID"
+// @Success 200 {object} services.Recipe
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/inventory/recipes/{menu_item_id} [get]
+func (h *InventoryHandler) GetRecipe(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	menuItemID, err := strconv.ParseUint(c.Param("menu_item_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
+		return
+	}
+
+	recipe, err := h.inventoryService.GetRecipe(c.Request.Context(), restaurantID, uint(menuItemID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recipe)
+}
+
+// ReplaceRecipe handles replacing the recipe of a menu item
+// @Summary Replace Recipe
+// @Description Replace the ingredients one serving of a menu item uses; an empty list removes the recipe
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param menu_item_id path int true "Menu item ID"
+// @Param request body services.RecipeRequest true "Recipe"
+// @Success 200 {object} services.Recipe
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/inventory/recipes/{menu_item_id} [put]
+func (h *InventoryHandler) ReplaceRecipe(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	menuItemID, err := strconv.ParseUint(c.Param("menu_item_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
+		return
+	}
+
+	var req services.RecipeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	recipe, err := h.inventoryService.ReplaceRecipe(c.Request.Context(), restaurantID, uint(menuItemID), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recipe)
+}
+
+// ListMenuItemCosts handles costing the menu
+// @Summary List Menu Item Costs
+// @Description Cost of goods of every menu item from its recipe at current ingredient costs, with the margin at its regular price
+// @Tags inventory
+// @Produce json
+// @Success 200 {array} services.MenuItemCost
+// @Router /api/v1/inventory/menu-costs [get]
+func (h *InventoryHandler) ListMenuItemCosts(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	costs, err := h.inventoryService.MenuItemCosts(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, costs)
+}
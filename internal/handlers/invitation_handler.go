@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InvitationHandler handles user invitation requests
+type InvitationHandler struct {
+	invitationService *services.InvitationService
+}
+
+// NewInvitationHandler creates a new InvitationHandler instance
+func NewInvitationHandler(invitationService *services.InvitationService) *InvitationHandler {
+	return &InvitationHandler{
+		invitationService: invitationService,
+	}
+}
+
+// CreateInvitation handles inviting a user to the restaurant
+// @Summary Invite User
+// @Description Invite someone to the restaurant with a role. They get an email with a link where they set their own password; the link expires
+// @Tags invitations
+// @Accept json
+// @Produce json
+// @Param request body services.CreateInvitationRequest true "Invitation"
+// @Success 201 {object} models.UserInvitation
+// @Failure 400 {object} apperrors.Response
+// @Failure 402 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/invitations [post]
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, _ := ctx.GetUserID(c.Request.Context())
+
+	var req services.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	invitation, err := h.invitationService.Create(c.Request.Context(), restaurantID, &req, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// ListInvitations handles listing the restaurant's invitations
+// @Summary List Invitations
+// @Description List the restaurant's invitations, newest first, with their status (pending, accepted, revoked, expired)
+// @Tags invitations
+// @Produce json
+// @Success 200 {array} models.UserInvitation
+// @Router /api/v1/invitations [get]
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	invitations, err := h.invitationService.List(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+// ResendInvitation handles resending an invitation
+// @Summary Resend Invitation
+// @Description Email a new link with a fresh expiry; earlier links stop working. Expired invitations can be resent
+// @Tags invitations
+// @Produce json
+// @Param id path int true "Invitation ID"
+// @Success 200 {object} models.UserInvitation
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/invitations/{id}/resend [post]
+func (h *InvitationHandler) ResendInvitation(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, _ := ctx.GetUserID(c.Request.Context())
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid invitation ID"))
+		return
+	}
+
+	invitation, err := h.invitationService.Resend(c.Request.Context(), restaurantID, uint(id), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// RevokeInvitation handles revoking an invitation
+// @Summary Revoke Invitation
+// @Description Cancel an invitation that wasn't accepted yet; its link stops working
+// @Tags invitations
+// @Param id path int true "Invitation ID"
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/invitations/{id} [delete]
+func (h *InvitationHandler) RevokeInvitation(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid invitation ID"))
+		return
+	}
+
+	if err := h.invitationService.Revoke(c.Request.Context(), restaurantID, uint(id)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PreviewInvitation handles showing the invitation behind an accept link
+// @Summary Preview Invitation
+// @Description Show the restaurant, email address and role of an invitation before accepting it
+// @Tags auth
+// @Produce json
+// @Param token query string true "Token from the invitation link"
+// @Success 200 {object} services.InvitationPreview
+// @Failure 404 {object} apperrors.Response
+// @Failure 410 {object} apperrors.Response
+// @Router /api/v1/auth/invitations [get]
+func (h *InvitationHandler) PreviewInvitation(c *gin.Context) {
+	preview, err := h.invitationService.Preview(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// AcceptInvitation handles accepting an invitation
+// @Summary Accept Invitation
+// @Description Create the invited account with a password of the user's choice and sign them in
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.AcceptInvitationRequest true "Token and password"
+// @Success 201 {object} services.LoginResponse
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Failure 410 {object} apperrors.Response
+// @Router /api/v1/auth/invitations/accept [post]
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var req services.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	response, err := h.invitationService.Accept(c.Request.Context(), &req, services.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KAMHandler handles the KAM portfolio requests
+type KAMHandler struct {
+	kamService    *services.KAMService
+	healthService *services.RestaurantHealthService
+}
+
+// NewKAMHandler creates a new KAMHandler instance
+func NewKAMHandler(kamService *services.KAMService, healthService *services.RestaurantHealthService) *KAMHandler {
+	return &KAMHandler{
+		kamService:    kamService,
+		healthService: healthService,
+	}
+}
+
+// GetDashboard handles retrieving the requesting KAM's portfolio dashboard
+// @Summary Get KAM Dashboard
+// @Description Summarize the restaurants assigned to the requesting KAM: activation funnel, order volume and revenue per restaurant this month (UTC), active restaurants without orders for 14 days, and pending registrations waiting for activation
+// @Tags kam
+// @Produce json
+// @Success 200 {object} services.KAMDashboard
+// @Failure 403 {object} apperrors.Response
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/kam/dashboard [get]
+func (h *KAMHandler) GetDashboard(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	dashboard, err := h.kamService.GetDashboard(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dashboard)
+}
+
+// ListHealth handles listing the health scores of the requesting KAM's restaurants
+// @Summary List Restaurant Health
+// @Description List the health scores (ordering trend, menu completeness, staff login recency, email delivery) and churn-risk flags of the active restaurants assigned to the requesting KAM, lowest score first. Scores are recomputed periodically
+// @Tags kam
+// @Produce json
+// @Param flagged query bool false "Only restaurants with flags"
+// @Success 200 {array} models.RestaurantHealth
+// @Failure 403 {object} apperrors.Response
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/kam/health [get]
+func (h *KAMHandler) ListHealth(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	scores, err := h.healthService.ListForKAM(c.Request.Context(), userID, c.Query("flagged") == "true")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scores)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MenuCloneHandler handles menu cloning between restaurants
+type MenuCloneHandler struct {
+	menuCloneService *services.MenuCloneService
+}
+
+// NewMenuCloneHandler creates a new MenuCloneHandler instance
+func NewMenuCloneHandler(menuCloneService *services.MenuCloneService) *MenuCloneHandler {
+	return &MenuCloneHandler{
+		menuCloneService: menuCloneService,
+	}
+}
+
+// CloneMenu handles copying a menu from one restaurant to another
+// @Summary Clone Menu
+// @Description Copy categories, items and images from one restaurant to another (KAM, or org Admin between the organization's locations). The target must not have a menu yet.
+// @Tags menu
+// @Accept json
+// @Produce json
+// @Param request body services.CloneMenuRequest true "Clone options"
+// @Success 201 {object} services.MenuCloneResult
+// @Failure 400 {object} apperrors.Response
+// @Failure 402 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/menu/clone [post]
+func (h *MenuCloneHandler) CloneMenu(c *gin.Context) {
+	var req services.CloneMenuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	role, _ := ctx.GetUserRole(c.Request.Context())
+	organizationID, _ := ctx.GetOrganizationID(c.Request.Context())
+
+	result, err := h.menuCloneService.CloneMenu(c.Request.Context(), &req, role, organizationID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strconv"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/dto"
+	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
 	"restaurant-backend/internal/services"
 
@@ -14,15 +16,24 @@ import (
 
 // MenuItemHandler handles menu item-related requests
 type MenuItemHandler struct {
-	menuItemRepo    *repositories.MenuItemRepository
-	menuItemService *services.MenuItemService
+	menuItemRepo      *repositories.MenuItemRepository
+	menuItemService   *services.MenuItemService
+	permissionService *services.PermissionService
+	approvalService   *services.ApprovalService
 }
 
 // NewMenuItemHandler creates a new MenuItemHandler instance
-func NewMenuItemHandler(menuItemRepo *repositories.MenuItemRepository) *MenuItemHandler {
+func NewMenuItemHandler(
+	menuItemRepo *repositories.MenuItemRepository,
+	menuItemService *services.MenuItemService,
+	permissionService *services.PermissionService,
+	approvalService *services.ApprovalService,
+) *MenuItemHandler {
 	return &MenuItemHandler{
-		menuItemRepo:    menuItemRepo,
-		menuItemService: services.NewMenuItemService(menuItemRepo),
+		menuItemRepo:      menuItemRepo,
+		menuItemService:   menuItemService,
+		permissionService: permissionService,
+		approvalService:   approvalService,
 	}
 }
 
@@ -34,27 +45,27 @@ func NewMenuItemHandler(menuItemRepo *repositories.MenuItemRepository) *MenuItem
 // @Produce json
 // @Param request body dto.CreateMenuItemRequest true "Menu Item data"
 // @Success 201 {object} models.MenuItem
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
 // @Router /api/v1/menu-items [post]
 func (h *MenuItemHandler) CreateMenuItem(c *gin.Context) {
 	// Bind request
 	var req dto.CreateMenuItemRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	// Get restaurant ID from context (set by middleware)
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	// Create menu item using service
 	menuItem, err := h.menuItemService.CreateMenuItem(c.Request.Context(), &req, restaurantID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -68,21 +79,28 @@ func (h *MenuItemHandler) CreateMenuItem(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Menu Item ID"
 // @Success 200 {object} models.MenuItem
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/menu-items/{id} [get]
 func (h *MenuItemHandler) GetMenuItem(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid menu item ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
 		return
 	}
 
-	menuItem, err := h.menuItemRepo.GetByIDWithContext(c.Request.Context(), uint(id))
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	menuItem, err := h.menuItemRepo.GetByIDForRestaurant(c.Request.Context(), uint(id), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "menu item not found"})
+		_ = c.Error(apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found"))
 		return
 	}
 
+	setETag(c, menuItem.Version)
 	c.JSON(http.StatusOK, menuItem)
 }
 
@@ -97,7 +115,7 @@ func (h *MenuItemHandler) GetMenuItem(c *gin.Context) {
 func (h *MenuItemHandler) ListMenuItems(c *gin.Context) {
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -108,7 +126,7 @@ func (h *MenuItemHandler) ListMenuItems(c *gin.Context) {
 		if err == nil {
 			menuItems, err := h.menuItemRepo.GetByCategoryIDWithContext(c.Request.Context(), uint(categoryID))
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				_ = c.Error(err)
 				return
 			}
 			c.JSON(http.StatusOK, menuItems)
@@ -119,7 +137,7 @@ func (h *MenuItemHandler) ListMenuItems(c *gin.Context) {
 	// Otherwise, get all menu items for the restaurant
 	menuItems, err := h.menuItemRepo.GetByRestaurantIDWithContext(c.Request.Context(), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -128,70 +146,147 @@ func (h *MenuItemHandler) ListMenuItems(c *gin.Context) {
 
 // UpdateMenuItem handles updating a menu item
 // @Summary Update Menu Item
-// @Description Update an existing menu item (only provided fields will be updated)
+// @Description Update an existing menu item (only provided fields will be updated). Changing prices requires the menu.change_prices permission. When the restaurant requires approval of Staff price changes, the update is queued for an Admin and 202 is returned with the pending change
 // @Tags menu-items
 // @Accept json
 // @Produce json
 // @Param id path int true "Menu Item ID"
 // @Param request body dto.UpdateMenuItemRequest true "Menu Item update data (only provided fields will be updated)"
+// @Param If-Match header string false "ETag from a previous read; rejects the update with 409 if the resource changed since"
 // @Success 200 {object} models.MenuItem
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Success 202 {object} models.PendingChange
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/menu-items/{id} [put]
 func (h *MenuItemHandler) UpdateMenuItem(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid menu item ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
 		return
 	}
 
 	// Bind update request
 	var req dto.UpdateMenuItemRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
+	// Price changes need their own permission, which an Admin may have revoked for this staff member
+	if req.Price != nil || req.ChannelPrices != nil {
+		if err := h.permissionService.Require(c.Request.Context(), models.PermissionChangePrices); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
 	// Get restaurant ID from context (set by middleware)
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
-	// Update menu item using service (with ownership validation)
-	menuItem, err := h.menuItemService.UpdateMenuItem(c.Request.Context(), uint(id), &req, restaurantID)
+	expectedVersion, err := ifMatchVersion(c)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "menu item not found" {
-			statusCode = http.StatusNotFound
+		_ = c.Error(err)
+		return
+	}
+
+	if req.Price != nil || req.ChannelPrices != nil {
+		if submitForApproval(c, h.approvalService, restaurantID, models.PendingChangeTypePriceChange, uint(id), &req) {
+			return
 		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+	}
+
+	// Update menu item using service (with ownership validation)
+	menuItem, err := h.menuItemService.UpdateMenuItem(c.Request.Context(), uint(id), &req, restaurantID, expectedVersion)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, menuItem.Version)
 	c.JSON(http.StatusOK, menuItem)
 }
 
 // DeleteMenuItem handles deleting a menu item
 // @Summary Delete Menu Item
-// @Description Delete a menu item
+// @Description Delete a menu item. When the restaurant requires approval of Staff deletions, the deletion is queued for an Admin and 202 is returned with the pending change
 // @Tags menu-items
+// @Produce json
 // @Param id path int true "Menu Item ID"
 // @Success 204
-// @Failure 404 {object} map[string]string
+// @Success 202 {object} models.PendingChange
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/menu-items/{id} [delete]
 func (h *MenuItemHandler) DeleteMenuItem(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid menu item ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
 		return
 	}
 
-	if err := h.menuItemRepo.DeleteWithContext(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	if submitForApproval(c, h.approvalService, restaurantID, models.PendingChangeTypeMenuItemDeletion, uint(id), nil) {
+		return
+	}
+
+	if err := h.menuItemService.DeleteMenuItem(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// BulkSetAvailability handles toggling availability for a list of menu items ("86 list")
+// @Summary Bulk Set Menu Item Availability
+// @Description Mark many menu items as available or unavailable in one update. Fails without changes if any item is not found. The change is audit-logged and emits a menu-changed event
+// @Tags menu-items
+// @Accept json
+// @Produce json
+// @Param request body dto.BulkMenuItemAvailabilityRequest true "Availability data"
+// @Success 200 {object} services.MenuItemAvailabilityResult
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/menu-items/bulk-availability [post]
+func (h *MenuItemHandler) BulkSetAvailability(c *gin.Context) {
+	var req dto.BulkMenuItemAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+	role, _ := ctx.GetUserRole(c.Request.Context())
+
+	result, err := h.menuItemService.SetItemsAvailability(c.Request.Context(), &req, restaurantID, services.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
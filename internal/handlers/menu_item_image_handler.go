@@ -1,14 +1,17 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // MenuItemImageHandler handles menu item image-related requests
@@ -32,25 +35,25 @@ func NewMenuItemImageHandler(imageRepo *repositories.MenuItemImageRepository) *M
 // @Param item_id path int true "Menu Item ID"
 // @Param image body models.MenuItemImage true "Image data"
 // @Success 201 {object} models.MenuItemImage
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
 // @Router /api/v1/menu-item-images/:item_id [post]
 func (h *MenuItemImageHandler) CreateMenuItemImage(c *gin.Context) {
 	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid menu item ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
 		return
 	}
 
 	var image models.MenuItemImage
 	if err := c.ShouldBindJSON(&image); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	// Get restaurant ID from request context (set by middleware)
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -58,14 +61,14 @@ func (h *MenuItemImageHandler) CreateMenuItemImage(c *gin.Context) {
 	image.MenuItemID = uint(itemID)
 
 	// Create the image
-	if err := h.imageRepo.Create(&image); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.imageRepo.CreateWithContext(c.Request.Context(), &image); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	// If this image should be primary, set it as primary
 	if image.IsPrimary {
-		if err := h.imageRepo.SetPrimary(image.MenuItemID, image.ID); err != nil {
+		if err := h.imageRepo.SetPrimaryWithContext(c.Request.Context(), image.MenuItemID, image.ID, restaurantID); err != nil {
 			// Log error but don't fail the request - image is already created
 		}
 	}
@@ -84,13 +87,19 @@ func (h *MenuItemImageHandler) CreateMenuItemImage(c *gin.Context) {
 func (h *MenuItemImageHandler) ListMenuItemImages(c *gin.Context) {
 	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid menu item ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
 		return
 	}
 
-	images, err := h.imageRepo.GetByMenuItemID(uint(itemID))
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	images, err := h.imageRepo.GetByMenuItemIDWithContext(c.Request.Context(), uint(itemID), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -104,17 +113,26 @@ func (h *MenuItemImageHandler) ListMenuItemImages(c *gin.Context) {
 // @Param item_id path int true "Menu Item ID"
 // @Param image_id path int true "Image ID"
 // @Success 204 "No Content"
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/menu-item-images/:item_id/:image_id [delete]
 func (h *MenuItemImageHandler) DeleteMenuItemImage(c *gin.Context) {
 	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid image ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid image ID"))
 		return
 	}
 
-	if err := h.imageRepo.Delete(uint(imageID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	if err := h.imageRepo.DeleteWithContext(c.Request.Context(), uint(imageID), restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = apperrors.NotFound(apperrors.CodeImageNotFound, "image not found")
+		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -128,23 +146,32 @@ func (h *MenuItemImageHandler) DeleteMenuItemImage(c *gin.Context) {
 // @Param item_id path int true "Menu Item ID"
 // @Param image_id path int true "Image ID"
 // @Success 200 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/menu-item-images/:item_id/:image_id/primary [put]
 func (h *MenuItemImageHandler) SetPrimaryImage(c *gin.Context) {
 	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid menu item ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
 		return
 	}
 
 	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid image ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid image ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
-	if err := h.imageRepo.SetPrimary(uint(itemID), uint(imageID)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.imageRepo.SetPrimaryWithContext(c.Request.Context(), uint(itemID), uint(imageID), restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = apperrors.NotFound(apperrors.CodeImageNotFound, "image not found")
+		}
+		_ = c.Error(err)
 		return
 	}
 
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MenuPairingHandler handles the "pairs well with" links of menu items
+type MenuPairingHandler struct {
+	pairingService *services.MenuPairingService
+}
+
+// NewMenuPairingHandler creates a new MenuPairingHandler instance
+func NewMenuPairingHandler(pairingService *services.MenuPairingService) *MenuPairingHandler {
+	return &MenuPairingHandler{
+		pairingService: pairingService,
+	}
+}
+
+// ListPairings handles listing a menu item's pairings
+// @Summary List Menu Item Pairings
+// @Description List the items suggested with a menu item: manual links (source manual) and items frequently ordered together (source automatic, score = orders with both, recomputed daily)
+// @Tags menu-items
+// @Produce json
+// @Param id path int true "Menu Item ID"
+// @Success 200 {array} models.MenuItemPairing
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/menu-items/{id}/pairings [get]
+func (h *MenuPairingHandler) ListPairings(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	pairings, err := h.pairingService.ListPairings(c.Request.Context(), restaurantID, uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pairings)
+}
+
+// SetPairings handles replacing a menu item's manual pairings
+// @Summary Set Menu Item Pairings
+// @Description Replace the items linked to a menu item as "pairs well with", suggested in the given order before the automatic pairings
+// @Tags menu-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Menu Item ID"
+// @Param request body services.SetPairingsRequest true "Paired items"
+// @Success 200 {array} models.MenuItemPairing
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/menu-items/{id}/pairings [put]
+func (h *MenuPairingHandler) SetPairings(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.SetPairingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	pairings, err := h.pairingService.SetPairings(c.Request.Context(), restaurantID, uint(id), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pairings)
+}
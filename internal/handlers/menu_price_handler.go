@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MenuPriceHandler handles the price history and scheduled price changes of menu items
+type MenuPriceHandler struct {
+	priceService    *services.MenuPriceService
+	approvalService *services.ApprovalService
+}
+
+// NewMenuPriceHandler creates a new MenuPriceHandler instance
+func NewMenuPriceHandler(priceService *services.MenuPriceService, approvalService *services.ApprovalService) *MenuPriceHandler {
+	return &MenuPriceHandler{
+		priceService:    priceService,
+		approvalService: approvalService,
+	}
+}
+
+// GetPriceHistory handles listing a menu item's price changes
+// @Summary Get Menu Item Price History
+// @Description List the price changes of a menu item, latest effective first: changes made through menu item updates (applied), scheduled changes and cancelled ones. An empty channel is the menu price
+// @Tags menu-items
+// @Produce json
+// @Param id path int true "Menu Item ID"
+// @Success 200 {array} models.MenuItemPriceChange
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/menu-items/{id}/price-history [get]
+func (h *MenuPriceHandler) GetPriceHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	changes, err := h.priceService.GetPriceHistory(c.Request.Context(), restaurantID, uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// SchedulePriceChange handles scheduling a price change of a menu item
+// @Summary Schedule Menu Item Price Change
+// @Description Schedule a new price of a menu item from a future time (e.g. a new menu on the 1st). Without a channel the menu price changes; a channel without a price removes the channel price. Orders placed from effective_at are charged the new price. When the restaurant requires approval of Staff price changes, the change is queued for an Admin and 202 is returned with the pending change
+// @Tags menu-items
+// @Accept json
+// @Produce json
+// @Param id path int true "Menu Item ID"
+// @Param request body services.SchedulePriceRequest true "Price change"
+// @Success 201 {object} models.MenuItemPriceChange
+// @Success 202 {object} models.PendingChange
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/menu-items/{id}/price-changes [post]
+func (h *MenuPriceHandler) SchedulePriceChange(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.SchedulePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	if submitForApproval(c, h.approvalService, restaurantID, models.PendingChangeTypeScheduledPriceChange, uint(id), &req) {
+		return
+	}
+
+	change, err := h.priceService.SchedulePriceChange(c.Request.Context(), restaurantID, userID, uint(id), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, change)
+}
+
+// CancelPriceChange handles cancelling a scheduled price change
+// @Summary Cancel Scheduled Price Change
+// @Description Cancel a scheduled price change of a menu item before it takes effect; it stays in the price history as cancelled
+// @Tags menu-items
+// @Produce json
+// @Param id path int true "Menu Item ID"
+// @Param change_id path int true "Price Change ID"
+// @Success 200 {object} models.MenuItemPriceChange
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/menu-items/{id}/price-changes/{change_id} [delete]
+func (h *MenuPriceHandler) CancelPriceChange(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
+		return
+	}
+	changeID, err := strconv.ParseUint(c.Param("change_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid price change ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	change, err := h.priceService.CancelPriceChange(c.Request.Context(), restaurantID, uint(id), uint(changeID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, change)
+}
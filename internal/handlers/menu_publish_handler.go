@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MenuPublishHandler handles the draft menu and its publishing
+type MenuPublishHandler struct {
+	publishService *services.MenuPublishService
+}
+
+// NewMenuPublishHandler creates a new MenuPublishHandler instance
+func NewMenuPublishHandler(publishService *services.MenuPublishService) *MenuPublishHandler {
+	return &MenuPublishHandler{
+		publishService: publishService,
+	}
+}
+
+// GetDraft handles getting the unpublished changes of the menu
+// @Summary Get Draft Menu
+// @Description List what the next publish puts on the public menu: draft categories and items (publish_status draft) and published ones with pending edits (draft_changes, in the form of their update request). Edits are drafts while menu_drafts_enabled is set in the restaurant settings
+// @Tags menu
+// @Produce json
+// @Success 200 {object} services.MenuDraft
+// @Router /api/v1/menu/draft [get]
+func (h *MenuPublishHandler) GetDraft(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	draft, err := h.publishService.GetDraft(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, draft)
+}
+
+// PublishMenu handles publishing the draft menu
+// @Summary Publish Menu
+// @Description Put the draft menu on the public menu at once: draft categories and items are published and pending edits applied in a single transaction, then the publication is recorded with the next version number
+// @Tags menu
+// @Accept json
+// @Produce json
+// @Param request body services.PublishMenuRequest false "Publication note"
+// @Success 201 {object} models.MenuPublication
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/menu/publish [post]
+func (h *MenuPublishHandler) PublishMenu(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	// The body is optional
+	var req services.PublishMenuRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	publication, err := h.publishService.Publish(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, publication)
+}
+
+// ListPublications handles listing the menu publish history
+// @Summary List Menu Publications
+// @Description List the restaurant's menu publishes, latest first, with what each one added and updated
+// @Tags menu
+// @Produce json
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.MenuPublicationList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/menu/publications [get]
+func (h *MenuPublishHandler) ListPublications(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	publications, err := h.publishService.ListPublications(c.Request.Context(), restaurantID, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, publications)
+}
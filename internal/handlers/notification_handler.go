@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/metrics"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// notificationPingInterval is how often the WebSocket is pinged to detect dead connections
+	notificationPingInterval = 30 * time.Second
+	// notificationWriteTimeout bounds each WebSocket write, so a stalled client can't hold the stream
+	notificationWriteTimeout = 10 * time.Second
+)
+
+// notificationUpgrader upgrades the live channel to a WebSocket
+// Any origin is accepted: the handshake is authenticated by a bearer token, never by cookies,
+// so another site can't open a channel on a user's behalf
+var notificationUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(*http.Request) bool { return true },
+}
+
+// NotificationHandler handles in-app notification requests
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+	dashboardService    *services.DashboardService
+}
+
+// NewNotificationHandler creates a new NotificationHandler instance
+func NewNotificationHandler(notificationService *services.NotificationService, dashboardService *services.DashboardService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		dashboardService:    dashboardService,
+	}
+}
+
+// liveMessage is a single message sent on the WebSocket channel
+type liveMessage struct {
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+// ListNotifications handles listing the requesting user's notifications
+// @Summary List Notifications
+// @Description List the requesting user's in-app notifications (new orders, new reservations, low stock, failed payments), newest first, with the unread count
+// @Tags notifications
+// @Produce json
+// @Param unread query bool false "Only unread notifications"
+// @Param limit query int false "Page size (default 50, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {object} services.NotificationList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid limit parameter"))
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid offset parameter"))
+		return
+	}
+
+	list, err := h.notificationService.List(c.Request.Context(), userID, c.Query("unread") == "true", limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// MarkRead handles marking notifications read
+// @Summary Mark Notifications Read
+// @Description Mark the given notifications of the requesting user read, or all of them when no IDs are given
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body services.MarkNotificationsReadRequest false "Notification IDs"
+// @Success 200 {object} map[string]int64
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/notifications/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.MarkNotificationsReadRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			_ = c.Error(apperrors.Validation(err))
+			return
+		}
+	}
+
+	updated, err := h.notificationService.MarkRead(c.Request.Context(), userID, req.IDs)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+// DeleteNotification handles deleting one notification
+// @Summary Delete Notification
+// @Description Delete one of the requesting user's notifications
+// @Tags notifications
+// @Param id path int true "Notification ID"
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/notifications/{id} [delete]
+func (h *NotificationHandler) DeleteNotification(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid notification ID"))
+		return
+	}
+
+	if err := h.notificationService.Delete(c.Request.Context(), userID, uint(id)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ClearNotifications handles clearing the requesting user's notifications
+// @Summary Clear Notifications
+// @Description Delete all of the requesting user's notifications, or only the read ones
+// @Tags notifications
+// @Produce json
+// @Param read_only query bool false "Only clear read notifications"
+// @Success 200 {object} map[string]int64
+// @Router /api/v1/notifications [delete]
+func (h *NotificationHandler) ClearNotifications(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	deleted, err := h.notificationService.Clear(c.Request.Context(), userID, c.Query("read_only") == "true")
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": deleted})
+}
+
+// StreamLive handles the live WebSocket channel
+// @Summary Live Channel
+// @Description WebSocket carrying the requesting user's new notifications as "notification" messages together with the restaurant's order event stream ("new-order", "order-status", "new-reservation", "course-fired", "menu-changed"). Messages are JSON objects with "event" and "data". Browsers may pass the token as the access_token parameter
+// @Tags notifications
+// @Param access_token query string false "Bearer token, when the Authorization header can't be set"
+// @Success 101
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/notifications/ws [get]
+func (h *NotificationHandler) StreamLive(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	// Only changes after the channel opens are sent; clients load the current state from the REST endpoints
+	orderCursor, err := h.dashboardService.CurrentCursor(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	notificationCursor, err := h.notificationService.CurrentCursor(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	conn, err := notificationUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader already replied with an error status
+		return
+	}
+	defer conn.Close()
+
+	closed := metrics.TrackRealtimeConnection(metrics.ChannelNotifications, h.dashboardService.StreamTier(c.Request.Context(), restaurantID))
+	defer closed()
+
+	// Clients only send control frames; reading handles them and notices when the client goes away
+	streamCtx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	send := func(event string, data interface{}) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(notificationWriteTimeout))
+		return conn.WriteJSON(liveMessage{Event: event, Data: data}) == nil
+	}
+	if !send("connected", gin.H{"restaurant_id": restaurantID, "user_id": userID}) {
+		return
+	}
+
+	poll := time.NewTicker(dashboardPollInterval)
+	defer poll.Stop()
+	ping := time.NewTicker(notificationPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-streamCtx.Done():
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(notificationWriteTimeout)); err != nil {
+				return
+			}
+			continue
+		case <-poll.C:
+		}
+
+		// Failed polls are retried on the next tick; a closed connection ends the loop above
+		var events []services.DashboardEvent
+		if orderEvents, err := h.dashboardService.GetEventsSince(streamCtx, restaurantID, orderCursor); err == nil {
+			events = append(events, orderEvents...)
+		}
+		if notificationEvents, err := h.notificationService.GetEventsSince(streamCtx, userID, &notificationCursor); err == nil {
+			events = append(events, notificationEvents...)
+		}
+		for _, event := range events {
+			if !send(event.Name, event.Data) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationPreferenceHandler handles customer notification preference and unsubscribe requests
+type NotificationPreferenceHandler struct {
+	preferenceService *services.NotificationPreferenceService
+}
+
+// NewNotificationPreferenceHandler creates a new NotificationPreferenceHandler instance
+func NewNotificationPreferenceHandler(preferenceService *services.NotificationPreferenceService) *NotificationPreferenceHandler {
+	return &NotificationPreferenceHandler{
+		preferenceService: preferenceService,
+	}
+}
+
+// GetPreferences handles returning the requesting customer's notification preferences
+// @Summary Get Notification Preferences
+// @Description Get the email, SMS and push channels the requesting customer receives per event type, and their marketing consent
+// @Tags notification-preferences
+// @Produce json
+// @Success 200 {object} models.NotificationPreference
+// @Router /api/v1/notification-preferences [get]
+func (h *NotificationPreferenceHandler) GetPreferences(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	preference, err := h.preferenceService.Get(c.Request.Context(), restaurantID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
+
+// UpdatePreferences handles updating the requesting customer's notification preferences
+// @Summary Update Notification Preferences
+// @Description Turn channels on or off per event type, and give or withdraw marketing consent. Omitted fields are kept
+// @Tags notification-preferences
+// @Accept json
+// @Produce json
+// @Param request body services.UpdateNotificationPreferencesRequest true "Preference changes"
+// @Success 200 {object} models.NotificationPreference
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/notification-preferences [put]
+func (h *NotificationPreferenceHandler) UpdatePreferences(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	preference, err := h.preferenceService.Update(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preference)
+}
+
+// Unsubscribe handles an unsubscribe link from a marketing email
+// @Summary Unsubscribe from Marketing Email
+// @Description Turn marketing email off for the customer the link's token was issued to, without signing in
+// @Tags notification-preferences
+// @Accept json
+// @Produce json
+// @Param request body services.UnsubscribeRequest true "Unsubscribe token"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/public/unsubscribe [post]
+func (h *NotificationPreferenceHandler) Unsubscribe(c *gin.Context) {
+	var req services.UnsubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	if err := h.preferenceService.Unsubscribe(c.Request.Context(), req.Token); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Unsubscribed from marketing email"})
+}
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
 	"restaurant-backend/internal/services"
 
@@ -13,18 +17,21 @@ import (
 
 // OrderHandler handles order-related requests
 type OrderHandler struct {
-	orderService *services.OrderService
-	orderRepo    *repositories.OrderRepository
+	orderService    *services.OrderService
+	orderRepo       *repositories.OrderRepository
+	approvalService *services.ApprovalService
 }
 
 // NewOrderHandler creates a new OrderHandler instance
 func NewOrderHandler(
 	orderService *services.OrderService,
 	orderRepo *repositories.OrderRepository,
+	approvalService *services.ApprovalService,
 ) *OrderHandler {
 	return &OrderHandler{
-		orderService: orderService,
-		orderRepo:    orderRepo,
+		orderService:    orderService,
+		orderRepo:       orderRepo,
+		approvalService: approvalService,
 	}
 }
 
@@ -36,24 +43,33 @@ func NewOrderHandler(
 // @Produce json
 // @Param request body services.CreateOrderRequest true "Order data"
 // @Success 201 {object} models.Order
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 503 {object} apperrors.Response "Online ordering is paused while the kitchen is over capacity"
 // @Router /api/v1/orders [post]
 func (h *OrderHandler) CreateOrder(c *gin.Context) {
 	var req services.CreateOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
+	// Customers can't order from restaurants that haven't launched publicly
+	if role, _ := ctx.GetUserRole(c.Request.Context()); role == "Client" {
+		if err := h.orderService.EnsurePublicOrdering(c.Request.Context(), restaurantID); err != nil {
+			_ = c.Error(err)
+			return
+		}
+	}
+
 	order, err := h.orderService.CreateOrder(c.Request.Context(), &req, restaurantID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -67,18 +83,24 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Order ID"
 // @Success 200 {object} models.Order
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/orders/{id} [get]
 func (h *OrderHandler) GetOrder(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
 		return
 	}
 
-	order, err := h.orderRepo.GetByIDWithContext(c.Request.Context(), uint(id))
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	order, err := h.orderRepo.GetByIDForRestaurant(c.Request.Context(), uint(id), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "order not found"})
+		_ = c.Error(apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found"))
 		return
 	}
 
@@ -96,7 +118,7 @@ func (h *OrderHandler) GetOrder(c *gin.Context) {
 func (h *OrderHandler) ListOrders(c *gin.Context) {
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -107,7 +129,7 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 		if err == nil {
 			orders, err := h.orderRepo.GetByUserIDWithContext(c.Request.Context(), restaurantID, uint(userID))
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				_ = c.Error(err)
 				return
 			}
 			c.JSON(http.StatusOK, orders)
@@ -118,7 +140,7 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 	// Otherwise, get all orders for the restaurant
 	orders, err := h.orderRepo.GetByRestaurantIDWithContext(c.Request.Context(), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -127,34 +149,218 @@ func (h *OrderHandler) ListOrders(c *gin.Context) {
 
 // UpdateOrderStatus handles updating order status
 // @Summary Update Order Status
-// @Description Update the status of an order
+// @Description Move an order to its next status (pending → confirmed → preparing → ready → completed, or cancelled before it is ready). When the restaurant requires approval of Staff cancellations, a cancellation is queued for an Admin and 202 is returned with the pending change
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Param id path int true "Order ID"
 // @Param request body services.UpdateOrderStatusRequest true "Status update data"
 // @Success 200 {object} models.Order
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Success 202 {object} models.PendingChange
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/orders/{id}/status [put]
 func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
 		return
 	}
 
 	var req services.UpdateOrderStatusRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
-	order, err := h.orderService.UpdateOrderStatusWithCtx(c.Request.Context(), uint(id), &req)
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	if req.Status == models.OrderStatusCancelled {
+		if submitForApproval(c, h.approvalService, restaurantID, models.PendingChangeTypeOrderCancellation, uint(id), nil) {
+			return
+		}
+	}
+
+	order, err := h.orderService.UpdateOrderStatusWithCtx(c.Request.Context(), uint(id), restaurantID, userID, &req)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, order)
 }
+
+// GetOrderStatusHistory handles listing the status changes of an order
+// @Summary Get Order Status History
+// @Description List the status transitions of an order and the users who made them, oldest first
+// @Tags orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {array} models.OrderStatusChange
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/status-history [get]
+func (h *OrderHandler) GetOrderStatusHistory(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	history, err := h.orderService.GetOrderStatusHistory(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// GetOrderNutrition handles getting the nutrition summary of an order
+// @Summary Get Order Nutrition Summary
+// @Description Get aggregated calories and macros for all items in an order
+// @Tags orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} services.NutritionSummary
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/nutrition [get]
+func (h *OrderHandler) GetOrderNutrition(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	summary, err := h.orderService.GetOrderNutritionSummary(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// ListKitchenTickets handles listing kitchen tickets for the kitchen display (KDS)
+// @Summary List Kitchen Tickets
+// @Description List confirmed and preparing orders as kitchen tickets, including per-item notes
+// @Tags orders
+// @Produce json
+// @Success 200 {array} services.KitchenTicket
+// @Router /api/v1/orders/kitchen [get]
+func (h *OrderHandler) ListKitchenTickets(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	tickets, err := h.orderService.GetKitchenTickets(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tickets)
+}
+
+// GetKitchenTicket handles getting the printable kitchen ticket of an order
+// @Summary Get Kitchen Ticket
+// @Description Get the kitchen ticket for an order. Returns plain text for printing when format=text
+// @Tags orders
+// @Produce json
+// @Produce plain
+// @Param id path int true "Order ID"
+// @Param format query string false "Response format (json, text)"
+// @Success 200 {object} services.KitchenTicket
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/ticket [get]
+func (h *OrderHandler) GetKitchenTicket(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	ticket, err := h.orderService.GetKitchenTicket(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	if c.Query("format") == "text" {
+		c.String(http.StatusOK, ticket.Render())
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
+
+// FireCourse handles firing a held course of a dine-in order to the kitchen
+// @Summary Fire Course
+// @Description Tell the kitchen to start a held course of an order. Without a course the next held course is fired. Emits a "course-fired" dashboard event and reprints the kitchen ticket on auto print kitchen printers
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body services.FireCourseRequest false "Course"
+// @Success 200 {object} services.KitchenTicket
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/fire-course [post]
+func (h *OrderHandler) FireCourse(c *gin.Context) {
+	restaurantID, id, ok := orderParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	// The body is optional
+	var req services.FireCourseRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	ticket, err := h.orderService.FireCourse(c.Request.Context(), id, restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ticket)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrderScheduleHandler handles opening hours and scheduled order requests
+type OrderScheduleHandler struct {
+	scheduleService *services.OrderScheduleService
+}
+
+// NewOrderScheduleHandler creates a new OrderScheduleHandler instance
+func NewOrderScheduleHandler(scheduleService *services.OrderScheduleService) *OrderScheduleHandler {
+	return &OrderScheduleHandler{
+		scheduleService: scheduleService,
+	}
+}
+
+// GetOpeningHours handles listing the restaurant's opening hours
+// @Summary Get Opening Hours
+// @Description List the restaurant's opening periods by weekday (0 = Sunday), in its time zone. Without periods the restaurant is always open
+// @Tags settings
+// @Produce json
+// @Success 200 {array} models.OpeningHours
+// @Router /api/v1/settings/opening-hours [get]
+func (h *OrderScheduleHandler) GetOpeningHours(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	hours, err := h.scheduleService.GetOpeningHours(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hours)
+}
+
+// UpdateOpeningHours handles replacing the restaurant's opening hours
+// @Summary Update Opening Hours
+// @Description Replace the restaurant's opening periods. Periods closing at or before their opening time run past midnight. Scheduled orders must fall into an opening period
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Param request body services.UpdateOpeningHoursRequest true "Opening hours"
+// @Success 200 {array} models.OpeningHours
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/settings/opening-hours [put]
+func (h *OrderScheduleHandler) UpdateOpeningHours(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.UpdateOpeningHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	hours, err := h.scheduleService.UpdateOpeningHours(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hours)
+}
+
+// ListScheduledQueue handles listing upcoming scheduled orders for the kitchen
+// @Summary List Scheduled Kitchen Queue
+// @Description List upcoming scheduled (order-ahead) orders grouped by 15-minute slot with the slot capacity. Orders move to the kitchen queue 30 minutes before their scheduled time
+// @Tags orders
+// @Produce json
+// @Success 200 {array} services.ScheduledSlot
+// @Router /api/v1/orders/scheduled [get]
+func (h *OrderScheduleHandler) ListScheduledQueue(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	slots, err := h.scheduleService.GetScheduledQueue(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
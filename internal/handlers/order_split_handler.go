@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrderSplitHandler handles seat assignment and split bill requests
+type OrderSplitHandler struct {
+	splitService *services.OrderSplitService
+}
+
+// NewOrderSplitHandler creates a new OrderSplitHandler instance
+func NewOrderSplitHandler(splitService *services.OrderSplitService) *OrderSplitHandler {
+	return &OrderSplitHandler{
+		splitService: splitService,
+	}
+}
+
+// AssignSeats handles assigning order items to seats
+// @Summary Assign Seats
+// @Description Assign order items to seats (guests). Items without a seat are shared by the table when splitting by seat
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body services.AssignSeatsRequest true "Seat assignments"
+// @Success 200 {object} models.Order
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/seats [put]
+func (h *OrderSplitHandler) AssignSeats(c *gin.Context) {
+	restaurantID, orderID, ok := orderParams(c)
+	if !ok {
+		return
+	}
+
+	var req services.AssignSeatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	order, err := h.splitService.AssignSeats(c.Request.Context(), orderID, restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// SplitOrder handles splitting an order into payment groups
+// @Summary Split Order
+// @Description Split an order into payment groups that sum exactly to its total: evenly into parts, by seat, or by item groups. Replaces previous splits unless some are paid
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body services.SplitOrderRequest true "Split"
+// @Success 201 {array} models.OrderSplit
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/split [post]
+func (h *OrderSplitHandler) SplitOrder(c *gin.Context) {
+	restaurantID, orderID, ok := orderParams(c)
+	if !ok {
+		return
+	}
+
+	var req services.SplitOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	splits, err := h.splitService.SplitOrder(c.Request.Context(), orderID, restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, splits)
+}
+
+// ListSplits handles listing the payment groups of an order
+// @Summary List Order Splits
+// @Description List the payment groups of a split order with their item shares
+// @Tags orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {array} models.OrderSplit
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/splits [get]
+func (h *OrderSplitHandler) ListSplits(c *gin.Context) {
+	restaurantID, orderID, ok := orderParams(c)
+	if !ok {
+		return
+	}
+
+	splits, err := h.splitService.ListSplits(c.Request.Context(), orderID, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, splits)
+}
+
+// PaySplit handles marking a payment group as paid
+// @Summary Pay Order Split
+// @Description Mark a payment group of an order as paid. An order with paid splits can't be split again
+// @Tags orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param splitId path int true "Split ID"
+// @Success 200 {array} models.OrderSplit
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/splits/{splitId}/pay [post]
+func (h *OrderSplitHandler) PaySplit(c *gin.Context) {
+	restaurantID, orderID, ok := orderParams(c)
+	if !ok {
+		return
+	}
+
+	splitID, err := strconv.ParseUint(c.Param("splitId"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid split ID"))
+		return
+	}
+
+	splits, err := h.splitService.PaySplit(c.Request.Context(), uint(splitID), orderID, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, splits)
+}
+
+// orderParams reads the tenant and the order ID path parameter, reporting failures on the context
+func orderParams(c *gin.Context) (restaurantID uint, orderID uint, ok bool) {
+	restaurantID, ok = ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
+		return 0, 0, false
+	}
+
+	return restaurantID, uint(id), true
+}
@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles multi-location organization requests
+type OrganizationHandler struct {
+	organizationService *services.OrganizationService
+	authService         *services.AuthService
+	userRepo            *repositories.UserRepository
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler instance
+func NewOrganizationHandler(
+	organizationService *services.OrganizationService,
+	authService *services.AuthService,
+	userRepo *repositories.UserRepository,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationService: organizationService,
+		authService:         authService,
+		userRepo:            userRepo,
+	}
+}
+
+// CreateOrganization handles organization creation
+// @Summary Create Organization
+// @Description Create an organization with the current restaurant as its first location. Returns a new token with organization scope.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param request body services.CreateOrganizationRequest true "Organization data"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/organization [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req services.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	organization, err := h.organizationService.CreateOrganization(c.Request.Context(), &req, restaurantID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Re-issue the token so it carries the new organization scope
+	user, err := h.userRepo.GetByIDWithContext(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(apperrors.Wrap(err, http.StatusInternalServerError, apperrors.CodeInternal, "failed to load user"))
+		return
+	}
+	token, err := h.authService.GenerateLocationToken(c.Request.Context(), user, restaurantID)
+	if err != nil {
+		_ = c.Error(apperrors.Wrap(err, http.StatusInternalServerError, apperrors.CodeInternal, "failed to generate token"))
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"organization": organization,
+		"token":        token,
+	})
+}
+
+// GetOrganization handles getting the current user's organization
+// @Summary Get Organization
+// @Description Get the organization and its locations
+// @Tags organization
+// @Produce json
+// @Success 200 {object} models.Organization
+// @Failure 403 {object} apperrors.Response
+// @Router /api/v1/organization [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	organizationID, ok := requireOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	organization, err := h.organizationService.GetOrganization(c.Request.Context(), organizationID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// AddLocation handles adding a location to the organization
+// @Summary Add Location
+// @Description Create a new restaurant location in the organization, optionally cloning the template menu
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param request body services.AddLocationRequest true "Location data"
+// @Success 201 {object} models.Restaurant
+// @Failure 400 {object} apperrors.Response
+// @Failure 402 {object} apperrors.Response
+// @Router /api/v1/organization/locations [post]
+func (h *OrganizationHandler) AddLocation(c *gin.Context) {
+	organizationID, ok := requireOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req services.AddLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	location, err := h.organizationService.AddLocation(c.Request.Context(), &req, organizationID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, location)
+}
+
+// SetTemplate handles setting the organization's template location
+// @Summary Set Template Location
+// @Description Set which location's menu new locations are cloned from
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param request body services.SetTemplateRequest true "Template location"
+// @Success 200 {object} models.Organization
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/organization/template [put]
+func (h *OrganizationHandler) SetTemplate(c *gin.Context) {
+	organizationID, ok := requireOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req services.SetTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	organization, err := h.organizationService.SetTemplate(c.Request.Context(), organizationID, req.RestaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, organization)
+}
+
+// CloneTemplateMenu handles cloning the template menu into a location
+// @Summary Clone Template Menu
+// @Description Copy the template location's categories and items into a location without a menu
+// @Tags organization
+// @Produce json
+// @Param id path int true "Location (Restaurant) ID"
+// @Success 201 {object} services.MenuCloneResult
+// @Failure 400 {object} apperrors.Response
+// @Failure 402 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/organization/locations/{id}/clone-menu [post]
+func (h *OrganizationHandler) CloneTemplateMenu(c *gin.Context) {
+	organizationID, ok := requireOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	locationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid location ID"))
+		return
+	}
+
+	result, err := h.organizationService.CloneTemplateMenu(c.Request.Context(), organizationID, uint(locationID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
+
+// SwitchLocation handles issuing a token scoped to another location
+// @Summary Switch Location
+// @Description Issue a token that scopes the org-scoped user to another location of the organization
+// @Tags organization
+// @Produce json
+// @Param id path int true "Location (Restaurant) ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/organization/locations/{id}/switch [post]
+func (h *OrganizationHandler) SwitchLocation(c *gin.Context) {
+	organizationID, ok := requireOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	locationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid location ID"))
+		return
+	}
+
+	location, err := h.organizationService.GetLocation(c.Request.Context(), organizationID, uint(locationID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	user, err := h.userRepo.GetByIDWithContext(c.Request.Context(), userID)
+	if err != nil {
+		_ = c.Error(apperrors.Wrap(err, http.StatusInternalServerError, apperrors.CodeInternal, "failed to load user"))
+		return
+	}
+
+	token, err := h.authService.GenerateLocationToken(c.Request.Context(), user, location.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"token":         token,
+		"restaurant_id": location.ID,
+	})
+}
+
+// SetUserScope handles granting or revoking org-wide access for a user
+// @Summary Set User Organization Scope
+// @Description Grant or revoke access to all organization locations for a user
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param request body services.SetUserScopeRequest true "Scope"
+// @Success 200 {object} models.User
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/organization/users/{id}/scope [put]
+func (h *OrganizationHandler) SetUserScope(c *gin.Context) {
+	organizationID, ok := requireOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
+		return
+	}
+
+	var req services.SetUserScopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	user, err := h.organizationService.SetUserScope(c.Request.Context(), organizationID, uint(userID), req.OrganizationScoped)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// GetReport handles cross-location reporting
+// @Summary Get Organization Report
+// @Description Get order and reservation totals per location and across the organization
+// @Tags organization
+// @Produce json
+// @Param period query string false "Time period (today, week, month, year)" default(month)
+// @Success 200 {object} services.OrganizationReport
+// @Router /api/v1/organization/report [get]
+func (h *OrganizationHandler) GetReport(c *gin.Context) {
+	organizationID, ok := requireOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	period := c.DefaultQuery("period", "month")
+
+	report, err := h.organizationService.GetReport(c.Request.Context(), organizationID, period)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// requireOrganizationID returns the caller's organization ID or attaches a 403 error
+func requireOrganizationID(c *gin.Context) (uint, bool) {
+	organizationID, ok := ctx.GetOrganizationID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.Forbidden(apperrors.CodeInsufficientScope, "organization access required"))
+		return 0, false
+	}
+	return organizationID, true
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PermissionHandler handles fine-grained permission requests
+type PermissionHandler struct {
+	permissionService *services.PermissionService
+}
+
+// NewPermissionHandler creates a new PermissionHandler instance
+func NewPermissionHandler(permissionService *services.PermissionService) *PermissionHandler {
+	return &PermissionHandler{
+		permissionService: permissionService,
+	}
+}
+
+// ListMyPermissions handles listing the current user's capabilities
+// @Summary List My Permissions
+// @Description List the overridable capabilities and whether the current user holds each
+// @Tags permissions
+// @Produce json
+// @Success 200 {array} services.UserPermissionView
+// @Failure 401 {object} apperrors.Response
+// @Router /api/v1/permissions [get]
+func (h *PermissionHandler) ListMyPermissions(c *gin.Context) {
+	permissions, err := h.permissionService.ListMine(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, permissions)
+}
+
+// ListUserPermissions handles listing the capabilities of a user of the restaurant
+// @Summary List User Permissions
+// @Description List the overridable capabilities of a user with the Admin's overrides and the effective value (Admin only)
+// @Tags permissions
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {array} services.UserPermissionView
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/users/{id}/permissions [get]
+func (h *PermissionHandler) ListUserPermissions(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
+		return
+	}
+
+	permissions, err := h.permissionService.ListForUser(c.Request.Context(), restaurantID, uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, permissions)
+}
+
+// SetUserPermission handles granting or revoking a capability for a staff member
+// @Summary Set User Permission
+// @Description Grant or revoke a capability for a staff member, overriding the Staff role's default; the change is audit-logged (Admin only)
+// @Tags permissions
+// @Accept json
+// @Produce json
+// @Param id path int true "User ID"
+// @Param permission path string true "Permission, e.g. menu.change_prices"
+// @Param request body services.SetPermissionRequest true "Override"
+// @Success 200 {array} services.UserPermissionView
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/users/{id}/permissions/{permission} [put]
+func (h *PermissionHandler) SetUserPermission(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
+		return
+	}
+
+	var req services.SetPermissionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	userID, _ := ctx.GetUserID(c.Request.Context())
+	role, _ := ctx.GetUserRole(c.Request.Context())
+	actor := services.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+
+	if err := h.permissionService.Set(c.Request.Context(), restaurantID, uint(id), c.Param("permission"), *req.Granted, actor); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	permissions, err := h.permissionService.ListForUser(c.Request.Context(), restaurantID, uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, permissions)
+}
+
+// ResetUserPermission handles removing a staff member's override of a capability
+// @Summary Reset User Permission
+// @Description Remove a staff member's override, so the Staff role's default applies again; the change is audit-logged (Admin only)
+// @Tags permissions
+// @Param id path int true "User ID"
+// @Param permission path string true "Permission, e.g. menu.change_prices"
+// @Success 204
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/users/{id}/permissions/{permission} [delete]
+func (h *PermissionHandler) ResetUserPermission(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
+		return
+	}
+
+	userID, _ := ctx.GetUserID(c.Request.Context())
+	role, _ := ctx.GetUserRole(c.Request.Context())
+	actor := services.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+
+	if err := h.permissionService.Reset(c.Request.Context(), restaurantID, uint(id), c.Param("permission"), actor); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
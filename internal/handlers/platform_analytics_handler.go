@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PlatformAnalyticsHandler handles platform-wide analytics requests (platform KAMs only)
+type PlatformAnalyticsHandler struct {
+	analyticsService *services.PlatformAnalyticsService
+}
+
+// NewPlatformAnalyticsHandler creates a new PlatformAnalyticsHandler instance
+func NewPlatformAnalyticsHandler(analyticsService *services.PlatformAnalyticsService) *PlatformAnalyticsHandler {
+	return &PlatformAnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetOverview handles retrieving the platform-wide overview
+// @Summary Get Platform Analytics Overview
+// @Description Aggregate all tenants over a date range (UTC): GMV per currency, orders, transacting, new and active restaurants, compared with the previous range of the same length, and orders per day
+// @Tags platform
+// @Produce json
+// @Param from query string false "First day (YYYY-MM-DD), 30 days before to by default"
+// @Param to query string false "Last day (YYYY-MM-DD), today by default"
+// @Success 200 {object} services.PlatformOverview
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/platform/analytics/overview [get]
+func (h *PlatformAnalyticsHandler) GetOverview(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	overview, err := h.analyticsService.GetOverview(c.Request.Context(), userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+// GetTrends handles retrieving the platform's monthly growth trends
+// @Summary Get Platform Growth Trends
+// @Description Aggregate all tenants per month (UTC), ending with the current month so far, with the growth of each month over the one before
+// @Tags platform
+// @Produce json
+// @Param months query int false "Number of months (default 12, max 36)"
+// @Success 200 {object} services.PlatformTrends
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/platform/analytics/trends [get]
+func (h *PlatformAnalyticsHandler) GetTrends(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	months, err := strconv.Atoi(c.DefaultQuery("months", "12"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid months parameter"))
+		return
+	}
+
+	trends, err := h.analyticsService.GetTrends(c.Request.Context(), userID, months)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trends)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/services"
 
@@ -14,16 +15,22 @@ import (
 type PlatformHandler struct {
 	platformService *services.PlatformService
 	authService     *services.AuthService
+	searchService   *services.PlatformSearchService
+	impersonation   *services.ImpersonationService
 }
 
 // NewPlatformHandler creates a new PlatformHandler instance
 func NewPlatformHandler(
 	platformService *services.PlatformService,
 	authService *services.AuthService,
+	searchService *services.PlatformSearchService,
+	impersonation *services.ImpersonationService,
 ) *PlatformHandler {
 	return &PlatformHandler{
 		platformService: platformService,
 		authService:     authService,
+		searchService:   searchService,
+		impersonation:   impersonation,
 	}
 }
 
@@ -35,45 +42,41 @@ func NewPlatformHandler(
 // @Produce json
 // @Param request body services.CreateKAMRequest true "KAM creation data"
 // @Success 201 {object} models.User
-// @Failure 400 {object} map[string]string
-// @Failure 403 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
 // @Router /api/v1/platform/kams [post]
 func (h *PlatformHandler) CreateKAM(c *gin.Context) {
 	var req services.CreateKAMRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	// Get creator user ID from request context
 	createdBy, ok := ctx.GetUserID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "user context not found"})
+		_ = c.Error(apperrors.ErrUserContextMissing)
 		return
 	}
 
 	// Create KAM user structure
 	user, err := h.platformService.CreateKAM(&req, createdBy)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "only platform KAMs or Admins can create new KAM users" {
-			statusCode = http.StatusForbidden
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to hash password"})
+		_ = c.Error(apperrors.Internal(err))
 		return
 	}
 	user.PasswordHash = string(hashedPassword)
 
 	// Create user in database
 	if err := h.platformService.CreateKAMUser(user); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create user: " + err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -89,14 +92,91 @@ func (h *PlatformHandler) CreateKAM(c *gin.Context) {
 // @Tags platform
 // @Produce json
 // @Success 200 {array} models.User
-// @Failure 403 {object} map[string]string
+// @Failure 403 {object} apperrors.Response
 // @Router /api/v1/platform/kams [get]
 func (h *PlatformHandler) ListKAMs(c *gin.Context) {
 	kams, err := h.platformService.ListKAMs()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, kams)
 }
+
+// Search handles platform-wide support search (KAM only)
+// @Summary Platform Search
+// @Description Find restaurants, users and orders across all tenants by email, phone, order number or name. Every search is audit-logged.
+// @Tags platform
+// @Produce json
+// @Param q query string true "Search term (min 3 characters)"
+// @Param limit query int false "Max results per entity type (default 20, max 50)"
+// @Success 200 {object} services.PlatformSearchResult
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Router /api/v1/platform/search [get]
+func (h *PlatformHandler) Search(c *gin.Context) {
+	var req services.PlatformSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	result, err := h.searchService.Search(c.Request.Context(), &req, services.SearchActor{
+		UserID:    userID,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// StartImpersonation handles a KAM starting an "act as restaurant" session
+// @Summary Impersonate Restaurant
+// @Description Issue a short-lived token acting as the restaurant's admin (KAM only). The token carries an impersonation claim, and starting the session and every request made with it are audit-logged
+// @Tags platform
+// @Accept json
+// @Produce json
+// @Param request body services.StartImpersonationRequest true "Restaurant and reason"
+// @Success 201 {object} services.ImpersonationSession
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/platform/impersonations [post]
+func (h *PlatformHandler) StartImpersonation(c *gin.Context) {
+	var req services.StartImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+	role, _ := ctx.GetUserRole(c.Request.Context())
+
+	session, err := h.impersonation.StartImpersonation(c.Request.Context(), &req, services.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
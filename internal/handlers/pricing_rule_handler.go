@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PricingRuleHandler handles time-based pricing rule (happy hour) requests
+type PricingRuleHandler struct {
+	pricingService *services.PricingRuleService
+}
+
+// NewPricingRuleHandler creates a new PricingRuleHandler instance
+func NewPricingRuleHandler(pricingService *services.PricingRuleService) *PricingRuleHandler {
+	return &PricingRuleHandler{
+		pricingService: pricingService,
+	}
+}
+
+// ListRules handles listing the restaurant's pricing rules
+// @Summary List Pricing Rules
+// @Description List the restaurant's time-based pricing rules (happy hours)
+// @Tags pricing-rules
+// @Produce json
+// @Success 200 {array} models.PricingRule
+// @Router /api/v1/pricing-rules [get]
+func (h *PricingRuleHandler) ListRules(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	rules, err := h.pricingService.ListRules(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+// CreateRule handles creating a pricing rule
+// @Summary Create Pricing Rule
+// @Description Create a pricing rule discounting or overriding the price of all items, a category or an item during a weekly time window. When several rules apply, the lowest price wins
+// @Tags pricing-rules
+// @Accept json
+// @Produce json
+// @Param request body services.PricingRuleRequest true "Pricing rule"
+// @Success 201 {object} models.PricingRule
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/pricing-rules [post]
+func (h *PricingRuleHandler) CreateRule(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.PricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	rule, err := h.pricingService.CreateRule(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+// UpdateRule handles replacing a pricing rule
+// @Summary Update Pricing Rule
+// @Description Replace a pricing rule's time window, scope and price adjustment, or switch it off
+// @Tags pricing-rules
+// @Accept json
+// @Produce json
+// @Param id path int true "Pricing rule ID"
+// @Param request body services.PricingRuleRequest true "Pricing rule"
+// @Success 200 {object} models.PricingRule
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/pricing-rules/{id} [put]
+func (h *PricingRuleHandler) UpdateRule(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid pricing rule ID"))
+		return
+	}
+
+	var req services.PricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	rule, err := h.pricingService.UpdateRule(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeleteRule handles deleting a pricing rule
+// @Summary Delete Pricing Rule
+// @Description Delete a pricing rule
+// @Tags pricing-rules
+// @Param id path int true "Pricing rule ID"
+// @Success 204
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/pricing-rules/{id} [delete]
+func (h *PricingRuleHandler) DeleteRule(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid pricing rule ID"))
+		return
+	}
+
+	if err := h.pricingService.DeleteRule(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
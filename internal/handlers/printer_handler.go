@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrinterHandler handles printer, print job and printer bridge requests
+type PrinterHandler struct {
+	printService *services.PrintService
+}
+
+// NewPrinterHandler creates a new PrinterHandler instance
+func NewPrinterHandler(printService *services.PrintService) *PrinterHandler {
+	return &PrinterHandler{
+		printService: printService,
+	}
+}
+
+// ListPrinters handles listing the restaurant's printers
+// @Summary List Printers
+// @Description List the restaurant's kitchen and receipt printers with the last time their bridge polled
+// @Tags printing
+// @Produce json
+// @Success 200 {array} models.Printer
+// @Router /api/v1/printers [get]
+func (h *PrinterHandler) ListPrinters(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	printers, err := h.printService.ListPrinters(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, printers)
+}
+
+// CreatePrinter handles registering a printer
+// @Summary Create Printer
+// @Description Register a printer. The response contains the bridge token, which is shown only once
+// @Tags printing
+// @Accept json
+// @Produce json
+// @Param request body services.CreatePrinterRequest true "Printer"
+// @Success 201 {object} services.PrinterCredentials
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/printers [post]
+func (h *PrinterHandler) CreatePrinter(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.CreatePrinterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	credentials, err := h.printService.CreatePrinter(c.Request.Context(), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, credentials)
+}
+
+// UpdatePrinter handles updating a printer
+// @Summary Update Printer
+// @Description Update a printer's name, kind, paper width, auto print or active flag
+// @Tags printing
+// @Accept json
+// @Produce json
+// @Param id path int true "Printer ID"
+// @Param request body services.UpdatePrinterRequest true "Printer updates"
+// @Success 200 {object} models.Printer
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/printers/{id} [put]
+func (h *PrinterHandler) UpdatePrinter(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid printer ID"))
+		return
+	}
+
+	var req services.UpdatePrinterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	printer, err := h.printService.UpdatePrinter(c.Request.Context(), uint(id), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, printer)
+}
+
+// RotatePrinterToken handles regenerating a printer's bridge token
+// @Summary Rotate Printer Token
+// @Description Generate a new bridge token for a printer, disconnecting bridges using the previous one
+// @Tags printing
+// @Produce json
+// @Param id path int true "Printer ID"
+// @Success 200 {object} services.PrinterCredentials
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/printers/{id}/token [post]
+func (h *PrinterHandler) RotatePrinterToken(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid printer ID"))
+		return
+	}
+
+	credentials, err := h.printService.RotatePrinterToken(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, credentials)
+}
+
+// DeletePrinter handles deleting a printer
+// @Summary Delete Printer
+// @Description Delete a printer together with its print jobs
+// @Tags printing
+// @Param id path int true "Printer ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/printers/{id} [delete]
+func (h *PrinterHandler) DeletePrinter(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid printer ID"))
+		return
+	}
+
+	if err := h.printService.DeletePrinter(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PrintOrder handles printing an order
+// @Summary Print Order
+// @Description Queue a kitchen ticket or receipt of an order for a printer
+// @Tags printing
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body services.PrintOrderRequest true "Printer and document"
+// @Success 201 {object} models.PrintJob
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/print [post]
+func (h *PrinterHandler) PrintOrder(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
+		return
+	}
+
+	var req services.PrintOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	job, err := h.printService.PrintOrder(c.Request.Context(), uint(id), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, job)
+}
+
+// ListPrintJobs handles listing print jobs
+// @Summary List Print Jobs
+// @Description List the restaurant's latest 100 print jobs, optionally filtered by printer, order and status
+// @Tags printing
+// @Produce json
+// @Param printer_id query int false "Printer ID"
+// @Param order_id query int false "Order ID"
+// @Param status query string false "Job status (pending, printing, printed, failed)"
+// @Success 200 {array} models.PrintJob
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/print-jobs [get]
+func (h *PrinterHandler) ListPrintJobs(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var filter repositories.PrintJobFilter
+	if raw := c.Query("printer_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid printer ID"))
+			return
+		}
+		printerID := uint(id)
+		filter.PrinterID = &printerID
+	}
+	if raw := c.Query("order_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
+			return
+		}
+		orderID := uint(id)
+		filter.OrderID = &orderID
+	}
+	if status := c.Query("status"); status != "" {
+		filter.Status = &status
+	}
+
+	jobs, err := h.printService.ListJobs(c.Request.Context(), restaurantID, filter)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// RetryPrintJob handles requeueing a failed print job
+// @Summary Retry Print Job
+// @Description Put a failed print job back into its printer's queue
+// @Tags printing
+// @Produce json
+// @Param id path int true "Print job ID"
+// @Success 200 {object} models.PrintJob
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/print-jobs/{id}/retry [post]
+func (h *PrinterHandler) RetryPrintJob(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid print job ID"))
+		return
+	}
+
+	job, err := h.printService.RetryJob(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// PollPrintJobs handles a printer bridge fetching its queued jobs
+// @Summary Poll Print Jobs
+// @Description Claim the printer's queued jobs (up to 10, oldest first). With wait, the request is held open until jobs arrive or the wait elapses (max 30 seconds). Claimed jobs must be reported, otherwise they are handed out again after 2 minutes
+// @Tags printing
+// @Produce json
+// @Param token path string true "Printer token"
+// @Param wait query int false "Seconds to wait for jobs (long polling)"
+// @Success 200 {array} services.BridgePrintJob
+// @Failure 401 {object} apperrors.Response
+// @Router /api/v1/public/printers/{token}/jobs [get]
+func (h *PrinterHandler) PollPrintJobs(c *gin.Context) {
+	var wait time.Duration
+	if raw := c.Query("wait"); raw != "" {
+		seconds, err := strconv.Atoi(raw)
+		if err != nil || seconds < 0 {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid wait"))
+			return
+		}
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	jobs, err := h.printService.PollJobs(c.Request.Context(), c.Param("token"), wait)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// ReportPrintJob handles a printer bridge reporting the outcome of a claimed job
+// @Summary Report Print Job
+// @Description Report whether a claimed job was printed or failed
+// @Tags printing
+// @Accept json
+// @Param token path string true "Printer token"
+// @Param id path int true "Print job ID"
+// @Param request body services.ReportPrintJobRequest true "Outcome"
+// @Success 204 "No Content"
+// @Failure 401 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/printers/{token}/jobs/{id} [post]
+func (h *PrinterHandler) ReportPrintJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid print job ID"))
+		return
+	}
+
+	var req services.ReportPrintJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	if err := h.printService.ReportJob(c.Request.Context(), c.Param("token"), uint(id), &req); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
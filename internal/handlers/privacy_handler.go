@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyHandler handles customers' data export and erasure (GDPR) requests
+type PrivacyHandler struct {
+	privacyService *services.PrivacyService
+}
+
+// NewPrivacyHandler creates a new PrivacyHandler instance
+func NewPrivacyHandler(privacyService *services.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyService: privacyService,
+	}
+}
+
+// ExportMyData handles exporting the requesting user's personal data
+// @Summary Export My Data
+// @Description Download a zip archive of the requesting user's personal data at the restaurant: profile, CRM record, orders, reservations, reviews, notification preferences and push subscriptions, one JSON file each
+// @Tags privacy
+// @Produce application/zip
+// @Success 200 {file} file
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/privacy/export [get]
+func (h *PrivacyHandler) ExportMyData(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+	role, _ := ctx.GetUserRole(c.Request.Context())
+
+	filename := fmt.Sprintf("my-data-%s.zip", time.Now().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Content-Type", "application/zip")
+
+	if err := h.privacyService.Export(c.Request.Context(), restaurantID, userID, services.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}, c.Writer); err != nil {
+		c.Writer.Header().Del("Content-Disposition")
+		_ = c.Error(err)
+	}
+}
+
+// DeleteMyData handles erasing the requesting user's personal data
+// @Summary Delete My Data
+// @Description Anonymize the requesting customer's personal data and deactivate their account. Orders, reservations and ratings are kept without identifying details so financial totals don't change. Requires the account password
+// @Tags privacy
+// @Accept json
+// @Produce json
+// @Param request body services.DeleteMyDataRequest true "Password confirmation"
+// @Success 200 {object} repositories.AnonymizeResult
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/privacy/data [delete]
+func (h *PrivacyHandler) DeleteMyData(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+	role, _ := ctx.GetUserRole(c.Request.Context())
+
+	var req services.DeleteMyDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	result, err := h.privacyService.DeleteMyData(c.Request.Context(), restaurantID, userID, &req, services.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,448 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultPrivateEventWindow is how far ahead the event list looks without an explicit range
+const defaultPrivateEventWindow = 90 * 24 * time.Hour
+
+// PrivateEventHandler handles private event booking and event package requests
+type PrivateEventHandler struct {
+	privateEventService *services.PrivateEventService
+}
+
+// NewPrivateEventHandler creates a new PrivateEventHandler instance
+func NewPrivateEventHandler(privateEventService *services.PrivateEventService) *PrivateEventHandler {
+	return &PrivateEventHandler{
+		privateEventService: privateEventService,
+	}
+}
+
+// ListPackages handles listing event packages
+// @Summary List Event Packages
+// @Description List the set-menu packages offered for private events
+// @Tags private-events
+// @Produce json
+// @Success 200 {array} models.EventPackage
+// @Router /api/v1/event-packages [get]
+func (h *PrivateEventHandler) ListPackages(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	packages, err := h.privateEventService.ListPackages(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, packages)
+}
+
+// CreatePackage handles creating an event package
+// @Summary Create Event Package
+// @Description Create a set-menu package for private events, priced per guest
+// @Tags private-events
+// @Accept json
+// @Produce json
+// @Param request body services.EventPackageRequest true "Event package"
+// @Success 201 {object} models.EventPackage
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/event-packages [post]
+func (h *PrivateEventHandler) CreatePackage(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.EventPackageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	pkg, err := h.privateEventService.CreatePackage(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, pkg)
+}
+
+// UpdatePackage handles updating an event package
+// @Summary Update Event Package
+// @Description Update or deactivate an event package
+// @Tags private-events
+// @Accept json
+// @Produce json
+// @Param id path int true "Event package ID"
+// @Param request body services.EventPackageRequest true "Event package"
+// @Success 200 {object} models.EventPackage
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/event-packages/{id} [put]
+func (h *PrivateEventHandler) UpdatePackage(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid event package ID"))
+		return
+	}
+
+	var req services.EventPackageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	pkg, err := h.privateEventService.UpdatePackage(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pkg)
+}
+
+// DeletePackage handles deleting an event package
+// @Summary Delete Event Package
+// @Description Delete an event package no event has chosen
+// @Tags private-events
+// @Param id path int true "Event package ID"
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/event-packages/{id} [delete]
+func (h *PrivateEventHandler) DeletePackage(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid event package ID"))
+		return
+	}
+
+	if err := h.privateEventService.DeletePackage(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListEvents handles listing private events
+// @Summary List Private Events
+// @Description List private events overlapping a time range, by start time
+// @Tags private-events
+// @Produce json
+// @Param from query string false "Range start (RFC3339), defaults to now"
+// @Param to query string false "Range end (RFC3339), defaults to 90 days after from"
+// @Param status query string false "Status (inquiry, quoted, confirmed, completed, cancelled)"
+// @Success 200 {array} models.PrivateEvent
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/private-events [get]
+func (h *PrivateEventHandler) ListEvents(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	from := time.Now()
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid from, expected RFC3339"))
+			return
+		}
+		from = parsed
+	}
+	to := from.Add(defaultPrivateEventWindow)
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid to, expected RFC3339"))
+			return
+		}
+		to = parsed
+	}
+
+	events, err := h.privateEventService.ListEvents(c.Request.Context(), restaurantID, from, to, c.Query("status"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// CreateEvent handles recording a private event inquiry
+// @Summary Create Private Event
+// @Description Record a private event inquiry for several tables or the full venue, with a package and/or custom menu. It holds no tables until quoted
+// @Tags private-events
+// @Accept json
+// @Produce json
+// @Param request body services.PrivateEventRequest true "Private event"
+// @Success 201 {object} models.PrivateEvent
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/private-events [post]
+func (h *PrivateEventHandler) CreateEvent(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.PrivateEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	event, err := h.privateEventService.CreateEvent(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, event)
+}
+
+// GetEvent handles retrieving a private event
+// @Summary Get Private Event
+// @Description Get a private event with its package, tables and deposit schedule
+// @Tags private-events
+// @Produce json
+// @Param id path int true "Private event ID"
+// @Success 200 {object} models.PrivateEvent
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/private-events/{id} [get]
+func (h *PrivateEventHandler) GetEvent(c *gin.Context) {
+	restaurantID, id, ok := h.eventParams(c)
+	if !ok {
+		return
+	}
+
+	event, err := h.privateEventService.GetEvent(c.Request.Context(), id, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// UpdateEvent handles updating a private event
+// @Summary Update Private Event
+// @Description Change an open private event and reprice it. Quoted and confirmed events must still find their tables free; their unpaid deposits are rebalanced to the new total
+// @Tags private-events
+// @Accept json
+// @Produce json
+// @Param id path int true "Private event ID"
+// @Param request body services.PrivateEventRequest true "Private event"
+// @Success 200 {object} models.PrivateEvent
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/private-events/{id} [put]
+func (h *PrivateEventHandler) UpdateEvent(c *gin.Context) {
+	restaurantID, id, ok := h.eventParams(c)
+	if !ok {
+		return
+	}
+
+	var req services.PrivateEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	event, err := h.privateEventService.UpdateEvent(c.Request.Context(), id, restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// GenerateQuote handles quoting a private event
+// @Summary Generate Private Event Quote
+// @Description Price a private event, set its deposit schedule and mark it quoted, which blocks its tables against reservations and other events
+// @Tags private-events
+// @Accept json
+// @Produce json
+// @Param id path int true "Private event ID"
+// @Param request body services.GenerateQuoteRequest false "Quote validity and deposit schedule"
+// @Success 200 {object} models.PrivateEvent
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/private-events/{id}/quote [post]
+func (h *PrivateEventHandler) GenerateQuote(c *gin.Context) {
+	restaurantID, id, ok := h.eventParams(c)
+	if !ok {
+		return
+	}
+
+	var req services.GenerateQuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	event, err := h.privateEventService.GenerateQuote(c.Request.Context(), id, restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// GetQuotePDF handles downloading a private event quote
+// @Summary Download Private Event Quote
+// @Description Render the quote of a private event as a PDF with its menu, totals and payment schedule
+// @Tags private-events
+// @Produce application/pdf
+// @Param id path int true "Private event ID"
+// @Success 200 {file} file
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/private-events/{id}/quote.pdf [get]
+func (h *PrivateEventHandler) GetQuotePDF(c *gin.Context) {
+	restaurantID, id, ok := h.eventParams(c)
+	if !ok {
+		return
+	}
+
+	quote, err := h.privateEventService.RenderQuotePDF(c.Request.Context(), id, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="event-quote-%d.pdf"`, id))
+	c.Data(http.StatusOK, "application/pdf", quote)
+}
+
+// ChangeStatus handles confirming, completing or cancelling a private event
+// @Summary Change Private Event Status
+// @Description Confirm a quoted event, complete a confirmed one or cancel an event, which releases its tables
+// @Tags private-events
+// @Accept json
+// @Produce json
+// @Param id path int true "Private event ID"
+// @Param request body services.PrivateEventStatusRequest true "New status"
+// @Success 200 {object} models.PrivateEvent
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/private-events/{id}/status [put]
+func (h *PrivateEventHandler) ChangeStatus(c *gin.Context) {
+	restaurantID, id, ok := h.eventParams(c)
+	if !ok {
+		return
+	}
+
+	var req services.PrivateEventStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	event, err := h.privateEventService.ChangeStatus(c.Request.Context(), id, restaurantID, req.Status)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// RecordDepositPayment handles recording the payment of a deposit
+// @Summary Record Deposit Payment
+// @Description Mark a deposit of a private event as paid. The first payment confirms a quoted event
+// @Tags private-events
+// @Accept json
+// @Produce json
+// @Param id path int true "Private event ID"
+// @Param deposit_id path int true "Deposit ID"
+// @Param request body services.RecordDepositPaymentRequest false "Payment reference"
+// @Success 200 {object} models.PrivateEvent
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/private-events/{id}/deposits/{deposit_id}/pay [post]
+func (h *PrivateEventHandler) RecordDepositPayment(c *gin.Context) {
+	restaurantID, id, ok := h.eventParams(c)
+	if !ok {
+		return
+	}
+
+	depositID, err := strconv.ParseUint(c.Param("deposit_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid deposit ID"))
+		return
+	}
+
+	var req services.RecordDepositPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	event, err := h.privateEventService.RecordDepositPayment(c.Request.Context(), id, uint(depositID), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, event)
+}
+
+// eventParams reads the restaurant context and the private event ID path parameter
+func (h *PrivateEventHandler) eventParams(c *gin.Context) (uint, uint, bool) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid private event ID"))
+		return 0, 0, false
+	}
+	return restaurantID, uint(id), true
+}
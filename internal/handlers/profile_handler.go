@@ -1,9 +1,9 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/dto"
 	"restaurant-backend/internal/services"
@@ -14,14 +14,14 @@ import (
 // ProfileHandler handles profile management requests
 type ProfileHandler struct {
 	profileService *services.ProfileService
-	s3Service      *services.S3Service
+	storage        services.Storage
 }
 
 // NewProfileHandler creates a new ProfileHandler instance
-func NewProfileHandler(profileService *services.ProfileService, s3Service *services.S3Service) *ProfileHandler {
+func NewProfileHandler(profileService *services.ProfileService, storage services.Storage) *ProfileHandler {
 	return &ProfileHandler{
 		profileService: profileService,
-		s3Service:      s3Service,
+		storage:        storage,
 	}
 }
 
@@ -31,24 +31,20 @@ func NewProfileHandler(profileService *services.ProfileService, s3Service *servi
 // @Tags profile
 // @Produce json
 // @Success 200 {object} models.User
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 401 {object} apperrors.Response
+// @Failure 500 {object} apperrors.Response
 // @Router /api/v1/profile [get]
 func (h *ProfileHandler) GetProfile(c *gin.Context) {
 	// Get user ID from context
 	userID, ok := ctx.GetUserID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		_ = c.Error(apperrors.ErrUserContextMissing)
 		return
 	}
 
 	user, err := h.profileService.GetProfile(c.Request.Context(), userID)
 	if err != nil {
-		if errors.Is(err, services.ErrProfileNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -63,30 +59,26 @@ func (h *ProfileHandler) GetProfile(c *gin.Context) {
 // @Produce json
 // @Param request body dto.UpdateProfileDTO true "Profile update data"
 // @Success 200 {object} models.User
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 401 {object} apperrors.Response
 // @Router /api/v1/profile [put]
 func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 	// Get user ID from context
 	userID, ok := ctx.GetUserID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		_ = c.Error(apperrors.ErrUserContextMissing)
 		return
 	}
 
 	var req dto.UpdateProfileDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	user, err := h.profileService.UpdateProfile(c.Request.Context(), userID, &req)
 	if err != nil {
-		if errors.Is(err, services.ErrProfileNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -101,29 +93,25 @@ func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
 // @Produce json
 // @Param request body dto.ChangePasswordDTO true "Password change data"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 401 {object} apperrors.Response
 // @Router /api/v1/profile/password [put]
 func (h *ProfileHandler) ChangePassword(c *gin.Context) {
 	// Get user ID from context
 	userID, ok := ctx.GetUserID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		_ = c.Error(apperrors.ErrUserContextMissing)
 		return
 	}
 
 	var req dto.ChangePasswordDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	if err := h.profileService.ChangePassword(c.Request.Context(), userID, &req); err != nil {
-		if errors.Is(err, services.ErrInvalidPassword) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -138,25 +126,25 @@ func (h *ProfileHandler) ChangePassword(c *gin.Context) {
 // @Produce json
 // @Param request body dto.UpdatePreferencesDTO true "Preferences update data"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 401 {object} apperrors.Response
 // @Router /api/v1/profile/preferences [put]
 func (h *ProfileHandler) UpdatePreferences(c *gin.Context) {
 	// Get user ID from context
 	userID, ok := ctx.GetUserID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		_ = c.Error(apperrors.ErrUserContextMissing)
 		return
 	}
 
 	var req dto.UpdatePreferencesDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	if err := h.profileService.UpdatePreferences(c.Request.Context(), userID, &req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -171,51 +159,51 @@ func (h *ProfileHandler) UpdatePreferences(c *gin.Context) {
 // @Produce json
 // @Param avatar formData file true "Avatar image file"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 401 {object} apperrors.Response
 // @Router /api/v1/profile/avatar [post]
 func (h *ProfileHandler) UploadAvatar(c *gin.Context) {
 	// Get user ID from context
 	userID, ok := ctx.GetUserID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "user_id not found in context"})
+		_ = c.Error(apperrors.ErrUserContextMissing)
 		return
 	}
 
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	// Get file from form
 	file, err := c.FormFile("avatar")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "avatar file is required"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeValidationFailed, "avatar file is required"))
 		return
 	}
 
 	// Open file
 	fileContent, err := file.Open()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to read file"})
+		_ = c.Error(apperrors.Internal(err))
 		return
 	}
 	defer fileContent.Close()
 
-	// Upload to S3 using existing S3Service
+	// Upload using configured storage backend
 	fileName := file.Filename
 	fileType := file.Header.Get("Content-Type")
-	avatarKey, err := h.s3Service.UploadFile(c.Request.Context(), restaurantID, fileName, fileType, fileContent)
+	avatarKey, err := h.storage.UploadFile(c.Request.Context(), restaurantID, fileName, fileType, fileContent)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to upload avatar"})
+		_ = c.Error(apperrors.Wrap(err, http.StatusInternalServerError, apperrors.CodeInternal, "failed to upload avatar"))
 		return
 	}
 
-	// Update user's avatar URL (storing the S3 key)
+	// Update user's avatar URL (storing the storage key)
 	if err := h.profileService.UpdateAvatar(c.Request.Context(), userID, avatarKey); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
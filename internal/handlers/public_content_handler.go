@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// publicContentMaxAge is how long search engines and CDNs may cache sitemaps and structured data
+const publicContentMaxAge = "public, max-age=3600"
+
+// PublicContentHandler serves the machine-readable content of restaurants to search engines (no authentication required)
+type PublicContentHandler struct {
+	contentService *services.PublicContentService
+}
+
+// NewPublicContentHandler creates a new PublicContentHandler instance
+func NewPublicContentHandler(contentService *services.PublicContentService) *PublicContentHandler {
+	return &PublicContentHandler{
+		contentService: contentService,
+	}
+}
+
+// GetStructuredData handles getting a restaurant's schema.org JSON-LD
+// @Summary Get Restaurant Structured Data (Public)
+// @Description Get the schema.org Restaurant of a restaurant as JSON-LD, with its menu sections and available items at their regular prices, for storefront pages to embed (no authentication required)
+// @Tags public-content
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Success 200 {object} services.SchemaRestaurant
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/structured-data [get]
+func (h *PublicContentHandler) GetStructuredData(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	data, err := h.contentService.StructuredData(c.Request.Context(), uint(restaurantID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", publicContentMaxAge)
+	c.Data(http.StatusOK, "application/ld+json; charset=utf-8", body)
+}
+
+// GetSitemap handles getting a restaurant's sitemap
+// @Summary Get Restaurant Sitemap (Public)
+// @Description Get the sitemap of a restaurant's storefront pages: profile, menu and available items (no authentication required)
+// @Tags public-content
+// @Produce xml
+// @Param restaurant_id path int true "Restaurant ID"
+// @Success 200 {string} string "Sitemap XML"
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/sitemap.xml [get]
+func (h *PublicContentHandler) GetSitemap(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	sitemap, err := h.contentService.Sitemap(c.Request.Context(), uint(restaurantID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", publicContentMaxAge)
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", sitemap)
+}
+
+// GetSitemapIndex handles getting the sitemap index of all restaurants
+// @Summary Get Sitemap Index (Public)
+// @Description Get the sitemap index linking the sitemap of every publicly visible restaurant, to submit to search engines (no authentication required)
+// @Tags public-content
+// @Produce xml
+// @Success 200 {string} string "Sitemap index XML"
+// @Router /api/v1/public/sitemap.xml [get]
+func (h *PublicContentHandler) GetSitemapIndex(c *gin.Context) {
+	index, err := h.contentService.SitemapIndex(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Cache-Control", publicContentMaxAge)
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", index)
+}
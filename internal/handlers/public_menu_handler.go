@@ -4,59 +4,117 @@ import (
 	"net/http"
 	"strconv"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 // PublicMenuHandler handles public menu-related requests (no authentication required)
 type PublicMenuHandler struct {
-	categoryRepo *repositories.CategoryRepository
-	menuItemRepo *repositories.MenuItemRepository
+	categoryRepo    *repositories.CategoryRepository
+	menuItemRepo    *repositories.MenuItemRepository
+	restaurantRepo  *repositories.RestaurantRepository
+	settingsService *services.RestaurantSettingsService
+	pricingService  *services.PricingRuleService
+	searchService   *services.MenuSearchService
+	pairingService  *services.MenuPairingService
+	prepTimeService *services.PrepTimeService
 }
 
 // NewPublicMenuHandler creates a new PublicMenuHandler instance
 func NewPublicMenuHandler(
 	categoryRepo *repositories.CategoryRepository,
 	menuItemRepo *repositories.MenuItemRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	settingsService *services.RestaurantSettingsService,
+	pricingService *services.PricingRuleService,
+	searchService *services.MenuSearchService,
+	pairingService *services.MenuPairingService,
+	prepTimeService *services.PrepTimeService,
 ) *PublicMenuHandler {
 	return &PublicMenuHandler{
-		categoryRepo: categoryRepo,
-		menuItemRepo: menuItemRepo,
+		categoryRepo:    categoryRepo,
+		menuItemRepo:    menuItemRepo,
+		restaurantRepo:  restaurantRepo,
+		settingsService: settingsService,
+		pricingService:  pricingService,
+		searchService:   searchService,
+		pairingService:  pairingService,
+		prepTimeService: prepTimeService,
 	}
 }
 
+// ensureVisible responds with 404 unless the restaurant is publicly visible (active and launched)
+func (h *PublicMenuHandler) ensureVisible(c *gin.Context, restaurantID uint) bool {
+	restaurant, err := h.restaurantRepo.GetByIDWithContext(c.Request.Context(), restaurantID)
+	if err != nil || !restaurant.IsPubliclyVisible() {
+		_ = c.Error(apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found"))
+		return false
+	}
+	return true
+}
+
 // GetMenuItemPublic handles getting a menu item by ID for public access
 // @Summary Get Menu Item (Public)
-// @Description Get menu item details for ordering (no authentication required)
+// @Description Get menu item details for ordering (no authentication required). The price is the current price for the channel, with regular_price set while a pricing rule applies. pairs_well_with lists available items to suggest with it: the restaurant's links first, then items frequently ordered together
 // @Tags public-menu
 // @Produce json
 // @Param restaurant_id path int true "Restaurant ID"
 // @Param item_id path int true "Menu Item ID"
+// @Param channel query string false "Sales channel to price for (dine_in, pickup, delivery, third_party)"
 // @Success 200 {object} models.MenuItem
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/public/restaurants/{restaurant_id}/menu-items/{item_id} [get]
 func (h *PublicMenuHandler) GetMenuItemPublic(c *gin.Context) {
 	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid restaurant ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	if !h.ensureVisible(c, uint(restaurantID)) {
+		return
+	}
+
+	channel, ok := menuChannel(c)
+	if !ok {
 		return
 	}
 
 	itemID, err := strconv.ParseUint(c.Param("item_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid menu item ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
 		return
 	}
 
-	menuItem, err := h.menuItemRepo.GetByIDPublic(uint(itemID), uint(restaurantID))
+	menuItem, err := h.menuItemRepo.GetByIDPublicWithContext(c.Request.Context(), uint(itemID), uint(restaurantID))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "menu item not found"})
+		_ = c.Error(apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found"))
+		return
+	}
+
+	items := []models.MenuItem{*menuItem}
+	if err := h.pricingService.PriceMenuItems(c.Request.Context(), uint(restaurantID), channel, items); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, menuItem)
+	// Sides and drinks to upsell, priced for the same channel
+	pairs, err := h.pairingService.PairsWellWith(c.Request.Context(), uint(restaurantID), menuItem.ID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if err := h.pricingService.PriceMenuItems(c.Request.Context(), uint(restaurantID), channel, pairs); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	items[0].PairsWellWith = pairs
+
+	c.JSON(http.StatusOK, items[0])
 }
 
 // ListCategoriesPublic handles listing categories for a restaurant (public access)
@@ -70,13 +128,17 @@ func (h *PublicMenuHandler) GetMenuItemPublic(c *gin.Context) {
 func (h *PublicMenuHandler) ListCategoriesPublic(c *gin.Context) {
 	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid restaurant ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
 		return
 	}
 
-	categories, err := h.categoryRepo.GetByRestaurantID(uint(restaurantID))
+	if !h.ensureVisible(c, uint(restaurantID)) {
+		return
+	}
+
+	categories, err := h.categoryRepo.GetPublishedByRestaurantIDWithContext(c.Request.Context(), uint(restaurantID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -85,17 +147,27 @@ func (h *PublicMenuHandler) ListCategoriesPublic(c *gin.Context) {
 
 // ListMenuItemsPublic handles listing menu items for a restaurant/category (public access)
 // @Summary List Menu Items (Public)
-// @Description List menu items for a restaurant, optionally filtered by category (no authentication required)
+// @Description List menu items for a restaurant, optionally filtered by category (no authentication required). Prices are current prices for the channel, with regular_price set while a pricing rule applies
 // @Tags public-menu
 // @Produce json
 // @Param restaurant_id path int true "Restaurant ID"
 // @Param category_id query int false "Category ID filter"
+// @Param channel query string false "Sales channel to price for (dine_in, pickup, delivery, third_party)"
 // @Success 200 {array} models.MenuItem
 // @Router /api/v1/public/restaurants/{restaurant_id}/menu-items [get]
 func (h *PublicMenuHandler) ListMenuItemsPublic(c *gin.Context) {
 	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid restaurant ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	if !h.ensureVisible(c, uint(restaurantID)) {
+		return
+	}
+
+	channel, ok := menuChannel(c)
+	if !ok {
 		return
 	}
 
@@ -104,30 +176,144 @@ func (h *PublicMenuHandler) ListMenuItemsPublic(c *gin.Context) {
 	if categoryIDParam != "" {
 		categoryID, err := strconv.ParseUint(categoryIDParam, 10, 32)
 		if err == nil {
-			// Get items for specific category (need to verify category belongs to restaurant)
-			menuItems, err := h.menuItemRepo.GetByCategoryID(uint(categoryID))
+			// Get items for specific category of the restaurant
+			menuItems, err := h.menuItemRepo.GetPublishedByCategoryIDWithContext(c.Request.Context(), uint(restaurantID), uint(categoryID))
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				_ = c.Error(err)
 				return
 			}
-			// Filter by restaurant_id to ensure proper access
-			var filteredItems []models.MenuItem
-			for _, item := range menuItems {
-				if item.RestaurantID == uint(restaurantID) {
-					filteredItems = append(filteredItems, item)
-				}
+			if err := h.pricingService.PriceMenuItems(c.Request.Context(), uint(restaurantID), channel, menuItems); err != nil {
+				_ = c.Error(err)
+				return
 			}
-			c.JSON(http.StatusOK, filteredItems)
+			c.JSON(http.StatusOK, menuItems)
 			return
 		}
 	}
 
 	// Otherwise, get all menu items for the restaurant
-	menuItems, err := h.menuItemRepo.GetByRestaurantID(uint(restaurantID))
+	menuItems, err := h.menuItemRepo.GetPublishedByRestaurantIDWithContext(c.Request.Context(), uint(restaurantID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
+		return
+	}
+	if err := h.pricingService.PriceMenuItems(c.Request.Context(), uint(restaurantID), channel, menuItems); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, menuItems)
 }
+
+// SearchMenuPublic handles searching a restaurant's menu (public access)
+// @Summary Search Menu (Public)
+// @Description Search menu items by name, tags and description, best matches first (no authentication required). Every word must match a word or word prefix; without any match, items with a similar name are returned (match=similar) so typos still find results
+// @Tags public-menu
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Param q query string true "Search query"
+// @Param limit query int false "Maximum number of items" default(20)
+// @Param channel query string false "Sales channel to price for (dine_in, pickup, delivery, third_party)"
+// @Success 200 {object} services.MenuSearchResult
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/search [get]
+func (h *PublicMenuHandler) SearchMenuPublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	if !h.ensureVisible(c, uint(restaurantID)) {
+		return
+	}
+
+	channel, ok := menuChannel(c)
+	if !ok {
+		return
+	}
+
+	var req services.MenuSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	result, err := h.searchService.Search(c.Request.Context(), uint(restaurantID), channel, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSettingsPublic handles getting a restaurant's storefront settings (public access)
+// @Summary Get Storefront Settings (Public)
+// @Description Get branding (logo, colors), currency, locale, time zone and online ordering status for a restaurant's storefront (no authentication required)
+// @Tags public-menu
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Success 200 {object} models.RestaurantSettings
+// @Router /api/v1/public/restaurants/{restaurant_id}/settings [get]
+func (h *PublicMenuHandler) GetSettingsPublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	if !h.ensureVisible(c, uint(restaurantID)) {
+		return
+	}
+
+	settings, err := h.settingsService.GetSettings(c.Request.Context(), uint(restaurantID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// GetOrderingStatusPublic handles checking whether a restaurant takes online orders right now (public access)
+// @Summary Get Ordering Status (Public)
+// @Description Whether the kitchen takes online orders right now. While it is over capacity, ordering is either paused (accepting_orders false, orders are refused with ORDERING_PAUSED) or orders are promised delay_minutes later (no authentication required)
+// @Tags public-menu
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Success 200 {object} services.OrderingStatus
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/ordering-status [get]
+func (h *PublicMenuHandler) GetOrderingStatusPublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	if !h.ensureVisible(c, uint(restaurantID)) {
+		return
+	}
+
+	status, err := h.prepTimeService.OrderingStatus(c.Request.Context(), uint(restaurantID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// menuChannel returns the sales channel menu prices are requested for, reporting an error if it is unknown
+// Without a channel the menu price is used
+func menuChannel(c *gin.Context) (string, bool) {
+	channel := c.Query("channel")
+	switch channel {
+	case "", models.OrderChannelDineIn, models.OrderChannelPickup, models.OrderChannelDelivery, models.OrderChannelThirdParty:
+		return channel, true
+	}
+	_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "channel must be dine_in, pickup, delivery or third_party"))
+	return "", false
+}
@@ -0,0 +1,407 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PurchasingHandler handles supplier, purchase order and goods receipt requests
+type PurchasingHandler struct {
+	purchasingService *services.PurchasingService
+}
+
+// NewPurchasingHandler creates a new PurchasingHandler instance
+func NewPurchasingHandler(purchasingService *services.PurchasingService) *PurchasingHandler {
+	return &PurchasingHandler{
+		purchasingService: purchasingService,
+	}
+}
+
+// ListSuppliers handles listing the restaurant's suppliers
+// @Summary List Suppliers
+// @Description List the restaurant's suppliers
+// @Tags inventory
+// @Produce json
+// @Success 200 {array} models.Supplier
+// @Router /api/v1/inventory/suppliers [get]
+func (h *PurchasingHandler) ListSuppliers(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	suppliers, err := h.purchasingService.ListSuppliers(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, suppliers)
+}
+
+// CreateSupplier handles creating a supplier
+// @Summary Create Supplier
+// @Description Create a supplier to order ingredients from
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param request body services.SupplierRequest true "Supplier"
+// @Success 201 {object} models.Supplier
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/inventory/suppliers [post]
+func (h *PurchasingHandler) CreateSupplier(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	supplier, err := h.purchasingService.CreateSupplier(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, supplier)
+}
+
+// UpdateSupplier handles updating a supplier
+// @Summary Update Supplier
+// @Description Update a supplier's details, or deactivate it so it can't be ordered from
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path int true "Supplier ID"
+// @Param request body services.SupplierRequest true "Supplier"
+// @Success 200 {object} models.Supplier
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/inventory/suppliers/{id} [put]
+func (h *PurchasingHandler) UpdateSupplier(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid supplier ID"))
+		return
+	}
+
+	var req services.SupplierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	supplier, err := h.purchasingService.UpdateSupplier(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, supplier)
+}
+
+// DeleteSupplier handles deleting a supplier
+// @Summary Delete Supplier
+// @Description Delete a supplier without purchase orders; suppliers with purchase orders can only be deactivated
+// @Tags inventory
+// @Param id path int true "Supplier ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/inventory/suppliers/{id} [delete]
+func (h *PurchasingHandler) DeleteSupplier(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid supplier ID"))
+		return
+	}
+
+	if err := h.purchasingService.DeleteSupplier(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPurchaseOrders handles listing the restaurant's purchase orders
+// @Summary List Purchase Orders
+// @Description List the restaurant's purchase orders with their lines, newest first
+// @Tags inventory
+// @Produce json
+// @Param status query string false "Status filter" Enums(draft, ordered, partially_received, received, cancelled)
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.PurchaseOrderList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/inventory/purchase-orders [get]
+func (h *PurchasingHandler) ListPurchaseOrders(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	status := c.Query("status")
+	switch status {
+	case "", models.PurchaseOrderStatusDraft, models.PurchaseOrderStatusOrdered, models.PurchaseOrderStatusPartiallyReceived,
+		models.PurchaseOrderStatusReceived, models.PurchaseOrderStatusCancelled:
+	default:
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid status"))
+		return
+	}
+
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	orders, err := h.purchasingService.ListPurchaseOrders(c.Request.Context(), restaurantID, status, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, orders)
+}
+
+// GetPurchaseOrder handles getting a purchase order
+// @Summary Get Purchase Order
+// @Description Get a purchase order with its supplier and lines, including what was received of each line
+// @Tags inventory
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/inventory/purchase-orders/{id} [get]
+func (h *PurchasingHandler) GetPurchaseOrder(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid purchase order ID"))
+		return
+	}
+
+	order, err := h.purchasingService.GetPurchaseOrder(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CreatePurchaseOrder handles drafting a purchase order
+// @Summary Create Purchase Order
+// @Description Draft a purchase order from an active supplier. Lines without a unit cost are priced at the ingredient's current cost
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param request body services.PurchaseOrderRequest true "Purchase order"
+// @Success 201 {object} models.PurchaseOrder
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response "The supplier is inactive"
+// @Router /api/v1/inventory/purchase-orders [post]
+func (h *PurchasingHandler) CreatePurchaseOrder(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	order, err := h.purchasingService.CreatePurchaseOrder(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// UpdatePurchaseOrder handles changing a draft purchase order
+// @Summary Update Purchase Order
+// @Description Replace the supplier, details and lines of a draft purchase order
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param request body services.PurchaseOrderRequest true "Purchase order"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response "The purchase order is no longer a draft"
+// @Router /api/v1/inventory/purchase-orders/{id} [put]
+func (h *PurchasingHandler) UpdatePurchaseOrder(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid purchase order ID"))
+		return
+	}
+
+	var req services.PurchaseOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	order, err := h.purchasingService.UpdatePurchaseOrder(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// SubmitPurchaseOrder handles marking a draft purchase order as ordered
+// @Summary Submit Purchase Order
+// @Description Mark a draft purchase order as sent to the supplier; goods can then be received against it
+// @Tags inventory
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/inventory/purchase-orders/{id}/submit [post]
+func (h *PurchasingHandler) SubmitPurchaseOrder(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid purchase order ID"))
+		return
+	}
+
+	order, err := h.purchasingService.SubmitPurchaseOrder(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelPurchaseOrder handles cancelling a purchase order
+// @Summary Cancel Purchase Order
+// @Description Cancel a draft or ordered purchase order nothing was received for yet
+// @Tags inventory
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/inventory/purchase-orders/{id}/cancel [post]
+func (h *PurchasingHandler) CancelPurchaseOrder(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid purchase order ID"))
+		return
+	}
+
+	order, err := h.purchasingService.CancelPurchaseOrder(c.Request.Context(), uint(id), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// ReceiveGoods handles booking a goods receipt against a purchase order
+// @Summary Receive Goods
+// @Description Book delivered quantities against an ordered purchase order. They are added to stock, and each ingredient's cost becomes the weighted average of its stock and the delivery at the invoiced unit cost. The order is received once every line is received in full
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path int true "Purchase order ID"
+// @Param request body services.ReceiveGoodsRequest true "Delivered quantities"
+// @Success 200 {object} models.PurchaseOrder
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response "The purchase order isn't ordered"
+// @Router /api/v1/inventory/purchase-orders/{id}/receive [post]
+func (h *PurchasingHandler) ReceiveGoods(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid purchase order ID"))
+		return
+	}
+
+	var req services.ReceiveGoodsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	order, err := h.purchasingService.ReceiveGoods(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
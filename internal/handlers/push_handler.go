@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PushHandler handles Web Push subscription requests
+type PushHandler struct {
+	pushService *services.PushService
+}
+
+// NewPushHandler creates a new PushHandler instance
+func NewPushHandler(pushService *services.PushService) *PushHandler {
+	return &PushHandler{
+		pushService: pushService,
+	}
+}
+
+// GetPublicKey handles returning the VAPID public key
+// @Summary Get Web Push Public Key
+// @Description Get the VAPID public key to pass as applicationServerKey when subscribing a device to order updates
+// @Tags push
+// @Produce json
+// @Success 200 {object} services.PushPublicKey
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/push/public-key [get]
+func (h *PushHandler) GetPublicKey(c *gin.Context) {
+	key, err := h.pushService.PublicKey()
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, key)
+}
+
+// Subscribe handles saving a device's push subscription
+// @Summary Subscribe to Web Push
+// @Description Save the browser's PushSubscription so the requesting user gets order status updates on this device
+// @Tags push
+// @Accept json
+// @Produce json
+// @Param request body services.PushSubscribeRequest true "Browser push subscription"
+// @Success 201 {object} models.PushSubscription
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/push/subscriptions [post]
+func (h *PushHandler) Subscribe(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.PushSubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	subscription, err := h.pushService.Subscribe(c.Request.Context(), restaurantID, userID, &req, c.Request.UserAgent())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, subscription)
+}
+
+// ListSubscriptions handles listing the requesting user's subscribed devices
+// @Summary List Web Push Subscriptions
+// @Description List the devices the requesting user subscribed to order updates, newest first
+// @Tags push
+// @Produce json
+// @Success 200 {array} models.PushSubscription
+// @Router /api/v1/push/subscriptions [get]
+func (h *PushHandler) ListSubscriptions(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	subscriptions, err := h.pushService.ListSubscriptions(c.Request.Context(), restaurantID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscriptions)
+}
+
+// Unsubscribe handles removing a device's push subscription
+// @Summary Unsubscribe from Web Push
+// @Description Remove the requesting user's subscription for a push endpoint, e.g. after PushSubscription.unsubscribe()
+// @Tags push
+// @Accept json
+// @Param request body services.PushUnsubscribeRequest true "Push endpoint"
+// @Success 204
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/push/subscriptions [delete]
+func (h *PushHandler) Unsubscribe(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.PushUnsubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	if err := h.pushService.Unsubscribe(c.Request.Context(), restaurantID, userID, req.Endpoint); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
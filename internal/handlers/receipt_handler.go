@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReceiptHandler handles order receipt requests
+type ReceiptHandler struct {
+	receiptService *services.ReceiptService
+}
+
+// NewReceiptHandler creates a new ReceiptHandler instance
+func NewReceiptHandler(receiptService *services.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{
+		receiptService: receiptService,
+	}
+}
+
+// GetOrderReceipt handles downloading an order receipt as PDF
+// @Summary Download Order Receipt
+// @Description Render an order receipt as a PDF with the restaurant logo, line items, tax breakdown and payment information. Customers can only download receipts of their own orders
+// @Tags orders
+// @Produce application/pdf
+// @Param id path int true "Order ID"
+// @Success 200 {file} file
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/receipt.pdf [get]
+func (h *ReceiptHandler) GetOrderReceipt(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var customerID uint
+	if role, _ := ctx.GetUserRole(c.Request.Context()); role == "Client" {
+		userID, ok := ctx.GetUserID(c.Request.Context())
+		if !ok {
+			_ = c.Error(apperrors.ErrUserContextMissing)
+			return
+		}
+		customerID = userID
+	}
+
+	receipt, err := h.receiptService.GetOrderReceipt(c.Request.Context(), uint(id), restaurantID, customerID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="receipt-%d.pdf"`, id))
+	c.Data(http.StatusOK, "application/pdf", receipt)
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"time"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/repositories"
 	"restaurant-backend/internal/services"
@@ -31,41 +32,70 @@ func NewReservationHandler(
 
 // CreateReservation handles reservation creation
 // @Summary Create Reservation
-// @Description Create a new table reservation with availability checking
+// @Description Create a new table reservation with availability checking and the restaurant's pacing rules
 // @Tags reservations
 // @Accept json
 // @Produce json
 // @Param request body services.CreateReservationRequest true "Reservation data"
 // @Success 201 {object} models.Reservation
-// @Failure 400 {object} map[string]string
-// @Failure 409 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/reservations [post]
 func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 	var req services.CreateReservationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	reservation, err := h.reservationService.CreateReservation(c.Request.Context(), &req, restaurantID)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "table is not available at the requested time" {
-			statusCode = http.StatusConflict
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, reservation)
 }
 
+// SearchAvailability handles searching reservation times
+// @Summary Search Reservation Availability
+// @Description List a day's start times within the opening hours with whether a party can book then, applying the restaurant's pacing rules (covers per slot, max party size, table turnover buffer), reservations and private events
+// @Tags reservations
+// @Produce json
+// @Param date query string true "Date (YYYY-MM-DD) in the restaurant's time zone"
+// @Param party_size query int true "Number of guests"
+// @Param duration_minutes query int false "Booking length in minutes, defaults to 90"
+// @Success 200 {object} services.ReservationAvailability
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/reservations/availability [get]
+func (h *ReservationHandler) SearchAvailability(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.AvailabilityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	availability, err := h.reservationService.SearchAvailability(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
 // GetReservation handles getting a reservation by ID
 // @Summary Get Reservation
 // @Description Get a reservation by ID
@@ -73,18 +103,24 @@ func (h *ReservationHandler) CreateReservation(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Reservation ID"
 // @Success 200 {object} models.Reservation
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/reservations/{id} [get]
 func (h *ReservationHandler) GetReservation(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid reservation ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
-	reservation, err := h.reservationRepo.GetByIDWithContext(c.Request.Context(), uint(id))
+	reservation, err := h.reservationRepo.GetByIDForRestaurant(c.Request.Context(), uint(id), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "reservation not found"})
+		_ = c.Error(apperrors.NotFound(apperrors.CodeReservationNotFound, "reservation not found"))
 		return
 	}
 
@@ -102,7 +138,7 @@ func (h *ReservationHandler) GetReservation(c *gin.Context) {
 func (h *ReservationHandler) ListReservations(c *gin.Context) {
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -113,7 +149,7 @@ func (h *ReservationHandler) ListReservations(c *gin.Context) {
 		if err == nil {
 			reservations, err := h.reservationRepo.GetByDateWithContext(c.Request.Context(), restaurantID, date)
 			if err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				_ = c.Error(err)
 				return
 			}
 			c.JSON(http.StatusOK, reservations)
@@ -124,7 +160,7 @@ func (h *ReservationHandler) ListReservations(c *gin.Context) {
 	// Otherwise, get all reservations for the restaurant
 	reservations, err := h.reservationRepo.GetByRestaurantIDWithContext(c.Request.Context(), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -133,32 +169,39 @@ func (h *ReservationHandler) ListReservations(c *gin.Context) {
 
 // UpdateReservation handles updating a reservation
 // @Summary Update Reservation
-// @Description Update an existing reservation (currently supports status updates)
+// @Description Update the status, time, table, party size or notes of a reservation. Time, table and party size changes are re-checked for availability and the guest is emailed.
 // @Tags reservations
 // @Accept json
 // @Produce json
 // @Param id path int true "Reservation ID"
-// @Param reservation body services.UpdateReservationStatusRequest true "Reservation update data"
+// @Param reservation body services.UpdateReservationRequest true "Reservation update data"
 // @Success 200 {object} models.Reservation
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response "The change would double-book the table"
 // @Router /api/v1/reservations/{id} [put]
 func (h *ReservationHandler) UpdateReservation(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid reservation ID"))
 		return
 	}
 
-	var req services.UpdateReservationStatusRequest
+	var req services.UpdateReservationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
-	reservation, err := h.reservationService.UpdateReservationStatusWithCtx(c.Request.Context(), uint(id), &req)
+	reservation, err := h.reservationService.UpdateReservationWithCtx(c.Request.Context(), uint(id), restaurantID, &req)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -171,17 +214,23 @@ func (h *ReservationHandler) UpdateReservation(c *gin.Context) {
 // @Tags reservations
 // @Param id path int true "Reservation ID"
 // @Success 204
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/reservations/{id} [delete]
 func (h *ReservationHandler) DeleteReservation(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid reservation ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid reservation ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
-	if err := h.reservationRepo.DeleteWithContext(c.Request.Context(), uint(id)); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.reservationService.CancelReservation(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
 		return
 	}
 
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RestaurantDirectoryHandler handles the public restaurant directory (no authentication required)
+type RestaurantDirectoryHandler struct {
+	directoryService *services.RestaurantDirectoryService
+}
+
+// NewRestaurantDirectoryHandler creates a new RestaurantDirectoryHandler instance
+func NewRestaurantDirectoryHandler(directoryService *services.RestaurantDirectoryService) *RestaurantDirectoryHandler {
+	return &RestaurantDirectoryHandler{
+		directoryService: directoryService,
+	}
+}
+
+// SearchRestaurantsPublic handles searching the public restaurant directory
+// @Summary Search Restaurants (Public)
+// @Description Search publicly visible restaurants by name, city and cuisine, and around a location (lat and lng, within radius_km). Results near a location are nearest first with distance_meters, otherwise best rated first (no authentication required)
+// @Tags public-restaurants
+// @Produce json
+// @Param q query string false "Name contains"
+// @Param city query string false "City"
+// @Param cuisine query string false "Cuisine"
+// @Param lat query number false "Latitude"
+// @Param lng query number false "Longitude"
+// @Param radius_km query number false "Search radius around lat/lng in km (max 100)" default(10)
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.DirectoryList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/public/restaurants [get]
+func (h *RestaurantDirectoryHandler) SearchRestaurantsPublic(c *gin.Context) {
+	var req services.DirectorySearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.directoryService.Search(c.Request.Context(), &req, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetRestaurantProfilePublic handles getting a restaurant's public profile
+// @Summary Get Restaurant Profile (Public)
+// @Description Get a restaurant's public profile: contact details, location, cuisines, photos, opening hours, rating and accepted order types (no authentication required)
+// @Tags public-restaurants
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Success 200 {object} services.RestaurantProfile
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id} [get]
+func (h *RestaurantDirectoryHandler) GetRestaurantProfilePublic(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	profile, err := h.directoryService.GetProfile(c.Request.Context(), uint(restaurantID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
@@ -37,23 +38,19 @@ func NewRestaurantHandler(
 // @Produce json
 // @Param request body services.RegisterRestaurantRequest true "Restaurant registration data"
 // @Success 201 {object} models.Restaurant
-// @Failure 400 {object} map[string]string
-// @Failure 409 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/restaurants/register [post]
 func (h *RestaurantHandler) RegisterRestaurant(c *gin.Context) {
 	var req services.RegisterRestaurantRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	restaurant, err := h.restaurantService.RegisterRestaurant(c.Request.Context(), &req)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "restaurant with this email already exists" {
-			statusCode = http.StatusConflict
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -71,7 +68,7 @@ func (h *RestaurantHandler) RegisterRestaurant(c *gin.Context) {
 // @Param status query string false "Filter by status (pending, active, inactive, suspended)"
 // @Param kam_id query int false "Filter by KAM ID"
 // @Success 200 {array} models.Restaurant
-// @Failure 403 {object} map[string]string
+// @Failure 403 {object} apperrors.Response
 // @Router /api/v1/restaurants [get]
 func (h *RestaurantHandler) ListRestaurants(c *gin.Context) {
 	var status *models.RestaurantStatus
@@ -95,7 +92,7 @@ func (h *RestaurantHandler) ListRestaurants(c *gin.Context) {
 
 	restaurants, err := h.restaurantRepo.ListWithContext(c.Request.Context(), status, kamID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -109,21 +106,22 @@ func (h *RestaurantHandler) ListRestaurants(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Restaurant ID"
 // @Success 200 {object} models.Restaurant
-// @Failure 404 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/restaurants/{id} [get]
 func (h *RestaurantHandler) GetRestaurant(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid restaurant ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
 		return
 	}
 
 	restaurant, err := h.restaurantRepo.GetByIDWithContext(c.Request.Context(), uint(id))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "restaurant not found"})
+		_ = c.Error(apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found"))
 		return
 	}
 
+	setETag(c, restaurant.Version)
 	c.JSON(http.StatusOK, restaurant)
 }
 
@@ -135,14 +133,14 @@ func (h *RestaurantHandler) GetRestaurant(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Restaurant ID"
 // @Success 200 {object} models.Restaurant
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 403 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
 // @Router /api/v1/restaurants/{id}/activate [post]
 func (h *RestaurantHandler) ActivateRestaurant(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid restaurant ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
 		return
 	}
 
@@ -150,22 +148,14 @@ func (h *RestaurantHandler) ActivateRestaurant(c *gin.Context) {
 	// This user must be a KAM (enforced by middleware)
 	activatedBy, ok := ctx.GetUserID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "user context not found"})
+		_ = c.Error(apperrors.ErrUserContextMissing)
 		return
 	}
 
 	// Activate restaurant - no request body needed, KAM ID comes from token
 	restaurant, err := h.restaurantService.ActivateRestaurant(c.Request.Context(), uint(id), activatedBy)
 	if err != nil {
-		statusCode := http.StatusBadRequest
-		if err.Error() == "restaurant not found" {
-			statusCode = http.StatusNotFound
-		} else if err.Error() == "only KAM users can activate restaurants" {
-			statusCode = http.StatusForbidden
-		} else if err.Error() == "restaurant is already active" {
-			statusCode = http.StatusConflict // 409 Conflict - already active
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -187,29 +177,38 @@ type UpdateRestaurantStatusRequest struct {
 // @Produce json
 // @Param id path int true "Restaurant ID"
 // @Param status body map[string]string true "Status update" SchemaExample({"status": "active"})
+// @Param If-Match header string false "ETag from a previous read; rejects the update with 409 if the resource changed since"
 // @Success 200 {object} models.Restaurant
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/restaurants/{id}/status [put]
 func (h *RestaurantHandler) UpdateRestaurantStatus(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid restaurant ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
 		return
 	}
 
 	var req UpdateRestaurantStatusRequest
 	if err := c.ShouldBind(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid the status value. It must be one of: pending, active, inactive, suspended."})
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	restaurant, err := h.restaurantService.UpdateRestaurantStatus(c.Request.Context(), uint(id), req.Status)
+	restaurant, err := h.restaurantService.UpdateRestaurantStatus(c.Request.Context(), uint(id), req.Status, expectedVersion)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, restaurant.Version)
 	c.JSON(http.StatusOK, restaurant)
 }
 
@@ -223,7 +222,7 @@ func (h *RestaurantHandler) UpdateRestaurantStatus(c *gin.Context) {
 func (h *RestaurantHandler) ListPendingRestaurants(c *gin.Context) {
 	restaurants, err := h.restaurantRepo.ListPendingWithContext(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -238,33 +237,93 @@ func (h *RestaurantHandler) ListPendingRestaurants(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Restaurant ID"
 // @Param request body map[string]uint true "KAM assignment" SchemaExample({"kam_id": 1})
+// @Param If-Match header string false "ETag from a previous read; rejects the update with 409 if the resource changed since"
 // @Success 200 {object} models.Restaurant
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/restaurants/{id}/assign-kam [put]
 func (h *RestaurantHandler) AssignKAM(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid restaurant ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
 		return
 	}
 
 	var req map[string]uint
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	kamID, exists := req["kam_id"]
 	if !exists {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "kam_id is required"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeValidationFailed, "kam_id is required"))
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	restaurant, err := h.restaurantService.AssignKAM(c.Request.Context(), uint(id), kamID, expectedVersion)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	setETag(c, restaurant.Version)
+	c.JSON(http.StatusOK, restaurant)
+}
+
+// UpdateVisibility handles soft-launch visibility changes (KAM/Admin only)
+// @Summary Update Restaurant Visibility
+// @Description Hide a restaurant from the public directory and public ordering (optionally scheduling go-live), or launch it now. The first launch sends an announcement email.
+// @Tags restaurants
+// @Accept json
+// @Produce json
+// @Param id path int true "Restaurant ID"
+// @Param request body services.UpdateVisibilityRequest true "Visibility update"
+// @Param If-Match header string false "ETag from a previous read; rejects the update with 409 if the resource changed since"
+// @Success 200 {object} models.Restaurant
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/restaurants/{id}/visibility [patch]
+func (h *RestaurantHandler) UpdateVisibility(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	var req services.UpdateVisibilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	// Restaurant Admins may only change their own restaurant; KAMs manage any
+	role, _ := ctx.GetUserRole(c.Request.Context())
+	restaurantID, _ := ctx.GetRestaurantID(c.Request.Context())
+	if role != "KAM" && restaurantID != uint(id) {
+		_ = c.Error(apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found"))
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	restaurant, err := h.restaurantService.AssignKAM(c.Request.Context(), uint(id), kamID)
+	restaurant, err := h.restaurantService.UpdateVisibility(c.Request.Context(), uint(id), &req, expectedVersion)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, restaurant.Version)
 	c.JSON(http.StatusOK, restaurant)
 }
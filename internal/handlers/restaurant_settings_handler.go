@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RestaurantSettingsHandler handles restaurant branding/settings requests
+type RestaurantSettingsHandler struct {
+	settingsService *services.RestaurantSettingsService
+}
+
+// NewRestaurantSettingsHandler creates a new RestaurantSettingsHandler instance
+func NewRestaurantSettingsHandler(settingsService *services.RestaurantSettingsService) *RestaurantSettingsHandler {
+	return &RestaurantSettingsHandler{
+		settingsService: settingsService,
+	}
+}
+
+// GetSettings handles getting the restaurant's settings
+// @Summary Get Restaurant Settings
+// @Description Get branding and storefront settings for the restaurant
+// @Tags settings
+// @Produce json
+// @Success 200 {object} models.RestaurantSettings
+// @Router /api/v1/settings [get]
+func (h *RestaurantSettingsHandler) GetSettings(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	settings, err := h.settingsService.GetSettings(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings handles updating the restaurant's settings
+// @Summary Update Restaurant Settings
+// @Description Update logo, theme colors, currency, locale, time zone, receipt footer and online ordering toggle
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Param request body services.UpdateRestaurantSettingsRequest true "Settings data"
+// @Success 200 {object} models.RestaurantSettings
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/settings [put]
+func (h *RestaurantSettingsHandler) UpdateSettings(c *gin.Context) {
+	var req services.UpdateRestaurantSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	settings, err := h.settingsService.UpdateSettings(c.Request.Context(), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
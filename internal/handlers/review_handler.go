@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReviewHandler handles review and rating requests
+type ReviewHandler struct {
+	reviewService *services.ReviewService
+}
+
+// NewReviewHandler creates a new ReviewHandler instance
+func NewReviewHandler(reviewService *services.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// CreateReview handles reviewing a completed order or one of its items
+// @Summary Create Review
+// @Description Rate a completed order, or one of its menu items, as the customer who placed it
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body services.CreateReviewRequest true "Review data"
+// @Success 201 {object} models.Review
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/orders/{id}/reviews [post]
+func (h *ReviewHandler) CreateReview(c *gin.Context) {
+	orderID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid order ID"))
+		return
+	}
+
+	var req services.CreateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	review, err := h.reviewService.CreateReview(c.Request.Context(), uint(orderID), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, review)
+}
+
+// ListReviews handles listing the restaurant's reviews for staff
+// @Summary List Reviews
+// @Description List reviews of the restaurant, including hidden ones on request
+// @Tags reviews
+// @Produce json
+// @Param menu_item_id query int false "Only reviews of this menu item"
+// @Param include_hidden query bool false "Include hidden reviews"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.ReviewList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/reviews [get]
+func (h *ReviewHandler) ListReviews(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	menuItemID, ok := optionalMenuItemID(c)
+	if !ok {
+		return
+	}
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	filter := repositories.ReviewFilter{
+		MenuItemID:    menuItemID,
+		IncludeHidden: c.Query("include_hidden") == "true",
+	}
+	reviews, err := h.reviewService.ListReviews(c.Request.Context(), restaurantID, filter, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// ListPublicReviews handles listing a restaurant's visible reviews (public access)
+// @Summary List Reviews (Public)
+// @Description List visible reviews and the rating of a restaurant (no authentication required)
+// @Tags public-menu
+// @Produce json
+// @Param restaurant_id path int true "Restaurant ID"
+// @Param menu_item_id query int false "Only reviews of this menu item"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.ReviewList
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/public/restaurants/{restaurant_id}/reviews [get]
+func (h *ReviewHandler) ListPublicReviews(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	menuItemID, ok := optionalMenuItemID(c)
+	if !ok {
+		return
+	}
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	reviews, err := h.reviewService.ListPublicReviews(c.Request.Context(), uint(restaurantID), menuItemID, limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reviews)
+}
+
+// ModerateReview handles hiding or restoring a review
+// @Summary Moderate Review
+// @Description Hide an abusive review (excluded from ratings) or restore a hidden one
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param request body services.ModerateReviewRequest true "Moderation decision"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/reviews/{id}/moderation [put]
+func (h *ReviewHandler) ModerateReview(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid review ID"))
+		return
+	}
+
+	var req services.ModerateReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	review, err := h.reviewService.ModerateReview(c.Request.Context(), uint(id), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// optionalMenuItemID parses the optional menu_item_id query parameter
+func optionalMenuItemID(c *gin.Context) (*uint, bool) {
+	raw := c.Query("menu_item_id")
+	if raw == "" {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid menu item ID"))
+		return nil, false
+	}
+	menuItemID := uint(id)
+	return &menuItemID, true
+}
+
+// pageParams parses the limit and offset query parameters
+func pageParams(c *gin.Context) (int, int, bool) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid limit parameter"))
+		return 0, 0, false
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid offset parameter"))
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
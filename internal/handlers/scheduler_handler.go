@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SchedulerHandler handles background job requests
+type SchedulerHandler struct {
+	schedulerService *services.SchedulerService
+}
+
+// NewSchedulerHandler creates a new SchedulerHandler instance
+func NewSchedulerHandler(schedulerService *services.SchedulerService) *SchedulerHandler {
+	return &SchedulerHandler{
+		schedulerService: schedulerService,
+	}
+}
+
+// ListJobs handles listing the scheduled background jobs
+// @Summary List Scheduled Jobs
+// @Description List the periodic background jobs with their interval, next run, last result and current lease
+// @Tags platform
+// @Produce json
+// @Success 200 {array} services.JobStatus
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/platform/jobs [get]
+func (h *SchedulerHandler) ListJobs(c *gin.Context) {
+	jobs, err := h.schedulerService.ListJobs(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+}
+
+// ListRuns handles listing a job's run history
+// @Summary List Scheduled Job Runs
+// @Description List the runs of a background job, newest first
+// @Tags platform
+// @Produce json
+// @Param name path string true "Job name"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.JobRunList
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/platform/jobs/{name}/runs [get]
+func (h *SchedulerHandler) ListRuns(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid limit parameter"))
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid offset parameter"))
+		return
+	}
+
+	runs, err := h.schedulerService.ListRuns(c.Request.Context(), c.Param("name"), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, runs)
+}
+
+// TriggerJob handles running a job now
+// @Summary Trigger Scheduled Job
+// @Description Make a background job due now; the next scheduler poll of any instance runs it
+// @Tags platform
+// @Produce json
+// @Param name path string true "Job name"
+// @Success 202 {object} map[string]string
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/platform/jobs/{name}/trigger [post]
+func (h *SchedulerHandler) TriggerJob(c *gin.Context) {
+	name := c.Param("name")
+	if err := h.schedulerService.TriggerJob(c.Request.Context(), name); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "job scheduled to run now", "job": name})
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionHandler handles login session requests
+type SessionHandler struct {
+	sessionService *services.SessionService
+}
+
+// NewSessionHandler creates a new SessionHandler instance
+func NewSessionHandler(sessionService *services.SessionService) *SessionHandler {
+	return &SessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+// ListSessions handles listing the current user's sessions
+// @Summary List My Sessions
+// @Description List the devices the current user is signed in on, with IP address, user agent and last activity; the session of the request is marked current
+// @Tags sessions
+// @Produce json
+// @Success 200 {array} services.SessionView
+// @Failure 401 {object} apperrors.Response
+// @Router /api/v1/sessions [get]
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+	sessionID, _ := ctx.GetSessionID(c.Request.Context())
+
+	sessions, err := h.sessionService.List(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeSession handles signing out one of the current user's sessions
+// @Summary Revoke Session
+// @Description Sign out one of the current user's devices; its token is rejected from the next request on
+// @Tags sessions
+// @Param session_id path string true "Session ID"
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/sessions/{session_id} [delete]
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	if err := h.sessionService.Revoke(c.Request.Context(), userID, c.Param("session_id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeOtherSessions handles signing out all of the current user's other sessions
+// @Summary Revoke Other Sessions
+// @Description Sign out every device of the current user except the one making the request
+// @Tags sessions
+// @Produce json
+// @Success 200 {object} map[string]int64
+// @Failure 401 {object} apperrors.Response
+// @Router /api/v1/sessions [delete]
+func (h *SessionHandler) RevokeOtherSessions(c *gin.Context) {
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+	sessionID, _ := ctx.GetSessionID(c.Request.Context())
+
+	revoked, err := h.sessionService.RevokeAll(c.Request.Context(), userID, sessionID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// ListUserSessions handles listing the sessions of a user of the restaurant
+// @Summary List User Sessions
+// @Description List the devices a user of the restaurant is signed in on (Admin only)
+// @Tags sessions
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {array} services.SessionView
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/users/{id}/sessions [get]
+func (h *SessionHandler) ListUserSessions(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
+		return
+	}
+
+	sessions, err := h.sessionService.ListForUser(c.Request.Context(), restaurantID, uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// RevokeUserSession handles signing out a session of a user of the restaurant
+// @Summary Revoke User Session
+// @Description Sign out a device of a user of the restaurant, e.g. a lost or stolen tablet (Admin only)
+// @Tags sessions
+// @Param id path int true "User ID"
+// @Param session_id path string true "Session ID"
+// @Success 204
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/users/{id}/sessions/{session_id} [delete]
+func (h *SessionHandler) RevokeUserSession(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
+		return
+	}
+
+	if err := h.sessionService.RevokeForUser(c.Request.Context(), restaurantID, uint(id), c.Param("session_id")); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SigningKeyHandler handles JWT signing key requests
+type SigningKeyHandler struct {
+	authService *services.AuthService
+}
+
+// NewSigningKeyHandler creates a new SigningKeyHandler instance
+func NewSigningKeyHandler(authService *services.AuthService) *SigningKeyHandler {
+	return &SigningKeyHandler{
+		authService: authService,
+	}
+}
+
+// ListSigningKeys handles listing the JWT signing keys
+// @Summary List JWT Signing Keys
+// @Description List the configured secret and the rotated keys by key ID with their status (signing, verifying, retired); secrets are never returned
+// @Tags platform
+// @Produce json
+// @Success 200 {array} services.SigningKeyStatus
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/platform/signing-keys [get]
+func (h *SigningKeyHandler) ListSigningKeys(c *gin.Context) {
+	keys, err := h.authService.ListSigningKeys(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// RotateSigningKey handles rotating the JWT signing key
+// @Summary Rotate JWT Signing Key
+// @Description Sign new tokens with a new random key. Replaced keys keep verifying their tokens until those expire, so sessions aren't ended; the rotation is audit-logged
+// @Tags platform
+// @Produce json
+// @Success 201 {object} services.SigningKeyStatus
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/platform/signing-keys/rotate [post]
+func (h *SigningKeyHandler) RotateSigningKey(c *gin.Context) {
+	userID, _ := ctx.GetUserID(c.Request.Context())
+	role, _ := ctx.GetUserRole(c.Request.Context())
+
+	key, err := h.authService.RotateSigningKey(c.Request.Context(), services.AuditActor{
+		UserID:    userID,
+		Role:      role,
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, key)
+}
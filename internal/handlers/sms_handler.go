@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// twilioSignatureHeader carries the signature of Twilio callbacks
+const twilioSignatureHeader = "X-Twilio-Signature"
+
+// SMSHandler handles the SMS delivery log and provider status callbacks
+type SMSHandler struct {
+	smsService *services.SMSService
+}
+
+// NewSMSHandler creates a new SMSHandler instance
+func NewSMSHandler(smsService *services.SMSService) *SMSHandler {
+	return &SMSHandler{
+		smsService: smsService,
+	}
+}
+
+// ListMessages handles listing the restaurant's SMS delivery log
+// @Summary List SMS Messages
+// @Description List the text messages sent to the restaurant's guests (reservation confirmations and reminders, order ready) with their delivery status, newest first
+// @Tags sms
+// @Produce json
+// @Param type query string false "Message type (reservation_confirmation, reservation_reminder, order_ready)"
+// @Param limit query int false "Page size (default 50, max 100)"
+// @Param offset query int false "Offset"
+// @Success 200 {array} models.SMSMessage
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/sms-messages [get]
+func (h *SMSHandler) ListMessages(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid limit parameter"))
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid offset parameter"))
+		return
+	}
+
+	messages, err := h.smsService.List(c.Request.Context(), restaurantID, c.Query("type"), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// TwilioStatusCallback handles Twilio message status callbacks
+// @Summary Twilio Status Callback
+// @Description Receive a delivery status update from Twilio; the request must carry a valid X-Twilio-Signature
+// @Tags sms
+// @Accept x-www-form-urlencoded
+// @Param X-Twilio-Signature header string true "Twilio request signature"
+// @Success 204
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Router /api/v1/public/sms/twilio/status [post]
+func (h *SMSHandler) TwilioStatusCallback(c *gin.Context) {
+	if err := c.Request.ParseForm(); err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid form body"))
+		return
+	}
+
+	if err := h.smsService.HandleTwilioStatus(c.Request.Context(), c.Request.PostForm, c.GetHeader(twilioSignatureHeader)); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
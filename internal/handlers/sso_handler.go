@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ssoStateCookie holds the signed login state between the redirect to the IdP and the callback
+const ssoStateCookie = "sso_state"
+
+// ssoCookiePath limits the state cookie to the SSO routes
+const ssoCookiePath = "/api/v1/auth/sso"
+
+// SSOHandler handles staff SSO login and configuration requests
+type SSOHandler struct {
+	ssoService    *services.SSOService
+	frontendURL   string
+	secureCookies bool
+}
+
+// NewSSOHandler creates a new SSOHandler instance
+func NewSSOHandler(ssoService *services.SSOService, frontendURL string, secureCookies bool) *SSOHandler {
+	return &SSOHandler{
+		ssoService:    ssoService,
+		frontendURL:   frontendURL,
+		secureCookies: secureCookies,
+	}
+}
+
+// StartLogin handles starting a staff SSO login
+// @Summary Start SSO Login
+// @Description Redirect to the restaurant's OpenID Connect identity provider. The login state is kept in a short-lived cookie
+// @Tags auth
+// @Param restaurant_id path int true "Restaurant ID"
+// @Success 302
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/auth/sso/{restaurant_id}/login [get]
+func (h *SSOHandler) StartLogin(c *gin.Context) {
+	restaurantID, err := strconv.ParseUint(c.Param("restaurant_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	start, err := h.ssoService.StartLogin(c.Request.Context(), uint(restaurantID))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	// Lax, not Strict: the callback is a top-level navigation coming from the IdP's site
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(ssoStateCookie, start.StateCookie, int(time.Until(start.ExpiresAt).Seconds()), ssoCookiePath, "", h.secureCookies, true)
+	c.Redirect(http.StatusFound, start.AuthURL)
+}
+
+// Callback handles the identity provider's redirect back after a login
+// @Summary SSO Login Callback
+// @Description Complete an SSO login and redirect to the frontend's /sso/callback with the token (or an error code) in the URL fragment
+// @Tags auth
+// @Param code query string true "Authorization code"
+// @Param state query string true "Login state"
+// @Success 302
+// @Router /api/v1/auth/sso/callback [get]
+func (h *SSOHandler) Callback(c *gin.Context) {
+	stateCookie, _ := c.Cookie(ssoStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(ssoStateCookie, "", -1, ssoCookiePath, "", h.secureCookies, true)
+
+	var resp *services.LoginResponse
+	var err error
+	if idpError := c.Query("error"); idpError != "" {
+		err = apperrors.Unauthorized(apperrors.CodeSSOLoginFailed, "identity provider denied the login: "+idpError)
+	} else {
+		resp, err = h.ssoService.Callback(c.Request.Context(), stateCookie, c.Query("state"), c.Query("code"), services.ClientInfo{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()})
+	}
+	if err != nil {
+		logger.Warn("SSO login failed", zap.Error(err))
+	}
+
+	c.Redirect(http.StatusFound, services.SSOFrontendRedirect(h.frontendURL, resp, err))
+}
+
+// GetConfig handles returning the restaurant's SSO configuration
+// @Summary Get SSO Configuration
+// @Description Get the restaurant's identity provider and group to role mappings; the client secret is never returned
+// @Tags sso
+// @Produce json
+// @Success 200 {object} models.SSOConfig
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/sso-config [get]
+func (h *SSOHandler) GetConfig(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	config, err := h.ssoService.GetConfig(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// UpdateConfig handles setting the restaurant's SSO configuration
+// @Summary Update SSO Configuration
+// @Description Set the OpenID Connect issuer and client, and which IdP groups map to the Admin and Staff roles. Enabling checks the issuer's discovery document
+// @Tags sso
+// @Accept json
+// @Produce json
+// @Param request body services.UpdateSSOConfigRequest true "SSO configuration"
+// @Success 200 {object} models.SSOConfig
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/sso-config [put]
+func (h *SSOHandler) UpdateConfig(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.UpdateSSOConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	config, err := h.ssoService.UpdateConfig(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, config)
+}
+
+// DeleteConfig handles removing the restaurant's SSO configuration
+// @Summary Delete SSO Configuration
+// @Description Turn SSO off and remove the identity provider; users created through SSO keep their accounts
+// @Tags sso
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/sso-config [delete]
+func (h *SSOHandler) DeleteConfig(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	if err := h.ssoService.DeleteConfig(c.Request.Context(), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorageBackupHandler handles tenant storage footprint and backup requests
+type StorageBackupHandler struct {
+	backupService *services.StorageBackupService
+}
+
+// NewStorageBackupHandler creates a new StorageBackupHandler instance
+func NewStorageBackupHandler(backupService *services.StorageBackupService) *StorageBackupHandler {
+	return &StorageBackupHandler{
+		backupService: backupService,
+	}
+}
+
+// GetStorageReport handles the storage report for all tenants
+// @Summary Get Storage Report
+// @Description Get each tenant's S3 storage footprint (current and backup versions) and backup status
+// @Tags platform
+// @Produce json
+// @Success 200 {object} services.StorageReport
+// @Failure 500 {object} apperrors.Response
+// @Router /api/v1/platform/storage [get]
+func (h *StorageBackupHandler) GetStorageReport(c *gin.Context) {
+	report, err := h.backupService.GetReport(c.Request.Context())
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetTenantStorage handles the storage report for a single tenant
+// @Summary Get Tenant Storage
+// @Description Get a restaurant's S3 storage footprint and backup status
+// @Tags platform
+// @Produce json
+// @Param id path int true "Restaurant ID"
+// @Success 200 {object} services.TenantStorageReport
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/platform/storage/restaurants/{id} [get]
+func (h *StorageBackupHandler) GetTenantStorage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	report, err := h.backupService.GetTenantReport(c.Request.Context(), uint(id))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// SetBackupPolicy handles setting a tenant's backup policy
+// @Summary Set Tenant Backup Policy
+// @Description Set a restaurant's backup tier (premium adds cross-region replication) and version retention, then apply the bucket configuration
+// @Tags platform
+// @Accept json
+// @Produce json
+// @Param id path int true "Restaurant ID"
+// @Param request body services.SetBackupPolicyRequest true "Backup policy"
+// @Success 200 {object} models.StorageBackupPolicy
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 502 {object} apperrors.Response
+// @Router /api/v1/platform/storage/restaurants/{id}/backup-policy [put]
+func (h *StorageBackupHandler) SetBackupPolicy(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid restaurant ID"))
+		return
+	}
+
+	var req services.SetBackupPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	policy, err := h.backupService.SetPolicy(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// SyncBackups handles re-applying the bucket backup configuration
+// @Summary Sync Storage Backups
+// @Description Re-apply versioning, lifecycle and replication rules for all tenants to the bucket
+// @Tags platform
+// @Success 204 "No Content"
+// @Failure 502 {object} apperrors.Response
+// @Router /api/v1/platform/storage/sync [post]
+func (h *StorageBackupHandler) SyncBackups(c *gin.Context) {
+	if err := h.backupService.ApplyPolicies(c.Request.Context()); err != nil {
+		_ = c.Error(apperrors.Wrap(err, http.StatusBadGateway, apperrors.CodeBackupSyncFailed, "bucket backup configuration could not be applied"))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SubscriptionHandler handles subscription plan requests
+type SubscriptionHandler struct {
+	subscriptionService *services.SubscriptionService
+}
+
+// NewSubscriptionHandler creates a new SubscriptionHandler instance
+func NewSubscriptionHandler(subscriptionService *services.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{
+		subscriptionService: subscriptionService,
+	}
+}
+
+// ListPlans handles listing the available plans
+// @Summary List Plans
+// @Description List subscription plans with their prices and limits (0 means unlimited)
+// @Tags subscription
+// @Produce json
+// @Success 200 {array} models.Plan
+// @Router /api/v1/subscription/plans [get]
+func (h *SubscriptionHandler) ListPlans(c *gin.Context) {
+	c.JSON(http.StatusOK, h.subscriptionService.ListPlans())
+}
+
+// GetSubscription handles getting the restaurant's subscription
+// @Summary Get Subscription
+// @Description Get the restaurant's plan, subscription status and current usage of plan limits
+// @Tags subscription
+// @Produce json
+// @Success 200 {object} services.SubscriptionOverview
+// @Router /api/v1/subscription [get]
+func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	overview, err := h.subscriptionService.GetSubscription(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
+
+// ChangePlan handles upgrading or downgrading the restaurant's plan
+// @Summary Change Plan
+// @Description Upgrade or downgrade the restaurant's plan (Admin only). Downgrades are rejected while usage exceeds the new plan's limits
+// @Tags subscription
+// @Accept json
+// @Produce json
+// @Param request body services.ChangePlanRequest true "Target plan"
+// @Success 200 {object} services.SubscriptionOverview
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/subscription [put]
+func (h *SubscriptionHandler) ChangePlan(c *gin.Context) {
+	var req services.ChangePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	overview, err := h.subscriptionService.ChangePlan(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, overview)
+}
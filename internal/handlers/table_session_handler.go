@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TableSessionHandler handles dine-in table session (open check) requests
+type TableSessionHandler struct {
+	sessionService *services.TableSessionService
+}
+
+// NewTableSessionHandler creates a new TableSessionHandler instance
+func NewTableSessionHandler(sessionService *services.TableSessionService) *TableSessionHandler {
+	return &TableSessionHandler{
+		sessionService: sessionService,
+	}
+}
+
+// OpenSession handles seating a party and opening its check
+// @Summary Open Table Session
+// @Description Seat a party at a table and open its check. A table can only have one open check
+// @Tags table-sessions
+// @Accept json
+// @Produce json
+// @Param request body services.OpenTableSessionRequest true "Table session"
+// @Success 201 {object} services.TableCheck
+// @Failure 400 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/table-sessions [post]
+func (h *TableSessionHandler) OpenSession(c *gin.Context) {
+	restaurantID, userID, ok := staffParams(c)
+	if !ok {
+		return
+	}
+
+	var req services.OpenTableSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	check, err := h.sessionService.OpenSession(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, check)
+}
+
+// ListSessions handles listing table sessions
+// @Summary List Table Sessions
+// @Description List the latest open or closed checks with their rounds and running totals, newest first
+// @Tags table-sessions
+// @Produce json
+// @Param status query string false "open or closed" default(open)
+// @Success 200 {array} services.TableCheck
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/table-sessions [get]
+func (h *TableSessionHandler) ListSessions(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	checks, err := h.sessionService.ListSessions(c.Request.Context(), restaurantID, c.Query("status"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, checks)
+}
+
+// GetSession handles retrieving a check
+// @Summary Get Table Session
+// @Description Get a check with its rounds, their items and the running total
+// @Tags table-sessions
+// @Produce json
+// @Param id path int true "Table session ID"
+// @Success 200 {object} services.TableCheck
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/table-sessions/{id} [get]
+func (h *TableSessionHandler) GetSession(c *gin.Context) {
+	restaurantID, id, ok := tableSessionParams(c)
+	if !ok {
+		return
+	}
+
+	check, err := h.sessionService.GetSession(c.Request.Context(), id, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, check)
+}
+
+// UpdateSession handles moving a check to another table or changing its guests
+// @Summary Update Table Session
+// @Description Move an open check to another table or change its guest count or notes
+// @Tags table-sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Table session ID"
+// @Param request body services.UpdateTableSessionRequest true "Table session"
+// @Success 200 {object} services.TableCheck
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/table-sessions/{id} [put]
+func (h *TableSessionHandler) UpdateSession(c *gin.Context) {
+	restaurantID, id, ok := tableSessionParams(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateTableSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	check, err := h.sessionService.UpdateSession(c.Request.Context(), id, restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, check)
+}
+
+// AddRound handles ordering a round for a seated party
+// @Summary Add Round
+// @Description Order a round of items for the party. The round is a dine-in order of the check and goes to the kitchen like any order
+// @Tags table-sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Table session ID"
+// @Param request body services.AddRoundRequest true "Round"
+// @Success 201 {object} models.Order
+// @Failure 400 {object} apperrors.Response
+// @Failure 402 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/table-sessions/{id}/rounds [post]
+func (h *TableSessionHandler) AddRound(c *gin.Context) {
+	restaurantID, id, ok := tableSessionParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.AddRoundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	order, err := h.sessionService.AddRound(c.Request.Context(), id, restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// CloseSession handles closing a check with its payment
+// @Summary Close Table Session
+// @Description Close a check with its payment. Every round must be ready, completed or cancelled; ready rounds are completed and the total is fixed
+// @Tags table-sessions
+// @Accept json
+// @Produce json
+// @Param id path int true "Table session ID"
+// @Param request body services.CloseTableSessionRequest true "Payment"
+// @Success 200 {object} services.TableCheck
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/table-sessions/{id}/close [post]
+func (h *TableSessionHandler) CloseSession(c *gin.Context) {
+	restaurantID, id, ok := tableSessionParams(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.CloseTableSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	check, err := h.sessionService.CloseSession(c.Request.Context(), id, restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, check)
+}
+
+// tableSessionParams returns the restaurant and table session ID of the request, reporting an error if either is invalid
+func tableSessionParams(c *gin.Context) (restaurantID, sessionID uint, ok bool) {
+	restaurantID, ok = ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return 0, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid table session ID"))
+		return 0, 0, false
+	}
+	return restaurantID, uint(id), true
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeEntryHandler handles time tracking and staff rate requests
+type TimeEntryHandler struct {
+	timeEntryService *services.TimeEntryService
+}
+
+// NewTimeEntryHandler creates a new TimeEntryHandler instance
+func NewTimeEntryHandler(timeEntryService *services.TimeEntryService) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		timeEntryService: timeEntryService,
+	}
+}
+
+// ClockIn handles clocking in the current user
+// @Summary Clock In
+// @Description Start a shift for the current user at their hourly rate
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param request body services.ClockRequest false "Notes"
+// @Success 201 {object} models.TimeEntry
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/time-entries/clock-in [post]
+func (h *TimeEntryHandler) ClockIn(c *gin.Context) {
+	restaurantID, userID, ok := staffParams(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional
+	var req services.ClockRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	entry, err := h.timeEntryService.ClockIn(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// ClockOut handles clocking out the current user
+// @Summary Clock Out
+// @Description End the current user's open shift
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param request body services.ClockRequest false "Notes"
+// @Success 200 {object} models.TimeEntry
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/time-entries/clock-out [post]
+func (h *TimeEntryHandler) ClockOut(c *gin.Context) {
+	restaurantID, userID, ok := staffParams(c)
+	if !ok {
+		return
+	}
+
+	// The body is optional
+	var req services.ClockRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	entry, err := h.timeEntryService.ClockOut(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// GetCurrentEntry handles retrieving the current user's open shift
+// @Summary Get Current Shift
+// @Description Get the current user's open shift
+// @Tags time-tracking
+// @Produce json
+// @Success 200 {object} models.TimeEntry
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/time-entries/current [get]
+func (h *TimeEntryHandler) GetCurrentEntry(c *gin.Context) {
+	restaurantID, userID, ok := staffParams(c)
+	if !ok {
+		return
+	}
+
+	entry, err := h.timeEntryService.GetCurrentEntry(c.Request.Context(), restaurantID, userID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// ListEntries handles listing time entries
+// @Summary List Time Entries
+// @Description List the shifts clocked in between two dates in the restaurant's time zone, the last 7 days by default. Staff only see their own shifts
+// @Tags time-tracking
+// @Produce json
+// @Param user_id query int false "Staff member (admins only)"
+// @Param from query string false "First date (YYYY-MM-DD)"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today"
+// @Success 200 {array} models.TimeEntry
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/time-entries [get]
+func (h *TimeEntryHandler) ListEntries(c *gin.Context) {
+	restaurantID, userID, ok := staffParams(c)
+	if !ok {
+		return
+	}
+
+	var filterUserID *uint
+	if role, _ := ctx.GetUserRole(c.Request.Context()); role != "Admin" {
+		filterUserID = &userID
+	} else if raw := c.Query("user_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
+			return
+		}
+		filterID := uint(id)
+		filterUserID = &filterID
+	}
+
+	entries, err := h.timeEntryService.ListEntries(c.Request.Context(), restaurantID, filterUserID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// CreateEntry handles recording a shift on behalf of a staff member
+// @Summary Create Time Entry
+// @Description Record a shift for a staff member, e.g. a forgotten clock-in. The rate defaults to the staff member's hourly rate
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param request body services.CreateTimeEntryRequest true "Time entry"
+// @Success 201 {object} models.TimeEntry
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
+// @Router /api/v1/time-entries [post]
+func (h *TimeEntryHandler) CreateEntry(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	var req services.CreateTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	entry, err := h.timeEntryService.CreateEntry(c.Request.Context(), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// UpdateEntry handles correcting a time entry
+// @Summary Update Time Entry
+// @Description Correct the clock-in, clock-out, hourly rate or notes of a shift
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param id path int true "Time entry ID"
+// @Param request body services.UpdateTimeEntryRequest true "Time entry"
+// @Success 200 {object} models.TimeEntry
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/time-entries/{id} [put]
+func (h *TimeEntryHandler) UpdateEntry(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid time entry ID"))
+		return
+	}
+
+	var req services.UpdateTimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	entry, err := h.timeEntryService.UpdateEntry(c.Request.Context(), uint(id), restaurantID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// DeleteEntry handles deleting a time entry
+// @Summary Delete Time Entry
+// @Description Delete a shift recorded by mistake
+// @Tags time-tracking
+// @Param id path int true "Time entry ID"
+// @Success 204
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/time-entries/{id} [delete]
+func (h *TimeEntryHandler) DeleteEntry(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid time entry ID"))
+		return
+	}
+
+	if err := h.timeEntryService.DeleteEntry(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListRates handles listing the staff's hourly rates
+// @Summary List Staff Rates
+// @Description List the hourly rates of the restaurant's staff, in cents
+// @Tags time-tracking
+// @Produce json
+// @Success 200 {array} models.StaffRate
+// @Router /api/v1/staff-rates [get]
+func (h *TimeEntryHandler) ListRates(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	rates, err := h.timeEntryService.ListRates(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+// SetRate handles setting a staff member's hourly rate
+// @Summary Set Staff Rate
+// @Description Set a staff member's hourly rate in cents. It applies to shifts clocked in from now on
+// @Tags time-tracking
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param request body services.SetStaffRateRequest true "Hourly rate"
+// @Success 200 {object} models.StaffRate
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/staff-rates/{user_id} [put]
+func (h *TimeEntryHandler) SetRate(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	userID, err := strconv.ParseUint(c.Param("user_id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
+		return
+	}
+
+	var req services.SetStaffRateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	rate, err := h.timeEntryService.SetRate(c.Request.Context(), restaurantID, uint(userID), &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rate)
+}
+
+// staffParams returns the restaurant and user of the request, reporting an error if either is missing
+func staffParams(c *gin.Context) (restaurantID, userID uint, ok bool) {
+	restaurantID, ok = ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return 0, 0, false
+	}
+	userID, ok = ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return 0, 0, false
+	}
+	return restaurantID, userID, true
+}
@@ -1,10 +1,10 @@
 package handlers
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/dto"
 	"restaurant-backend/internal/services"
@@ -30,19 +30,19 @@ func NewUserHandler(userService *services.UserService) *UserHandler {
 // @Tags users
 // @Produce json
 // @Success 200 {array} models.User
-// @Failure 500 {object} map[string]string
+// @Failure 500 {object} apperrors.Response
 // @Router /api/v1/users [get]
 func (h *UserHandler) ListUsers(c *gin.Context) {
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
 	users, err := h.userService.ListUsers(c.Request.Context(), restaurantID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -56,14 +56,14 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 // @Produce json
 // @Param id path int true "User ID"
 // @Success 200 {object} models.User
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/users/:id [get]
 func (h *UserHandler) GetUser(c *gin.Context) {
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -71,78 +71,40 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
 		return
 	}
 
 	user, err := h.userService.GetUser(c.Request.Context(), uint(id), restaurantID)
 	if err != nil {
-		if errors.Is(err, services.ErrUserNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
-// CreateUser handles creating a new user
-// @Summary Create User
-// @Description Create a new user in the restaurant
-// @Tags users
-// @Accept json
-// @Produce json
-// @Param request body dto.CreateUserDTO true "User creation data"
-// @Success 201 {object} models.User
-// @Failure 400 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Router /api/v1/users [post]
-func (h *UserHandler) CreateUser(c *gin.Context) {
-	// Get restaurant ID from context
-	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
-	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
-		return
-	}
-
-	var req dto.CreateUserDTO
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	user, err := h.userService.CreateUser(c.Request.Context(), &req, restaurantID)
-	if err != nil {
-		statusCode := http.StatusBadRequest
-		if errors.Is(err, services.ErrUserExists) {
-			statusCode = http.StatusConflict
-		}
-		c.JSON(statusCode, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusCreated, user)
-}
-
 // UpdateUser handles updating an existing user
 // @Summary Update User
-// @Description Update user information
+// @Description Update user information (Admin only; users cannot change their own role)
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param id path int true "User ID"
 // @Param request body dto.UpdateUserDTO true "User update data"
+// @Param If-Match header string false "ETag from a previous read; rejects the update with 409 if the resource changed since"
 // @Success 200 {object} models.User
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 403 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Failure 409 {object} apperrors.Response
 // @Router /api/v1/users/:id [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -150,26 +112,29 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
 		return
 	}
 
 	var req dto.UpdateUserDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		_ = c.Error(err)
 		return
 	}
 
-	user, err := h.userService.UpdateUser(c.Request.Context(), uint(id), &req, restaurantID)
+	user, err := h.userService.UpdateUser(c.Request.Context(), uint(id), &req, restaurantID, expectedVersion)
 	if err != nil {
-		if errors.Is(err, services.ErrUserNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -179,14 +144,14 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 // @Tags users
 // @Param id path int true "User ID"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/users/:id [delete]
 func (h *UserHandler) DeleteUser(c *gin.Context) {
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -194,16 +159,12 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
 		return
 	}
 
 	if err := h.userService.DeleteUser(c.Request.Context(), uint(id), restaurantID); err != nil {
-		if errors.Is(err, services.ErrUserNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
@@ -218,14 +179,14 @@ func (h *UserHandler) DeleteUser(c *gin.Context) {
 // @Param id path int true "User ID"
 // @Param request body dto.UpdateUserStatusDTO true "Status update data"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
 // @Router /api/v1/users/:id/status [patch]
 func (h *UserHandler) ToggleUserStatus(c *gin.Context) {
 	// Get restaurant ID from context
 	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "restaurant_id not found in context"})
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
 		return
 	}
 
@@ -233,22 +194,18 @@ func (h *UserHandler) ToggleUserStatus(c *gin.Context) {
 	idParam := c.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID"})
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid user ID"))
 		return
 	}
 
 	var req dto.UpdateUserStatusDTO
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		_ = c.Error(apperrors.Validation(err))
 		return
 	}
 
 	if err := h.userService.ToggleUserStatus(c.Request.Context(), uint(id), restaurantID, req.IsActive); err != nil {
-		if errors.Is(err, services.ErrUserNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		_ = c.Error(err)
 		return
 	}
 
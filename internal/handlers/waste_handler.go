@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"net/http"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WasteHandler handles waste log requests
+type WasteHandler struct {
+	wasteService *services.WasteService
+}
+
+// NewWasteHandler creates a new WasteHandler instance
+func NewWasteHandler(wasteService *services.WasteService) *WasteHandler {
+	return &WasteHandler{
+		wasteService: wasteService,
+	}
+}
+
+// LogWaste handles logging waste
+// @Summary Log Waste
+// @Description Log stock lost outside of sales: a quantity of an ingredient, or servings of a menu item deducted through its recipe. The stock is deducted (down to zero) and the entry is costed at current ingredient costs
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param request body services.WasteRequest true "Waste"
+// @Success 201 {object} models.WasteEntry
+// @Failure 400 {object} apperrors.Response
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/inventory/waste [post]
+func (h *WasteHandler) LogWaste(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+	userID, ok := ctx.GetUserID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrUserContextMissing)
+		return
+	}
+
+	var req services.WasteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	entry, err := h.wasteService.LogWaste(c.Request.Context(), restaurantID, userID, &req)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// ListWaste handles listing the waste log
+// @Summary List Waste
+// @Description List the waste logged between two dates in the restaurant's time zone, newest first. Defaults to the last 30 days
+// @Tags inventory
+// @Produce json
+// @Param reason query string false "Reason filter" Enums(spoiled, expired, damaged, overproduction, theft, other)
+// @Param from query string false "First date (YYYY-MM-DD)"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today"
+// @Param limit query int false "Page size" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} services.WasteList
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/inventory/waste [get]
+func (h *WasteHandler) ListWaste(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	reason := c.Query("reason")
+	switch reason {
+	case "", models.WasteReasonSpoiled, models.WasteReasonExpired, models.WasteReasonDamaged,
+		models.WasteReasonOverproduction, models.WasteReasonTheft, models.WasteReasonOther:
+	default:
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid reason"))
+		return
+	}
+
+	limit, offset, ok := pageParams(c)
+	if !ok {
+		return
+	}
+
+	entries, err := h.wasteService.ListWaste(c.Request.Context(), restaurantID, reason, c.Query("from"), c.Query("to"), limit, offset)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetWasteSummary handles totalling the waste log
+// @Summary Get Waste Summary
+// @Description Total the wasted quantity and cost per ingredient and reason between two dates in the restaurant's time zone, telling theft apart from spoilage and other waste. Defaults to the last 30 days
+// @Tags inventory
+// @Produce json
+// @Param from query string false "First date (YYYY-MM-DD)"
+// @Param to query string false "Last date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} services.WasteSummary
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/inventory/waste/summary [get]
+func (h *WasteHandler) GetWasteSummary(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	summary, err := h.wasteService.GetSummary(c.Request.Context(), restaurantID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler handles webhook endpoint management requests
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook handles webhook endpoint registration
+// @Summary Create Webhook
+// @Description Register a webhook endpoint to receive menu.updated events. The signing secret is only returned once.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param request body services.CreateWebhookRequest true "Webhook data"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} apperrors.Response
+// @Router /api/v1/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req services.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		_ = c.Error(apperrors.Validation(err))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	webhook, secret, err := h.webhookService.CreateWebhook(c.Request.Context(), &req, restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"webhook": webhook,
+		"secret":  secret,
+	})
+}
+
+// ListWebhooks handles listing webhook endpoints
+// @Summary List Webhooks
+// @Description List webhook endpoints for the restaurant
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} models.WebhookEndpoint
+// @Router /api/v1/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	webhooks, err := h.webhookService.ListWebhooks(c.Request.Context(), restaurantID)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
+
+// DeleteWebhook handles removing a webhook endpoint
+// @Summary Delete Webhook
+// @Description Remove a webhook endpoint
+// @Tags webhooks
+// @Param id path int true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 404 {object} apperrors.Response
+// @Router /api/v1/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		_ = c.Error(apperrors.BadRequest(apperrors.CodeBadRequest, "invalid webhook ID"))
+		return
+	}
+
+	restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+	if !ok {
+		_ = c.Error(apperrors.ErrRestaurantContextMissing)
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), uint(id), restaurantID); err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
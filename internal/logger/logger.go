@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	tenantctx "restaurant-backend/internal/ctx"
+
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -33,16 +36,32 @@ func Initialize(environment string) error {
 	return nil
 }
 
+// WithContext returns the logger annotated with the request ID, trace, route and tenant of ctx
 func WithContext(ctx context.Context) *zap.Logger {
+	if Logger == nil {
+		return zap.NewNop()
+	}
 	fields := []zap.Field{}
-	if rid := ctx.Value("request_id"); rid != nil {
-		if id, ok := rid.(string); ok {
-			fields = append(fields, zap.String("request_id", id))
-		}
+	if id, ok := tenantctx.GetRequestID(ctx); ok {
+		fields = append(fields, zap.String("request_id", id))
 	}
-	if uid := ctx.Value("user_id"); uid != nil {
-		if id, ok := uid.(uint); ok {
-			fields = append(fields, zap.Uint("user_id", id))
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanCtx.TraceID().String()),
+			zap.String("span_id", spanCtx.SpanID().String()),
+		)
+	}
+	if route, ok := tenantctx.GetRoute(ctx); ok {
+		fields = append(fields, zap.String("route", route))
+	}
+	if tenant, ok := tenantctx.GetTenant(ctx); ok {
+		fields = append(fields,
+			zap.Uint("restaurant_id", tenant.RestaurantID),
+			zap.Uint("user_id", tenant.UserID),
+			zap.String("role", tenant.Role),
+		)
+		if tenant.ImpersonatorID != 0 {
+			fields = append(fields, zap.Uint("impersonator_id", tenant.ImpersonatorID))
 		}
 	}
 	return Logger.With(fields...)
@@ -72,8 +91,14 @@ func Debug(msg string, fields ...zap.Field) {
 	}
 }
 
-func LogRequest(method, path string, status int, duration time.Duration) {
-	Info("request",
+// LogRequest logs a served request; requests without a tenant are logged under a separate message
+// so the sampler of the production config (per message) thins them independently of tenant traffic
+func LogRequest(ctx context.Context, method, path string, status int, duration time.Duration) {
+	msg := "request"
+	if _, ok := tenantctx.GetTenant(ctx); !ok {
+		msg = "public request"
+	}
+	WithContext(ctx).Info(msg,
 		zap.String("method", method),
 		zap.String("path", path),
 		zap.Int("status", status),
@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Queue names for in-process background work
+const (
+	QueueEmailOutbox = "email_outbox"
+)
+
+// Realtime channel names
+const (
+	ChannelDisplayBoard  = "display_board"
+	ChannelDashboard     = "dashboard"
+	ChannelNotifications = "notifications"
+)
+
+var (
+	// Capacity metrics
+	RealtimeConnections = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "realtime_connections",
+			Help: "Number of open streaming connections",
+		},
+		[]string{"channel", "tier"},
+	)
+
+	BackgroundJobsRunning = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "background_jobs_running",
+			Help: "Number of scheduled background jobs currently running",
+		},
+		[]string{"job"},
+	)
+
+	BackgroundJobDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "background_job_duration_seconds",
+			Help:    "Scheduled background job run duration in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"job"},
+	)
+
+	BackgroundJobLastRun = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "background_job_last_run_timestamp_seconds",
+			Help: "Unix time the scheduled background job last finished",
+		},
+		[]string{"job"},
+	)
+
+	queues = newQueueCollector()
+)
+
+func init() {
+	// Replace the default Go collector with one that also exports GC pause,
+	// heap and scheduler latency histograms from runtime/metrics
+	prometheus.Unregister(collectors.NewGoCollector())
+	prometheus.MustRegister(collectors.NewGoCollector(
+		collectors.WithGoCollectorRuntimeMetrics(
+			collectors.MetricsGC,
+			collectors.MetricsMemory,
+			collectors.MetricsScheduler,
+		),
+	))
+	prometheus.MustRegister(queues)
+}
+
+// RegisterDBStats exports connection pool saturation (in use, idle, wait count) of a database
+func RegisterDBStats(db *sql.DB, name string) {
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, name))
+}
+
+// TrackRealtimeConnection records an open streaming connection
+// The returned func must be called when the connection closes
+func TrackRealtimeConnection(channel, tier string) func() {
+	gauge := RealtimeConnections.WithLabelValues(channel, tier)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// TrackBackgroundJob records a scheduled background job run
+// The returned func must be called when the run finishes
+func TrackBackgroundJob(job string) func() {
+	start := time.Now()
+	BackgroundJobsRunning.WithLabelValues(job).Inc()
+	return func() {
+		BackgroundJobsRunning.WithLabelValues(job).Dec()
+		BackgroundJobDuration.WithLabelValues(job).Observe(time.Since(start).Seconds())
+		BackgroundJobLastRun.WithLabelValues(job).SetToCurrentTime()
+	}
+}
+
+// Enqueue records pending work on an in-process queue
+// The returned func must be called once the work is done, successful or not
+func Enqueue(queue string) func() {
+	return queues.enqueue(queue)
+}
+
+// queueCollector reports the depth and the age of the oldest pending item of each queue
+// Ages are computed at scrape time so a stuck item keeps growing between events
+type queueCollector struct {
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[string]map[uint64]time.Time
+
+	depthDesc *prometheus.Desc
+	ageDesc   *prometheus.Desc
+}
+
+func newQueueCollector() *queueCollector {
+	return &queueCollector{
+		pending: make(map[string]map[uint64]time.Time),
+		depthDesc: prometheus.NewDesc(
+			"job_queue_depth",
+			"Number of pending items in an in-process queue",
+			[]string{"queue"}, nil,
+		),
+		ageDesc: prometheus.NewDesc(
+			"job_queue_oldest_age_seconds",
+			"Age of the oldest pending item in an in-process queue",
+			[]string{"queue"}, nil,
+		),
+	}
+}
+
+func (q *queueCollector) enqueue(queue string) func() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.pending[queue] == nil {
+		q.pending[queue] = make(map[uint64]time.Time)
+	}
+	q.nextID++
+	id := q.nextID
+	q.pending[queue][id] = time.Now()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			q.mu.Lock()
+			defer q.mu.Unlock()
+			delete(q.pending[queue], id)
+		})
+	}
+}
+
+// Describe implements prometheus.Collector
+func (q *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- q.depthDesc
+	ch <- q.ageDesc
+}
+
+// Collect implements prometheus.Collector
+func (q *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	for queue, items := range q.pending {
+		var oldest time.Duration
+		for _, enqueuedAt := range items {
+			if age := now.Sub(enqueuedAt); age > oldest {
+				oldest = age
+			}
+		}
+		ch <- prometheus.MustNewConstMetric(q.depthDesc, prometheus.GaugeValue, float64(len(items)), queue)
+		ch <- prometheus.MustNewConstMetric(q.ageDesc, prometheus.GaugeValue, oldest.Seconds(), queue)
+	}
+}
@@ -115,6 +115,12 @@ func IncrementHTTPRequest(method, path, status string) {
 	HTTPRequestsTotal.WithLabelValues(method, path, status).Inc()
 }
 
+// RecordHTTPRequest records an HTTP request and its duration
+func RecordHTTPRequest(method, path, status string, duration float64) {
+	IncrementHTTPRequest(method, path, status)
+	HTTPRequestDuration.WithLabelValues(method, path).Observe(duration)
+}
+
 // RecordDBQuery records a database query
 func RecordDBQuery(operation, table string, duration float64) {
 	DBQueriesTotal.WithLabelValues(operation, table).Inc()
@@ -1,39 +1,36 @@
 package middleware
 
 import (
-	"context"
-	"net/http"
 	"slices"
 	"strings"
 
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-const (
-	UserIDKey       = "user_id"
-	RestaurantIDKey = "restaurant_id"
-	UserRoleKey     = "role"
-	UserEmailKey    = "email"
-)
-
 // RequireAuth validates JWT token and extracts user context
 func RequireAuth(authService *services.AuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Extract token from Authorization header
 		authHeader := c.GetHeader("Authorization")
+		if authHeader == "" && c.IsWebsocket() {
+			// Browsers can't set headers on WebSocket handshakes, so those may pass the token as a parameter
+			if token := c.Query("access_token"); token != "" {
+				authHeader = "Bearer " + token
+			}
+		}
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "authorization header required"})
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized(apperrors.CodeUnauthorized, "authorization header required"))
 			return
 		}
 
 		// Parse Bearer token
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization header format"})
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized(apperrors.CodeUnauthorized, "invalid authorization header format"))
 			return
 		}
 
@@ -42,25 +39,28 @@ func RequireAuth(authService *services.AuthService) gin.HandlerFunc {
 		// Validate token
 		claims, err := authService.ValidateToken(tokenString)
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid or expired token"))
+			return
+		}
+		if err := authService.ValidateSession(c.Request.Context(), claims, c.ClientIP()); err != nil {
+			abortWithError(c, err)
 			return
 		}
 
-		// Store user context in Gin context
-		c.Set(UserIDKey, claims.UserID)
-		c.Set(RestaurantIDKey, claims.RestaurantID)
-		c.Set(UserRoleKey, claims.Role)
-		c.Set(UserEmailKey, claims.Email)
-
-		// Also store values in the request context so services/repositories
-		// that don't depend on Gin can retrieve them from context.Context.
-		reqCtx := c.Request.Context()
-		reqCtx = context.WithValue(reqCtx, UserIDKey, claims.UserID)
-		reqCtx = context.WithValue(reqCtx, RestaurantIDKey, claims.RestaurantID)
-		reqCtx = context.WithValue(reqCtx, UserRoleKey, claims.Role)
-		reqCtx = context.WithValue(reqCtx, UserEmailKey, claims.Email)
-		c.Request = c.Request.WithContext(reqCtx)
+		// Store the tenant in the request context; handlers, services and the
+		// database layer (RLS session settings) all read it from there
+		tenant := ctx.Tenant{
+			UserID:         claims.UserID,
+			RestaurantID:   claims.RestaurantID,
+			Role:           claims.Role,
+			Email:          claims.Email,
+			OrganizationID: claims.OrganizationID,
+			SessionID:      claims.ID,
+		}
+		if claims.Impersonation != nil {
+			tenant.ImpersonatorID = claims.Impersonation.KAMUserID
+		}
+		c.Request = c.Request.WithContext(ctx.WithTenant(c.Request.Context(), tenant))
 
 		c.Next()
 	}
@@ -69,19 +69,29 @@ func RequireAuth(authService *services.AuthService) gin.HandlerFunc {
 // RequireRole checks if the authenticated user has the required role
 func RequireRole(roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRole, exists := c.Get(UserRoleKey)
+		role, exists := ctx.GetUserRole(c.Request.Context())
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "user role not found in context"})
-			c.Abort()
+			abortWithError(c, apperrors.Unauthorized(apperrors.CodeUnauthorized, "user role not found in context"))
 			return
 		}
 
-		role := userRole.(string)
 		hasRole := slices.Contains(roles, role)
 
 		if !hasRole {
-			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
-			c.Abort()
+			abortWithError(c, apperrors.ErrInsufficientPermissions)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission checks the authenticated user holds a capability: Admins always do, Staff by their role's
+// default unless an Admin granted or revoked it for them
+func RequirePermission(permissionService *services.PermissionService, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := permissionService.Require(c.Request.Context(), permission); err != nil {
+			abortWithError(c, err)
 			return
 		}
 
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangelogPath is the public endpoint describing API changes and deprecations
+const ChangelogPath = "/api/v1/changelog"
+
+// DeprecationHeaders flags responses from deprecated endpoints (per the API changelog)
+// with Deprecation, Sunset and Link headers so integrators get advance warning
+func DeprecationHeaders(changelogService *services.APIChangelogService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		if entry, ok := changelogService.LookupDeprecation(c.Request.Context(), c.Request.Method, route); ok {
+			for name, value := range services.DeprecationHeaders(entry, ChangelogPath) {
+				c.Header(name, value)
+			}
+		}
+
+		c.Next()
+	}
+}
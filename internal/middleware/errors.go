@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// ErrorHandler renders errors attached with c.Error as the standard JSON error envelope
+// Errors without an API code are treated as internal errors; their details are logged, not returned
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		appErr := apperrors.From(c.Errors.Last().Err)
+		if appErr.Status >= 500 {
+			logger.WithContext(c.Request.Context()).Error("Request failed",
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("code", string(appErr.Code)),
+				zap.Error(c.Errors.Last().Err),
+			)
+		}
+
+		// The request ID lets clients and support match an error to its logs and trace
+		response := appErr.Response()
+		response.RequestID, _ = ctx.GetRequestID(c.Request.Context())
+		c.AbortWithStatusJSON(appErr.Status, response)
+	}
+}
+
+// abortWithError attaches err to the context and stops the handler chain
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
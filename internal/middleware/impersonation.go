@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AuditImpersonation records every request made with a KAM impersonation token in the audit log
+// Must run after RequireAuth; requests made with regular tokens pass through untouched
+func AuditImpersonation(impersonationService *services.ImpersonationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		kamUserID, impersonating := ctx.GetImpersonatorID(c.Request.Context())
+		if !impersonating {
+			c.Next()
+			return
+		}
+
+		c.Next()
+
+		// Errors are rendered by ErrorHandler after this middleware returns
+		status := c.Writer.Status()
+		if len(c.Errors) > 0 && !c.Writer.Written() {
+			status = apperrors.From(c.Errors.Last().Err).Status
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+
+		restaurantID, _ := ctx.GetRestaurantID(c.Request.Context())
+		// Record even if the client went away before the response was written
+		auditCtx := context.WithoutCancel(c.Request.Context())
+		if err := impersonationService.RecordImpersonatedRequest(auditCtx, kamUserID, restaurantID, services.ImpersonatedRequest{
+			Method:    c.Request.Method,
+			Route:     route,
+			Status:    status,
+			IPAddress: c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+		}); err != nil {
+			logger.Error("Failed to audit impersonated request",
+				zap.Uint("kam_user_id", kamUserID),
+				zap.Uint("restaurant_id", restaurantID),
+				zap.String("method", c.Request.Method),
+				zap.String("route", route),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// DenyImpersonation rejects requests made with an impersonation token
+// Used for account-level actions a KAM must not take on the restaurant's behalf
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, impersonating := ctx.GetImpersonatorID(c.Request.Context()); impersonating {
+			abortWithError(c, apperrors.Forbidden(apperrors.CodeInsufficientScope, "not allowed while impersonating a restaurant"))
+			return
+		}
+		c.Next()
+	}
+}
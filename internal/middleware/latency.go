@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// LatencyBudget configures per-route-group request timeouts and slow-request logging
+type LatencyBudget struct {
+	Default       time.Duration            // Timeout for routes without an override (0 disables)
+	Routes        map[string]time.Duration // Overrides keyed by route prefix; longest prefix wins, 0 disables
+	SlowThreshold time.Duration            // Requests slower than this are logged (0 disables)
+}
+
+// timeoutFor returns the timeout for a route pattern
+func (b LatencyBudget) timeoutFor(route string) time.Duration {
+	timeout := b.Default
+	longest := -1
+	for prefix, d := range b.Routes {
+		if strings.HasPrefix(route, prefix) && len(prefix) > longest {
+			timeout = d
+			longest = len(prefix)
+		}
+	}
+	return timeout
+}
+
+// EnforceLatencyBudget sets a context deadline on each request so that hung downstream calls
+// (DB, Brevo, S3) fail fast with 504 instead of piling up goroutines, and logs slow requests
+// Must run after ErrorHandler so the timeout error is rendered
+func EnforceLatencyBudget(budget LatencyBudget) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		timeout := budget.timeoutFor(route)
+		start := time.Now()
+
+		if timeout > 0 {
+			reqCtx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+			defer cancel()
+			c.Request = c.Request.WithContext(reqCtx)
+		}
+
+		c.Next()
+
+		// Whatever error the handler surfaced (e.g. "not found" after a cancelled query),
+		// an exceeded deadline is reported as a timeout
+		if errors.Is(c.Request.Context().Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.Errors = c.Errors[:0]
+			_ = c.Error(apperrors.ErrRequestTimeout)
+		}
+
+		elapsed := time.Since(start)
+		if budget.SlowThreshold > 0 && elapsed > budget.SlowThreshold {
+			logger.WithContext(c.Request.Context()).Warn("Slow request",
+				zap.String("method", c.Request.Method),
+				zap.String("route", route),
+				zap.Int("status", c.Writer.Status()),
+				zap.Duration("duration", elapsed),
+				zap.Duration("timeout", timeout),
+			)
+		}
+	}
+}
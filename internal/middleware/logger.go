@@ -1,23 +1,37 @@
 package middleware
 
 import (
+	"math/rand"
+	"net/url"
 	"time"
 
+	"restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/logger"
 
 	"github.com/gin-gonic/gin"
 )
 
 // RequestLogger returns a middleware that logs HTTP requests using the application logger
-func RequestLogger() gin.HandlerFunc {
+// Successful requests without a tenant (public menus, probes) are kept at publicSampleRate (0-1);
+// failed requests and every tenant request are always logged
+func RequestLogger(publicSampleRate float64) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
 		raw := c.Request.URL.RawQuery
 
+		// The route template is known once routing matched; later log lines of the request carry it
+		if route := c.FullPath(); route != "" {
+			c.Request = c.Request.WithContext(ctx.WithRoute(c.Request.Context(), route))
+		}
+
 		// Process request
 		c.Next()
 
+		if !shouldLogRequest(c, publicSampleRate) {
+			return
+		}
+
 		// Calculate metrics
 		param := gin.LogFormatterParams{
 			Request: c.Request,
@@ -26,13 +40,14 @@ func RequestLogger() gin.HandlerFunc {
 
 		// Update logged path with query params if present
 		if raw != "" {
-			path = path + "?" + raw
+			path = path + "?" + redactQuery(raw)
 		}
 
 		// Log using our zap logger wrapper
 		// StatusCode check to determine log level could be improved here if needed
 		// For now we use the standardized LogRequest function
 		logger.LogRequest(
+			c.Request.Context(),
 			param.Request.Method,
 			path,
 			c.Writer.Status(),
@@ -40,3 +55,23 @@ func RequestLogger() gin.HandlerFunc {
 		)
 	}
 }
+
+// shouldLogRequest applies publicSampleRate to successful requests that had no tenant
+// c.Request is read after the handlers ran, so it carries the tenant set by RequireAuth
+func shouldLogRequest(c *gin.Context, publicSampleRate float64) bool {
+	if _, ok := ctx.GetTenant(c.Request.Context()); ok || c.Writer.Status() >= 400 {
+		return true
+	}
+	return publicSampleRate >= 1 || rand.Float64() < publicSampleRate
+}
+
+// redactQuery hides the access token WebSocket handshakes may carry in the query string
+func redactQuery(raw string) string {
+	// Malformed pairs are skipped, the rest are still parsed
+	query, _ := url.ParseQuery(raw)
+	if !query.Has("access_token") {
+		return raw
+	}
+	query.Set("access_token", "REDACTED")
+	return query.Encode()
+}
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests that matched no route, so unknown paths can't create unbounded series
+const unmatchedRoute = "unmatched"
+
+// Metrics records http_requests_total and http_request_duration_seconds per route template,
+// and errors_total per API error code
+// Must run before ErrorHandler so the status it renders is the one recorded
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		metrics.RecordHTTPRequest(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), time.Since(start).Seconds())
+
+		if len(c.Errors) > 0 {
+			metrics.IncrementError(string(apperrors.From(c.Errors.Last().Err).Code), route)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnforcePlanLimit rejects creating a resource once the restaurant's plan limit is reached
+// Returns 402 PLAN_LIMIT_REACHED at the limit and 403 SUBSCRIPTION_INACTIVE for lapsed subscriptions
+func EnforcePlanLimit(subscriptionService *services.SubscriptionService, resource services.PlanResource) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		restaurantID, ok := ctx.GetRestaurantID(c.Request.Context())
+		if !ok {
+			abortWithError(c, apperrors.ErrRestaurantContextMissing)
+			return
+		}
+
+		if err := subscriptionService.CheckLimit(c.Request.Context(), restaurantID, resource); err != nil {
+			abortWithError(c, err)
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"restaurant-backend/internal/ctx"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadFromReplica lets the route's reads be served by the database read replicas (DB_REPLICA_URLS)
+// Only for read-only routes that tolerate replication lag, e.g. reports, exports and public menus; it goes after
+// authentication so session checks still read the primary
+func ReadFromReplica() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(ctx.WithReadReplica(c.Request.Context()))
+		c.Next()
+	}
+}
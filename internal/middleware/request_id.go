@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"restaurant-backend/internal/ctx"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the request ID in requests (from proxies and clients) and responses
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied IDs, which end up in logs and traces
+const maxRequestIDLength = 128
+
+// RequestID gives every request an ID: the caller's X-Request-ID when valid, otherwise a new UUID
+// The ID is echoed in the response header, stored on the request context for logs and error
+// responses, and recorded on the request's trace span
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
+
+		c.Request = c.Request.WithContext(ctx.WithRequestID(c.Request.Context(), requestID))
+		c.Writer.Header().Set(RequestIDHeader, requestID)
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("http.request_id", requestID))
+
+		c.Next()
+	}
+}
+
+// validRequestID accepts non-empty IDs of printable ASCII up to maxRequestIDLength
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(requestID); i++ {
+		if requestID[i] < 0x21 || requestID[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+)
+
+// API changelog change types
+const (
+	APIChangeAdded      = "added"
+	APIChangeChanged    = "changed"
+	APIChangeDeprecated = "deprecated"
+	APIChangeRemoved    = "removed"
+)
+
+// APIChangelogEntry is a platform-published change to the public API
+// Platform-wide table: not tenant-scoped, so no RLS
+type APIChangelogEntry struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	Version     string     `gorm:"type:varchar(20);index;not null" json:"version"` // API release, e.g. "2025-06-01"
+	ChangeType  string     `gorm:"type:varchar(20);not null" json:"change_type"`   // added, changed, deprecated, removed
+	Title       string     `gorm:"not null" json:"title"`
+	Description string     `json:"description"`
+	Method      string     `gorm:"type:varchar(10)" json:"method,omitempty"` // Affected endpoint (optional), e.g. GET
+	Path        string     `json:"path,omitempty"`                           // Route pattern, e.g. /api/v1/orders/:id
+	Replacement string     `json:"replacement,omitempty"`                    // Suggested replacement endpoint, if any
+	SunsetAt    *time.Time `json:"sunset_at,omitempty"`                      // When a deprecated endpoint stops working
+	PublishedAt time.Time  `gorm:"index;not null" json:"published_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for APIChangelogEntry
+func (APIChangelogEntry) TableName() string {
+	return "api_changelog_entries"
+}
+
+// IsDeprecation reports whether the entry flags an endpoint as deprecated
+func (e *APIChangelogEntry) IsDeprecation() bool {
+	return e.ChangeType == APIChangeDeprecated && e.Method != "" && e.Path != ""
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// ArchivedOrder is a finished order moved out of orders by the data archive job
+// The columns lists, filters and the customer aggregates use are copied; Data holds the rest of the order as it
+// was, with its items, status history, splits, delivery assignments and course firings
+type ArchivedOrder struct {
+	ID           uint            `gorm:"primaryKey;autoIncrement:false" json:"id"`                                                 // The order's original ID
+	RestaurantID uint            `gorm:"not null;index:idx_archived_orders_restaurant_created_at,priority:1" json:"restaurant_id"` // Crucial for RLS
+	UserID       uint            `gorm:"index;not null" json:"user_id"`
+	Status       string          `gorm:"type:varchar(20);not null" json:"status"`
+	TotalAmount  money.Cents     `gorm:"type:numeric(12,2);not null" json:"total_amount"`
+	CreatedAt    time.Time       `gorm:"not null;index:idx_archived_orders_restaurant_created_at,priority:2" json:"created_at"`
+	ArchivedAt   time.Time       `gorm:"not null" json:"archived_at"`
+	Data         json.RawMessage `gorm:"type:jsonb;not null" json:"data"`
+}
+
+// TableName specifies the table name for ArchivedOrder
+func (ArchivedOrder) TableName() string {
+	return "archived_orders"
+}
+
+// ArchivedReservation is a past reservation moved out of reservations by the data archive job
+// Data holds the reservation as it was
+type ArchivedReservation struct {
+	ID           uint            `gorm:"primaryKey;autoIncrement:false" json:"id"`                                                       // The reservation's original ID
+	RestaurantID uint            `gorm:"not null;index:idx_archived_reservations_restaurant_start_time,priority:1" json:"restaurant_id"` // Crucial for RLS
+	UserID       uint            `gorm:"index;not null" json:"user_id"`
+	Status       string          `gorm:"type:varchar(20);not null" json:"status"`
+	StartTime    time.Time       `gorm:"not null;index:idx_archived_reservations_restaurant_start_time,priority:2" json:"start_time"`
+	CreatedAt    time.Time       `gorm:"not null" json:"created_at"`
+	ArchivedAt   time.Time       `gorm:"not null" json:"archived_at"`
+	Data         json.RawMessage `gorm:"type:jsonb;not null" json:"data"`
+}
+
+// TableName specifies the table name for ArchivedReservation
+func (ArchivedReservation) TableName() string {
+	return "archived_reservations"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit log actions
+const (
+	AuditActionPlatformSearch           = "platform.search"
+	AuditActionCategoryBulkAvailability = "category.bulk_availability"
+	AuditActionMenuItemBulkAvailability = "menu_item.bulk_availability"
+	AuditActionImpersonationStart       = "impersonation.start"
+	AuditActionImpersonatedRequest      = "impersonation.request"
+	AuditActionPrivacyExport            = "privacy.export"
+	AuditActionPrivacyErase             = "privacy.erase"
+	AuditActionSigningKeyRotate         = "auth.signing_key_rotate"
+	AuditActionPermissionChange         = "user.permission_change"
+)
+
+// AuditLog records sensitive actions (e.g., cross-tenant lookups by KAMs, bulk menu changes)
+// Platform-wide table: RestaurantID is optional, so no RLS
+type AuditLog struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID *uint     `gorm:"index" json:"restaurant_id,omitempty"` // Tenant the action applied to, if any
+	ActorUserID  uint      `gorm:"index;not null" json:"actor_user_id"`
+	ActorRole    string    `gorm:"type:varchar(20);not null" json:"actor_role"`
+	Action       string    `gorm:"type:varchar(50);index;not null" json:"action"`
+	Details      string    `gorm:"type:jsonb;default:'{}'" json:"details"` // JSON string with action-specific data
+	IPAddress    string    `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent    string    `json:"user_agent"`
+	CreatedAt    time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the table name for AuditLog
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// Usage metrics metered per restaurant and month
+const (
+	UsageMetricOrdersProcessed = "orders_processed" // Non-cancelled orders created in the period
+	UsageMetricEmailsSent      = "emails_sent"      // Transactional emails delivered through Brevo
+	UsageMetricStorageBytes    = "storage_bytes"    // Object storage footprint when the invoice was generated
+	UsageMetricEmailsFailed    = "emails_failed"    // Transactional emails Brevo rejected (not billed, feeds the health score)
+)
+
+// UsageRecord is a restaurant's metered usage of one metric in a billing period
+// Platform-wide table: billing data managed by KAMs, not tenant-scoped, so no RLS
+type UsageRecord struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"not null;uniqueIndex:idx_usage_records_period_metric" json:"restaurant_id"`
+	PeriodStart  time.Time `gorm:"type:date;not null;uniqueIndex:idx_usage_records_period_metric" json:"period_start"` // First day of the month (UTC)
+	Metric       string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_usage_records_period_metric" json:"metric"`
+	Quantity     int64     `gorm:"not null;default:0" json:"quantity"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for UsageRecord
+func (UsageRecord) TableName() string {
+	return "usage_records"
+}
+
+// InvoiceStatus represents the lifecycle of an invoice
+type InvoiceStatus string
+
+const (
+	InvoiceStatusDraft  InvoiceStatus = "draft"  // Regenerated from usage until issued
+	InvoiceStatusIssued InvoiceStatus = "issued" // Final; never regenerated
+)
+
+// Invoice is a restaurant's bill for one month of platform usage
+// Platform-wide table: billing data managed by KAMs, not tenant-scoped, so no RLS
+type Invoice struct {
+	ID           uint          `gorm:"primaryKey" json:"id"`
+	RestaurantID uint          `gorm:"not null;uniqueIndex:idx_invoices_restaurant_period" json:"restaurant_id"`
+	PeriodStart  time.Time     `gorm:"type:date;not null;uniqueIndex:idx_invoices_restaurant_period" json:"period_start"`
+	PeriodEnd    time.Time     `gorm:"type:date;not null" json:"period_end"` // Exclusive
+	Status       InvoiceStatus `gorm:"type:varchar(20);default:'draft';not null;index" json:"status"`
+	Plan         PlanCode      `gorm:"type:varchar(20);not null" json:"plan"`
+	Currency     string        `gorm:"type:varchar(3);default:'USD';not null" json:"currency"`
+	Total        money.Cents   `gorm:"type:numeric(12,2);not null" json:"total"`
+	IssuedAt     *time.Time    `json:"issued_at,omitempty"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+
+	// Relationships
+	Restaurant *Restaurant   `gorm:"foreignKey:RestaurantID" json:"restaurant,omitempty"`
+	Lines      []InvoiceLine `gorm:"foreignKey:InvoiceID;constraint:OnDelete:CASCADE" json:"lines"`
+}
+
+// TableName specifies the table name for Invoice
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+// InvoiceLine is a single charge on an invoice
+type InvoiceLine struct {
+	ID          uint        `gorm:"primaryKey" json:"id"`
+	InvoiceID   uint        `gorm:"index;not null" json:"invoice_id"`
+	Description string      `gorm:"not null" json:"description"`
+	Metric      string      `gorm:"type:varchar(50)" json:"metric,omitempty"` // Empty for the plan subscription fee
+	Quantity    int64       `gorm:"not null" json:"quantity"`
+	UnitPrice   money.Cents `gorm:"type:numeric(12,2);not null" json:"unit_price"` // Price per Unit
+	Unit        int64       `gorm:"not null;default:1" json:"unit"`                // Quantity covered by one unit price, e.g. 1000 emails
+	Amount      money.Cents `gorm:"type:numeric(12,2);not null" json:"amount"`
+}
+
+// TableName specifies the table name for InvoiceLine
+func (InvoiceLine) TableName() string {
+	return "invoice_lines"
+}
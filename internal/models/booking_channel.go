@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// External booking channels a restaurant can connect
+const (
+	BookingChannelGoogleReserve = "google_reserve"
+)
+
+// ReservationSourceDirect is the Source of reservations made with the restaurant itself rather than a booking channel
+const ReservationSourceDirect = "direct"
+
+// BookingChannel connects a restaurant to an external reservation channel
+// The channel queries availability and writes bookings through the partner API with the token hashed in TokenHash; bookings become
+// reservations with a matching Source, and their cancellations here are pushed back to the channel
+type BookingChannel struct {
+	ID                 uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID       uint      `gorm:"not null;uniqueIndex:idx_booking_channels_provider" json:"restaurant_id"` // Crucial for RLS
+	Provider           string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_booking_channels_provider" json:"provider"`
+	ExternalMerchantID string    `gorm:"type:varchar(100);not null" json:"external_merchant_id"` // The restaurant's merchant ID on the channel
+	TokenHash          string    `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"`         // HMAC of the token authenticating the channel's calls to the partner API
+	AccessToken        string    `gorm:"type:text;not null;serializer:pii" json:"-"`             // Authenticates our calls to the channel; encrypted with the PII key
+	Enabled            bool      `gorm:"default:true;not null" json:"enabled"`
+	ChannelUserID      uint      `gorm:"not null" json:"channel_user_id"` // Client account channel bookings are attributed to
+	LastError          string    `json:"last_error,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"-"`
+}
+
+// TableName specifies the table name for BookingChannel
+func (BookingChannel) TableName() string {
+	return "booking_channels"
+}
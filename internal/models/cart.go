@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+)
+
+// CartItem is a line of a cart; items are priced when the cart is shown and when it is ordered
+type CartItem struct {
+	MenuItemID uint   `json:"menu_item_id"`
+	Quantity   int    `json:"quantity"`
+	Notes      string `json:"notes,omitempty"`
+}
+
+// Cart is a shopping cart of the public ordering flow, kept server-side so it follows the shopper across devices
+// Anonymous carts are reached by their token; a customer's cart also by the customer's login
+type Cart struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID    uint       `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Token           string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"token"`
+	UserID          *uint      `gorm:"index" json:"user_id,omitempty"`           // Nil for anonymous carts
+	Email           string     `gorm:"type:varchar(255)" json:"email,omitempty"` // Recovery email address left by an anonymous shopper
+	Items           []CartItem `gorm:"type:jsonb;serializer:json;not null;default:'[]'" json:"items"`
+	FulfillmentType string     `gorm:"type:varchar(20)" json:"fulfillment_type,omitempty"`
+	ExpiresAt       time.Time  `gorm:"not null;index" json:"expires_at"` // Extended on every change
+	OrderID         *uint      `json:"order_id,omitempty"`               // Set once the cart was ordered
+	RecoveryEmailAt *time.Time `json:"recovery_email_at,omitempty"`      // When the reminder of the abandoned cart was sent
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName specifies the table name for Cart
+func (Cart) TableName() string {
+	return "carts"
+}
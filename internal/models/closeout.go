@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// DailyCloseout is the end-of-day (Z) report of a restaurant's business day
+// Closeouts are immutable: a database trigger rejects updates and deletes, so closed numbers can't change
+// Amounts are in Currency; sales are tax-inclusive and the tax is derived from TaxRate
+type DailyCloseout struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"not null;uniqueIndex:idx_daily_closeouts_day" json:"restaurant_id"` // Crucial for RLS
+	BusinessDate time.Time `gorm:"type:date;not null;uniqueIndex:idx_daily_closeouts_day" json:"business_date"`
+	TimeZone     string    `gorm:"type:varchar(50);not null" json:"time_zone"` // Zone the business day was cut in
+	PeriodStart  time.Time `gorm:"not null" json:"period_start"`
+	PeriodEnd    time.Time `gorm:"not null" json:"period_end"` // Exclusive
+	Currency     string    `gorm:"type:varchar(3);not null" json:"currency"`
+	TaxRate      float64   `gorm:"type:numeric(5,2);not null" json:"tax_rate"`
+
+	OrderCount      int64 `gorm:"not null" json:"order_count"` // All orders placed in the period, including cancelled ones
+	CompletedOrders int64 `gorm:"not null" json:"completed_orders"`
+	OpenOrders      int64 `gorm:"not null" json:"open_orders"` // Neither completed nor cancelled at close
+	CancelledOrders int64 `gorm:"not null" json:"cancelled_orders"`
+
+	GrossSales money.Cents `gorm:"type:numeric(12,2);not null" json:"gross_sales"` // Non-cancelled orders
+	NetSales   money.Cents `gorm:"type:numeric(12,2);not null" json:"net_sales"`
+	Tax        money.Cents `gorm:"type:numeric(12,2);not null" json:"tax"`
+	Discount   money.Cents `gorm:"type:numeric(12,2);not null" json:"discount"`
+	Refund     money.Cents `gorm:"type:numeric(12,2);not null" json:"refund"`
+	Cancelled  money.Cents `gorm:"type:numeric(12,2);not null" json:"cancelled"` // Voided order value, not part of sales
+
+	ClosedByUserID uint      `gorm:"not null" json:"closed_by_user_id"`
+	ClosedAt       time.Time `gorm:"not null" json:"closed_at"`
+
+	// Relationships
+	Payments []DailyCloseoutPayment `gorm:"foreignKey:CloseoutID" json:"payments"`
+}
+
+// TableName specifies the table name for DailyCloseout
+func (DailyCloseout) TableName() string {
+	return "daily_closeouts"
+}
+
+// DailyCloseoutPayment is the sales of a closeout settled through one payment method
+// Method is the order source: in-house orders (internal) are settled at the restaurant,
+// delivery platform orders are paid on the platform
+type DailyCloseoutPayment struct {
+	ID           uint        `gorm:"primaryKey" json:"-"`
+	RestaurantID uint        `gorm:"index;not null" json:"-"` // Crucial for RLS
+	CloseoutID   uint        `gorm:"index;not null" json:"-"`
+	Method       string      `gorm:"type:varchar(20);not null" json:"method"`
+	OrderCount   int64       `gorm:"not null" json:"order_count"`
+	Amount       money.Cents `gorm:"type:numeric(12,2);not null" json:"amount"`
+}
+
+// TableName specifies the table name for DailyCloseoutPayment
+func (DailyCloseoutPayment) TableName() string {
+	return "daily_closeout_payments"
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// Customer is a guest of a restaurant, distinct from staff users
+// Customers are deduplicated per restaurant by email, phone and linked user account
+// Order and visit aggregates are recomputed from the linked user's orders and reservations
+// Email and phone are encrypted at rest; EmailHash and PhoneHash are deterministic hashes for exact lookups
+type Customer struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	UserID       *uint       `gorm:"index" json:"user_id,omitempty"`      // Client account used for online orders and bookings, if any
+	Name         string      `gorm:"type:varchar(255)" json:"name"`
+	Email        string      `gorm:"type:text;serializer:pii" json:"email,omitempty"`
+	Phone        string      `gorm:"type:text;serializer:pii" json:"phone,omitempty"`
+	EmailHash    string      `gorm:"type:varchar(64);index" json:"-"`
+	PhoneHash    string      `gorm:"type:varchar(64);index" json:"-"`
+	Notes        string      `gorm:"type:text" json:"notes,omitempty"` // Staff notes (allergies, preferences)
+	OrderCount   int         `gorm:"not null;default:0" json:"order_count"`
+	TotalSpent   money.Cents `gorm:"type:numeric(12,2);not null;default:0" json:"total_spent"` // Sum of non-cancelled orders
+	VisitCount   int         `gorm:"not null;default:0" json:"visit_count"`                    // Completed orders and reservations
+	LastVisitAt  *time.Time  `json:"last_visit_at,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for Customer
+func (Customer) TableName() string {
+	return "customers"
+}
+
+// BeforeSave refreshes the lookup hashes of the contact fields
+func (c *Customer) BeforeSave(tx *gorm.DB) error {
+	c.EmailHash = pii.HashEmail(c.Email)
+	c.PhoneHash = pii.HashPhone(c.Phone)
+	return nil
+}
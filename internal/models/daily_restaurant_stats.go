@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// DailyRestaurantStats is the rollup of the orders and reservations placed on one day of a restaurant
+// StatDate is a day in the restaurant's time zone. Rows are rebuilt by the analytics rollup jobs,
+// so they trail the raw tables by up to an hour; the current day is always read live
+type DailyRestaurantStats struct {
+	ID           uint      `gorm:"primaryKey" json:"-"`
+	RestaurantID uint      `gorm:"not null;uniqueIndex:idx_daily_restaurant_stats_day" json:"restaurant_id"` // Crucial for RLS
+	StatDate     time.Time `gorm:"type:date;not null;uniqueIndex:idx_daily_restaurant_stats_day" json:"stat_date"`
+
+	TotalOrders     int64       `gorm:"not null;default:0" json:"total_orders"`
+	PendingOrders   int64       `gorm:"not null;default:0" json:"pending_orders"`
+	CompletedOrders int64       `gorm:"not null;default:0" json:"completed_orders"`
+	CancelledOrders int64       `gorm:"not null;default:0" json:"cancelled_orders"`
+	TotalRevenue    money.Cents `gorm:"type:numeric(14,2);not null;default:0" json:"total_revenue"` // Completed orders
+
+	TotalReservations     int64 `gorm:"not null;default:0" json:"total_reservations"`
+	PendingReservations   int64 `gorm:"not null;default:0" json:"pending_reservations"`
+	ConfirmedReservations int64 `gorm:"not null;default:0" json:"confirmed_reservations"`
+	CompletedReservations int64 `gorm:"not null;default:0" json:"completed_reservations"`
+	CancelledReservations int64 `gorm:"not null;default:0" json:"cancelled_reservations"`
+
+	ComputedAt time.Time `gorm:"not null" json:"computed_at"`
+}
+
+// TableName specifies the table name for DailyRestaurantStats
+func (DailyRestaurantStats) TableName() string {
+	return "daily_restaurant_stats"
+}
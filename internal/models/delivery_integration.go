@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// Delivery platforms a restaurant can connect
+const (
+	DeliveryProviderUberEats  = "uber_eats"
+	DeliveryProviderDeliveroo = "deliveroo"
+)
+
+// DeliveryIntegration connects a restaurant to a third-party delivery platform
+// The menu is pushed to the platform and its orders are pulled in as orders with a matching Source
+type DeliveryIntegration struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID    uint       `gorm:"not null;uniqueIndex:idx_delivery_integrations_provider" json:"restaurant_id"` // Crucial for RLS
+	Provider        string     `gorm:"type:varchar(20);not null;uniqueIndex:idx_delivery_integrations_provider" json:"provider"`
+	ExternalStoreID string     `gorm:"type:varchar(100);not null" json:"external_store_id"`  // The restaurant's store/site ID on the platform
+	ExternalBrandID string     `gorm:"type:varchar(100)" json:"external_brand_id,omitempty"` // Deliveroo brand ID; unused by Uber Eats
+	AccessToken     string     `gorm:"type:text;not null;serializer:pii" json:"-"`           // Encrypted with the PII key, never returned
+	Enabled         bool       `gorm:"default:true;not null" json:"enabled"`
+	ChannelUserID   uint       `gorm:"not null" json:"channel_user_id"` // Client account imported orders are attributed to
+	LastMenuSyncAt  *time.Time `json:"last_menu_sync_at,omitempty"`
+	MenuHash        string     `gorm:"type:varchar(64)" json:"-"` // Hash of the last pushed menu; the menu is pushed again when it changes
+	LastOrderPullAt *time.Time `json:"last_order_pull_at,omitempty"`
+	LastError       string     `json:"last_error,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"-"`
+}
+
+// TableName specifies the table name for DeliveryIntegration
+func (DeliveryIntegration) TableName() string {
+	return "delivery_integrations"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// Delivery zone shapes
+const (
+	DeliveryZoneKindRadius  = "radius"  // Circle around a center point
+	DeliveryZoneKindPolygon = "polygon" // Area enclosed by [latitude, longitude] vertices
+)
+
+// DeliveryZone is an area a restaurant delivers to, with its delivery fee and minimum order value
+// Zones may overlap; an address gets the active zone with the lowest priority that contains it
+type DeliveryZone struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	RestaurantID uint         `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Name         string       `gorm:"type:varchar(100);not null" json:"name"`
+	Kind         string       `gorm:"type:varchar(10);not null" json:"kind"`
+	CenterLat    *float64     `json:"center_lat,omitempty"`
+	CenterLng    *float64     `json:"center_lng,omitempty"`
+	RadiusMeters *int         `json:"radius_meters,omitempty"`
+	Polygon      [][2]float64 `gorm:"type:jsonb;serializer:json" json:"polygon,omitempty"`
+	Fee          money.Cents  `gorm:"type:numeric(12,2);not null;default:0" json:"fee"`
+	MinimumOrder money.Cents  `gorm:"type:numeric(12,2);not null;default:0" json:"minimum_order"` // Item subtotal required, excluding the fee
+	Priority     int          `gorm:"not null;default:0" json:"priority"`
+	IsActive     bool         `gorm:"default:true;not null" json:"is_active"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// TableName specifies the table name for DeliveryZone
+func (DeliveryZone) TableName() string {
+	return "delivery_zones"
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// Delivery assignment statuses
+const (
+	DeliveryStatusAssigned  = "assigned"
+	DeliveryStatusPickedUp  = "picked_up"
+	DeliveryStatusDelivered = "delivered"
+)
+
+// Driver is a courier delivering a restaurant's delivery orders
+// Drivers use their token to see their open deliveries and update them
+// The phone is encrypted at rest; PhoneHash is a deterministic hash for exact lookups
+type Driver struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Name         string    `gorm:"type:varchar(100);not null" json:"name"`
+	Phone        string    `gorm:"type:text;serializer:pii" json:"phone,omitempty"`
+	PhoneHash    string    `gorm:"type:varchar(64);index" json:"-"`
+	IsActive     bool      `gorm:"not null;default:true" json:"is_active"`
+	Token        string    `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"` // Driver app credential
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"-"`
+}
+
+// TableName specifies the table name for Driver
+func (Driver) TableName() string {
+	return "drivers"
+}
+
+// BeforeSave refreshes the lookup hash of the phone number
+func (d *Driver) BeforeSave(tx *gorm.DB) error {
+	d.PhoneHash = pii.HashPhone(d.Phone)
+	return nil
+}
+
+// DeliveryAssignment assigns a delivery order to a driver and tracks the delivery
+// An order has at most one assignment; the driver can be changed until the order is picked up
+type DeliveryAssignment struct {
+	ID               uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID     uint       `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	OrderID          uint       `gorm:"uniqueIndex;not null" json:"order_id"`
+	DriverID         uint       `gorm:"index;not null" json:"driver_id"`
+	Status           string     `gorm:"type:varchar(20);not null;default:'assigned'" json:"status"` // assigned, picked_up, delivered
+	AssignedByUserID uint       `gorm:"not null" json:"assigned_by_user_id"`
+	AssignedAt       time.Time  `gorm:"not null" json:"assigned_at"`
+	PickedUpAt       *time.Time `json:"picked_up_at,omitempty"`
+	DeliveredAt      *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// Relationships
+	Order  Order  `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"-"`
+	Driver Driver `gorm:"foreignKey:DriverID;constraint:OnDelete:RESTRICT" json:"driver"`
+}
+
+// TableName specifies the table name for DeliveryAssignment
+func (DeliveryAssignment) TableName() string {
+	return "delivery_assignments"
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// EmailVerification is a token emailed to a newly registered user to prove they own the address
+// Platform-wide table: not tenant-scoped, so no RLS (tokens are redeemed before login)
+type EmailVerification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // SHA-256 of the link's token
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the table name for EmailVerification
+func (EmailVerification) TableName() string {
+	return "email_verifications"
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+)
+
+// Dining table shapes
+const (
+	TableShapeRound     = "round"
+	TableShapeSquare    = "square"
+	TableShapeRectangle = "rectangle"
+)
+
+// FloorSection is an area of the dining room (e.g. terrace, bar) drawn as its own floor plan canvas
+type FloorSection struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Name         string    `gorm:"type:varchar(100);not null" json:"name"`
+	SortOrder    int       `gorm:"not null;default:0" json:"sort_order"`
+	Width        int       `gorm:"not null;default:1000" json:"width"` // Canvas size in floor plan units
+	Height       int       `gorm:"not null;default:1000" json:"height"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Relationships
+	Tables []DiningTable `gorm:"foreignKey:SectionID;constraint:OnDelete:CASCADE" json:"tables"`
+}
+
+// TableName specifies the table name for FloorSection
+func (FloorSection) TableName() string {
+	return "floor_sections"
+}
+
+// DiningTable is a table placed on a floor section
+// Number is the label reservations refer to (Reservation.TableNumber) and is unique per restaurant
+// X and Y are the top-left corner in the section's units; Rotation is in degrees clockwise
+type DiningTable struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"not null;uniqueIndex:idx_dining_tables_number" json:"restaurant_id"` // Crucial for RLS
+	SectionID    uint      `gorm:"index;not null" json:"section_id"`
+	Number       string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_dining_tables_number" json:"number"`
+	Seats        int       `gorm:"not null" json:"seats"`
+	Shape        string    `gorm:"type:varchar(20);not null" json:"shape"`
+	X            int       `gorm:"not null" json:"x"`
+	Y            int       `gorm:"not null" json:"y"`
+	Width        int       `gorm:"not null" json:"width"`
+	Height       int       `gorm:"not null" json:"height"`
+	Rotation     int       `gorm:"not null;default:0" json:"rotation"`
+	IsActive     bool      `gorm:"not null;default:true" json:"is_active"` // Inactive tables stay on the plan but can't be seated
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for DiningTable
+func (DiningTable) TableName() string {
+	return "dining_tables"
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// Tenant integrity checks
+const (
+	IntegrityCheckOrderItemTenant = "order_item_menu_item_tenant" // Order item references another restaurant's menu item
+	IntegrityCheckReservationUser = "reservation_missing_user"    // Reservation references a user that no longer exists
+	IntegrityCheckImagePrefix     = "image_prefix_mismatch"       // Image stored under another restaurant's storage prefix
+)
+
+// Integrity finding statuses
+const (
+	IntegrityFindingOpen        = "open"
+	IntegrityFindingQuarantined = "quarantined"
+	IntegrityFindingDismissed   = "dismissed"
+)
+
+// IntegrityFinding records a cross-tenant anomaly detected by the integrity checker
+// Platform-wide table: not tenant-scoped, so no RLS
+type IntegrityFinding struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	CheckName     string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_integrity_findings_row" json:"check_name"`
+	SourceTable   string     `gorm:"type:varchar(50);not null;uniqueIndex:idx_integrity_findings_row" json:"source_table"`
+	RowID         uint       `gorm:"not null;uniqueIndex:idx_integrity_findings_row" json:"row_id"`
+	RestaurantID  uint       `gorm:"index;not null" json:"restaurant_id"` // Tenant owning the offending row
+	Details       string     `json:"details"`
+	RowData       string     `gorm:"type:jsonb" json:"row_data,omitempty"`                // Snapshot of the row, kept when quarantined
+	Status        string     `gorm:"type:varchar(20);index;default:'open'" json:"status"` // open, quarantined, dismissed
+	DetectedAt    time.Time  `gorm:"not null" json:"detected_at"`
+	QuarantinedAt *time.Time `json:"quarantined_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for IntegrityFinding
+func (IntegrityFinding) TableName() string {
+	return "integrity_findings"
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+)
+
+// Ingredient is a stocked ingredient or supply, counted in its unit
+// StockQuantity is raised by received purchase orders; CostPrice is the weighted average cost of the stock
+type Ingredient struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID  uint      `gorm:"not null;uniqueIndex:idx_ingredients_name" json:"restaurant_id"` // Crucial for RLS
+	Name          string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_ingredients_name" json:"name"`
+	Unit          string    `gorm:"type:varchar(20);not null" json:"unit"` // e.g. kg, l, pcs; recipes and purchases use the same unit
+	StockQuantity float64   `gorm:"type:numeric(12,3);not null;default:0" json:"stock_quantity"`
+	CostPrice     float64   `gorm:"type:numeric(12,4);not null;default:0" json:"cost_price"` // Per unit
+	SupplierID    *uint     `gorm:"index" json:"supplier_id,omitempty"`                      // Preferred supplier
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+
+	// Relationships
+	Supplier *Supplier `gorm:"foreignKey:SupplierID;constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// TableName specifies the table name for Ingredient
+func (Ingredient) TableName() string {
+	return "ingredients"
+}
+
+// MenuItemIngredient is a line of a menu item's recipe: the quantity of an ingredient one serving uses
+type MenuItemIngredient struct {
+	ID           uint    `gorm:"primaryKey" json:"-"`
+	RestaurantID uint    `gorm:"index;not null" json:"-"` // Crucial for RLS
+	MenuItemID   uint    `gorm:"not null;uniqueIndex:idx_menu_item_ingredients_ingredient" json:"menu_item_id"`
+	IngredientID uint    `gorm:"not null;uniqueIndex:idx_menu_item_ingredients_ingredient;index" json:"ingredient_id"`
+	Quantity     float64 `gorm:"type:numeric(12,3);not null" json:"quantity"` // In the ingredient's unit
+
+	// Relationships
+	MenuItem   MenuItem   `gorm:"foreignKey:MenuItemID;constraint:OnDelete:CASCADE" json:"-"`
+	Ingredient Ingredient `gorm:"foreignKey:IngredientID" json:"ingredient"`
+}
+
+// TableName specifies the table name for MenuItemIngredient
+func (MenuItemIngredient) TableName() string {
+	return "menu_item_ingredients"
+}
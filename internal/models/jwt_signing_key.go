@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// JWTSigningKey is a JWT signing secret created by a key rotation, shared by every instance
+// The newest key signs new tokens; older keys only verify tokens until those expire, then they are retired
+// and their secret erased. Platform-wide table: not tenant-scoped, so no RLS
+type JWTSigningKey struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	KeyID     string     `gorm:"type:varchar(32);not null;uniqueIndex" json:"key_id"` // "kid" header of the tokens it signs
+	Secret    string     `gorm:"type:text;not null;serializer:pii" json:"-"`          // Encrypted with the PII key; empty once retired
+	CreatedAt time.Time  `json:"created_at"`
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+}
+
+// TableName specifies the table name for JWTSigningKey
+func (JWTSigningKey) TableName() string {
+	return "jwt_signing_keys"
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// KitchenLoadFactor is how much slower (or faster) than its items' average prep times the kitchen
+// prepares orders during an hour of the day, learned from orders as they are marked ready
+type KitchenLoadFactor struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"not null;uniqueIndex:idx_kitchen_load_factors_hour" json:"restaurant_id"` // Crucial for RLS
+	Hour         int       `gorm:"not null;uniqueIndex:idx_kitchen_load_factors_hour" json:"hour"`          // 0-23 in the restaurant's time zone
+	Factor       float64   `gorm:"not null;default:1" json:"factor"`                                        // Actual over expected prep time
+	Samples      int       `gorm:"not null;default:0" json:"samples"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for KitchenLoadFactor
+func (KitchenLoadFactor) TableName() string {
+	return "kitchen_load_factors"
+}
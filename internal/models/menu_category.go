@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -15,11 +16,33 @@ type MenuCategory struct {
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 
+	// ArchivedAt is set while the category is archived; archived categories and their items are hidden
+	// from menus and can't be ordered, but stay referenced by past orders
+	ArchivedAt *time.Time `gorm:"index" json:"archived_at,omitempty"`
+
+	// PublishStatus is draft until the category is first published (MenuPublishStatus*); drafts are hidden from the
+	// public menu. DraftChanges holds the edits of a published category waiting for the next publish
+	PublishStatus string          `gorm:"type:varchar(20);not null;default:'published'" json:"publish_status"`
+	DraftChanges  json.RawMessage `gorm:"type:jsonb" json:"draft_changes,omitempty"`
+
+	// Version is incremented on every update and exposed as the ETag (optimistic locking)
+	Version int `gorm:"default:1;not null" json:"version"`
+
 	// Relationships
 	Restaurant Restaurant `gorm:"foreignKey:RestaurantID"`
 	MenuItems  []MenuItem `gorm:"foreignKey:CategoryID"`
 }
 
+// IsArchived reports whether the category is archived
+func (c *MenuCategory) IsArchived() bool {
+	return c.ArchivedAt != nil
+}
+
+// IsPublished reports whether the category is on the public menu (unless archived)
+func (c *MenuCategory) IsPublished() bool {
+	return c.PublishStatus != MenuPublishStatusDraft
+}
+
 // TableName specifies the table name for MenuCategory
 func (MenuCategory) TableName() string {
 	return "menu_categories"
@@ -1,22 +1,63 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
+
+	"restaurant-backend/internal/money"
 )
 
 // MenuItem represents a menu item within a category
 type MenuItem struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
-	CategoryID   uint      `gorm:"index;not null" json:"category_id"`   // References MenuCategory
-	Name         string    `gorm:"not null" json:"name"`
-	Description  string    `json:"description"`
-	Price        float64   `gorm:"not null" json:"price"`
-	ImageURL     string    `json:"image_url"`                               // Deprecated: use Images relationship instead
-	DisplayOrder int       `gorm:"default:0;not null" json:"display_order"` // Order for sorting items within category
-	IsAvailable  bool      `gorm:"default:true" json:"is_available"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	CategoryID   uint        `gorm:"index;not null" json:"category_id"`   // References MenuCategory
+	Name         string      `gorm:"not null" json:"name"`
+	Description  string      `json:"description"`
+	Price        money.Cents `gorm:"type:numeric(12,2);not null" json:"price"`
+	ImageURL     string      `json:"image_url"`                               // Deprecated: use Images relationship instead
+	DisplayOrder int         `gorm:"default:0;not null" json:"display_order"` // Order for sorting items within category
+	IsAvailable  bool        `gorm:"default:true" json:"is_available"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+
+	// Tags are search keywords such as "vegan" or "spicy"; they are matched by the public menu search
+	Tags []string `gorm:"type:jsonb;serializer:json;not null;default:'[]'" json:"tags"`
+
+	// Channel prices override Price for orders of a sales channel; nil means the channel pays Price
+	DineInPrice     *money.Cents `gorm:"type:numeric(12,2)" json:"dine_in_price,omitempty"`
+	PickupPrice     *money.Cents `gorm:"type:numeric(12,2)" json:"pickup_price,omitempty"`
+	DeliveryPrice   *money.Cents `gorm:"type:numeric(12,2)" json:"delivery_price,omitempty"`
+	ThirdPartyPrice *money.Cents `gorm:"type:numeric(12,2)" json:"third_party_price,omitempty"`
+
+	// Nutrition information (optional, per serving)
+	Calories     *int     `json:"calories,omitempty"`
+	ProteinGrams *float64 `json:"protein_grams,omitempty"`
+	CarbsGrams   *float64 `json:"carbs_grams,omitempty"`
+	FatGrams     *float64 `json:"fat_grams,omitempty"`
+
+	// Ratings aggregated from visible reviews, maintained by the review service
+	RatingAverage float64 `gorm:"not null;default:0" json:"rating_average"`
+	RatingCount   int     `gorm:"not null;default:0" json:"rating_count"`
+
+	// Average prep time learned from the kitchen, maintained by the prep time service; 0 until the item was first prepared
+	AvgPrepSeconds int `gorm:"not null;default:0" json:"avg_prep_seconds"`
+	PrepSamples    int `gorm:"not null;default:0" json:"prep_samples"`
+
+	// PublishStatus is draft until the item is first published (MenuPublishStatus*); drafts are hidden from the
+	// public menu. DraftChanges holds the edits of a published item waiting for the next publish
+	PublishStatus string          `gorm:"type:varchar(20);not null;default:'published'" json:"publish_status"`
+	DraftChanges  json.RawMessage `gorm:"type:jsonb" json:"draft_changes,omitempty"`
+
+	// Version is incremented on every update and exposed as the ETag (optimistic locking)
+	Version int `gorm:"default:1;not null" json:"version"`
+
+	// RegularPrice and PricingRule are set on menu responses while a pricing rule adjusts Price (not stored)
+	RegularPrice *money.Cents `gorm:"-" json:"regular_price,omitempty"`
+	PricingRule  string       `gorm:"-" json:"pricing_rule,omitempty"`
+
+	// PairsWellWith are the items suggested with this one on the public item endpoint (not stored)
+	PairsWellWith []MenuItem `gorm:"-" json:"pairs_well_with,omitempty"`
 
 	// Relationships
 	Restaurant Restaurant      `gorm:"foreignKey:RestaurantID"`
@@ -24,3 +65,54 @@ type MenuItem struct {
 	Images     []MenuItemImage `gorm:"foreignKey:MenuItemID;order:display_order asc" json:"images,omitempty"`
 	OrderItems []OrderItem     `gorm:"foreignKey:MenuItemID"`
 }
+
+// IsPublished reports whether the item is on the public menu (unless its category isn't)
+func (m *MenuItem) IsPublished() bool {
+	return m.PublishStatus != MenuPublishStatusDraft
+}
+
+// PriceFor returns the item's price for a sales channel (OrderChannel*), falling back to Price
+func (m *MenuItem) PriceFor(channel string) money.Cents {
+	if price := m.ChannelPrice(channel); price != nil {
+		return *price
+	}
+	return m.Price
+}
+
+// ChannelPrice returns the price set for a sales channel, the menu price for an empty channel;
+// nil means the channel pays the menu price
+func (m *MenuItem) ChannelPrice(channel string) *money.Cents {
+	switch channel {
+	case "":
+		price := m.Price
+		return &price
+	case OrderChannelDineIn:
+		return m.DineInPrice
+	case OrderChannelPickup:
+		return m.PickupPrice
+	case OrderChannelDelivery:
+		return m.DeliveryPrice
+	case OrderChannelThirdParty:
+		return m.ThirdPartyPrice
+	}
+	return nil
+}
+
+// SetChannelPrice sets the price of a sales channel, the menu price for an empty channel
+// A nil price removes a channel price; the menu price can't be removed and stays unchanged
+func (m *MenuItem) SetChannelPrice(channel string, price *money.Cents) {
+	switch channel {
+	case "":
+		if price != nil {
+			m.Price = *price
+		}
+	case OrderChannelDineIn:
+		m.DineInPrice = price
+	case OrderChannelPickup:
+		m.PickupPrice = price
+	case OrderChannelDelivery:
+		m.DeliveryPrice = price
+	case OrderChannelThirdParty:
+		m.ThirdPartyPrice = price
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// Menu item pairing sources
+const (
+	MenuItemPairingManual    = "manual"    // Linked by the restaurant
+	MenuItemPairingAutomatic = "automatic" // Frequently ordered together, recomputed from order history
+)
+
+// MenuItemPairing suggests a menu item (a side, a drink) to customers ordering another one
+// A manual link replaces the automatic one of the same pair
+type MenuItemPairing struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	MenuItemID   uint      `gorm:"not null;uniqueIndex:idx_menu_item_pairings_pair" json:"menu_item_id"`
+	PairedItemID uint      `gorm:"not null;uniqueIndex:idx_menu_item_pairings_pair;index" json:"paired_item_id"`
+	Source       string    `gorm:"type:varchar(10);not null" json:"source"`
+	Score        int       `gorm:"not null;default:0" json:"score"`         // Orders with both items (automatic)
+	DisplayOrder int       `gorm:"not null;default:0" json:"display_order"` // Order of the manual links
+	CreatedAt    time.Time `json:"created_at"`
+
+	// Relationships
+	MenuItem   MenuItem `gorm:"foreignKey:MenuItemID;constraint:OnDelete:CASCADE" json:"-"`
+	PairedItem MenuItem `gorm:"foreignKey:PairedItemID;constraint:OnDelete:CASCADE" json:"paired_item"`
+}
+
+// TableName specifies the table name for MenuItemPairing
+func (MenuItemPairing) TableName() string {
+	return "menu_item_pairings"
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// Menu item price change statuses
+// Immediate changes are recorded as applied; scheduled ones apply once their effective time has passed
+const (
+	PriceChangeStatusScheduled = "scheduled"
+	PriceChangeStatusApplied   = "applied"
+	PriceChangeStatusCancelled = "cancelled"
+)
+
+// MenuItemPriceChange is a change of a menu item's price or of one of its channel prices, made or scheduled
+// OldPrice is the price it replaced once applied; nil channel prices mean the channel pays the menu price
+type MenuItemPriceChange struct {
+	ID           uint         `gorm:"primaryKey" json:"id"`
+	RestaurantID uint         `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	MenuItemID   uint         `gorm:"index;not null" json:"menu_item_id"`
+	Channel      string       `gorm:"type:varchar(20);not null;default:''" json:"channel,omitempty"` // OrderChannel*, empty for the menu price
+	OldPrice     *money.Cents `gorm:"type:numeric(12,2)" json:"old_price"`
+	NewPrice     *money.Cents `gorm:"type:numeric(12,2)" json:"new_price"`
+	Status       string       `gorm:"type:varchar(20);not null;index:idx_menu_item_price_changes_due" json:"status"`
+	EffectiveAt  time.Time    `gorm:"not null;index:idx_menu_item_price_changes_due" json:"effective_at"`
+	AppliedAt    *time.Time   `json:"applied_at,omitempty"`
+	ChangedBy    uint         `gorm:"not null" json:"changed_by"` // User who made or scheduled the change
+	CreatedAt    time.Time    `json:"created_at"`
+
+	// Relationships
+	MenuItem MenuItem `gorm:"foreignKey:MenuItemID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for MenuItemPriceChange
+func (MenuItemPriceChange) TableName() string {
+	return "menu_item_price_changes"
+}
+
+// MenuItemPriceColumn returns the menu_items column holding the price of a sales channel (OrderChannel*),
+// or the menu price for an empty channel; unknown channels return an empty string
+func MenuItemPriceColumn(channel string) string {
+	switch channel {
+	case "":
+		return "price"
+	case OrderChannelDineIn:
+		return "dine_in_price"
+	case OrderChannelPickup:
+		return "pickup_price"
+	case OrderChannelDelivery:
+		return "delivery_price"
+	case OrderChannelThirdParty:
+		return "third_party_price"
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+)
+
+// Menu publish states of categories and items
+const (
+	MenuPublishStatusDraft     = "draft"
+	MenuPublishStatusPublished = "published"
+)
+
+// MenuPublication records a publish of a restaurant's draft menu
+type MenuPublication struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	RestaurantID uint   `gorm:"not null;uniqueIndex:idx_menu_publications_version" json:"restaurant_id"` // Crucial for RLS
+	Version      int    `gorm:"not null;uniqueIndex:idx_menu_publications_version" json:"version"`       // 1 for the first publish
+	PublishedBy  uint   `gorm:"not null" json:"published_by"`
+	Note         string `gorm:"type:varchar(255)" json:"note"`
+
+	// What the publish changed on the public menu
+	CategoriesAdded   int `gorm:"not null;default:0" json:"categories_added"`
+	CategoriesUpdated int `gorm:"not null;default:0" json:"categories_updated"`
+	ItemsAdded        int `gorm:"not null;default:0" json:"items_added"`
+	ItemsUpdated      int `gorm:"not null;default:0" json:"items_updated"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for MenuPublication
+func (MenuPublication) TableName() string {
+	return "menu_publications"
+}
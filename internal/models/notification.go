@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+)
+
+// Notification types
+const (
+	NotificationTypeNewOrder       = "new_order"
+	NotificationTypeNewReservation = "new_reservation"
+	NotificationTypeLowStock       = "low_stock"      // Menu items ran out and were marked unavailable
+	NotificationTypePaymentFailed  = "payment_failed" // Raised by payment integrations
+
+	NotificationTypeApprovalRequested = "approval_requested" // A Staff change waits for an Admin's approval (Admins only)
+	NotificationTypeApprovalResolved  = "approval_resolved"  // The requester's change was approved or rejected
+)
+
+// Notification is an in-app alert for one staff member
+// Each Admin and Staff user of the restaurant gets their own copy, so read state and clearing are per user
+type Notification struct {
+	ID           uint                   `gorm:"primaryKey;index:idx_notifications_user_id_id,priority:2" json:"id"`
+	RestaurantID uint                   `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	UserID       uint                   `gorm:"not null;index:idx_notifications_user_id_id,priority:1" json:"user_id"`
+	Type         string                 `gorm:"type:varchar(30);not null" json:"type"`
+	Title        string                 `gorm:"not null" json:"title"`
+	Body         string                 `json:"body"`
+	Data         map[string]interface{} `gorm:"type:jsonb;serializer:json;not null;default:'{}'" json:"data"` // IDs of the records the alert is about
+	ReadAt       *time.Time             `json:"read_at,omitempty"`
+	CreatedAt    time.Time              `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the table name for Notification
+func (Notification) TableName() string {
+	return "notifications"
+}
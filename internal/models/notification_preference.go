@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+)
+
+// Customer notification event types
+const (
+	NotificationEventOrderUpdates       = "order_updates"       // Order confirmation, status and ready messages
+	NotificationEventReservationUpdates = "reservation_updates" // Reservation status, confirmation and reminder messages
+	NotificationEventMarketing          = "marketing"           // Promotions; needs the customer's recorded consent
+)
+
+// Customer notification channels
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelSMS   = "sms"
+	NotificationChannelPush  = "push"
+)
+
+// NotificationPreference holds a customer's channel choices per event type at a restaurant
+// Customers without a saved row get DefaultNotificationPreference: transactional messages on, marketing off
+type NotificationPreference struct {
+	ID           uint `gorm:"primaryKey" json:"id"`
+	RestaurantID uint `gorm:"not null;uniqueIndex:idx_notification_preferences_restaurant_user,priority:1" json:"restaurant_id"` // Crucial for RLS
+	UserID       uint `gorm:"not null;uniqueIndex:idx_notification_preferences_restaurant_user,priority:2" json:"user_id"`
+
+	OrderEmail       bool `gorm:"not null;default:true" json:"order_email"`
+	OrderSMS         bool `gorm:"not null;default:true" json:"order_sms"`
+	OrderPush        bool `gorm:"not null;default:true" json:"order_push"`
+	ReservationEmail bool `gorm:"not null;default:true" json:"reservation_email"`
+	ReservationSMS   bool `gorm:"not null;default:true" json:"reservation_sms"`
+	ReservationPush  bool `gorm:"not null;default:true" json:"reservation_push"`
+	MarketingEmail   bool `gorm:"not null;default:false" json:"marketing_email"`
+	MarketingSMS     bool `gorm:"not null;default:false" json:"marketing_sms"`
+	MarketingPush    bool `gorm:"not null;default:false" json:"marketing_push"`
+
+	// Marketing is only sent with recorded consent; withdrawing it turns every marketing channel off
+	MarketingConsentAt     *time.Time `json:"marketing_consent_at,omitempty"`
+	MarketingConsentSource string     `gorm:"type:varchar(30)" json:"marketing_consent_source,omitempty"`
+	UnsubscribedAt         *time.Time `json:"unsubscribed_at,omitempty"` // Last unsubscribe through an email link
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for NotificationPreference
+func (NotificationPreference) TableName() string {
+	return "notification_preferences"
+}
+
+// DefaultNotificationPreference returns the preferences of a customer who hasn't changed them
+func DefaultNotificationPreference(restaurantID, userID uint) *NotificationPreference {
+	return &NotificationPreference{
+		RestaurantID:     restaurantID,
+		UserID:           userID,
+		OrderEmail:       true,
+		OrderSMS:         true,
+		OrderPush:        true,
+		ReservationEmail: true,
+		ReservationSMS:   true,
+		ReservationPush:  true,
+	}
+}
+
+// Allows reports whether the customer accepts messages of the event type on the channel
+func (p *NotificationPreference) Allows(event, channel string) bool {
+	switch event {
+	case NotificationEventOrderUpdates:
+		return pickChannel(channel, p.OrderEmail, p.OrderSMS, p.OrderPush)
+	case NotificationEventReservationUpdates:
+		return pickChannel(channel, p.ReservationEmail, p.ReservationSMS, p.ReservationPush)
+	case NotificationEventMarketing:
+		return p.MarketingConsentAt != nil && pickChannel(channel, p.MarketingEmail, p.MarketingSMS, p.MarketingPush)
+	}
+	return false
+}
+
+func pickChannel(channel string, email, sms, push bool) bool {
+	switch channel {
+	case NotificationChannelEmail:
+		return email
+	case NotificationChannelSMS:
+		return sms
+	case NotificationChannelPush:
+		return push
+	}
+	return false
+}
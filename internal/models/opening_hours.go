@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// OrderSlotDuration is the length of the kitchen capacity slots scheduled orders are booked into
+const OrderSlotDuration = 15 * time.Minute
+
+// OpeningHours is a period a restaurant is open on a weekday, in the restaurant's time zone
+// A day may have several periods; a period closing at or before its opening time runs past midnight
+type OpeningHours struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Weekday      int       `gorm:"not null" json:"weekday"`             // 0 = Sunday ... 6 = Saturday
+	OpensAt      string    `gorm:"type:varchar(5);not null" json:"opens_at"`
+	ClosesAt     string    `gorm:"type:varchar(5);not null" json:"closes_at"` // HH:MM
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for OpeningHours
+func (OpeningHours) TableName() string {
+	return "opening_hours"
+}
@@ -2,18 +2,79 @@ package models
 
 import (
 	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// Order statuses
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusConfirmed = "confirmed"
+	OrderStatusPreparing = "preparing"
+	OrderStatusReady     = "ready"
+	OrderStatusCompleted = "completed"
+	OrderStatusCancelled = "cancelled"
+)
+
+// Order fulfillment types
+const (
+	OrderFulfillmentDineIn   = "dine_in"
+	OrderFulfillmentPickup   = "pickup"
+	OrderFulfillmentDelivery = "delivery"
+)
+
+// Order sales channels; menu items may be priced differently per channel
+const (
+	OrderChannelDineIn     = "dine_in"
+	OrderChannelPickup     = "pickup"
+	OrderChannelDelivery   = "delivery"
+	OrderChannelThirdParty = "third_party" // Delivery platforms and other resellers
 )
 
+// OrderSourceInternal marks orders placed through this API (staff, storefront)
+// Imported orders use their delivery provider (DeliveryProviderUberEats, DeliveryProviderDeliveroo) as source
+const OrderSourceInternal = "internal"
+
 // Order represents an order
 type Order struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
-	UserID       uint      `gorm:"index;not null" json:"user_id"`
-	Status       string    `gorm:"type:varchar(20);default:'pending'" json:"status"` // pending, confirmed, preparing, ready, completed, cancelled
-	TotalAmount  float64   `gorm:"not null" json:"total_amount"`
-	Notes        string    `json:"notes"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	UserID       uint        `gorm:"index;not null" json:"user_id"`
+	Status       string      `gorm:"type:varchar(20);default:'pending'" json:"status"` // pending, confirmed, preparing, ready, completed, cancelled
+	TotalAmount  money.Cents `gorm:"type:numeric(12,2);not null" json:"total_amount"`
+	Notes        string      `json:"notes"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+
+	// Source is where the order was placed; ExternalID is the delivery platform's order ID
+	Source     string `gorm:"type:varchar(20);default:'internal';not null" json:"source"`
+	ExternalID string `gorm:"type:varchar(100)" json:"external_id,omitempty"`
+
+	// Channel is the sales channel the items were priced for
+	Channel string `gorm:"type:varchar(20);not null;default:''" json:"channel"`
+
+	// ScheduledFor is the pickup/delivery time of an order placed ahead; nil means as soon as possible
+	ScheduledFor *time.Time `gorm:"index" json:"scheduled_for,omitempty"`
+
+	// Delivery orders carry the address, the delivery zone it fell into and the zone's fee, which is part of TotalAmount
+	FulfillmentType string      `gorm:"type:varchar(20);default:'pickup';not null" json:"fulfillment_type"`
+	DeliveryAddress string      `gorm:"type:varchar(255)" json:"delivery_address,omitempty"`
+	DeliveryLat     *float64    `json:"delivery_lat,omitempty"`
+	DeliveryLng     *float64    `json:"delivery_lng,omitempty"`
+	DeliveryZoneID  *uint       `json:"delivery_zone_id,omitempty"`
+	DeliveryFee     money.Cents `gorm:"type:numeric(12,2);not null;default:0" json:"delivery_fee"`
+
+	// TableSessionID links a dine-in order to the table session (open check) it is a round of
+	TableSessionID *uint `gorm:"index" json:"table_session_id,omitempty"`
+
+	// Prep time estimate, kept up to date by the prep time service as the kitchen queue moves
+	// PrepStartedAt is when the kitchen started preparing the order; the time to ready teaches the estimator
+	EstimatedPrepMinutes int        `gorm:"not null;default:0" json:"estimated_prep_minutes"` // From the latest estimate until ready
+	EstimatedReadyAt     *time.Time `json:"estimated_ready_at,omitempty"`
+	PrepStartedAt        *time.Time `json:"prep_started_at,omitempty"`
+
+	// ThrottleDelayMinutes is added to the promised time of an order taken while the kitchen was over capacity
+	ThrottleDelayMinutes int `gorm:"not null;default:0" json:"throttle_delay_minutes"`
 
 	// Relationships
 	Restaurant Restaurant  `gorm:"foreignKey:RestaurantID"`
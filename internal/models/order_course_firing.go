@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// OrderCourseFiring records a course of an order being fired to the kitchen and who fired it
+type OrderCourseFiring struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID  uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	OrderID       uint      `gorm:"index;not null" json:"order_id"`
+	Course        int       `gorm:"not null" json:"course"`
+	FiredByUserID uint      `gorm:"index;not null" json:"fired_by_user_id"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	// Relationships
+	Order Order `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for OrderCourseFiring
+func (OrderCourseFiring) TableName() string {
+	return "order_course_firings"
+}
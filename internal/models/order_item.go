@@ -2,22 +2,61 @@ package models
 
 import (
 	"time"
+
+	"restaurant-backend/internal/money"
 )
 
 // OrderItem represents an item in an order
 type OrderItem struct {
-	ID           uint      `gorm:"primaryKey" json:"id"`
-	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
-	OrderID      uint      `gorm:"index;not null" json:"order_id"`
-	MenuItemID   uint      `gorm:"index;not null" json:"menu_item_id"`
-	Quantity     int       `gorm:"not null" json:"quantity"`
-	Price        float64   `gorm:"not null" json:"price"` // Price at time of order
-	Notes        string    `json:"notes"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	OrderID      uint        `gorm:"index;not null" json:"order_id"`
+	MenuItemID   uint        `gorm:"index;not null" json:"menu_item_id"`
+	Quantity     int         `gorm:"not null" json:"quantity"`
+	Price        money.Cents `gorm:"type:numeric(12,2);not null" json:"price"` // Price at time of order
+	Notes        string      `json:"notes"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+
+	// PricingRuleID is the pricing rule that set Price, if any
+	PricingRuleID *uint `json:"pricing_rule_id,omitempty"`
+
+	// Name is a snapshot of the menu item name at order time (the menu item may be renamed or deleted later)
+	Name string `gorm:"type:varchar(255);not null;default:''" json:"name"`
+
+	// Seat is the guest the item was ordered for (1-based); unassigned items are shared by the table
+	Seat *int `json:"seat,omitempty"`
+
+	// Course is the dine-in course the item is served in (1-based); unassigned items go with the first course.
+	// Later courses are held by the kitchen until they are fired, which sets FiredAt
+	Course  *int       `json:"course,omitempty"`
+	FiredAt *time.Time `json:"fired_at,omitempty"`
 
 	// Relationships
 	Restaurant Restaurant `gorm:"foreignKey:RestaurantID"`
 	Order      Order      `gorm:"foreignKey:OrderID"`
 	MenuItem   MenuItem   `gorm:"foreignKey:MenuItemID"`
 }
+
+// DisplayName returns the item name as ordered, falling back to the current menu item name
+// for orders placed before names were snapshotted
+func (oi *OrderItem) DisplayName() string {
+	if oi.Name != "" {
+		return oi.Name
+	}
+	return oi.MenuItem.Name
+}
+
+// CourseNumber returns the course the item is served in; unassigned items go with the first course
+func (oi *OrderItem) CourseNumber() int {
+	if oi.Course == nil {
+		return 1
+	}
+	return *oi.Course
+}
+
+// IsHeld reports whether the kitchen is holding the item until its course is fired
+// The first course is fired with the order
+func (oi *OrderItem) IsHeld() bool {
+	return oi.CourseNumber() > 1 && oi.FiredAt == nil
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// Order split statuses
+const (
+	OrderSplitStatusPending = "pending"
+	OrderSplitStatusPaid    = "paid"
+)
+
+// Order split modes
+const (
+	OrderSplitModeEven  = "even"  // Equal parts
+	OrderSplitModeSeats = "seats" // One part per seat, unassigned items shared
+	OrderSplitModeItems = "items" // Explicit item groups
+)
+
+// OrderSplit is one payment group of a split bill
+// The splits of an order always sum exactly to its total
+type OrderSplit struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	OrderID      uint        `gorm:"index;not null" json:"order_id"`
+	Mode         string      `gorm:"type:varchar(20);not null" json:"mode"`
+	Label        string      `gorm:"type:varchar(100);not null" json:"label"`
+	Seat         *int        `json:"seat,omitempty"`
+	Amount       money.Cents `gorm:"type:numeric(12,2);not null" json:"amount"`
+	Status       string      `gorm:"type:varchar(20);default:'pending';not null" json:"status"`
+	PaidAt       *time.Time  `json:"paid_at,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+
+	// Relationships
+	Items []OrderSplitItem `gorm:"foreignKey:SplitID;constraint:OnDelete:CASCADE" json:"items"`
+}
+
+// TableName specifies the table name for OrderSplit
+func (OrderSplit) TableName() string {
+	return "order_splits"
+}
+
+// OrderSplitItem is the share of an order item paid by a split
+// Items shared between splits are divided evenly
+type OrderSplitItem struct {
+	ID           uint        `gorm:"primaryKey" json:"-"`
+	RestaurantID uint        `gorm:"index;not null" json:"-"` // Crucial for RLS
+	SplitID      uint        `gorm:"index;not null" json:"-"`
+	OrderItemID  uint        `gorm:"index;not null" json:"order_item_id"`
+	Amount       money.Cents `gorm:"type:numeric(12,2);not null" json:"amount"`
+}
+
+// TableName specifies the table name for OrderSplitItem
+func (OrderSplitItem) TableName() string {
+	return "order_split_items"
+}
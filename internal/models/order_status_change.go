@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// OrderStatusChange records a single order status transition and who made it
+type OrderStatusChange struct {
+	ID              uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID    uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	OrderID         uint      `gorm:"index;not null" json:"order_id"`
+	FromStatus      string    `gorm:"type:varchar(20);not null" json:"from_status"`
+	ToStatus        string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	ChangedByUserID uint      `gorm:"index;not null" json:"changed_by_user_id"`
+	CreatedAt       time.Time `json:"created_at"`
+
+	// Relationships
+	Order Order `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for OrderStatusChange
+func (OrderStatusChange) TableName() string {
+	return "order_status_changes"
+}
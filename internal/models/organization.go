@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// Organization groups multiple restaurant locations under one owner (e.g., a chain)
+// Each location is still a tenant (restaurant); org-scoped users can access all of them
+type Organization struct {
+	ID                   uint      `gorm:"primaryKey" json:"id"`
+	Name                 string    `gorm:"not null" json:"name"`
+	OwnerUserID          uint      `gorm:"index;not null" json:"owner_user_id"`
+	TemplateRestaurantID *uint     `json:"template_restaurant_id,omitempty"` // Location whose menu new locations are cloned from
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+
+	// Relationships
+	Locations []Restaurant `gorm:"foreignKey:OrganizationID" json:"locations,omitempty"`
+}
+
+// TableName specifies the table name for Organization
+func (Organization) TableName() string {
+	return "organizations"
+}
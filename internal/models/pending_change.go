@@ -0,0 +1,43 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Pending change types: Staff changes a restaurant can require an Admin to approve
+const (
+	PendingChangeTypePriceChange          = "price_change"           // Menu item update changing prices
+	PendingChangeTypeScheduledPriceChange = "scheduled_price_change" // Price change scheduled for a future date
+	PendingChangeTypeMenuItemDeletion     = "menu_item_deletion"
+	PendingChangeTypeCategoryDeletion     = "category_deletion"
+	PendingChangeTypeOrderCancellation    = "order_cancellation" // Voids the order's sale
+)
+
+// Pending change statuses
+const (
+	PendingChangeStatusPending  = "pending"
+	PendingChangeStatusApproved = "approved"
+	PendingChangeStatusRejected = "rejected"
+)
+
+// PendingChange is a Staff change waiting for an Admin's approval; it is made when approved
+type PendingChange struct {
+	ID           uint            `gorm:"primaryKey" json:"id"`
+	RestaurantID uint            `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Type         string          `gorm:"type:varchar(30);not null" json:"type"`
+	EntityID     uint            `gorm:"not null" json:"entity_id"`           // The menu item, category or order changed
+	Payload      json.RawMessage `gorm:"type:jsonb" json:"payload,omitempty"` // The change's request, made as is when approved
+	Status       string          `gorm:"type:varchar(20);not null;index" json:"status"`
+	RequestedBy  uint            `gorm:"not null" json:"requested_by"`
+	ReviewedBy   *uint           `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time      `json:"reviewed_at,omitempty"`
+	ReviewNote   string          `gorm:"type:varchar(255)" json:"review_note,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// TableName specifies the table name for PendingChange
+func (PendingChange) TableName() string {
+	return "pending_changes"
+}
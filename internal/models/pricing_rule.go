@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+)
+
+// Pricing rule adjustments
+const (
+	PricingRuleKindPercentOff = "percent_off" // Value is the percentage taken off the menu price
+	PricingRuleKindAmountOff  = "amount_off"  // Value is subtracted from the menu price
+	PricingRuleKindFixedPrice = "fixed_price" // Value replaces the menu price
+)
+
+// Pricing rule scopes
+const (
+	PricingRuleScopeAll      = "all"      // Every menu item
+	PricingRuleScopeCategory = "category" // The items of CategoryID
+	PricingRuleScopeItem     = "item"     // MenuItemID only
+)
+
+// PricingRule adjusts menu prices during a recurring time window (e.g. happy hour), in the restaurant's time zone
+// A window ending at or before its start runs past midnight; equal times cover the whole day.
+// When several rules apply to an item, the lowest resulting price wins
+type PricingRule struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Name         string    `gorm:"type:varchar(100);not null" json:"name"`
+	Weekdays     []int     `gorm:"type:jsonb;serializer:json" json:"weekdays"` // 0 = Sunday ... 6 = Saturday; empty means every day
+	StartsAt     string    `gorm:"type:varchar(5);not null" json:"starts_at"`
+	EndsAt       string    `gorm:"type:varchar(5);not null" json:"ends_at"` // HH:MM
+	Scope        string    `gorm:"type:varchar(10);not null" json:"scope"`
+	CategoryID   *uint     `gorm:"index" json:"category_id,omitempty"`
+	MenuItemID   *uint     `gorm:"index" json:"menu_item_id,omitempty"`
+	Kind         string    `gorm:"type:varchar(15);not null" json:"kind"`
+	Value        float64   `gorm:"type:numeric(12,2);not null" json:"value"` // Percent or amount, per Kind
+	IsActive     bool      `gorm:"default:true;not null" json:"is_active"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for PricingRule
+func (PricingRule) TableName() string {
+	return "pricing_rules"
+}
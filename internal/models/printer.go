@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+)
+
+// Printer roles: kitchen printers get kitchen tickets, receipt printers get customer receipts
+const (
+	PrinterKindKitchen = "kitchen"
+	PrinterKindReceipt = "receipt"
+)
+
+// Print job kinds
+const (
+	PrintJobKindKitchenTicket = "kitchen_ticket"
+	PrintJobKindReceipt       = "receipt"
+)
+
+// Print job statuses
+const (
+	PrintJobStatusPending  = "pending"  // Queued, waiting for the printer bridge
+	PrintJobStatusPrinting = "printing" // Claimed by the printer bridge
+	PrintJobStatusPrinted  = "printed"
+	PrintJobStatusFailed   = "failed"
+)
+
+// Printer is an ESC/POS printer reached through an on-premises bridge
+// The bridge polls for the printer's jobs with the printer token
+type Printer struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID uint       `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Name         string     `gorm:"type:varchar(100);not null" json:"name"`
+	Kind         string     `gorm:"type:varchar(20);not null" json:"kind"`   // kitchen, receipt
+	PaperWidth   int        `gorm:"not null;default:48" json:"paper_width"`  // Characters per line: 32 (58mm) or 48 (80mm)
+	AutoPrint    bool       `gorm:"not null;default:true" json:"auto_print"` // Print confirmed orders (kitchen) or completed orders (receipt) automatically
+	IsActive     bool       `gorm:"not null;default:true" json:"is_active"`
+	Token        string     `gorm:"type:varchar(64);uniqueIndex;not null" json:"-"` // Bridge credential
+	LastSeenAt   *time.Time `json:"last_seen_at,omitempty"`                         // Last poll of the bridge
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"-"`
+}
+
+// TableName specifies the table name for Printer
+func (Printer) TableName() string {
+	return "printers"
+}
+
+// PrintJob is an ESC/POS document queued for a printer
+type PrintJob struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID uint       `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	PrinterID    uint       `gorm:"index:idx_print_jobs_queue;not null" json:"printer_id"`
+	OrderID      *uint      `gorm:"index" json:"order_id,omitempty"`
+	Kind         string     `gorm:"type:varchar(20);not null" json:"kind"` // kitchen_ticket, receipt
+	Status       string     `gorm:"type:varchar(20);index:idx_print_jobs_queue;not null;default:'pending'" json:"status"`
+	Payload      []byte     `gorm:"type:bytea;not null" json:"-"` // ESC/POS command stream
+	Attempts     int        `gorm:"not null;default:0" json:"attempts"`
+	Error        string     `json:"error,omitempty"`
+	ClaimedAt    *time.Time `json:"claimed_at,omitempty"`
+	PrintedAt    *time.Time `json:"printed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// Relationships
+	Printer Printer `gorm:"foreignKey:PrinterID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for PrintJob
+func (PrintJob) TableName() string {
+	return "print_jobs"
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// Private event statuses
+// Quoted and confirmed events hold their tables (or the whole venue) against reservations and other events
+const (
+	PrivateEventStatusInquiry   = "inquiry"
+	PrivateEventStatusQuoted    = "quoted"
+	PrivateEventStatusConfirmed = "confirmed"
+	PrivateEventStatusCompleted = "completed"
+	PrivateEventStatusCancelled = "cancelled"
+)
+
+// EventPackage is a set menu offered for private events, priced per guest
+type EventPackage struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID  uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Name          string      `gorm:"type:varchar(100);not null" json:"name"`
+	Description   string      `json:"description"`
+	Courses       []string    `gorm:"type:jsonb;serializer:json;not null;default:'[]'" json:"courses"` // Printed on the quote
+	PricePerGuest money.Cents `gorm:"type:numeric(12,2);not null" json:"price_per_guest"`
+	MinGuests     int         `gorm:"not null;default:1" json:"min_guests"`
+	IsActive      bool        `gorm:"not null;default:true" json:"is_active"` // Inactive packages can't be chosen for new events
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for EventPackage
+func (EventPackage) TableName() string {
+	return "event_packages"
+}
+
+// PrivateEventMenuLine is an item of an event's custom menu, priced when it's added
+type PrivateEventMenuLine struct {
+	MenuItemID *uint       `json:"menu_item_id,omitempty"` // Nil for dishes not on the menu
+	Name       string      `json:"name"`
+	Quantity   int         `json:"quantity"`
+	UnitPrice  money.Cents `json:"unit_price"`
+}
+
+// PrivateEvent is a private-dining booking of several tables or the whole venue
+// The total is the subtotal (package, custom menu and venue fee) plus service charge; tax is included as on receipts
+// The contact email and phone are encrypted at rest, with deterministic hashes for exact lookups
+type PrivateEvent struct {
+	ID                   uint                   `gorm:"primaryKey" json:"id"`
+	RestaurantID         uint                   `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Name                 string                 `gorm:"type:varchar(200);not null" json:"name"`
+	ContactName          string                 `gorm:"type:varchar(200);not null" json:"contact_name"`
+	ContactEmail         string                 `gorm:"type:text;serializer:pii" json:"contact_email"`
+	ContactPhone         string                 `gorm:"type:text;serializer:pii" json:"contact_phone"`
+	ContactEmailHash     string                 `gorm:"type:varchar(64);index" json:"-"`
+	ContactPhoneHash     string                 `gorm:"type:varchar(64);index" json:"-"`
+	StartTime            time.Time              `gorm:"not null;index" json:"start_time"`
+	EndTime              time.Time              `gorm:"not null" json:"end_time"`
+	NumberOfGuests       int                    `gorm:"not null" json:"number_of_guests"`
+	FullVenue            bool                   `gorm:"not null;default:false" json:"full_venue"` // Blocks every table
+	Status               string                 `gorm:"type:varchar(20);default:'inquiry';not null;index" json:"status"`
+	PackageID            *uint                  `gorm:"index" json:"package_id,omitempty"`
+	CustomMenu           []PrivateEventMenuLine `gorm:"type:jsonb;serializer:json;not null;default:'[]'" json:"custom_menu"`
+	VenueFee             money.Cents            `gorm:"type:numeric(12,2);not null;default:0" json:"venue_fee"`
+	ServiceChargePercent float64                `gorm:"type:numeric(5,2);not null;default:0" json:"service_charge_percent"`
+	Subtotal             money.Cents            `gorm:"type:numeric(12,2);not null;default:0" json:"subtotal"`
+	ServiceCharge        money.Cents            `gorm:"type:numeric(12,2);not null;default:0" json:"service_charge"`
+	Tax                  money.Cents            `gorm:"type:numeric(12,2);not null;default:0" json:"tax"` // Included in the total
+	Total                money.Cents            `gorm:"type:numeric(12,2);not null;default:0" json:"total"`
+	QuotedAt             *time.Time             `json:"quoted_at,omitempty"`
+	QuoteExpiresAt       *time.Time             `json:"quote_expires_at,omitempty"`
+	Notes                string                 `json:"notes"`
+	CreatedBy            uint                   `gorm:"not null" json:"created_by"`
+	CreatedAt            time.Time              `json:"created_at"`
+	UpdatedAt            time.Time              `json:"updated_at"`
+
+	// Relationships
+	Package  *EventPackage         `gorm:"foreignKey:PackageID" json:"package,omitempty"`
+	Tables   []PrivateEventTable   `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE" json:"tables"`
+	Deposits []PrivateEventDeposit `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE" json:"deposits"`
+}
+
+// TableName specifies the table name for PrivateEvent
+func (PrivateEvent) TableName() string {
+	return "private_events"
+}
+
+// BeforeSave refreshes the lookup hashes of the contact fields
+func (e *PrivateEvent) BeforeSave(tx *gorm.DB) error {
+	e.ContactEmailHash = pii.HashEmail(e.ContactEmail)
+	e.ContactPhoneHash = pii.HashPhone(e.ContactPhone)
+	return nil
+}
+
+// HoldsVenue reports whether the event blocks its tables against other bookings
+func (e *PrivateEvent) HoldsVenue() bool {
+	return e.Status == PrivateEventStatusQuoted || e.Status == PrivateEventStatusConfirmed
+}
+
+// TableNumbers returns the numbers of the event's tables
+func (e *PrivateEvent) TableNumbers() []string {
+	numbers := make([]string, 0, len(e.Tables))
+	for _, table := range e.Tables {
+		numbers = append(numbers, table.TableNumber)
+	}
+	return numbers
+}
+
+// PrivateEventTable is a table blocked by a private event (Reservation.TableNumber)
+type PrivateEventTable struct {
+	ID           uint   `gorm:"primaryKey" json:"-"`
+	RestaurantID uint   `gorm:"index;not null" json:"-"` // Crucial for RLS
+	EventID      uint   `gorm:"not null;uniqueIndex:idx_private_event_tables_number" json:"-"`
+	TableNumber  string `gorm:"type:varchar(20);not null;uniqueIndex:idx_private_event_tables_number;index" json:"table_number"`
+}
+
+// TableName specifies the table name for PrivateEventTable
+func (PrivateEventTable) TableName() string {
+	return "private_event_tables"
+}
+
+// PrivateEventDeposit is an installment of an event's payment schedule
+// Percent is its share of the total; unpaid installments are rebalanced when the total changes
+type PrivateEventDeposit struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID     uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	EventID          uint        `gorm:"index;not null" json:"event_id"`
+	Label            string      `gorm:"type:varchar(100);not null" json:"label"`
+	Percent          float64     `gorm:"type:numeric(5,2);not null" json:"percent"`
+	Amount           money.Cents `gorm:"type:numeric(12,2);not null" json:"amount"`
+	DueDate          time.Time   `gorm:"type:date;not null" json:"due_date"`
+	PaidAt           *time.Time  `json:"paid_at,omitempty"`
+	PaymentReference string      `gorm:"type:varchar(100)" json:"payment_reference,omitempty"`
+	CreatedAt        time.Time   `json:"created_at"`
+	UpdatedAt        time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for PrivateEventDeposit
+func (PrivateEventDeposit) TableName() string {
+	return "private_event_deposits"
+}
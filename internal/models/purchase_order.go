@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// Purchase order statuses
+// Drafts can be edited; ordered purchase orders are received in one or more goods receipts
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusOrdered           = "ordered"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+// Supplier is a vendor the restaurant buys ingredients from
+type Supplier struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Name         string    `gorm:"type:varchar(100);not null" json:"name"`
+	ContactName  string    `gorm:"type:varchar(200)" json:"contact_name"`
+	Email        string    `gorm:"type:varchar(255)" json:"email"`
+	Phone        string    `gorm:"type:varchar(50)" json:"phone"`
+	Notes        string    `json:"notes"`
+	IsActive     bool      `gorm:"not null;default:true" json:"is_active"` // Inactive suppliers can't be ordered from
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for Supplier
+func (Supplier) TableName() string {
+	return "suppliers"
+}
+
+// PurchaseOrder is an order of ingredients from a supplier
+// TotalCost is the ordered quantities at the ordered unit costs; received goods are costed on their lines
+type PurchaseOrder struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	SupplierID   uint        `gorm:"index;not null" json:"supplier_id"`
+	Status       string      `gorm:"type:varchar(20);default:'draft';not null;index" json:"status"`
+	Reference    string      `gorm:"type:varchar(100)" json:"reference"` // e.g. the supplier's order number
+	ExpectedAt   *time.Time  `json:"expected_at,omitempty"`
+	OrderedAt    *time.Time  `json:"ordered_at,omitempty"`
+	ReceivedAt   *time.Time  `json:"received_at,omitempty"` // Last goods receipt
+	TotalCost    money.Cents `gorm:"type:numeric(12,2);not null;default:0" json:"total_cost"`
+	Notes        string      `json:"notes"`
+	CreatedBy    uint        `gorm:"not null" json:"created_by"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+
+	// Relationships
+	Supplier Supplier            `gorm:"foreignKey:SupplierID" json:"supplier"`
+	Lines    []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID;constraint:OnDelete:CASCADE" json:"lines"`
+}
+
+// TableName specifies the table name for PurchaseOrder
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+// PurchaseOrderLine is an ingredient ordered on a purchase order
+// ReceivedCost is what the received quantity cost, at the unit costs invoiced on each goods receipt
+type PurchaseOrderLine struct {
+	ID               uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID     uint        `gorm:"index;not null" json:"-"` // Crucial for RLS
+	PurchaseOrderID  uint        `gorm:"index;not null" json:"purchase_order_id"`
+	IngredientID     uint        `gorm:"index;not null" json:"ingredient_id"`
+	Quantity         float64     `gorm:"type:numeric(12,3);not null" json:"quantity"`
+	UnitCost         float64     `gorm:"type:numeric(12,4);not null" json:"unit_cost"`
+	ReceivedQuantity float64     `gorm:"type:numeric(12,3);not null;default:0" json:"received_quantity"`
+	ReceivedCost     money.Cents `gorm:"type:numeric(12,2);not null;default:0" json:"received_cost"`
+
+	// Relationships
+	Ingredient Ingredient `gorm:"foreignKey:IngredientID" json:"ingredient"`
+}
+
+// TableName specifies the table name for PurchaseOrderLine
+func (PurchaseOrderLine) TableName() string {
+	return "purchase_order_lines"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// PushSubscription is a browser's Web Push subscription for a customer, one per device
+// The endpoint is the push service URL of the device; p256dh and auth are the keys the payload is encrypted with
+type PushSubscription struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID uint       `gorm:"not null;uniqueIndex:idx_push_subscriptions_restaurant_endpoint,priority:1" json:"restaurant_id"` // Crucial for RLS
+	UserID       uint       `gorm:"index;not null" json:"user_id"`
+	Endpoint     string     `gorm:"type:text;not null;uniqueIndex:idx_push_subscriptions_restaurant_endpoint,priority:2" json:"endpoint"`
+	P256dh       string     `gorm:"type:varchar(255);not null" json:"-"`
+	Auth         string     `gorm:"type:varchar(255);not null" json:"-"`
+	UserAgent    string     `gorm:"type:varchar(255)" json:"user_agent"`
+	ExpiresAt    *time.Time `gorm:"index" json:"expires_at,omitempty"` // Set by browsers whose subscriptions expire
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`            // Last successful push
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for PushSubscription
+func (PushSubscription) TableName() string {
+	return "push_subscriptions"
+}
@@ -2,6 +2,10 @@ package models
 
 import (
 	"time"
+
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
 )
 
 // Reservation represents a table reservation
@@ -15,10 +19,26 @@ type Reservation struct {
 	NumberOfGuests int       `gorm:"not null" json:"number_of_guests"`
 	Status         string    `gorm:"type:varchar(20);default:'pending'" json:"status"` // pending, confirmed, cancelled, completed
 	Notes          string    `json:"notes"`
+	Source         string    `gorm:"type:varchar(20);default:'direct';not null" json:"source"` // direct, or the booking channel provider
+	ExternalID     *string   `gorm:"type:varchar(100)" json:"external_id,omitempty"`           // Booking ID on the channel
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 
+	// Contact details of a guest booked through a channel, as channel reservations share the channel's user
+	// Encrypted at rest; GuestEmailHash and GuestPhoneHash are deterministic hashes for exact lookups
+	GuestEmail     string `gorm:"type:text;serializer:pii" json:"guest_email,omitempty"`
+	GuestPhone     string `gorm:"type:text;serializer:pii" json:"guest_phone,omitempty"`
+	GuestEmailHash string `gorm:"type:varchar(64);index" json:"-"`
+	GuestPhoneHash string `gorm:"type:varchar(64);index" json:"-"`
+
 	// Relationships
 	Restaurant Restaurant `gorm:"foreignKey:RestaurantID"`
 	User       User       `gorm:"foreignKey:UserID"`
 }
+
+// BeforeSave refreshes the lookup hashes of the guest contact fields
+func (r *Reservation) BeforeSave(tx *gorm.DB) error {
+	r.GuestEmailHash = pii.HashEmail(r.GuestEmail)
+	r.GuestPhoneHash = pii.HashPhone(r.GuestPhone)
+	return nil
+}
@@ -14,6 +14,14 @@ const (
 	RestaurantStatusSuspended RestaurantStatus = "suspended"
 )
 
+// RestaurantVisibility controls whether a restaurant is shown publicly (independent of status)
+type RestaurantVisibility string
+
+const (
+	RestaurantVisibilityPublic RestaurantVisibility = "public"
+	RestaurantVisibilityHidden RestaurantVisibility = "hidden" // Soft launch: staff finish setup before go-live
+)
+
 // PlatformOrganizationID is the special organization ID for platform-level users (KAMs)
 // This is a reserved organization that represents the platform itself
 const PlatformOrganizationID uint = 1
@@ -43,6 +51,23 @@ type Restaurant struct {
 	ContactEmail string `json:"contact_email"`
 	ContactPhone string `json:"contact_phone"`
 
+	// Visibility (soft launch): hidden restaurants are excluded from the public directory and public ordering
+	Visibility RestaurantVisibility `gorm:"type:varchar(20);default:'public';not null" json:"visibility"`
+	GoLiveAt   *time.Time           `json:"go_live_at,omitempty"`  // Scheduled automatic launch
+	LaunchedAt *time.Time           `json:"launched_at,omitempty"` // Set when the restaurant went public
+
+	// OrganizationID links a location to its owning organization (multi-location chains)
+	OrganizationID *uint `gorm:"index" json:"organization_id,omitempty"`
+
+	// MenuVersion is incremented on every menu change (used for aggregator sync)
+	MenuVersion int `gorm:"default:0;not null" json:"menu_version"`
+
+	// DisplayToken grants read-only access to the pickup-screen order board (TV mode)
+	DisplayToken *string `gorm:"uniqueIndex" json:"-"`
+
+	// Version is incremented on every update and exposed as the ETag (optimistic locking)
+	Version int `gorm:"default:1;not null" json:"version"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
@@ -53,3 +78,8 @@ type Restaurant struct {
 	Orders       []Order        `gorm:"foreignKey:RestaurantID"`
 	KAM          *User          `gorm:"foreignKey:KAMID" json:"kam,omitempty"`
 }
+
+// IsPubliclyVisible checks if the restaurant can appear in the public directory and take public orders
+func (r *Restaurant) IsPubliclyVisible() bool {
+	return r.Status == RestaurantStatusActive && r.Visibility != RestaurantVisibilityHidden
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+)
+
+// Restaurant health flags (churn-risk signals raised for KAMs)
+const (
+	HealthFlagQuiet          = "quiet"           // No orders for the configured number of days
+	HealthFlagDeclining      = "declining"       // Orders fell by half or more against the previous window
+	HealthFlagNoRecentLogin  = "no_recent_login" // No staff sign-in for 30 days
+	HealthFlagEmailFailures  = "email_failures"  // A fifth or more of this month's emails failed
+	HealthFlagIncompleteMenu = "incomplete_menu" // Under half of the menu items have a description and an image
+)
+
+// RestaurantHealth is the latest health score of an active restaurant, recomputed by the health job
+// Each component score is 0-100; Score is their weighted sum
+// Platform-wide table: only read by KAMs, so no RLS
+type RestaurantHealth struct {
+	ID           uint `gorm:"primaryKey" json:"id"`
+	RestaurantID uint `gorm:"uniqueIndex;not null" json:"restaurant_id"`
+
+	Score         int `gorm:"not null" json:"score"`
+	ActivityScore int `gorm:"not null" json:"activity_score"` // Ordering trend
+	MenuScore     int `gorm:"not null" json:"menu_score"`     // Menu completeness
+	LoginScore    int `gorm:"not null" json:"login_score"`    // Staff login recency
+	EmailScore    int `gorm:"not null" json:"email_score"`    // Email delivery
+
+	// Inputs the scores were computed from
+	RecentOrders      int64      `gorm:"not null;default:0" json:"recent_orders"`   // Last 14 days
+	PreviousOrders    int64      `gorm:"not null;default:0" json:"previous_orders"` // The 14 days before
+	LastOrderAt       *time.Time `json:"last_order_at,omitempty"`
+	LastLoginAt       *time.Time `json:"last_login_at,omitempty"`
+	MenuItems         int64      `gorm:"not null;default:0" json:"menu_items"`
+	CompleteMenuItems int64      `gorm:"not null;default:0" json:"complete_menu_items"`
+	EmailsSent        int64      `gorm:"not null;default:0" json:"emails_sent"`   // This month
+	EmailsFailed      int64      `gorm:"not null;default:0" json:"emails_failed"` // This month
+
+	Flags      []string   `gorm:"type:jsonb;serializer:json;not null;default:'[]'" json:"flags"`
+	FlaggedAt  *time.Time `json:"flagged_at,omitempty"` // Since when the restaurant has had flags
+	ComputedAt time.Time  `gorm:"not null" json:"computed_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Relationships
+	Restaurant *Restaurant `gorm:"foreignKey:RestaurantID" json:"restaurant,omitempty"`
+}
+
+// TableName specifies the table name for RestaurantHealth
+func (RestaurantHealth) TableName() string {
+	return "restaurant_health"
+}
+
+// HasFlag reports whether the flag is raised
+func (h *RestaurantHealth) HasFlag(flag string) bool {
+	for _, f := range h.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
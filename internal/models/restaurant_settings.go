@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+)
+
+// Default storefront settings applied when a restaurant hasn't customized them
+const (
+	DefaultCurrency = "USD"
+	DefaultLocale   = "en-US"
+	DefaultTimeZone = "UTC"
+)
+
+// What happens to new orders while the kitchen is over capacity
+const (
+	KitchenOverloadDelay = "delay" // Orders are taken with a later promised time
+	KitchenOverloadPause = "pause" // Public online ordering pauses until the queue drains; staff can still take orders
+)
+
+// RestaurantSettings represents a restaurant's storefront branding and preferences
+type RestaurantSettings struct {
+	ID                    uint    `gorm:"primaryKey" json:"id"`
+	RestaurantID          uint    `gorm:"uniqueIndex;not null" json:"restaurant_id"` // Crucial for RLS
+	LogoURL               string  `json:"logo_url"`
+	PrimaryColor          string  `gorm:"type:varchar(7)" json:"primary_color"`
+	SecondaryColor        string  `gorm:"type:varchar(7)" json:"secondary_color"`
+	AccentColor           string  `gorm:"type:varchar(7)" json:"accent_color"`
+	Currency              string  `gorm:"type:varchar(3);default:'USD';not null" json:"currency"` // ISO 4217
+	Locale                string  `gorm:"type:varchar(10);default:'en-US';not null" json:"locale"`
+	TimeZone              string  `gorm:"type:varchar(50);default:'UTC';not null" json:"time_zone"` // IANA time zone
+	ReceiptFooter         string  `gorm:"type:text" json:"receipt_footer"`
+	TaxRate               float64 `gorm:"type:numeric(5,2);default:0;not null" json:"tax_rate"` // Percent included in menu prices, broken down on receipts
+	OnlineOrderingEnabled bool    `gorm:"default:true;not null" json:"online_ordering_enabled"`
+	OrderSlotCapacity     int     `gorm:"default:0;not null" json:"order_slot_capacity"` // Max scheduled orders per 15-minute slot, 0 = unlimited
+
+	// Public profile, shown in the restaurant directory; restaurants without coordinates aren't found by location
+	City      string   `gorm:"type:varchar(100)" json:"city"`
+	Cuisines  []string `gorm:"type:jsonb;serializer:json;not null;default:'[]'" json:"cuisines"`
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+	PhotoURLs []string `gorm:"type:jsonb;serializer:json;not null;default:'[]'" json:"photo_urls"`
+
+	// Reservation pacing, on top of per-table conflicts; zero limits are unlimited
+	ReservationSlotMinutes        int `gorm:"default:15;not null" json:"reservation_slot_minutes"`        // Pacing slot length, 15 or 30
+	ReservationMaxCoversPerSlot   int `gorm:"default:0;not null" json:"reservation_max_covers_per_slot"`  // Guests arriving per slot
+	ReservationMaxPartySize       int `gorm:"default:0;not null" json:"reservation_max_party_size"`       // Larger parties book a private event
+	ReservationTableBufferMinutes int `gorm:"default:0;not null" json:"reservation_table_buffer_minutes"` // Turnover time between seatings of a table
+
+	// Kitchen capacity, counted over the orders waiting for or in preparation; zero limits are unlimited
+	KitchenMaxOpenOrders        int    `gorm:"default:0;not null" json:"kitchen_max_open_orders"`
+	KitchenMaxItemsInFlight     int    `gorm:"default:0;not null" json:"kitchen_max_items_in_flight"` // Portions of all open orders
+	KitchenOverloadAction       string `gorm:"type:varchar(10);default:'delay';not null" json:"kitchen_overload_action"`
+	KitchenOverloadDelayMinutes int    `gorm:"default:15;not null" json:"kitchen_overload_delay_minutes"` // Added to orders taken while overloaded (delay)
+
+	// CartRecoveryEmail reminds shoppers of carts they left without ordering, with a link to resume them
+	CartRecoveryEmail bool `gorm:"default:false;not null" json:"cart_recovery_email"`
+
+	// MenuDraftsEnabled makes menu edits a draft: new categories and items and edits of published ones only
+	// reach the public menu with the next menu publish. Drafts left when it is turned off wait for a publish
+	MenuDraftsEnabled bool `gorm:"default:false;not null" json:"menu_drafts_enabled"`
+
+	// Staff changes that wait for an Admin's approval in the pending changes queue instead of being made
+	RequireApprovalPriceChanges  bool `gorm:"default:false;not null" json:"require_approval_price_changes"`
+	RequireApprovalDeletions     bool `gorm:"default:false;not null" json:"require_approval_deletions"`     // Menu items and categories
+	RequireApprovalCancellations bool `gorm:"default:false;not null" json:"require_approval_cancellations"` // Order cancellations
+
+	// ArchiveAfterDays is the age in days after which finished orders and past reservations move to the archive
+	// (archived_orders, archived_reservations); 0 uses the platform default (ARCHIVE_AFTER_DAYS)
+	ArchiveAfterDays int `gorm:"default:0;not null" json:"archive_after_days"`
+
+	// MinMarginPercent is the margin (percent of the price) below which the margin report flags menu items
+	MinMarginPercent float64 `gorm:"type:numeric(5,2);default:65;not null" json:"min_margin_percent"`
+
+	// SMS opt-in per message type; guests are only texted about what the restaurant enabled
+	SMSReservationConfirmation bool `gorm:"default:false;not null" json:"sms_reservation_confirmation"`
+	SMSReservationReminder     bool `gorm:"default:false;not null" json:"sms_reservation_reminder"`
+	SMSOrderReady              bool `gorm:"default:false;not null" json:"sms_order_ready"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"-"`
+}
+
+// ReservationSlot is the length of the reservation pacing slots
+func (s *RestaurantSettings) ReservationSlot() time.Duration {
+	if s.ReservationSlotMinutes <= 0 {
+		return 15 * time.Minute
+	}
+	return time.Duration(s.ReservationSlotMinutes) * time.Minute
+}
+
+// TableName specifies the table name for RestaurantSettings
+func (RestaurantSettings) TableName() string {
+	return "restaurant_settings"
+}
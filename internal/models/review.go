@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// Review is a customer's rating of a completed order or of one of its menu items
+// MenuItemID is nil for a review of the order (restaurant) as a whole
+type Review struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID   uint       `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	OrderID        uint       `gorm:"index;not null" json:"order_id"`
+	MenuItemID     *uint      `gorm:"index" json:"menu_item_id,omitempty"`
+	UserID         uint       `gorm:"index;not null" json:"user_id"`
+	Rating         int        `gorm:"not null" json:"rating"` // 1-5
+	Comment        string     `gorm:"type:text" json:"comment"`
+	IsHidden       bool       `gorm:"not null;default:false" json:"is_hidden"` // Hidden by moderation; excluded from ratings
+	HiddenReason   string     `json:"hidden_reason,omitempty"`
+	HiddenByUserID *uint      `json:"hidden_by_user_id,omitempty"`
+	HiddenAt       *time.Time `json:"hidden_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Relationships
+	Order Order `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for Review
+func (Review) TableName() string {
+	return "reviews"
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+)
+
+// Job run statuses
+const (
+	JobRunRunning   = "running"
+	JobRunSucceeded = "succeeded"
+	JobRunFailed    = "failed"
+)
+
+// ScheduledJob is the shared schedule and lock of a periodic background job, one row per job
+// An instance runs a due job only after taking its lease, so each run happens on exactly one instance
+// Platform-wide table: not tenant-scoped, so no RLS
+type ScheduledJob struct {
+	Name        string     `gorm:"primaryKey;type:varchar(100)" json:"name"`
+	NextRunAt   time.Time  `gorm:"not null" json:"next_run_at"`
+	LockedBy    string     `gorm:"type:varchar(255)" json:"locked_by,omitempty"` // Instance holding the lease
+	LockedUntil *time.Time `json:"locked_until,omitempty"`                       // Lease expiry; a crashed instance's lease lapses
+	LastRunAt   *time.Time `json:"last_run_at,omitempty"`
+	LastStatus  string     `gorm:"type:varchar(20)" json:"last_status,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for ScheduledJob
+func (ScheduledJob) TableName() string {
+	return "scheduled_jobs"
+}
+
+// JobRun is the history entry of one run of a scheduled job
+// Platform-wide table: not tenant-scoped, so no RLS
+type JobRun struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	JobName    string     `gorm:"type:varchar(100);not null" json:"job_name"`
+	Instance   string     `gorm:"type:varchar(255);not null" json:"instance"`
+	Status     string     `gorm:"type:varchar(20);not null" json:"status"` // running, succeeded, failed
+	Error      string     `gorm:"type:text" json:"error,omitempty"`
+	StartedAt  time.Time  `gorm:"not null" json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs int64      `json:"duration_ms"`
+}
+
+// TableName specifies the table name for JobRun
+func (JobRun) TableName() string {
+	return "job_runs"
+}
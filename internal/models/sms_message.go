@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
+)
+
+// SMS message types, each opted into per restaurant in its settings
+const (
+	SMSTypeReservationConfirmation = "reservation_confirmation"
+	SMSTypeReservationReminder     = "reservation_reminder"
+	SMSTypeOrderReady              = "order_ready"
+)
+
+// SMS delivery statuses, as reported by the provider
+const (
+	SMSStatusQueued      = "queued" // Recorded, not yet accepted by the provider
+	SMSStatusSent        = "sent"
+	SMSStatusDelivered   = "delivered"
+	SMSStatusUndelivered = "undelivered"
+	SMSStatusFailed      = "failed"
+)
+
+// SMSMessage is the delivery log of a text message sent to a guest
+// A reservation or order gets at most one message of each type, so retries and concurrent jobs can't send twice
+// The recipient number is encrypted at rest; ToHash is a deterministic hash for exact lookups
+type SMSMessage struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID  uint       `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	Type          string     `gorm:"type:varchar(30);not null;uniqueIndex:idx_sms_messages_reservation_type,priority:2;uniqueIndex:idx_sms_messages_order_type,priority:2" json:"type"`
+	ReservationID *uint      `gorm:"uniqueIndex:idx_sms_messages_reservation_type,priority:1" json:"reservation_id,omitempty"`
+	OrderID       *uint      `gorm:"uniqueIndex:idx_sms_messages_order_type,priority:1" json:"order_id,omitempty"`
+	To            string     `gorm:"type:text;not null;serializer:pii" json:"to"`
+	ToHash        string     `gorm:"type:varchar(64);index" json:"-"`
+	Body          string     `gorm:"type:text;not null" json:"body"`
+	Provider      string     `gorm:"type:varchar(20);not null" json:"provider"`
+	ExternalID    string     `gorm:"type:varchar(64);index" json:"external_id,omitempty"` // Provider message ID, matched by status callbacks
+	Status        string     `gorm:"type:varchar(20);not null;default:'queued'" json:"status"`
+	ErrorCode     string     `gorm:"type:varchar(20)" json:"error_code,omitempty"`
+	ErrorMessage  string     `gorm:"type:text" json:"error_message,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"index" json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for SMSMessage
+func (SMSMessage) TableName() string {
+	return "sms_messages"
+}
+
+// BeforeSave refreshes the lookup hash of the recipient number
+func (m *SMSMessage) BeforeSave(tx *gorm.DB) error {
+	m.ToHash = pii.HashPhone(m.To)
+	return nil
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// DefaultSSOGroupsClaim is the ID token claim holding the user's IdP groups unless configured otherwise
+const DefaultSSOGroupsClaim = "groups"
+
+// SSOConfig is a restaurant's OpenID Connect identity provider for staff login
+// Staff signing in through it get the role their IdP groups map to; unknown users are created on first login
+// when auto provisioning is on
+type SSOConfig struct {
+	ID           uint   `gorm:"primaryKey" json:"id"`
+	RestaurantID uint   `gorm:"not null;uniqueIndex" json:"restaurant_id"` // Crucial for RLS
+	Enabled      bool   `gorm:"not null;default:false" json:"enabled"`
+	Issuer       string `gorm:"type:varchar(500);not null" json:"issuer"` // Discovery: <issuer>/.well-known/openid-configuration
+	ClientID     string `gorm:"type:varchar(255);not null" json:"client_id"`
+	ClientSecret string `gorm:"type:text;not null;serializer:pii" json:"-"` // Encrypted with the PII key, never returned
+
+	// Role mapping: the highest role any of the user's groups maps to wins; DefaultRole (empty to deny) otherwise
+	GroupsClaim   string            `gorm:"type:varchar(100);not null;default:'groups'" json:"groups_claim"`
+	RoleMappings  map[string]string `gorm:"type:jsonb;serializer:json;not null;default:'{}'" json:"role_mappings"` // IdP group -> Admin or Staff
+	DefaultRole   string            `gorm:"type:varchar(20)" json:"default_role,omitempty"`
+	AutoProvision bool              `gorm:"not null;default:true" json:"auto_provision"` // Create unknown users on first login
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for SSOConfig
+func (SSOConfig) TableName() string {
+	return "sso_configs"
+}
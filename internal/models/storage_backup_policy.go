@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Backup tiers for a restaurant's stored assets
+const (
+	BackupTierStandard = "standard" // Versioned objects, noncurrent versions kept for RetentionDays
+	BackupTierPremium  = "premium"  // Standard plus cross-region replication
+)
+
+// StorageBackupPolicy configures backups of a restaurant's S3 assets (the restaurant-{id}/ prefix)
+// Platform-wide table: managed by KAMs, not tenant-scoped, so no RLS
+type StorageBackupPolicy struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID  uint       `gorm:"uniqueIndex;not null" json:"restaurant_id"`
+	Tier          string     `gorm:"type:varchar(20);default:'standard';not null" json:"tier"` // standard, premium
+	RetentionDays int        `gorm:"not null" json:"retention_days"`                           // Days noncurrent versions are kept
+	LastAppliedAt *time.Time `json:"last_applied_at,omitempty"`                                // Last time the bucket configuration was synced
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for StorageBackupPolicy
+func (StorageBackupPolicy) TableName() string {
+	return "storage_backup_policies"
+}
+
+// Replicated reports whether the restaurant's assets are replicated to another region
+func (p *StorageBackupPolicy) Replicated() bool {
+	return p.Tier == BackupTierPremium
+}
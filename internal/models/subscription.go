@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// PlanCode identifies a subscription plan
+type PlanCode string
+
+const (
+	PlanFree     PlanCode = "free"
+	PlanStandard PlanCode = "standard"
+	PlanPremium  PlanCode = "premium"
+)
+
+// SubscriptionStatus represents the billing state of a subscription
+type SubscriptionStatus string
+
+const (
+	SubscriptionStatusActive    SubscriptionStatus = "active"
+	SubscriptionStatusPastDue   SubscriptionStatus = "past_due"  // Payment failed; creating resources is blocked
+	SubscriptionStatusCancelled SubscriptionStatus = "cancelled" // Creating resources is blocked
+)
+
+// PlanLimits caps what a restaurant can create; 0 means unlimited
+type PlanLimits struct {
+	MaxMenuItems     int `json:"max_menu_items"`
+	MaxStaffUsers    int `json:"max_staff_users"`    // Admin and Staff users
+	MaxMonthlyOrders int `json:"max_monthly_orders"` // Orders created since the start of the calendar month (UTC)
+}
+
+// Plan is a subscription tier
+type Plan struct {
+	Code         PlanCode    `json:"code"`
+	Name         string      `json:"name"`
+	MonthlyPrice money.Cents `json:"monthly_price"`
+	Rank         int         `json:"rank"` // Higher is a bigger plan; used to tell upgrades from downgrades
+	Limits       PlanLimits  `json:"limits"`
+}
+
+// Plans is the plan catalog, ordered from smallest to largest
+var Plans = []Plan{
+	{
+		Code:         PlanFree,
+		Name:         "Free",
+		MonthlyPrice: 0,
+		Rank:         0,
+		Limits:       PlanLimits{MaxMenuItems: 30, MaxStaffUsers: 2, MaxMonthlyOrders: 200},
+	},
+	{
+		Code:         PlanStandard,
+		Name:         "Standard",
+		MonthlyPrice: 4900,
+		Rank:         1,
+		Limits:       PlanLimits{MaxMenuItems: 300, MaxStaffUsers: 15, MaxMonthlyOrders: 5000},
+	},
+	{
+		Code:         PlanPremium,
+		Name:         "Premium",
+		MonthlyPrice: 14900,
+		Rank:         2,
+		Limits:       PlanLimits{},
+	},
+}
+
+// DefaultPlan is the plan of restaurants without a subscription
+const DefaultPlan = PlanFree
+
+// GetPlan returns the plan with the given code
+func GetPlan(code PlanCode) (Plan, bool) {
+	for _, plan := range Plans {
+		if plan.Code == code {
+			return plan, true
+		}
+	}
+	return Plan{}, false
+}
+
+// Subscription represents the plan a restaurant is subscribed to
+type Subscription struct {
+	ID                 uint               `gorm:"primaryKey" json:"id"`
+	RestaurantID       uint               `gorm:"uniqueIndex;not null" json:"restaurant_id"` // Crucial for RLS
+	Plan               PlanCode           `gorm:"type:varchar(20);default:'free';not null" json:"plan"`
+	Status             SubscriptionStatus `gorm:"type:varchar(20);default:'active';not null" json:"status"`
+	CurrentPeriodStart time.Time          `json:"current_period_start"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"-"`
+}
+
+// TableName specifies the table name for Subscription
+func (Subscription) TableName() string {
+	return "subscriptions"
+}
+
+// IsActive checks if the subscription allows creating resources
+func (s *Subscription) IsActive() bool {
+	return s.Status == SubscriptionStatusActive
+}
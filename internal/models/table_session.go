@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// Table session statuses
+const (
+	TableSessionStatusOpen   = "open"
+	TableSessionStatusClosed = "closed"
+)
+
+// Payment methods of a closed table session
+const (
+	PaymentMethodCash  = "cash"
+	PaymentMethodCard  = "card"
+	PaymentMethodOther = "other"
+)
+
+// TableSession is the open check of a seated dine-in party
+// Each round the party orders is a dine-in order of the session; the check's total is the sum of its
+// non-cancelled rounds and is fixed when the session is closed with its payment
+// A table has at most one open session
+type TableSession struct {
+	ID             uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID   uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	TableNumber    string      `gorm:"type:varchar(20);not null" json:"table_number"`
+	Guests         int         `gorm:"not null" json:"guests"`
+	Status         string      `gorm:"type:varchar(20);default:'open';not null" json:"status"`
+	Notes          string      `gorm:"type:varchar(255)" json:"notes,omitempty"`
+	OpenedByUserID uint        `gorm:"not null" json:"opened_by_user_id"`
+	OpenedAt       time.Time   `gorm:"not null" json:"opened_at"`
+	ClosedByUserID *uint       `json:"closed_by_user_id,omitempty"`
+	ClosedAt       *time.Time  `json:"closed_at,omitempty"`
+	PaymentMethod  string      `gorm:"type:varchar(20)" json:"payment_method,omitempty"`
+	Total          money.Cents `gorm:"type:numeric(12,2);not null;default:0" json:"total"` // Set at close
+	Tip            money.Cents `gorm:"type:numeric(12,2);not null;default:0" json:"tip"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+
+	// Relationships
+	Rounds []Order `gorm:"foreignKey:TableSessionID" json:"rounds,omitempty"`
+}
+
+// TableName specifies the table name for TableSession
+func (TableSession) TableName() string {
+	return "table_sessions"
+}
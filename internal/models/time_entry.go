@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// StaffRate is the hourly wage of a staff member, in the restaurant's currency
+type StaffRate struct {
+	ID           uint        `gorm:"primaryKey" json:"-"`
+	RestaurantID uint        `gorm:"not null;uniqueIndex:idx_staff_rates_user" json:"restaurant_id"` // Crucial for RLS
+	UserID       uint        `gorm:"not null;uniqueIndex:idx_staff_rates_user" json:"user_id"`
+	HourlyRate   money.Cents `gorm:"type:numeric(12,2);not null" json:"hourly_rate"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for StaffRate
+func (StaffRate) TableName() string {
+	return "staff_rates"
+}
+
+// TimeEntry is a worked shift of a staff member, from clock-in to clock-out
+// The hourly rate is copied from the staff rate at clock-in, so later rate changes don't rewrite past labor cost
+// A staff member has at most one open entry (without ClockOutAt)
+type TimeEntry struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID uint        `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	UserID       uint        `gorm:"index;not null" json:"user_id"`
+	ClockInAt    time.Time   `gorm:"index;not null" json:"clock_in_at"`
+	ClockOutAt   *time.Time  `json:"clock_out_at,omitempty"`
+	HourlyRate   money.Cents `gorm:"type:numeric(12,2);not null" json:"hourly_rate"`
+	Notes        string      `gorm:"type:varchar(255)" json:"notes,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+}
+
+// TableName specifies the table name for TimeEntry
+func (TimeEntry) TableName() string {
+	return "time_entries"
+}
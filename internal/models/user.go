@@ -2,10 +2,15 @@ package models
 
 import (
 	"time"
+
+	"restaurant-backend/internal/pii"
+
+	"gorm.io/gorm"
 )
 
 // User represents a user (admin, staff, client, or KAM)
 // KAM users belong to the Platform Organization (restaurant_id = PlatformOrganizationID)
+// The phone is encrypted at rest; PhoneHash is a deterministic hash for exact lookups
 type User struct {
 	ID           uint      `gorm:"primaryKey" json:"id"`
 	RestaurantID uint      `gorm:"index;not null" json:"restaurant_id"` // Required - KAMs belong to Platform Organization
@@ -15,7 +20,8 @@ type User struct {
 	LastName     string    `json:"last_name"`
 	Role         string    `gorm:"type:varchar(20);not null" json:"role"` // Admin, Staff, Client, KAM (Key Account Manager)
 	IsActive     bool      `gorm:"default:true" json:"is_active"`
-	Phone        string    `gorm:"type:varchar(20)" json:"phone,omitempty"`
+	Phone        string    `gorm:"type:text;serializer:pii" json:"phone,omitempty"`
+	PhoneHash    string    `gorm:"type:varchar(64);index" json:"-"`
 	Timezone     string    `gorm:"type:varchar(50);default:'UTC'" json:"timezone"`
 	Language     string    `gorm:"type:varchar(10);default:'en'" json:"language"`
 	Preferences  string    `gorm:"type:jsonb;default:'{}'" json:"preferences,omitempty"` // JSON string for preferences
@@ -23,15 +29,39 @@ type User struct {
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
 
+	// EmailVerificationPending is set for self-registered accounts until the email address is verified;
+	// password login is refused until then
+	EmailVerificationPending bool `gorm:"not null;default:false" json:"email_verification_pending,omitempty"`
+
+	// LastLoginAt is when the user last signed in (password or SSO)
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+
+	// OrganizationID is set for org-scoped users who can access every location of the organization
+	OrganizationID *uint `gorm:"index" json:"organization_id,omitempty"`
+
+	// Version is incremented on every update and exposed as the ETag (optimistic locking)
+	Version int `gorm:"default:1;not null" json:"version"`
+
 	// Relationships
 	Restaurant *Restaurant `gorm:"foreignKey:RestaurantID" json:"restaurant,omitempty"`
 }
 
+// BeforeSave refreshes the lookup hash of the phone number
+func (u *User) BeforeSave(tx *gorm.DB) error {
+	u.PhoneHash = pii.HashPhone(u.Phone)
+	return nil
+}
+
 // IsKAM checks if user is a KAM
 func (u *User) IsKAM() bool {
 	return u.Role == "KAM"
 }
 
+// IsOrganizationUser checks if user is scoped to an organization (all its locations)
+func (u *User) IsOrganizationUser() bool {
+	return u.OrganizationID != nil
+}
+
 // IsPlatformUser checks if user belongs to the platform organization
 func (u *User) IsPlatformUser() bool {
 	return u.RestaurantID == PlatformOrganizationID
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// Invitation statuses, derived from the timestamps
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+	InvitationStatusExpired  = "expired"
+)
+
+// UserInvitation invites someone to join a restaurant with a role; the account is created when they accept
+// the emailed link and choose their own password
+type UserInvitation struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID   uint       `gorm:"not null;index" json:"restaurant_id"` // Crucial for RLS
+	Email          string     `gorm:"type:varchar(255);not null;index" json:"email"`
+	FirstName      string     `gorm:"type:varchar(100);not null" json:"first_name"`
+	LastName       string     `gorm:"type:varchar(100);not null" json:"last_name"`
+	Role           string     `gorm:"type:varchar(20);not null" json:"role"`
+	TokenHash      string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"-"` // SHA-256 of the accept link's token
+	InvitedBy      uint       `gorm:"not null" json:"invited_by"`
+	ExpiresAt      time.Time  `gorm:"not null" json:"expires_at"`
+	SentAt         time.Time  `gorm:"not null" json:"sent_at"` // Last time the link was (re)issued
+	AcceptedAt     *time.Time `json:"accepted_at,omitempty"`
+	AcceptedUserID *uint      `json:"accepted_user_id,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Status string `gorm:"-" json:"status"` // Derived from the timestamps when returned
+}
+
+// TableName specifies the table name for UserInvitation
+func (UserInvitation) TableName() string {
+	return "user_invitations"
+}
+
+// CurrentStatus derives the invitation's status at the given time
+func (i *UserInvitation) CurrentStatus(now time.Time) string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationStatusAccepted
+	case i.RevokedAt != nil:
+		return InvitationStatusRevoked
+	case !now.Before(i.ExpiresAt):
+		return InvitationStatusExpired
+	default:
+		return InvitationStatusPending
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// Capabilities an Admin can grant to or revoke from a staff member, overriding the Staff role's default
+const (
+	PermissionChangePrices       = "menu.change_prices"   // Change menu item prices
+	PermissionManagePricingRules = "pricing_rules.manage" // Create, change and delete happy hour pricing rules
+	PermissionExportData         = "data.export"          // Export orders, reservations and customers
+	PermissionModerateReviews    = "reviews.moderate"     // Publish and hide reviews
+	PermissionCloseDay           = "closeout.close"       // Close the day (Z report)
+)
+
+// UserPermission overrides the Staff role's default for one capability of one user
+// Admins always hold every capability; Clients none
+type UserPermission struct {
+	ID           uint      `gorm:"primaryKey" json:"-"`
+	RestaurantID uint      `gorm:"not null;index" json:"-"` // Crucial for RLS
+	UserID       uint      `gorm:"not null;uniqueIndex:idx_user_permissions_user_permission" json:"user_id"`
+	Permission   string    `gorm:"type:varchar(50);not null;uniqueIndex:idx_user_permissions_user_permission" json:"permission"`
+	Granted      bool      `gorm:"not null" json:"granted"`    // false revokes a capability the role has by default
+	GrantedBy    uint      `gorm:"not null" json:"granted_by"` // Admin who set the override
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for UserPermission
+func (UserPermission) TableName() string {
+	return "user_permissions"
+}
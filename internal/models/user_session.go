@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+)
+
+// UserSession is a login of a user on one device; every token issued for the login carries its SessionID
+// Revoking a session rejects its tokens at once, before they expire
+// Platform-wide table: tokens are checked before the tenant is known, so no RLS; always queried by user
+type UserSession struct {
+	ID         uint       `gorm:"primaryKey" json:"-"`
+	SessionID  string     `gorm:"type:varchar(64);not null;uniqueIndex" json:"id"` // "jti" claim of the session's tokens
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	IPAddress  string     `gorm:"type:varchar(45)" json:"ip_address"` // At login
+	UserAgent  string     `gorm:"type:text" json:"user_agent"`
+	LastSeenAt time.Time  `gorm:"not null" json:"last_seen_at"`
+	LastSeenIP string     `gorm:"type:varchar(45)" json:"last_seen_ip"`
+	ExpiresAt  time.Time  `gorm:"not null;index" json:"expires_at"` // Expiry of the latest token issued for the session
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for UserSession
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
+
+// IsActive reports whether the session's tokens are still accepted
+func (s *UserSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
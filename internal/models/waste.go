@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"restaurant-backend/internal/money"
+)
+
+// Waste reasons; theft is kept apart so variance reports can tell shrinkage from spoilage
+const (
+	WasteReasonSpoiled        = "spoiled"
+	WasteReasonExpired        = "expired"
+	WasteReasonDamaged        = "damaged"
+	WasteReasonOverproduction = "overproduction"
+	WasteReasonTheft          = "theft"
+	WasteReasonOther          = "other"
+)
+
+// WasteEntry records stock lost outside of sales: an ingredient, or servings of a menu item
+// deducted through its recipe. Cost is what the lost stock was worth at the time
+type WasteEntry struct {
+	ID           uint        `gorm:"primaryKey" json:"id"`
+	RestaurantID uint        `gorm:"not null;index:idx_waste_entries_restaurant_created" json:"restaurant_id"` // Crucial for RLS
+	MenuItemID   *uint       `gorm:"index" json:"menu_item_id,omitempty"`
+	IngredientID *uint       `gorm:"index" json:"ingredient_id,omitempty"`
+	Quantity     float64     `gorm:"type:numeric(12,3);not null" json:"quantity"` // Servings, or in the ingredient's unit
+	Reason       string      `gorm:"type:varchar(20);not null;index" json:"reason"`
+	Notes        string      `json:"notes"`
+	Cost         money.Cents `gorm:"type:numeric(12,2);not null;default:0" json:"cost"`
+	LoggedBy     uint        `gorm:"not null" json:"logged_by"`
+	CreatedAt    time.Time   `gorm:"index:idx_waste_entries_restaurant_created" json:"created_at"`
+
+	// Relationships
+	MenuItem   *MenuItem        `gorm:"foreignKey:MenuItemID;constraint:OnDelete:SET NULL" json:"-"`
+	Ingredient *Ingredient      `gorm:"foreignKey:IngredientID" json:"-"`
+	Lines      []WasteEntryLine `gorm:"foreignKey:WasteEntryID;constraint:OnDelete:CASCADE" json:"lines"`
+}
+
+// TableName specifies the table name for WasteEntry
+func (WasteEntry) TableName() string {
+	return "waste_entries"
+}
+
+// WasteEntryLine is the stock of one ingredient a waste entry deducted
+type WasteEntryLine struct {
+	ID           uint        `gorm:"primaryKey" json:"-"`
+	RestaurantID uint        `gorm:"index;not null" json:"-"` // Crucial for RLS
+	WasteEntryID uint        `gorm:"index;not null" json:"-"`
+	IngredientID uint        `gorm:"index;not null" json:"ingredient_id"`
+	Quantity     float64     `gorm:"type:numeric(12,3);not null" json:"quantity"` // In the ingredient's unit
+	Cost         money.Cents `gorm:"type:numeric(12,2);not null;default:0" json:"cost"`
+
+	// Relationships
+	Ingredient Ingredient `gorm:"foreignKey:IngredientID" json:"ingredient"`
+}
+
+// TableName specifies the table name for WasteEntryLine
+func (WasteEntryLine) TableName() string {
+	return "waste_entry_lines"
+}
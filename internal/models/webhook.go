@@ -0,0 +1,63 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookEndpoint represents an external URL subscribed to tenant events
+// (e.g., delivery aggregators or tenant websites syncing the menu)
+type WebhookEndpoint struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	RestaurantID    uint       `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	URL             string     `gorm:"not null" json:"url"`
+	Description     string     `json:"description"`
+	Secret          string     `gorm:"not null" json:"-"` // Used to sign payloads (HMAC-SHA256)
+	IsActive        bool       `gorm:"default:true" json:"is_active"`
+	LastDeliveredAt *time.Time `json:"last_delivered_at,omitempty"`
+	LastStatusCode  int        `json:"last_status_code,omitempty"`
+	FailureCount    int        `gorm:"default:0;not null" json:"failure_count"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relationships
+	Restaurant Restaurant `gorm:"foreignKey:RestaurantID" json:"-"`
+}
+
+// TableName specifies the table name for WebhookEndpoint
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// Webhook delivery states
+const (
+	WebhookDeliveryStatusPending   = "pending" // Waiting for its next attempt
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusFailed    = "failed" // Out of attempts
+)
+
+// WebhookDelivery is an event queued for a webhook endpoint, sent and retried by the delivery job
+// so deliveries survive restarts
+type WebhookDelivery struct {
+	ID             uint            `gorm:"primaryKey" json:"id"`
+	RestaurantID   uint            `gorm:"index;not null" json:"restaurant_id"` // Crucial for RLS
+	WebhookID      uint            `gorm:"index;not null" json:"webhook_id"`
+	Event          string          `gorm:"type:varchar(50);not null" json:"event"`
+	Payload        json.RawMessage `gorm:"type:jsonb;not null" json:"payload"`
+	Status         string          `gorm:"type:varchar(20);not null;default:'pending';index:idx_webhook_deliveries_due,priority:1" json:"status"`
+	Attempts       int             `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time       `gorm:"not null;index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at"` // Also the lease of a claimed delivery
+	LastStatusCode int             `json:"last_status_code,omitempty"`
+	Error          string          `json:"error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+
+	// Relationships
+	Webhook WebhookEndpoint `gorm:"foreignKey:WebhookID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName specifies the table name for WebhookDelivery
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,153 @@
+// Package money does currency arithmetic in integer cents, so totals always equal the sum of their stored lines
+//
+// Amounts are stored and served as decimals with two places (NUMERIC(12,2) columns, JSON numbers) and held as
+// Cents in between, which reads and writes both, so they are added and multiplied exactly. Anything that produces
+// fractions of a cent (percentages, tax, splits) rounds half away from zero to whole cents at that step
+package money
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Cents is an amount in the smallest unit of a two-decimal currency
+type Cents int64
+
+// FromFloat converts a decimal amount to cents, rounding half away from zero
+func FromFloat(amount float64) Cents {
+	return Cents(math.Round(amount * 100))
+}
+
+// Round rounds a decimal amount to whole cents
+func Round(amount float64) float64 {
+	return FromFloat(amount).Float()
+}
+
+// Float returns the amount as a decimal for storage and JSON
+func (c Cents) Float() float64 {
+	return float64(c) / 100
+}
+
+// Times returns the amount of a quantity at this unit price
+func (c Cents) Times(quantity int) Cents {
+	return c * Cents(quantity)
+}
+
+// Percent returns the given percentage of the amount, rounded to cents
+func (c Cents) Percent(percent float64) Cents {
+	return Cents(math.Round(float64(c) * percent / 100))
+}
+
+// ExcludingTax returns the net amount of a tax-inclusive amount at a tax rate in percent, rounded to cents
+// The tax is the difference, so net and tax always add up to the amount
+func (c Cents) ExcludingTax(ratePercent float64) Cents {
+	return Cents(math.Round(float64(c) / (1 + ratePercent/100)))
+}
+
+// String formats the amount with two decimals, e.g. "12.50"
+func (c Cents) String() string {
+	sign := ""
+	if c < 0 {
+		sign = "-"
+		c = -c
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, c/100, c%100)
+}
+
+// Parse parses a decimal amount such as "12.5" or "-3.999", rounding half away from zero to whole cents
+func Parse(s string) (Cents, error) {
+	s = strings.TrimSpace(s)
+	sign, digits := Cents(1), s
+	switch {
+	case strings.HasPrefix(digits, "-"):
+		sign, digits = -1, digits[1:]
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	}
+
+	units, fraction, _ := strings.Cut(digits, ".")
+	if units == "" && fraction == "" || !isDigits(units) || !isDigits(fraction) {
+		// Exponents and other number forms go through float parsing
+		amount, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+		return FromFloat(amount), nil
+	}
+
+	whole, err := strconv.ParseInt("0"+units, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	fraction += "000"
+	cents := Cents(whole)*100 + Cents(fraction[0]-'0')*10 + Cents(fraction[1]-'0')
+	if fraction[2] >= '5' {
+		cents++
+	}
+	return sign * cents, nil
+}
+
+// isDigits reports whether s only has decimal digits
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalJSON encodes the amount as a JSON number with two decimals
+func (c Cents) MarshalJSON() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalJSON decodes a JSON number (or a numeric string), rounding to whole cents
+func (c *Cents) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	amount, err := Parse(strings.Trim(s, `"`))
+	if err != nil {
+		return err
+	}
+	*c = amount
+	return nil
+}
+
+// Scan reads a NUMERIC column (or any numeric value) as cents
+func (c *Cents) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*c = 0
+		return nil
+	case []byte:
+		amount, err := Parse(string(v))
+		if err != nil {
+			return err
+		}
+		*c = amount
+	case string:
+		amount, err := Parse(v)
+		if err != nil {
+			return err
+		}
+		*c = amount
+	case float64:
+		*c = FromFloat(v)
+	case int64:
+		*c = Cents(v * 100)
+	default:
+		return fmt.Errorf("cannot scan %T into money.Cents", src)
+	}
+	return nil
+}
+
+// Value writes the amount as a decimal for NUMERIC columns
+func (c Cents) Value() (driver.Value, error) {
+	return c.String(), nil
+}
@@ -0,0 +1,218 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Cents
+		wantErr bool
+	}{
+		{in: "12.50", want: 1250},
+		{in: "12.5", want: 1250},
+		{in: "12", want: 1200},
+		{in: " 7.1 ", want: 710},
+		{in: "+1.5", want: 150},
+		{in: ".99", want: 99},
+		{in: "12.344", want: 1234},
+		{in: "12.345", want: 1235},
+		{in: "0.004", want: 0},
+		{in: "0.005", want: 1},
+		{in: "-3.999", want: -400},
+		{in: "-12.345", want: -1235},
+		{in: "-0.004", want: 0},
+		{in: "-0.005", want: -1},
+		{in: "1e2", want: 10000},
+		{in: "1.5E-1", want: 15},
+		{in: "", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "12.3.4", wantErr: true},
+		{in: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %d, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) failed: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  Cents
+		percent float64
+		want    Cents
+	}{
+		{"exact", 1000, 12.5, 125},
+		{"rounds up", 999, 10, 100},
+		{"rounds half away from zero", 5, 10, 1},
+		{"rounds down", 1234, 10, 123},
+		{"negative", -999, 10, -100},
+		{"zero percent", 1234, 0, 0},
+		{"full amount", 1234, 100, 1234},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.amount.Percent(tt.percent); got != tt.want {
+				t.Errorf("Cents(%d).Percent(%v) = %d, want %d", tt.amount, tt.percent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExcludingTax(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Cents
+		rate   float64
+		want   Cents
+	}{
+		{"exact", 1190, 19, 1000},
+		{"rounds down", 1000, 20, 833},
+		{"rounds up", 1000, 7, 935},
+		{"no tax", 1234, 0, 1234},
+		{"negative", -1190, 19, -1000},
+		{"zero", 0, 19, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net := tt.amount.ExcludingTax(tt.rate)
+			if net != tt.want {
+				t.Errorf("Cents(%d).ExcludingTax(%v) = %d, want %d", tt.amount, tt.rate, net, tt.want)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     interface{}
+		want    Cents
+		wantErr bool
+	}{
+		{name: "numeric bytes", src: []byte("12.30"), want: 1230},
+		{name: "negative numeric bytes", src: []byte("-0.05"), want: -5},
+		{name: "string", src: "7", want: 700},
+		{name: "float", src: 2.5, want: 250},
+		{name: "integer", src: int64(3), want: 300},
+		{name: "null", src: nil, want: 0},
+		{name: "invalid bytes", src: []byte("abc"), wantErr: true},
+		{name: "unsupported type", src: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Cents(99)
+			err := got.Scan(tt.src)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%v) = %d, want an error", tt.src, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%v) failed: %v", tt.src, err)
+			}
+			if got != tt.want {
+				t.Errorf("Scan(%v) = %d, want %d", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValueRoundTrip(t *testing.T) {
+	for _, amount := range []Cents{0, 5, -5, 1230, -1230, 100000000} {
+		value, err := amount.Value()
+		if err != nil {
+			t.Fatalf("Cents(%d).Value() failed: %v", amount, err)
+		}
+		s, ok := value.(string)
+		if !ok {
+			t.Fatalf("Cents(%d).Value() = %T, want a string", amount, value)
+		}
+
+		var got Cents
+		if err := got.Scan([]byte(s)); err != nil {
+			t.Fatalf("Scan(%q) failed: %v", s, err)
+		}
+		if got != amount {
+			t.Errorf("Cents(%d) round-tripped through %q as %d", amount, s, got)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type line struct {
+		Price Cents  `json:"price"`
+		Tip   *Cents `json:"tip,omitempty"`
+	}
+
+	tip := Cents(-5)
+	data, err := json.Marshal(line{Price: 1250, Tip: &tip})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if want := `{"price":12.50,"tip":-0.05}`; string(data) != want {
+		t.Errorf("Marshal = %s, want %s", data, want)
+	}
+
+	var decoded line
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Price != 1250 || decoded.Tip == nil || *decoded.Tip != tip {
+		t.Errorf("Unmarshal(%s) = %+v, want the marshalled line", data, decoded)
+	}
+
+	tests := []struct {
+		name    string
+		in      string
+		want    Cents
+		wantErr bool
+	}{
+		{name: "number", in: `{"price":12.5}`, want: 1250},
+		{name: "rounded number", in: `{"price":0.125}`, want: 13},
+		{name: "quoted", in: `{"price":"3.99"}`, want: 399},
+		{name: "negative", in: `{"price":-1.005}`, want: -101},
+		{name: "null keeps the amount", in: `{"price":null}`, want: 42},
+		{name: "invalid", in: `{"price":"abc"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := line{Price: 42}
+			err := json.Unmarshal([]byte(tt.in), &got)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Unmarshal(%s) = %+v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal(%s) failed: %v", tt.in, err)
+			}
+			if got.Price != tt.want {
+				t.Errorf("Unmarshal(%s) price = %d, want %d", tt.in, got.Price, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+// Package pdf writes simple text PDF documents (invoices, receipts) with optional images, without external dependencies
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"strings"
+)
+
+// A4 page size and margins in points
+const (
+	pageWidth  = 595.0
+	pageHeight = 842.0
+	margin     = 50.0
+)
+
+// Font sizes
+const (
+	headingSize = 14.0
+	textSize    = 9.0
+)
+
+// line is a line of text placed on a page, or an image when img is set
+type line struct {
+	y    float64
+	size float64
+	bold bool
+	text string
+
+	img           *picture
+	width, height float64
+}
+
+// picture is an image embedded once in the document as an RGB XObject
+type picture struct {
+	name   string
+	pixels image.Image
+}
+
+// Document is a multi-page PDF built line by line in a monospaced font
+// Monospacing lets callers align table columns with fmt padding
+type Document struct {
+	pages    [][]line
+	pictures []*picture
+	y        float64
+}
+
+// New creates an empty document
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+// Heading adds a bold heading line
+func (d *Document) Heading(text string) {
+	d.add(text, headingSize, true)
+}
+
+// Text adds a line of body text
+func (d *Document) Text(text string) {
+	d.add(text, textSize, false)
+}
+
+// Bold adds a bold line of body text
+func (d *Document) Bold(text string) {
+	d.add(text, textSize, true)
+}
+
+// Space adds an empty line
+func (d *Document) Space() {
+	d.add("", textSize, false)
+}
+
+// Image adds an image scaled to fit within maxWidth x maxHeight points, keeping its aspect ratio
+func (d *Document) Image(img image.Image, maxWidth, maxHeight float64) {
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return
+	}
+	scale := maxWidth / float64(bounds.Dx())
+	if s := maxHeight / float64(bounds.Dy()); s < scale {
+		scale = s
+	}
+	width, height := float64(bounds.Dx())*scale, float64(bounds.Dy())*scale
+
+	if d.y-height < margin {
+		d.newPage()
+	}
+	d.y -= height
+	pic := &picture{name: fmt.Sprintf("Im%d", len(d.pictures)+1), pixels: img}
+	d.pictures = append(d.pictures, pic)
+	page := len(d.pages) - 1
+	d.pages[page] = append(d.pages[page], line{y: d.y, img: pic, width: width, height: height})
+	d.y -= textSize * 0.6 // Gap below the image
+}
+
+// Columns returns the number of body text characters that fit on a line
+func Columns() int {
+	width := (pageWidth - 2*margin) / (textSize * 0.6) // Courier glyphs are 0.6em wide
+	return int(width)
+}
+
+// add places a line, starting a new page when the current one is full
+func (d *Document) add(text string, size float64, bold bool) {
+	height := size * 1.4
+	if d.y-height < margin {
+		d.newPage()
+	}
+	d.y -= height
+	page := len(d.pages) - 1
+	d.pages[page] = append(d.pages[page], line{y: d.y, size: size, bold: bold, text: text})
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, nil)
+	d.y = pageHeight - margin
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	// Objects 1-4 are fixed; each page adds a page and a content stream object, followed by one object per image
+	buf.WriteString("%PDF-1.4\n")
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+
+	xobjects := ""
+	if len(d.pictures) > 0 {
+		refs := make([]string, len(d.pictures))
+		for i, pic := range d.pictures {
+			refs[i] = fmt.Sprintf("/%s %d 0 R", pic.name, 5+2*len(d.pages)+i)
+		}
+		xobjects = fmt.Sprintf(" /XObject << %s >>", strings.Join(refs, " "))
+	}
+
+	for i, page := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >>%s >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, xobjects, 6+2*i,
+		))
+
+		var content bytes.Buffer
+		for _, l := range page {
+			if l.img != nil {
+				fmt.Fprintf(&content, "q %.2f 0 0 %.2f %.1f %.1f cm /%s Do Q\n", l.width, l.height, margin, l.y, l.img.name)
+				continue
+			}
+			if l.text == "" {
+				continue
+			}
+			font := "F1"
+			if l.bold {
+				font = "F2"
+			}
+			fmt.Fprintf(&content, "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n", font, l.size, margin, l.y, escape(l.text))
+		}
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()))
+	}
+
+	for _, pic := range d.pictures {
+		bounds := pic.pixels.Bounds()
+		data := compressRGB(pic.pixels)
+		object(fmt.Sprintf(
+			"<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream",
+			bounds.Dx(), bounds.Dy(), len(data), data,
+		))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.Bytes()
+}
+
+// compressRGB encodes an image as zlib-compressed 8-bit RGB rows
+// Transparent pixels are blended onto white, the page color
+func compressRGB(img image.Image) []byte {
+	bounds := img.Bounds()
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	row := make([]byte, 0, bounds.Dx()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		row = row[:0]
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA() // Alpha-premultiplied, 16 bits per channel
+			white := 0xffff - a
+			row = append(row, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+		_, _ = w.Write(row)
+	}
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+// escape encodes text as a PDF string literal body in WinAnsi (Latin-1) encoding
+// Characters outside Latin-1 are replaced with '?'
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32:
+			b.WriteByte(' ')
+		case r < 128:
+			b.WriteRune(r)
+		case r < 256:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
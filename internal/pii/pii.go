@@ -0,0 +1,220 @@
+package pii
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/logger"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"gorm.io/gorm/schema"
+)
+
+// ciphertextPrefix marks encrypted values; values without it are plaintext written before encryption was enabled
+const ciphertextPrefix = "enc:v1:"
+
+// developmentKeySeed derives the key used outside production when no key is configured
+const developmentKeySeed = "restaurant-backend development PII key"
+
+// ErrNotConfigured is returned when encrypted values are read or written before Setup
+var ErrNotConfigured = errors.New("PII encryption is not configured")
+
+// keys is the process-wide key set; gorm serializers are registered globally, so the keys are too
+var keys struct {
+	sync.RWMutex
+	aead    cipher.AEAD
+	hashKey []byte
+}
+
+// Models tag their encrypted fields with the serializer, so it must exist before any schema is parsed
+func init() {
+	schema.RegisterSerializer("pii", Serializer{})
+}
+
+// Setup loads the PII encryption key; encrypted fields can't be read or written before it ran
+// The key is PII_ENCRYPTION_KEY, or a data key decrypted with AWS KMS from PII_ENCRYPTION_KEY_KMS_CIPHERTEXT.
+// Outside production a fixed development key is used when neither is set
+func Setup(ctx context.Context, cfg *config.Config) error {
+	key, err := loadKey(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	return configure(key)
+}
+
+// loadKey returns the 32-byte data key from the configuration
+func loadKey(ctx context.Context, cfg *config.Config) ([]byte, error) {
+	switch {
+	case cfg.PIIEncryptionKeyKMSCiphertext != "":
+		blob, err := base64.StdEncoding.DecodeString(cfg.PIIEncryptionKeyKMSCiphertext)
+		if err != nil {
+			return nil, fmt.Errorf("PII_ENCRYPTION_KEY_KMS_CIPHERTEXT must be base64: %w", err)
+		}
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		if cfg.AWSAccessKeyID != "" && cfg.AWSSecretAccessKey != "" {
+			awsCfg.Credentials = credentials.NewStaticCredentialsProvider(cfg.AWSAccessKeyID, cfg.AWSSecretAccessKey, "")
+		}
+		out, err := kms.NewFromConfig(awsCfg).Decrypt(ctx, &kms.DecryptInput{CiphertextBlob: blob})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt PII data key with KMS: %w", err)
+		}
+		return out.Plaintext, nil
+	case cfg.PIIEncryptionKey != "":
+		key, err := base64.StdEncoding.DecodeString(cfg.PIIEncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("PII_ENCRYPTION_KEY must be base64: %w", err)
+		}
+		return key, nil
+	case cfg.Environment != "production":
+		logger.Warn("PII_ENCRYPTION_KEY is not set, customer contact data is encrypted with the development key")
+		key := sha256.Sum256([]byte(developmentKeySeed))
+		return key[:], nil
+	}
+	return nil, errors.New("PII_ENCRYPTION_KEY or PII_ENCRYPTION_KEY_KMS_CIPHERTEXT is required in production")
+}
+
+// configure installs the data key: AES-256-GCM for encryption and a derived HMAC key for lookup hashes
+func configure(key []byte) error {
+	if len(key) != 32 {
+		return fmt.Errorf("PII encryption key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return fmt.Errorf("failed to create PII cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return fmt.Errorf("failed to create PII cipher: %w", err)
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("pii-lookup-hash"))
+
+	keys.Lock()
+	defer keys.Unlock()
+	keys.aead = aead
+	keys.hashKey = mac.Sum(nil)
+	return nil
+}
+
+// Encrypt encrypts a value with a random nonce; empty values stay empty
+func Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	keys.RLock()
+	aead := keys.aead
+	keys.RUnlock()
+	if aead == nil {
+		return "", ErrNotConfigured
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return ciphertextPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts a value written by Encrypt; values without the ciphertext prefix are returned unchanged
+func Decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, ciphertextPrefix) {
+		return value, nil
+	}
+	keys.RLock()
+	aead := keys.aead
+	keys.RUnlock()
+	if aead == nil {
+		return "", ErrNotConfigured
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, ciphertextPrefix))
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed PII ciphertext")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt PII: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// HashEmail returns the deterministic lookup hash of an email address (case-insensitive); empty for empty input
+func HashEmail(email string) string {
+	return hash(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// HashPhone returns the deterministic lookup hash of a phone number; empty for empty input
+func HashPhone(phone string) string {
+	return hash(strings.TrimSpace(phone))
+}
+
+// HashToken returns the deterministic lookup hash of an API token, so only the hash has to be stored; empty for
+// empty input
+func HashToken(token string) string {
+	return hash(token)
+}
+
+// hash computes the hex-encoded HMAC-SHA256 of a normalized value
+func hash(value string) string {
+	if value == "" {
+		return ""
+	}
+	keys.RLock()
+	hashKey := keys.hashKey
+	keys.RUnlock()
+
+	mac := hmac.New(sha256.New, hashKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Serializer encrypts string fields tagged `gorm:"serializer:pii"` on write and decrypts them on read
+type Serializer struct{}
+
+// Scan decrypts the column value into the field
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("unsupported PII column value %T", dbValue)
+	}
+
+	plaintext, err := Decrypt(value)
+	if err != nil {
+		return err
+	}
+	field.ReflectValueOf(ctx, dst).SetString(plaintext)
+	return nil
+}
+
+// Value encrypts the field for storage
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	plaintext, ok := fieldValue.(string)
+	if !ok {
+		return nil, fmt.Errorf("PII serializer supports string fields only, got %T", fieldValue)
+	}
+	return Encrypt(plaintext)
+}
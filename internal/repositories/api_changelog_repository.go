@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIChangelogRepository handles API changelog-related database operations
+type APIChangelogRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIChangelogRepository creates a new APIChangelogRepository instance
+func NewAPIChangelogRepository(db *gorm.DB) *APIChangelogRepository {
+	return &APIChangelogRepository{db: db}
+}
+
+// CreateWithContext creates a new changelog entry using the provided context
+func (r *APIChangelogRepository) CreateWithContext(ctx context.Context, entry *models.APIChangelogEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// GetByIDWithContext retrieves a changelog entry by ID using the provided context
+func (r *APIChangelogRepository) GetByIDWithContext(ctx context.Context, id uint) (*models.APIChangelogEntry, error) {
+	var entry models.APIChangelogEntry
+	if err := r.db.WithContext(ctx).First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListPublishedWithContext lists entries published up to now, newest first
+// When since is non-zero, only entries published after it are returned
+func (r *APIChangelogRepository) ListPublishedWithContext(ctx context.Context, since time.Time) ([]models.APIChangelogEntry, error) {
+	var entries []models.APIChangelogEntry
+	query := r.db.WithContext(ctx).Where("published_at <= ?", time.Now())
+	if !since.IsZero() {
+		query = query.Where("published_at > ?", since)
+	}
+	if err := query.Order("published_at DESC, id DESC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ListDeprecationsWithContext lists published endpoint deprecations that have not yet been removed
+func (r *APIChangelogRepository) ListDeprecationsWithContext(ctx context.Context) ([]models.APIChangelogEntry, error) {
+	var entries []models.APIChangelogEntry
+	if err := r.db.WithContext(ctx).
+		Where("change_type = ? AND method <> '' AND path <> '' AND published_at <= ?", models.APIChangeDeprecated, time.Now()).
+		Order("sunset_at ASC NULLS LAST").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// DeleteWithContext deletes a changelog entry using the provided context
+func (r *APIChangelogRepository) DeleteWithContext(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.APIChangelogEntry{}, id).Error
+}
@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// archiveBatchSize is how many orders or reservations are moved per transaction, keeping locks short
+const archiveBatchSize = 500
+
+// ArchiveRepository moves old orders and reservations to the archive tables and reads them back
+type ArchiveRepository struct {
+	db *gorm.DB
+}
+
+// NewArchiveRepository creates a new ArchiveRepository instance
+func NewArchiveRepository(db *gorm.DB) *ArchiveRepository {
+	return &ArchiveRepository{db: db}
+}
+
+// RestaurantRetention is the age in days after which a restaurant's orders and reservations are archived
+type RestaurantRetention struct {
+	RestaurantID uint
+	Days         int
+}
+
+// ListRetentionsWithContext lists the restaurants that archive, with their archive_after_days setting or,
+// when that is 0, the platform default (restaurants with neither are left out)
+func (r *ArchiveRepository) ListRetentionsWithContext(ctx context.Context, defaultDays int) ([]RestaurantRetention, error) {
+	var retentions []RestaurantRetention
+	if err := r.db.WithContext(ctx).
+		Table("restaurants").
+		Select("restaurants.id AS restaurant_id, COALESCE(NULLIF(restaurant_settings.archive_after_days, 0), ?) AS days", defaultDays).
+		Joins("LEFT JOIN restaurant_settings ON restaurant_settings.restaurant_id = restaurants.id").
+		Where("COALESCE(NULLIF(restaurant_settings.archive_after_days, 0), ?) > 0", defaultDays).
+		Order("restaurants.id").
+		Scan(&retentions).Error; err != nil {
+		return nil, err
+	}
+	return retentions, nil
+}
+
+// ArchiveOrdersWithContext moves a restaurant's completed and cancelled orders created before the cutoff, with
+// their items, status history, splits, delivery assignments and course firings, to archived_orders, and returns
+// how many it moved
+// Orders with a review stay, as reviews are shown with their order. Each batch is its own transaction, so an
+// interrupted run keeps what it moved; orders being changed concurrently are skipped until the next run
+func (r *ArchiveRepository) ArchiveOrdersWithContext(ctx context.Context, restaurantID uint, cutoff time.Time) (int64, error) {
+	var archived int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return archived, err
+		}
+
+		var ids []uint
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Raw(`
+				WITH batch AS (
+					SELECT o.id FROM orders o
+					WHERE o.restaurant_id = @restaurant_id AND o.status IN @statuses AND o.created_at < @cutoff
+						AND NOT EXISTS (SELECT 1 FROM reviews rv WHERE rv.order_id = o.id)
+					ORDER BY o.id
+					LIMIT @limit
+					FOR UPDATE SKIP LOCKED
+				)
+				INSERT INTO archived_orders (id, restaurant_id, user_id, status, total_amount, created_at, archived_at, data)
+				SELECT o.id, o.restaurant_id, o.user_id, o.status, o.total_amount, o.created_at, NOW(),
+					to_jsonb(o) || jsonb_build_object(
+						'order_items', COALESCE((
+							SELECT jsonb_agg(to_jsonb(i) ORDER BY i.id) FROM order_items i WHERE i.order_id = o.id
+						), '[]'::jsonb),
+						'status_history', COALESCE((
+							SELECT jsonb_agg(to_jsonb(h) ORDER BY h.id) FROM order_status_changes h WHERE h.order_id = o.id
+						), '[]'::jsonb),
+						'splits', COALESCE((
+							SELECT jsonb_agg(to_jsonb(sp) || jsonb_build_object('items', COALESCE((
+								SELECT jsonb_agg(to_jsonb(si) ORDER BY si.id) FROM order_split_items si WHERE si.split_id = sp.id
+							), '[]'::jsonb)) ORDER BY sp.id) FROM order_splits sp WHERE sp.order_id = o.id
+						), '[]'::jsonb),
+						'delivery_assignments', COALESCE((
+							SELECT jsonb_agg(to_jsonb(da) ORDER BY da.id) FROM delivery_assignments da WHERE da.order_id = o.id
+						), '[]'::jsonb),
+						'course_firings', COALESCE((
+							SELECT jsonb_agg(to_jsonb(cf) ORDER BY cf.id) FROM order_course_firings cf WHERE cf.order_id = o.id
+						), '[]'::jsonb)
+					)
+				FROM orders o
+				JOIN batch ON batch.id = o.id
+				RETURNING id
+			`, map[string]interface{}{
+				"restaurant_id": restaurantID,
+				"statuses":      []string{models.OrderStatusCompleted, models.OrderStatusCancelled},
+				"cutoff":        cutoff,
+				"limit":         archiveBatchSize,
+			}).Scan(&ids).Error; err != nil {
+				return fmt.Errorf("failed to copy orders to the archive: %w", err)
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+
+			// Status changes, course firings and delivery assignments cascade with the order;
+			// splits and items have no cascade and go first
+			for _, stmt := range []struct{ table, sql string }{
+				{"order_split_items", "DELETE FROM order_split_items WHERE split_id IN (SELECT id FROM order_splits WHERE order_id IN ?)"},
+				{"order_splits", "DELETE FROM order_splits WHERE order_id IN ?"},
+				{"order_items", "DELETE FROM order_items WHERE order_id IN ?"},
+				{"orders", "DELETE FROM orders WHERE id IN ?"},
+			} {
+				if err := tx.Exec(stmt.sql, ids).Error; err != nil {
+					return fmt.Errorf("failed to delete archived %s: %w", stmt.table, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return archived, err
+		}
+
+		archived += int64(len(ids))
+		if len(ids) < archiveBatchSize {
+			return archived, nil
+		}
+	}
+}
+
+// ArchiveReservationsWithContext moves a restaurant's reservations that ended before the cutoff to
+// archived_reservations, in batches like ArchiveOrdersWithContext, and returns how many it moved
+// The guest contact fields stay encrypted in the snapshot; their lookup hashes are left out
+func (r *ArchiveRepository) ArchiveReservationsWithContext(ctx context.Context, restaurantID uint, cutoff time.Time) (int64, error) {
+	var archived int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return archived, err
+		}
+
+		var ids []uint
+		err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Raw(`
+				WITH batch AS (
+					SELECT r.id FROM reservations r
+					WHERE r.restaurant_id = @restaurant_id AND r.end_time < @cutoff
+					ORDER BY r.id
+					LIMIT @limit
+					FOR UPDATE SKIP LOCKED
+				)
+				INSERT INTO archived_reservations (id, restaurant_id, user_id, status, start_time, created_at, archived_at, data)
+				SELECT r.id, r.restaurant_id, r.user_id, r.status, r.start_time, r.created_at, NOW(),
+					to_jsonb(r) - 'guest_email_hash' - 'guest_phone_hash'
+				FROM reservations r
+				JOIN batch ON batch.id = r.id
+				RETURNING id
+			`, map[string]interface{}{
+				"restaurant_id": restaurantID,
+				"cutoff":        cutoff,
+				"limit":         archiveBatchSize,
+			}).Scan(&ids).Error; err != nil {
+				return fmt.Errorf("failed to copy reservations to the archive: %w", err)
+			}
+			if len(ids) == 0 {
+				return nil
+			}
+
+			if err := tx.Exec("DELETE FROM reservations WHERE id IN ?", ids).Error; err != nil {
+				return fmt.Errorf("failed to delete archived reservations: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			return archived, err
+		}
+
+		archived += int64(len(ids))
+		if len(ids) < archiveBatchSize {
+			return archived, nil
+		}
+	}
+}
+
+// ListOrdersWithContext lists a restaurant's archived orders created in [from, to), newest first, with the total
+func (r *ArchiveRepository) ListOrdersWithContext(ctx context.Context, restaurantID uint, from, to time.Time, limit, offset int) ([]models.ArchivedOrder, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ArchivedOrder{}).
+		Where("restaurant_id = ? AND created_at >= ? AND created_at < ?", restaurantID, from, to)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var orders []models.ArchivedOrder
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// ListReservationsWithContext lists a restaurant's archived reservations starting in [from, to), latest first,
+// with the total
+func (r *ArchiveRepository) ListReservationsWithContext(ctx context.Context, restaurantID uint, from, to time.Time, limit, offset int) ([]models.ArchivedReservation, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ArchivedReservation{}).
+		Where("restaurant_id = ? AND start_time >= ? AND start_time < ?", restaurantID, from, to)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reservations []models.ArchivedReservation
+	if err := query.Order("start_time DESC, id DESC").Limit(limit).Offset(offset).Find(&reservations).Error; err != nil {
+		return nil, 0, err
+	}
+	return reservations, total, nil
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository handles audit log-related database operations
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new AuditLogRepository instance
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{db: db}
+}
+
+// CreateWithContext creates a new audit log entry using the provided context
+func (r *AuditLogRepository) CreateWithContext(ctx context.Context, entry *models.AuditLog) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+// GetActionsAfterWithContext retrieves a restaurant's audit entries of the given actions with an ID above afterID, oldest first
+func (r *AuditLogRepository) GetActionsAfterWithContext(ctx context.Context, restaurantID uint, actions []string, afterID uint, limit int) ([]models.AuditLog, error) {
+	var entries []models.AuditLog
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND action IN ? AND id > ?", restaurantID, actions, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetLatestIDWithContext returns the highest audit entry ID of a restaurant for the given actions
+func (r *AuditLogRepository) GetLatestIDWithContext(ctx context.Context, restaurantID uint, actions []string) (uint, error) {
+	var id uint
+	if err := r.db.WithContext(ctx).Model(&models.AuditLog{}).
+		Where("restaurant_id = ? AND action IN ?", restaurantID, actions).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).Error; err != nil {
+		return 0, err
+	}
+	return id, nil
+}
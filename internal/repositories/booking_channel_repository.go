@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrBookingChannelExists is returned when a restaurant is already connected to the booking channel
+var ErrBookingChannelExists = errors.New("restaurant is already connected to this booking channel")
+
+// BookingChannelRepository handles external booking channel database operations
+type BookingChannelRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingChannelRepository creates a new BookingChannelRepository instance
+func NewBookingChannelRepository(db *gorm.DB) *BookingChannelRepository {
+	return &BookingChannelRepository{db: db}
+}
+
+// CreateWithContext creates a new booking channel
+func (r *BookingChannelRepository) CreateWithContext(ctx context.Context, channel *models.BookingChannel) error {
+	err := r.db.WithContext(ctx).Create(channel).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrBookingChannelExists
+	}
+	return err
+}
+
+// GetByIDForRestaurant retrieves a booking channel by ID, scoped to the restaurant
+func (r *BookingChannelRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.BookingChannel, error) {
+	var channel models.BookingChannel
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&channel, id).Error; err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// GetByRestaurantIDWithContext lists the booking channels of a restaurant
+func (r *BookingChannelRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.BookingChannel, error) {
+	var channels []models.BookingChannel
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("provider ASC").
+		Find(&channels).Error; err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+// GetByProviderWithContext retrieves the restaurant's channel of a provider
+func (r *BookingChannelRepository) GetByProviderWithContext(ctx context.Context, restaurantID uint, provider string) (*models.BookingChannel, error) {
+	var channel models.BookingChannel
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND provider = ?", restaurantID, provider).
+		First(&channel).Error; err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// GetByTokenHashWithContext retrieves a booking channel by the hash of its partner API token
+func (r *BookingChannelRepository) GetByTokenHashWithContext(ctx context.Context, tokenHash string) (*models.BookingChannel, error) {
+	var channel models.BookingChannel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&channel).Error; err != nil {
+		return nil, err
+	}
+	return &channel, nil
+}
+
+// SaveWithContext updates a booking channel
+func (r *BookingChannelRepository) SaveWithContext(ctx context.Context, channel *models.BookingChannel) error {
+	return r.db.WithContext(ctx).Save(channel).Error
+}
+
+// UpdateLastErrorWithContext records the outcome of the last push to the channel
+func (r *BookingChannelRepository) UpdateLastErrorWithContext(ctx context.Context, id uint, message string) error {
+	return r.db.WithContext(ctx).Model(&models.BookingChannel{}).Where("id = ?", id).Update("last_error", message).Error
+}
+
+// DeleteWithContext deletes a booking channel of a restaurant
+// Returns gorm.ErrRecordNotFound if no channel matched
+func (r *BookingChannelRepository) DeleteWithContext(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).Delete(&models.BookingChannel{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"gorm.io/gorm"
+)
+
+// BulkBatchSize is the default number of rows per INSERT of a bulk write
+const BulkBatchSize = 500
+
+// postgresMaxParams is the most bind parameters Postgres accepts in one statement
+const postgresMaxParams = 65535
+
+// CreateInBatches inserts a slice of models with multi-row INSERTs of up to batchSize rows each (BulkBatchSize
+// when 0), lowered so a statement stays under the bind parameter limit, and fills in their IDs in order
+// Has-many associations are inserted the same way, batched across the parents. The batches run in the
+// caller's transaction, or in one of their own; clauses on db (Omit, OnConflict) apply to every batch
+func CreateInBatches(db *gorm.DB, rows interface{}, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = BulkBatchSize
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(rows); err != nil {
+		return err
+	}
+	if columns := len(stmt.Schema.DBNames); columns > 0 && batchSize*columns > postgresMaxParams {
+		batchSize = postgresMaxParams / columns
+	}
+
+	return db.Session(&gorm.Session{CreateBatchSize: batchSize}).Create(rows).Error
+}
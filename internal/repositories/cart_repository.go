@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// CartRepository handles the carts of the public ordering flow
+type CartRepository struct {
+	db *gorm.DB
+}
+
+// NewCartRepository creates a new CartRepository instance
+func NewCartRepository(db *gorm.DB) *CartRepository {
+	return &CartRepository{db: db}
+}
+
+// CreateWithContext creates a new cart
+func (r *CartRepository) CreateWithContext(ctx context.Context, cart *models.Cart) error {
+	return r.db.WithContext(ctx).Create(cart).Error
+}
+
+// SaveWithContext updates a cart
+func (r *CartRepository) SaveWithContext(ctx context.Context, cart *models.Cart) error {
+	return r.db.WithContext(ctx).Save(cart).Error
+}
+
+// DeleteWithContext deletes a cart
+func (r *CartRepository) DeleteWithContext(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.Cart{}, id).Error
+}
+
+// active scopes a query to a restaurant's carts that weren't ordered and haven't expired
+func (r *CartRepository) active(ctx context.Context, restaurantID uint) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND order_id IS NULL AND expires_at > ?", restaurantID, time.Now())
+}
+
+// GetByTokenWithContext retrieves an active cart by its token
+func (r *CartRepository) GetByTokenWithContext(ctx context.Context, restaurantID uint, token string) (*models.Cart, error) {
+	var cart models.Cart
+	if err := r.active(ctx, restaurantID).Where("token = ?", token).First(&cart).Error; err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+// GetByUserWithContext retrieves a customer's active cart, the most recently changed one if there are several
+func (r *CartRepository) GetByUserWithContext(ctx context.Context, restaurantID, userID uint) (*models.Cart, error) {
+	var cart models.Cart
+	if err := r.active(ctx, restaurantID).Where("user_id = ?", userID).Order("updated_at DESC").First(&cart).Error; err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+// MarkOrderedWithContext records the order placed from a cart
+func (r *CartRepository) MarkOrderedWithContext(ctx context.Context, id, orderID uint) error {
+	return r.db.WithContext(ctx).Model(&models.Cart{}).Where("id = ?", id).Update("order_id", orderID).Error
+}
+
+// DeleteExpiredWithContext deletes the carts of every restaurant that expired before the given time
+func (r *CartRepository) DeleteExpiredWithContext(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", before).Delete(&models.Cart{})
+	return result.RowsAffected, result.Error
+}
+
+// GetAbandonedWithContext retrieves carts of every restaurant that were left unchanged since idleSince without being
+// ordered, of restaurants sending recovery emails, that have items, someone to email and no recovery email yet
+func (r *CartRepository) GetAbandonedWithContext(ctx context.Context, idleSince time.Time, limit int) ([]models.Cart, error) {
+	var carts []models.Cart
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN restaurant_settings ON restaurant_settings.restaurant_id = carts.restaurant_id").
+		Where("restaurant_settings.cart_recovery_email").
+		Where("carts.order_id IS NULL AND carts.recovery_email_at IS NULL").
+		Where("carts.updated_at < ? AND carts.expires_at > ?", idleSince, time.Now()).
+		Where("carts.items <> '[]'::jsonb").
+		Where("carts.email <> '' OR carts.user_id IS NOT NULL").
+		Preload("User").
+		Order("carts.updated_at ASC").
+		Limit(limit).
+		Find(&carts).Error; err != nil {
+		return nil, err
+	}
+	return carts, nil
+}
+
+// MarkRecoveryEmailSentWithContext records that the recovery email of a cart was sent, without touching updated_at
+func (r *CartRepository) MarkRecoveryEmailSentWithContext(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Cart{}).Where("id = ?", id).UpdateColumn("recovery_email_at", at).Error
+}
@@ -2,11 +2,18 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"restaurant-backend/internal/models"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrCategoryNotEmpty is returned when deleting a category that still has menu items
+var ErrCategoryNotEmpty = errors.New("category has menu items")
+
 // CategoryRepository handles menu category-related database operations
 type CategoryRepository struct {
 	db *gorm.DB
@@ -45,6 +52,17 @@ func (r *CategoryRepository) GetByIDWithContext(ctx context.Context, id uint) (*
 	return &category, nil
 }
 
+// GetByIDForRestaurant retrieves a category with its items by ID, scoped to the restaurant
+func (r *CategoryRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.MenuCategory, error) {
+	var category models.MenuCategory
+	if err := r.db.WithContext(ctx).Preload("MenuItems").
+		Where("restaurant_id = ?", restaurantID).
+		First(&category, id).Error; err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
 // GetByName retrieves a category by name
 func (r *CategoryRepository) GetByName(name string) (*models.MenuCategory, error) {
 	var category models.MenuCategory
@@ -75,10 +93,10 @@ func (r *CategoryRepository) GetByRestaurantID(restaurantID uint) ([]models.Menu
 	return categories, nil
 }
 
-// GetByRestaurantIDWithContext retrieves categories for a restaurant using context
+// GetByRestaurantIDWithContext retrieves the unarchived categories for a restaurant using context
 func (r *CategoryRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.MenuCategory, error) {
 	var categories []models.MenuCategory
-	if err := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ? AND archived_at IS NULL", restaurantID).
 		Preload("MenuItems", "is_available = ?", true).Order("display_order ASC").
 		Find(&categories).Error; err != nil {
 		return nil, err
@@ -86,6 +104,120 @@ func (r *CategoryRepository) GetByRestaurantIDWithContext(ctx context.Context, r
 	return categories, nil
 }
 
+// GetMenuByRestaurantIDWithContext retrieves a restaurant's categories that aren't archived with all their
+// items, drafts and unavailable ones included, and the items' images
+func (r *CategoryRepository) GetMenuByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.MenuCategory, error) {
+	var categories []models.MenuCategory
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ? AND archived_at IS NULL", restaurantID).
+		Preload("MenuItems", func(db *gorm.DB) *gorm.DB { return db.Order("display_order ASC") }).
+		Preload("MenuItems.Images").
+		Order("display_order ASC").
+		Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// GetPublishedByRestaurantIDWithContext retrieves the categories on a restaurant's public menu with their
+// available published items
+func (r *CategoryRepository) GetPublishedByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.MenuCategory, error) {
+	var categories []models.MenuCategory
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND archived_at IS NULL AND publish_status = ?", restaurantID, models.MenuPublishStatusPublished).
+		Preload("MenuItems", "is_available = ? AND publish_status = ?", true, models.MenuPublishStatusPublished).
+		Order("display_order ASC").
+		Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// ReorderWithContext sets the display order of a restaurant's unarchived categories to their position in ids
+// Returns ErrReorderMismatch unless ids lists every unarchived category of the restaurant exactly once
+func (r *CategoryRepository) ReorderWithContext(ctx context.Context, restaurantID uint, ids []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return reorderDisplayOrder(tx, &models.MenuCategory{}, ids, "restaurant_id = ? AND archived_at IS NULL", restaurantID)
+	})
+}
+
+// GetArchivedByRestaurantIDWithContext retrieves the archived categories of a restaurant with all their items,
+// most recently archived first
+func (r *CategoryRepository) GetArchivedByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.MenuCategory, error) {
+	var categories []models.MenuCategory
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ? AND archived_at IS NOT NULL", restaurantID).
+		Preload("MenuItems").Order("archived_at DESC").
+		Find(&categories).Error; err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// SetArchivedWithContext archives the category at archivedAt, or restores it when archivedAt is nil
+func (r *CategoryRepository) SetArchivedWithContext(ctx context.Context, id, restaurantID uint, archivedAt *time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.MenuCategory{}).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Updates(map[string]interface{}{"archived_at": archivedAt, "version": bumpVersion})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteEmptyWithContext deletes a category of the restaurant, returning ErrCategoryNotEmpty if it still has menu items
+func (r *CategoryRepository) DeleteEmptyWithContext(ctx context.Context, id, restaurantID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category models.MenuCategory
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("restaurant_id = ?", restaurantID).
+			First(&category, id).Error; err != nil {
+			return err
+		}
+
+		var items int64
+		if err := tx.Model(&models.MenuItem{}).Where("category_id = ?", id).Count(&items).Error; err != nil {
+			return err
+		}
+		if items > 0 {
+			return ErrCategoryNotEmpty
+		}
+
+		return tx.Delete(&category).Error
+	})
+}
+
+// DeleteMovingItemsWithContext moves all menu items of a category to targetID and deletes the category in one
+// transaction; returns the number of items moved
+func (r *CategoryRepository) DeleteMovingItemsWithContext(ctx context.Context, id, targetID, restaurantID uint) (int64, error) {
+	var moved int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.MenuItem{}).
+			Where("category_id = ? AND restaurant_id = ?", id, restaurantID).
+			Updates(map[string]interface{}{"category_id": targetID, "version": bumpVersion})
+		if result.Error != nil {
+			return result.Error
+		}
+		moved = result.RowsAffected
+
+		deleted := tx.Where("id = ? AND restaurant_id = ?", id, restaurantID).Delete(&models.MenuCategory{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		if deleted.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return moved, nil
+}
+
 // Update updates an existing category using provided updates map (only updates fields in the map)
 func (r *CategoryRepository) Update(id uint, updates map[string]interface{}) error {
 	if len(updates) == 0 {
@@ -95,11 +227,12 @@ func (r *CategoryRepository) Update(id uint, updates map[string]interface{}) err
 }
 
 // UpdateWithContext updates a category using the provided context
-func (r *CategoryRepository) UpdateWithContext(ctx context.Context, id uint, updates map[string]interface{}) error {
+// Returns ErrVersionConflict if the category's version no longer matches version
+func (r *CategoryRepository) UpdateWithContext(ctx context.Context, id uint, version int, updates map[string]interface{}) error {
 	if len(updates) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).Model(&models.MenuCategory{}).Where("id = ?", id).Updates(updates).Error
+	return updateVersioned(r.db.WithContext(ctx), &models.MenuCategory{}, id, version, updates)
 }
 
 // Delete deletes a category
@@ -111,3 +244,195 @@ func (r *CategoryRepository) Delete(id uint) error {
 func (r *CategoryRepository) DeleteWithContext(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.MenuCategory{}, id).Error
 }
+
+// CountByRestaurantIDWithContext counts the categories of a restaurant
+func (r *CategoryRepository) CountByRestaurantIDWithContext(ctx context.Context, restaurantID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.MenuCategory{}).
+		Where("restaurant_id = ?", restaurantID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// MenuCloneOptions controls how a menu is copied between restaurants
+type MenuCloneOptions struct {
+	IncludePrices     bool // When false, cloned items are created with a zero price
+	ResetAvailability bool // When true, all cloned categories and items are active/available
+	// CheckMenuItems, when set, is called with the number of items to clone before anything is written;
+	// its error aborts the clone
+	CheckMenuItems func(count int) error
+}
+
+// MenuCloneCounts summarizes the rows created by a menu clone
+type MenuCloneCounts struct {
+	Categories int `json:"categories"`
+	MenuItems  int `json:"menu_items"`
+	Images     int `json:"images"`
+}
+
+// CloneMenuWithContext copies all categories, menu items and item images from one restaurant to another in a transaction
+// The tenant context is switched (SET LOCAL) for reads and writes so RLS policies apply to each side
+func (r *CategoryRepository) CloneMenuWithContext(ctx context.Context, sourceRestaurantID, targetRestaurantID uint, opts MenuCloneOptions) (*MenuCloneCounts, error) {
+	counts := &MenuCloneCounts{}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL app.current_restaurant = %d", sourceRestaurantID)).Error; err != nil {
+			return err
+		}
+
+		// Only the published menu is cloned: archived categories and drafts aren't part of the live menu
+		var categories []models.MenuCategory
+		if err := tx.Where("restaurant_id = ? AND archived_at IS NULL AND publish_status = ?", sourceRestaurantID, models.MenuPublishStatusPublished).
+			Preload("MenuItems", "publish_status = ?", models.MenuPublishStatusPublished).Preload("MenuItems.Images").Order("display_order ASC").
+			Find(&categories).Error; err != nil {
+			return err
+		}
+		if opts.CheckMenuItems != nil {
+			count := 0
+			for _, category := range categories {
+				count += len(category.MenuItems)
+			}
+			if err := opts.CheckMenuItems(count); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec(fmt.Sprintf("SET LOCAL app.current_restaurant = %d", targetRestaurantID)).Error; err != nil {
+			return err
+		}
+
+		// Each level is inserted in batches; the new IDs come back in order and link the next level to its parent
+		// Zero values are replaced by column defaults on insert (and read back), so the inactive rows are
+		// noted first and restored at the end
+		newCategories := make([]models.MenuCategory, len(categories))
+		inactive := make([]bool, len(categories))
+		for i, category := range categories {
+			inactive[i] = !category.IsActive && !opts.ResetAvailability
+			newCategories[i] = models.MenuCategory{
+				RestaurantID: targetRestaurantID,
+				Name:         category.Name,
+				Description:  category.Description,
+				DisplayOrder: category.DisplayOrder,
+				IsActive:     category.IsActive || opts.ResetAvailability,
+			}
+		}
+		if len(newCategories) > 0 {
+			if err := CreateInBatches(tx.Omit(clause.Associations), &newCategories, 0); err != nil {
+				return err
+			}
+		}
+		var inactiveCategories []uint
+		for i, category := range newCategories {
+			if inactive[i] {
+				inactiveCategories = append(inactiveCategories, category.ID)
+			}
+		}
+		counts.Categories = len(newCategories)
+
+		var newItems []models.MenuItem
+		var sourceItems []models.MenuItem
+		var unavailable []bool
+		for i, category := range categories {
+			for _, item := range category.MenuItems {
+				newItem := models.MenuItem{
+					RestaurantID: targetRestaurantID,
+					CategoryID:   newCategories[i].ID,
+					Name:         item.Name,
+					Description:  item.Description,
+					ImageURL:     item.ImageURL,
+					DisplayOrder: item.DisplayOrder,
+					IsAvailable:  item.IsAvailable || opts.ResetAvailability,
+					Calories:     item.Calories,
+					ProteinGrams: item.ProteinGrams,
+					CarbsGrams:   item.CarbsGrams,
+					FatGrams:     item.FatGrams,
+					Tags:         item.Tags,
+				}
+				if opts.IncludePrices {
+					newItem.Price = item.Price
+					newItem.DineInPrice = item.DineInPrice
+					newItem.PickupPrice = item.PickupPrice
+					newItem.DeliveryPrice = item.DeliveryPrice
+					newItem.ThirdPartyPrice = item.ThirdPartyPrice
+				}
+				newItems = append(newItems, newItem)
+				sourceItems = append(sourceItems, item)
+				unavailable = append(unavailable, !newItem.IsAvailable)
+			}
+		}
+		if len(newItems) > 0 {
+			if err := CreateInBatches(tx.Omit(clause.Associations), &newItems, 0); err != nil {
+				return err
+			}
+		}
+		var unavailableItems []uint
+		for i, item := range newItems {
+			if unavailable[i] {
+				unavailableItems = append(unavailableItems, item.ID)
+			}
+		}
+		counts.MenuItems = len(newItems)
+
+		var newImages []models.MenuItemImage
+		for i, item := range sourceItems {
+			for _, image := range item.Images {
+				newImages = append(newImages, models.MenuItemImage{
+					RestaurantID: targetRestaurantID,
+					MenuItemID:   newItems[i].ID,
+					ImageURL:     image.ImageURL,
+					DisplayOrder: image.DisplayOrder,
+					IsPrimary:    image.IsPrimary,
+				})
+			}
+		}
+		if len(newImages) > 0 {
+			if err := CreateInBatches(tx.Omit(clause.Associations), &newImages, 0); err != nil {
+				return err
+			}
+		}
+		counts.Images = len(newImages)
+
+		if len(inactiveCategories) > 0 {
+			if err := tx.Model(&models.MenuCategory{}).Where("id IN ?", inactiveCategories).Update("is_active", false).Error; err != nil {
+				return err
+			}
+		}
+		if len(unavailableItems) > 0 {
+			if err := tx.Model(&models.MenuItem{}).Where("id IN ?", unavailableItems).Update("is_available", false).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// SetItemsAvailabilityWithContext sets is_available on every menu item in a category and records
+// the audit entry in the same transaction; returns the number of items updated
+func (r *CategoryRepository) SetItemsAvailabilityWithContext(ctx context.Context, categoryID, restaurantID uint, isAvailable bool, audit *models.AuditLog) (int64, error) {
+	var updated int64
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.MenuItem{}).
+			Where("category_id = ? AND restaurant_id = ?", categoryID, restaurantID).
+			Updates(map[string]interface{}{"is_available": isAvailable, "version": bumpVersion})
+		if result.Error != nil {
+			return result.Error
+		}
+		updated = result.RowsAffected
+
+		return tx.Create(audit).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
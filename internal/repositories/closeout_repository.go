@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrCloseoutExists is returned when the business day has already been closed
+var ErrCloseoutExists = errors.New("business day is already closed")
+
+// CloseoutRepository handles daily closeout (Z-report) database operations
+// Closeouts are only ever inserted; the database rejects updates and deletes
+type CloseoutRepository struct {
+	db *gorm.DB
+}
+
+// NewCloseoutRepository creates a new CloseoutRepository instance
+func NewCloseoutRepository(db *gorm.DB) *CloseoutRepository {
+	return &CloseoutRepository{db: db}
+}
+
+// CreateWithContext stores a closeout together with its payment breakdown
+func (r *CloseoutRepository) CreateWithContext(ctx context.Context, closeout *models.DailyCloseout) error {
+	err := r.db.WithContext(ctx).Create(closeout).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrCloseoutExists
+	}
+	return err
+}
+
+// GetByIDForRestaurant retrieves a closeout by ID, scoped to the restaurant
+func (r *CloseoutRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.DailyCloseout, error) {
+	var closeout models.DailyCloseout
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Preload("Payments").
+		First(&closeout, id).Error; err != nil {
+		return nil, err
+	}
+	return &closeout, nil
+}
+
+// GetByDateWithContext retrieves the closeout of a business day
+func (r *CloseoutRepository) GetByDateWithContext(ctx context.Context, restaurantID uint, businessDate time.Time) (*models.DailyCloseout, error) {
+	var closeout models.DailyCloseout
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND business_date = ?", restaurantID, businessDate.Format(time.DateOnly)).
+		Preload("Payments").
+		First(&closeout).Error; err != nil {
+		return nil, err
+	}
+	return &closeout, nil
+}
+
+// ListWithContext lists the closeouts of business days in [from, to], newest first
+func (r *CloseoutRepository) ListWithContext(ctx context.Context, restaurantID uint, from, to time.Time) ([]models.DailyCloseout, error) {
+	var closeouts []models.DailyCloseout
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND business_date BETWEEN ? AND ?", restaurantID, from.Format(time.DateOnly), to.Format(time.DateOnly)).
+		Preload("Payments").
+		Order("business_date DESC").
+		Find(&closeouts).Error; err != nil {
+		return nil, err
+	}
+	return closeouts, nil
+}
@@ -0,0 +1,248 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/pii"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrCustomerExists is returned when a write violates a customer deduplication index
+var ErrCustomerExists = errors.New("customer with the same email, phone or account already exists")
+
+// uniqueViolationCode is the PostgreSQL SQLSTATE for unique constraint violations
+const uniqueViolationCode = "23505"
+
+// CustomerRepository handles customer (CRM) database operations
+type CustomerRepository struct {
+	db *gorm.DB
+}
+
+// NewCustomerRepository creates a new CustomerRepository instance
+func NewCustomerRepository(db *gorm.DB) *CustomerRepository {
+	return &CustomerRepository{db: db}
+}
+
+// CreateWithContext creates a new customer
+func (r *CustomerRepository) CreateWithContext(ctx context.Context, customer *models.Customer) error {
+	return translateCustomerError(r.db.WithContext(ctx).Create(customer).Error)
+}
+
+// GetByIDWithContext retrieves a customer by ID (RLS ensures tenant isolation)
+func (r *CustomerRepository) GetByIDWithContext(ctx context.Context, id uint) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.WithContext(ctx).First(&customer, id).Error; err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// GetByIDForRestaurant retrieves a customer by ID, scoped to the restaurant
+func (r *CustomerRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&customer, id).Error; err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// GetByUserIDWithContext retrieves the customer linked to a user account
+func (r *CustomerRepository) GetByUserIDWithContext(ctx context.Context, restaurantID uint, userID uint) (*models.Customer, error) {
+	var customer models.Customer
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		First(&customer).Error; err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+// FindByContactWithContext retrieves the customers matching an email (case-insensitive) or phone
+// Contact fields are encrypted, so they are matched through their lookup hashes. Empty values are ignored
+func (r *CustomerRepository) FindByContactWithContext(ctx context.Context, restaurantID uint, email, phone string) ([]models.Customer, error) {
+	var customers []models.Customer
+	if email == "" && phone == "" {
+		return customers, nil
+	}
+	emailHash, phoneHash := pii.HashEmail(email), pii.HashPhone(phone)
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Where("(? <> '' AND email_hash = ?) OR (? <> '' AND phone_hash = ?)", emailHash, emailHash, phoneHash, phoneHash).
+		Order("id ASC").
+		Find(&customers).Error; err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
+// SearchWithContext finds a restaurant's customers by name, email or phone, most recent visitors first
+// An empty term lists all customers
+func (r *CustomerRepository) SearchWithContext(ctx context.Context, restaurantID uint, term string, limit, offset int) ([]models.Customer, int64, error) {
+	query := searchCustomers(r.db.WithContext(ctx).Model(&models.Customer{}), restaurantID, term)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var customers []models.Customer
+	if err := query.
+		Order("last_visit_at DESC NULLS LAST, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&customers).Error; err != nil {
+		return nil, 0, err
+	}
+	return customers, total, nil
+}
+
+// UpdateWithContext updates a customer
+func (r *CustomerRepository) UpdateWithContext(ctx context.Context, customer *models.Customer) error {
+	return translateCustomerError(r.db.WithContext(ctx).Save(customer).Error)
+}
+
+// ExportWithContext passes a restaurant's customers matching the search term to fn in batches,
+// oldest first; an empty term exports all customers
+func (r *CustomerRepository) ExportWithContext(ctx context.Context, restaurantID uint, term string, fn func([]models.Customer) error) error {
+	var batch []models.Customer
+	return searchCustomers(r.db.WithContext(ctx), restaurantID, term).
+		FindInBatches(&batch, exportBatchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
+// searchCustomers narrows the query to a restaurant's customers whose name contains the term
+// or whose email or phone is exactly the term (contact fields are encrypted, so they can't be searched partially)
+func searchCustomers(query *gorm.DB, restaurantID uint, term string) *gorm.DB {
+	query = query.Where("restaurant_id = ?", restaurantID)
+	if term = strings.TrimSpace(term); term != "" {
+		query = query.Where(`name ILIKE ? ESCAPE '\' OR email_hash = ? OR phone_hash = ?`, containsPattern(term), pii.HashEmail(term), pii.HashPhone(term))
+	}
+	return query
+}
+
+// customerOrdersSQL and customerReservationsSQL read the orders and reservations together with the archived ones,
+// so the customer aggregates don't change when the archive job moves them
+const (
+	customerOrdersSQL = `(
+		SELECT restaurant_id, user_id, status, total_amount, created_at, updated_at FROM orders
+		UNION ALL
+		SELECT restaurant_id, user_id, status, total_amount, created_at, (data->>'updated_at')::timestamptz FROM archived_orders
+	)`
+	customerReservationsSQL = `(
+		SELECT restaurant_id, user_id, status, start_time FROM reservations
+		UNION ALL
+		SELECT restaurant_id, user_id, status, start_time FROM archived_reservations
+	)`
+)
+
+// RefreshStatsWithContext recomputes a customer's order and visit aggregates from the linked user's
+// orders and reservations, archived ones included; customers without a linked account are left unchanged
+func (r *CustomerRepository) RefreshStatsWithContext(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE customers c SET
+			order_count = stats.order_count,
+			total_spent = stats.total_spent,
+			visit_count = stats.visit_count,
+			last_visit_at = stats.last_visit_at,
+			updated_at = NOW()
+		FROM (
+			SELECT
+				cu.id,
+				(SELECT COUNT(*) FROM `+customerOrdersSQL+` o
+					WHERE o.restaurant_id = cu.restaurant_id AND o.user_id = cu.user_id AND o.status <> 'cancelled') AS order_count,
+				(SELECT COALESCE(SUM(o.total_amount), 0) FROM `+customerOrdersSQL+` o
+					WHERE o.restaurant_id = cu.restaurant_id AND o.user_id = cu.user_id AND o.status <> 'cancelled') AS total_spent,
+				(SELECT COUNT(*) FROM `+customerOrdersSQL+` o
+					WHERE o.restaurant_id = cu.restaurant_id AND o.user_id = cu.user_id AND o.status = 'completed')
+				+ (SELECT COUNT(*) FROM `+customerReservationsSQL+` rv
+					WHERE rv.restaurant_id = cu.restaurant_id AND rv.user_id = cu.user_id AND rv.status = 'completed') AS visit_count,
+				GREATEST(
+					(SELECT MAX(o.updated_at) FROM `+customerOrdersSQL+` o
+						WHERE o.restaurant_id = cu.restaurant_id AND o.user_id = cu.user_id AND o.status = 'completed'),
+					(SELECT MAX(rv.start_time) FROM `+customerReservationsSQL+` rv
+						WHERE rv.restaurant_id = cu.restaurant_id AND rv.user_id = cu.user_id AND rv.status = 'completed')
+				) AS last_visit_at
+			FROM customers cu
+			WHERE cu.id = ? AND cu.user_id IS NOT NULL
+		) stats
+		WHERE c.id = stats.id
+	`, id).Error
+}
+
+// CustomerCohortStats classifies the completed orders placed in a period by customer cohort
+// Orders, archived ones included, are attributed to a customer through the customer's linked user account; a
+// customer is new when their first completed order falls in the period and returning when they had ordered before
+type CustomerCohortStats struct {
+	NewCustomers             int64
+	ReturningCustomers       int64
+	RepeatCustomers          int64 // Customers of the period with more than one completed order so far
+	NewCustomerOrders        int64
+	ReturningCustomerOrders  int64
+	UnattributedOrders       int64 // Orders of walk-ins and other guests without a customer record
+	NewCustomerRevenue       float64
+	ReturningCustomerRevenue float64
+	LifetimeCustomers        int64   // Customers with a completed order before the end of the period
+	LifetimeRevenue          float64 // Their completed order revenue before the end of the period
+}
+
+// GetCohortStatsWithContext computes the customer cohorts of the completed orders placed in [start, end)
+func (r *CustomerRepository) GetCohortStatsWithContext(ctx context.Context, restaurantID uint, start, end time.Time) (*CustomerCohortStats, error) {
+	var stats CustomerCohortStats
+	if err := r.db.WithContext(ctx).Raw(`
+		WITH customer_orders AS (
+			SELECT c.id AS customer_id, o.created_at, o.total_amount
+			FROM `+customerOrdersSQL+` o
+			JOIN customers c ON c.restaurant_id = o.restaurant_id AND c.user_id = o.user_id
+			WHERE o.restaurant_id = @restaurant_id AND o.status = 'completed' AND o.created_at < @end
+		), customer_summary AS (
+			SELECT customer_id,
+				MIN(created_at) AS first_order_at,
+				COUNT(*) AS lifetime_orders,
+				SUM(total_amount) AS lifetime_value,
+				COUNT(*) FILTER (WHERE created_at >= @start) AS period_orders,
+				COALESCE(SUM(total_amount) FILTER (WHERE created_at >= @start), 0) AS period_revenue
+			FROM customer_orders
+			GROUP BY customer_id
+		)
+		SELECT
+			COUNT(*) FILTER (WHERE first_order_at >= @start) AS new_customers,
+			COUNT(*) FILTER (WHERE first_order_at < @start AND period_orders > 0) AS returning_customers,
+			COUNT(*) FILTER (WHERE period_orders > 0 AND lifetime_orders > 1) AS repeat_customers,
+			COALESCE(SUM(period_orders) FILTER (WHERE first_order_at >= @start), 0) AS new_customer_orders,
+			COALESCE(SUM(period_orders) FILTER (WHERE first_order_at < @start), 0) AS returning_customer_orders,
+			(
+				SELECT COUNT(*) FROM `+customerOrdersSQL+` o
+				WHERE o.restaurant_id = @restaurant_id AND o.status = 'completed'
+					AND o.created_at >= @start AND o.created_at < @end
+					AND NOT EXISTS (
+						SELECT 1 FROM customers c WHERE c.restaurant_id = o.restaurant_id AND c.user_id = o.user_id
+					)
+			) AS unattributed_orders,
+			COALESCE(SUM(period_revenue) FILTER (WHERE first_order_at >= @start), 0) AS new_customer_revenue,
+			COALESCE(SUM(period_revenue) FILTER (WHERE first_order_at < @start), 0) AS returning_customer_revenue,
+			COUNT(*) AS lifetime_customers,
+			COALESCE(SUM(lifetime_value), 0) AS lifetime_revenue
+		FROM customer_summary
+	`, map[string]interface{}{"restaurant_id": restaurantID, "start": start, "end": end}).
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// translateCustomerError maps deduplication index violations to ErrCustomerExists
+func translateCustomerError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrCustomerExists
+	}
+	return err
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// DailyStatsRepository handles the daily_restaurant_stats rollups of orders and reservations
+// Days are dates in the restaurant's time zone, passed as YYYY-MM-DD
+type DailyStatsRepository struct {
+	db *gorm.DB
+}
+
+// NewDailyStatsRepository creates a new DailyStatsRepository instance
+func NewDailyStatsRepository(db *gorm.DB) *DailyStatsRepository {
+	return &DailyStatsRepository{db: db}
+}
+
+// RestaurantTimeZone is a restaurant with the time zone its days are cut in
+type RestaurantTimeZone struct {
+	RestaurantID uint
+	TimeZone     string
+}
+
+// RestaurantDay is one day of a restaurant whose rollup is stale
+type RestaurantDay struct {
+	RestaurantID uint
+	TimeZone     string
+	StatDate     time.Time
+}
+
+// rebuildDailyStatsSQL recomputes the rollups of a restaurant's days in [@from, @to) from the raw tables
+// Days without orders or reservations get no row
+const rebuildDailyStatsSQL = `
+	WITH bounds AS (
+		SELECT (@from::date)::timestamp AT TIME ZONE @tz AS start_at,
+			(@to::date)::timestamp AT TIME ZONE @tz AS end_at
+	), order_days AS (
+		SELECT (o.created_at AT TIME ZONE @tz)::date AS stat_date,
+			COUNT(*) AS total_orders,
+			COUNT(*) FILTER (WHERE o.status = 'pending') AS pending_orders,
+			COUNT(*) FILTER (WHERE o.status = 'completed') AS completed_orders,
+			COUNT(*) FILTER (WHERE o.status = 'cancelled') AS cancelled_orders,
+			COALESCE(SUM(o.total_amount) FILTER (WHERE o.status = 'completed'), 0) AS total_revenue
+		FROM orders o, bounds b
+		WHERE o.restaurant_id = @restaurant_id AND o.created_at >= b.start_at AND o.created_at < b.end_at
+		GROUP BY 1
+	), reservation_days AS (
+		SELECT (r.created_at AT TIME ZONE @tz)::date AS stat_date,
+			COUNT(*) AS total_reservations,
+			COUNT(*) FILTER (WHERE r.status = 'pending') AS pending_reservations,
+			COUNT(*) FILTER (WHERE r.status = 'confirmed') AS confirmed_reservations,
+			COUNT(*) FILTER (WHERE r.status = 'completed') AS completed_reservations,
+			COUNT(*) FILTER (WHERE r.status = 'cancelled') AS cancelled_reservations
+		FROM reservations r, bounds b
+		WHERE r.restaurant_id = @restaurant_id AND r.created_at >= b.start_at AND r.created_at < b.end_at
+		GROUP BY 1
+	)
+	INSERT INTO daily_restaurant_stats (
+		restaurant_id, stat_date,
+		total_orders, pending_orders, completed_orders, cancelled_orders, total_revenue,
+		total_reservations, pending_reservations, confirmed_reservations, completed_reservations, cancelled_reservations,
+		computed_at
+	)
+	SELECT @restaurant_id, COALESCE(o.stat_date, r.stat_date),
+		COALESCE(o.total_orders, 0), COALESCE(o.pending_orders, 0), COALESCE(o.completed_orders, 0),
+		COALESCE(o.cancelled_orders, 0), COALESCE(o.total_revenue, 0),
+		COALESCE(r.total_reservations, 0), COALESCE(r.pending_reservations, 0), COALESCE(r.confirmed_reservations, 0),
+		COALESCE(r.completed_reservations, 0), COALESCE(r.cancelled_reservations, 0),
+		NOW()
+	FROM order_days o
+	FULL JOIN reservation_days r ON r.stat_date = o.stat_date
+`
+
+// RebuildWithContext replaces the rollups of a restaurant's days in [from, to) (YYYY-MM-DD, in timeZone)
+func (r *DailyStatsRepository) RebuildWithContext(ctx context.Context, restaurantID uint, timeZone, from, to string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("restaurant_id = ? AND stat_date >= ? AND stat_date < ?", restaurantID, from, to).
+			Delete(&models.DailyRestaurantStats{}).Error; err != nil {
+			return err
+		}
+		return tx.Exec(rebuildDailyStatsSQL, map[string]interface{}{
+			"restaurant_id": restaurantID,
+			"tz":            timeZone,
+			"from":          from,
+			"to":            to,
+		}).Error
+	})
+}
+
+// ListRestaurantTimeZonesWithContext lists every restaurant with its time zone (UTC without settings)
+func (r *DailyStatsRepository) ListRestaurantTimeZonesWithContext(ctx context.Context) ([]RestaurantTimeZone, error) {
+	var zones []RestaurantTimeZone
+	if err := r.db.WithContext(ctx).
+		Table("restaurants").
+		Select("restaurants.id AS restaurant_id, COALESCE(restaurant_settings.time_zone, ?) AS time_zone", models.DefaultTimeZone).
+		Joins("LEFT JOIN restaurant_settings ON restaurant_settings.restaurant_id = restaurants.id").
+		Order("restaurants.id").
+		Scan(&zones).Error; err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// ListChangedDaysWithContext lists the days, in each restaurant's time zone, of the orders and
+// reservations updated since the given time
+func (r *DailyStatsRepository) ListChangedDaysWithContext(ctx context.Context, since time.Time) ([]RestaurantDay, error) {
+	var days []RestaurantDay
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT DISTINCT c.restaurant_id, COALESCE(s.time_zone, @default_tz) AS time_zone,
+			(c.created_at AT TIME ZONE COALESCE(s.time_zone, @default_tz))::date AS stat_date
+		FROM (
+			SELECT restaurant_id, created_at FROM orders WHERE updated_at >= @since
+			UNION ALL
+			SELECT restaurant_id, created_at FROM reservations WHERE updated_at >= @since
+		) c
+		LEFT JOIN restaurant_settings s ON s.restaurant_id = c.restaurant_id
+		ORDER BY c.restaurant_id, stat_date
+	`, map[string]interface{}{"since": since, "default_tz": models.DefaultTimeZone}).
+		Scan(&days).Error; err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// SumWithContext adds up a restaurant's rollups of the days in [from, to) (YYYY-MM-DD)
+func (r *DailyStatsRepository) SumWithContext(ctx context.Context, restaurantID uint, from, to string) (*OrderStats, *ReservationStats, error) {
+	var sums struct {
+		OrderStats
+		ReservationStats
+	}
+	if err := r.db.WithContext(ctx).
+		Model(&models.DailyRestaurantStats{}).
+		Select(`
+			COALESCE(SUM(total_orders), 0) AS total_orders,
+			COALESCE(SUM(pending_orders), 0) AS pending_orders,
+			COALESCE(SUM(completed_orders), 0) AS completed_orders,
+			COALESCE(SUM(cancelled_orders), 0) AS cancelled_orders,
+			COALESCE(SUM(total_revenue), 0) AS total_revenue,
+			COALESCE(SUM(total_reservations), 0) AS total_reservations,
+			COALESCE(SUM(pending_reservations), 0) AS pending_reservations,
+			COALESCE(SUM(confirmed_reservations), 0) AS confirmed_reservations,
+			COALESCE(SUM(completed_reservations), 0) AS completed_reservations,
+			COALESCE(SUM(cancelled_reservations), 0) AS cancelled_reservations`).
+		Where("restaurant_id = ? AND stat_date >= ? AND stat_date < ?", restaurantID, from, to).
+		Scan(&sums).Error; err != nil {
+		return nil, nil, err
+	}
+	return &sums.OrderStats, &sums.ReservationStats, nil
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDeliveryStarted is returned when changing the driver of a delivery that was already picked up
+var ErrDeliveryStarted = errors.New("delivery already picked up")
+
+// DeliveryAssignmentRepository handles delivery assignment database operations
+type DeliveryAssignmentRepository struct {
+	db *gorm.DB
+}
+
+// NewDeliveryAssignmentRepository creates a new DeliveryAssignmentRepository instance
+func NewDeliveryAssignmentRepository(db *gorm.DB) *DeliveryAssignmentRepository {
+	return &DeliveryAssignmentRepository{db: db}
+}
+
+// DeliveryAssignmentFilter filters delivery listings; nil fields are not filtered
+type DeliveryAssignmentFilter struct {
+	DriverID *uint
+	Status   *string
+}
+
+// AssignWithContext assigns an order to a driver, replacing the driver of an assignment not yet picked up
+func (r *DeliveryAssignmentRepository) AssignWithContext(ctx context.Context, assignment *models.DeliveryAssignment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.DeliveryAssignment
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("order_id = ?", assignment.OrderID).
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(assignment).Error
+		}
+		if err != nil {
+			return err
+		}
+		if existing.Status != models.DeliveryStatusAssigned {
+			return ErrDeliveryStarted
+		}
+
+		assignment.ID = existing.ID
+		assignment.CreatedAt = existing.CreatedAt
+		return tx.Save(assignment).Error
+	})
+}
+
+// GetByOrderWithContext retrieves the assignment of an order with its driver
+func (r *DeliveryAssignmentRepository) GetByOrderWithContext(ctx context.Context, orderID uint, restaurantID uint) (*models.DeliveryAssignment, error) {
+	var assignment models.DeliveryAssignment
+	if err := r.db.WithContext(ctx).
+		Where("order_id = ? AND restaurant_id = ?", orderID, restaurantID).
+		Preload("Driver").
+		First(&assignment).Error; err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// ListWithContext lists the latest delivery assignments of a restaurant with their drivers, newest first
+func (r *DeliveryAssignmentRepository) ListWithContext(ctx context.Context, restaurantID uint, filter DeliveryAssignmentFilter, limit int) ([]models.DeliveryAssignment, error) {
+	query := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID)
+	if filter.DriverID != nil {
+		query = query.Where("driver_id = ?", *filter.DriverID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var assignments []models.DeliveryAssignment
+	if err := query.Preload("Driver").Order("id DESC").Limit(limit).Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// ListOpenByDriverWithContext lists a driver's deliveries not yet delivered with their orders, oldest first
+func (r *DeliveryAssignmentRepository) ListOpenByDriverWithContext(ctx context.Context, driverID uint) ([]models.DeliveryAssignment, error) {
+	var assignments []models.DeliveryAssignment
+	if err := r.db.WithContext(ctx).
+		Where("driver_id = ? AND status <> ?", driverID, models.DeliveryStatusDelivered).
+		Preload("Order").
+		Preload("Order.User").
+		Preload("Order.OrderItems").
+		Preload("Order.OrderItems.MenuItem").
+		Order("assigned_at ASC").
+		Find(&assignments).Error; err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// TransitionWithContext moves a driver's delivery from one status to another, stamping the time
+// Returns gorm.ErrRecordNotFound if the driver has no such delivery in the from status
+func (r *DeliveryAssignmentRepository) TransitionWithContext(ctx context.Context, id, driverID uint, from, to string, at time.Time) (*models.DeliveryAssignment, error) {
+	updates := map[string]interface{}{"status": to}
+	switch to {
+	case models.DeliveryStatusPickedUp:
+		updates["picked_up_at"] = at
+	case models.DeliveryStatusDelivered:
+		updates["delivered_at"] = at
+	}
+
+	var assignment models.DeliveryAssignment
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.DeliveryAssignment{}).
+			Where("id = ? AND driver_id = ? AND status = ?", id, driverID, from).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.First(&assignment, id).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// DeleteAssignedWithContext removes an order's assignment that has not been picked up yet
+// Returns gorm.ErrRecordNotFound if the order has no such assignment
+func (r *DeliveryAssignmentRepository) DeleteAssignedWithContext(ctx context.Context, orderID uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("order_id = ? AND restaurant_id = ? AND status = ?", orderID, restaurantID, models.DeliveryStatusAssigned).
+		Delete(&models.DeliveryAssignment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
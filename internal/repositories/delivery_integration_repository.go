@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrDeliveryIntegrationExists is returned when a restaurant already has an integration with the provider
+var ErrDeliveryIntegrationExists = errors.New("restaurant is already connected to this delivery provider")
+
+// DeliveryIntegrationRepository handles delivery platform integration database operations
+type DeliveryIntegrationRepository struct {
+	db *gorm.DB
+}
+
+// NewDeliveryIntegrationRepository creates a new DeliveryIntegrationRepository instance
+func NewDeliveryIntegrationRepository(db *gorm.DB) *DeliveryIntegrationRepository {
+	return &DeliveryIntegrationRepository{db: db}
+}
+
+// CreateWithContext creates a new integration
+func (r *DeliveryIntegrationRepository) CreateWithContext(ctx context.Context, integration *models.DeliveryIntegration) error {
+	err := r.db.WithContext(ctx).Create(integration).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrDeliveryIntegrationExists
+	}
+	return err
+}
+
+// GetByIDForRestaurant retrieves an integration by ID, scoped to the restaurant
+func (r *DeliveryIntegrationRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.DeliveryIntegration, error) {
+	var integration models.DeliveryIntegration
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&integration, id).Error; err != nil {
+		return nil, err
+	}
+	return &integration, nil
+}
+
+// GetByRestaurantIDWithContext lists the integrations of a restaurant
+func (r *DeliveryIntegrationRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.DeliveryIntegration, error) {
+	var integrations []models.DeliveryIntegration
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("provider ASC").
+		Find(&integrations).Error; err != nil {
+		return nil, err
+	}
+	return integrations, nil
+}
+
+// ListEnabledWithContext lists the enabled integrations of all restaurants (background sync)
+func (r *DeliveryIntegrationRepository) ListEnabledWithContext(ctx context.Context) ([]models.DeliveryIntegration, error) {
+	var integrations []models.DeliveryIntegration
+	if err := r.db.WithContext(ctx).
+		Where("enabled = ?", true).
+		Order("id ASC").
+		Find(&integrations).Error; err != nil {
+		return nil, err
+	}
+	return integrations, nil
+}
+
+// SaveWithContext updates an integration
+func (r *DeliveryIntegrationRepository) SaveWithContext(ctx context.Context, integration *models.DeliveryIntegration) error {
+	return r.db.WithContext(ctx).Save(integration).Error
+}
+
+// UpdateSyncStateWithContext records the outcome of a menu push or order pull
+func (r *DeliveryIntegrationRepository) UpdateSyncStateWithContext(ctx context.Context, id uint, updates map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&models.DeliveryIntegration{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteWithContext deletes an integration of a restaurant
+// Returns gorm.ErrRecordNotFound if no integration matched
+func (r *DeliveryIntegrationRepository) DeleteWithContext(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).Delete(&models.DeliveryIntegration{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
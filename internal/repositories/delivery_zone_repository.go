@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// DeliveryZoneRepository handles delivery zone database operations
+type DeliveryZoneRepository struct {
+	db *gorm.DB
+}
+
+// NewDeliveryZoneRepository creates a new DeliveryZoneRepository instance
+func NewDeliveryZoneRepository(db *gorm.DB) *DeliveryZoneRepository {
+	return &DeliveryZoneRepository{db: db}
+}
+
+// CreateWithContext creates a new delivery zone
+func (r *DeliveryZoneRepository) CreateWithContext(ctx context.Context, zone *models.DeliveryZone) error {
+	return r.db.WithContext(ctx).Create(zone).Error
+}
+
+// GetByIDForRestaurant retrieves a delivery zone by ID, scoped to the restaurant
+func (r *DeliveryZoneRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.DeliveryZone, error) {
+	var zone models.DeliveryZone
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&zone, id).Error; err != nil {
+		return nil, err
+	}
+	return &zone, nil
+}
+
+// GetByRestaurantIDWithContext lists a restaurant's delivery zones in matching order
+// activeOnly restricts the list to the zones currently delivered to
+func (r *DeliveryZoneRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint, activeOnly bool) ([]models.DeliveryZone, error) {
+	query := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var zones []models.DeliveryZone
+	if err := query.Order("priority ASC, id ASC").Find(&zones).Error; err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+// UpdateWithContext saves a delivery zone
+func (r *DeliveryZoneRepository) UpdateWithContext(ctx context.Context, zone *models.DeliveryZone) error {
+	return r.db.WithContext(ctx).Save(zone).Error
+}
+
+// DeleteForRestaurant deletes a delivery zone scoped to the restaurant
+// Returns gorm.ErrRecordNotFound if the zone doesn't exist
+func (r *DeliveryZoneRepository) DeleteForRestaurant(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Delete(&models.DeliveryZone{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrReorderMismatch is returned when a reorder list doesn't name every row in scope exactly once
+var ErrReorderMismatch = errors.New("reorder list must contain every entry exactly once")
+
+// reorderDisplayOrder sets display_order to each row's position in ids with a single update. The rows
+// matched by the scope condition are locked first and must be exactly the rows listed
+func reorderDisplayOrder(tx *gorm.DB, model interface{}, ids []uint, scope string, args ...interface{}) error {
+	var existing []uint
+	if err := tx.Model(model).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(scope, args...).
+		Pluck("id", &existing).Error; err != nil {
+		return err
+	}
+
+	listed := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		listed[id] = true
+	}
+	if len(listed) != len(ids) || len(existing) != len(ids) {
+		return ErrReorderMismatch
+	}
+	for _, id := range existing {
+		if !listed[id] {
+			return ErrReorderMismatch
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var position strings.Builder
+	positionArgs := make([]interface{}, 0, len(ids)*2)
+	position.WriteString("CASE id")
+	for i, id := range ids {
+		position.WriteString(" WHEN ? THEN ?")
+		positionArgs = append(positionArgs, id, i)
+	}
+	position.WriteString(" END")
+
+	return tx.Model(model).
+		Where("id IN ?", ids).
+		Updates(map[string]interface{}{
+			"display_order": gorm.Expr(position.String(), positionArgs...),
+			"version":       bumpVersion,
+		}).Error
+}
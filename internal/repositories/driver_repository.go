@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// DriverRepository handles driver database operations
+type DriverRepository struct {
+	db *gorm.DB
+}
+
+// NewDriverRepository creates a new DriverRepository instance
+func NewDriverRepository(db *gorm.DB) *DriverRepository {
+	return &DriverRepository{db: db}
+}
+
+// CreateWithContext creates a new driver
+func (r *DriverRepository) CreateWithContext(ctx context.Context, driver *models.Driver) error {
+	return r.db.WithContext(ctx).Create(driver).Error
+}
+
+// GetByIDForRestaurant retrieves a driver by ID, scoped to the restaurant
+func (r *DriverRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.Driver, error) {
+	var driver models.Driver
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&driver, id).Error; err != nil {
+		return nil, err
+	}
+	return &driver, nil
+}
+
+// GetByRestaurantIDWithContext lists the drivers of a restaurant
+func (r *DriverRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.Driver, error) {
+	var drivers []models.Driver
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("name ASC").
+		Find(&drivers).Error; err != nil {
+		return nil, err
+	}
+	return drivers, nil
+}
+
+// GetByTokenWithContext retrieves a driver by their token
+func (r *DriverRepository) GetByTokenWithContext(ctx context.Context, token string) (*models.Driver, error) {
+	var driver models.Driver
+	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&driver).Error; err != nil {
+		return nil, err
+	}
+	return &driver, nil
+}
+
+// SaveWithContext updates a driver
+func (r *DriverRepository) SaveWithContext(ctx context.Context, driver *models.Driver) error {
+	return r.db.WithContext(ctx).Save(driver).Error
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrVerificationUsed is returned when a verification token was redeemed or expired concurrently
+var ErrVerificationUsed = errors.New("verification token was already used")
+
+// EmailVerificationRepository handles email verification token database operations
+type EmailVerificationRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailVerificationRepository creates a new EmailVerificationRepository instance
+func NewEmailVerificationRepository(db *gorm.DB) *EmailVerificationRepository {
+	return &EmailVerificationRepository{db: db}
+}
+
+// CreateWithContext stores a new verification token
+func (r *EmailVerificationRepository) CreateWithContext(ctx context.Context, verification *models.EmailVerification) error {
+	return r.db.WithContext(ctx).Create(verification).Error
+}
+
+// GetByTokenHashWithContext retrieves a verification by the hash of its token
+func (r *EmailVerificationRepository) GetByTokenHashWithContext(ctx context.Context, tokenHash string) (*models.EmailVerification, error) {
+	var verification models.EmailVerification
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&verification).Error; err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// CountSinceWithContext counts the verification tokens issued to a user since the given time
+func (r *EmailVerificationRepository) CountSinceWithContext(ctx context.Context, userID uint, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.EmailVerification{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// VerifyWithContext redeems a token and marks its user's email address verified in one transaction
+// Returns ErrVerificationUsed if the token was used or expired in the meantime
+func (r *EmailVerificationRepository) VerifyWithContext(ctx context.Context, verification *models.EmailVerification, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.EmailVerification{}).
+			Where("id = ? AND used_at IS NULL AND expires_at > ?", verification.ID, at).
+			Update("used_at", at)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVerificationUsed
+		}
+
+		return tx.Model(&models.User{}).
+			Where("id = ?", verification.UserID).
+			UpdateColumn("email_verification_pending", false).Error
+	})
+}
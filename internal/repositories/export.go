@@ -0,0 +1,5 @@
+package repositories
+
+// exportBatchSize is how many rows an export reads at a time, so exports stream instead of
+// loading whole tables into memory
+const exportBatchSize = 500
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrTableNumberExists is returned when a restaurant already has a table with the number
+var ErrTableNumberExists = errors.New("table number already exists")
+
+// FloorPlanRepository handles floor section and dining table database operations
+type FloorPlanRepository struct {
+	db *gorm.DB
+}
+
+// NewFloorPlanRepository creates a new FloorPlanRepository instance
+func NewFloorPlanRepository(db *gorm.DB) *FloorPlanRepository {
+	return &FloorPlanRepository{db: db}
+}
+
+// GetSectionsWithContext lists the floor sections of a restaurant with their tables, in display order
+func (r *FloorPlanRepository) GetSectionsWithContext(ctx context.Context, restaurantID uint) ([]models.FloorSection, error) {
+	var sections []models.FloorSection
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Preload("Tables", func(db *gorm.DB) *gorm.DB {
+			return db.Order("number ASC")
+		}).
+		Order("sort_order ASC, id ASC").
+		Find(&sections).Error; err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// CreateSectionWithContext creates a floor section
+func (r *FloorPlanRepository) CreateSectionWithContext(ctx context.Context, section *models.FloorSection) error {
+	return r.db.WithContext(ctx).Create(section).Error
+}
+
+// GetSectionForRestaurant retrieves a floor section by ID, scoped to the restaurant
+func (r *FloorPlanRepository) GetSectionForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.FloorSection, error) {
+	var section models.FloorSection
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&section, id).Error; err != nil {
+		return nil, err
+	}
+	return &section, nil
+}
+
+// UpdateSectionWithContext updates a floor section
+func (r *FloorPlanRepository) UpdateSectionWithContext(ctx context.Context, section *models.FloorSection) error {
+	return r.db.WithContext(ctx).Omit("Tables").Save(section).Error
+}
+
+// DeleteSectionForRestaurant deletes a floor section, scoped to the restaurant; its tables are deleted by cascade
+func (r *FloorPlanRepository) DeleteSectionForRestaurant(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Delete(&models.FloorSection{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CreateTableWithContext creates a dining table
+func (r *FloorPlanRepository) CreateTableWithContext(ctx context.Context, table *models.DiningTable) error {
+	return translateTableError(r.db.WithContext(ctx).Create(table).Error)
+}
+
+// GetTableForRestaurant retrieves a dining table by ID, scoped to the restaurant
+func (r *FloorPlanRepository) GetTableForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.DiningTable, error) {
+	var table models.DiningTable
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&table, id).Error; err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// GetTableByNumberWithContext retrieves a dining table by its number
+func (r *FloorPlanRepository) GetTableByNumberWithContext(ctx context.Context, restaurantID uint, number string) (*models.DiningTable, error) {
+	var table models.DiningTable
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND number = ?", restaurantID, number).
+		First(&table).Error; err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// UpdateTableWithContext updates a dining table
+func (r *FloorPlanRepository) UpdateTableWithContext(ctx context.Context, table *models.DiningTable) error {
+	return translateTableError(r.db.WithContext(ctx).Save(table).Error)
+}
+
+// DeleteTableForRestaurant deletes a dining table, scoped to the restaurant
+func (r *FloorPlanRepository) DeleteTableForRestaurant(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Delete(&models.DiningTable{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// translateTableError maps table number unique violations to ErrTableNumberExists
+func translateTableError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrTableNumberExists
+	}
+	return err
+}
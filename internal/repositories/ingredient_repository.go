@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrIngredientExists is returned when a restaurant already has an ingredient with the name
+var ErrIngredientExists = errors.New("ingredient already exists")
+
+// ErrIngredientInUse is returned when a deleted ingredient is still used by recipes, purchase orders or waste entries
+var ErrIngredientInUse = errors.New("ingredient is used by recipes, purchase orders or waste entries")
+
+// foreignKeyViolationCode is the PostgreSQL SQLSTATE for foreign key violations
+const foreignKeyViolationCode = "23503"
+
+// IngredientRepository handles ingredient and recipe database operations
+type IngredientRepository struct {
+	db *gorm.DB
+}
+
+// NewIngredientRepository creates a new IngredientRepository instance
+func NewIngredientRepository(db *gorm.DB) *IngredientRepository {
+	return &IngredientRepository{db: db}
+}
+
+// CreateWithContext creates an ingredient
+func (r *IngredientRepository) CreateWithContext(ctx context.Context, ingredient *models.Ingredient) error {
+	return translateIngredientError(r.db.WithContext(ctx).Omit("Supplier").Create(ingredient).Error)
+}
+
+// GetByIDForRestaurant retrieves an ingredient by ID, scoped to the restaurant
+func (r *IngredientRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.Ingredient, error) {
+	var ingredient models.Ingredient
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&ingredient, id).Error; err != nil {
+		return nil, err
+	}
+	return &ingredient, nil
+}
+
+// GetByRestaurantIDWithContext lists the ingredients of a restaurant by name
+func (r *IngredientRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.Ingredient, error) {
+	var ingredients []models.Ingredient
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("name ASC").
+		Find(&ingredients).Error; err != nil {
+		return nil, err
+	}
+	return ingredients, nil
+}
+
+// GetByIDsWithContext retrieves the restaurant's ingredients among the IDs; unknown IDs are left out
+func (r *IngredientRepository) GetByIDsWithContext(ctx context.Context, restaurantID uint, ids []uint) ([]models.Ingredient, error) {
+	var ingredients []models.Ingredient
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND id IN ?", restaurantID, ids).
+		Find(&ingredients).Error; err != nil {
+		return nil, err
+	}
+	return ingredients, nil
+}
+
+// UpdateWithContext updates an ingredient
+func (r *IngredientRepository) UpdateWithContext(ctx context.Context, ingredient *models.Ingredient) error {
+	return translateIngredientError(r.db.WithContext(ctx).Omit("Supplier").Save(ingredient).Error)
+}
+
+// DeleteForRestaurant deletes an ingredient, scoped to the restaurant
+// Returns gorm.ErrRecordNotFound if no ingredient matched and ErrIngredientInUse if recipes, purchase orders or waste entries use it
+func (r *IngredientRepository) DeleteForRestaurant(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Delete(&models.Ingredient{})
+	if result.Error != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(result.Error, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return ErrIngredientInUse
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetRecipeWithContext lists the recipe lines of a menu item with their ingredients
+func (r *IngredientRepository) GetRecipeWithContext(ctx context.Context, restaurantID, menuItemID uint) ([]models.MenuItemIngredient, error) {
+	var lines []models.MenuItemIngredient
+	if err := r.db.WithContext(ctx).
+		Preload("Ingredient").
+		Where("restaurant_id = ? AND menu_item_id = ?", restaurantID, menuItemID).
+		Order("id ASC").
+		Find(&lines).Error; err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// GetRecipesWithContext lists the recipe lines of every menu item of a restaurant with their ingredients
+func (r *IngredientRepository) GetRecipesWithContext(ctx context.Context, restaurantID uint) ([]models.MenuItemIngredient, error) {
+	var lines []models.MenuItemIngredient
+	if err := r.db.WithContext(ctx).
+		Preload("Ingredient").
+		Where("restaurant_id = ?", restaurantID).
+		Order("menu_item_id ASC, id ASC").
+		Find(&lines).Error; err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// ReplaceRecipeWithContext replaces the recipe lines of a menu item
+func (r *IngredientRepository) ReplaceRecipeWithContext(ctx context.Context, restaurantID, menuItemID uint, lines []models.MenuItemIngredient) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("restaurant_id = ? AND menu_item_id = ?", restaurantID, menuItemID).
+			Delete(&models.MenuItemIngredient{}).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+		return tx.Omit("MenuItem", "Ingredient").Create(&lines).Error
+	})
+}
+
+// translateIngredientError maps unique violations of the ingredient name to ErrIngredientExists
+func translateIngredientError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrIngredientExists
+	}
+	return err
+}
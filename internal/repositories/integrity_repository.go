@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IntegrityRepository handles tenant integrity scans and findings
+type IntegrityRepository struct {
+	db *gorm.DB
+}
+
+// NewIntegrityRepository creates a new IntegrityRepository instance
+func NewIntegrityRepository(db *gorm.DB) *IntegrityRepository {
+	return &IntegrityRepository{db: db}
+}
+
+// integrityCheck is a scan query returning row_id, restaurant_id and details for each anomaly
+type integrityCheck struct {
+	name        string
+	sourceTable string
+	query       string
+}
+
+// integrityChecks are the cross-tenant anomaly scans; source tables double as the quarantine allow-list
+var integrityChecks = []integrityCheck{
+	{
+		name:        models.IntegrityCheckOrderItemTenant,
+		sourceTable: "order_items",
+		query: `
+			SELECT oi.id AS row_id, oi.restaurant_id,
+				format('order item of restaurant %s references menu item %s of restaurant %s', oi.restaurant_id, mi.id, mi.restaurant_id) AS details
+			FROM order_items oi
+			JOIN menu_items mi ON mi.id = oi.menu_item_id
+			WHERE mi.restaurant_id <> oi.restaurant_id`,
+	},
+	{
+		name:        models.IntegrityCheckReservationUser,
+		sourceTable: "reservations",
+		query: `
+			SELECT r.id AS row_id, r.restaurant_id,
+				format('reservation references missing user %s', r.user_id) AS details
+			FROM reservations r
+			LEFT JOIN users u ON u.id = r.user_id
+			WHERE u.id IS NULL`,
+	},
+	{
+		name:        models.IntegrityCheckImagePrefix,
+		sourceTable: "menu_item_images",
+		query: `
+			SELECT id AS row_id, restaurant_id,
+				format('image %s is not stored under restaurant-%s/', image_url, restaurant_id) AS details
+			FROM menu_item_images
+			WHERE image_url ~ 'restaurant-[0-9]+/'
+				AND image_url NOT LIKE '%restaurant-' || restaurant_id || '/%'`,
+	},
+	{
+		name:        models.IntegrityCheckImagePrefix,
+		sourceTable: "menu_items",
+		query: `
+			SELECT id AS row_id, restaurant_id,
+				format('menu item image %s is not stored under restaurant-%s/', image_url, restaurant_id) AS details
+			FROM menu_items
+			WHERE image_url ~ 'restaurant-[0-9]+/'
+				AND image_url NOT LIKE '%restaurant-' || restaurant_id || '/%'`,
+	},
+}
+
+// integrityRow is a single scan result
+type integrityRow struct {
+	RowID        uint
+	RestaurantID uint
+	Details      string
+}
+
+// withPlatformAccess runs fn in a transaction that bypasses the tenant role (RLS),
+// so the scan sees every tenant's rows
+func (r *IntegrityRepository) withPlatformAccess(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL ROLE NONE").Error; err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
+
+// ScanWithContext runs all integrity checks across tenants and returns the anomalies found
+func (r *IntegrityRepository) ScanWithContext(ctx context.Context) ([]models.IntegrityFinding, error) {
+	var findings []models.IntegrityFinding
+	now := time.Now()
+
+	err := r.withPlatformAccess(ctx, func(tx *gorm.DB) error {
+		for _, check := range integrityChecks {
+			var rows []integrityRow
+			if err := tx.Raw(check.query).Scan(&rows).Error; err != nil {
+				return fmt.Errorf("integrity check %s on %s failed: %w", check.name, check.sourceTable, err)
+			}
+			for _, row := range rows {
+				findings = append(findings, models.IntegrityFinding{
+					CheckName:    check.name,
+					SourceTable:  check.sourceTable,
+					RowID:        row.RowID,
+					RestaurantID: row.RestaurantID,
+					Details:      row.Details,
+					Status:       models.IntegrityFindingOpen,
+					DetectedAt:   now,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return findings, nil
+}
+
+// SaveFindingsWithContext stores new findings, skipping rows already recorded by a previous run
+// Returns the findings that were newly inserted
+func (r *IntegrityRepository) SaveFindingsWithContext(ctx context.Context, findings []models.IntegrityFinding) ([]models.IntegrityFinding, error) {
+	created := make([]models.IntegrityFinding, 0, len(findings))
+	for _, finding := range findings {
+		result := r.db.WithContext(ctx).
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&finding)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected > 0 {
+			created = append(created, finding)
+		}
+	}
+	return created, nil
+}
+
+// ListFindingsWithContext lists findings, optionally filtered by status, newest first
+func (r *IntegrityRepository) ListFindingsWithContext(ctx context.Context, status string, limit, offset int) ([]models.IntegrityFinding, int64, error) {
+	var findings []models.IntegrityFinding
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.IntegrityFinding{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("detected_at DESC, id DESC").Limit(limit).Offset(offset).Find(&findings).Error; err != nil {
+		return nil, 0, err
+	}
+	return findings, total, nil
+}
+
+// GetFindingByIDWithContext retrieves a finding by ID
+func (r *IntegrityRepository) GetFindingByIDWithContext(ctx context.Context, id uint) (*models.IntegrityFinding, error) {
+	var finding models.IntegrityFinding
+	if err := r.db.WithContext(ctx).First(&finding, id).Error; err != nil {
+		return nil, err
+	}
+	return &finding, nil
+}
+
+// UpdateFindingStatusWithContext sets the status of a finding
+func (r *IntegrityRepository) UpdateFindingStatusWithContext(ctx context.Context, id uint, status string) error {
+	return r.db.WithContext(ctx).Model(&models.IntegrityFinding{}).Where("id = ?", id).Update("status", status).Error
+}
+
+// QuarantineWithContext snapshots the offending row into the finding and removes it from its table
+func (r *IntegrityRepository) QuarantineWithContext(ctx context.Context, finding *models.IntegrityFinding) error {
+	if !isIntegritySourceTable(finding.SourceTable) {
+		return fmt.Errorf("table %s cannot be quarantined", finding.SourceTable)
+	}
+
+	return r.withPlatformAccess(ctx, func(tx *gorm.DB) error {
+		var rowData string
+		if err := tx.Raw(
+			fmt.Sprintf("SELECT row_to_json(t)::text FROM %s t WHERE t.id = ?", finding.SourceTable),
+			finding.RowID,
+		).Scan(&rowData).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE id = ?", finding.SourceTable), finding.RowID).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		updates := map[string]interface{}{
+			"status":         models.IntegrityFindingQuarantined,
+			"quarantined_at": now,
+		}
+		if rowData != "" {
+			updates["row_data"] = rowData
+		}
+		if err := tx.Model(finding).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		finding.Status = models.IntegrityFindingQuarantined
+		finding.QuarantinedAt = &now
+		finding.RowData = rowData
+		return nil
+	})
+}
+
+// isIntegritySourceTable reports whether table is scanned by an integrity check
+func isIntegritySourceTable(table string) bool {
+	for _, check := range integrityChecks {
+		if check.sourceTable == table {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InvoiceRepository handles invoice database operations
+type InvoiceRepository struct {
+	db *gorm.DB
+}
+
+// NewInvoiceRepository creates a new InvoiceRepository instance
+func NewInvoiceRepository(db *gorm.DB) *InvoiceRepository {
+	return &InvoiceRepository{db: db}
+}
+
+// InvoiceFilter narrows an invoice listing; zero values match everything
+type InvoiceFilter struct {
+	PeriodStart  *time.Time
+	RestaurantID *uint
+	Status       *models.InvoiceStatus
+}
+
+// GetByIDWithContext retrieves an invoice with its lines and restaurant
+func (r *InvoiceRepository) GetByIDWithContext(ctx context.Context, id uint) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := r.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Restaurant").
+		First(&invoice, id).Error; err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// GetByRestaurantPeriodWithContext retrieves a restaurant's invoice for a period
+func (r *InvoiceRepository) GetByRestaurantPeriodWithContext(ctx context.Context, restaurantID uint, periodStart time.Time) (*models.Invoice, error) {
+	var invoice models.Invoice
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND period_start = ?", restaurantID, periodStart).
+		First(&invoice).Error; err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// ListWithContext lists invoices with their lines and restaurant, newest period first
+func (r *InvoiceRepository) ListWithContext(ctx context.Context, filter InvoiceFilter) ([]models.Invoice, error) {
+	query := r.db.WithContext(ctx)
+	if filter.PeriodStart != nil {
+		query = query.Where("period_start = ?", *filter.PeriodStart)
+	}
+	if filter.RestaurantID != nil {
+		query = query.Where("restaurant_id = ?", *filter.RestaurantID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var invoices []models.Invoice
+	if err := query.
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Restaurant").
+		Order("period_start DESC, restaurant_id ASC").
+		Find(&invoices).Error; err != nil {
+		return nil, err
+	}
+	return invoices, nil
+}
+
+// SaveDraftWithContext creates or replaces a draft invoice and its lines in a single transaction
+func (r *InvoiceRepository) SaveDraftWithContext(ctx context.Context, invoice *models.Invoice) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if invoice.ID != 0 {
+			if err := tx.Where("invoice_id = ?", invoice.ID).Delete(&models.InvoiceLine{}).Error; err != nil {
+				return err
+			}
+		}
+		for i := range invoice.Lines {
+			invoice.Lines[i].ID = 0
+		}
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(invoice).Error
+	})
+}
+
+// IssueWithContext marks a draft invoice as issued
+// Returns gorm.ErrRecordNotFound if the invoice doesn't exist or is not a draft
+func (r *InvoiceRepository) IssueWithContext(ctx context.Context, id uint, issuedAt time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.Invoice{}).
+		Where("id = ? AND status = ?", id, models.InvoiceStatusDraft).
+		Updates(map[string]interface{}{"status": models.InvoiceStatusIssued, "issued_at": issuedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
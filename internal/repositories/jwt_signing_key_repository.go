@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// JWTSigningKeyRepository handles JWT signing key database operations
+type JWTSigningKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewJWTSigningKeyRepository creates a new JWTSigningKeyRepository instance
+func NewJWTSigningKeyRepository(db *gorm.DB) *JWTSigningKeyRepository {
+	return &JWTSigningKeyRepository{db: db}
+}
+
+// ListWithContext retrieves every signing key, retired ones included, newest first
+func (r *JWTSigningKeyRepository) ListWithContext(ctx context.Context) ([]models.JWTSigningKey, error) {
+	var keys []models.JWTSigningKey
+	if err := r.db.WithContext(ctx).Order("created_at DESC, id DESC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RotateWithContext stores a new signing key, retires the given keys (erasing their secret) and records the
+// audit entry, in one transaction
+func (r *JWTSigningKeyRepository) RotateWithContext(ctx context.Context, key *models.JWTSigningKey, retireIDs []uint, audit *models.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(key).Error; err != nil {
+			return err
+		}
+		if len(retireIDs) > 0 {
+			if err := tx.Model(&models.JWTSigningKey{}).
+				Where("id IN ? AND retired_at IS NULL", retireIDs).
+				Updates(map[string]interface{}{"secret": "", "retired_at": time.Now()}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(audit).Error
+	})
+}
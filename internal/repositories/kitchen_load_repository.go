@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// KitchenLoadRepository handles the hourly kitchen load factors learned by the prep time estimator
+type KitchenLoadRepository struct {
+	db *gorm.DB
+}
+
+// NewKitchenLoadRepository creates a new KitchenLoadRepository instance
+func NewKitchenLoadRepository(db *gorm.DB) *KitchenLoadRepository {
+	return &KitchenLoadRepository{db: db}
+}
+
+// GetByRestaurantIDWithContext retrieves the load factors learned for a restaurant, by hour
+func (r *KitchenLoadRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.KitchenLoadFactor, error) {
+	var factors []models.KitchenLoadFactor
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("hour ASC").
+		Find(&factors).Error; err != nil {
+		return nil, err
+	}
+	return factors, nil
+}
+
+// RecordSampleWithContext folds a measured load ratio into the factor of an hour
+// The factor moves by rate towards the sample; the first sample of an hour is taken as is
+func (r *KitchenLoadRepository) RecordSampleWithContext(ctx context.Context, restaurantID uint, hour int, ratio, rate float64) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO kitchen_load_factors (restaurant_id, hour, factor, samples, updated_at)
+		VALUES (?, ?, ?, 1, NOW())
+		ON CONFLICT (restaurant_id, hour) DO UPDATE SET
+			factor = kitchen_load_factors.factor + ? * (EXCLUDED.factor - kitchen_load_factors.factor),
+			samples = kitchen_load_factors.samples + 1,
+			updated_at = NOW()
+	`, restaurantID, hour, ratio, rate).Error
+}
@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"context"
+
 	"restaurant-backend/internal/models"
 
 	"gorm.io/gorm"
@@ -21,6 +23,11 @@ func (r *MenuItemImageRepository) Create(image *models.MenuItemImage) error {
 	return r.db.Create(image).Error
 }
 
+// CreateWithContext creates a new menu item image with context
+func (r *MenuItemImageRepository) CreateWithContext(ctx context.Context, image *models.MenuItemImage) error {
+	return r.db.WithContext(ctx).Create(image).Error
+}
+
 // GetByID retrieves an image by ID (RLS ensures tenant isolation)
 func (r *MenuItemImageRepository) GetByID(id uint) (*models.MenuItemImage, error) {
 	var image models.MenuItemImage
@@ -42,6 +49,17 @@ func (r *MenuItemImageRepository) GetByMenuItemID(menuItemID uint) ([]models.Men
 	return images, nil
 }
 
+// GetByMenuItemIDWithContext retrieves all images for a menu item of the restaurant
+func (r *MenuItemImageRepository) GetByMenuItemIDWithContext(ctx context.Context, menuItemID uint, restaurantID uint) ([]models.MenuItemImage, error) {
+	var images []models.MenuItemImage
+	if err := r.db.WithContext(ctx).Where("menu_item_id = ? AND restaurant_id = ?", menuItemID, restaurantID).
+		Order("is_primary DESC, display_order ASC").
+		Find(&images).Error; err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
 // Update updates an existing menu item image
 func (r *MenuItemImageRepository) Update(image *models.MenuItemImage) error {
 	return r.db.Save(image).Error
@@ -52,6 +70,19 @@ func (r *MenuItemImageRepository) Delete(id uint) error {
 	return r.db.Delete(&models.MenuItemImage{}, id).Error
 }
 
+// DeleteWithContext deletes a menu item image of the restaurant
+// Returns gorm.ErrRecordNotFound if no such image belongs to the restaurant
+func (r *MenuItemImageRepository) DeleteWithContext(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).Delete(&models.MenuItemImage{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // DeleteByMenuItemID deletes all images for a menu item
 func (r *MenuItemImageRepository) DeleteByMenuItemID(menuItemID uint) error {
 	return r.db.Where("menu_item_id = ?", menuItemID).Delete(&models.MenuItemImage{}).Error
@@ -71,3 +102,26 @@ func (r *MenuItemImageRepository) SetPrimary(menuItemID uint, imageID uint) erro
 		Where("id = ? AND menu_item_id = ?", imageID, menuItemID).
 		Update("is_primary", true).Error
 }
+
+// SetPrimaryWithContext sets an image as primary and un-sets others for the same menu item, in one transaction
+// Returns gorm.ErrRecordNotFound if the image does not belong to the restaurant's menu item
+func (r *MenuItemImageRepository) SetPrimaryWithContext(ctx context.Context, menuItemID uint, imageID uint, restaurantID uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.MenuItemImage{}).
+			Where("menu_item_id = ? AND restaurant_id = ?", menuItemID, restaurantID).
+			Update("is_primary", false).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.MenuItemImage{}).
+			Where("id = ? AND menu_item_id = ? AND restaurant_id = ?", imageID, menuItemID, restaurantID).
+			Update("is_primary", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
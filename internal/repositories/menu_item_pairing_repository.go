@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MenuItemPairingRepository handles the "pairs well with" links between menu items
+type MenuItemPairingRepository struct {
+	db *gorm.DB
+}
+
+// NewMenuItemPairingRepository creates a new MenuItemPairingRepository instance
+func NewMenuItemPairingRepository(db *gorm.DB) *MenuItemPairingRepository {
+	return &MenuItemPairingRepository{db: db}
+}
+
+// ListByMenuItemWithContext retrieves a menu item's pairings with their items: manual links first, then by score
+func (r *MenuItemPairingRepository) ListByMenuItemWithContext(ctx context.Context, restaurantID, menuItemID uint) ([]models.MenuItemPairing, error) {
+	var pairings []models.MenuItemPairing
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND menu_item_id = ?", restaurantID, menuItemID).
+		Preload("PairedItem").
+		Order("source = 'manual' DESC, display_order ASC, score DESC, id ASC").
+		Find(&pairings).Error; err != nil {
+		return nil, err
+	}
+	return pairings, nil
+}
+
+// ReplaceManualWithContext replaces a menu item's manual links, in the given order
+// Automatic links of the same pairs are dropped, so a pair is only suggested once
+func (r *MenuItemPairingRepository) ReplaceManualWithContext(ctx context.Context, restaurantID, menuItemID uint, pairedItemIDs []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("restaurant_id = ? AND menu_item_id = ? AND source = ?", restaurantID, menuItemID, models.MenuItemPairingManual).
+			Delete(&models.MenuItemPairing{}).Error; err != nil {
+			return err
+		}
+		if len(pairedItemIDs) == 0 {
+			return nil
+		}
+		if err := tx.Where("restaurant_id = ? AND menu_item_id = ? AND paired_item_id IN ?", restaurantID, menuItemID, pairedItemIDs).
+			Delete(&models.MenuItemPairing{}).Error; err != nil {
+			return err
+		}
+
+		pairings := make([]models.MenuItemPairing, 0, len(pairedItemIDs))
+		for i, pairedItemID := range pairedItemIDs {
+			pairings = append(pairings, models.MenuItemPairing{
+				RestaurantID: restaurantID,
+				MenuItemID:   menuItemID,
+				PairedItemID: pairedItemID,
+				Source:       models.MenuItemPairingManual,
+				DisplayOrder: i,
+			})
+		}
+		return tx.Create(&pairings).Error
+	})
+}
+
+// GetPairedItemsWithContext retrieves the available items paired with a menu item, with their images:
+// manual links first, then the items most often ordered together with it
+// Items off the public menu (drafts, archived categories) are left out
+func (r *MenuItemPairingRepository) GetPairedItemsWithContext(ctx context.Context, restaurantID, menuItemID uint, limit int) ([]models.MenuItem, error) {
+	var items []models.MenuItem
+	if err := r.db.WithContext(ctx).
+		Joins("JOIN menu_item_pairings ON menu_item_pairings.paired_item_id = menu_items.id").
+		Where("menu_item_pairings.restaurant_id = ? AND menu_item_pairings.menu_item_id = ?", restaurantID, menuItemID).
+		Where("menu_items.is_available").
+		Where(onPublishedMenu).
+		Preload("Images").
+		Order("menu_item_pairings.source = 'manual' DESC, menu_item_pairings.display_order ASC, menu_item_pairings.score DESC, menu_items.id ASC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// RecomputeAutomaticWithContext replaces the automatic links of every restaurant with the items most often ordered
+// together since the given time: up to perItem items per menu item, each in at least minOrders orders with it
+// Cancelled orders don't count, and pairs with a manual link keep it
+func (r *MenuItemPairingRepository) RecomputeAutomaticWithContext(ctx context.Context, since time.Time, minOrders, perItem int) (int64, error) {
+	var created int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source = ?", models.MenuItemPairingAutomatic).Delete(&models.MenuItemPairing{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Exec(`
+			INSERT INTO menu_item_pairings (restaurant_id, menu_item_id, paired_item_id, source, score, display_order, created_at)
+			SELECT restaurant_id, menu_item_id, paired_item_id, ?, score, 0, NOW()
+			FROM (
+				SELECT a.restaurant_id, a.menu_item_id, b.menu_item_id AS paired_item_id,
+					COUNT(DISTINCT a.order_id) AS score,
+					ROW_NUMBER() OVER (
+						PARTITION BY a.menu_item_id ORDER BY COUNT(DISTINCT a.order_id) DESC, b.menu_item_id ASC
+					) AS pair_rank
+				FROM order_items a
+				JOIN order_items b ON b.order_id = a.order_id AND b.menu_item_id <> a.menu_item_id
+				JOIN orders ON orders.id = a.order_id
+				JOIN menu_items item ON item.id = a.menu_item_id
+				JOIN menu_items paired ON paired.id = b.menu_item_id
+				WHERE orders.created_at >= ? AND orders.status <> ?
+				GROUP BY a.restaurant_id, a.menu_item_id, b.menu_item_id
+				HAVING COUNT(DISTINCT a.order_id) >= ?
+			) ranked
+			WHERE pair_rank <= ?
+			ON CONFLICT (menu_item_id, paired_item_id) DO NOTHING
+		`, models.MenuItemPairingAutomatic, since, models.OrderStatusCancelled, minOrders, perItem)
+		if result.Error != nil {
+			return result.Error
+		}
+		created = result.RowsAffected
+		return nil
+	})
+	return created, err
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MenuItemPriceChangeRepository handles menu item price history and scheduled price change database operations
+type MenuItemPriceChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewMenuItemPriceChangeRepository creates a new MenuItemPriceChangeRepository instance
+func NewMenuItemPriceChangeRepository(db *gorm.DB) *MenuItemPriceChangeRepository {
+	return &MenuItemPriceChangeRepository{db: db}
+}
+
+// CreateWithContext creates a price change
+func (r *MenuItemPriceChangeRepository) CreateWithContext(ctx context.Context, change *models.MenuItemPriceChange) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations).Create(change).Error
+}
+
+// GetByIDForRestaurant retrieves a price change of a menu item, scoped to the restaurant
+func (r *MenuItemPriceChangeRepository) GetByIDForRestaurant(ctx context.Context, id, menuItemID, restaurantID uint) (*models.MenuItemPriceChange, error) {
+	var change models.MenuItemPriceChange
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND menu_item_id = ?", restaurantID, menuItemID).
+		First(&change, id).Error; err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// GetByMenuItemWithContext lists a menu item's price changes, latest effective first
+func (r *MenuItemPriceChangeRepository) GetByMenuItemWithContext(ctx context.Context, restaurantID, menuItemID uint) ([]models.MenuItemPriceChange, error) {
+	var changes []models.MenuItemPriceChange
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND menu_item_id = ?", restaurantID, menuItemID).
+		Order("effective_at DESC, id DESC").
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetDueWithContext lists a restaurant's scheduled price changes effective at the given time, oldest first
+func (r *MenuItemPriceChangeRepository) GetDueWithContext(ctx context.Context, restaurantID uint, at time.Time) ([]models.MenuItemPriceChange, error) {
+	var changes []models.MenuItemPriceChange
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND status = ? AND effective_at <= ?", restaurantID, models.PriceChangeStatusScheduled, at).
+		Order("effective_at ASC, id ASC").
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// CancelWithContext cancels a scheduled price change
+// Returns gorm.ErrRecordNotFound if the menu item has no such scheduled change
+func (r *MenuItemPriceChangeRepository) CancelWithContext(ctx context.Context, id, menuItemID, restaurantID uint) error {
+	result := r.db.WithContext(ctx).Model(&models.MenuItemPriceChange{}).
+		Where("id = ? AND menu_item_id = ? AND restaurant_id = ? AND status = ?",
+			id, menuItemID, restaurantID, models.PriceChangeStatusScheduled).
+		Update("status", models.PriceChangeStatusCancelled)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ApplyDueWithContext applies up to limit scheduled price changes of any restaurant effective at the given time,
+// oldest first, in a single transaction. Each change sets the menu item's price (bumping its version) and records
+// the price it replaced; concurrent runs skip the changes another run is applying
+func (r *MenuItemPriceChangeRepository) ApplyDueWithContext(ctx context.Context, at time.Time, limit int) ([]models.MenuItemPriceChange, error) {
+	var changes []models.MenuItemPriceChange
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND effective_at <= ?", models.PriceChangeStatusScheduled, at).
+			Order("effective_at ASC, id ASC").
+			Limit(limit).
+			Find(&changes).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i := range changes {
+			change := &changes[i]
+			var item models.MenuItem
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, change.MenuItemID).Error; err != nil {
+				return err
+			}
+			change.OldPrice = item.ChannelPrice(change.Channel)
+
+			if err := tx.Model(&models.MenuItem{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+				models.MenuItemPriceColumn(change.Channel): change.NewPrice,
+				"version": bumpVersion,
+			}).Error; err != nil {
+				return err
+			}
+			change.Status = models.PriceChangeStatusApplied
+			change.AppliedAt = &now
+			if err := tx.Model(change).Updates(map[string]interface{}{
+				"status":     change.Status,
+				"old_price":  change.OldPrice,
+				"applied_at": change.AppliedAt,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -2,12 +2,23 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"restaurant-backend/internal/models"
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrMenuItemsNotFound is returned when a bulk update references menu items the restaurant doesn't have
+var ErrMenuItemsNotFound = errors.New("menu items not found")
+
+// outsideArchivedCategory excludes menu items whose category is archived
+const outsideArchivedCategory = "NOT EXISTS (SELECT 1 FROM menu_categories WHERE menu_categories.id = menu_items.category_id AND menu_categories.archived_at IS NOT NULL)"
+
+// onPublishedMenu keeps the menu items on the public menu: published items of published, unarchived categories
+const onPublishedMenu = "menu_items.publish_status = 'published' AND NOT EXISTS (SELECT 1 FROM menu_categories WHERE menu_categories.id = menu_items.category_id AND (menu_categories.archived_at IS NOT NULL OR menu_categories.publish_status <> 'published'))"
+
 // MenuItemRepository handles menu item-related database operations
 type MenuItemRepository struct {
 	db *gorm.DB
@@ -52,6 +63,29 @@ func (r *MenuItemRepository) GetByIDWithContext(ctx context.Context, id uint) (*
 	return &menuItem, nil
 }
 
+// GetByIDForRestaurant retrieves a menu item by ID, scoped to the restaurant
+func (r *MenuItemRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.MenuItem, error) {
+	var menuItem models.MenuItem
+	if err := r.db.WithContext(ctx).Preload("Images").
+		Preload("Category").
+		Where("restaurant_id = ?", restaurantID).
+		First(&menuItem, id).Error; err != nil {
+		return nil, err
+	}
+	return &menuItem, nil
+}
+
+// GetByIDsForRestaurantWithContext retrieves the menu items of a restaurant with the given IDs
+func (r *MenuItemRepository) GetByIDsForRestaurantWithContext(ctx context.Context, restaurantID uint, ids []uint) ([]models.MenuItem, error) {
+	var menuItems []models.MenuItem
+	if err := r.db.WithContext(ctx).
+		Where("id IN ? AND restaurant_id = ?", ids, restaurantID).
+		Find(&menuItems).Error; err != nil {
+		return nil, err
+	}
+	return menuItems, nil
+}
+
 func (r *MenuItemRepository) GetByName(name string) (*models.MenuItem, error) {
 	var menuItem models.MenuItem
 	if err := r.db.Where("lower(name) = lower(?)", strings.TrimSpace(name)).First(&menuItem).Error; err != nil {
@@ -86,6 +120,7 @@ func (r *MenuItemRepository) GetByIDPublic(id uint, restaurantID uint) (*models.
 func (r *MenuItemRepository) GetByIDPublicWithContext(ctx context.Context, id uint, restaurantID uint) (*models.MenuItem, error) {
 	var menuItem models.MenuItem
 	if err := r.db.WithContext(ctx).Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Where(onPublishedMenu).
 		Preload("Images").
 		Preload("Category").
 		First(&menuItem).Error; err != nil {
@@ -107,9 +142,23 @@ func (r *MenuItemRepository) GetByCategoryID(categoryID uint) ([]models.MenuItem
 }
 
 // GetByCategoryIDWithContext retrieves menu items by category using context
+// An archived category has no items here
 func (r *MenuItemRepository) GetByCategoryIDWithContext(ctx context.Context, categoryID uint) ([]models.MenuItem, error) {
 	var menuItems []models.MenuItem
 	if err := r.db.WithContext(ctx).Where("category_id = ?", categoryID).
+		Where(outsideArchivedCategory).
+		Preload("Images").
+		Order("display_order ASC").Find(&menuItems).Error; err != nil {
+		return nil, err
+	}
+	return menuItems, nil
+}
+
+// GetPublishedByCategoryIDWithContext retrieves the public menu items of a restaurant's category
+func (r *MenuItemRepository) GetPublishedByCategoryIDWithContext(ctx context.Context, restaurantID, categoryID uint) ([]models.MenuItem, error) {
+	var menuItems []models.MenuItem
+	if err := r.db.WithContext(ctx).Where("category_id = ? AND restaurant_id = ?", categoryID, restaurantID).
+		Where(onPublishedMenu).
 		Preload("Images").
 		Order("display_order ASC").Find(&menuItems).Error; err != nil {
 		return nil, err
@@ -132,9 +181,26 @@ func (r *MenuItemRepository) GetByRestaurantID(restaurantID uint) ([]models.Menu
 }
 
 // GetByRestaurantIDWithContext retrieves menu items for a restaurant using context
+// Items of archived categories are left out
 func (r *MenuItemRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.MenuItem, error) {
 	var menuItems []models.MenuItem
 	if err := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).
+		Where(outsideArchivedCategory).
+		Preload("Images").
+		Preload("Category").
+		Order("category_id, display_order ASC").
+		Find(&menuItems).Error; err != nil {
+		return nil, err
+	}
+	return menuItems, nil
+}
+
+// GetPublishedByRestaurantIDWithContext retrieves the menu items on a restaurant's public menu
+// Drafts and items of draft or archived categories are left out
+func (r *MenuItemRepository) GetPublishedByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.MenuItem, error) {
+	var menuItems []models.MenuItem
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).
+		Where(onPublishedMenu).
 		Preload("Images").
 		Preload("Category").
 		Order("category_id, display_order ASC").
@@ -144,6 +210,78 @@ func (r *MenuItemRepository) GetByRestaurantIDWithContext(ctx context.Context, r
 	return menuItems, nil
 }
 
+// SetAvailabilityWithContext sets is_available on the given menu items in one update and records the audit
+// entry in the same transaction. Nothing is changed if any item doesn't belong to the restaurant (ErrMenuItemsNotFound)
+func (r *MenuItemRepository) SetAvailabilityWithContext(ctx context.Context, restaurantID uint, ids []uint, isAvailable bool, audit *models.AuditLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var updated []models.MenuItem
+		result := tx.Model(&updated).
+			Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).
+			Where("id IN ? AND restaurant_id = ?", ids, restaurantID).
+			Updates(map[string]interface{}{"is_available": isAvailable, "version": bumpVersion})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return ErrMenuItemsNotFound
+		}
+
+		return tx.Create(audit).Error
+	})
+}
+
+// ReorderInCategoryWithContext sets the display order of all items in a category to their position in ids
+// Returns ErrReorderMismatch unless ids lists every item of the category exactly once
+func (r *MenuItemRepository) ReorderInCategoryWithContext(ctx context.Context, restaurantID, categoryID uint, ids []uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return reorderDisplayOrder(tx, &models.MenuItem{}, ids, "category_id = ? AND restaurant_id = ?", categoryID, restaurantID)
+	})
+}
+
+// SearchWithContext finds a restaurant's menu items matching a full-text query over names, tags and descriptions,
+// best ranked first. tsquery is a to_tsquery expression; items of inactive categories are left out
+func (r *MenuItemRepository) SearchWithContext(ctx context.Context, restaurantID uint, tsquery string, limit int) ([]models.MenuItem, error) {
+	var menuItems []models.MenuItem
+	if err := r.searchable(ctx, restaurantID).
+		Where("menu_items.search_vector @@ to_tsquery('simple', ?)", tsquery).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank_cd(menu_items.search_vector, to_tsquery('simple', ?)) DESC, menu_items.id ASC",
+			Vars: []interface{}{tsquery},
+		}}).
+		Limit(limit).
+		Find(&menuItems).Error; err != nil {
+		return nil, err
+	}
+	return menuItems, nil
+}
+
+// SearchSimilarWithContext finds a restaurant's menu items whose name contains a word similar to the term
+// (trigram word similarity of at least threshold), most similar first; it tolerates typos the full-text search misses
+func (r *MenuItemRepository) SearchSimilarWithContext(ctx context.Context, restaurantID uint, term string, threshold float64, limit int) ([]models.MenuItem, error) {
+	var menuItems []models.MenuItem
+	if err := r.searchable(ctx, restaurantID).
+		Where("word_similarity(?, menu_items.name) >= ?", term, threshold).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "word_similarity(?, menu_items.name) DESC, menu_items.id ASC",
+			Vars: []interface{}{term},
+		}}).
+		Limit(limit).
+		Find(&menuItems).Error; err != nil {
+		return nil, err
+	}
+	return menuItems, nil
+}
+
+// searchable scopes a query to a restaurant's published menu items in active, unarchived categories, with their
+// images and category
+func (r *MenuItemRepository) searchable(ctx context.Context, restaurantID uint) *gorm.DB {
+	return r.db.WithContext(ctx).
+		Joins("JOIN menu_categories ON menu_categories.id = menu_items.category_id AND menu_categories.is_active AND menu_categories.archived_at IS NULL AND menu_categories.publish_status = 'published'").
+		Where("menu_items.restaurant_id = ? AND menu_items.publish_status = 'published'", restaurantID).
+		Preload("Images").
+		Preload("Category")
+}
+
 // Update updates an existing menu item using provided updates map (only updates fields in the map)
 func (r *MenuItemRepository) Update(id uint, updates map[string]interface{}) error {
 	if len(updates) == 0 {
@@ -153,11 +291,32 @@ func (r *MenuItemRepository) Update(id uint, updates map[string]interface{}) err
 }
 
 // UpdateWithContext updates a menu item using the provided context
-func (r *MenuItemRepository) UpdateWithContext(ctx context.Context, id uint, updates map[string]interface{}) error {
+// Returns ErrVersionConflict if the item's version no longer matches version
+func (r *MenuItemRepository) UpdateWithContext(ctx context.Context, id uint, version int, updates map[string]interface{}) error {
 	if len(updates) == 0 {
 		return nil
 	}
-	return r.db.WithContext(ctx).Model(&models.MenuItem{}).Where("id = ?", id).Updates(updates).Error
+	return updateVersioned(r.db.WithContext(ctx), &models.MenuItem{}, id, version, updates)
+}
+
+// UpdateWithPriceChangesWithContext updates a menu item and records its price changes in a single transaction
+// Returns ErrVersionConflict if the item's version no longer matches version
+func (r *MenuItemRepository) UpdateWithPriceChangesWithContext(
+	ctx context.Context,
+	id uint,
+	version int,
+	updates map[string]interface{},
+	changes []models.MenuItemPriceChange,
+) error {
+	if len(changes) == 0 {
+		return r.UpdateWithContext(ctx, id, version, updates)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, &models.MenuItem{}, id, version, updates); err != nil {
+			return err
+		}
+		return tx.Omit(clause.Associations).Create(&changes).Error
+	})
 }
 
 // Delete deletes a menu item
@@ -169,3 +328,25 @@ func (r *MenuItemRepository) Delete(id uint) error {
 func (r *MenuItemRepository) DeleteWithContext(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Delete(&models.MenuItem{}, id).Error
 }
+
+// CountByRestaurantIDWithContext counts the menu items of a restaurant
+func (r *MenuItemRepository) CountByRestaurantIDWithContext(ctx context.Context, restaurantID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.MenuItem{}).
+		Where("restaurant_id = ?", restaurantID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// RecordPrepTimeWithContext folds a measured prep time into a menu item's average prep time
+// The average moves by rate towards the sample (the first sample is taken as is), without bumping the item's version
+func (r *MenuItemRepository) RecordPrepTimeWithContext(ctx context.Context, id uint, seconds int, rate float64) error {
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE menu_items SET
+			avg_prep_seconds = CASE WHEN prep_samples = 0 THEN ? ELSE ROUND(avg_prep_seconds + ? * (? - avg_prep_seconds)) END,
+			prep_samples = prep_samples + 1
+		WHERE id = ?
+	`, seconds, rate, seconds, id).Error
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MenuPublicationRepository handles draft menus and their publish history
+type MenuPublicationRepository struct {
+	db *gorm.DB
+}
+
+// NewMenuPublicationRepository creates a new MenuPublicationRepository instance
+func NewMenuPublicationRepository(db *gorm.DB) *MenuPublicationRepository {
+	return &MenuPublicationRepository{db: db}
+}
+
+// MenuDraftUpdate is the pending edit of a published category or menu item, as column updates
+// It is applied only if the row's version still matches
+type MenuDraftUpdate struct {
+	ID      uint
+	Version int
+	Updates map[string]interface{}
+}
+
+// MenuPublish is what a publish applies besides turning the drafts into published categories and items
+type MenuPublish struct {
+	CategoryUpdates []MenuDraftUpdate
+	ItemUpdates     []MenuDraftUpdate
+	PriceChanges    []models.MenuItemPriceChange
+}
+
+// unpublished matches the categories and menu items that are drafts or have pending edits
+const unpublished = "restaurant_id = ? AND (publish_status = 'draft' OR draft_changes IS NOT NULL)"
+
+// GetDraftWithContext retrieves a restaurant's unpublished categories and menu items: drafts and those with
+// pending edits. Archived categories are left out
+func (r *MenuPublicationRepository) GetDraftWithContext(ctx context.Context, restaurantID uint) ([]models.MenuCategory, []models.MenuItem, error) {
+	var categories []models.MenuCategory
+	if err := r.db.WithContext(ctx).
+		Where(unpublished, restaurantID).
+		Where("archived_at IS NULL").
+		Order("display_order ASC, id ASC").
+		Find(&categories).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var items []models.MenuItem
+	if err := r.db.WithContext(ctx).
+		Where(unpublished, restaurantID).
+		Where(outsideArchivedCategory).
+		Order("category_id, display_order ASC, id ASC").
+		Find(&items).Error; err != nil {
+		return nil, nil, err
+	}
+	return categories, items, nil
+}
+
+// PublishWithContext publishes a restaurant's draft menu in a single transaction, so the public menu switches
+// from the old to the new version at once: pending edits are applied, drafts become published and the
+// publication is recorded with the next version number, counting what changed
+// Returns ErrVersionConflict if a category or item was edited since the draft was read
+func (r *MenuPublicationRepository) PublishWithContext(ctx context.Context, publication *models.MenuPublication, publish *MenuPublish) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Serialize the restaurant's publishes so versions are sequential
+		var restaurant models.Restaurant
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&restaurant, publication.RestaurantID).Error; err != nil {
+			return err
+		}
+
+		for _, update := range publish.CategoryUpdates {
+			if err := updateVersioned(tx, &models.MenuCategory{}, update.ID, update.Version, publishedUpdates(update.Updates)); err != nil {
+				return err
+			}
+		}
+		for _, update := range publish.ItemUpdates {
+			if err := updateVersioned(tx, &models.MenuItem{}, update.ID, update.Version, publishedUpdates(update.Updates)); err != nil {
+				return err
+			}
+		}
+		publication.CategoriesUpdated = len(publish.CategoryUpdates)
+		publication.ItemsUpdated = len(publish.ItemUpdates)
+
+		published := map[string]interface{}{"publish_status": models.MenuPublishStatusPublished, "version": bumpVersion}
+		result := tx.Model(&models.MenuCategory{}).
+			Where("restaurant_id = ? AND publish_status = ?", publication.RestaurantID, models.MenuPublishStatusDraft).
+			Updates(published)
+		if result.Error != nil {
+			return result.Error
+		}
+		publication.CategoriesAdded = int(result.RowsAffected)
+
+		result = tx.Model(&models.MenuItem{}).
+			Where("restaurant_id = ? AND publish_status = ?", publication.RestaurantID, models.MenuPublishStatusDraft).
+			Updates(published)
+		if result.Error != nil {
+			return result.Error
+		}
+		publication.ItemsAdded = int(result.RowsAffected)
+
+		if len(publish.PriceChanges) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&publish.PriceChanges).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.MenuPublication{}).
+			Where("restaurant_id = ?", publication.RestaurantID).
+			Select("COALESCE(MAX(version), 0) + 1").
+			Scan(&publication.Version).Error; err != nil {
+			return err
+		}
+		return tx.Create(publication).Error
+	})
+}
+
+// publishedUpdates adds clearing the pending edits to the column updates of a draft
+func publishedUpdates(updates map[string]interface{}) map[string]interface{} {
+	published := make(map[string]interface{}, len(updates)+1)
+	for column, value := range updates {
+		published[column] = value
+	}
+	published["draft_changes"] = gorm.Expr("NULL")
+	return published
+}
+
+// ListWithContext retrieves a page of a restaurant's publish history, latest first, and the total count
+func (r *MenuPublicationRepository) ListWithContext(ctx context.Context, restaurantID uint, limit, offset int) ([]models.MenuPublication, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.MenuPublication{}).Where("restaurant_id = ?", restaurantID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var publications []models.MenuPublication
+	if err := query.Order("version DESC").Limit(limit).Offset(offset).Find(&publications).Error; err != nil {
+		return nil, 0, err
+	}
+	return publications, total, nil
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// NotificationPreferenceRepository handles customer notification preference database operations
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationPreferenceRepository creates a new NotificationPreferenceRepository instance
+func NewNotificationPreferenceRepository(db *gorm.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{db: db}
+}
+
+// GetWithContext retrieves a customer's preferences at a restaurant
+func (r *NotificationPreferenceRepository) GetWithContext(ctx context.Context, restaurantID, userID uint) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		First(&preference).Error; err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// SaveWithContext creates or updates a customer's preferences
+func (r *NotificationPreferenceRepository) SaveWithContext(ctx context.Context, preference *models.NotificationPreference) error {
+	return r.db.WithContext(ctx).Save(preference).Error
+}
@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepository handles in-app notification database operations
+// Notifications are always read and changed through the user they belong to
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new NotificationRepository instance
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// CreateForStaffWithContext gives every active Admin and Staff user of the notification's restaurant a
+// copy of it, in one statement, and returns how many were created
+func (r *NotificationRepository) CreateForStaffWithContext(ctx context.Context, notification *models.Notification) (int64, error) {
+	return r.CreateForRolesWithContext(ctx, notification, []string{"Admin", "Staff"})
+}
+
+// CreateForRolesWithContext gives every active user of the notification's restaurant with one of the roles a
+// copy of it, in one statement, and returns how many were created
+func (r *NotificationRepository) CreateForRolesWithContext(ctx context.Context, notification *models.Notification, roles []string) (int64, error) {
+	data := []byte("{}")
+	if notification.Data != nil {
+		var err error
+		if data, err = json.Marshal(notification.Data); err != nil {
+			return 0, err
+		}
+	}
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO notifications (restaurant_id, user_id, type, title, body, data, created_at)
+		SELECT @restaurant_id, u.id, @type, @title, @body, @data::jsonb, NOW()
+		FROM users u
+		WHERE u.restaurant_id = @restaurant_id AND u.role IN @roles AND u.is_active
+	`, map[string]interface{}{
+		"restaurant_id": notification.RestaurantID,
+		"roles":         roles,
+		"type":          notification.Type,
+		"title":         notification.Title,
+		"body":          notification.Body,
+		"data":          string(data),
+	})
+	return result.RowsAffected, result.Error
+}
+
+// CreateWithContext creates a notification for its user
+func (r *NotificationRepository) CreateWithContext(ctx context.Context, notification *models.Notification) error {
+	return r.db.WithContext(ctx).Create(notification).Error
+}
+
+// ListWithContext lists a user's notifications, newest first
+func (r *NotificationRepository) ListWithContext(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// CountUnreadWithContext counts a user's unread notifications
+func (r *NotificationRepository) CountUnreadWithContext(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// GetCreatedAfterWithContext returns a user's notifications with an ID greater than afterID, oldest first
+func (r *NotificationRepository) GetCreatedAfterWithContext(ctx context.Context, userID, afterID uint, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND id > ?", userID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// GetLatestIDWithContext returns the highest notification ID of a user
+func (r *NotificationRepository) GetLatestIDWithContext(ctx context.Context, userID uint) (uint, error) {
+	var id uint
+	if err := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).Error; err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// MarkReadWithContext marks a user's notifications read; all unread ones when ids is empty
+func (r *NotificationRepository) MarkReadWithContext(ctx context.Context, userID uint, ids []uint, at time.Time) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Notification{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+	result := query.Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
+
+// DeleteWithContext deletes one of a user's notifications
+func (r *NotificationRepository) DeleteWithContext(ctx context.Context, userID, id uint) (int64, error) {
+	result := r.db.WithContext(ctx).Where("user_id = ? AND id = ?", userID, id).Delete(&models.Notification{})
+	return result.RowsAffected, result.Error
+}
+
+// ClearWithContext deletes a user's notifications, only the read ones when readOnly is set
+func (r *NotificationRepository) ClearWithContext(ctx context.Context, userID uint, readOnly bool) (int64, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if readOnly {
+		query = query.Where("read_at IS NOT NULL")
+	}
+	result := query.Delete(&models.Notification{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteOlderThanWithContext deletes every notification created before the cutoff
+func (r *NotificationRepository) DeleteOlderThanWithContext(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", cutoff).Delete(&models.Notification{})
+	return result.RowsAffected, result.Error
+}
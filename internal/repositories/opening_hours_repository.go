@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// OpeningHoursRepository handles opening hours database operations
+type OpeningHoursRepository struct {
+	db *gorm.DB
+}
+
+// NewOpeningHoursRepository creates a new OpeningHoursRepository instance
+func NewOpeningHoursRepository(db *gorm.DB) *OpeningHoursRepository {
+	return &OpeningHoursRepository{db: db}
+}
+
+// GetByRestaurantIDWithContext lists a restaurant's opening hours by weekday and opening time
+func (r *OpeningHoursRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.OpeningHours, error) {
+	var hours []models.OpeningHours
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("weekday ASC, opens_at ASC").
+		Find(&hours).Error; err != nil {
+		return nil, err
+	}
+	return hours, nil
+}
+
+// ReplaceWithContext replaces a restaurant's opening hours in one transaction
+func (r *OpeningHoursRepository) ReplaceWithContext(ctx context.Context, restaurantID uint, hours []models.OpeningHours) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("restaurant_id = ?", restaurantID).Delete(&models.OpeningHours{}).Error; err != nil {
+			return err
+		}
+		if len(hours) == 0 {
+			return nil
+		}
+		return tx.Create(&hours).Error
+	})
+}
@@ -58,3 +58,22 @@ func (r *OrderItemRepository) GetByOrderIDWithContext(ctx context.Context, order
 	}
 	return orderItems, nil
 }
+
+// UpdateSeatsWithContext assigns order items of an order to seats; a nil seat unassigns the item
+// Returns gorm.ErrRecordNotFound if an item doesn't belong to the order
+func (r *OrderItemRepository) UpdateSeatsWithContext(ctx context.Context, orderID uint, seats map[uint]*int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for itemID, seat := range seats {
+			result := tx.Model(&models.OrderItem{}).
+				Where("id = ? AND order_id = ?", itemID, orderID).
+				Update("seat", seat)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return gorm.ErrRecordNotFound
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,275 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OrderPartitionTables are the tables partitioned by month of created_at, in conversion order
+// (order_items references orders)
+var OrderPartitionTables = []string{"orders", "order_items"}
+
+// OrderPartitionRepository converts the order tables to monthly range partitions and creates upcoming partitions
+type OrderPartitionRepository struct {
+	db *gorm.DB
+}
+
+// NewOrderPartitionRepository creates a new OrderPartitionRepository instance
+func NewOrderPartitionRepository(db *gorm.DB) *OrderPartitionRepository {
+	return &OrderPartitionRepository{db: db}
+}
+
+// PartitionConversion reports what converting a table to partitions changed
+type PartitionConversion struct {
+	Table              string
+	Partitions         int
+	DroppedForeignKeys []string // Foreign keys of other tables to this one, which partitioned tables can't have
+	NonUniqueIndexes   []string // Unique indexes kept as plain ones, as they can't be unique without created_at
+}
+
+// tablePolicy is a row security policy as listed by pg_policies
+type tablePolicy struct {
+	Name       string
+	Permissive string
+	Roles      string
+	Cmd        string
+	Qual       *string
+	WithCheck  *string
+}
+
+// IsPartitionedWithContext reports whether a table is already partitioned
+func (r *OrderPartitionRepository) IsPartitionedWithContext(ctx context.Context, table string) (bool, error) {
+	var partitioned bool
+	err := r.db.WithContext(ctx).Raw(
+		"SELECT EXISTS (SELECT 1 FROM pg_partitioned_table WHERE partrelid = to_regclass(?))",
+		table,
+	).Scan(&partitioned).Error
+	return partitioned, err
+}
+
+// CreateMonthlyPartitionsWithContext creates the missing monthly partitions of a partitioned table from the month
+// of from through the given number of following months, and returns how many it created
+func (r *OrderPartitionRepository) CreateMonthlyPartitionsWithContext(ctx context.Context, table string, from time.Time, months int) (int, error) {
+	created := 0
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		created, err = createMonthlyPartitions(tx, table, from, months)
+		return err
+	})
+	return created, err
+}
+
+// PartitionWithContext converts an unpartitioned table to monthly range partitions on created_at in one transaction,
+// holding an exclusive lock while the rows are copied (run it in a maintenance window). Partitions cover the
+// oldest row's month through monthsAhead months from now, with a default partition for anything outside them
+// The primary key becomes (id, created_at); indexes, outgoing foreign keys, row security policies and grants
+// are recreated on the new table
+func (r *OrderPartitionRepository) PartitionWithContext(ctx context.Context, table string, monthsAhead int) (*PartitionConversion, error) {
+	conversion := &PartitionConversion{Table: table}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(fmt.Sprintf("LOCK TABLE %s IN ACCESS EXCLUSIVE MODE", table)).Error; err != nil {
+			return fmt.Errorf("failed to lock %s: %w", table, err)
+		}
+
+		// Capture what the new table gets back before the old one is renamed
+		var indexes []string
+		if err := tx.Raw(
+			"SELECT pg_get_indexdef(indexrelid) FROM pg_index WHERE indrelid = ?::regclass AND NOT indisprimary",
+			table,
+		).Scan(&indexes).Error; err != nil {
+			return fmt.Errorf("failed to list indexes of %s: %w", table, err)
+		}
+		var foreignKeys []struct{ Name, Definition string }
+		if err := tx.Raw(
+			"SELECT conname AS name, pg_get_constraintdef(oid) AS definition FROM pg_constraint WHERE conrelid = ?::regclass AND contype = 'f'",
+			table,
+		).Scan(&foreignKeys).Error; err != nil {
+			return fmt.Errorf("failed to list foreign keys of %s: %w", table, err)
+		}
+		var references []struct{ ReferencingTable, Name string }
+		if err := tx.Raw(
+			"SELECT conrelid::regclass::text AS referencing_table, conname AS name FROM pg_constraint WHERE confrelid = ?::regclass AND contype = 'f'",
+			table,
+		).Scan(&references).Error; err != nil {
+			return fmt.Errorf("failed to list foreign keys to %s: %w", table, err)
+		}
+		var policies []tablePolicy
+		if err := tx.Raw(
+			"SELECT policyname AS name, permissive, array_to_string(roles, ', ') AS roles, cmd, qual, with_check FROM pg_policies WHERE schemaname = current_schema() AND tablename = ?",
+			table,
+		).Scan(&policies).Error; err != nil {
+			return fmt.Errorf("failed to list policies of %s: %w", table, err)
+		}
+		var grants []struct{ Grantee, Privilege string }
+		if err := tx.Raw(
+			"SELECT grantee, privilege_type AS privilege FROM information_schema.role_table_grants WHERE table_schema = current_schema() AND table_name = ? AND grantee NOT IN (current_user, 'PUBLIC')",
+			table,
+		).Scan(&grants).Error; err != nil {
+			return fmt.Errorf("failed to list grants of %s: %w", table, err)
+		}
+		var security struct{ Enabled, Forced bool }
+		if err := tx.Raw(
+			"SELECT relrowsecurity AS enabled, relforcerowsecurity AS forced FROM pg_class WHERE oid = ?::regclass",
+			table,
+		).Scan(&security).Error; err != nil {
+			return fmt.Errorf("failed to read row security of %s: %w", table, err)
+		}
+		var sequence *string
+		if err := tx.Raw("SELECT pg_get_serial_sequence(?, 'id')", table).Scan(&sequence).Error; err != nil {
+			return fmt.Errorf("failed to find id sequence of %s: %w", table, err)
+		}
+		var oldest *time.Time
+		if err := tx.Raw(fmt.Sprintf("SELECT MIN(created_at) FROM %s", table)).Scan(&oldest).Error; err != nil {
+			return fmt.Errorf("failed to find oldest row of %s: %w", table, err)
+		}
+
+		// Foreign keys to a partitioned table need a unique key on id alone, which it can't have
+		for _, ref := range references {
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s", ref.ReferencingTable, ref.Name)).Error; err != nil {
+				return fmt.Errorf("failed to drop foreign key %s: %w", ref.Name, err)
+			}
+			conversion.DroppedForeignKeys = append(conversion.DroppedForeignKeys, ref.ReferencingTable+"."+ref.Name)
+		}
+
+		legacy := table + "_unpartitioned"
+		if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s RENAME TO %s", table, legacy)).Error; err != nil {
+			return fmt.Errorf("failed to rename %s: %w", table, err)
+		}
+		if err := tx.Exec(fmt.Sprintf(
+			"CREATE TABLE %s (LIKE %s INCLUDING DEFAULTS INCLUDING CONSTRAINTS INCLUDING STORAGE INCLUDING COMMENTS) PARTITION BY RANGE (created_at)",
+			table, legacy,
+		)).Error; err != nil {
+			return fmt.Errorf("failed to create partitioned %s: %w", table, err)
+		}
+
+		from := time.Now()
+		if oldest != nil && oldest.Before(from) {
+			from = *oldest
+		}
+		months := monthsBetween(from, time.Now()) + monthsAhead
+		created, err := createMonthlyPartitions(tx, table, from, months)
+		if err != nil {
+			return err
+		}
+		if err := tx.Exec(fmt.Sprintf("CREATE TABLE %s_default PARTITION OF %s DEFAULT", table, table)).Error; err != nil {
+			return fmt.Errorf("failed to create default partition of %s: %w", table, err)
+		}
+		if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s_default ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+			return fmt.Errorf("failed to enable RLS on default partition of %s: %w", table, err)
+		}
+		conversion.Partitions = created + 1
+
+		if err := tx.Exec(fmt.Sprintf("INSERT INTO %s SELECT * FROM %s", table, legacy)).Error; err != nil {
+			return fmt.Errorf("failed to copy %s: %w", table, err)
+		}
+
+		// The id sequence belongs to the old table and would be dropped with it
+		if sequence != nil {
+			if err := tx.Exec(fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s.id", *sequence, table)).Error; err != nil {
+				return fmt.Errorf("failed to move id sequence of %s: %w", table, err)
+			}
+		}
+		if err := tx.Exec(fmt.Sprintf("DROP TABLE %s", legacy)).Error; err != nil {
+			return fmt.Errorf("failed to drop old %s: %w", table, err)
+		}
+
+		// Unique keys of partitioned tables must include the partition key
+		if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (id, created_at)", table)).Error; err != nil {
+			return fmt.Errorf("failed to add primary key to %s: %w", table, err)
+		}
+		for _, index := range indexes {
+			if strings.HasPrefix(index, "CREATE UNIQUE INDEX") && !strings.Contains(index, "created_at") {
+				index = strings.Replace(index, "CREATE UNIQUE INDEX", "CREATE INDEX", 1)
+				conversion.NonUniqueIndexes = append(conversion.NonUniqueIndexes, strings.Fields(index)[2])
+			}
+			if err := tx.Exec(index).Error; err != nil {
+				return fmt.Errorf("failed to recreate index on %s: %w", table, err)
+			}
+		}
+		for _, fk := range foreignKeys {
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s %s", table, fk.Name, fk.Definition)).Error; err != nil {
+				return fmt.Errorf("failed to recreate foreign key %s: %w", fk.Name, err)
+			}
+		}
+
+		if security.Enabled {
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", table)).Error; err != nil {
+				return fmt.Errorf("failed to enable RLS on %s: %w", table, err)
+			}
+		}
+		if security.Forced {
+			if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s FORCE ROW LEVEL SECURITY", table)).Error; err != nil {
+				return fmt.Errorf("failed to force RLS on %s: %w", table, err)
+			}
+		}
+		for _, policy := range policies {
+			if err := tx.Exec(policy.createSQL(table)).Error; err != nil {
+				return fmt.Errorf("failed to recreate policy %s: %w", policy.Name, err)
+			}
+		}
+		for _, grant := range grants {
+			if err := tx.Exec(fmt.Sprintf(`GRANT %s ON %s TO "%s"`, grant.Privilege, table, grant.Grantee)).Error; err != nil {
+				return fmt.Errorf("failed to grant %s on %s: %w", grant.Privilege, table, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return conversion, nil
+}
+
+// createSQL returns the statement recreating the policy on a table
+func (p tablePolicy) createSQL(table string) string {
+	sql := fmt.Sprintf("CREATE POLICY %s ON %s AS %s FOR %s TO %s", p.Name, table, p.Permissive, p.Cmd, p.Roles)
+	if p.Qual != nil {
+		sql += fmt.Sprintf(" USING (%s)", *p.Qual)
+	}
+	if p.WithCheck != nil {
+		sql += fmt.Sprintf(" WITH CHECK (%s)", *p.WithCheck)
+	}
+	return sql
+}
+
+// createMonthlyPartitions creates the missing monthly partitions of a table, named like orders_2026_01
+// Partitions have row security enabled without policies: the parent's policies apply to queries through it,
+// and direct queries on a partition return nothing to the app role
+func createMonthlyPartitions(tx *gorm.DB, table string, from time.Time, months int) (int, error) {
+	created := 0
+	month := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i <= months; i, month = i+1, month.AddDate(0, 1, 0) {
+		partition := fmt.Sprintf("%s_%04d_%02d", table, month.Year(), int(month.Month()))
+
+		var exists bool
+		if err := tx.Raw("SELECT to_regclass(?) IS NOT NULL", partition).Scan(&exists).Error; err != nil {
+			return created, fmt.Errorf("failed to check partition %s: %w", partition, err)
+		}
+		if exists {
+			continue
+		}
+
+		if err := tx.Exec(fmt.Sprintf(
+			"CREATE TABLE %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s')",
+			partition, table, month.Format(time.RFC3339), month.AddDate(0, 1, 0).Format(time.RFC3339),
+		)).Error; err != nil {
+			return created, fmt.Errorf("failed to create partition %s: %w", partition, err)
+		}
+		if err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ENABLE ROW LEVEL SECURITY", partition)).Error; err != nil {
+			return created, fmt.Errorf("failed to enable RLS on partition %s: %w", partition, err)
+		}
+		created++
+	}
+	return created, nil
+}
+
+// monthsBetween returns the number of calendar months from the month of from to the month of to
+func monthsBetween(from, to time.Time) int {
+	from, to = from.UTC(), to.UTC()
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
@@ -2,11 +2,25 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/pii"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrOrderSlotFull is returned when the kitchen capacity of a scheduled order's slot is exhausted
+var ErrOrderSlotFull = errors.New("order slot is full")
+
+// ErrOrderStatusChanged is returned when an order's status changed between reading and transitioning it
+var ErrOrderStatusChanged = errors.New("order status changed concurrently")
+
+// ErrCourseNotHeld is returned when an order has no held items left in the course being fired
+var ErrCourseNotHeld = errors.New("course is not held")
+
 // OrderRepository handles order-related database operations
 type OrderRepository struct {
 	db *gorm.DB
@@ -27,6 +41,63 @@ func (r *OrderRepository) CreateWithContext(ctx context.Context, order *models.O
 	return r.db.WithContext(ctx).Create(order).Error
 }
 
+// CreateWithLockedMenuItemsWithContext creates an order in a single transaction
+// The referenced menu items are locked (SELECT ... FOR UPDATE) for the duration of the transaction,
+// so their price and availability cannot change between pricing and inserting the order
+// build receives the locked menu items keyed by ID and must fill in the order; returning an error rolls back
+// For scheduled orders, a positive slotCapacity limits the orders scheduled in the order's slot;
+// ErrOrderSlotFull is returned when it is reached
+func (r *OrderRepository) CreateWithLockedMenuItemsWithContext(
+	ctx context.Context,
+	order *models.Order,
+	menuItemIDs []uint,
+	slotCapacity int,
+	build func(menuItems map[uint]*models.MenuItem) error,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock in ID order so concurrent orders for the same items cannot deadlock
+		var menuItems []models.MenuItem
+		// Items off the public menu (drafts, archived categories) can't be ordered and are treated as missing
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", menuItemIDs).
+			Where(onPublishedMenu).
+			Order("id ASC").
+			Find(&menuItems).Error; err != nil {
+			return err
+		}
+
+		byID := make(map[uint]*models.MenuItem, len(menuItems))
+		for i := range menuItems {
+			byID[menuItems[i].ID] = &menuItems[i]
+		}
+
+		if err := build(byID); err != nil {
+			return err
+		}
+
+		if order.ScheduledFor != nil && slotCapacity > 0 {
+			// Serialize orders booking the same slot so its capacity can't be exceeded
+			slot := order.ScheduledFor.Truncate(models.OrderSlotDuration)
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", int32(order.RestaurantID), int32(slot.Unix()/60)).Error; err != nil {
+				return err
+			}
+
+			var booked int64
+			if err := tx.Model(&models.Order{}).
+				Where("restaurant_id = ? AND status <> ? AND scheduled_for >= ? AND scheduled_for < ?",
+					order.RestaurantID, models.OrderStatusCancelled, slot, slot.Add(models.OrderSlotDuration)).
+				Count(&booked).Error; err != nil {
+				return err
+			}
+			if booked >= int64(slotCapacity) {
+				return ErrOrderSlotFull
+			}
+		}
+
+		return tx.Create(order).Error
+	})
+}
+
 // GetByID retrieves an order by ID (RLS ensures tenant isolation)
 func (r *OrderRepository) GetByID(id uint) (*models.Order, error) {
 	var order models.Order
@@ -45,6 +116,18 @@ func (r *OrderRepository) GetByIDWithContext(ctx context.Context, id uint) (*mod
 	return &order, nil
 }
 
+// GetByIDForRestaurant retrieves an order by ID, scoped to the restaurant
+// Orders of other restaurants return gorm.ErrRecordNotFound, as if they did not exist
+func (r *OrderRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.Order, error) {
+	var order models.Order
+	if err := r.db.WithContext(ctx).Preload("OrderItems").Preload("OrderItems.MenuItem").Preload("User").
+		Where("restaurant_id = ?", restaurantID).
+		First(&order, id).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
 // GetByRestaurantID retrieves all orders for a restaurant (RLS ensures tenant isolation)
 func (r *OrderRepository) GetByRestaurantID(restaurantID uint) ([]models.Order, error) {
 	var orders []models.Order
@@ -73,6 +156,21 @@ func (r *OrderRepository) GetByRestaurantIDWithContext(ctx context.Context, rest
 	return orders, nil
 }
 
+// ExportWithContext passes a restaurant's orders with their items to fn in batches, oldest first,
+// optionally only those of one user as in the order list
+func (r *OrderRepository) ExportWithContext(ctx context.Context, restaurantID uint, userID *uint, fn func([]models.Order) error) error {
+	query := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID)
+	if userID != nil {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	var batch []models.Order
+	return query.Preload("OrderItems").
+		FindInBatches(&batch, exportBatchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
 // GetByUserID retrieves all orders for a user (RLS ensures tenant isolation)
 func (r *OrderRepository) GetByUserID(restaurantID uint, userID uint) ([]models.Order, error) {
 	var orders []models.Order
@@ -119,6 +217,146 @@ func (r *OrderRepository) UpdateStatusWithContext(ctx context.Context, id uint,
 	return r.db.WithContext(ctx).Model(&models.Order{}).Where("id = ?", id).Update("status", status).Error
 }
 
+// TransitionStatusWithContext moves an order from change.FromStatus to change.ToStatus and records the change
+// The update only applies while the order is still in FromStatus, so concurrent transitions cannot both succeed
+// Moving to preparing also records the change's time as the order's PrepStartedAt
+func (r *OrderRepository) TransitionStatusWithContext(ctx context.Context, change *models.OrderStatusChange) error {
+	if change.CreatedAt.IsZero() {
+		change.CreatedAt = time.Now()
+	}
+	updates := map[string]interface{}{"status": change.ToStatus}
+	if change.ToStatus == models.OrderStatusPreparing {
+		updates["prep_started_at"] = change.CreatedAt
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Order{}).
+			Where("id = ? AND status = ?", change.OrderID, change.FromStatus).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderStatusChanged
+		}
+
+		return tx.Create(change).Error
+	})
+}
+
+// UpdatePrepEstimatesWithContext stores the prep time estimates of orders without touching their other columns
+func (r *OrderRepository) UpdatePrepEstimatesWithContext(ctx context.Context, orders []models.Order) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i := range orders {
+			if err := tx.Model(&models.Order{}).Where("id = ?", orders[i].ID).UpdateColumns(map[string]interface{}{
+				"estimated_prep_minutes": orders[i].EstimatedPrepMinutes,
+				"estimated_ready_at":     orders[i].EstimatedReadyAt,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetStatusHistoryWithContext retrieves the status changes of an order, oldest first
+func (r *OrderRepository) GetStatusHistoryWithContext(ctx context.Context, orderID uint) ([]models.OrderStatusChange, error) {
+	var changes []models.OrderStatusChange
+	if err := r.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at ASC, id ASC").
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetCreatedAfterWithContext retrieves a restaurant's orders with an ID above afterID, oldest first
+func (r *OrderRepository) GetCreatedAfterWithContext(ctx context.Context, restaurantID, afterID uint, limit int) ([]models.Order, error) {
+	var orders []models.Order
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND id > ?", restaurantID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetStatusChangesAfterWithContext retrieves a restaurant's order status changes with an ID above afterID, oldest first
+func (r *OrderRepository) GetStatusChangesAfterWithContext(ctx context.Context, restaurantID, afterID uint, limit int) ([]models.OrderStatusChange, error) {
+	var changes []models.OrderStatusChange
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND id > ?", restaurantID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetLatestIDsWithContext returns the highest order and order status change IDs of a restaurant
+func (r *OrderRepository) GetLatestIDsWithContext(ctx context.Context, restaurantID uint) (orderID, statusChangeID uint, err error) {
+	if err = r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("restaurant_id = ?", restaurantID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&orderID).Error; err != nil {
+		return 0, 0, err
+	}
+	if err = r.db.WithContext(ctx).Model(&models.OrderStatusChange{}).
+		Where("restaurant_id = ?", restaurantID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&statusChangeID).Error; err != nil {
+		return 0, 0, err
+	}
+	return orderID, statusChangeID, nil
+}
+
+// FireCourseWithContext releases the held items of a course to the kitchen and records the firing
+// Only items not fired yet are released, so a course cannot be fired twice
+func (r *OrderRepository) FireCourseWithContext(ctx context.Context, firing *models.OrderCourseFiring) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.OrderItem{}).
+			Where("order_id = ? AND course = ? AND fired_at IS NULL", firing.OrderID, firing.Course).
+			Update("fired_at", gorm.Expr("NOW()"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCourseNotHeld
+		}
+
+		return tx.Create(firing).Error
+	})
+}
+
+// GetCourseFiringsAfterWithContext retrieves a restaurant's course firings with an ID above afterID, oldest first
+func (r *OrderRepository) GetCourseFiringsAfterWithContext(ctx context.Context, restaurantID, afterID uint, limit int) ([]models.OrderCourseFiring, error) {
+	var firings []models.OrderCourseFiring
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND id > ?", restaurantID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&firings).Error; err != nil {
+		return nil, err
+	}
+	return firings, nil
+}
+
+// GetLatestCourseFiringIDWithContext returns the highest course firing ID of a restaurant
+func (r *OrderRepository) GetLatestCourseFiringIDWithContext(ctx context.Context, restaurantID uint) (uint, error) {
+	var id uint
+	if err := r.db.WithContext(ctx).Model(&models.OrderCourseFiring{}).
+		Where("restaurant_id = ?", restaurantID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).Error; err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
 // OrderStats represents order statistics
 type OrderStats struct {
 	TotalOrders     int64   `json:"total_orders"`
@@ -128,52 +366,197 @@ type OrderStats struct {
 	TotalRevenue    float64 `json:"total_revenue"`
 }
 
-// GetOrderStats retrieves order statistics for a restaurant within a date range
+// GetOrderStats retrieves order statistics for a restaurant within a date range in a single aggregate query
 func (r *OrderRepository) GetOrderStats(ctx context.Context, restaurantID uint, startDate, endDate string) (*OrderStats, error) {
 	var stats OrderStats
-
-	// Get total orders
 	if err := r.db.WithContext(ctx).
 		Model(&models.Order{}).
+		Select(`
+			COUNT(*) AS total_orders,
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending_orders,
+			COUNT(*) FILTER (WHERE status = 'completed') AS completed_orders,
+			COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_orders,
+			COALESCE(SUM(total_amount) FILTER (WHERE status = 'completed'), 0) AS total_revenue`).
 		Where("restaurant_id = ? AND created_at >= ? AND created_at <= ?", restaurantID, startDate, endDate).
-		Count(&stats.TotalOrders).Error; err != nil {
+		Scan(&stats).Error; err != nil {
 		return nil, err
 	}
+	return &stats, nil
+}
 
-	// Get pending orders
+// OrderSourceTotals is the order count and total of one order source and status
+type OrderSourceTotals struct {
+	Source     string
+	Status     string
+	OrderCount int64
+	Amount     money.Cents
+}
+
+// GetSourceTotals aggregates the orders placed in [start, end) by source and status
+func (r *OrderRepository) GetSourceTotals(ctx context.Context, restaurantID uint, start, end time.Time) ([]OrderSourceTotals, error) {
+	var totals []OrderSourceTotals
 	if err := r.db.WithContext(ctx).
 		Model(&models.Order{}).
-		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at <= ?", restaurantID, "pending", startDate, endDate).
-		Count(&stats.PendingOrders).Error; err != nil {
+		Select(`
+			source,
+			status,
+			COUNT(*) AS order_count,
+			COALESCE(SUM(total_amount), 0) AS amount`).
+		Where("restaurant_id = ? AND created_at >= ? AND created_at < ?", restaurantID, start, end).
+		Group("source, status").
+		Order("source, status").
+		Scan(&totals).Error; err != nil {
 		return nil, err
 	}
+	return totals, nil
+}
 
-	// Get completed orders
+// RevenueBucket is the completed order revenue of one day or week
+type RevenueBucket struct {
+	Bucket  time.Time
+	Revenue money.Cents
+}
+
+// GetRevenueBuckets aggregates the revenue of the orders completed among those placed in [start, end)
+// per day or week (unit) in the given time zone
+func (r *OrderRepository) GetRevenueBuckets(ctx context.Context, restaurantID uint, unit, timeZone string, start, end time.Time) ([]RevenueBucket, error) {
+	var buckets []RevenueBucket
 	if err := r.db.WithContext(ctx).
 		Model(&models.Order{}).
-		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at <= ?", restaurantID, "completed", startDate, endDate).
-		Count(&stats.CompletedOrders).Error; err != nil {
+		Select(`
+			date_trunc(?, created_at AT TIME ZONE ?) AS bucket,
+			COALESCE(SUM(total_amount), 0) AS revenue`,
+			unit, timeZone).
+		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at < ?",
+			restaurantID, models.OrderStatusCompleted, start, end).
+		Group("bucket").
+		Order("bucket").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// ItemSales is the quantity sold and revenue of one menu item
+type ItemSales struct {
+	MenuItemID uint    `json:"menu_item_id"`
+	Name       string  `json:"name"`
+	Quantity   int64   `json:"quantity"`
+	Revenue    float64 `json:"revenue"`
+}
+
+// GetTopItems returns the best-selling menu items by quantity among the orders completed of those
+// placed in [start, end)
+func (r *OrderRepository) GetTopItems(ctx context.Context, restaurantID uint, start, end time.Time, limit int) ([]ItemSales, error) {
+	items := []ItemSales{}
+	if err := r.db.WithContext(ctx).
+		Table("order_items").
+		Select(`
+			order_items.menu_item_id,
+			COALESCE(MAX(menu_items.name), MAX(order_items.name)) AS name,
+			SUM(order_items.quantity) AS quantity,
+			ROUND(SUM(order_items.quantity * order_items.price)::NUMERIC, 2) AS revenue`).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Joins("LEFT JOIN menu_items ON menu_items.id = order_items.menu_item_id").
+		Where("order_items.restaurant_id = ? AND orders.status = ? AND orders.created_at >= ? AND orders.created_at < ?",
+			restaurantID, models.OrderStatusCompleted, start, end).
+		Group("order_items.menu_item_id").
+		Order("quantity DESC, revenue DESC, order_items.menu_item_id").
+		Limit(limit).
+		Scan(&items).Error; err != nil {
 		return nil, err
 	}
+	return items, nil
+}
 
-	// Get cancelled orders
+// CategorySales is the quantity sold and revenue of one menu category
+type CategorySales struct {
+	CategoryID uint    `json:"category_id"`
+	Name       string  `json:"name"`
+	Quantity   int64   `json:"quantity"`
+	Revenue    float64 `json:"revenue"`
+}
+
+// GetCategorySales aggregates the items of the orders completed among those placed in [start, end)
+// per menu category, by revenue
+func (r *OrderRepository) GetCategorySales(ctx context.Context, restaurantID uint, start, end time.Time) ([]CategorySales, error) {
+	categories := []CategorySales{}
 	if err := r.db.WithContext(ctx).
-		Model(&models.Order{}).
-		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at <= ?", restaurantID, "cancelled", startDate, endDate).
-		Count(&stats.CancelledOrders).Error; err != nil {
+		Table("order_items").
+		Select(`
+			menu_items.category_id,
+			MAX(menu_categories.name) AS name,
+			SUM(order_items.quantity) AS quantity,
+			ROUND(SUM(order_items.quantity * order_items.price)::NUMERIC, 2) AS revenue`).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Joins("JOIN menu_items ON menu_items.id = order_items.menu_item_id").
+		Joins("LEFT JOIN menu_categories ON menu_categories.id = menu_items.category_id").
+		Where("order_items.restaurant_id = ? AND orders.status = ? AND orders.created_at >= ? AND orders.created_at < ?",
+			restaurantID, models.OrderStatusCompleted, start, end).
+		Group("menu_items.category_id").
+		Order("revenue DESC, menu_items.category_id").
+		Scan(&categories).Error; err != nil {
 		return nil, err
 	}
+	return categories, nil
+}
+
+// HeatmapCell is the completed order count and revenue of one hour of one weekday
+type HeatmapCell struct {
+	DayOfWeek  int     `json:"day_of_week"` // 0 is Sunday
+	Hour       int     `json:"hour"`        // 0-23
+	OrderCount int64   `json:"order_count"`
+	Revenue    float64 `json:"revenue"`
+}
 
-	// Get total revenue (sum of total_amount for completed orders)
+// GetRevenueHeatmap aggregates the orders completed among those placed in [start, end) per weekday
+// and hour in the given time zone; cells without orders are omitted
+func (r *OrderRepository) GetRevenueHeatmap(ctx context.Context, restaurantID uint, timeZone string, start, end time.Time) ([]HeatmapCell, error) {
+	cells := []HeatmapCell{}
 	if err := r.db.WithContext(ctx).
 		Model(&models.Order{}).
-		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at <= ?", restaurantID, "completed", startDate, endDate).
-		Select("COALESCE(SUM(total_amount), 0)").
-		Scan(&stats.TotalRevenue).Error; err != nil {
+		Select(`
+			EXTRACT(DOW FROM created_at AT TIME ZONE ?)::INT AS day_of_week,
+			EXTRACT(HOUR FROM created_at AT TIME ZONE ?)::INT AS hour,
+			COUNT(*) AS order_count,
+			ROUND(SUM(total_amount)::NUMERIC, 2) AS revenue`,
+			timeZone, timeZone).
+		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at < ?",
+			restaurantID, models.OrderStatusCompleted, start, end).
+		Group("day_of_week, hour").
+		Order("day_of_week, hour").
+		Scan(&cells).Error; err != nil {
 		return nil, err
 	}
+	return cells, nil
+}
 
-	return &stats, nil
+// OrderValueBucket is the completed order count and revenue of one day or week
+type OrderValueBucket struct {
+	Bucket     time.Time
+	OrderCount int64
+	Revenue    float64
+}
+
+// GetOrderValueBuckets aggregates the orders completed among those placed in [start, end)
+// per day or week (unit) in the given time zone
+func (r *OrderRepository) GetOrderValueBuckets(ctx context.Context, restaurantID uint, unit, timeZone string, start, end time.Time) ([]OrderValueBucket, error) {
+	var buckets []OrderValueBucket
+	if err := r.db.WithContext(ctx).
+		Model(&models.Order{}).
+		Select(`
+			date_trunc(?, created_at AT TIME ZONE ?) AS bucket,
+			COUNT(*) AS order_count,
+			COALESCE(SUM(total_amount), 0) AS revenue`,
+			unit, timeZone).
+		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at < ?",
+			restaurantID, models.OrderStatusCompleted, start, end).
+		Group("bucket").
+		Order("bucket").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
 }
 
 // GetRecentOrders retrieves the most recent orders for a restaurant
@@ -211,3 +594,157 @@ func (r *OrderRepository) GetOrdersByStatus(ctx context.Context, restaurantID ui
 	}
 	return statusCounts, nil
 }
+
+// DisplayOrder represents the minimal order data shown on a pickup screen
+type DisplayOrder struct {
+	ID        uint      `json:"id"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetDisplayOrders retrieves recent orders in the given statuses for a pickup screen
+func (r *OrderRepository) GetDisplayOrders(ctx context.Context, restaurantID uint, statuses []string, since time.Time) ([]DisplayOrder, error) {
+	var orders []DisplayOrder
+	if err := r.db.WithContext(ctx).
+		Model(&models.Order{}).
+		Select("id, status, updated_at").
+		Where("restaurant_id = ? AND status IN ? AND updated_at >= ?", restaurantID, statuses, since).
+		Order("updated_at ASC").
+		Scan(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetKitchenOrdersWithContext retrieves orders in the given statuses with their items, oldest first (kitchen queue)
+// Scheduled orders are only included once they are due before dueBefore, ordered by their scheduled time
+func (r *OrderRepository) GetKitchenOrdersWithContext(ctx context.Context, restaurantID uint, statuses []string, dueBefore time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND status IN ?", restaurantID, statuses).
+		Where("scheduled_for IS NULL OR scheduled_for < ?", dueBefore).
+		Preload("OrderItems").
+		Preload("OrderItems.MenuItem").
+		Order("COALESCE(scheduled_for, created_at) ASC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// KitchenLoad is the number of orders in the kitchen queue and the portions they hold
+type KitchenLoad struct {
+	OpenOrders    int64 `json:"open_orders"`
+	ItemsInFlight int64 `json:"items_in_flight"`
+}
+
+// GetKitchenLoadWithContext counts the orders in the given statuses and their portions, like GetKitchenOrdersWithContext
+func (r *OrderRepository) GetKitchenLoadWithContext(ctx context.Context, restaurantID uint, statuses []string, dueBefore time.Time) (*KitchenLoad, error) {
+	var load KitchenLoad
+	if err := r.db.WithContext(ctx).Table("orders").
+		Select("COUNT(DISTINCT orders.id) AS open_orders, COALESCE(SUM(order_items.quantity), 0) AS items_in_flight").
+		Joins("LEFT JOIN order_items ON order_items.order_id = orders.id").
+		Where("orders.restaurant_id = ? AND orders.status IN ?", restaurantID, statuses).
+		Where("orders.scheduled_for IS NULL OR orders.scheduled_for < ?", dueBefore).
+		Scan(&load).Error; err != nil {
+		return nil, err
+	}
+	return &load, nil
+}
+
+// GetScheduledOrdersWithContext retrieves orders in the given statuses scheduled in [from, to) with their items, by scheduled time
+func (r *OrderRepository) GetScheduledOrdersWithContext(ctx context.Context, restaurantID uint, statuses []string, from, to time.Time) ([]models.Order, error) {
+	var orders []models.Order
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND status IN ?", restaurantID, statuses).
+		Where("scheduled_for >= ? AND scheduled_for < ?", from, to).
+		Preload("OrderItems").
+		Preload("OrderItems.MenuItem").
+		Order("scheduled_for ASC, id ASC").
+		Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// OrderSearchResult represents an order match in platform-wide support search
+type OrderSearchResult struct {
+	ID             uint      `json:"id"`
+	RestaurantID   uint      `json:"restaurant_id"`
+	RestaurantName string    `json:"restaurant_name"`
+	UserID         uint      `json:"user_id"`
+	CustomerEmail  string    `json:"customer_email"`
+	CustomerName   string    `json:"customer_name"`
+	CustomerPhone  string    `gorm:"serializer:pii" json:"customer_phone"`
+	Status         string    `json:"status"`
+	TotalAmount    float64   `json:"total_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// SearchWithContext finds orders by order number or by the customer's email, name or exact phone number (cross-tenant)
+func (r *OrderRepository) SearchWithContext(ctx context.Context, orderID *uint, term string, limit int) ([]OrderSearchResult, error) {
+	var results []OrderSearchResult
+	pattern := containsPattern(term)
+
+	query := r.db.WithContext(ctx).
+		Table("orders").
+		Select(`orders.id, orders.restaurant_id, restaurants.name AS restaurant_name, orders.user_id,
+			users.email AS customer_email, TRIM(users.first_name || ' ' || users.last_name) AS customer_name,
+			users.phone AS customer_phone, orders.status, orders.total_amount, orders.created_at`).
+		Joins("JOIN users ON users.id = orders.user_id").
+		Joins("JOIN restaurants ON restaurants.id = orders.restaurant_id")
+
+	if orderID != nil {
+		query = query.Where("orders.id = ?", *orderID)
+	} else {
+		query = query.Where(`users.email ILIKE ? ESCAPE '\' OR users.phone_hash = ?
+			OR (users.first_name || ' ' || users.last_name) ILIKE ? ESCAPE '\'`,
+			pattern, pii.HashPhone(term), pattern)
+	}
+
+	if err := query.Order("orders.created_at DESC").Limit(limit).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CountCreatedSinceWithContext counts the orders a restaurant received since the given time
+func (r *OrderRepository) CountCreatedSinceWithContext(ctx context.Context, restaurantID uint, since time.Time) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("restaurant_id = ? AND created_at >= ?", restaurantID, since).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// RestaurantOrderCount is the number of orders of a restaurant
+type RestaurantOrderCount struct {
+	RestaurantID uint
+	Count        int64
+}
+
+// CountProcessedByRestaurantWithContext counts the non-cancelled orders of every restaurant created in [start, end)
+func (r *OrderRepository) CountProcessedByRestaurantWithContext(ctx context.Context, start, end time.Time) ([]RestaurantOrderCount, error) {
+	var counts []RestaurantOrderCount
+	if err := r.db.WithContext(ctx).Model(&models.Order{}).
+		Select("restaurant_id, COUNT(*) AS count").
+		Where("created_at >= ? AND created_at < ? AND status <> ?", start, end, models.OrderStatusCancelled).
+		Group("restaurant_id").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// ExistsExternalWithContext checks if an order from a delivery platform was already imported
+func (r *OrderRepository) ExistsExternalWithContext(ctx context.Context, restaurantID uint, source, externalID string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Order{}).
+		Where("restaurant_id = ? AND source = ? AND external_id = ?", restaurantID, source, externalID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
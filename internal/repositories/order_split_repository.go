@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrOrderSplitPaid is returned when re-splitting an order some splits of which are already paid
+var ErrOrderSplitPaid = errors.New("order has paid splits")
+
+// OrderSplitRepository handles split bill database operations
+type OrderSplitRepository struct {
+	db *gorm.DB
+}
+
+// NewOrderSplitRepository creates a new OrderSplitRepository instance
+func NewOrderSplitRepository(db *gorm.DB) *OrderSplitRepository {
+	return &OrderSplitRepository{db: db}
+}
+
+// ReplaceForOrderWithContext replaces the splits of an order, unless some of them are already paid
+func (r *OrderSplitRepository) ReplaceForOrderWithContext(ctx context.Context, orderID uint, splits []models.OrderSplit) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the order so concurrent splits and payments of it serialize
+		if err := tx.Exec("SELECT id FROM orders WHERE id = ? FOR UPDATE", orderID).Error; err != nil {
+			return err
+		}
+
+		var paid int64
+		if err := tx.Model(&models.OrderSplit{}).
+			Where("order_id = ? AND status = ?", orderID, models.OrderSplitStatusPaid).
+			Count(&paid).Error; err != nil {
+			return err
+		}
+		if paid > 0 {
+			return ErrOrderSplitPaid
+		}
+
+		if err := tx.Where("order_id = ?", orderID).Delete(&models.OrderSplit{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&splits).Error
+	})
+}
+
+// ListByOrderWithContext lists the splits of an order with their item shares
+func (r *OrderSplitRepository) ListByOrderWithContext(ctx context.Context, orderID uint, restaurantID uint) ([]models.OrderSplit, error) {
+	var splits []models.OrderSplit
+	if err := r.db.WithContext(ctx).
+		Where("order_id = ? AND restaurant_id = ?", orderID, restaurantID).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Order("id ASC").
+		Find(&splits).Error; err != nil {
+		return nil, err
+	}
+	return splits, nil
+}
+
+// MarkPaidWithContext marks a pending split of an order as paid
+// Returns gorm.ErrRecordNotFound if the order has no such pending split
+func (r *OrderSplitRepository) MarkPaidWithContext(ctx context.Context, id uint, orderID uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).Model(&models.OrderSplit{}).
+		Where("id = ? AND order_id = ? AND restaurant_id = ? AND status = ?", id, orderID, restaurantID, models.OrderSplitStatusPending).
+		Updates(map[string]interface{}{
+			"status":  models.OrderSplitStatusPaid,
+			"paid_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
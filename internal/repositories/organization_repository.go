@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository handles organization-related database operations
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new OrganizationRepository instance
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// CreateWithContext creates a new organization using the provided context
+func (r *OrganizationRepository) CreateWithContext(ctx context.Context, organization *models.Organization) error {
+	return r.db.WithContext(ctx).Create(organization).Error
+}
+
+// GetByIDWithContext retrieves an organization by ID with its locations
+func (r *OrganizationRepository) GetByIDWithContext(ctx context.Context, id uint) (*models.Organization, error) {
+	var organization models.Organization
+	if err := r.db.WithContext(ctx).
+		Preload("Locations", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name ASC")
+		}).
+		First(&organization, id).Error; err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// UpdateWithContext updates an organization using the provided context
+func (r *OrganizationRepository) UpdateWithContext(ctx context.Context, organization *models.Organization) error {
+	return r.db.WithContext(ctx).Save(organization).Error
+}
+
+// LocationReport represents per-location totals for cross-location reporting
+type LocationReport struct {
+	RestaurantID      uint    `json:"restaurant_id"`
+	RestaurantName    string  `json:"restaurant_name"`
+	TotalOrders       int64   `json:"total_orders"`
+	CompletedOrders   int64   `json:"completed_orders"`
+	CancelledOrders   int64   `json:"cancelled_orders"`
+	TotalRevenue      float64 `json:"total_revenue"`
+	TotalReservations int64   `json:"total_reservations"`
+}
+
+// GetLocationReportWithContext aggregates order and reservation totals per location within a date range
+// Revenue counts completed orders only (consistent with dashboard stats)
+func (r *OrganizationRepository) GetLocationReportWithContext(ctx context.Context, organizationID uint, startDate, endDate string) ([]LocationReport, error) {
+	var reports []LocationReport
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT
+			r.id AS restaurant_id,
+			r.name AS restaurant_name,
+			COALESCE(o.total_orders, 0) AS total_orders,
+			COALESCE(o.completed_orders, 0) AS completed_orders,
+			COALESCE(o.cancelled_orders, 0) AS cancelled_orders,
+			COALESCE(o.total_revenue, 0) AS total_revenue,
+			COALESCE(res.total_reservations, 0) AS total_reservations
+		FROM restaurants r
+		LEFT JOIN (
+			SELECT restaurant_id,
+				COUNT(*) AS total_orders,
+				COUNT(*) FILTER (WHERE status = 'completed') AS completed_orders,
+				COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_orders,
+				SUM(total_amount) FILTER (WHERE status = 'completed') AS total_revenue
+			FROM orders
+			WHERE created_at >= ? AND created_at <= ?
+			GROUP BY restaurant_id
+		) o ON o.restaurant_id = r.id
+		LEFT JOIN (
+			SELECT restaurant_id, COUNT(*) AS total_reservations
+			FROM reservations
+			WHERE created_at >= ? AND created_at <= ?
+			GROUP BY restaurant_id
+		) res ON res.restaurant_id = r.id
+		WHERE r.organization_id = ?
+		ORDER BY r.name ASC
+	`, startDate, endDate, startDate, endDate, organizationID).Scan(&reports).Error; err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PendingChangeRepository handles the queue of Staff changes waiting for approval
+type PendingChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewPendingChangeRepository creates a new PendingChangeRepository instance
+func NewPendingChangeRepository(db *gorm.DB) *PendingChangeRepository {
+	return &PendingChangeRepository{db: db}
+}
+
+// PendingChangeFilter narrows a pending change listing; zero values don't filter
+type PendingChangeFilter struct {
+	Status      string
+	RequestedBy uint
+}
+
+// CreateWithContext creates a pending change
+func (r *PendingChangeRepository) CreateWithContext(ctx context.Context, change *models.PendingChange) error {
+	return r.db.WithContext(ctx).Create(change).Error
+}
+
+// GetByIDForRestaurant retrieves a pending change, scoped to the restaurant
+func (r *PendingChangeRepository) GetByIDForRestaurant(ctx context.Context, id, restaurantID uint) (*models.PendingChange, error) {
+	var change models.PendingChange
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&change, id).Error; err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// ListWithContext retrieves a page of a restaurant's pending changes, oldest first while pending and latest
+// first otherwise, and the total count
+func (r *PendingChangeRepository) ListWithContext(ctx context.Context, restaurantID uint, filter PendingChangeFilter, limit, offset int) ([]models.PendingChange, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.PendingChange{}).Where("restaurant_id = ?", restaurantID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.RequestedBy != 0 {
+		query = query.Where("requested_by = ?", filter.RequestedBy)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "id DESC"
+	if filter.Status == models.PendingChangeStatusPending {
+		order = "id ASC"
+	}
+	var changes []models.PendingChange
+	if err := query.Order(order).Limit(limit).Offset(offset).Find(&changes).Error; err != nil {
+		return nil, 0, err
+	}
+	return changes, total, nil
+}
+
+// ResolveWithContext records the review of a pending change, only while it is still pending, so each change is
+// approved or rejected once. Returns gorm.ErrRecordNotFound if it was already reviewed
+func (r *PendingChangeRepository) ResolveWithContext(ctx context.Context, id, restaurantID uint, status string, reviewedBy uint, note string, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&models.PendingChange{}).
+		Where("id = ? AND restaurant_id = ? AND status = ?", id, restaurantID, models.PendingChangeStatusPending).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"reviewed_by": reviewedBy,
+			"reviewed_at": at,
+			"review_note": note,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ReopenWithContext puts an approved change back in the queue, after making it failed
+func (r *PendingChangeRepository) ReopenWithContext(ctx context.Context, id, restaurantID uint) error {
+	return r.db.WithContext(ctx).Model(&models.PendingChange{}).
+		Where("id = ? AND restaurant_id = ? AND status = ?", id, restaurantID, models.PendingChangeStatusApproved).
+		Updates(map[string]interface{}{
+			"status":      models.PendingChangeStatusPending,
+			"reviewed_by": nil,
+			"reviewed_at": nil,
+			"review_note": "",
+		}).Error
+}
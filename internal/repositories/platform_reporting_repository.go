@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	tenantctx "restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Platform report bucket units, as accepted by date_trunc
+const (
+	PlatformBucketDay   = "day"
+	PlatformBucketMonth = "month"
+)
+
+// PlatformReportingRepository reads aggregates across all tenants for platform staff
+// Every query runs as the read-only platform reporting role rather than bypassing RLS with the
+// connection's own privileges, and fails if that role is unavailable. Times are bucketed in UTC
+// and the platform organization itself is excluded
+type PlatformReportingRepository struct {
+	db *gorm.DB
+}
+
+// NewPlatformReportingRepository creates a new PlatformReportingRepository instance
+func NewPlatformReportingRepository(db *gorm.DB) *PlatformReportingRepository {
+	return &PlatformReportingRepository{db: db}
+}
+
+// PlatformOrderBucket is the order volume of one day or month in one currency
+type PlatformOrderBucket struct {
+	Bucket                 time.Time `json:"-"`
+	Currency               string    `json:"currency"`
+	OrderCount             int64     `json:"order_count"`
+	CompletedOrders        int64     `json:"completed_orders"`
+	GMV                    float64   `json:"gmv"` // Total of completed orders
+	TransactingRestaurants int64     `json:"transacting_restaurants"`
+}
+
+// PlatformSignupBucket is the number of restaurants registered in one day or month
+type PlatformSignupBucket struct {
+	Bucket time.Time
+	Count  int64
+}
+
+// reporting returns a session whose statements run as the platform reporting role
+func (r *PlatformReportingRepository) reporting(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(tenantctx.WithPlatformReporting(ctx))
+}
+
+// ListOrderBucketsWithContext aggregates the orders created in [from, to) per day or month and currency
+// A restaurant has a single currency, so transacting restaurants add up across currencies
+func (r *PlatformReportingRepository) ListOrderBucketsWithContext(ctx context.Context, unit string, from, to time.Time) ([]PlatformOrderBucket, error) {
+	var buckets []PlatformOrderBucket
+	if err := r.reporting(ctx).Raw(`
+		SELECT date_trunc(@unit, o.created_at AT TIME ZONE 'UTC') AS bucket,
+			COALESCE(s.currency, @default_currency) AS currency,
+			COUNT(*) AS order_count,
+			COUNT(*) FILTER (WHERE o.status = 'completed') AS completed_orders,
+			COALESCE(SUM(o.total_amount) FILTER (WHERE o.status = 'completed'), 0) AS gmv,
+			COUNT(DISTINCT o.restaurant_id) AS transacting_restaurants
+		FROM orders o
+		LEFT JOIN restaurant_settings s ON s.restaurant_id = o.restaurant_id
+		WHERE o.created_at >= @from AND o.created_at < @to AND o.restaurant_id <> @platform_id
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`, map[string]interface{}{
+		"unit":             unit,
+		"default_currency": models.DefaultCurrency,
+		"from":             from,
+		"to":               to,
+		"platform_id":      models.PlatformOrganizationID,
+	}).Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// SumOrdersWithContext aggregates the orders created in [from, to) per currency; unlike the buckets,
+// a restaurant transacting on several days counts once
+func (r *PlatformReportingRepository) SumOrdersWithContext(ctx context.Context, from, to time.Time) ([]PlatformOrderBucket, error) {
+	var totals []PlatformOrderBucket
+	if err := r.reporting(ctx).Raw(`
+		SELECT COALESCE(s.currency, @default_currency) AS currency,
+			COUNT(*) AS order_count,
+			COUNT(*) FILTER (WHERE o.status = 'completed') AS completed_orders,
+			COALESCE(SUM(o.total_amount) FILTER (WHERE o.status = 'completed'), 0) AS gmv,
+			COUNT(DISTINCT o.restaurant_id) AS transacting_restaurants
+		FROM orders o
+		LEFT JOIN restaurant_settings s ON s.restaurant_id = o.restaurant_id
+		WHERE o.created_at >= @from AND o.created_at < @to AND o.restaurant_id <> @platform_id
+		GROUP BY 1
+		ORDER BY 1
+	`, map[string]interface{}{
+		"default_currency": models.DefaultCurrency,
+		"from":             from,
+		"to":               to,
+		"platform_id":      models.PlatformOrganizationID,
+	}).Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// ListSignupBucketsWithContext counts the restaurants registered in [from, to) per day or month
+func (r *PlatformReportingRepository) ListSignupBucketsWithContext(ctx context.Context, unit string, from, to time.Time) ([]PlatformSignupBucket, error) {
+	var buckets []PlatformSignupBucket
+	if err := r.reporting(ctx).
+		Model(&models.Restaurant{}).
+		Select("date_trunc(?, created_at AT TIME ZONE 'UTC') AS bucket, COUNT(*) AS count", unit).
+		Where("created_at >= ? AND created_at < ? AND id <> ?", from, to, models.PlatformOrganizationID).
+		Group("1").
+		Order("1").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// CountActiveRestaurantsWithContext counts the restaurants currently active
+func (r *PlatformReportingRepository) CountActiveRestaurantsWithContext(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.reporting(ctx).
+		Model(&models.Restaurant{}).
+		Where("status = ? AND id <> ?", models.RestaurantStatusActive, models.PlatformOrganizationID).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PricingRuleRepository handles pricing rule database operations
+type PricingRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewPricingRuleRepository creates a new PricingRuleRepository instance
+func NewPricingRuleRepository(db *gorm.DB) *PricingRuleRepository {
+	return &PricingRuleRepository{db: db}
+}
+
+// CreateWithContext creates a new pricing rule
+func (r *PricingRuleRepository) CreateWithContext(ctx context.Context, rule *models.PricingRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+// GetByIDForRestaurant retrieves a pricing rule by ID, scoped to the restaurant
+func (r *PricingRuleRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.PricingRule, error) {
+	var rule models.PricingRule
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&rule, id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// GetByRestaurantIDWithContext lists a restaurant's pricing rules, oldest first
+// activeOnly restricts the list to the rules that are switched on
+func (r *PricingRuleRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint, activeOnly bool) ([]models.PricingRule, error) {
+	query := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID)
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+
+	var rules []models.PricingRule
+	if err := query.Order("id ASC").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// UpdateWithContext saves a pricing rule
+func (r *PricingRuleRepository) UpdateWithContext(ctx context.Context, rule *models.PricingRule) error {
+	return r.db.WithContext(ctx).Save(rule).Error
+}
+
+// DeleteForRestaurant deletes a pricing rule scoped to the restaurant
+// Returns gorm.ErrRecordNotFound if the rule doesn't exist
+func (r *PricingRuleRepository) DeleteForRestaurant(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Delete(&models.PricingRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PrintJobRepository handles print job database operations
+type PrintJobRepository struct {
+	db *gorm.DB
+}
+
+// NewPrintJobRepository creates a new PrintJobRepository instance
+func NewPrintJobRepository(db *gorm.DB) *PrintJobRepository {
+	return &PrintJobRepository{db: db}
+}
+
+// PrintJobFilter filters print job listings; nil fields are not filtered
+type PrintJobFilter struct {
+	PrinterID *uint
+	OrderID   *uint
+	Status    *string
+}
+
+// CreateWithContext queues a print job
+func (r *PrintJobRepository) CreateWithContext(ctx context.Context, job *models.PrintJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// GetByIDForRestaurant retrieves a print job by ID, scoped to the restaurant
+func (r *PrintJobRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.PrintJob, error) {
+	var job models.PrintJob
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListWithContext lists the latest print jobs of a restaurant, newest first
+func (r *PrintJobRepository) ListWithContext(ctx context.Context, restaurantID uint, filter PrintJobFilter, limit int) ([]models.PrintJob, error) {
+	query := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID)
+	if filter.PrinterID != nil {
+		query = query.Where("printer_id = ?", *filter.PrinterID)
+	}
+	if filter.OrderID != nil {
+		query = query.Where("order_id = ?", *filter.OrderID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+
+	var jobs []models.PrintJob
+	if err := query.Order("id DESC").Limit(limit).Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// ClaimWithContext hands a printer's queued jobs to its bridge, oldest first, marking them as printing
+// Jobs claimed before staleBefore that were never confirmed are handed out again, until they
+// have been claimed maxAttempts times; they are failed then
+func (r *PrintJobRepository) ClaimWithContext(ctx context.Context, printerID uint, limit int, staleBefore time.Time, maxAttempts int) ([]models.PrintJob, error) {
+	var jobs []models.PrintJob
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.PrintJob{}).
+			Where("printer_id = ? AND status = ? AND claimed_at < ? AND attempts >= ?", printerID, models.PrintJobStatusPrinting, staleBefore, maxAttempts).
+			Updates(map[string]interface{}{
+				"status": models.PrintJobStatusFailed,
+				"error":  "printer did not confirm the job",
+			}).Error; err != nil {
+			return err
+		}
+
+		// SKIP LOCKED lets concurrent polls of the same printer claim different jobs
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("printer_id = ?", printerID).
+			Where("status = ? OR (status = ? AND claimed_at < ?)", models.PrintJobStatusPending, models.PrintJobStatusPrinting, staleBefore).
+			Order("id ASC").
+			Limit(limit).
+			Find(&jobs).Error; err != nil {
+			return err
+		}
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		ids := make([]uint, len(jobs))
+		for i := range jobs {
+			ids[i] = jobs[i].ID
+			jobs[i].Status = models.PrintJobStatusPrinting
+			jobs[i].ClaimedAt = &now
+			jobs[i].Attempts++
+		}
+		return tx.Model(&models.PrintJob{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"status":     models.PrintJobStatusPrinting,
+			"claimed_at": now,
+			"attempts":   gorm.Expr("attempts + 1"),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CompleteWithContext records the outcome a printer's bridge reported for a claimed job
+// Returns gorm.ErrRecordNotFound if the printer has no such job being printed
+func (r *PrintJobRepository) CompleteWithContext(ctx context.Context, id uint, printerID uint, status string, errMessage string) error {
+	updates := map[string]interface{}{
+		"status": status,
+		"error":  errMessage,
+	}
+	if status == models.PrintJobStatusPrinted {
+		updates["printed_at"] = time.Now()
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.PrintJob{}).
+		Where("id = ? AND printer_id = ? AND status = ?", id, printerID, models.PrintJobStatusPrinting).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// RequeueWithContext puts a job back into its printer's queue
+func (r *PrintJobRepository) RequeueWithContext(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.PrintJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     models.PrintJobStatusPending,
+		"error":      "",
+		"attempts":   0,
+		"claimed_at": nil,
+	}).Error
+}
@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PrinterRepository handles printer database operations
+type PrinterRepository struct {
+	db *gorm.DB
+}
+
+// NewPrinterRepository creates a new PrinterRepository instance
+func NewPrinterRepository(db *gorm.DB) *PrinterRepository {
+	return &PrinterRepository{db: db}
+}
+
+// CreateWithContext creates a new printer
+func (r *PrinterRepository) CreateWithContext(ctx context.Context, printer *models.Printer) error {
+	return r.db.WithContext(ctx).Create(printer).Error
+}
+
+// GetByIDForRestaurant retrieves a printer by ID, scoped to the restaurant
+func (r *PrinterRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.Printer, error) {
+	var printer models.Printer
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&printer, id).Error; err != nil {
+		return nil, err
+	}
+	return &printer, nil
+}
+
+// GetByRestaurantIDWithContext lists the printers of a restaurant
+func (r *PrinterRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.Printer, error) {
+	var printers []models.Printer
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("name ASC").
+		Find(&printers).Error; err != nil {
+		return nil, err
+	}
+	return printers, nil
+}
+
+// GetAutoPrintWithContext lists the active printers of a kind that print orders automatically
+func (r *PrinterRepository) GetAutoPrintWithContext(ctx context.Context, restaurantID uint, kind string) ([]models.Printer, error) {
+	var printers []models.Printer
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND kind = ? AND auto_print = ? AND is_active = ?", restaurantID, kind, true, true).
+		Order("id ASC").
+		Find(&printers).Error; err != nil {
+		return nil, err
+	}
+	return printers, nil
+}
+
+// GetByTokenWithContext retrieves a printer by its bridge token
+func (r *PrinterRepository) GetByTokenWithContext(ctx context.Context, token string) (*models.Printer, error) {
+	var printer models.Printer
+	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&printer).Error; err != nil {
+		return nil, err
+	}
+	return &printer, nil
+}
+
+// SaveWithContext updates a printer
+func (r *PrinterRepository) SaveWithContext(ctx context.Context, printer *models.Printer) error {
+	return r.db.WithContext(ctx).Save(printer).Error
+}
+
+// TouchLastSeenWithContext records a poll of the printer's bridge
+func (r *PrinterRepository) TouchLastSeenWithContext(ctx context.Context, id uint, seenAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.Printer{}).Where("id = ?", id).Update("last_seen_at", seenAt).Error
+}
+
+// DeleteWithContext deletes a printer of a restaurant together with its print jobs
+// Returns gorm.ErrRecordNotFound when the restaurant has no such printer
+func (r *PrinterRepository) DeleteWithContext(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).Delete(&models.Printer{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
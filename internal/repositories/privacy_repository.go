@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// PrivacyRepository handles the reads and writes of customers' data protection requests
+type PrivacyRepository struct {
+	db *gorm.DB
+}
+
+// NewPrivacyRepository creates a new PrivacyRepository instance
+func NewPrivacyRepository(db *gorm.DB) *PrivacyRepository {
+	return &PrivacyRepository{db: db}
+}
+
+// AnonymizeResult counts the rows an anonymization changed
+type AnonymizeResult struct {
+	Orders                  int64 `json:"orders"`
+	Reservations            int64 `json:"reservations"`
+	ArchivedOrders          int64 `json:"archived_orders"`
+	ArchivedReservations    int64 `json:"archived_reservations"`
+	Reviews                 int64 `json:"reviews"`
+	Customers               int64 `json:"customers"`
+	SMSMessages             int64 `json:"sms_messages"`
+	PushSubscriptions       int64 `json:"push_subscriptions"`
+	NotificationPreferences int64 `json:"notification_preferences"`
+}
+
+// GetReviewsByUserWithContext retrieves a user's reviews at a restaurant, newest first
+func (r *PrivacyRepository) GetReviewsByUserWithContext(ctx context.Context, restaurantID, userID uint) ([]models.Review, error) {
+	var reviews []models.Review
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		Order("id DESC").
+		Find(&reviews).Error; err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// AnonymizeUserWithContext erases a user's personal data at a restaurant and records the audit entry, in one transaction
+// Rows are kept with their amounts, ratings and statuses so revenue, stats and invoices don't change;
+// only the fields identifying the person are cleared. The account itself is deactivated and can't sign in again
+func (r *PrivacyRepository) AnonymizeUserWithContext(ctx context.Context, restaurantID, userID uint, audit *models.AuditLog) (*AnonymizeResult, error) {
+	result := &AnonymizeResult{}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).
+			Where("id = ? AND restaurant_id = ?", userID, restaurantID).
+			Updates(map[string]interface{}{
+				"email":         fmt.Sprintf("deleted-user-%d@anonymized.invalid", userID),
+				"password_hash": "",
+				"first_name":    "",
+				"last_name":     "",
+				"phone":         "",
+				"phone_hash":    "",
+				"avatar_url":    "",
+				"preferences":   "{}",
+				"is_active":     false,
+				"version":       bumpVersion,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to anonymize user: %w", err)
+		}
+
+		orders := tx.Model(&models.Order{}).
+			Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+			Updates(map[string]interface{}{
+				"notes":            "",
+				"delivery_address": "",
+				"delivery_lat":     nil,
+				"delivery_lng":     nil,
+			})
+		if orders.Error != nil {
+			return fmt.Errorf("failed to anonymize orders: %w", orders.Error)
+		}
+		result.Orders = orders.RowsAffected
+
+		if err := tx.Model(&models.OrderItem{}).
+			Where("restaurant_id = ? AND order_id IN (?)", restaurantID,
+				tx.Model(&models.Order{}).Select("id").Where("restaurant_id = ? AND user_id = ?", restaurantID, userID)).
+			Update("notes", "").Error; err != nil {
+			return fmt.Errorf("failed to anonymize order items: %w", err)
+		}
+
+		reservations := tx.Model(&models.Reservation{}).
+			Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+			Updates(map[string]interface{}{
+				"notes":            "",
+				"guest_email":      "",
+				"guest_phone":      "",
+				"guest_email_hash": "",
+				"guest_phone_hash": "",
+			})
+		if reservations.Error != nil {
+			return fmt.Errorf("failed to anonymize reservations: %w", reservations.Error)
+		}
+		result.Reservations = reservations.RowsAffected
+
+		// Archived rows keep their snapshot; the same fields are cleared in it
+		archivedOrders := tx.Exec(`
+			UPDATE archived_orders
+			SET data = data
+				|| '{"notes": "", "delivery_address": "", "delivery_lat": null, "delivery_lng": null}'::jsonb
+				|| jsonb_build_object('order_items', COALESCE((
+					SELECT jsonb_agg(e.item || '{"notes": ""}'::jsonb ORDER BY e.n)
+					FROM jsonb_array_elements(data->'order_items') WITH ORDINALITY AS e(item, n)
+				), '[]'::jsonb))
+			WHERE restaurant_id = ? AND user_id = ?
+		`, restaurantID, userID)
+		if archivedOrders.Error != nil {
+			return fmt.Errorf("failed to anonymize archived orders: %w", archivedOrders.Error)
+		}
+		result.ArchivedOrders = archivedOrders.RowsAffected
+
+		archivedReservations := tx.Exec(
+			`UPDATE archived_reservations SET data = data || '{"notes": "", "guest_email": "", "guest_phone": ""}'::jsonb WHERE restaurant_id = ? AND user_id = ?`,
+			restaurantID, userID,
+		)
+		if archivedReservations.Error != nil {
+			return fmt.Errorf("failed to anonymize archived reservations: %w", archivedReservations.Error)
+		}
+		result.ArchivedReservations = archivedReservations.RowsAffected
+
+		// Ratings stay so menu item and restaurant averages don't move
+		reviews := tx.Model(&models.Review{}).
+			Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+			Update("comment", "")
+		if reviews.Error != nil {
+			return fmt.Errorf("failed to anonymize reviews: %w", reviews.Error)
+		}
+		result.Reviews = reviews.RowsAffected
+
+		// CRM aggregates (order count, total spent, visits) are kept
+		customers := tx.Model(&models.Customer{}).
+			Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+			Updates(map[string]interface{}{
+				"name":       "",
+				"email":      "",
+				"phone":      "",
+				"email_hash": "",
+				"phone_hash": "",
+				"notes":      "",
+			})
+		if customers.Error != nil {
+			return fmt.Errorf("failed to anonymize customers: %w", customers.Error)
+		}
+		result.Customers = customers.RowsAffected
+
+		sms := tx.Model(&models.SMSMessage{}).
+			Where("restaurant_id = ?", restaurantID).
+			Where("order_id IN (?) OR reservation_id IN (?) OR order_id IN (?) OR reservation_id IN (?)",
+				tx.Model(&models.Order{}).Select("id").Where("restaurant_id = ? AND user_id = ?", restaurantID, userID),
+				tx.Model(&models.Reservation{}).Select("id").Where("restaurant_id = ? AND user_id = ?", restaurantID, userID),
+				tx.Model(&models.ArchivedOrder{}).Select("id").Where("restaurant_id = ? AND user_id = ?", restaurantID, userID),
+				tx.Model(&models.ArchivedReservation{}).Select("id").Where("restaurant_id = ? AND user_id = ?", restaurantID, userID)).
+			Updates(map[string]interface{}{"to": "", "to_hash": "", "body": ""})
+		if sms.Error != nil {
+			return fmt.Errorf("failed to anonymize SMS messages: %w", sms.Error)
+		}
+		result.SMSMessages = sms.RowsAffected
+
+		push := tx.Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).Delete(&models.PushSubscription{})
+		if push.Error != nil {
+			return fmt.Errorf("failed to delete push subscriptions: %w", push.Error)
+		}
+		result.PushSubscriptions = push.RowsAffected
+
+		preferences := tx.Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).Delete(&models.NotificationPreference{})
+		if preferences.Error != nil {
+			return fmt.Errorf("failed to delete notification preferences: %w", preferences.Error)
+		}
+		result.NotificationPreferences = preferences.RowsAffected
+
+		return tx.Create(audit).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,277 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrPrivateEventConflict is returned when an event's tables are reserved or blocked by another event at its time
+	ErrPrivateEventConflict = errors.New("private event overlaps a reservation or another event")
+	// ErrPrivateEventDepositPaid is returned when replacing the deposit schedule of an event some deposits of which are paid
+	ErrPrivateEventDepositPaid = errors.New("private event has paid deposits")
+)
+
+// privateEventHoldingStatuses are the statuses in which an event blocks its tables
+var privateEventHoldingStatuses = []string{models.PrivateEventStatusQuoted, models.PrivateEventStatusConfirmed}
+
+// PrivateEventRepository handles private event and event package database operations
+type PrivateEventRepository struct {
+	db *gorm.DB
+}
+
+// NewPrivateEventRepository creates a new PrivateEventRepository instance
+func NewPrivateEventRepository(db *gorm.DB) *PrivateEventRepository {
+	return &PrivateEventRepository{db: db}
+}
+
+// ListPackagesWithContext lists the event packages of a restaurant by name
+func (r *PrivateEventRepository) ListPackagesWithContext(ctx context.Context, restaurantID uint) ([]models.EventPackage, error) {
+	var packages []models.EventPackage
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("name ASC, id ASC").
+		Find(&packages).Error; err != nil {
+		return nil, err
+	}
+	return packages, nil
+}
+
+// GetPackageForRestaurant retrieves an event package by ID, scoped to the restaurant
+func (r *PrivateEventRepository) GetPackageForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.EventPackage, error) {
+	var pkg models.EventPackage
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&pkg, id).Error; err != nil {
+		return nil, err
+	}
+	return &pkg, nil
+}
+
+// CreatePackageWithContext creates an event package
+func (r *PrivateEventRepository) CreatePackageWithContext(ctx context.Context, pkg *models.EventPackage) error {
+	return r.db.WithContext(ctx).Create(pkg).Error
+}
+
+// UpdatePackageWithContext updates an event package
+func (r *PrivateEventRepository) UpdatePackageWithContext(ctx context.Context, pkg *models.EventPackage) error {
+	return r.db.WithContext(ctx).Save(pkg).Error
+}
+
+// CountEventsWithPackageWithContext counts the events that chose a package
+func (r *PrivateEventRepository) CountEventsWithPackageWithContext(ctx context.Context, packageID uint) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.PrivateEvent{}).
+		Where("package_id = ?", packageID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// DeletePackageForRestaurant deletes an event package, scoped to the restaurant
+func (r *PrivateEventRepository) DeletePackageForRestaurant(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Delete(&models.EventPackage{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListWithContext lists a restaurant's events overlapping [from, to), optionally with one status, by start time
+func (r *PrivateEventRepository) ListWithContext(ctx context.Context, restaurantID uint, from, to time.Time, status string) ([]models.PrivateEvent, error) {
+	query := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND start_time < ? AND end_time > ?", restaurantID, to, from)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var events []models.PrivateEvent
+	if err := query.
+		Preload("Tables", func(db *gorm.DB) *gorm.DB { return db.Order("table_number ASC") }).
+		Order("start_time ASC, id ASC").
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetHoldingBetweenWithContext retrieves the quoted and confirmed events overlapping [start, end) with their tables
+func (r *PrivateEventRepository) GetHoldingBetweenWithContext(ctx context.Context, restaurantID uint, start, end time.Time) ([]models.PrivateEvent, error) {
+	var events []models.PrivateEvent
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND status IN ? AND start_time < ? AND end_time > ?",
+			restaurantID, privateEventHoldingStatuses, end, start).
+		Preload("Tables").
+		Order("start_time ASC").
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetForRestaurant retrieves an event by ID with its package, tables and deposits, scoped to the restaurant
+func (r *PrivateEventRepository) GetForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.PrivateEvent, error) {
+	var event models.PrivateEvent
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Preload("Package").
+		Preload("Tables", func(db *gorm.DB) *gorm.DB { return db.Order("table_number ASC") }).
+		Preload("Deposits", func(db *gorm.DB) *gorm.DB { return db.Order("due_date ASC, id ASC") }).
+		First(&event, id).Error; err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CreateWithContext creates an event with its tables
+// Returns ErrPrivateEventConflict if the event holds its tables and they aren't free
+func (r *PrivateEventRepository) CreateWithContext(ctx context.Context, event *models.PrivateEvent) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkEventConflicts(tx, event); err != nil {
+			return err
+		}
+		return tx.Omit("Package", "Deposits").Create(event).Error
+	})
+}
+
+// SaveWithContext saves an event and replaces its tables; with replaceDeposits its deposit schedule is replaced too,
+// otherwise the amounts of the existing deposits are saved
+// Returns ErrPrivateEventConflict if the event holds its tables and they aren't free,
+// and ErrPrivateEventDepositPaid if a schedule with paid deposits would be replaced
+func (r *PrivateEventRepository) SaveWithContext(ctx context.Context, event *models.PrivateEvent, replaceDeposits bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the event so concurrent changes and deposit payments of it serialize
+		if err := tx.Exec("SELECT id FROM private_events WHERE id = ? FOR UPDATE", event.ID).Error; err != nil {
+			return err
+		}
+		if err := checkEventConflicts(tx, event); err != nil {
+			return err
+		}
+
+		if err := tx.Where("event_id = ?", event.ID).Delete(&models.PrivateEventTable{}).Error; err != nil {
+			return err
+		}
+		for i := range event.Tables {
+			event.Tables[i].ID = 0
+			event.Tables[i].EventID = event.ID
+		}
+		if len(event.Tables) > 0 {
+			if err := tx.Create(&event.Tables).Error; err != nil {
+				return err
+			}
+		}
+
+		if replaceDeposits {
+			var paid int64
+			if err := tx.Model(&models.PrivateEventDeposit{}).
+				Where("event_id = ? AND paid_at IS NOT NULL", event.ID).
+				Count(&paid).Error; err != nil {
+				return err
+			}
+			if paid > 0 {
+				return ErrPrivateEventDepositPaid
+			}
+			if err := tx.Where("event_id = ?", event.ID).Delete(&models.PrivateEventDeposit{}).Error; err != nil {
+				return err
+			}
+			for i := range event.Deposits {
+				event.Deposits[i].ID = 0
+				event.Deposits[i].EventID = event.ID
+			}
+			if len(event.Deposits) > 0 {
+				if err := tx.Create(&event.Deposits).Error; err != nil {
+					return err
+				}
+			}
+		} else {
+			for _, deposit := range event.Deposits {
+				if err := tx.Model(&models.PrivateEventDeposit{}).
+					Where("id = ? AND event_id = ? AND paid_at IS NULL", deposit.ID, event.ID).
+					Update("amount", deposit.Amount).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		return tx.Omit(clause.Associations).Save(event).Error
+	})
+}
+
+// MarkDepositPaidWithContext marks an unpaid deposit of an event as paid and confirms the event if it was quoted
+// Returns gorm.ErrRecordNotFound if the event has no such unpaid deposit
+func (r *PrivateEventRepository) MarkDepositPaidWithContext(ctx context.Context, depositID, eventID, restaurantID uint, reference string, paidAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT id FROM private_events WHERE id = ? FOR UPDATE", eventID).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.PrivateEventDeposit{}).
+			Where("id = ? AND event_id = ? AND restaurant_id = ? AND paid_at IS NULL", depositID, eventID, restaurantID).
+			Updates(map[string]interface{}{
+				"paid_at":           paidAt,
+				"payment_reference": reference,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&models.PrivateEvent{}).
+			Where("id = ? AND status = ?", eventID, models.PrivateEventStatusQuoted).
+			Update("status", models.PrivateEventStatusConfirmed).Error
+	})
+}
+
+// checkEventConflicts rejects an event holding its tables while they're reserved or blocked by another event
+// A full-venue event conflicts with every reservation and event at its time
+func checkEventConflicts(tx *gorm.DB, event *models.PrivateEvent) error {
+	if !event.HoldsVenue() {
+		return nil
+	}
+	if err := lockTableBookings(tx, event.RestaurantID); err != nil {
+		return err
+	}
+	tables := event.TableNumbers()
+
+	reservations := tx.Model(&models.Reservation{}).
+		Where("restaurant_id = ? AND status <> ? AND start_time < ? AND end_time > ?",
+			event.RestaurantID, "cancelled", event.EndTime, event.StartTime)
+	if !event.FullVenue {
+		reservations = reservations.Where("table_number IN ?", tables)
+	}
+	var reserved int64
+	if err := reservations.Count(&reserved).Error; err != nil {
+		return err
+	}
+	if reserved > 0 {
+		return ErrPrivateEventConflict
+	}
+
+	events := tx.Model(&models.PrivateEvent{}).
+		Where("restaurant_id = ? AND id <> ? AND status IN ? AND start_time < ? AND end_time > ?",
+			event.RestaurantID, event.ID, privateEventHoldingStatuses, event.EndTime, event.StartTime)
+	if !event.FullVenue {
+		events = events.Where("full_venue OR EXISTS (SELECT 1 FROM private_event_tables t WHERE t.event_id = private_events.id AND t.table_number IN ?)", tables)
+	}
+	var blocked int64
+	if err := events.Count(&blocked).Error; err != nil {
+		return err
+	}
+	if blocked > 0 {
+		return ErrPrivateEventConflict
+	}
+	return nil
+}
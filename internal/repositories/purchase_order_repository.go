@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PurchaseOrderRepository handles purchase order and goods receipt database operations
+type PurchaseOrderRepository struct {
+	db *gorm.DB
+}
+
+// NewPurchaseOrderRepository creates a new PurchaseOrderRepository instance
+func NewPurchaseOrderRepository(db *gorm.DB) *PurchaseOrderRepository {
+	return &PurchaseOrderRepository{db: db}
+}
+
+// CreateWithContext creates a purchase order with its lines
+func (r *PurchaseOrderRepository) CreateWithContext(ctx context.Context, order *models.PurchaseOrder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(order).Error; err != nil {
+			return err
+		}
+		return createPurchaseOrderLines(tx, order)
+	})
+}
+
+// GetByIDForRestaurant retrieves a purchase order by ID with its supplier and lines, scoped to the restaurant
+func (r *PurchaseOrderRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	if err := r.db.WithContext(ctx).
+		Preload("Supplier").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Lines.Ingredient").
+		Where("restaurant_id = ?", restaurantID).
+		First(&order, id).Error; err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ListWithContext lists a restaurant's purchase orders, newest first, optionally of one status
+func (r *PurchaseOrderRepository) ListWithContext(ctx context.Context, restaurantID uint, status string, limit, offset int) ([]models.PurchaseOrder, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.PurchaseOrder{}).Where("restaurant_id = ?", restaurantID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var orders []models.PurchaseOrder
+	if err := query.
+		Preload("Supplier").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Lines.Ingredient").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&orders).Error; err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// SaveWithContext saves a purchase order; with replaceLines its lines are replaced too
+func (r *PurchaseOrderRepository) SaveWithContext(ctx context.Context, order *models.PurchaseOrder, replaceLines bool) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if replaceLines {
+			if err := tx.Where("purchase_order_id = ?", order.ID).Delete(&models.PurchaseOrderLine{}).Error; err != nil {
+				return err
+			}
+			if err := createPurchaseOrderLines(tx, order); err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Save(order).Error
+	})
+}
+
+// ReceiveWithContext books a goods receipt against a purchase order in a single transaction
+// The purchase order and the ingredients of its lines are locked (SELECT ... FOR UPDATE), so concurrent receipts
+// and stock changes serialize; apply receives them keyed by ID and must update them, returning an error rolls back
+// Returns gorm.ErrRecordNotFound if the restaurant has no such purchase order
+func (r *PurchaseOrderRepository) ReceiveWithContext(
+	ctx context.Context,
+	id uint,
+	restaurantID uint,
+	apply func(order *models.PurchaseOrder, ingredients map[uint]*models.Ingredient) error,
+) (*models.PurchaseOrder, error) {
+	var order models.PurchaseOrder
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("restaurant_id = ?", restaurantID).
+			First(&order, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("purchase_order_id = ?", order.ID).Order("id ASC").Find(&order.Lines).Error; err != nil {
+			return err
+		}
+
+		ingredientIDs := make([]uint, 0, len(order.Lines))
+		for _, line := range order.Lines {
+			ingredientIDs = append(ingredientIDs, line.IngredientID)
+		}
+		// Lock in ID order so concurrent receipts cannot deadlock
+		var ingredients []models.Ingredient
+		if len(ingredientIDs) > 0 {
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id IN ?", ingredientIDs).
+				Order("id ASC").
+				Find(&ingredients).Error; err != nil {
+				return err
+			}
+		}
+		byID := make(map[uint]*models.Ingredient, len(ingredients))
+		for i := range ingredients {
+			byID[ingredients[i].ID] = &ingredients[i]
+		}
+
+		if err := apply(&order, byID); err != nil {
+			return err
+		}
+
+		for i := range order.Lines {
+			if err := tx.Omit(clause.Associations).Save(&order.Lines[i]).Error; err != nil {
+				return err
+			}
+		}
+		for _, ingredient := range byID {
+			if err := tx.Model(ingredient).Updates(map[string]interface{}{
+				"stock_quantity": ingredient.StockQuantity,
+				"cost_price":     ingredient.CostPrice,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit(clause.Associations).Save(&order).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// createPurchaseOrderLines inserts the lines of a purchase order
+func createPurchaseOrderLines(tx *gorm.DB, order *models.PurchaseOrder) error {
+	for i := range order.Lines {
+		order.Lines[i].ID = 0
+		order.Lines[i].RestaurantID = order.RestaurantID
+		order.Lines[i].PurchaseOrderID = order.ID
+	}
+	if len(order.Lines) == 0 {
+		return nil
+	}
+	return tx.Omit(clause.Associations).Create(&order.Lines).Error
+}
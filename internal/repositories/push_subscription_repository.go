@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PushSubscriptionRepository handles Web Push subscription database operations
+type PushSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewPushSubscriptionRepository creates a new PushSubscriptionRepository instance
+func NewPushSubscriptionRepository(db *gorm.DB) *PushSubscriptionRepository {
+	return &PushSubscriptionRepository{db: db}
+}
+
+// UpsertWithContext saves a subscription, taking over the restaurant's existing one for the same endpoint
+// A device that resubscribes, or is used by another customer, keeps a single subscription
+func (r *PushSubscriptionRepository) UpsertWithContext(ctx context.Context, subscription *models.PushSubscription) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "restaurant_id"}, {Name: "endpoint"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "p256dh", "auth", "user_agent", "expires_at", "updated_at"}),
+	}).Create(subscription).Error
+}
+
+// ListByUserWithContext lists a user's subscriptions, newest first
+func (r *PushSubscriptionRepository) ListByUserWithContext(ctx context.Context, restaurantID, userID uint) ([]models.PushSubscription, error) {
+	var subscriptions []models.PushSubscription
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		Order("id DESC").
+		Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// DeleteByEndpointWithContext deletes a user's subscription for an endpoint
+func (r *PushSubscriptionRepository) DeleteByEndpointWithContext(ctx context.Context, restaurantID, userID uint, endpoint string) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ? AND endpoint = ?", restaurantID, userID, endpoint).
+		Delete(&models.PushSubscription{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteWithContext deletes a subscription the push service reported as gone
+func (r *PushSubscriptionRepository) DeleteWithContext(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.PushSubscription{}, id).Error
+}
+
+// TouchWithContext records a successful push to a subscription
+func (r *PushSubscriptionRepository) TouchWithContext(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.PushSubscription{}).Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error
+}
+
+// DeleteStaleWithContext deletes the subscriptions that expired before now, and those neither used nor renewed
+// since the cutoff, across all restaurants
+func (r *PushSubscriptionRepository) DeleteStaleWithContext(ctx context.Context, now, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ? OR COALESCE(last_used_at, updated_at) < ?", now, cutoff).
+		Delete(&models.PushSubscription{})
+	return result.RowsAffected, result.Error
+}
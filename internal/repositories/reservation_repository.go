@@ -2,12 +2,28 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"restaurant-backend/internal/models"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+// ErrReservationConflict is returned when a write violates the reservations_no_overlap constraint
+// or the table is blocked by a private event
+var ErrReservationConflict = errors.New("reservation overlaps an existing reservation or private event")
+
+// ErrReservationSlotFull is returned when a reservation would exceed the covers of its pacing slot
+var ErrReservationSlotFull = errors.New("reservation slot is full")
+
+// exclusionViolationCode is the PostgreSQL SQLSTATE for exclusion constraint violations
+const exclusionViolationCode = "23P01"
+
+// tableBookingLockKey is the advisory lock key (with the restaurant ID) serializing reservation and private event
+// writes of a restaurant, so neither can take a table the other is booking; order slot locks use positive keys
+const tableBookingLockKey int32 = -1
+
 // ReservationRepository handles reservation-related database operations
 type ReservationRepository struct {
 	db *gorm.DB
@@ -18,14 +34,28 @@ func NewReservationRepository(db *gorm.DB) *ReservationRepository {
 	return &ReservationRepository{db: db}
 }
 
+// ReservationPacing limits how a restaurant's reservations are spread; zero values don't limit
+type ReservationPacing struct {
+	Slot        time.Duration // Length of the slots covers are counted in, by start time
+	MaxCovers   int           // Guests arriving per slot
+	TableBuffer time.Duration // Turnover time kept free between seatings of a table
+}
+
 // Create creates a new reservation
 func (r *ReservationRepository) Create(reservation *models.Reservation) error {
-	return r.db.Create(reservation).Error
+	return r.CreateWithContext(context.Background(), reservation, ReservationPacing{})
 }
 
 // CreateWithContext creates a new reservation using the provided context
-func (r *ReservationRepository) CreateWithContext(ctx context.Context, reservation *models.Reservation) error {
-	return r.db.WithContext(ctx).Create(reservation).Error
+// Returns ErrReservationConflict if the table is reserved (within the turnover buffer) or blocked by a private event
+// at that time, and ErrReservationSlotFull if the slot has no covers left
+func (r *ReservationRepository) CreateWithContext(ctx context.Context, reservation *models.Reservation, pacing ReservationPacing) error {
+	return translateReservationError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkBooking(tx, reservation, pacing); err != nil {
+			return err
+		}
+		return tx.Create(reservation).Error
+	}))
 }
 
 // GetByID retrieves a reservation by ID (RLS ensures tenant isolation)
@@ -46,6 +76,28 @@ func (r *ReservationRepository) GetByIDWithContext(ctx context.Context, id uint)
 	return &reservation, nil
 }
 
+// GetByIDForRestaurant retrieves a reservation by ID, scoped to the restaurant
+func (r *ReservationRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.Reservation, error) {
+	var reservation models.Reservation
+	if err := r.db.WithContext(ctx).Preload("User").
+		Where("restaurant_id = ?", restaurantID).
+		First(&reservation, id).Error; err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
+// GetByExternalIDWithContext retrieves the reservation of a booking channel by the channel's booking ID
+func (r *ReservationRepository) GetByExternalIDWithContext(ctx context.Context, restaurantID uint, source, externalID string) (*models.Reservation, error) {
+	var reservation models.Reservation
+	if err := r.db.WithContext(ctx).Preload("User").
+		Where("restaurant_id = ? AND source = ? AND external_id = ?", restaurantID, source, externalID).
+		First(&reservation).Error; err != nil {
+		return nil, err
+	}
+	return &reservation, nil
+}
+
 // GetByRestaurantID retrieves all reservations for a restaurant (RLS ensures tenant isolation)
 func (r *ReservationRepository) GetByRestaurantID(restaurantID uint) ([]models.Reservation, error) {
 	var reservations []models.Reservation
@@ -70,6 +122,22 @@ func (r *ReservationRepository) GetByRestaurantIDWithContext(ctx context.Context
 	return reservations, nil
 }
 
+// ExportWithContext passes a restaurant's reservations with their guests to fn in batches, oldest first,
+// optionally only those starting on one date as in the reservation list
+func (r *ReservationRepository) ExportWithContext(ctx context.Context, restaurantID uint, date *time.Time, fn func([]models.Reservation) error) error {
+	query := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID)
+	if date != nil {
+		startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+		query = query.Where("start_time >= ? AND start_time < ?", startOfDay, startOfDay.Add(24*time.Hour))
+	}
+
+	var batch []models.Reservation
+	return query.Preload("User").
+		FindInBatches(&batch, exportBatchSize, func(_ *gorm.DB, _ int) error {
+			return fn(batch)
+		}).Error
+}
+
 // GetByDate retrieves reservations for a specific date
 func (r *ReservationRepository) GetByDate(restaurantID uint, date time.Time) ([]models.Reservation, error) {
 	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
@@ -100,6 +168,56 @@ func (r *ReservationRepository) GetByDateWithContext(ctx context.Context, restau
 	return reservations, nil
 }
 
+// GetOpenBetweenWithContext retrieves the pending and confirmed reservations overlapping [start, end) with their guests
+func (r *ReservationRepository) GetOpenBetweenWithContext(ctx context.Context, restaurantID uint, start, end time.Time) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND status IN ? AND start_time < ? AND end_time > ?",
+			restaurantID, []string{"pending", "confirmed"}, end, start).
+		Preload("User").
+		Order("start_time ASC").
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// GetByUserIDWithContext retrieves a user's reservations at a restaurant, newest first
+func (r *ReservationRepository) GetByUserIDWithContext(ctx context.Context, restaurantID uint, userID uint) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		Order("start_time DESC").
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// GetCreatedAfterWithContext retrieves a restaurant's reservations with an ID above afterID, oldest first
+func (r *ReservationRepository) GetCreatedAfterWithContext(ctx context.Context, restaurantID, afterID uint, limit int) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND id > ?", restaurantID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
+
+// GetLatestIDWithContext returns the highest reservation ID of a restaurant
+func (r *ReservationRepository) GetLatestIDWithContext(ctx context.Context, restaurantID uint) (uint, error) {
+	var id uint
+	if err := r.db.WithContext(ctx).Model(&models.Reservation{}).
+		Where("restaurant_id = ?", restaurantID).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&id).Error; err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
 // GetByTableAndTime retrieves reservations for a specific table and time range
 func (r *ReservationRepository) GetByTableAndTime(restaurantID uint, tableNumber string, startTime, endTime time.Time) ([]models.Reservation, error) {
 	var reservations []models.Reservation
@@ -126,22 +244,18 @@ func (r *ReservationRepository) GetByTableAndTimeWithContext(ctx context.Context
 
 // Update updates an existing reservation
 func (r *ReservationRepository) Update(reservation *models.Reservation) error {
-	return r.db.Save(reservation).Error
+	return r.UpdateWithContext(context.Background(), reservation, ReservationPacing{})
 }
 
 // UpdateWithContext updates a reservation using the provided context
-func (r *ReservationRepository) UpdateWithContext(ctx context.Context, reservation *models.Reservation) error {
-	return r.db.WithContext(ctx).Save(reservation).Error
-}
-
-// Delete deletes a reservation (soft delete by setting status to cancelled)
-func (r *ReservationRepository) Delete(id uint) error {
-	return r.db.Model(&models.Reservation{}).Where("id = ?", id).Update("status", "cancelled").Error
-}
-
-// DeleteWithContext deletes (soft) a reservation using the provided context
-func (r *ReservationRepository) DeleteWithContext(ctx context.Context, id uint) error {
-	return r.db.WithContext(ctx).Model(&models.Reservation{}).Where("id = ?", id).Update("status", "cancelled").Error
+// The pacing is checked as for CreateWithContext; pass no pacing for changes that don't rebook the reservation
+func (r *ReservationRepository) UpdateWithContext(ctx context.Context, reservation *models.Reservation, pacing ReservationPacing) error {
+	return translateReservationError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkBooking(tx, reservation, pacing); err != nil {
+			return err
+		}
+		return tx.Save(reservation).Error
+	}))
 }
 
 // ReservationStats represents reservation statistics
@@ -153,49 +267,90 @@ type ReservationStats struct {
 	CancelledReservations int64 `json:"cancelled_reservations"`
 }
 
-// GetReservationStats retrieves reservation statistics for a restaurant within a date range
+// GetReservationStats retrieves reservation statistics for a restaurant within a date range in a single aggregate query
 func (r *ReservationRepository) GetReservationStats(ctx context.Context, restaurantID uint, startDate, endDate string) (*ReservationStats, error) {
 	var stats ReservationStats
-
-	// Get total reservations
 	if err := r.db.WithContext(ctx).
 		Model(&models.Reservation{}).
+		Select(`
+			COUNT(*) AS total_reservations,
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending_reservations,
+			COUNT(*) FILTER (WHERE status = 'confirmed') AS confirmed_reservations,
+			COUNT(*) FILTER (WHERE status = 'completed') AS completed_reservations,
+			COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled_reservations`).
 		Where("restaurant_id = ? AND created_at >= ? AND created_at <= ?", restaurantID, startDate, endDate).
-		Count(&stats.TotalReservations).Error; err != nil {
+		Scan(&stats).Error; err != nil {
 		return nil, err
 	}
+	return &stats, nil
+}
 
-	// Get pending reservations
-	if err := r.db.WithContext(ctx).
-		Model(&models.Reservation{}).
-		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at <= ?", restaurantID, "pending", startDate, endDate).
-		Count(&stats.PendingReservations).Error; err != nil {
-		return nil, err
+// lockTableBookings takes the restaurant's table booking lock for the rest of the transaction
+func lockTableBookings(tx *gorm.DB, restaurantID uint) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", int32(restaurantID), tableBookingLockKey).Error
+}
+
+// checkBooking rejects a reservation whose table is blocked by a quoted or confirmed private event (of that table or
+// the whole venue) or still turning over from another seating, or whose pacing slot is full; cancelled reservations
+// never conflict
+func checkBooking(tx *gorm.DB, reservation *models.Reservation, pacing ReservationPacing) error {
+	if reservation.Status == "cancelled" {
+		return nil
+	}
+	if err := lockTableBookings(tx, reservation.RestaurantID); err != nil {
+		return err
 	}
 
-	// Get confirmed reservations
-	if err := r.db.WithContext(ctx).
-		Model(&models.Reservation{}).
-		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at <= ?", restaurantID, "confirmed", startDate, endDate).
-		Count(&stats.ConfirmedReservations).Error; err != nil {
-		return nil, err
+	var held int64
+	if err := tx.Model(&models.PrivateEvent{}).
+		Where("restaurant_id = ? AND status IN ? AND start_time < ? AND end_time > ?",
+			reservation.RestaurantID, privateEventHoldingStatuses, reservation.EndTime, reservation.StartTime).
+		Where("full_venue OR EXISTS (SELECT 1 FROM private_event_tables t WHERE t.event_id = private_events.id AND t.table_number = ?)",
+			reservation.TableNumber).
+		Count(&held).Error; err != nil {
+		return err
+	}
+	if held > 0 {
+		return ErrReservationConflict
 	}
 
-	// Get completed reservations
-	if err := r.db.WithContext(ctx).
-		Model(&models.Reservation{}).
-		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at <= ?", restaurantID, "completed", startDate, endDate).
-		Count(&stats.CompletedReservations).Error; err != nil {
-		return nil, err
+	// Seatings closer than the buffer overlap once it's added around the reservation
+	if pacing.TableBuffer > 0 {
+		var turning int64
+		if err := tx.Model(&models.Reservation{}).
+			Where("restaurant_id = ? AND id <> ? AND table_number = ? AND status <> ? AND start_time < ? AND end_time > ?",
+				reservation.RestaurantID, reservation.ID, reservation.TableNumber, "cancelled",
+				reservation.EndTime.Add(pacing.TableBuffer), reservation.StartTime.Add(-pacing.TableBuffer)).
+			Count(&turning).Error; err != nil {
+			return err
+		}
+		if turning > 0 {
+			return ErrReservationConflict
+		}
 	}
 
-	// Get cancelled reservations
-	if err := r.db.WithContext(ctx).
-		Model(&models.Reservation{}).
-		Where("restaurant_id = ? AND status = ? AND created_at >= ? AND created_at <= ?", restaurantID, "cancelled", startDate, endDate).
-		Count(&stats.CancelledReservations).Error; err != nil {
-		return nil, err
+	if pacing.MaxCovers > 0 && pacing.Slot > 0 {
+		slot := reservation.StartTime.Truncate(pacing.Slot)
+		var covers int64
+		if err := tx.Model(&models.Reservation{}).
+			Where("restaurant_id = ? AND id <> ? AND status <> ? AND start_time >= ? AND start_time < ?",
+				reservation.RestaurantID, reservation.ID, "cancelled", slot, slot.Add(pacing.Slot)).
+			Select("COALESCE(SUM(number_of_guests), 0)").
+			Scan(&covers).Error; err != nil {
+			return err
+		}
+		if covers+int64(reservation.NumberOfGuests) > int64(pacing.MaxCovers) {
+			return ErrReservationSlotFull
+		}
 	}
+	return nil
+}
 
-	return &stats, nil
+// translateReservationError maps overlap constraint violations to ErrReservationConflict
+func translateReservationError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == exclusionViolationCode {
+		return ErrReservationConflict
+	}
+	return err
 }
@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RestaurantHealthRepository handles restaurant health scores and the activity they are computed from
+type RestaurantHealthRepository struct {
+	db *gorm.DB
+}
+
+// NewRestaurantHealthRepository creates a new RestaurantHealthRepository instance
+func NewRestaurantHealthRepository(db *gorm.DB) *RestaurantHealthRepository {
+	return &RestaurantHealthRepository{db: db}
+}
+
+// RestaurantHealthInputs is the activity of an active restaurant that its health score is computed from
+type RestaurantHealthInputs struct {
+	RestaurantID      uint
+	KAMID             *uint
+	ActivatedAt       *time.Time
+	CreatedAt         time.Time
+	RecentOrders      int64
+	PreviousOrders    int64
+	LastOrderAt       *time.Time
+	LastLoginAt       *time.Time // Latest Admin or Staff sign-in
+	MenuItems         int64
+	CompleteMenuItems int64 // With a description and an image
+	EmailsSent        int64
+	EmailsFailed      int64
+}
+
+// ListInputsWithContext gathers the health inputs of every active restaurant: orders in the windows
+// [previousStart, recentStart) and [recentStart, now), and email usage of the month starting at periodStart
+func (r *RestaurantHealthRepository) ListInputsWithContext(ctx context.Context, previousStart, recentStart, periodStart time.Time) ([]RestaurantHealthInputs, error) {
+	var inputs []RestaurantHealthInputs
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT r.id AS restaurant_id, r.kam_id, r.activated_at, r.created_at,
+			COALESCE(o.recent_orders, 0) AS recent_orders,
+			COALESCE(o.previous_orders, 0) AS previous_orders,
+			o.last_order_at,
+			u.last_login_at,
+			COALESCE(m.menu_items, 0) AS menu_items,
+			COALESCE(m.complete_menu_items, 0) AS complete_menu_items,
+			COALESCE(e.emails_sent, 0) AS emails_sent,
+			COALESCE(e.emails_failed, 0) AS emails_failed
+		FROM restaurants r
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) FILTER (WHERE created_at >= @recent_start) AS recent_orders,
+				COUNT(*) FILTER (WHERE created_at >= @previous_start AND created_at < @recent_start) AS previous_orders,
+				MAX(created_at) AS last_order_at
+			FROM orders WHERE restaurant_id = r.id
+		) o ON true
+		LEFT JOIN LATERAL (
+			SELECT MAX(last_login_at) AS last_login_at
+			FROM users WHERE restaurant_id = r.id AND role IN ('Admin', 'Staff')
+		) u ON true
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS menu_items,
+				COUNT(*) FILTER (WHERE mi.description <> '' AND (mi.image_url <> '' OR EXISTS (
+					SELECT 1 FROM menu_item_images img WHERE img.menu_item_id = mi.id
+				))) AS complete_menu_items
+			FROM menu_items mi WHERE mi.restaurant_id = r.id
+		) m ON true
+		LEFT JOIN LATERAL (
+			SELECT SUM(quantity) FILTER (WHERE metric = @emails_sent) AS emails_sent,
+				SUM(quantity) FILTER (WHERE metric = @emails_failed) AS emails_failed
+			FROM usage_records WHERE restaurant_id = r.id AND period_start = @period_start
+		) e ON true
+		WHERE r.status = @status AND r.id <> @platform_id
+		ORDER BY r.id
+	`, map[string]interface{}{
+		"previous_start": previousStart,
+		"recent_start":   recentStart,
+		"period_start":   periodStart,
+		"emails_sent":    models.UsageMetricEmailsSent,
+		"emails_failed":  models.UsageMetricEmailsFailed,
+		"status":         models.RestaurantStatusActive,
+		"platform_id":    models.PlatformOrganizationID,
+	}).Scan(&inputs).Error; err != nil {
+		return nil, err
+	}
+	return inputs, nil
+}
+
+// ListWithContext lists the current health scores, keyed by restaurant
+func (r *RestaurantHealthRepository) ListWithContext(ctx context.Context) (map[uint]models.RestaurantHealth, error) {
+	var scores []models.RestaurantHealth
+	if err := r.db.WithContext(ctx).Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	byRestaurant := make(map[uint]models.RestaurantHealth, len(scores))
+	for _, score := range scores {
+		byRestaurant[score.RestaurantID] = score
+	}
+	return byRestaurant, nil
+}
+
+// ListByKAMWithContext lists the health scores of a KAM's restaurants, lowest score first
+func (r *RestaurantHealthRepository) ListByKAMWithContext(ctx context.Context, kamID uint, flaggedOnly bool) ([]models.RestaurantHealth, error) {
+	var scores []models.RestaurantHealth
+	query := r.db.WithContext(ctx).
+		Joins("Restaurant").
+		Where("\"Restaurant\".kam_id = ?", kamID)
+	if flaggedOnly {
+		query = query.Where("restaurant_health.flags <> '[]'::jsonb")
+	}
+	if err := query.Order("restaurant_health.score ASC, restaurant_health.restaurant_id ASC").
+		Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// SaveAllWithContext upserts the health scores of a run and removes those of restaurants it no longer
+// covers (no longer active)
+func (r *RestaurantHealthRepository) SaveAllWithContext(ctx context.Context, scores []models.RestaurantHealth, computedAt time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(scores) > 0 {
+			if err := CreateInBatches(tx.Clauses(clause.OnConflict{
+				Columns: []clause.Column{{Name: "restaurant_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{
+					"score", "activity_score", "menu_score", "login_score", "email_score",
+					"recent_orders", "previous_orders", "last_order_at", "last_login_at",
+					"menu_items", "complete_menu_items", "emails_sent", "emails_failed",
+					"flags", "flagged_at", "computed_at", "updated_at",
+				}),
+			}), &scores, 0); err != nil {
+				return err
+			}
+		}
+		return tx.Where("computed_at < ?", computedAt).Delete(&models.RestaurantHealth{}).Error
+	})
+}
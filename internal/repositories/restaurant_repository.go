@@ -2,7 +2,10 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"restaurant-backend/internal/models"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -185,8 +188,9 @@ func (r *RestaurantRepository) Update(restaurant *models.Restaurant) error {
 }
 
 // UpdateWithContext updates a restaurant using the provided context
+// Returns ErrVersionConflict if the restaurant was modified since it was loaded
 func (r *RestaurantRepository) UpdateWithContext(ctx context.Context, restaurant *models.Restaurant) error {
-	return r.db.WithContext(ctx).Save(restaurant).Error
+	return saveVersioned(r.db.WithContext(ctx), restaurant, restaurant.ID, &restaurant.Version)
 }
 
 // Delete deletes a restaurant (soft delete by setting status)
@@ -198,5 +202,244 @@ func (r *RestaurantRepository) Delete(id uint) error {
 // DeleteWithContext deletes (soft) a restaurant using the provided context
 func (r *RestaurantRepository) DeleteWithContext(ctx context.Context, id uint) error {
 	return r.db.WithContext(ctx).Model(&models.Restaurant{}).Where("id = ?", id).
-		Update("status", models.RestaurantStatusSuspended).Error
+		Updates(map[string]interface{}{"status": models.RestaurantStatusSuspended, "version": bumpVersion}).Error
+}
+
+// IncrementMenuVersionWithContext bumps the menu version of a restaurant and returns the new value
+func (r *RestaurantRepository) IncrementMenuVersionWithContext(ctx context.Context, id uint) (int, error) {
+	var version int
+	if err := r.db.WithContext(ctx).
+		Raw("UPDATE restaurants SET menu_version = menu_version + 1 WHERE id = ? RETURNING menu_version", id).
+		Scan(&version).Error; err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// GetByDisplayTokenWithContext retrieves a restaurant by its order board display token
+func (r *RestaurantRepository) GetByDisplayTokenWithContext(ctx context.Context, token string) (*models.Restaurant, error) {
+	var restaurant models.Restaurant
+	if err := r.db.WithContext(ctx).Where("display_token = ?", token).First(&restaurant).Error; err != nil {
+		return nil, err
+	}
+	return &restaurant, nil
+}
+
+// UpdateDisplayTokenWithContext sets the order board display token of a restaurant
+func (r *RestaurantRepository) UpdateDisplayTokenWithContext(ctx context.Context, id uint, token string) error {
+	return r.db.WithContext(ctx).Model(&models.Restaurant{}).Where("id = ?", id).Update("display_token", token).Error
+}
+
+// SearchWithContext finds restaurants by name, email or phone (case-insensitive, cross-tenant)
+func (r *RestaurantRepository) SearchWithContext(ctx context.Context, term string, limit int) ([]models.Restaurant, error) {
+	var restaurants []models.Restaurant
+	pattern := containsPattern(term)
+	if err := r.db.WithContext(ctx).
+		Where("id <> ?", models.PlatformOrganizationID).
+		Where(`name ILIKE ? ESCAPE '\' OR email ILIKE ? ESCAPE '\' OR contact_email ILIKE ? ESCAPE '\'
+			OR phone LIKE ? ESCAPE '\' OR contact_phone LIKE ? ESCAPE '\'`,
+			pattern, pattern, pattern, pattern, pattern).
+		Order("name ASC").
+		Limit(limit).
+		Find(&restaurants).Error; err != nil {
+		return nil, err
+	}
+	return restaurants, nil
+}
+
+// ListByOrganizationIDWithContext retrieves all locations of an organization
+func (r *RestaurantRepository) ListByOrganizationIDWithContext(ctx context.Context, organizationID uint) ([]models.Restaurant, error) {
+	var restaurants []models.Restaurant
+	if err := r.db.WithContext(ctx).Where("organization_id = ?", organizationID).
+		Order("name ASC").
+		Find(&restaurants).Error; err != nil {
+		return nil, err
+	}
+	return restaurants, nil
+}
+
+// UpdateOrganizationWithContext links a restaurant to an organization
+func (r *RestaurantRepository) UpdateOrganizationWithContext(ctx context.Context, id uint, organizationID uint) error {
+	return r.db.WithContext(ctx).Model(&models.Restaurant{}).Where("id = ?", id).Update("organization_id", organizationID).Error
+}
+
+// ListDueForLaunchWithContext retrieves active hidden restaurants whose scheduled go-live time has passed
+func (r *RestaurantRepository) ListDueForLaunchWithContext(ctx context.Context, now time.Time) ([]models.Restaurant, error) {
+	var restaurants []models.Restaurant
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND visibility = ? AND go_live_at IS NOT NULL AND go_live_at <= ?",
+			models.RestaurantStatusActive, models.RestaurantVisibilityHidden, now).
+		Find(&restaurants).Error; err != nil {
+		return nil, err
+	}
+	return restaurants, nil
+}
+
+// RestaurantActivity is a restaurant's status with its order volume since a cutoff and its last order
+type RestaurantActivity struct {
+	RestaurantID uint                    `json:"restaurant_id"`
+	Name         string                  `json:"name"`
+	Status       models.RestaurantStatus `json:"status"`
+	ActivatedAt  *time.Time              `json:"activated_at,omitempty"`
+	LaunchedAt   *time.Time              `json:"launched_at,omitempty"`
+	OrderCount   int64                   `json:"order_count"` // Orders placed since the cutoff, including cancelled ones
+	Revenue      float64                 `json:"revenue"`     // Completed orders placed since the cutoff
+	LastOrderAt  *time.Time              `json:"last_order_at,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+}
+
+// ListActivityByKAMWithContext lists the activity of the restaurants assigned to a KAM, by revenue since the cutoff
+func (r *RestaurantRepository) ListActivityByKAMWithContext(ctx context.Context, kamID uint, since time.Time) ([]RestaurantActivity, error) {
+	var activity []RestaurantActivity
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT r.id AS restaurant_id, r.name, r.status, r.activated_at, r.launched_at, r.created_at,
+			COALESCE(recent.order_count, 0) AS order_count,
+			COALESCE(recent.revenue, 0) AS revenue,
+			latest.last_order_at
+		FROM restaurants r
+		LEFT JOIN LATERAL (
+			SELECT COUNT(*) AS order_count,
+				SUM(o.total_amount) FILTER (WHERE o.status = ?) AS revenue
+			FROM orders o
+			WHERE o.restaurant_id = r.id AND o.created_at >= ?
+		) recent ON true
+		LEFT JOIN LATERAL (
+			SELECT MAX(o.created_at) AS last_order_at FROM orders o WHERE o.restaurant_id = r.id
+		) latest ON true
+		WHERE r.kam_id = ?
+		ORDER BY revenue DESC, r.id
+	`, models.OrderStatusCompleted, since, kamID).
+		Scan(&activity).Error; err != nil {
+		return nil, err
+	}
+	return activity, nil
+}
+
+// CountPendingUnassignedWithContext counts pending registrations no KAM has been assigned to
+func (r *RestaurantRepository) CountPendingUnassignedWithContext(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Restaurant{}).
+		Where("status = ? AND kam_id IS NULL", models.RestaurantStatusPending).
+		Count(&count).Error
+	return count, err
+}
+
+// RestaurantDirectoryFilter narrows the public restaurant directory
+// Restaurants are only searched by location when both coordinates are set
+type RestaurantDirectoryFilter struct {
+	Name         string
+	City         string
+	Cuisine      string
+	Latitude     *float64
+	Longitude    *float64
+	RadiusMeters float64
+}
+
+// RestaurantDirectoryEntry is a publicly visible restaurant as listed in the directory
+type RestaurantDirectoryEntry struct {
+	ID                    uint     `json:"id"`
+	Name                  string   `json:"name"`
+	Description           string   `json:"description"`
+	Address               string   `json:"address"`
+	City                  string   `json:"city"`
+	Cuisines              []string `gorm:"serializer:json" json:"cuisines"`
+	Latitude              *float64 `json:"latitude,omitempty"`
+	Longitude             *float64 `json:"longitude,omitempty"`
+	LogoURL               string   `json:"logo_url"`
+	OnlineOrderingEnabled bool     `json:"online_ordering_enabled"`
+	Rating                float64  `json:"rating"` // Average of the visible order reviews
+	ReviewCount           int64    `json:"review_count"`
+	DistanceMeters        *float64 `json:"distance_meters,omitempty"` // Only when searching by location
+}
+
+// ListDirectoryWithContext lists the publicly visible restaurants matching a filter (cross-tenant):
+// nearest first when searching by location, otherwise best rated first
+func (r *RestaurantRepository) ListDirectoryWithContext(ctx context.Context, filter RestaurantDirectoryFilter, limit, offset int) ([]RestaurantDirectoryEntry, int64, error) {
+	query := r.db.WithContext(ctx).Table("restaurants").
+		Joins("LEFT JOIN restaurant_settings ON restaurant_settings.restaurant_id = restaurants.id").
+		Where("restaurants.id <> ?", models.PlatformOrganizationID).
+		Where("restaurants.status = ? AND restaurants.visibility <> ?", models.RestaurantStatusActive, models.RestaurantVisibilityHidden)
+	if filter.Name != "" {
+		query = query.Where(`restaurants.name ILIKE ? ESCAPE '\'`, containsPattern(filter.Name))
+	}
+	if filter.City != "" {
+		query = query.Where("LOWER(restaurant_settings.city) = LOWER(?)", filter.City)
+	}
+	if filter.Cuisine != "" {
+		cuisine, err := json.Marshal([]string{strings.ToLower(filter.Cuisine)})
+		if err != nil {
+			return nil, 0, err
+		}
+		query = query.Where("restaurant_settings.cuisines @> ?::jsonb", string(cuisine))
+	}
+
+	near := filter.Latitude != nil && filter.Longitude != nil
+	distance := gorm.Expr("NULL::DOUBLE PRECISION")
+	if near {
+		// Great-circle (haversine) distance in meters
+		distance = gorm.Expr(`2 * 6371000 * ASIN(LEAST(1, SQRT(
+			POWER(SIN(RADIANS(restaurant_settings.latitude - ?) / 2), 2) +
+			COS(RADIANS(?)) * COS(RADIANS(restaurant_settings.latitude)) *
+			POWER(SIN(RADIANS(restaurant_settings.longitude - ?) / 2), 2)
+		)))`, *filter.Latitude, *filter.Latitude, *filter.Longitude)
+		query = query.
+			Where("restaurant_settings.latitude IS NOT NULL AND restaurant_settings.longitude IS NOT NULL").
+			Where("? <= ?", distance, filter.RadiusMeters)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.
+		Joins(`LEFT JOIN (
+			SELECT restaurant_id, AVG(rating) AS average, COUNT(*) AS count
+			FROM reviews WHERE menu_item_id IS NULL AND NOT is_hidden
+			GROUP BY restaurant_id
+		) ratings ON ratings.restaurant_id = restaurants.id`).
+		Select(`restaurants.id, restaurants.name, restaurants.description, restaurants.address,
+			COALESCE(restaurant_settings.city, '') AS city,
+			COALESCE(restaurant_settings.cuisines, '[]'::jsonb) AS cuisines,
+			restaurant_settings.latitude, restaurant_settings.longitude,
+			COALESCE(restaurant_settings.logo_url, '') AS logo_url,
+			COALESCE(restaurant_settings.online_ordering_enabled, true) AS online_ordering_enabled,
+			COALESCE(ratings.average, 0) AS rating,
+			COALESCE(ratings.count, 0) AS review_count,
+			? AS distance_meters`, distance)
+	if near {
+		query = query.Order("distance_meters ASC, restaurants.id ASC")
+	} else {
+		query = query.Order("rating DESC, review_count DESC, restaurants.name ASC, restaurants.id ASC")
+	}
+
+	var entries []RestaurantDirectoryEntry
+	if err := query.Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// RestaurantSitemapEntry is a publicly visible restaurant with the time its public content last changed
+type RestaurantSitemapEntry struct {
+	RestaurantID uint
+	LastModified time.Time
+}
+
+// ListSitemapEntriesWithContext lists the publicly visible restaurants (cross-tenant) with the time they or
+// their menu items were last updated
+func (r *RestaurantRepository) ListSitemapEntriesWithContext(ctx context.Context) ([]RestaurantSitemapEntry, error) {
+	var entries []RestaurantSitemapEntry
+	if err := r.db.WithContext(ctx).Raw(`
+		SELECT r.id AS restaurant_id, GREATEST(r.updated_at, COALESCE(MAX(mi.updated_at), r.updated_at)) AS last_modified
+		FROM restaurants r
+		LEFT JOIN menu_items mi ON mi.restaurant_id = r.id
+		WHERE r.id <> ? AND r.status = ? AND r.visibility <> ?
+		GROUP BY r.id
+		ORDER BY r.id
+	`, models.PlatformOrganizationID, models.RestaurantStatusActive, models.RestaurantVisibilityHidden).
+		Scan(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
 }
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// RestaurantSettingsRepository handles restaurant settings-related database operations
+type RestaurantSettingsRepository struct {
+	db *gorm.DB
+}
+
+// NewRestaurantSettingsRepository creates a new RestaurantSettingsRepository instance
+func NewRestaurantSettingsRepository(db *gorm.DB) *RestaurantSettingsRepository {
+	return &RestaurantSettingsRepository{db: db}
+}
+
+// GetByRestaurantIDWithContext retrieves the settings for a restaurant
+func (r *RestaurantSettingsRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	var settings models.RestaurantSettings
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).First(&settings).Error; err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveWithContext creates or updates the settings for a restaurant
+func (r *RestaurantSettingsRepository) SaveWithContext(ctx context.Context, settings *models.RestaurantSettings) error {
+	return r.db.WithContext(ctx).Save(settings).Error
+}
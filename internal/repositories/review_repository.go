@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrReviewExists is returned when the order or menu item was already reviewed
+var ErrReviewExists = errors.New("review already exists")
+
+// ReviewRepository handles review-related database operations
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewRepository creates a new ReviewRepository instance
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// ReviewFilter narrows a review listing
+type ReviewFilter struct {
+	MenuItemID    *uint
+	OrderOnly     bool // Only reviews of orders as a whole (restaurant reviews)
+	IncludeHidden bool
+}
+
+// RatingSummary is the average rating and number of visible reviews
+type RatingSummary struct {
+	Average float64 `json:"average"`
+	Count   int64   `json:"count"`
+}
+
+// CreateWithContext creates a new review
+func (r *ReviewRepository) CreateWithContext(ctx context.Context, review *models.Review) error {
+	err := r.db.WithContext(ctx).Create(review).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrReviewExists
+	}
+	return err
+}
+
+// GetByIDWithContext retrieves a review by ID (RLS ensures tenant isolation)
+func (r *ReviewRepository) GetByIDWithContext(ctx context.Context, id uint) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.WithContext(ctx).First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// GetByIDForRestaurant retrieves a review by ID, scoped to the restaurant
+func (r *ReviewRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.Review, error) {
+	var review models.Review
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&review, id).Error; err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+// ListWithContext lists a restaurant's reviews, newest first
+// The restaurant filter is explicit so the listing can be served publicly
+func (r *ReviewRepository) ListWithContext(ctx context.Context, restaurantID uint, filter ReviewFilter, limit, offset int) ([]models.Review, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.Review{}).Where("restaurant_id = ?", restaurantID)
+	if filter.MenuItemID != nil {
+		query = query.Where("menu_item_id = ?", *filter.MenuItemID)
+	}
+	if filter.OrderOnly {
+		query = query.Where("menu_item_id IS NULL")
+	}
+	if !filter.IncludeHidden {
+		query = query.Where("is_hidden = ?", false)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reviews []models.Review
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// SetHiddenWithContext hides or restores a review
+func (r *ReviewRepository) SetHiddenWithContext(ctx context.Context, id uint, hidden bool, hiddenBy *uint, reason string) error {
+	updates := map[string]interface{}{
+		"is_hidden":         hidden,
+		"hidden_reason":     reason,
+		"hidden_by_user_id": hiddenBy,
+		"hidden_at":         nil,
+	}
+	if hidden {
+		updates["hidden_at"] = time.Now()
+	}
+	return r.db.WithContext(ctx).Model(&models.Review{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// RefreshMenuItemRatingWithContext recomputes a menu item's rating aggregates from its visible reviews
+func (r *ReviewRepository) RefreshMenuItemRatingWithContext(ctx context.Context, menuItemID uint) error {
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE menu_items SET
+			rating_average = COALESCE((SELECT AVG(rating) FROM reviews WHERE menu_item_id = ? AND NOT is_hidden), 0),
+			rating_count = (SELECT COUNT(*) FROM reviews WHERE menu_item_id = ? AND NOT is_hidden)
+		WHERE id = ?
+	`, menuItemID, menuItemID, menuItemID).Error
+}
+
+// GetRestaurantSummaryWithContext returns the rating summary of a restaurant's visible order reviews
+func (r *ReviewRepository) GetRestaurantSummaryWithContext(ctx context.Context, restaurantID uint) (*RatingSummary, error) {
+	var summary RatingSummary
+	if err := r.db.WithContext(ctx).Model(&models.Review{}).
+		Select("COALESCE(AVG(rating), 0) AS average, COUNT(*) AS count").
+		Where("restaurant_id = ? AND menu_item_id IS NULL AND NOT is_hidden", restaurantID).
+		Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
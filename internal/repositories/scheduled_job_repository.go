@@ -0,0 +1,110 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ScheduledJobRepository handles the schedule, leases and run history of background jobs
+// Lease and schedule times use the database clock, so instances with skewed clocks agree on them
+type ScheduledJobRepository struct {
+	db *gorm.DB
+}
+
+// NewScheduledJobRepository creates a new ScheduledJobRepository instance
+func NewScheduledJobRepository(db *gorm.DB) *ScheduledJobRepository {
+	return &ScheduledJobRepository{db: db}
+}
+
+// EnsureWithContext creates the job's row, first due after firstRunIn; an existing schedule is kept
+func (r *ScheduledJobRepository) EnsureWithContext(ctx context.Context, name string, firstRunIn time.Duration) error {
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO scheduled_jobs (name, next_run_at, created_at, updated_at)
+		VALUES (?, NOW() + make_interval(secs => ?), NOW(), NOW())
+		ON CONFLICT (name) DO NOTHING
+	`, name, firstRunIn.Seconds()).Error
+}
+
+// ClaimWithContext takes the lease of a due job for instance, for the given duration
+// It returns false when the job isn't due yet or another instance holds an unexpired lease
+func (r *ScheduledJobRepository) ClaimWithContext(ctx context.Context, name, instance string, lease time.Duration) (bool, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		UPDATE scheduled_jobs
+		SET locked_by = ?, locked_until = NOW() + make_interval(secs => ?), updated_at = NOW()
+		WHERE name = ? AND next_run_at <= NOW() AND (locked_until IS NULL OR locked_until < NOW())
+	`, instance, lease.Seconds(), name)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleaseWithContext ends instance's lease, records the run's status and schedules the next run after nextRunIn
+func (r *ScheduledJobRepository) ReleaseWithContext(ctx context.Context, name, instance, status string, nextRunIn time.Duration) error {
+	return r.db.WithContext(ctx).Exec(`
+		UPDATE scheduled_jobs
+		SET locked_by = '', locked_until = NULL, last_run_at = NOW(), last_status = ?,
+			next_run_at = NOW() + make_interval(secs => ?), updated_at = NOW()
+		WHERE name = ? AND locked_by = ?
+	`, status, nextRunIn.Seconds(), name, instance).Error
+}
+
+// TriggerWithContext makes a job due now; the next poll of any instance runs it
+func (r *ScheduledJobRepository) TriggerWithContext(ctx context.Context, name string) error {
+	result := r.db.WithContext(ctx).Model(&models.ScheduledJob{}).
+		Where("name = ?", name).
+		Updates(map[string]interface{}{"next_run_at": gorm.Expr("NOW()"), "updated_at": gorm.Expr("NOW()")})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListWithContext lists all jobs by name
+func (r *ScheduledJobRepository) ListWithContext(ctx context.Context) ([]models.ScheduledJob, error) {
+	var jobs []models.ScheduledJob
+	if err := r.db.WithContext(ctx).Order("name").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// CreateRunWithContext records the start of a run
+func (r *ScheduledJobRepository) CreateRunWithContext(ctx context.Context, run *models.JobRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// FinishRunWithContext records the outcome of a run
+func (r *ScheduledJobRepository) FinishRunWithContext(ctx context.Context, run *models.JobRun) error {
+	return r.db.WithContext(ctx).Model(run).
+		Select("status", "error", "finished_at", "duration_ms").
+		Updates(run).Error
+}
+
+// ListRunsWithContext lists a job's runs, newest first
+func (r *ScheduledJobRepository) ListRunsWithContext(ctx context.Context, name string, limit, offset int) ([]models.JobRun, int64, error) {
+	var runs []models.JobRun
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&models.JobRun{}).Where("job_name = ?", name)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("started_at DESC, id DESC").Limit(limit).Offset(offset).Find(&runs).Error; err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+// DeleteRunsBeforeWithContext deletes the history of runs started before the cutoff
+func (r *ScheduledJobRepository) DeleteRunsBeforeWithContext(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("started_at < ?", before).Delete(&models.JobRun{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import "strings"
+
+// likeEscaper escapes the LIKE wildcards and the escape character itself
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// containsPattern returns a LIKE pattern matching values that contain term literally, so a % or _ typed
+// by the user isn't a wildcard. Use it with ESCAPE '\'
+func containsPattern(term string) string {
+	return "%" + likeEscaper.Replace(term) + "%"
+}
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SMSMessageRepository handles SMS delivery log database operations
+type SMSMessageRepository struct {
+	db *gorm.DB
+}
+
+// NewSMSMessageRepository creates a new SMSMessageRepository instance
+func NewSMSMessageRepository(db *gorm.DB) *SMSMessageRepository {
+	return &SMSMessageRepository{db: db}
+}
+
+// CreateOnceWithContext records a message unless one of the same type already exists for its reservation or order
+// It reports whether the message was recorded, so the caller only sends what it recorded
+func (r *SMSMessageRepository) CreateOnceWithContext(ctx context.Context, message *models.SMSMessage) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(message)
+	return result.RowsAffected > 0, result.Error
+}
+
+// UpdateSendResultWithContext stores the provider's answer to a message
+func (r *SMSMessageRepository) UpdateSendResultWithContext(ctx context.Context, message *models.SMSMessage) error {
+	return r.db.WithContext(ctx).Model(message).
+		Select("external_id", "status", "error_code", "error_message", "sent_at", "updated_at").
+		Updates(message).Error
+}
+
+// UpdateStatusByExternalIDWithContext applies a delivery status reported by the provider
+// Final statuses are never replaced, since status callbacks can arrive out of order
+func (r *SMSMessageRepository) UpdateStatusByExternalIDWithContext(ctx context.Context, provider, externalID, status, errorCode string, at time.Time) (int64, error) {
+	updates := map[string]interface{}{
+		"status":     status,
+		"updated_at": at,
+	}
+	if errorCode != "" {
+		updates["error_code"] = errorCode
+	}
+	if status == models.SMSStatusDelivered {
+		updates["delivered_at"] = at
+	}
+	result := r.db.WithContext(ctx).Model(&models.SMSMessage{}).
+		Where("provider = ? AND external_id = ?", provider, externalID).
+		Where("status NOT IN ?", []string{models.SMSStatusDelivered, models.SMSStatusUndelivered, models.SMSStatusFailed}).
+		Updates(updates)
+	return result.RowsAffected, result.Error
+}
+
+// ListWithContext lists a restaurant's messages, newest first, optionally of one type only
+func (r *SMSMessageRepository) ListWithContext(ctx context.Context, restaurantID uint, messageType string, limit, offset int) ([]models.SMSMessage, error) {
+	var messages []models.SMSMessage
+	query := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID)
+	if messageType != "" {
+		query = query.Where("type = ?", messageType)
+	}
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&messages).Error; err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// ListDueRemindersWithContext returns the confirmed reservations starting in [from, to) of restaurants that opted
+// into reminders, whose guest has a phone number and hasn't been sent a reminder yet
+func (r *SMSMessageRepository) ListDueRemindersWithContext(ctx context.Context, from, to time.Time) ([]models.Reservation, error) {
+	var reservations []models.Reservation
+	if err := r.db.WithContext(ctx).Preload("User").
+		Joins("JOIN restaurant_settings rs ON rs.restaurant_id = reservations.restaurant_id AND rs.sms_reservation_reminder").
+		Joins("JOIN users u ON u.id = reservations.user_id AND u.phone <> ''").
+		Where("reservations.status = ? AND reservations.start_time >= ? AND reservations.start_time < ?", "confirmed", from, to).
+		Where("NOT EXISTS (SELECT 1 FROM sms_messages sm WHERE sm.reservation_id = reservations.id AND sm.type = ?)", models.SMSTypeReservationReminder).
+		Order("reservations.start_time ASC").
+		Find(&reservations).Error; err != nil {
+		return nil, err
+	}
+	return reservations, nil
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SSOConfigRepository handles restaurant SSO configuration database operations
+type SSOConfigRepository struct {
+	db *gorm.DB
+}
+
+// NewSSOConfigRepository creates a new SSOConfigRepository instance
+func NewSSOConfigRepository(db *gorm.DB) *SSOConfigRepository {
+	return &SSOConfigRepository{db: db}
+}
+
+// GetByRestaurantIDWithContext retrieves a restaurant's SSO configuration
+func (r *SSOConfigRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) (*models.SSOConfig, error) {
+	var config models.SSOConfig
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).First(&config).Error; err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// SaveWithContext creates or updates a restaurant's SSO configuration
+func (r *SSOConfigRepository) SaveWithContext(ctx context.Context, config *models.SSOConfig) error {
+	return r.db.WithContext(ctx).Save(config).Error
+}
+
+// DeleteWithContext removes a restaurant's SSO configuration
+func (r *SSOConfigRepository) DeleteWithContext(ctx context.Context, restaurantID uint) (int64, error) {
+	result := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).Delete(&models.SSOConfig{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StorageBackupRepository handles storage backup policy database operations
+type StorageBackupRepository struct {
+	db *gorm.DB
+}
+
+// NewStorageBackupRepository creates a new StorageBackupRepository instance
+func NewStorageBackupRepository(db *gorm.DB) *StorageBackupRepository {
+	return &StorageBackupRepository{db: db}
+}
+
+// GetByRestaurantIDWithContext retrieves the backup policy of a restaurant
+func (r *StorageBackupRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) (*models.StorageBackupPolicy, error) {
+	var policy models.StorageBackupPolicy
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).First(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// ListWithContext lists all backup policies
+func (r *StorageBackupRepository) ListWithContext(ctx context.Context) ([]models.StorageBackupPolicy, error) {
+	var policies []models.StorageBackupPolicy
+	if err := r.db.WithContext(ctx).Order("restaurant_id ASC").Find(&policies).Error; err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// UpsertWithContext creates or replaces the tier and retention of a restaurant's backup policy
+func (r *StorageBackupRepository) UpsertWithContext(ctx context.Context, policy *models.StorageBackupPolicy) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "restaurant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tier", "retention_days", "updated_at"}),
+	}).Create(policy).Error
+}
+
+// RecordSyncWithContext records the outcome of applying the bucket configuration to all policies
+func (r *StorageBackupRepository) RecordSyncWithContext(ctx context.Context, appliedAt time.Time, syncErr error) error {
+	updates := map[string]interface{}{"last_error": ""}
+	if syncErr != nil {
+		updates["last_error"] = syncErr.Error()
+	} else {
+		updates["last_applied_at"] = appliedAt
+	}
+	return r.db.WithContext(ctx).Model(&models.StorageBackupPolicy{}).Where("1 = 1").Updates(updates).Error
+}
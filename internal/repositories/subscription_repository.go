@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SubscriptionRepository handles subscription-related database operations
+type SubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewSubscriptionRepository creates a new SubscriptionRepository instance
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+// GetByRestaurantIDWithContext retrieves the subscription of a restaurant
+func (r *SubscriptionRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) (*models.Subscription, error) {
+	var subscription models.Subscription
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).First(&subscription).Error; err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// SaveWithContext creates or updates the subscription of a restaurant
+func (r *SubscriptionRepository) SaveWithContext(ctx context.Context, subscription *models.Subscription) error {
+	return r.db.WithContext(ctx).Save(subscription).Error
+}
+
+// ListWithContext lists the subscriptions of all restaurants
+func (r *SubscriptionRepository) ListWithContext(ctx context.Context) ([]models.Subscription, error) {
+	var subscriptions []models.Subscription
+	if err := r.db.WithContext(ctx).Order("restaurant_id ASC").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
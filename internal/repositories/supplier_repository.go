@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrSupplierInUse is returned when a deleted supplier still has purchase orders
+var ErrSupplierInUse = errors.New("supplier has purchase orders")
+
+// SupplierRepository handles supplier database operations
+type SupplierRepository struct {
+	db *gorm.DB
+}
+
+// NewSupplierRepository creates a new SupplierRepository instance
+func NewSupplierRepository(db *gorm.DB) *SupplierRepository {
+	return &SupplierRepository{db: db}
+}
+
+// CreateWithContext creates a supplier
+func (r *SupplierRepository) CreateWithContext(ctx context.Context, supplier *models.Supplier) error {
+	return r.db.WithContext(ctx).Create(supplier).Error
+}
+
+// GetByIDForRestaurant retrieves a supplier by ID, scoped to the restaurant
+func (r *SupplierRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.Supplier, error) {
+	var supplier models.Supplier
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&supplier, id).Error; err != nil {
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+// GetByRestaurantIDWithContext lists the suppliers of a restaurant by name
+func (r *SupplierRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.Supplier, error) {
+	var suppliers []models.Supplier
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("name ASC, id ASC").
+		Find(&suppliers).Error; err != nil {
+		return nil, err
+	}
+	return suppliers, nil
+}
+
+// UpdateWithContext updates a supplier
+func (r *SupplierRepository) UpdateWithContext(ctx context.Context, supplier *models.Supplier) error {
+	return r.db.WithContext(ctx).Save(supplier).Error
+}
+
+// DeleteForRestaurant deletes a supplier, scoped to the restaurant; ingredients preferring it keep no preferred supplier
+// Returns gorm.ErrRecordNotFound if no supplier matched and ErrSupplierInUse if it has purchase orders
+func (r *SupplierRepository) DeleteForRestaurant(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Delete(&models.Supplier{})
+	if result.Error != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(result.Error, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return ErrSupplierInUse
+		}
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// Table session errors
+var (
+	ErrTableOccupied      = errors.New("table already has an open session")
+	ErrTableSessionClosed = errors.New("table session is already closed")
+)
+
+// TableSessionRepository handles table session database operations
+type TableSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewTableSessionRepository creates a new TableSessionRepository instance
+func NewTableSessionRepository(db *gorm.DB) *TableSessionRepository {
+	return &TableSessionRepository{db: db}
+}
+
+// CreateWithContext opens a table session
+func (r *TableSessionRepository) CreateWithContext(ctx context.Context, session *models.TableSession) error {
+	return translateTableSessionError(r.db.WithContext(ctx).Create(session).Error)
+}
+
+// GetByIDForRestaurant retrieves a table session with its rounds and their items, scoped to the restaurant
+func (r *TableSessionRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.TableSession, error) {
+	var session models.TableSession
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Preload("Rounds", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Preload("Rounds.OrderItems").
+		First(&session, id).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListWithContext lists the latest table sessions with a status with their rounds, newest first
+func (r *TableSessionRepository) ListWithContext(ctx context.Context, restaurantID uint, status string, limit int) ([]models.TableSession, error) {
+	var sessions []models.TableSession
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND status = ?", restaurantID, status).
+		Preload("Rounds", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id ASC")
+		}).
+		Order("id DESC").
+		Limit(limit).
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// GetOpenWithContext lists the open table sessions of a restaurant without their rounds
+func (r *TableSessionRepository) GetOpenWithContext(ctx context.Context, restaurantID uint) ([]models.TableSession, error) {
+	var sessions []models.TableSession
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND status = ?", restaurantID, models.TableSessionStatusOpen).
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// UpdateWithContext updates the table, guests and notes of an open table session
+func (r *TableSessionRepository) UpdateWithContext(ctx context.Context, session *models.TableSession) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.TableSession{}).
+		Where("id = ? AND restaurant_id = ? AND status = ?", session.ID, session.RestaurantID, models.TableSessionStatusOpen).
+		Updates(map[string]interface{}{
+			"table_number": session.TableNumber,
+			"guests":       session.Guests,
+			"notes":        session.Notes,
+		})
+	if result.Error != nil {
+		return translateTableSessionError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTableSessionClosed
+	}
+	return nil
+}
+
+// CloseWithContext closes an open table session with its payment
+// The total is summed from the session's non-cancelled rounds in the same statement
+func (r *TableSessionRepository) CloseWithContext(ctx context.Context, session *models.TableSession) error {
+	result := r.db.WithContext(ctx).
+		Model(&models.TableSession{}).
+		Where("id = ? AND restaurant_id = ? AND status = ?", session.ID, session.RestaurantID, models.TableSessionStatusOpen).
+		Updates(map[string]interface{}{
+			"status":            models.TableSessionStatusClosed,
+			"closed_by_user_id": session.ClosedByUserID,
+			"closed_at":         session.ClosedAt,
+			"payment_method":    session.PaymentMethod,
+			"tip":               session.Tip,
+			"total": gorm.Expr(
+				"(SELECT COALESCE(SUM(total_amount), 0) FROM orders WHERE table_session_id = ? AND status <> ?)",
+				session.ID, models.OrderStatusCancelled,
+			),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTableSessionClosed
+	}
+	return nil
+}
+
+// translateTableSessionError maps open session unique violations to ErrTableOccupied
+func translateTableSessionError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrTableOccupied
+	}
+	return err
+}
@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAlreadyClockedIn is returned when clocking in a staff member who has an open time entry
+var ErrAlreadyClockedIn = errors.New("staff member is already clocked in")
+
+// TimeEntryRepository handles time entry and staff rate database operations
+type TimeEntryRepository struct {
+	db *gorm.DB
+}
+
+// NewTimeEntryRepository creates a new TimeEntryRepository instance
+func NewTimeEntryRepository(db *gorm.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{db: db}
+}
+
+// TimeEntryFilter filters time entry listings; nil fields are not filtered
+type TimeEntryFilter struct {
+	UserID *uint
+}
+
+// LaborBucket is the worked hours and labor cost of the shifts started in one day or week
+type LaborBucket struct {
+	Bucket time.Time
+	Hours  float64
+	Labor  money.Cents
+}
+
+// ClockInWithContext creates an open time entry
+func (r *TimeEntryRepository) ClockInWithContext(ctx context.Context, entry *models.TimeEntry) error {
+	err := r.db.WithContext(ctx).Create(entry).Error
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+		return ErrAlreadyClockedIn
+	}
+	return err
+}
+
+// ClockOutWithContext closes the open time entry of a staff member
+func (r *TimeEntryRepository) ClockOutWithContext(ctx context.Context, restaurantID, userID uint, at time.Time) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("restaurant_id = ? AND user_id = ? AND clock_out_at IS NULL", restaurantID, userID).
+			First(&entry).Error; err != nil {
+			return err
+		}
+		entry.ClockOutAt = &at
+		return tx.Save(&entry).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetOpenWithContext retrieves the open time entry of a staff member
+func (r *TimeEntryRepository) GetOpenWithContext(ctx context.Context, restaurantID, userID uint) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ? AND clock_out_at IS NULL", restaurantID, userID).
+		First(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetByIDForRestaurant retrieves a time entry by ID, scoped to the restaurant
+func (r *TimeEntryRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// SaveWithContext updates a time entry
+func (r *TimeEntryRepository) SaveWithContext(ctx context.Context, entry *models.TimeEntry) error {
+	return r.db.WithContext(ctx).Save(entry).Error
+}
+
+// DeleteWithContext deletes a time entry, scoped to the restaurant
+func (r *TimeEntryRepository) DeleteWithContext(ctx context.Context, id uint, restaurantID uint) error {
+	result := r.db.WithContext(ctx).
+		Where("id = ? AND restaurant_id = ?", id, restaurantID).
+		Delete(&models.TimeEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListWithContext lists the time entries clocked in during [start, end) with their staff members, newest first
+func (r *TimeEntryRepository) ListWithContext(ctx context.Context, restaurantID uint, filter TimeEntryFilter, start, end time.Time) ([]models.TimeEntry, error) {
+	query := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND clock_in_at >= ? AND clock_in_at < ?", restaurantID, start, end)
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+
+	var entries []models.TimeEntry
+	if err := query.Preload("User").Order("clock_in_at DESC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetLaborBuckets aggregates the labor of the shifts clocked in during [start, end) per day or week
+// (unit) in the given time zone. Shifts count toward the day they started; open shifts count up to now
+func (r *TimeEntryRepository) GetLaborBuckets(ctx context.Context, restaurantID uint, unit, timeZone string, start, end time.Time) ([]LaborBucket, error) {
+	var buckets []LaborBucket
+	if err := r.db.WithContext(ctx).
+		Model(&models.TimeEntry{}).
+		Select(`
+			date_trunc(?, clock_in_at AT TIME ZONE ?) AS bucket,
+			COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(clock_out_at, NOW()) - clock_in_at)) / 3600, 0) AS hours,
+			COALESCE(ROUND(SUM(EXTRACT(EPOCH FROM COALESCE(clock_out_at, NOW()) - clock_in_at) / 3600 * hourly_rate), 2), 0) AS labor`,
+			unit, timeZone).
+		Where("restaurant_id = ? AND clock_in_at >= ? AND clock_in_at < ?", restaurantID, start, end).
+		Group("bucket").
+		Order("bucket").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// SetRateWithContext creates or replaces the hourly rate of a staff member
+func (r *TimeEntryRepository) SetRateWithContext(ctx context.Context, rate *models.StaffRate) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "restaurant_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"hourly_rate", "updated_at"}),
+		}).
+		Create(rate).Error
+}
+
+// GetRateWithContext retrieves the hourly rate of a staff member
+func (r *TimeEntryRepository) GetRateWithContext(ctx context.Context, restaurantID, userID uint) (*models.StaffRate, error) {
+	var rate models.StaffRate
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		First(&rate).Error; err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+// ListRatesWithContext lists the hourly rates of a restaurant's staff
+func (r *TimeEntryRepository) ListRatesWithContext(ctx context.Context, restaurantID uint) ([]models.StaffRate, error) {
+	var rates []models.StaffRate
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("user_id ASC").
+		Find(&rates).Error; err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UsageRepository handles metered usage database operations
+type UsageRepository struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new UsageRepository instance
+func NewUsageRepository(db *gorm.DB) *UsageRepository {
+	return &UsageRepository{db: db}
+}
+
+// usageConflict is the unique key of a usage record
+var usageConflict = []clause.Column{{Name: "restaurant_id"}, {Name: "period_start"}, {Name: "metric"}}
+
+// IncrementWithContext adds quantity to a restaurant's usage of a metric in the month containing at
+func (r *UsageRepository) IncrementWithContext(ctx context.Context, restaurantID uint, metric string, at time.Time, quantity int64) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: usageConflict,
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"quantity":   gorm.Expr("usage_records.quantity + EXCLUDED.quantity"),
+			"updated_at": gorm.Expr("EXCLUDED.updated_at"),
+		}),
+	}).Create(&models.UsageRecord{
+		RestaurantID: restaurantID,
+		PeriodStart:  UsagePeriodStart(at),
+		Metric:       metric,
+		Quantity:     quantity,
+	}).Error
+}
+
+// SetWithContext records a restaurant's usage of a metric in a period, replacing any previous value
+// Used for metrics measured at invoice time rather than counted as they happen
+func (r *UsageRepository) SetWithContext(ctx context.Context, record *models.UsageRecord) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   usageConflict,
+		DoUpdates: clause.AssignmentColumns([]string{"quantity", "updated_at"}),
+	}).Create(record).Error
+}
+
+// ListByPeriodWithContext lists the usage of every restaurant in a period
+func (r *UsageRepository) ListByPeriodWithContext(ctx context.Context, periodStart time.Time) ([]models.UsageRecord, error) {
+	var records []models.UsageRecord
+	if err := r.db.WithContext(ctx).
+		Where("period_start = ?", periodStart).
+		Order("restaurant_id ASC, metric ASC").
+		Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// UsagePeriodStart returns the billing period (calendar month, UTC) containing t
+func UsagePeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// ErrInvitationNotPending is returned when an invitation was accepted, revoked or expired concurrently
+var ErrInvitationNotPending = errors.New("invitation is no longer pending")
+
+// pendingInvitation matches invitations that can still be accepted
+const pendingInvitation = "accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?"
+
+// UserInvitationRepository handles user invitation database operations
+type UserInvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewUserInvitationRepository creates a new UserInvitationRepository instance
+func NewUserInvitationRepository(db *gorm.DB) *UserInvitationRepository {
+	return &UserInvitationRepository{db: db}
+}
+
+// CreateWithContext stores a new invitation
+func (r *UserInvitationRepository) CreateWithContext(ctx context.Context, invitation *models.UserInvitation) error {
+	return r.db.WithContext(ctx).Create(invitation).Error
+}
+
+// GetByIDWithContext retrieves an invitation of the restaurant
+func (r *UserInvitationRepository) GetByIDWithContext(ctx context.Context, restaurantID, id uint) (*models.UserInvitation, error) {
+	var invitation models.UserInvitation
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND id = ?", restaurantID, id).
+		First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetByTokenHashWithContext retrieves an invitation by the hash of its link's token
+func (r *UserInvitationRepository) GetByTokenHashWithContext(ctx context.Context, tokenHash string) (*models.UserInvitation, error) {
+	var invitation models.UserInvitation
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&invitation).Error; err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ExistsPendingForEmailWithContext reports whether the restaurant has a pending invitation for the email address
+func (r *UserInvitationRepository) ExistsPendingForEmailWithContext(ctx context.Context, restaurantID uint, email string, now time.Time) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.UserInvitation{}).
+		Where("restaurant_id = ? AND LOWER(email) = LOWER(?)", restaurantID, email).
+		Where(pendingInvitation, now).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ListWithContext retrieves the restaurant's invitations, newest first
+func (r *UserInvitationRepository) ListWithContext(ctx context.Context, restaurantID uint) ([]models.UserInvitation, error) {
+	var invitations []models.UserInvitation
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		Order("created_at DESC").
+		Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// ReissueWithContext replaces the link's token and expiry of an invitation that wasn't accepted or revoked
+// Expired invitations can be reissued; reports whether the invitation could be
+func (r *UserInvitationRepository) ReissueWithContext(ctx context.Context, id uint, tokenHash string, expiresAt, sentAt time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.UserInvitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"token_hash": tokenHash,
+			"expires_at": expiresAt,
+			"sent_at":    sentAt,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevokeWithContext revokes an invitation that wasn't accepted or revoked; reports whether it could be
+func (r *UserInvitationRepository) RevokeWithContext(ctx context.Context, restaurantID, id uint, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.UserInvitation{}).
+		Where("restaurant_id = ? AND id = ? AND accepted_at IS NULL AND revoked_at IS NULL", restaurantID, id).
+		Update("revoked_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// AcceptWithContext creates the invited user and marks the invitation accepted in one transaction
+// Returns ErrInvitationNotPending if the invitation was accepted, revoked or expired in the meantime
+func (r *UserInvitationRepository) AcceptWithContext(ctx context.Context, invitation *models.UserInvitation, user *models.User, at time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.UserInvitation{}).
+			Where("id = ?", invitation.ID).
+			Where(pendingInvitation, at).
+			Updates(map[string]interface{}{
+				"accepted_at":      at,
+				"accepted_user_id": user.ID,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvitationNotPending
+		}
+
+		invitation.AcceptedAt = &at
+		invitation.AcceptedUserID = &user.ID
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserPermissionRepository handles per-user permission override database operations
+type UserPermissionRepository struct {
+	db *gorm.DB
+}
+
+// NewUserPermissionRepository creates a new UserPermissionRepository instance
+func NewUserPermissionRepository(db *gorm.DB) *UserPermissionRepository {
+	return &UserPermissionRepository{db: db}
+}
+
+// GetWithContext retrieves a user's override of one permission
+func (r *UserPermissionRepository) GetWithContext(ctx context.Context, userID uint, permission string) (*models.UserPermission, error) {
+	var override models.UserPermission
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND permission = ?", userID, permission).
+		First(&override).Error; err != nil {
+		return nil, err
+	}
+	return &override, nil
+}
+
+// ListByUserWithContext retrieves a user's permission overrides
+func (r *UserPermissionRepository) ListByUserWithContext(ctx context.Context, restaurantID, userID uint) ([]models.UserPermission, error) {
+	var overrides []models.UserPermission
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ?", restaurantID, userID).
+		Order("permission").
+		Find(&overrides).Error; err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// UpsertWithContext saves a user's override of a permission, replacing an existing one
+func (r *UserPermissionRepository) UpsertWithContext(ctx context.Context, override *models.UserPermission) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "permission"}},
+		DoUpdates: clause.AssignmentColumns([]string{"granted", "granted_by", "updated_at"}),
+	}).Create(override).Error
+}
+
+// DeleteWithContext removes a user's override of a permission; reports whether there was one
+func (r *UserPermissionRepository) DeleteWithContext(ctx context.Context, restaurantID, userID uint, permission string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("restaurant_id = ? AND user_id = ? AND permission = ?", restaurantID, userID, permission).
+		Delete(&models.UserPermission{})
+	return result.RowsAffected > 0, result.Error
+}
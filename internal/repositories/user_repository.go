@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/pii"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -115,8 +117,9 @@ func (r *UserRepository) Update(user *models.User) error {
 }
 
 // UpdateWithContext updates a user using the provided context
+// Returns ErrVersionConflict if the user was modified since it was loaded
 func (r *UserRepository) UpdateWithContext(ctx context.Context, user *models.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	return saveVersioned(r.db.WithContext(ctx), user, user.ID, &user.Version)
 }
 
 // Delete deletes a user
@@ -131,7 +134,8 @@ func (r *UserRepository) DeleteWithContext(ctx context.Context, id uint) error {
 
 // UpdateUserStatus updates the is_active status of a user
 func (r *UserRepository) UpdateUserStatus(ctx context.Context, id uint, isActive bool) error {
-	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("is_active", isActive).Error
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"is_active": isActive, "version": bumpVersion}).Error
 }
 
 // UpdateUserPassword updates the password hash of a user
@@ -139,6 +143,11 @@ func (r *UserRepository) UpdateUserPassword(ctx context.Context, userID uint, ha
 	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("password_hash", hashedPassword).Error
 }
 
+// UpdateLastLoginWithContext records a user's sign-in time; it is not an edit, so the version is kept
+func (r *UserRepository) UpdateLastLoginWithContext(ctx context.Context, id uint, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).UpdateColumn("last_login_at", at).Error
+}
+
 // GetByEmailAnyRestaurant checks if email exists in any restaurant (for uniqueness check)
 func (r *UserRepository) GetByEmailAnyRestaurant(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
@@ -147,3 +156,34 @@ func (r *UserRepository) GetByEmailAnyRestaurant(ctx context.Context, email stri
 	}
 	return &user, nil
 }
+
+// SearchWithContext finds users by email, name or exact phone number across all tenants
+func (r *UserRepository) SearchWithContext(ctx context.Context, term string, limit int) ([]models.User, error) {
+	var users []models.User
+	pattern := containsPattern(term)
+	if err := r.db.WithContext(ctx).
+		Where(`email ILIKE ? ESCAPE '\' OR phone_hash = ? OR (first_name || ' ' || last_name) ILIKE ? ESCAPE '\'`,
+			pattern, pii.HashPhone(term), pattern).
+		Order("email ASC").
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// UpdateOrganizationWithContext sets or clears (nil) a user's organization scope
+func (r *UserRepository) UpdateOrganizationWithContext(ctx context.Context, id uint, organizationID *uint) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("organization_id", organizationID).Error
+}
+
+// CountByRolesWithContext counts the users of a restaurant with one of the given roles
+func (r *UserRepository) CountByRolesWithContext(ctx context.Context, restaurantID uint, roles []string) (int64, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("restaurant_id = ? AND role IN ?", restaurantID, roles).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
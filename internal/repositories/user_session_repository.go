@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// UserSessionRepository handles user login session database operations
+type UserSessionRepository struct {
+	db *gorm.DB
+}
+
+// NewUserSessionRepository creates a new UserSessionRepository instance
+func NewUserSessionRepository(db *gorm.DB) *UserSessionRepository {
+	return &UserSessionRepository{db: db}
+}
+
+// CreateWithContext stores a new session
+func (r *UserSessionRepository) CreateWithContext(ctx context.Context, session *models.UserSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+// GetBySessionIDWithContext retrieves a session by its token ID
+func (r *UserSessionRepository) GetBySessionIDWithContext(ctx context.Context, sessionID string) (*models.UserSession, error) {
+	var session models.UserSession
+	if err := r.db.WithContext(ctx).Where("session_id = ?", sessionID).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListActiveByUserWithContext retrieves a user's unrevoked, unexpired sessions, most recently used first
+func (r *UserSessionRepository) ListActiveByUserWithContext(ctx context.Context, userID uint, now time.Time) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// TouchWithContext records that a session was used
+func (r *UserSessionRepository) TouchWithContext(ctx context.Context, id uint, at time.Time, ip string) error {
+	return r.db.WithContext(ctx).Model(&models.UserSession{}).Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"last_seen_at": at, "last_seen_ip": ip}).Error
+}
+
+// ExtendWithContext moves a session's expiry to that of a newly issued token, never earlier
+func (r *UserSessionRepository) ExtendWithContext(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("session_id = ? AND expires_at < ?", sessionID, expiresAt).
+		UpdateColumn("expires_at", expiresAt).Error
+}
+
+// RevokeWithContext revokes one of a user's sessions; returns false if the user has no such active session
+func (r *UserSessionRepository) RevokeWithContext(ctx context.Context, userID uint, sessionID string, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("user_id = ? AND session_id = ? AND revoked_at IS NULL", userID, sessionID).
+		UpdateColumn("revoked_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevokeAllWithContext revokes a user's sessions except the one to keep (empty to revoke all)
+func (r *UserSessionRepository) RevokeAllWithContext(ctx context.Context, userID uint, keepSessionID string, at time.Time) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, at)
+	if keepSessionID != "" {
+		query = query.Where("session_id <> ?", keepSessionID)
+	}
+	result := query.UpdateColumn("revoked_at", at)
+	return result.RowsAffected, result.Error
+}
+
+// CountActiveWithContext counts the unrevoked, unexpired sessions of all users
+func (r *UserSessionRepository) CountActiveWithContext(ctx context.Context, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.UserSession{}).
+		Where("revoked_at IS NULL AND expires_at > ?", now).
+		Count(&count).Error
+	return count, err
+}
+
+// DeleteEndedWithContext deletes the sessions that expired or were revoked before the cutoff
+func (r *UserSessionRepository) DeleteEndedWithContext(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ? OR revoked_at < ?", cutoff, cutoff).
+		Delete(&models.UserSession{})
+	return result.RowsAffected, result.Error
+}
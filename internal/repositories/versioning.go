@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrVersionConflict is returned when an update is rejected because the record
+// was modified since it was read (optimistic locking)
+var ErrVersionConflict = errors.New("record was modified concurrently")
+
+// bumpVersion is the update expression that increments a row's version
+var bumpVersion = gorm.Expr("version + 1")
+
+// updateVersioned applies updates to the row with the given id only if its version still matches
+func updateVersioned(db *gorm.DB, model interface{}, id uint, version int, updates map[string]interface{}) error {
+	versioned := make(map[string]interface{}, len(updates)+1)
+	for column, value := range updates {
+		versioned[column] = value
+	}
+	versioned["version"] = bumpVersion
+
+	result := db.Model(model).Where("id = ? AND version = ?", id, version).Updates(versioned)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// saveVersioned saves all fields of the loaded model with the given id only if the stored
+// version still matches *version, incrementing *version on success
+func saveVersioned(db *gorm.DB, model interface{}, id uint, version *int) error {
+	if id == 0 {
+		return gorm.ErrMissingWhereClause
+	}
+
+	expected := *version
+	*version = expected + 1
+
+	result := db.Model(model).
+		Select("*").
+		Omit(clause.Associations).
+		Where("id = ? AND version = ?", id, expected).
+		Updates(model)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = expected
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WasteRepository handles waste log database operations
+type WasteRepository struct {
+	db *gorm.DB
+}
+
+// NewWasteRepository creates a new WasteRepository instance
+func NewWasteRepository(db *gorm.DB) *WasteRepository {
+	return &WasteRepository{db: db}
+}
+
+// WasteFilter narrows a waste log listing; zero values don't filter
+type WasteFilter struct {
+	Reason string
+	From   time.Time // Inclusive
+	To     time.Time // Exclusive
+}
+
+// WasteTotal is the wasted quantity and cost of an ingredient for one reason
+type WasteTotal struct {
+	IngredientID uint    `json:"ingredient_id"`
+	Name         string  `json:"name"`
+	Unit         string  `json:"unit"`
+	Reason       string  `json:"reason"`
+	Quantity     float64 `json:"quantity"`
+	Cost         float64 `json:"cost"`
+}
+
+// CreateWithLockedIngredientsWithContext logs a waste entry and deducts its stock in a single transaction
+// The restaurant's ingredients with the given IDs are locked (SELECT ... FOR UPDATE) and passed to apply keyed by ID;
+// apply must set the entry's lines and update the ingredients' stock, returning an error rolls back
+func (r *WasteRepository) CreateWithLockedIngredientsWithContext(
+	ctx context.Context,
+	entry *models.WasteEntry,
+	ingredientIDs []uint,
+	apply func(ingredients map[uint]*models.Ingredient) error,
+) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock in ID order so concurrent stock changes cannot deadlock
+		var ingredients []models.Ingredient
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("restaurant_id = ? AND id IN ?", entry.RestaurantID, ingredientIDs).
+			Order("id ASC").
+			Find(&ingredients).Error; err != nil {
+			return err
+		}
+		byID := make(map[uint]*models.Ingredient, len(ingredients))
+		for i := range ingredients {
+			byID[ingredients[i].ID] = &ingredients[i]
+		}
+
+		if err := apply(byID); err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Create(entry).Error; err != nil {
+			return err
+		}
+		for i := range entry.Lines {
+			entry.Lines[i].RestaurantID = entry.RestaurantID
+			entry.Lines[i].WasteEntryID = entry.ID
+		}
+		if len(entry.Lines) > 0 {
+			if err := tx.Omit("Ingredient").Create(&entry.Lines).Error; err != nil {
+				return err
+			}
+		}
+		for _, ingredient := range byID {
+			if err := tx.Model(ingredient).Update("stock_quantity", ingredient.StockQuantity).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetByIDForRestaurant retrieves a waste entry by ID with its lines, scoped to the restaurant
+func (r *WasteRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.WasteEntry, error) {
+	var entry models.WasteEntry
+	if err := r.db.WithContext(ctx).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Lines.Ingredient").
+		Where("restaurant_id = ?", restaurantID).
+		First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// ListWithContext lists a restaurant's waste entries, newest first
+func (r *WasteRepository) ListWithContext(ctx context.Context, restaurantID uint, filter WasteFilter, limit, offset int) ([]models.WasteEntry, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.WasteEntry{}).Where("restaurant_id = ?", restaurantID)
+	if filter.Reason != "" {
+		query = query.Where("reason = ?", filter.Reason)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("created_at < ?", filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []models.WasteEntry
+	if err := query.
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Lines.Ingredient").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error; err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// GetTotalsWithContext sums the wasted quantity and cost per ingredient and reason in [from, to)
+func (r *WasteRepository) GetTotalsWithContext(ctx context.Context, restaurantID uint, from, to time.Time) ([]WasteTotal, error) {
+	var totals []WasteTotal
+	if err := r.db.WithContext(ctx).
+		Table("waste_entry_lines AS l").
+		Select(`l.ingredient_id, i.name, i.unit, e.reason,
+			SUM(l.quantity)::float8 AS quantity, SUM(l.cost)::float8 AS cost`).
+		Joins("JOIN waste_entries e ON e.id = l.waste_entry_id").
+		Joins("JOIN ingredients i ON i.id = l.ingredient_id").
+		Where("e.restaurant_id = ? AND e.created_at >= ? AND e.created_at < ?", restaurantID, from, to).
+		Group("l.ingredient_id, i.name, i.unit, e.reason").
+		Order("cost DESC, i.name ASC").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
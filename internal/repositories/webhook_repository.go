@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"context"
+	"restaurant-backend/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WebhookRepository handles webhook endpoint-related database operations
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new WebhookRepository instance
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// CreateWithContext creates a new webhook endpoint using the provided context
+func (r *WebhookRepository) CreateWithContext(ctx context.Context, endpoint *models.WebhookEndpoint) error {
+	return r.db.WithContext(ctx).Create(endpoint).Error
+}
+
+// GetByIDWithContext retrieves a webhook endpoint by ID using the provided context
+func (r *WebhookRepository) GetByIDWithContext(ctx context.Context, id uint) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	if err := r.db.WithContext(ctx).First(&endpoint, id).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// GetByIDForRestaurant retrieves a webhook endpoint by ID, scoped to the restaurant
+func (r *WebhookRepository) GetByIDForRestaurant(ctx context.Context, id uint, restaurantID uint) (*models.WebhookEndpoint, error) {
+	var endpoint models.WebhookEndpoint
+	if err := r.db.WithContext(ctx).
+		Where("restaurant_id = ?", restaurantID).
+		First(&endpoint, id).Error; err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// GetByRestaurantIDWithContext retrieves all webhook endpoints for a restaurant
+func (r *WebhookRepository) GetByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ?", restaurantID).
+		Order("created_at ASC").
+		Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// GetActiveByRestaurantIDWithContext retrieves active webhook endpoints for a restaurant
+func (r *WebhookRepository) GetActiveByRestaurantIDWithContext(ctx context.Context, restaurantID uint) ([]models.WebhookEndpoint, error) {
+	var endpoints []models.WebhookEndpoint
+	if err := r.db.WithContext(ctx).Where("restaurant_id = ? AND is_active = ?", restaurantID, true).
+		Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+// RecordDeliveryWithContext stores the outcome of the latest delivery attempt
+// Failure count is reset on success and incremented on failure
+func (r *WebhookRepository) RecordDeliveryWithContext(ctx context.Context, id uint, statusCode int, success bool) error {
+	updates := map[string]interface{}{
+		"last_delivered_at": time.Now(),
+		"last_status_code":  statusCode,
+	}
+	if success {
+		updates["failure_count"] = 0
+	} else {
+		updates["failure_count"] = gorm.Expr("failure_count + 1")
+	}
+	return r.db.WithContext(ctx).Model(&models.WebhookEndpoint{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteWithContext deletes a webhook endpoint using the provided context
+func (r *WebhookRepository) DeleteWithContext(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.WebhookEndpoint{}, id).Error
+}
+
+// CreateDeliveriesWithContext queues deliveries, due right away
+func (r *WebhookRepository) CreateDeliveriesWithContext(ctx context.Context, deliveries []models.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return CreateInBatches(r.db.WithContext(ctx).Omit(clause.Associations), &deliveries, 0)
+}
+
+// ClaimDueDeliveriesWithContext hands out pending deliveries whose next attempt is due, oldest first, with
+// their endpoint, counting the attempt and moving next_attempt_at out by the lease. A delivery whose
+// attempt never finishes (e.g. the instance stopped) is due again once the lease runs out
+// Deliveries to endpoints that were deactivated are skipped
+func (r *WebhookRepository) ClaimDueDeliveriesWithContext(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED lets overlapping runs claim different deliveries
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED", Table: clause.Table{Name: "webhook_deliveries"}}).
+			InnerJoins("Webhook").
+			Where("webhook_deliveries.status = ? AND webhook_deliveries.next_attempt_at <= ?", models.WebhookDeliveryStatusPending, time.Now()).
+			Where(`"Webhook".is_active`).
+			Order("webhook_deliveries.id ASC").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return err
+		}
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+			deliveries[i].Attempts++
+		}
+		return tx.Model(&models.WebhookDelivery{}).Where("id IN ?", ids).Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": time.Now().Add(lease),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// CompleteDeliveryWithContext records a delivered attempt
+func (r *WebhookRepository) CompleteDeliveryWithContext(ctx context.Context, id uint, statusCode int) error {
+	return r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":           models.WebhookDeliveryStatusDelivered,
+		"last_status_code": statusCode,
+		"error":            "",
+		"delivered_at":     time.Now(),
+	}).Error
+}
+
+// FailDeliveryWithContext records a failed attempt: the delivery is retried at nextAttemptAt, or failed
+// for good when nextAttemptAt is nil
+func (r *WebhookRepository) FailDeliveryWithContext(ctx context.Context, id uint, statusCode int, errMessage string, nextAttemptAt *time.Time) error {
+	updates := map[string]interface{}{
+		"last_status_code": statusCode,
+		"error":            errMessage,
+	}
+	if nextAttemptAt != nil {
+		updates["next_attempt_at"] = *nextAttemptAt
+	} else {
+		updates["status"] = models.WebhookDeliveryStatusFailed
+	}
+	return r.db.WithContext(ctx).Model(&models.WebhookDelivery{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// DeleteFinishedDeliveriesBeforeWithContext deletes delivered and failed deliveries created before the given
+// time and returns how many were deleted
+func (r *WebhookRepository) DeleteFinishedDeliveriesBeforeWithContext(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("status IN ? AND created_at < ?", []string{models.WebhookDeliveryStatusDelivered, models.WebhookDeliveryStatusFailed}, before).
+		Delete(&models.WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
@@ -1,18 +1,23 @@
 package router
 
 import (
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/handlers"
 
 	"github.com/gin-gonic/gin"
 )
 
 // setupAuthRoutes configures authentication routes
-func setupAuthRoutes(api *gin.RouterGroup, authHandler *handlers.AuthHandler) {
+func setupAuthRoutes(api *gin.RouterGroup, c *container.Container) {
+	authHandler := handlers.NewAuthHandler(c.Auth, c.EmailVerification)
+
 	auth := api.Group("/auth")
 	{
 		auth.POST("/login", authHandler.Login)
 		// User registration (for restaurant admins to create staff/users)
 		// Note: KAM role is NOT allowed via this endpoint
 		auth.POST("/register", authHandler.Register)
+		auth.POST("/verify-email", authHandler.VerifyEmail)
+		auth.POST("/verify-email/resend", authHandler.ResendVerification)
 	}
 }
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupBillingRoutes configures platform usage metering and invoice routes (KAM only)
+func setupBillingRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	billingHandler := handlers.NewBillingHandler(c.Billing)
+
+	billing := protected.Group("/platform/billing")
+	billing.Use(middleware.RequireRole("KAM"))
+	{
+		billing.GET("/usage", billingHandler.ListUsage)
+		billing.GET("/invoices", billingHandler.ListInvoices)
+		billing.GET("/invoices/export", billingHandler.ExportInvoices)
+		billing.POST("/invoices/generate", billingHandler.GenerateInvoices)
+		billing.GET("/invoices/:id", billingHandler.GetInvoice)
+		billing.GET("/invoices/:id/pdf", billingHandler.GetInvoicePDF)
+		billing.POST("/invoices/:id/issue", billingHandler.IssueInvoice)
+	}
+}
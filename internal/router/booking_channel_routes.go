@@ -0,0 +1,35 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupBookingChannelRoutes configures external booking channel routes (includes the partner API the channels call)
+func setupBookingChannelRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	channelHandler := handlers.NewBookingChannelHandler(c.BookingChannel, c.Reservation)
+
+	// Partner API (protected by booking channel token instead of JWT)
+	partner := api.Group("/public/booking-channels/:token")
+	{
+		partner.GET("/availability", channelHandler.SearchChannelAvailability)
+		partner.POST("/bookings", channelHandler.CreateChannelBooking)
+		partner.GET("/bookings/:external_id", channelHandler.GetChannelBooking)
+		partner.POST("/bookings/:external_id/cancel", channelHandler.CancelChannelBooking)
+	}
+
+	// Booking channel management (Admin only)
+	channels := protected.Group("/integrations/booking-channels")
+	channels.Use(middleware.RequireRole("Admin"))
+	{
+		channels.GET("", channelHandler.ListChannels)
+		channels.POST("", channelHandler.CreateChannel)
+		channels.PUT("/:id", channelHandler.UpdateChannel)
+		channels.DELETE("/:id", channelHandler.DeleteChannel)
+		channels.POST("/:id/token", channelHandler.RotateChannelToken)
+	}
+}
@@ -1,57 +1,84 @@
 package router
 
 import (
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/handlers"
-	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/middleware"
+	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // setupBusinessRoutes configures business-related routes (categories, menu items, orders, reservations)
-func setupBusinessRoutes(protected *gin.RouterGroup, db *gorm.DB) {
-	// Initialize repositories
-	categoryRepo := repositories.NewCategoryRepository(db)
-	menuItemRepo := repositories.NewMenuItemRepository(db)
-	reservationRepo := repositories.NewReservationRepository(db)
-	orderRepo := repositories.NewOrderRepository(db)
-	orderItemRepo := repositories.NewOrderItemRepository(db)
-
-	// Initialize services
-	reservationService := services.NewReservationService(reservationRepo)
-	orderService := services.NewOrderService(orderRepo, orderItemRepo, menuItemRepo)
-
+func setupBusinessRoutes(protected *gin.RouterGroup, c *container.Container) {
 	// Initialize handlers
-	categoryHandler := handlers.NewCategoryHandler(categoryRepo)
-	menuItemHandler := handlers.NewMenuItemHandler(menuItemRepo)
-	reservationHandler := handlers.NewReservationHandler(reservationService, reservationRepo)
-	orderHandler := handlers.NewOrderHandler(orderService, orderRepo)
+	categoryHandler := handlers.NewCategoryHandler(c.Repos.Category, c.Category, c.Approval)
+	menuItemHandler := handlers.NewMenuItemHandler(c.Repos.MenuItem, c.MenuItem, c.Permission, c.Approval)
+	reservationHandler := handlers.NewReservationHandler(c.Reservation, c.Repos.Reservation)
+	orderHandler := handlers.NewOrderHandler(c.Order, c.Repos.Order, c.Approval)
+	orderSplitHandler := handlers.NewOrderSplitHandler(c.OrderSplit)
+	orderScheduleHandler := handlers.NewOrderScheduleHandler(c.OrderSchedule)
+	deliveryZoneHandler := handlers.NewDeliveryZoneHandler(c.DeliveryZone, c.Repos.Restaurant)
+	pricingRuleHandler := handlers.NewPricingRuleHandler(c.PricingRule)
+	webhookHandler := handlers.NewWebhookHandler(c.Webhook)
+	settingsHandler := handlers.NewRestaurantSettingsHandler(c.Settings)
+	menuCloneHandler := handlers.NewMenuCloneHandler(c.MenuClone)
+	menuPublishHandler := handlers.NewMenuPublishHandler(c.MenuPublish)
+	menuPairingHandler := handlers.NewMenuPairingHandler(c.MenuPairing)
+	menuPriceHandler := handlers.NewMenuPriceHandler(c.MenuPrice, c.Approval)
+	customerHandler := handlers.NewCustomerHandler(c.Customer)
+	reviewHandler := handlers.NewReviewHandler(c.Review)
+	receiptHandler := handlers.NewReceiptHandler(c.Receipt)
+	imageHandler := handlers.NewMenuItemImageHandler(c.Repos.MenuItemImage)
+	exportHandler := handlers.NewExportHandler(c.Export)
+	cartHandler := handlers.NewCartHandler(c.Cart)
+	approvalHandler := handlers.NewApprovalHandler(c.Approval)
+	archiveHandler := handlers.NewArchiveHandler(c.Archive)
 
 	// Menu Category routes (Admin/Staff only - for managing categories)
 	categories := protected.Group("/categories")
 	{
 		categories.POST("", categoryHandler.CreateCategory)
 		categories.GET("", categoryHandler.ListCategories)
+		categories.PUT("/reorder", middleware.RequireRole("Admin", "Staff"), categoryHandler.ReorderCategories)
 		categories.GET("/:id", categoryHandler.GetCategory)
 		categories.PUT("/:id", categoryHandler.UpdateCategory)
 		categories.DELETE("/:id", categoryHandler.DeleteCategory)
+		categories.PATCH("/:id/availability", middleware.RequireRole("Admin", "Staff"), categoryHandler.SetCategoryAvailability)
+		categories.PUT("/:id/items/reorder", middleware.RequireRole("Admin", "Staff"), categoryHandler.ReorderCategoryItems)
+		categories.POST("/:id/archive", middleware.RequireRole("Admin", "Staff"), categoryHandler.ArchiveCategory)
+		categories.POST("/:id/restore", middleware.RequireRole("Admin", "Staff"), categoryHandler.RestoreCategory)
 	}
 
 	// Menu Item routes (Admin/Staff only - for managing items)
+	changePrices := middleware.RequirePermission(c.Permission, models.PermissionChangePrices)
 	menuItems := protected.Group("/menu-items")
 	{
-		menuItems.POST("", menuItemHandler.CreateMenuItem)
+		menuItems.POST("", middleware.EnforcePlanLimit(c.Subscription, services.PlanResourceMenuItems), menuItemHandler.CreateMenuItem)
 		menuItems.GET("", menuItemHandler.ListMenuItems)
+		menuItems.POST("/bulk-availability", middleware.RequireRole("Admin", "Staff"), menuItemHandler.BulkSetAvailability)
 		menuItems.GET("/:id", menuItemHandler.GetMenuItem)
 		menuItems.PUT("/:id", menuItemHandler.UpdateMenuItem)
 		menuItems.DELETE("/:id", menuItemHandler.DeleteMenuItem)
+		menuItems.GET("/:id/pairings", middleware.RequireRole("Admin", "Staff"), menuPairingHandler.ListPairings)
+		menuItems.PUT("/:id/pairings", middleware.RequireRole("Admin", "Staff"), menuPairingHandler.SetPairings)
+		menuItems.GET("/:id/price-history", middleware.RequireRole("Admin", "Staff"), menuPriceHandler.GetPriceHistory)
+		menuItems.POST("/:id/price-changes", changePrices, menuPriceHandler.SchedulePriceChange)
+		menuItems.DELETE("/:id/price-changes/:change_id", changePrices, menuPriceHandler.CancelPriceChange)
+	}
+
+	// Menu clone routes (KAM, or org Admin between the organization's locations) and draft menu publishing
+	menu := protected.Group("/menu")
+	{
+		menu.POST("/clone", middleware.RequireRole("KAM", "Admin"), menuCloneHandler.CloneMenu)
+		menu.GET("/draft", middleware.RequireRole("Admin", "Staff"), menuPublishHandler.GetDraft)
+		menu.POST("/publish", middleware.RequireRole("Admin"), menuPublishHandler.PublishMenu)
+		menu.GET("/publications", middleware.RequireRole("Admin"), menuPublishHandler.ListPublications)
 	}
 
 	// Menu Item Image routes (Admin/Staff only - for managing item images)
 	// Using separate prefix to avoid routing conflicts with /menu-items/:id
-	imageRepo := repositories.NewMenuItemImageRepository(db)
-	imageHandler := handlers.NewMenuItemImageHandler(imageRepo)
 	menuItemImages := protected.Group("/menu-item-images")
 	{
 		menuItemImages.POST("/:item_id", imageHandler.CreateMenuItemImage)
@@ -65,17 +92,115 @@ func setupBusinessRoutes(protected *gin.RouterGroup, db *gorm.DB) {
 	{
 		reservations.POST("", reservationHandler.CreateReservation)
 		reservations.GET("", reservationHandler.ListReservations)
+		reservations.GET("/availability", reservationHandler.SearchAvailability)
+		reservations.GET("/export", middleware.RequirePermission(c.Permission, models.PermissionExportData), middleware.ReadFromReplica(), exportHandler.ExportReservations)
 		reservations.GET("/:id", reservationHandler.GetReservation)
 		reservations.PUT("/:id", reservationHandler.UpdateReservation)
 		reservations.DELETE("/:id", reservationHandler.DeleteReservation)
 	}
 
+	// Approval queue of sensitive Staff changes (Staff see their own requests, Admins review them)
+	approvals := protected.Group("/approvals")
+	{
+		approvals.GET("", middleware.RequireRole("Admin", "Staff"), approvalHandler.ListPendingChanges)
+		approvals.POST("/:id/approve", middleware.RequireRole("Admin"), approvalHandler.ApproveChange)
+		approvals.POST("/:id/reject", middleware.RequireRole("Admin"), approvalHandler.RejectChange)
+	}
+
 	// Order routes
 	orders := protected.Group("/orders")
 	{
-		orders.POST("", orderHandler.CreateOrder)
+		orders.POST("", middleware.EnforcePlanLimit(c.Subscription, services.PlanResourceMonthlyOrders), orderHandler.CreateOrder)
 		orders.GET("", orderHandler.ListOrders)
+		orders.GET("/export", middleware.RequirePermission(c.Permission, models.PermissionExportData), middleware.ReadFromReplica(), exportHandler.ExportOrders)
+		orders.GET("/kitchen", middleware.RequireRole("Admin", "Staff"), orderHandler.ListKitchenTickets)
+		orders.GET("/scheduled", middleware.RequireRole("Admin", "Staff"), orderScheduleHandler.ListScheduledQueue)
 		orders.GET("/:id", orderHandler.GetOrder)
+		orders.GET("/:id/nutrition", orderHandler.GetOrderNutrition)
+		orders.GET("/:id/receipt.pdf", receiptHandler.GetOrderReceipt)
+		orders.GET("/:id/ticket", middleware.RequireRole("Admin", "Staff"), orderHandler.GetKitchenTicket)
+		orders.POST("/:id/fire-course", middleware.RequireRole("Admin", "Staff"), orderHandler.FireCourse)
 		orders.PUT("/:id/status", orderHandler.UpdateOrderStatus)
+		orders.GET("/:id/status-history", middleware.RequireRole("Admin", "Staff"), orderHandler.GetOrderStatusHistory)
+		orders.PUT("/:id/seats", middleware.RequireRole("Admin", "Staff"), orderSplitHandler.AssignSeats)
+		orders.POST("/:id/split", middleware.RequireRole("Admin", "Staff"), orderSplitHandler.SplitOrder)
+		orders.GET("/:id/splits", middleware.RequireRole("Admin", "Staff"), orderSplitHandler.ListSplits)
+		orders.POST("/:id/splits/:splitId/pay", middleware.RequireRole("Admin", "Staff"), orderSplitHandler.PaySplit)
+		orders.POST("/:id/reviews", reviewHandler.CreateReview)
+	}
+
+	// Archived orders and reservations (Admin only)
+	archive := protected.Group("/archive")
+	archive.Use(middleware.RequireRole("Admin"))
+	{
+		archive.GET("/orders", archiveHandler.ListArchivedOrders)
+		archive.GET("/reservations", archiveHandler.ListArchivedReservations)
+	}
+
+	// Cart routes (customers; the cart follows them across devices)
+	cart := protected.Group("/cart")
+	cart.Use(middleware.RequireRole("Client"))
+	{
+		cart.GET("", cartHandler.GetMyCart)
+		cart.PUT("", cartHandler.SaveMyCart)
+		cart.POST("/claim", cartHandler.ClaimCart)
+	}
+
+	// Review routes (Staff can read, Admin moderates)
+	reviews := protected.Group("/reviews")
+	reviews.Use(middleware.RequireRole("Admin", "Staff"))
+	{
+		reviews.GET("", reviewHandler.ListReviews)
+		reviews.PUT("/:id/moderation", middleware.RequirePermission(c.Permission, models.PermissionModerateReviews), reviewHandler.ModerateReview)
+	}
+
+	// Customer (CRM) routes (Admin and Staff)
+	customers := protected.Group("/customers")
+	customers.Use(middleware.RequireRole("Admin", "Staff"))
+	{
+		customers.GET("", customerHandler.ListCustomers)
+		customers.GET("/export", middleware.RequirePermission(c.Permission, models.PermissionExportData), middleware.ReadFromReplica(), exportHandler.ExportCustomers)
+		customers.POST("", customerHandler.CreateCustomer)
+		customers.GET("/:id", customerHandler.GetCustomer)
+		customers.PUT("/:id", customerHandler.UpdateCustomer)
+		customers.GET("/:id/history", customerHandler.GetCustomerHistory)
+	}
+
+	// Webhook routes (Admin only - endpoints receive signed menu.updated events)
+	webhooks := protected.Group("/webhooks")
+	webhooks.Use(middleware.RequireRole("Admin"))
+	{
+		webhooks.POST("", webhookHandler.CreateWebhook)
+		webhooks.GET("", webhookHandler.ListWebhooks)
+		webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+	}
+
+	// Delivery zone routes (zones are managed by admins; any user can check an address)
+	deliveryZones := protected.Group("/delivery-zones")
+	{
+		deliveryZones.GET("", middleware.RequireRole("Admin", "Staff"), deliveryZoneHandler.ListZones)
+		deliveryZones.POST("", middleware.RequireRole("Admin"), deliveryZoneHandler.CreateZone)
+		deliveryZones.POST("/check", deliveryZoneHandler.CheckAddress)
+		deliveryZones.PUT("/:id", middleware.RequireRole("Admin"), deliveryZoneHandler.UpdateZone)
+		deliveryZones.DELETE("/:id", middleware.RequireRole("Admin"), deliveryZoneHandler.DeleteZone)
+	}
+
+	// Pricing rule routes (happy hours; rules are managed by admins and staff granted the permission)
+	managePricingRules := middleware.RequirePermission(c.Permission, models.PermissionManagePricingRules)
+	pricingRules := protected.Group("/pricing-rules")
+	{
+		pricingRules.GET("", middleware.RequireRole("Admin", "Staff"), pricingRuleHandler.ListRules)
+		pricingRules.POST("", managePricingRules, pricingRuleHandler.CreateRule)
+		pricingRules.PUT("/:id", managePricingRules, pricingRuleHandler.UpdateRule)
+		pricingRules.DELETE("/:id", managePricingRules, pricingRuleHandler.DeleteRule)
+	}
+
+	// Restaurant settings routes (branding/storefront; updates are Admin only)
+	settings := protected.Group("/settings")
+	{
+		settings.GET("", settingsHandler.GetSettings)
+		settings.PUT("", middleware.RequireRole("Admin"), settingsHandler.UpdateSettings)
+		settings.GET("/opening-hours", orderScheduleHandler.GetOpeningHours)
+		settings.PUT("/opening-hours", middleware.RequireRole("Admin"), orderScheduleHandler.UpdateOpeningHours)
 	}
 }
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupChangelogRoutes configures the API changelog routes
+func setupChangelogRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	changelogHandler := handlers.NewAPIChangelogHandler(c.Changelog)
+
+	// Public changelog for integrators (no authentication required)
+	api.GET("/changelog", changelogHandler.GetChangelog)
+
+	// Changelog publishing (KAM only)
+	changelog := protected.Group("/platform/changelog")
+	changelog.Use(middleware.RequireRole("KAM"))
+	{
+		changelog.POST("", changelogHandler.CreateEntry)
+		changelog.DELETE("/:id", changelogHandler.DeleteEntry)
+	}
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+	"restaurant-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupCloseoutRoutes configures end-of-day (Z) report routes
+func setupCloseoutRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	closeoutHandler := handlers.NewCloseoutHandler(c.Closeout)
+
+	// Staff can check the running day; closing is for admins and staff granted it, past closeouts for admins
+	reports := protected.Group("/reports/z")
+	{
+		reports.GET("", middleware.RequireRole("Admin", "Staff"), closeoutHandler.GetReport)
+		reports.POST("/close", middleware.RequirePermission(c.Permission, models.PermissionCloseDay), closeoutHandler.CloseDay)
+		reports.GET("/closeouts", middleware.RequireRole("Admin"), closeoutHandler.ListCloseouts)
+		reports.GET("/closeouts/:id", middleware.RequireRole("Admin"), closeoutHandler.GetCloseout)
+	}
+}
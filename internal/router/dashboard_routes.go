@@ -1,31 +1,27 @@
 package router
 
 import (
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/handlers"
-	"restaurant-backend/internal/repositories"
-	"restaurant-backend/internal/services"
+	"restaurant-backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // setupDashboardRoutes configures dashboard routes
-func setupDashboardRoutes(protected *gin.RouterGroup, db *gorm.DB) {
-	// Initialize repositories
-	orderRepo := repositories.NewOrderRepository(db)
-	reservationRepo := repositories.NewReservationRepository(db)
-
-	// Initialize service
-	dashboardService := services.NewDashboardService(orderRepo, reservationRepo)
-
+func setupDashboardRoutes(protected *gin.RouterGroup, c *container.Container) {
 	// Initialize handler
-	dashboardHandler := handlers.NewDashboardHandler(dashboardService)
+	dashboardHandler := handlers.NewDashboardHandler(c.Dashboard)
 
-	// Dashboard routes
-	dashboard := protected.Group("/dashboard")
+	// Dashboard routes (read-only reports, served by the read replicas when configured)
+	dashboard := protected.Group("/dashboard", middleware.ReadFromReplica())
 	{
 		dashboard.GET("/stats", dashboardHandler.GetDashboardStats)
 		dashboard.GET("/recent-orders", dashboardHandler.GetRecentOrders)
 		dashboard.GET("/analytics", dashboardHandler.GetAnalytics)
+		dashboard.GET("/analytics/breakdown", dashboardHandler.GetAnalyticsBreakdown)
+		dashboard.GET("/labor", middleware.RequireRole("Admin"), dashboardHandler.GetLaborReport)
+		dashboard.GET("/margins", middleware.RequireRole("Admin"), dashboardHandler.GetMarginReport)
+		dashboard.GET("/stream", dashboardHandler.StreamDashboard)
 	}
 }
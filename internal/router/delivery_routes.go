@@ -0,0 +1,26 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupDeliveryRoutes configures delivery platform integration routes (Admin only)
+func setupDeliveryRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	deliveryHandler := handlers.NewDeliveryHandler(c.Delivery)
+
+	delivery := protected.Group("/integrations/delivery")
+	delivery.Use(middleware.RequireRole("Admin"))
+	{
+		delivery.GET("", deliveryHandler.ListIntegrations)
+		delivery.POST("", deliveryHandler.CreateIntegration)
+		delivery.PUT("/:id", deliveryHandler.UpdateIntegration)
+		delivery.DELETE("/:id", deliveryHandler.DeleteIntegration)
+		delivery.POST("/:id/sync-menu", deliveryHandler.SyncMenu)
+		delivery.POST("/:id/pull-orders", deliveryHandler.PullOrders)
+	}
+}
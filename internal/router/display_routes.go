@@ -0,0 +1,29 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupDisplayRoutes configures the order status board (TV mode) routes
+func setupDisplayRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	displayHandler := handlers.NewDisplayHandler(c.Display)
+
+	// Public read-only board (protected by display token instead of JWT)
+	displayPublic := api.Group("/public/display")
+	{
+		displayPublic.GET("/:token", displayHandler.GetDisplayBoard)
+		displayPublic.GET("/:token/stream", displayHandler.StreamDisplayBoard)
+	}
+
+	// Display token management (Admin only)
+	display := protected.Group("/display")
+	display.Use(middleware.RequireRole("Admin"))
+	{
+		display.POST("/token", displayHandler.RotateDisplayToken)
+	}
+}
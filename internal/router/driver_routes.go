@@ -0,0 +1,37 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupDriverRoutes configures driver, delivery assignment and driver app routes
+func setupDriverRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	driverHandler := handlers.NewDriverHandler(c.Driver)
+
+	// Driver app endpoints (protected by driver token instead of JWT)
+	driverApp := api.Group("/public/drivers/:token")
+	{
+		driverApp.GET("/deliveries", driverHandler.ListDriverDeliveries)
+		driverApp.POST("/deliveries/:id", driverHandler.UpdateDriverDelivery)
+	}
+
+	// Driver management (Admin only; staff can list drivers to dispatch)
+	drivers := protected.Group("/drivers")
+	{
+		drivers.GET("", middleware.RequireRole("Admin", "Staff"), driverHandler.ListDrivers)
+		drivers.POST("", middleware.RequireRole("Admin"), driverHandler.CreateDriver)
+		drivers.PUT("/:id", middleware.RequireRole("Admin"), driverHandler.UpdateDriver)
+		drivers.POST("/:id/token", middleware.RequireRole("Admin"), driverHandler.RotateDriverToken)
+	}
+
+	// Dispatch (Admin/Staff)
+	protected.GET("/deliveries", middleware.RequireRole("Admin", "Staff"), driverHandler.ListDeliveries)
+	protected.GET("/orders/:id/driver", middleware.RequireRole("Admin", "Staff"), driverHandler.GetOrderDriver)
+	protected.PUT("/orders/:id/driver", middleware.RequireRole("Admin", "Staff"), driverHandler.AssignDriver)
+	protected.DELETE("/orders/:id/driver", middleware.RequireRole("Admin", "Staff"), driverHandler.UnassignDriver)
+}
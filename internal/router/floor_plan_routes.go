@@ -0,0 +1,28 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupFloorPlanRoutes configures floor plan and table map routes
+func setupFloorPlanRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	floorPlanHandler := handlers.NewFloorPlanHandler(c.FloorPlan)
+
+	// Staff use the plan at the host stand; editing the layout is for admins
+	floorPlan := protected.Group("/floor-plan")
+	{
+		floorPlan.GET("", middleware.RequireRole("Admin", "Staff"), floorPlanHandler.GetFloorPlan)
+		floorPlan.GET("/live", middleware.RequireRole("Admin", "Staff"), floorPlanHandler.GetLiveFloorPlan)
+		floorPlan.POST("/sections", middleware.RequireRole("Admin"), floorPlanHandler.CreateSection)
+		floorPlan.PUT("/sections/:id", middleware.RequireRole("Admin"), floorPlanHandler.UpdateSection)
+		floorPlan.DELETE("/sections/:id", middleware.RequireRole("Admin"), floorPlanHandler.DeleteSection)
+		floorPlan.POST("/tables", middleware.RequireRole("Admin"), floorPlanHandler.CreateTable)
+		floorPlan.PUT("/tables/:id", middleware.RequireRole("Admin"), floorPlanHandler.UpdateTable)
+		floorPlan.DELETE("/tables/:id", middleware.RequireRole("Admin"), floorPlanHandler.DeleteTable)
+	}
+}
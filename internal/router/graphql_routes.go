@@ -0,0 +1,26 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/graph"
+	"restaurant-backend/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupGraphQLRoutes configures the GraphQL read API (menus, orders, reservations)
+// It reads the same repositories as the REST handlers, behind the same authentication and tenant context
+func setupGraphQLRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	graphQLHandler := handlers.NewGraphQLHandler(graph.NewResolver(
+		c.Repos.Restaurant,
+		c.Repos.Category,
+		c.Repos.MenuItem,
+		c.Repos.Order,
+		c.Repos.Reservation,
+	))
+
+	// GraphQL queries (any authenticated user, scoped to their restaurant like the REST reads)
+	protected.GET("/graphql", graphQLHandler.Query)
+	protected.POST("/graphql", graphQLHandler.Query)
+}
@@ -0,0 +1,16 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupHealthRoutes configures the liveness (/health) and readiness (/ready) probes
+func setupHealthRoutes(r *gin.Engine, c *container.Container) {
+	healthHandler := handlers.NewHealthHandler(c.Health)
+
+	r.GET("/health", healthHandler.Health)
+	r.GET("/ready", healthHandler.Ready)
+}
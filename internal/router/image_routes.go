@@ -1,35 +1,36 @@
 package router
 
 import (
-	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/handlers"
 	"restaurant-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
 )
 
-// setupImageRoutes configures image-related routes (S3)
-func setupImageRoutes(protected *gin.RouterGroup, cfg *config.Config) *handlers.ImageHandler {
-	// Initialize S3 service (optional, only if configured)
-	var s3Service *services.S3Service
+// setupImageRoutes configures image-related routes (S3, MinIO, or local storage)
+func setupImageRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) *handlers.ImageHandler {
+	// Initialize storage (optional, only if configured)
 	var imageHandler *handlers.ImageHandler
 
-	if cfg.S3BucketName != "" {
-		if s3Svc, err := services.NewS3Service(cfg); err == nil {
-			s3Service = s3Svc
-			imageHandler = handlers.NewImageHandler(s3Service)
+	if storage := c.Storage; storage != nil {
+		imageHandler = handlers.NewImageHandler(storage)
 
-			// Image routes (if S3 is configured)
-			images := protected.Group("/images")
-			{
-				images.POST("/upload", imageHandler.UploadImage)
-				images.GET("/*key", imageHandler.GetImageURL)
-				images.DELETE("/*key", imageHandler.DeleteImage)
-			}
+		// Image routes (if storage is configured)
+		images := protected.Group("/images")
+		{
+			images.POST("/upload", imageHandler.UploadImage)
+			images.GET("/*key", imageHandler.GetImageURL)
+			images.DELETE("/*key", imageHandler.DeleteImage)
+		}
+
+		// Local storage serves files itself via signed URLs (public, signature-checked)
+		if localStorage, ok := storage.(*services.LocalStorage); ok {
+			fileHandler := handlers.NewFileHandler(localStorage)
+			api.GET("/files/*key", fileHandler.ServeFile)
 		}
-		// Log error but don't fail startup if S3 is not configured
-		// In production, this should be handled more gracefully
 	}
+	// Storage is not configured: image routes are left out
 
 	return imageHandler
 }
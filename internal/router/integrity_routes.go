@@ -0,0 +1,23 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupIntegrityRoutes configures the tenant integrity checker routes (KAM only)
+func setupIntegrityRoutes(protected *gin.RouterGroup, c *container.Container) {
+	integrityHandler := handlers.NewIntegrityHandler(c.Integrity)
+
+	integrity := protected.Group("/platform/integrity")
+	integrity.Use(middleware.RequireRole("KAM"))
+	{
+		integrity.GET("/findings", integrityHandler.ListFindings)
+		integrity.POST("/run", integrityHandler.RunChecks)
+		integrity.POST("/findings/:id/quarantine", integrityHandler.QuarantineFinding)
+		integrity.POST("/findings/:id/dismiss", integrityHandler.DismissFinding)
+	}
+}
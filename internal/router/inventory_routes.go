@@ -0,0 +1,47 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupInventoryRoutes configures ingredient, recipe, supplier, purchase order and waste log routes
+func setupInventoryRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handlers
+	inventoryHandler := handlers.NewInventoryHandler(c.Inventory)
+	purchasingHandler := handlers.NewPurchasingHandler(c.Purchasing)
+	wasteHandler := handlers.NewWasteHandler(c.Waste)
+
+	// Staff check stock and recipes, receive deliveries and log waste; purchasing and costing are for admins
+	inventory := protected.Group("/inventory")
+	{
+		inventory.GET("/ingredients", middleware.RequireRole("Admin", "Staff"), inventoryHandler.ListIngredients)
+		inventory.POST("/ingredients", middleware.RequireRole("Admin"), inventoryHandler.CreateIngredient)
+		inventory.PUT("/ingredients/:id", middleware.RequireRole("Admin"), inventoryHandler.UpdateIngredient)
+		inventory.DELETE("/ingredients/:id", middleware.RequireRole("Admin"), inventoryHandler.DeleteIngredient)
+
+		inventory.GET("/recipes/:menu_item_id", middleware.RequireRole("Admin", "Staff"), inventoryHandler.GetRecipe)
+		inventory.PUT("/recipes/:menu_item_id", middleware.RequireRole("Admin"), inventoryHandler.ReplaceRecipe)
+		inventory.GET("/menu-costs", middleware.RequireRole("Admin"), inventoryHandler.ListMenuItemCosts)
+
+		inventory.GET("/suppliers", middleware.RequireRole("Admin"), purchasingHandler.ListSuppliers)
+		inventory.POST("/suppliers", middleware.RequireRole("Admin"), purchasingHandler.CreateSupplier)
+		inventory.PUT("/suppliers/:id", middleware.RequireRole("Admin"), purchasingHandler.UpdateSupplier)
+		inventory.DELETE("/suppliers/:id", middleware.RequireRole("Admin"), purchasingHandler.DeleteSupplier)
+
+		inventory.GET("/purchase-orders", middleware.RequireRole("Admin", "Staff"), purchasingHandler.ListPurchaseOrders)
+		inventory.POST("/purchase-orders", middleware.RequireRole("Admin"), purchasingHandler.CreatePurchaseOrder)
+		inventory.GET("/purchase-orders/:id", middleware.RequireRole("Admin", "Staff"), purchasingHandler.GetPurchaseOrder)
+		inventory.PUT("/purchase-orders/:id", middleware.RequireRole("Admin"), purchasingHandler.UpdatePurchaseOrder)
+		inventory.POST("/purchase-orders/:id/submit", middleware.RequireRole("Admin"), purchasingHandler.SubmitPurchaseOrder)
+		inventory.POST("/purchase-orders/:id/cancel", middleware.RequireRole("Admin"), purchasingHandler.CancelPurchaseOrder)
+		inventory.POST("/purchase-orders/:id/receive", middleware.RequireRole("Admin", "Staff"), purchasingHandler.ReceiveGoods)
+
+		inventory.POST("/waste", middleware.RequireRole("Admin", "Staff"), wasteHandler.LogWaste)
+		inventory.GET("/waste", middleware.RequireRole("Admin", "Staff"), wasteHandler.ListWaste)
+		inventory.GET("/waste/summary", middleware.RequireRole("Admin"), wasteHandler.GetWasteSummary)
+	}
+}
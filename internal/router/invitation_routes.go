@@ -0,0 +1,31 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupInvitationRoutes configures user invitation routes
+func setupInvitationRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	invitationHandler := handlers.NewInvitationHandler(c.Invitation)
+
+	// Accept links are opened by people without an account yet
+	public := api.Group("/auth/invitations")
+	{
+		public.GET("", invitationHandler.PreviewInvitation)
+		public.POST("/accept", invitationHandler.AcceptInvitation)
+	}
+
+	// Invitation management (Admin only)
+	invitations := protected.Group("/invitations")
+	invitations.Use(middleware.RequireRole("Admin"))
+	{
+		invitations.POST("", invitationHandler.CreateInvitation)
+		invitations.GET("", invitationHandler.ListInvitations)
+		invitations.POST("/:id/resend", invitationHandler.ResendInvitation)
+		invitations.DELETE("/:id", invitationHandler.RevokeInvitation)
+	}
+}
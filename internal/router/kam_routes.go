@@ -0,0 +1,22 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupKAMRoutes configures the KAM portfolio routes (KAM only)
+func setupKAMRoutes(protected *gin.RouterGroup, c *container.Container) {
+	kamHandler := handlers.NewKAMHandler(c.KAM, c.RestaurantHealth)
+
+	kam := protected.Group("/kam")
+	kam.Use(middleware.RequireRole("KAM"))
+	kam.Use(middleware.DenyImpersonation())
+	{
+		kam.GET("/dashboard", kamHandler.GetDashboard)
+		kam.GET("/health", kamHandler.ListHealth)
+	}
+}
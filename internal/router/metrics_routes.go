@@ -0,0 +1,35 @@
+package router
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/container"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// setupMetricsRoutes exposes the Prometheus metrics at /metrics
+// With METRICS_TOKEN set, scrapes must send it as a bearer token
+func setupMetricsRoutes(r *gin.Engine, c *container.Container) {
+	handlers := []gin.HandlerFunc{gin.WrapH(promhttp.Handler())}
+	if token := c.Config.MetricsToken; token != "" {
+		handlers = append([]gin.HandlerFunc{requireMetricsToken(token)}, handlers...)
+	}
+	r.GET("/metrics", handlers...)
+}
+
+// requireMetricsToken rejects scrapes without the configured bearer token
+func requireMetricsToken(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			_ = c.Error(apperrors.Unauthorized(apperrors.CodeUnauthorized, "invalid metrics token"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,20 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupNotificationPreferenceRoutes configures the customer notification preference and unsubscribe routes
+func setupNotificationPreferenceRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	preferenceHandler := handlers.NewNotificationPreferenceHandler(c.NotificationPreference)
+
+	// Unsubscribe links in marketing emails (public, token-signed)
+	api.POST("/public/unsubscribe", preferenceHandler.Unsubscribe)
+
+	// The requesting customer's own preferences
+	protected.GET("/notification-preferences", preferenceHandler.GetPreferences)
+	protected.PUT("/notification-preferences", preferenceHandler.UpdatePreferences)
+}
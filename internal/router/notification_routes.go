@@ -0,0 +1,22 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupNotificationRoutes configures the in-app notification routes and the live WebSocket channel
+func setupNotificationRoutes(protected *gin.RouterGroup, c *container.Container) {
+	notificationHandler := handlers.NewNotificationHandler(c.Notification, c.Dashboard)
+
+	notifications := protected.Group("/notifications")
+	{
+		notifications.GET("", notificationHandler.ListNotifications)
+		notifications.DELETE("", notificationHandler.ClearNotifications)
+		notifications.POST("/read", notificationHandler.MarkRead)
+		notifications.DELETE("/:id", notificationHandler.DeleteNotification)
+		notifications.GET("/ws", notificationHandler.StreamLive)
+	}
+}
@@ -0,0 +1,29 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupOrganizationRoutes configures multi-location organization routes
+func setupOrganizationRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	organizationHandler := handlers.NewOrganizationHandler(c.Organization, c.Auth, c.Repos.User)
+
+	// Organization routes (restaurant Admins; all but creation require an org-scoped token)
+	organization := protected.Group("/organization")
+	organization.Use(middleware.RequireRole("Admin"))
+	{
+		organization.POST("", organizationHandler.CreateOrganization)
+		organization.GET("", organizationHandler.GetOrganization)
+		organization.PUT("/template", organizationHandler.SetTemplate)
+		organization.GET("/report", organizationHandler.GetReport)
+		organization.POST("/locations", organizationHandler.AddLocation)
+		organization.POST("/locations/:id/clone-menu", organizationHandler.CloneTemplateMenu)
+		organization.POST("/locations/:id/switch", organizationHandler.SwitchLocation)
+		organization.PUT("/users/:id/scope", organizationHandler.SetUserScope)
+	}
+}
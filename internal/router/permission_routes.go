@@ -0,0 +1,26 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPermissionRoutes configures the fine-grained permission routes
+func setupPermissionRoutes(protected *gin.RouterGroup, c *container.Container) {
+	permissionHandler := handlers.NewPermissionHandler(c.Permission)
+
+	// Own capabilities (any authenticated user)
+	protected.GET("/permissions", permissionHandler.ListMyPermissions)
+
+	// Overrides for the restaurant's staff (Admin only)
+	userPermissions := protected.Group("/users/:id/permissions")
+	userPermissions.Use(middleware.RequireRole("Admin"))
+	{
+		userPermissions.GET("", permissionHandler.ListUserPermissions)
+		userPermissions.PUT("/:permission", permissionHandler.SetUserPermission)
+		userPermissions.DELETE("/:permission", permissionHandler.ResetUserPermission)
+	}
+}
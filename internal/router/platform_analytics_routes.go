@@ -0,0 +1,22 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPlatformAnalyticsRoutes configures the cross-tenant analytics routes (platform KAMs only)
+func setupPlatformAnalyticsRoutes(protected *gin.RouterGroup, c *container.Container) {
+	analyticsHandler := handlers.NewPlatformAnalyticsHandler(c.PlatformAnalytics)
+
+	analytics := protected.Group("/platform/analytics")
+	analytics.Use(middleware.RequireRole("KAM"))
+	analytics.Use(middleware.DenyImpersonation())
+	{
+		analytics.GET("/overview", analyticsHandler.GetOverview)
+		analytics.GET("/trends", analyticsHandler.GetTrends)
+	}
+}
@@ -1,28 +1,30 @@
 package router
 
 import (
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/handlers"
 	"restaurant-backend/internal/middleware"
-	"restaurant-backend/internal/repositories"
-	"restaurant-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // setupPlatformRoutes configures platform-level routes (KAM management)
-func setupPlatformRoutes(protected *gin.RouterGroup, db *gorm.DB, authService *services.AuthService) {
-	// Initialize platform service and handler
-	platformRepo := repositories.NewRestaurantRepository(db)
-	platformUserRepo := repositories.NewUserRepository(db)
-	platformService := services.NewPlatformService(platformRepo, platformUserRepo)
-	platformHandler := handlers.NewPlatformHandler(platformService, authService)
+func setupPlatformRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	platformHandler := handlers.NewPlatformHandler(c.Platform, c.Auth, c.PlatformSearch, c.Impersonation)
 
 	// Platform management routes (KAM/Admin only)
 	platform := protected.Group("/platform")
 	platform.Use(middleware.RequireKAMOrAdmin())
+	platform.Use(middleware.DenyImpersonation()) // Impersonation tokens carry the Admin role
 	{
 		platform.POST("/kams", platformHandler.CreateKAM)
 		platform.GET("/kams", platformHandler.ListKAMs)
+
+		// Cross-tenant support search (KAM only, audit-logged)
+		platform.GET("/search", middleware.RequireRole("KAM"), platformHandler.Search)
+
+		// Act as a restaurant's admin for support (KAM only, short-lived, audit-logged)
+		platform.POST("/impersonations", middleware.RequireRole("KAM"), platformHandler.StartImpersonation)
 	}
 }
@@ -0,0 +1,42 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPrinterRoutes configures printer, print job and printer bridge routes
+func setupPrinterRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	printerHandler := handlers.NewPrinterHandler(c.Print)
+
+	// Printer bridge endpoints (protected by printer token instead of JWT)
+	bridge := api.Group("/public/printers/:token")
+	{
+		bridge.GET("/jobs", printerHandler.PollPrintJobs)
+		bridge.POST("/jobs/:id", printerHandler.ReportPrintJob)
+	}
+
+	// Printer management (Admin only)
+	printers := protected.Group("/printers")
+	printers.Use(middleware.RequireRole("Admin"))
+	{
+		printers.GET("", printerHandler.ListPrinters)
+		printers.POST("", printerHandler.CreatePrinter)
+		printers.PUT("/:id", printerHandler.UpdatePrinter)
+		printers.DELETE("/:id", printerHandler.DeletePrinter)
+		printers.POST("/:id/token", printerHandler.RotatePrinterToken)
+	}
+
+	// Print jobs (Admin/Staff)
+	printJobs := protected.Group("/print-jobs")
+	printJobs.Use(middleware.RequireRole("Admin", "Staff"))
+	{
+		printJobs.GET("", printerHandler.ListPrintJobs)
+		printJobs.POST("/:id/retry", printerHandler.RetryPrintJob)
+	}
+	protected.POST("/orders/:id/print", middleware.RequireRole("Admin", "Staff"), printerHandler.PrintOrder)
+}
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPrivacyRoutes configures the data export and erasure (GDPR) routes for the requesting user
+func setupPrivacyRoutes(protected *gin.RouterGroup, c *container.Container) {
+	privacyHandler := handlers.NewPrivacyHandler(c.Privacy)
+
+	// Impersonating KAMs may not export or erase a customer's data through the restaurant's session
+	privacy := protected.Group("/privacy")
+	privacy.Use(middleware.DenyImpersonation())
+	{
+		privacy.GET("/export", privacyHandler.ExportMyData)
+		// Erasure is for customer accounts; staff accounts are removed by their restaurant's Admin
+		privacy.DELETE("/data", middleware.RequireRole("Client"), privacyHandler.DeleteMyData)
+	}
+}
@@ -0,0 +1,37 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPrivateEventRoutes configures private event booking and event package routes
+func setupPrivateEventRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	privateEventHandler := handlers.NewPrivateEventHandler(c.PrivateEvent)
+
+	// Staff take event bookings; the packages on offer are set by admins
+	packages := protected.Group("/event-packages")
+	{
+		packages.GET("", middleware.RequireRole("Admin", "Staff"), privateEventHandler.ListPackages)
+		packages.POST("", middleware.RequireRole("Admin"), privateEventHandler.CreatePackage)
+		packages.PUT("/:id", middleware.RequireRole("Admin"), privateEventHandler.UpdatePackage)
+		packages.DELETE("/:id", middleware.RequireRole("Admin"), privateEventHandler.DeletePackage)
+	}
+
+	events := protected.Group("/private-events")
+	events.Use(middleware.RequireRole("Admin", "Staff"))
+	{
+		events.GET("", privateEventHandler.ListEvents)
+		events.POST("", privateEventHandler.CreateEvent)
+		events.GET("/:id", privateEventHandler.GetEvent)
+		events.PUT("/:id", privateEventHandler.UpdateEvent)
+		events.POST("/:id/quote", privateEventHandler.GenerateQuote)
+		events.GET("/:id/quote.pdf", privateEventHandler.GetQuotePDF)
+		events.PUT("/:id/status", privateEventHandler.ChangeStatus)
+		events.POST("/:id/deposits/:deposit_id/pay", privateEventHandler.RecordDepositPayment)
+	}
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPublicContentRoutes configures the sitemaps and structured data served to search engines (no authentication required)
+func setupPublicContentRoutes(api *gin.RouterGroup, c *container.Container) {
+	contentHandler := handlers.NewPublicContentHandler(c.PublicContent)
+
+	// Sitemap index linking every restaurant's sitemap
+	api.GET("/public/sitemap.xml", contentHandler.GetSitemapIndex)
+
+	public := api.Group("/public/restaurants")
+	{
+		// Storefront pages of a restaurant
+		public.GET("/:restaurant_id/sitemap.xml", contentHandler.GetSitemap)
+
+		// schema.org Restaurant and Menu as JSON-LD
+		public.GET("/:restaurant_id/structured-data", contentHandler.GetStructuredData)
+	}
+}
@@ -1,33 +1,61 @@
 package router
 
 import (
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/handlers"
-	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // setupPublicMenuRoutes configures public menu routes (no authentication required)
 // Clients can view menu items and categories for ordering
-func setupPublicMenuRoutes(api *gin.RouterGroup, db *gorm.DB) {
-	// Initialize repositories
-	categoryRepo := repositories.NewCategoryRepository(db)
-	menuItemRepo := repositories.NewMenuItemRepository(db)
+func setupPublicMenuRoutes(api *gin.RouterGroup, c *container.Container) {
+	// Initialize handlers
+	publicMenuHandler := handlers.NewPublicMenuHandler(c.Repos.Category, c.Repos.MenuItem, c.Repos.Restaurant, c.Settings, c.PricingRule, c.MenuSearch, c.MenuPairing, c.PrepTime)
+	reviewHandler := handlers.NewReviewHandler(c.Review)
+	deliveryZoneHandler := handlers.NewDeliveryZoneHandler(c.DeliveryZone, c.Repos.Restaurant)
+	cartHandler := handlers.NewCartHandler(c.Cart)
+	directoryHandler := handlers.NewRestaurantDirectoryHandler(c.RestaurantDirectory)
 
-	// Initialize handler
-	publicMenuHandler := handlers.NewPublicMenuHandler(categoryRepo, menuItemRepo)
+	// Menu reads tolerate replication lag, so they are served by the read replicas when configured
+	replica := middleware.ReadFromReplica()
 
 	// Public menu routes (no authentication required)
 	public := api.Group("/public/restaurants")
 	{
+		// Restaurant directory and public profiles (marketplace)
+		public.GET("", directoryHandler.SearchRestaurantsPublic)
+		public.GET("/:restaurant_id", directoryHandler.GetRestaurantProfilePublic)
+
 		// Get menu item details for ordering
-		public.GET("/:restaurant_id/menu-items/:item_id", publicMenuHandler.GetMenuItemPublic)
+		public.GET("/:restaurant_id/menu-items/:item_id", replica, publicMenuHandler.GetMenuItemPublic)
 
 		// List categories for a restaurant
-		public.GET("/:restaurant_id/categories", publicMenuHandler.ListCategoriesPublic)
+		public.GET("/:restaurant_id/categories", replica, publicMenuHandler.ListCategoriesPublic)
 
 		// List menu items for a restaurant (optionally filtered by category)
-		public.GET("/:restaurant_id/menu-items", publicMenuHandler.ListMenuItemsPublic)
+		public.GET("/:restaurant_id/menu-items", replica, publicMenuHandler.ListMenuItemsPublic)
+
+		// Full-text search over menu item names, tags and descriptions
+		public.GET("/:restaurant_id/search", replica, publicMenuHandler.SearchMenuPublic)
+
+		// Storefront branding and settings for a restaurant
+		public.GET("/:restaurant_id/settings", publicMenuHandler.GetSettingsPublic)
+
+		// Whether the kitchen takes online orders right now (paused or delayed while over capacity)
+		public.GET("/:restaurant_id/ordering-status", publicMenuHandler.GetOrderingStatusPublic)
+
+		// Visible reviews and rating for a restaurant (optionally for one menu item)
+		public.GET("/:restaurant_id/reviews", reviewHandler.ListPublicReviews)
+
+		// Delivery fee and minimum order for an address
+		public.POST("/:restaurant_id/delivery-zones/check", deliveryZoneHandler.CheckAddressPublic)
+
+		// Anonymous carts, resumed on any device with their token
+		public.POST("/:restaurant_id/carts", cartHandler.CreateCartPublic)
+		public.GET("/:restaurant_id/carts/:token", cartHandler.GetCartPublic)
+		public.PUT("/:restaurant_id/carts/:token", cartHandler.UpdateCartPublic)
+		public.DELETE("/:restaurant_id/carts/:token", cartHandler.DeleteCartPublic)
 	}
 }
@@ -0,0 +1,21 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupPushRoutes configures the Web Push subscription routes (any authenticated user, per device)
+func setupPushRoutes(protected *gin.RouterGroup, c *container.Container) {
+	pushHandler := handlers.NewPushHandler(c.Push)
+
+	push := protected.Group("/push")
+	{
+		push.GET("/public-key", pushHandler.GetPublicKey)
+		push.GET("/subscriptions", pushHandler.ListSubscriptions)
+		push.POST("/subscriptions", pushHandler.Subscribe)
+		push.DELETE("/subscriptions", pushHandler.Unsubscribe)
+	}
+}
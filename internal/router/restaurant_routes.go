@@ -1,22 +1,17 @@
 package router
 
 import (
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/handlers"
 	"restaurant-backend/internal/middleware"
-	"restaurant-backend/internal/repositories"
-	"restaurant-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // setupRestaurantRoutes configures restaurant-related routes
-func setupRestaurantRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, db *gorm.DB, emailService *services.EmailService) {
-	// Initialize repositories and services for restaurant routes
-	restaurantRepo := repositories.NewRestaurantRepository(db)
-	userRepo := repositories.NewUserRepository(db)
-	restaurantService := services.NewRestaurantService(restaurantRepo, userRepo, emailService)
-	restaurantHandler := handlers.NewRestaurantHandler(restaurantService, restaurantRepo)
+func setupRestaurantRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	restaurantHandler := handlers.NewRestaurantHandler(c.Restaurant, c.Repos.Restaurant)
 
 	// Public restaurant registration route
 	restaurantPublic := api.Group("/restaurants")
@@ -33,6 +28,7 @@ func setupRestaurantRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, db
 		restaurants.GET("/:id", restaurantHandler.GetRestaurant)
 		restaurants.POST("/:id/activate", restaurantHandler.ActivateRestaurant)
 		restaurants.PATCH("/:id/status", restaurantHandler.UpdateRestaurantStatus)
+		restaurants.PATCH("/:id/visibility", restaurantHandler.UpdateVisibility)
 		restaurants.PUT("/:id/assign-kam", restaurantHandler.AssignKAM)
 	}
 }
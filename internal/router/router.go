@@ -1,84 +1,217 @@
 package router
 
 import (
+	"net/http"
+	"time"
+
 	"restaurant-backend/internal/config"
-	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/middleware"
-	"restaurant-backend/internal/repositories"
 	"restaurant-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // SetupRouter configures and returns the Gin router
-func SetupRouter(cfg *config.Config, db *gorm.DB) *gin.Engine {
+// Routes get their services from the container, which builds the dependency graph once
+func SetupRouter(c *container.Container) *gin.Engine {
+	cfg := c.Config
+
+	// Report binding errors by request field name
+	useRequestFieldNames()
+
 	// Use gin.New() instead of Default() to skip default logger
 	r := gin.New()
 
 	// Add middlewares
-	r.Use(middleware.RequestLogger())
+	// Tracing and the request ID come first so the logs and errors of everything after carry them
+	r.Use(otelgin.Middleware(cfg.OTelServiceName, otelgin.WithFilter(skipProbeTracing)))
+	r.Use(middleware.RequestID())
+	r.Use(middleware.RequestLogger(cfg.LogPublicSampleRate))
+	r.Use(middleware.Metrics())
 	r.Use(gin.Recovery())
 	r.Use(corsMiddleware(cfg))
+	r.Use(middleware.ErrorHandler())
+	r.Use(middleware.EnforceLatencyBudget(latencyBudget(cfg)))
 
-	// Initialize repositories
-	userRepo := repositories.NewUserRepository(db)
+	// Flag deprecated endpoints (needs the service, so registered after the global middlewares above)
+	r.Use(middleware.DeprecationHeaders(c.Changelog))
 
-	// Initialize services
-	emailService := services.NewEmailService(cfg)
-	authService := services.NewAuthService(db, cfg, userRepo)
+	// Health check endpoints (liveness and dependency readiness)
+	setupHealthRoutes(r, c)
 
-	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(authService)
-
-	// Health check endpoint
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status":  "ok",
-			"service": "restaurant-backend",
-		})
-	})
+	// Prometheus scrape endpoint
+	setupMetricsRoutes(r, c)
 
 	// Public API routes
 	api := r.Group("/api/v1")
 	{
 		// Setup authentication routes
-		setupAuthRoutes(api, authHandler)
+		setupAuthRoutes(api, c)
 
 		// Setup public menu routes (no authentication required for viewing menu)
-		setupPublicMenuRoutes(api, db)
+		setupPublicMenuRoutes(api, c)
+
+		// Setup sitemap and structured data routes (no authentication required, indexed by search engines)
+		setupPublicContentRoutes(api, c)
 	}
 
 	// Protected API routes
 	protected := api.Group("")
-	protected.Use(middleware.RequireAuth(authService))
-	protected.Use(middleware.SetTenantContext(db))
+	protected.Use(middleware.RequireAuth(c.Auth))
+	protected.Use(middleware.AuditImpersonation(c.Impersonation))
 	{
 		// Setup business routes (menus, orders, reservations)
-		setupBusinessRoutes(protected, db)
+		setupBusinessRoutes(protected, c)
 
 		// Setup restaurant routes (includes public registration)
-		setupRestaurantRoutes(api, protected, db, emailService)
+		setupRestaurantRoutes(api, protected, c)
 
 		// Setup platform routes (KAM management)
-		setupPlatformRoutes(protected, db, authService)
+		setupPlatformRoutes(protected, c)
 
-		// Setup image routes (S3)
-		setupImageRoutes(protected, cfg)
+		// Setup image routes (S3, MinIO, or local storage)
+		setupImageRoutes(api, protected, c)
 
 		// Setup user management routes
-		setupUserRoutes(protected, db)
+		setupUserRoutes(protected, c)
+
+		// Setup invite-based user onboarding routes
+		setupInvitationRoutes(api, protected, c)
+
+		// Setup login session listing and revocation routes
+		setupSessionRoutes(protected, c)
+
+		// Setup per-user permission override routes
+		setupPermissionRoutes(protected, c)
+
+		// Setup subscription plan routes (plans, upgrade/downgrade)
+		setupSubscriptionRoutes(protected, c)
 
 		// Setup profile management routes
-		setupProfileRoutes(protected, db, cfg)
+		setupProfileRoutes(protected, c)
 
 		// Setup dashboard routes
-		setupDashboardRoutes(protected, db)
+		setupDashboardRoutes(protected, c)
+
+		// Setup in-app notification routes and the live WebSocket channel
+		setupNotificationRoutes(protected, c)
+
+		// Setup SMS delivery log routes (includes the public provider status callback)
+		setupSMSRoutes(api, protected, c)
+
+		// Setup Web Push subscription routes (customer order updates)
+		setupPushRoutes(protected, c)
+
+		// Setup customer notification preference routes (includes the public unsubscribe link)
+		setupNotificationPreferenceRoutes(api, protected, c)
+
+		// Setup staff SSO login and identity provider configuration routes
+		setupSSORoutes(api, protected, c)
+
+		// Setup data export and erasure (GDPR) routes
+		setupPrivacyRoutes(protected, c)
+
+		// Setup end-of-day (Z) report routes
+		setupCloseoutRoutes(protected, c)
+
+		// Setup time tracking routes
+		setupTimeEntryRoutes(protected, c)
+
+		// Setup floor plan routes
+		setupFloorPlanRoutes(protected, c)
+
+		// Setup inventory and purchasing routes
+		setupInventoryRoutes(protected, c)
+
+		// Setup private event booking routes
+		setupPrivateEventRoutes(protected, c)
+
+		// Setup organization routes (multi-location management)
+		setupOrganizationRoutes(protected, c)
+
+		// Setup order status board routes (TV mode, includes public token access)
+		setupDisplayRoutes(api, protected, c)
+
+		// Setup API changelog routes (public changelog, KAM publishing)
+		setupChangelogRoutes(api, protected, c)
+
+		// Setup tenant integrity checker routes (KAM only)
+		setupIntegrityRoutes(protected, c)
+
+		// Setup background job status and trigger routes (KAM only)
+		setupSchedulerRoutes(protected, c)
+
+		// Setup JWT signing key listing and rotation routes (KAM only)
+		setupSigningKeyRoutes(protected, c)
+
+		// Setup KAM portfolio routes (KAM only)
+		setupKAMRoutes(protected, c)
+
+		// Setup cross-tenant platform analytics routes (KAM only)
+		setupPlatformAnalyticsRoutes(protected, c)
+
+		// Setup usage metering and invoice routes (KAM only)
+		setupBillingRoutes(protected, c)
+
+		// Setup tenant storage footprint and backup routes (KAM only, s3 backend)
+		setupStorageRoutes(protected, c)
+
+		// Setup delivery platform integration routes (Admin only)
+		setupDeliveryRoutes(protected, c)
+
+		// Setup external booking channel routes (includes the public partner API)
+		setupBookingChannelRoutes(api, protected, c)
+
+		// Setup printer routes (includes public printer bridge access)
+		setupPrinterRoutes(api, protected, c)
+
+		// Setup driver and delivery routes (includes public driver app access)
+		setupDriverRoutes(api, protected, c)
+
+		// Setup dine-in table session routes
+		setupTableSessionRoutes(protected, c)
+
+		// Setup GraphQL read API routes (menus, orders, reservations)
+		setupGraphQLRoutes(protected, c)
 	}
 
 	return r
 }
 
+// latencyBudget builds the per-route-group timeouts from config
+func latencyBudget(cfg *config.Config) middleware.LatencyBudget {
+	return middleware.LatencyBudget{
+		Default: cfg.RequestTimeout,
+		Routes: map[string]time.Duration{
+			"/api/v1/images/upload":                cfg.UploadRequestTimeout,
+			"/api/v1/profile/avatar":               cfg.UploadRequestTimeout,
+			"/api/v1/menu-item-images":             cfg.UploadRequestTimeout,
+			"/api/v1/dashboard":                    cfg.ReportRequestTimeout,
+			"/api/v1/kam/dashboard":                cfg.ReportRequestTimeout,
+			"/api/v1/organization/report":          cfg.ReportRequestTimeout,
+			"/api/v1/platform/search":              cfg.ReportRequestTimeout,
+			"/api/v1/platform/analytics":           cfg.ReportRequestTimeout,
+			"/api/v1/menu/clone":                   cfg.ReportRequestTimeout,
+			"/api/v1/platform/storage":             cfg.ReportRequestTimeout,
+			"/api/v1/orders/export":                cfg.ExportRequestTimeout,
+			"/api/v1/reservations/export":          cfg.ExportRequestTimeout,
+			"/api/v1/customers/export":             cfg.ExportRequestTimeout,
+			"/api/v1/public/display/:token/stream": 0, // Server-sent events stay open
+			"/api/v1/dashboard/stream":             0,
+			"/api/v1/notifications/ws":             0,                                         // The WebSocket stays open
+			"/api/v1/public/printers/:token/jobs":  services.PrintPollMaxWait + 5*time.Second, // Long polling
+		},
+		SlowThreshold: cfg.SlowRequestThreshold,
+	}
+}
+
+// skipProbeTracing keeps health probes and metric scrapes, which run every few seconds, out of the traces
+func skipProbeTracing(req *http.Request) bool {
+	return req.URL.Path != "/health" && req.URL.Path != "/ready" && req.URL.Path != "/metrics"
+}
+
 // corsMiddleware handles CORS
 func corsMiddleware(cfg *config.Config) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -103,7 +236,8 @@ func corsMiddleware(cfg *config.Config) gin.HandlerFunc {
 		}
 
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With, If-Match, X-Request-ID, traceparent, tracestate")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, X-Request-ID")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE, PATCH")
 
 		if c.Request.Method == "OPTIONS" {
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupSchedulerRoutes configures the background job routes (KAM only)
+func setupSchedulerRoutes(protected *gin.RouterGroup, c *container.Container) {
+	schedulerHandler := handlers.NewSchedulerHandler(c.Scheduler)
+
+	jobs := protected.Group("/platform/jobs")
+	jobs.Use(middleware.RequireRole("KAM"))
+	{
+		jobs.GET("", schedulerHandler.ListJobs)
+		jobs.GET("/:name/runs", schedulerHandler.ListRuns)
+		jobs.POST("/:name/trigger", schedulerHandler.TriggerJob)
+	}
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupSessionRoutes configures the login session routes
+func setupSessionRoutes(protected *gin.RouterGroup, c *container.Container) {
+	sessionHandler := handlers.NewSessionHandler(c.Session)
+
+	// Own sessions (any authenticated user)
+	sessions := protected.Group("/sessions")
+	{
+		sessions.GET("", sessionHandler.ListSessions)
+		sessions.DELETE("", sessionHandler.RevokeOtherSessions)
+		sessions.DELETE("/:session_id", sessionHandler.RevokeSession)
+	}
+
+	// Sessions of the restaurant's users (Admin only)
+	userSessions := protected.Group("/users/:id/sessions")
+	userSessions.Use(middleware.RequireRole("Admin"))
+	{
+		userSessions.GET("", sessionHandler.ListUserSessions)
+		userSessions.DELETE("/:session_id", sessionHandler.RevokeUserSession)
+	}
+}
@@ -0,0 +1,22 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupSigningKeyRoutes configures the JWT signing key routes (KAM only)
+func setupSigningKeyRoutes(protected *gin.RouterGroup, c *container.Container) {
+	signingKeyHandler := handlers.NewSigningKeyHandler(c.Auth)
+
+	keys := protected.Group("/platform/signing-keys")
+	keys.Use(middleware.RequireRole("KAM"))
+	keys.Use(middleware.DenyImpersonation())
+	{
+		keys.GET("", signingKeyHandler.ListSigningKeys)
+		keys.POST("/rotate", signingKeyHandler.RotateSigningKey)
+	}
+}
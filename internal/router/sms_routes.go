@@ -0,0 +1,20 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupSMSRoutes configures the SMS delivery log and provider status callback routes
+func setupSMSRoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	smsHandler := handlers.NewSMSHandler(c.SMS)
+
+	// Provider status callbacks (public, signature-checked)
+	api.POST("/public/sms/twilio/status", smsHandler.TwilioStatusCallback)
+
+	// Delivery log (Admin only)
+	protected.GET("/sms-messages", middleware.RequireRole("Admin"), smsHandler.ListMessages)
+}
@@ -0,0 +1,31 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupSSORoutes configures the staff SSO login (public) and SSO configuration (restaurant admin) routes
+func setupSSORoutes(api *gin.RouterGroup, protected *gin.RouterGroup, c *container.Container) {
+	ssoHandler := handlers.NewSSOHandler(c.SSO, c.Config.FrontendURL, c.Config.Environment == "production")
+
+	// OpenID Connect login flow (public)
+	sso := api.Group("/auth/sso")
+	{
+		sso.GET("/:restaurant_id/login", ssoHandler.StartLogin)
+		sso.GET("/callback", ssoHandler.Callback)
+	}
+
+	// Identity provider configuration (Admin only; a KAM impersonating the admin can't change how staff log in)
+	config := protected.Group("/sso-config")
+	config.Use(middleware.RequireRole("Admin"))
+	config.Use(middleware.DenyImpersonation())
+	{
+		config.GET("", ssoHandler.GetConfig)
+		config.PUT("", ssoHandler.UpdateConfig)
+		config.DELETE("", ssoHandler.DeleteConfig)
+	}
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupStorageRoutes configures tenant storage footprint and backup routes (KAM only)
+// Backups are managed through bucket configuration, so they are only available on the s3 backend
+func setupStorageRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Backups are only available on the S3 backend
+	if c.StorageBackup == nil {
+		return
+	}
+	backupHandler := handlers.NewStorageBackupHandler(c.StorageBackup)
+
+	storageGroup := protected.Group("/platform/storage")
+	storageGroup.Use(middleware.RequireRole("KAM"))
+	{
+		storageGroup.GET("", backupHandler.GetStorageReport)
+		storageGroup.POST("/sync", backupHandler.SyncBackups)
+		storageGroup.GET("/restaurants/:id", backupHandler.GetTenantStorage)
+		storageGroup.PUT("/restaurants/:id/backup-policy", backupHandler.SetBackupPolicy)
+	}
+}
@@ -0,0 +1,23 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupSubscriptionRoutes configures subscription plan routes
+func setupSubscriptionRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	subscriptionHandler := handlers.NewSubscriptionHandler(c.Subscription)
+
+	// Subscription routes (plan changes are Admin only)
+	subscription := protected.Group("/subscription")
+	{
+		subscription.GET("", subscriptionHandler.GetSubscription)
+		subscription.GET("/plans", subscriptionHandler.ListPlans)
+		subscription.PUT("", middleware.RequireRole("Admin"), subscriptionHandler.ChangePlan)
+	}
+}
@@ -0,0 +1,27 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupTableSessionRoutes configures dine-in table session (open check) routes
+func setupTableSessionRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	sessionHandler := handlers.NewTableSessionHandler(c.TableSession)
+
+	// Table sessions (Admin/Staff)
+	sessions := protected.Group("/table-sessions")
+	sessions.Use(middleware.RequireRole("Admin", "Staff"))
+	{
+		sessions.POST("", sessionHandler.OpenSession)
+		sessions.GET("", sessionHandler.ListSessions)
+		sessions.GET("/:id", sessionHandler.GetSession)
+		sessions.PUT("/:id", sessionHandler.UpdateSession)
+		sessions.POST("/:id/rounds", sessionHandler.AddRound)
+		sessions.POST("/:id/close", sessionHandler.CloseSession)
+	}
+}
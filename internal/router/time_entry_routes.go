@@ -0,0 +1,34 @@
+package router
+
+import (
+	"restaurant-backend/internal/container"
+	"restaurant-backend/internal/handlers"
+	"restaurant-backend/internal/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupTimeEntryRoutes configures time tracking and staff rate routes
+func setupTimeEntryRoutes(protected *gin.RouterGroup, c *container.Container) {
+	// Initialize handler
+	timeEntryHandler := handlers.NewTimeEntryHandler(c.TimeEntry)
+
+	// Staff clock themselves in and out; corrections and wages are for admins
+	timeEntries := protected.Group("/time-entries")
+	{
+		timeEntries.POST("/clock-in", middleware.RequireRole("Admin", "Staff"), timeEntryHandler.ClockIn)
+		timeEntries.POST("/clock-out", middleware.RequireRole("Admin", "Staff"), timeEntryHandler.ClockOut)
+		timeEntries.GET("/current", middleware.RequireRole("Admin", "Staff"), timeEntryHandler.GetCurrentEntry)
+		timeEntries.GET("", middleware.RequireRole("Admin", "Staff"), timeEntryHandler.ListEntries)
+		timeEntries.POST("", middleware.RequireRole("Admin"), timeEntryHandler.CreateEntry)
+		timeEntries.PUT("/:id", middleware.RequireRole("Admin"), timeEntryHandler.UpdateEntry)
+		timeEntries.DELETE("/:id", middleware.RequireRole("Admin"), timeEntryHandler.DeleteEntry)
+	}
+
+	staffRates := protected.Group("/staff-rates")
+	staffRates.Use(middleware.RequireRole("Admin"))
+	{
+		staffRates.GET("", timeEntryHandler.ListRates)
+		staffRates.PUT("/:user_id", timeEntryHandler.SetRate)
+	}
+}
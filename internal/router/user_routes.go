@@ -1,33 +1,25 @@
 package router
 
 import (
+	"restaurant-backend/internal/container"
 	"restaurant-backend/internal/handlers"
-	"restaurant-backend/internal/repositories"
-	"restaurant-backend/internal/services"
+	"restaurant-backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
 // setupUserRoutes configures user management routes
-func setupUserRoutes(protected *gin.RouterGroup, db *gorm.DB) {
-	// Initialize repository
-	userRepo := repositories.NewUserRepository(db)
-
-	// Initialize service
-	userService := services.NewUserService(userRepo)
-
+func setupUserRoutes(protected *gin.RouterGroup, c *container.Container) {
 	// Initialize handler
-	userHandler := handlers.NewUserHandler(userService)
+	userHandler := handlers.NewUserHandler(c.User)
 
-	// User routes (Admin/Staff access)
+	// User routes (Admin/Staff access; only Admins change users)
 	users := protected.Group("/users")
 	{
 		users.GET("", userHandler.ListUsers)
 		users.GET("/:id", userHandler.GetUser)
-		users.POST("", userHandler.CreateUser)
-		users.PUT("/:id", userHandler.UpdateUser)
-		users.DELETE("/:id", userHandler.DeleteUser)
-		users.PATCH("/:id/status", userHandler.ToggleUserStatus)
+		users.PUT("/:id", middleware.RequireRole("Admin"), userHandler.UpdateUser)
+		users.DELETE("/:id", middleware.RequireRole("Admin"), userHandler.DeleteUser)
+		users.PATCH("/:id/status", middleware.RequireRole("Admin"), userHandler.ToggleUserStatus)
 	}
 }
@@ -0,0 +1,88 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/logger"
+
+	"go.uber.org/zap"
+)
+
+// Secret names, the same as the environment variables they replace
+const (
+	DBPassword           = "DB_PASSWORD"
+	JWTSecret            = "JWT_SECRET"
+	BrevoAPIKey          = "BREVO_API_KEY"
+	AWSAccessKeyID       = "AWS_ACCESS_KEY_ID"
+	AWSSecretAccessKey   = "AWS_SECRET_ACCESS_KEY"
+	StorageSigningSecret = "STORAGE_SIGNING_SECRET"
+)
+
+// Secret providers
+const (
+	ProviderEnv   = "env" // Environment variables only (default)
+	ProviderVault = "vault"
+	ProviderSSM   = "ssm"
+)
+
+// ErrNotFound is returned when the provider holds no value for a secret; its environment value is kept
+var ErrNotFound = errors.New("secret not found")
+
+// Provider reads secrets from an external secret store
+type Provider interface {
+	// Name identifies the store in logs
+	Name() string
+	// Get returns the current value of a secret, ErrNotFound if the store doesn't hold it
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// NewProvider creates the configured secret provider; nil when secrets come from the environment
+func NewProvider(ctx context.Context, cfg *config.Config) (Provider, error) {
+	switch cfg.SecretsProvider {
+	case "", ProviderEnv:
+		return nil, nil
+	case ProviderVault:
+		return newVaultProvider(cfg)
+	case ProviderSSM:
+		return newSSMProvider(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unknown SECRETS_PROVIDER %q (want env, vault or ssm)", cfg.SecretsProvider)
+	}
+}
+
+// Load replaces the configuration's secrets with the values from the configured provider and checks the
+// required ones are set. The provider is returned so secrets can be refreshed later; nil when config.Load
+// already validated the environment values
+func Load(ctx context.Context, cfg *config.Config) (Provider, error) {
+	provider, err := NewProvider(ctx, cfg)
+	if err != nil || provider == nil {
+		return nil, err
+	}
+
+	for name, field := range map[string]*string{
+		DBPassword:           &cfg.DBPassword,
+		JWTSecret:            &cfg.JWTSecret,
+		BrevoAPIKey:          &cfg.BrevoAPIKey,
+		AWSAccessKeyID:       &cfg.AWSAccessKeyID,
+		AWSSecretAccessKey:   &cfg.AWSSecretAccessKey,
+		StorageSigningSecret: &cfg.StorageSigningSecret,
+	} {
+		value, err := provider.Get(ctx, name)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from %s: %w", name, provider.Name(), err)
+		}
+		*field = value
+	}
+	logger.Info("Loaded secrets", zap.String("provider", provider.Name()))
+
+	if err := cfg.ValidateSecrets(); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"restaurant-backend/internal/config"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// ssmProvider reads secrets from AWS SSM Parameter Store, one SecureString parameter per secret name
+// It authenticates with the default AWS credential chain (instance or task role): the AWS keys
+// it may itself provide are not known yet
+type ssmProvider struct {
+	client *ssm.Client
+	prefix string // Prepended to secret names, e.g. "/restaurant-backend/"
+}
+
+// newSSMProvider creates a Parameter Store provider for the SSM_PARAMETER_PREFIX
+func newSSMProvider(ctx context.Context, cfg *config.Config) (*ssmProvider, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(cfg.AWSRegion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	prefix := cfg.SSMParameterPrefix
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &ssmProvider{
+		client: ssm.NewFromConfig(awsCfg),
+		prefix: prefix,
+	}, nil
+}
+
+// Name identifies Parameter Store in logs
+func (p *ssmProvider) Name() string {
+	return ProviderSSM
+}
+
+// Get reads and decrypts a parameter
+func (p *ssmProvider) Get(ctx context.Context, name string) (string, error) {
+	out, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(p.prefix + name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		var notFound *types.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return "", ErrNotFound
+		}
+		return "", err
+	}
+	value := aws.ToString(out.Parameter.Value)
+	if value == "" {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
@@ -0,0 +1,77 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/tracing"
+)
+
+// vaultProvider reads secrets from one HashiCorp Vault KV version 2 secret, one key per secret name
+type vaultProvider struct {
+	addr   string
+	token  string
+	path   string // API path of the secret, e.g. "secret/data/restaurant-backend"
+	client *http.Client
+}
+
+// newVaultProvider creates a Vault provider from VAULT_ADDR, VAULT_TOKEN and VAULT_SECRET_PATH
+func newVaultProvider(cfg *config.Config) (*vaultProvider, error) {
+	if cfg.VaultAddr == "" || cfg.VaultToken == "" || cfg.VaultSecretPath == "" {
+		return nil, fmt.Errorf("VAULT_ADDR, VAULT_TOKEN and VAULT_SECRET_PATH are required for the vault secrets provider")
+	}
+	return &vaultProvider{
+		addr:   strings.TrimRight(cfg.VaultAddr, "/"),
+		token:  cfg.VaultToken,
+		path:   strings.Trim(cfg.VaultSecretPath, "/"),
+		client: &http.Client{Timeout: 10 * time.Second, Transport: tracing.Transport(nil)},
+	}, nil
+}
+
+// Name identifies Vault in logs
+func (p *vaultProvider) Name() string {
+	return ProviderVault
+}
+
+// Get reads the latest version of the secret and returns one of its keys
+func (p *vaultProvider) Get(ctx context.Context, name string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.addr+"/v1/"+p.path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	value, ok := body.Data.Data[name].(string)
+	if !ok || value == "" {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
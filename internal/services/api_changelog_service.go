@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// deprecationCacheTTL bounds how long deprecation lookups are served from memory,
+// since the deprecation headers are checked on every request
+const deprecationCacheTTL = time.Minute
+
+// APIChangelogService manages the public API changelog and endpoint deprecations
+type APIChangelogService struct {
+	changelogRepo *repositories.APIChangelogRepository
+
+	mu           sync.RWMutex
+	deprecations map[string]models.APIChangelogEntry // keyed by "METHOD path"
+	loadedAt     time.Time
+}
+
+// NewAPIChangelogService creates a new APIChangelogService instance
+func NewAPIChangelogService(changelogRepo *repositories.APIChangelogRepository) *APIChangelogService {
+	return &APIChangelogService{
+		changelogRepo: changelogRepo,
+	}
+}
+
+// CreateChangelogEntryRequest represents changelog entry creation request
+type CreateChangelogEntryRequest struct {
+	Version     string     `json:"version" binding:"required,max=20"`
+	ChangeType  string     `json:"change_type" binding:"required,oneof=added changed deprecated removed"`
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description"`
+	Method      string     `json:"method" binding:"omitempty,oneof=GET POST PUT PATCH DELETE"`
+	Path        string     `json:"path"`
+	Replacement string     `json:"replacement"`
+	SunsetAt    *time.Time `json:"sunset_at"`
+	PublishedAt *time.Time `json:"published_at"` // Defaults to now; future dates schedule the entry
+}
+
+// APIChangelog is the machine-readable changelog returned to integrators
+type APIChangelog struct {
+	Entries      []models.APIChangelogEntry `json:"entries"`
+	Deprecations []models.APIChangelogEntry `json:"deprecations"`
+}
+
+// CreateEntry publishes a new changelog entry
+func (s *APIChangelogService) CreateEntry(ctx context.Context, req *CreateChangelogEntryRequest) (*models.APIChangelogEntry, error) {
+	if req.ChangeType == models.APIChangeDeprecated && (req.Method == "" || req.Path == "") {
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "method and path are required for deprecations")
+	}
+	if req.Path != "" && !strings.HasPrefix(req.Path, "/") {
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "path must start with /")
+	}
+
+	entry := &models.APIChangelogEntry{
+		Version:     strings.TrimSpace(req.Version),
+		ChangeType:  req.ChangeType,
+		Title:       strings.TrimSpace(req.Title),
+		Description: req.Description,
+		Method:      req.Method,
+		Path:        req.Path,
+		Replacement: req.Replacement,
+		SunsetAt:    req.SunsetAt,
+		PublishedAt: time.Now(),
+	}
+	if req.PublishedAt != nil {
+		entry.PublishedAt = *req.PublishedAt
+	}
+
+	if err := s.changelogRepo.CreateWithContext(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	s.invalidate()
+	return entry, nil
+}
+
+// DeleteEntry removes a changelog entry
+func (s *APIChangelogService) DeleteEntry(ctx context.Context, id uint) error {
+	if _, err := s.changelogRepo.GetByIDWithContext(ctx, id); err != nil {
+		return apperrors.NotFound(apperrors.CodeNotFound, "changelog entry not found")
+	}
+
+	if err := s.changelogRepo.DeleteWithContext(ctx, id); err != nil {
+		return err
+	}
+
+	s.invalidate()
+	return nil
+}
+
+// GetChangelog returns published entries (optionally only those after since) and active deprecations
+func (s *APIChangelogService) GetChangelog(ctx context.Context, since time.Time) (*APIChangelog, error) {
+	entries, err := s.changelogRepo.ListPublishedWithContext(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	deprecations, err := s.changelogRepo.ListDeprecationsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &APIChangelog{
+		Entries:      entries,
+		Deprecations: deprecations,
+	}, nil
+}
+
+// LookupDeprecation returns the deprecation entry for a route, if any
+// path is the route pattern (e.g. /api/v1/orders/:id), not the concrete request path
+func (s *APIChangelogService) LookupDeprecation(ctx context.Context, method, path string) (*models.APIChangelogEntry, bool) {
+	s.mu.RLock()
+	fresh := s.deprecations != nil && time.Since(s.loadedAt) < deprecationCacheTTL
+	s.mu.RUnlock()
+
+	if !fresh {
+		s.refresh(ctx)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.deprecations[method+" "+path]
+	if !ok {
+		return nil, false
+	}
+	return &entry, true
+}
+
+// refresh reloads deprecations from the database; on failure the previous set is kept
+func (s *APIChangelogService) refresh(ctx context.Context) {
+	entries, err := s.changelogRepo.ListDeprecationsWithContext(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Retry after the TTL either way, so a failing database isn't queried on every request
+	s.loadedAt = time.Now()
+	if err != nil {
+		logger.Warn("Failed to load API deprecations", zap.Error(err))
+		if s.deprecations == nil {
+			s.deprecations = map[string]models.APIChangelogEntry{}
+		}
+		return
+	}
+
+	deprecations := make(map[string]models.APIChangelogEntry, len(entries))
+	for _, entry := range entries {
+		deprecations[strings.ToUpper(entry.Method)+" "+entry.Path] = entry
+	}
+	s.deprecations = deprecations
+}
+
+// invalidate forces the next lookup to reload deprecations
+func (s *APIChangelogService) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+// DeprecationHeaders returns the response headers flagging a deprecated endpoint
+// (Deprecation per RFC 9745, Sunset per RFC 8594, and a Link to the changelog)
+func DeprecationHeaders(entry *models.APIChangelogEntry, changelogURL string) map[string]string {
+	headers := map[string]string{
+		"Deprecation": "@" + strconv.FormatInt(entry.PublishedAt.Unix(), 10),
+		"Link":        "<" + changelogURL + ">; rel=\"deprecation\"; type=\"application/json\"",
+	}
+	if entry.SunsetAt != nil {
+		headers["Sunset"] = entry.SunsetAt.UTC().Format(http.TimeFormat)
+	}
+	return headers
+}
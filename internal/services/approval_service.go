@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	tenantctx "restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/dto"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// pendingChangeLabels describe the pending change types in notifications
+var pendingChangeLabels = map[string]string{
+	models.PendingChangeTypePriceChange:          "Price change of menu item",
+	models.PendingChangeTypeScheduledPriceChange: "Scheduled price change of menu item",
+	models.PendingChangeTypeMenuItemDeletion:     "Deletion of menu item",
+	models.PendingChangeTypeCategoryDeletion:     "Deletion of category",
+	models.PendingChangeTypeOrderCancellation:    "Cancellation of order",
+}
+
+// ApprovalService queues sensitive changes made by Staff users for an Admin's approval, as far as the
+// restaurant requires it (require_approval_* settings), and makes them once approved
+type ApprovalService struct {
+	pendingRepo   *repositories.PendingChangeRepository
+	menuItemRepo  *repositories.MenuItemRepository
+	categoryRepo  *repositories.CategoryRepository
+	orderRepo     *repositories.OrderRepository
+	settings      *RestaurantSettingsService
+	notifications *NotificationService
+	menuItems     *MenuItemService
+	categories    *CategoryService
+	menuPrices    *MenuPriceService
+	orders        *OrderService
+}
+
+// NewApprovalService creates a new ApprovalService instance
+func NewApprovalService(
+	pendingRepo *repositories.PendingChangeRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	categoryRepo *repositories.CategoryRepository,
+	orderRepo *repositories.OrderRepository,
+	settings *RestaurantSettingsService,
+	notifications *NotificationService,
+	menuItems *MenuItemService,
+	categories *CategoryService,
+	menuPrices *MenuPriceService,
+	orders *OrderService,
+) *ApprovalService {
+	return &ApprovalService{
+		pendingRepo:   pendingRepo,
+		menuItemRepo:  menuItemRepo,
+		categoryRepo:  categoryRepo,
+		orderRepo:     orderRepo,
+		settings:      settings,
+		notifications: notifications,
+		menuItems:     menuItems,
+		categories:    categories,
+		menuPrices:    menuPrices,
+		orders:        orders,
+	}
+}
+
+// ReviewPendingChangeRequest approves or rejects a pending change
+type ReviewPendingChangeRequest struct {
+	Note string `json:"note" binding:"max=255"`
+}
+
+// PendingChangeList is a page of a restaurant's pending changes
+type PendingChangeList struct {
+	Changes []models.PendingChange `json:"changes"`
+	Total   int64                  `json:"total"`
+	Limit   int                    `json:"limit"`
+	Offset  int                    `json:"offset"`
+}
+
+// Submit queues a change of the current user for approval if they are Staff and the restaurant requires approval
+// for its type, and notifies the Admins. It returns nil when the change can be made right away
+// payload is the change's request, made as is when approved
+func (s *ApprovalService) Submit(ctx context.Context, restaurantID uint, changeType string, entityID uint, payload interface{}) (*models.PendingChange, error) {
+	if role, _ := tenantctx.GetUserRole(ctx); role != "Staff" {
+		return nil, nil
+	}
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if !requiresApproval(settings, changeType) {
+		return nil, nil
+	}
+
+	userID, ok := tenantctx.GetUserID(ctx)
+	if !ok {
+		return nil, apperrors.ErrUserContextMissing
+	}
+	// Changes that can't be made are rejected now rather than queued
+	if err := s.checkEntity(ctx, restaurantID, changeType, entityID); err != nil {
+		return nil, err
+	}
+
+	change := &models.PendingChange{
+		RestaurantID: restaurantID,
+		Type:         changeType,
+		EntityID:     entityID,
+		Status:       models.PendingChangeStatusPending,
+		RequestedBy:  userID,
+	}
+	if payload != nil {
+		if change.Payload, err = json.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to encode pending change: %w", err)
+		}
+	}
+	if err := s.pendingRepo.CreateWithContext(ctx, change); err != nil {
+		return nil, err
+	}
+
+	s.notifications.NotifyAdmins(ctx, &models.Notification{
+		RestaurantID: restaurantID,
+		Type:         models.NotificationTypeApprovalRequested,
+		Title:        fmt.Sprintf("%s %d waits for approval", pendingChangeLabels[changeType], entityID),
+		Data:         map[string]interface{}{"pending_change_id": change.ID, "type": changeType, "entity_id": entityID},
+	})
+
+	return change, nil
+}
+
+// requiresApproval reports whether the restaurant requires approval for Staff changes of a type
+func requiresApproval(settings *models.RestaurantSettings, changeType string) bool {
+	switch changeType {
+	case models.PendingChangeTypePriceChange, models.PendingChangeTypeScheduledPriceChange:
+		return settings.RequireApprovalPriceChanges
+	case models.PendingChangeTypeMenuItemDeletion, models.PendingChangeTypeCategoryDeletion:
+		return settings.RequireApprovalDeletions
+	case models.PendingChangeTypeOrderCancellation:
+		return settings.RequireApprovalCancellations
+	}
+	return false
+}
+
+// checkEntity verifies that the record a change is about exists and, for cancellations, can be cancelled
+func (s *ApprovalService) checkEntity(ctx context.Context, restaurantID uint, changeType string, entityID uint) error {
+	switch changeType {
+	case models.PendingChangeTypeCategoryDeletion:
+		if _, err := s.categoryRepo.GetByIDForRestaurant(ctx, entityID, restaurantID); err != nil {
+			return apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+		}
+	case models.PendingChangeTypeOrderCancellation:
+		order, err := s.orderRepo.GetByIDForRestaurant(ctx, entityID, restaurantID)
+		if err != nil {
+			return apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+		}
+		if !canTransitionOrder(order.Status, models.OrderStatusCancelled) {
+			return invalidOrderTransition(order.Status, models.OrderStatusCancelled)
+		}
+	default:
+		if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, entityID, restaurantID); err != nil {
+			return apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+		}
+	}
+	return nil
+}
+
+// ListPendingChanges lists a restaurant's pending changes; Staff users only see their own
+func (s *ApprovalService) ListPendingChanges(ctx context.Context, restaurantID uint, status string, limit, offset int) (*PendingChangeList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	filter := repositories.PendingChangeFilter{Status: status}
+	if role, _ := tenantctx.GetUserRole(ctx); role != "Admin" {
+		userID, ok := tenantctx.GetUserID(ctx)
+		if !ok {
+			return nil, apperrors.ErrUserContextMissing
+		}
+		filter.RequestedBy = userID
+	}
+
+	changes, total, err := s.pendingRepo.ListWithContext(ctx, restaurantID, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		changes = []models.PendingChange{}
+	}
+
+	return &PendingChangeList{
+		Changes: changes,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
+// Approve approves a pending change and makes it. If it can't be made (e.g. the menu item was deleted in the
+// meantime) the error is returned and the change stays pending, to be rejected
+func (s *ApprovalService) Approve(ctx context.Context, restaurantID, reviewerID, id uint, req *ReviewPendingChangeRequest) (*models.PendingChange, error) {
+	change, err := s.pendingRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodePendingChangeNotFound, "pending change not found")
+	}
+
+	// Claim the change first, so it is made once however often it is approved
+	now := time.Now()
+	note := strings.TrimSpace(req.Note)
+	if err := s.pendingRepo.ResolveWithContext(ctx, id, restaurantID, models.PendingChangeStatusApproved, reviewerID, note, now); err != nil {
+		return nil, alreadyReviewed(err)
+	}
+
+	if err := s.apply(ctx, restaurantID, change); err != nil {
+		if reopenErr := s.pendingRepo.ReopenWithContext(ctx, id, restaurantID); reopenErr != nil {
+			return nil, errors.Join(err, reopenErr)
+		}
+		return nil, err
+	}
+
+	change.Status = models.PendingChangeStatusApproved
+	change.ReviewedBy = &reviewerID
+	change.ReviewedAt = &now
+	change.ReviewNote = note
+	s.notifyResolved(ctx, change)
+	return change, nil
+}
+
+// Reject rejects a pending change without making it
+func (s *ApprovalService) Reject(ctx context.Context, restaurantID, reviewerID, id uint, req *ReviewPendingChangeRequest) (*models.PendingChange, error) {
+	change, err := s.pendingRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodePendingChangeNotFound, "pending change not found")
+	}
+
+	now := time.Now()
+	note := strings.TrimSpace(req.Note)
+	if err := s.pendingRepo.ResolveWithContext(ctx, id, restaurantID, models.PendingChangeStatusRejected, reviewerID, note, now); err != nil {
+		return nil, alreadyReviewed(err)
+	}
+
+	change.Status = models.PendingChangeStatusRejected
+	change.ReviewedBy = &reviewerID
+	change.ReviewedAt = &now
+	change.ReviewNote = note
+	s.notifyResolved(ctx, change)
+	return change, nil
+}
+
+// alreadyReviewed maps a failed review of a change that is no longer pending to its API error
+func alreadyReviewed(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperrors.Conflict(apperrors.CodeInvalidTransition, "the change was already reviewed")
+	}
+	return err
+}
+
+// apply makes an approved change through the service that makes it when no approval is needed
+func (s *ApprovalService) apply(ctx context.Context, restaurantID uint, change *models.PendingChange) error {
+	switch change.Type {
+	case models.PendingChangeTypePriceChange:
+		var req dto.UpdateMenuItemRequest
+		if err := json.Unmarshal(change.Payload, &req); err != nil {
+			return fmt.Errorf("failed to decode pending change %d: %w", change.ID, err)
+		}
+		_, err := s.menuItems.UpdateMenuItem(ctx, change.EntityID, &req, restaurantID, 0)
+		return err
+
+	case models.PendingChangeTypeScheduledPriceChange:
+		var req SchedulePriceRequest
+		if err := json.Unmarshal(change.Payload, &req); err != nil {
+			return fmt.Errorf("failed to decode pending change %d: %w", change.ID, err)
+		}
+		_, err := s.menuPrices.SchedulePriceChange(ctx, restaurantID, change.RequestedBy, change.EntityID, &req)
+		return err
+
+	case models.PendingChangeTypeMenuItemDeletion:
+		return s.menuItems.DeleteMenuItem(ctx, change.EntityID, restaurantID)
+
+	case models.PendingChangeTypeCategoryDeletion:
+		var req dto.DeleteCategoryRequest
+		if err := json.Unmarshal(change.Payload, &req); err != nil {
+			return fmt.Errorf("failed to decode pending change %d: %w", change.ID, err)
+		}
+		_, err := s.categories.DeleteCategory(ctx, change.EntityID, &req, restaurantID)
+		return err
+
+	case models.PendingChangeTypeOrderCancellation:
+		_, err := s.orders.UpdateOrderStatusWithCtx(ctx, change.EntityID, restaurantID, change.RequestedBy, &UpdateOrderStatusRequest{
+			Status: models.OrderStatusCancelled,
+		})
+		return err
+	}
+	return fmt.Errorf("unknown pending change type %q", change.Type)
+}
+
+// notifyResolved tells the requester that their change was approved or rejected
+func (s *ApprovalService) notifyResolved(ctx context.Context, change *models.PendingChange) {
+	s.notifications.NotifyUser(ctx, &models.Notification{
+		RestaurantID: change.RestaurantID,
+		UserID:       change.RequestedBy,
+		Type:         models.NotificationTypeApprovalResolved,
+		Title:        fmt.Sprintf("%s %d was %s", pendingChangeLabels[change.Type], change.EntityID, change.Status),
+		Body:         change.ReviewNote,
+		Data:         map[string]interface{}{"pending_change_id": change.ID, "type": change.Type, "entity_id": change.EntityID},
+	})
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/pii"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// Archive list date ranges
+const (
+	archiveDefaultDays = 365
+	archiveMaxDays     = 366
+)
+
+// ArchiveService moves old orders and reservations out of the hot tables, keeping their lists and indexes small,
+// and reads the archive
+type ArchiveService struct {
+	archiveRepo *repositories.ArchiveRepository
+	settings    *RestaurantSettingsService
+}
+
+// NewArchiveService creates a new ArchiveService instance
+func NewArchiveService(archiveRepo *repositories.ArchiveRepository, settings *RestaurantSettingsService) *ArchiveService {
+	return &ArchiveService{
+		archiveRepo: archiveRepo,
+		settings:    settings,
+	}
+}
+
+// ArchivedOrderList is a page of a restaurant's archived orders
+type ArchivedOrderList struct {
+	Orders []models.ArchivedOrder `json:"orders"`
+	Total  int64                  `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+}
+
+// ArchivedReservationList is a page of a restaurant's archived reservations
+type ArchivedReservationList struct {
+	Reservations []models.ArchivedReservation `json:"reservations"`
+	Total        int64                        `json:"total"`
+	Limit        int                          `json:"limit"`
+	Offset       int                          `json:"offset"`
+}
+
+// ArchiveJob is the scheduled job that archives every restaurant's orders and reservations older than its
+// archive_after_days setting, or defaultDays when that is 0; a zero interval disables it
+func (s *ArchiveService) ArchiveJob(interval time.Duration, defaultDays int) Job {
+	return Job{
+		Name:     "data_archive",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			return s.archive(ctx, defaultDays)
+		},
+	}
+}
+
+// archive archives the orders and reservations of every restaurant with a retention
+func (s *ArchiveService) archive(ctx context.Context, defaultDays int) error {
+	retentions, err := s.archiveRepo.ListRetentionsWithContext(ctx, defaultDays)
+	if err != nil {
+		return fmt.Errorf("failed to list restaurant retentions: %w", err)
+	}
+
+	var orders, reservations int64
+	var errs []error
+	for _, retention := range retentions {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		cutoff := time.Now().AddDate(0, 0, -retention.Days)
+
+		archived, err := s.archiveRepo.ArchiveOrdersWithContext(ctx, retention.RestaurantID, cutoff)
+		orders += archived
+		if err != nil {
+			errs = append(errs, fmt.Errorf("restaurant %d orders: %w", retention.RestaurantID, err))
+		}
+
+		archived, err = s.archiveRepo.ArchiveReservationsWithContext(ctx, retention.RestaurantID, cutoff)
+		reservations += archived
+		if err != nil {
+			errs = append(errs, fmt.Errorf("restaurant %d reservations: %w", retention.RestaurantID, err))
+		}
+	}
+
+	if orders > 0 || reservations > 0 {
+		logger.Info("Archived old orders and reservations",
+			zap.Int64("orders", orders),
+			zap.Int64("reservations", reservations),
+		)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to archive: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// ListOrders lists a restaurant's archived orders created between two dates (inclusive, in the restaurant's
+// time zone), newest first. Defaults to the last year
+func (s *ArchiveService) ListOrders(ctx context.Context, restaurantID uint, from, to string, limit, offset int) (*ArchivedOrderList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := dateRangeIn(settingsLocation(settings), from, to, archiveDefaultDays, archiveMaxDays)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, total, err := s.archiveRepo.ListOrdersWithContext(ctx, restaurantID, start, end, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if orders == nil {
+		orders = []models.ArchivedOrder{}
+	}
+	return &ArchivedOrderList{Orders: orders, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// ListReservations lists a restaurant's archived reservations starting between two dates (inclusive, in the
+// restaurant's time zone), latest first. Defaults to the last year
+func (s *ArchiveService) ListReservations(ctx context.Context, restaurantID uint, from, to string, limit, offset int) (*ArchivedReservationList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := dateRangeIn(settingsLocation(settings), from, to, archiveDefaultDays, archiveMaxDays)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations, total, err := s.archiveRepo.ListReservationsWithContext(ctx, restaurantID, start, end, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if reservations == nil {
+		reservations = []models.ArchivedReservation{}
+	}
+	for i := range reservations {
+		data, err := decryptSnapshotFields(reservations[i].Data, "guest_email", "guest_phone")
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt archived reservation %d: %w", reservations[i].ID, err)
+		}
+		reservations[i].Data = data
+	}
+	return &ArchivedReservationList{Reservations: reservations, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// decryptSnapshotFields decrypts the encrypted string fields of an archived row's snapshot, which is copied
+// from the table as stored and so skips the PII serializer
+func decryptSnapshotFields(data json.RawMessage, fields ...string) (json.RawMessage, error) {
+	var snapshot map[string]json.RawMessage
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+
+	decrypted := false
+	for _, field := range fields {
+		var value string
+		if raw, ok := snapshot[field]; !ok || json.Unmarshal(raw, &value) != nil || value == "" {
+			continue
+		}
+		plaintext, err := pii.Decrypt(value)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(plaintext)
+		if err != nil {
+			return nil, err
+		}
+		snapshot[field] = encoded
+		decrypted = true
+	}
+
+	if !decrypted {
+		return data, nil
+	}
+	return json.Marshal(snapshot)
+}
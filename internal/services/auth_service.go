@@ -5,28 +5,38 @@ import (
 	"errors"
 	"time"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/config"
+	tenantctx "restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/logger"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
 
 	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 // AuthService handles authentication operations
 type AuthService struct {
-	db       *gorm.DB
-	config   *config.Config
-	userRepo *repositories.UserRepository
+	db             *gorm.DB
+	config         *config.Config
+	userRepo       *repositories.UserRepository
+	signingKeyRepo *repositories.JWTSigningKeyRepository
+	sessions       *SessionService
+	keys           *signingKeys
 }
 
 // NewAuthService creates a new AuthService instance
-func NewAuthService(db *gorm.DB, cfg *config.Config, userRepo *repositories.UserRepository) *AuthService {
+func NewAuthService(db *gorm.DB, cfg *config.Config, userRepo *repositories.UserRepository, signingKeyRepo *repositories.JWTSigningKeyRepository, sessions *SessionService) *AuthService {
 	return &AuthService{
-		db:       db,
-		config:   cfg,
-		userRepo: userRepo,
+		db:             db,
+		config:         cfg,
+		userRepo:       userRepo,
+		signingKeyRepo: signingKeyRepo,
+		sessions:       sessions,
+		keys:           newSigningKeys(cfg.JWTSecret),
 	}
 }
 
@@ -36,9 +46,20 @@ type JWTClaims struct {
 	RestaurantID uint   `json:"restaurant_id"` // Always present (KAMs belong to Platform Organization)
 	Email        string `json:"email"`
 	Role         string `json:"role"`
+	// OrganizationID is set for org-scoped users (multi-location access)
+	OrganizationID uint `json:"organization_id,omitempty"`
+	// Impersonation is set when a KAM acts as the restaurant's admin
+	Impersonation *ImpersonationClaim `json:"impersonation,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// ImpersonationClaim identifies the KAM behind an impersonation token
+type ImpersonationClaim struct {
+	KAMUserID  uint   `json:"kam_user_id"`
+	KAMEmail   string `json:"kam_email"`
+	AuditLogID uint   `json:"audit_log_id"` // Audit entry recording why the session was started
+}
+
 // LoginRequest represents login request payload
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
@@ -51,28 +72,52 @@ type LoginResponse struct {
 	User  *models.User `json:"user"`
 }
 
-// Login authenticates a user and returns a JWT token
-func (s *AuthService) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+// Login authenticates a user and returns a JWT token for a new session on the client's device
+func (s *AuthService) Login(ctx context.Context, req *LoginRequest, client ClientInfo) (*LoginResponse, error) {
 	// Use repository to load user (preloads Restaurant)
 	user, err := s.userRepo.GetByEmailGlobalWithContext(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("invalid credentials")
+			return nil, apperrors.Unauthorized(apperrors.CodeInvalidCredentials, "invalid credentials")
 		}
 		return nil, err
 	}
 
 	// Verify password
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		return nil, errors.New("invalid credentials")
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidCredentials, "invalid credentials")
 	}
 
-	// Generate JWT token
-	token, err := s.generateToken(user)
+	// Self-registered accounts must prove they own the address first
+	if user.EmailVerificationPending {
+		return nil, apperrors.Forbidden(apperrors.CodeEmailNotVerified, "verify your email address before logging in; check your inbox or request a new link")
+	}
+
+	return s.CompleteLogin(ctx, user, client)
+}
+
+// CompleteLogin starts a session for an authenticated user, issues its token and records the sign-in
+func (s *AuthService) CompleteLogin(ctx context.Context, user *models.User, client ClientInfo) (*LoginResponse, error) {
+	session, err := s.sessions.Start(ctx, user.ID, client, s.tokenExpiry())
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.generateTokenForRestaurant(user, user.RestaurantID, session.SessionID, session.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
 
+	// Login recency feeds the restaurant health score; a failed update must not block the login
+	now := time.Now()
+	if err := s.userRepo.UpdateLastLoginWithContext(ctx, user.ID, now); err != nil {
+		logger.Warn("Failed to record last login",
+			zap.Uint("user_id", user.ID),
+			zap.Error(err),
+		)
+	} else {
+		user.LastLoginAt = &now
+	}
+
 	// Clear password hash from response
 	user.PasswordHash = ""
 
@@ -93,26 +138,26 @@ type RegisterRequest struct {
 	RestaurantID uint   `json:"restaurant_id" binding:"required"`
 }
 
-// Register creates a new user account (for restaurant users only)
+// Register creates a new user account (for restaurant users only) pending email verification
 // KAM users cannot be created via this endpoint - use CreateKAM endpoint
 func (s *AuthService) Register(ctx context.Context, req *RegisterRequest) (*models.User, error) {
 	// KAM role is not allowed in regular registration
 	if req.Role == "KAM" {
-		return nil, errors.New("KAM users cannot be created via this endpoint. Use the KAM creation endpoint instead")
+		return nil, apperrors.Forbidden(apperrors.CodeInvalidRole, "KAM users cannot be created via this endpoint. Use the KAM creation endpoint instead")
 	}
 	// Verify restaurant exists and is active
 	var restaurant models.Restaurant
 	if err := s.db.WithContext(ctx).First(&restaurant, req.RestaurantID).Error; err != nil {
-		return nil, errors.New("restaurant not found")
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
 	}
 
 	if restaurant.Status != models.RestaurantStatusActive {
-		return nil, errors.New("restaurant is not active")
+		return nil, apperrors.Forbidden(apperrors.CodeRestaurantInactive, "restaurant is not active")
 	}
 
 	// Check if user already exists (use repository)
 	if existing, _ := s.userRepo.GetByEmailWithContext(ctx, req.Email, req.RestaurantID); existing != nil {
-		return nil, errors.New("user with this email already exists in this restaurant")
+		return nil, apperrors.Conflict(apperrors.CodeEmailTaken, "user with this email already exists in this restaurant")
 	}
 
 	// Hash password
@@ -130,6 +175,8 @@ func (s *AuthService) Register(ctx context.Context, req *RegisterRequest) (*mode
 		LastName:     req.LastName,
 		Role:         req.Role,
 		IsActive:     true,
+		// Login is refused until the emailed verification link is followed
+		EmailVerificationPending: true,
 	}
 
 	if err := s.userRepo.CreateWithContext(ctx, user); err != nil {
@@ -142,24 +189,84 @@ func (s *AuthService) Register(ctx context.Context, req *RegisterRequest) (*mode
 	return user, nil
 }
 
-// generateToken generates a JWT token for a user
-func (s *AuthService) generateToken(user *models.User) (string, error) {
-	expirationTime := time.Now().Add(time.Duration(s.config.JWTExpiration) * time.Hour)
+// GenerateLocationToken issues a token that scopes an org-scoped user to one of the organization's locations
+// The token belongs to the session of the request, which lives on until it expires
+// The caller must verify the location belongs to the user's organization
+func (s *AuthService) GenerateLocationToken(ctx context.Context, user *models.User, restaurantID uint) (string, error) {
+	if !user.IsOrganizationUser() {
+		return "", apperrors.Forbidden(apperrors.CodeInsufficientScope, "user is not scoped to an organization")
+	}
+
+	expiresAt := s.tokenExpiry()
+	sessionID, _ := tenantctx.GetSessionID(ctx)
+	if sessionID != "" {
+		if err := s.sessions.Extend(ctx, sessionID, expiresAt); err != nil {
+			return "", err
+		}
+	}
+	return s.generateTokenForRestaurant(user, restaurantID, sessionID, expiresAt)
+}
+
+// GenerateImpersonationToken issues a short-lived token letting a KAM act as the admin of a restaurant
+// It belongs to the KAM's own session of the request, so revoking that session ends the impersonation too
+// The caller must verify the KAM and record the impersonation in the audit log
+func (s *AuthService) GenerateImpersonationToken(ctx context.Context, kam *models.User, restaurantID uint, auditLogID uint, ttl time.Duration) (string, time.Time, error) {
+	if !kam.IsPlatformUser() || !kam.IsKAM() {
+		return "", time.Time{}, apperrors.Forbidden(apperrors.CodeInsufficientScope, "only platform KAMs can impersonate restaurants")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims := &JWTClaims{
+		UserID:       kam.ID,
+		RestaurantID: restaurantID,
+		Email:        kam.Email,
+		Role:         "Admin",
+		Impersonation: &ImpersonationClaim{
+			KAMUserID:  kam.ID,
+			KAMEmail:   kam.Email,
+			AuditLogID: auditLogID,
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Subject:   kam.Email,
+		},
+	}
+	claims.ID, _ = tenantctx.GetSessionID(ctx)
+
+	tokenString, err := s.keys.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
+
+// tokenExpiry is the expiry of a user token issued now
+func (s *AuthService) tokenExpiry() time.Time {
+	return time.Now().Add(time.Duration(s.config.JWTExpiration) * time.Hour)
+}
 
+// generateTokenForRestaurant generates a JWT token of a session for a user acting within the given restaurant
+func (s *AuthService) generateTokenForRestaurant(user *models.User, restaurantID uint, sessionID string, expiresAt time.Time) (string, error) {
 	claims := &JWTClaims{
 		UserID:       user.ID,
-		RestaurantID: user.RestaurantID, // Always present
+		RestaurantID: restaurantID, // Always present
 		Email:        user.Email,
 		Role:         user.Role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ID:        sessionID,
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Subject:   user.Email,
 		},
 	}
+	if user.OrganizationID != nil {
+		claims.OrganizationID = *user.OrganizationID
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.config.JWTSecret))
+	tokenString, err := s.keys.sign(claims)
 	if err != nil {
 		return "", err
 	}
@@ -167,24 +274,38 @@ func (s *AuthService) generateToken(user *models.User) (string, error) {
 	return tokenString, nil
 }
 
+// ValidateSession rejects the tokens of revoked or expired sessions and records the session's use
+// Tokens issued before sessions were tracked carry no session and stay valid until they expire
+func (s *AuthService) ValidateSession(ctx context.Context, claims *JWTClaims, ip string) error {
+	if claims.ID == "" {
+		return nil
+	}
+	return s.sessions.Check(ctx, claims.ID, ip)
+}
+
 // ValidateToken validates a JWT token and returns the claims
 func (s *AuthService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	claims := &JWTClaims{}
-
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
+			return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid signing method")
 		}
-		return []byte(s.config.JWTSecret), nil
-	})
+		return s.keys.verificationKey(token)
+	}
 
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyFunc)
+	if err != nil && s.reloadForUnknownKey(err) {
+		// Signed with a key rotated on another instance since this one last loaded them
+		claims = &JWTClaims{}
+		token, err = jwt.ParseWithClaims(tokenString, claims, keyFunc)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	if !token.Valid {
-		return nil, errors.New("invalid token")
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid token")
 	}
 
 	return claims, nil
@@ -0,0 +1,404 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/pdf"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// billingPeriodLayout is the format of billing periods in requests and exports
+const billingPeriodLayout = "2006-01"
+
+// bytesPerGB is the storage billing unit
+const bytesPerGB = 1_000_000_000
+
+// BillingPrices are the usage prices
+type BillingPrices struct {
+	Currency   string
+	OrderFee   money.Cents // Per processed order
+	EmailFee   money.Cents // Per 1000 emails sent
+	StorageFee money.Cents // Per GB of object storage
+}
+
+// NewBillingPrices reads the usage prices from config
+func NewBillingPrices(cfg *config.Config) BillingPrices {
+	return BillingPrices{
+		Currency:   cfg.BillingCurrency,
+		OrderFee:   money.Cents(cfg.BillingOrderFeeCents),
+		EmailFee:   money.Cents(cfg.BillingEmailFeeCents),
+		StorageFee: money.Cents(cfg.BillingStorageFeeCents),
+	}
+}
+
+// storageMeter measures the object storage footprint of every tenant (S3-compatible backends)
+type storageMeter interface {
+	StorageUsage(ctx context.Context, prefix string) (map[uint]*TenantStorageUsage, error)
+}
+
+// BillingService aggregates per-tenant usage into monthly invoices for the platform
+type BillingService struct {
+	usageRepo        *repositories.UsageRepository
+	invoiceRepo      *repositories.InvoiceRepository
+	restaurantRepo   *repositories.RestaurantRepository
+	subscriptionRepo *repositories.SubscriptionRepository
+	orderRepo        *repositories.OrderRepository
+	storage          storageMeter // nil when the storage backend can't be metered; storage is then not billed
+	prices           BillingPrices
+}
+
+// NewBillingService creates a new BillingService instance
+func NewBillingService(
+	usageRepo *repositories.UsageRepository,
+	invoiceRepo *repositories.InvoiceRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	subscriptionRepo *repositories.SubscriptionRepository,
+	orderRepo *repositories.OrderRepository,
+	storage Storage,
+	prices BillingPrices,
+) *BillingService {
+	meter, _ := storage.(storageMeter)
+
+	return &BillingService{
+		usageRepo:        usageRepo,
+		invoiceRepo:      invoiceRepo,
+		restaurantRepo:   restaurantRepo,
+		subscriptionRepo: subscriptionRepo,
+		orderRepo:        orderRepo,
+		storage:          meter,
+		prices:           prices,
+	}
+}
+
+// GenerateInvoicesRequest represents a request to generate draft invoices for a month
+type GenerateInvoicesRequest struct {
+	Period     string `json:"period" binding:"required"` // YYYY-MM
+	Regenerate bool   `json:"regenerate"`                // Rebuild existing drafts from current usage
+}
+
+// InvoiceRunResult summarizes an invoice generation run
+type InvoiceRunResult struct {
+	Period    string `json:"period"`
+	Generated int    `json:"generated"`
+	Skipped   int    `json:"skipped"` // Issued invoices, and existing drafts unless regenerating
+}
+
+// ParseBillingPeriod parses a YYYY-MM billing period into the first day of the month (UTC)
+func ParseBillingPeriod(period string) (time.Time, error) {
+	start, err := time.Parse(billingPeriodLayout, period)
+	if err != nil {
+		return time.Time{}, apperrors.BadRequest(apperrors.CodeInvalidPeriod, "period must be formatted as YYYY-MM")
+	}
+	return start, nil
+}
+
+// GenerateInvoices meters a month's usage and creates a draft invoice for every restaurant
+// The current month can be generated as a preview; future months are rejected
+func (s *BillingService) GenerateInvoices(ctx context.Context, periodStart time.Time, regenerate bool) (*InvoiceRunResult, error) {
+	if periodStart.After(repositories.UsagePeriodStart(time.Now())) {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidPeriod, "cannot invoice a future period")
+	}
+	periodEnd := periodStart.AddDate(0, 1, 0)
+
+	if err := s.meterUsage(ctx, periodStart, periodEnd); err != nil {
+		return nil, err
+	}
+
+	restaurants, err := s.restaurantRepo.ListWithContext(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list restaurants: %w", err)
+	}
+	usage, err := s.usageByRestaurant(ctx, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := s.plansByRestaurant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &InvoiceRunResult{Period: periodStart.Format(billingPeriodLayout)}
+	for _, restaurant := range restaurants {
+		// Restaurants created after the period have nothing to bill
+		if models.IsPlatformOrganization(restaurant.ID) || !restaurant.CreatedAt.Before(periodEnd) {
+			continue
+		}
+
+		invoice, err := s.invoiceRepo.GetByRestaurantPeriodWithContext(ctx, restaurant.ID, periodStart)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			invoice = &models.Invoice{RestaurantID: restaurant.ID, PeriodStart: periodStart}
+		case err != nil:
+			return nil, fmt.Errorf("failed to get invoice: %w", err)
+		case invoice.Status != models.InvoiceStatusDraft || !regenerate:
+			result.Skipped++
+			continue
+		}
+
+		plan, ok := plans[restaurant.ID]
+		if !ok {
+			plan, _ = models.GetPlan(models.DefaultPlan)
+		}
+		s.buildInvoice(invoice, periodEnd, plan, usage[restaurant.ID])
+		if err := s.invoiceRepo.SaveDraftWithContext(ctx, invoice); err != nil {
+			return nil, fmt.Errorf("failed to save invoice for restaurant %d: %w", restaurant.ID, err)
+		}
+		result.Generated++
+	}
+
+	return result, nil
+}
+
+// ListUsage lists the metered usage of every restaurant in a period
+func (s *BillingService) ListUsage(ctx context.Context, periodStart time.Time) ([]models.UsageRecord, error) {
+	return s.usageRepo.ListByPeriodWithContext(ctx, periodStart)
+}
+
+// ListInvoices lists invoices matching the filter
+func (s *BillingService) ListInvoices(ctx context.Context, filter repositories.InvoiceFilter) ([]models.Invoice, error) {
+	return s.invoiceRepo.ListWithContext(ctx, filter)
+}
+
+// GetInvoice returns an invoice with its lines
+func (s *BillingService) GetInvoice(ctx context.Context, id uint) (*models.Invoice, error) {
+	invoice, err := s.invoiceRepo.GetByIDWithContext(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeInvoiceNotFound, "invoice not found")
+		}
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// IssueInvoice finalizes a draft invoice so it is no longer regenerated
+func (s *BillingService) IssueInvoice(ctx context.Context, id uint) (*models.Invoice, error) {
+	invoice, err := s.GetInvoice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != models.InvoiceStatusDraft {
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "invoice is already "+string(invoice.Status))
+	}
+
+	if err := s.invoiceRepo.IssueWithContext(ctx, id, time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.Conflict(apperrors.CodeConflict, "invoice was issued by another request")
+		}
+		return nil, err
+	}
+
+	return s.GetInvoice(ctx, id)
+}
+
+// WriteInvoicesCSV writes invoices as CSV, one row per invoice line
+func (s *BillingService) WriteInvoicesCSV(w io.Writer, invoices []models.Invoice) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{
+		"invoice_id", "restaurant_id", "restaurant_name", "period", "status", "plan", "currency",
+		"description", "metric", "quantity", "unit", "unit_price", "amount", "invoice_total",
+	}); err != nil {
+		return err
+	}
+
+	for _, invoice := range invoices {
+		for _, line := range invoice.Lines {
+			if err := writer.Write([]string{
+				strconv.FormatUint(uint64(invoice.ID), 10),
+				strconv.FormatUint(uint64(invoice.RestaurantID), 10),
+				restaurantName(&invoice),
+				invoice.PeriodStart.Format(billingPeriodLayout),
+				string(invoice.Status),
+				string(invoice.Plan),
+				invoice.Currency,
+				line.Description,
+				line.Metric,
+				strconv.FormatInt(line.Quantity, 10),
+				strconv.FormatInt(line.Unit, 10),
+				line.UnitPrice.String(),
+				line.Amount.String(),
+				invoice.Total.String(),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// RenderInvoicePDF renders an invoice as a PDF document
+func (s *BillingService) RenderInvoicePDF(invoice *models.Invoice) []byte {
+	doc := pdf.New()
+	doc.Heading(fmt.Sprintf("Invoice #%d", invoice.ID))
+	doc.Space()
+	doc.Text("Restaurant: " + restaurantName(invoice))
+	doc.Text("Period:     " + invoice.PeriodStart.Format("January 2006"))
+	doc.Text("Plan:       " + string(invoice.Plan))
+	doc.Text("Status:     " + string(invoice.Status))
+	if invoice.IssuedAt != nil {
+		doc.Text("Issued:     " + invoice.IssuedAt.Format("2006-01-02"))
+	}
+	doc.Space()
+
+	// Description, quantity and amount columns sized to the page width
+	descWidth := pdf.Columns() - 30
+	row := func(description, quantity, amount string) string {
+		if len(description) > descWidth {
+			description = description[:descWidth]
+		}
+		return fmt.Sprintf("%-*s %14s %14s", descWidth, description, quantity, amount)
+	}
+	doc.Bold(row("Description", "Quantity", "Amount"))
+	for _, line := range invoice.Lines {
+		doc.Text(row(line.Description, strconv.FormatInt(line.Quantity, 10), line.Amount.String()))
+	}
+	doc.Space()
+	doc.Bold(row("Total ("+invoice.Currency+")", "", invoice.Total.String()))
+
+	return doc.Bytes()
+}
+
+// InvoiceJob is the scheduled job that generates last month's draft invoices; a zero interval disables it
+func (s *BillingService) InvoiceJob(interval time.Duration) Job {
+	return Job{Name: "invoice_generation", Interval: interval, Run: s.runInvoiceJob}
+}
+
+// runInvoiceJob generates the draft invoices of last month
+func (s *BillingService) runInvoiceJob(ctx context.Context) error {
+	lastMonth := repositories.UsagePeriodStart(time.Now()).AddDate(0, -1, 0)
+	result, err := s.GenerateInvoices(ctx, lastMonth, false)
+	if err != nil {
+		return fmt.Errorf("failed to generate invoices: %w", err)
+	}
+	if result.Generated > 0 {
+		logger.Info("Generated draft invoices",
+			zap.String("period", result.Period),
+			zap.Int("generated", result.Generated),
+		)
+	}
+	return nil
+}
+
+// meterUsage records the usage measured at invoice time: processed orders and storage footprint
+// Emails are metered as they are sent
+func (s *BillingService) meterUsage(ctx context.Context, periodStart, periodEnd time.Time) error {
+	orderCounts, err := s.orderRepo.CountProcessedByRestaurantWithContext(ctx, periodStart, periodEnd)
+	if err != nil {
+		return fmt.Errorf("failed to count orders: %w", err)
+	}
+	for _, count := range orderCounts {
+		if err := s.usageRepo.SetWithContext(ctx, &models.UsageRecord{
+			RestaurantID: count.RestaurantID,
+			PeriodStart:  periodStart,
+			Metric:       models.UsageMetricOrdersProcessed,
+			Quantity:     count.Count,
+		}); err != nil {
+			return fmt.Errorf("failed to record order usage: %w", err)
+		}
+	}
+
+	if s.storage == nil {
+		return nil
+	}
+	storage, err := s.storage.StorageUsage(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to measure storage: %w", err)
+	}
+	for restaurantID, tenant := range storage {
+		if err := s.usageRepo.SetWithContext(ctx, &models.UsageRecord{
+			RestaurantID: restaurantID,
+			PeriodStart:  periodStart,
+			Metric:       models.UsageMetricStorageBytes,
+			Quantity:     tenant.CurrentBytes + tenant.NoncurrentBytes,
+		}); err != nil {
+			return fmt.Errorf("failed to record storage usage: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildInvoice replaces an invoice's lines with the plan fee and usage charges
+func (s *BillingService) buildInvoice(invoice *models.Invoice, periodEnd time.Time, plan models.Plan, usage map[string]int64) {
+	invoice.PeriodEnd = periodEnd
+	invoice.Status = models.InvoiceStatusDraft
+	invoice.Plan = plan.Code
+	invoice.Currency = s.prices.Currency
+	invoice.Lines = []models.InvoiceLine{
+		{Description: plan.Name + " plan", Quantity: 1, Unit: 1, UnitPrice: plan.MonthlyPrice},
+		{Description: "Orders processed", Metric: models.UsageMetricOrdersProcessed, Unit: 1, UnitPrice: s.prices.OrderFee},
+		{Description: "Emails sent (billed per 1000)", Metric: models.UsageMetricEmailsSent, Unit: 1000, UnitPrice: s.prices.EmailFee},
+	}
+	if s.storage != nil {
+		invoice.Lines = append(invoice.Lines, models.InvoiceLine{
+			Description: "Storage in bytes (billed per GB)", Metric: models.UsageMetricStorageBytes, Unit: bytesPerGB, UnitPrice: s.prices.StorageFee,
+		})
+	}
+
+	invoice.Total = 0
+	for i := range invoice.Lines {
+		line := &invoice.Lines[i]
+		if line.Metric != "" {
+			line.Quantity = usage[line.Metric]
+		}
+		// Rounded to the nearest cent
+		line.Amount = money.Cents((line.Quantity*int64(line.UnitPrice) + line.Unit/2) / line.Unit)
+		invoice.Total += line.Amount
+	}
+}
+
+// usageByRestaurant loads a period's usage keyed by restaurant and metric
+func (s *BillingService) usageByRestaurant(ctx context.Context, periodStart time.Time) (map[uint]map[string]int64, error) {
+	records, err := s.usageRepo.ListByPeriodWithContext(ctx, periodStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage: %w", err)
+	}
+	usage := make(map[uint]map[string]int64)
+	for _, record := range records {
+		if usage[record.RestaurantID] == nil {
+			usage[record.RestaurantID] = make(map[string]int64)
+		}
+		usage[record.RestaurantID][record.Metric] = record.Quantity
+	}
+	return usage, nil
+}
+
+// plansByRestaurant returns the current plan of every subscribed restaurant
+// Restaurants missing from the map are on the default plan
+func (s *BillingService) plansByRestaurant(ctx context.Context) (map[uint]models.Plan, error) {
+	subscriptions, err := s.subscriptionRepo.ListWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	plans := make(map[uint]models.Plan, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if plan, ok := models.GetPlan(subscription.Plan); ok {
+			plans[subscription.RestaurantID] = plan
+		}
+	}
+	return plans, nil
+}
+
+// restaurantName returns the invoiced restaurant's name when it was loaded
+func restaurantName(invoice *models.Invoice) string {
+	if invoice.Restaurant == nil {
+		return ""
+	}
+	return invoice.Restaurant.Name
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/models"
+)
+
+// BookingChannelAdapter pushes reservation changes made at the restaurant back to an external booking channel
+// Availability and bookings flow the other way, from the channel through the partner API
+type BookingChannelAdapter interface {
+	Provider() string
+	CancelBooking(ctx context.Context, channel *models.BookingChannel, reservation *models.Reservation) error
+}
+
+// NewBookingChannelAdapters creates the adapters of every supported booking channel keyed by provider
+func NewBookingChannelAdapters(cfg *config.Config) map[string]BookingChannelAdapter {
+	client := newPublicHTTPClient(15 * time.Second)
+	adapters := []BookingChannelAdapter{
+		&googleReserveAdapter{baseURL: cfg.GoogleReserveAPIURL, partnerID: cfg.GoogleReservePartnerID, client: client},
+	}
+
+	byProvider := make(map[string]BookingChannelAdapter, len(adapters))
+	for _, adapter := range adapters {
+		byProvider[adapter.Provider()] = adapter
+	}
+	return byProvider
+}
+
+// googleReserveAdapter notifies Reserve with Google of booking changes through the Maps Booking notification API
+// Bookings are named after our partner ID, so the adapter needs it configured
+type googleReserveAdapter struct {
+	baseURL   string
+	partnerID string
+	client    *http.Client
+}
+
+type googleReserveBooking struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+func (a *googleReserveAdapter) Provider() string {
+	return models.BookingChannelGoogleReserve
+}
+
+func (a *googleReserveAdapter) CancelBooking(ctx context.Context, channel *models.BookingChannel, reservation *models.Reservation) error {
+	if a.partnerID == "" {
+		return errors.New("google reserve partner ID is not configured")
+	}
+	if reservation.ExternalID == nil {
+		return fmt.Errorf("reservation %d has no google reserve booking ID", reservation.ID)
+	}
+
+	name := fmt.Sprintf("partners/%s/bookings/%s", url.PathEscape(a.partnerID), url.PathEscape(*reservation.ExternalID))
+	endpoint := fmt.Sprintf("%s/v1alpha/notification/%s?updateMask=status", strings.TrimRight(a.baseURL, "/"), name)
+	return deliveryRequest(ctx, a.client, http.MethodPatch, endpoint, channel.AccessToken,
+		googleReserveBooking{Name: name, Status: "CANCELED"}, nil)
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/pii"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// BookingChannelService connects restaurants to external reservation channels: it issues the tokens channels
+// call the partner API with and pushes cancellations of their bookings back to them
+type BookingChannelService struct {
+	channelRepo *repositories.BookingChannelRepository
+	userRepo    *repositories.UserRepository
+	adapters    map[string]BookingChannelAdapter
+}
+
+// NewBookingChannelService creates a new BookingChannelService instance
+func NewBookingChannelService(
+	channelRepo *repositories.BookingChannelRepository,
+	userRepo *repositories.UserRepository,
+	adapters map[string]BookingChannelAdapter,
+) *BookingChannelService {
+	return &BookingChannelService{
+		channelRepo: channelRepo,
+		userRepo:    userRepo,
+		adapters:    adapters,
+	}
+}
+
+// CreateBookingChannelRequest represents a request to connect a booking channel
+type CreateBookingChannelRequest struct {
+	Provider           string `json:"provider" binding:"required,oneof=google_reserve"`
+	ExternalMerchantID string `json:"external_merchant_id" binding:"required,max=100"`
+	AccessToken        string `json:"access_token" binding:"required"`
+}
+
+// UpdateBookingChannelRequest represents a request to update a booking channel connection
+type UpdateBookingChannelRequest struct {
+	ExternalMerchantID *string `json:"external_merchant_id" binding:"omitempty,min=1,max=100"`
+	AccessToken        *string `json:"access_token" binding:"omitempty,min=1"`
+	Enabled            *bool   `json:"enabled"`
+}
+
+// BookingChannelCredentials is a booking channel with its partner API token, returned only when the token is (re)generated
+type BookingChannelCredentials struct {
+	Channel *models.BookingChannel `json:"channel"`
+	Token   string                 `json:"token"`
+}
+
+// ListChannels lists the booking channels a restaurant is connected to
+func (s *BookingChannelService) ListChannels(ctx context.Context, restaurantID uint) ([]models.BookingChannel, error) {
+	return s.channelRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// CreateChannel connects a restaurant to a booking channel and generates its partner API token
+// Channel bookings are attributed to an inactive Client account of the channel, created on first connect
+func (s *BookingChannelService) CreateChannel(ctx context.Context, req *CreateBookingChannelRequest, restaurantID uint) (*BookingChannelCredentials, error) {
+	if _, ok := s.adapters[req.Provider]; !ok {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidProvider, "unsupported booking channel")
+	}
+
+	channelUser, err := s.channelUser(ctx, req.Provider, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	token := randomToken()
+
+	channel := &models.BookingChannel{
+		RestaurantID:       restaurantID,
+		Provider:           req.Provider,
+		ExternalMerchantID: strings.TrimSpace(req.ExternalMerchantID),
+		TokenHash:          pii.HashToken(token),
+		AccessToken:        req.AccessToken,
+		Enabled:            true,
+		ChannelUserID:      channelUser.ID,
+	}
+	if err := s.channelRepo.CreateWithContext(ctx, channel); err != nil {
+		if errors.Is(err, repositories.ErrBookingChannelExists) {
+			return nil, apperrors.Conflict(apperrors.CodeIntegrationExists, err.Error())
+		}
+		return nil, err
+	}
+
+	return &BookingChannelCredentials{Channel: channel, Token: token}, nil
+}
+
+// UpdateChannel updates a booking channel connection
+func (s *BookingChannelService) UpdateChannel(ctx context.Context, id uint, req *UpdateBookingChannelRequest, restaurantID uint) (*models.BookingChannel, error) {
+	channel, err := s.getChannel(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ExternalMerchantID != nil {
+		channel.ExternalMerchantID = strings.TrimSpace(*req.ExternalMerchantID)
+	}
+	if req.AccessToken != nil {
+		channel.AccessToken = *req.AccessToken
+	}
+	if req.Enabled != nil {
+		channel.Enabled = *req.Enabled
+	}
+
+	if err := s.channelRepo.SaveWithContext(ctx, channel); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
+
+// RotateToken generates a new partner API token; the channel must be given the new token
+func (s *BookingChannelService) RotateToken(ctx context.Context, id uint, restaurantID uint) (*BookingChannelCredentials, error) {
+	channel, err := s.getChannel(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	token := randomToken()
+	channel.TokenHash = pii.HashToken(token)
+	if err := s.channelRepo.SaveWithContext(ctx, channel); err != nil {
+		return nil, err
+	}
+
+	return &BookingChannelCredentials{Channel: channel, Token: token}, nil
+}
+
+// DeleteChannel disconnects a booking channel; its reservations are kept
+func (s *BookingChannelService) DeleteChannel(ctx context.Context, id uint, restaurantID uint) error {
+	if err := s.channelRepo.DeleteWithContext(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeIntegrationNotFound, "booking channel not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// Authenticate retrieves the enabled booking channel owning a partner API token
+func (s *BookingChannelService) Authenticate(ctx context.Context, token string) (*models.BookingChannel, error) {
+	if token == "" {
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid booking channel token")
+	}
+
+	channel, err := s.channelRepo.GetByTokenHashWithContext(ctx, pii.HashToken(token))
+	if err != nil {
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid booking channel token")
+	}
+	if !channel.Enabled {
+		return nil, apperrors.Forbidden(apperrors.CodeChannelDisabled, "booking channel is disabled")
+	}
+	return channel, nil
+}
+
+// PushCancellation tells the channel a reservation was cancelled at the restaurant
+// Failures are recorded on the channel and logged; they don't undo the cancellation
+func (s *BookingChannelService) PushCancellation(ctx context.Context, reservation *models.Reservation) {
+	channel, err := s.channelRepo.GetByProviderWithContext(ctx, reservation.RestaurantID, reservation.Source)
+	if err != nil {
+		logger.Warn("No booking channel to push cancellation to",
+			zap.Uint("reservation_id", reservation.ID),
+			zap.String("source", reservation.Source),
+			zap.Error(err),
+		)
+		return
+	}
+	adapter, ok := s.adapters[channel.Provider]
+	if !ok {
+		return
+	}
+
+	if err := adapter.CancelBooking(ctx, channel, reservation); err != nil {
+		logger.Warn("Failed to push cancellation to booking channel",
+			zap.Uint("channel_id", channel.ID),
+			zap.Uint("reservation_id", reservation.ID),
+			zap.Error(err),
+		)
+		s.recordError(ctx, channel, err.Error())
+		return
+	}
+	if channel.LastError != "" {
+		s.recordError(ctx, channel, "")
+	}
+}
+
+// channelUser returns the restaurant's Client account for bookings from a provider, creating it if needed
+// The account cannot sign in: it is inactive and its password hash matches no password
+func (s *BookingChannelService) channelUser(ctx context.Context, provider string, restaurantID uint) (*models.User, error) {
+	email := fmt.Sprintf("%s.bookings+r%d@booking.invalid", strings.ReplaceAll(provider, "_", "-"), restaurantID)
+	user, err := s.userRepo.GetByEmailWithContext(ctx, email, restaurantID)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user = &models.User{
+		RestaurantID: restaurantID,
+		Email:        email,
+		PasswordHash: "!",
+		FirstName:    bookingChannelName(provider),
+		LastName:     "Bookings",
+		Role:         "Client",
+		IsActive:     false,
+	}
+	if err := s.userRepo.CreateWithContext(ctx, user); err != nil {
+		return nil, err
+	}
+	// IsActive defaults to true in the database, so a false value is not inserted
+	if err := s.userRepo.UpdateUserStatus(ctx, user.ID, false); err != nil {
+		return nil, err
+	}
+	user.IsActive = false
+	return user, nil
+}
+
+// bookingChannelName returns a provider's display name
+func bookingChannelName(provider string) string {
+	switch provider {
+	case models.BookingChannelGoogleReserve:
+		return "Google Reserve"
+	default:
+		return provider
+	}
+}
+
+// recordError stores the last push error on the channel so restaurant admins can see it
+func (s *BookingChannelService) recordError(ctx context.Context, channel *models.BookingChannel, message string) {
+	if len(message) > 500 {
+		message = message[:500]
+	}
+	channel.LastError = message
+	if err := s.channelRepo.UpdateLastErrorWithContext(ctx, channel.ID, message); err != nil {
+		logger.Warn("Failed to record booking channel error", zap.Uint("channel_id", channel.ID), zap.Error(err))
+	}
+}
+
+// getChannel retrieves a booking channel of the restaurant
+func (s *BookingChannelService) getChannel(ctx context.Context, id uint, restaurantID uint) (*models.BookingChannel, error) {
+	channel, err := s.channelRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeIntegrationNotFound, "booking channel not found")
+		}
+		return nil, err
+	}
+	return channel, nil
+}
@@ -0,0 +1,456 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// cartRecoveryBatch is the number of abandoned carts emailed per job run
+const cartRecoveryBatch = 200
+
+// CartService handles the server-side carts of the public ordering flow
+// Anonymous shoppers hold their cart's token; customers also find their cart on any device once logged in
+type CartService struct {
+	cartRepo       *repositories.CartRepository
+	menuItemRepo   *repositories.MenuItemRepository
+	restaurantRepo *repositories.RestaurantRepository
+	pricing        *PricingRuleService
+	mailer         Mailer
+	preferences    *NotificationPreferenceService
+	frontendURL    string
+	ttl            time.Duration
+}
+
+// NewCartService creates a new CartService instance
+func NewCartService(
+	cartRepo *repositories.CartRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	pricing *PricingRuleService,
+	mailer Mailer,
+	preferences *NotificationPreferenceService,
+	frontendURL string,
+	ttl time.Duration,
+) *CartService {
+	return &CartService{
+		cartRepo:       cartRepo,
+		menuItemRepo:   menuItemRepo,
+		restaurantRepo: restaurantRepo,
+		pricing:        pricing,
+		mailer:         mailer,
+		preferences:    preferences,
+		frontendURL:    strings.TrimRight(frontendURL, "/"),
+		ttl:            ttl,
+	}
+}
+
+// CartItemRequest is a line of a cart request
+type CartItemRequest struct {
+	MenuItemID uint   `json:"menu_item_id" binding:"required"`
+	Quantity   int    `json:"quantity" binding:"required,min=1,max=99"`
+	Notes      string `json:"notes" binding:"max=255"`
+}
+
+// CartRequest replaces the contents of a cart
+// Email is where an anonymous shopper wants to be reminded of the cart if they leave it
+type CartRequest struct {
+	Items           []CartItemRequest `json:"items" binding:"max=100,dive"`
+	Email           string            `json:"email" binding:"omitempty,email,max=255"`
+	FulfillmentType string            `json:"fulfillment_type" binding:"omitempty,oneof=dine_in pickup delivery"`
+}
+
+// ClaimCartRequest moves an anonymous cart to the logged-in customer
+type ClaimCartRequest struct {
+	Token string `json:"token" binding:"required,max=64"`
+}
+
+// CartLine is a cart item priced at the current menu prices
+type CartLine struct {
+	MenuItemID uint        `json:"menu_item_id"`
+	Name       string      `json:"name"`
+	Quantity   int         `json:"quantity"`
+	Notes      string      `json:"notes,omitempty"`
+	UnitPrice  money.Cents `json:"unit_price"`
+	Subtotal   money.Cents `json:"subtotal"`
+	Available  bool        `json:"available"` // Unavailable items stay in the cart but can't be ordered
+}
+
+// CartView is a cart with its priced lines
+type CartView struct {
+	*models.Cart
+	Lines    []CartLine  `json:"lines"`
+	Subtotal money.Cents `json:"subtotal"` // Available lines only
+}
+
+// CreateCart creates an anonymous cart of a publicly visible restaurant; its token is the shopper's key to it
+func (s *CartService) CreateCart(ctx context.Context, restaurantID uint, req *CartRequest) (*CartView, error) {
+	if err := s.ensurePublic(ctx, restaurantID); err != nil {
+		return nil, err
+	}
+	cart := &models.Cart{RestaurantID: restaurantID, Token: randomToken()}
+	if err := s.apply(ctx, cart, req); err != nil {
+		return nil, err
+	}
+	if err := s.cartRepo.CreateWithContext(ctx, cart); err != nil {
+		return nil, err
+	}
+	return s.view(ctx, cart)
+}
+
+// GetCart returns a cart by its token
+func (s *CartService) GetCart(ctx context.Context, restaurantID uint, token string) (*CartView, error) {
+	cart, err := s.byToken(ctx, restaurantID, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, cart)
+}
+
+// UpdateCart replaces the contents of a cart reached by its token
+func (s *CartService) UpdateCart(ctx context.Context, restaurantID uint, token string, req *CartRequest) (*CartView, error) {
+	cart, err := s.byToken(ctx, restaurantID, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, cart, req); err != nil {
+		return nil, err
+	}
+	if err := s.cartRepo.SaveWithContext(ctx, cart); err != nil {
+		return nil, err
+	}
+	return s.view(ctx, cart)
+}
+
+// DeleteCart empties a cart for good
+func (s *CartService) DeleteCart(ctx context.Context, restaurantID uint, token string) error {
+	cart, err := s.byToken(ctx, restaurantID, token)
+	if err != nil {
+		return err
+	}
+	return s.cartRepo.DeleteWithContext(ctx, cart.ID)
+}
+
+// GetCustomerCart returns the logged-in customer's cart
+func (s *CartService) GetCustomerCart(ctx context.Context, restaurantID, userID uint) (*CartView, error) {
+	cart, err := s.cartRepo.GetByUserWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeCartNotFound, "cart not found")
+		}
+		return nil, err
+	}
+	return s.view(ctx, cart)
+}
+
+// SaveCustomerCart replaces the contents of the logged-in customer's cart, creating it if needed
+func (s *CartService) SaveCustomerCart(ctx context.Context, restaurantID, userID uint, req *CartRequest) (*CartView, error) {
+	cart, err := s.cartRepo.GetByUserWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		cart = &models.Cart{RestaurantID: restaurantID, Token: randomToken(), UserID: &userID}
+	}
+	if err := s.apply(ctx, cart, req); err != nil {
+		return nil, err
+	}
+	if cart.ID == 0 {
+		err = s.cartRepo.CreateWithContext(ctx, cart)
+	} else {
+		err = s.cartRepo.SaveWithContext(ctx, cart)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.view(ctx, cart)
+}
+
+// ClaimCart moves an anonymous cart to the logged-in customer, e.g. after signing in during checkout
+// If the customer already has a cart, the anonymous cart's items are added to it
+func (s *CartService) ClaimCart(ctx context.Context, restaurantID, userID uint, token string) (*CartView, error) {
+	anonymous, err := s.byToken(ctx, restaurantID, token)
+	if err != nil {
+		return nil, err
+	}
+	if anonymous.UserID != nil && *anonymous.UserID != userID {
+		return nil, apperrors.NotFound(apperrors.CodeCartNotFound, "cart not found")
+	}
+
+	cart, err := s.cartRepo.GetByUserWithContext(ctx, restaurantID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if cart == nil || cart.ID == anonymous.ID {
+		anonymous.UserID = &userID
+		anonymous.ExpiresAt = time.Now().Add(s.ttl)
+		if err := s.cartRepo.SaveWithContext(ctx, anonymous); err != nil {
+			return nil, err
+		}
+		return s.view(ctx, anonymous)
+	}
+
+	cart.Items = mergeCartItems(cart.Items, anonymous.Items)
+	if cart.FulfillmentType == "" {
+		cart.FulfillmentType = anonymous.FulfillmentType
+	}
+	cart.ExpiresAt = time.Now().Add(s.ttl)
+	cart.RecoveryEmailAt = nil
+	if err := s.cartRepo.SaveWithContext(ctx, cart); err != nil {
+		return nil, err
+	}
+	if err := s.cartRepo.DeleteWithContext(ctx, anonymous.ID); err != nil {
+		return nil, err
+	}
+	return s.view(ctx, cart)
+}
+
+// MarkOrdered closes the cart an order was placed from: the one of the token, or else the customer's
+// A failure is logged and never fails the order
+func (s *CartService) MarkOrdered(ctx context.Context, restaurantID, userID uint, token string, orderID uint) {
+	var cart *models.Cart
+	var err error
+	if token != "" {
+		cart, err = s.cartRepo.GetByTokenWithContext(ctx, restaurantID, token)
+	} else {
+		cart, err = s.cartRepo.GetByUserWithContext(ctx, restaurantID, userID)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return
+	}
+	if err == nil {
+		err = s.cartRepo.MarkOrderedWithContext(ctx, cart.ID, orderID)
+	}
+	if err != nil {
+		logger.Warn("Failed to close ordered cart", zap.Uint("order_id", orderID), zap.Error(err))
+	}
+}
+
+// CartJob is the scheduled job that deletes expired carts and emails the shoppers of carts left unchanged
+// for recoveryDelay, at restaurants with recovery emails on; a zero interval disables it
+func (s *CartService) CartJob(interval, recoveryDelay time.Duration) Job {
+	return Job{
+		Name:     "cart_maintenance",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			deleted, err := s.cartRepo.DeleteExpiredWithContext(ctx, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to delete expired carts: %w", err)
+			}
+			if deleted > 0 {
+				logger.Info("Deleted expired carts", zap.Int64("deleted", deleted))
+			}
+
+			sent, err := s.sendRecoveryEmails(ctx, time.Now().Add(-recoveryDelay))
+			if err != nil {
+				return fmt.Errorf("failed to send cart recovery emails: %w", err)
+			}
+			if sent > 0 {
+				logger.Info("Sent cart recovery emails", zap.Int("sent", sent))
+			}
+			return nil
+		},
+	}
+}
+
+// sendRecoveryEmails emails a link to resume carts abandoned since idleSince, once per cart
+// Customers are only emailed if they accept marketing email; anonymous shoppers asked for the reminder by leaving their address
+func (s *CartService) sendRecoveryEmails(ctx context.Context, idleSince time.Time) (int, error) {
+	carts, err := s.cartRepo.GetAbandonedWithContext(ctx, idleSince, cartRecoveryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	restaurantNames := make(map[uint]string)
+	for i := range carts {
+		cart := &carts[i]
+
+		email, name, unsubscribeURL := cart.Email, "", ""
+		if cart.User != nil {
+			email, name = cart.User.Email, cart.User.FirstName
+			if !cart.User.IsActive ||
+				!s.preferences.Allows(ctx, cart.RestaurantID, cart.User.ID, models.NotificationEventMarketing, models.NotificationChannelEmail) {
+				email = ""
+			} else if s.preferences != nil {
+				unsubscribeURL = s.preferences.UnsubscribeURL(cart.RestaurantID, cart.User.ID)
+			}
+		}
+
+		// Carts without anyone to email are marked too, so they aren't looked at again
+		if email != "" {
+			restaurantName, ok := restaurantNames[cart.RestaurantID]
+			if !ok {
+				if restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, cart.RestaurantID); err == nil {
+					restaurantName = restaurant.Name
+				}
+				restaurantNames[cart.RestaurantID] = restaurantName
+			}
+
+			view, err := s.view(ctx, cart)
+			if err != nil {
+				return sent, err
+			}
+			items := make([]OrderItem, 0, len(view.Lines))
+			for _, line := range view.Lines {
+				if line.Available {
+					items = append(items, OrderItem{Name: line.Name, Quantity: line.Quantity, Price: line.UnitPrice, Subtotal: line.Subtotal, Notes: line.Notes})
+				}
+			}
+
+			resumeURL := fmt.Sprintf("%s/restaurants/%d/cart?token=%s", s.frontendURL, cart.RestaurantID, cart.Token)
+			if err := s.mailer.SendCartRecoveryEmail(ctx, cart.RestaurantID, email, name, restaurantName,
+				items, view.Subtotal, resumeURL, unsubscribeURL, cart.ExpiresAt); err != nil {
+				logger.Warn("Failed to send cart recovery email", zap.Uint("cart_id", cart.ID), zap.Error(err))
+				continue
+			}
+			sent++
+		}
+
+		if err := s.cartRepo.MarkRecoveryEmailSentWithContext(ctx, cart.ID, time.Now()); err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// byToken retrieves an active cart by its token
+func (s *CartService) byToken(ctx context.Context, restaurantID uint, token string) (*models.Cart, error) {
+	cart, err := s.cartRepo.GetByTokenWithContext(ctx, restaurantID, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeCartNotFound, "cart not found or expired")
+		}
+		return nil, err
+	}
+	return cart, nil
+}
+
+// ensurePublic reports a restaurant that isn't publicly visible as not found, like the public menu
+func (s *CartService) ensurePublic(ctx context.Context, restaurantID uint) error {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil || !restaurant.IsPubliclyVisible() {
+		return apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+	return nil
+}
+
+// apply validates a cart request against the restaurant's menu and copies it onto the cart, extending its expiry
+// A changed cart may be reminded of again once abandoned
+func (s *CartService) apply(ctx context.Context, cart *models.Cart, req *CartRequest) error {
+	ids := make([]uint, 0, len(req.Items))
+	for _, item := range req.Items {
+		ids = append(ids, item.MenuItemID)
+	}
+	known := make(map[uint]bool, len(ids))
+	if len(ids) > 0 {
+		menuItems, err := s.menuItemRepo.GetByIDsForRestaurantWithContext(ctx, cart.RestaurantID, ids)
+		if err != nil {
+			return err
+		}
+		for _, menuItem := range menuItems {
+			known[menuItem.ID] = true
+		}
+	}
+
+	items := make([]models.CartItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		if !known[item.MenuItemID] {
+			return apperrors.NotFound(apperrors.CodeMenuItemNotFound, fmt.Sprintf("menu item %d not found", item.MenuItemID))
+		}
+		items = append(items, models.CartItem{
+			MenuItemID: item.MenuItemID,
+			Quantity:   item.Quantity,
+			Notes:      strings.TrimSpace(item.Notes),
+		})
+	}
+
+	cart.Items = items
+	cart.FulfillmentType = req.FulfillmentType
+	if cart.UserID == nil {
+		cart.Email = strings.TrimSpace(req.Email)
+	}
+	cart.ExpiresAt = time.Now().Add(s.ttl)
+	cart.RecoveryEmailAt = nil
+	return nil
+}
+
+// view prices a cart's items at the current menu prices of its fulfillment channel
+func (s *CartService) view(ctx context.Context, cart *models.Cart) (*CartView, error) {
+	view := &CartView{Cart: cart, Lines: make([]CartLine, 0, len(cart.Items))}
+	if len(cart.Items) == 0 {
+		return view, nil
+	}
+
+	ids := make([]uint, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		ids = append(ids, item.MenuItemID)
+	}
+	menuItems, err := s.menuItemRepo.GetByIDsForRestaurantWithContext(ctx, cart.RestaurantID, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*models.MenuItem, len(menuItems))
+	for i := range menuItems {
+		byID[menuItems[i].ID] = &menuItems[i]
+	}
+
+	pricer := &MenuPricer{}
+	if s.pricing != nil {
+		if pricer, err = s.pricing.Pricer(ctx, cart.RestaurantID, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	channel := cart.FulfillmentType
+	if channel == "" {
+		channel = models.OrderChannelPickup
+	}
+
+	var subtotal money.Cents
+	for _, item := range cart.Items {
+		line := CartLine{MenuItemID: item.MenuItemID, Quantity: item.Quantity, Notes: item.Notes}
+		if menuItem, ok := byID[item.MenuItemID]; ok {
+			unitPrice, _ := pricer.Price(menuItem, channel)
+			line.Name = menuItem.Name
+			line.UnitPrice = unitPrice
+			line.Subtotal = unitPrice.Times(item.Quantity)
+			line.Available = menuItem.IsAvailable
+			if line.Available {
+				subtotal += unitPrice.Times(item.Quantity)
+			}
+		}
+		view.Lines = append(view.Lines, line)
+	}
+	view.Subtotal = subtotal
+	return view, nil
+}
+
+// mergeCartItems adds items to a cart's items, summing the quantities of the same item with the same notes
+func mergeCartItems(items, added []models.CartItem) []models.CartItem {
+	merged := append([]models.CartItem{}, items...)
+	for _, item := range added {
+		found := false
+		for i := range merged {
+			if merged[i].MenuItemID == item.MenuItemID && merged[i].Notes == item.Notes {
+				merged[i].Quantity += item.Quantity
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, item)
+		}
+	}
+	return merged
+}
@@ -2,38 +2,79 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
+	"time"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/dto"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
 )
 
 // CategoryService handles category business logic
 type CategoryService struct {
-	categoryRepo *repositories.CategoryRepository
+	categoryRepo   *repositories.CategoryRepository
+	menuItemRepo   *repositories.MenuItemRepository
+	webhookService *WebhookService
+	settings       *RestaurantSettingsService
 }
 
 // NewCategoryService creates a new CategoryService instance
-func NewCategoryService(categoryRepo *repositories.CategoryRepository) *CategoryService {
+func NewCategoryService(
+	categoryRepo *repositories.CategoryRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	webhookService *WebhookService,
+	settings *RestaurantSettingsService,
+) *CategoryService {
 	return &CategoryService{
-		categoryRepo: categoryRepo,
+		categoryRepo:   categoryRepo,
+		menuItemRepo:   menuItemRepo,
+		webhookService: webhookService,
+		settings:       settings,
 	}
 }
 
+// AuditActor identifies who performed an audited action
+type AuditActor struct {
+	UserID    uint
+	Role      string
+	IPAddress string
+	UserAgent string
+}
+
+// CategoryAvailabilityResult summarizes a bulk availability change
+type CategoryAvailabilityResult struct {
+	CategoryID   uint  `json:"category_id"`
+	IsAvailable  bool  `json:"is_available"`
+	UpdatedItems int64 `json:"updated_items"`
+}
+
+// CategoryDeleteResult describes what happened to a deleted category and its items
+type CategoryDeleteResult struct {
+	CategoryID    uint  `json:"category_id"`
+	Archived      bool  `json:"archived"` // The category was archived with its items instead of deleted
+	ArchivedItems int64 `json:"archived_items"`
+	MovedTo       *uint `json:"moved_to,omitempty"`
+	MovedItems    int64 `json:"moved_items"`
+}
+
 // CreateCategory creates a new category
 func (s *CategoryService) CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest, restaurantID uint) (*models.MenuCategory, error) {
 	// Trim name
 	name := strings.TrimSpace(req.Name)
 	if name == "" {
-		return nil, errors.New("name cannot be empty")
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "name cannot be empty")
 	}
 
 	// Check if name already exists for this restaurant
 	existing, _ := s.categoryRepo.GetByNameWithContext(ctx, name)
 	if existing != nil && existing.RestaurantID == restaurantID {
-		return nil, errors.New("category name already taken")
+		return nil, apperrors.Conflict(apperrors.CodeNameTaken, "category name already taken")
 	}
 
 	category := &models.MenuCategory{
@@ -43,35 +84,98 @@ func (s *CategoryService) CreateCategory(ctx context.Context, req *dto.CreateCat
 		DisplayOrder: req.DisplayOrder,
 		IsActive:     req.IsActive,
 	}
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MenuDraftsEnabled {
+		category.PublishStatus = models.MenuPublishStatusDraft
+	}
 
 	if err := s.categoryRepo.CreateWithContext(ctx, category); err != nil {
 		return nil, err
 	}
 
+	// A draft only reaches the menu with the next publish
+	if category.IsPublished() {
+		s.webhookService.NotifyMenuChange(ctx, restaurantID, MenuChange{
+			Entity:   MenuEntityCategory,
+			EntityID: category.ID,
+			Action:   MenuChangeCreated,
+		})
+	}
+
 	return category, nil
 }
 
 // UpdateCategory updates a category (only updates provided fields)
-func (s *CategoryService) UpdateCategory(ctx context.Context, id uint, req *dto.UpdateCategoryRequest, restaurantID uint) (*models.MenuCategory, error) {
-	// Verify category exists
-	category, err := s.categoryRepo.GetByIDWithContext(ctx, id)
+// expectedVersion is the client's If-Match version; 0 skips the precondition
+func (s *CategoryService) UpdateCategory(ctx context.Context, id uint, req *dto.UpdateCategoryRequest, restaurantID uint, expectedVersion int) (*models.MenuCategory, error) {
+	// Verify category exists and belongs to the requesting restaurant
+	// Other tenants' categories are reported as not found so their existence is not revealed
+	category, err := s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+	}
+
+	if err := checkVersion(expectedVersion, category.Version); err != nil {
+		return nil, err
+	}
+
+	updates, err := categoryUpdates(req)
 	if err != nil {
-		return nil, errors.New("category not found")
+		return nil, err
 	}
 
-	// Validate ownership - ensure category belongs to the requesting restaurant
-	// This is a defense-in-depth measure in addition to RLS
-	if category.RestaurantID != restaurantID {
-		return nil, errors.New("category not found") // Don't reveal existence of other tenants' data
+	// While the menu is edited as a draft, edits of a published category wait for the next publish
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MenuDraftsEnabled && category.IsPublished() && len(updates) > 0 {
+		draftChanges, err := mergeDraftChanges(category.DraftChanges, req)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.categoryRepo.UpdateWithContext(ctx, id, category.Version, map[string]interface{}{
+			"draft_changes": gorm.Expr("?::jsonb", string(draftChanges)),
+		}); err != nil {
+			return nil, versionConflict(err)
+		}
+		return s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID)
 	}
 
-	// Build update map with only provided (non-nil) fields
+	// Only update if there are fields to update
+	if len(updates) == 0 {
+		return category, nil // No changes
+	}
+
+	// Update the category
+	if err := s.categoryRepo.UpdateWithContext(ctx, id, category.Version, updates); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	if category.IsPublished() {
+		s.webhookService.NotifyMenuChange(ctx, restaurantID, MenuChange{
+			Entity:   MenuEntityCategory,
+			EntityID: id,
+			Action:   MenuChangeUpdated,
+			Fields:   changedFields(updates),
+		})
+	}
+
+	// Fetch and return updated category
+	return s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+}
+
+// categoryUpdates validates an update of a category and returns its column updates (only provided fields)
+func categoryUpdates(req *dto.UpdateCategoryRequest) (map[string]interface{}, error) {
 	updates := make(map[string]interface{})
 
 	if req.Name != nil {
 		trimmed := strings.TrimSpace(*req.Name)
 		if trimmed == "" {
-			return nil, errors.New("name cannot be empty")
+			return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "name cannot be empty")
 		}
 		updates["name"] = trimmed
 	}
@@ -88,16 +192,219 @@ func (s *CategoryService) UpdateCategory(ctx context.Context, id uint, req *dto.
 		updates["is_active"] = *req.IsActive
 	}
 
-	// Only update if there are fields to update
-	if len(updates) == 0 {
-		return category, nil // No changes
+	return updates, nil
+}
+
+// DeleteCategory deletes a category belonging to the restaurant. A category that still has menu items is only
+// deleted when req moves them to another category; with req.ArchiveItems it is archived together with its items
+// instead, so past orders keep their references
+func (s *CategoryService) DeleteCategory(ctx context.Context, id uint, req *dto.DeleteCategoryRequest, restaurantID uint) (*CategoryDeleteResult, error) {
+	if req.MoveItemsTo != nil && req.ArchiveItems {
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "move_items_to and archive_items cannot be combined")
 	}
 
-	// Update the category
-	if err := s.categoryRepo.UpdateWithContext(ctx, id, updates); err != nil {
+	category, err := s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+	}
+
+	result := &CategoryDeleteResult{CategoryID: id}
+
+	switch {
+	case req.ArchiveItems:
+		if _, err := s.ArchiveCategory(ctx, id, restaurantID); err != nil {
+			return nil, err
+		}
+		result.Archived = true
+		result.ArchivedItems = int64(len(category.MenuItems))
+		return result, nil
+
+	case req.MoveItemsTo != nil:
+		target, err := s.categoryRepo.GetByIDForRestaurant(ctx, *req.MoveItemsTo, restaurantID)
+		if err != nil {
+			return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "target category not found")
+		}
+		if target.ID == id {
+			return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "items cannot be moved to the category being deleted")
+		}
+		if target.IsArchived() {
+			return nil, apperrors.Conflict(apperrors.CodeCategoryArchived, "items cannot be moved to an archived category")
+		}
+
+		moved, err := s.categoryRepo.DeleteMovingItemsWithContext(ctx, id, target.ID, restaurantID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+			}
+			return nil, err
+		}
+		result.MovedTo = &target.ID
+		result.MovedItems = moved
+
+	default:
+		if err := s.categoryRepo.DeleteEmptyWithContext(ctx, id, restaurantID); err != nil {
+			switch {
+			case errors.Is(err, repositories.ErrCategoryNotEmpty):
+				return nil, apperrors.Conflict(apperrors.CodeCategoryNotEmpty, "category still has menu items; move them to another category or archive them")
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+			}
+			return nil, err
+		}
+	}
+
+	changes := []MenuChange{{
+		Entity:   MenuEntityCategory,
+		EntityID: id,
+		Action:   MenuChangeDeleted,
+	}}
+	for _, item := range category.MenuItems {
+		changes = append(changes, MenuChange{
+			Entity:   MenuEntityMenuItem,
+			EntityID: item.ID,
+			Action:   MenuChangeUpdated,
+			Fields:   []string{"category_id"},
+		})
+	}
+	s.webhookService.NotifyMenuChanges(ctx, restaurantID, changes)
+
+	return result, nil
+}
+
+// ArchiveCategory hides a category and its items from menus and ordering without deleting them
+func (s *CategoryService) ArchiveCategory(ctx context.Context, id uint, restaurantID uint) (*models.MenuCategory, error) {
+	category, err := s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+	}
+	if category.IsArchived() {
+		return category, nil
+	}
+
+	now := time.Now()
+	return s.setArchived(ctx, id, restaurantID, &now)
+}
+
+// RestoreCategory brings an archived category and its items back onto the menu
+func (s *CategoryService) RestoreCategory(ctx context.Context, id uint, restaurantID uint) (*models.MenuCategory, error) {
+	category, err := s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+	}
+	if !category.IsArchived() {
+		return category, nil
+	}
+
+	return s.setArchived(ctx, id, restaurantID, nil)
+}
+
+// setArchived stores the archive timestamp and announces the change
+func (s *CategoryService) setArchived(ctx context.Context, id uint, restaurantID uint, archivedAt *time.Time) (*models.MenuCategory, error) {
+	if err := s.categoryRepo.SetArchivedWithContext(ctx, id, restaurantID, archivedAt); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+		}
 		return nil, err
 	}
 
-	// Fetch and return updated category
-	return s.categoryRepo.GetByIDWithContext(ctx, id)
+	s.webhookService.NotifyMenuChange(ctx, restaurantID, MenuChange{
+		Entity:   MenuEntityCategory,
+		EntityID: id,
+		Action:   MenuChangeUpdated,
+		Fields:   []string{"archived_at"},
+	})
+
+	return s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+}
+
+// SetCategoryAvailability flips is_available for all items in a category at once
+// (e.g. "fryer broken - all fried items off"). The update and its audit entry are
+// written in one transaction; the menu version bump invalidates cached menus
+func (s *CategoryService) SetCategoryAvailability(ctx context.Context, id uint, req *dto.UpdateCategoryAvailabilityRequest, restaurantID uint, actor AuditActor) (*CategoryAvailabilityResult, error) {
+	if _, err := s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+	}
+
+	details, err := json.Marshal(map[string]interface{}{
+		"category_id":  id,
+		"is_available": *req.IsAvailable,
+		"reason":       strings.TrimSpace(req.Reason),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit details: %w", err)
+	}
+
+	updated, err := s.categoryRepo.SetItemsAvailabilityWithContext(ctx, id, restaurantID, *req.IsAvailable, &models.AuditLog{
+		RestaurantID: &restaurantID,
+		ActorUserID:  actor.UserID,
+		ActorRole:    actor.Role,
+		Action:       models.AuditActionCategoryBulkAvailability,
+		Details:      string(details),
+		IPAddress:    actor.IPAddress,
+		UserAgent:    actor.UserAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.webhookService.NotifyMenuChange(ctx, restaurantID, MenuChange{
+		Entity:   MenuEntityCategory,
+		EntityID: id,
+		Action:   MenuChangeUpdated,
+		Fields:   []string{"menu_items.is_available"},
+	})
+
+	return &CategoryAvailabilityResult{
+		CategoryID:   id,
+		IsAvailable:  *req.IsAvailable,
+		UpdatedItems: updated,
+	}, nil
+}
+
+// ReorderCategories sets the display order of the restaurant's categories to the order of req.IDs,
+// which must list every category exactly once
+func (s *CategoryService) ReorderCategories(ctx context.Context, req *dto.ReorderRequest, restaurantID uint) ([]models.MenuCategory, error) {
+	if err := s.categoryRepo.ReorderWithContext(ctx, restaurantID, req.IDs); err != nil {
+		if errors.Is(err, repositories.ErrReorderMismatch) {
+			return nil, apperrors.BadRequest(apperrors.CodeReorderMismatch, "ids must list every category exactly once")
+		}
+		return nil, err
+	}
+
+	s.webhookService.NotifyMenuChanges(ctx, restaurantID, reorderChanges(MenuEntityCategory, req.IDs))
+
+	return s.categoryRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// ReorderCategoryItems sets the display order of a category's menu items to the order of req.IDs,
+// which must list every item in the category exactly once
+func (s *CategoryService) ReorderCategoryItems(ctx context.Context, id uint, req *dto.ReorderRequest, restaurantID uint) ([]models.MenuItem, error) {
+	if _, err := s.categoryRepo.GetByIDForRestaurant(ctx, id, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+	}
+
+	if err := s.menuItemRepo.ReorderInCategoryWithContext(ctx, restaurantID, id, req.IDs); err != nil {
+		if errors.Is(err, repositories.ErrReorderMismatch) {
+			return nil, apperrors.BadRequest(apperrors.CodeReorderMismatch, "ids must list every item in the category exactly once")
+		}
+		return nil, err
+	}
+
+	s.webhookService.NotifyMenuChanges(ctx, restaurantID, reorderChanges(MenuEntityMenuItem, req.IDs))
+
+	return s.menuItemRepo.GetByCategoryIDWithContext(ctx, id)
+}
+
+// reorderChanges describes a display order change of the given entities
+func reorderChanges(entity string, ids []uint) []MenuChange {
+	changes := make([]MenuChange, 0, len(ids))
+	for _, id := range ids {
+		changes = append(changes, MenuChange{
+			Entity:   entity,
+			EntityID: id,
+			Action:   MenuChangeUpdated,
+			Fields:   []string{"display_order"},
+		})
+	}
+	return changes
 }
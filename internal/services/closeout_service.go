@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// maxCloseoutListDays bounds the date range of a closeout listing
+const maxCloseoutListDays = 366
+
+// Z-report states
+const (
+	ZReportStatusOpen   = "open"
+	ZReportStatusClosed = "closed"
+)
+
+// CloseoutService builds end-of-day (Z) reports and closes business days
+// A business day runs from midnight to midnight in the restaurant's time zone
+type CloseoutService struct {
+	closeoutRepo *repositories.CloseoutRepository
+	orderRepo    *repositories.OrderRepository
+	settingsRepo *repositories.RestaurantSettingsRepository
+}
+
+// NewCloseoutService creates a new CloseoutService instance
+func NewCloseoutService(
+	closeoutRepo *repositories.CloseoutRepository,
+	orderRepo *repositories.OrderRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+) *CloseoutService {
+	return &CloseoutService{
+		closeoutRepo: closeoutRepo,
+		orderRepo:    orderRepo,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// ZReport is the report of a business day: the stored closeout once the day is closed,
+// otherwise the live numbers so far
+type ZReport struct {
+	Status string `json:"status"`
+	*models.DailyCloseout
+}
+
+// CloseDayRequest represents a request to close a business day
+type CloseDayRequest struct {
+	Date string `json:"date" binding:"required"` // YYYY-MM-DD in the restaurant's time zone
+}
+
+// GetReport returns the Z-report of a business day, today's if date is empty
+func (s *CloseoutService) GetReport(ctx context.Context, restaurantID uint, date string) (*ZReport, error) {
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if date == "" {
+		location, err := time.LoadLocation(settings.TimeZone)
+		if err != nil {
+			location = time.UTC
+		}
+		date = time.Now().In(location).Format(time.DateOnly)
+	}
+	businessDate, err := parseBusinessDate(date)
+	if err != nil {
+		return nil, err
+	}
+
+	closeout, err := s.closeoutRepo.GetByDateWithContext(ctx, restaurantID, businessDate)
+	if err == nil {
+		return &ZReport{Status: ZReportStatusClosed, DailyCloseout: closeout}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	closeout, err = s.snapshot(ctx, settings, businessDate)
+	if err != nil {
+		return nil, err
+	}
+	return &ZReport{Status: ZReportStatusOpen, DailyCloseout: closeout}, nil
+}
+
+// CloseDay snapshots a business day into an immutable closeout
+// Only days that are over can be closed, and each day only once
+func (s *CloseoutService) CloseDay(ctx context.Context, restaurantID, userID uint, req *CloseDayRequest) (*models.DailyCloseout, error) {
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	businessDate, err := parseBusinessDate(req.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	closeout, err := s.snapshot(ctx, settings, businessDate)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().Before(closeout.PeriodEnd) {
+		return nil, apperrors.BadRequest(apperrors.CodeDayNotOver, "business day is not over yet")
+	}
+
+	closeout.ClosedByUserID = userID
+	closeout.ClosedAt = time.Now().UTC()
+	if err := s.closeoutRepo.CreateWithContext(ctx, closeout); err != nil {
+		if errors.Is(err, repositories.ErrCloseoutExists) {
+			return nil, apperrors.Conflict(apperrors.CodeDayAlreadyClosed, "business day is already closed")
+		}
+		return nil, err
+	}
+
+	return closeout, nil
+}
+
+// GetCloseout retrieves a stored closeout
+func (s *CloseoutService) GetCloseout(ctx context.Context, id, restaurantID uint) (*models.DailyCloseout, error) {
+	closeout, err := s.closeoutRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeCloseoutNotFound, "closeout not found")
+		}
+		return nil, err
+	}
+	return closeout, nil
+}
+
+// ListCloseouts lists the closeouts of business days in [from, to]
+// Empty bounds default to the last 30 days
+func (s *CloseoutService) ListCloseouts(ctx context.Context, restaurantID uint, from, to string) ([]models.DailyCloseout, error) {
+	toDate := time.Now().UTC().Truncate(24 * time.Hour)
+	if to != "" {
+		parsed, err := parseBusinessDate(to)
+		if err != nil {
+			return nil, err
+		}
+		toDate = parsed
+	}
+	fromDate := toDate.AddDate(0, 0, -30)
+	if from != "" {
+		parsed, err := parseBusinessDate(from)
+		if err != nil {
+			return nil, err
+		}
+		fromDate = parsed
+	}
+
+	if fromDate.After(toDate) {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "from must not be after to")
+	}
+	if toDate.Sub(fromDate) > maxCloseoutListDays*24*time.Hour {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "date range must not exceed one year")
+	}
+
+	return s.closeoutRepo.ListWithContext(ctx, restaurantID, fromDate, toDate)
+}
+
+// snapshot aggregates the orders of a business day
+// The model has no discounts or refunds yet, so those are recorded as zero; cancelled orders are voids,
+// reported separately and excluded from sales. Payments are broken down by order source
+func (s *CloseoutService) snapshot(ctx context.Context, settings *models.RestaurantSettings, businessDate time.Time) (*models.DailyCloseout, error) {
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	start := time.Date(businessDate.Year(), businessDate.Month(), businessDate.Day(), 0, 0, 0, 0, location)
+	end := start.AddDate(0, 0, 1)
+
+	totals, err := s.orderRepo.GetSourceTotals(ctx, settings.RestaurantID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	closeout := &models.DailyCloseout{
+		RestaurantID: settings.RestaurantID,
+		BusinessDate: businessDate,
+		TimeZone:     location.String(),
+		PeriodStart:  start.UTC(),
+		PeriodEnd:    end.UTC(),
+		Currency:     settings.Currency,
+		TaxRate:      settings.TaxRate,
+		Payments:     []models.DailyCloseoutPayment{},
+	}
+
+	payments := make(map[string]int)
+	for _, total := range totals {
+		closeout.OrderCount += total.OrderCount
+		switch total.Status {
+		case models.OrderStatusCancelled:
+			closeout.CancelledOrders += total.OrderCount
+			closeout.Cancelled += total.Amount
+			continue
+		case models.OrderStatusCompleted:
+			closeout.CompletedOrders += total.OrderCount
+		default:
+			closeout.OpenOrders += total.OrderCount
+		}
+		closeout.GrossSales += total.Amount
+
+		method := total.Source
+		if method == "" {
+			method = models.OrderSourceInternal
+		}
+		i, ok := payments[method]
+		if !ok {
+			i = len(closeout.Payments)
+			payments[method] = i
+			closeout.Payments = append(closeout.Payments, models.DailyCloseoutPayment{
+				RestaurantID: settings.RestaurantID,
+				Method:       method,
+			})
+		}
+		closeout.Payments[i].OrderCount += total.OrderCount
+		closeout.Payments[i].Amount += total.Amount
+	}
+
+	// Sales are tax-inclusive: the tax is the included share of the sales after discounts and refunds
+	sales := NewReceiptTotals(closeout.GrossSales-closeout.Discount-closeout.Refund, settings.TaxRate)
+	closeout.NetSales = sales.Net
+	closeout.Tax = sales.Tax
+
+	return closeout, nil
+}
+
+// settings loads the restaurant's settings, falling back to default settings
+func (s *CloseoutService) settings(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+	return settings, nil
+}
+
+// parseBusinessDate parses a YYYY-MM-DD business date
+func parseBusinessDate(date string) (time.Time, error) {
+	parsed, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return time.Time{}, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid date, expected YYYY-MM-DD")
+	}
+	return parsed, nil
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// CustomerService manages the restaurant customer directory (CRM)
+type CustomerService struct {
+	customerRepo    *repositories.CustomerRepository
+	userRepo        *repositories.UserRepository
+	orderRepo       *repositories.OrderRepository
+	reservationRepo *repositories.ReservationRepository
+}
+
+// NewCustomerService creates a new CustomerService instance
+func NewCustomerService(
+	customerRepo *repositories.CustomerRepository,
+	userRepo *repositories.UserRepository,
+	orderRepo *repositories.OrderRepository,
+	reservationRepo *repositories.ReservationRepository,
+) *CustomerService {
+	return &CustomerService{
+		customerRepo:    customerRepo,
+		userRepo:        userRepo,
+		orderRepo:       orderRepo,
+		reservationRepo: reservationRepo,
+	}
+}
+
+// CustomerRequest represents a customer creation or update request
+type CustomerRequest struct {
+	Name  string `json:"name" binding:"required,max=255"`
+	Email string `json:"email" binding:"omitempty,email,max=255"`
+	Phone string `json:"phone" binding:"omitempty,max=20"`
+	Notes string `json:"notes"`
+}
+
+// CustomerList is a page of customers
+type CustomerList struct {
+	Customers []models.Customer `json:"customers"`
+	Total     int64             `json:"total"`
+	Limit     int               `json:"limit"`
+	Offset    int               `json:"offset"`
+}
+
+// CustomerHistory is a customer with their orders and reservations at the restaurant
+type CustomerHistory struct {
+	Customer     models.Customer      `json:"customer"`
+	Orders       []models.Order       `json:"orders"`
+	Reservations []models.Reservation `json:"reservations"`
+}
+
+// CreateCustomer adds a customer, e.g. a walk-in or phone booking without an account
+func (s *CustomerService) CreateCustomer(ctx context.Context, restaurantID uint, req *CustomerRequest) (*models.Customer, error) {
+	customer := &models.Customer{RestaurantID: restaurantID}
+	applyCustomerRequest(customer, req)
+
+	if err := s.ensureUniqueContact(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	if err := s.customerRepo.CreateWithContext(ctx, customer); err != nil {
+		return nil, customerWriteError(err)
+	}
+	return customer, nil
+}
+
+// UpdateCustomer updates a customer's contact details and notes
+func (s *CustomerService) UpdateCustomer(ctx context.Context, id uint, restaurantID uint, req *CustomerRequest) (*models.Customer, error) {
+	customer, err := s.GetCustomer(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	applyCustomerRequest(customer, req)
+
+	if err := s.ensureUniqueContact(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	if err := s.customerRepo.UpdateWithContext(ctx, customer); err != nil {
+		return nil, customerWriteError(err)
+	}
+	return customer, nil
+}
+
+// GetCustomer retrieves a customer of the restaurant by ID
+func (s *CustomerService) GetCustomer(ctx context.Context, id uint, restaurantID uint) (*models.Customer, error) {
+	customer, err := s.customerRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeCustomerNotFound, "customer not found")
+	}
+	return customer, nil
+}
+
+// SearchCustomers finds customers by name, email or phone
+func (s *CustomerService) SearchCustomers(ctx context.Context, restaurantID uint, term string, limit, offset int) (*CustomerList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	customers, total, err := s.customerRepo.SearchWithContext(ctx, restaurantID, term, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CustomerList{
+		Customers: customers,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}, nil
+}
+
+// GetCustomerHistory returns a customer with their full order and reservation history
+func (s *CustomerService) GetCustomerHistory(ctx context.Context, id uint, restaurantID uint) (*CustomerHistory, error) {
+	customer, err := s.GetCustomer(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &CustomerHistory{
+		Customer:     *customer,
+		Orders:       []models.Order{},
+		Reservations: []models.Reservation{},
+	}
+	if customer.UserID == nil {
+		return history, nil
+	}
+
+	if history.Orders, err = s.orderRepo.GetByUserIDWithContext(ctx, customer.RestaurantID, *customer.UserID); err != nil {
+		return nil, err
+	}
+	if history.Reservations, err = s.reservationRepo.GetByUserIDWithContext(ctx, customer.RestaurantID, *customer.UserID); err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// SyncUser links a client account to its customer record and refreshes the customer's aggregates
+// An existing customer with the same email or phone is linked instead of creating a duplicate
+// Called after orders and reservations change; failures are logged and never fail the caller
+func (s *CustomerService) SyncUser(ctx context.Context, restaurantID, userID uint) {
+	if err := s.syncUser(ctx, restaurantID, userID); err != nil {
+		logger.Warn("Failed to sync customer record",
+			zap.Uint("restaurant_id", restaurantID),
+			zap.Uint("user_id", userID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *CustomerService) syncUser(ctx context.Context, restaurantID, userID uint) error {
+	customer, err := s.customerRepo.GetByUserIDWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		user, err := s.userRepo.GetByIDWithContext(ctx, userID)
+		if err != nil {
+			return err
+		}
+		// Staff and KAM accounts are not customers
+		if user.Role != "Client" {
+			return nil
+		}
+
+		if customer, err = s.linkUser(ctx, restaurantID, user); err != nil {
+			return err
+		}
+	}
+
+	return s.customerRepo.RefreshStatsWithContext(ctx, customer.ID)
+}
+
+// linkUser attaches a client account to the customer with the same contact details, or creates one
+func (s *CustomerService) linkUser(ctx context.Context, restaurantID uint, user *models.User) (*models.Customer, error) {
+	matches, err := s.customerRepo.FindByContactWithContext(ctx, restaurantID, user.Email, user.Phone)
+	if err != nil {
+		return nil, err
+	}
+	for i := range matches {
+		if matches[i].UserID == nil {
+			matches[i].UserID = &user.ID
+			if err := s.customerRepo.UpdateWithContext(ctx, &matches[i]); err != nil {
+				return nil, err
+			}
+			return &matches[i], nil
+		}
+	}
+
+	customer := &models.Customer{
+		RestaurantID: restaurantID,
+		UserID:       &user.ID,
+		Name:         strings.TrimSpace(user.FirstName + " " + user.LastName),
+		Email:        user.Email,
+		Phone:        user.Phone,
+	}
+	// The contact details already belong to a customer with another account
+	if len(matches) > 0 {
+		customer.Email = ""
+		customer.Phone = ""
+	}
+	if err := s.customerRepo.CreateWithContext(ctx, customer); err != nil {
+		return nil, err
+	}
+	return customer, nil
+}
+
+// ensureUniqueContact rejects contact details already used by another customer of the restaurant
+func (s *CustomerService) ensureUniqueContact(ctx context.Context, customer *models.Customer) error {
+	matches, err := s.customerRepo.FindByContactWithContext(ctx, customer.RestaurantID, customer.Email, customer.Phone)
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		if match.ID != customer.ID {
+			return apperrors.Conflict(apperrors.CodeCustomerExists, "a customer with this email or phone already exists")
+		}
+	}
+	return nil
+}
+
+// applyCustomerRequest copies normalized request fields onto a customer
+func applyCustomerRequest(customer *models.Customer, req *CustomerRequest) {
+	customer.Name = strings.TrimSpace(req.Name)
+	customer.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	customer.Phone = strings.TrimSpace(req.Phone)
+	customer.Notes = req.Notes
+}
+
+// customerWriteError maps deduplication index violations that raced past ensureUniqueContact
+func customerWriteError(err error) error {
+	if errors.Is(err, repositories.ErrCustomerExists) {
+		return apperrors.Conflict(apperrors.CodeCustomerExists, "a customer with this email or phone already exists")
+	}
+	return err
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+)
+
+// Dashboard event names sent on the live stream
+const (
+	DashboardEventNewOrder       = "new-order"
+	DashboardEventOrderStatus    = "order-status"
+	DashboardEventNewReservation = "new-reservation"
+	DashboardEventCourseFired    = "course-fired"
+	DashboardEventMenuChanged    = "menu-changed"
+)
+
+// dashboardEventBatch caps the events of each kind read per poll
+const dashboardEventBatch = 100
+
+// dashboardMenuActions are the audit actions streamed as menu-changed events
+var dashboardMenuActions = []string{
+	models.AuditActionCategoryBulkAvailability,
+	models.AuditActionMenuItemBulkAvailability,
+}
+
+// DashboardCursor marks the last order, status change, reservation, course firing and menu change already sent to a stream
+type DashboardCursor struct {
+	OrderID        uint
+	StatusChangeID uint
+	ReservationID  uint
+	CourseFiringID uint
+	MenuChangeID   uint
+}
+
+// DashboardEvent is a single live dashboard update
+type DashboardEvent struct {
+	Name string
+	Data interface{}
+}
+
+// DashboardOrderEvent is the payload of a new-order event
+type DashboardOrderEvent struct {
+	OrderID     uint        `json:"order_id"`
+	UserID      uint        `json:"user_id"`
+	Status      string      `json:"status"`
+	TotalAmount money.Cents `json:"total_amount"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// DashboardStatusEvent is the payload of an order-status event
+type DashboardStatusEvent struct {
+	OrderID         uint      `json:"order_id"`
+	FromStatus      string    `json:"from_status"`
+	ToStatus        string    `json:"to_status"`
+	ChangedByUserID uint      `json:"changed_by_user_id"`
+	ChangedAt       time.Time `json:"changed_at"`
+}
+
+// DashboardReservationEvent is the payload of a new-reservation event
+type DashboardReservationEvent struct {
+	ReservationID  uint      `json:"reservation_id"`
+	TableNumber    string    `json:"table_number"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	NumberOfGuests int       `json:"number_of_guests"`
+	Status         string    `json:"status"`
+}
+
+// DashboardCourseFiredEvent is the payload of a course-fired event
+type DashboardCourseFiredEvent struct {
+	OrderID       uint      `json:"order_id"`
+	Course        int       `json:"course"`
+	FiredByUserID uint      `json:"fired_by_user_id"`
+	FiredAt       time.Time `json:"fired_at"`
+}
+
+// DashboardMenuEvent is the payload of a menu-changed event, sent when items are 86'd or brought back
+type DashboardMenuEvent struct {
+	Action          string    `json:"action"`
+	CategoryID      *uint     `json:"category_id,omitempty"`
+	MenuItemIDs     []uint    `json:"menu_item_ids,omitempty"`
+	IsAvailable     bool      `json:"is_available"`
+	Reason          string    `json:"reason,omitempty"`
+	ChangedByUserID uint      `json:"changed_by_user_id"`
+	ChangedAt       time.Time `json:"changed_at"`
+}
+
+// CurrentCursor returns a cursor positioned after everything that already happened,
+// so a new stream only receives later events
+func (s *DashboardService) CurrentCursor(ctx context.Context, restaurantID uint) (*DashboardCursor, error) {
+	orderID, statusChangeID, err := s.orderRepo.GetLatestIDsWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	reservationID, err := s.reservationRepo.GetLatestIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	courseFiringID, err := s.orderRepo.GetLatestCourseFiringIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	menuChangeID, err := s.auditLogRepo.GetLatestIDWithContext(ctx, restaurantID, dashboardMenuActions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DashboardCursor{
+		OrderID:        orderID,
+		StatusChangeID: statusChangeID,
+		ReservationID:  reservationID,
+		CourseFiringID: courseFiringID,
+		MenuChangeID:   menuChangeID,
+	}, nil
+}
+
+// GetEventsSince returns the events after the cursor and advances it
+// Events are read from the database, so every instance serving a stream sees every change
+func (s *DashboardService) GetEventsSince(ctx context.Context, restaurantID uint, cursor *DashboardCursor) ([]DashboardEvent, error) {
+	var events []DashboardEvent
+
+	orders, err := s.orderRepo.GetCreatedAfterWithContext(ctx, restaurantID, cursor.OrderID, dashboardEventBatch)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		events = append(events, DashboardEvent{Name: DashboardEventNewOrder, Data: newDashboardOrderEvent(&order)})
+		cursor.OrderID = order.ID
+	}
+
+	changes, err := s.orderRepo.GetStatusChangesAfterWithContext(ctx, restaurantID, cursor.StatusChangeID, dashboardEventBatch)
+	if err != nil {
+		return nil, err
+	}
+	for _, change := range changes {
+		events = append(events, DashboardEvent{Name: DashboardEventOrderStatus, Data: DashboardStatusEvent{
+			OrderID:         change.OrderID,
+			FromStatus:      change.FromStatus,
+			ToStatus:        change.ToStatus,
+			ChangedByUserID: change.ChangedByUserID,
+			ChangedAt:       change.CreatedAt,
+		}})
+		cursor.StatusChangeID = change.ID
+	}
+
+	reservations, err := s.reservationRepo.GetCreatedAfterWithContext(ctx, restaurantID, cursor.ReservationID, dashboardEventBatch)
+	if err != nil {
+		return nil, err
+	}
+	for _, reservation := range reservations {
+		events = append(events, DashboardEvent{Name: DashboardEventNewReservation, Data: DashboardReservationEvent{
+			ReservationID:  reservation.ID,
+			TableNumber:    reservation.TableNumber,
+			StartTime:      reservation.StartTime,
+			EndTime:        reservation.EndTime,
+			NumberOfGuests: reservation.NumberOfGuests,
+			Status:         reservation.Status,
+		}})
+		cursor.ReservationID = reservation.ID
+	}
+
+	firings, err := s.orderRepo.GetCourseFiringsAfterWithContext(ctx, restaurantID, cursor.CourseFiringID, dashboardEventBatch)
+	if err != nil {
+		return nil, err
+	}
+	for _, firing := range firings {
+		events = append(events, DashboardEvent{Name: DashboardEventCourseFired, Data: DashboardCourseFiredEvent{
+			OrderID:       firing.OrderID,
+			Course:        firing.Course,
+			FiredByUserID: firing.FiredByUserID,
+			FiredAt:       firing.CreatedAt,
+		}})
+		cursor.CourseFiringID = firing.ID
+	}
+
+	menuChanges, err := s.auditLogRepo.GetActionsAfterWithContext(ctx, restaurantID, dashboardMenuActions, cursor.MenuChangeID, dashboardEventBatch)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range menuChanges {
+		events = append(events, DashboardEvent{Name: DashboardEventMenuChanged, Data: newDashboardMenuEvent(&entry)})
+		cursor.MenuChangeID = entry.ID
+	}
+
+	return events, nil
+}
+
+// StreamTier returns the tenant tier used to label the stream's connection metrics
+func (s *DashboardService) StreamTier(ctx context.Context, restaurantID uint) string {
+	return tenantTier(ctx, s.backupRepo, restaurantID)
+}
+
+func newDashboardOrderEvent(order *models.Order) DashboardOrderEvent {
+	return DashboardOrderEvent{
+		OrderID:     order.ID,
+		UserID:      order.UserID,
+		Status:      order.Status,
+		TotalAmount: order.TotalAmount,
+		CreatedAt:   order.CreatedAt,
+	}
+}
+
+// newDashboardMenuEvent builds a menu-changed payload from an availability audit entry
+func newDashboardMenuEvent(entry *models.AuditLog) DashboardMenuEvent {
+	var details struct {
+		CategoryID  *uint  `json:"category_id"`
+		MenuItemIDs []uint `json:"menu_item_ids"`
+		IsAvailable bool   `json:"is_available"`
+		Reason      string `json:"reason"`
+	}
+	// Details are written by the availability services; an unreadable entry still announces the change
+	_ = json.Unmarshal([]byte(entry.Details), &details)
+
+	return DashboardMenuEvent{
+		Action:          entry.Action,
+		CategoryID:      details.CategoryID,
+		MenuItemIDs:     details.MenuItemIDs,
+		IsAvailable:     details.IsAvailable,
+		Reason:          details.Reason,
+		ChangedByUserID: entry.ActorUserID,
+		ChangedAt:       entry.CreatedAt,
+	}
+}
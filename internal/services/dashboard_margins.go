@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"restaurant-backend/internal/money"
+)
+
+// MarginReport is the theoretical food cost and margin of the menu, from the recipes at current ingredient costs
+// Items and categories whose margin percentage is below ThresholdPercent are flagged; items without a
+// recipe aren't costed and are left out of the category and menu figures
+type MarginReport struct {
+	Currency         string           `json:"currency"`
+	ThresholdPercent float64          `json:"threshold_percent"`
+	FoodCostPercent  *float64         `json:"food_cost_percent,omitempty"` // Of the costed items' prices
+	MarginPercent    *float64         `json:"margin_percent,omitempty"`
+	CostedItems      int              `json:"costed_items"`
+	UncostedItems    int              `json:"uncosted_items"`
+	BelowThreshold   int              `json:"below_threshold"`
+	Categories       []CategoryMargin `json:"categories"`
+	Items            []ItemMargin     `json:"items"` // Lowest margin percentage first, uncosted items last
+}
+
+// CategoryMargin is the theoretical food cost and margin of the costed items of a category
+// Percentages are of the summed prices, so every item weighs by its price rather than its sales
+type CategoryMargin struct {
+	CategoryID      uint     `json:"category_id"`
+	Name            string   `json:"name"`
+	CostedItems     int      `json:"costed_items"`
+	UncostedItems   int      `json:"uncosted_items"`
+	FoodCostPercent *float64 `json:"food_cost_percent,omitempty"`
+	MarginPercent   *float64 `json:"margin_percent,omitempty"`
+	BelowThreshold  bool     `json:"below_threshold"`
+}
+
+// ItemMargin is the theoretical food cost and margin of a menu item
+type ItemMargin struct {
+	MenuItemCost
+	BelowThreshold bool `json:"below_threshold"`
+}
+
+// marginTotals sums the prices and food costs of costed items
+type marginTotals struct {
+	price, foodCost money.Cents
+	costed          int
+	uncosted        int
+}
+
+// add counts an item toward the totals
+func (t *marginTotals) add(cost *MenuItemCost) {
+	if !cost.HasRecipe {
+		t.uncosted++
+		return
+	}
+	t.costed++
+	t.price += cost.Price
+	t.foodCost += cost.FoodCost
+}
+
+// percents returns the food cost and margin as percentages of the price, nil without priced items
+func (t *marginTotals) percents() (*float64, *float64) {
+	if t.price <= 0 {
+		return nil, nil
+	}
+	price := t.price.Float()
+	foodCost := math.Round(t.foodCost.Float()/price*10000) / 100
+	margin := math.Round((t.price-t.foodCost).Float()/price*10000) / 100
+	return &foodCost, &margin
+}
+
+// GetMarginReport returns the theoretical food cost and margin per menu item and category,
+// flagging those below the restaurant's minimum margin
+func (s *DashboardService) GetMarginReport(ctx context.Context, restaurantID uint) (*MarginReport, error) {
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	costs, err := s.inventory.MenuItemCosts(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := settings.MinMarginPercent
+	report := &MarginReport{
+		Currency:         settings.Currency,
+		ThresholdPercent: threshold,
+		Categories:       []CategoryMargin{},
+		Items:            make([]ItemMargin, 0, len(costs)),
+	}
+
+	var menu marginTotals
+	categories := make(map[uint]*marginTotals)
+	var categoryOrder []CategoryMargin
+	for i := range costs {
+		cost := &costs[i]
+		totals, ok := categories[cost.CategoryID]
+		if !ok {
+			totals = &marginTotals{}
+			categories[cost.CategoryID] = totals
+			categoryOrder = append(categoryOrder, CategoryMargin{CategoryID: cost.CategoryID, Name: cost.CategoryName})
+		}
+		totals.add(cost)
+		menu.add(cost)
+
+		item := ItemMargin{MenuItemCost: *cost}
+		if cost.HasRecipe && cost.MarginPercent < threshold {
+			item.BelowThreshold = true
+			report.BelowThreshold++
+		}
+		report.Items = append(report.Items, item)
+	}
+
+	report.CostedItems, report.UncostedItems = menu.costed, menu.uncosted
+	report.FoodCostPercent, report.MarginPercent = menu.percents()
+	for _, category := range categoryOrder {
+		totals := categories[category.CategoryID]
+		category.CostedItems, category.UncostedItems = totals.costed, totals.uncosted
+		category.FoodCostPercent, category.MarginPercent = totals.percents()
+		category.BelowThreshold = category.MarginPercent != nil && *category.MarginPercent < threshold
+		report.Categories = append(report.Categories, category)
+	}
+
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		a, b := report.Items[i], report.Items[j]
+		if a.HasRecipe != b.HasRecipe {
+			return a.HasRecipe
+		}
+		return a.MarginPercent < b.MarginPercent
+	})
+	return report, nil
+}
@@ -2,24 +2,57 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"time"
 
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
 	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
 	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
 )
 
 // DashboardService handles dashboard statistics operations
 type DashboardService struct {
 	orderRepo       *repositories.OrderRepository
 	reservationRepo *repositories.ReservationRepository
+	backupRepo      *repositories.StorageBackupRepository
+	timeEntryRepo   *repositories.TimeEntryRepository
+	settingsRepo    *repositories.RestaurantSettingsRepository
+	auditLogRepo    *repositories.AuditLogRepository
+	dailyStatsRepo  *repositories.DailyStatsRepository
+	customerRepo    *repositories.CustomerRepository
+	inventory       *InventoryService
 }
 
 // NewDashboardService creates a new DashboardService instance
-func NewDashboardService(orderRepo *repositories.OrderRepository, reservationRepo *repositories.ReservationRepository) *DashboardService {
+func NewDashboardService(
+	orderRepo *repositories.OrderRepository,
+	reservationRepo *repositories.ReservationRepository,
+	backupRepo *repositories.StorageBackupRepository,
+	timeEntryRepo *repositories.TimeEntryRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+	auditLogRepo *repositories.AuditLogRepository,
+	dailyStatsRepo *repositories.DailyStatsRepository,
+	customerRepo *repositories.CustomerRepository,
+	inventory *InventoryService,
+) *DashboardService {
 	return &DashboardService{
 		orderRepo:       orderRepo,
 		reservationRepo: reservationRepo,
+		backupRepo:      backupRepo,
+		timeEntryRepo:   timeEntryRepo,
+		settingsRepo:    settingsRepo,
+		auditLogRepo:    auditLogRepo,
+		dailyStatsRepo:  dailyStatsRepo,
+		customerRepo:    customerRepo,
+		inventory:       inventory,
 	}
 }
 
@@ -32,32 +65,31 @@ type DashboardStats struct {
 
 // GetDashboardStats retrieves overall dashboard statistics for a restaurant
 func (s *DashboardService) GetDashboardStats(ctx context.Context, restaurantID uint, period string) (*DashboardStats, error) {
-	// Calculate date range based on period
-	startDate, endDate := s.calculateDateRange(period)
-
-	// Get order stats
-	orderStats, err := s.orderRepo.GetOrderStats(ctx, restaurantID, startDate, endDate)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get order stats: %w", err)
-	}
-
-	// Get reservation stats
-	reservationStats, err := s.reservationRepo.GetReservationStats(ctx, restaurantID, startDate, endDate)
+	settings, err := s.settings(ctx, restaurantID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reservation stats: %w", err)
+		return nil, err
 	}
+	reporting := restaurantPeriod(settingsLocation(settings), period)
 
-	// Get orders by status
-	ordersByStatus, err := s.orderRepo.GetOrdersByStatus(ctx, restaurantID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get orders by status: %w", err)
+	// The aggregates are independent, so they run concurrently on separate connections
+	var stats DashboardStats
+	var parts periodStats
+	g, gctx := errgroup.WithContext(ctx)
+	s.loadPeriodStats(gctx, g, restaurantID, reporting, &parts)
+	g.Go(func() error {
+		ordersByStatus, err := s.orderRepo.GetOrdersByStatus(gctx, restaurantID)
+		if err != nil {
+			return fmt.Errorf("failed to get orders by status: %w", err)
+		}
+		stats.OrdersByStatus = ordersByStatus
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
 	}
+	stats.OrderStats, stats.ReservationStats = parts.totals()
 
-	return &DashboardStats{
-		OrderStats:       orderStats,
-		ReservationStats: reservationStats,
-		OrdersByStatus:   ordersByStatus,
-	}, nil
+	return &stats, nil
 }
 
 // GetRecentOrders retrieves the most recent orders for a restaurant
@@ -79,41 +111,235 @@ func (s *DashboardService) GetRecentOrders(ctx context.Context, restaurantID uin
 
 // AnalyticsData represents analytics data for a specific period
 type AnalyticsData struct {
-	Period           string                         `json:"period"`
-	StartDate        string                         `json:"start_date"`
-	EndDate          string                         `json:"end_date"`
-	OrderStats       *repositories.OrderStats       `json:"order_stats"`
-	ReservationStats *repositories.ReservationStats `json:"reservation_stats"`
+	Period            string                         `json:"period"`
+	StartDate         string                         `json:"start_date"`
+	EndDate           string                         `json:"end_date"`
+	OrderStats        *repositories.OrderStats       `json:"order_stats"`
+	ReservationStats  *repositories.ReservationStats `json:"reservation_stats"`
+	Customers         *CustomerAnalytics             `json:"customers"`
+	PreviousCustomers *CustomerAnalytics             `json:"previous_customers"` // The whole previous period, for comparison
+	Labor             *LaborReport                   `json:"labor,omitempty"`    // Admins only
 }
 
-// GetAnalytics retrieves analytics data for a specific period
-func (s *DashboardService) GetAnalytics(ctx context.Context, restaurantID uint, period string) (*AnalyticsData, error) {
-	// Calculate date range
-	startDate, endDate := s.calculateDateRange(period)
+// CustomerAnalytics splits the completed orders of a period between new and returning customers
+// Orders are attributed to CRM customers through their linked user account; a customer is new when their
+// first completed order falls in the period
+type CustomerAnalytics struct {
+	StartDate                string  `json:"start_date"`
+	EndDate                  string  `json:"end_date"`
+	NewCustomers             int64   `json:"new_customers"`
+	ReturningCustomers       int64   `json:"returning_customers"`
+	NewCustomerOrders        int64   `json:"new_customer_orders"`
+	ReturningCustomerOrders  int64   `json:"returning_customer_orders"`
+	UnattributedOrders       int64   `json:"unattributed_orders"` // Orders without a customer record, e.g. walk-ins
+	NewCustomerRevenue       float64 `json:"new_customer_revenue"`
+	ReturningCustomerRevenue float64 `json:"returning_customer_revenue"`
+	RepeatRate               float64 `json:"repeat_rate"`             // Percentage of the period's customers with more than one order so far
+	CustomerLifetimeValue    float64 `json:"customer_lifetime_value"` // Average revenue per customer up to the end of the period
+}
 
-	// Get order stats
-	orderStats, err := s.orderRepo.GetOrderStats(ctx, restaurantID, startDate, endDate)
+// GetAnalytics retrieves analytics data for a specific period, with the labor cost if includeLabor is set
+// Periods are cut in the restaurant's time zone; past days are read from the daily rollups
+func (s *DashboardService) GetAnalytics(ctx context.Context, restaurantID uint, period string, includeLabor bool) (*AnalyticsData, error) {
+	settings, err := s.settings(ctx, restaurantID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get order stats: %w", err)
+		return nil, err
+	}
+	reporting := restaurantPeriod(settingsLocation(settings), period)
+
+	analytics := &AnalyticsData{
+		Period:    period,
+		StartDate: reporting.Start.Format(time.RFC3339),
+		EndDate:   reporting.last().Format(time.RFC3339),
+	}
+	var parts periodStats
+	g, gctx := errgroup.WithContext(ctx)
+	s.loadPeriodStats(gctx, g, restaurantID, reporting, &parts)
+	previousStart, previousEnd := reporting.previous(period)
+	g.Go(func() error {
+		customers, err := s.customerAnalytics(gctx, restaurantID, reporting.Start, reporting.end())
+		if err != nil {
+			return fmt.Errorf("failed to get customer analytics: %w", err)
+		}
+		analytics.Customers = customers
+		return nil
+	})
+	g.Go(func() error {
+		customers, err := s.customerAnalytics(gctx, restaurantID, previousStart, previousEnd)
+		if err != nil {
+			return fmt.Errorf("failed to get previous customer analytics: %w", err)
+		}
+		analytics.PreviousCustomers = customers
+		return nil
+	})
+	if includeLabor {
+		g.Go(func() error {
+			labor, err := s.getPeriodLabor(gctx, restaurantID, settings, period, reporting)
+			if err != nil {
+				return fmt.Errorf("failed to get labor report: %w", err)
+			}
+			analytics.Labor = labor
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	analytics.OrderStats, analytics.ReservationStats = parts.totals()
+
+	return analytics, nil
+}
+
+// reportingPeriod is an analytics period in the restaurant's time zone
+// Days in [Start, Today) are read from the daily rollups and the current day live
+type reportingPeriod struct {
+	Start time.Time
+	Today time.Time // Start of the current day
+}
+
+// restaurantPeriod returns the reporting period (today, week, month, year; month by default) in the location
+func restaurantPeriod(location *time.Location, period string) reportingPeriod {
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	var start time.Time
+	switch period {
+	case "today":
+		start = today
+	case "week":
+		// Start from beginning of current week (Sunday)
+		start = today.AddDate(0, 0, -int(today.Weekday()))
+	case "year":
+		start = time.Date(today.Year(), 1, 1, 0, 0, 0, 0, location)
+	default:
+		start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, location)
 	}
+	return reportingPeriod{Start: start, Today: today}
+}
+
+// end is the exclusive end of the period, the start of tomorrow
+func (p reportingPeriod) end() time.Time {
+	return p.Today.AddDate(0, 0, 1)
+}
+
+// last is the last instant of the period
+func (p reportingPeriod) last() time.Time {
+	return p.end().Add(-time.Nanosecond)
+}
 
-	// Get reservation stats
-	reservationStats, err := s.reservationRepo.GetReservationStats(ctx, restaurantID, startDate, endDate)
+// previous returns the [start, end) of the whole period before this one, e.g. last month for a month
+func (p reportingPeriod) previous(period string) (time.Time, time.Time) {
+	switch period {
+	case "today":
+		return p.Start.AddDate(0, 0, -1), p.Start
+	case "week":
+		return p.Start.AddDate(0, 0, -7), p.Start
+	case "year":
+		return p.Start.AddDate(-1, 0, 0), p.Start
+	default:
+		return p.Start.AddDate(0, -1, 0), p.Start
+	}
+}
+
+// customerAnalytics computes the customer cohorts of the completed orders placed in [start, end)
+func (s *DashboardService) customerAnalytics(ctx context.Context, restaurantID uint, start, end time.Time) (*CustomerAnalytics, error) {
+	stats, err := s.customerRepo.GetCohortStatsWithContext(ctx, restaurantID, start, end)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reservation stats: %w", err)
+		return nil, err
 	}
 
-	return &AnalyticsData{
-		Period:           period,
-		StartDate:        startDate,
-		EndDate:          endDate,
-		OrderStats:       orderStats,
-		ReservationStats: reservationStats,
-	}, nil
+	analytics := &CustomerAnalytics{
+		StartDate:                start.Format(time.RFC3339),
+		EndDate:                  end.Add(-time.Nanosecond).Format(time.RFC3339),
+		NewCustomers:             stats.NewCustomers,
+		ReturningCustomers:       stats.ReturningCustomers,
+		NewCustomerOrders:        stats.NewCustomerOrders,
+		ReturningCustomerOrders:  stats.ReturningCustomerOrders,
+		UnattributedOrders:       stats.UnattributedOrders,
+		NewCustomerRevenue:       money.Round(stats.NewCustomerRevenue),
+		ReturningCustomerRevenue: money.Round(stats.ReturningCustomerRevenue),
+	}
+	if customers := stats.NewCustomers + stats.ReturningCustomers; customers > 0 {
+		analytics.RepeatRate = math.Round(float64(stats.RepeatCustomers)/float64(customers)*10000) / 100
+	}
+	if stats.LifetimeCustomers > 0 {
+		analytics.CustomerLifetimeValue = money.Round(stats.LifetimeRevenue / float64(stats.LifetimeCustomers))
+	}
+	return analytics, nil
+}
+
+// periodStats holds the parts of a period's order and reservation aggregates, loaded concurrently
+type periodStats struct {
+	pastOrders        *repositories.OrderStats
+	pastReservations  *repositories.ReservationStats
+	todayOrders       *repositories.OrderStats
+	todayReservations *repositories.ReservationStats
+}
+
+// loadPeriodStats schedules the order and reservation aggregates of a period on the group:
+// the days before today from the rollups, today from the raw tables
+// Each part is written by its own goroutine and must only be read after g.Wait
+func (s *DashboardService) loadPeriodStats(
+	ctx context.Context,
+	g *errgroup.Group,
+	restaurantID uint,
+	period reportingPeriod,
+	parts *periodStats,
+) {
+	if period.Start.Before(period.Today) {
+		g.Go(func() error {
+			orders, reservations, err := s.dailyStatsRepo.SumWithContext(ctx, restaurantID,
+				period.Start.Format(time.DateOnly), period.Today.Format(time.DateOnly))
+			if err != nil {
+				return fmt.Errorf("failed to get daily stats: %w", err)
+			}
+			parts.pastOrders, parts.pastReservations = orders, reservations
+			return nil
+		})
+	}
+
+	startDate, endDate := period.Today.Format(time.RFC3339Nano), period.last().Format(time.RFC3339Nano)
+	g.Go(func() error {
+		stats, err := s.orderRepo.GetOrderStats(ctx, restaurantID, startDate, endDate)
+		if err != nil {
+			return fmt.Errorf("failed to get order stats: %w", err)
+		}
+		parts.todayOrders = stats
+		return nil
+	})
+	g.Go(func() error {
+		stats, err := s.reservationRepo.GetReservationStats(ctx, restaurantID, startDate, endDate)
+		if err != nil {
+			return fmt.Errorf("failed to get reservation stats: %w", err)
+		}
+		parts.todayReservations = stats
+		return nil
+	})
 }
 
-// calculateDateRange calculates the start and end date based on the period
-func (s *DashboardService) calculateDateRange(period string) (string, string) {
+// totals adds up the loaded parts of the period
+func (p *periodStats) totals() (*repositories.OrderStats, *repositories.ReservationStats) {
+	orders := *p.todayOrders
+	reservations := *p.todayReservations
+	if p.pastOrders != nil {
+		orders.TotalOrders += p.pastOrders.TotalOrders
+		orders.PendingOrders += p.pastOrders.PendingOrders
+		orders.CompletedOrders += p.pastOrders.CompletedOrders
+		orders.CancelledOrders += p.pastOrders.CancelledOrders
+		orders.TotalRevenue = money.Round(orders.TotalRevenue + p.pastOrders.TotalRevenue)
+	}
+	if p.pastReservations != nil {
+		reservations.TotalReservations += p.pastReservations.TotalReservations
+		reservations.PendingReservations += p.pastReservations.PendingReservations
+		reservations.ConfirmedReservations += p.pastReservations.ConfirmedReservations
+		reservations.CompletedReservations += p.pastReservations.CompletedReservations
+		reservations.CancelledReservations += p.pastReservations.CancelledReservations
+	}
+	return &orders, &reservations
+}
+
+// periodDateRange returns the RFC3339 start and end of a reporting period (today, week, month, year)
+func periodDateRange(period string) (string, string) {
 	now := time.Now()
 	var startDate time.Time
 
@@ -140,3 +366,391 @@ func (s *DashboardService) calculateDateRange(period string) (string, string) {
 
 	return startDate.Format(time.RFC3339), endDate.Format(time.RFC3339)
 }
+
+// Analytics breakdown dimensions
+const (
+	BreakdownItems      = "items"       // Top-selling menu items
+	BreakdownCategories = "categories"  // Sales per menu category
+	BreakdownHeatmap    = "heatmap"     // Revenue per weekday and hour
+	BreakdownOrderValue = "order_value" // Average order value per day, or per week for a year
+)
+
+// maxBreakdownItems bounds the number of top-selling items
+const maxBreakdownItems = 50
+
+// AnalyticsBreakdown is the revenue of a period broken down along one dimension
+// Only completed orders count; the period is cut in the restaurant's time zone
+type AnalyticsBreakdown struct {
+	Dimension   string                       `json:"dimension"`
+	Period      string                       `json:"period"`
+	StartDate   string                       `json:"start_date"`
+	EndDate     string                       `json:"end_date"`
+	TimeZone    string                       `json:"time_zone"`
+	Granularity string                       `json:"granularity,omitempty"` // order_value only
+	Items       []repositories.ItemSales     `json:"items,omitempty"`
+	Categories  []repositories.CategorySales `json:"categories,omitempty"`
+	Heatmap     []repositories.HeatmapCell   `json:"heatmap,omitempty"`
+	OrderValue  []OrderValuePoint            `json:"order_value,omitempty"`
+}
+
+// OrderValuePoint is the average completed order value of one day or week (starting Monday)
+type OrderValuePoint struct {
+	Start             string  `json:"start"` // YYYY-MM-DD
+	OrderCount        int64   `json:"order_count"`
+	Revenue           float64 `json:"revenue"`
+	AverageOrderValue float64 `json:"average_order_value"`
+}
+
+// GetAnalyticsBreakdown breaks the revenue of a period down by item, category, weekday and hour, or
+// into the average order value trend; limit bounds the number of items (10 by default)
+func (s *DashboardService) GetAnalyticsBreakdown(
+	ctx context.Context,
+	restaurantID uint,
+	dimension, period string,
+	limit int,
+) (*AnalyticsBreakdown, error) {
+	switch dimension {
+	case BreakdownItems, BreakdownCategories, BreakdownHeatmap, BreakdownOrderValue:
+	default:
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "dimension must be one of: items, categories, heatmap, order_value")
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > maxBreakdownItems {
+		limit = maxBreakdownItems
+	}
+
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	location := settingsLocation(settings)
+	reporting := restaurantPeriod(location, period)
+	start, end := reporting.Start, reporting.end()
+
+	breakdown := &AnalyticsBreakdown{
+		Dimension: dimension,
+		Period:    period,
+		StartDate: start.Format(time.RFC3339),
+		EndDate:   reporting.last().Format(time.RFC3339),
+		TimeZone:  location.String(),
+	}
+	switch dimension {
+	case BreakdownItems:
+		breakdown.Items, err = s.orderRepo.GetTopItems(ctx, restaurantID, start, end, limit)
+	case BreakdownCategories:
+		breakdown.Categories, err = s.orderRepo.GetCategorySales(ctx, restaurantID, start, end)
+	case BreakdownHeatmap:
+		breakdown.Heatmap, err = s.orderRepo.GetRevenueHeatmap(ctx, restaurantID, location.String(), start, end)
+	case BreakdownOrderValue:
+		breakdown.Granularity = LaborGranularityDay
+		if period == "year" {
+			breakdown.Granularity = LaborGranularityWeek
+		}
+		breakdown.OrderValue, err = s.orderValueTrend(ctx, restaurantID, location, breakdown.Granularity, start, end)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s breakdown: %w", dimension, err)
+	}
+	return breakdown, nil
+}
+
+// orderValueTrend returns the average order value of every day or week in [start, end)
+func (s *DashboardService) orderValueTrend(
+	ctx context.Context,
+	restaurantID uint,
+	location *time.Location,
+	granularity string,
+	start, end time.Time,
+) ([]OrderValuePoint, error) {
+	buckets, err := s.orderRepo.GetOrderValueBuckets(ctx, restaurantID, granularity, location.String(), start, end)
+	if err != nil {
+		return nil, err
+	}
+	byStart := make(map[string]repositories.OrderValueBucket, len(buckets))
+	for _, bucket := range buckets {
+		byStart[bucket.Bucket.Format(time.DateOnly)] = bucket
+	}
+
+	points := []OrderValuePoint{}
+	for bucket := laborBucketStart(start, granularity); bucket.Before(end); bucket = nextLaborBucket(bucket, granularity) {
+		point := OrderValuePoint{Start: bucket.Format(time.DateOnly)}
+		if values, ok := byStart[point.Start]; ok && values.OrderCount > 0 {
+			point.OrderCount = values.OrderCount
+			point.Revenue = money.Round(values.Revenue)
+			point.AverageOrderValue = money.Round(values.Revenue / float64(values.OrderCount))
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// Labor report granularities
+const (
+	LaborGranularityDay  = "day"
+	LaborGranularityWeek = "week"
+)
+
+// maxLaborReportDays bounds the date range of a labor report
+const maxLaborReportDays = 366
+
+// LaborReport compares the labor cost of worked shifts with the revenue of completed orders
+// Amounts are in Currency; LaborPercent is omitted when there was no revenue
+type LaborReport struct {
+	Granularity  string        `json:"granularity"`
+	TimeZone     string        `json:"time_zone"`
+	Currency     string        `json:"currency"`
+	HoursWorked  float64       `json:"hours_worked"`
+	LaborCost    money.Cents   `json:"labor_cost"`
+	Revenue      money.Cents   `json:"revenue"`
+	LaborPercent *float64      `json:"labor_percent,omitempty"`
+	Periods      []LaborPeriod `json:"periods"`
+}
+
+// LaborPeriod is the labor cost and revenue of one day or week (starting Monday)
+// Shifts count toward the day they were clocked in
+type LaborPeriod struct {
+	Start        string      `json:"start"` // YYYY-MM-DD
+	HoursWorked  float64     `json:"hours_worked"`
+	LaborCost    money.Cents `json:"labor_cost"`
+	Revenue      money.Cents `json:"revenue"`
+	LaborPercent *float64    `json:"labor_percent,omitempty"`
+}
+
+// GetLaborReport returns the labor cost against revenue per day or week between two dates
+// (inclusive, in the restaurant's time zone), the last 7 days or 8 weeks by default
+func (s *DashboardService) GetLaborReport(ctx context.Context, restaurantID uint, granularity, from, to string) (*LaborReport, error) {
+	defaultDays := 7
+	switch granularity {
+	case "", LaborGranularityDay:
+		granularity = LaborGranularityDay
+	case LaborGranularityWeek:
+		defaultDays = 8 * 7
+	default:
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "granularity must be day or week")
+	}
+
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := dateRangeIn(settingsLocation(settings), from, to, defaultDays, maxLaborReportDays)
+	if err != nil {
+		return nil, err
+	}
+	return s.laborReport(ctx, restaurantID, settings, granularity, start, end)
+}
+
+// getPeriodLabor returns the labor report of an analytics period: daily, weekly for a year
+func (s *DashboardService) getPeriodLabor(
+	ctx context.Context,
+	restaurantID uint,
+	settings *models.RestaurantSettings,
+	period string,
+	reporting reportingPeriod,
+) (*LaborReport, error) {
+	granularity := LaborGranularityDay
+	if period == "year" {
+		granularity = LaborGranularityWeek
+	}
+	return s.laborReport(ctx, restaurantID, settings, granularity, reporting.Start, reporting.end())
+}
+
+// laborReport builds the labor report of [start, end), with an entry for every day or week
+func (s *DashboardService) laborReport(
+	ctx context.Context,
+	restaurantID uint,
+	settings *models.RestaurantSettings,
+	granularity string,
+	start, end time.Time,
+) (*LaborReport, error) {
+	location := settingsLocation(settings)
+	timeZone := location.String()
+
+	var laborBuckets []repositories.LaborBucket
+	var revenueBuckets []repositories.RevenueBucket
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		buckets, err := s.timeEntryRepo.GetLaborBuckets(gctx, restaurantID, granularity, timeZone, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to get labor cost: %w", err)
+		}
+		laborBuckets = buckets
+		return nil
+	})
+	g.Go(func() error {
+		buckets, err := s.orderRepo.GetRevenueBuckets(gctx, restaurantID, granularity, timeZone, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to get revenue: %w", err)
+		}
+		revenueBuckets = buckets
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Buckets are wall-clock dates in the restaurant's time zone
+	periods := make(map[string]*LaborPeriod)
+	report := &LaborReport{
+		Granularity: granularity,
+		TimeZone:    timeZone,
+		Currency:    settings.Currency,
+		Periods:     []LaborPeriod{},
+	}
+	for bucket := laborBucketStart(start, granularity); bucket.Before(end); bucket = nextLaborBucket(bucket, granularity) {
+		report.Periods = append(report.Periods, LaborPeriod{Start: bucket.Format(time.DateOnly)})
+	}
+	for i := range report.Periods {
+		periods[report.Periods[i].Start] = &report.Periods[i]
+	}
+
+	for _, bucket := range laborBuckets {
+		report.HoursWorked += bucket.Hours
+		report.LaborCost += bucket.Labor
+		if period, ok := periods[bucket.Bucket.Format(time.DateOnly)]; ok {
+			period.HoursWorked = roundHours(bucket.Hours)
+			period.LaborCost = bucket.Labor
+		}
+	}
+	for _, bucket := range revenueBuckets {
+		report.Revenue += bucket.Revenue
+		if period, ok := periods[bucket.Bucket.Format(time.DateOnly)]; ok {
+			period.Revenue = bucket.Revenue
+		}
+	}
+
+	report.HoursWorked = roundHours(report.HoursWorked)
+	report.LaborPercent = laborPercent(report.LaborCost, report.Revenue)
+	for i := range report.Periods {
+		report.Periods[i].LaborPercent = laborPercent(report.Periods[i].LaborCost, report.Periods[i].Revenue)
+	}
+	return report, nil
+}
+
+// settings returns the restaurant's settings, falling back to the defaults
+func (s *DashboardService) settings(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+	return settings, nil
+}
+
+// laborBucketStart returns the start of the day or week (Monday, like PostgreSQL's date_trunc) containing t
+func laborBucketStart(t time.Time, granularity string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if granularity == LaborGranularityWeek {
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return day
+}
+
+// nextLaborBucket returns the start of the day or week after bucket
+func nextLaborBucket(bucket time.Time, granularity string) time.Time {
+	if granularity == LaborGranularityWeek {
+		return bucket.AddDate(0, 0, 7)
+	}
+	return bucket.AddDate(0, 0, 1)
+}
+
+// laborPercent returns labor cost as a percentage of revenue, rounded to 2 decimals, nil without revenue
+func laborPercent(labor, revenue money.Cents) *float64 {
+	if revenue <= 0 {
+		return nil
+	}
+	percent := math.Round(float64(labor)*10000/float64(revenue)) / 100
+	return &percent
+}
+
+// roundHours rounds worked hours to 2 decimals
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// rollupLookbackDays is how many past days the full rollup rebuilds, catching late changes
+// the incremental rollup missed, e.g. deleted orders
+const rollupLookbackDays = 7
+
+// RollupJob is the scheduled job that rebuilds the recent daily rollups of every restaurant;
+// a zero interval disables it
+func (s *DashboardService) RollupJob(interval time.Duration) Job {
+	return Job{Name: "analytics_rollup", Interval: interval, Run: s.rebuildRecentDays}
+}
+
+// IncrementalRollupJob is the scheduled job that rebuilds the daily rollups of the days whose orders
+// or reservations changed since its previous run; a zero interval disables it
+func (s *DashboardService) IncrementalRollupJob(interval time.Duration) Job {
+	return Job{
+		Name:     "analytics_rollup_incremental",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			// Look back two intervals so a late or failed run doesn't leave a gap
+			return s.rebuildChangedDays(ctx, time.Now().Add(-2*interval))
+		},
+	}
+}
+
+// rebuildRecentDays rebuilds the last rollupLookbackDays days before today of every restaurant
+func (s *DashboardService) rebuildRecentDays(ctx context.Context) error {
+	zones, err := s.dailyStatsRepo.ListRestaurantTimeZonesWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list restaurants: %w", err)
+	}
+
+	var errs []error
+	for _, zone := range zones {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		location := settingsLocation(&models.RestaurantSettings{TimeZone: zone.TimeZone})
+		today := restaurantPeriod(location, "today").Today
+		from := today.AddDate(0, 0, -rollupLookbackDays)
+		if err := s.dailyStatsRepo.RebuildWithContext(ctx, zone.RestaurantID, location.String(),
+			from.Format(time.DateOnly), today.Format(time.DateOnly)); err != nil {
+			errs = append(errs, fmt.Errorf("restaurant %d: %w", zone.RestaurantID, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to rebuild daily stats: %w", errors.Join(errs...))
+	}
+
+	logger.Info("Rebuilt daily restaurant stats",
+		zap.Int("restaurants", len(zones)),
+		zap.Int("days", rollupLookbackDays),
+	)
+	return nil
+}
+
+// rebuildChangedDays rebuilds the days of the orders and reservations updated since the given time
+func (s *DashboardService) rebuildChangedDays(ctx context.Context, since time.Time) error {
+	days, err := s.dailyStatsRepo.ListChangedDaysWithContext(ctx, since)
+	if err != nil {
+		return fmt.Errorf("failed to list changed days: %w", err)
+	}
+
+	var errs []error
+	for _, day := range days {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		location := settingsLocation(&models.RestaurantSettings{TimeZone: day.TimeZone})
+		date := day.StatDate.Format(time.DateOnly)
+		next := day.StatDate.AddDate(0, 0, 1).Format(time.DateOnly)
+		if err := s.dailyStatsRepo.RebuildWithContext(ctx, day.RestaurantID, location.String(), date, next); err != nil {
+			errs = append(errs, fmt.Errorf("restaurant %d, %s: %w", day.RestaurantID, date, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to rebuild daily stats: %w", errors.Join(errs...))
+	}
+
+	if len(days) > 0 {
+		logger.Info("Rebuilt changed daily restaurant stats", zap.Int("days", len(days)))
+	}
+	return nil
+}
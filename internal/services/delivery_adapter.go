@@ -0,0 +1,119 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/models"
+)
+
+// DeliveryAdapter connects to a third-party delivery platform
+// Menu items are pushed with our menu item IDs as the platform's external item IDs,
+// so pulled order items reference them back
+type DeliveryAdapter interface {
+	Provider() string
+	PushMenu(ctx context.Context, integration *models.DeliveryIntegration, menu *DeliveryMenu) error
+	PullOrders(ctx context.Context, integration *models.DeliveryIntegration, since time.Time) ([]ExternalOrder, error)
+}
+
+// DeliveryMenu is a restaurant's menu in platform-neutral form
+type DeliveryMenu struct {
+	Categories []DeliveryMenuCategory `json:"categories"`
+}
+
+// DeliveryMenuCategory is a category of a DeliveryMenu
+type DeliveryMenuCategory struct {
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Items       []DeliveryMenuItem `json:"items"`
+}
+
+// DeliveryMenuItem is an item of a DeliveryMenu
+type DeliveryMenuItem struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	PriceCents  int64  `json:"price_cents"`
+	Available   bool   `json:"available"`
+}
+
+// ExternalOrder is an order pulled from a delivery platform, normalized across platforms
+type ExternalOrder struct {
+	ExternalID   string
+	CustomerName string
+	Notes        string
+	PlacedAt     time.Time
+	Items        []ExternalOrderItem
+}
+
+// ExternalOrderItem is an item of an ExternalOrder
+type ExternalOrderItem struct {
+	MenuItemID     string // Our menu item ID, as pushed with the menu
+	Name           string
+	Quantity       int
+	UnitPriceCents int64 // Price charged by the platform
+	Notes          string
+}
+
+// NewDeliveryAdapters creates the adapters of every supported delivery platform keyed by provider
+func NewDeliveryAdapters(cfg *config.Config) map[string]DeliveryAdapter {
+	client := newPublicHTTPClient(15 * time.Second)
+	adapters := []DeliveryAdapter{
+		&uberEatsAdapter{baseURL: cfg.UberEatsAPIURL, client: client},
+		&deliverooAdapter{baseURL: cfg.DeliverooAPIURL, client: client},
+	}
+
+	byProvider := make(map[string]DeliveryAdapter, len(adapters))
+	for _, adapter := range adapters {
+		byProvider[adapter.Provider()] = adapter
+	}
+	return byProvider
+}
+
+// deliveryRequest sends a JSON request to a delivery platform and decodes the JSON response into out (if not nil)
+func deliveryRequest(ctx context.Context, client *http.Client, method, url, token string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s returned %d: %s", method, url, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/models"
+)
+
+// deliverooAdapter pushes menus to and pulls orders from the Deliveroo Partner Platform API
+// Deliveroo menus belong to a brand and are assigned to sites, so both IDs are required
+type deliverooAdapter struct {
+	baseURL string
+	client  *http.Client
+}
+
+type deliverooText struct {
+	En string `json:"en"`
+}
+
+type deliverooMenuPayload struct {
+	Name    string   `json:"name"`
+	SiteIDs []string `json:"site_ids"`
+	Menu    struct {
+		Categories []deliverooCategory `json:"categories"`
+		Items      []deliverooItem     `json:"items"`
+	} `json:"menu"`
+}
+
+type deliverooCategory struct {
+	ID          string        `json:"id"`
+	Name        deliverooText `json:"name"`
+	Description deliverooText `json:"description"`
+	ItemIDs     []string      `json:"item_ids"`
+}
+
+type deliverooItem struct {
+	ID          string        `json:"id"`
+	PLU         string        `json:"plu"`
+	Name        deliverooText `json:"name"`
+	Description deliverooText `json:"description"`
+	PriceInfo   struct {
+		Price int64 `json:"price"` // Minor units
+	} `json:"price_info"`
+	Available bool `json:"available"`
+}
+
+type deliverooOrdersResponse struct {
+	Orders []struct {
+		ID        string    `json:"id"`
+		Status    string    `json:"status"`
+		CreatedAt time.Time `json:"created_at"`
+		Notes     string    `json:"notes"`
+		Customer  struct {
+			FirstName string `json:"first_name"`
+		} `json:"customer"`
+		Items []struct {
+			PosItemID string `json:"pos_item_id"`
+			Name      string `json:"name"`
+			Quantity  int    `json:"quantity"`
+			UnitPrice struct {
+				Fractional int64 `json:"fractional"` // Minor units
+			} `json:"unit_price"`
+		} `json:"items"`
+	} `json:"orders"`
+	Next string `json:"next"` // Cursor URL of the next page, empty on the last page
+}
+
+// deliverooMaxPages bounds a single pull; the rest is fetched on the next pull
+const deliverooMaxPages = 20
+
+func (a *deliverooAdapter) Provider() string {
+	return models.DeliveryProviderDeliveroo
+}
+
+// PushMenu replaces the brand menu assigned to the site
+func (a *deliverooAdapter) PushMenu(ctx context.Context, integration *models.DeliveryIntegration, menu *DeliveryMenu) error {
+	if integration.ExternalBrandID == "" {
+		return errors.New("deliveroo integrations require an external brand ID")
+	}
+
+	var payload deliverooMenuPayload
+	payload.Name = "Menu"
+	payload.SiteIDs = []string{integration.ExternalStoreID}
+	payload.Menu.Categories = []deliverooCategory{}
+	payload.Menu.Items = []deliverooItem{}
+
+	for _, category := range menu.Categories {
+		itemIDs := make([]string, 0, len(category.Items))
+		for _, item := range category.Items {
+			itemIDs = append(itemIDs, item.ID)
+
+			deliverooItem := deliverooItem{
+				ID:          item.ID,
+				PLU:         item.ID,
+				Name:        deliverooText{En: item.Name},
+				Description: deliverooText{En: item.Description},
+				Available:   item.Available,
+			}
+			deliverooItem.PriceInfo.Price = item.PriceCents
+			payload.Menu.Items = append(payload.Menu.Items, deliverooItem)
+		}
+		payload.Menu.Categories = append(payload.Menu.Categories, deliverooCategory{
+			ID:          category.ID,
+			Name:        deliverooText{En: category.Name},
+			Description: deliverooText{En: category.Description},
+			ItemIDs:     itemIDs,
+		})
+	}
+
+	// One menu per site, so each site's push replaces only its own menu
+	endpoint := fmt.Sprintf("%s/menu/v1/brands/%s/menus/site-%s",
+		strings.TrimRight(a.baseURL, "/"),
+		url.PathEscape(integration.ExternalBrandID),
+		url.PathEscape(integration.ExternalStoreID),
+	)
+	return deliveryRequest(ctx, a.client, http.MethodPut, endpoint, integration.AccessToken, payload, nil)
+}
+
+// PullOrders lists the site's orders placed after since
+func (a *deliverooAdapter) PullOrders(ctx context.Context, integration *models.DeliveryIntegration, since time.Time) ([]ExternalOrder, error) {
+	if integration.ExternalBrandID == "" {
+		return nil, errors.New("deliveroo integrations require an external brand ID")
+	}
+
+	endpoint := fmt.Sprintf("%s/order/v2/brand/%s/restaurant/%s/orders?start_date=%s",
+		strings.TrimRight(a.baseURL, "/"),
+		url.PathEscape(integration.ExternalBrandID),
+		url.PathEscape(integration.ExternalStoreID),
+		url.QueryEscape(since.UTC().Format(time.RFC3339)),
+	)
+
+	var orders []ExternalOrder
+	for page := 0; endpoint != "" && page < deliverooMaxPages; page++ {
+		var resp deliverooOrdersResponse
+		if err := deliveryRequest(ctx, a.client, http.MethodGet, endpoint, integration.AccessToken, nil, &resp); err != nil {
+			return nil, err
+		}
+
+		for _, o := range resp.Orders {
+			if !o.CreatedAt.After(since) || o.Status == "canceled" || o.Status == "rejected" {
+				continue
+			}
+			order := ExternalOrder{
+				ExternalID:   o.ID,
+				CustomerName: o.Customer.FirstName,
+				Notes:        o.Notes,
+				PlacedAt:     o.CreatedAt,
+			}
+			for _, item := range o.Items {
+				order.Items = append(order.Items, ExternalOrderItem{
+					MenuItemID:     item.PosItemID,
+					Name:           item.Name,
+					Quantity:       item.Quantity,
+					UnitPriceCents: item.UnitPrice.Fractional,
+				})
+			}
+			orders = append(orders, order)
+		}
+		endpoint = resp.Next
+	}
+	return orders, nil
+}
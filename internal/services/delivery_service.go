@@ -0,0 +1,550 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/metrics"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// DeliveryService connects restaurants to delivery platforms: it pushes their menus and
+// imports the platforms' orders as internal orders
+type DeliveryService struct {
+	integrationRepo *repositories.DeliveryIntegrationRepository
+	menuItemRepo    *repositories.MenuItemRepository
+	orderRepo       *repositories.OrderRepository
+	userRepo        *repositories.UserRepository
+	adapters        map[string]DeliveryAdapter
+	notifications   *NotificationService
+	prepTimes       *PrepTimeService
+	subscription    *SubscriptionService
+}
+
+// NewDeliveryService creates a new DeliveryService instance
+func NewDeliveryService(
+	integrationRepo *repositories.DeliveryIntegrationRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	orderRepo *repositories.OrderRepository,
+	userRepo *repositories.UserRepository,
+	adapters map[string]DeliveryAdapter,
+	notifications *NotificationService,
+	prepTimes *PrepTimeService,
+	subscription *SubscriptionService,
+) *DeliveryService {
+	return &DeliveryService{
+		integrationRepo: integrationRepo,
+		menuItemRepo:    menuItemRepo,
+		orderRepo:       orderRepo,
+		userRepo:        userRepo,
+		adapters:        adapters,
+		notifications:   notifications,
+		prepTimes:       prepTimes,
+		subscription:    subscription,
+	}
+}
+
+// CreateDeliveryIntegrationRequest represents a request to connect a delivery platform
+type CreateDeliveryIntegrationRequest struct {
+	Provider        string `json:"provider" binding:"required,oneof=uber_eats deliveroo"`
+	ExternalStoreID string `json:"external_store_id" binding:"required,max=100"`
+	ExternalBrandID string `json:"external_brand_id" binding:"max=100"` // Required by Deliveroo
+	AccessToken     string `json:"access_token" binding:"required"`
+}
+
+// UpdateDeliveryIntegrationRequest represents a request to update a delivery platform connection
+type UpdateDeliveryIntegrationRequest struct {
+	ExternalStoreID *string `json:"external_store_id" binding:"omitempty,min=1,max=100"`
+	ExternalBrandID *string `json:"external_brand_id" binding:"omitempty,max=100"`
+	AccessToken     *string `json:"access_token" binding:"omitempty,min=1"`
+	Enabled         *bool   `json:"enabled"`
+}
+
+// OrderImportResult summarizes an order pull
+type OrderImportResult struct {
+	Imported   int `json:"imported"`
+	Duplicates int `json:"duplicates"` // Already imported by an earlier pull
+	Failed     int `json:"failed"`     // Orders referencing unknown menu items, see the server log
+}
+
+// ListIntegrations lists the delivery platforms a restaurant is connected to
+func (s *DeliveryService) ListIntegrations(ctx context.Context, restaurantID uint) ([]models.DeliveryIntegration, error) {
+	return s.integrationRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// CreateIntegration connects a restaurant to a delivery platform
+// Imported orders are attributed to an inactive Client account of the platform, created on first connect
+func (s *DeliveryService) CreateIntegration(ctx context.Context, req *CreateDeliveryIntegrationRequest, restaurantID uint) (*models.DeliveryIntegration, error) {
+	if _, ok := s.adapters[req.Provider]; !ok {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidProvider, "unsupported delivery provider")
+	}
+	if req.Provider == models.DeliveryProviderDeliveroo && strings.TrimSpace(req.ExternalBrandID) == "" {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidProvider, "deliveroo integrations require an external brand ID")
+	}
+
+	channelUser, err := s.channelUser(ctx, req.Provider, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	integration := &models.DeliveryIntegration{
+		RestaurantID:    restaurantID,
+		Provider:        req.Provider,
+		ExternalStoreID: strings.TrimSpace(req.ExternalStoreID),
+		ExternalBrandID: strings.TrimSpace(req.ExternalBrandID),
+		AccessToken:     req.AccessToken,
+		Enabled:         true,
+		ChannelUserID:   channelUser.ID,
+	}
+	if err := s.integrationRepo.CreateWithContext(ctx, integration); err != nil {
+		if errors.Is(err, repositories.ErrDeliveryIntegrationExists) {
+			return nil, apperrors.Conflict(apperrors.CodeIntegrationExists, err.Error())
+		}
+		return nil, err
+	}
+
+	return integration, nil
+}
+
+// UpdateIntegration updates a delivery platform connection
+// Changing the store or brand pushes the menu again on the next sync
+func (s *DeliveryService) UpdateIntegration(ctx context.Context, id uint, req *UpdateDeliveryIntegrationRequest, restaurantID uint) (*models.DeliveryIntegration, error) {
+	integration, err := s.getIntegration(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.ExternalStoreID != nil && strings.TrimSpace(*req.ExternalStoreID) != integration.ExternalStoreID {
+		integration.ExternalStoreID = strings.TrimSpace(*req.ExternalStoreID)
+		integration.MenuHash = ""
+	}
+	if req.ExternalBrandID != nil && strings.TrimSpace(*req.ExternalBrandID) != integration.ExternalBrandID {
+		integration.ExternalBrandID = strings.TrimSpace(*req.ExternalBrandID)
+		integration.MenuHash = ""
+	}
+	if req.AccessToken != nil {
+		integration.AccessToken = *req.AccessToken
+	}
+	if req.Enabled != nil {
+		integration.Enabled = *req.Enabled
+	}
+	if integration.Provider == models.DeliveryProviderDeliveroo && integration.ExternalBrandID == "" {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidProvider, "deliveroo integrations require an external brand ID")
+	}
+
+	if err := s.integrationRepo.SaveWithContext(ctx, integration); err != nil {
+		return nil, err
+	}
+	return integration, nil
+}
+
+// DeleteIntegration disconnects a delivery platform; imported orders are kept
+func (s *DeliveryService) DeleteIntegration(ctx context.Context, id uint, restaurantID uint) error {
+	if err := s.integrationRepo.DeleteWithContext(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeIntegrationNotFound, "delivery integration not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// SyncMenu pushes the restaurant's menu to the platform, even if it is unchanged
+func (s *DeliveryService) SyncMenu(ctx context.Context, id uint, restaurantID uint) (*models.DeliveryIntegration, error) {
+	integration, err := s.getIntegration(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.pushMenu(ctx, integration, true); err != nil {
+		return nil, apperrors.Wrap(err, http.StatusBadGateway, apperrors.CodeDeliverySyncFailed, "menu could not be pushed to the delivery platform")
+	}
+	return integration, nil
+}
+
+// PullOrders imports the orders placed on the platform since the last pull
+func (s *DeliveryService) PullOrders(ctx context.Context, id uint, restaurantID uint) (*OrderImportResult, error) {
+	integration, err := s.getIntegration(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.pullOrders(ctx, integration)
+	if err != nil {
+		return nil, apperrors.Wrap(err, http.StatusBadGateway, apperrors.CodeDeliverySyncFailed, "orders could not be pulled from the delivery platform")
+	}
+	return result, nil
+}
+
+// DeliverySyncJob is the scheduled job that pushes changed menus and pulls new orders of every
+// enabled integration; a zero interval disables it
+func (s *DeliveryService) DeliverySyncJob(interval time.Duration) Job {
+	return Job{Name: "delivery_sync", Interval: interval, Run: s.syncAll}
+}
+
+// syncAll syncs every enabled integration; failures are recorded on the integration and logged
+// so one failing platform doesn't fail the run
+func (s *DeliveryService) syncAll(ctx context.Context) error {
+	integrations, err := s.integrationRepo.ListEnabledWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list delivery integrations: %w", err)
+	}
+
+	for i := range integrations {
+		integration := &integrations[i]
+		if err := s.pushMenu(ctx, integration, false); err != nil {
+			logger.Warn("Failed to push menu to delivery platform",
+				zap.Uint("integration_id", integration.ID),
+				zap.String("provider", integration.Provider),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		result, err := s.pullOrders(ctx, integration)
+		if err != nil {
+			logger.Warn("Failed to pull orders from delivery platform",
+				zap.Uint("integration_id", integration.ID),
+				zap.String("provider", integration.Provider),
+				zap.Error(err),
+			)
+			continue
+		}
+		if result.Imported > 0 {
+			logger.Info("Imported delivery orders",
+				zap.Uint("integration_id", integration.ID),
+				zap.String("provider", integration.Provider),
+				zap.Int("imported", result.Imported),
+			)
+		}
+	}
+	return nil
+}
+
+// pushMenu pushes the menu when it changed since the last push (or always with force)
+func (s *DeliveryService) pushMenu(ctx context.Context, integration *models.DeliveryIntegration, force bool) error {
+	adapter, ok := s.adapters[integration.Provider]
+	if !ok {
+		return fmt.Errorf("unsupported delivery provider %q", integration.Provider)
+	}
+
+	menu, err := s.buildMenu(ctx, integration.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(menu)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(payload)
+	hash := hex.EncodeToString(sum[:])
+	if !force && hash == integration.MenuHash {
+		return nil
+	}
+
+	if err := adapter.PushMenu(ctx, integration, menu); err != nil {
+		s.recordError(ctx, integration, err)
+		return err
+	}
+
+	now := time.Now()
+	integration.MenuHash = hash
+	integration.LastMenuSyncAt = &now
+	integration.LastError = ""
+	return s.integrationRepo.UpdateSyncStateWithContext(ctx, integration.ID, map[string]interface{}{
+		"menu_hash":         hash,
+		"last_menu_sync_at": now,
+		"last_error":        "",
+	})
+}
+
+// pullOrders imports the orders placed since the last pull (or since the integration was created)
+// The pull cursor advances past orders that failed to import, so they are reported once rather than on every pull
+// Imported orders count toward the plan's monthly orders; at the limit the pull stops and is retried by later pulls
+func (s *DeliveryService) pullOrders(ctx context.Context, integration *models.DeliveryIntegration) (*OrderImportResult, error) {
+	adapter, ok := s.adapters[integration.Provider]
+	if !ok {
+		return nil, fmt.Errorf("unsupported delivery provider %q", integration.Provider)
+	}
+
+	since := integration.CreatedAt
+	if integration.LastOrderPullAt != nil {
+		since = *integration.LastOrderPullAt
+	}
+
+	externalOrders, err := adapter.PullOrders(ctx, integration, since)
+	if err != nil {
+		s.recordError(ctx, integration, err)
+		return nil, err
+	}
+
+	menuItems, err := s.menuItemRepo.GetByRestaurantIDWithContext(ctx, integration.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]*models.MenuItem, len(menuItems))
+	for i := range menuItems {
+		byID[menuItems[i].ID] = &menuItems[i]
+	}
+
+	result := &OrderImportResult{}
+	cursor := since
+	for i := range externalOrders {
+		externalOrder := &externalOrders[i]
+		if externalOrder.PlacedAt.After(cursor) {
+			cursor = externalOrder.PlacedAt
+		}
+
+		exists, err := s.orderRepo.ExistsExternalWithContext(ctx, integration.RestaurantID, integration.Provider, externalOrder.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			result.Duplicates++
+			continue
+		}
+
+		order, err := normalizeExternalOrder(integration, externalOrder, byID)
+		if err != nil {
+			result.Failed++
+			logger.Warn("Failed to import delivery order",
+				zap.Uint("integration_id", integration.ID),
+				zap.String("provider", integration.Provider),
+				zap.String("external_id", externalOrder.ExternalID),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		if err := s.subscription.CheckLimit(ctx, integration.RestaurantID, PlanResourceMonthlyOrders); err != nil {
+			s.recordError(ctx, integration, err)
+			return nil, err
+		}
+		if err := s.orderRepo.CreateWithContext(ctx, order); err != nil {
+			// A concurrent pull may have imported the order first (unique external ID)
+			if exists, _ := s.orderRepo.ExistsExternalWithContext(ctx, integration.RestaurantID, integration.Provider, externalOrder.ExternalID); exists {
+				result.Duplicates++
+				continue
+			}
+			return nil, err
+		}
+		metrics.IncrementOrdersCreated(strconv.FormatUint(uint64(integration.RestaurantID), 10), order.Status)
+		s.notifications.NotifyNewOrder(ctx, order)
+		result.Imported++
+	}
+
+	// Imported orders join the kitchen queue, which changes every queued order's estimate
+	if result.Imported > 0 {
+		if err := s.prepTimes.Refresh(ctx, integration.RestaurantID); err != nil {
+			logger.Warn("Failed to refresh kitchen prep estimates",
+				zap.Uint("restaurant_id", integration.RestaurantID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	integration.LastOrderPullAt = &cursor
+	integration.LastError = ""
+	if err := s.integrationRepo.UpdateSyncStateWithContext(ctx, integration.ID, map[string]interface{}{
+		"last_order_pull_at": cursor,
+		"last_error":         "",
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// normalizeExternalOrder maps a platform order onto an internal order
+// Items must reference the restaurant's menu items; prices are the ones the platform charged
+func normalizeExternalOrder(integration *models.DeliveryIntegration, externalOrder *ExternalOrder, menuItems map[uint]*models.MenuItem) (*models.Order, error) {
+	if externalOrder.ExternalID == "" {
+		return nil, errors.New("order has no external ID")
+	}
+	if len(externalOrder.Items) == 0 {
+		return nil, errors.New("order has no items")
+	}
+
+	order := &models.Order{
+		RestaurantID: integration.RestaurantID,
+		UserID:       integration.ChannelUserID,
+		Status:       models.OrderStatusPending,
+		Source:       integration.Provider,
+		ExternalID:   externalOrder.ExternalID,
+		Channel:      models.OrderChannelThirdParty,
+		Notes:        externalOrderNotes(externalOrder),
+	}
+
+	var totalCents int64
+	for _, item := range externalOrder.Items {
+		id, err := strconv.ParseUint(item.MenuItemID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("item %q does not reference a menu item", item.Name)
+		}
+		menuItem, ok := menuItems[uint(id)]
+		if !ok {
+			return nil, fmt.Errorf("item %q references unknown menu item %d", item.Name, id)
+		}
+		if item.Quantity < 1 {
+			return nil, fmt.Errorf("item %q has invalid quantity %d", item.Name, item.Quantity)
+		}
+
+		totalCents += item.UnitPriceCents * int64(item.Quantity)
+		order.OrderItems = append(order.OrderItems, models.OrderItem{
+			RestaurantID: integration.RestaurantID,
+			MenuItemID:   menuItem.ID,
+			Quantity:     item.Quantity,
+			Price:        money.Cents(item.UnitPriceCents),
+			Name:         menuItem.Name,
+			Notes:        strings.TrimSpace(item.Notes),
+		})
+	}
+	order.TotalAmount = money.Cents(totalCents)
+
+	return order, nil
+}
+
+// externalOrderNotes combines the customer's name and notes, as the channel user is shared by all platform customers
+func externalOrderNotes(externalOrder *ExternalOrder) string {
+	var parts []string
+	if name := strings.TrimSpace(externalOrder.CustomerName); name != "" {
+		parts = append(parts, "Customer: "+name)
+	}
+	if notes := strings.TrimSpace(externalOrder.Notes); notes != "" {
+		parts = append(parts, notes)
+	}
+	return strings.Join(parts, "\n")
+}
+
+// buildMenu builds the restaurant's menu in platform-neutral form
+// Only the published menu is pushed, without items of inactive categories; unavailable items are pushed as unavailable
+func (s *DeliveryService) buildMenu(ctx context.Context, restaurantID uint) (*DeliveryMenu, error) {
+	menuItems, err := s.menuItemRepo.GetPublishedByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[uint]*models.MenuCategory)
+	itemsByCategory := make(map[uint][]DeliveryMenuItem)
+	for i := range menuItems {
+		item := &menuItems[i]
+		if !item.Category.IsActive {
+			continue
+		}
+		categories[item.CategoryID] = &item.Category
+		itemsByCategory[item.CategoryID] = append(itemsByCategory[item.CategoryID], DeliveryMenuItem{
+			ID:          strconv.FormatUint(uint64(item.ID), 10),
+			Name:        item.Name,
+			Description: item.Description,
+			PriceCents:  int64(item.PriceFor(models.OrderChannelThirdParty)),
+			Available:   item.IsAvailable,
+		})
+	}
+
+	ordered := make([]*models.MenuCategory, 0, len(categories))
+	for _, category := range categories {
+		ordered = append(ordered, category)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].DisplayOrder != ordered[j].DisplayOrder {
+			return ordered[i].DisplayOrder < ordered[j].DisplayOrder
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	menu := &DeliveryMenu{Categories: make([]DeliveryMenuCategory, 0, len(ordered))}
+	for _, category := range ordered {
+		menu.Categories = append(menu.Categories, DeliveryMenuCategory{
+			ID:          strconv.FormatUint(uint64(category.ID), 10),
+			Name:        category.Name,
+			Description: category.Description,
+			Items:       itemsByCategory[category.ID],
+		})
+	}
+	return menu, nil
+}
+
+// channelUser returns the restaurant's Client account for orders from a provider, creating it if needed
+// The account cannot sign in: it is inactive and its password hash matches no password
+func (s *DeliveryService) channelUser(ctx context.Context, provider string, restaurantID uint) (*models.User, error) {
+	email := fmt.Sprintf("%s.orders+r%d@delivery.invalid", strings.ReplaceAll(provider, "_", "-"), restaurantID)
+	user, err := s.userRepo.GetByEmailWithContext(ctx, email, restaurantID)
+	if err == nil {
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	user = &models.User{
+		RestaurantID: restaurantID,
+		Email:        email,
+		PasswordHash: "!",
+		FirstName:    deliveryProviderName(provider),
+		LastName:     "Orders",
+		Role:         "Client",
+		IsActive:     false,
+	}
+	if err := s.userRepo.CreateWithContext(ctx, user); err != nil {
+		return nil, err
+	}
+	// IsActive defaults to true in the database, so a false value is not inserted
+	if err := s.userRepo.UpdateUserStatus(ctx, user.ID, false); err != nil {
+		return nil, err
+	}
+	user.IsActive = false
+	return user, nil
+}
+
+// deliveryProviderName returns a provider's display name
+func deliveryProviderName(provider string) string {
+	switch provider {
+	case models.DeliveryProviderUberEats:
+		return "Uber Eats"
+	case models.DeliveryProviderDeliveroo:
+		return "Deliveroo"
+	default:
+		return provider
+	}
+}
+
+// recordError stores the last sync error on the integration so restaurant admins can see it
+func (s *DeliveryService) recordError(ctx context.Context, integration *models.DeliveryIntegration, syncErr error) {
+	message := syncErr.Error()
+	if len(message) > 500 {
+		message = message[:500]
+	}
+	integration.LastError = message
+	if err := s.integrationRepo.UpdateSyncStateWithContext(ctx, integration.ID, map[string]interface{}{"last_error": message}); err != nil {
+		logger.Warn("Failed to record delivery sync error", zap.Uint("integration_id", integration.ID), zap.Error(err))
+	}
+}
+
+// getIntegration retrieves an integration of the restaurant
+func (s *DeliveryService) getIntegration(ctx context.Context, id uint, restaurantID uint) (*models.DeliveryIntegration, error) {
+	integration, err := s.integrationRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeIntegrationNotFound, "delivery integration not found")
+		}
+		return nil, err
+	}
+	return integration, nil
+}
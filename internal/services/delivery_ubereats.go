@@ -0,0 +1,176 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/models"
+)
+
+// uberEatsAdapter pushes menus to and pulls orders from the Uber Eats Marketplace API
+type uberEatsAdapter struct {
+	baseURL string
+	client  *http.Client
+}
+
+// Uber Eats localizes every text field
+type uberEatsText struct {
+	Translations map[string]string `json:"translations"`
+}
+
+func uberEatsTextOf(text string) uberEatsText {
+	return uberEatsText{Translations: map[string]string{"en": text}}
+}
+
+type uberEatsMenuPayload struct {
+	Menus      []uberEatsMenu     `json:"menus"`
+	Categories []uberEatsCategory `json:"categories"`
+	Items      []uberEatsItem     `json:"items"`
+}
+
+type uberEatsMenu struct {
+	ID          string       `json:"id"`
+	Title       uberEatsText `json:"title"`
+	CategoryIDs []string     `json:"category_ids"`
+}
+
+type uberEatsCategory struct {
+	ID       string           `json:"id"`
+	Title    uberEatsText     `json:"title"`
+	Entities []uberEatsEntity `json:"entities"`
+}
+
+type uberEatsEntity struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type uberEatsItem struct {
+	ID             string              `json:"id"`
+	ExternalData   string              `json:"external_data"`
+	Title          uberEatsText        `json:"title"`
+	Description    uberEatsText        `json:"description"`
+	PriceInfo      uberEatsPriceInfo   `json:"price_info"`
+	SuspensionInfo *uberEatsSuspension `json:"suspension_info,omitempty"`
+}
+
+type uberEatsPriceInfo struct {
+	Price int64 `json:"price"` // Minor units
+}
+
+type uberEatsSuspension struct {
+	Suspension struct {
+		SuspendUntil int64  `json:"suspend_until"` // Unix seconds
+		Reason       string `json:"reason"`
+	} `json:"suspension"`
+}
+
+type uberEatsOrdersResponse struct {
+	Orders []struct {
+		ID           string    `json:"id"`
+		CurrentState string    `json:"current_state"`
+		PlacedAt     time.Time `json:"placed_at"`
+		Eater        struct {
+			FirstName string `json:"first_name"`
+			LastName  string `json:"last_name"`
+		} `json:"eater"`
+		Cart struct {
+			SpecialInstructions string `json:"special_instructions"`
+			Items               []struct {
+				ExternalData        string `json:"external_data"`
+				Title               string `json:"title"`
+				Quantity            int    `json:"quantity"`
+				SpecialInstructions string `json:"special_instructions"`
+				Price               struct {
+					UnitPrice struct {
+						Amount int64 `json:"amount"` // Minor units
+					} `json:"unit_price"`
+				} `json:"price"`
+			} `json:"items"`
+		} `json:"cart"`
+	} `json:"orders"`
+}
+
+// uberEatsSuspendedFor is how long unavailable items are suspended; the next menu push lifts it
+const uberEatsSuspendedFor = 365 * 24 * time.Hour
+
+func (a *uberEatsAdapter) Provider() string {
+	return models.DeliveryProviderUberEats
+}
+
+// PushMenu replaces the store's menu
+func (a *uberEatsAdapter) PushMenu(ctx context.Context, integration *models.DeliveryIntegration, menu *DeliveryMenu) error {
+	payload := uberEatsMenuPayload{
+		Menus:      []uberEatsMenu{{ID: "main", Title: uberEatsTextOf("Menu")}},
+		Categories: []uberEatsCategory{},
+		Items:      []uberEatsItem{},
+	}
+
+	for _, category := range menu.Categories {
+		payload.Menus[0].CategoryIDs = append(payload.Menus[0].CategoryIDs, category.ID)
+		entities := make([]uberEatsEntity, 0, len(category.Items))
+		for _, item := range category.Items {
+			entities = append(entities, uberEatsEntity{ID: item.ID, Type: "ITEM"})
+
+			uberItem := uberEatsItem{
+				ID:           item.ID,
+				ExternalData: item.ID,
+				Title:        uberEatsTextOf(item.Name),
+				Description:  uberEatsTextOf(item.Description),
+				PriceInfo:    uberEatsPriceInfo{Price: item.PriceCents},
+			}
+			if !item.Available {
+				uberItem.SuspensionInfo = &uberEatsSuspension{}
+				uberItem.SuspensionInfo.Suspension.SuspendUntil = time.Now().Add(uberEatsSuspendedFor).Unix()
+				uberItem.SuspensionInfo.Suspension.Reason = "unavailable"
+			}
+			payload.Items = append(payload.Items, uberItem)
+		}
+		payload.Categories = append(payload.Categories, uberEatsCategory{
+			ID:       category.ID,
+			Title:    uberEatsTextOf(category.Name),
+			Entities: entities,
+		})
+	}
+
+	endpoint := fmt.Sprintf("%s/v2/eats/stores/%s/menus", strings.TrimRight(a.baseURL, "/"), url.PathEscape(integration.ExternalStoreID))
+	return deliveryRequest(ctx, a.client, http.MethodPut, endpoint, integration.AccessToken, payload, nil)
+}
+
+// PullOrders lists the store's orders placed after since
+func (a *uberEatsAdapter) PullOrders(ctx context.Context, integration *models.DeliveryIntegration, since time.Time) ([]ExternalOrder, error) {
+	endpoint := fmt.Sprintf("%s/v1/eats/stores/%s/created-orders", strings.TrimRight(a.baseURL, "/"), url.PathEscape(integration.ExternalStoreID))
+
+	var resp uberEatsOrdersResponse
+	if err := deliveryRequest(ctx, a.client, http.MethodGet, endpoint, integration.AccessToken, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	orders := make([]ExternalOrder, 0, len(resp.Orders))
+	for _, o := range resp.Orders {
+		if !o.PlacedAt.After(since) || o.CurrentState == "CANCELED" {
+			continue
+		}
+		order := ExternalOrder{
+			ExternalID:   o.ID,
+			CustomerName: strings.TrimSpace(o.Eater.FirstName + " " + o.Eater.LastName),
+			Notes:        o.Cart.SpecialInstructions,
+			PlacedAt:     o.PlacedAt,
+		}
+		for _, item := range o.Cart.Items {
+			order.Items = append(order.Items, ExternalOrderItem{
+				MenuItemID:     item.ExternalData,
+				Name:           item.Title,
+				Quantity:       item.Quantity,
+				UnitPriceCents: item.Price.UnitPrice.Amount,
+				Notes:          item.SpecialInstructions,
+			})
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
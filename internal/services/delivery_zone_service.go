@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// earthRadiusMeters is the mean Earth radius used for distances between coordinates
+const earthRadiusMeters = 6371000.0
+
+// DeliveryZoneService manages delivery zones and quotes delivery fees for addresses
+// Addresses are located by their coordinates; geocoding is up to the client
+type DeliveryZoneService struct {
+	zoneRepo *repositories.DeliveryZoneRepository
+}
+
+// NewDeliveryZoneService creates a new DeliveryZoneService instance
+func NewDeliveryZoneService(zoneRepo *repositories.DeliveryZoneRepository) *DeliveryZoneService {
+	return &DeliveryZoneService{
+		zoneRepo: zoneRepo,
+	}
+}
+
+// DeliveryZoneRequest represents a delivery zone create or update request
+// Radius zones need a center and radius, polygon zones at least 3 [latitude, longitude] vertices
+type DeliveryZoneRequest struct {
+	Name         string       `json:"name" binding:"required,max=100"`
+	Kind         string       `json:"kind" binding:"required,oneof=radius polygon"`
+	CenterLat    *float64     `json:"center_lat" binding:"omitempty,min=-90,max=90"`
+	CenterLng    *float64     `json:"center_lng" binding:"omitempty,min=-180,max=180"`
+	RadiusMeters *int         `json:"radius_meters" binding:"omitempty,min=1,max=100000"`
+	Polygon      [][2]float64 `json:"polygon" binding:"omitempty,max=500"`
+	Fee          money.Cents  `json:"fee" binding:"min=0"`
+	MinimumOrder money.Cents  `json:"minimum_order" binding:"min=0"`
+	Priority     int          `json:"priority"`
+	IsActive     *bool        `json:"is_active"`
+}
+
+// CheckDeliveryRequest represents a delivery address check
+// Subtotal is the item subtotal to check against the zone's minimum order
+type CheckDeliveryRequest struct {
+	Address   string      `json:"address" binding:"max=255"`
+	Latitude  *float64    `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64    `json:"longitude" binding:"required,min=-180,max=180"`
+	Subtotal  money.Cents `json:"subtotal" binding:"min=0"`
+}
+
+// DeliveryQuote is the delivery terms for an address
+type DeliveryQuote struct {
+	Deliverable  bool        `json:"deliverable"`
+	ZoneID       *uint       `json:"zone_id,omitempty"`
+	ZoneName     string      `json:"zone_name,omitempty"`
+	Fee          money.Cents `json:"fee"`
+	MinimumOrder money.Cents `json:"minimum_order"`
+	MeetsMinimum bool        `json:"meets_minimum"`
+}
+
+// ListZones lists a restaurant's delivery zones in matching order
+func (s *DeliveryZoneService) ListZones(ctx context.Context, restaurantID uint) ([]models.DeliveryZone, error) {
+	return s.zoneRepo.GetByRestaurantIDWithContext(ctx, restaurantID, false)
+}
+
+// CreateZone creates a delivery zone
+func (s *DeliveryZoneService) CreateZone(ctx context.Context, restaurantID uint, req *DeliveryZoneRequest) (*models.DeliveryZone, error) {
+	zone := &models.DeliveryZone{RestaurantID: restaurantID, IsActive: true}
+	if err := applyDeliveryZoneRequest(zone, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.zoneRepo.CreateWithContext(ctx, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// UpdateZone replaces a delivery zone's shape and terms
+func (s *DeliveryZoneService) UpdateZone(ctx context.Context, id, restaurantID uint, req *DeliveryZoneRequest) (*models.DeliveryZone, error) {
+	zone, err := s.zoneRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeDeliveryZoneNotFound, "delivery zone not found")
+	}
+	if err := applyDeliveryZoneRequest(zone, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.zoneRepo.UpdateWithContext(ctx, zone); err != nil {
+		return nil, err
+	}
+	return zone, nil
+}
+
+// DeleteZone deletes a delivery zone
+func (s *DeliveryZoneService) DeleteZone(ctx context.Context, id, restaurantID uint) error {
+	if err := s.zoneRepo.DeleteForRestaurant(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeDeliveryZoneNotFound, "delivery zone not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// Quote returns the delivery terms of the first active zone containing the coordinates
+func (s *DeliveryZoneService) Quote(ctx context.Context, restaurantID uint, lat, lng float64, subtotal money.Cents) (*DeliveryQuote, error) {
+	zones, err := s.zoneRepo.GetByRestaurantIDWithContext(ctx, restaurantID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range zones {
+		zone := &zones[i]
+		if !zoneContains(zone, lat, lng) {
+			continue
+		}
+		return &DeliveryQuote{
+			Deliverable:  true,
+			ZoneID:       &zone.ID,
+			ZoneName:     zone.Name,
+			Fee:          zone.Fee,
+			MinimumOrder: zone.MinimumOrder,
+			MeetsMinimum: subtotal >= zone.MinimumOrder,
+		}, nil
+	}
+
+	return &DeliveryQuote{}, nil
+}
+
+// applyDeliveryZoneRequest validates the zone's shape and copies the request onto the zone
+func applyDeliveryZoneRequest(zone *models.DeliveryZone, req *DeliveryZoneRequest) error {
+	switch req.Kind {
+	case models.DeliveryZoneKindRadius:
+		if req.CenterLat == nil || req.CenterLng == nil || req.RadiusMeters == nil {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "radius zones need center_lat, center_lng and radius_meters")
+		}
+		zone.CenterLat, zone.CenterLng, zone.RadiusMeters = req.CenterLat, req.CenterLng, req.RadiusMeters
+		zone.Polygon = nil
+	case models.DeliveryZoneKindPolygon:
+		if len(req.Polygon) < 3 {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "polygon zones need at least 3 vertices")
+		}
+		for _, vertex := range req.Polygon {
+			if math.Abs(vertex[0]) > 90 || math.Abs(vertex[1]) > 180 {
+				return apperrors.BadRequest(apperrors.CodeBadRequest, "polygon vertices must be [latitude, longitude] pairs")
+			}
+		}
+		zone.Polygon = req.Polygon
+		zone.CenterLat, zone.CenterLng, zone.RadiusMeters = nil, nil, nil
+	}
+
+	zone.Name = req.Name
+	zone.Kind = req.Kind
+	zone.Fee = req.Fee
+	zone.MinimumOrder = req.MinimumOrder
+	zone.Priority = req.Priority
+	if req.IsActive != nil {
+		zone.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// zoneContains reports whether the coordinates lie in the zone
+func zoneContains(zone *models.DeliveryZone, lat, lng float64) bool {
+	switch zone.Kind {
+	case models.DeliveryZoneKindRadius:
+		if zone.CenterLat == nil || zone.CenterLng == nil || zone.RadiusMeters == nil {
+			return false
+		}
+		return distanceMeters(*zone.CenterLat, *zone.CenterLng, lat, lng) <= float64(*zone.RadiusMeters)
+	case models.DeliveryZoneKindPolygon:
+		return polygonContains(zone.Polygon, lat, lng)
+	}
+	return false
+}
+
+// distanceMeters returns the great-circle (haversine) distance between two coordinates
+func distanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLng := toRadians(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// polygonContains tests a point against [latitude, longitude] vertices by ray casting
+// Delivery areas are small enough to treat coordinates as planar
+func polygonContains(polygon [][2]float64, lat, lng float64) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		latI, lngI := polygon[i][0], polygon[i][1]
+		latJ, lngJ := polygon[j][0], polygon[j][1]
+		if (lngI > lng) != (lngJ > lng) &&
+			lat < (latJ-latI)*(lng-lngI)/(lngJ-lngI)+latI {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+// displayLookback limits the order board to orders touched recently,
+// so orders that were never completed don't stay on screen forever
+const displayLookback = 12 * time.Hour
+
+// DisplayService powers the read-only order status board (TV mode)
+type DisplayService struct {
+	restaurantRepo *repositories.RestaurantRepository
+	orderRepo      *repositories.OrderRepository
+	backupRepo     *repositories.StorageBackupRepository
+}
+
+// NewDisplayService creates a new DisplayService instance
+func NewDisplayService(
+	restaurantRepo *repositories.RestaurantRepository,
+	orderRepo *repositories.OrderRepository,
+	backupRepo *repositories.StorageBackupRepository,
+) *DisplayService {
+	return &DisplayService{
+		restaurantRepo: restaurantRepo,
+		orderRepo:      orderRepo,
+		backupRepo:     backupRepo,
+	}
+}
+
+// DisplayBoard lists order numbers currently being prepared or ready for pickup
+type DisplayBoard struct {
+	RestaurantName string `json:"restaurant_name"`
+	Preparing      []uint `json:"preparing"`
+	Ready          []uint `json:"ready"`
+}
+
+// RotateDisplayToken generates a new display token, invalidating the previous one
+func (s *DisplayService) RotateDisplayToken(ctx context.Context, restaurantID uint) (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate display token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+
+	if err := s.restaurantRepo.UpdateDisplayTokenWithContext(ctx, restaurantID, token); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// GetBoard returns the current order board for the restaurant owning the token
+func (s *DisplayService) GetBoard(ctx context.Context, token string) (*DisplayBoard, error) {
+	if token == "" {
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid display token")
+	}
+
+	restaurant, err := s.restaurantRepo.GetByDisplayTokenWithContext(ctx, token)
+	if err != nil {
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid display token")
+	}
+
+	if restaurant.Status != models.RestaurantStatusActive {
+		return nil, apperrors.Forbidden(apperrors.CodeRestaurantInactive, "restaurant is not active")
+	}
+
+	orders, err := s.orderRepo.GetDisplayOrders(ctx, restaurant.ID, []string{"preparing", "ready"}, time.Now().Add(-displayLookback))
+	if err != nil {
+		return nil, err
+	}
+
+	board := &DisplayBoard{
+		RestaurantName: restaurant.Name,
+		Preparing:      make([]uint, 0),
+		Ready:          make([]uint, 0),
+	}
+	for _, order := range orders {
+		if order.Status == "ready" {
+			board.Ready = append(board.Ready, order.ID)
+		} else {
+			board.Preparing = append(board.Preparing, order.ID)
+		}
+	}
+
+	return board, nil
+}
+
+// TenantTier returns the tier of the restaurant owning the token, used to label connection metrics
+func (s *DisplayService) TenantTier(ctx context.Context, token string) string {
+	restaurant, err := s.restaurantRepo.GetByDisplayTokenWithContext(ctx, token)
+	if err != nil {
+		return models.BackupTierStandard
+	}
+	return tenantTier(ctx, s.backupRepo, restaurant.ID)
+}
+
+// tenantTier returns a restaurant's backup tier; restaurants without a backup policy are on the standard tier
+func tenantTier(ctx context.Context, backupRepo *repositories.StorageBackupRepository, restaurantID uint) string {
+	policy, err := backupRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return models.BackupTierStandard
+	}
+	return policy.Tier
+}
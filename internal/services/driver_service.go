@@ -0,0 +1,352 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// deliveryListLimit bounds delivery listings to the latest assignments
+const deliveryListLimit = 100
+
+// deliveryStatusTransitions lists the status a driver may move a delivery to from each status
+var deliveryStatusTransitions = map[string]string{
+	models.DeliveryStatusPickedUp:  models.DeliveryStatusAssigned,
+	models.DeliveryStatusDelivered: models.DeliveryStatusPickedUp,
+}
+
+// DriverService manages drivers, assigns delivery orders to them and tracks deliveries
+// Drivers authenticate with their token on the driver-facing endpoints
+type DriverService struct {
+	driverRepo     *repositories.DriverRepository
+	assignmentRepo *repositories.DeliveryAssignmentRepository
+	orderRepo      *repositories.OrderRepository
+	orders         *OrderService
+}
+
+// NewDriverService creates a new DriverService instance
+func NewDriverService(
+	driverRepo *repositories.DriverRepository,
+	assignmentRepo *repositories.DeliveryAssignmentRepository,
+	orderRepo *repositories.OrderRepository,
+	orders *OrderService,
+) *DriverService {
+	return &DriverService{
+		driverRepo:     driverRepo,
+		assignmentRepo: assignmentRepo,
+		orderRepo:      orderRepo,
+		orders:         orders,
+	}
+}
+
+// CreateDriverRequest represents a driver creation request
+type CreateDriverRequest struct {
+	Name  string `json:"name" binding:"required,max=100"`
+	Phone string `json:"phone" binding:"max=20"`
+}
+
+// UpdateDriverRequest represents a driver update request; drivers are deactivated rather than deleted
+type UpdateDriverRequest struct {
+	Name     *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Phone    *string `json:"phone" binding:"omitempty,max=20"`
+	IsActive *bool   `json:"is_active"`
+}
+
+// DriverCredentials is a driver with their token, returned only when the token is (re)generated
+type DriverCredentials struct {
+	Driver *models.Driver `json:"driver"`
+	Token  string         `json:"token"`
+}
+
+// AssignDriverRequest represents a request to assign a delivery order to a driver
+type AssignDriverRequest struct {
+	DriverID uint `json:"driver_id" binding:"required"`
+}
+
+// UpdateDeliveryStatusRequest represents a driver's status update of a delivery
+type UpdateDeliveryStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=picked_up delivered"`
+}
+
+// DriverDelivery is the driver-facing view of a delivery: where to go and what to bring
+type DriverDelivery struct {
+	ID            uint                `json:"id"`
+	OrderID       uint                `json:"order_id"`
+	Status        string              `json:"status"`
+	Address       string              `json:"address"`
+	Latitude      *float64            `json:"latitude,omitempty"`
+	Longitude     *float64            `json:"longitude,omitempty"`
+	CustomerName  string              `json:"customer_name"`
+	CustomerPhone string              `json:"customer_phone,omitempty"`
+	Total         money.Cents         `json:"total"`
+	Notes         string              `json:"notes,omitempty"`
+	Items         []KitchenTicketItem `json:"items"`
+	ScheduledFor  *time.Time          `json:"scheduled_for,omitempty"`
+	AssignedAt    time.Time           `json:"assigned_at"`
+	PickedUpAt    *time.Time          `json:"picked_up_at,omitempty"`
+}
+
+// ListDrivers lists the drivers of a restaurant
+func (s *DriverService) ListDrivers(ctx context.Context, restaurantID uint) ([]models.Driver, error) {
+	return s.driverRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// CreateDriver registers a driver and generates their token
+func (s *DriverService) CreateDriver(ctx context.Context, req *CreateDriverRequest, restaurantID uint) (*DriverCredentials, error) {
+	token, err := newDriverToken()
+	if err != nil {
+		return nil, err
+	}
+
+	driver := &models.Driver{
+		RestaurantID: restaurantID,
+		Name:         strings.TrimSpace(req.Name),
+		Phone:        strings.TrimSpace(req.Phone),
+		IsActive:     true,
+		Token:        token,
+	}
+	if err := s.driverRepo.CreateWithContext(ctx, driver); err != nil {
+		return nil, err
+	}
+
+	return &DriverCredentials{Driver: driver, Token: token}, nil
+}
+
+// UpdateDriver updates a driver
+func (s *DriverService) UpdateDriver(ctx context.Context, id uint, req *UpdateDriverRequest, restaurantID uint) (*models.Driver, error) {
+	driver, err := s.getDriver(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		driver.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Phone != nil {
+		driver.Phone = strings.TrimSpace(*req.Phone)
+	}
+	if req.IsActive != nil {
+		driver.IsActive = *req.IsActive
+	}
+
+	if err := s.driverRepo.SaveWithContext(ctx, driver); err != nil {
+		return nil, err
+	}
+	return driver, nil
+}
+
+// RotateDriverToken generates a new token for a driver, signing out the previous one
+func (s *DriverService) RotateDriverToken(ctx context.Context, id uint, restaurantID uint) (*DriverCredentials, error) {
+	driver, err := s.getDriver(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newDriverToken()
+	if err != nil {
+		return nil, err
+	}
+	driver.Token = token
+	if err := s.driverRepo.SaveWithContext(ctx, driver); err != nil {
+		return nil, err
+	}
+
+	return &DriverCredentials{Driver: driver, Token: token}, nil
+}
+
+// AssignDriver assigns a delivery order to an active driver
+// The driver can be changed until the order is picked up
+func (s *DriverService) AssignDriver(ctx context.Context, orderID, restaurantID, assignedBy uint, req *AssignDriverRequest) (*models.DeliveryAssignment, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+	if order.FulfillmentType != models.OrderFulfillmentDelivery {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "only delivery orders can be assigned to drivers")
+	}
+	if order.Status == models.OrderStatusCompleted || order.Status == models.OrderStatusCancelled {
+		return nil, apperrors.Conflict(apperrors.CodeInvalidTransition, fmt.Sprintf("%s orders can't be assigned to drivers", order.Status))
+	}
+
+	driver, err := s.getDriver(ctx, req.DriverID, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if !driver.IsActive {
+		return nil, apperrors.Conflict(apperrors.CodeDriverInactive, "driver is not active")
+	}
+
+	assignment := &models.DeliveryAssignment{
+		RestaurantID:     restaurantID,
+		OrderID:          orderID,
+		DriverID:         driver.ID,
+		Status:           models.DeliveryStatusAssigned,
+		AssignedByUserID: assignedBy,
+		AssignedAt:       time.Now(),
+	}
+	if err := s.assignmentRepo.AssignWithContext(ctx, assignment); err != nil {
+		if errors.Is(err, repositories.ErrDeliveryStarted) {
+			return nil, apperrors.Conflict(apperrors.CodeDeliveryStarted, "order was already picked up by its driver")
+		}
+		return nil, err
+	}
+	assignment.Driver = *driver
+
+	return assignment, nil
+}
+
+// GetAssignment retrieves the delivery assignment of an order
+func (s *DriverService) GetAssignment(ctx context.Context, orderID, restaurantID uint) (*models.DeliveryAssignment, error) {
+	assignment, err := s.assignmentRepo.GetByOrderWithContext(ctx, orderID, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeDeliveryNotFound, "order is not assigned to a driver")
+		}
+		return nil, err
+	}
+	return assignment, nil
+}
+
+// UnassignDriver removes the driver of an order that has not been picked up
+func (s *DriverService) UnassignDriver(ctx context.Context, orderID, restaurantID uint) error {
+	if err := s.assignmentRepo.DeleteAssignedWithContext(ctx, orderID, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeDeliveryNotFound, "order has no driver assignment that can be removed")
+		}
+		return err
+	}
+	return nil
+}
+
+// ListDeliveries lists the latest delivery assignments of a restaurant
+func (s *DriverService) ListDeliveries(ctx context.Context, restaurantID uint, filter repositories.DeliveryAssignmentFilter) ([]models.DeliveryAssignment, error) {
+	return s.assignmentRepo.ListWithContext(ctx, restaurantID, filter, deliveryListLimit)
+}
+
+// ListDriverDeliveries lists the open deliveries of the driver owning the token
+func (s *DriverService) ListDriverDeliveries(ctx context.Context, token string) ([]DriverDelivery, error) {
+	driver, err := s.tokenDriver(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assignmentRepo.ListOpenByDriverWithContext(ctx, driver.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]DriverDelivery, 0, len(assignments))
+	for i := range assignments {
+		deliveries = append(deliveries, newDriverDelivery(&assignments[i]))
+	}
+	return deliveries, nil
+}
+
+// UpdateDriverDelivery moves a delivery of the driver owning the token to its next status
+// Delivering completes the order when it is ready, on behalf of the user who assigned the driver
+func (s *DriverService) UpdateDriverDelivery(ctx context.Context, token string, id uint, req *UpdateDeliveryStatusRequest) (*models.DeliveryAssignment, error) {
+	driver, err := s.tokenDriver(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	from := deliveryStatusTransitions[req.Status]
+	assignment, err := s.assignmentRepo.TransitionWithContext(ctx, id, driver.ID, from, req.Status, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.Conflict(apperrors.CodeInvalidTransition,
+				fmt.Sprintf("only %s deliveries can be marked %s", from, req.Status))
+		}
+		return nil, err
+	}
+
+	if req.Status == models.DeliveryStatusDelivered && s.orders != nil {
+		s.completeOrder(ctx, assignment)
+	}
+	return assignment, nil
+}
+
+// completeOrder completes a delivered order that is ready; the delivery stands if that fails
+func (s *DriverService) completeOrder(ctx context.Context, assignment *models.DeliveryAssignment) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, assignment.OrderID, assignment.RestaurantID)
+	if err != nil || order.Status != models.OrderStatusReady {
+		return
+	}
+
+	if _, err := s.orders.UpdateOrderStatusWithCtx(ctx, order.ID, order.RestaurantID, assignment.AssignedByUserID,
+		&UpdateOrderStatusRequest{Status: models.OrderStatusCompleted}); err != nil {
+		logger.Warn("Failed to complete delivered order",
+			zap.Uint("order_id", order.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// newDriverDelivery builds the driver-facing view of an assignment
+// The assignment's order must be loaded with its user and items
+func newDriverDelivery(assignment *models.DeliveryAssignment) DriverDelivery {
+	order := &assignment.Order
+	return DriverDelivery{
+		ID:            assignment.ID,
+		OrderID:       assignment.OrderID,
+		Status:        assignment.Status,
+		Address:       order.DeliveryAddress,
+		Latitude:      order.DeliveryLat,
+		Longitude:     order.DeliveryLng,
+		CustomerName:  strings.TrimSpace(order.User.FirstName + " " + order.User.LastName),
+		CustomerPhone: order.User.Phone,
+		Total:         order.TotalAmount,
+		Notes:         order.Notes,
+		Items:         NewKitchenTicket(order).Items,
+		ScheduledFor:  order.ScheduledFor,
+		AssignedAt:    assignment.AssignedAt,
+		PickedUpAt:    assignment.PickedUpAt,
+	}
+}
+
+// tokenDriver retrieves the active driver owning a token
+func (s *DriverService) tokenDriver(ctx context.Context, token string) (*models.Driver, error) {
+	if token == "" {
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid driver token")
+	}
+
+	driver, err := s.driverRepo.GetByTokenWithContext(ctx, token)
+	if err != nil {
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid driver token")
+	}
+	if !driver.IsActive {
+		return nil, apperrors.Forbidden(apperrors.CodeDriverInactive, "driver is not active")
+	}
+	return driver, nil
+}
+
+// getDriver retrieves a driver of the restaurant
+func (s *DriverService) getDriver(ctx context.Context, id uint, restaurantID uint) (*models.Driver, error) {
+	driver, err := s.driverRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeDriverNotFound, "driver not found")
+	}
+	return driver, nil
+}
+
+// newDriverToken generates a random driver token
+func newDriverToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate driver token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -3,14 +3,23 @@ package services
 import (
 	"context"
 	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"math/big"
+	"net/http"
 	"strings"
+	"time"
 
 	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/metrics"
 	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/tracing"
 
 	brevo "github.com/getbrevo/brevo-go/lib"
+	"go.uber.org/zap"
 )
 
 // EmailTemplateID constants for Brevo template IDs
@@ -23,32 +32,65 @@ const (
 	TemplateOrderStatusUpdate       int64 = 11 // Not implemented
 	TemplateReservationConfirm      int64 = 6
 	TemplateReservationStatusUpdate int64 = 10 // Not implemented
+	TemplateRestaurantLaunch        int64 = 12
+	TemplateEmailVerification       int64 = 13
+	TemplateCartRecovery            int64 = 14
 )
 
 // EmailService handles email operations via Brevo
 type EmailService struct {
 	client      *brevo.APIClient
 	config      *config.Config
+	usageRepo   *repositories.UsageRepository
 	senderEmail string
 	senderName  string
 }
 
 // NewEmailService creates a new EmailService instance
-func NewEmailService(cfg *config.Config) *EmailService {
+func NewEmailService(cfg *config.Config, usageRepo *repositories.UsageRepository) *EmailService {
 	// Configure Brevo API client
 	configuration := brevo.NewConfiguration()
 	configuration.AddDefaultHeader("api-key", cfg.BrevoAPIKey)
+	configuration.HTTPClient = &http.Client{Transport: tracing.Transport(nil)}
 
 	client := brevo.NewAPIClient(configuration)
 
 	return &EmailService{
 		client:      client,
 		config:      cfg,
+		usageRepo:   usageRepo,
 		senderEmail: cfg.BrevoSenderEmail,
 		senderName:  cfg.BrevoSenderName,
 	}
 }
 
+// send delivers a transactional email, tracking it as pending on the email outbox queue
+// Sends are inline, so the outbox depth is the number of Brevo calls in flight
+// Delivered emails are metered against the restaurant for billing, failed ones for its health score
+func (s *EmailService) send(ctx context.Context, restaurantID uint, emailRequest brevo.SendSmtpEmail) error {
+	done := metrics.Enqueue(metrics.QueueEmailOutbox)
+	defer done()
+
+	if _, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailRequest); err != nil {
+		// Failures are counted for the restaurant health score
+		if meterErr := s.usageRepo.IncrementWithContext(ctx, restaurantID, models.UsageMetricEmailsFailed, time.Now(), 1); meterErr != nil {
+			logger.Warn("Failed to meter failed email",
+				zap.Uint("restaurant_id", restaurantID),
+				zap.Error(meterErr),
+			)
+		}
+		return err
+	}
+
+	if err := s.usageRepo.IncrementWithContext(ctx, restaurantID, models.UsageMetricEmailsSent, time.Now(), 1); err != nil {
+		logger.Warn("Failed to meter sent email",
+			zap.Uint("restaurant_id", restaurantID),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
 // SendRestaurantWelcomeEmail sends a welcome email to a newly activated restaurant
 // Uses Brevo template ID: TemplateRestaurantWelcome
 func (s *EmailService) SendRestaurantWelcomeEmail(
@@ -85,7 +127,7 @@ func (s *EmailService) SendRestaurantWelcomeEmail(
 		Params:     params,
 	}
 
-	_, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailRequest)
+	err := s.send(ctx, restaurant.ID, emailRequest)
 	if err != nil {
 		return fmt.Errorf("failed to send welcome email: %w", err)
 	}
@@ -165,22 +207,79 @@ func ExtractLastName(fullName string) string {
 
 // OrderItem represents an item in an order for email template
 type OrderItem struct {
-	Name     string  `json:"name"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
-	Subtotal float64 `json:"subtotal"`
+	Name     string      `json:"name"`
+	Quantity int         `json:"quantity"`
+	Price    money.Cents `json:"price"`
+	Subtotal money.Cents `json:"subtotal"`
+	Notes    string      `json:"notes,omitempty"` // Per-item customer notes (e.g. "no onions")
 }
 
-// SendUserInvitationEmail sends an invitation email to a new user
+// BuildOrderEmailItems converts order items into the email template item list
+// Older order items without a name snapshot need their MenuItem relationship loaded
+func BuildOrderEmailItems(items []models.OrderItem) []OrderItem {
+	emailItems := make([]OrderItem, 0, len(items))
+	for _, item := range items {
+		emailItems = append(emailItems, OrderItem{
+			Name:     item.DisplayName(),
+			Quantity: item.Quantity,
+			Price:    item.Price,
+			Subtotal: item.Price.Times(item.Quantity),
+			Notes:    item.Notes,
+		})
+	}
+	return emailItems
+}
+
+// SendRestaurantLaunchEmail announces that a restaurant is now publicly visible
+// Uses Brevo template ID: TemplateRestaurantLaunch
+func (s *EmailService) SendRestaurantLaunchEmail(ctx context.Context, restaurant *models.Restaurant) error {
+	sender := brevo.SendSmtpEmailSender{
+		Name:  s.senderName,
+		Email: s.senderEmail,
+	}
+
+	to := []brevo.SendSmtpEmailTo{
+		{
+			Email: restaurant.ContactEmail,
+			Name:  restaurant.ContactName,
+		},
+	}
+
+	// Template parameters
+	params := map[string]interface{}{
+		"contact_name":    restaurant.ContactName,
+		"restaurant_name": restaurant.Name,
+		"restaurant_id":   restaurant.ID,
+		"frontend_url":    s.config.FrontendURL,
+	}
+
+	emailRequest := brevo.SendSmtpEmail{
+		Sender:     &sender,
+		To:         to,
+		TemplateId: TemplateRestaurantLaunch,
+		Params:     params,
+	}
+
+	err := s.send(ctx, restaurant.ID, emailRequest)
+	if err != nil {
+		return fmt.Errorf("failed to send launch email: %w", err)
+	}
+
+	return nil
+}
+
+// SendUserInvitationEmail sends an invitation email with the link where the new user sets their password
 // Uses Brevo template ID: TemplateUserInvitation
 func (s *EmailService) SendUserInvitationEmail(
 	ctx context.Context,
+	restaurantID uint,
 	userEmail string,
 	userFirstName string,
 	restaurantName string,
 	inviterName string,
-	tempPassword string,
 	userRole string,
+	acceptURL string,
+	expiresAt time.Time,
 ) error {
 	sender := brevo.SendSmtpEmailSender{
 		Name:  s.senderName,
@@ -211,7 +310,8 @@ func (s *EmailService) SendUserInvitationEmail(
 		"inviter_name":     inviterName,
 		"restaurant_name":  restaurantName,
 		"user_email":       userEmail,
-		"temp_password":    tempPassword,
+		"accept_url":       acceptURL,
+		"expires_at":       expiresAt.UTC().Format("January 2, 2006 15:04 MST"),
 		"user_role":        userRole,
 		"role_description": roleDesc,
 		"frontend_url":     s.config.FrontendURL,
@@ -224,7 +324,7 @@ func (s *EmailService) SendUserInvitationEmail(
 		Params:     params,
 	}
 
-	_, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailRequest)
+	err := s.send(ctx, restaurantID, emailRequest)
 	if err != nil {
 		return fmt.Errorf("failed to send user invitation email: %w", err)
 	}
@@ -236,6 +336,7 @@ func (s *EmailService) SendUserInvitationEmail(
 // Uses Brevo template ID: TemplatePasswordReset
 func (s *EmailService) SendPasswordResetEmail(
 	ctx context.Context,
+	restaurantID uint,
 	userEmail string,
 	userFirstName string,
 	resetToken string,
@@ -270,7 +371,7 @@ func (s *EmailService) SendPasswordResetEmail(
 		Params:     params,
 	}
 
-	_, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailRequest)
+	err := s.send(ctx, restaurantID, emailRequest)
 	if err != nil {
 		return fmt.Errorf("failed to send password reset email: %w", err)
 	}
@@ -278,23 +379,126 @@ func (s *EmailService) SendPasswordResetEmail(
 	return nil
 }
 
+// SendEmailVerificationEmail sends a newly registered user the link that verifies their email address
+// Uses Brevo template ID: TemplateEmailVerification
+func (s *EmailService) SendEmailVerificationEmail(
+	ctx context.Context,
+	restaurantID uint,
+	userEmail string,
+	userFirstName string,
+	verificationToken string,
+	expiresAt time.Time,
+) error {
+	sender := brevo.SendSmtpEmailSender{
+		Name:  s.senderName,
+		Email: s.senderEmail,
+	}
+
+	to := []brevo.SendSmtpEmailTo{
+		{
+			Email: userEmail,
+			Name:  userFirstName,
+		},
+	}
+
+	verifyLink := fmt.Sprintf("%s/verify-email?token=%s", s.config.FrontendURL, verificationToken)
+
+	// Template parameters
+	params := map[string]interface{}{
+		"user_first_name": userFirstName,
+		"verify_link":     verifyLink,
+		"expires_at":      expiresAt.UTC().Format("January 2, 2006 15:04 MST"),
+	}
+
+	emailRequest := brevo.SendSmtpEmail{
+		Sender:     &sender,
+		To:         to,
+		TemplateId: TemplateEmailVerification,
+		Params:     params,
+	}
+
+	err := s.send(ctx, restaurantID, emailRequest)
+	if err != nil {
+		return fmt.Errorf("failed to send email verification email: %w", err)
+	}
+
+	return nil
+}
+
+// SendCartRecoveryEmail reminds a shopper of a cart left without ordering, with a link to resume it
+// The unsubscribe link is only set for customers, whose reminders count as marketing
+// Uses Brevo template ID: TemplateCartRecovery
+func (s *EmailService) SendCartRecoveryEmail(
+	ctx context.Context,
+	restaurantID uint,
+	email string,
+	name string,
+	restaurantName string,
+	items []OrderItem,
+	subtotal money.Cents,
+	resumeURL string,
+	unsubscribeURL string,
+	expiresAt time.Time,
+) error {
+	sender := brevo.SendSmtpEmailSender{
+		Name:  s.senderName,
+		Email: s.senderEmail,
+	}
+
+	to := []brevo.SendSmtpEmailTo{
+		{
+			Email: email,
+			Name:  name,
+		},
+	}
+
+	// Template parameters
+	params := map[string]interface{}{
+		"customer_name":   name,
+		"restaurant_name": restaurantName,
+		"items":           items,
+		"subtotal":        subtotal,
+		"resume_url":      resumeURL,
+		"unsubscribe_url": unsubscribeURL,
+		"expires_at":      expiresAt.UTC().Format("January 2, 2006 15:04 MST"),
+	}
+
+	emailRequest := brevo.SendSmtpEmail{
+		Sender:     &sender,
+		To:         to,
+		TemplateId: TemplateCartRecovery,
+		Params:     params,
+	}
+
+	err := s.send(ctx, restaurantID, emailRequest)
+	if err != nil {
+		return fmt.Errorf("failed to send cart recovery email: %w", err)
+	}
+
+	return nil
+}
+
 // SendOrderConfirmationEmail sends order confirmation email to customer
+// The PDF receipt is attached when receiptPDF is not empty
 // Uses Brevo template ID: TemplateOrderConfirmation
 func (s *EmailService) SendOrderConfirmationEmail(
 	ctx context.Context,
+	restaurantID uint,
 	customerEmail string,
 	customerName string,
 	restaurantName string,
 	orderID uint,
 	items []OrderItem,
-	subtotal float64,
-	tax float64,
-	deliveryFee float64,
-	total float64,
+	subtotal money.Cents,
+	tax money.Cents,
+	deliveryFee money.Cents,
+	total money.Cents,
 	estimatedMinutes int,
 	specialNotes string,
 	restaurantPhone string,
 	restaurantAddress string,
+	nutrition *NutritionSummary,
+	receiptPDF []byte,
 ) error {
 	sender := brevo.SendSmtpEmailSender{
 		Name:  s.senderName,
@@ -325,14 +529,25 @@ func (s *EmailService) SendOrderConfirmationEmail(
 		"frontend_url":       s.config.FrontendURL,
 	}
 
+	// Nutrition summary is optional (only included when menu items provide it)
+	if nutrition != nil {
+		params["nutrition"] = nutrition
+	}
+
 	emailRequest := brevo.SendSmtpEmail{
 		Sender:     &sender,
 		To:         to,
 		TemplateId: TemplateOrderConfirmation,
 		Params:     params,
 	}
+	if len(receiptPDF) > 0 {
+		emailRequest.Attachment = []brevo.SendSmtpEmailAttachment{{
+			Name:    fmt.Sprintf("receipt-%d.pdf", orderID),
+			Content: base64.StdEncoding.EncodeToString(receiptPDF),
+		}}
+	}
 
-	_, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailRequest)
+	err := s.send(ctx, restaurantID, emailRequest)
 	if err != nil {
 		return fmt.Errorf("failed to send order confirmation email: %w", err)
 	}
@@ -344,6 +559,7 @@ func (s *EmailService) SendOrderConfirmationEmail(
 // Uses Brevo template ID: TemplateOrderStatusUpdate
 func (s *EmailService) SendOrderStatusUpdateEmail(
 	ctx context.Context,
+	restaurantID uint,
 	customerEmail string,
 	customerName string,
 	restaurantName string,
@@ -384,7 +600,7 @@ func (s *EmailService) SendOrderStatusUpdateEmail(
 		Params:     params,
 	}
 
-	_, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailRequest)
+	err := s.send(ctx, restaurantID, emailRequest)
 	if err != nil {
 		return fmt.Errorf("failed to send order status update email: %w", err)
 	}
@@ -396,6 +612,7 @@ func (s *EmailService) SendOrderStatusUpdateEmail(
 // Uses Brevo template ID: TemplateReservationConfirm
 func (s *EmailService) SendReservationConfirmationEmail(
 	ctx context.Context,
+	restaurantID uint,
 	customerEmail string,
 	customerName string,
 	restaurantName string,
@@ -446,7 +663,7 @@ func (s *EmailService) SendReservationConfirmationEmail(
 		Params:     params,
 	}
 
-	_, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailRequest)
+	err := s.send(ctx, restaurantID, emailRequest)
 	if err != nil {
 		return fmt.Errorf("failed to send reservation confirmation email: %w", err)
 	}
@@ -458,6 +675,7 @@ func (s *EmailService) SendReservationConfirmationEmail(
 // Uses Brevo template ID: TemplateReservationStatusUpdate
 func (s *EmailService) SendReservationStatusUpdateEmail(
 	ctx context.Context,
+	restaurantID uint,
 	customerEmail string,
 	customerName string,
 	restaurantName string,
@@ -500,7 +718,7 @@ func (s *EmailService) SendReservationStatusUpdateEmail(
 		Params:     params,
 	}
 
-	_, _, err := s.client.TransactionalEmailsApi.SendTransacEmail(ctx, emailRequest)
+	err := s.send(ctx, restaurantID, emailRequest)
 	if err != nil {
 		return fmt.Errorf("failed to send reservation status update email: %w", err)
 	}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Resend limits per user: one email per minimum interval and at most the hourly limit
+const (
+	verificationResendInterval = time.Minute
+	verificationHourlyLimit    = 5
+)
+
+var (
+	// errInvalidVerification is returned for unknown, used or expired verification links
+	errInvalidVerification = apperrors.BadRequest(apperrors.CodeInvalidToken, "verification link is invalid or has expired; request a new one")
+	// errVerificationRateLimited is returned when verification emails are requested too often
+	errVerificationRateLimited = apperrors.New(http.StatusTooManyRequests, apperrors.CodeTooManyRequests, "too many verification emails requested; try again later")
+)
+
+// VerifyEmailRequest represents redeeming a verification link
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ResendVerificationRequest represents asking for a new verification email
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// EmailVerificationService verifies the email address of self-registered users: registration emails a link,
+// password login is refused until it's followed, and a new link can be requested within rate limits
+type EmailVerificationService struct {
+	verificationRepo *repositories.EmailVerificationRepository
+	userRepo         *repositories.UserRepository
+	authService      *AuthService
+	mailer           Mailer
+	ttl              time.Duration
+}
+
+// NewEmailVerificationService creates a new EmailVerificationService instance
+func NewEmailVerificationService(
+	verificationRepo *repositories.EmailVerificationRepository,
+	userRepo *repositories.UserRepository,
+	authService *AuthService,
+	mailer Mailer,
+	ttl time.Duration,
+) *EmailVerificationService {
+	return &EmailVerificationService{
+		verificationRepo: verificationRepo,
+		userRepo:         userRepo,
+		authService:      authService,
+		mailer:           mailer,
+		ttl:              ttl,
+	}
+}
+
+// Send emails a verification link to a user whose address is pending verification
+func (s *EmailVerificationService) Send(ctx context.Context, user *models.User) error {
+	if !user.EmailVerificationPending {
+		return nil
+	}
+
+	now := time.Now()
+	token := randomToken()
+	verification := &models.EmailVerification{
+		UserID:    user.ID,
+		TokenHash: hashLinkToken(token),
+		ExpiresAt: now.Add(s.ttl),
+	}
+	if err := s.verificationRepo.CreateWithContext(ctx, verification); err != nil {
+		return fmt.Errorf("failed to create email verification: %w", err)
+	}
+
+	// A failed email can be retried through resend
+	if err := s.mailer.SendEmailVerificationEmail(ctx, user.RestaurantID, user.Email, user.FirstName, token, verification.ExpiresAt); err != nil {
+		logger.Warn("Failed to send verification email",
+			zap.Uint("user_id", user.ID),
+			zap.Error(err),
+		)
+	}
+	return nil
+}
+
+// Resend emails a new verification link to the unverified account of the address
+// Unknown and already verified addresses are ignored, so the response doesn't reveal which accounts exist
+func (s *EmailVerificationService) Resend(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByEmailGlobalWithContext(ctx, strings.TrimSpace(email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.EmailVerificationPending {
+		return nil
+	}
+
+	now := time.Now()
+	recent, err := s.verificationRepo.CountSinceWithContext(ctx, user.ID, now.Add(-verificationResendInterval))
+	if err != nil {
+		return err
+	}
+	hourly, err := s.verificationRepo.CountSinceWithContext(ctx, user.ID, now.Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	if recent > 0 || hourly >= verificationHourlyLimit {
+		return errVerificationRateLimited
+	}
+
+	return s.Send(ctx, user)
+}
+
+// Verify redeems a verification link, marks the address verified and signs the user in
+func (s *EmailVerificationService) Verify(ctx context.Context, token string, client ClientInfo) (*LoginResponse, error) {
+	verification, err := s.verificationRepo.GetByTokenHashWithContext(ctx, hashLinkToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errInvalidVerification
+		}
+		return nil, err
+	}
+
+	if err := s.verificationRepo.VerifyWithContext(ctx, verification, time.Now()); err != nil {
+		if errors.Is(err, repositories.ErrVerificationUsed) {
+			return nil, errInvalidVerification
+		}
+		return nil, fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	user, err := s.userRepo.GetByIDWithContext(ctx, verification.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	logger.Info("Email address verified", zap.Uint("user_id", user.ID))
+
+	return s.authService.CompleteLogin(ctx, user, client)
+}
@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"github.com/xuri/excelize/v2"
+	"gorm.io/gorm"
+)
+
+// Export formats
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatXLSX = "xlsx"
+)
+
+// Export content types, also used for Accept header negotiation
+const (
+	ExportContentTypeCSV  = "text/csv"
+	ExportContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// ExportService writes orders, reservations and customers as CSV or XLSX for accountants and external analysis
+// Rows are read and written in batches, so exports stream instead of loading whole tables into memory.
+// Times are in the restaurant's time zone
+type ExportService struct {
+	orderRepo       *repositories.OrderRepository
+	reservationRepo *repositories.ReservationRepository
+	customerRepo    *repositories.CustomerRepository
+	settingsRepo    *repositories.RestaurantSettingsRepository
+}
+
+// NewExportService creates a new ExportService instance
+func NewExportService(
+	orderRepo *repositories.OrderRepository,
+	reservationRepo *repositories.ReservationRepository,
+	customerRepo *repositories.CustomerRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+) *ExportService {
+	return &ExportService{
+		orderRepo:       orderRepo,
+		reservationRepo: reservationRepo,
+		customerRepo:    customerRepo,
+		settingsRepo:    settingsRepo,
+	}
+}
+
+// ParseExportFormat validates an export format, CSV when empty
+func ParseExportFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", ExportFormatCSV:
+		return ExportFormatCSV, nil
+	case ExportFormatXLSX:
+		return ExportFormatXLSX, nil
+	default:
+		return "", apperrors.BadRequest(apperrors.CodeBadRequest, "format must be csv or xlsx")
+	}
+}
+
+// ExportContentType returns the content type of an export format
+func ExportContentType(format string) string {
+	if format == ExportFormatXLSX {
+		return ExportContentTypeXLSX
+	}
+	return ExportContentTypeCSV + "; charset=utf-8"
+}
+
+// ExportOrders writes a restaurant's orders, one row per order, optionally only those of one user
+func (s *ExportService) ExportOrders(ctx context.Context, restaurantID uint, userID *uint, format string, w io.Writer) error {
+	location, err := s.location(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	out, err := newExportWriter(w, format, "Orders", []string{
+		"ID", "Created At", "Status", "Source", "External ID", "Channel", "Fulfillment", "User ID",
+		"Items", "Item Count", "Delivery Fee", "Total Amount", "Scheduled For", "Notes",
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.orderRepo.ExportWithContext(ctx, restaurantID, userID, func(orders []models.Order) error {
+		for _, order := range orders {
+			items := make([]string, 0, len(order.OrderItems))
+			itemCount := 0
+			for _, item := range order.OrderItems {
+				items = append(items, fmt.Sprintf("%dx %s", item.Quantity, item.Name))
+				itemCount += item.Quantity
+			}
+			if err := out.WriteRow([]interface{}{
+				order.ID, order.CreatedAt.In(location), order.Status, order.Source, order.ExternalID, order.Channel,
+				order.FulfillmentType, order.UserID, strings.Join(items, "; "), itemCount, order.DeliveryFee,
+				order.TotalAmount, localTime(order.ScheduledFor, location), order.Notes,
+			}); err != nil {
+				return err
+			}
+		}
+		return out.Flush()
+	})
+	return closeExport(out, err)
+}
+
+// ExportReservations writes a restaurant's reservations, optionally only those starting on one date
+func (s *ExportService) ExportReservations(ctx context.Context, restaurantID uint, date *time.Time, format string, w io.Writer) error {
+	location, err := s.location(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	out, err := newExportWriter(w, format, "Reservations", []string{
+		"ID", "Start Time", "End Time", "Table", "Guests", "Status", "Guest Name", "Guest Email", "Notes", "Created At",
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.reservationRepo.ExportWithContext(ctx, restaurantID, date, func(reservations []models.Reservation) error {
+		for _, reservation := range reservations {
+			guestName := strings.TrimSpace(reservation.User.FirstName + " " + reservation.User.LastName)
+			if err := out.WriteRow([]interface{}{
+				reservation.ID, reservation.StartTime.In(location), reservation.EndTime.In(location),
+				reservation.TableNumber, reservation.NumberOfGuests, reservation.Status, guestName,
+				reservation.User.Email, reservation.Notes, reservation.CreatedAt.In(location),
+			}); err != nil {
+				return err
+			}
+		}
+		return out.Flush()
+	})
+	return closeExport(out, err)
+}
+
+// ExportCustomers writes a restaurant's customers matching the search term, all customers when empty
+func (s *ExportService) ExportCustomers(ctx context.Context, restaurantID uint, term, format string, w io.Writer) error {
+	location, err := s.location(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	out, err := newExportWriter(w, format, "Customers", []string{
+		"ID", "Name", "Email", "Phone", "User ID", "Order Count", "Total Spent", "Visit Count",
+		"Last Visit At", "Notes", "Created At",
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.customerRepo.ExportWithContext(ctx, restaurantID, term, func(customers []models.Customer) error {
+		for _, customer := range customers {
+			var userID interface{}
+			if customer.UserID != nil {
+				userID = *customer.UserID
+			}
+			if err := out.WriteRow([]interface{}{
+				customer.ID, customer.Name, customer.Email, customer.Phone, userID, customer.OrderCount,
+				customer.TotalSpent, customer.VisitCount, localTime(customer.LastVisitAt, location),
+				customer.Notes, customer.CreatedAt.In(location),
+			}); err != nil {
+				return err
+			}
+		}
+		return out.Flush()
+	})
+	return closeExport(out, err)
+}
+
+// location returns the restaurant's time zone, UTC without settings
+func (s *ExportService) location(ctx context.Context, restaurantID uint) (*time.Location, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+	return settingsLocation(settings), nil
+}
+
+// localTime converts an optional time to the location; nil stays an empty cell
+func localTime(t *time.Time, location *time.Location) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.In(location)
+}
+
+// closeExport finishes the export; after a failed read it only releases the writer
+func closeExport(out exportWriter, err error) error {
+	if err != nil {
+		_ = out.Abort()
+		return fmt.Errorf("failed to export: %w", err)
+	}
+	return out.Close()
+}
+
+// exportWriter writes the rows of a tabular export
+type exportWriter interface {
+	WriteRow(values []interface{}) error
+	Flush() error // Sends the rows written so far, where the format allows
+	Close() error // Completes the export
+	Abort() error // Releases the writer without completing the export
+}
+
+// newExportWriter starts an export in the format with the header row
+func newExportWriter(w io.Writer, format, sheet string, header []string) (exportWriter, error) {
+	var out exportWriter
+	switch format {
+	case ExportFormatCSV:
+		out = &csvExportWriter{w: w, csv: csv.NewWriter(w)}
+	case ExportFormatXLSX:
+		xlsx, err := newXLSXExportWriter(w, sheet)
+		if err != nil {
+			return nil, err
+		}
+		out = xlsx
+	default:
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "format must be csv or xlsx")
+	}
+
+	row := make([]interface{}, len(header))
+	for i, name := range header {
+		row[i] = name
+	}
+	if err := out.WriteRow(row); err != nil {
+		_ = out.Abort()
+		return nil, err
+	}
+	return out, nil
+}
+
+// csvExportWriter writes CSV, flushing each batch to the client
+type csvExportWriter struct {
+	w   io.Writer
+	csv *csv.Writer
+}
+
+// WriteRow writes one CSV record
+func (e *csvExportWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = csvValue(value)
+	}
+	return e.csv.Write(record)
+}
+
+// Flush sends the buffered records, down to the client when w is a streaming response
+func (e *csvExportWriter) Flush() error {
+	e.csv.Flush()
+	if err := e.csv.Error(); err != nil {
+		return err
+	}
+	if flusher, ok := e.w.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// Close flushes the remaining records
+func (e *csvExportWriter) Close() error {
+	return e.Flush()
+}
+
+// Abort does nothing: the rows already sent can't be taken back
+func (e *csvExportWriter) Abort() error {
+	return nil
+}
+
+// csvValue formats a cell; text that a spreadsheet would run as a formula is prefixed with a quote
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// xlsxExportWriter writes an XLSX workbook through excelize's stream writer, which keeps rows on disk
+// rather than in memory; the workbook can only be sent once complete
+type xlsxExportWriter struct {
+	w         io.Writer
+	file      *excelize.File
+	stream    *excelize.StreamWriter
+	row       int
+	timeStyle int
+}
+
+// newXLSXExportWriter creates a workbook with a single sheet
+func newXLSXExportWriter(w io.Writer, sheet string) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", sheet); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	timeStyle, err := file.NewStyle(&excelize.Style{NumFmt: 22}) // m/d/yy h:mm
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &xlsxExportWriter{w: w, file: file, stream: stream, timeStyle: timeStyle}, nil
+}
+
+// WriteRow appends one row to the sheet
+func (e *xlsxExportWriter) WriteRow(values []interface{}) error {
+	e.row++
+	cells := make([]interface{}, len(values))
+	for i, value := range values {
+		if t, ok := value.(time.Time); ok {
+			// Excel has no time zones: the cell holds the wall clock time in the restaurant's zone
+			wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+			cells[i] = excelize.Cell{StyleID: e.timeStyle, Value: wall}
+			continue
+		}
+		cells[i] = value
+	}
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	return e.stream.SetRow(cell, cells)
+}
+
+// Flush does nothing: rows are buffered by the stream writer until the workbook is complete
+func (e *xlsxExportWriter) Flush() error {
+	return nil
+}
+
+// Close completes the workbook, writes it out and removes its temporary files
+func (e *xlsxExportWriter) Close() error {
+	defer e.file.Close()
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	_, err := e.file.WriteTo(e.w)
+	return err
+}
+
+// Abort removes the workbook's temporary files
+func (e *xlsxExportWriter) Abort() error {
+	return e.file.Close()
+}
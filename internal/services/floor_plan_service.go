@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// upcomingReservationWindow is how far ahead the live floor plan marks tables as reserved
+const upcomingReservationWindow = 2 * time.Hour
+
+// Live table statuses
+const (
+	TableStatusAvailable = "available"
+	TableStatusReserved  = "reserved" // A reservation starts within the upcoming window
+	TableStatusOccupied  = "occupied" // A party is seated (open check) or a reservation is in progress
+	TableStatusInactive  = "inactive"
+)
+
+// FloorPlanService manages the floor plan and annotates it with the live table status for the host stand
+type FloorPlanService struct {
+	floorPlanRepo   *repositories.FloorPlanRepository
+	reservationRepo *repositories.ReservationRepository
+	sessionRepo     *repositories.TableSessionRepository
+}
+
+// NewFloorPlanService creates a new FloorPlanService instance
+func NewFloorPlanService(
+	floorPlanRepo *repositories.FloorPlanRepository,
+	reservationRepo *repositories.ReservationRepository,
+	sessionRepo *repositories.TableSessionRepository,
+) *FloorPlanService {
+	return &FloorPlanService{
+		floorPlanRepo:   floorPlanRepo,
+		reservationRepo: reservationRepo,
+		sessionRepo:     sessionRepo,
+	}
+}
+
+// FloorSectionRequest represents a floor section create or update request
+type FloorSectionRequest struct {
+	Name      string `json:"name" binding:"required,max=100"`
+	SortOrder int    `json:"sort_order"`
+	Width     int    `json:"width" binding:"omitempty,min=1,max=10000"` // Defaults to 1000
+	Height    int    `json:"height" binding:"omitempty,min=1,max=10000"`
+}
+
+// DiningTableRequest represents a dining table create or update request
+type DiningTableRequest struct {
+	SectionID uint   `json:"section_id" binding:"required"`
+	Number    string `json:"number" binding:"required,max=20"`
+	Seats     int    `json:"seats" binding:"required,min=1,max=100"`
+	Shape     string `json:"shape" binding:"required,oneof=round square rectangle"`
+	X         int    `json:"x" binding:"min=0"`
+	Y         int    `json:"y" binding:"min=0"`
+	Width     int    `json:"width" binding:"required,min=1"`
+	Height    int    `json:"height" binding:"required,min=1"`
+	Rotation  int    `json:"rotation" binding:"min=0,max=359"`
+	IsActive  *bool  `json:"is_active"`
+}
+
+// LiveFloorPlan is the floor plan with the status of every table at a point in time
+// Reservations for table numbers that aren't on the plan are listed as unplaced
+type LiveFloorPlan struct {
+	At                   time.Time          `json:"at"`
+	Sections             []LiveFloorSection `json:"sections"`
+	UnplacedReservations []TableReservation `json:"unplaced_reservations"`
+}
+
+// LiveFloorSection is a floor section with the live status of its tables
+type LiveFloorSection struct {
+	ID        uint        `json:"id"`
+	Name      string      `json:"name"`
+	SortOrder int         `json:"sort_order"`
+	Width     int         `json:"width"`
+	Height    int         `json:"height"`
+	Tables    []LiveTable `json:"tables"`
+}
+
+// LiveTable is a dining table with its status, open check, current and next reservation
+type LiveTable struct {
+	models.DiningTable
+	Status             string            `json:"status"`
+	OpenSession        *LiveTableSession `json:"open_session,omitempty"`
+	CurrentReservation *TableReservation `json:"current_reservation,omitempty"`
+	NextReservation    *TableReservation `json:"next_reservation,omitempty"`
+}
+
+// LiveTableSession is the open check of the party seated at a table
+type LiveTableSession struct {
+	ID       uint      `json:"id"`
+	Guests   int       `json:"guests"`
+	OpenedAt time.Time `json:"opened_at"`
+}
+
+// TableReservation is the host-stand view of a reservation
+type TableReservation struct {
+	ID             uint      `json:"id"`
+	TableNumber    string    `json:"table_number"`
+	GuestName      string    `json:"guest_name"`
+	NumberOfGuests int       `json:"number_of_guests"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	Status         string    `json:"status"`
+}
+
+// GetFloorPlan returns the floor sections of a restaurant with their tables
+func (s *FloorPlanService) GetFloorPlan(ctx context.Context, restaurantID uint) ([]models.FloorSection, error) {
+	return s.floorPlanRepo.GetSectionsWithContext(ctx, restaurantID)
+}
+
+// GetLiveFloorPlan returns the floor plan annotated with each table's status at the given time
+// Open checks are the current seating, so they are shown whatever the time
+func (s *FloorPlanService) GetLiveFloorPlan(ctx context.Context, restaurantID uint, at time.Time) (*LiveFloorPlan, error) {
+	sections, err := s.floorPlanRepo.GetSectionsWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := s.reservationRepo.GetOpenBetweenWithContext(ctx, restaurantID, at, at.Add(upcomingReservationWindow))
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.sessionRepo.GetOpenWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	openSessions := make(map[string]*LiveTableSession, len(sessions))
+	for _, session := range sessions {
+		openSessions[session.TableNumber] = &LiveTableSession{ID: session.ID, Guests: session.Guests, OpenedAt: session.OpenedAt}
+	}
+
+	// Reservations are ordered by start time, so the first of a table is its current or next one
+	byTable := make(map[string][]models.Reservation)
+	for _, reservation := range reservations {
+		byTable[reservation.TableNumber] = append(byTable[reservation.TableNumber], reservation)
+	}
+
+	plan := &LiveFloorPlan{
+		At:                   at,
+		Sections:             make([]LiveFloorSection, 0, len(sections)),
+		UnplacedReservations: []TableReservation{},
+	}
+	for _, section := range sections {
+		live := LiveFloorSection{
+			ID:        section.ID,
+			Name:      section.Name,
+			SortOrder: section.SortOrder,
+			Width:     section.Width,
+			Height:    section.Height,
+			Tables:    make([]LiveTable, 0, len(section.Tables)),
+		}
+		for _, table := range section.Tables {
+			live.Tables = append(live.Tables, liveTable(table, openSessions[table.Number], byTable[table.Number], at))
+			delete(byTable, table.Number)
+		}
+		plan.Sections = append(plan.Sections, live)
+	}
+
+	for _, reservation := range reservations {
+		if _, unplaced := byTable[reservation.TableNumber]; unplaced {
+			plan.UnplacedReservations = append(plan.UnplacedReservations, *newTableReservation(&reservation))
+		}
+	}
+	return plan, nil
+}
+
+// CreateSection creates a floor section
+func (s *FloorPlanService) CreateSection(ctx context.Context, restaurantID uint, req *FloorSectionRequest) (*models.FloorSection, error) {
+	section := &models.FloorSection{RestaurantID: restaurantID}
+	applyFloorSectionRequest(section, req)
+
+	if err := s.floorPlanRepo.CreateSectionWithContext(ctx, section); err != nil {
+		return nil, err
+	}
+	section.Tables = []models.DiningTable{}
+	return section, nil
+}
+
+// UpdateSection updates a floor section
+func (s *FloorPlanService) UpdateSection(ctx context.Context, id, restaurantID uint, req *FloorSectionRequest) (*models.FloorSection, error) {
+	section, err := s.floorPlanRepo.GetSectionForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeFloorSectionNotFound, "floor section not found")
+	}
+	applyFloorSectionRequest(section, req)
+
+	if err := s.floorPlanRepo.UpdateSectionWithContext(ctx, section); err != nil {
+		return nil, err
+	}
+	return section, nil
+}
+
+// DeleteSection deletes a floor section with its tables
+func (s *FloorPlanService) DeleteSection(ctx context.Context, id, restaurantID uint) error {
+	if err := s.floorPlanRepo.DeleteSectionForRestaurant(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeFloorSectionNotFound, "floor section not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateTable places a dining table on a floor section
+func (s *FloorPlanService) CreateTable(ctx context.Context, restaurantID uint, req *DiningTableRequest) (*models.DiningTable, error) {
+	table := &models.DiningTable{RestaurantID: restaurantID, IsActive: true}
+	if err := s.applyDiningTableRequest(ctx, table, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.floorPlanRepo.CreateTableWithContext(ctx, table); err != nil {
+		return nil, tableError(err)
+	}
+	return table, nil
+}
+
+// UpdateTable updates or moves a dining table
+func (s *FloorPlanService) UpdateTable(ctx context.Context, id, restaurantID uint, req *DiningTableRequest) (*models.DiningTable, error) {
+	table, err := s.floorPlanRepo.GetTableForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeTableNotFound, "table not found")
+	}
+	if err := s.applyDiningTableRequest(ctx, table, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.floorPlanRepo.UpdateTableWithContext(ctx, table); err != nil {
+		return nil, tableError(err)
+	}
+	return table, nil
+}
+
+// DeleteTable removes a dining table from the floor plan
+func (s *FloorPlanService) DeleteTable(ctx context.Context, id, restaurantID uint) error {
+	if err := s.floorPlanRepo.DeleteTableForRestaurant(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeTableNotFound, "table not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// applyDiningTableRequest checks the table's section and copies the request onto the table
+func (s *FloorPlanService) applyDiningTableRequest(ctx context.Context, table *models.DiningTable, req *DiningTableRequest) error {
+	if _, err := s.floorPlanRepo.GetSectionForRestaurant(ctx, req.SectionID, table.RestaurantID); err != nil {
+		return apperrors.NotFound(apperrors.CodeFloorSectionNotFound, "floor section not found")
+	}
+
+	number := strings.TrimSpace(req.Number)
+	if number == "" {
+		return apperrors.BadRequest(apperrors.CodeBadRequest, "table number is required")
+	}
+
+	table.SectionID = req.SectionID
+	table.Number = number
+	table.Seats = req.Seats
+	table.Shape = req.Shape
+	table.X = req.X
+	table.Y = req.Y
+	table.Width = req.Width
+	table.Height = req.Height
+	table.Rotation = req.Rotation
+	if req.IsActive != nil {
+		table.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// applyFloorSectionRequest copies the request onto the section
+func applyFloorSectionRequest(section *models.FloorSection, req *FloorSectionRequest) {
+	section.Name = strings.TrimSpace(req.Name)
+	section.SortOrder = req.SortOrder
+	section.Width = 1000
+	if req.Width > 0 {
+		section.Width = req.Width
+	}
+	section.Height = 1000
+	if req.Height > 0 {
+		section.Height = req.Height
+	}
+}
+
+// liveTable annotates a table with its open check and its reservations overlapping the upcoming window,
+// ordered by start time
+func liveTable(table models.DiningTable, session *LiveTableSession, reservations []models.Reservation, at time.Time) LiveTable {
+	live := LiveTable{DiningTable: table, Status: TableStatusAvailable, OpenSession: session}
+	for i := range reservations {
+		reservation := &reservations[i]
+		if !reservation.StartTime.After(at) {
+			if live.CurrentReservation == nil {
+				live.CurrentReservation = newTableReservation(reservation)
+			}
+			continue
+		}
+		live.NextReservation = newTableReservation(reservation)
+		break
+	}
+
+	switch {
+	case !table.IsActive:
+		live.Status = TableStatusInactive
+	case live.OpenSession != nil, live.CurrentReservation != nil:
+		live.Status = TableStatusOccupied
+	case live.NextReservation != nil:
+		live.Status = TableStatusReserved
+	}
+	return live
+}
+
+// newTableReservation builds the host-stand view of a reservation
+func newTableReservation(reservation *models.Reservation) *TableReservation {
+	return &TableReservation{
+		ID:             reservation.ID,
+		TableNumber:    reservation.TableNumber,
+		GuestName:      strings.TrimSpace(reservation.User.FirstName + " " + reservation.User.LastName),
+		NumberOfGuests: reservation.NumberOfGuests,
+		StartTime:      reservation.StartTime,
+		EndTime:        reservation.EndTime,
+		Status:         reservation.Status,
+	}
+}
+
+// tableError maps duplicate table numbers to a conflict
+func tableError(err error) error {
+	if errors.Is(err, repositories.ErrTableNumberExists) {
+		return apperrors.Conflict(apperrors.CodeTableNumberExists, "a table with this number already exists")
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"restaurant-backend/internal/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Dependency check statuses reported by the readiness probe
+const (
+	DependencyStatusOK            = "ok"
+	DependencyStatusError         = "error"
+	DependencyStatusNotConfigured = "not_configured"
+)
+
+// Dependency names reported by the readiness probe
+const (
+	DependencyDatabase = "database"
+	DependencyStorage  = "storage"
+	DependencyRedis    = "redis"
+)
+
+// DependencyStatus is the result of checking a single dependency
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+// ReadinessReport is the overall readiness with per-dependency status
+type ReadinessReport struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+// Ready reports whether every configured dependency is reachable
+func (r *ReadinessReport) Ready() bool {
+	return r.Status == DependencyStatusOK
+}
+
+// HealthService checks that the dependencies needed to serve traffic are reachable
+type HealthService struct {
+	db      *gorm.DB
+	storage Storage // nil when no storage backend is configured
+	timeout time.Duration
+}
+
+// NewHealthService creates a new HealthService instance
+func NewHealthService(db *gorm.DB, storage Storage, timeout time.Duration) *HealthService {
+	return &HealthService{
+		db:      db,
+		storage: storage,
+		timeout: timeout,
+	}
+}
+
+// CheckReadiness pings Postgres and object storage concurrently, each bounded by the check timeout
+// Redis is reported as not configured since this service does not use it yet
+func (s *HealthService) CheckReadiness(ctx context.Context) *ReadinessReport {
+	checks := map[string]func(context.Context) error{
+		DependencyDatabase: s.pingDatabase,
+	}
+	if s.storage != nil {
+		checks[DependencyStorage] = s.storage.Ping
+	}
+
+	report := &ReadinessReport{
+		Status: DependencyStatusOK,
+		Dependencies: map[string]DependencyStatus{
+			DependencyStorage: {Status: DependencyStatusNotConfigured},
+			DependencyRedis:   {Status: DependencyStatusNotConfigured},
+		},
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func(context.Context) error) {
+			defer wg.Done()
+
+			status := s.runCheck(ctx, name, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Dependencies[name] = status
+			if status.Status != DependencyStatusOK {
+				report.Status = DependencyStatusError
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// runCheck times a single dependency check; failures are logged rather than returned
+// so the public probe does not leak connection details
+func (s *HealthService) runCheck(ctx context.Context, name string, check func(context.Context) error) DependencyStatus {
+	checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(checkCtx)
+	latency := time.Since(start)
+
+	if err != nil {
+		logger.Warn("Readiness check failed",
+			zap.String("dependency", name),
+			zap.Duration("latency", latency),
+			zap.Error(err),
+		)
+		return DependencyStatus{Status: DependencyStatusError, LatencyMs: latency.Milliseconds()}
+	}
+
+	return DependencyStatus{Status: DependencyStatusOK, LatencyMs: latency.Milliseconds()}
+}
+
+// pingDatabase checks that a pooled Postgres connection answers
+func (s *HealthService) pingDatabase(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
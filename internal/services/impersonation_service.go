@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+// ImpersonationService lets platform KAMs act as a restaurant's admin for support
+// Every session start and every request made while impersonating is recorded in the audit log
+type ImpersonationService struct {
+	authService    *AuthService
+	userRepo       *repositories.UserRepository
+	restaurantRepo *repositories.RestaurantRepository
+	auditLogRepo   *repositories.AuditLogRepository
+	tokenTTL       time.Duration
+}
+
+// NewImpersonationService creates a new ImpersonationService instance
+func NewImpersonationService(
+	authService *AuthService,
+	userRepo *repositories.UserRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	auditLogRepo *repositories.AuditLogRepository,
+	tokenTTL time.Duration,
+) *ImpersonationService {
+	return &ImpersonationService{
+		authService:    authService,
+		userRepo:       userRepo,
+		restaurantRepo: restaurantRepo,
+		auditLogRepo:   auditLogRepo,
+		tokenTTL:       tokenTTL,
+	}
+}
+
+// StartImpersonationRequest represents a request to act as a restaurant
+type StartImpersonationRequest struct {
+	RestaurantID uint   `json:"restaurant_id" binding:"required"`
+	Reason       string `json:"reason" binding:"required,min=5,max=500"` // e.g. support ticket reference
+}
+
+// ImpersonationSession is an issued impersonation token
+type ImpersonationSession struct {
+	Token      string            `json:"token"`
+	ExpiresAt  time.Time         `json:"expires_at"`
+	Restaurant models.Restaurant `json:"restaurant"`
+}
+
+// StartImpersonation issues a short-lived admin token for the restaurant to a platform KAM
+// The session is audit-logged before the token is issued; no token is issued if it can't be recorded
+func (s *ImpersonationService) StartImpersonation(ctx context.Context, req *StartImpersonationRequest, actor AuditActor) (*ImpersonationSession, error) {
+	kam, err := s.userRepo.GetByIDWithContext(ctx, actor.UserID)
+	if err != nil {
+		return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "user not found")
+	}
+	if !kam.IsPlatformUser() || !kam.IsKAM() || !kam.IsActive {
+		return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "only platform KAMs can impersonate restaurants")
+	}
+
+	if models.IsPlatformOrganization(req.RestaurantID) {
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "the platform organization cannot be impersonated")
+	}
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, req.RestaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	details, _ := json.Marshal(map[string]interface{}{
+		"reason":      strings.TrimSpace(req.Reason),
+		"ttl_seconds": int(s.tokenTTL.Seconds()),
+	})
+	entry := &models.AuditLog{
+		RestaurantID: &restaurant.ID,
+		ActorUserID:  kam.ID,
+		ActorRole:    kam.Role,
+		Action:       models.AuditActionImpersonationStart,
+		Details:      string(details),
+		IPAddress:    actor.IPAddress,
+		UserAgent:    actor.UserAgent,
+	}
+	if err := s.auditLogRepo.CreateWithContext(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to record audit log: %w", err)
+	}
+
+	token, expiresAt, err := s.authService.GenerateImpersonationToken(ctx, kam, restaurant.ID, entry.ID, s.tokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImpersonationSession{
+		Token:      token,
+		ExpiresAt:  expiresAt,
+		Restaurant: *restaurant,
+	}, nil
+}
+
+// ImpersonatedRequest describes a request made with an impersonation token
+type ImpersonatedRequest struct {
+	Method    string
+	Route     string
+	Status    int
+	IPAddress string
+	UserAgent string
+}
+
+// RecordImpersonatedRequest audit-logs a request a KAM made while impersonating the restaurant
+func (s *ImpersonationService) RecordImpersonatedRequest(ctx context.Context, kamUserID, restaurantID uint, req ImpersonatedRequest) error {
+	details, _ := json.Marshal(map[string]interface{}{
+		"method": req.Method,
+		"route":  req.Route,
+		"status": req.Status,
+	})
+	return s.auditLogRepo.CreateWithContext(ctx, &models.AuditLog{
+		RestaurantID: &restaurantID,
+		ActorUserID:  kamUserID,
+		ActorRole:    "KAM",
+		Action:       models.AuditActionImpersonatedRequest,
+		Details:      string(details),
+		IPAddress:    req.IPAddress,
+		UserAgent:    req.UserAgent,
+	})
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// IntegrityService detects and quarantines cross-tenant data anomalies
+type IntegrityService struct {
+	integrityRepo  *repositories.IntegrityRepository
+	autoQuarantine bool
+}
+
+// NewIntegrityService creates a new IntegrityService instance
+// When autoQuarantine is set, offending rows are quarantined as soon as they are found
+func NewIntegrityService(integrityRepo *repositories.IntegrityRepository, autoQuarantine bool) *IntegrityService {
+	return &IntegrityService{
+		integrityRepo:  integrityRepo,
+		autoQuarantine: autoQuarantine,
+	}
+}
+
+// IntegrityRunResult summarizes a single integrity scan
+type IntegrityRunResult struct {
+	Found       int       `json:"found"`       // Anomalies currently present
+	New         int       `json:"new"`         // Anomalies not reported by a previous run
+	Quarantined int       `json:"quarantined"` // Rows quarantined automatically during this run
+	RanAt       time.Time `json:"ran_at"`
+}
+
+// IntegrityFindingList is a page of integrity findings
+type IntegrityFindingList struct {
+	Findings []models.IntegrityFinding `json:"findings"`
+	Total    int64                     `json:"total"`
+	Limit    int                       `json:"limit"`
+	Offset   int                       `json:"offset"`
+}
+
+// RunChecks scans all tenants for integrity anomalies and records new findings
+func (s *IntegrityService) RunChecks(ctx context.Context) (*IntegrityRunResult, error) {
+	result := &IntegrityRunResult{RanAt: time.Now()}
+
+	findings, err := s.integrityRepo.ScanWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.Found = len(findings)
+
+	created, err := s.integrityRepo.SaveFindingsWithContext(ctx, findings)
+	if err != nil {
+		return nil, err
+	}
+	result.New = len(created)
+
+	for i := range created {
+		logger.Warn("Tenant integrity anomaly detected",
+			zap.String("check", created[i].CheckName),
+			zap.String("table", created[i].SourceTable),
+			zap.Uint("row_id", created[i].RowID),
+			zap.Uint("restaurant_id", created[i].RestaurantID),
+		)
+
+		if !s.autoQuarantine {
+			continue
+		}
+		if err := s.integrityRepo.QuarantineWithContext(ctx, &created[i]); err != nil {
+			logger.Warn("Failed to quarantine integrity finding",
+				zap.Uint("finding_id", created[i].ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		result.Quarantined++
+	}
+
+	return result, nil
+}
+
+// ListFindings lists integrity findings, optionally filtered by status
+func (s *IntegrityService) ListFindings(ctx context.Context, status string, limit, offset int) (*IntegrityFindingList, error) {
+	switch status {
+	case "", models.IntegrityFindingOpen, models.IntegrityFindingQuarantined, models.IntegrityFindingDismissed:
+	default:
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid status filter")
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	findings, total, err := s.integrityRepo.ListFindingsWithContext(ctx, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IntegrityFindingList{
+		Findings: findings,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}, nil
+}
+
+// QuarantineFinding moves the row behind an open finding out of its table
+// The row is kept as a JSON snapshot on the finding
+func (s *IntegrityService) QuarantineFinding(ctx context.Context, id uint) (*models.IntegrityFinding, error) {
+	finding, err := s.getOpenFinding(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.integrityRepo.QuarantineWithContext(ctx, finding); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Quarantined integrity finding",
+		zap.Uint("finding_id", finding.ID),
+		zap.String("table", finding.SourceTable),
+		zap.Uint("row_id", finding.RowID),
+	)
+
+	return finding, nil
+}
+
+// DismissFinding marks an open finding as reviewed without touching the row
+func (s *IntegrityService) DismissFinding(ctx context.Context, id uint) (*models.IntegrityFinding, error) {
+	finding, err := s.getOpenFinding(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.integrityRepo.UpdateFindingStatusWithContext(ctx, id, models.IntegrityFindingDismissed); err != nil {
+		return nil, err
+	}
+	finding.Status = models.IntegrityFindingDismissed
+
+	return finding, nil
+}
+
+// getOpenFinding loads a finding and ensures it has not been resolved yet
+func (s *IntegrityService) getOpenFinding(ctx context.Context, id uint) (*models.IntegrityFinding, error) {
+	finding, err := s.integrityRepo.GetFindingByIDWithContext(ctx, id)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeFindingNotFound, "integrity finding not found")
+	}
+	if finding.Status != models.IntegrityFindingOpen {
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "integrity finding is already "+finding.Status)
+	}
+	return finding, nil
+}
+
+// IntegrityJob is the scheduled job that runs the integrity checks; a zero interval disables it
+func (s *IntegrityService) IntegrityJob(interval time.Duration) Job {
+	return Job{Name: "integrity_check", Interval: interval, Run: s.runIntegrityJob}
+}
+
+// runIntegrityJob runs the integrity checks and logs new anomalies
+func (s *IntegrityService) runIntegrityJob(ctx context.Context) error {
+	result, err := s.RunChecks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to run tenant integrity checks: %w", err)
+	}
+	if result.New > 0 {
+		logger.Info("Tenant integrity checks found new anomalies",
+			zap.Int("new", result.New),
+			zap.Int("found", result.Found),
+			zap.Int("quarantined", result.Quarantined),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// InventoryService manages ingredients, their stock and the recipes linking them to menu items,
+// and costs menu items from their recipes
+type InventoryService struct {
+	ingredientRepo *repositories.IngredientRepository
+	supplierRepo   *repositories.SupplierRepository
+	menuItemRepo   *repositories.MenuItemRepository
+}
+
+// NewInventoryService creates a new InventoryService instance
+func NewInventoryService(
+	ingredientRepo *repositories.IngredientRepository,
+	supplierRepo *repositories.SupplierRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+) *InventoryService {
+	return &InventoryService{
+		ingredientRepo: ingredientRepo,
+		supplierRepo:   supplierRepo,
+		menuItemRepo:   menuItemRepo,
+	}
+}
+
+// IngredientRequest represents an ingredient create or update request
+// Stock and cost are set on creation (opening stock); afterwards goods receipts maintain them
+type IngredientRequest struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Unit          string   `json:"unit" binding:"required,max=20"`
+	SupplierID    *uint    `json:"supplier_id"`
+	StockQuantity *float64 `json:"stock_quantity" binding:"omitempty,min=0"`
+	CostPrice     *float64 `json:"cost_price" binding:"omitempty,min=0"`
+}
+
+// RecipeRequest replaces the recipe of a menu item
+type RecipeRequest struct {
+	Lines []RecipeLineRequest `json:"lines" binding:"max=100,dive"`
+}
+
+// RecipeLineRequest is an ingredient of a recipe
+type RecipeLineRequest struct {
+	IngredientID uint    `json:"ingredient_id" binding:"required"`
+	Quantity     float64 `json:"quantity" binding:"required,gt=0"` // In the ingredient's unit, per serving
+}
+
+// Recipe is the recipe of a menu item with what one serving costs at current ingredient costs
+type Recipe struct {
+	MenuItemID uint                        `json:"menu_item_id"`
+	Lines      []models.MenuItemIngredient `json:"lines"`
+	FoodCost   money.Cents                 `json:"food_cost"`
+}
+
+// MenuItemCost is the cost of goods of a menu item and the margin at its regular price
+type MenuItemCost struct {
+	MenuItemID    uint        `json:"menu_item_id"`
+	Name          string      `json:"name"`
+	CategoryID    uint        `json:"category_id"`
+	CategoryName  string      `json:"category_name"`
+	Price         money.Cents `json:"price"`
+	FoodCost      money.Cents `json:"food_cost"`
+	Margin        money.Cents `json:"margin"`
+	MarginPercent float64     `json:"margin_percent"` // Margin as a percentage of the price
+	HasRecipe     bool        `json:"has_recipe"`     // Items without a recipe have no food cost
+}
+
+// ListIngredients lists a restaurant's ingredients with their stock
+func (s *InventoryService) ListIngredients(ctx context.Context, restaurantID uint) ([]models.Ingredient, error) {
+	return s.ingredientRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// CreateIngredient creates an ingredient with its opening stock
+func (s *InventoryService) CreateIngredient(ctx context.Context, restaurantID uint, req *IngredientRequest) (*models.Ingredient, error) {
+	ingredient := &models.Ingredient{RestaurantID: restaurantID}
+	if err := s.applyIngredientRequest(ctx, ingredient, req); err != nil {
+		return nil, err
+	}
+	if req.StockQuantity != nil {
+		ingredient.StockQuantity = *req.StockQuantity
+	}
+	if req.CostPrice != nil {
+		ingredient.CostPrice = *req.CostPrice
+	}
+
+	if err := s.ingredientRepo.CreateWithContext(ctx, ingredient); err != nil {
+		return nil, ingredientError(err)
+	}
+	return ingredient, nil
+}
+
+// UpdateIngredient renames an ingredient or changes its unit or preferred supplier
+// Stock and cost are left to goods receipts
+func (s *InventoryService) UpdateIngredient(ctx context.Context, id, restaurantID uint, req *IngredientRequest) (*models.Ingredient, error) {
+	ingredient, err := s.getIngredient(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyIngredientRequest(ctx, ingredient, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ingredientRepo.UpdateWithContext(ctx, ingredient); err != nil {
+		return nil, ingredientError(err)
+	}
+	return ingredient, nil
+}
+
+// DeleteIngredient deletes an ingredient no recipe or purchase order uses
+func (s *InventoryService) DeleteIngredient(ctx context.Context, id, restaurantID uint) error {
+	if err := s.ingredientRepo.DeleteForRestaurant(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeIngredientNotFound, "ingredient not found")
+		}
+		if errors.Is(err, repositories.ErrIngredientInUse) {
+			return apperrors.Conflict(apperrors.CodeIngredientInUse, "ingredient is used by recipes, purchase orders or waste entries")
+		}
+		return err
+	}
+	return nil
+}
+
+// GetRecipe returns the recipe of a menu item
+func (s *InventoryService) GetRecipe(ctx context.Context, restaurantID, menuItemID uint) (*Recipe, error) {
+	if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, menuItemID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+	}
+
+	lines, err := s.ingredientRepo.GetRecipeWithContext(ctx, restaurantID, menuItemID)
+	if err != nil {
+		return nil, err
+	}
+	return newRecipe(menuItemID, lines), nil
+}
+
+// ReplaceRecipe replaces the recipe of a menu item; an empty recipe removes it
+func (s *InventoryService) ReplaceRecipe(ctx context.Context, restaurantID, menuItemID uint, req *RecipeRequest) (*Recipe, error) {
+	if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, menuItemID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+	}
+
+	ids := make([]uint, 0, len(req.Lines))
+	seen := make(map[uint]bool, len(req.Lines))
+	for _, line := range req.Lines {
+		if seen[line.IngredientID] {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "each ingredient can only be listed once")
+		}
+		seen[line.IngredientID] = true
+		ids = append(ids, line.IngredientID)
+	}
+	if len(ids) > 0 {
+		ingredients, err := s.ingredientRepo.GetByIDsWithContext(ctx, restaurantID, ids)
+		if err != nil {
+			return nil, err
+		}
+		if len(ingredients) != len(ids) {
+			return nil, apperrors.NotFound(apperrors.CodeIngredientNotFound, "ingredient not found")
+		}
+	}
+
+	lines := make([]models.MenuItemIngredient, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, models.MenuItemIngredient{
+			RestaurantID: restaurantID,
+			MenuItemID:   menuItemID,
+			IngredientID: line.IngredientID,
+			Quantity:     line.Quantity,
+		})
+	}
+	if err := s.ingredientRepo.ReplaceRecipeWithContext(ctx, restaurantID, menuItemID, lines); err != nil {
+		return nil, err
+	}
+
+	return s.GetRecipe(ctx, restaurantID, menuItemID)
+}
+
+// MenuItemCosts costs every menu item from its recipe at current ingredient costs
+// Margins are at the item's regular price, before channel prices and pricing rules
+func (s *InventoryService) MenuItemCosts(ctx context.Context, restaurantID uint) ([]MenuItemCost, error) {
+	menuItems, err := s.menuItemRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := s.ingredientRepo.GetRecipesWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	foodCosts := make(map[uint]float64)
+	for _, line := range lines {
+		foodCosts[line.MenuItemID] += line.Quantity * line.Ingredient.CostPrice
+	}
+
+	costs := make([]MenuItemCost, 0, len(menuItems))
+	for _, item := range menuItems {
+		foodCost, hasRecipe := foodCosts[item.ID]
+		cost := MenuItemCost{
+			MenuItemID:   item.ID,
+			Name:         item.Name,
+			CategoryID:   item.CategoryID,
+			CategoryName: item.Category.Name,
+			Price:        item.Price,
+			FoodCost:     money.FromFloat(foodCost),
+			Margin:       item.Price - money.FromFloat(foodCost),
+			HasRecipe:    hasRecipe,
+		}
+		if item.Price > 0 {
+			price := item.Price.Float()
+			cost.MarginPercent = math.Round((price-foodCost)/price*10000) / 100
+		}
+		costs = append(costs, cost)
+	}
+	return costs, nil
+}
+
+// applyIngredientRequest checks the preferred supplier and copies the request onto the ingredient
+func (s *InventoryService) applyIngredientRequest(ctx context.Context, ingredient *models.Ingredient, req *IngredientRequest) error {
+	name := strings.TrimSpace(req.Name)
+	unit := strings.TrimSpace(req.Unit)
+	if name == "" || unit == "" {
+		return apperrors.BadRequest(apperrors.CodeBadRequest, "ingredient name and unit are required")
+	}
+	if req.SupplierID != nil {
+		if _, err := s.supplierRepo.GetByIDForRestaurant(ctx, *req.SupplierID, ingredient.RestaurantID); err != nil {
+			return apperrors.NotFound(apperrors.CodeSupplierNotFound, "supplier not found")
+		}
+	}
+
+	ingredient.Name = name
+	ingredient.Unit = unit
+	ingredient.SupplierID = req.SupplierID
+	return nil
+}
+
+// getIngredient retrieves an ingredient of the restaurant
+func (s *InventoryService) getIngredient(ctx context.Context, id, restaurantID uint) (*models.Ingredient, error) {
+	ingredient, err := s.ingredientRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeIngredientNotFound, "ingredient not found")
+		}
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+// newRecipe builds a recipe and costs one serving
+func newRecipe(menuItemID uint, lines []models.MenuItemIngredient) *Recipe {
+	if lines == nil {
+		lines = []models.MenuItemIngredient{}
+	}
+	var foodCost float64
+	for _, line := range lines {
+		foodCost += line.Quantity * line.Ingredient.CostPrice
+	}
+	return &Recipe{MenuItemID: menuItemID, Lines: lines, FoodCost: money.FromFloat(foodCost)}
+}
+
+// ingredientError maps ingredient repository errors to API errors
+func ingredientError(err error) error {
+	if errors.Is(err, repositories.ErrIngredientExists) {
+		return apperrors.Conflict(apperrors.CodeNameTaken, "an ingredient with this name already exists")
+	}
+	return err
+}
@@ -0,0 +1,330 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// errInvitationNotPending is returned for links of accepted, revoked or expired invitations
+var errInvitationNotPending = apperrors.New(http.StatusGone, apperrors.CodeInvitationNotPending, "invitation has expired or is no longer valid; ask for a new one")
+
+// CreateInvitationRequest represents an invitation to join the restaurant
+// KAM role is NOT allowed here
+type CreateInvitationRequest struct {
+	Email     string `json:"email" binding:"required,email"`
+	FirstName string `json:"first_name" binding:"required"`
+	LastName  string `json:"last_name" binding:"required"`
+	Role      string `json:"role" binding:"required,oneof=Admin Staff Client"`
+}
+
+// AcceptInvitationRequest represents accepting an invitation with the password the new user chose
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+// InvitationPreview is what the accept page shows before the user sets a password
+type InvitationPreview struct {
+	RestaurantName string    `json:"restaurant_name"`
+	Email          string    `json:"email"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+	Role           string    `json:"role"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// InvitationService onboards users by invitation: an admin invites an email address with a role, the invitee
+// follows the emailed link and sets their own password, which creates the account and signs them in
+type InvitationService struct {
+	invitationRepo      *repositories.UserInvitationRepository
+	userRepo            *repositories.UserRepository
+	restaurantRepo      *repositories.RestaurantRepository
+	subscriptionService *SubscriptionService
+	authService         *AuthService
+	mailer              Mailer
+	frontendURL         string
+	ttl                 time.Duration
+}
+
+// NewInvitationService creates a new InvitationService instance
+func NewInvitationService(
+	invitationRepo *repositories.UserInvitationRepository,
+	userRepo *repositories.UserRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	subscriptionService *SubscriptionService,
+	authService *AuthService,
+	mailer Mailer,
+	frontendURL string,
+	ttl time.Duration,
+) *InvitationService {
+	return &InvitationService{
+		invitationRepo:      invitationRepo,
+		userRepo:            userRepo,
+		restaurantRepo:      restaurantRepo,
+		subscriptionService: subscriptionService,
+		authService:         authService,
+		mailer:              mailer,
+		frontendURL:         strings.TrimRight(frontendURL, "/"),
+		ttl:                 ttl,
+	}
+}
+
+// Create invites someone to the restaurant and emails them the accept link
+func (s *InvitationService) Create(ctx context.Context, restaurantID uint, req *CreateInvitationRequest, inviterID uint) (*models.UserInvitation, error) {
+	if err := validateRole(req.Role); err != nil {
+		return nil, err
+	}
+	email := strings.TrimSpace(req.Email)
+
+	// Admin and Staff users count against the plan's staff limit; checked again on acceptance
+	if IsStaffRole(req.Role) {
+		if err := s.subscriptionService.CheckLimit(ctx, restaurantID, PlanResourceStaffUsers); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkEmailAvailable(ctx, email); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	pending, err := s.invitationRepo.ExistsPendingForEmailWithContext(ctx, restaurantID, email, now)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, apperrors.Conflict(apperrors.CodeEmailTaken, "this email address already has a pending invitation; resend it instead")
+	}
+
+	token := randomToken()
+	invitation := &models.UserInvitation{
+		RestaurantID: restaurantID,
+		Email:        email,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Role:         req.Role,
+		TokenHash:    hashLinkToken(token),
+		InvitedBy:    inviterID,
+		ExpiresAt:    now.Add(s.ttl),
+		SentAt:       now,
+	}
+	if err := s.invitationRepo.CreateWithContext(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	logger.Info("User invited",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.Uint("invitation_id", invitation.ID),
+		zap.String("role", invitation.Role),
+	)
+	s.send(ctx, invitation, token, inviterID)
+	invitation.Status = invitation.CurrentStatus(now)
+	return invitation, nil
+}
+
+// List returns the restaurant's invitations, newest first
+func (s *InvitationService) List(ctx context.Context, restaurantID uint) ([]models.UserInvitation, error) {
+	invitations, err := s.invitationRepo.ListWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range invitations {
+		invitations[i].Status = invitations[i].CurrentStatus(now)
+	}
+	return invitations, nil
+}
+
+// Resend issues a new accept link with a fresh expiry and emails it; earlier links stop working
+// Expired invitations can be resent, accepted and revoked ones can't
+func (s *InvitationService) Resend(ctx context.Context, restaurantID, id uint, inviterID uint) (*models.UserInvitation, error) {
+	invitation, err := s.get(ctx, restaurantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	token := randomToken()
+	reissued, err := s.invitationRepo.ReissueWithContext(ctx, invitation.ID, hashLinkToken(token), now.Add(s.ttl), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reissue invitation: %w", err)
+	}
+	if !reissued {
+		return nil, apperrors.Conflict(apperrors.CodeInvitationNotPending, "invitation was already accepted or revoked")
+	}
+	invitation.ExpiresAt = now.Add(s.ttl)
+	invitation.SentAt = now
+
+	s.send(ctx, invitation, token, inviterID)
+	invitation.Status = invitation.CurrentStatus(now)
+	return invitation, nil
+}
+
+// Revoke cancels an invitation; its link stops working
+func (s *InvitationService) Revoke(ctx context.Context, restaurantID, id uint) error {
+	if _, err := s.get(ctx, restaurantID, id); err != nil {
+		return err
+	}
+
+	revoked, err := s.invitationRepo.RevokeWithContext(ctx, restaurantID, id, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to revoke invitation: %w", err)
+	}
+	if !revoked {
+		return apperrors.Conflict(apperrors.CodeInvitationNotPending, "invitation was already accepted or revoked")
+	}
+	logger.Info("Invitation revoked", zap.Uint("restaurant_id", restaurantID), zap.Uint("invitation_id", id))
+	return nil
+}
+
+// Preview returns the invitation behind an accept link
+func (s *InvitationService) Preview(ctx context.Context, token string) (*InvitationPreview, error) {
+	invitation, err := s.pendingByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, invitation.RestaurantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load restaurant: %w", err)
+	}
+
+	return &InvitationPreview{
+		RestaurantName: restaurant.Name,
+		Email:          invitation.Email,
+		FirstName:      invitation.FirstName,
+		LastName:       invitation.LastName,
+		Role:           invitation.Role,
+		ExpiresAt:      invitation.ExpiresAt,
+	}, nil
+}
+
+// Accept creates the invited user with the password they chose and signs them in
+func (s *InvitationService) Accept(ctx context.Context, req *AcceptInvitationRequest, client ClientInfo) (*LoginResponse, error) {
+	invitation, err := s.pendingByToken(ctx, req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	if IsStaffRole(invitation.Role) {
+		if err := s.subscriptionService.CheckLimit(ctx, invitation.RestaurantID, PlanResourceStaffUsers); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkEmailAvailable(ctx, invitation.Email); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user := &models.User{
+		RestaurantID: invitation.RestaurantID,
+		Email:        invitation.Email,
+		PasswordHash: string(hashedPassword),
+		FirstName:    invitation.FirstName,
+		LastName:     invitation.LastName,
+		Role:         invitation.Role,
+		Timezone:     defaultTimezone,
+		Language:     defaultLanguage,
+		Preferences:  defaultPreferences,
+		IsActive:     true,
+	}
+	if err := s.invitationRepo.AcceptWithContext(ctx, invitation, user, time.Now()); err != nil {
+		if errors.Is(err, repositories.ErrInvitationNotPending) {
+			return nil, errInvitationNotPending
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	logger.Info("Invitation accepted",
+		zap.Uint("restaurant_id", invitation.RestaurantID),
+		zap.Uint("invitation_id", invitation.ID),
+		zap.Uint("user_id", user.ID),
+	)
+	return s.authService.CompleteLogin(ctx, user, client)
+}
+
+// get loads an invitation of the restaurant
+func (s *InvitationService) get(ctx context.Context, restaurantID, id uint) (*models.UserInvitation, error) {
+	invitation, err := s.invitationRepo.GetByIDWithContext(ctx, restaurantID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeInvitationNotFound, "invitation not found")
+		}
+		return nil, err
+	}
+	return invitation, nil
+}
+
+// pendingByToken loads the invitation of an accept link, rejecting links that no longer work
+func (s *InvitationService) pendingByToken(ctx context.Context, token string) (*models.UserInvitation, error) {
+	invitation, err := s.invitationRepo.GetByTokenHashWithContext(ctx, hashLinkToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeInvitationNotFound, "invitation not found")
+		}
+		return nil, err
+	}
+	if invitation.CurrentStatus(time.Now()) != models.InvitationStatusPending {
+		return nil, errInvitationNotPending
+	}
+	return invitation, nil
+}
+
+// checkEmailAvailable rejects email addresses that already have an account; logins look emails up across
+// restaurants, so an address can only belong to one user
+func (s *InvitationService) checkEmailAvailable(ctx context.Context, email string) error {
+	existing, err := s.userRepo.GetByEmailAnyRestaurant(ctx, email)
+	if err == nil && existing != nil {
+		return ErrUserExists
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// send emails the accept link; a failed email doesn't fail the request, the invitation can be resent
+func (s *InvitationService) send(ctx context.Context, invitation *models.UserInvitation, token string, inviterID uint) {
+	restaurantName := ""
+	if restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, invitation.RestaurantID); err == nil {
+		restaurantName = restaurant.Name
+	}
+	inviterName := ""
+	if inviter, err := s.userRepo.GetByIDWithContext(ctx, inviterID); err == nil {
+		inviterName = strings.TrimSpace(inviter.FirstName + " " + inviter.LastName)
+	}
+
+	acceptURL := s.frontendURL + "/invitations/accept#token=" + token
+	if err := s.mailer.SendUserInvitationEmail(ctx, invitation.RestaurantID, invitation.Email, invitation.FirstName,
+		restaurantName, inviterName, invitation.Role, acceptURL, invitation.ExpiresAt); err != nil {
+		logger.Warn("Failed to send invitation email",
+			zap.Uint("restaurant_id", invitation.RestaurantID),
+			zap.Uint("invitation_id", invitation.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// hashLinkToken derives the stored hash of an emailed link's token; the token itself is only emailed
+func hashLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
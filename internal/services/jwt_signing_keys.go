@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// signingKeyReloadCooldown limits the reloads triggered by tokens signed with a key this instance doesn't know yet
+const signingKeyReloadCooldown = 10 * time.Second
+
+// Signing key statuses
+const (
+	SigningKeyStatusSigning   = "signing"   // Signs new tokens
+	SigningKeyStatusVerifying = "verifying" // Replaced; still verifies the tokens it signed until they expire
+	SigningKeyStatusRetired   = "retired"   // No longer accepted
+)
+
+// Signing key sources
+const (
+	SigningKeySourceConfigured = "configured" // JWT_SECRET from the environment or the secret store
+	SigningKeySourceRotated    = "rotated"    // Created by a rotation, stored in the database
+)
+
+// errUnknownSigningKey is returned for tokens whose "kid" header names no accepted key
+var errUnknownSigningKey = apperrors.Unauthorized(apperrors.CodeInvalidToken, "unknown signing key")
+
+// signingKey is an HMAC secret with the key ID put in the "kid" header of the tokens it signs
+type signingKey struct {
+	id     string
+	secret []byte
+}
+
+// newSigningKey derives the key ID from the secret, so every instance names the same secret the same way
+func newSigningKey(secret string) signingKey {
+	sum := sha256.Sum256([]byte("jwt-kid:" + secret))
+	return signingKey{id: hex.EncodeToString(sum[:8]), secret: []byte(secret)}
+}
+
+// signingKeys is the key set of an instance: the configured secret (and the one it replaced in the secret store)
+// and the rotated keys from the database. The newest rotated key signs; the configured secret signs until the
+// first rotation and verifies until the tokens it signed have expired
+type signingKeys struct {
+	mu                 sync.RWMutex
+	configured         signingKey
+	configuredPrevious *signingKey
+	rotated            []signingKey // Accepted rotated keys, newest (the signing key) first
+	acceptConfigured   bool
+	reloadedAt         time.Time
+}
+
+// newSigningKeys creates the key set for the configured secret
+func newSigningKeys(secret string) *signingKeys {
+	return &signingKeys{configured: newSigningKey(secret), acceptConfigured: true}
+}
+
+// setConfigured replaces the configured secret, keeping the replaced one for verification; reports whether it changed
+func (k *signingKeys) setConfigured(secret string) bool {
+	key := newSigningKey(secret)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if key.id == k.configured.id {
+		return false
+	}
+	previous := k.configured
+	k.configured = key
+	k.configuredPrevious = &previous
+	return true
+}
+
+// setRotated replaces the rotated keys
+func (k *signingKeys) setRotated(rotated []signingKey, acceptConfigured bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.rotated = rotated
+	k.acceptConfigured = acceptConfigured
+	k.reloadedAt = time.Now()
+}
+
+// current returns the key new tokens are signed with
+func (k *signingKeys) current() signingKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if len(k.rotated) > 0 {
+		return k.rotated[0]
+	}
+	return k.configured
+}
+
+// configuredID returns the key ID of the configured secret
+func (k *signingKeys) configuredID() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.configured.id
+}
+
+// sign signs the claims with the current key
+func (k *signingKeys) sign(claims jwt.Claims) (string, error) {
+	key := k.current()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.secret)
+}
+
+// verificationKey returns the secret a token was signed with, by its key ID
+// Tokens issued before key IDs were added have none and were signed with the configured secret
+func (k *signingKeys) verificationKey(token *jwt.Token) (interface{}, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range k.rotated {
+		if kid == key.id {
+			return key.secret, nil
+		}
+	}
+	if k.acceptConfigured || len(k.rotated) == 0 {
+		switch {
+		case kid == "" || kid == k.configured.id:
+			return k.configured.secret, nil
+		case k.configuredPrevious != nil && kid == k.configuredPrevious.id:
+			return k.configuredPrevious.secret, nil
+		}
+	}
+	return nil, errUnknownSigningKey
+}
+
+// reloadDue reports whether an unknown key ID may trigger a reload, at most once per cooldown
+func (k *signingKeys) reloadDue() bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if time.Since(k.reloadedAt) < signingKeyReloadCooldown {
+		return false
+	}
+	k.reloadedAt = time.Now()
+	return true
+}
+
+// SigningKeyStatus describes a JWT signing key; secrets are never returned
+type SigningKeyStatus struct {
+	KeyID     string     `json:"key_id"`
+	Source    string     `json:"source"`
+	Status    string     `json:"status"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+}
+
+// SetSigningSecret replaces the configured JWT secret; tokens signed with the replaced secret remain valid
+func (s *AuthService) SetSigningSecret(secret string) {
+	if secret == "" {
+		return
+	}
+	if s.keys.setConfigured(secret) {
+		logger.Info("JWT signing secret changed in the secret store")
+	}
+}
+
+// WatchSigningSecret re-reads the JWT secret from the secret store at each interval until the context is done,
+// replacing the configured secret when it changed. Every instance runs its own watcher, unlike scheduler jobs:
+// until an instance picks up a new secret, it rejects the tokens other instances already signed with it
+func (s *AuthService) WatchSigningSecret(ctx context.Context, interval time.Duration, fetch func(ctx context.Context) (string, error)) {
+	watch(ctx, interval, func(ctx context.Context) {
+		secret, err := fetch(ctx)
+		if err != nil {
+			logger.Warn("Failed to refresh JWT secret", zap.Error(err))
+			return
+		}
+		s.SetSigningSecret(secret)
+	})
+}
+
+// LoadSigningKeys loads the rotated signing keys from the database
+func (s *AuthService) LoadSigningKeys(ctx context.Context) error {
+	rows, err := s.signingKeyRepo.ListWithContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load JWT signing keys: %w", err)
+	}
+	accepted, _, acceptConfigured := acceptedSigningKeys(rows, time.Now().Add(-s.tokenTTL()))
+
+	rotated := make([]signingKey, 0, len(accepted))
+	for _, row := range accepted {
+		rotated = append(rotated, signingKey{id: row.KeyID, secret: []byte(row.Secret)})
+	}
+	s.keys.setRotated(rotated, acceptConfigured)
+	return nil
+}
+
+// WatchSigningKeys reloads the rotated signing keys at each interval until the context is done, so every
+// instance picks up rotations made on another one. Tokens signed with a key not loaded yet also trigger a reload
+func (s *AuthService) WatchSigningKeys(ctx context.Context, interval time.Duration) {
+	watch(ctx, interval, func(ctx context.Context) {
+		if err := s.LoadSigningKeys(ctx); err != nil {
+			logger.Warn("Failed to reload JWT signing keys", zap.Error(err))
+		}
+	})
+}
+
+// RotateSigningKey creates a new random signing key for new tokens. Replaced keys keep verifying the tokens they
+// signed until those expire, so no session ends early; keys past that are retired and their secret erased
+func (s *AuthService) RotateSigningKey(ctx context.Context, actor AuditActor) (*SigningKeyStatus, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	keyID := make([]byte, 8)
+	if _, err := rand.Read(keyID); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key ID: %w", err)
+	}
+	key := &models.JWTSigningKey{
+		KeyID:     hex.EncodeToString(keyID),
+		Secret:    base64.RawURLEncoding.EncodeToString(secret),
+		CreatedAt: time.Now(),
+	}
+
+	rows, err := s.signingKeyRepo.ListWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWT signing keys: %w", err)
+	}
+	_, retire, _ := acceptedSigningKeys(append([]models.JWTSigningKey{*key}, rows...), time.Now().Add(-s.tokenTTL()))
+
+	details, err := json.Marshal(map[string]interface{}{
+		"key_id":  key.KeyID,
+		"retired": len(retire),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit details: %w", err)
+	}
+	if err := s.signingKeyRepo.RotateWithContext(ctx, key, retire, &models.AuditLog{
+		ActorUserID: actor.UserID,
+		ActorRole:   actor.Role,
+		Action:      models.AuditActionSigningKeyRotate,
+		Details:     string(details),
+		IPAddress:   actor.IPAddress,
+		UserAgent:   actor.UserAgent,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store JWT signing key: %w", err)
+	}
+
+	if err := s.LoadSigningKeys(ctx); err != nil {
+		return nil, err
+	}
+	logger.Info("JWT signing key rotated", zap.String("key_id", key.KeyID), zap.Int("retired", len(retire)))
+
+	return &SigningKeyStatus{
+		KeyID:     key.KeyID,
+		Source:    SigningKeySourceRotated,
+		Status:    SigningKeyStatusSigning,
+		CreatedAt: &key.CreatedAt,
+	}, nil
+}
+
+// ListSigningKeys lists the configured secret and the rotated keys with their status, newest first
+func (s *AuthService) ListSigningKeys(ctx context.Context) ([]SigningKeyStatus, error) {
+	rows, err := s.signingKeyRepo.ListWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load JWT signing keys: %w", err)
+	}
+	accepted, _, acceptConfigured := acceptedSigningKeys(rows, time.Now().Add(-s.tokenTTL()))
+	isAccepted := make(map[uint]bool, len(accepted))
+	for _, row := range accepted {
+		isAccepted[row.ID] = true
+	}
+
+	statuses := make([]SigningKeyStatus, 0, len(rows)+1)
+	for i := range rows {
+		status := SigningKeyStatus{
+			KeyID:     rows[i].KeyID,
+			Source:    SigningKeySourceRotated,
+			Status:    SigningKeyStatusRetired,
+			CreatedAt: &rows[i].CreatedAt,
+			RetiredAt: rows[i].RetiredAt,
+		}
+		switch {
+		case i == 0:
+			status.Status = SigningKeyStatusSigning
+		case isAccepted[rows[i].ID]:
+			status.Status = SigningKeyStatusVerifying
+		}
+		statuses = append(statuses, status)
+	}
+
+	configured := SigningKeyStatus{
+		KeyID:  s.keys.configuredID(),
+		Source: SigningKeySourceConfigured,
+		Status: SigningKeyStatusRetired,
+	}
+	switch {
+	case len(rows) == 0:
+		configured.Status = SigningKeyStatusSigning
+	case acceptConfigured:
+		configured.Status = SigningKeyStatusVerifying
+	}
+	return append(statuses, configured), nil
+}
+
+// reloadForUnknownKey reloads the rotated keys when a token names a key this instance doesn't know; it may have
+// been rotated on another instance since the last reload. Reports whether the keys were reloaded
+func (s *AuthService) reloadForUnknownKey(err error) bool {
+	if !errors.Is(err, errUnknownSigningKey) || !s.keys.reloadDue() {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.LoadSigningKeys(ctx); err != nil {
+		logger.Warn("Failed to reload JWT signing keys", zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// tokenTTL is the longest lifetime of an issued token: how long a replaced key must keep verifying
+func (s *AuthService) tokenTTL() time.Duration {
+	ttl := time.Duration(s.config.JWTExpiration) * time.Hour
+	if s.config.ImpersonationTokenTTL > ttl {
+		ttl = s.config.ImpersonationTokenTTL
+	}
+	return ttl
+}
+
+// acceptedSigningKeys splits the rotated keys (newest first) into those still accepted and the active ones to
+// retire. A key is accepted while the key that replaced it is younger than the token lifetime (cutoff), the newest
+// always; the configured secret likewise until the first rotated key is older than the token lifetime
+func acceptedSigningKeys(rows []models.JWTSigningKey, cutoff time.Time) (accepted []models.JWTSigningKey, retire []uint, acceptConfigured bool) {
+	for i, row := range rows {
+		if row.RetiredAt != nil {
+			continue
+		}
+		if i == 0 || rows[i-1].CreatedAt.After(cutoff) {
+			accepted = append(accepted, row)
+		} else if row.ID != 0 {
+			retire = append(retire, row.ID)
+		}
+	}
+	acceptConfigured = len(rows) == 0 || rows[len(rows)-1].CreatedAt.After(cutoff)
+	return accepted, retire, acceptConfigured
+}
+
+// watch runs fn at each interval until the context is done; a non-positive interval disables it
+func watch(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			fn(ctx)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// kamInactiveAfter is how long an active restaurant can go without orders before it is flagged as inactive
+const kamInactiveAfter = 14 * 24 * time.Hour
+
+// KAMService provides the portfolio view of the restaurants assigned to a KAM
+type KAMService struct {
+	restaurantRepo *repositories.RestaurantRepository
+}
+
+// NewKAMService creates a new KAMService instance
+func NewKAMService(restaurantRepo *repositories.RestaurantRepository) *KAMService {
+	return &KAMService{
+		restaurantRepo: restaurantRepo,
+	}
+}
+
+// KAMDashboard summarizes a KAM's restaurants; order figures are for the current month (UTC)
+type KAMDashboard struct {
+	MonthStart           string                            `json:"month_start"`
+	Funnel               KAMFunnel                         `json:"funnel"`
+	StatusCounts         map[models.RestaurantStatus]int   `json:"status_counts"`
+	OrderCount           int64                             `json:"order_count"`
+	Revenue              float64                           `json:"revenue"`
+	Restaurants          []repositories.RestaurantActivity `json:"restaurants"`           // By revenue this month
+	InactiveRestaurants  []repositories.RestaurantActivity `json:"inactive_restaurants"`  // Active, but no orders for 14 days
+	PendingRegistrations []PendingRegistration             `json:"pending_registrations"` // Oldest first
+	UnassignedPending    int64                             `json:"unassigned_pending"`    // Pending registrations without a KAM
+}
+
+// KAMFunnel counts the assigned restaurants that reached each activation stage
+type KAMFunnel struct {
+	Registered  int `json:"registered"`
+	Activated   int `json:"activated"`
+	Launched    int `json:"launched"`    // Went public
+	Transacting int `json:"transacting"` // Received an order this month
+}
+
+// PendingRegistration is a registration waiting for the KAM to activate it
+type PendingRegistration struct {
+	RestaurantID uint      `json:"restaurant_id"`
+	Name         string    `json:"name"`
+	ContactName  string    `json:"contact_name"`
+	ContactEmail string    `json:"contact_email"`
+	ContactPhone string    `json:"contact_phone"`
+	RegisteredAt time.Time `json:"registered_at"`
+	WaitingDays  int       `json:"waiting_days"`
+}
+
+// GetDashboard builds the portfolio dashboard of the restaurants assigned to the KAM
+func (s *KAMService) GetDashboard(ctx context.Context, kamID uint) (*KAMDashboard, error) {
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var activity []repositories.RestaurantActivity
+	var pending []models.Restaurant
+	var unassigned int64
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		if activity, err = s.restaurantRepo.ListActivityByKAMWithContext(gctx, kamID, monthStart); err != nil {
+			return fmt.Errorf("failed to get restaurant activity: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		status := models.RestaurantStatusPending
+		var err error
+		if pending, err = s.restaurantRepo.ListWithContext(gctx, &status, &kamID); err != nil {
+			return fmt.Errorf("failed to list pending registrations: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if unassigned, err = s.restaurantRepo.CountPendingUnassignedWithContext(gctx); err != nil {
+			return fmt.Errorf("failed to count unassigned registrations: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	if activity == nil {
+		activity = []repositories.RestaurantActivity{}
+	}
+	dashboard := &KAMDashboard{
+		MonthStart:           monthStart.Format(time.DateOnly),
+		StatusCounts:         make(map[models.RestaurantStatus]int),
+		Restaurants:          activity,
+		InactiveRestaurants:  []repositories.RestaurantActivity{},
+		PendingRegistrations: make([]PendingRegistration, 0, len(pending)),
+		UnassignedPending:    unassigned,
+	}
+	inactiveSince := now.Add(-kamInactiveAfter)
+	for _, restaurant := range activity {
+		dashboard.StatusCounts[restaurant.Status]++
+		dashboard.OrderCount += restaurant.OrderCount
+		dashboard.Revenue += restaurant.Revenue
+
+		dashboard.Funnel.Registered++
+		if restaurant.ActivatedAt != nil {
+			dashboard.Funnel.Activated++
+		}
+		if restaurant.LaunchedAt != nil {
+			dashboard.Funnel.Launched++
+		}
+		if restaurant.OrderCount > 0 {
+			dashboard.Funnel.Transacting++
+		}
+
+		if restaurant.Status == models.RestaurantStatusActive &&
+			(restaurant.LastOrderAt == nil || restaurant.LastOrderAt.Before(inactiveSince)) {
+			dashboard.InactiveRestaurants = append(dashboard.InactiveRestaurants, restaurant)
+		}
+	}
+	dashboard.Revenue = money.Round(dashboard.Revenue)
+
+	// Oldest first: those have waited longest (the list comes newest first)
+	for i := len(pending) - 1; i >= 0; i-- {
+		restaurant := pending[i]
+		dashboard.PendingRegistrations = append(dashboard.PendingRegistrations, PendingRegistration{
+			RestaurantID: restaurant.ID,
+			Name:         restaurant.Name,
+			ContactName:  restaurant.ContactName,
+			ContactEmail: restaurant.ContactEmail,
+			ContactPhone: restaurant.ContactPhone,
+			RegisteredAt: restaurant.CreatedAt,
+			WaitingDays:  int(now.Sub(restaurant.CreatedAt).Hours() / 24),
+		})
+	}
+	return dashboard, nil
+}
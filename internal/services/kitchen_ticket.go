@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+)
+
+// kitchenStatuses are the order statuses shown on the kitchen display (KDS)
+var kitchenStatuses = []string{"confirmed", "preparing"}
+
+// Kitchen ticket course statuses: held courses wait for the server to fire them
+const (
+	KitchenCourseHeld  = "held"
+	KitchenCourseFired = "fired"
+)
+
+// KitchenTicketItem is a line on a kitchen ticket
+type KitchenTicketItem struct {
+	MenuItemID uint   `json:"menu_item_id"`
+	Name       string `json:"name"`
+	Quantity   int    `json:"quantity"`
+	Notes      string `json:"notes,omitempty"`
+	Course     int    `json:"course"`
+	Status     string `json:"status"` // held or fired
+}
+
+// KitchenTicketCourse is the status of one course of a ticket
+type KitchenTicketCourse struct {
+	Course  int        `json:"course"`
+	Status  string     `json:"status"` // held or fired
+	FiredAt *time.Time `json:"fired_at,omitempty"`
+}
+
+// KitchenTicket is the kitchen-facing view of an order (no prices or customer details)
+type KitchenTicket struct {
+	OrderID   uint                `json:"order_id"`
+	Status    string              `json:"status"`
+	Notes     string              `json:"notes,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+	Items     []KitchenTicketItem `json:"items"`
+
+	// Courses lists the ticket's courses in serving order; NextCourse is the first one still held
+	Courses    []KitchenTicketCourse `json:"courses"`
+	NextCourse *int                  `json:"next_course,omitempty"`
+
+	// ScheduledFor is the pickup/delivery time of an order placed ahead
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"`
+}
+
+// NewKitchenTicket builds a kitchen ticket from an order
+// Order items must have their MenuItem relationship loaded
+func NewKitchenTicket(order *models.Order) *KitchenTicket {
+	ticket := &KitchenTicket{
+		OrderID:   order.ID,
+		Status:    order.Status,
+		Notes:     order.Notes,
+		CreatedAt: order.CreatedAt,
+		Items:     make([]KitchenTicketItem, 0, len(order.OrderItems)),
+
+		ScheduledFor: order.ScheduledFor,
+	}
+
+	courses := make(map[int]*KitchenTicketCourse)
+	for _, item := range order.OrderItems {
+		status := KitchenCourseFired
+		if item.IsHeld() {
+			status = KitchenCourseHeld
+		}
+		ticket.Items = append(ticket.Items, KitchenTicketItem{
+			MenuItemID: item.MenuItemID,
+			Name:       item.DisplayName(),
+			Quantity:   item.Quantity,
+			Notes:      item.Notes,
+			Course:     item.CourseNumber(),
+			Status:     status,
+		})
+
+		course, ok := courses[item.CourseNumber()]
+		if !ok {
+			course = &KitchenTicketCourse{Course: item.CourseNumber(), Status: KitchenCourseFired}
+			courses[item.CourseNumber()] = course
+		}
+		if status == KitchenCourseHeld {
+			course.Status = KitchenCourseHeld
+		}
+		if item.FiredAt != nil && (course.FiredAt == nil || item.FiredAt.After(*course.FiredAt)) {
+			course.FiredAt = item.FiredAt
+		}
+	}
+	sort.SliceStable(ticket.Items, func(i, j int) bool {
+		return ticket.Items[i].Course < ticket.Items[j].Course
+	})
+
+	ticket.Courses = make([]KitchenTicketCourse, 0, len(courses))
+	for _, course := range courses {
+		ticket.Courses = append(ticket.Courses, *course)
+	}
+	sort.Slice(ticket.Courses, func(i, j int) bool {
+		return ticket.Courses[i].Course < ticket.Courses[j].Course
+	})
+	for _, course := range ticket.Courses {
+		if course.Status == KitchenCourseHeld {
+			next := course.Course
+			ticket.NextCourse = &next
+			break
+		}
+	}
+
+	return ticket
+}
+
+// coursed reports whether the ticket is served in more than one course
+func (t *KitchenTicket) coursed() bool {
+	return len(t.Courses) > 1
+}
+
+// courseHeader returns the heading printed above the items of a course, marking held courses
+func (t *KitchenTicket) courseHeader(course int) string {
+	for _, c := range t.Courses {
+		if c.Course == course && c.Status == KitchenCourseHeld {
+			return fmt.Sprintf("-- COURSE %d (HOLD) --", course)
+		}
+	}
+	return fmt.Sprintf("-- COURSE %d --", course)
+}
+
+// Render formats the ticket as plain text for printing, with item notes indented under each line
+// and the items of multi-course orders grouped under their course
+func (t *KitchenTicket) Render() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "ORDER #%d\n", t.OrderID)
+	fmt.Fprintf(&b, "%s\n", t.CreatedAt.Format("2006-01-02 15:04"))
+	if t.ScheduledFor != nil {
+		fmt.Fprintf(&b, "PICKUP %s\n", t.ScheduledFor.Format("2006-01-02 15:04"))
+	}
+	b.WriteString("------------------------------\n")
+	course := 0
+	for _, item := range t.Items {
+		if t.coursed() && item.Course != course {
+			course = item.Course
+			fmt.Fprintf(&b, "%s\n", t.courseHeader(course))
+		}
+		fmt.Fprintf(&b, "%2dx %s\n", item.Quantity, item.Name)
+		if item.Notes != "" {
+			fmt.Fprintf(&b, "    >> %s\n", item.Notes)
+		}
+	}
+	if t.Notes != "" {
+		b.WriteString("------------------------------\n")
+		fmt.Fprintf(&b, "NOTE: %s\n", t.Notes)
+	}
+
+	return b.String()
+}
+
+// GetKitchenTickets returns tickets for all orders currently in the kitchen queue
+// Scheduled orders join the queue once they are due within the fire lead time
+func (s *OrderService) GetKitchenTickets(ctx context.Context, restaurantID uint) ([]*KitchenTicket, error) {
+	orders, err := s.orderRepo.GetKitchenOrdersWithContext(ctx, restaurantID, kitchenStatuses, time.Now().Add(scheduledOrderFireLead))
+	if err != nil {
+		return nil, err
+	}
+
+	tickets := make([]*KitchenTicket, 0, len(orders))
+	for i := range orders {
+		tickets = append(tickets, NewKitchenTicket(&orders[i]))
+	}
+
+	return tickets, nil
+}
+
+// GetKitchenTicket returns the kitchen ticket for a single order
+func (s *OrderService) GetKitchenTicket(ctx context.Context, orderID uint, restaurantID uint) (*KitchenTicket, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+
+	return NewKitchenTicket(order), nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/logger"
+
+	"github.com/google/uuid"
+)
+
+// LocalFilesPath is the public route prefix that serves locally stored files
+const LocalFilesPath = "/api/v1/files"
+
+// LocalStorage stores files on local disk for development without AWS credentials
+// Presigned URLs are emulated with HMAC-signed, expiring links served by this API
+type LocalStorage struct {
+	basePath   string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewLocalStorage creates a new LocalStorage instance
+func NewLocalStorage(cfg *config.Config) (*LocalStorage, error) {
+	basePath, err := filepath.Abs(cfg.LocalStoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local storage path: %w", err)
+	}
+
+	if err := os.MkdirAll(basePath, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory: %w", err)
+	}
+
+	// Without a configured secret (development) links are signed with a random key and stop working on restart
+	signingKey := []byte(cfg.StorageSigningSecret)
+	if len(signingKey) == 0 {
+		signingKey = make([]byte, 32)
+		if _, err := rand.Read(signingKey); err != nil {
+			return nil, fmt.Errorf("failed to generate storage signing key: %w", err)
+		}
+		logger.Warn("STORAGE_SIGNING_SECRET is not set; signed file URLs will not survive a restart")
+	}
+
+	return &LocalStorage{
+		basePath:   basePath,
+		baseURL:    strings.TrimRight(cfg.LocalStorageBaseURL, "/"),
+		signingKey: signingKey,
+	}, nil
+}
+
+// UploadFile writes a file to disk with tenant-specific prefix
+func (s *LocalStorage) UploadFile(ctx context.Context, restaurantID uint, fileName string, fileType string, fileReader io.Reader) (string, error) {
+	// Same key layout as S3 so stored keys are portable between backends
+	fileExtension := getFileExtension(fileName)
+	uniqueID := uuid.New().String()
+	key := fmt.Sprintf("restaurant-%d/menu-items/%s%s", restaurantID, uniqueID, fileExtension)
+
+	path, err := s.resolve(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, fileReader); err != nil {
+		os.Remove(path)
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return key, nil
+}
+
+// GeneratePresignedURL generates a signed, expiring URL served by the files route
+func (s *LocalStorage) GeneratePresignedURL(ctx context.Context, key string, expiration time.Duration) (string, error) {
+	if _, err := s.resolve(key); err != nil {
+		return "", err
+	}
+
+	expires := time.Now().Add(expiration).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(key, expires))
+
+	return fmt.Sprintf("%s%s/%s?%s", s.baseURL, LocalFilesPath, key, query.Encode()), nil
+}
+
+// DeleteFile deletes a file from disk
+func (s *LocalStorage) DeleteFile(ctx context.Context, key string) error {
+	path, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+// Ping checks that the storage directory is still present
+func (s *LocalStorage) Ping(ctx context.Context) error {
+	info, err := os.Stat(s.basePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat local storage path: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("local storage path is not a directory: %s", s.basePath)
+	}
+
+	return nil
+}
+
+// OpenSignedFile verifies a signed URL and returns the file path on disk
+func (s *LocalStorage) OpenSignedFile(key string, expires string, signature string) (string, error) {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return "", apperrors.Forbidden(apperrors.CodeForbidden, "invalid signature")
+	}
+
+	expected := s.sign(key, expiresAt)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return "", apperrors.Forbidden(apperrors.CodeForbidden, "invalid signature")
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return "", apperrors.Forbidden(apperrors.CodeForbidden, "url expired")
+	}
+
+	path, err := s.resolve(key)
+	if err != nil {
+		return "", apperrors.Wrap(err, http.StatusForbidden, apperrors.CodeForbidden, err.Error())
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return "", apperrors.NotFound(apperrors.CodeFileNotFound, "file not found")
+	}
+
+	return path, nil
+}
+
+// sign computes the hex-encoded HMAC-SHA256 signature of a key and expiry
+func (s *LocalStorage) sign(key string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(fmt.Sprintf("%s:%d", key, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// resolve maps a key to a path under the base directory, rejecting traversal
+func (s *LocalStorage) resolve(key string) (string, error) {
+	key = strings.TrimPrefix(key, "/")
+	path := filepath.Join(s.basePath, filepath.FromSlash(key))
+	if key == "" || !strings.HasPrefix(path, s.basePath+string(os.PathSeparator)) {
+		return "", errors.New("invalid key")
+	}
+	return path, nil
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+
+	"go.uber.org/zap"
+)
+
+// Mailer sends the transactional emails triggered by services
+// EmailService delivers them through Brevo; LogMailer only logs them
+type Mailer interface {
+	SendRestaurantWelcomeEmail(ctx context.Context, restaurant *models.Restaurant, adminEmail string, tempPassword string) error
+	SendRestaurantLaunchEmail(ctx context.Context, restaurant *models.Restaurant) error
+	SendUserInvitationEmail(
+		ctx context.Context,
+		restaurantID uint,
+		userEmail string,
+		userFirstName string,
+		restaurantName string,
+		inviterName string,
+		userRole string,
+		acceptURL string,
+		expiresAt time.Time,
+	) error
+	SendEmailVerificationEmail(
+		ctx context.Context,
+		restaurantID uint,
+		userEmail string,
+		userFirstName string,
+		verificationToken string,
+		expiresAt time.Time,
+	) error
+	SendOrderConfirmationEmail(
+		ctx context.Context,
+		restaurantID uint,
+		customerEmail string,
+		customerName string,
+		restaurantName string,
+		orderID uint,
+		items []OrderItem,
+		subtotal money.Cents,
+		tax money.Cents,
+		deliveryFee money.Cents,
+		total money.Cents,
+		estimatedMinutes int,
+		specialNotes string,
+		restaurantPhone string,
+		restaurantAddress string,
+		nutrition *NutritionSummary,
+		receiptPDF []byte,
+	) error
+	SendCartRecoveryEmail(
+		ctx context.Context,
+		restaurantID uint,
+		email string,
+		name string,
+		restaurantName string,
+		items []OrderItem,
+		subtotal money.Cents,
+		resumeURL string,
+		unsubscribeURL string,
+		expiresAt time.Time,
+	) error
+	SendReservationStatusUpdateEmail(
+		ctx context.Context,
+		restaurantID uint,
+		customerEmail string,
+		customerName string,
+		restaurantName string,
+		reservationID uint,
+		status string,
+		statusMessage string,
+		reservationDate string,
+		reservationTime string,
+		cancellationReason string,
+	) error
+}
+
+var (
+	_ Mailer = (*EmailService)(nil)
+	_ Mailer = LogMailer{}
+)
+
+// LogMailer logs emails instead of sending them, for local development without a Brevo account
+type LogMailer struct{}
+
+// SendRestaurantWelcomeEmail logs the welcome email (the temporary password is not logged)
+func (LogMailer) SendRestaurantWelcomeEmail(ctx context.Context, restaurant *models.Restaurant, adminEmail string, tempPassword string) error {
+	logger.Info("Email not sent (log mailer): restaurant welcome",
+		zap.Uint("restaurant_id", restaurant.ID),
+		zap.String("to", adminEmail),
+	)
+	return nil
+}
+
+// SendRestaurantLaunchEmail logs the launch email
+func (LogMailer) SendRestaurantLaunchEmail(ctx context.Context, restaurant *models.Restaurant) error {
+	logger.Info("Email not sent (log mailer): restaurant launch",
+		zap.Uint("restaurant_id", restaurant.ID),
+		zap.String("to", restaurant.ContactEmail),
+	)
+	return nil
+}
+
+// SendUserInvitationEmail logs the invitation email (the accept link is not logged)
+func (LogMailer) SendUserInvitationEmail(
+	ctx context.Context,
+	restaurantID uint,
+	userEmail string,
+	userFirstName string,
+	restaurantName string,
+	inviterName string,
+	userRole string,
+	acceptURL string,
+	expiresAt time.Time,
+) error {
+	logger.Info("Email not sent (log mailer): user invitation",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.String("to", userEmail),
+		zap.String("role", userRole),
+	)
+	return nil
+}
+
+// SendEmailVerificationEmail logs the email verification email (the token is not logged)
+func (LogMailer) SendEmailVerificationEmail(
+	ctx context.Context,
+	restaurantID uint,
+	userEmail string,
+	userFirstName string,
+	verificationToken string,
+	expiresAt time.Time,
+) error {
+	logger.Info("Email not sent (log mailer): email verification",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.String("to", userEmail),
+	)
+	return nil
+}
+
+// SendOrderConfirmationEmail logs the order confirmation email
+func (LogMailer) SendOrderConfirmationEmail(
+	ctx context.Context,
+	restaurantID uint,
+	customerEmail string,
+	customerName string,
+	restaurantName string,
+	orderID uint,
+	items []OrderItem,
+	subtotal money.Cents,
+	tax money.Cents,
+	deliveryFee money.Cents,
+	total money.Cents,
+	estimatedMinutes int,
+	specialNotes string,
+	restaurantPhone string,
+	restaurantAddress string,
+	nutrition *NutritionSummary,
+	receiptPDF []byte,
+) error {
+	logger.Info("Email not sent (log mailer): order confirmation",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.Uint("order_id", orderID),
+		zap.String("to", customerEmail),
+		zap.Stringer("total", total),
+	)
+	return nil
+}
+
+// SendReservationStatusUpdateEmail logs the reservation status email
+func (LogMailer) SendReservationStatusUpdateEmail(
+	ctx context.Context,
+	restaurantID uint,
+	customerEmail string,
+	customerName string,
+	restaurantName string,
+	reservationID uint,
+	status string,
+	statusMessage string,
+	reservationDate string,
+	reservationTime string,
+	cancellationReason string,
+) error {
+	logger.Info("Email not sent (log mailer): reservation status update",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.Uint("reservation_id", reservationID),
+		zap.String("to", customerEmail),
+		zap.String("status", status),
+	)
+	return nil
+}
+
+// SendCartRecoveryEmail logs the cart recovery email (the resume link is not logged)
+func (LogMailer) SendCartRecoveryEmail(
+	ctx context.Context,
+	restaurantID uint,
+	email string,
+	name string,
+	restaurantName string,
+	items []OrderItem,
+	subtotal money.Cents,
+	resumeURL string,
+	unsubscribeURL string,
+	expiresAt time.Time,
+) error {
+	logger.Info("Email not sent (log mailer): cart recovery",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.String("to", email),
+		zap.Int("items", len(items)),
+	)
+	return nil
+}
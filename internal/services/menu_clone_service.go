@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+// MenuCloneService copies menus between restaurants (chain onboarding)
+type MenuCloneService struct {
+	restaurantRepo *repositories.RestaurantRepository
+	categoryRepo   *repositories.CategoryRepository
+	subscription   *SubscriptionService
+}
+
+// NewMenuCloneService creates a new MenuCloneService instance
+func NewMenuCloneService(
+	restaurantRepo *repositories.RestaurantRepository,
+	categoryRepo *repositories.CategoryRepository,
+	subscription *SubscriptionService,
+) *MenuCloneService {
+	return &MenuCloneService{
+		restaurantRepo: restaurantRepo,
+		categoryRepo:   categoryRepo,
+		subscription:   subscription,
+	}
+}
+
+// CloneMenuRequest represents a menu clone request
+type CloneMenuRequest struct {
+	SourceRestaurantID uint  `json:"source_restaurant_id" binding:"required"`
+	TargetRestaurantID uint  `json:"target_restaurant_id" binding:"required"`
+	IncludePrices      *bool `json:"include_prices"`     // Defaults to true; false clones items with a zero price
+	ResetAvailability  bool  `json:"reset_availability"` // Mark every cloned category/item as active/available
+}
+
+// MenuCloneResult summarizes a menu clone
+type MenuCloneResult struct {
+	SourceRestaurantID uint `json:"source_restaurant_id"`
+	TargetRestaurantID uint `json:"target_restaurant_id"`
+	repositories.MenuCloneCounts
+}
+
+// CloneMenu copies categories, items and item images from one restaurant to another
+// KAMs may clone between any restaurants; org Admins only between locations of their organization
+// The target restaurant must not have a menu yet, and its plan must allow the cloned items
+func (s *MenuCloneService) CloneMenu(ctx context.Context, req *CloneMenuRequest, role string, organizationID uint) (*MenuCloneResult, error) {
+	if req.SourceRestaurantID == req.TargetRestaurantID {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "source and target restaurants must differ")
+	}
+
+	source, err := s.restaurantRepo.GetByIDWithContext(ctx, req.SourceRestaurantID)
+	if err != nil || models.IsPlatformOrganization(source.ID) {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "source restaurant not found")
+	}
+
+	target, err := s.restaurantRepo.GetByIDWithContext(ctx, req.TargetRestaurantID)
+	if err != nil || models.IsPlatformOrganization(target.ID) {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "target restaurant not found")
+	}
+
+	if !canCloneMenu(role, organizationID, source, target) {
+		return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "insufficient permissions to clone between these restaurants")
+	}
+
+	count, err := s.categoryRepo.CountByRestaurantIDWithContext(ctx, target.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, apperrors.Conflict(apperrors.CodeMenuNotEmpty, "target restaurant already has a menu")
+	}
+
+	opts := repositories.MenuCloneOptions{
+		IncludePrices:     req.IncludePrices == nil || *req.IncludePrices,
+		ResetAvailability: req.ResetAvailability,
+		CheckMenuItems: func(count int) error {
+			return s.subscription.CheckCapacity(ctx, target.ID, PlanResourceMenuItems, int64(count))
+		},
+	}
+
+	counts, err := s.categoryRepo.CloneMenuWithContext(ctx, source.ID, target.ID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone menu: %w", err)
+	}
+
+	return &MenuCloneResult{
+		SourceRestaurantID: source.ID,
+		TargetRestaurantID: target.ID,
+		MenuCloneCounts:    *counts,
+	}, nil
+}
+
+// canCloneMenu checks whether the caller may clone from source to target
+func canCloneMenu(role string, organizationID uint, source, target *models.Restaurant) bool {
+	if role == "KAM" {
+		return true
+	}
+	if role != "Admin" || organizationID == 0 {
+		return false
+	}
+	return source.OrganizationID != nil && *source.OrganizationID == organizationID &&
+		target.OrganizationID != nil && *target.OrganizationID == organizationID
+}
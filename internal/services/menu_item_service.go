@@ -2,42 +2,69 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
 	"strings"
+	"time"
 
+	"restaurant-backend/internal/apperrors"
+	tenantctx "restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/dto"
 	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
 	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
 )
 
 // MenuItemService handles menu item business logic
 type MenuItemService struct {
-	menuItemRepo *repositories.MenuItemRepository
+	menuItemRepo   *repositories.MenuItemRepository
+	webhookService *WebhookService
+	notifications  *NotificationService
+	settings       *RestaurantSettingsService
 }
 
 // NewMenuItemService creates a new MenuItemService instance
-func NewMenuItemService(menuItemRepo *repositories.MenuItemRepository) *MenuItemService {
+func NewMenuItemService(
+	menuItemRepo *repositories.MenuItemRepository,
+	webhookService *WebhookService,
+	notifications *NotificationService,
+	settings *RestaurantSettingsService,
+) *MenuItemService {
 	return &MenuItemService{
-		menuItemRepo: menuItemRepo,
+		menuItemRepo:   menuItemRepo,
+		webhookService: webhookService,
+		notifications:  notifications,
+		settings:       settings,
 	}
 }
 
+// MenuItemAvailabilityResult summarizes a bulk menu item availability change
+type MenuItemAvailabilityResult struct {
+	MenuItemIDs  []uint `json:"menu_item_ids"`
+	IsAvailable  bool   `json:"is_available"`
+	UpdatedItems int64  `json:"updated_items"`
+}
+
 // CreateMenuItem creates a new menu item
 func (s *MenuItemService) CreateMenuItem(ctx context.Context, req *dto.CreateMenuItemRequest, restaurantID uint) (*models.MenuItem, error) {
 	// Validate required fields
 	if req.Name == "" {
-		return nil, errors.New("name is required")
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "name is required")
 	}
 	if req.CategoryID == 0 {
-		return nil, errors.New("category_id is required")
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "category_id is required")
 	}
 	if req.Price < 0 {
-		return nil, errors.New("price cannot be negative")
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "price cannot be negative")
 	}
 
 	// Check if name is already taken
 	if _, err := s.menuItemRepo.GetByNameWithContext(ctx, req.Name); err == nil {
-		return nil, errors.New("name already taken")
+		return nil, apperrors.Conflict(apperrors.CodeNameTaken, "name already taken")
 	}
 
 	menuItem := &models.MenuItem{
@@ -49,40 +76,155 @@ func (s *MenuItemService) CreateMenuItem(ctx context.Context, req *dto.CreateMen
 		ImageURL:     req.ImageURL,
 		DisplayOrder: req.DisplayOrder,
 		IsAvailable:  req.IsAvailable,
+		Calories:     req.Calories,
+		ProteinGrams: req.ProteinGrams,
+		CarbsGrams:   req.CarbsGrams,
+		FatGrams:     req.FatGrams,
+		Tags:         normalizeTags(req.Tags),
+	}
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MenuDraftsEnabled {
+		menuItem.PublishStatus = models.MenuPublishStatusDraft
+	}
+	if req.ChannelPrices != nil {
+		menuItem.DineInPrice = req.ChannelPrices.DineIn
+		menuItem.PickupPrice = req.ChannelPrices.Pickup
+		menuItem.DeliveryPrice = req.ChannelPrices.Delivery
+		menuItem.ThirdPartyPrice = req.ChannelPrices.ThirdParty
 	}
 
 	if err := s.menuItemRepo.CreateWithContext(ctx, menuItem); err != nil {
 		return nil, err
 	}
 
+	// A draft only reaches the menu with the next publish
+	if menuItem.IsPublished() {
+		s.webhookService.NotifyMenuChange(ctx, restaurantID, MenuChange{
+			Entity:   MenuEntityMenuItem,
+			EntityID: menuItem.ID,
+			Action:   MenuChangeCreated,
+		})
+	}
+
 	// Fetch created item with relationships
-	return s.menuItemRepo.GetByIDWithContext(ctx, menuItem.ID)
+	return s.menuItemRepo.GetByIDForRestaurant(ctx, menuItem.ID, restaurantID)
 }
 
 // UpdateMenuItem updates a menu item (only updates provided fields)
-func (s *MenuItemService) UpdateMenuItem(ctx context.Context, id uint, req *dto.UpdateMenuItemRequest, restaurantID uint) (*models.MenuItem, error) {
-	// Verify menu item exists
-	menuItem, err := s.menuItemRepo.GetByIDWithContext(ctx, id)
+// expectedVersion is the client's If-Match version; 0 skips the precondition
+func (s *MenuItemService) UpdateMenuItem(ctx context.Context, id uint, req *dto.UpdateMenuItemRequest, restaurantID uint, expectedVersion int) (*models.MenuItem, error) {
+	// Verify menu item exists and belongs to the requesting restaurant
+	// Other tenants' items are reported as not found so their existence is not revealed
+	menuItem, err := s.menuItemRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+	}
+
+	if err := checkVersion(expectedVersion, menuItem.Version); err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.MenuDraftsEnabled && menuItem.IsPublished() {
+		return s.updateDraft(ctx, menuItem, req)
+	}
+
+	updates, prices, err := menuItemUpdates(ctx, s.menuItemRepo, menuItem, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only update if there are fields to update
+	if len(updates) == 0 {
+		return menuItem, nil // No changes
+	}
+
+	// Update the menu item
+	userID, _ := tenantctx.GetUserID(ctx)
+	changes := newPriceChanges(menuItem, prices, userID)
+	if err := s.menuItemRepo.UpdateWithPriceChangesWithContext(ctx, id, menuItem.Version, updates, changes); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	if menuItem.IsPublished() {
+		s.webhookService.NotifyMenuChange(ctx, restaurantID, MenuChange{
+			Entity:   MenuEntityMenuItem,
+			EntityID: id,
+			Action:   MenuChangeUpdated,
+			Fields:   changedFields(updates),
+		})
+	}
+
+	// Fetch and return updated menu item
+	return s.menuItemRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+}
+
+// updateDraft keeps the edits of a published menu item for the next menu publish
+// Availability is operational (the item ran out) and changes on the menu right away
+func (s *MenuItemService) updateDraft(ctx context.Context, menuItem *models.MenuItem, req *dto.UpdateMenuItemRequest) (*models.MenuItem, error) {
+	// Rejected edits are reported now rather than when publishing
+	if _, _, err := menuItemUpdates(ctx, s.menuItemRepo, menuItem, req); err != nil {
+		return nil, err
+	}
+
+	draft := *req
+	draft.IsAvailable = nil
+	draftChanges, err := mergeDraftChanges(menuItem.DraftChanges, &draft)
 	if err != nil {
-		return nil, errors.New("menu item not found")
+		return nil, err
 	}
 
-	// Validate ownership - ensure menu item belongs to the requesting restaurant
-	// This is a defense-in-depth measure in addition to RLS
-	if menuItem.RestaurantID != restaurantID {
-		return nil, errors.New("menu item not found") // Don't reveal existence of other tenants' data
+	updates := make(map[string]interface{})
+	if draftChanges != nil {
+		updates["draft_changes"] = gorm.Expr("?::jsonb", string(draftChanges))
+	}
+	if req.IsAvailable != nil {
+		updates["is_available"] = *req.IsAvailable
+	}
+	if len(updates) == 0 {
+		return menuItem, nil
+	}
+
+	if err := s.menuItemRepo.UpdateWithContext(ctx, menuItem.ID, menuItem.Version, updates); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	if req.IsAvailable != nil {
+		s.webhookService.NotifyMenuChange(ctx, menuItem.RestaurantID, MenuChange{
+			Entity:   MenuEntityMenuItem,
+			EntityID: menuItem.ID,
+			Action:   MenuChangeUpdated,
+			Fields:   []string{"is_available"},
+		})
 	}
 
+	return s.menuItemRepo.GetByIDForRestaurant(ctx, menuItem.ID, menuItem.RestaurantID)
+}
+
+// menuItemUpdates validates an update of a menu item and returns its column updates (only provided fields) and
+// the prices it sets, keyed by channel (empty for the menu price)
+func menuItemUpdates(
+	ctx context.Context,
+	menuItemRepo *repositories.MenuItemRepository,
+	menuItem *models.MenuItem,
+	req *dto.UpdateMenuItemRequest,
+) (map[string]interface{}, map[string]*money.Cents, error) {
 	// Build update map with only provided (non-nil) fields
 	updates := make(map[string]interface{})
 
 	if req.Name != nil {
 		if *req.Name == "" {
-			return nil, errors.New("name cannot be empty")
+			return nil, nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "name cannot be empty")
 		}
 		// Validate name is not already taken
-		if _, err := s.menuItemRepo.GetByNameWithContext(ctx, *req.Name); err == nil {
-			return nil, errors.New("name already taken")
+		if _, err := menuItemRepo.GetByNameWithContext(ctx, *req.Name); err == nil {
+			return nil, nil, apperrors.Conflict(apperrors.CodeNameTaken, "name already taken")
 		}
 		updates["name"] = *req.Name
 	}
@@ -90,6 +232,16 @@ func (s *MenuItemService) UpdateMenuItem(ctx context.Context, id uint, req *dto.
 		updates["image_url"] = *req.ImageURL
 	}
 
+	// Price changes are recorded in the item's price history
+	prices := make(map[string]*money.Cents)
+	if req.Price != nil {
+		if *req.Price < 0 {
+			return nil, nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "price cannot be negative")
+		}
+		updates["price"] = *req.Price
+		prices[""] = req.Price
+	}
+
 	if req.DisplayOrder != nil {
 		updates["display_order"] = *req.DisplayOrder
 	}
@@ -98,6 +250,42 @@ func (s *MenuItemService) UpdateMenuItem(ctx context.Context, id uint, req *dto.
 		updates["is_available"] = *req.IsAvailable
 	}
 
+	if req.Calories != nil {
+		updates["calories"] = *req.Calories
+	}
+
+	if req.ProteinGrams != nil {
+		updates["protein_grams"] = *req.ProteinGrams
+	}
+
+	if req.CarbsGrams != nil {
+		updates["carbs_grams"] = *req.CarbsGrams
+	}
+
+	if req.FatGrams != nil {
+		updates["fat_grams"] = *req.FatGrams
+	}
+
+	if req.Tags != nil {
+		tags, err := json.Marshal(normalizeTags(*req.Tags))
+		if err != nil {
+			return nil, nil, err
+		}
+		updates["tags"] = gorm.Expr("?::jsonb", string(tags))
+	}
+
+	if req.ChannelPrices != nil {
+		prices[models.OrderChannelDineIn] = req.ChannelPrices.DineIn
+		prices[models.OrderChannelPickup] = req.ChannelPrices.Pickup
+		prices[models.OrderChannelDelivery] = req.ChannelPrices.Delivery
+		prices[models.OrderChannelThirdParty] = req.ChannelPrices.ThirdParty
+		for channel, price := range prices {
+			if channel != "" {
+				updates[models.MenuItemPriceColumn(channel)] = price
+			}
+		}
+	}
+
 	if req.CategoryID != nil {
 		// Validate category exists if category is being changed
 		if *req.CategoryID != menuItem.CategoryID {
@@ -107,16 +295,142 @@ func (s *MenuItemService) UpdateMenuItem(ctx context.Context, id uint, req *dto.
 		}
 	}
 
-	// Only update if there are fields to update
-	if len(updates) == 0 {
-		return menuItem, nil // No changes
+	return updates, prices, nil
+}
+
+// DeleteMenuItem deletes a menu item belonging to the restaurant
+func (s *MenuItemService) DeleteMenuItem(ctx context.Context, id uint, restaurantID uint) error {
+	menuItem, err := s.menuItemRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
 	}
 
-	// Update the menu item
-	if err := s.menuItemRepo.UpdateWithContext(ctx, id, updates); err != nil {
+	if err := s.menuItemRepo.DeleteWithContext(ctx, id); err != nil {
+		return err
+	}
+
+	if menuItem.IsPublished() {
+		s.webhookService.NotifyMenuChange(ctx, restaurantID, MenuChange{
+			Entity:   MenuEntityMenuItem,
+			EntityID: id,
+			Action:   MenuChangeDeleted,
+		})
+	}
+
+	return nil
+}
+
+// SetItemsAvailability marks the given menu items available or unavailable in a single update. The change is
+// audit-logged and announced as one menu change batch
+func (s *MenuItemService) SetItemsAvailability(ctx context.Context, req *dto.BulkMenuItemAvailabilityRequest, restaurantID uint, actor AuditActor) (*MenuItemAvailabilityResult, error) {
+	ids := make([]uint, 0, len(req.MenuItemIDs))
+	for _, id := range req.MenuItemIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	details, err := json.Marshal(map[string]interface{}{
+		"menu_item_ids": ids,
+		"is_available":  *req.IsAvailable,
+		"reason":        strings.TrimSpace(req.Reason),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit details: %w", err)
+	}
+
+	if err := s.menuItemRepo.SetAvailabilityWithContext(ctx, restaurantID, ids, *req.IsAvailable, &models.AuditLog{
+		RestaurantID: &restaurantID,
+		ActorUserID:  actor.UserID,
+		ActorRole:    actor.Role,
+		Action:       models.AuditActionMenuItemBulkAvailability,
+		Details:      string(details),
+		IPAddress:    actor.IPAddress,
+		UserAgent:    actor.UserAgent,
+	}); err != nil {
+		if errors.Is(err, repositories.ErrMenuItemsNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "one or more menu items not found")
+		}
 		return nil, err
 	}
 
-	// Fetch and return updated menu item
-	return s.menuItemRepo.GetByIDWithContext(ctx, id)
+	changes := make([]MenuChange, 0, len(ids))
+	for _, id := range ids {
+		changes = append(changes, MenuChange{
+			Entity:   MenuEntityMenuItem,
+			EntityID: id,
+			Action:   MenuChangeUpdated,
+			Fields:   []string{"is_available"},
+		})
+	}
+	s.webhookService.NotifyMenuChanges(ctx, restaurantID, changes)
+
+	// Items are taken off when they run out, which staff should know about
+	if !*req.IsAvailable {
+		s.notifications.NotifyLowStock(ctx, restaurantID, ids, strings.TrimSpace(req.Reason))
+	}
+
+	return &MenuItemAvailabilityResult{
+		MenuItemIDs:  ids,
+		IsAvailable:  *req.IsAvailable,
+		UpdatedItems: int64(len(ids)),
+	}, nil
+}
+
+// normalizeTags lowercases and trims tags, dropping empty and duplicate ones
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// priceChangeChannels orders the recorded price changes: the menu price, then the channel prices
+var priceChangeChannels = []string{
+	"",
+	models.OrderChannelDineIn,
+	models.OrderChannelPickup,
+	models.OrderChannelDelivery,
+	models.OrderChannelThirdParty,
+}
+
+// newPriceChanges returns the applied price changes of an update setting the given prices (keyed by channel,
+// empty for the menu price); prices that stay the same aren't recorded
+func newPriceChanges(item *models.MenuItem, prices map[string]*money.Cents, userID uint) []models.MenuItemPriceChange {
+	now := time.Now()
+	var changes []models.MenuItemPriceChange
+	for _, channel := range priceChangeChannels {
+		price, ok := prices[channel]
+		if !ok {
+			continue
+		}
+		old := item.ChannelPrice(channel)
+		if samePrice(old, price) {
+			continue
+		}
+		changes = append(changes, models.MenuItemPriceChange{
+			RestaurantID: item.RestaurantID,
+			MenuItemID:   item.ID,
+			Channel:      channel,
+			OldPrice:     old,
+			NewPrice:     price,
+			Status:       models.PriceChangeStatusApplied,
+			EffectiveAt:  now,
+			AppliedAt:    &now,
+			ChangedBy:    userID,
+		})
+	}
+	return changes
+}
+
+// samePrice reports whether two optional prices are equal to the cent
+func samePrice(a, b *money.Cents) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
 }
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// "Frequently ordered together" computation
+const (
+	pairingLookback  = 90 * 24 * time.Hour // Order history the automatic pairings are computed from
+	pairingMinOrders = 3                   // Orders two items must share to be paired
+	pairingsPerItem  = 5                   // Automatic pairings kept per menu item
+	pairsWellWithMax = 6                   // Items suggested on the public item endpoint
+)
+
+// MenuPairingService handles the "pairs well with" suggestions of menu items: links set by the restaurant
+// and items frequently ordered together, recomputed from the order history
+type MenuPairingService struct {
+	pairingRepo  *repositories.MenuItemPairingRepository
+	menuItemRepo *repositories.MenuItemRepository
+}
+
+// NewMenuPairingService creates a new MenuPairingService instance
+func NewMenuPairingService(pairingRepo *repositories.MenuItemPairingRepository, menuItemRepo *repositories.MenuItemRepository) *MenuPairingService {
+	return &MenuPairingService{
+		pairingRepo:  pairingRepo,
+		menuItemRepo: menuItemRepo,
+	}
+}
+
+// SetPairingsRequest replaces a menu item's manual pairings, suggested in the given order
+type SetPairingsRequest struct {
+	PairedItemIDs []uint `json:"paired_item_ids" binding:"max=20,dive,required"`
+}
+
+// ListPairings returns a menu item's manual and automatic pairings
+func (s *MenuPairingService) ListPairings(ctx context.Context, restaurantID, menuItemID uint) ([]models.MenuItemPairing, error) {
+	if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, menuItemID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+	}
+	return s.pairingRepo.ListByMenuItemWithContext(ctx, restaurantID, menuItemID)
+}
+
+// SetPairings replaces a menu item's manual pairings with items of the same restaurant
+func (s *MenuPairingService) SetPairings(ctx context.Context, restaurantID, menuItemID uint, req *SetPairingsRequest) ([]models.MenuItemPairing, error) {
+	if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, menuItemID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+	}
+
+	seen := make(map[uint]bool, len(req.PairedItemIDs))
+	pairedItemIDs := make([]uint, 0, len(req.PairedItemIDs))
+	for _, id := range req.PairedItemIDs {
+		if id == menuItemID {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "a menu item can't be paired with itself")
+		}
+		if seen[id] {
+			continue
+		}
+		if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, id, restaurantID); err != nil {
+			return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, fmt.Sprintf("paired menu item %d not found", id))
+		}
+		seen[id] = true
+		pairedItemIDs = append(pairedItemIDs, id)
+	}
+
+	if err := s.pairingRepo.ReplaceManualWithContext(ctx, restaurantID, menuItemID, pairedItemIDs); err != nil {
+		return nil, err
+	}
+	return s.pairingRepo.ListByMenuItemWithContext(ctx, restaurantID, menuItemID)
+}
+
+// PairsWellWith returns the available items suggested with a menu item, manual links first
+func (s *MenuPairingService) PairsWellWith(ctx context.Context, restaurantID, menuItemID uint) ([]models.MenuItem, error) {
+	return s.pairingRepo.GetPairedItemsWithContext(ctx, restaurantID, menuItemID, pairsWellWithMax)
+}
+
+// PairingJob is the scheduled job that recomputes the "frequently ordered together" pairings of every restaurant;
+// a zero interval disables it
+func (s *MenuPairingService) PairingJob(interval time.Duration) Job {
+	return Job{
+		Name:     "menu_pairings",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			created, err := s.pairingRepo.RecomputeAutomaticWithContext(ctx, time.Now().Add(-pairingLookback), pairingMinOrders, pairingsPerItem)
+			if err != nil {
+				return fmt.Errorf("failed to recompute menu item pairings: %w", err)
+			}
+			logger.Info("Menu item pairings recomputed", zap.Int64("pairings", created))
+			return nil
+		},
+	}
+}
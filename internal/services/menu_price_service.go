@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// priceChangeBatchSize bounds the scheduled price changes applied in one transaction
+const priceChangeBatchSize = 100
+
+// MenuPriceService keeps the price history of menu items and schedules price changes for a future date
+// Orders are charged the price in effect when they are placed: the pricer counts due changes right away,
+// and the price change job writes them to the menu items shortly after
+type MenuPriceService struct {
+	priceChangeRepo *repositories.MenuItemPriceChangeRepository
+	menuItemRepo    *repositories.MenuItemRepository
+	webhookService  *WebhookService
+}
+
+// NewMenuPriceService creates a new MenuPriceService instance
+func NewMenuPriceService(
+	priceChangeRepo *repositories.MenuItemPriceChangeRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	webhookService *WebhookService,
+) *MenuPriceService {
+	return &MenuPriceService{
+		priceChangeRepo: priceChangeRepo,
+		menuItemRepo:    menuItemRepo,
+		webhookService:  webhookService,
+	}
+}
+
+// SchedulePriceRequest schedules a price change of a menu item
+// Without a channel the menu price changes; a channel price without a price is removed
+type SchedulePriceRequest struct {
+	Channel     string       `json:"channel" binding:"omitempty,oneof=dine_in pickup delivery third_party"`
+	Price       *money.Cents `json:"price" binding:"omitempty,min=0"`
+	EffectiveAt time.Time    `json:"effective_at" binding:"required"`
+}
+
+// GetPriceHistory lists a menu item's price changes, scheduled ones included, latest effective first
+func (s *MenuPriceService) GetPriceHistory(ctx context.Context, restaurantID, menuItemID uint) ([]models.MenuItemPriceChange, error) {
+	if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, menuItemID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+	}
+
+	changes, err := s.priceChangeRepo.GetByMenuItemWithContext(ctx, restaurantID, menuItemID)
+	if err != nil {
+		return nil, err
+	}
+	if changes == nil {
+		changes = []models.MenuItemPriceChange{}
+	}
+	return changes, nil
+}
+
+// SchedulePriceChange schedules a price change of a menu item for a future time
+func (s *MenuPriceService) SchedulePriceChange(
+	ctx context.Context,
+	restaurantID, userID, menuItemID uint,
+	req *SchedulePriceRequest,
+) (*models.MenuItemPriceChange, error) {
+	if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, menuItemID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+	}
+	if req.Channel == "" && req.Price == nil {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "price is required to change the menu price")
+	}
+	if !req.EffectiveAt.After(time.Now()) {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "effective_at must be in the future")
+	}
+
+	change := &models.MenuItemPriceChange{
+		RestaurantID: restaurantID,
+		MenuItemID:   menuItemID,
+		Channel:      req.Channel,
+		NewPrice:     req.Price,
+		Status:       models.PriceChangeStatusScheduled,
+		EffectiveAt:  req.EffectiveAt,
+		ChangedBy:    userID,
+	}
+	if err := s.priceChangeRepo.CreateWithContext(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// CancelPriceChange cancels a scheduled price change that hasn't taken effect
+func (s *MenuPriceService) CancelPriceChange(ctx context.Context, restaurantID, menuItemID, id uint) (*models.MenuItemPriceChange, error) {
+	change, err := s.priceChangeRepo.GetByIDForRestaurant(ctx, id, menuItemID, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodePriceChangeNotFound, "price change not found")
+	}
+	// Due changes already count toward order prices, so they can't be taken back anymore
+	if change.Status != models.PriceChangeStatusScheduled || !change.EffectiveAt.After(time.Now()) {
+		return nil, apperrors.Conflict(apperrors.CodeInvalidTransition, "only price changes that haven't taken effect can be cancelled")
+	}
+
+	if err := s.priceChangeRepo.CancelWithContext(ctx, id, menuItemID, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.Conflict(apperrors.CodeInvalidTransition, "only price changes that haven't taken effect can be cancelled")
+		}
+		return nil, err
+	}
+	change.Status = models.PriceChangeStatusCancelled
+	return change, nil
+}
+
+// PriceChangeJob is the scheduled job that writes due scheduled price changes to the menu items
+// and notifies menu subscribers; a zero interval disables it
+func (s *MenuPriceService) PriceChangeJob(interval time.Duration) Job {
+	return Job{Name: "menu_price_changes", Interval: interval, Run: s.applyDueChanges}
+}
+
+// applyDueChanges applies the due scheduled price changes in batches
+func (s *MenuPriceService) applyDueChanges(ctx context.Context) error {
+	now := time.Now()
+	for {
+		changes, err := s.priceChangeRepo.ApplyDueWithContext(ctx, now, priceChangeBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to apply scheduled price changes: %w", err)
+		}
+
+		byRestaurant := make(map[uint][]MenuChange)
+		for _, change := range changes {
+			byRestaurant[change.RestaurantID] = append(byRestaurant[change.RestaurantID], MenuChange{
+				Entity:   MenuEntityMenuItem,
+				EntityID: change.MenuItemID,
+				Action:   MenuChangeUpdated,
+				Fields:   []string{models.MenuItemPriceColumn(change.Channel)},
+			})
+		}
+		for restaurantID, menuChanges := range byRestaurant {
+			s.webhookService.NotifyMenuChanges(ctx, restaurantID, menuChanges)
+		}
+		if len(changes) > 0 {
+			logger.Info("Scheduled price changes applied", zap.Int("changes", len(changes)))
+		}
+
+		if len(changes) < priceChangeBatchSize {
+			return nil
+		}
+	}
+}
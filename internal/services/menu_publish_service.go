@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/dto"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+// MenuPublishService publishes the draft menu of restaurants editing their menu as a draft
+// (menu_drafts_enabled): new categories and items and the pending edits of published ones go live together
+type MenuPublishService struct {
+	publicationRepo *repositories.MenuPublicationRepository
+	menuItemRepo    *repositories.MenuItemRepository
+	webhookService  *WebhookService
+}
+
+// NewMenuPublishService creates a new MenuPublishService instance
+func NewMenuPublishService(
+	publicationRepo *repositories.MenuPublicationRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	webhookService *WebhookService,
+) *MenuPublishService {
+	return &MenuPublishService{
+		publicationRepo: publicationRepo,
+		menuItemRepo:    menuItemRepo,
+		webhookService:  webhookService,
+	}
+}
+
+// PublishMenuRequest publishes the draft menu
+type PublishMenuRequest struct {
+	Note string `json:"note" binding:"max=255"` // e.g. "Autumn menu"
+}
+
+// MenuDraft lists what the next publish puts on the public menu: draft categories and items
+// (publish_status draft) and published ones with pending edits (draft_changes)
+type MenuDraft struct {
+	Categories []models.MenuCategory `json:"categories"`
+	Items      []models.MenuItem     `json:"items"`
+}
+
+// MenuPublicationList is a page of a restaurant's menu publish history
+type MenuPublicationList struct {
+	Publications []models.MenuPublication `json:"publications"`
+	Total        int64                    `json:"total"`
+	Limit        int                      `json:"limit"`
+	Offset       int                      `json:"offset"`
+}
+
+// GetDraft returns the unpublished categories and items of a restaurant's menu
+func (s *MenuPublishService) GetDraft(ctx context.Context, restaurantID uint) (*MenuDraft, error) {
+	categories, items, err := s.publicationRepo.GetDraftWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if categories == nil {
+		categories = []models.MenuCategory{}
+	}
+	if items == nil {
+		items = []models.MenuItem{}
+	}
+	return &MenuDraft{Categories: categories, Items: items}, nil
+}
+
+// Publish puts a restaurant's draft menu on the public menu in a single transaction and records the publication
+// Pending price edits are recorded in the items' price history as changed by the publishing user
+func (s *MenuPublishService) Publish(ctx context.Context, restaurantID, userID uint, req *PublishMenuRequest) (*models.MenuPublication, error) {
+	categories, items, err := s.publicationRepo.GetDraftWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 && len(items) == 0 {
+		return nil, apperrors.Conflict(apperrors.CodeNothingToPublish, "the menu has no unpublished changes")
+	}
+
+	publish := &repositories.MenuPublish{}
+	var changes []MenuChange
+	for i := range categories {
+		category := &categories[i]
+		if !category.IsPublished() {
+			changes = append(changes, MenuChange{Entity: MenuEntityCategory, EntityID: category.ID, Action: MenuChangeCreated})
+			continue
+		}
+
+		var draft dto.UpdateCategoryRequest
+		if err := json.Unmarshal(category.DraftChanges, &draft); err != nil {
+			return nil, fmt.Errorf("failed to decode draft of category %d: %w", category.ID, err)
+		}
+		updates, err := categoryUpdates(&draft)
+		if err != nil {
+			return nil, err
+		}
+		publish.CategoryUpdates = append(publish.CategoryUpdates, repositories.MenuDraftUpdate{
+			ID:      category.ID,
+			Version: category.Version,
+			Updates: updates,
+		})
+		changes = append(changes, MenuChange{
+			Entity:   MenuEntityCategory,
+			EntityID: category.ID,
+			Action:   MenuChangeUpdated,
+			Fields:   changedFields(updates),
+		})
+	}
+
+	for i := range items {
+		item := &items[i]
+		if !item.IsPublished() {
+			changes = append(changes, MenuChange{Entity: MenuEntityMenuItem, EntityID: item.ID, Action: MenuChangeCreated})
+			continue
+		}
+
+		var draft dto.UpdateMenuItemRequest
+		if err := json.Unmarshal(item.DraftChanges, &draft); err != nil {
+			return nil, fmt.Errorf("failed to decode draft of menu item %d: %w", item.ID, err)
+		}
+		updates, prices, err := menuItemUpdates(ctx, s.menuItemRepo, item, &draft)
+		if err != nil {
+			return nil, err
+		}
+		publish.ItemUpdates = append(publish.ItemUpdates, repositories.MenuDraftUpdate{
+			ID:      item.ID,
+			Version: item.Version,
+			Updates: updates,
+		})
+		publish.PriceChanges = append(publish.PriceChanges, newPriceChanges(item, prices, userID)...)
+		changes = append(changes, MenuChange{
+			Entity:   MenuEntityMenuItem,
+			EntityID: item.ID,
+			Action:   MenuChangeUpdated,
+			Fields:   changedFields(updates),
+		})
+	}
+
+	publication := &models.MenuPublication{
+		RestaurantID: restaurantID,
+		PublishedBy:  userID,
+		Note:         strings.TrimSpace(req.Note),
+	}
+	if err := s.publicationRepo.PublishWithContext(ctx, publication, publish); err != nil {
+		if errors.Is(err, repositories.ErrVersionConflict) {
+			return nil, apperrors.Conflict(apperrors.CodeVersionConflict, "the menu was edited while publishing; publish again")
+		}
+		return nil, err
+	}
+
+	s.webhookService.NotifyMenuChanges(ctx, restaurantID, changes)
+
+	return publication, nil
+}
+
+// ListPublications lists a restaurant's menu publishes, latest first
+func (s *MenuPublishService) ListPublications(ctx context.Context, restaurantID uint, limit, offset int) (*MenuPublicationList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	publications, total, err := s.publicationRepo.ListWithContext(ctx, restaurantID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if publications == nil {
+		publications = []models.MenuPublication{}
+	}
+
+	return &MenuPublicationList{
+		Publications: publications,
+		Total:        total,
+		Limit:        limit,
+		Offset:       offset,
+	}, nil
+}
+
+// mergeDraftChanges adds the provided fields of a partial update request (nil pointers are left out) to the
+// pending edits of a category or item, replacing earlier edits of the same fields
+// Returns nil when no edits are pending
+func mergeDraftChanges(draftChanges json.RawMessage, req interface{}) (json.RawMessage, error) {
+	merged := make(map[string]json.RawMessage)
+	if len(draftChanges) > 0 {
+		if err := json.Unmarshal(draftChanges, &merged); err != nil {
+			return nil, fmt.Errorf("failed to decode draft changes: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for field, value := range fields {
+		if string(value) != "null" {
+			merged[field] = value
+		}
+	}
+
+	if len(merged) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(merged)
+}
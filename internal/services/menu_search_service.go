@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+const (
+	menuSearchDefaultLimit = 20
+	menuSearchSimilarity   = 0.3 // Minimum trigram word similarity of the typo-tolerant fallback
+)
+
+// Menu search match kinds
+const (
+	MenuSearchMatchFullText = "fulltext" // Words (or word prefixes) found in names, tags or descriptions
+	MenuSearchMatchSimilar  = "similar"  // Names similar to the query, when no word matched
+)
+
+// MenuSearchService searches a restaurant's public menu
+type MenuSearchService struct {
+	menuItemRepo *repositories.MenuItemRepository
+	pricing      *PricingRuleService
+}
+
+// NewMenuSearchService creates a new MenuSearchService instance
+func NewMenuSearchService(menuItemRepo *repositories.MenuItemRepository, pricing *PricingRuleService) *MenuSearchService {
+	return &MenuSearchService{
+		menuItemRepo: menuItemRepo,
+		pricing:      pricing,
+	}
+}
+
+// MenuSearchRequest represents a public menu search
+type MenuSearchRequest struct {
+	Query string `form:"q" binding:"required,max=100"`
+	Limit int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// MenuSearchResult is the menu items matching a search, best matches first
+type MenuSearchResult struct {
+	Query string            `json:"query"`
+	Match string            `json:"match,omitempty"` // fulltext or similar; empty without results
+	Items []models.MenuItem `json:"items"`
+}
+
+// Search finds menu items by the words of the query, each matching a word or word prefix of the item's
+// name, tags or description, ranked by relevance (names weigh most). Without any match it falls back to
+// items with a similar name, so typos still find results. Items are priced for the sales channel
+func (s *MenuSearchService) Search(ctx context.Context, restaurantID uint, channel string, req *MenuSearchRequest) (*MenuSearchResult, error) {
+	terms := menuSearchTerms(req.Query)
+	if len(terms) == 0 {
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "query must contain a letter or digit")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = menuSearchDefaultLimit
+	}
+
+	result := &MenuSearchResult{Query: strings.TrimSpace(req.Query), Items: []models.MenuItem{}}
+
+	prefixes := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefixes = append(prefixes, term+":*")
+	}
+	items, err := s.menuItemRepo.SearchWithContext(ctx, restaurantID, strings.Join(prefixes, " & "), limit)
+	if err != nil {
+		return nil, err
+	}
+	result.Match = MenuSearchMatchFullText
+
+	if len(items) == 0 {
+		items, err = s.menuItemRepo.SearchSimilarWithContext(ctx, restaurantID, strings.Join(terms, " "), menuSearchSimilarity, limit)
+		if err != nil {
+			return nil, err
+		}
+		result.Match = MenuSearchMatchSimilar
+	}
+	if len(items) == 0 {
+		result.Match = ""
+		return result, nil
+	}
+
+	if err := s.pricing.PriceMenuItems(ctx, restaurantID, channel, items); err != nil {
+		return nil, err
+	}
+	result.Items = items
+	return result, nil
+}
+
+// menuSearchTerms splits a query into lowercase words, dropping punctuation and tsquery operators
+func menuSearchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
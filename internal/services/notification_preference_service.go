@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Where a customer's marketing consent was recorded
+const (
+	MarketingConsentSourcePreferences = "preferences"
+)
+
+// NotificationPreferenceService manages customers' channel preferences and marketing consent, and decides
+// whether a message may be sent: every email, SMS and push to a customer is checked against it first
+type NotificationPreferenceService struct {
+	preferenceRepo *repositories.NotificationPreferenceRepository
+	signingKey     []byte
+	frontendURL    string
+}
+
+// NewNotificationPreferenceService creates a new NotificationPreferenceService instance
+func NewNotificationPreferenceService(
+	preferenceRepo *repositories.NotificationPreferenceRepository,
+	unsubscribeSecret string,
+	frontendURL string,
+) *NotificationPreferenceService {
+	return &NotificationPreferenceService{
+		preferenceRepo: preferenceRepo,
+		signingKey:     []byte(unsubscribeSecret),
+		frontendURL:    frontendURL,
+	}
+}
+
+// UpdateNotificationPreferencesRequest represents a notification preferences update request
+// Omitted fields keep their current value. Enabling a marketing channel needs consent, given now or before
+type UpdateNotificationPreferencesRequest struct {
+	OrderEmail       *bool `json:"order_email"`
+	OrderSMS         *bool `json:"order_sms"`
+	OrderPush        *bool `json:"order_push"`
+	ReservationEmail *bool `json:"reservation_email"`
+	ReservationSMS   *bool `json:"reservation_sms"`
+	ReservationPush  *bool `json:"reservation_push"`
+	MarketingEmail   *bool `json:"marketing_email"`
+	MarketingSMS     *bool `json:"marketing_sms"`
+	MarketingPush    *bool `json:"marketing_push"`
+	MarketingConsent *bool `json:"marketing_consent"` // true records consent, false withdraws it
+}
+
+// UnsubscribeRequest carries the token of an email unsubscribe link
+type UnsubscribeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// Get returns a customer's preferences, the defaults if they haven't changed them
+func (s *NotificationPreferenceService) Get(ctx context.Context, restaurantID, userID uint) (*models.NotificationPreference, error) {
+	preference, err := s.preferenceRepo.GetWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return models.DefaultNotificationPreference(restaurantID, userID), nil
+		}
+		return nil, err
+	}
+	return preference, nil
+}
+
+// Update applies a partial update to a customer's preferences
+func (s *NotificationPreferenceService) Update(ctx context.Context, restaurantID, userID uint, req *UpdateNotificationPreferencesRequest) (*models.NotificationPreference, error) {
+	preference, err := s.Get(ctx, restaurantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	setIfPresent(&preference.OrderEmail, req.OrderEmail)
+	setIfPresent(&preference.OrderSMS, req.OrderSMS)
+	setIfPresent(&preference.OrderPush, req.OrderPush)
+	setIfPresent(&preference.ReservationEmail, req.ReservationEmail)
+	setIfPresent(&preference.ReservationSMS, req.ReservationSMS)
+	setIfPresent(&preference.ReservationPush, req.ReservationPush)
+	setIfPresent(&preference.MarketingEmail, req.MarketingEmail)
+	setIfPresent(&preference.MarketingSMS, req.MarketingSMS)
+	setIfPresent(&preference.MarketingPush, req.MarketingPush)
+
+	if req.MarketingConsent != nil {
+		if *req.MarketingConsent {
+			if preference.MarketingConsentAt == nil {
+				now := time.Now()
+				preference.MarketingConsentAt = &now
+				preference.MarketingConsentSource = MarketingConsentSourcePreferences
+			}
+		} else {
+			preference.MarketingConsentAt = nil
+			preference.MarketingConsentSource = ""
+			preference.MarketingEmail = false
+			preference.MarketingSMS = false
+			preference.MarketingPush = false
+		}
+	}
+	if preference.MarketingConsentAt == nil && (preference.MarketingEmail || preference.MarketingSMS || preference.MarketingPush) {
+		return nil, apperrors.BadRequest(apperrors.CodeConsentRequired, "marketing messages need marketing consent")
+	}
+
+	if err := s.preferenceRepo.SaveWithContext(ctx, preference); err != nil {
+		return nil, err
+	}
+	return preference, nil
+}
+
+// Allows reports whether a customer may be sent a message of the event type on the channel
+// When the preferences can't be loaded nothing is sent, so a message never goes out against a preference
+func (s *NotificationPreferenceService) Allows(ctx context.Context, restaurantID, userID uint, event, channel string) bool {
+	if s == nil {
+		return event != models.NotificationEventMarketing
+	}
+	preference, err := s.Get(ctx, restaurantID, userID)
+	if err != nil {
+		logger.Warn("Failed to load notification preferences, message not sent",
+			zap.Uint("restaurant_id", restaurantID),
+			zap.Uint("user_id", userID),
+			zap.String("event", event),
+			zap.String("channel", channel),
+			zap.Error(err),
+		)
+		return false
+	}
+	return preference.Allows(event, channel)
+}
+
+// UnsubscribeURL returns the link marketing emails to the customer carry to unsubscribe without signing in
+func (s *NotificationPreferenceService) UnsubscribeURL(restaurantID, userID uint) string {
+	return strings.TrimRight(s.frontendURL, "/") + "/unsubscribe?token=" + url.QueryEscape(s.unsubscribeToken(restaurantID, userID))
+}
+
+// Unsubscribe turns marketing email off for the customer an unsubscribe token was issued to
+// Tokens don't expire, so links in old emails keep working
+func (s *NotificationPreferenceService) Unsubscribe(ctx context.Context, token string) error {
+	restaurantID, userID, ok := s.parseUnsubscribeToken(token)
+	if !ok {
+		return apperrors.BadRequest(apperrors.CodeInvalidToken, "invalid unsubscribe token")
+	}
+
+	preference, err := s.Get(ctx, restaurantID, userID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	preference.MarketingEmail = false
+	preference.UnsubscribedAt = &now
+	if err := s.preferenceRepo.SaveWithContext(ctx, preference); err != nil {
+		return err
+	}
+
+	logger.Info("Customer unsubscribed from marketing email",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.Uint("user_id", userID),
+	)
+	return nil
+}
+
+// unsubscribeToken builds "<restaurant ID>.<user ID>.<signature>"
+func (s *NotificationPreferenceService) unsubscribeToken(restaurantID, userID uint) string {
+	return fmt.Sprintf("%d.%d.%s", restaurantID, userID, s.sign(restaurantID, userID))
+}
+
+// parseUnsubscribeToken verifies an unsubscribe token and returns the customer it was issued to
+func (s *NotificationPreferenceService) parseUnsubscribeToken(token string) (uint, uint, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return 0, 0, false
+	}
+	restaurantID, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	userID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if !hmac.Equal([]byte(s.sign(uint(restaurantID), uint(userID))), []byte(parts[2])) {
+		return 0, 0, false
+	}
+	return uint(restaurantID), uint(userID), true
+}
+
+// sign computes the hex-encoded HMAC-SHA256 signature of an unsubscribe token
+func (s *NotificationPreferenceService) sign(restaurantID, userID uint) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(fmt.Sprintf("unsubscribe:%d:%d", restaurantID, userID)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// setIfPresent sets a preference flag when the request provided it
+func setIfPresent(field *bool, value *bool) {
+	if value != nil {
+		*field = *value
+	}
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// DashboardEventNotification is the live event carrying a new in-app notification
+const DashboardEventNotification = "notification"
+
+// NotificationService raises in-app notifications for restaurant staff and manages their read state
+type NotificationService struct {
+	notificationRepo *repositories.NotificationRepository
+}
+
+// NewNotificationService creates a new NotificationService instance
+func NewNotificationService(notificationRepo *repositories.NotificationRepository) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+	}
+}
+
+// NotificationList is a page of a user's notifications
+type NotificationList struct {
+	Notifications []models.Notification `json:"notifications"`
+	UnreadCount   int64                 `json:"unread_count"`
+}
+
+// MarkNotificationsReadRequest lists the notifications to mark read; all unread ones when empty
+type MarkNotificationsReadRequest struct {
+	IDs []uint `json:"ids"`
+}
+
+// Notify sends the notification to every Admin and Staff user of its restaurant
+// Notifications are best effort: failures are logged and never block the caller
+func (s *NotificationService) Notify(ctx context.Context, notification *models.Notification) {
+	if s == nil {
+		return
+	}
+	if _, err := s.notificationRepo.CreateForStaffWithContext(ctx, notification); err != nil {
+		logger.Warn("Failed to create notifications",
+			zap.Uint("restaurant_id", notification.RestaurantID),
+			zap.String("type", notification.Type),
+			zap.Error(err),
+		)
+	}
+}
+
+// NotifyAdmins sends the notification to every Admin of its restaurant
+func (s *NotificationService) NotifyAdmins(ctx context.Context, notification *models.Notification) {
+	if s == nil {
+		return
+	}
+	if _, err := s.notificationRepo.CreateForRolesWithContext(ctx, notification, []string{"Admin"}); err != nil {
+		logger.Warn("Failed to create notifications",
+			zap.Uint("restaurant_id", notification.RestaurantID),
+			zap.String("type", notification.Type),
+			zap.Error(err),
+		)
+	}
+}
+
+// NotifyUser sends the notification to its user only
+func (s *NotificationService) NotifyUser(ctx context.Context, notification *models.Notification) {
+	if s == nil {
+		return
+	}
+	if err := s.notificationRepo.CreateWithContext(ctx, notification); err != nil {
+		logger.Warn("Failed to create notification",
+			zap.Uint("restaurant_id", notification.RestaurantID),
+			zap.Uint("user_id", notification.UserID),
+			zap.String("type", notification.Type),
+			zap.Error(err),
+		)
+	}
+}
+
+// NotifyNewOrder announces a new order to the restaurant's staff
+func (s *NotificationService) NotifyNewOrder(ctx context.Context, order *models.Order) {
+	s.Notify(ctx, &models.Notification{
+		RestaurantID: order.RestaurantID,
+		Type:         models.NotificationTypeNewOrder,
+		Title:        fmt.Sprintf("New order #%d", order.ID),
+		Body:         fmt.Sprintf("%d item(s), total %s", len(order.OrderItems), order.TotalAmount),
+		Data:         map[string]interface{}{"order_id": order.ID},
+	})
+}
+
+// NotifyNewReservation announces a new reservation to the restaurant's staff
+func (s *NotificationService) NotifyNewReservation(ctx context.Context, reservation *models.Reservation) {
+	s.Notify(ctx, &models.Notification{
+		RestaurantID: reservation.RestaurantID,
+		Type:         models.NotificationTypeNewReservation,
+		Title:        fmt.Sprintf("New reservation for %d", reservation.NumberOfGuests),
+		Body:         fmt.Sprintf("Table %s at %s", reservation.TableNumber, reservation.StartTime.Format(time.RFC3339)),
+		Data:         map[string]interface{}{"reservation_id": reservation.ID},
+	})
+}
+
+// NotifyLowStock announces menu items that ran out and were marked unavailable
+func (s *NotificationService) NotifyLowStock(ctx context.Context, restaurantID uint, menuItemIDs []uint, reason string) {
+	s.Notify(ctx, &models.Notification{
+		RestaurantID: restaurantID,
+		Type:         models.NotificationTypeLowStock,
+		Title:        fmt.Sprintf("%d menu item(s) marked unavailable", len(menuItemIDs)),
+		Body:         reason,
+		Data:         map[string]interface{}{"menu_item_ids": menuItemIDs},
+	})
+}
+
+// List returns a page of the user's notifications, newest first, with their unread count
+func (s *NotificationService) List(ctx context.Context, userID uint, unreadOnly bool, limit, offset int) (*NotificationList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	notifications, err := s.notificationRepo.ListWithContext(ctx, userID, unreadOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	unread, err := s.notificationRepo.CountUnreadWithContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationList{Notifications: notifications, UnreadCount: unread}, nil
+}
+
+// MarkRead marks the user's notifications read, all of them when ids is empty, and returns how many changed
+func (s *NotificationService) MarkRead(ctx context.Context, userID uint, ids []uint) (int64, error) {
+	return s.notificationRepo.MarkReadWithContext(ctx, userID, ids, time.Now())
+}
+
+// Delete removes one of the user's notifications
+func (s *NotificationService) Delete(ctx context.Context, userID, id uint) error {
+	deleted, err := s.notificationRepo.DeleteWithContext(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return apperrors.NotFound(apperrors.CodeNotificationNotFound, "notification not found")
+	}
+	return nil
+}
+
+// Clear removes the user's notifications, only the read ones when readOnly is set, and returns how many
+func (s *NotificationService) Clear(ctx context.Context, userID uint, readOnly bool) (int64, error) {
+	return s.notificationRepo.ClearWithContext(ctx, userID, readOnly)
+}
+
+// CurrentCursor returns the user's latest notification ID, so a new stream only receives later ones
+func (s *NotificationService) CurrentCursor(ctx context.Context, userID uint) (uint, error) {
+	return s.notificationRepo.GetLatestIDWithContext(ctx, userID)
+}
+
+// GetEventsSince returns the user's notifications after the cursor as live events and advances it
+func (s *NotificationService) GetEventsSince(ctx context.Context, userID uint, cursor *uint) ([]DashboardEvent, error) {
+	notifications, err := s.notificationRepo.GetCreatedAfterWithContext(ctx, userID, *cursor, dashboardEventBatch)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]DashboardEvent, 0, len(notifications))
+	for _, notification := range notifications {
+		events = append(events, DashboardEvent{Name: DashboardEventNotification, Data: notification})
+		*cursor = notification.ID
+	}
+	return events, nil
+}
+
+// CleanupJob is the scheduled job that deletes notifications older than the retention;
+// a zero interval disables it
+func (s *NotificationService) CleanupJob(interval, retention time.Duration) Job {
+	return Job{
+		Name:     "notification_cleanup",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			deleted, err := s.notificationRepo.DeleteOlderThanWithContext(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return fmt.Errorf("failed to delete old notifications: %w", err)
+			}
+			if deleted > 0 {
+				logger.Info("Deleted old notifications", zap.Int64("deleted", deleted))
+			}
+			return nil
+		},
+	}
+}
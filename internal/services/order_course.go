@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+// FireCourseRequest represents a request to fire a course of a dine-in order to the kitchen
+type FireCourseRequest struct {
+	// Course defaults to the next held course
+	Course *int `json:"course" binding:"omitempty,min=2,max=9"`
+}
+
+// FireCourse tells the kitchen to start a held course of an order and returns the updated ticket
+// Courses can only be fired while the order is in the kitchen; firing is recorded for the live dashboard stream
+func (s *OrderService) FireCourse(ctx context.Context, orderID, restaurantID, firedBy uint, req *FireCourseRequest) (*KitchenTicket, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+	if order.Status != models.OrderStatusConfirmed && order.Status != models.OrderStatusPreparing {
+		return nil, apperrors.Conflict(apperrors.CodeInvalidTransition,
+			fmt.Sprintf("courses can only be fired while the order is in the kitchen, order is %s", order.Status))
+	}
+
+	ticket := NewKitchenTicket(order)
+	course := ticket.NextCourse
+	if req.Course != nil {
+		course = req.Course
+	}
+	if course == nil {
+		return nil, apperrors.Conflict(apperrors.CodeCourseNotHeld, "order has no held courses")
+	}
+
+	firing := &models.OrderCourseFiring{
+		RestaurantID:  order.RestaurantID,
+		OrderID:       order.ID,
+		Course:        *course,
+		FiredByUserID: firedBy,
+	}
+	if err := s.orderRepo.FireCourseWithContext(ctx, firing); err != nil {
+		if errors.Is(err, repositories.ErrCourseNotHeld) {
+			return nil, apperrors.Conflict(apperrors.CodeCourseNotHeld,
+				fmt.Sprintf("course %d has no held items", *course))
+		}
+		return nil, err
+	}
+
+	order, err = s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if s.printing != nil {
+		s.printing.AutoPrintCourseFire(ctx, order)
+	}
+
+	return NewKitchenTicket(order), nil
+}
+
+// heldCourses returns the courses of an order the kitchen is still holding
+func heldCourses(order *models.Order) []int {
+	var held []int
+	for _, course := range NewKitchenTicket(order).Courses {
+		if course.Status == KitchenCourseHeld {
+			held = append(held, course.Course)
+		}
+	}
+	return held
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// orderPartitionMonthsAhead is how many months of partitions are kept ready beyond the current one, so new
+// orders never land in the default partition
+const orderPartitionMonthsAhead = 3
+
+// OrderPartitionService manages the optional monthly partitioning of orders and order_items
+type OrderPartitionService struct {
+	partitionRepo *repositories.OrderPartitionRepository
+}
+
+// NewOrderPartitionService creates a new OrderPartitionService instance
+func NewOrderPartitionService(partitionRepo *repositories.OrderPartitionRepository) *OrderPartitionService {
+	return &OrderPartitionService{
+		partitionRepo: partitionRepo,
+	}
+}
+
+// Partition converts the order tables that aren't partitioned yet to monthly partitions (--partition-orders)
+// Foreign keys from other tables to them are dropped and unique indexes without created_at become plain ones;
+// both are logged. Tables already partitioned are skipped, so it can be run again safely
+func (s *OrderPartitionService) Partition(ctx context.Context) error {
+	for _, table := range repositories.OrderPartitionTables {
+		partitioned, err := s.partitionRepo.IsPartitionedWithContext(ctx, table)
+		if err != nil {
+			return fmt.Errorf("failed to check %s: %w", table, err)
+		}
+		if partitioned {
+			logger.Info("Table is already partitioned", zap.String("table", table))
+			continue
+		}
+
+		conversion, err := s.partitionRepo.PartitionWithContext(ctx, table, orderPartitionMonthsAhead)
+		if err != nil {
+			return err
+		}
+		logger.Info("Partitioned table by month",
+			zap.String("table", table),
+			zap.Int("partitions", conversion.Partitions),
+			zap.Strings("dropped_foreign_keys", conversion.DroppedForeignKeys),
+			zap.Strings("non_unique_indexes", conversion.NonUniqueIndexes),
+		)
+	}
+	return nil
+}
+
+// PartitionJob returns the job creating the upcoming monthly partitions of the partitioned order tables
+// It does nothing while the tables aren't partitioned
+func (s *OrderPartitionService) PartitionJob(interval time.Duration) Job {
+	return Job{
+		Name:     "order_partitions",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			for _, table := range repositories.OrderPartitionTables {
+				partitioned, err := s.partitionRepo.IsPartitionedWithContext(ctx, table)
+				if err != nil {
+					return fmt.Errorf("failed to check %s: %w", table, err)
+				}
+				if !partitioned {
+					continue
+				}
+
+				created, err := s.partitionRepo.CreateMonthlyPartitionsWithContext(ctx, table, time.Now(), orderPartitionMonthsAhead)
+				if err != nil {
+					return err
+				}
+				if created > 0 {
+					logger.Info("Created order partitions", zap.String("table", table), zap.Int("created", created))
+				}
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// Order scheduling windows
+const (
+	scheduledOrderMinLead  = models.OrderSlotDuration // Earliest pickup time of a scheduled order from now
+	scheduledOrderMaxAhead = 14 * 24 * time.Hour      // Latest pickup time of a scheduled order from now
+	scheduledOrderFireLead = 30 * time.Minute         // Scheduled orders move to the kitchen queue this long before pickup
+)
+
+// scheduledStatuses are the statuses of scheduled orders waiting to be fired to the kitchen
+var scheduledStatuses = []string{models.OrderStatusPending, models.OrderStatusConfirmed}
+
+// OrderScheduleService handles order-ahead scheduling: opening hours, slot capacity and the scheduled kitchen queue
+type OrderScheduleService struct {
+	orderRepo    *repositories.OrderRepository
+	settingsRepo *repositories.RestaurantSettingsRepository
+	hoursRepo    *repositories.OpeningHoursRepository
+}
+
+// NewOrderScheduleService creates a new OrderScheduleService instance
+func NewOrderScheduleService(
+	orderRepo *repositories.OrderRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+	hoursRepo *repositories.OpeningHoursRepository,
+) *OrderScheduleService {
+	return &OrderScheduleService{
+		orderRepo:    orderRepo,
+		settingsRepo: settingsRepo,
+		hoursRepo:    hoursRepo,
+	}
+}
+
+// OpeningHoursPeriod is an opening period of a weekday (0 = Sunday), with HH:MM times
+type OpeningHoursPeriod struct {
+	Weekday  *int   `json:"weekday" binding:"required,min=0,max=6"`
+	OpensAt  string `json:"opens_at" binding:"required,len=5"`
+	ClosesAt string `json:"closes_at" binding:"required,len=5"`
+}
+
+// UpdateOpeningHoursRequest replaces a restaurant's opening hours
+// Without any period the restaurant is treated as always open
+type UpdateOpeningHoursRequest struct {
+	Periods []OpeningHoursPeriod `json:"periods" binding:"max=50,dive"`
+}
+
+// ScheduledSlot is a 15-minute kitchen slot with the scheduled orders booked into it
+type ScheduledSlot struct {
+	Start    time.Time        `json:"start"`
+	Capacity int              `json:"capacity"` // 0 = unlimited
+	Booked   int              `json:"booked"`
+	Tickets  []*KitchenTicket `json:"tickets"`
+}
+
+// GetOpeningHours lists a restaurant's opening hours
+func (s *OrderScheduleService) GetOpeningHours(ctx context.Context, restaurantID uint) ([]models.OpeningHours, error) {
+	return s.hoursRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// UpdateOpeningHours replaces a restaurant's opening hours
+func (s *OrderScheduleService) UpdateOpeningHours(ctx context.Context, restaurantID uint, req *UpdateOpeningHoursRequest) ([]models.OpeningHours, error) {
+	hours := make([]models.OpeningHours, 0, len(req.Periods))
+	for _, period := range req.Periods {
+		if _, err := parseClock(period.OpensAt); err != nil {
+			return nil, err
+		}
+		if _, err := parseClock(period.ClosesAt); err != nil {
+			return nil, err
+		}
+		hours = append(hours, models.OpeningHours{
+			RestaurantID: restaurantID,
+			Weekday:      *period.Weekday,
+			OpensAt:      period.OpensAt,
+			ClosesAt:     period.ClosesAt,
+		})
+	}
+
+	if err := s.hoursRepo.ReplaceWithContext(ctx, restaurantID, hours); err != nil {
+		return nil, err
+	}
+	return s.hoursRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// ValidatePickupTime checks a scheduled order's pickup time against the scheduling window and
+// the opening hours, and returns the restaurant's slot capacity for booking it
+func (s *OrderScheduleService) ValidatePickupTime(ctx context.Context, restaurantID uint, pickup time.Time) (int, error) {
+	now := time.Now()
+	if pickup.Before(now.Add(scheduledOrderMinLead)) {
+		return 0, apperrors.BadRequest(apperrors.CodeInvalidTimeRange,
+			fmt.Sprintf("scheduled time must be at least %d minutes ahead", int(scheduledOrderMinLead.Minutes())))
+	}
+	if pickup.After(now.Add(scheduledOrderMaxAhead)) {
+		return 0, apperrors.BadRequest(apperrors.CodeInvalidTimeRange,
+			fmt.Sprintf("scheduled time must be within %d days", int(scheduledOrderMaxAhead.Hours()/24)))
+	}
+
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return 0, err
+	}
+	hours, err := s.hoursRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return 0, err
+	}
+
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	if !isOpenAt(hours, pickup.In(location)) {
+		return 0, apperrors.BadRequest(apperrors.CodeOutsideOpeningHours, "restaurant is closed at the scheduled time")
+	}
+
+	return settings.OrderSlotCapacity, nil
+}
+
+// GetScheduledQueue lists the upcoming scheduled orders by slot
+// Orders due within the fire lead time are on the kitchen queue instead
+func (s *OrderScheduleService) GetScheduledQueue(ctx context.Context, restaurantID uint) ([]*ScheduledSlot, error) {
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	orders, err := s.orderRepo.GetScheduledOrdersWithContext(ctx, restaurantID, scheduledStatuses,
+		now.Add(scheduledOrderFireLead), now.Add(scheduledOrderMaxAhead))
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make([]*ScheduledSlot, 0)
+	for i := range orders {
+		start := orders[i].ScheduledFor.Truncate(models.OrderSlotDuration)
+		if len(slots) == 0 || !slots[len(slots)-1].Start.Equal(start) {
+			slots = append(slots, &ScheduledSlot{
+				Start:    start,
+				Capacity: settings.OrderSlotCapacity,
+				Tickets:  []*KitchenTicket{},
+			})
+		}
+		slot := slots[len(slots)-1]
+		slot.Booked++
+		slot.Tickets = append(slot.Tickets, NewKitchenTicket(&orders[i]))
+	}
+
+	return slots, nil
+}
+
+// settings loads the restaurant's settings, falling back to default settings
+func (s *OrderScheduleService) settings(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+	return settings, nil
+}
+
+// isOpenAt reports whether a local time falls into one of the opening periods
+// Periods closing at or before their opening time run into the next day; no periods means always open
+func isOpenAt(hours []models.OpeningHours, local time.Time) bool {
+	if len(hours) == 0 {
+		return true
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	weekday := int(local.Weekday())
+	previous := (weekday + 6) % 7
+	for _, period := range hours {
+		opens, err := parseClock(period.OpensAt)
+		if err != nil {
+			continue
+		}
+		closes, err := parseClock(period.ClosesAt)
+		if err != nil {
+			continue
+		}
+
+		if closes > opens {
+			if period.Weekday == weekday && minute >= opens && minute < closes {
+				return true
+			}
+			continue
+		}
+		if (period.Weekday == weekday && minute >= opens) || (period.Weekday == previous && minute < closes) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses an HH:MM time of day into minutes after midnight
+func parseClock(clock string) (int, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid time of day, expected HH:MM")
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
@@ -3,16 +3,39 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/metrics"
 	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
 	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
 )
 
 // OrderService handles order business logic
 type OrderService struct {
-	orderRepo     *repositories.OrderRepository
-	orderItemRepo *repositories.OrderItemRepository
-	menuItemRepo  *repositories.MenuItemRepository
+	orderRepo      *repositories.OrderRepository
+	orderItemRepo  *repositories.OrderItemRepository
+	menuItemRepo   *repositories.MenuItemRepository
+	restaurantRepo *repositories.RestaurantRepository
+	customers      *CustomerService
+	printing       *PrintService
+	receipts       *ReceiptService
+	scheduling     *OrderScheduleService
+	prepTimes      *PrepTimeService
+	carts          *CartService
+	zones          *DeliveryZoneService
+	pricing        *PricingRuleService
+	notifications  *NotificationService
+	sms            *SMSService
+	push           *PushService
 }
 
 // NewOrderService creates a new OrderService instance
@@ -20,19 +43,70 @@ func NewOrderService(
 	orderRepo *repositories.OrderRepository,
 	orderItemRepo *repositories.OrderItemRepository,
 	menuItemRepo *repositories.MenuItemRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	customers *CustomerService,
+	printing *PrintService,
+	receipts *ReceiptService,
+	scheduling *OrderScheduleService,
+	prepTimes *PrepTimeService,
+	carts *CartService,
+	zones *DeliveryZoneService,
+	pricing *PricingRuleService,
+	notifications *NotificationService,
+	sms *SMSService,
+	push *PushService,
 ) *OrderService {
 	return &OrderService{
-		orderRepo:     orderRepo,
-		orderItemRepo: orderItemRepo,
-		menuItemRepo:  menuItemRepo,
+		orderRepo:      orderRepo,
+		orderItemRepo:  orderItemRepo,
+		menuItemRepo:   menuItemRepo,
+		restaurantRepo: restaurantRepo,
+		customers:      customers,
+		printing:       printing,
+		receipts:       receipts,
+		scheduling:     scheduling,
+		prepTimes:      prepTimes,
+		carts:          carts,
+		zones:          zones,
+		pricing:        pricing,
+		notifications:  notifications,
+		sms:            sms,
+		push:           push,
+	}
+}
+
+// EnsurePublicOrdering checks that a restaurant accepts orders from customers (Client role)
+// Hidden (soft launch) or inactive restaurants only accept orders from staff, as do kitchens that paused ordering over capacity
+func (s *OrderService) EnsurePublicOrdering(ctx context.Context, restaurantID uint) error {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+	if !restaurant.IsPubliclyVisible() {
+		return apperrors.Forbidden(apperrors.CodeRestaurantNotPublic, "restaurant is not accepting public orders")
+	}
+
+	// Over capacity the kitchen may pause online ordering; staff can still take orders
+	if s.prepTimes != nil {
+		status, err := s.prepTimes.OrderingStatus(ctx, restaurantID)
+		if err != nil {
+			return err
+		}
+		if !status.AcceptingOrders {
+			return apperrors.New(http.StatusServiceUnavailable, apperrors.CodeOrderingPaused,
+				"the kitchen is at capacity and online ordering is paused; try again in a few minutes")
+		}
 	}
+	return nil
 }
 
 // OrderItemRequest represents an item in an order request
 type OrderItemRequest struct {
 	MenuItemID uint   `json:"menu_item_id" binding:"required"`
 	Quantity   int    `json:"quantity" binding:"required,min=1"`
-	Notes      string `json:"notes"`
+	Notes      string `json:"notes" binding:"max=255"` // e.g. "no onions"; shown on kitchen tickets and emails
+	Seat       *int   `json:"seat" binding:"omitempty,min=1,max=100"`
+	Course     *int   `json:"course" binding:"omitempty,min=1,max=9"` // dine-in course; later courses are held until fired
 }
 
 // CreateOrderRequest represents order creation request
@@ -40,104 +114,271 @@ type CreateOrderRequest struct {
 	UserID uint               `json:"user_id" binding:"required"`
 	Items  []OrderItemRequest `json:"items" binding:"required,min=1"`
 	Notes  string             `json:"notes"`
+
+	// ScheduledFor orders ahead for a pickup/delivery time within opening hours; omitted means as soon as possible
+	ScheduledFor *time.Time `json:"scheduled_for"`
+
+	// FulfillmentType defaults to pickup; delivery orders need a Delivery address inside a delivery zone
+	FulfillmentType string                  `json:"fulfillment_type" binding:"omitempty,oneof=dine_in pickup delivery"`
+	Delivery        *DeliveryAddressRequest `json:"delivery"`
+
+	// Channel selects the menu prices charged; it defaults to the fulfillment type
+	Channel string `json:"channel" binding:"omitempty,oneof=dine_in pickup delivery third_party"`
+
+	// CartToken is the anonymous cart the order was placed from, closed once ordered; without it the customer's cart is
+	CartToken string `json:"cart_token" binding:"max=64"`
+
+	// TableSessionID is set by TableSessionService for the rounds of an open check; it isn't bound from JSON
+	TableSessionID *uint `json:"-"`
+}
+
+// DeliveryAddressRequest is the address of a delivery order, located by its coordinates
+type DeliveryAddressRequest struct {
+	Address   string   `json:"address" binding:"required,max=255"`
+	Latitude  *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required,min=-180,max=180"`
 }
 
 // CreateOrder creates a new order with items
+// Menu items are locked while the order is priced and inserted in one transaction, and each
+// order item snapshots the menu item's name and price
 func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest, restaurantID uint) (*models.Order, error) {
 	if len(req.Items) == 0 {
-		return nil, errors.New("order must contain at least one item")
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "order must contain at least one item")
 	}
 
-	// Validate menu items and calculate total
-	var totalAmount float64
-	orderItems := make([]models.OrderItem, 0, len(req.Items))
-
+	menuItemIDs := make([]uint, 0, len(req.Items))
 	for _, itemReq := range req.Items {
-		// Get menu item to validate and get price
-		menuItem, err := s.menuItemRepo.GetByIDWithContext(ctx, itemReq.MenuItemID)
+		menuItemIDs = append(menuItemIDs, itemReq.MenuItemID)
+	}
+
+	order := &models.Order{
+		RestaurantID:    restaurantID,
+		UserID:          req.UserID,
+		Status:          models.OrderStatusPending,
+		Notes:           req.Notes,
+		FulfillmentType: req.FulfillmentType,
+		TableSessionID:  req.TableSessionID,
+	}
+	if order.FulfillmentType == "" {
+		order.FulfillmentType = models.OrderFulfillmentPickup
+	}
+	if order.TableSessionID != nil {
+		order.FulfillmentType = models.OrderFulfillmentDineIn
+	}
+	order.Channel = req.Channel
+	if order.Channel == "" {
+		order.Channel = order.FulfillmentType
+	}
+	if order.FulfillmentType != models.OrderFulfillmentDineIn {
+		for _, itemReq := range req.Items {
+			if itemReq.Course != nil && *itemReq.Course > 1 {
+				return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "only dine-in orders can be served in courses")
+			}
+		}
+	}
+
+	var delivery *DeliveryQuote
+	if order.FulfillmentType == models.OrderFulfillmentDelivery {
+		if req.Delivery == nil {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "delivery orders need a delivery address")
+		}
+		if s.zones == nil {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "delivery is not available")
+		}
+		quote, err := s.zones.Quote(ctx, restaurantID, *req.Delivery.Latitude, *req.Delivery.Longitude, 0)
+		if err != nil {
+			return nil, err
+		}
+		if !quote.Deliverable {
+			return nil, apperrors.BadRequest(apperrors.CodeOutsideDeliveryZone, "address is outside the delivery area")
+		}
+		delivery = quote
+		order.DeliveryAddress = strings.TrimSpace(req.Delivery.Address)
+		order.DeliveryLat = req.Delivery.Latitude
+		order.DeliveryLng = req.Delivery.Longitude
+		order.DeliveryZoneID = quote.ZoneID
+	}
+
+	slotCapacity := 0
+	if req.ScheduledFor != nil {
+		if s.scheduling == nil {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "order scheduling is not available")
+		}
+		capacity, err := s.scheduling.ValidatePickupTime(ctx, restaurantID, *req.ScheduledFor)
 		if err != nil {
-			return nil, errors.New("menu item not found")
+			return nil, err
 		}
+		scheduledFor := req.ScheduledFor.UTC()
+		order.ScheduledFor = &scheduledFor
+		slotCapacity = capacity
+	}
 
-		// Validate menu item belongs to restaurant (RLS ensures this)
-		if menuItem.RestaurantID != restaurantID {
-			return nil, errors.New("menu item does not belong to restaurant")
+	// Orders taken as soon as possible while the kitchen is over capacity are promised later
+	if order.ScheduledFor == nil && s.prepTimes != nil {
+		status, err := s.prepTimes.OrderingStatus(ctx, restaurantID)
+		if err != nil {
+			return nil, err
 		}
+		order.ThrottleDelayMinutes = status.DelayMinutes
+	}
+
+	// Items are charged the channel's menu price when the order is placed, happy hours included
+	pricer := &MenuPricer{}
+	if s.pricing != nil {
+		var err error
+		if pricer, err = s.pricing.Pricer(ctx, restaurantID, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	err := s.orderRepo.CreateWithLockedMenuItemsWithContext(ctx, order, menuItemIDs, slotCapacity, func(menuItems map[uint]*models.MenuItem) error {
+		// Validate menu items and calculate total from the locked rows, in cents so it matches the items exactly
+		var total money.Cents
+		orderItems := make([]models.OrderItem, 0, len(req.Items))
+
+		for _, itemReq := range req.Items {
+			menuItem, ok := menuItems[itemReq.MenuItemID]
+			if !ok {
+				return apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+			}
 
-		// Check availability
-		if !menuItem.IsAvailable {
-			return nil, errors.New("menu item is not available")
+			// Validate menu item belongs to restaurant (RLS ensures this)
+			if menuItem.RestaurantID != restaurantID {
+				return apperrors.BadRequest(apperrors.CodeMenuItemNotFound, "menu item does not belong to restaurant")
+			}
+
+			// Check availability
+			if !menuItem.IsAvailable {
+				return apperrors.Conflict(apperrors.CodeMenuItemUnavailable, "menu item is not available")
+			}
+
+			// Calculate item total
+			unitPrice, rule := pricer.Price(menuItem, order.Channel)
+			total += unitPrice.Times(itemReq.Quantity)
+
+			orderItem := models.OrderItem{
+				RestaurantID: restaurantID,
+				MenuItemID:   itemReq.MenuItemID,
+				Quantity:     itemReq.Quantity,
+				Price:        unitPrice,
+				Name:         menuItem.Name,
+				Notes:        strings.TrimSpace(itemReq.Notes),
+				Seat:         itemReq.Seat,
+				Course:       itemReq.Course,
+			}
+			if rule != nil {
+				orderItem.PricingRuleID = &rule.ID
+			}
+			orderItems = append(orderItems, orderItem)
 		}
 
-		// Calculate item total
-		itemTotal := menuItem.Price * float64(itemReq.Quantity)
-		totalAmount += itemTotal
+		// The zone's minimum applies to the items; the delivery fee is added on top
+		if delivery != nil {
+			if total < delivery.MinimumOrder {
+				return apperrors.BadRequest(apperrors.CodeBelowMinimumOrder,
+					fmt.Sprintf("delivery orders to this address must be at least %s", delivery.MinimumOrder))
+			}
+			order.DeliveryFee = delivery.Fee
+			total += delivery.Fee
+		}
 
-		// Create order item
-		orderItem := models.OrderItem{
-			MenuItemID: itemReq.MenuItemID,
-			Quantity:   itemReq.Quantity,
-			Price:      menuItem.Price,
-			Notes:      itemReq.Notes,
+		order.TotalAmount = total
+		order.OrderItems = orderItems
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repositories.ErrOrderSlotFull) {
+			return nil, apperrors.Conflict(apperrors.CodeOrderSlotFull, "the kitchen is fully booked at the scheduled time")
 		}
-		orderItems = append(orderItems, orderItem)
+		return nil, err
 	}
 
-	// Create order
-	order := &models.Order{
-		RestaurantID: restaurantID,
-		UserID:       req.UserID,
-		Status:       "pending",
-		TotalAmount:  totalAmount,
-		Notes:        req.Notes,
-		OrderItems:   orderItems,
+	metrics.IncrementOrdersCreated(strconv.FormatUint(uint64(restaurantID), 10), order.Status)
+
+	if s.customers != nil {
+		s.customers.SyncUser(ctx, restaurantID, order.UserID)
+	}
+
+	s.notifications.NotifyNewOrder(ctx, order)
+
+	if s.carts != nil && req.TableSessionID == nil {
+		s.carts.MarkOrdered(ctx, restaurantID, order.UserID, req.CartToken, order.ID)
 	}
 
-	// Set restaurant ID for all order items
-	for i := range order.OrderItems {
-		order.OrderItems[i].RestaurantID = restaurantID
+	// Estimated before the confirmation email, which tells the customer when to expect the order
+	if s.prepTimes != nil {
+		s.prepTimes.TrackOrder(ctx, order)
 	}
 
-	if err := s.orderRepo.CreateWithContext(ctx, order); err != nil {
-		return nil, err
+	// The order stands even if the confirmation email fails
+	if s.receipts != nil {
+		if err := s.receipts.SendOrderConfirmation(ctx, order.ID, restaurantID); err != nil {
+			logger.Warn("Failed to send order confirmation email",
+				zap.Uint("order_id", order.ID),
+				zap.Error(err),
+			)
+		}
 	}
 
 	return order, nil
 }
 
-// UpdateOrderStatusRequest represents order status update request
-type UpdateOrderStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=pending confirmed preparing ready completed cancelled"`
+// NutritionSummary represents aggregated nutrition information for an order
+type NutritionSummary struct {
+	Calories     int     `json:"calories"`
+	ProteinGrams float64 `json:"protein_grams"`
+	CarbsGrams   float64 `json:"carbs_grams"`
+	FatGrams     float64 `json:"fat_grams"`
+	// IsComplete is false when an ordered item is missing any of the nutrients summed here
+	IsComplete bool `json:"is_complete"`
 }
 
-// UpdateOrderStatus updates the status of an order
-func (s *OrderService) UpdateOrderStatus(orderID uint, req *UpdateOrderStatusRequest) (*models.Order, error) {
-	order, err := s.orderRepo.GetByIDWithContext(context.Background(), orderID)
-	if err != nil {
-		return nil, errors.New("order not found")
-	}
+// CalculateNutritionSummary aggregates nutrition values across order items
+// Order items must have their MenuItem relationship loaded
+func CalculateNutritionSummary(items []models.OrderItem) *NutritionSummary {
+	summary := &NutritionSummary{IsComplete: true}
 
-	order.Status = req.Status
+	for _, item := range items {
+		menuItem := item.MenuItem
+		if menuItem.Calories == nil && menuItem.ProteinGrams == nil &&
+			menuItem.CarbsGrams == nil && menuItem.FatGrams == nil {
+			summary.IsComplete = false
+			continue
+		}
 
-	if err := s.orderRepo.UpdateWithContext(context.Background(), order); err != nil {
-		return nil, err
+		quantity := float64(item.Quantity)
+		if menuItem.Calories != nil {
+			summary.Calories += *menuItem.Calories * item.Quantity
+		} else {
+			summary.IsComplete = false
+		}
+		if menuItem.ProteinGrams != nil {
+			summary.ProteinGrams += *menuItem.ProteinGrams * quantity
+		} else {
+			summary.IsComplete = false
+		}
+		if menuItem.CarbsGrams != nil {
+			summary.CarbsGrams += *menuItem.CarbsGrams * quantity
+		} else {
+			summary.IsComplete = false
+		}
+		if menuItem.FatGrams != nil {
+			summary.FatGrams += *menuItem.FatGrams * quantity
+		} else {
+			summary.IsComplete = false
+		}
 	}
 
-	return order, nil
+	return summary
 }
 
-// UpdateOrderStatusWithCtx updates order status using provided context
-func (s *OrderService) UpdateOrderStatusWithCtx(ctx context.Context, orderID uint, req *UpdateOrderStatusRequest) (*models.Order, error) {
-	order, err := s.orderRepo.GetByIDWithContext(ctx, orderID)
+// GetOrderNutritionSummary computes the nutrition summary for an order
+func (s *OrderService) GetOrderNutritionSummary(ctx context.Context, orderID uint, restaurantID uint) (*NutritionSummary, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
 	if err != nil {
-		return nil, errors.New("order not found")
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
 	}
 
-	order.Status = req.Status
-
-	if err := s.orderRepo.UpdateWithContext(ctx, order); err != nil {
-		return nil, err
-	}
-
-	return order, nil
+	return CalculateNutritionSummary(order.OrderItems), nil
 }
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"testing"
+
+	"restaurant-backend/internal/models"
+)
+
+func TestCalculateNutritionSummary(t *testing.T) {
+	calories := 500
+	grams := 10.0
+	complete := models.MenuItem{Calories: &calories, ProteinGrams: &grams, CarbsGrams: &grams, FatGrams: &grams}
+
+	tests := []struct {
+		name     string
+		item     func(m *models.MenuItem)
+		complete bool
+	}{
+		{"all nutrients", func(m *models.MenuItem) {}, true},
+		{"missing calories", func(m *models.MenuItem) { m.Calories = nil }, false},
+		{"missing protein", func(m *models.MenuItem) { m.ProteinGrams = nil }, false},
+		{"missing carbs", func(m *models.MenuItem) { m.CarbsGrams = nil }, false},
+		{"missing fat", func(m *models.MenuItem) { m.FatGrams = nil }, false},
+		{"no nutrients", func(m *models.MenuItem) { *m = models.MenuItem{} }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			partial := complete
+			tt.item(&partial)
+			items := []models.OrderItem{
+				{Quantity: 2, MenuItem: complete},
+				{Quantity: 1, MenuItem: partial},
+			}
+
+			summary := CalculateNutritionSummary(items)
+			if summary.IsComplete != tt.complete {
+				t.Errorf("IsComplete = %v, want %v", summary.IsComplete, tt.complete)
+			}
+			if summary.Calories < 2*calories {
+				t.Errorf("Calories = %d, want at least %d from the complete item", summary.Calories, 2*calories)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// OrderSplitService handles seat assignment and split bills
+// Splits are payment groups of an order that sum exactly to its total; the order itself is unchanged
+type OrderSplitService struct {
+	orderRepo     *repositories.OrderRepository
+	orderItemRepo *repositories.OrderItemRepository
+	splitRepo     *repositories.OrderSplitRepository
+}
+
+// NewOrderSplitService creates a new OrderSplitService instance
+func NewOrderSplitService(
+	orderRepo *repositories.OrderRepository,
+	orderItemRepo *repositories.OrderItemRepository,
+	splitRepo *repositories.OrderSplitRepository,
+) *OrderSplitService {
+	return &OrderSplitService{
+		orderRepo:     orderRepo,
+		orderItemRepo: orderItemRepo,
+		splitRepo:     splitRepo,
+	}
+}
+
+// SeatAssignment assigns an order item to a seat; a nil seat makes the item shared
+type SeatAssignment struct {
+	OrderItemID uint `json:"order_item_id" binding:"required"`
+	Seat        *int `json:"seat" binding:"omitempty,min=1,max=100"`
+}
+
+// AssignSeatsRequest represents a request to assign order items to seats
+type AssignSeatsRequest struct {
+	Seats []SeatAssignment `json:"seats" binding:"required,min=1,dive"`
+}
+
+// SplitGroupRequest is a payment group of items; items listed in several groups are shared evenly
+type SplitGroupRequest struct {
+	Label        string `json:"label" binding:"max=100"`
+	OrderItemIDs []uint `json:"order_item_ids" binding:"required,min=1"`
+}
+
+// SplitOrderRequest represents a request to split an order into payment groups
+// Mode even splits into Parts equal parts, seats into one part per seat (unassigned items
+// are shared by all seats) and items into the given Groups
+type SplitOrderRequest struct {
+	Mode   string              `json:"mode" binding:"required,oneof=even seats items"`
+	Parts  int                 `json:"parts" binding:"omitempty,min=2,max=50"`
+	Groups []SplitGroupRequest `json:"groups" binding:"omitempty,max=50,dive"`
+}
+
+// splitGroup is a payment group being built: its label, seat and the indices of its order items
+type splitGroup struct {
+	label string
+	seat  *int
+	items []int
+}
+
+// AssignSeats assigns order items to seats and returns the updated order
+func (s *OrderSplitService) AssignSeats(ctx context.Context, orderID, restaurantID uint, req *AssignSeatsRequest) (*models.Order, error) {
+	if _, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+
+	seats := make(map[uint]*int, len(req.Seats))
+	for _, assignment := range req.Seats {
+		seats[assignment.OrderItemID] = assignment.Seat
+	}
+	if err := s.orderItemRepo.UpdateSeatsWithContext(ctx, orderID, seats); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "order item does not belong to order")
+		}
+		return nil, err
+	}
+
+	return s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+}
+
+// SplitOrder splits an order into payment groups, replacing its previous splits
+// Orders with paid splits can't be split again
+func (s *OrderSplitService) SplitOrder(ctx context.Context, orderID, restaurantID uint, req *SplitOrderRequest) ([]models.OrderSplit, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+	if order.Status == models.OrderStatusCancelled {
+		return nil, apperrors.Conflict(apperrors.CodeInvalidTransition, "cancelled orders can't be split")
+	}
+
+	items := order.OrderItems
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	var groups []splitGroup
+	switch req.Mode {
+	case models.OrderSplitModeEven:
+		if req.Parts < 2 {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "parts must be at least 2")
+		}
+		for i := 0; i < req.Parts; i++ {
+			groups = append(groups, splitGroup{label: fmt.Sprintf("Part %d", i+1)})
+		}
+	case models.OrderSplitModeSeats:
+		groups, err = seatGroups(items)
+	case models.OrderSplitModeItems:
+		groups, err = itemGroups(items, req.Groups)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	splits := buildSplits(order, items, req.Mode, groups)
+	if err := s.splitRepo.ReplaceForOrderWithContext(ctx, orderID, splits); err != nil {
+		if errors.Is(err, repositories.ErrOrderSplitPaid) {
+			return nil, apperrors.Conflict(apperrors.CodeOrderSplitPaid, "order has paid splits and can't be split again")
+		}
+		return nil, err
+	}
+
+	return splits, nil
+}
+
+// ListSplits lists the payment groups of an order
+func (s *OrderSplitService) ListSplits(ctx context.Context, orderID, restaurantID uint) ([]models.OrderSplit, error) {
+	if _, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+	return s.splitRepo.ListByOrderWithContext(ctx, orderID, restaurantID)
+}
+
+// PaySplit marks a payment group of an order as paid
+func (s *OrderSplitService) PaySplit(ctx context.Context, splitID, orderID, restaurantID uint) ([]models.OrderSplit, error) {
+	if err := s.splitRepo.MarkPaidWithContext(ctx, splitID, orderID, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeOrderSplitNotFound, "unpaid split not found")
+		}
+		return nil, err
+	}
+	return s.splitRepo.ListByOrderWithContext(ctx, orderID, restaurantID)
+}
+
+// seatGroups builds one group per seat, in seat order; unassigned items are shared by all seats
+func seatGroups(items []models.OrderItem) ([]splitGroup, error) {
+	bySeat := make(map[int][]int)
+	var shared []int
+	for i := range items {
+		if items[i].Seat == nil {
+			shared = append(shared, i)
+			continue
+		}
+		bySeat[*items[i].Seat] = append(bySeat[*items[i].Seat], i)
+	}
+	if len(bySeat) == 0 {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "no order items are assigned to seats")
+	}
+
+	seats := make([]int, 0, len(bySeat))
+	for seat := range bySeat {
+		seats = append(seats, seat)
+	}
+	sort.Ints(seats)
+
+	groups := make([]splitGroup, 0, len(seats))
+	for _, seat := range seats {
+		seat := seat
+		groups = append(groups, splitGroup{
+			label: fmt.Sprintf("Seat %d", seat),
+			seat:  &seat,
+			items: append(bySeat[seat], shared...),
+		})
+	}
+	return groups, nil
+}
+
+// itemGroups builds the requested item groups; every order item has to be in at least one group
+func itemGroups(items []models.OrderItem, requests []SplitGroupRequest) ([]splitGroup, error) {
+	if len(requests) < 2 {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "at least 2 groups are required")
+	}
+
+	index := make(map[uint]int, len(items))
+	for i := range items {
+		index[items[i].ID] = i
+	}
+
+	covered := make([]bool, len(items))
+	groups := make([]splitGroup, 0, len(requests))
+	for g, request := range requests {
+		group := splitGroup{label: request.Label}
+		if group.label == "" {
+			group.label = fmt.Sprintf("Group %d", g+1)
+		}
+
+		seen := make(map[uint]bool, len(request.OrderItemIDs))
+		for _, id := range request.OrderItemIDs {
+			i, ok := index[id]
+			if !ok {
+				return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "order item does not belong to order")
+			}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			covered[i] = true
+			group.items = append(group.items, i)
+		}
+		groups = append(groups, group)
+	}
+
+	for i := range covered {
+		if !covered[i] {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "every order item must be in a group")
+		}
+	}
+	return groups, nil
+}
+
+// buildSplits prices the groups so they sum exactly to the order total
+// The total is first allocated to the items by their value, then each item's amount is
+// divided evenly among the groups sharing it; groups without items share the total evenly
+func buildSplits(order *models.Order, items []models.OrderItem, mode string, groups []splitGroup) []models.OrderSplit {
+
+	splits := make([]models.OrderSplit, len(groups))
+	for g, group := range groups {
+		splits[g] = models.OrderSplit{
+			RestaurantID: order.RestaurantID,
+			OrderID:      order.ID,
+			Mode:         mode,
+			Label:        group.label,
+			Seat:         group.seat,
+			Status:       models.OrderSplitStatusPending,
+			Items:        []models.OrderSplitItem{},
+		}
+	}
+
+	if mode == models.OrderSplitModeEven {
+		for g, amount := range allocateCents(order.TotalAmount, equalWeights(len(groups))) {
+			splits[g].Amount = amount
+		}
+		return splits
+	}
+
+	weights := make([]float64, len(items))
+	for i := range items {
+		weights[i] = items[i].Price.Times(items[i].Quantity).Float()
+	}
+	itemAmounts := allocateCents(order.TotalAmount, weights)
+
+	sharers := make([][]int, len(items))
+	for g, group := range groups {
+		for _, i := range group.items {
+			sharers[i] = append(sharers[i], g)
+		}
+	}
+	for i := range items {
+		for k, amount := range allocateCents(itemAmounts[i], equalWeights(len(sharers[i]))) {
+			g := sharers[i][k]
+			splits[g].Amount += amount
+			splits[g].Items = append(splits[g].Items, models.OrderSplitItem{
+				RestaurantID: order.RestaurantID,
+				OrderItemID:  items[i].ID,
+				Amount:       amount,
+			})
+		}
+	}
+	return splits
+}
+
+// allocateCents divides an amount in proportion to weights using the largest remainder method,
+// so the parts always sum to the amount; zero weights split it evenly
+func allocateCents(amount money.Cents, weights []float64) []money.Cents {
+	parts := make([]money.Cents, len(weights))
+	if len(weights) == 0 {
+		return parts
+	}
+
+	var sum float64
+	for _, weight := range weights {
+		sum += weight
+	}
+	if sum <= 0 {
+		weights = equalWeights(len(weights))
+		sum = float64(len(weights))
+	}
+
+	remainders := make([]float64, len(weights))
+	allocated := money.Cents(0)
+	for i, weight := range weights {
+		exact := float64(amount) * weight / sum
+		parts[i] = money.Cents(math.Floor(exact))
+		remainders[i] = exact - float64(parts[i])
+		allocated += parts[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return remainders[order[a]] > remainders[order[b]] })
+	for k := 0; allocated < amount; k++ {
+		parts[order[k%len(order)]]++
+		allocated++
+	}
+	return parts
+}
+
+// equalWeights returns n equal weights
+func equalWeights(n int) []float64 {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
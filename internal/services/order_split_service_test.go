@@ -0,0 +1,75 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"restaurant-backend/internal/money"
+)
+
+func TestAllocateCents(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  money.Cents
+		weights []float64
+		want    []money.Cents
+	}{
+		{"even split", 900, equalWeights(3), []money.Cents{300, 300, 300}},
+		{"one cent left over", 1000, equalWeights(3), []money.Cents{334, 333, 333}},
+		{"two cents left over", 1001, equalWeights(3), []money.Cents{334, 334, 333}},
+		{"fewer cents than parts", 2, equalWeights(3), []money.Cents{1, 1, 0}},
+		{"proportional", 1000, []float64{1, 3}, []money.Cents{250, 750}},
+		{"largest remainder gets the cent", 100, []float64{1, 2}, []money.Cents{33, 67}},
+		{"ties go to the first parts", 100, []float64{1, 1, 1, 4}, []money.Cents{15, 14, 14, 57}},
+		{"uneven weights", 999, []float64{12.5, 7.25, 0.3}, []money.Cents{623, 361, 15}},
+		{"zero weight gets nothing", 500, []float64{2, 0, 3}, []money.Cents{200, 0, 300}},
+		{"all zero weights split evenly", 100, []float64{0, 0, 0}, []money.Cents{34, 33, 33}},
+		{"single part", 1234, []float64{5}, []money.Cents{1234}},
+		{"zero amount", 0, []float64{1, 2}, []money.Cents{0, 0}},
+		{"no parts", 100, nil, []money.Cents{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allocateCents(tt.amount, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allocateCents(%d, %v) = %v, want %v", tt.amount, tt.weights, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocateCentsSumsToAmount(t *testing.T) {
+	weightSets := [][]float64{
+		equalWeights(2),
+		equalWeights(3),
+		equalWeights(7),
+		{1, 2, 3},
+		{0.1, 0.2, 0.7},
+		{3.33, 3.33, 3.34},
+		{1, 0, 0, 0},
+		{0, 0},
+		{19.99, 4.5, 0.01, 7},
+	}
+	amounts := []money.Cents{0, 1, 2, 99, 100, 101, 1001, 99999, 123457}
+
+	for _, weights := range weightSets {
+		for _, amount := range amounts {
+			parts := allocateCents(amount, weights)
+			if len(parts) != len(weights) {
+				t.Fatalf("allocateCents(%d, %v) returned %d parts, want %d", amount, weights, len(parts), len(weights))
+			}
+
+			var sum money.Cents
+			for _, part := range parts {
+				if part < 0 {
+					t.Errorf("allocateCents(%d, %v) = %v has a negative part", amount, weights, parts)
+				}
+				sum += part
+			}
+			if sum != amount {
+				t.Errorf("allocateCents(%d, %v) = %v sums to %d", amount, weights, parts, sum)
+			}
+		}
+	}
+}
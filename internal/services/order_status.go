@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+// orderStatusTransitions lists the statuses an order may move to from each status
+// Completed and cancelled orders are final
+var orderStatusTransitions = map[string][]string{
+	models.OrderStatusPending:   {models.OrderStatusConfirmed, models.OrderStatusCancelled},
+	models.OrderStatusConfirmed: {models.OrderStatusPreparing, models.OrderStatusCancelled},
+	models.OrderStatusPreparing: {models.OrderStatusReady, models.OrderStatusCancelled},
+	models.OrderStatusReady:     {models.OrderStatusCompleted},
+	models.OrderStatusCompleted: {},
+	models.OrderStatusCancelled: {},
+}
+
+// UpdateOrderStatusRequest represents order status update request
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending confirmed preparing ready completed cancelled"`
+}
+
+// canTransitionOrder reports whether an order may move from one status to another
+func canTransitionOrder(from, to string) bool {
+	for _, next := range orderStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidOrderTransition describes a rejected transition and the statuses that are allowed instead
+func invalidOrderTransition(from, to string) error {
+	allowed := orderStatusTransitions[from]
+	if len(allowed) == 0 {
+		return apperrors.Conflict(apperrors.CodeInvalidTransition,
+			fmt.Sprintf("cannot change order status from %s to %s: %s orders are final", from, to, from))
+	}
+	return apperrors.Conflict(apperrors.CodeInvalidTransition,
+		fmt.Sprintf("cannot change order status from %s to %s: allowed next statuses are %v", from, to, allowed))
+}
+
+// UpdateOrderStatusWithCtx moves an order to a new status if the transition is valid
+// The change is recorded in the order's status history together with the user who made it
+func (s *OrderService) UpdateOrderStatusWithCtx(ctx context.Context, orderID, restaurantID, changedBy uint, req *UpdateOrderStatusRequest) (*models.Order, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+
+	if !canTransitionOrder(order.Status, req.Status) {
+		return nil, invalidOrderTransition(order.Status, req.Status)
+	}
+	if req.Status == models.OrderStatusReady {
+		if held := heldCourses(order); len(held) > 0 {
+			return nil, apperrors.Conflict(apperrors.CodeCoursesHeld,
+				fmt.Sprintf("courses %v are still held; fire them before marking the order ready", held))
+		}
+	}
+
+	change := &models.OrderStatusChange{
+		RestaurantID:    order.RestaurantID,
+		OrderID:         order.ID,
+		FromStatus:      order.Status,
+		ToStatus:        req.Status,
+		ChangedByUserID: changedBy,
+	}
+	if err := s.orderRepo.TransitionStatusWithContext(ctx, change); err != nil {
+		if errors.Is(err, repositories.ErrOrderStatusChanged) {
+			return nil, apperrors.Conflict(apperrors.CodeInvalidTransition, "order status was changed by another request, reload and try again")
+		}
+		return nil, err
+	}
+	order.Status = req.Status
+	if order.Status == models.OrderStatusPreparing {
+		order.PrepStartedAt = &change.CreatedAt
+	}
+
+	if s.prepTimes != nil {
+		s.prepTimes.TrackOrder(ctx, order)
+	}
+	if s.customers != nil {
+		s.customers.SyncUser(ctx, order.RestaurantID, order.UserID)
+	}
+	if s.printing != nil {
+		s.printing.AutoPrintOrder(ctx, order)
+	}
+	if order.Status == models.OrderStatusReady {
+		s.sms.NotifyOrderReady(ctx, order)
+	}
+	s.push.NotifyOrderStatus(ctx, order)
+
+	return order, nil
+}
+
+// GetOrderStatusHistory returns the status changes of an order, oldest first
+func (s *OrderService) GetOrderStatusHistory(ctx context.Context, orderID uint, restaurantID uint) ([]models.OrderStatusChange, error) {
+	if _, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+	return s.orderRepo.GetStatusHistoryWithContext(ctx, orderID)
+}
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+)
+
+// OrganizationService handles multi-location organizations
+type OrganizationService struct {
+	organizationRepo *repositories.OrganizationRepository
+	restaurantRepo   *repositories.RestaurantRepository
+	userRepo         *repositories.UserRepository
+	categoryRepo     *repositories.CategoryRepository
+	subscription     *SubscriptionService
+}
+
+// NewOrganizationService creates a new OrganizationService instance
+func NewOrganizationService(
+	organizationRepo *repositories.OrganizationRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	userRepo *repositories.UserRepository,
+	categoryRepo *repositories.CategoryRepository,
+	subscription *SubscriptionService,
+) *OrganizationService {
+	return &OrganizationService{
+		organizationRepo: organizationRepo,
+		restaurantRepo:   restaurantRepo,
+		userRepo:         userRepo,
+		categoryRepo:     categoryRepo,
+		subscription:     subscription,
+	}
+}
+
+// CreateOrganizationRequest represents organization creation request
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// AddLocationRequest represents a new location for an organization
+type AddLocationRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	Address     string `json:"address" binding:"required"`
+	Phone       string `json:"phone" binding:"required"`
+	Email       string `json:"email" binding:"required,email"`
+	CloneMenu   bool   `json:"clone_menu"` // Copy the template location's menu into the new location
+}
+
+// SetTemplateRequest represents the template location update request
+type SetTemplateRequest struct {
+	RestaurantID uint `json:"restaurant_id" binding:"required"`
+}
+
+// SetUserScopeRequest represents the org-scope toggle for a user
+type SetUserScopeRequest struct {
+	OrganizationScoped bool `json:"organization_scoped"`
+}
+
+// OrganizationReport represents cross-location reporting for an organization
+type OrganizationReport struct {
+	Period    string                        `json:"period"`
+	StartDate string                        `json:"start_date"`
+	EndDate   string                        `json:"end_date"`
+	Locations []repositories.LocationReport `json:"locations"`
+	Totals    repositories.LocationReport   `json:"totals"`
+}
+
+// CreateOrganization creates an organization with the caller's restaurant as its first location
+// The caller becomes the owner and an org-scoped user; the first location is the menu template
+func (s *OrganizationService) CreateOrganization(ctx context.Context, req *CreateOrganizationRequest, restaurantID uint, userID uint) (*models.Organization, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+	if restaurant.OrganizationID != nil {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "restaurant already belongs to an organization")
+	}
+
+	organization := &models.Organization{
+		Name:                 req.Name,
+		OwnerUserID:          userID,
+		TemplateRestaurantID: &restaurant.ID,
+	}
+	if err := s.organizationRepo.CreateWithContext(ctx, organization); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	if err := s.restaurantRepo.UpdateOrganizationWithContext(ctx, restaurant.ID, organization.ID); err != nil {
+		return nil, fmt.Errorf("failed to link restaurant to organization: %w", err)
+	}
+
+	if err := s.userRepo.UpdateOrganizationWithContext(ctx, userID, &organization.ID); err != nil {
+		return nil, fmt.Errorf("failed to scope owner to organization: %w", err)
+	}
+
+	return s.organizationRepo.GetByIDWithContext(ctx, organization.ID)
+}
+
+// GetOrganization retrieves an organization with its locations
+func (s *OrganizationService) GetOrganization(ctx context.Context, organizationID uint) (*models.Organization, error) {
+	organization, err := s.organizationRepo.GetByIDWithContext(ctx, organizationID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrganizationNotFound, "organization not found")
+	}
+	return organization, nil
+}
+
+// AddLocation creates a new restaurant location in the organization
+// Locations of an organization are active immediately: the org owner manages them directly
+func (s *OrganizationService) AddLocation(ctx context.Context, req *AddLocationRequest, organizationID uint, userID uint) (*models.Restaurant, error) {
+	organization, err := s.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing, _ := s.restaurantRepo.GetByEmailWithContext(ctx, req.Email); existing != nil {
+		return nil, apperrors.Conflict(apperrors.CodeEmailTaken, "restaurant with this email already exists")
+	}
+
+	now := time.Now()
+	location := &models.Restaurant{
+		Name:           req.Name,
+		Description:    req.Description,
+		Address:        req.Address,
+		Phone:          req.Phone,
+		Email:          req.Email,
+		Status:         models.RestaurantStatusActive,
+		Visibility:     models.RestaurantVisibilityHidden, // Soft launch: staff finish setup before go-live
+		OrganizationID: &organization.ID,
+		ActivatedBy:    &userID,
+		ActivatedAt:    &now,
+	}
+
+	// Inherit the KAM and contact details from the template location
+	if organization.TemplateRestaurantID != nil {
+		if template, err := s.restaurantRepo.GetByIDWithContext(ctx, *organization.TemplateRestaurantID); err == nil {
+			location.KAMID = template.KAMID
+			location.ContactName = template.ContactName
+			location.ContactEmail = template.ContactEmail
+			location.ContactPhone = template.ContactPhone
+		}
+	}
+
+	if err := s.restaurantRepo.CreateWithContext(ctx, location); err != nil {
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+
+	if req.CloneMenu {
+		if _, err := s.CloneTemplateMenu(ctx, organizationID, location.ID); err != nil {
+			return nil, fmt.Errorf("location created but menu clone failed: %w", err)
+		}
+	}
+
+	return location, nil
+}
+
+// SetTemplate sets which location's menu is used as the organization template
+func (s *OrganizationService) SetTemplate(ctx context.Context, organizationID uint, restaurantID uint) (*models.Organization, error) {
+	organization, err := s.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !organizationHasLocation(organization, restaurantID) {
+		return nil, apperrors.NotFound(apperrors.CodeLocationNotFound, "location not found")
+	}
+
+	organization.TemplateRestaurantID = &restaurantID
+	organization.Locations = nil // Avoid re-saving associations
+	if err := s.organizationRepo.UpdateWithContext(ctx, organization); err != nil {
+		return nil, err
+	}
+
+	return s.GetOrganization(ctx, organizationID)
+}
+
+// CloneTemplateMenu copies the template location's menu into another location of the organization
+// Only allowed when the target location has no menu yet and its plan allows the cloned items
+func (s *OrganizationService) CloneTemplateMenu(ctx context.Context, organizationID uint, restaurantID uint) (*MenuCloneResult, error) {
+	organization, err := s.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !organizationHasLocation(organization, restaurantID) {
+		return nil, apperrors.NotFound(apperrors.CodeLocationNotFound, "location not found")
+	}
+	if organization.TemplateRestaurantID == nil {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "organization has no template location")
+	}
+	if *organization.TemplateRestaurantID == restaurantID {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "cannot clone the template location onto itself")
+	}
+
+	count, err := s.categoryRepo.CountByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, apperrors.Conflict(apperrors.CodeMenuNotEmpty, "location already has a menu")
+	}
+
+	counts, err := s.categoryRepo.CloneMenuWithContext(ctx, *organization.TemplateRestaurantID, restaurantID, repositories.MenuCloneOptions{
+		IncludePrices: true,
+		CheckMenuItems: func(count int) error {
+			return s.subscription.CheckCapacity(ctx, restaurantID, PlanResourceMenuItems, int64(count))
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone menu: %w", err)
+	}
+
+	return &MenuCloneResult{
+		SourceRestaurantID: *organization.TemplateRestaurantID,
+		TargetRestaurantID: restaurantID,
+		MenuCloneCounts:    *counts,
+	}, nil
+}
+
+// SetUserScope grants or revokes org-wide access for a user of one of the organization's locations
+func (s *OrganizationService) SetUserScope(ctx context.Context, organizationID uint, userID uint, scoped bool) (*models.User, error) {
+	organization, err := s.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByIDWithContext(ctx, userID)
+	if err != nil || !organizationHasLocation(organization, user.RestaurantID) {
+		return nil, apperrors.NotFound(apperrors.CodeUserNotFound, "user not found")
+	}
+
+	if !scoped && user.ID == organization.OwnerUserID {
+		return nil, apperrors.Forbidden(apperrors.CodeForbidden, "cannot remove organization scope from the owner")
+	}
+
+	var organizationIDPtr *uint
+	if scoped {
+		organizationIDPtr = &organization.ID
+	}
+	if err := s.userRepo.UpdateOrganizationWithContext(ctx, user.ID, organizationIDPtr); err != nil {
+		return nil, err
+	}
+
+	user.OrganizationID = organizationIDPtr
+	user.PasswordHash = ""
+	return user, nil
+}
+
+// GetLocation verifies a location belongs to the organization and returns it
+func (s *OrganizationService) GetLocation(ctx context.Context, organizationID uint, restaurantID uint) (*models.Restaurant, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil || restaurant.OrganizationID == nil || *restaurant.OrganizationID != organizationID {
+		return nil, apperrors.NotFound(apperrors.CodeLocationNotFound, "location not found")
+	}
+	return restaurant, nil
+}
+
+// GetReport returns per-location and total order/reservation figures for a period
+func (s *OrganizationService) GetReport(ctx context.Context, organizationID uint, period string) (*OrganizationReport, error) {
+	startDate, endDate := periodDateRange(period)
+
+	locations, err := s.organizationRepo.GetLocationReportWithContext(ctx, organizationID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location report: %w", err)
+	}
+
+	report := &OrganizationReport{
+		Period:    period,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Locations: locations,
+	}
+	for _, location := range locations {
+		report.Totals.TotalOrders += location.TotalOrders
+		report.Totals.CompletedOrders += location.CompletedOrders
+		report.Totals.CancelledOrders += location.CancelledOrders
+		report.Totals.TotalRevenue += location.TotalRevenue
+		report.Totals.TotalReservations += location.TotalReservations
+	}
+	report.Totals.TotalRevenue = money.Round(report.Totals.TotalRevenue)
+
+	return report, nil
+}
+
+// organizationHasLocation checks whether a restaurant is one of the organization's locations
+func organizationHasLocation(organization *models.Organization, restaurantID uint) bool {
+	for _, location := range organization.Locations {
+		if location.ID == restaurantID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// errBlockedAddress is returned for outbound requests to addresses that aren't on the public internet
+var errBlockedAddress = errors.New("address is not publicly routable")
+
+// blockedPrefixes are the ranges tenant-configured URLs may not reach besides loopback, private, link-local
+// and multicast addresses (net/netip classifies those): "this network", carrier-grade NAT, IETF protocol
+// assignments, documentation/benchmarking ranges and reserved space
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("192.0.2.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("198.51.100.0/24"),
+	netip.MustParsePrefix("203.0.113.0/24"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// isPublicAddress reports whether an IP address is on the public internet, so a tenant-configured URL may
+// reach it; this keeps webhooks away from the instance metadata service (169.254.169.254), localhost and
+// the private network
+func isPublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkPublicURL resolves the host of an http(s) URL and fails if any of its addresses isn't public
+func checkPublicURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Hostname() == "" {
+		return errors.New("must be an http(s) url")
+	}
+
+	if addr, err := netip.ParseAddr(parsed.Hostname()); err == nil {
+		if !isPublicAddress(addr) {
+			return errBlockedAddress
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("host can't be resolved: %w", err)
+	}
+	for _, addr := range addrs {
+		if !isPublicAddress(addr) {
+			return errBlockedAddress
+		}
+	}
+	return nil
+}
+
+// newPublicHTTPClient returns an HTTP client that only connects to public addresses. The check runs on
+// the address actually dialled, after DNS resolution and on every redirect, so a host re-pointed after
+// it was validated (DNS rebinding) is still refused. Proxies are not used, as they would dial for us
+func newPublicHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			if !isPublicAddress(addrPort.Addr()) {
+				return fmt.Errorf("%s: %w", address, errBlockedAddress)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,266 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"restaurant-backend/internal/apperrors"
+	tenantctx "restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// PermissionDefinition describes a capability that can be overridden per staff member
+type PermissionDefinition struct {
+	Permission   string `json:"permission"`
+	Description  string `json:"description"`
+	StaffDefault bool   `json:"staff_default"` // Whether Staff hold it without an override
+}
+
+// permissionCatalog lists the overridable capabilities; the Staff defaults match what the role could do before
+// overrides existed
+var permissionCatalog = []PermissionDefinition{
+	{Permission: models.PermissionChangePrices, Description: "Change menu item prices", StaffDefault: true},
+	{Permission: models.PermissionManagePricingRules, Description: "Create, change and delete pricing rules"},
+	{Permission: models.PermissionExportData, Description: "Export orders, reservations and customers"},
+	{Permission: models.PermissionModerateReviews, Description: "Publish and hide reviews"},
+	{Permission: models.PermissionCloseDay, Description: "Close the day (Z report)"},
+}
+
+// UserPermissionView is a capability as it applies to one user
+type UserPermissionView struct {
+	PermissionDefinition
+	Override *bool `json:"override"` // Set by an Admin; null follows the role's default
+	Granted  bool  `json:"granted"`  // Effective value
+}
+
+// SetPermissionRequest represents a permission override
+type SetPermissionRequest struct {
+	Granted *bool `json:"granted" binding:"required"`
+}
+
+// PermissionService evaluates fine-grained capabilities: Admins hold all of them, Staff the catalog's defaults
+// unless an Admin granted or revoked one for them, Clients and KAMs (outside impersonation) none
+type PermissionService struct {
+	permissionRepo *repositories.UserPermissionRepository
+	userRepo       *repositories.UserRepository
+	auditLogRepo   *repositories.AuditLogRepository
+}
+
+// NewPermissionService creates a new PermissionService instance
+func NewPermissionService(
+	permissionRepo *repositories.UserPermissionRepository,
+	userRepo *repositories.UserRepository,
+	auditLogRepo *repositories.AuditLogRepository,
+) *PermissionService {
+	return &PermissionService{
+		permissionRepo: permissionRepo,
+		userRepo:       userRepo,
+		auditLogRepo:   auditLogRepo,
+	}
+}
+
+// Catalog returns the overridable capabilities
+func (s *PermissionService) Catalog() []PermissionDefinition {
+	return permissionCatalog
+}
+
+// Has reports whether a user with the given role holds a capability
+func (s *PermissionService) Has(ctx context.Context, userID uint, role, permission string) (bool, error) {
+	definition, ok := permissionDefinition(permission)
+	if !ok {
+		return false, fmt.Errorf("unknown permission %q", permission)
+	}
+
+	switch role {
+	case "Admin":
+		return true, nil
+	case "Staff":
+		override, err := s.permissionRepo.GetWithContext(ctx, userID, permission)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return definition.StaffDefault, nil
+			}
+			return false, err
+		}
+		return override.Granted, nil
+	default:
+		return false, nil
+	}
+}
+
+// Require rejects the request's user unless they hold the capability
+func (s *PermissionService) Require(ctx context.Context, permission string) error {
+	userID, _ := tenantctx.GetUserID(ctx)
+	role, ok := tenantctx.GetUserRole(ctx)
+	if !ok {
+		return apperrors.ErrUserContextMissing
+	}
+
+	granted, err := s.Has(ctx, userID, role, permission)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		return apperrors.Forbidden(apperrors.CodeInsufficientScope, "missing permission "+permission)
+	}
+	return nil
+}
+
+// ListMine returns the capabilities of the request's user
+func (s *PermissionService) ListMine(ctx context.Context) ([]UserPermissionView, error) {
+	userID, _ := tenantctx.GetUserID(ctx)
+	role, ok := tenantctx.GetUserRole(ctx)
+	if !ok {
+		return nil, apperrors.ErrUserContextMissing
+	}
+
+	views := make([]UserPermissionView, 0, len(permissionCatalog))
+	for _, definition := range permissionCatalog {
+		granted, err := s.Has(ctx, userID, role, definition.Permission)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, UserPermissionView{PermissionDefinition: definition, Granted: granted})
+	}
+	return views, nil
+}
+
+// ListForUser returns the capabilities of a user of the restaurant with their overrides
+func (s *PermissionService) ListForUser(ctx context.Context, restaurantID, userID uint) ([]UserPermissionView, error) {
+	user, err := s.restaurantUser(ctx, restaurantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides, err := s.permissionRepo.ListByUserWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		return nil, err
+	}
+	byPermission := make(map[string]bool, len(overrides))
+	for _, override := range overrides {
+		byPermission[override.Permission] = override.Granted
+	}
+
+	views := make([]UserPermissionView, 0, len(permissionCatalog))
+	for _, definition := range permissionCatalog {
+		view := UserPermissionView{PermissionDefinition: definition}
+		switch user.Role {
+		case "Admin":
+			view.Granted = true
+		case "Staff":
+			view.Granted = definition.StaffDefault
+			if granted, ok := byPermission[definition.Permission]; ok {
+				view.Override = &granted
+				view.Granted = granted
+			}
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// Set grants or revokes a capability for a staff member of the restaurant, overriding the role's default
+func (s *PermissionService) Set(ctx context.Context, restaurantID, userID uint, permission string, granted bool, actor AuditActor) error {
+	if err := s.checkOverridable(ctx, restaurantID, userID, permission); err != nil {
+		return err
+	}
+
+	if err := s.permissionRepo.UpsertWithContext(ctx, &models.UserPermission{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		Permission:   permission,
+		Granted:      granted,
+		GrantedBy:    actor.UserID,
+	}); err != nil {
+		return fmt.Errorf("failed to save permission: %w", err)
+	}
+	return s.audit(ctx, restaurantID, userID, permission, &granted, actor)
+}
+
+// Reset removes a staff member's override, so the role's default applies again
+func (s *PermissionService) Reset(ctx context.Context, restaurantID, userID uint, permission string, actor AuditActor) error {
+	if err := s.checkOverridable(ctx, restaurantID, userID, permission); err != nil {
+		return err
+	}
+
+	deleted, err := s.permissionRepo.DeleteWithContext(ctx, restaurantID, userID, permission)
+	if err != nil {
+		return fmt.Errorf("failed to delete permission: %w", err)
+	}
+	if !deleted {
+		return nil
+	}
+	return s.audit(ctx, restaurantID, userID, permission, nil, actor)
+}
+
+// checkOverridable verifies the permission exists and the user is a staff member of the restaurant
+func (s *PermissionService) checkOverridable(ctx context.Context, restaurantID, userID uint, permission string) error {
+	if _, ok := permissionDefinition(permission); !ok {
+		return apperrors.NotFound(apperrors.CodePermissionNotFound, "permission not found")
+	}
+	user, err := s.restaurantUser(ctx, restaurantID, userID)
+	if err != nil {
+		return err
+	}
+	if user.Role != "Staff" {
+		return apperrors.BadRequest(apperrors.CodeInvalidRole, "permissions can only be overridden for staff members")
+	}
+	return nil
+}
+
+// restaurantUser loads a user of the restaurant
+func (s *PermissionService) restaurantUser(ctx context.Context, restaurantID, userID uint) (*models.User, error) {
+	user, err := s.userRepo.GetByIDWithContext(ctx, userID)
+	if err != nil || user.RestaurantID != restaurantID {
+		return nil, apperrors.NotFound(apperrors.CodeUserNotFound, "user not found")
+	}
+	return user, nil
+}
+
+// audit records a permission change; granted is nil when the override was removed
+func (s *PermissionService) audit(ctx context.Context, restaurantID, userID uint, permission string, granted *bool, actor AuditActor) error {
+	details, err := json.Marshal(map[string]interface{}{
+		"user_id":    userID,
+		"permission": permission,
+		"granted":    granted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode audit details: %w", err)
+	}
+	if err := s.auditLogRepo.CreateWithContext(ctx, &models.AuditLog{
+		RestaurantID: &restaurantID,
+		ActorUserID:  actor.UserID,
+		ActorRole:    actor.Role,
+		Action:       models.AuditActionPermissionChange,
+		Details:      string(details),
+		IPAddress:    actor.IPAddress,
+		UserAgent:    actor.UserAgent,
+	}); err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+
+	logger.Info("User permission changed",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.Uint("user_id", userID),
+		zap.String("permission", permission),
+		zap.Uint("actor_user_id", actor.UserID),
+	)
+	return nil
+}
+
+// permissionDefinition looks up a capability in the catalog
+func permissionDefinition(permission string) (PermissionDefinition, bool) {
+	for _, definition := range permissionCatalog {
+		if definition.Permission == permission {
+			return definition, true
+		}
+	}
+	return PermissionDefinition{}, false
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	platformAnalyticsDefaultDays   = 30
+	platformAnalyticsMaxDays       = 366
+	platformAnalyticsDefaultMonths = 12
+	platformAnalyticsMaxMonths     = 36
+)
+
+// PlatformAnalyticsService provides platform-wide analytics across all tenants for platform KAMs
+// Figures are in UTC; GMV is reported per currency since restaurants don't share one
+type PlatformAnalyticsService struct {
+	reportingRepo *repositories.PlatformReportingRepository
+	userRepo      *repositories.UserRepository
+}
+
+// NewPlatformAnalyticsService creates a new PlatformAnalyticsService instance
+func NewPlatformAnalyticsService(
+	reportingRepo *repositories.PlatformReportingRepository,
+	userRepo *repositories.UserRepository,
+) *PlatformAnalyticsService {
+	return &PlatformAnalyticsService{
+		reportingRepo: reportingRepo,
+		userRepo:      userRepo,
+	}
+}
+
+// PlatformStats is the platform's activity over a period
+type PlatformStats struct {
+	OrderCount             int64            `json:"order_count"`
+	CompletedOrders        int64            `json:"completed_orders"`
+	GMV                    []CurrencyAmount `json:"gmv"` // Total of completed orders, per currency
+	TransactingRestaurants int64            `json:"transacting_restaurants"`
+	NewRestaurants         int64            `json:"new_restaurants"`
+}
+
+// CurrencyAmount is an amount in one currency
+type CurrencyAmount struct {
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount"`
+}
+
+// PlatformGrowth is the change of each figure against the previous period, in percent;
+// nil when the previous period had none
+type PlatformGrowth struct {
+	Orders                 *float64            `json:"orders"`
+	GMV                    map[string]*float64 `json:"gmv"` // By currency
+	TransactingRestaurants *float64            `json:"transacting_restaurants"`
+	NewRestaurants         *float64            `json:"new_restaurants"`
+}
+
+// PlatformOverview summarizes the platform over a date range, compared with the range before it
+type PlatformOverview struct {
+	From              string          `json:"from"`
+	To                string          `json:"to"`
+	ActiveRestaurants int64           `json:"active_restaurants"` // Currently active
+	Current           PlatformStats   `json:"current"`
+	Previous          PlatformStats   `json:"previous"` // The same number of days before From
+	Growth            PlatformGrowth  `json:"growth"`
+	Daily             []PlatformPoint `json:"daily"` // Every day of the range
+}
+
+// PlatformPoint is the platform's activity in one day or month
+type PlatformPoint struct {
+	Period string          `json:"period"` // YYYY-MM-DD for days, YYYY-MM for months
+	Stats  PlatformStats   `json:"stats"`
+	Growth *PlatformGrowth `json:"growth,omitempty"` // Against the month before; months only
+}
+
+// PlatformTrends is the platform's month-over-month growth
+type PlatformTrends struct {
+	Months []PlatformPoint `json:"months"` // Oldest first, ending with the current (partial) month
+}
+
+// GetOverview returns GMV, orders and restaurants across all tenants for the days from..to
+// (YYYY-MM-DD, inclusive; the last 30 days by default) with the daily series
+func (s *PlatformAnalyticsService) GetOverview(ctx context.Context, userID uint, from, to string) (*PlatformOverview, error) {
+	if err := s.requirePlatformKAM(ctx, userID); err != nil {
+		return nil, err
+	}
+	start, end, err := dateRangeIn(time.UTC, from, to, platformAnalyticsDefaultDays, platformAnalyticsMaxDays)
+	if err != nil {
+		return nil, err
+	}
+	days := int(end.Sub(start).Hours() / 24)
+	previousStart := start.AddDate(0, 0, -days)
+
+	var current, previous []repositories.PlatformOrderBucket
+	var daily []repositories.PlatformOrderBucket
+	var signups []repositories.PlatformSignupBucket
+	var active int64
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		if current, err = s.reportingRepo.SumOrdersWithContext(gctx, start, end); err != nil {
+			return fmt.Errorf("failed to sum orders: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if previous, err = s.reportingRepo.SumOrdersWithContext(gctx, previousStart, start); err != nil {
+			return fmt.Errorf("failed to sum previous orders: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if daily, err = s.reportingRepo.ListOrderBucketsWithContext(gctx, repositories.PlatformBucketDay, start, end); err != nil {
+			return fmt.Errorf("failed to get daily orders: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if signups, err = s.reportingRepo.ListSignupBucketsWithContext(gctx, repositories.PlatformBucketDay, previousStart, end); err != nil {
+			return fmt.Errorf("failed to get restaurant signups: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if active, err = s.reportingRepo.CountActiveRestaurantsWithContext(gctx); err != nil {
+			return fmt.Errorf("failed to count active restaurants: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	overview := &PlatformOverview{
+		From:              start.Format(time.DateOnly),
+		To:                end.AddDate(0, 0, -1).Format(time.DateOnly),
+		ActiveRestaurants: active,
+		Current:           platformStats(current),
+		Previous:          platformStats(previous),
+	}
+	for _, signup := range signups {
+		if signup.Bucket.Before(start) {
+			overview.Previous.NewRestaurants += signup.Count
+		} else {
+			overview.Current.NewRestaurants += signup.Count
+		}
+	}
+	overview.Growth = platformGrowth(overview.Current, overview.Previous)
+	overview.Daily = platformSeries(daily, signups, start, end, time.DateOnly, func(t time.Time) time.Time {
+		return t.AddDate(0, 0, 1)
+	})
+	return overview, nil
+}
+
+// GetTrends returns the platform's activity per month for the last months (12 by default), including
+// the current month so far, each compared with the month before
+func (s *PlatformAnalyticsService) GetTrends(ctx context.Context, userID uint, months int) (*PlatformTrends, error) {
+	if err := s.requirePlatformKAM(ctx, userID); err != nil {
+		return nil, err
+	}
+	if months <= 0 {
+		months = platformAnalyticsDefaultMonths
+	}
+	if months > platformAnalyticsMaxMonths {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, fmt.Sprintf("months must be at most %d", platformAnalyticsMaxMonths))
+	}
+
+	now := time.Now().UTC()
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+	// One extra month so the first month has a growth figure
+	start := end.AddDate(0, -months-1, 0)
+
+	var orders []repositories.PlatformOrderBucket
+	var signups []repositories.PlatformSignupBucket
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		var err error
+		if orders, err = s.reportingRepo.ListOrderBucketsWithContext(gctx, repositories.PlatformBucketMonth, start, end); err != nil {
+			return fmt.Errorf("failed to get monthly orders: %w", err)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		var err error
+		if signups, err = s.reportingRepo.ListSignupBucketsWithContext(gctx, repositories.PlatformBucketMonth, start, end); err != nil {
+			return fmt.Errorf("failed to get monthly signups: %w", err)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	series := platformSeries(orders, signups, start, end, "2006-01", func(t time.Time) time.Time {
+		return t.AddDate(0, 1, 0)
+	})
+	for i := 1; i < len(series); i++ {
+		growth := platformGrowth(series[i].Stats, series[i-1].Stats)
+		series[i].Growth = &growth
+	}
+	return &PlatformTrends{Months: series[1:]}, nil
+}
+
+// requirePlatformKAM checks the user is an active KAM of the platform organization
+// The routes already require the KAM role; this also rules out tokens of any tenant
+func (s *PlatformAnalyticsService) requirePlatformKAM(ctx context.Context, userID uint) error {
+	user, err := s.userRepo.GetByIDWithContext(ctx, userID)
+	if err != nil {
+		return apperrors.Forbidden(apperrors.CodeInsufficientScope, "user not found")
+	}
+	if !user.IsPlatformUser() || !user.IsKAM() || !user.IsActive {
+		return apperrors.Forbidden(apperrors.CodeInsufficientScope, "only platform KAMs can view platform analytics")
+	}
+	return nil
+}
+
+// platformStats adds up per-currency order aggregates
+func platformStats(buckets []repositories.PlatformOrderBucket) PlatformStats {
+	stats := PlatformStats{GMV: []CurrencyAmount{}}
+	for _, bucket := range buckets {
+		stats.OrderCount += bucket.OrderCount
+		stats.CompletedOrders += bucket.CompletedOrders
+		stats.TransactingRestaurants += bucket.TransactingRestaurants
+		stats.GMV = append(stats.GMV, CurrencyAmount{
+			Currency: bucket.Currency,
+			Amount:   money.Round(bucket.GMV),
+		})
+	}
+	return stats
+}
+
+// platformSeries lays out the buckets as one point per period in [start, end), including empty ones
+func platformSeries(
+	orders []repositories.PlatformOrderBucket,
+	signups []repositories.PlatformSignupBucket,
+	start, end time.Time,
+	layout string,
+	next func(time.Time) time.Time,
+) []PlatformPoint {
+	ordersByPeriod := make(map[string][]repositories.PlatformOrderBucket)
+	for _, bucket := range orders {
+		period := bucket.Bucket.Format(layout)
+		ordersByPeriod[period] = append(ordersByPeriod[period], bucket)
+	}
+	signupsByPeriod := make(map[string]int64)
+	for _, bucket := range signups {
+		signupsByPeriod[bucket.Bucket.Format(layout)] += bucket.Count
+	}
+
+	var series []PlatformPoint
+	for t := start; t.Before(end); t = next(t) {
+		period := t.Format(layout)
+		stats := platformStats(ordersByPeriod[period])
+		stats.NewRestaurants = signupsByPeriod[period]
+		series = append(series, PlatformPoint{Period: period, Stats: stats})
+	}
+	return series
+}
+
+// platformGrowth compares two periods
+func platformGrowth(current, previous PlatformStats) PlatformGrowth {
+	growth := PlatformGrowth{
+		Orders:                 growthPercent(float64(current.OrderCount), float64(previous.OrderCount)),
+		GMV:                    make(map[string]*float64),
+		TransactingRestaurants: growthPercent(float64(current.TransactingRestaurants), float64(previous.TransactingRestaurants)),
+		NewRestaurants:         growthPercent(float64(current.NewRestaurants), float64(previous.NewRestaurants)),
+	}
+	previousGMV := make(map[string]float64)
+	for _, amount := range previous.GMV {
+		previousGMV[amount.Currency] = amount.Amount
+	}
+	for _, amount := range current.GMV {
+		growth.GMV[amount.Currency] = growthPercent(amount.Amount, previousGMV[amount.Currency])
+	}
+	return growth
+}
+
+// growthPercent returns the change from previous to current in percent, rounded to 2 decimals,
+// nil when previous is zero
+func growthPercent(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	percent := math.Round((current-previous)*10000/previous) / 100
+	return &percent
+}
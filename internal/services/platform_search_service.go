@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+const (
+	platformSearchMinLength    = 3
+	platformSearchDefaultLimit = 20
+	platformSearchMaxLimit     = 50
+)
+
+// PlatformSearchService provides cross-tenant lookups for KAM support staff
+type PlatformSearchService struct {
+	restaurantRepo *repositories.RestaurantRepository
+	userRepo       *repositories.UserRepository
+	orderRepo      *repositories.OrderRepository
+	auditLogRepo   *repositories.AuditLogRepository
+}
+
+// NewPlatformSearchService creates a new PlatformSearchService instance
+func NewPlatformSearchService(
+	restaurantRepo *repositories.RestaurantRepository,
+	userRepo *repositories.UserRepository,
+	orderRepo *repositories.OrderRepository,
+	auditLogRepo *repositories.AuditLogRepository,
+) *PlatformSearchService {
+	return &PlatformSearchService{
+		restaurantRepo: restaurantRepo,
+		userRepo:       userRepo,
+		orderRepo:      orderRepo,
+		auditLogRepo:   auditLogRepo,
+	}
+}
+
+// PlatformSearchRequest represents a platform-wide search request
+type PlatformSearchRequest struct {
+	Query string `form:"q" binding:"required"`
+	Limit int    `form:"limit" binding:"omitempty,min=1"`
+}
+
+// SearchActor identifies who performed a search (for audit logging)
+type SearchActor struct {
+	UserID    uint
+	IPAddress string
+	UserAgent string
+}
+
+// PlatformSearchResult groups search matches by entity type
+type PlatformSearchResult struct {
+	Query       string                           `json:"query"`
+	Restaurants []models.Restaurant              `json:"restaurants"`
+	Users       []models.User                    `json:"users"`
+	Orders      []repositories.OrderSearchResult `json:"orders"`
+}
+
+// Search finds restaurants, users and orders across all tenants
+// Only platform KAMs may search; every search is recorded in the audit log
+func (s *PlatformSearchService) Search(ctx context.Context, req *PlatformSearchRequest, actor SearchActor) (*PlatformSearchResult, error) {
+	// Strict role check: middleware ensures the role, but we also require the
+	// user to belong to the platform organization (restaurant Admins cannot search)
+	user, err := s.userRepo.GetByIDWithContext(ctx, actor.UserID)
+	if err != nil {
+		return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "user not found")
+	}
+	if !user.IsPlatformUser() || !user.IsKAM() || !user.IsActive {
+		return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "only platform KAMs can search across tenants")
+	}
+
+	term := strings.TrimSpace(req.Query)
+
+	// Order numbers may be entered as "123" or "#123", and are searched at any length
+	var orderID *uint
+	if id, err := strconv.ParseUint(strings.TrimPrefix(term, "#"), 10, 64); err == nil {
+		parsed := uint(id)
+		orderID = &parsed
+	}
+	if orderID == nil && len(term) < platformSearchMinLength {
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, fmt.Sprintf("query must be at least %d characters", platformSearchMinLength))
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = platformSearchDefaultLimit
+	}
+	if limit > platformSearchMaxLimit {
+		limit = platformSearchMaxLimit
+	}
+
+	// Audit before reading any tenant data; refuse to search if it can't be recorded
+	details, _ := json.Marshal(map[string]interface{}{
+		"query": term,
+		"limit": limit,
+	})
+	if err := s.auditLogRepo.CreateWithContext(ctx, &models.AuditLog{
+		ActorUserID: user.ID,
+		ActorRole:   user.Role,
+		Action:      models.AuditActionPlatformSearch,
+		Details:     string(details),
+		IPAddress:   actor.IPAddress,
+		UserAgent:   actor.UserAgent,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record audit log: %w", err)
+	}
+
+	result := &PlatformSearchResult{Query: term}
+
+	result.Restaurants, err = s.restaurantRepo.SearchWithContext(ctx, term, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search restaurants: %w", err)
+	}
+
+	result.Users, err = s.userRepo.SearchWithContext(ctx, term, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	result.Orders, err = s.orderRepo.SearchWithContext(ctx, orderID, term, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search orders: %w", err)
+	}
+
+	return result, nil
+}
@@ -1,9 +1,9 @@
 package services
 
 import (
-	"errors"
 	"fmt"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
 )
@@ -62,17 +62,17 @@ func (s *PlatformService) CreateKAM(req *CreateKAMRequest, createdBy uint) (*mod
 	// Verify creator is KAM or Admin from platform organization
 	creator, err := s.userRepo.GetByID(createdBy)
 	if err != nil {
-		return nil, errors.New("creator user not found")
+		return nil, apperrors.NotFound(apperrors.CodeUserNotFound, "creator user not found")
 	}
 
 	if !creator.IsPlatformUser() || (creator.Role != "KAM" && creator.Role != "Admin") {
-		return nil, errors.New("only platform KAMs or Admins can create new KAM users")
+		return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "only platform KAMs or Admins can create new KAM users")
 	}
 
 	// Check if user already exists
 	existing, _ := s.userRepo.GetByEmail(req.Email, models.PlatformOrganizationID)
 	if existing != nil {
-		return nil, errors.New("user with this email already exists")
+		return nil, apperrors.Conflict(apperrors.CodeEmailTaken, "user with this email already exists")
 	}
 
 	// Create KAM user in platform organization
@@ -94,16 +94,16 @@ func (s *PlatformService) CreateKAM(req *CreateKAMRequest, createdBy uint) (*mod
 func (s *PlatformService) CreateKAMUser(user *models.User) error {
 	// Verify this is a KAM user for platform organization
 	if user.RestaurantID != models.PlatformOrganizationID {
-		return errors.New("KAM users must belong to platform organization")
+		return apperrors.BadRequest(apperrors.CodeInvalidRole, "KAM users must belong to platform organization")
 	}
 	if user.Role != "KAM" {
-		return errors.New("only KAM role allowed for platform organization")
+		return apperrors.BadRequest(apperrors.CodeInvalidRole, "only KAM role allowed for platform organization")
 	}
 
 	// Check if user already exists
 	existing, _ := s.userRepo.GetByEmail(user.Email, models.PlatformOrganizationID)
 	if existing != nil {
-		return errors.New("user with this email already exists")
+		return apperrors.Conflict(apperrors.CodeEmailTaken, "user with this email already exists")
 	}
 
 	// Create user via repository
@@ -0,0 +1,316 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Prep time estimation
+const (
+	defaultItemPrepTime  = 10 * time.Minute // Prep time of items the kitchen hasn't prepared yet
+	extraPortionPrepTime = 30 * time.Second // Added for each portion of an item beyond the first
+	kitchenParallelism   = 3                // Orders the kitchen prepares at the same time
+	prepLearningRate     = 0.2              // Weight of a new sample in the learned averages
+	maxPrepSample        = 2 * time.Hour    // Longer preps are orders left open, not kitchen speed
+	minKitchenLoadFactor = 0.25
+	maxKitchenLoadFactor = 4.0
+)
+
+// prepQueueStatuses are the statuses of orders waiting for or in preparation
+var prepQueueStatuses = []string{models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusPreparing}
+
+// PrepTimeService estimates when orders will be ready from the kitchen queue, the items' average prep times
+// and how busy the kitchen is at that hour, and learns the averages from orders as they are marked ready
+type PrepTimeService struct {
+	orderRepo    *repositories.OrderRepository
+	menuItemRepo *repositories.MenuItemRepository
+	loadRepo     *repositories.KitchenLoadRepository
+	settingsRepo *repositories.RestaurantSettingsRepository
+}
+
+// NewPrepTimeService creates a new PrepTimeService instance
+func NewPrepTimeService(
+	orderRepo *repositories.OrderRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	loadRepo *repositories.KitchenLoadRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+) *PrepTimeService {
+	return &PrepTimeService{
+		orderRepo:    orderRepo,
+		menuItemRepo: menuItemRepo,
+		loadRepo:     loadRepo,
+		settingsRepo: settingsRepo,
+	}
+}
+
+// OrderingStatusKitchenBusy is the reason given while the kitchen is over capacity
+const OrderingStatusKitchenBusy = "kitchen_busy"
+
+// OrderingStatus tells storefronts whether a restaurant takes online orders right now
+type OrderingStatus struct {
+	AcceptingOrders bool   `json:"accepting_orders"` // False while public online ordering is paused
+	Reason          string `json:"reason,omitempty"` // Why ordering is paused or delayed
+	DelayMinutes    int    `json:"delay_minutes"`    // Added to the promised time of orders taken now
+}
+
+// OrderingStatus checks a restaurant's kitchen queue against its capacity settings
+// Over capacity, new orders are either delayed or public online ordering pauses, as the restaurant chose
+func (s *PrepTimeService) OrderingStatus(ctx context.Context, restaurantID uint) (*OrderingStatus, error) {
+	status := &OrderingStatus{AcceptingOrders: true}
+
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if settings.KitchenMaxOpenOrders <= 0 && settings.KitchenMaxItemsInFlight <= 0 {
+		return status, nil
+	}
+
+	load, err := s.orderRepo.GetKitchenLoadWithContext(ctx, restaurantID, prepQueueStatuses, time.Now().Add(scheduledOrderFireLead))
+	if err != nil {
+		return nil, err
+	}
+	overloaded := (settings.KitchenMaxOpenOrders > 0 && load.OpenOrders >= int64(settings.KitchenMaxOpenOrders)) ||
+		(settings.KitchenMaxItemsInFlight > 0 && load.ItemsInFlight >= int64(settings.KitchenMaxItemsInFlight))
+	if !overloaded {
+		return status, nil
+	}
+
+	status.Reason = OrderingStatusKitchenBusy
+	if settings.KitchenOverloadAction == models.KitchenOverloadPause {
+		status.AcceptingOrders = false
+	} else {
+		status.DelayMinutes = settings.KitchenOverloadDelayMinutes
+	}
+	return status, nil
+}
+
+// TrackOrder updates the estimates after an order was placed or changed status, and copies the order's new estimate onto it
+// Ready orders first teach the estimator how long they took; a failure is logged and never fails the order
+func (s *PrepTimeService) TrackOrder(ctx context.Context, order *models.Order) {
+	location := s.location(ctx, order.RestaurantID)
+
+	if order.Status == models.OrderStatusReady {
+		if order.PrepStartedAt != nil {
+			if err := s.learn(ctx, order, location); err != nil {
+				logger.Warn("Failed to learn order prep time", zap.Uint("order_id", order.ID), zap.Error(err))
+			}
+		}
+		now := time.Now()
+		order.EstimatedPrepMinutes = 0
+		order.EstimatedReadyAt = &now
+		if err := s.orderRepo.UpdatePrepEstimatesWithContext(ctx, []models.Order{*order}); err != nil {
+			logger.Warn("Failed to store order prep estimate", zap.Uint("order_id", order.ID), zap.Error(err))
+		}
+	}
+
+	orders, err := s.refresh(ctx, order.RestaurantID, location)
+	if err != nil {
+		logger.Warn("Failed to refresh kitchen prep estimates", zap.Uint("restaurant_id", order.RestaurantID), zap.Error(err))
+		return
+	}
+	for i := range orders {
+		if orders[i].ID == order.ID {
+			order.EstimatedPrepMinutes = orders[i].EstimatedPrepMinutes
+			order.EstimatedReadyAt = orders[i].EstimatedReadyAt
+		}
+	}
+}
+
+// Refresh re-estimates the orders of a restaurant's kitchen queue, e.g. after orders were imported
+func (s *PrepTimeService) Refresh(ctx context.Context, restaurantID uint) error {
+	_, err := s.refresh(ctx, restaurantID, s.location(ctx, restaurantID))
+	return err
+}
+
+// refresh estimates the kitchen queue by handing its orders, oldest first, to the earliest free of the kitchen's stations
+// Orders in preparation keep their station until their own estimate; scheduled orders don't start before they are due,
+// nor orders taken while the kitchen was over capacity before their throttle delay
+// Scheduled orders are estimated once they enter the kitchen queue (scheduledOrderFireLead before pickup)
+func (s *PrepTimeService) refresh(ctx context.Context, restaurantID uint, location *time.Location) ([]models.Order, error) {
+	now := time.Now()
+	orders, err := s.orderRepo.GetKitchenOrdersWithContext(ctx, restaurantID, prepQueueStatuses, now.Add(scheduledOrderFireLead))
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return orders, nil
+	}
+	factors, err := s.loadFactors(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders already in preparation occupy their stations first
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].Status == models.OrderStatusPreparing && orders[j].Status != models.OrderStatusPreparing
+	})
+
+	stations := make([]time.Time, kitchenParallelism)
+	for i := range stations {
+		stations[i] = now
+	}
+	for i := range orders {
+		order := &orders[i]
+		hour := now.In(location).Hour()
+		if order.ScheduledFor != nil && order.ScheduledFor.After(now) {
+			hour = order.ScheduledFor.In(location).Hour()
+		}
+		cook := time.Duration(float64(orderPrepTime(order)) * loadFactor(factors, hour))
+
+		station := 0
+		for j := range stations {
+			if stations[j].Before(stations[station]) {
+				station = j
+			}
+		}
+
+		var ready time.Time
+		if order.Status == models.OrderStatusPreparing && order.PrepStartedAt != nil {
+			ready = order.PrepStartedAt.Add(cook)
+			if ready.Before(now) {
+				ready = now // Overdue orders are expected any minute
+			}
+		} else {
+			start := stations[station]
+			if order.ScheduledFor != nil && order.ScheduledFor.Add(-cook).After(start) {
+				start = order.ScheduledFor.Add(-cook)
+			}
+			if delayed := order.CreatedAt.Add(time.Duration(order.ThrottleDelayMinutes) * time.Minute); delayed.After(start) {
+				start = delayed
+			}
+			ready = start.Add(cook)
+		}
+		stations[station] = ready
+
+		minutes := int(math.Ceil(ready.Sub(now).Minutes()))
+		if minutes < 1 {
+			minutes = 1
+		}
+		order.EstimatedPrepMinutes = minutes
+		order.EstimatedReadyAt = &ready
+	}
+
+	if err := s.orderRepo.UpdatePrepEstimatesWithContext(ctx, orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// learn folds the measured prep time of a ready order into the load factor of the hour it was started in
+// and into the average prep time of its longest item, which the order waited on
+func (s *PrepTimeService) learn(ctx context.Context, order *models.Order, location *time.Location) error {
+	actual := time.Since(*order.PrepStartedAt)
+	if actual <= 0 || actual > maxPrepSample || len(order.OrderItems) == 0 {
+		return nil
+	}
+
+	factors, err := s.loadFactors(ctx, order.RestaurantID)
+	if err != nil {
+		return err
+	}
+	hour := order.PrepStartedAt.In(location).Hour()
+	factor := loadFactor(factors, hour)
+
+	ratio := math.Min(math.Max(actual.Seconds()/orderPrepTime(order).Seconds(), minKitchenLoadFactor), maxKitchenLoadFactor)
+	if err := s.loadRepo.RecordSampleWithContext(ctx, order.RestaurantID, hour, ratio, prepLearningRate); err != nil {
+		return err
+	}
+
+	// The slowest item sets the order's prep time; its sample is the measured time without the hour's load and extra portions
+	var slowest *models.OrderItem
+	for i := range order.OrderItems {
+		if slowest == nil || itemPrepTime(&order.OrderItems[i]) > itemPrepTime(slowest) {
+			slowest = &order.OrderItems[i]
+		}
+	}
+	sample := time.Duration(float64(actual)/factor) - time.Duration(slowest.Quantity-1)*extraPortionPrepTime
+	if sample < time.Minute {
+		sample = time.Minute
+	}
+	return s.menuItemRepo.RecordPrepTimeWithContext(ctx, slowest.MenuItemID, int(sample.Seconds()), prepLearningRate)
+}
+
+// loadFactors returns the learned load factors of a restaurant by hour
+func (s *PrepTimeService) loadFactors(ctx context.Context, restaurantID uint) (map[int]float64, error) {
+	rows, err := s.loadRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	factors := make(map[int]float64, len(rows))
+	for _, row := range rows {
+		factors[row.Hour] = row.Factor
+	}
+	return factors, nil
+}
+
+// settings returns a restaurant's settings, or the defaults if none are saved
+func (s *PrepTimeService) settings(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+	return settings, nil
+}
+
+// location returns the restaurant's time zone, UTC when unknown
+func (s *PrepTimeService) location(ctx context.Context, restaurantID uint) *time.Location {
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		logger.Warn("Failed to load restaurant settings", zap.Uint("restaurant_id", restaurantID), zap.Error(err))
+		return time.UTC
+	}
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// loadFactor returns the load factor of an hour, 1 until one was learned
+func loadFactor(factors map[int]float64, hour int) float64 {
+	if factor, ok := factors[hour]; ok && factor > 0 {
+		return factor
+	}
+	return 1
+}
+
+// orderPrepTime is how long an order takes without load: its items are prepared in parallel, so the slowest one counts
+// Order items must have their MenuItem relationship loaded
+func orderPrepTime(order *models.Order) time.Duration {
+	longest := defaultItemPrepTime
+	if len(order.OrderItems) > 0 {
+		longest = 0
+	}
+	for i := range order.OrderItems {
+		if prep := itemPrepTime(&order.OrderItems[i]); prep > longest {
+			longest = prep
+		}
+	}
+	return longest
+}
+
+// itemPrepTime is the learned prep time of an order item's menu item plus its extra portions
+func itemPrepTime(item *models.OrderItem) time.Duration {
+	prep := defaultItemPrepTime
+	if item.MenuItem.AvgPrepSeconds > 0 {
+		prep = time.Duration(item.MenuItem.AvgPrepSeconds) * time.Second
+	}
+	if item.Quantity > 1 {
+		prep += time.Duration(item.Quantity-1) * extraPortionPrepTime
+	}
+	return prep
+}
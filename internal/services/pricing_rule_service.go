@@ -0,0 +1,303 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// PricingRuleService manages time-based pricing rules (happy hours) and prices menu items with them
+// Menu responses and orders are priced by the same rules, so displayed prices match charged prices
+type PricingRuleService struct {
+	ruleRepo        *repositories.PricingRuleRepository
+	categoryRepo    *repositories.CategoryRepository
+	menuItemRepo    *repositories.MenuItemRepository
+	settingsRepo    *repositories.RestaurantSettingsRepository
+	priceChangeRepo *repositories.MenuItemPriceChangeRepository
+}
+
+// NewPricingRuleService creates a new PricingRuleService instance
+func NewPricingRuleService(
+	ruleRepo *repositories.PricingRuleRepository,
+	categoryRepo *repositories.CategoryRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+	priceChangeRepo *repositories.MenuItemPriceChangeRepository,
+) *PricingRuleService {
+	return &PricingRuleService{
+		ruleRepo:        ruleRepo,
+		categoryRepo:    categoryRepo,
+		menuItemRepo:    menuItemRepo,
+		settingsRepo:    settingsRepo,
+		priceChangeRepo: priceChangeRepo,
+	}
+}
+
+// PricingRuleRequest represents a pricing rule create or update request
+// Category rules need a category_id, item rules a menu_item_id; percent_off values are percentages
+type PricingRuleRequest struct {
+	Name       string  `json:"name" binding:"required,max=100"`
+	Weekdays   []int   `json:"weekdays" binding:"max=7,dive,min=0,max=6"`
+	StartsAt   string  `json:"starts_at" binding:"required,len=5"`
+	EndsAt     string  `json:"ends_at" binding:"required,len=5"`
+	Scope      string  `json:"scope" binding:"required,oneof=all category item"`
+	CategoryID *uint   `json:"category_id"`
+	MenuItemID *uint   `json:"menu_item_id"`
+	Kind       string  `json:"kind" binding:"required,oneof=percent_off amount_off fixed_price"`
+	Value      float64 `json:"value" binding:"min=0"`
+	IsActive   *bool   `json:"is_active"`
+}
+
+// MenuPricer prices menu items with the prices and pricing rules in effect at one point in time
+// Scheduled price changes that are due count even before the scheduler has written them to the menu items
+type MenuPricer struct {
+	rules     []models.PricingRule
+	local     time.Time
+	scheduled map[uint][]models.MenuItemPriceChange // Due price changes per menu item, oldest first
+}
+
+// ListRules lists a restaurant's pricing rules
+func (s *PricingRuleService) ListRules(ctx context.Context, restaurantID uint) ([]models.PricingRule, error) {
+	return s.ruleRepo.GetByRestaurantIDWithContext(ctx, restaurantID, false)
+}
+
+// CreateRule creates a pricing rule
+func (s *PricingRuleService) CreateRule(ctx context.Context, restaurantID uint, req *PricingRuleRequest) (*models.PricingRule, error) {
+	rule := &models.PricingRule{RestaurantID: restaurantID, IsActive: true}
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.CreateWithContext(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// UpdateRule replaces a pricing rule's window, scope and adjustment
+func (s *PricingRuleService) UpdateRule(ctx context.Context, id, restaurantID uint, req *PricingRuleRequest) (*models.PricingRule, error) {
+	rule, err := s.ruleRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodePricingRuleNotFound, "pricing rule not found")
+	}
+	if err := s.applyRequest(ctx, rule, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.ruleRepo.UpdateWithContext(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// DeleteRule deletes a pricing rule
+func (s *PricingRuleService) DeleteRule(ctx context.Context, id, restaurantID uint) error {
+	if err := s.ruleRepo.DeleteForRestaurant(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodePricingRuleNotFound, "pricing rule not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// Pricer returns a pricer for the restaurant's active rules at a point in time
+func (s *PricingRuleService) Pricer(ctx context.Context, restaurantID uint, at time.Time) (*MenuPricer, error) {
+	due, err := s.priceChangeRepo.GetDueWithContext(ctx, restaurantID, at)
+	if err != nil {
+		return nil, err
+	}
+	var scheduled map[uint][]models.MenuItemPriceChange
+	if len(due) > 0 {
+		scheduled = make(map[uint][]models.MenuItemPriceChange)
+		for _, change := range due {
+			scheduled[change.MenuItemID] = append(scheduled[change.MenuItemID], change)
+		}
+	}
+
+	rules, err := s.ruleRepo.GetByRestaurantIDWithContext(ctx, restaurantID, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return &MenuPricer{local: at, scheduled: scheduled}, nil
+	}
+
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+
+	return &MenuPricer{rules: rules, local: at.In(settingsLocation(settings)), scheduled: scheduled}, nil
+}
+
+// PriceMenuItems sets the current price of menu items for a sales channel (OrderChannel*) on menu responses,
+// keeping the channel price as RegularPrice while a pricing rule applies; an empty channel uses the menu price
+func (s *PricingRuleService) PriceMenuItems(ctx context.Context, restaurantID uint, channel string, items []models.MenuItem) error {
+	pricer, err := s.Pricer(ctx, restaurantID, time.Now())
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		pricer.Apply(&items[i], channel)
+	}
+	return nil
+}
+
+// Price returns the price of a menu item for a sales channel and the rule that set it
+// Rules adjust the channel price; without an applicable rule the channel price is charged
+func (p *MenuPricer) Price(item *models.MenuItem, channel string) (money.Cents, *models.PricingRule) {
+	base := p.effective(item).PriceFor(channel)
+	price := base
+	var applied *models.PricingRule
+	for i := range p.rules {
+		rule := &p.rules[i]
+		if !pricingRuleCovers(rule, item) || !pricingRuleActiveAt(rule, p.local) {
+			continue
+		}
+		if adjusted := adjustPrice(base, rule); adjusted < price {
+			price, applied = adjusted, rule
+		}
+	}
+	return price, applied
+}
+
+// Apply sets a menu item's Price to its current price for a sales channel, keeping the channel price as RegularPrice
+func (p *MenuPricer) Apply(item *models.MenuItem, channel string) {
+	price, rule := p.Price(item, channel)
+	if rule != nil {
+		regular := p.effective(item).PriceFor(channel)
+		item.RegularPrice = &regular
+		item.PricingRule = rule.Name
+	}
+	item.Price = price
+}
+
+// effective returns the menu item with its due scheduled price changes applied
+func (p *MenuPricer) effective(item *models.MenuItem) *models.MenuItem {
+	changes, ok := p.scheduled[item.ID]
+	if !ok {
+		return item
+	}
+	effective := *item
+	for i := range changes {
+		effective.SetChannelPrice(changes[i].Channel, changes[i].NewPrice)
+	}
+	return &effective
+}
+
+// applyRequest validates the rule's window and scope and copies the request onto the rule
+func (s *PricingRuleService) applyRequest(ctx context.Context, rule *models.PricingRule, req *PricingRuleRequest) error {
+	if _, err := parseClock(req.StartsAt); err != nil {
+		return err
+	}
+	if _, err := parseClock(req.EndsAt); err != nil {
+		return err
+	}
+	if req.Kind == models.PricingRuleKindPercentOff && req.Value > 100 {
+		return apperrors.BadRequest(apperrors.CodeBadRequest, "percent_off value must be at most 100")
+	}
+
+	rule.CategoryID = nil
+	rule.MenuItemID = nil
+	switch req.Scope {
+	case models.PricingRuleScopeCategory:
+		if req.CategoryID == nil {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "category rules need a category_id")
+		}
+		if _, err := s.categoryRepo.GetByIDForRestaurant(ctx, *req.CategoryID, rule.RestaurantID); err != nil {
+			return apperrors.NotFound(apperrors.CodeCategoryNotFound, "category not found")
+		}
+		rule.CategoryID = req.CategoryID
+	case models.PricingRuleScopeItem:
+		if req.MenuItemID == nil {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "item rules need a menu_item_id")
+		}
+		if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, *req.MenuItemID, rule.RestaurantID); err != nil {
+			return apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+		}
+		rule.MenuItemID = req.MenuItemID
+	}
+
+	weekdays := slices.Clone(req.Weekdays)
+	slices.Sort(weekdays)
+	rule.Name = strings.TrimSpace(req.Name)
+	rule.Weekdays = slices.Compact(weekdays)
+	rule.StartsAt = req.StartsAt
+	rule.EndsAt = req.EndsAt
+	rule.Scope = req.Scope
+	rule.Kind = req.Kind
+	rule.Value = money.Round(req.Value)
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	return nil
+}
+
+// pricingRuleCovers reports whether a menu item is in a rule's scope
+func pricingRuleCovers(rule *models.PricingRule, item *models.MenuItem) bool {
+	switch rule.Scope {
+	case models.PricingRuleScopeAll:
+		return true
+	case models.PricingRuleScopeCategory:
+		return rule.CategoryID != nil && *rule.CategoryID == item.CategoryID
+	case models.PricingRuleScopeItem:
+		return rule.MenuItemID != nil && *rule.MenuItemID == item.ID
+	}
+	return false
+}
+
+// pricingRuleActiveAt reports whether a local time falls into a rule's weekly window
+// Windows ending at or before their start run into the next day, so they count for the day they start on
+func pricingRuleActiveAt(rule *models.PricingRule, local time.Time) bool {
+	starts, err := parseClock(rule.StartsAt)
+	if err != nil {
+		return false
+	}
+	ends, err := parseClock(rule.EndsAt)
+	if err != nil {
+		return false
+	}
+
+	onDay := func(weekday int) bool {
+		return len(rule.Weekdays) == 0 || slices.Contains(rule.Weekdays, weekday)
+	}
+	minute := local.Hour()*60 + local.Minute()
+	weekday := int(local.Weekday())
+	previous := (weekday + 6) % 7
+
+	switch {
+	case starts == ends:
+		return onDay(weekday)
+	case ends > starts:
+		return onDay(weekday) && minute >= starts && minute < ends
+	default:
+		return (onDay(weekday) && minute >= starts) || (onDay(previous) && minute < ends)
+	}
+}
+
+// adjustPrice applies a rule's adjustment to a menu price, rounded to cents and never below zero
+func adjustPrice(price money.Cents, rule *models.PricingRule) money.Cents {
+	adjusted := price
+	switch rule.Kind {
+	case models.PricingRuleKindPercentOff:
+		adjusted -= adjusted.Percent(rule.Value)
+	case models.PricingRuleKindAmountOff:
+		adjusted -= money.FromFloat(rule.Value)
+	case models.PricingRuleKindFixedPrice:
+		adjusted = money.FromFloat(rule.Value)
+	}
+	return max(0, adjusted)
+}
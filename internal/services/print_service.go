@@ -0,0 +1,491 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/escpos"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Print queue tuning
+const (
+	printJobBatchSize   = 10               // Jobs handed to a bridge per poll
+	printJobClaimTTL    = 2 * time.Minute  // Claimed jobs not confirmed within this time are handed out again
+	printJobMaxAttempts = 3                // Claims before an unconfirmed job fails
+	printPollInterval   = time.Second      // How often a long poll checks the queue
+	PrintPollMaxWait    = 30 * time.Second // Longest a bridge poll may wait for jobs
+	printJobListLimit   = 100
+)
+
+// PrintService renders orders as ESC/POS documents and queues them for on-premises printer bridges
+type PrintService struct {
+	printerRepo    *repositories.PrinterRepository
+	jobRepo        *repositories.PrintJobRepository
+	orderRepo      *repositories.OrderRepository
+	restaurantRepo *repositories.RestaurantRepository
+}
+
+// NewPrintService creates a new PrintService instance
+func NewPrintService(
+	printerRepo *repositories.PrinterRepository,
+	jobRepo *repositories.PrintJobRepository,
+	orderRepo *repositories.OrderRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+) *PrintService {
+	return &PrintService{
+		printerRepo:    printerRepo,
+		jobRepo:        jobRepo,
+		orderRepo:      orderRepo,
+		restaurantRepo: restaurantRepo,
+	}
+}
+
+// CreatePrinterRequest represents a printer registration request
+type CreatePrinterRequest struct {
+	Name       string `json:"name" binding:"required,max=100"`
+	Kind       string `json:"kind" binding:"required,oneof=kitchen receipt"`
+	PaperWidth int    `json:"paper_width" binding:"omitempty,oneof=32 42 48"` // Defaults to 48 (80mm)
+	AutoPrint  *bool  `json:"auto_print"`                                     // Defaults to true
+}
+
+// UpdatePrinterRequest represents a printer update request
+type UpdatePrinterRequest struct {
+	Name       *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Kind       *string `json:"kind" binding:"omitempty,oneof=kitchen receipt"`
+	PaperWidth *int    `json:"paper_width" binding:"omitempty,oneof=32 42 48"`
+	AutoPrint  *bool   `json:"auto_print"`
+	IsActive   *bool   `json:"is_active"`
+}
+
+// PrinterCredentials is a printer with its bridge token, returned only when the token is (re)generated
+type PrinterCredentials struct {
+	Printer *models.Printer `json:"printer"`
+	Token   string          `json:"token"`
+}
+
+// PrintOrderRequest represents a request to print an order
+type PrintOrderRequest struct {
+	PrinterID uint   `json:"printer_id" binding:"required"`
+	Kind      string `json:"kind" binding:"required,oneof=kitchen_ticket receipt"`
+}
+
+// ReportPrintJobRequest represents a bridge's report on a claimed print job
+type ReportPrintJobRequest struct {
+	Status string `json:"status" binding:"required,oneof=printed failed"`
+	Error  string `json:"error" binding:"max=500"`
+}
+
+// BridgePrintJob is a print job handed to a printer bridge; payload is the base64 encoded ESC/POS stream
+type BridgePrintJob struct {
+	ID        uint      `json:"id"`
+	Kind      string    `json:"kind"`
+	OrderID   *uint     `json:"order_id,omitempty"`
+	Payload   []byte    `json:"payload"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ListPrinters lists the printers of a restaurant
+func (s *PrintService) ListPrinters(ctx context.Context, restaurantID uint) ([]models.Printer, error) {
+	return s.printerRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// CreatePrinter registers a printer and generates its bridge token
+func (s *PrintService) CreatePrinter(ctx context.Context, req *CreatePrinterRequest, restaurantID uint) (*PrinterCredentials, error) {
+	token, err := newPrinterToken()
+	if err != nil {
+		return nil, err
+	}
+
+	printer := &models.Printer{
+		RestaurantID: restaurantID,
+		Name:         req.Name,
+		Kind:         req.Kind,
+		PaperWidth:   req.PaperWidth,
+		AutoPrint:    true,
+		IsActive:     true,
+		Token:        token,
+	}
+	if printer.PaperWidth == 0 {
+		printer.PaperWidth = escpos.Width80mm
+	}
+	if req.AutoPrint != nil {
+		printer.AutoPrint = *req.AutoPrint
+	}
+
+	if err := s.printerRepo.CreateWithContext(ctx, printer); err != nil {
+		return nil, err
+	}
+	// Booleans default to true in the database, so false values are not inserted
+	if !printer.AutoPrint {
+		if err := s.printerRepo.SaveWithContext(ctx, printer); err != nil {
+			return nil, err
+		}
+	}
+
+	return &PrinterCredentials{Printer: printer, Token: token}, nil
+}
+
+// UpdatePrinter updates a printer
+func (s *PrintService) UpdatePrinter(ctx context.Context, id uint, req *UpdatePrinterRequest, restaurantID uint) (*models.Printer, error) {
+	printer, err := s.getPrinter(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		printer.Name = *req.Name
+	}
+	if req.Kind != nil {
+		printer.Kind = *req.Kind
+	}
+	if req.PaperWidth != nil {
+		printer.PaperWidth = *req.PaperWidth
+	}
+	if req.AutoPrint != nil {
+		printer.AutoPrint = *req.AutoPrint
+	}
+	if req.IsActive != nil {
+		printer.IsActive = *req.IsActive
+	}
+
+	if err := s.printerRepo.SaveWithContext(ctx, printer); err != nil {
+		return nil, err
+	}
+	return printer, nil
+}
+
+// RotatePrinterToken generates a new bridge token, disconnecting bridges using the previous one
+func (s *PrintService) RotatePrinterToken(ctx context.Context, id uint, restaurantID uint) (*PrinterCredentials, error) {
+	printer, err := s.getPrinter(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newPrinterToken()
+	if err != nil {
+		return nil, err
+	}
+	printer.Token = token
+	if err := s.printerRepo.SaveWithContext(ctx, printer); err != nil {
+		return nil, err
+	}
+
+	return &PrinterCredentials{Printer: printer, Token: token}, nil
+}
+
+// DeletePrinter deletes a printer and its queued jobs
+func (s *PrintService) DeletePrinter(ctx context.Context, id uint, restaurantID uint) error {
+	if err := s.printerRepo.DeleteWithContext(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodePrinterNotFound, "printer not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// PrintOrder queues a kitchen ticket or receipt of an order for a printer
+func (s *PrintService) PrintOrder(ctx context.Context, orderID uint, req *PrintOrderRequest, restaurantID uint) (*models.PrintJob, error) {
+	printer, err := s.getPrinter(ctx, req.PrinterID, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if !printer.IsActive {
+		return nil, apperrors.Conflict(apperrors.CodePrinterInactive, "printer is not active")
+	}
+
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+
+	return s.enqueue(ctx, printer, order, req.Kind)
+}
+
+// AutoPrintOrder queues the documents an order status change triggers: kitchen tickets when an
+// order is confirmed and receipts when it is completed, on printers with auto print enabled
+// Printing never blocks the status change, so failures are only logged
+func (s *PrintService) AutoPrintOrder(ctx context.Context, order *models.Order) {
+	var printerKind, jobKind string
+	switch order.Status {
+	case models.OrderStatusConfirmed:
+		printerKind, jobKind = models.PrinterKindKitchen, models.PrintJobKindKitchenTicket
+	case models.OrderStatusCompleted:
+		printerKind, jobKind = models.PrinterKindReceipt, models.PrintJobKindReceipt
+	default:
+		return
+	}
+	s.autoPrint(ctx, order, printerKind, jobKind)
+}
+
+// AutoPrintCourseFire queues the updated kitchen ticket of an order whose course was fired,
+// on kitchen printers with auto print enabled
+func (s *PrintService) AutoPrintCourseFire(ctx context.Context, order *models.Order) {
+	s.autoPrint(ctx, order, models.PrinterKindKitchen, models.PrintJobKindKitchenTicket)
+}
+
+// autoPrint queues a document of an order on the restaurant's auto print printers of a kind
+func (s *PrintService) autoPrint(ctx context.Context, order *models.Order, printerKind, jobKind string) {
+	printers, err := s.printerRepo.GetAutoPrintWithContext(ctx, order.RestaurantID, printerKind)
+	if err != nil {
+		logger.Warn("Failed to list auto print printers", zap.Uint("restaurant_id", order.RestaurantID), zap.Error(err))
+		return
+	}
+
+	for i := range printers {
+		if _, err := s.enqueue(ctx, &printers[i], order, jobKind); err != nil {
+			logger.Warn("Failed to queue print job",
+				zap.Uint("order_id", order.ID),
+				zap.Uint("printer_id", printers[i].ID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// ListJobs lists the latest print jobs of a restaurant
+func (s *PrintService) ListJobs(ctx context.Context, restaurantID uint, filter repositories.PrintJobFilter) ([]models.PrintJob, error) {
+	return s.jobRepo.ListWithContext(ctx, restaurantID, filter, printJobListLimit)
+}
+
+// RetryJob puts a failed print job back into its printer's queue
+func (s *PrintService) RetryJob(ctx context.Context, id uint, restaurantID uint) (*models.PrintJob, error) {
+	job, err := s.jobRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodePrintJobNotFound, "print job not found")
+	}
+	if job.Status != models.PrintJobStatusFailed {
+		return nil, apperrors.Conflict(apperrors.CodeInvalidTransition, "only failed print jobs can be retried")
+	}
+
+	if err := s.jobRepo.RequeueWithContext(ctx, job.ID); err != nil {
+		return nil, err
+	}
+	job.Status = models.PrintJobStatusPending
+	job.Error = ""
+	job.Attempts = 0
+	job.ClaimedAt = nil
+	return job, nil
+}
+
+// PollJobs hands the queued jobs of the printer owning the token to its bridge
+// With a wait, the poll is held open until jobs arrive or the wait elapses (long polling)
+func (s *PrintService) PollJobs(ctx context.Context, token string, wait time.Duration) ([]BridgePrintJob, error) {
+	printer, err := s.bridgePrinter(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if wait > PrintPollMaxWait {
+		wait = PrintPollMaxWait
+	}
+
+	if err := s.printerRepo.TouchLastSeenWithContext(ctx, printer.ID, time.Now()); err != nil {
+		logger.Warn("Failed to record printer poll", zap.Uint("printer_id", printer.ID), zap.Error(err))
+	}
+
+	deadline := time.Now().Add(wait)
+	for {
+		jobs, err := s.jobRepo.ClaimWithContext(ctx, printer.ID, printJobBatchSize, time.Now().Add(-printJobClaimTTL), printJobMaxAttempts)
+		if err != nil {
+			return nil, err
+		}
+		if len(jobs) > 0 || !time.Now().Add(printPollInterval).Before(deadline) {
+			result := make([]BridgePrintJob, 0, len(jobs))
+			for _, job := range jobs {
+				result = append(result, BridgePrintJob{
+					ID:        job.ID,
+					Kind:      job.Kind,
+					OrderID:   job.OrderID,
+					Payload:   job.Payload,
+					CreatedAt: job.CreatedAt,
+				})
+			}
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return []BridgePrintJob{}, nil
+		case <-time.After(printPollInterval):
+		}
+	}
+}
+
+// ReportJob records whether a claimed job was printed
+func (s *PrintService) ReportJob(ctx context.Context, token string, jobID uint, req *ReportPrintJobRequest) error {
+	printer, err := s.bridgePrinter(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.jobRepo.CompleteWithContext(ctx, jobID, printer.ID, req.Status, req.Error); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodePrintJobNotFound, "print job not found or not claimed by this printer")
+		}
+		return err
+	}
+	return nil
+}
+
+// enqueue renders an order document for a printer and queues it
+func (s *PrintService) enqueue(ctx context.Context, printer *models.Printer, order *models.Order, kind string) (*models.PrintJob, error) {
+	var payload []byte
+	switch kind {
+	case models.PrintJobKindKitchenTicket:
+		payload = RenderKitchenTicketESCPOS(NewKitchenTicket(order), printer.PaperWidth)
+	case models.PrintJobKindReceipt:
+		restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, order.RestaurantID)
+		if err != nil {
+			return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+		}
+		payload = RenderReceiptESCPOS(restaurant, order, printer.PaperWidth)
+	default:
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "unknown print job kind")
+	}
+
+	orderID := order.ID
+	job := &models.PrintJob{
+		RestaurantID: order.RestaurantID,
+		PrinterID:    printer.ID,
+		OrderID:      &orderID,
+		Kind:         kind,
+		Status:       models.PrintJobStatusPending,
+		Payload:      payload,
+	}
+	if err := s.jobRepo.CreateWithContext(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// RenderKitchenTicketESCPOS renders a kitchen ticket for a thermal printer: large order number
+// and quantities, item notes under each line, no prices
+func RenderKitchenTicketESCPOS(ticket *KitchenTicket, width int) []byte {
+	doc := escpos.New(width)
+
+	doc.Align(escpos.AlignCenter)
+	doc.Large(true)
+	doc.Bold(true)
+	doc.Line(fmt.Sprintf("ORDER #%d", ticket.OrderID))
+	doc.Large(false)
+	doc.Bold(false)
+	doc.Line(ticket.CreatedAt.Format("2006-01-02 15:04"))
+
+	doc.Align(escpos.AlignLeft)
+	doc.Separator()
+	course := 0
+	for _, item := range ticket.Items {
+		if ticket.coursed() && item.Course != course {
+			course = item.Course
+			doc.Line(ticket.courseHeader(course))
+		}
+		doc.Bold(true)
+		doc.Line(fmt.Sprintf("%2dx %s", item.Quantity, item.Name))
+		doc.Bold(false)
+		if item.Notes != "" {
+			doc.Line("    >> " + item.Notes)
+		}
+	}
+	if ticket.Notes != "" {
+		doc.Separator()
+		doc.Bold(true)
+		doc.Line("NOTE: " + ticket.Notes)
+		doc.Bold(false)
+	}
+
+	doc.Feed(3)
+	doc.Cut()
+	return doc.Bytes()
+}
+
+// RenderReceiptESCPOS renders a customer receipt for a thermal printer
+// Order items must have their MenuItem relationship loaded
+func RenderReceiptESCPOS(restaurant *models.Restaurant, order *models.Order, width int) []byte {
+	doc := escpos.New(width)
+
+	doc.Align(escpos.AlignCenter)
+	doc.Bold(true)
+	doc.Line(restaurant.Name)
+	doc.Bold(false)
+	if restaurant.Address != "" {
+		doc.Line(restaurant.Address)
+	}
+	if restaurant.Phone != "" {
+		doc.Line(restaurant.Phone)
+	}
+
+	doc.Align(escpos.AlignLeft)
+	doc.Separator()
+	doc.Columns(fmt.Sprintf("Order #%d", order.ID), order.CreatedAt.Format("2006-01-02 15:04"))
+	doc.Separator()
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		doc.Columns(
+			fmt.Sprintf("%dx %s", item.Quantity, item.DisplayName()),
+			item.Price.Times(item.Quantity).String(),
+		)
+		if item.Quantity > 1 {
+			doc.Line("   @ " + item.Price.String())
+		}
+	}
+	doc.Separator()
+	doc.Bold(true)
+	doc.Columns("TOTAL", order.TotalAmount.String())
+	doc.Bold(false)
+	if order.Notes != "" {
+		doc.Separator()
+		doc.Line(order.Notes)
+	}
+
+	doc.Feed(1)
+	doc.Align(escpos.AlignCenter)
+	doc.Line("Thank you!")
+	doc.Feed(3)
+	doc.Cut()
+	return doc.Bytes()
+}
+
+// bridgePrinter retrieves the active printer owning a bridge token
+func (s *PrintService) bridgePrinter(ctx context.Context, token string) (*models.Printer, error) {
+	if token == "" {
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid printer token")
+	}
+
+	printer, err := s.printerRepo.GetByTokenWithContext(ctx, token)
+	if err != nil {
+		return nil, apperrors.Unauthorized(apperrors.CodeInvalidToken, "invalid printer token")
+	}
+	if !printer.IsActive {
+		return nil, apperrors.Forbidden(apperrors.CodePrinterInactive, "printer is not active")
+	}
+	return printer, nil
+}
+
+// getPrinter retrieves a printer of the restaurant
+func (s *PrintService) getPrinter(ctx context.Context, id uint, restaurantID uint) (*models.Printer, error) {
+	printer, err := s.printerRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodePrinterNotFound, "printer not found")
+	}
+	return printer, nil
+}
+
+// newPrinterToken generates a random bridge token
+func newPrinterToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate printer token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
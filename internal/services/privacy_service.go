@@ -0,0 +1,321 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// PrivacyService answers customers' data protection (GDPR) requests for the data a restaurant holds about them:
+// access, as a downloadable archive, and erasure, by anonymizing their records. Both are audit-logged
+type PrivacyService struct {
+	privacyRepo     *repositories.PrivacyRepository
+	userRepo        *repositories.UserRepository
+	customerRepo    *repositories.CustomerRepository
+	orderRepo       *repositories.OrderRepository
+	reservationRepo *repositories.ReservationRepository
+	preferenceRepo  *repositories.NotificationPreferenceRepository
+	pushRepo        *repositories.PushSubscriptionRepository
+	auditLogRepo    *repositories.AuditLogRepository
+}
+
+// NewPrivacyService creates a new PrivacyService instance
+func NewPrivacyService(
+	privacyRepo *repositories.PrivacyRepository,
+	userRepo *repositories.UserRepository,
+	customerRepo *repositories.CustomerRepository,
+	orderRepo *repositories.OrderRepository,
+	reservationRepo *repositories.ReservationRepository,
+	preferenceRepo *repositories.NotificationPreferenceRepository,
+	pushRepo *repositories.PushSubscriptionRepository,
+	auditLogRepo *repositories.AuditLogRepository,
+) *PrivacyService {
+	return &PrivacyService{
+		privacyRepo:     privacyRepo,
+		userRepo:        userRepo,
+		customerRepo:    customerRepo,
+		orderRepo:       orderRepo,
+		reservationRepo: reservationRepo,
+		preferenceRepo:  preferenceRepo,
+		pushRepo:        pushRepo,
+		auditLogRepo:    auditLogRepo,
+	}
+}
+
+// DeleteMyDataRequest confirms an erasure request with the account password
+type DeleteMyDataRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// PrivacyExportManifest describes an export archive
+type PrivacyExportManifest struct {
+	GeneratedAt  time.Time `json:"generated_at"`
+	RestaurantID uint      `json:"restaurant_id"`
+	UserID       uint      `json:"user_id"`
+	Files        []string  `json:"files"`
+}
+
+// PrivacyExportOrder is an order as included in a data export
+type PrivacyExportOrder struct {
+	ID              uint                     `json:"id"`
+	Status          string                   `json:"status"`
+	FulfillmentType string                   `json:"fulfillment_type"`
+	Channel         string                   `json:"channel"`
+	TotalAmount     money.Cents              `json:"total_amount"`
+	DeliveryFee     money.Cents              `json:"delivery_fee"`
+	DeliveryAddress string                   `json:"delivery_address,omitempty"`
+	Notes           string                   `json:"notes,omitempty"`
+	ScheduledFor    *time.Time               `json:"scheduled_for,omitempty"`
+	CreatedAt       time.Time                `json:"created_at"`
+	Items           []PrivacyExportOrderItem `json:"items"`
+}
+
+// PrivacyExportOrderItem is an order line as included in a data export
+type PrivacyExportOrderItem struct {
+	MenuItemID uint        `json:"menu_item_id"`
+	Name       string      `json:"name"`
+	Quantity   int         `json:"quantity"`
+	Price      money.Cents `json:"price"`
+	Notes      string      `json:"notes,omitempty"`
+}
+
+// PrivacyExportReservation is a reservation as included in a data export
+type PrivacyExportReservation struct {
+	ID             uint      `json:"id"`
+	TableNumber    string    `json:"table_number"`
+	StartTime      time.Time `json:"start_time"`
+	EndTime        time.Time `json:"end_time"`
+	NumberOfGuests int       `json:"number_of_guests"`
+	Status         string    `json:"status"`
+	Notes          string    `json:"notes,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Export writes a zip archive of the user's personal data at the restaurant, one JSON file per kind of record
+// The archive is built in memory before the first byte is written, so failures still produce an error response
+func (s *PrivacyService) Export(ctx context.Context, restaurantID, userID uint, actor AuditActor, w io.Writer) error {
+	user, err := s.userRepo.GetByIDWithContext(ctx, userID)
+	if err != nil || user.RestaurantID != restaurantID {
+		return apperrors.NotFound(apperrors.CodeUserNotFound, "user not found")
+	}
+	user.PasswordHash = ""
+
+	files := map[string]interface{}{"profile.json": user}
+
+	customer, err := s.customerRepo.GetByUserIDWithContext(ctx, restaurantID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to load customer record: %w", err)
+	}
+	if customer != nil {
+		files["customer.json"] = customer
+	}
+
+	orders := []PrivacyExportOrder{}
+	if err := s.orderRepo.ExportWithContext(ctx, restaurantID, &userID, func(batch []models.Order) error {
+		for i := range batch {
+			orders = append(orders, privacyExportOrder(&batch[i]))
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to load orders: %w", err)
+	}
+	files["orders.json"] = orders
+
+	reservations, err := s.reservationRepo.GetByUserIDWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load reservations: %w", err)
+	}
+	exportReservations := make([]PrivacyExportReservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		exportReservations = append(exportReservations, PrivacyExportReservation{
+			ID:             reservation.ID,
+			TableNumber:    reservation.TableNumber,
+			StartTime:      reservation.StartTime,
+			EndTime:        reservation.EndTime,
+			NumberOfGuests: reservation.NumberOfGuests,
+			Status:         reservation.Status,
+			Notes:          reservation.Notes,
+			CreatedAt:      reservation.CreatedAt,
+		})
+	}
+	files["reservations.json"] = exportReservations
+
+	reviews, err := s.privacyRepo.GetReviewsByUserWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load reviews: %w", err)
+	}
+	files["reviews.json"] = reviews
+
+	preference, err := s.preferenceRepo.GetWithContext(ctx, restaurantID, userID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to load notification preferences: %w", err)
+	}
+	if preference != nil {
+		files["notification_preferences.json"] = preference
+	}
+
+	subscriptions, err := s.pushRepo.ListByUserWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load push subscriptions: %w", err)
+	}
+	files["push_subscriptions.json"] = subscriptions
+
+	archive, err := privacyArchive(restaurantID, userID, files)
+	if err != nil {
+		return err
+	}
+
+	if err := s.audit(ctx, restaurantID, userID, actor, models.AuditActionPrivacyExport, map[string]interface{}{
+		"orders":       len(orders),
+		"reservations": len(reservations),
+		"reviews":      len(reviews),
+	}); err != nil {
+		return err
+	}
+
+	_, err = w.Write(archive)
+	return err
+}
+
+// DeleteMyData erases the user's personal data at the restaurant after checking their password
+// Orders, reservations, reviews and CRM aggregates are kept anonymized, so revenue and stats don't change
+func (s *PrivacyService) DeleteMyData(ctx context.Context, restaurantID, userID uint, req *DeleteMyDataRequest, actor AuditActor) (*repositories.AnonymizeResult, error) {
+	user, err := s.userRepo.GetByIDWithContext(ctx, userID)
+	if err != nil || user.RestaurantID != restaurantID {
+		return nil, apperrors.NotFound(apperrors.CodeUserNotFound, "user not found")
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		return nil, ErrInvalidPassword
+	}
+
+	// The audit entry must not hold the erased data: only IDs are recorded
+	details, err := json.Marshal(map[string]interface{}{"user_id": userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode audit details: %w", err)
+	}
+	result, err := s.privacyRepo.AnonymizeUserWithContext(ctx, restaurantID, userID, &models.AuditLog{
+		RestaurantID: &restaurantID,
+		ActorUserID:  actor.UserID,
+		ActorRole:    actor.Role,
+		Action:       models.AuditActionPrivacyErase,
+		Details:      string(details),
+		IPAddress:    actor.IPAddress,
+		UserAgent:    actor.UserAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Customer data anonymized",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.Uint("user_id", userID),
+		zap.Int64("orders", result.Orders),
+		zap.Int64("reservations", result.Reservations),
+	)
+	return result, nil
+}
+
+// audit records a data protection request
+func (s *PrivacyService) audit(ctx context.Context, restaurantID, userID uint, actor AuditActor, action string, details map[string]interface{}) error {
+	details["user_id"] = userID
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit details: %w", err)
+	}
+	if err := s.auditLogRepo.CreateWithContext(ctx, &models.AuditLog{
+		RestaurantID: &restaurantID,
+		ActorUserID:  actor.UserID,
+		ActorRole:    actor.Role,
+		Action:       action,
+		Details:      string(encoded),
+		IPAddress:    actor.IPAddress,
+		UserAgent:    actor.UserAgent,
+	}); err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+	return nil
+}
+
+// privacyArchive zips the export files with a manifest listing them
+func privacyArchive(restaurantID, userID uint, files map[string]interface{}) ([]byte, error) {
+	manifest := PrivacyExportManifest{
+		GeneratedAt:  time.Now().UTC(),
+		RestaurantID: restaurantID,
+		UserID:       userID,
+	}
+	// Fixed order, so archives of the same data list their files the same way
+	for _, name := range []string{
+		"profile.json", "customer.json", "orders.json", "reservations.json",
+		"reviews.json", "notification_preferences.json", "push_subscriptions.json",
+	} {
+		if _, ok := files[name]; ok {
+			manifest.Files = append(manifest.Files, name)
+		}
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	write := func(name string, v interface{}) error {
+		f, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(f)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(v)
+	}
+	if err := write("manifest.json", manifest); err != nil {
+		return nil, fmt.Errorf("failed to write export manifest: %w", err)
+	}
+	for _, name := range manifest.Files {
+		if err := write(name, files[name]); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish export archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// privacyExportOrder maps an order with its items to its export form
+func privacyExportOrder(order *models.Order) PrivacyExportOrder {
+	exported := PrivacyExportOrder{
+		ID:              order.ID,
+		Status:          order.Status,
+		FulfillmentType: order.FulfillmentType,
+		Channel:         order.Channel,
+		TotalAmount:     order.TotalAmount,
+		DeliveryFee:     order.DeliveryFee,
+		DeliveryAddress: order.DeliveryAddress,
+		Notes:           order.Notes,
+		ScheduledFor:    order.ScheduledFor,
+		CreatedAt:       order.CreatedAt,
+		Items:           make([]PrivacyExportOrderItem, 0, len(order.OrderItems)),
+	}
+	for _, item := range order.OrderItems {
+		exported.Items = append(exported.Items, PrivacyExportOrderItem{
+			MenuItemID: item.MenuItemID,
+			Name:       item.DisplayName(),
+			Quantity:   item.Quantity,
+			Price:      item.Price,
+			Notes:      item.Notes,
+		})
+	}
+	return exported
+}
@@ -0,0 +1,634 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/pdf"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// Quote defaults: how long a quote is valid and the deposit schedule used when none is given
+const (
+	defaultQuoteValidity   = 14 * 24 * time.Hour
+	defaultDepositPercent  = 30
+	defaultDepositDueAfter = 7 * 24 * time.Hour // After the quote
+	defaultBalanceDueLead  = 7 * 24 * time.Hour // Before the event
+)
+
+// privateEventTransitions lists the statuses an event can be moved to by hand; events become quoted by generating a quote
+var privateEventTransitions = map[string][]string{
+	models.PrivateEventStatusInquiry:   {models.PrivateEventStatusCancelled},
+	models.PrivateEventStatusQuoted:    {models.PrivateEventStatusConfirmed, models.PrivateEventStatusCancelled},
+	models.PrivateEventStatusConfirmed: {models.PrivateEventStatusCompleted, models.PrivateEventStatusCancelled},
+}
+
+// errEventTablesUnavailable is returned when an event's tables are reserved or blocked by another event
+var errEventTablesUnavailable = apperrors.Conflict(apperrors.CodeTableUnavailable, "tables are not available at the requested time")
+
+// EventPackageRequest represents an event package create or update request
+type EventPackageRequest struct {
+	Name          string      `json:"name" binding:"required,max=100"`
+	Description   string      `json:"description"`
+	Courses       []string    `json:"courses" binding:"dive,required"`
+	PricePerGuest money.Cents `json:"price_per_guest" binding:"min=0"`
+	MinGuests     int         `json:"min_guests" binding:"omitempty,min=1"` // Defaults to 1
+	IsActive      *bool       `json:"is_active"`                            // Defaults to true
+}
+
+// PrivateEventMenuLineRequest is an item of an event's custom menu
+// Menu items default to their name and current price; other dishes need both
+type PrivateEventMenuLineRequest struct {
+	MenuItemID *uint        `json:"menu_item_id"`
+	Name       string       `json:"name" binding:"max=200"`
+	Quantity   int          `json:"quantity" binding:"required,min=1"`
+	UnitPrice  *money.Cents `json:"unit_price" binding:"omitempty,min=0"`
+}
+
+// PrivateEventRequest represents a private event create or update request
+// Full-venue events block every table; others block the listed tables
+type PrivateEventRequest struct {
+	Name                 string                        `json:"name" binding:"required,max=200"`
+	ContactName          string                        `json:"contact_name" binding:"required,max=200"`
+	ContactEmail         string                        `json:"contact_email" binding:"omitempty,email"`
+	ContactPhone         string                        `json:"contact_phone" binding:"max=50"`
+	StartTime            time.Time                     `json:"start_time" binding:"required"`
+	EndTime              time.Time                     `json:"end_time" binding:"required"`
+	NumberOfGuests       int                           `json:"number_of_guests" binding:"required,min=1"`
+	FullVenue            bool                          `json:"full_venue"`
+	Tables               []string                      `json:"tables" binding:"dive,required,max=20"`
+	PackageID            *uint                         `json:"package_id"`
+	CustomMenu           []PrivateEventMenuLineRequest `json:"custom_menu" binding:"dive"`
+	VenueFee             money.Cents                   `json:"venue_fee" binding:"min=0"`
+	ServiceChargePercent float64                       `json:"service_charge_percent" binding:"min=0,max=100"`
+	Notes                string                        `json:"notes"`
+}
+
+// DepositScheduleRequest is an installment of a quote's deposit schedule
+type DepositScheduleRequest struct {
+	Label   string    `json:"label" binding:"required,max=100"`
+	Percent float64   `json:"percent" binding:"required,gt=0,lte=100"`
+	DueDate time.Time `json:"due_date" binding:"required"`
+}
+
+// GenerateQuoteRequest represents generating an event's quote
+// Without deposits the schedule is a 30% deposit due within a week and the balance due a week before the event
+type GenerateQuoteRequest struct {
+	ValidDays int                      `json:"valid_days" binding:"omitempty,min=1,max=365"` // Defaults to 14
+	Deposits  []DepositScheduleRequest `json:"deposits" binding:"dive"`
+}
+
+// PrivateEventStatusRequest represents moving an event to another status
+type PrivateEventStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=confirmed completed cancelled"`
+}
+
+// RecordDepositPaymentRequest represents recording the payment of a deposit
+type RecordDepositPaymentRequest struct {
+	PaymentReference string `json:"payment_reference" binding:"max=100"`
+}
+
+// PrivateEventService manages private-dining bookings: events blocking several tables or the whole venue,
+// priced from set-menu packages and custom menus, quoted with a deposit schedule
+// Quoted and confirmed events hold their tables; regular reservations can't take them and vice versa
+type PrivateEventService struct {
+	eventRepo      *repositories.PrivateEventRepository
+	menuItemRepo   *repositories.MenuItemRepository
+	restaurantRepo *repositories.RestaurantRepository
+	settingsRepo   *repositories.RestaurantSettingsRepository
+}
+
+// NewPrivateEventService creates a new PrivateEventService instance
+func NewPrivateEventService(
+	eventRepo *repositories.PrivateEventRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+) *PrivateEventService {
+	return &PrivateEventService{
+		eventRepo:      eventRepo,
+		menuItemRepo:   menuItemRepo,
+		restaurantRepo: restaurantRepo,
+		settingsRepo:   settingsRepo,
+	}
+}
+
+// ListPackages lists the restaurant's event packages
+func (s *PrivateEventService) ListPackages(ctx context.Context, restaurantID uint) ([]models.EventPackage, error) {
+	return s.eventRepo.ListPackagesWithContext(ctx, restaurantID)
+}
+
+// CreatePackage creates an event package
+func (s *PrivateEventService) CreatePackage(ctx context.Context, restaurantID uint, req *EventPackageRequest) (*models.EventPackage, error) {
+	pkg := &models.EventPackage{RestaurantID: restaurantID}
+	applyEventPackageRequest(pkg, req)
+	if err := s.eventRepo.CreatePackageWithContext(ctx, pkg); err != nil {
+		return nil, fmt.Errorf("failed to create event package: %w", err)
+	}
+	return pkg, nil
+}
+
+// UpdatePackage updates an event package; events already priced with it keep their totals until they change
+func (s *PrivateEventService) UpdatePackage(ctx context.Context, id, restaurantID uint, req *EventPackageRequest) (*models.EventPackage, error) {
+	pkg, err := s.eventRepo.GetPackageForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeEventPackageNotFound, "event package not found")
+	}
+	applyEventPackageRequest(pkg, req)
+	if err := s.eventRepo.UpdatePackageWithContext(ctx, pkg); err != nil {
+		return nil, fmt.Errorf("failed to update event package: %w", err)
+	}
+	return pkg, nil
+}
+
+// DeletePackage deletes an event package no event has chosen; chosen packages can be deactivated instead
+func (s *PrivateEventService) DeletePackage(ctx context.Context, id, restaurantID uint) error {
+	if _, err := s.eventRepo.GetPackageForRestaurant(ctx, id, restaurantID); err != nil {
+		return apperrors.NotFound(apperrors.CodeEventPackageNotFound, "event package not found")
+	}
+	used, err := s.eventRepo.CountEventsWithPackageWithContext(ctx, id)
+	if err != nil {
+		return err
+	}
+	if used > 0 {
+		return apperrors.Conflict(apperrors.CodeEventPackageInUse, "event package is used by events; deactivate it instead")
+	}
+
+	if err := s.eventRepo.DeletePackageForRestaurant(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeEventPackageNotFound, "event package not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// ListEvents lists the restaurant's events overlapping [from, to), optionally with one status
+func (s *PrivateEventService) ListEvents(ctx context.Context, restaurantID uint, from, to time.Time, status string) ([]models.PrivateEvent, error) {
+	if !to.After(from) {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "to must be after from")
+	}
+	return s.eventRepo.ListWithContext(ctx, restaurantID, from, to, status)
+}
+
+// GetEvent returns an event with its package, tables and deposit schedule
+func (s *PrivateEventService) GetEvent(ctx context.Context, id, restaurantID uint) (*models.PrivateEvent, error) {
+	event, err := s.eventRepo.GetForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodePrivateEventNotFound, "private event not found")
+	}
+	return event, nil
+}
+
+// CreateEvent records an inquiry for a private event with its estimated total; it holds no tables until quoted
+func (s *PrivateEventService) CreateEvent(ctx context.Context, restaurantID, userID uint, req *PrivateEventRequest) (*models.PrivateEvent, error) {
+	event := &models.PrivateEvent{
+		RestaurantID: restaurantID,
+		Status:       models.PrivateEventStatusInquiry,
+		CreatedBy:    userID,
+	}
+	if err := s.applyEventRequest(ctx, event, req); err != nil {
+		return nil, err
+	}
+	if err := s.price(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventRepo.CreateWithContext(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to create private event: %w", err)
+	}
+	return s.GetEvent(ctx, event.ID, restaurantID)
+}
+
+// UpdateEvent changes an open event and reprices it
+// Quoted and confirmed events must still find their tables free, and their unpaid deposits are rebalanced to the new total
+func (s *PrivateEventService) UpdateEvent(ctx context.Context, id, restaurantID uint, req *PrivateEventRequest) (*models.PrivateEvent, error) {
+	event, err := s.GetEvent(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status == models.PrivateEventStatusCompleted || event.Status == models.PrivateEventStatusCancelled {
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "cannot modify a "+event.Status+" private event")
+	}
+
+	if err := s.applyEventRequest(ctx, event, req); err != nil {
+		return nil, err
+	}
+	if err := s.price(ctx, event); err != nil {
+		return nil, err
+	}
+	rebalanceDeposits(event.Total, event.Deposits)
+
+	if err := s.save(ctx, event, false); err != nil {
+		return nil, err
+	}
+	return s.GetEvent(ctx, id, restaurantID)
+}
+
+// GenerateQuote prices an event, sets its deposit schedule and marks it quoted, which holds its tables
+// Requoting replaces the schedule unless a deposit has been paid
+func (s *PrivateEventService) GenerateQuote(ctx context.Context, id, restaurantID uint, req *GenerateQuoteRequest) (*models.PrivateEvent, error) {
+	event, err := s.GetEvent(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status != models.PrivateEventStatusInquiry && event.Status != models.PrivateEventStatusQuoted {
+		return nil, apperrors.Conflict(apperrors.CodeInvalidTransition, "cannot quote a "+event.Status+" private event")
+	}
+	if !event.StartTime.After(time.Now()) {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "private event cannot be in the past")
+	}
+
+	if err := s.price(ctx, event); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	validity := defaultQuoteValidity
+	if req.ValidDays > 0 {
+		validity = time.Duration(req.ValidDays) * 24 * time.Hour
+	}
+	expiresAt := now.Add(validity)
+	if expiresAt.After(event.StartTime) {
+		expiresAt = event.StartTime
+	}
+
+	deposits, err := depositSchedule(event, req.Deposits, now)
+	if err != nil {
+		return nil, err
+	}
+	event.Deposits = deposits
+	event.Status = models.PrivateEventStatusQuoted
+	event.QuotedAt = &now
+	event.QuoteExpiresAt = &expiresAt
+
+	if err := s.save(ctx, event, true); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Private event quoted",
+		zap.Uint("restaurant_id", restaurantID),
+		zap.Uint("event_id", event.ID),
+		zap.Stringer("total", event.Total),
+	)
+	return s.GetEvent(ctx, id, restaurantID)
+}
+
+// ChangeStatus confirms, completes or cancels an event; cancelling releases its tables
+func (s *PrivateEventService) ChangeStatus(ctx context.Context, id, restaurantID uint, status string) (*models.PrivateEvent, error) {
+	event, err := s.GetEvent(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if !privateEventTransitionAllowed(event.Status, status) {
+		return nil, apperrors.Conflict(apperrors.CodeInvalidTransition, "cannot move a "+event.Status+" private event to "+status)
+	}
+
+	event.Status = status
+	if err := s.save(ctx, event, false); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// RecordDepositPayment marks a deposit as paid; the first payment confirms a quoted event
+func (s *PrivateEventService) RecordDepositPayment(ctx context.Context, eventID, depositID, restaurantID uint, req *RecordDepositPaymentRequest) (*models.PrivateEvent, error) {
+	event, err := s.GetEvent(ctx, eventID, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status == models.PrivateEventStatusCancelled {
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "cannot record payments of a cancelled private event")
+	}
+
+	if err := s.eventRepo.MarkDepositPaidWithContext(ctx, depositID, eventID, restaurantID, strings.TrimSpace(req.PaymentReference), time.Now()); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeDepositNotFound, "unpaid deposit not found")
+		}
+		return nil, fmt.Errorf("failed to record deposit payment: %w", err)
+	}
+	return s.GetEvent(ctx, eventID, restaurantID)
+}
+
+// RenderQuotePDF renders the quote of a quoted or confirmed event as a PDF document
+func (s *PrivateEventService) RenderQuotePDF(ctx context.Context, id, restaurantID uint) ([]byte, error) {
+	event, err := s.GetEvent(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if event.QuotedAt == nil {
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "private event has not been quoted")
+	}
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	doc := pdf.New()
+	doc.Heading(restaurant.Name)
+	doc.Bold(fmt.Sprintf("Quote for private event #%d", event.ID))
+	doc.Text("Event:       " + event.Name)
+	doc.Text("Contact:     " + event.ContactName)
+	doc.Text("Date:        " + event.StartTime.In(location).Format("2006-01-02 15:04") + " - " + event.EndTime.In(location).Format("15:04 MST"))
+	doc.Text(fmt.Sprintf("Guests:      %d", event.NumberOfGuests))
+	if event.FullVenue {
+		doc.Text("Space:       Full venue")
+	} else {
+		doc.Text("Tables:      " + strings.Join(event.TableNumbers(), ", "))
+	}
+	doc.Text("Valid until: " + event.QuoteExpiresAt.In(location).Format("2006-01-02"))
+	doc.Space()
+
+	// Description, quantity, unit price and amount columns sized to the page width
+	descWidth := pdf.Columns() - 36
+	row := func(description, quantity, unit, amount string) string {
+		if runes := []rune(description); len(runes) > descWidth {
+			description = string(runes[:descWidth])
+		}
+		return fmt.Sprintf("%-*s %6s %14s %14s", descWidth, description, quantity, unit, amount)
+	}
+	doc.Bold(row("Description", "Qty", "Unit price", "Amount"))
+	if event.Package != nil {
+		doc.Text(row("Package: "+event.Package.Name, fmt.Sprintf("%d", event.NumberOfGuests),
+			event.Package.PricePerGuest.String(), event.Package.PricePerGuest.Times(event.NumberOfGuests).String()))
+		for _, course := range event.Package.Courses {
+			doc.Text("  " + course)
+		}
+	}
+	for _, line := range event.CustomMenu {
+		doc.Text(row(line.Name, fmt.Sprintf("%d", line.Quantity), line.UnitPrice.String(), line.UnitPrice.Times(line.Quantity).String()))
+	}
+	if event.VenueFee > 0 {
+		doc.Text(row("Venue fee", "", "", event.VenueFee.String()))
+	}
+	doc.Space()
+	doc.Text(row("Subtotal", "", "", event.Subtotal.String()))
+	if event.ServiceCharge > 0 {
+		doc.Text(row(fmt.Sprintf("Service charge %.2f%%", event.ServiceChargePercent), "", "", event.ServiceCharge.String()))
+	}
+	if settings.TaxRate > 0 {
+		doc.Text(row(fmt.Sprintf("Tax %.2f%% (included)", settings.TaxRate), "", "", event.Tax.String()))
+	}
+	doc.Bold(row("Total ("+settings.Currency+")", "", "", event.Total.String()))
+	doc.Space()
+
+	if len(event.Deposits) > 0 {
+		doc.Bold("Payment schedule")
+		for _, deposit := range event.Deposits {
+			status := "due " + deposit.DueDate.Format("2006-01-02")
+			if deposit.PaidAt != nil {
+				status = "paid " + deposit.PaidAt.In(location).Format("2006-01-02")
+			}
+			doc.Text(row(fmt.Sprintf("%s (%.0f%%)", deposit.Label, deposit.Percent), "", status, deposit.Amount.String()))
+		}
+	}
+
+	return doc.Bytes(), nil
+}
+
+// applyEventRequest validates an event request and applies it, resolving custom menu items and the package
+func (s *PrivateEventService) applyEventRequest(ctx context.Context, event *models.PrivateEvent, req *PrivateEventRequest) error {
+	if !req.EndTime.After(req.StartTime) {
+		return apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "end time must be after start time")
+	}
+	if req.StartTime.Before(time.Now()) && !req.StartTime.Equal(event.StartTime) {
+		return apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "private event cannot be in the past")
+	}
+
+	tables := make([]models.PrivateEventTable, 0, len(req.Tables))
+	if !req.FullVenue {
+		seen := make(map[string]bool, len(req.Tables))
+		for _, number := range req.Tables {
+			number = strings.TrimSpace(number)
+			if number == "" || seen[number] {
+				continue
+			}
+			seen[number] = true
+			tables = append(tables, models.PrivateEventTable{RestaurantID: event.RestaurantID, TableNumber: number})
+		}
+		if len(tables) == 0 {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "choose at least one table or the full venue")
+		}
+	}
+
+	previousPackageID := event.PackageID
+	event.Package = nil
+	event.PackageID = nil
+	if req.PackageID != nil {
+		pkg, err := s.eventRepo.GetPackageForRestaurant(ctx, *req.PackageID, event.RestaurantID)
+		if err != nil {
+			return apperrors.NotFound(apperrors.CodeEventPackageNotFound, "event package not found")
+		}
+		// An event keeps a package deactivated after it was chosen
+		if !pkg.IsActive && (previousPackageID == nil || *previousPackageID != pkg.ID) {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "event package is not offered anymore")
+		}
+		if req.NumberOfGuests < pkg.MinGuests {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, fmt.Sprintf("package %s requires at least %d guests", pkg.Name, pkg.MinGuests))
+		}
+		event.Package = pkg
+		event.PackageID = &pkg.ID
+	}
+
+	menu := make([]models.PrivateEventMenuLine, 0, len(req.CustomMenu))
+	for _, lineReq := range req.CustomMenu {
+		line := models.PrivateEventMenuLine{
+			MenuItemID: lineReq.MenuItemID,
+			Name:       strings.TrimSpace(lineReq.Name),
+			Quantity:   lineReq.Quantity,
+		}
+		if lineReq.MenuItemID != nil {
+			item, err := s.menuItemRepo.GetByIDForRestaurant(ctx, *lineReq.MenuItemID, event.RestaurantID)
+			if err != nil {
+				return apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+			}
+			if line.Name == "" {
+				line.Name = item.Name
+			}
+			line.UnitPrice = item.Price
+		} else if line.Name == "" || lineReq.UnitPrice == nil {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "custom menu lines without a menu item need a name and unit price")
+		}
+		if lineReq.UnitPrice != nil {
+			line.UnitPrice = *lineReq.UnitPrice
+		}
+		menu = append(menu, line)
+	}
+
+	event.Name = strings.TrimSpace(req.Name)
+	event.ContactName = strings.TrimSpace(req.ContactName)
+	event.ContactEmail = strings.TrimSpace(req.ContactEmail)
+	event.ContactPhone = strings.TrimSpace(req.ContactPhone)
+	event.StartTime = req.StartTime
+	event.EndTime = req.EndTime
+	event.NumberOfGuests = req.NumberOfGuests
+	event.FullVenue = req.FullVenue
+	event.Tables = tables
+	event.CustomMenu = menu
+	event.VenueFee = req.VenueFee
+	event.ServiceChargePercent = req.ServiceChargePercent
+	event.Notes = req.Notes
+	return nil
+}
+
+// price computes an event's totals: package and custom menu plus venue fee, then the service charge on top,
+// with the restaurant's tax included as on receipts
+func (s *PrivateEventService) price(ctx context.Context, event *models.PrivateEvent) error {
+	settings, err := s.settings(ctx, event.RestaurantID)
+	if err != nil {
+		return err
+	}
+
+	subtotal := event.VenueFee
+	if event.Package != nil {
+		subtotal += event.Package.PricePerGuest.Times(event.NumberOfGuests)
+	}
+	for _, line := range event.CustomMenu {
+		subtotal += line.UnitPrice.Times(line.Quantity)
+	}
+
+	event.Subtotal = subtotal
+	event.ServiceCharge = subtotal.Percent(event.ServiceChargePercent)
+	event.Total = event.Subtotal + event.ServiceCharge
+	event.Tax = event.Total - event.Total.ExcludingTax(settings.TaxRate)
+	return nil
+}
+
+// save stores an event, translating table conflicts and paid deposit schedules
+func (s *PrivateEventService) save(ctx context.Context, event *models.PrivateEvent, replaceDeposits bool) error {
+	if err := s.eventRepo.SaveWithContext(ctx, event, replaceDeposits); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrPrivateEventConflict):
+			return errEventTablesUnavailable
+		case errors.Is(err, repositories.ErrPrivateEventDepositPaid):
+			return apperrors.Conflict(apperrors.CodeDepositPaid, "deposits have been paid; the schedule can't be replaced")
+		}
+		return fmt.Errorf("failed to save private event: %w", err)
+	}
+	return nil
+}
+
+// settings loads the restaurant's settings, falling back to the defaults
+func (s *PrivateEventService) settings(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+	return settings, nil
+}
+
+// depositSchedule builds an event's deposit schedule from the requested installments, or the default one
+// Installment percentages must add up to 100; amounts always sum exactly to the total
+func depositSchedule(event *models.PrivateEvent, installments []DepositScheduleRequest, now time.Time) ([]models.PrivateEventDeposit, error) {
+	if len(installments) == 0 {
+		depositDue := now.Add(defaultDepositDueAfter)
+		if depositDue.After(event.StartTime) {
+			depositDue = now
+		}
+		balanceDue := event.StartTime.Add(-defaultBalanceDueLead)
+		if balanceDue.Before(depositDue) {
+			balanceDue = depositDue
+		}
+		installments = []DepositScheduleRequest{
+			{Label: "Deposit", Percent: defaultDepositPercent, DueDate: depositDue},
+			{Label: "Balance", Percent: 100 - defaultDepositPercent, DueDate: balanceDue},
+		}
+	}
+
+	var total float64
+	for _, installment := range installments {
+		total += installment.Percent
+		if installment.DueDate.After(event.StartTime) {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "deposits must be due before the event")
+		}
+	}
+	if math.Abs(total-100) > 0.001 {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "deposit percentages must add up to 100")
+	}
+
+	deposits := make([]models.PrivateEventDeposit, 0, len(installments))
+	for _, installment := range installments {
+		deposits = append(deposits, models.PrivateEventDeposit{
+			RestaurantID: event.RestaurantID,
+			Label:        installment.Label,
+			Percent:      installment.Percent,
+			DueDate:      installment.DueDate.UTC().Truncate(24 * time.Hour),
+		})
+	}
+	rebalanceDeposits(event.Total, deposits)
+	return deposits, nil
+}
+
+// rebalanceDeposits spreads what's left of the total after paid deposits over the unpaid ones by their percentages
+func rebalanceDeposits(total money.Cents, deposits []models.PrivateEventDeposit) {
+	remaining := total
+	var unpaid []int
+	var weights []float64
+	for i := range deposits {
+		if deposits[i].PaidAt != nil {
+			remaining -= deposits[i].Amount
+			continue
+		}
+		unpaid = append(unpaid, i)
+		weights = append(weights, deposits[i].Percent)
+	}
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	for k, amount := range allocateCents(remaining, weights) {
+		deposits[unpaid[k]].Amount = amount
+	}
+}
+
+// privateEventTransitionAllowed reports whether an event can be moved from one status to another by hand
+func privateEventTransitionAllowed(from, to string) bool {
+	for _, allowed := range privateEventTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// applyEventPackageRequest applies a package request with its defaults
+func applyEventPackageRequest(pkg *models.EventPackage, req *EventPackageRequest) {
+	pkg.Name = strings.TrimSpace(req.Name)
+	pkg.Description = req.Description
+	pkg.Courses = req.Courses
+	if pkg.Courses == nil {
+		pkg.Courses = []string{}
+	}
+	pkg.PricePerGuest = req.PricePerGuest
+	pkg.MinGuests = req.MinGuests
+	if pkg.MinGuests == 0 {
+		pkg.MinGuests = 1
+	}
+	pkg.IsActive = true
+	if req.IsActive != nil {
+		pkg.IsActive = *req.IsActive
+	}
+}
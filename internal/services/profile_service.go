@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"restaurant-backend/internal/apperrors"
 	"restaurant-backend/internal/dto"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
@@ -15,9 +16,9 @@ import (
 
 var (
 	// ErrProfileNotFound is returned when a profile is not found
-	ErrProfileNotFound = errors.New("profile not found")
+	ErrProfileNotFound = apperrors.NotFound(apperrors.CodeUserNotFound, "profile not found")
 	// ErrInvalidPassword is returned when the current password is incorrect
-	ErrInvalidPassword = errors.New("current password is incorrect")
+	ErrInvalidPassword = apperrors.BadRequest(apperrors.CodeInvalidPassword, "current password is incorrect")
 )
 
 // ProfileService handles profile management operations
@@ -78,7 +79,7 @@ func (s *ProfileService) UpdateProfile(ctx context.Context, userID uint, updateD
 
 	// Save updated user
 	if err := s.userRepo.UpdateWithContext(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to update profile: %w", err)
+		return nil, fmt.Errorf("failed to update profile: %w", versionConflict(err))
 	}
 
 	// Clear password hash
@@ -133,7 +134,7 @@ func (s *ProfileService) UpdatePreferences(ctx context.Context, userID uint, pre
 
 	// Save updated user
 	if err := s.userRepo.UpdateWithContext(ctx, user); err != nil {
-		return fmt.Errorf("failed to update preferences: %w", err)
+		return fmt.Errorf("failed to update preferences: %w", versionConflict(err))
 	}
 
 	return nil
@@ -155,7 +156,7 @@ func (s *ProfileService) UpdateAvatar(ctx context.Context, userID uint, avatarUR
 
 	// Save updated user
 	if err := s.userRepo.UpdateWithContext(ctx, user); err != nil {
-		return fmt.Errorf("failed to update avatar: %w", err)
+		return fmt.Errorf("failed to update avatar: %w", versionConflict(err))
 	}
 
 	return nil
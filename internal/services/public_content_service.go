@@ -0,0 +1,415 @@
+package services
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+// sitemapNamespace is the XML namespace of sitemaps and sitemap indexes
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// schemaWeekdays are the schema.org days of the week, indexed like OpeningHours.Weekday
+var schemaWeekdays = [7]string{
+	"https://schema.org/Sunday", "https://schema.org/Monday", "https://schema.org/Tuesday", "https://schema.org/Wednesday",
+	"https://schema.org/Thursday", "https://schema.org/Friday", "https://schema.org/Saturday",
+}
+
+// PublicContentService generates the machine-readable content search engines index: schema.org JSON-LD
+// of restaurants and their menus, and sitemaps of the storefront pages
+type PublicContentService struct {
+	restaurantRepo *repositories.RestaurantRepository
+	categoryRepo   *repositories.CategoryRepository
+	menuItemRepo   *repositories.MenuItemRepository
+	hoursRepo      *repositories.OpeningHoursRepository
+	reviewRepo     *repositories.ReviewRepository
+	settings       *RestaurantSettingsService
+	frontendURL    string
+	publicAPIURL   string
+}
+
+// NewPublicContentService creates a new PublicContentService instance
+func NewPublicContentService(
+	restaurantRepo *repositories.RestaurantRepository,
+	categoryRepo *repositories.CategoryRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	hoursRepo *repositories.OpeningHoursRepository,
+	reviewRepo *repositories.ReviewRepository,
+	settings *RestaurantSettingsService,
+	frontendURL string,
+	publicAPIURL string,
+) *PublicContentService {
+	return &PublicContentService{
+		restaurantRepo: restaurantRepo,
+		categoryRepo:   categoryRepo,
+		menuItemRepo:   menuItemRepo,
+		hoursRepo:      hoursRepo,
+		reviewRepo:     reviewRepo,
+		settings:       settings,
+		frontendURL:    strings.TrimRight(frontendURL, "/"),
+		publicAPIURL:   strings.TrimRight(publicAPIURL, "/"),
+	}
+}
+
+// SchemaRestaurant is a schema.org Restaurant with its menu, as JSON-LD
+type SchemaRestaurant struct {
+	Context                   string                   `json:"@context"`
+	Type                      string                   `json:"@type"`
+	ID                        string                   `json:"@id"`
+	Name                      string                   `json:"name"`
+	Description               string                   `json:"description,omitempty"`
+	URL                       string                   `json:"url"`
+	Telephone                 string                   `json:"telephone,omitempty"`
+	Image                     []string                 `json:"image,omitempty"`
+	Address                   *SchemaPostalAddress     `json:"address,omitempty"`
+	Geo                       *SchemaGeoCoordinates    `json:"geo,omitempty"`
+	ServesCuisine             []string                 `json:"servesCuisine,omitempty"`
+	CurrenciesAccepted        string                   `json:"currenciesAccepted,omitempty"`
+	OpeningHoursSpecification []SchemaOpeningHoursSpec `json:"openingHoursSpecification,omitempty"`
+	AggregateRating           *SchemaAggregateRating   `json:"aggregateRating,omitempty"`
+	HasMenu                   SchemaMenu               `json:"hasMenu"`
+}
+
+// SchemaPostalAddress is a schema.org PostalAddress
+type SchemaPostalAddress struct {
+	Type            string `json:"@type"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+}
+
+// SchemaGeoCoordinates is a schema.org GeoCoordinates
+type SchemaGeoCoordinates struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// SchemaOpeningHoursSpec is a schema.org OpeningHoursSpecification
+type SchemaOpeningHoursSpec struct {
+	Type      string `json:"@type"`
+	DayOfWeek string `json:"dayOfWeek"`
+	Opens     string `json:"opens"`
+	Closes    string `json:"closes"`
+}
+
+// SchemaAggregateRating is a schema.org AggregateRating
+type SchemaAggregateRating struct {
+	Type        string  `json:"@type"`
+	RatingValue float64 `json:"ratingValue"`
+	ReviewCount int64   `json:"reviewCount"`
+	BestRating  int     `json:"bestRating"`
+	WorstRating int     `json:"worstRating"`
+}
+
+// SchemaMenu is a schema.org Menu
+type SchemaMenu struct {
+	Type           string              `json:"@type"`
+	URL            string              `json:"url"`
+	HasMenuSection []SchemaMenuSection `json:"hasMenuSection"`
+}
+
+// SchemaMenuSection is a schema.org MenuSection, a menu category
+type SchemaMenuSection struct {
+	Type        string           `json:"@type"`
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	HasMenuItem []SchemaMenuItem `json:"hasMenuItem"`
+}
+
+// SchemaMenuItem is a schema.org MenuItem
+type SchemaMenuItem struct {
+	Type        string                 `json:"@type"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	URL         string                 `json:"url"`
+	Image       string                 `json:"image,omitempty"`
+	Offers      SchemaOffer            `json:"offers"`
+	Nutrition   *SchemaNutrition       `json:"nutrition,omitempty"`
+	Rating      *SchemaAggregateRating `json:"aggregateRating,omitempty"`
+}
+
+// SchemaOffer is a schema.org Offer, the regular price of a menu item
+type SchemaOffer struct {
+	Type          string `json:"@type"`
+	Price         string `json:"price"`
+	PriceCurrency string `json:"priceCurrency"`
+	Availability  string `json:"availability"`
+}
+
+// SchemaNutrition is a schema.org NutritionInformation, per serving
+type SchemaNutrition struct {
+	Type                string `json:"@type"`
+	Calories            string `json:"calories,omitempty"`
+	ProteinContent      string `json:"proteinContent,omitempty"`
+	CarbohydrateContent string `json:"carbohydrateContent,omitempty"`
+	FatContent          string `json:"fatContent,omitempty"`
+}
+
+// sitemapURLSet is a sitemap
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a page of a sitemap
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+}
+
+// sitemapIndex lists sitemaps
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+// StructuredData returns the schema.org JSON-LD of a publicly visible restaurant and its available menu items
+func (s *PublicContentService) StructuredData(ctx context.Context, restaurantID uint) (*SchemaRestaurant, error) {
+	restaurant, err := s.visibleRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := s.hoursRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	rating, err := s.reviewRepo.GetRestaurantSummaryWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	categories, items, err := s.menu(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	pageURL := s.restaurantURL(restaurantID)
+	data := &SchemaRestaurant{
+		Context:            "https://schema.org",
+		Type:               "Restaurant",
+		ID:                 pageURL,
+		Name:               restaurant.Name,
+		Description:        restaurant.Description,
+		URL:                pageURL,
+		Telephone:          restaurant.Phone,
+		ServesCuisine:      settings.Cuisines,
+		CurrenciesAccepted: settings.Currency,
+		HasMenu: SchemaMenu{
+			Type:           "Menu",
+			URL:            pageURL + "/menu",
+			HasMenuSection: []SchemaMenuSection{},
+		},
+	}
+	if settings.LogoURL != "" {
+		data.Image = append(data.Image, settings.LogoURL)
+	}
+	data.Image = append(data.Image, settings.PhotoURLs...)
+	if restaurant.Address != "" || settings.City != "" {
+		data.Address = &SchemaPostalAddress{Type: "PostalAddress", StreetAddress: restaurant.Address, AddressLocality: settings.City}
+	}
+	if settings.Latitude != nil && settings.Longitude != nil {
+		data.Geo = &SchemaGeoCoordinates{Type: "GeoCoordinates", Latitude: *settings.Latitude, Longitude: *settings.Longitude}
+	}
+	for _, period := range hours {
+		if period.Weekday < 0 || period.Weekday >= len(schemaWeekdays) {
+			continue
+		}
+		data.OpeningHoursSpecification = append(data.OpeningHoursSpecification, SchemaOpeningHoursSpec{
+			Type:      "OpeningHoursSpecification",
+			DayOfWeek: schemaWeekdays[period.Weekday],
+			Opens:     period.OpensAt,
+			Closes:    period.ClosesAt,
+		})
+	}
+	if rating.Count > 0 {
+		data.AggregateRating = schemaRating(rating.Average, rating.Count)
+	}
+
+	byCategory := make(map[uint][]models.MenuItem)
+	for _, item := range items {
+		byCategory[item.CategoryID] = append(byCategory[item.CategoryID], item)
+	}
+	for _, category := range categories {
+		section := SchemaMenuSection{
+			Type:        "MenuSection",
+			Name:        category.Name,
+			Description: category.Description,
+			HasMenuItem: []SchemaMenuItem{},
+		}
+		for i := range byCategory[category.ID] {
+			section.HasMenuItem = append(section.HasMenuItem, s.schemaMenuItem(&byCategory[category.ID][i], settings.Currency))
+		}
+		if len(section.HasMenuItem) > 0 {
+			data.HasMenu.HasMenuSection = append(data.HasMenu.HasMenuSection, section)
+		}
+	}
+
+	return data, nil
+}
+
+// Sitemap returns the sitemap of a publicly visible restaurant's storefront: its profile, menu and item pages
+func (s *PublicContentService) Sitemap(ctx context.Context, restaurantID uint) ([]byte, error) {
+	restaurant, err := s.visibleRestaurant(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	_, items, err := s.menu(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastModified := restaurant.UpdatedAt
+	for _, item := range items {
+		if item.UpdatedAt.After(lastModified) {
+			lastModified = item.UpdatedAt
+		}
+	}
+
+	pageURL := s.restaurantURL(restaurantID)
+	urls := make([]sitemapURL, 0, len(items)+2)
+	urls = append(urls,
+		sitemapURL{Loc: pageURL, LastMod: sitemapDate(restaurant.UpdatedAt), ChangeFreq: "weekly"},
+		sitemapURL{Loc: pageURL + "/menu", LastMod: sitemapDate(lastModified), ChangeFreq: "daily"},
+	)
+	for _, item := range items {
+		urls = append(urls, sitemapURL{
+			Loc:        fmt.Sprintf("%s/menu-items/%d", pageURL, item.ID),
+			LastMod:    sitemapDate(item.UpdatedAt),
+			ChangeFreq: "weekly",
+		})
+	}
+
+	return marshalSitemap(sitemapURLSet{Xmlns: sitemapNamespace, URLs: urls})
+}
+
+// SitemapIndex returns the sitemap index linking the sitemap of every publicly visible restaurant
+func (s *PublicContentService) SitemapIndex(ctx context.Context) ([]byte, error) {
+	entries, err := s.restaurantRepo.ListSitemapEntriesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sitemaps := make([]sitemapURL, 0, len(entries))
+	for _, entry := range entries {
+		sitemaps = append(sitemaps, sitemapURL{
+			Loc:     fmt.Sprintf("%s/api/v1/public/restaurants/%d/sitemap.xml", s.publicAPIURL, entry.RestaurantID),
+			LastMod: sitemapDate(entry.LastModified),
+		})
+	}
+
+	return marshalSitemap(sitemapIndex{Xmlns: sitemapNamespace, Sitemaps: sitemaps})
+}
+
+// visibleRestaurant retrieves a restaurant, reporting one that isn't publicly visible as not found
+func (s *PublicContentService) visibleRestaurant(ctx context.Context, restaurantID uint) (*models.Restaurant, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil || !restaurant.IsPubliclyVisible() {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+	return restaurant, nil
+}
+
+// menu retrieves a restaurant's published categories and their available items, with images
+func (s *PublicContentService) menu(ctx context.Context, restaurantID uint) ([]models.MenuCategory, []models.MenuItem, error) {
+	categories, err := s.categoryRepo.GetPublishedByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	allItems, err := s.menuItemRepo.GetPublishedByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	items := make([]models.MenuItem, 0, len(allItems))
+	for _, item := range allItems {
+		if item.IsAvailable {
+			items = append(items, item)
+		}
+	}
+	return categories, items, nil
+}
+
+// restaurantURL is the storefront page of a restaurant
+func (s *PublicContentService) restaurantURL(restaurantID uint) string {
+	return fmt.Sprintf("%s/restaurants/%d", s.frontendURL, restaurantID)
+}
+
+// schemaMenuItem describes a menu item at its regular price
+func (s *PublicContentService) schemaMenuItem(item *models.MenuItem, currency string) SchemaMenuItem {
+	schemaItem := SchemaMenuItem{
+		Type:        "MenuItem",
+		Name:        item.Name,
+		Description: item.Description,
+		URL:         fmt.Sprintf("%s/menu-items/%d", s.restaurantURL(item.RestaurantID), item.ID),
+		Image:       item.ImageURL,
+		Offers: SchemaOffer{
+			Type:          "Offer",
+			Price:         item.Price.String(),
+			PriceCurrency: currency,
+			Availability:  "https://schema.org/InStock",
+		},
+	}
+	for _, image := range item.Images {
+		if image.IsPrimary || schemaItem.Image == "" {
+			schemaItem.Image = image.ImageURL
+		}
+	}
+
+	if item.Calories != nil || item.ProteinGrams != nil || item.CarbsGrams != nil || item.FatGrams != nil {
+		nutrition := &SchemaNutrition{Type: "NutritionInformation"}
+		if item.Calories != nil {
+			nutrition.Calories = fmt.Sprintf("%d calories", *item.Calories)
+		}
+		if item.ProteinGrams != nil {
+			nutrition.ProteinContent = fmt.Sprintf("%g grams", *item.ProteinGrams)
+		}
+		if item.CarbsGrams != nil {
+			nutrition.CarbohydrateContent = fmt.Sprintf("%g grams", *item.CarbsGrams)
+		}
+		if item.FatGrams != nil {
+			nutrition.FatContent = fmt.Sprintf("%g grams", *item.FatGrams)
+		}
+		schemaItem.Nutrition = nutrition
+	}
+	if item.RatingCount > 0 {
+		schemaItem.Rating = schemaRating(item.RatingAverage, int64(item.RatingCount))
+	}
+	return schemaItem
+}
+
+// schemaRating is the aggregate rating of reviews on the 1-5 scale
+func schemaRating(average float64, count int64) *SchemaAggregateRating {
+	return &SchemaAggregateRating{
+		Type:        "AggregateRating",
+		RatingValue: math.Round(average*10) / 10,
+		ReviewCount: count,
+		BestRating:  5,
+		WorstRating: 1,
+	}
+}
+
+// sitemapDate formats a sitemap lastmod date
+func sitemapDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// marshalSitemap encodes a sitemap or sitemap index as an XML document
+func marshalSitemap(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode sitemap: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
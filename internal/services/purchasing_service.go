@@ -0,0 +1,384 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// PurchasingService manages suppliers and purchase orders; receiving goods adds them to stock
+// and costs the stock at the weighted average of what it was bought for
+type PurchasingService struct {
+	supplierRepo   *repositories.SupplierRepository
+	orderRepo      *repositories.PurchaseOrderRepository
+	ingredientRepo *repositories.IngredientRepository
+}
+
+// NewPurchasingService creates a new PurchasingService instance
+func NewPurchasingService(
+	supplierRepo *repositories.SupplierRepository,
+	orderRepo *repositories.PurchaseOrderRepository,
+	ingredientRepo *repositories.IngredientRepository,
+) *PurchasingService {
+	return &PurchasingService{
+		supplierRepo:   supplierRepo,
+		orderRepo:      orderRepo,
+		ingredientRepo: ingredientRepo,
+	}
+}
+
+// SupplierRequest represents a supplier create or update request
+type SupplierRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	ContactName string `json:"contact_name" binding:"max=200"`
+	Email       string `json:"email" binding:"omitempty,email,max=255"`
+	Phone       string `json:"phone" binding:"max=50"`
+	Notes       string `json:"notes" binding:"max=2000"`
+	IsActive    *bool  `json:"is_active"`
+}
+
+// PurchaseOrderRequest represents a purchase order create or update request (drafts only)
+type PurchaseOrderRequest struct {
+	SupplierID uint                       `json:"supplier_id" binding:"required"`
+	Reference  string                     `json:"reference" binding:"max=100"`
+	ExpectedAt *time.Time                 `json:"expected_at"`
+	Notes      string                     `json:"notes" binding:"max=2000"`
+	Lines      []PurchaseOrderLineRequest `json:"lines" binding:"required,min=1,max=200,dive"`
+}
+
+// PurchaseOrderLineRequest is an ingredient ordered on a purchase order
+type PurchaseOrderLineRequest struct {
+	IngredientID uint     `json:"ingredient_id" binding:"required"`
+	Quantity     float64  `json:"quantity" binding:"required,gt=0"`
+	UnitCost     *float64 `json:"unit_cost" binding:"omitempty,min=0"` // Defaults to the ingredient's current cost
+}
+
+// ReceiveGoodsRequest books delivered goods against an ordered purchase order
+type ReceiveGoodsRequest struct {
+	Lines []ReceiveGoodsLine `json:"lines" binding:"required,min=1,max=200,dive"`
+}
+
+// ReceiveGoodsLine is a delivered quantity of a purchase order line
+type ReceiveGoodsLine struct {
+	LineID   uint     `json:"line_id" binding:"required"`
+	Quantity float64  `json:"quantity" binding:"required,gt=0"`
+	UnitCost *float64 `json:"unit_cost" binding:"omitempty,min=0"` // Invoiced cost per unit; defaults to the ordered unit cost
+}
+
+// PurchaseOrderList is a page of purchase orders
+type PurchaseOrderList struct {
+	PurchaseOrders []models.PurchaseOrder `json:"purchase_orders"`
+	Total          int64                  `json:"total"`
+	Limit          int                    `json:"limit"`
+	Offset         int                    `json:"offset"`
+}
+
+// ListSuppliers lists a restaurant's suppliers
+func (s *PurchasingService) ListSuppliers(ctx context.Context, restaurantID uint) ([]models.Supplier, error) {
+	return s.supplierRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// CreateSupplier creates a supplier
+func (s *PurchasingService) CreateSupplier(ctx context.Context, restaurantID uint, req *SupplierRequest) (*models.Supplier, error) {
+	supplier := &models.Supplier{RestaurantID: restaurantID, IsActive: true}
+	if err := applySupplierRequest(supplier, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.supplierRepo.CreateWithContext(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// UpdateSupplier updates a supplier
+func (s *PurchasingService) UpdateSupplier(ctx context.Context, id, restaurantID uint, req *SupplierRequest) (*models.Supplier, error) {
+	supplier, err := s.getSupplier(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applySupplierRequest(supplier, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.supplierRepo.UpdateWithContext(ctx, supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// DeleteSupplier deletes a supplier without purchase orders; deactivate suppliers that have some instead
+func (s *PurchasingService) DeleteSupplier(ctx context.Context, id, restaurantID uint) error {
+	if err := s.supplierRepo.DeleteForRestaurant(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeSupplierNotFound, "supplier not found")
+		}
+		if errors.Is(err, repositories.ErrSupplierInUse) {
+			return apperrors.Conflict(apperrors.CodeSupplierInUse, "supplier has purchase orders; deactivate it instead")
+		}
+		return err
+	}
+	return nil
+}
+
+// ListPurchaseOrders lists a restaurant's purchase orders, newest first, optionally of one status
+func (s *PurchasingService) ListPurchaseOrders(ctx context.Context, restaurantID uint, status string, limit, offset int) (*PurchaseOrderList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	orders, total, err := s.orderRepo.ListWithContext(ctx, restaurantID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if orders == nil {
+		orders = []models.PurchaseOrder{}
+	}
+	return &PurchaseOrderList{PurchaseOrders: orders, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// GetPurchaseOrder returns a purchase order with its lines
+func (s *PurchasingService) GetPurchaseOrder(ctx context.Context, id, restaurantID uint) (*models.PurchaseOrder, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodePurchaseOrderNotFound, "purchase order not found")
+		}
+		return nil, err
+	}
+	return order, nil
+}
+
+// CreatePurchaseOrder drafts a purchase order from an active supplier
+func (s *PurchasingService) CreatePurchaseOrder(ctx context.Context, restaurantID, userID uint, req *PurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	order := &models.PurchaseOrder{
+		RestaurantID: restaurantID,
+		Status:       models.PurchaseOrderStatusDraft,
+		CreatedBy:    userID,
+	}
+	if err := s.applyPurchaseOrderRequest(ctx, order, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.orderRepo.CreateWithContext(ctx, order); err != nil {
+		return nil, err
+	}
+	return s.GetPurchaseOrder(ctx, order.ID, restaurantID)
+}
+
+// UpdatePurchaseOrder replaces the supplier, details and lines of a draft purchase order
+func (s *PurchasingService) UpdatePurchaseOrder(ctx context.Context, id, restaurantID uint, req *PurchaseOrderRequest) (*models.PurchaseOrder, error) {
+	order, err := s.GetPurchaseOrder(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.PurchaseOrderStatusDraft {
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "only draft purchase orders can be changed")
+	}
+	if err := s.applyPurchaseOrderRequest(ctx, order, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.orderRepo.SaveWithContext(ctx, order, true); err != nil {
+		return nil, err
+	}
+	return s.GetPurchaseOrder(ctx, id, restaurantID)
+}
+
+// SubmitPurchaseOrder marks a draft purchase order as sent to the supplier, so goods can be received against it
+func (s *PurchasingService) SubmitPurchaseOrder(ctx context.Context, id, restaurantID uint) (*models.PurchaseOrder, error) {
+	order, err := s.GetPurchaseOrder(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.PurchaseOrderStatusDraft {
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "only draft purchase orders can be submitted")
+	}
+	if !order.Supplier.IsActive {
+		return nil, apperrors.Conflict(apperrors.CodeSupplierInactive, "supplier is inactive")
+	}
+
+	now := time.Now()
+	order.Status = models.PurchaseOrderStatusOrdered
+	order.OrderedAt = &now
+	if err := s.orderRepo.SaveWithContext(ctx, order, false); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// CancelPurchaseOrder cancels a purchase order nothing was received for yet
+func (s *PurchasingService) CancelPurchaseOrder(ctx context.Context, id, restaurantID uint) (*models.PurchaseOrder, error) {
+	order, err := s.GetPurchaseOrder(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != models.PurchaseOrderStatusDraft && order.Status != models.PurchaseOrderStatusOrdered {
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "cannot cancel a "+strings.ReplaceAll(order.Status, "_", " ")+" purchase order")
+	}
+
+	order.Status = models.PurchaseOrderStatusCancelled
+	if err := s.orderRepo.SaveWithContext(ctx, order, false); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// ReceiveGoods books a delivery against an ordered purchase order: the received quantities are added to stock and
+// each ingredient's cost becomes the weighted average of its stock and the delivery at the invoiced unit cost
+// The order is received once every line is received in full; deliveries above the ordered quantity are accepted
+func (s *PurchasingService) ReceiveGoods(ctx context.Context, id, restaurantID uint, req *ReceiveGoodsRequest) (*models.PurchaseOrder, error) {
+	_, err := s.orderRepo.ReceiveWithContext(ctx, id, restaurantID, func(order *models.PurchaseOrder, ingredients map[uint]*models.Ingredient) error {
+		if order.Status != models.PurchaseOrderStatusOrdered && order.Status != models.PurchaseOrderStatusPartiallyReceived {
+			return apperrors.Conflict(apperrors.CodeConflict, "goods can only be received for ordered purchase orders")
+		}
+
+		lines := make(map[uint]*models.PurchaseOrderLine, len(order.Lines))
+		for i := range order.Lines {
+			lines[order.Lines[i].ID] = &order.Lines[i]
+		}
+
+		for _, receipt := range req.Lines {
+			line, ok := lines[receipt.LineID]
+			if !ok {
+				return apperrors.NotFound(apperrors.CodeNotFound, "purchase order line not found")
+			}
+			ingredient, ok := ingredients[line.IngredientID]
+			if !ok {
+				return apperrors.NotFound(apperrors.CodeIngredientNotFound, "ingredient not found")
+			}
+
+			unitCost := line.UnitCost
+			if receipt.UnitCost != nil {
+				unitCost = *receipt.UnitCost
+			}
+
+			// Stock counted below zero carries no value, so it doesn't dilute the cost of the delivery
+			if ingredient.StockQuantity > 0 {
+				ingredient.CostPrice = (ingredient.StockQuantity*ingredient.CostPrice + receipt.Quantity*unitCost) /
+					(ingredient.StockQuantity + receipt.Quantity)
+			} else {
+				ingredient.CostPrice = unitCost
+			}
+			ingredient.CostPrice = math.Round(ingredient.CostPrice*10000) / 10000
+			ingredient.StockQuantity = math.Round((ingredient.StockQuantity+receipt.Quantity)*1000) / 1000
+
+			line.ReceivedQuantity = math.Round((line.ReceivedQuantity+receipt.Quantity)*1000) / 1000
+			line.ReceivedCost += money.FromFloat(receipt.Quantity * unitCost)
+		}
+
+		now := time.Now()
+		order.ReceivedAt = &now
+		order.Status = models.PurchaseOrderStatusReceived
+		for _, line := range order.Lines {
+			if line.ReceivedQuantity < line.Quantity {
+				order.Status = models.PurchaseOrderStatusPartiallyReceived
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodePurchaseOrderNotFound, "purchase order not found")
+		}
+		return nil, err
+	}
+
+	return s.GetPurchaseOrder(ctx, id, restaurantID)
+}
+
+// applyPurchaseOrderRequest checks the supplier and ingredients and copies the request onto the order,
+// pricing lines without a unit cost at the ingredient's current cost
+func (s *PurchasingService) applyPurchaseOrderRequest(ctx context.Context, order *models.PurchaseOrder, req *PurchaseOrderRequest) error {
+	supplier, err := s.getSupplier(ctx, req.SupplierID, order.RestaurantID)
+	if err != nil {
+		return err
+	}
+	if !supplier.IsActive {
+		return apperrors.Conflict(apperrors.CodeSupplierInactive, "supplier is inactive")
+	}
+
+	ids := make([]uint, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		ids = append(ids, line.IngredientID)
+	}
+	ingredients, err := s.ingredientRepo.GetByIDsWithContext(ctx, order.RestaurantID, ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]*models.Ingredient, len(ingredients))
+	for i := range ingredients {
+		byID[ingredients[i].ID] = &ingredients[i]
+	}
+
+	lines := make([]models.PurchaseOrderLine, 0, len(req.Lines))
+	var total float64
+	for _, line := range req.Lines {
+		ingredient, ok := byID[line.IngredientID]
+		if !ok {
+			return apperrors.NotFound(apperrors.CodeIngredientNotFound, "ingredient not found")
+		}
+		unitCost := ingredient.CostPrice
+		if line.UnitCost != nil {
+			unitCost = *line.UnitCost
+		}
+		total += line.Quantity * unitCost
+		lines = append(lines, models.PurchaseOrderLine{
+			IngredientID: line.IngredientID,
+			Quantity:     line.Quantity,
+			UnitCost:     unitCost,
+		})
+	}
+
+	order.SupplierID = supplier.ID
+	order.Supplier = *supplier
+	order.Reference = strings.TrimSpace(req.Reference)
+	order.ExpectedAt = req.ExpectedAt
+	order.Notes = strings.TrimSpace(req.Notes)
+	order.Lines = lines
+	order.TotalCost = money.FromFloat(total)
+	return nil
+}
+
+// getSupplier retrieves a supplier of the restaurant
+func (s *PurchasingService) getSupplier(ctx context.Context, id, restaurantID uint) (*models.Supplier, error) {
+	supplier, err := s.supplierRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeSupplierNotFound, "supplier not found")
+		}
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// applySupplierRequest copies the request onto the supplier
+func applySupplierRequest(supplier *models.Supplier, req *SupplierRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return apperrors.BadRequest(apperrors.CodeBadRequest, "supplier name is required")
+	}
+
+	supplier.Name = name
+	supplier.ContactName = strings.TrimSpace(req.ContactName)
+	supplier.Email = strings.TrimSpace(req.Email)
+	supplier.Phone = strings.TrimSpace(req.Phone)
+	supplier.Notes = strings.TrimSpace(req.Notes)
+	if req.IsActive != nil {
+		supplier.IsActive = *req.IsActive
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	webpush "github.com/SherClockHolmes/webpush-go"
+	"go.uber.org/zap"
+)
+
+// pushTTL is how long a push service keeps an order update for an offline device; older updates are stale
+const pushTTL = int(time.Hour / time.Second)
+
+// PushService sends Web Push (VAPID) notifications about order updates to the devices customers subscribed
+// Push is disabled when no VAPID key pair is configured
+type PushService struct {
+	subscriptionRepo *repositories.PushSubscriptionRepository
+	restaurantRepo   *repositories.RestaurantRepository
+	preferences      *NotificationPreferenceService
+	publicKey        string
+	privateKey       string
+	subject          string
+	client           *http.Client
+}
+
+// NewPushService creates a new PushService instance
+func NewPushService(
+	subscriptionRepo *repositories.PushSubscriptionRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	preferences *NotificationPreferenceService,
+	publicKey string,
+	privateKey string,
+	subject string,
+) *PushService {
+	return &PushService{
+		subscriptionRepo: subscriptionRepo,
+		restaurantRepo:   restaurantRepo,
+		preferences:      preferences,
+		publicKey:        publicKey,
+		privateKey:       privateKey,
+		subject:          subject,
+		client:           newPublicHTTPClient(10 * time.Second),
+	}
+}
+
+// PushSubscribeRequest is a browser PushSubscription, as returned by PushSubscription.toJSON()
+type PushSubscribeRequest struct {
+	Endpoint       string `json:"endpoint" binding:"required,url,max=2048"`
+	ExpirationTime *int64 `json:"expirationTime"` // Unix milliseconds, null when the subscription doesn't expire
+	Keys           struct {
+		P256dh string `json:"p256dh" binding:"required,max=255"`
+		Auth   string `json:"auth" binding:"required,max=255"`
+	} `json:"keys" binding:"required"`
+}
+
+// PushUnsubscribeRequest identifies the subscription to remove by its endpoint
+type PushUnsubscribeRequest struct {
+	Endpoint string `json:"endpoint" binding:"required"`
+}
+
+// PushPublicKey is the VAPID public key browsers subscribe with (applicationServerKey)
+type PushPublicKey struct {
+	PublicKey string `json:"public_key"`
+}
+
+// PushMessage is the JSON payload delivered to the service worker
+type PushMessage struct {
+	Title string                 `json:"title"`
+	Body  string                 `json:"body"`
+	Tag   string                 `json:"tag"` // Replaces the previous notification of the same order on the device
+	Data  map[string]interface{} `json:"data"`
+}
+
+// Enabled reports whether a VAPID key pair is configured
+func (s *PushService) Enabled() bool {
+	return s != nil && s.publicKey != "" && s.privateKey != ""
+}
+
+// PublicKey returns the VAPID public key
+func (s *PushService) PublicKey() (*PushPublicKey, error) {
+	if !s.Enabled() {
+		return nil, apperrors.NotFound(apperrors.CodePushNotConfigured, "web push is not configured")
+	}
+	return &PushPublicKey{PublicKey: s.publicKey}, nil
+}
+
+// Subscribe saves a device's subscription for the user; the endpoint must be an https URL resolving to public addresses
+func (s *PushService) Subscribe(ctx context.Context, restaurantID, userID uint, req *PushSubscribeRequest, userAgent string) (*models.PushSubscription, error) {
+	if !s.Enabled() {
+		return nil, apperrors.NotFound(apperrors.CodePushNotConfigured, "web push is not configured")
+	}
+	if !strings.HasPrefix(req.Endpoint, "https://") {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "push endpoint must be an https URL")
+	}
+	// Deliveries go to the endpoint on every order update, so it must not reach internal hosts
+	if err := checkPublicURL(ctx, req.Endpoint); err != nil {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "push endpoint "+err.Error())
+	}
+
+	if len(userAgent) > 255 {
+		userAgent = userAgent[:255]
+	}
+	subscription := &models.PushSubscription{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		Endpoint:     req.Endpoint,
+		P256dh:       req.Keys.P256dh,
+		Auth:         req.Keys.Auth,
+		UserAgent:    userAgent,
+	}
+	if req.ExpirationTime != nil {
+		expiresAt := time.UnixMilli(*req.ExpirationTime)
+		subscription.ExpiresAt = &expiresAt
+	}
+	if err := s.subscriptionRepo.UpsertWithContext(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions returns the devices the user subscribed, newest first
+func (s *PushService) ListSubscriptions(ctx context.Context, restaurantID, userID uint) ([]models.PushSubscription, error) {
+	return s.subscriptionRepo.ListByUserWithContext(ctx, restaurantID, userID)
+}
+
+// Unsubscribe removes one of the user's subscriptions
+func (s *PushService) Unsubscribe(ctx context.Context, restaurantID, userID uint, endpoint string) error {
+	deleted, err := s.subscriptionRepo.DeleteByEndpointWithContext(ctx, restaurantID, userID, endpoint)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return apperrors.NotFound(apperrors.CodePushSubscriptionNotFound, "push subscription not found")
+	}
+	return nil
+}
+
+// NotifyOrderStatus pushes the order's new status to every device of its customer
+// Push is best effort: failures are logged and never block the caller
+func (s *PushService) NotifyOrderStatus(ctx context.Context, order *models.Order) {
+	if !s.Enabled() {
+		return
+	}
+	body := orderStatusPushBody(order)
+	if body == "" {
+		return
+	}
+	if !s.preferences.Allows(ctx, order.RestaurantID, order.UserID, models.NotificationEventOrderUpdates, models.NotificationChannelPush) {
+		return
+	}
+
+	subscriptions, err := s.subscriptionRepo.ListByUserWithContext(ctx, order.RestaurantID, order.UserID)
+	if err != nil {
+		logger.Warn("Failed to load push subscriptions",
+			zap.Uint("order_id", order.ID),
+			zap.Error(err),
+		)
+		return
+	}
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	title := fmt.Sprintf("Order #%d", order.ID)
+	if restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, order.RestaurantID); err == nil {
+		title = restaurant.Name
+	}
+	payload, err := json.Marshal(PushMessage{
+		Title: title,
+		Body:  body,
+		Tag:   fmt.Sprintf("order-%d", order.ID),
+		Data:  map[string]interface{}{"order_id": order.ID, "status": order.Status},
+	})
+	if err != nil {
+		return
+	}
+
+	urgency := webpush.UrgencyNormal
+	if order.Status == models.OrderStatusReady {
+		urgency = webpush.UrgencyHigh
+	}
+	for i := range subscriptions {
+		s.send(ctx, &subscriptions[i], payload, fmt.Sprintf("order-%d", order.ID), urgency)
+	}
+}
+
+// CleanupJob deletes expired subscriptions and those unused for maxAge
+func (s *PushService) CleanupJob(interval, maxAge time.Duration) Job {
+	return Job{
+		Name:     "push_subscription_cleanup",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			now := time.Now()
+			deleted, err := s.subscriptionRepo.DeleteStaleWithContext(ctx, now, now.Add(-maxAge))
+			if err != nil {
+				return fmt.Errorf("failed to delete stale push subscriptions: %w", err)
+			}
+			if deleted > 0 {
+				logger.Info("Deleted stale push subscriptions", zap.Int64("deleted", deleted))
+			}
+			return nil
+		},
+	}
+}
+
+// send delivers a payload to one subscription
+// Subscriptions the push service no longer knows (404 Not Found, 410 Gone) are deleted
+func (s *PushService) send(ctx context.Context, subscription *models.PushSubscription, payload []byte, topic string, urgency webpush.Urgency) {
+	resp, err := webpush.SendNotificationWithContext(ctx, payload, &webpush.Subscription{
+		Endpoint: subscription.Endpoint,
+		Keys:     webpush.Keys{P256dh: subscription.P256dh, Auth: subscription.Auth},
+	}, &webpush.Options{
+		HTTPClient:      s.client,
+		Subscriber:      s.subject,
+		Topic:           topic,
+		TTL:             pushTTL,
+		Urgency:         urgency,
+		VAPIDPublicKey:  s.publicKey,
+		VAPIDPrivateKey: s.privateKey,
+	})
+	if err != nil {
+		logger.Warn("Failed to send push notification",
+			zap.Uint("push_subscription_id", subscription.ID),
+			zap.Error(err),
+		)
+		return
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		if err := s.subscriptionRepo.DeleteWithContext(ctx, subscription.ID); err != nil {
+			logger.Warn("Failed to delete expired push subscription",
+				zap.Uint("push_subscription_id", subscription.ID),
+				zap.Error(err),
+			)
+		}
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		if err := s.subscriptionRepo.TouchWithContext(ctx, subscription.ID, time.Now()); err != nil {
+			logger.Warn("Failed to record push delivery",
+				zap.Uint("push_subscription_id", subscription.ID),
+				zap.Error(err),
+			)
+		}
+	default:
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		logger.Warn("Push service rejected notification",
+			zap.Uint("push_subscription_id", subscription.ID),
+			zap.Int("status", resp.StatusCode),
+			zap.ByteString("detail", detail),
+		)
+	}
+}
+
+// orderStatusPushBody describes an order's status to its customer, empty for statuses not pushed
+func orderStatusPushBody(order *models.Order) string {
+	switch order.Status {
+	case models.OrderStatusConfirmed:
+		return fmt.Sprintf("Your order #%d has been confirmed.", order.ID)
+	case models.OrderStatusPreparing:
+		return fmt.Sprintf("Your order #%d is being prepared.", order.ID)
+	case models.OrderStatusReady:
+		switch order.FulfillmentType {
+		case models.OrderFulfillmentPickup:
+			return fmt.Sprintf("Your order #%d is ready for pickup.", order.ID)
+		case models.OrderFulfillmentDelivery:
+			return fmt.Sprintf("Your order #%d is ready and will be on its way shortly.", order.ID)
+		}
+		return fmt.Sprintf("Your order #%d is ready.", order.ID)
+	case models.OrderStatusCompleted:
+		return fmt.Sprintf("Your order #%d is complete. Thank you!", order.ID)
+	case models.OrderStatusCancelled:
+		return fmt.Sprintf("Your order #%d has been cancelled.", order.ID)
+	}
+	return ""
+}
@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif" // Logo formats accepted by image.Decode
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/pdf"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// maxLogoBytes bounds the logo download for receipts
+const maxLogoBytes = 2 << 20
+
+// ReceiptService renders PDF receipts for orders and sends them with the order confirmation email
+type ReceiptService struct {
+	orderRepo      *repositories.OrderRepository
+	restaurantRepo *repositories.RestaurantRepository
+	settingsRepo   *repositories.RestaurantSettingsRepository
+	emailService   Mailer
+	preferences    *NotificationPreferenceService
+	client         *http.Client
+}
+
+// NewReceiptService creates a new ReceiptService instance
+func NewReceiptService(
+	orderRepo *repositories.OrderRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+	emailService Mailer,
+	preferences *NotificationPreferenceService,
+) *ReceiptService {
+	return &ReceiptService{
+		orderRepo:      orderRepo,
+		restaurantRepo: restaurantRepo,
+		settingsRepo:   settingsRepo,
+		emailService:   emailService,
+		preferences:    preferences,
+		client:         newPublicHTTPClient(5 * time.Second),
+	}
+}
+
+// ReceiptTotals is an order total broken down into net amount and included tax
+type ReceiptTotals struct {
+	Net     money.Cents
+	Tax     money.Cents
+	Total   money.Cents
+	TaxRate float64 // Percent
+}
+
+// NewReceiptTotals splits a tax-inclusive total using the restaurant's tax rate
+func NewReceiptTotals(total money.Cents, taxRate float64) ReceiptTotals {
+	net := total.ExcludingTax(taxRate)
+	return ReceiptTotals{
+		Net:     net,
+		Tax:     total - net,
+		Total:   total,
+		TaxRate: taxRate,
+	}
+}
+
+// GetOrderReceipt renders the PDF receipt of an order
+// A non-zero customerID restricts the lookup to that customer's orders
+func (s *ReceiptService) GetOrderReceipt(ctx context.Context, orderID, restaurantID, customerID uint) ([]byte, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil || (customerID != 0 && order.UserID != customerID) {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+
+	restaurant, settings, err := s.branding(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.render(ctx, restaurant, settings, order), nil
+}
+
+// SendOrderConfirmation emails the order confirmation with the PDF receipt attached to the ordering customer
+// Orders of users without an email address, of inactive accounts (e.g. delivery channel users)
+// or of customers who turned order emails off are skipped
+func (s *ReceiptService) SendOrderConfirmation(ctx context.Context, orderID, restaurantID uint) error {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return err
+	}
+	if order.User.Email == "" || !order.User.IsActive {
+		return nil
+	}
+	if !s.preferences.Allows(ctx, restaurantID, order.UserID, models.NotificationEventOrderUpdates, models.NotificationChannelEmail) {
+		return nil
+	}
+
+	restaurant, settings, err := s.branding(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+
+	// Tax is included in the item prices; the delivery fee is charged on top
+	totals := NewReceiptTotals(order.TotalAmount-order.DeliveryFee, settings.TaxRate)
+	var nutrition *NutritionSummary
+	if summary := CalculateNutritionSummary(order.OrderItems); summary.IsComplete {
+		nutrition = summary
+	}
+
+	return s.emailService.SendOrderConfirmationEmail(
+		ctx,
+		restaurantID,
+		order.User.Email,
+		strings.TrimSpace(order.User.FirstName+" "+order.User.LastName),
+		restaurant.Name,
+		order.ID,
+		BuildOrderEmailItems(order.OrderItems),
+		totals.Net,
+		totals.Tax,
+		order.DeliveryFee,
+		order.TotalAmount,
+		order.EstimatedPrepMinutes,
+		order.Notes,
+		restaurant.Phone,
+		restaurant.Address,
+		nutrition,
+		s.render(ctx, restaurant, settings, order),
+	)
+}
+
+// render lays out the receipt: logo and restaurant details, line items, tax breakdown, payment and footer
+// Order items must have their MenuItem relationship loaded
+func (s *ReceiptService) render(ctx context.Context, restaurant *models.Restaurant, settings *models.RestaurantSettings, order *models.Order) []byte {
+	doc := pdf.New()
+
+	if logo := s.fetchLogo(ctx, settings.LogoURL); logo != nil {
+		doc.Image(logo, 160, 60)
+	}
+	doc.Heading(restaurant.Name)
+	if restaurant.Address != "" {
+		doc.Text(restaurant.Address)
+	}
+	if restaurant.Phone != "" {
+		doc.Text(restaurant.Phone)
+	}
+	doc.Space()
+
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	doc.Bold(fmt.Sprintf("Receipt for order #%d", order.ID))
+	doc.Text("Date:     " + order.CreatedAt.In(location).Format("2006-01-02 15:04 MST"))
+	if order.User.IsActive {
+		if name := strings.TrimSpace(order.User.FirstName + " " + order.User.LastName); name != "" {
+			doc.Text("Customer: " + name)
+		}
+	}
+	doc.Space()
+
+	// Item, quantity, unit price and amount columns sized to the page width
+	nameWidth := pdf.Columns() - 36
+	row := func(name, quantity, unit, amount string) string {
+		if runes := []rune(name); len(runes) > nameWidth {
+			name = string(runes[:nameWidth])
+		}
+		return fmt.Sprintf("%-*s %6s %14s %14s", nameWidth, name, quantity, unit, amount)
+	}
+	doc.Bold(row("Item", "Qty", "Unit price", "Amount"))
+	for i := range order.OrderItems {
+		item := &order.OrderItems[i]
+		doc.Text(row(
+			item.DisplayName(),
+			fmt.Sprintf("%d", item.Quantity),
+			item.Price.String(),
+			item.Price.Times(item.Quantity).String(),
+		))
+		if item.Notes != "" {
+			doc.Text("  " + item.Notes)
+		}
+	}
+	doc.Space()
+
+	totals := NewReceiptTotals(order.TotalAmount-order.DeliveryFee, settings.TaxRate)
+	if totals.TaxRate > 0 {
+		doc.Text(row("Net", "", "", totals.Net.String()))
+		doc.Text(row(fmt.Sprintf("Tax %.2f%% (included)", totals.TaxRate), "", "", totals.Tax.String()))
+	}
+	if order.DeliveryFee > 0 {
+		doc.Text(row("Delivery", "", "", order.DeliveryFee.String()))
+	}
+	doc.Bold(row("Total ("+settings.Currency+")", "", "", order.TotalAmount.String()))
+	doc.Space()
+
+	doc.Text("Payment:  " + receiptPayment(order))
+	if order.FulfillmentType == models.OrderFulfillmentDelivery && order.DeliveryAddress != "" {
+		doc.Text("Deliver:  " + order.DeliveryAddress)
+	}
+	if order.Notes != "" {
+		doc.Text("Notes:    " + order.Notes)
+	}
+
+	if settings.ReceiptFooter != "" {
+		doc.Space()
+		for _, line := range strings.Split(settings.ReceiptFooter, "\n") {
+			doc.Text(line)
+		}
+	}
+
+	return doc.Bytes()
+}
+
+// receiptPayment describes how an order is paid
+// Delivery platform orders are paid on the platform; other orders are settled at the restaurant
+func receiptPayment(order *models.Order) string {
+	switch {
+	case order.Source != "" && order.Source != models.OrderSourceInternal:
+		return "Paid via " + deliveryProviderName(order.Source)
+	case order.Status == models.OrderStatusCompleted:
+		return "Paid"
+	case order.Status == models.OrderStatusCancelled:
+		return "Cancelled, not charged"
+	default:
+		return "Due at the restaurant"
+	}
+}
+
+// branding loads the restaurant and its settings, falling back to default settings
+func (s *ReceiptService) branding(ctx context.Context, restaurantID uint) (*models.Restaurant, *models.RestaurantSettings, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+
+	return restaurant, settings, nil
+}
+
+// fetchLogo downloads and decodes the restaurant logo, from public addresses only as the URL is set by the
+// restaurant; receipts are rendered without it on failure
+func (s *ReceiptService) fetchLogo(ctx context.Context, url string) image.Image {
+	if url == "" {
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		logger.Warn("Failed to download logo for receipt", zap.String("url", url), zap.Error(err))
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		logger.Warn("Failed to download logo for receipt", zap.String("url", url), zap.Int("status", resp.StatusCode))
+		return nil
+	}
+
+	img, _, err := image.Decode(io.LimitReader(resp.Body, maxLogoBytes))
+	if err != nil {
+		logger.Warn("Failed to decode logo for receipt", zap.String("url", url), zap.Error(err))
+		return nil
+	}
+	return img
+}
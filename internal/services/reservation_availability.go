@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+)
+
+// defaultReservationDuration is how long a searched booking lasts unless the search says otherwise
+const defaultReservationDuration = 90 * time.Minute
+
+// AvailabilityRequest represents a search for reservation times on a day
+type AvailabilityRequest struct {
+	Date            string `form:"date" binding:"required"` // YYYY-MM-DD in the restaurant's time zone
+	PartySize       int    `form:"party_size" binding:"required,min=1"`
+	DurationMinutes int    `form:"duration_minutes" binding:"omitempty,min=15,max=720"` // Defaults to 90
+}
+
+// AvailabilitySlot is a reservation start time with the tables that can seat the party then
+type AvailabilitySlot struct {
+	StartTime       time.Time `json:"start_time"`
+	EndTime         time.Time `json:"end_time"`
+	Available       bool      `json:"available"`
+	RemainingCovers *int      `json:"remaining_covers,omitempty"` // Guests the pacing slot still takes; null when unlimited
+	Tables          []string  `json:"tables"`                     // Free tables seating the party; empty without a floor plan
+}
+
+// ReservationAvailability lists the reservation times of a day
+type ReservationAvailability struct {
+	Date            string              `json:"date"`
+	PartySize       int                 `json:"party_size"`
+	DurationMinutes int                 `json:"duration_minutes"`
+	Slots           []*AvailabilitySlot `json:"slots"`
+}
+
+// SearchAvailability lists the start times of a day, one per pacing slot within the opening hours, with whether a party
+// can book then: the slot must have covers left and, with a floor plan, an active table seating the party must be free
+// of reservations (and their turnover buffer) and private events for the whole duration
+func (s *ReservationService) SearchAvailability(ctx context.Context, restaurantID uint, req *AvailabilityRequest) (*ReservationAvailability, error) {
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPartySize(settings, req.PartySize); err != nil {
+		return nil, err
+	}
+
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	day, err := time.ParseInLocation("2006-01-02", req.Date, location)
+	if err != nil {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid date, expected YYYY-MM-DD")
+	}
+
+	duration := defaultReservationDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+	pacing := reservationPacing(settings)
+	dayEnd := day.AddDate(0, 0, 1)
+
+	hours, err := s.hoursRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	reservations, err := s.reservationRepo.GetOpenBetweenWithContext(ctx, restaurantID,
+		day.Add(-duration-pacing.TableBuffer), dayEnd.Add(duration+pacing.TableBuffer))
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.eventRepo.GetHoldingBetweenWithContext(ctx, restaurantID, day, dayEnd.Add(duration))
+	if err != nil {
+		return nil, err
+	}
+	sections, err := s.floorPlanRepo.GetSectionsWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Smallest suitable tables first, so parties aren't offered oversized tables before fitting ones
+	var tables []models.DiningTable
+	hasFloorPlan := false
+	for _, section := range sections {
+		for _, table := range section.Tables {
+			hasFloorPlan = true
+			if table.IsActive && table.Seats >= req.PartySize {
+				tables = append(tables, table)
+			}
+		}
+	}
+	sort.SliceStable(tables, func(i, j int) bool { return tables[i].Seats < tables[j].Seats })
+
+	now := time.Now()
+	slots := make([]*AvailabilitySlot, 0)
+	for start := day; start.Before(dayEnd); start = start.Add(pacing.Slot) {
+		if start.Before(now) || !isOpenAt(hours, start) {
+			continue
+		}
+		end := start.Add(duration)
+		slot := &AvailabilitySlot{StartTime: start, EndTime: end, Tables: []string{}}
+
+		coversLeft := true
+		if pacing.MaxCovers > 0 {
+			slotStart := start.Truncate(pacing.Slot)
+			remaining := pacing.MaxCovers
+			for _, reservation := range reservations {
+				if !reservation.StartTime.Before(slotStart) && reservation.StartTime.Before(slotStart.Add(pacing.Slot)) {
+					remaining -= reservation.NumberOfGuests
+				}
+			}
+			if remaining < 0 {
+				remaining = 0
+			}
+			slot.RemainingCovers = &remaining
+			coversLeft = remaining >= req.PartySize
+		}
+
+		for _, table := range tables {
+			if tableFree(table.Number, start, end, pacing.TableBuffer, reservations, events) {
+				slot.Tables = append(slot.Tables, table.Number)
+			}
+		}
+		if hasFloorPlan {
+			slot.Available = coversLeft && len(slot.Tables) > 0
+		} else {
+			slot.Available = coversLeft && !venueBlocked(start, end, events)
+		}
+		slots = append(slots, slot)
+	}
+
+	return &ReservationAvailability{
+		Date:            req.Date,
+		PartySize:       req.PartySize,
+		DurationMinutes: int(duration / time.Minute),
+		Slots:           slots,
+	}, nil
+}
+
+// tableFree reports whether a table has no reservation within the turnover buffer of [start, end)
+// and isn't blocked by a private event then
+func tableFree(number string, start, end time.Time, buffer time.Duration, reservations []models.Reservation, events []models.PrivateEvent) bool {
+	for _, reservation := range reservations {
+		if reservation.TableNumber == number &&
+			reservation.StartTime.Before(end.Add(buffer)) && reservation.EndTime.After(start.Add(-buffer)) {
+			return false
+		}
+	}
+	for i := range events {
+		event := &events[i]
+		if !event.StartTime.Before(end) || !event.EndTime.After(start) {
+			continue
+		}
+		if event.FullVenue {
+			return false
+		}
+		for _, table := range event.Tables {
+			if table.TableNumber == number {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// venueBlocked reports whether a full-venue private event overlaps [start, end)
+func venueBlocked(start, end time.Time, events []models.PrivateEvent) bool {
+	for _, event := range events {
+		if event.FullVenue && event.StartTime.Before(end) && event.EndTime.After(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/metrics"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// ChannelBookingRequest is a booking written by an external booking channel
+type ChannelBookingRequest struct {
+	ExternalID      string    `json:"external_id" binding:"required,max=100"` // The channel's booking ID; retried writes return the same reservation
+	StartTime       time.Time `json:"start_time" binding:"required"`          // One of the available start times
+	DurationMinutes int       `json:"duration_minutes" binding:"omitempty,min=15,max=720"`
+	PartySize       int       `json:"party_size" binding:"required,min=1"`
+	GuestName       string    `json:"guest_name" binding:"max=200"`
+	GuestPhone      string    `json:"guest_phone" binding:"max=50"`
+	GuestEmail      string    `json:"guest_email" binding:"omitempty,email,max=255"`
+	Notes           string    `json:"notes" binding:"max=1000"`
+}
+
+// CreateChannelBooking books a reservation for a booking channel at one of the available start times, seating the party
+// at the smallest free table. Bookings are confirmed right away and attributed to the channel's user, with the guest's
+// name in the notes and contact details on the reservation. Returns false with the existing reservation when the booking was already written
+func (s *ReservationService) CreateChannelBooking(ctx context.Context, channel *models.BookingChannel, req *ChannelBookingRequest) (*models.Reservation, bool, error) {
+	existing, err := s.reservationRepo.GetByExternalIDWithContext(ctx, channel.RestaurantID, channel.Provider, req.ExternalID)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	settings, err := s.settings(ctx, channel.RestaurantID)
+	if err != nil {
+		return nil, false, err
+	}
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	availability, err := s.SearchAvailability(ctx, channel.RestaurantID, &AvailabilityRequest{
+		Date:            req.StartTime.In(location).Format("2006-01-02"),
+		PartySize:       req.PartySize,
+		DurationMinutes: req.DurationMinutes,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Channel bookings need a table to seat the party, so restaurants without a floor plan can't take them
+	var slot *AvailabilitySlot
+	for _, candidate := range availability.Slots {
+		if candidate.StartTime.Equal(req.StartTime) && candidate.Available && len(candidate.Tables) > 0 {
+			slot = candidate
+			break
+		}
+	}
+	if slot == nil {
+		return nil, false, apperrors.Conflict(apperrors.CodeTableUnavailable, "no table is available at the requested time")
+	}
+
+	externalID := req.ExternalID
+	reservation := &models.Reservation{
+		RestaurantID:   channel.RestaurantID,
+		UserID:         channel.ChannelUserID,
+		TableNumber:    slot.Tables[0],
+		StartTime:      slot.StartTime,
+		EndTime:        slot.EndTime,
+		NumberOfGuests: req.PartySize,
+		Status:         "confirmed",
+		Notes:          channelBookingNotes(req),
+		GuestEmail:     strings.TrimSpace(req.GuestEmail),
+		GuestPhone:     strings.TrimSpace(req.GuestPhone),
+		Source:         channel.Provider,
+		ExternalID:     &externalID,
+	}
+
+	// The overlap constraint and the booking checks under lock are the source of truth under concurrent bookings
+	if err := s.reservationRepo.CreateWithContext(ctx, reservation, reservationPacing(settings)); err != nil {
+		// A retried write may have booked it first (unique external ID)
+		if existing, lookupErr := s.reservationRepo.GetByExternalIDWithContext(ctx, channel.RestaurantID, channel.Provider, req.ExternalID); lookupErr == nil {
+			return existing, false, nil
+		}
+		return nil, false, translateBookingError(err)
+	}
+
+	metrics.IncrementReservationsCreated(strconv.FormatUint(uint64(channel.RestaurantID), 10), reservation.Status)
+	s.notifications.NotifyNewReservation(ctx, reservation)
+
+	return reservation, true, nil
+}
+
+// GetChannelBooking retrieves a booking channel's reservation by the channel's booking ID
+func (s *ReservationService) GetChannelBooking(ctx context.Context, channel *models.BookingChannel, externalID string) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByExternalIDWithContext(ctx, channel.RestaurantID, channel.Provider, externalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeReservationNotFound, "reservation not found")
+		}
+		return nil, err
+	}
+	return reservation, nil
+}
+
+// CancelChannelBooking cancels a booking channel's reservation at the channel's request
+// The cancellation came from the channel, so it isn't pushed back to it
+func (s *ReservationService) CancelChannelBooking(ctx context.Context, channel *models.BookingChannel, externalID string) (*models.Reservation, error) {
+	reservation, err := s.GetChannelBooking(ctx, channel, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reservation.Status {
+	case "cancelled":
+		return reservation, nil
+	case "completed":
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "cannot cancel a completed reservation")
+	}
+
+	reservation.Status = "cancelled"
+	if err := s.reservationRepo.UpdateWithContext(ctx, reservation, repositories.ReservationPacing{}); err != nil {
+		return nil, translateBookingError(err)
+	}
+	return reservation, nil
+}
+
+// channelBookingNotes combines the guest's name and notes, as the channel user is shared by all channel guests
+// The guest's email and phone are kept encrypted on the reservation instead
+func channelBookingNotes(req *ChannelBookingRequest) string {
+	var parts []string
+	if name := strings.TrimSpace(req.GuestName); name != "" {
+		parts = append(parts, "Guest: "+name)
+	}
+	if notes := strings.TrimSpace(req.Notes); notes != "" {
+		parts = append(parts, notes)
+	}
+	return strings.Join(parts, "\n")
+}
@@ -0,0 +1,132 @@
+//go:build integration
+
+package services_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/container"
+	tenantctx "restaurant-backend/internal/ctx"
+	"restaurant-backend/internal/database"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/pii"
+	"restaurant-backend/internal/services"
+)
+
+// concurrentBookings is how many guests try to book the same table and slot at once
+const concurrentBookings = 20
+
+// TestCreateReservationConcurrentBookings books one table for the same slot from many goroutines at once
+// and checks exactly one booking wins while every other one gets the table conflict, not a raw 500
+// Needs a migrated test database configured like the server (DB_* variables): go test -tags integration
+func TestCreateReservationConcurrentBookings(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if err := logger.Initialize(cfg.Environment); err != nil {
+		t.Fatalf("failed to initialize logger: %v", err)
+	}
+	if err := pii.Setup(context.Background(), cfg); err != nil {
+		t.Fatalf("failed to initialize PII encryption: %v", err)
+	}
+	db, err := database.NewConnection(cfg)
+	if err != nil {
+		t.Fatalf("failed to connect to the test database: %v", err)
+	}
+	if err := database.RunMigrations(db, cfg); err != nil {
+		t.Fatalf("failed to run migrations: %v", err)
+	}
+	deps := container.New(cfg, db)
+
+	suffix := time.Now().UnixNano()
+	restaurant := &models.Restaurant{
+		Name:       "Concurrency Test",
+		Email:      fmt.Sprintf("concurrency-%d@example.com", suffix),
+		Status:     models.RestaurantStatusActive,
+		Visibility: models.RestaurantVisibilityPublic,
+	}
+	if err := db.Create(restaurant).Error; err != nil {
+		t.Fatalf("failed to create restaurant: %v", err)
+	}
+	guest := &models.User{
+		RestaurantID: restaurant.ID,
+		Email:        fmt.Sprintf("guest-%d@example.com", suffix),
+		PasswordHash: "-",
+		Role:         "Client",
+		IsActive:     true,
+	}
+	if err := db.Create(guest).Error; err != nil {
+		t.Fatalf("failed to create guest: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM reservations WHERE restaurant_id = ?", restaurant.ID)
+		db.Exec("DELETE FROM customers WHERE restaurant_id = ?", restaurant.ID)
+		db.Exec("DELETE FROM notifications WHERE restaurant_id = ?", restaurant.ID)
+		db.Exec("DELETE FROM users WHERE restaurant_id = ?", restaurant.ID)
+		db.Exec("DELETE FROM restaurants WHERE id = ?", restaurant.ID)
+	})
+
+	ctx := tenantctx.WithTenant(context.Background(), tenantctx.Tenant{
+		UserID:       guest.ID,
+		RestaurantID: restaurant.ID,
+		Role:         "Admin",
+	})
+	start := time.Now().Add(48 * time.Hour).Truncate(time.Hour)
+
+	var wg sync.WaitGroup
+	errs := make([]error, concurrentBookings)
+	ready := make(chan struct{})
+	for i := 0; i < concurrentBookings; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-ready
+			_, errs[i] = deps.Reservation.CreateReservation(ctx, &services.CreateReservationRequest{
+				UserID:         guest.ID,
+				TableNumber:    "T1",
+				StartTime:      start,
+				EndTime:        start.Add(90 * time.Minute),
+				NumberOfGuests: 2,
+			}, restaurant.ID)
+		}(i)
+	}
+	close(ready)
+	wg.Wait()
+
+	booked := 0
+	for i, err := range errs {
+		if err == nil {
+			booked++
+			continue
+		}
+		var appErr *apperrors.Error
+		if !errors.As(err, &appErr) {
+			t.Errorf("booking %d: got %v, want the table conflict error", i, err)
+			continue
+		}
+		if appErr.Status != http.StatusConflict || appErr.Code != apperrors.CodeTableUnavailable {
+			t.Errorf("booking %d: got %d %s, want %d %s", i, appErr.Status, appErr.Code, http.StatusConflict, apperrors.CodeTableUnavailable)
+		}
+	}
+	if booked != 1 {
+		t.Errorf("got %d successful bookings, want exactly 1", booked)
+	}
+
+	var stored int64
+	if err := db.Model(&models.Reservation{}).Where("restaurant_id = ? AND table_number = ?", restaurant.ID, "T1").Count(&stored).Error; err != nil {
+		t.Fatalf("failed to count reservations: %v", err)
+	}
+	if stored != 1 {
+		t.Errorf("got %d stored reservations, want 1", stored)
+	}
+}
@@ -3,21 +3,65 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/metrics"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
 // ReservationService handles reservation business logic
 type ReservationService struct {
 	reservationRepo *repositories.ReservationRepository
+	restaurantRepo  *repositories.RestaurantRepository
+	settingsRepo    *repositories.RestaurantSettingsRepository
+	hoursRepo       *repositories.OpeningHoursRepository
+	floorPlanRepo   *repositories.FloorPlanRepository
+	eventRepo       *repositories.PrivateEventRepository
+	emailService    Mailer
+	customers       *CustomerService
+	notifications   *NotificationService
+	sms             *SMSService
+	preferences     *NotificationPreferenceService
+	channels        *BookingChannelService
 }
 
 // NewReservationService creates a new ReservationService instance
-func NewReservationService(reservationRepo *repositories.ReservationRepository) *ReservationService {
+func NewReservationService(
+	reservationRepo *repositories.ReservationRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+	hoursRepo *repositories.OpeningHoursRepository,
+	floorPlanRepo *repositories.FloorPlanRepository,
+	eventRepo *repositories.PrivateEventRepository,
+	emailService Mailer,
+	customers *CustomerService,
+	notifications *NotificationService,
+	sms *SMSService,
+	preferences *NotificationPreferenceService,
+	channels *BookingChannelService,
+) *ReservationService {
 	return &ReservationService{
 		reservationRepo: reservationRepo,
+		restaurantRepo:  restaurantRepo,
+		settingsRepo:    settingsRepo,
+		hoursRepo:       hoursRepo,
+		floorPlanRepo:   floorPlanRepo,
+		eventRepo:       eventRepo,
+		emailService:    emailService,
+		customers:       customers,
+		notifications:   notifications,
+		sms:             sms,
+		preferences:     preferences,
+		channels:        channels,
 	}
 }
 
@@ -31,25 +75,33 @@ type CreateReservationRequest struct {
 	Notes          string    `json:"notes"`
 }
 
-// CreateReservation creates a new reservation with availability checking
+// CreateReservation creates a new reservation with availability checking and the restaurant's pacing rules
 func (s *ReservationService) CreateReservation(ctx context.Context, req *CreateReservationRequest, restaurantID uint) (*models.Reservation, error) {
-	// Validate time range
-	if req.EndTime.Before(req.StartTime) {
-		return nil, errors.New("end time must be after start time")
+	// Validate time range (zero-length bookings would never conflict with the overlap constraint)
+	if !req.EndTime.After(req.StartTime) {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "end time must be after start time")
 	}
 
 	if req.StartTime.Before(time.Now()) {
-		return nil, errors.New("reservation cannot be in the past")
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "reservation cannot be in the past")
+	}
+
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPartySize(settings, req.NumberOfGuests); err != nil {
+		return nil, err
 	}
 
-	// Check table availability
+	// Fast path for a friendly error; the overlap constraint below is what makes booking atomic
 	isAvailable, err := s.checkTableAvailability(ctx, restaurantID, req.TableNumber, req.StartTime, req.EndTime)
 	if err != nil {
 		return nil, err
 	}
 
 	if !isAvailable {
-		return nil, errors.New("table is not available at the requested time")
+		return nil, apperrors.Conflict(apperrors.CodeTableUnavailable, "table is not available at the requested time")
 	}
 
 	// Create reservation
@@ -64,48 +116,187 @@ func (s *ReservationService) CreateReservation(ctx context.Context, req *CreateR
 		Notes:          req.Notes,
 	}
 
-	if err := s.reservationRepo.CreateWithContext(ctx, reservation); err != nil {
-		return nil, err
+	// The overlap constraint and the booking checks under lock are the source of truth under concurrent bookings
+	if err := s.reservationRepo.CreateWithContext(ctx, reservation, reservationPacing(settings)); err != nil {
+		return nil, translateBookingError(err)
 	}
 
+	metrics.IncrementReservationsCreated(strconv.FormatUint(uint64(restaurantID), 10), reservation.Status)
+
+	if s.customers != nil {
+		s.customers.SyncUser(ctx, restaurantID, reservation.UserID)
+	}
+
+	s.notifications.NotifyNewReservation(ctx, reservation)
+
 	return reservation, nil
 }
 
-// UpdateReservationStatusRequest represents reservation status update request
-type UpdateReservationStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=pending confirmed cancelled completed"`
+// UpdateReservationRequest represents a reservation update request
+// Only the provided fields are changed; time, table and guest changes are re-validated for availability
+type UpdateReservationRequest struct {
+	Status         *string    `json:"status" binding:"omitempty,oneof=pending confirmed cancelled completed"`
+	TableNumber    *string    `json:"table_number" binding:"omitempty,min=1"`
+	StartTime      *time.Time `json:"start_time"`
+	EndTime        *time.Time `json:"end_time"`
+	NumberOfGuests *int       `json:"number_of_guests" binding:"omitempty,min=1"`
+	Notes          *string    `json:"notes"`
 }
 
-// UpdateReservationStatus updates the status of a reservation
-func (s *ReservationService) UpdateReservationStatus(reservationID uint, req *UpdateReservationStatusRequest) (*models.Reservation, error) {
-	reservation, err := s.reservationRepo.GetByIDWithContext(context.Background(), reservationID)
+// UpdateReservationWithCtx changes the status, time, table, party size or notes of a reservation
+// The guest is emailed when the status or booking details change, and texted once confirmed;
+// cancelling a booking channel's reservation tells the channel
+func (s *ReservationService) UpdateReservationWithCtx(ctx context.Context, reservationID uint, restaurantID uint, req *UpdateReservationRequest) (*models.Reservation, error) {
+	reservation, err := s.reservationRepo.GetByIDForRestaurant(ctx, reservationID, restaurantID)
 	if err != nil {
-		return nil, errors.New("reservation not found")
+		return nil, apperrors.NotFound(apperrors.CodeReservationNotFound, "reservation not found")
 	}
 
-	reservation.Status = req.Status
+	// Pacing only applies to rebookings, so tightened rules don't block status changes of existing reservations
+	var pacing repositories.ReservationPacing
+	rebooked := req.TableNumber != nil || req.StartTime != nil || req.EndTime != nil || req.NumberOfGuests != nil
+	if rebooked {
+		settings, err := s.settings(ctx, restaurantID)
+		if err != nil {
+			return nil, err
+		}
+		if req.NumberOfGuests != nil {
+			if err := checkPartySize(settings, *req.NumberOfGuests); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.applyBookingChanges(ctx, reservation, req); err != nil {
+			return nil, err
+		}
+		pacing = reservationPacing(settings)
+	}
 
-	if err := s.reservationRepo.UpdateWithContext(context.Background(), reservation); err != nil {
-		return nil, err
+	statusChanged := req.Status != nil && *req.Status != reservation.Status
+	if req.Status != nil {
+		reservation.Status = *req.Status
+	}
+	if req.Notes != nil {
+		reservation.Notes = *req.Notes
+	}
+
+	// The overlap constraint and the booking checks under lock are the source of truth under concurrent bookings
+	if err := s.reservationRepo.UpdateWithContext(ctx, reservation, pacing); err != nil {
+		return nil, translateBookingError(err)
+	}
+
+	if rebooked || statusChanged {
+		s.notifyGuest(ctx, reservation, rebooked)
+	}
+	if statusChanged && reservation.Status == "confirmed" {
+		s.sms.NotifyReservationConfirmed(ctx, reservation)
+	}
+	if statusChanged && reservation.Status == "cancelled" && reservation.Source != models.ReservationSourceDirect {
+		s.channels.PushCancellation(ctx, reservation)
+	}
+	if statusChanged && s.customers != nil {
+		s.customers.SyncUser(ctx, reservation.RestaurantID, reservation.UserID)
 	}
 
 	return reservation, nil
 }
 
-// UpdateReservationStatusWithCtx updates reservation status using provided context
-func (s *ReservationService) UpdateReservationStatusWithCtx(ctx context.Context, reservationID uint, req *UpdateReservationStatusRequest) (*models.Reservation, error) {
-	reservation, err := s.reservationRepo.GetByIDWithContext(ctx, reservationID)
+// CancelReservation cancels a reservation of the restaurant, telling the guest or booking channel like any other
+// cancellation; cancelling it again changes nothing
+func (s *ReservationService) CancelReservation(ctx context.Context, reservationID, restaurantID uint) error {
+	status := "cancelled"
+	_, err := s.UpdateReservationWithCtx(ctx, reservationID, restaurantID, &UpdateReservationRequest{Status: &status})
+	return err
+}
+
+// applyBookingChanges validates and applies time, table and party size changes to a reservation
+func (s *ReservationService) applyBookingChanges(ctx context.Context, reservation *models.Reservation, req *UpdateReservationRequest) error {
+	if reservation.Status == "cancelled" || reservation.Status == "completed" {
+		return apperrors.Conflict(apperrors.CodeConflict, "cannot modify a "+reservation.Status+" reservation")
+	}
+
+	if req.TableNumber != nil {
+		reservation.TableNumber = *req.TableNumber
+	}
+	if req.StartTime != nil {
+		reservation.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		reservation.EndTime = *req.EndTime
+	}
+	if req.NumberOfGuests != nil {
+		reservation.NumberOfGuests = *req.NumberOfGuests
+	}
+
+	// Validate time range (zero-length bookings would never conflict with the overlap constraint)
+	if !reservation.EndTime.After(reservation.StartTime) {
+		return apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "end time must be after start time")
+	}
+
+	if reservation.StartTime.Before(time.Now()) {
+		return apperrors.BadRequest(apperrors.CodeInvalidTimeRange, "reservation cannot be in the past")
+	}
+
+	// Fast path for a friendly error, ignoring the reservation's own slot
+	conflicting, err := s.reservationRepo.GetByTableAndTimeWithContext(ctx, reservation.RestaurantID, reservation.TableNumber, reservation.StartTime, reservation.EndTime)
 	if err != nil {
-		return nil, errors.New("reservation not found")
+		return err
+	}
+	for _, other := range conflicting {
+		if other.ID != reservation.ID {
+			return apperrors.Conflict(apperrors.CodeTableUnavailable, "table is not available at the requested time")
+		}
 	}
 
-	reservation.Status = req.Status
+	return nil
+}
 
-	if err := s.reservationRepo.UpdateWithContext(ctx, reservation); err != nil {
-		return nil, err
+// notifyGuest emails the guest about a changed reservation, unless they turned reservation emails off
+// Guests of booking channel reservations hear from the channel instead
+// Note: Email failure should not roll back the update
+func (s *ReservationService) notifyGuest(ctx context.Context, reservation *models.Reservation, rebooked bool) {
+	if s.emailService == nil || reservation.User.Email == "" || reservation.Source != models.ReservationSourceDirect {
+		return
+	}
+	if !s.preferences.Allows(ctx, reservation.RestaurantID, reservation.UserID, models.NotificationEventReservationUpdates, models.NotificationChannelEmail) {
+		return
 	}
 
-	return reservation, nil
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, reservation.RestaurantID)
+	if err != nil {
+		logger.Warn("Failed to load restaurant for reservation email",
+			zap.Uint("reservation_id", reservation.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	statusMessage := "Your reservation is now " + reservation.Status + "."
+	if rebooked {
+		statusMessage = fmt.Sprintf("Your reservation has been updated: table %s for %d guests.", reservation.TableNumber, reservation.NumberOfGuests)
+	}
+	cancellationReason := ""
+	if reservation.Status == "cancelled" {
+		cancellationReason = reservation.Notes
+	}
+
+	if err := s.emailService.SendReservationStatusUpdateEmail(
+		ctx,
+		reservation.RestaurantID,
+		reservation.User.Email,
+		strings.TrimSpace(reservation.User.FirstName+" "+reservation.User.LastName),
+		restaurant.Name,
+		reservation.ID,
+		reservation.Status,
+		statusMessage,
+		reservation.StartTime.Format("2006-01-02"),
+		reservation.StartTime.Format("15:04")+" - "+reservation.EndTime.Format("15:04"),
+		cancellationReason,
+	); err != nil {
+		logger.Warn("Failed to send reservation update email",
+			zap.Uint("reservation_id", reservation.ID),
+			zap.Error(err),
+		)
+	}
 }
 
 // checkTableAvailability checks if a table is available at the given time range
@@ -119,3 +310,44 @@ func (s *ReservationService) checkTableAvailability(ctx context.Context, restaur
 	// If there are any conflicting reservations, table is not available
 	return len(conflictingReservations) == 0, nil
 }
+
+// settings loads the restaurant's settings, falling back to default settings
+func (s *ReservationService) settings(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+	return settings, nil
+}
+
+// reservationPacing returns the restaurant's pacing rules
+func reservationPacing(settings *models.RestaurantSettings) repositories.ReservationPacing {
+	return repositories.ReservationPacing{
+		Slot:        settings.ReservationSlot(),
+		MaxCovers:   settings.ReservationMaxCoversPerSlot,
+		TableBuffer: time.Duration(settings.ReservationTableBufferMinutes) * time.Minute,
+	}
+}
+
+// checkPartySize rejects parties larger than the restaurant takes reservations for
+func checkPartySize(settings *models.RestaurantSettings, guests int) error {
+	if settings.ReservationMaxPartySize > 0 && guests > settings.ReservationMaxPartySize {
+		return apperrors.BadRequest(apperrors.CodePartyTooLarge,
+			fmt.Sprintf("reservations are limited to %d guests; contact the restaurant for larger parties", settings.ReservationMaxPartySize))
+	}
+	return nil
+}
+
+// translateBookingError maps the booking errors of the reservation repository to API errors
+func translateBookingError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrReservationConflict):
+		return apperrors.Conflict(apperrors.CodeTableUnavailable, "table is not available at the requested time")
+	case errors.Is(err, repositories.ErrReservationSlotFull):
+		return apperrors.Conflict(apperrors.CodeReservationSlotFull, "no more reservations can start at the requested time; choose another time")
+	}
+	return err
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+)
+
+// Directory search limits
+const (
+	directoryDefaultRadiusKm = 10.0
+	directoryMaxRadiusKm     = 100.0
+)
+
+// RestaurantDirectoryService serves the public restaurant directory and profiles a consumer marketplace is built on
+type RestaurantDirectoryService struct {
+	restaurantRepo *repositories.RestaurantRepository
+	hoursRepo      *repositories.OpeningHoursRepository
+	reviewRepo     *repositories.ReviewRepository
+	zoneRepo       *repositories.DeliveryZoneRepository
+	settings       *RestaurantSettingsService
+}
+
+// NewRestaurantDirectoryService creates a new RestaurantDirectoryService instance
+func NewRestaurantDirectoryService(
+	restaurantRepo *repositories.RestaurantRepository,
+	hoursRepo *repositories.OpeningHoursRepository,
+	reviewRepo *repositories.ReviewRepository,
+	zoneRepo *repositories.DeliveryZoneRepository,
+	settings *RestaurantSettingsService,
+) *RestaurantDirectoryService {
+	return &RestaurantDirectoryService{
+		restaurantRepo: restaurantRepo,
+		hoursRepo:      hoursRepo,
+		reviewRepo:     reviewRepo,
+		zoneRepo:       zoneRepo,
+		settings:       settings,
+	}
+}
+
+// DirectorySearchRequest is a directory search; Latitude and Longitude search within RadiusKm of a point
+type DirectorySearchRequest struct {
+	Name      string   `form:"q" binding:"max=100"`
+	City      string   `form:"city" binding:"max=100"`
+	Cuisine   string   `form:"cuisine" binding:"max=50"`
+	Latitude  *float64 `form:"lat" binding:"required_with=Longitude,omitempty,min=-90,max=90"`
+	Longitude *float64 `form:"lng" binding:"required_with=Latitude,omitempty,min=-180,max=180"`
+	RadiusKm  float64  `form:"radius_km" binding:"omitempty,gt=0,max=100"` // Defaults to 10
+}
+
+// DirectoryList is a page of the restaurant directory
+type DirectoryList struct {
+	Restaurants []repositories.RestaurantDirectoryEntry `json:"restaurants"`
+	Total       int64                                   `json:"total"`
+	Limit       int                                     `json:"limit"`
+	Offset      int                                     `json:"offset"`
+}
+
+// RestaurantProfile is the public profile of a restaurant
+type RestaurantProfile struct {
+	ID           uint                       `json:"id"`
+	Name         string                     `json:"name"`
+	Description  string                     `json:"description"`
+	Address      string                     `json:"address"`
+	Phone        string                     `json:"phone"`
+	City         string                     `json:"city"`
+	Cuisines     []string                   `json:"cuisines"`
+	Latitude     *float64                   `json:"latitude,omitempty"`
+	Longitude    *float64                   `json:"longitude,omitempty"`
+	LogoURL      string                     `json:"logo_url"`
+	PhotoURLs    []string                   `json:"photo_urls"`
+	Currency     string                     `json:"currency"`
+	TimeZone     string                     `json:"time_zone"`
+	OpeningHours []models.OpeningHours      `json:"opening_hours"` // In the restaurant's time zone
+	Rating       repositories.RatingSummary `json:"rating"`
+	OrderTypes   []string                   `json:"order_types"` // Accepted fulfillment types (OrderChannel*)
+}
+
+// Search lists the publicly visible restaurants matching a search
+func (s *RestaurantDirectoryService) Search(ctx context.Context, search *DirectorySearchRequest, limit, offset int) (*DirectoryList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	radiusKm := search.RadiusKm
+	if radiusKm <= 0 {
+		radiusKm = directoryDefaultRadiusKm
+	}
+	if radiusKm > directoryMaxRadiusKm {
+		radiusKm = directoryMaxRadiusKm
+	}
+
+	restaurants, total, err := s.restaurantRepo.ListDirectoryWithContext(ctx, repositories.RestaurantDirectoryFilter{
+		Name:         search.Name,
+		City:         search.City,
+		Cuisine:      search.Cuisine,
+		Latitude:     search.Latitude,
+		Longitude:    search.Longitude,
+		RadiusMeters: radiusKm * 1000,
+	}, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if restaurants == nil {
+		restaurants = []repositories.RestaurantDirectoryEntry{}
+	}
+
+	return &DirectoryList{
+		Restaurants: restaurants,
+		Total:       total,
+		Limit:       limit,
+		Offset:      offset,
+	}, nil
+}
+
+// GetProfile returns the public profile of a publicly visible restaurant
+func (s *RestaurantDirectoryService) GetProfile(ctx context.Context, restaurantID uint) (*RestaurantProfile, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil || !restaurant.IsPubliclyVisible() {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	hours, err := s.hoursRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	rating, err := s.reviewRepo.GetRestaurantSummaryWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pickup and delivery are online orders; delivery also needs an area to deliver to
+	orderTypes := []string{models.OrderChannelDineIn}
+	if settings.OnlineOrderingEnabled {
+		orderTypes = append(orderTypes, models.OrderChannelPickup)
+		zones, err := s.zoneRepo.GetByRestaurantIDWithContext(ctx, restaurantID, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(zones) > 0 {
+			orderTypes = append(orderTypes, models.OrderChannelDelivery)
+		}
+	}
+
+	return &RestaurantProfile{
+		ID:           restaurant.ID,
+		Name:         restaurant.Name,
+		Description:  restaurant.Description,
+		Address:      restaurant.Address,
+		Phone:        restaurant.Phone,
+		City:         settings.City,
+		Cuisines:     settings.Cuisines,
+		Latitude:     settings.Latitude,
+		Longitude:    settings.Longitude,
+		LogoURL:      settings.LogoURL,
+		PhotoURLs:    settings.PhotoURLs,
+		Currency:     settings.Currency,
+		TimeZone:     settings.TimeZone,
+		OpeningHours: hours,
+		Rating:       *rating,
+		OrderTypes:   orderTypes,
+	}, nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+const (
+	healthTrendWindow      = 14 * 24 * time.Hour // Orders of this window are compared with the one before
+	healthLoginFresh       = 7 * 24 * time.Hour  // Logins this recent score full marks
+	healthLoginStale       = 30 * 24 * time.Hour // Logins older than this score nothing and raise a flag
+	healthEmailFailureRate = 0.2                 // Failure rate that raises the email flag
+	healthMenuIncomplete   = 50                  // Menu score below which the menu flag is raised
+	healthDecliningRatio   = 0.5                 // Recent to previous orders ratio below which orders are declining
+)
+
+// Health score component weights, adding up to 1
+const (
+	healthWeightActivity = 0.4
+	healthWeightMenu     = 0.2
+	healthWeightLogin    = 0.2
+	healthWeightEmail    = 0.2
+)
+
+// RestaurantHealthService scores the health of active restaurants and flags those at risk of churning
+type RestaurantHealthService struct {
+	healthRepo *repositories.RestaurantHealthRepository
+	quietAfter time.Duration
+}
+
+// NewRestaurantHealthService creates a new RestaurantHealthService instance; restaurants without orders
+// for quietDays are flagged as quiet
+func NewRestaurantHealthService(healthRepo *repositories.RestaurantHealthRepository, quietDays int) *RestaurantHealthService {
+	return &RestaurantHealthService{
+		healthRepo: healthRepo,
+		quietAfter: time.Duration(quietDays) * 24 * time.Hour,
+	}
+}
+
+// HealthResult summarizes a health score run
+type HealthResult struct {
+	Scored     int `json:"scored"`
+	Flagged    int `json:"flagged"`
+	NewlyQuiet int `json:"newly_quiet"`
+}
+
+// ListForKAM lists the health of the KAM's restaurants, lowest score first, optionally only flagged ones
+func (s *RestaurantHealthService) ListForKAM(ctx context.Context, kamID uint, flaggedOnly bool) ([]models.RestaurantHealth, error) {
+	scores, err := s.healthRepo.ListByKAMWithContext(ctx, kamID, flaggedOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list restaurant health: %w", err)
+	}
+	return scores, nil
+}
+
+// HealthJob is the scheduled job that recomputes every active restaurant's health score;
+// a zero interval disables it
+func (s *RestaurantHealthService) HealthJob(interval time.Duration) Job {
+	return Job{Name: "restaurant_health", Interval: interval, Run: s.runHealthJob}
+}
+
+// runHealthJob recomputes the health scores and logs restaurants that just went quiet
+func (s *RestaurantHealthService) runHealthJob(ctx context.Context) error {
+	result, err := s.Compute(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to compute restaurant health: %w", err)
+	}
+	logger.Info("Restaurant health scores computed",
+		zap.Int("scored", result.Scored),
+		zap.Int("flagged", result.Flagged),
+		zap.Int("newly_quiet", result.NewlyQuiet),
+	)
+	return nil
+}
+
+// Compute recomputes the health score and flags of every active restaurant
+func (s *RestaurantHealthService) Compute(ctx context.Context) (*HealthResult, error) {
+	now := time.Now().UTC()
+	recentStart := now.Add(-healthTrendWindow)
+	inputs, err := s.healthRepo.ListInputsWithContext(ctx, recentStart.Add(-healthTrendWindow), recentStart, repositories.UsagePeriodStart(now))
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather health inputs: %w", err)
+	}
+	previous, err := s.healthRepo.ListWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load previous health: %w", err)
+	}
+
+	result := &HealthResult{Scored: len(inputs)}
+	scores := make([]models.RestaurantHealth, 0, len(inputs))
+	for _, in := range inputs {
+		health := s.score(in, now)
+		before, scoredBefore := previous[in.RestaurantID]
+		if len(health.Flags) > 0 {
+			result.Flagged++
+			health.FlaggedAt = &now
+			if scoredBefore && before.FlaggedAt != nil {
+				health.FlaggedAt = before.FlaggedAt
+			}
+		}
+		if health.HasFlag(models.HealthFlagQuiet) && !before.HasFlag(models.HealthFlagQuiet) {
+			result.NewlyQuiet++
+			fields := []zap.Field{zap.Uint("restaurant_id", in.RestaurantID), zap.Timep("last_order_at", in.LastOrderAt)}
+			if in.KAMID != nil {
+				fields = append(fields, zap.Uint("kam_id", *in.KAMID))
+			}
+			logger.Warn("Restaurant went quiet", fields...)
+		}
+		scores = append(scores, health)
+	}
+
+	if err := s.healthRepo.SaveAllWithContext(ctx, scores, now); err != nil {
+		return nil, fmt.Errorf("failed to save restaurant health: %w", err)
+	}
+	return result, nil
+}
+
+// score computes a restaurant's health from its inputs
+func (s *RestaurantHealthService) score(in repositories.RestaurantHealthInputs, now time.Time) models.RestaurantHealth {
+	health := models.RestaurantHealth{
+		RestaurantID:      in.RestaurantID,
+		ActivityScore:     activityScore(in.RecentOrders, in.PreviousOrders),
+		MenuScore:         ratioScore(in.CompleteMenuItems, in.MenuItems),
+		LoginScore:        loginScore(in.LastLoginAt, now),
+		EmailScore:        100,
+		RecentOrders:      in.RecentOrders,
+		PreviousOrders:    in.PreviousOrders,
+		LastOrderAt:       in.LastOrderAt,
+		LastLoginAt:       in.LastLoginAt,
+		MenuItems:         in.MenuItems,
+		CompleteMenuItems: in.CompleteMenuItems,
+		EmailsSent:        in.EmailsSent,
+		EmailsFailed:      in.EmailsFailed,
+		Flags:             []string{},
+		ComputedAt:        now,
+	}
+	emails := in.EmailsSent + in.EmailsFailed
+	if emails > 0 {
+		health.EmailScore = ratioScore(in.EmailsSent, emails)
+	}
+	health.Score = int(math.Round(
+		float64(health.ActivityScore)*healthWeightActivity +
+			float64(health.MenuScore)*healthWeightMenu +
+			float64(health.LoginScore)*healthWeightLogin +
+			float64(health.EmailScore)*healthWeightEmail,
+	))
+
+	// Quiet is measured from the last order, or from activation for restaurants that never had one
+	lastActive := in.CreatedAt
+	if in.ActivatedAt != nil {
+		lastActive = *in.ActivatedAt
+	}
+	if in.LastOrderAt != nil {
+		lastActive = *in.LastOrderAt
+	}
+	if now.Sub(lastActive) >= s.quietAfter {
+		health.Flags = append(health.Flags, models.HealthFlagQuiet)
+	}
+	if in.PreviousOrders > 0 && float64(in.RecentOrders) < float64(in.PreviousOrders)*healthDecliningRatio {
+		health.Flags = append(health.Flags, models.HealthFlagDeclining)
+	}
+	if in.LastLoginAt == nil || now.Sub(*in.LastLoginAt) >= healthLoginStale {
+		health.Flags = append(health.Flags, models.HealthFlagNoRecentLogin)
+	}
+	if emails > 0 && float64(in.EmailsFailed) >= float64(emails)*healthEmailFailureRate {
+		health.Flags = append(health.Flags, models.HealthFlagEmailFailures)
+	}
+	if health.MenuScore < healthMenuIncomplete {
+		health.Flags = append(health.Flags, models.HealthFlagIncompleteMenu)
+	}
+	return health
+}
+
+// activityScore rates the ordering trend: full marks when orders hold or grow, proportionally less as
+// they fall, nothing without recent orders
+func activityScore(recent, previous int64) int {
+	if recent == 0 {
+		return 0
+	}
+	if previous == 0 || recent >= previous {
+		return 100
+	}
+	return ratioScore(recent, previous)
+}
+
+// ratioScore returns part/total as a 0-100 score, 0 when total is zero
+func ratioScore(part, total int64) int {
+	if total == 0 {
+		return 0
+	}
+	return int(math.Round(float64(part) * 100 / float64(total)))
+}
+
+// loginScore rates login recency: full marks within a week, falling to nothing at 30 days
+func loginScore(lastLogin *time.Time, now time.Time) int {
+	if lastLogin == nil {
+		return 0
+	}
+	age := now.Sub(*lastLogin)
+	switch {
+	case age <= healthLoginFresh:
+		return 100
+	case age >= healthLoginStale:
+		return 0
+	default:
+		return int(math.Round(float64(healthLoginStale-age) * 100 / float64(healthLoginStale-healthLoginFresh)))
+	}
+}
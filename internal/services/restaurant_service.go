@@ -2,13 +2,15 @@ package services
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
 
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -16,14 +18,14 @@ import (
 type RestaurantService struct {
 	restaurantRepo *repositories.RestaurantRepository
 	userRepo       *repositories.UserRepository
-	emailService   *EmailService
+	emailService   Mailer
 }
 
 // NewRestaurantService creates a new RestaurantService instance
 func NewRestaurantService(
 	restaurantRepo *repositories.RestaurantRepository,
 	userRepo *repositories.UserRepository,
-	emailService *EmailService,
+	emailService Mailer,
 ) *RestaurantService {
 	return &RestaurantService{
 		restaurantRepo: restaurantRepo,
@@ -49,7 +51,7 @@ func (s *RestaurantService) RegisterRestaurant(ctx context.Context, req *Registe
 	// Check if restaurant with same email already exists
 	existing, _ := s.restaurantRepo.GetByEmailWithContext(ctx, req.Email)
 	if existing != nil {
-		return nil, errors.New("restaurant with this email already exists")
+		return nil, apperrors.Conflict(apperrors.CodeEmailTaken, "restaurant with this email already exists")
 	}
 
 	// Create restaurant with pending status
@@ -62,6 +64,7 @@ func (s *RestaurantService) RegisterRestaurant(ctx context.Context, req *Registe
 		Phone:        req.Phone,
 		Email:        req.Email,
 		Status:       models.RestaurantStatusPending,
+		Visibility:   models.RestaurantVisibilityHidden, // Soft launch: hidden until launched
 		ContactName:  req.ContactName,
 		ContactEmail: req.ContactEmail,
 		ContactPhone: req.ContactPhone,
@@ -82,18 +85,18 @@ func (s *RestaurantService) ActivateRestaurant(ctx context.Context, restaurantID
 	// Get restaurant
 	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
 	if err != nil {
-		return nil, errors.New("restaurant not found")
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
 	}
 
 	// Check if restaurant is already active
 	if restaurant.Status == models.RestaurantStatusActive {
-		return nil, errors.New("restaurant is already active")
+		return nil, apperrors.Conflict(apperrors.CodeConflict, "restaurant is already active")
 	}
 
 	// Verify the activating user is a KAM
 	activatingUser, err := s.userRepo.GetByIDWithContext(ctx, activatedBy)
 	if err != nil || activatingUser.Role != "KAM" {
-		return nil, errors.New("only KAM users can activate restaurants")
+		return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "only KAM users can activate restaurants")
 	}
 
 	// Generate secure temporary password for admin user
@@ -122,7 +125,7 @@ func (s *RestaurantService) ActivateRestaurant(ctx context.Context, restaurantID
 	// Check if user with this email already exists for this restaurant
 	existingUser, _ := s.userRepo.GetByEmailWithContext(ctx, restaurant.ContactEmail, restaurant.ID)
 	if existingUser != nil {
-		return nil, errors.New("admin user already exists for this restaurant")
+		return nil, apperrors.Conflict(apperrors.CodeEmailTaken, "admin user already exists for this restaurant")
 	}
 
 	// Create the admin user
@@ -142,7 +145,7 @@ func (s *RestaurantService) ActivateRestaurant(ctx context.Context, restaurantID
 	}
 
 	if err := s.restaurantRepo.UpdateWithContext(ctx, restaurant); err != nil {
-		return nil, err
+		return nil, versionConflict(err)
 	}
 
 	// Send welcome email with credentials
@@ -159,41 +162,162 @@ func (s *RestaurantService) ActivateRestaurant(ctx context.Context, restaurantID
 }
 
 // UpdateRestaurantStatus updates the status of a restaurant
-func (s *RestaurantService) UpdateRestaurantStatus(ctx context.Context, restaurantID uint, status models.RestaurantStatus) (*models.Restaurant, error) {
+// expectedVersion is the client's If-Match version; 0 skips the precondition
+func (s *RestaurantService) UpdateRestaurantStatus(ctx context.Context, restaurantID uint, status models.RestaurantStatus, expectedVersion int) (*models.Restaurant, error) {
 	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
 	if err != nil {
-		return nil, errors.New("restaurant not found")
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	if err := checkVersion(expectedVersion, restaurant.Version); err != nil {
+		return nil, err
 	}
 
 	restaurant.Status = status
 
 	if err := s.restaurantRepo.UpdateWithContext(ctx, restaurant); err != nil {
-		return nil, err
+		return nil, versionConflict(err)
 	}
 
 	return restaurant, nil
 }
 
 // AssignKAM assigns a Key Account Manager to a restaurant
-func (s *RestaurantService) AssignKAM(ctx context.Context, restaurantID uint, kamID uint) (*models.Restaurant, error) {
+// expectedVersion is the client's If-Match version; 0 skips the precondition
+func (s *RestaurantService) AssignKAM(ctx context.Context, restaurantID uint, kamID uint, expectedVersion int) (*models.Restaurant, error) {
 	// Verify KAM exists and is a KAM
 	kam, err := s.userRepo.GetByIDWithContext(ctx, kamID)
 	if err != nil || kam.Role != "KAM" {
-		return nil, errors.New("invalid KAM")
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid KAM")
 	}
 
 	// Get restaurant
 	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
 	if err != nil {
-		return nil, errors.New("restaurant not found")
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	if err := checkVersion(expectedVersion, restaurant.Version); err != nil {
+		return nil, err
 	}
 
 	// Assign KAM
 	restaurant.KAMID = &kamID
 
 	if err := s.restaurantRepo.UpdateWithContext(ctx, restaurant); err != nil {
+		return nil, versionConflict(err)
+	}
+
+	return restaurant, nil
+}
+
+// UpdateVisibilityRequest represents a restaurant visibility (soft launch) update
+type UpdateVisibilityRequest struct {
+	Visibility models.RestaurantVisibility `json:"visibility" binding:"required,oneof=public hidden"`
+	GoLiveAt   *time.Time                  `json:"go_live_at"` // Only used when hidden: schedules automatic launch
+}
+
+// UpdateVisibility hides a restaurant (optionally scheduling its go-live) or launches it immediately
+// expectedVersion is the client's If-Match version; 0 skips the precondition
+func (s *RestaurantService) UpdateVisibility(ctx context.Context, restaurantID uint, req *UpdateVisibilityRequest, expectedVersion int) (*models.Restaurant, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	if err := checkVersion(expectedVersion, restaurant.Version); err != nil {
 		return nil, err
 	}
 
+	if req.Visibility == models.RestaurantVisibilityPublic {
+		if err := s.launch(ctx, restaurant); err != nil {
+			return nil, err
+		}
+		return restaurant, nil
+	}
+
+	if req.GoLiveAt != nil && !req.GoLiveAt.After(time.Now()) {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "go_live_at must be in the future")
+	}
+
+	restaurant.Visibility = models.RestaurantVisibilityHidden
+	restaurant.GoLiveAt = req.GoLiveAt
+
+	if err := s.restaurantRepo.UpdateWithContext(ctx, restaurant); err != nil {
+		return nil, versionConflict(err)
+	}
+
 	return restaurant, nil
 }
+
+// LaunchDueRestaurants launches hidden restaurants whose scheduled go-live time has passed
+// Returns the number of restaurants launched
+func (s *RestaurantService) LaunchDueRestaurants(ctx context.Context) (int, error) {
+	restaurants, err := s.restaurantRepo.ListDueForLaunchWithContext(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	launched := 0
+	for i := range restaurants {
+		if err := s.launch(ctx, &restaurants[i]); err != nil {
+			logger.Warn("Failed to launch restaurant",
+				zap.Uint("restaurant_id", restaurants[i].ID),
+				zap.Error(err),
+			)
+			continue
+		}
+		launched++
+	}
+
+	return launched, nil
+}
+
+// LaunchJob is the scheduled job that launches restaurants with a due go-live time
+func (s *RestaurantService) LaunchJob(interval time.Duration) Job {
+	return Job{Name: "restaurant_launch", Interval: interval, Run: s.runLaunchJob}
+}
+
+// runLaunchJob launches the restaurants whose go-live time has passed
+func (s *RestaurantService) runLaunchJob(ctx context.Context) error {
+	launched, err := s.LaunchDueRestaurants(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to launch scheduled restaurants: %w", err)
+	}
+	if launched > 0 {
+		logger.Info("Launched scheduled restaurants", zap.Int("count", launched))
+	}
+	return nil
+}
+
+// launch makes a restaurant public and sends the announcement email on its first launch
+func (s *RestaurantService) launch(ctx context.Context, restaurant *models.Restaurant) error {
+	if restaurant.Status != models.RestaurantStatusActive {
+		return apperrors.BadRequest(apperrors.CodeRestaurantInactive, "restaurant must be active to launch")
+	}
+
+	firstLaunch := restaurant.LaunchedAt == nil
+	now := time.Now()
+
+	restaurant.Visibility = models.RestaurantVisibilityPublic
+	restaurant.GoLiveAt = nil
+	if firstLaunch {
+		restaurant.LaunchedAt = &now
+	}
+
+	if err := s.restaurantRepo.UpdateWithContext(ctx, restaurant); err != nil {
+		return versionConflict(err)
+	}
+
+	// Note: Email failure should not roll back the launch
+	if firstLaunch && s.emailService != nil {
+		if err := s.emailService.SendRestaurantLaunchEmail(ctx, restaurant); err != nil {
+			logger.Warn("Failed to send launch email",
+				zap.Uint("restaurant_id", restaurant.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// RestaurantSettingsService handles restaurant branding and storefront settings
+type RestaurantSettingsService struct {
+	settingsRepo *repositories.RestaurantSettingsRepository
+}
+
+// NewRestaurantSettingsService creates a new RestaurantSettingsService instance
+func NewRestaurantSettingsService(settingsRepo *repositories.RestaurantSettingsRepository) *RestaurantSettingsService {
+	return &RestaurantSettingsService{
+		settingsRepo: settingsRepo,
+	}
+}
+
+// UpdateRestaurantSettingsRequest represents restaurant settings update request
+// Omitted fields keep their current value
+type UpdateRestaurantSettingsRequest struct {
+	LogoURL               *string  `json:"logo_url" binding:"omitempty,max=500"`
+	PrimaryColor          *string  `json:"primary_color" binding:"omitempty,hexcolor"`
+	SecondaryColor        *string  `json:"secondary_color" binding:"omitempty,hexcolor"`
+	AccentColor           *string  `json:"accent_color" binding:"omitempty,hexcolor"`
+	Currency              *string  `json:"currency" binding:"omitempty,len=3,alpha"`
+	Locale                *string  `json:"locale" binding:"omitempty,min=2,max=10"`
+	TimeZone              *string  `json:"time_zone" binding:"omitempty,max=50"`
+	ReceiptFooter         *string  `json:"receipt_footer" binding:"omitempty,max=500"`
+	TaxRate               *float64 `json:"tax_rate" binding:"omitempty,min=0,max=100"`
+	OnlineOrderingEnabled *bool    `json:"online_ordering_enabled"`
+	OrderSlotCapacity     *int     `json:"order_slot_capacity" binding:"omitempty,min=0,max=1000"`
+
+	City      *string   `json:"city" binding:"omitempty,max=100"`
+	Cuisines  *[]string `json:"cuisines" binding:"omitempty,max=10,dive,required,max=50"`
+	Latitude  *float64  `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64  `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	PhotoURLs *[]string `json:"photo_urls" binding:"omitempty,max=10,dive,url,max=500"`
+
+	ReservationSlotMinutes        *int `json:"reservation_slot_minutes" binding:"omitempty,oneof=15 30"`
+	ReservationMaxCoversPerSlot   *int `json:"reservation_max_covers_per_slot" binding:"omitempty,min=0,max=1000"`
+	ReservationMaxPartySize       *int `json:"reservation_max_party_size" binding:"omitempty,min=0,max=1000"`
+	ReservationTableBufferMinutes *int `json:"reservation_table_buffer_minutes" binding:"omitempty,min=0,max=240"`
+
+	KitchenMaxOpenOrders        *int    `json:"kitchen_max_open_orders" binding:"omitempty,min=0,max=10000"`
+	KitchenMaxItemsInFlight     *int    `json:"kitchen_max_items_in_flight" binding:"omitempty,min=0,max=100000"`
+	KitchenOverloadAction       *string `json:"kitchen_overload_action" binding:"omitempty,oneof=delay pause"`
+	KitchenOverloadDelayMinutes *int    `json:"kitchen_overload_delay_minutes" binding:"omitempty,min=0,max=240"`
+
+	CartRecoveryEmail *bool `json:"cart_recovery_email"`
+
+	MenuDraftsEnabled *bool `json:"menu_drafts_enabled"`
+
+	RequireApprovalPriceChanges  *bool `json:"require_approval_price_changes"`
+	RequireApprovalDeletions     *bool `json:"require_approval_deletions"`
+	RequireApprovalCancellations *bool `json:"require_approval_cancellations"`
+
+	ArchiveAfterDays *int `json:"archive_after_days" binding:"omitempty,eq=0|min=30,max=3650"`
+
+	MinMarginPercent *float64 `json:"min_margin_percent" binding:"omitempty,min=0,max=100"`
+
+	SMSReservationConfirmation *bool `json:"sms_reservation_confirmation"`
+	SMSReservationReminder     *bool `json:"sms_reservation_reminder"`
+	SMSOrderReady              *bool `json:"sms_order_ready"`
+}
+
+// GetSettings returns a restaurant's settings, falling back to defaults if none are saved
+func (s *RestaurantSettingsService) GetSettings(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return defaultRestaurantSettings(restaurantID), nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings applies a partial update to a restaurant's settings
+func (s *RestaurantSettingsService) UpdateSettings(ctx context.Context, req *UpdateRestaurantSettingsRequest, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TimeZone != nil {
+		if _, err := time.LoadLocation(*req.TimeZone); err != nil {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "invalid time zone")
+		}
+		settings.TimeZone = *req.TimeZone
+	}
+	if req.LogoURL != nil {
+		settings.LogoURL = *req.LogoURL
+	}
+	if req.PrimaryColor != nil {
+		settings.PrimaryColor = *req.PrimaryColor
+	}
+	if req.SecondaryColor != nil {
+		settings.SecondaryColor = *req.SecondaryColor
+	}
+	if req.AccentColor != nil {
+		settings.AccentColor = *req.AccentColor
+	}
+	if req.Currency != nil {
+		settings.Currency = strings.ToUpper(*req.Currency)
+	}
+	if req.Locale != nil {
+		settings.Locale = *req.Locale
+	}
+	if req.ReceiptFooter != nil {
+		settings.ReceiptFooter = *req.ReceiptFooter
+	}
+	if req.TaxRate != nil {
+		settings.TaxRate = *req.TaxRate
+	}
+	if req.OnlineOrderingEnabled != nil {
+		settings.OnlineOrderingEnabled = *req.OnlineOrderingEnabled
+	}
+	if req.OrderSlotCapacity != nil {
+		settings.OrderSlotCapacity = *req.OrderSlotCapacity
+	}
+	if req.City != nil {
+		settings.City = strings.TrimSpace(*req.City)
+	}
+	if req.Cuisines != nil {
+		cuisines := make([]string, 0, len(*req.Cuisines))
+		for _, cuisine := range *req.Cuisines {
+			cuisines = append(cuisines, strings.ToLower(strings.TrimSpace(cuisine)))
+		}
+		settings.Cuisines = cuisines
+	}
+	if req.Latitude != nil {
+		settings.Latitude = req.Latitude
+	}
+	if req.Longitude != nil {
+		settings.Longitude = req.Longitude
+	}
+	if req.PhotoURLs != nil {
+		settings.PhotoURLs = *req.PhotoURLs
+	}
+	if req.ReservationSlotMinutes != nil {
+		settings.ReservationSlotMinutes = *req.ReservationSlotMinutes
+	}
+	if req.ReservationMaxCoversPerSlot != nil {
+		settings.ReservationMaxCoversPerSlot = *req.ReservationMaxCoversPerSlot
+	}
+	if req.ReservationMaxPartySize != nil {
+		settings.ReservationMaxPartySize = *req.ReservationMaxPartySize
+	}
+	if req.ReservationTableBufferMinutes != nil {
+		settings.ReservationTableBufferMinutes = *req.ReservationTableBufferMinutes
+	}
+	if req.KitchenMaxOpenOrders != nil {
+		settings.KitchenMaxOpenOrders = *req.KitchenMaxOpenOrders
+	}
+	if req.KitchenMaxItemsInFlight != nil {
+		settings.KitchenMaxItemsInFlight = *req.KitchenMaxItemsInFlight
+	}
+	if req.KitchenOverloadAction != nil {
+		settings.KitchenOverloadAction = *req.KitchenOverloadAction
+	}
+	if req.KitchenOverloadDelayMinutes != nil {
+		settings.KitchenOverloadDelayMinutes = *req.KitchenOverloadDelayMinutes
+	}
+	if req.CartRecoveryEmail != nil {
+		settings.CartRecoveryEmail = *req.CartRecoveryEmail
+	}
+	if req.MenuDraftsEnabled != nil {
+		settings.MenuDraftsEnabled = *req.MenuDraftsEnabled
+	}
+	if req.RequireApprovalPriceChanges != nil {
+		settings.RequireApprovalPriceChanges = *req.RequireApprovalPriceChanges
+	}
+	if req.RequireApprovalDeletions != nil {
+		settings.RequireApprovalDeletions = *req.RequireApprovalDeletions
+	}
+	if req.RequireApprovalCancellations != nil {
+		settings.RequireApprovalCancellations = *req.RequireApprovalCancellations
+	}
+	if req.ArchiveAfterDays != nil {
+		settings.ArchiveAfterDays = *req.ArchiveAfterDays
+	}
+	if req.MinMarginPercent != nil {
+		settings.MinMarginPercent = *req.MinMarginPercent
+	}
+	if req.SMSReservationConfirmation != nil {
+		settings.SMSReservationConfirmation = *req.SMSReservationConfirmation
+	}
+	if req.SMSReservationReminder != nil {
+		settings.SMSReservationReminder = *req.SMSReservationReminder
+	}
+	if req.SMSOrderReady != nil {
+		settings.SMSOrderReady = *req.SMSOrderReady
+	}
+
+	if err := s.settingsRepo.SaveWithContext(ctx, settings); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// defaultRestaurantSettings returns the settings used before a restaurant customizes its storefront
+func defaultRestaurantSettings(restaurantID uint) *models.RestaurantSettings {
+	return &models.RestaurantSettings{
+		RestaurantID:          restaurantID,
+		Currency:              models.DefaultCurrency,
+		Locale:                models.DefaultLocale,
+		TimeZone:              models.DefaultTimeZone,
+		OnlineOrderingEnabled: true,
+		Cuisines:              []string{},
+		PhotoURLs:             []string{},
+
+		ReservationSlotMinutes: 15,
+
+		KitchenOverloadAction:       models.KitchenOverloadDelay,
+		KitchenOverloadDelayMinutes: 15,
+
+		MinMarginPercent: 65,
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// ReviewService handles order and menu item reviews
+type ReviewService struct {
+	reviewRepo     *repositories.ReviewRepository
+	orderRepo      *repositories.OrderRepository
+	restaurantRepo *repositories.RestaurantRepository
+}
+
+// NewReviewService creates a new ReviewService instance
+func NewReviewService(
+	reviewRepo *repositories.ReviewRepository,
+	orderRepo *repositories.OrderRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+) *ReviewService {
+	return &ReviewService{
+		reviewRepo:     reviewRepo,
+		orderRepo:      orderRepo,
+		restaurantRepo: restaurantRepo,
+	}
+}
+
+// CreateReviewRequest represents a review of an order or one of its menu items
+type CreateReviewRequest struct {
+	MenuItemID *uint  `json:"menu_item_id"` // Omit to review the order as a whole
+	Rating     int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment    string `json:"comment" binding:"max=2000"`
+}
+
+// ModerateReviewRequest represents a moderation decision on a review
+type ModerateReviewRequest struct {
+	Hidden bool   `json:"hidden"`
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// ReviewList is a page of reviews with the restaurant's rating summary
+type ReviewList struct {
+	Reviews []models.Review             `json:"reviews"`
+	Summary *repositories.RatingSummary `json:"summary"` // Restaurant rating from visible order reviews
+	Total   int64                       `json:"total"`
+	Limit   int                         `json:"limit"`
+	Offset  int                         `json:"offset"`
+}
+
+// CreateReview records a customer's review of their completed order or of an item in it
+func (s *ReviewService) CreateReview(ctx context.Context, orderID, restaurantID, userID uint, req *CreateReviewRequest) (*models.Review, error) {
+	order, err := s.orderRepo.GetByIDForRestaurant(ctx, orderID, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeOrderNotFound, "order not found")
+	}
+	if order.UserID != userID {
+		return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "only the customer who placed the order can review it")
+	}
+	if order.Status != models.OrderStatusCompleted {
+		return nil, apperrors.Conflict(apperrors.CodeOrderNotCompleted, "only completed orders can be reviewed")
+	}
+	if req.MenuItemID != nil && !orderContainsMenuItem(order, *req.MenuItemID) {
+		return nil, apperrors.BadRequest(apperrors.CodeMenuItemNotFound, "menu item is not part of this order")
+	}
+
+	review := &models.Review{
+		RestaurantID: order.RestaurantID,
+		OrderID:      order.ID,
+		MenuItemID:   req.MenuItemID,
+		UserID:       userID,
+		Rating:       req.Rating,
+		Comment:      strings.TrimSpace(req.Comment),
+	}
+	if err := s.reviewRepo.CreateWithContext(ctx, review); err != nil {
+		if errors.Is(err, repositories.ErrReviewExists) {
+			return nil, apperrors.Conflict(apperrors.CodeReviewExists, "this order or item has already been reviewed")
+		}
+		return nil, err
+	}
+
+	s.refreshRating(ctx, review)
+	return review, nil
+}
+
+// ListReviews lists a restaurant's reviews for staff, optionally including hidden ones
+func (s *ReviewService) ListReviews(ctx context.Context, restaurantID uint, filter repositories.ReviewFilter, limit, offset int) (*ReviewList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	reviews, total, err := s.reviewRepo.ListWithContext(ctx, restaurantID, filter, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.reviewRepo.GetRestaurantSummaryWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReviewList{
+		Reviews: reviews,
+		Summary: summary,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
+// ListPublicReviews lists the visible reviews of a publicly visible restaurant
+func (s *ReviewService) ListPublicReviews(ctx context.Context, restaurantID uint, menuItemID *uint, limit, offset int) (*ReviewList, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil || !restaurant.IsPubliclyVisible() {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	return s.ListReviews(ctx, restaurantID, repositories.ReviewFilter{MenuItemID: menuItemID}, limit, offset)
+}
+
+// ModerateReview hides an abusive review (or restores it) and updates the affected rating
+func (s *ReviewService) ModerateReview(ctx context.Context, id, restaurantID, moderatorID uint, req *ModerateReviewRequest) (*models.Review, error) {
+	review, err := s.reviewRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeReviewNotFound, "review not found")
+	}
+
+	var hiddenBy *uint
+	reason := ""
+	if req.Hidden {
+		hiddenBy = &moderatorID
+		reason = strings.TrimSpace(req.Reason)
+	}
+	if err := s.reviewRepo.SetHiddenWithContext(ctx, id, req.Hidden, hiddenBy, reason); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Moderated review",
+		zap.Uint("review_id", id),
+		zap.Uint("moderator_id", moderatorID),
+		zap.Bool("hidden", req.Hidden),
+	)
+
+	s.refreshRating(ctx, review)
+	return s.reviewRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+}
+
+// refreshRating recomputes the rating of the reviewed menu item
+// Note: A failed refresh should not fail the review; the next review or moderation recomputes it
+func (s *ReviewService) refreshRating(ctx context.Context, review *models.Review) {
+	if review.MenuItemID == nil {
+		return
+	}
+	if err := s.reviewRepo.RefreshMenuItemRatingWithContext(ctx, *review.MenuItemID); err != nil {
+		logger.Warn("Failed to refresh menu item rating",
+			zap.Uint("menu_item_id", *review.MenuItemID),
+			zap.Error(err),
+		)
+	}
+}
+
+// orderContainsMenuItem reports whether a menu item was ordered in the order
+func orderContainsMenuItem(order *models.Order, menuItemID uint) bool {
+	for _, item := range order.OrderItems {
+		if item.MenuItemID == menuItemID {
+			return true
+		}
+	}
+	return false
+}
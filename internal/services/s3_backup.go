@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// abortIncompleteUploadDays cleans up abandoned multipart uploads in every tenant prefix
+const abortIncompleteUploadDays = 7
+
+// TenantBackupRule is the bucket configuration for one restaurant's storage prefix
+type TenantBackupRule struct {
+	RestaurantID  uint
+	RetentionDays int
+	Replicate     bool
+}
+
+// ReplicationTarget is the destination for cross-region replication
+type ReplicationTarget struct {
+	RoleARN      string
+	BucketARN    string
+	StorageClass string
+}
+
+// TenantStorageUsage is the storage footprint of one restaurant's prefix
+type TenantStorageUsage struct {
+	Objects            int64 `json:"objects"`
+	CurrentBytes       int64 `json:"current_bytes"`
+	NoncurrentVersions int64 `json:"noncurrent_versions"`
+	NoncurrentBytes    int64 `json:"noncurrent_bytes"` // Previous versions retained as backups
+}
+
+// tenantPrefix returns the storage key prefix of a restaurant
+func tenantPrefix(restaurantID uint) string {
+	return fmt.Sprintf("restaurant-%d/", restaurantID)
+}
+
+// tenantFromKey extracts the restaurant ID from a tenant-prefixed key
+func tenantFromKey(key string) (uint, bool) {
+	rest, ok := strings.CutPrefix(key, "restaurant-")
+	if !ok {
+		return 0, false
+	}
+	idPart, _, ok := strings.Cut(rest, "/")
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(idPart, 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// VersioningEnabled reports whether object versioning is enabled on the bucket
+func (s *S3Service) VersioningEnabled(ctx context.Context) (bool, error) {
+	out, err := s.client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{
+		Bucket: aws.String(s.bucketName),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get bucket versioning: %w", err)
+	}
+	return out.Status == types.BucketVersioningStatusEnabled, nil
+}
+
+// ApplyBackupRules enables versioning and replaces the bucket lifecycle and replication
+// configuration with one rule per tenant prefix
+// The platform owns these bucket configurations: rules not generated here are removed
+func (s *S3Service) ApplyBackupRules(ctx context.Context, rules []TenantBackupRule, target *ReplicationTarget) error {
+	// Versioning is required for noncurrent-version retention and for replication
+	if _, err := s.client.PutBucketVersioning(ctx, &s3.PutBucketVersioningInput{
+		Bucket: aws.String(s.bucketName),
+		VersioningConfiguration: &types.VersioningConfiguration{
+			Status: types.BucketVersioningStatusEnabled,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to enable bucket versioning: %w", err)
+	}
+
+	if err := s.putLifecycleRules(ctx, rules); err != nil {
+		return err
+	}
+
+	return s.putReplicationRules(ctx, rules, target)
+}
+
+// putLifecycleRules expires each tenant's noncurrent versions after its retention period
+func (s *S3Service) putLifecycleRules(ctx context.Context, rules []TenantBackupRule) error {
+	if len(rules) == 0 {
+		_, err := s.client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{
+			Bucket: aws.String(s.bucketName),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete bucket lifecycle: %w", err)
+		}
+		return nil
+	}
+
+	lifecycleRules := make([]types.LifecycleRule, 0, len(rules))
+	for _, rule := range rules {
+		lifecycleRules = append(lifecycleRules, types.LifecycleRule{
+			ID:     aws.String(fmt.Sprintf("tenant-%d-retention", rule.RestaurantID)),
+			Status: types.ExpirationStatusEnabled,
+			Filter: &types.LifecycleRuleFilterMemberPrefix{Value: tenantPrefix(rule.RestaurantID)},
+			NoncurrentVersionExpiration: &types.NoncurrentVersionExpiration{
+				NoncurrentDays: aws.Int32(int32(rule.RetentionDays)),
+			},
+			AbortIncompleteMultipartUpload: &types.AbortIncompleteMultipartUpload{
+				DaysAfterInitiation: aws.Int32(abortIncompleteUploadDays),
+			},
+		})
+	}
+
+	if _, err := s.client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket:                 aws.String(s.bucketName),
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{Rules: lifecycleRules},
+	}); err != nil {
+		return fmt.Errorf("failed to put bucket lifecycle: %w", err)
+	}
+
+	return nil
+}
+
+// putReplicationRules replicates the prefixes of tenants with replication enabled
+func (s *S3Service) putReplicationRules(ctx context.Context, rules []TenantBackupRule, target *ReplicationTarget) error {
+	var replicationRules []types.ReplicationRule
+	for _, rule := range rules {
+		if !rule.Replicate {
+			continue
+		}
+		replicationRules = append(replicationRules, types.ReplicationRule{
+			ID:       aws.String(fmt.Sprintf("tenant-%d-replication", rule.RestaurantID)),
+			Priority: aws.Int32(int32(len(replicationRules) + 1)),
+			Status:   types.ReplicationRuleStatusEnabled,
+			Filter:   &types.ReplicationRuleFilterMemberPrefix{Value: tenantPrefix(rule.RestaurantID)},
+			DeleteMarkerReplication: &types.DeleteMarkerReplication{
+				Status: types.DeleteMarkerReplicationStatusDisabled,
+			},
+		})
+	}
+
+	if len(replicationRules) == 0 {
+		_, err := s.client.DeleteBucketReplication(ctx, &s3.DeleteBucketReplicationInput{
+			Bucket: aws.String(s.bucketName),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete bucket replication: %w", err)
+		}
+		return nil
+	}
+
+	if target == nil || target.RoleARN == "" || target.BucketARN == "" {
+		return errors.New("replication requires S3_REPLICATION_ROLE_ARN and S3_REPLICATION_BUCKET_ARN")
+	}
+
+	for i := range replicationRules {
+		replicationRules[i].Destination = &types.Destination{
+			Bucket:       aws.String(target.BucketARN),
+			StorageClass: types.StorageClass(target.StorageClass),
+		}
+	}
+
+	if _, err := s.client.PutBucketReplication(ctx, &s3.PutBucketReplicationInput{
+		Bucket: aws.String(s.bucketName),
+		ReplicationConfiguration: &types.ReplicationConfiguration{
+			Role:  aws.String(target.RoleARN),
+			Rules: replicationRules,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to put bucket replication: %w", err)
+	}
+
+	return nil
+}
+
+// ReplicatedTenants returns the restaurants whose prefix has an enabled replication rule on the bucket
+func (s *S3Service) ReplicatedTenants(ctx context.Context) (map[uint]bool, error) {
+	out, err := s.client.GetBucketReplication(ctx, &s3.GetBucketReplicationInput{
+		Bucket: aws.String(s.bucketName),
+	})
+	if err != nil {
+		// A bucket without replication configuration returns an error rather than an empty list
+		if strings.Contains(err.Error(), "ReplicationConfigurationNotFoundError") {
+			return map[uint]bool{}, nil
+		}
+		return nil, fmt.Errorf("failed to get bucket replication: %w", err)
+	}
+
+	replicated := make(map[uint]bool)
+	if out.ReplicationConfiguration == nil {
+		return replicated, nil
+	}
+	for _, rule := range out.ReplicationConfiguration.Rules {
+		prefix, ok := rule.Filter.(*types.ReplicationRuleFilterMemberPrefix)
+		if !ok || rule.Status != types.ReplicationRuleStatusEnabled {
+			continue
+		}
+		if restaurantID, ok := tenantFromKey(prefix.Value); ok {
+			replicated[restaurantID] = true
+		}
+	}
+	return replicated, nil
+}
+
+// StorageUsage lists every object version under prefix and aggregates the footprint per tenant
+// An empty prefix scans the whole bucket
+func (s *S3Service) StorageUsage(ctx context.Context, prefix string) (map[uint]*TenantStorageUsage, error) {
+	usage := make(map[uint]*TenantStorageUsage)
+	input := &s3.ListObjectVersionsInput{
+		Bucket: aws.String(s.bucketName),
+	}
+	if prefix != "" {
+		input.Prefix = aws.String(prefix)
+	}
+
+	for {
+		out, err := s.client.ListObjectVersions(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list object versions: %w", err)
+		}
+
+		for _, version := range out.Versions {
+			restaurantID, ok := tenantFromKey(aws.ToString(version.Key))
+			if !ok {
+				continue
+			}
+			tenant, exists := usage[restaurantID]
+			if !exists {
+				tenant = &TenantStorageUsage{}
+				usage[restaurantID] = tenant
+			}
+			if aws.ToBool(version.IsLatest) {
+				tenant.Objects++
+				tenant.CurrentBytes += aws.ToInt64(version.Size)
+			} else {
+				tenant.NoncurrentVersions++
+				tenant.NoncurrentBytes += aws.ToInt64(version.Size)
+			}
+		}
+
+		if !aws.ToBool(out.IsTruncated) {
+			break
+		}
+		input.KeyMarker = out.NextKeyMarker
+		input.VersionIdMarker = out.NextVersionIdMarker
+	}
+
+	return usage, nil
+}
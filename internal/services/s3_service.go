@@ -10,9 +10,11 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 )
 
 // S3Service handles S3 operations for tenant isolation
@@ -33,11 +35,23 @@ func NewS3Service(cfg *config.Config) (*S3Service, error) {
 	// If credentials are provided via environment, set them explicitly
 	if cfg.AWSAccessKeyID != "" && cfg.AWSSecretAccessKey != "" {
 		// Note: In production, use IAM roles instead of explicit credentials
-		// This is for development/testing purposes
+		// This is for development/testing purposes (and MinIO)
+		awsCfg.Credentials = credentials.NewStaticCredentialsProvider(cfg.AWSAccessKeyID, cfg.AWSSecretAccessKey, "")
 	}
 
+	// S3 calls become spans of the request's trace
+	otelaws.AppendMiddlewares(&awsCfg.APIOptions)
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		// S3-compatible services (e.g., MinIO) use a custom endpoint and path-style addressing
+		if cfg.S3Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.S3Endpoint)
+		}
+		o.UsePathStyle = cfg.S3UsePathStyle
+	})
+
 	return &S3Service{
-		client:     s3.NewFromConfig(awsCfg),
+		client:     client,
 		bucketName: cfg.S3BucketName,
 	}, nil
 }
@@ -94,6 +108,18 @@ func (s *S3Service) DeleteFile(ctx context.Context, key string) error {
 	return nil
 }
 
+// Ping checks that the bucket exists and is accessible with the configured credentials
+func (s *S3Service) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: aws.String(s.bucketName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reach S3 bucket: %w", err)
+	}
+
+	return nil
+}
+
 // getFileExtension extracts the file extension from a filename
 func getFileExtension(fileName string) string {
 	extension := ""
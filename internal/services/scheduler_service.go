@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/metrics"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	// schedulerPollInterval is how often each instance looks for due jobs
+	schedulerPollInterval = 15 * time.Second
+
+	// defaultJobTimeout bounds a run, and is the lease length, when the job doesn't set one
+	defaultJobTimeout = 10 * time.Minute
+
+	// jobRunRetention is how long run history is kept
+	jobRunRetention = 30 * 24 * time.Hour
+
+	// jobFinishTimeout bounds recording a run's outcome, which still happens during shutdown
+	jobFinishTimeout = 5 * time.Second
+)
+
+// Job is a periodic background task run by the SchedulerService
+type Job struct {
+	Name     string
+	Interval time.Duration // From the end of one run to the next; jobs with no interval are disabled
+	Timeout  time.Duration // Run deadline and lock lease; defaultJobTimeout when zero
+	Run      func(ctx context.Context) error
+}
+
+// JobStatus is a registered job with its shared schedule
+type JobStatus struct {
+	models.ScheduledJob
+	Interval string `json:"interval"`
+	Running  bool   `json:"running"` // Some instance holds an unexpired lease
+}
+
+// JobRunList is a page of a job's run history
+type JobRunList struct {
+	Runs   []models.JobRun `json:"runs"`
+	Total  int64           `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// SchedulerService runs the registered periodic jobs
+// Every instance polls for due jobs, but a run needs the job's lease, so each run happens on one
+// instance and the schedule is shared across instances. Runs are recorded in job_runs
+type SchedulerService struct {
+	jobRepo  *repositories.ScheduledJobRepository
+	instance string
+
+	mu    sync.Mutex
+	jobs  []Job
+	names map[string]bool
+}
+
+// NewSchedulerService creates a new SchedulerService instance
+// It registers the cleanup of old run history itself
+func NewSchedulerService(jobRepo *repositories.ScheduledJobRepository) *SchedulerService {
+	hostname, _ := os.Hostname()
+	s := &SchedulerService{
+		jobRepo:  jobRepo,
+		instance: fmt.Sprintf("%s:%d", hostname, os.Getpid()),
+		names:    make(map[string]bool),
+	}
+	s.Register(Job{Name: "job_run_cleanup", Interval: 24 * time.Hour, Run: s.deleteOldRuns})
+	return s
+}
+
+// Register adds a job; jobs without an interval are disabled and skipped
+// Registering the same name twice is a programming error and panics
+func (s *SchedulerService) Register(job Job) {
+	if job.Interval <= 0 {
+		return
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultJobTimeout
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.names[job.Name] {
+		panic("scheduler: job registered twice: " + job.Name)
+	}
+	s.names[job.Name] = true
+	s.jobs = append(s.jobs, job)
+}
+
+// Run polls for due jobs until ctx is cancelled
+// A job's first run is one interval after it is first seen, as with a ticker
+func (s *SchedulerService) Run(ctx context.Context) {
+	jobs := s.registered()
+	for _, job := range jobs {
+		if err := s.jobRepo.EnsureWithContext(ctx, job.Name, job.Interval); err != nil {
+			logger.Warn("Failed to register scheduled job", zap.String("job", job.Name), zap.Error(err))
+		}
+	}
+
+	ticker := time.NewTicker(schedulerPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, job := range jobs {
+				s.tryRun(ctx, job)
+			}
+		}
+	}
+}
+
+// tryRun starts the job in the background if it is due and this instance gets its lease
+func (s *SchedulerService) tryRun(ctx context.Context, job Job) {
+	claimed, err := s.jobRepo.ClaimWithContext(ctx, job.Name, s.instance, job.Timeout)
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Warn("Failed to claim scheduled job", zap.String("job", job.Name), zap.Error(err))
+		}
+		return
+	}
+	if claimed {
+		go s.execute(ctx, job)
+	}
+}
+
+// execute runs a claimed job, records the run and releases the lease
+func (s *SchedulerService) execute(ctx context.Context, job Job) {
+	run := &models.JobRun{
+		JobName:   job.Name,
+		Instance:  s.instance,
+		Status:    models.JobRunRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.jobRepo.CreateRunWithContext(ctx, run); err != nil {
+		logger.Warn("Failed to record scheduled job run", zap.String("job", job.Name), zap.Error(err))
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, job.Timeout)
+	done := metrics.TrackBackgroundJob(job.Name)
+	err := runJob(runCtx, job)
+	done()
+	cancel()
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+	run.Status = models.JobRunSucceeded
+	if err != nil {
+		run.Status = models.JobRunFailed
+		run.Error = err.Error()
+		logger.Warn("Scheduled job failed", zap.String("job", job.Name), zap.Error(err))
+	}
+
+	// The outcome is recorded and the lease released even when shutdown cancelled ctx
+	finishCtx, cancelFinish := context.WithTimeout(context.WithoutCancel(ctx), jobFinishTimeout)
+	defer cancelFinish()
+	if run.ID != 0 {
+		if err := s.jobRepo.FinishRunWithContext(finishCtx, run); err != nil {
+			logger.Warn("Failed to record scheduled job result", zap.String("job", job.Name), zap.Error(err))
+		}
+	}
+	if err := s.jobRepo.ReleaseWithContext(finishCtx, job.Name, s.instance, run.Status, job.Interval); err != nil {
+		logger.Warn("Failed to release scheduled job", zap.String("job", job.Name), zap.Error(err))
+	}
+}
+
+// runJob runs the job, turning a panic into a failed run so the scheduler keeps going
+func runJob(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// ListJobs lists the registered jobs with their shared schedule
+func (s *SchedulerService) ListJobs(ctx context.Context) ([]JobStatus, error) {
+	stored, err := s.jobRepo.ListWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]models.ScheduledJob, len(stored))
+	for _, job := range stored {
+		byName[job.Name] = job
+	}
+
+	now := time.Now()
+	jobs := s.registered()
+	statuses := make([]JobStatus, 0, len(jobs))
+	for _, job := range jobs {
+		state, ok := byName[job.Name]
+		if !ok {
+			state = models.ScheduledJob{Name: job.Name}
+		}
+		statuses = append(statuses, JobStatus{
+			ScheduledJob: state,
+			Interval:     job.Interval.String(),
+			Running:      state.LockedUntil != nil && state.LockedUntil.After(now),
+		})
+	}
+	return statuses, nil
+}
+
+// ListRuns lists a job's run history, newest first
+func (s *SchedulerService) ListRuns(ctx context.Context, name string, limit, offset int) (*JobRunList, error) {
+	if !s.isRegistered(name) {
+		return nil, apperrors.NotFound(apperrors.CodeJobNotFound, "scheduled job not found")
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	runs, total, err := s.jobRepo.ListRunsWithContext(ctx, name, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &JobRunList{Runs: runs, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// TriggerJob makes a job due now instead of waiting for its next scheduled run
+func (s *SchedulerService) TriggerJob(ctx context.Context, name string) error {
+	if !s.isRegistered(name) {
+		return apperrors.NotFound(apperrors.CodeJobNotFound, "scheduled job not found")
+	}
+	if err := s.jobRepo.TriggerWithContext(ctx, name); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Registered, but no instance has started the scheduler yet
+			return apperrors.NotFound(apperrors.CodeJobNotFound, "scheduled job not found")
+		}
+		return err
+	}
+
+	logger.Info("Triggered scheduled job", zap.String("job", name))
+	return nil
+}
+
+// deleteOldRuns deletes run history older than jobRunRetention
+func (s *SchedulerService) deleteOldRuns(ctx context.Context) error {
+	deleted, err := s.jobRepo.DeleteRunsBeforeWithContext(ctx, time.Now().Add(-jobRunRetention))
+	if err != nil {
+		return err
+	}
+	if deleted > 0 {
+		logger.Info("Deleted old scheduled job runs", zap.Int64("count", deleted))
+	}
+	return nil
+}
+
+// registered returns a copy of the registered jobs
+func (s *SchedulerService) registered() []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Job(nil), s.jobs...)
+}
+
+// isRegistered reports whether a job with the name is registered
+func (s *SchedulerService) isRegistered(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.names[name]
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/metrics"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// sessionTouchInterval limits last-seen updates to one write per session per interval
+const sessionTouchInterval = time.Minute
+
+// errSessionEnded is returned for tokens of revoked or expired sessions
+var errSessionEnded = apperrors.Unauthorized(apperrors.CodeInvalidToken, "session has ended; log in again")
+
+// ClientInfo identifies the device a login comes from
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// SessionView is a session as listed to its user
+type SessionView struct {
+	models.UserSession
+	Current bool `json:"current"` // The session of the requesting token
+}
+
+// SessionService tracks login sessions per device, so users (and restaurant admins) can see where an account is
+// signed in and revoke a lost or stolen device without waiting for its token to expire
+type SessionService struct {
+	sessionRepo *repositories.UserSessionRepository
+	userRepo    *repositories.UserRepository
+}
+
+// NewSessionService creates a new SessionService instance
+func NewSessionService(sessionRepo *repositories.UserSessionRepository, userRepo *repositories.UserRepository) *SessionService {
+	return &SessionService{
+		sessionRepo: sessionRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// Start records a new login session valid until the expiry of its first token
+func (s *SessionService) Start(ctx context.Context, userID uint, client ClientInfo, expiresAt time.Time) (*models.UserSession, error) {
+	now := time.Now()
+	session := &models.UserSession{
+		SessionID:  uuid.New().String(),
+		UserID:     userID,
+		IPAddress:  client.IPAddress,
+		UserAgent:  client.UserAgent,
+		LastSeenAt: now,
+		LastSeenIP: client.IPAddress,
+		ExpiresAt:  expiresAt,
+	}
+	if err := s.sessionRepo.CreateWithContext(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	return session, nil
+}
+
+// Check rejects tokens of revoked or expired sessions and records the session's use
+func (s *SessionService) Check(ctx context.Context, sessionID, ip string) error {
+	session, err := s.sessionRepo.GetBySessionIDWithContext(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errSessionEnded
+		}
+		return err
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) {
+		return errSessionEnded
+	}
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval || session.LastSeenIP != ip {
+		// Last seen is informational; a failed update must not fail the request
+		if err := s.sessionRepo.TouchWithContext(ctx, session.ID, now, ip); err != nil {
+			logger.Warn("Failed to record session activity", zap.String("session_id", sessionID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Extend keeps a session alive until the expiry of a token newly issued for it
+func (s *SessionService) Extend(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	return s.sessionRepo.ExtendWithContext(ctx, sessionID, expiresAt)
+}
+
+// List returns a user's active sessions, marking the current one
+func (s *SessionService) List(ctx context.Context, userID uint, currentSessionID string) ([]SessionView, error) {
+	sessions, err := s.sessionRepo.ListActiveByUserWithContext(ctx, userID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	views := make([]SessionView, 0, len(sessions))
+	for _, session := range sessions {
+		views = append(views, SessionView{
+			UserSession: session,
+			Current:     currentSessionID != "" && session.SessionID == currentSessionID,
+		})
+	}
+	return views, nil
+}
+
+// Revoke ends one of a user's sessions; its tokens are rejected from the next request on
+func (s *SessionService) Revoke(ctx context.Context, userID uint, sessionID string) error {
+	revoked, err := s.sessionRepo.RevokeWithContext(ctx, userID, sessionID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return apperrors.NotFound(apperrors.CodeSessionNotFound, "session not found")
+	}
+	logger.Info("Session revoked", zap.Uint("user_id", userID), zap.String("session_id", sessionID))
+	return nil
+}
+
+// RevokeAll ends a user's sessions except the one to keep (empty to end all); returns how many were ended
+func (s *SessionService) RevokeAll(ctx context.Context, userID uint, keepSessionID string) (int64, error) {
+	revoked, err := s.sessionRepo.RevokeAllWithContext(ctx, userID, keepSessionID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	logger.Info("Sessions revoked", zap.Uint("user_id", userID), zap.Int64("revoked", revoked))
+	return revoked, nil
+}
+
+// ListForUser returns the active sessions of a user of the restaurant (restaurant admins)
+func (s *SessionService) ListForUser(ctx context.Context, restaurantID, userID uint) ([]SessionView, error) {
+	if err := s.checkRestaurantUser(ctx, restaurantID, userID); err != nil {
+		return nil, err
+	}
+	return s.List(ctx, userID, "")
+}
+
+// RevokeForUser ends a session of a user of the restaurant (restaurant admins)
+func (s *SessionService) RevokeForUser(ctx context.Context, restaurantID, userID uint, sessionID string) error {
+	if err := s.checkRestaurantUser(ctx, restaurantID, userID); err != nil {
+		return err
+	}
+	return s.Revoke(ctx, userID, sessionID)
+}
+
+// checkRestaurantUser verifies the user belongs to the restaurant; sessions aren't tenant-scoped themselves
+func (s *SessionService) checkRestaurantUser(ctx context.Context, restaurantID, userID uint) error {
+	user, err := s.userRepo.GetByIDWithContext(ctx, userID)
+	if err != nil || user.RestaurantID != restaurantID {
+		return apperrors.NotFound(apperrors.CodeUserNotFound, "user not found")
+	}
+	return nil
+}
+
+// WatchActiveSessions refreshes the active sessions gauge at each interval until the context is done
+// Every instance runs it, so the gauge reads the same whichever instance is scraped
+func (s *SessionService) WatchActiveSessions(ctx context.Context, interval time.Duration) {
+	refresh := func(ctx context.Context) {
+		count, err := s.sessionRepo.CountActiveWithContext(ctx, time.Now())
+		if err != nil {
+			logger.Warn("Failed to count active sessions", zap.Error(err))
+			return
+		}
+		metrics.SetActiveSessions(float64(count))
+	}
+	refresh(ctx)
+	watch(ctx, interval, refresh)
+}
+
+// CleanupJob deletes sessions that ended (expired or revoked) longer than the retention ago
+func (s *SessionService) CleanupJob(interval, retention time.Duration) Job {
+	return Job{
+		Name:     "session_cleanup",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			deleted, err := s.sessionRepo.DeleteEndedWithContext(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return fmt.Errorf("failed to delete ended sessions: %w", err)
+			}
+			if deleted > 0 {
+				logger.Info("Deleted ended sessions", zap.Int64("deleted", deleted))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"restaurant-backend/internal/config"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+
+	"go.uber.org/zap"
+)
+
+// SMS providers
+const (
+	SMSProviderTwilio = "twilio"
+	SMSProviderLog    = "log"
+)
+
+// SMSProvider sends text messages
+// Twilio delivers them; LogSMSProvider only logs them
+type SMSProvider interface {
+	Name() string
+	Send(ctx context.Context, to, body string) (*SMSResult, error)
+}
+
+// SMSResult is the provider's answer to an accepted message
+type SMSResult struct {
+	ExternalID string // Provider message ID
+	Status     string // One of the models.SMSStatus* values
+}
+
+// SMSError is a message the provider refused, with the provider's error code
+type SMSError struct {
+	Code    string
+	Message string
+}
+
+func (e *SMSError) Error() string {
+	return "sms rejected (" + e.Code + "): " + e.Message
+}
+
+var (
+	_ SMSProvider = (*twilioProvider)(nil)
+	_ SMSProvider = LogSMSProvider{}
+)
+
+// NewSMSProvider sends through Twilio, except without a Twilio account SID where messages are only logged
+func NewSMSProvider(cfg *config.Config) SMSProvider {
+	if cfg.TwilioAccountSID == "" {
+		return LogSMSProvider{}
+	}
+	return &twilioProvider{
+		baseURL:           cfg.TwilioAPIURL,
+		accountSID:        cfg.TwilioAccountSID,
+		authToken:         cfg.TwilioAuthToken,
+		from:              cfg.TwilioFromNumber,
+		statusCallbackURL: cfg.TwilioStatusCallbackURL,
+		client:            &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// LogSMSProvider logs messages instead of sending them, for local development without a Twilio account
+type LogSMSProvider struct{}
+
+// Name returns the provider name
+func (LogSMSProvider) Name() string {
+	return SMSProviderLog
+}
+
+// Send logs the message and reports it as sent
+func (LogSMSProvider) Send(ctx context.Context, to, body string) (*SMSResult, error) {
+	logger.Info("SMS not sent (log provider)",
+		zap.String("to", to),
+		zap.String("body", body),
+	)
+	return &SMSResult{Status: models.SMSStatusSent}, nil
+}
@@ -0,0 +1,276 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// SMSService texts guests about their reservations and orders, for the message types their restaurant opted into
+// Every message is logged with its delivery status, which the provider updates through the status callback
+type SMSService struct {
+	smsRepo           *repositories.SMSMessageRepository
+	settingsRepo      *repositories.RestaurantSettingsRepository
+	restaurantRepo    *repositories.RestaurantRepository
+	preferences       *NotificationPreferenceService
+	provider          SMSProvider
+	twilioAuthToken   string // Verifies Twilio status callbacks
+	statusCallbackURL string // The URL Twilio signs status callbacks with
+}
+
+// NewSMSService creates a new SMSService instance
+func NewSMSService(
+	smsRepo *repositories.SMSMessageRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	preferences *NotificationPreferenceService,
+	provider SMSProvider,
+	twilioAuthToken string,
+	statusCallbackURL string,
+) *SMSService {
+	return &SMSService{
+		smsRepo:           smsRepo,
+		settingsRepo:      settingsRepo,
+		restaurantRepo:    restaurantRepo,
+		preferences:       preferences,
+		provider:          provider,
+		twilioAuthToken:   twilioAuthToken,
+		statusCallbackURL: statusCallbackURL,
+	}
+}
+
+// NotifyReservationConfirmed texts the guest that their reservation is confirmed
+// SMS is best effort: failures are logged and never block the caller
+func (s *SMSService) NotifyReservationConfirmed(ctx context.Context, reservation *models.Reservation) {
+	if s == nil || reservation.User.Phone == "" {
+		return
+	}
+	if !s.preferences.Allows(ctx, reservation.RestaurantID, reservation.UserID, models.NotificationEventReservationUpdates, models.NotificationChannelSMS) {
+		return
+	}
+	settings := s.optedIn(ctx, reservation.RestaurantID, func(settings *models.RestaurantSettings) bool {
+		return settings.SMSReservationConfirmation
+	})
+	if settings == nil {
+		return
+	}
+
+	name := s.restaurantName(ctx, reservation.RestaurantID)
+	body := smsSigned(name, fmt.Sprintf("Your reservation for %d on %s is confirmed. Table %s.",
+		reservation.NumberOfGuests, reservation.StartTime.In(settingsLocation(settings)).Format("Mon Jan 2 at 15:04"), reservation.TableNumber))
+	s.send(ctx, &models.SMSMessage{
+		RestaurantID:  reservation.RestaurantID,
+		Type:          models.SMSTypeReservationConfirmation,
+		ReservationID: &reservation.ID,
+		To:            reservation.User.Phone,
+		Body:          body,
+	})
+}
+
+// NotifyOrderReady texts the customer that their order is ready
+func (s *SMSService) NotifyOrderReady(ctx context.Context, order *models.Order) {
+	if s == nil || order.User.Phone == "" {
+		return
+	}
+	if !s.preferences.Allows(ctx, order.RestaurantID, order.UserID, models.NotificationEventOrderUpdates, models.NotificationChannelSMS) {
+		return
+	}
+	if s.optedIn(ctx, order.RestaurantID, func(settings *models.RestaurantSettings) bool {
+		return settings.SMSOrderReady
+	}) == nil {
+		return
+	}
+
+	name := s.restaurantName(ctx, order.RestaurantID)
+	text := fmt.Sprintf("Your order #%d is ready.", order.ID)
+	switch order.FulfillmentType {
+	case models.OrderFulfillmentPickup:
+		text = fmt.Sprintf("Your order #%d is ready for pickup.", order.ID)
+	case models.OrderFulfillmentDelivery:
+		text = fmt.Sprintf("Your order #%d is ready and will be on its way shortly.", order.ID)
+	}
+	s.send(ctx, &models.SMSMessage{
+		RestaurantID: order.RestaurantID,
+		Type:         models.SMSTypeOrderReady,
+		OrderID:      &order.ID,
+		To:           order.User.Phone,
+		Body:         smsSigned(name, text),
+	})
+}
+
+// ReminderJob texts the guests of confirmed reservations starting within the lead time, once per reservation
+func (s *SMSService) ReminderJob(interval, leadTime time.Duration) Job {
+	return Job{
+		Name:     "sms_reservation_reminders",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			now := time.Now()
+			reservations, err := s.smsRepo.ListDueRemindersWithContext(ctx, now, now.Add(leadTime))
+			if err != nil {
+				return fmt.Errorf("failed to list due reservation reminders: %w", err)
+			}
+
+			names := make(map[uint]string)
+			for i := range reservations {
+				reservation := &reservations[i]
+				if !s.preferences.Allows(ctx, reservation.RestaurantID, reservation.UserID, models.NotificationEventReservationUpdates, models.NotificationChannelSMS) {
+					continue
+				}
+				settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, reservation.RestaurantID)
+				if err != nil {
+					return fmt.Errorf("failed to load settings of restaurant %d: %w", reservation.RestaurantID, err)
+				}
+				name, ok := names[reservation.RestaurantID]
+				if !ok {
+					name = s.restaurantName(ctx, reservation.RestaurantID)
+					names[reservation.RestaurantID] = name
+				}
+
+				s.send(ctx, &models.SMSMessage{
+					RestaurantID:  reservation.RestaurantID,
+					Type:          models.SMSTypeReservationReminder,
+					ReservationID: &reservation.ID,
+					To:            reservation.User.Phone,
+					Body: smsSigned(name, fmt.Sprintf("Reminder: your reservation for %d is at %s. Table %s.",
+						reservation.NumberOfGuests, reservation.StartTime.In(settingsLocation(settings)).Format("15:04"), reservation.TableNumber)),
+				})
+			}
+			if len(reservations) > 0 {
+				logger.Info("Sent reservation reminders", zap.Int("reservations", len(reservations)))
+			}
+			return nil
+		},
+	}
+}
+
+// HandleTwilioStatus applies a Twilio delivery status callback after verifying its signature
+func (s *SMSService) HandleTwilioStatus(ctx context.Context, params url.Values, signature string) error {
+	if s.statusCallbackURL == "" || !verifyTwilioSignature(s.twilioAuthToken, s.statusCallbackURL, params, signature) {
+		return apperrors.Forbidden(apperrors.CodeForbidden, "invalid signature")
+	}
+
+	externalID := params.Get("MessageSid")
+	if externalID == "" {
+		return apperrors.BadRequest(apperrors.CodeBadRequest, "missing MessageSid")
+	}
+	status := twilioStatus(params.Get("MessageStatus"))
+	updated, err := s.smsRepo.UpdateStatusByExternalIDWithContext(ctx, SMSProviderTwilio, externalID, status, params.Get("ErrorCode"), time.Now())
+	if err != nil {
+		return err
+	}
+	if updated > 0 && (status == models.SMSStatusFailed || status == models.SMSStatusUndelivered) {
+		logger.Warn("SMS not delivered",
+			zap.String("external_id", externalID),
+			zap.String("status", status),
+			zap.String("error_code", params.Get("ErrorCode")),
+		)
+	}
+	return nil
+}
+
+// List returns a page of the restaurant's SMS delivery log, newest first, optionally of one message type only
+func (s *SMSService) List(ctx context.Context, restaurantID uint, messageType string, limit, offset int) ([]models.SMSMessage, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.smsRepo.ListWithContext(ctx, restaurantID, messageType, limit, offset)
+}
+
+// send records a message and sends it, unless its reservation or order already got one of the same type
+func (s *SMSService) send(ctx context.Context, message *models.SMSMessage) {
+	message.Provider = s.provider.Name()
+	message.Status = models.SMSStatusQueued
+	created, err := s.smsRepo.CreateOnceWithContext(ctx, message)
+	if err != nil {
+		logger.Warn("Failed to record SMS",
+			zap.Uint("restaurant_id", message.RestaurantID),
+			zap.String("type", message.Type),
+			zap.Error(err),
+		)
+		return
+	}
+	if !created {
+		return
+	}
+
+	result, err := s.provider.Send(ctx, message.To, message.Body)
+	if err != nil {
+		message.Status = models.SMSStatusFailed
+		message.ErrorMessage = err.Error()
+		var smsErr *SMSError
+		if errors.As(err, &smsErr) {
+			message.ErrorCode = smsErr.Code
+			message.ErrorMessage = smsErr.Message
+		}
+		logger.Warn("Failed to send SMS",
+			zap.Uint("restaurant_id", message.RestaurantID),
+			zap.Uint("sms_message_id", message.ID),
+			zap.String("type", message.Type),
+			zap.Error(err),
+		)
+	} else {
+		now := time.Now()
+		message.ExternalID = result.ExternalID
+		message.Status = result.Status
+		message.SentAt = &now
+	}
+
+	if err := s.smsRepo.UpdateSendResultWithContext(ctx, message); err != nil {
+		logger.Warn("Failed to record SMS send result",
+			zap.Uint("sms_message_id", message.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+// optedIn returns the restaurant's settings if it opted into the message type, nil otherwise
+func (s *SMSService) optedIn(ctx context.Context, restaurantID uint, enabled func(*models.RestaurantSettings) bool) *models.RestaurantSettings {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Failed to load restaurant settings for SMS",
+				zap.Uint("restaurant_id", restaurantID),
+				zap.Error(err),
+			)
+		}
+		return nil
+	}
+	if !enabled(settings) {
+		return nil
+	}
+	return settings
+}
+
+// restaurantName returns the name messages are signed with, empty if the restaurant can't be loaded
+func (s *SMSService) restaurantName(ctx context.Context, restaurantID uint) string {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		logger.Warn("Failed to load restaurant for SMS",
+			zap.Uint("restaurant_id", restaurantID),
+			zap.Error(err),
+		)
+		return ""
+	}
+	return restaurant.Name
+}
+
+// smsSigned prefixes a message with the restaurant's name, so guests know who is texting
+func smsSigned(restaurantName, text string) string {
+	if restaurantName == "" {
+		return text
+	}
+	return restaurantName + ": " + text
+}
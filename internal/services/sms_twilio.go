@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"restaurant-backend/internal/models"
+)
+
+// twilioProvider sends text messages through the Twilio Programmable Messaging API
+type twilioProvider struct {
+	baseURL           string
+	accountSID        string
+	authToken         string
+	from              string
+	statusCallbackURL string
+	client            *http.Client
+}
+
+type twilioMessageResponse struct {
+	SID          string `json:"sid"`
+	Status       string `json:"status"`
+	ErrorCode    *int   `json:"error_code"`
+	ErrorMessage string `json:"error_message"`
+}
+
+type twilioErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Name returns the provider name
+func (p *twilioProvider) Name() string {
+	return SMSProviderTwilio
+}
+
+// Send creates a message; Twilio queues it and reports delivery to the status callback
+func (p *twilioProvider) Send(ctx context.Context, to, body string) (*SMSResult, error) {
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("From", p.from)
+	form.Set("Body", body)
+	if p.statusCallbackURL != "" {
+		form.Set("StatusCallback", p.statusCallbackURL)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", strings.TrimRight(p.baseURL, "/"), url.PathEscape(p.accountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(p.accountSID, p.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		var twilioErr twilioErrorResponse
+		if json.Unmarshal(detail, &twilioErr) == nil && twilioErr.Code != 0 {
+			return nil, &SMSError{Code: strconv.Itoa(twilioErr.Code), Message: twilioErr.Message}
+		}
+		return nil, fmt.Errorf("POST %s returned %d: %s", endpoint, resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	var message twilioMessageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&message); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if message.ErrorCode != nil {
+		return nil, &SMSError{Code: strconv.Itoa(*message.ErrorCode), Message: message.ErrorMessage}
+	}
+
+	return &SMSResult{ExternalID: message.SID, Status: twilioStatus(message.Status)}, nil
+}
+
+// twilioStatus maps a Twilio message status to ours
+// Twilio's intermediate statuses (accepted, scheduled, sending) are reported as queued or sent
+func twilioStatus(status string) string {
+	switch status {
+	case "delivered", "read":
+		return models.SMSStatusDelivered
+	case "undelivered":
+		return models.SMSStatusUndelivered
+	case "failed", "canceled":
+		return models.SMSStatusFailed
+	case "sending", "sent":
+		return models.SMSStatusSent
+	default:
+		return models.SMSStatusQueued
+	}
+}
+
+// verifyTwilioSignature checks the X-Twilio-Signature of a callback: the base64-encoded HMAC-SHA1, keyed by
+// the auth token, of the callback URL followed by every POST parameter name and value sorted by name
+func verifyTwilioSignature(authToken, callbackURL string, params url.Values, signature string) bool {
+	if authToken == "" || signature == "" {
+		return false
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var payload strings.Builder
+	payload.WriteString(callbackURL)
+	for _, key := range keys {
+		for _, value := range params[key] {
+			payload.WriteString(key)
+			payload.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(payload.String()))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,476 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+	"restaurant-backend/internal/tracing"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+// ssoRoleRank orders the roles IdP groups can map to; the highest mapped role wins
+var ssoRoleRank = map[string]int{"Staff": 1, "Admin": 2}
+
+// SSOService signs restaurant staff in through their organization's OpenID Connect identity provider
+// The login is the authorization code flow with PKCE; its state travels in a signed, short-lived cookie, so
+// instances share nothing but the signing secret
+type SSOService struct {
+	configRepo     *repositories.SSOConfigRepository
+	userRepo       *repositories.UserRepository
+	restaurantRepo *repositories.RestaurantRepository
+	auth           *AuthService
+	subscription   *SubscriptionService
+	callbackURL    string
+	stateKey       []byte
+	stateTTL       time.Duration
+	client         *http.Client
+
+	mu        sync.Mutex
+	providers map[string]*oidc.Provider // By issuer; a provider caches its signing keys
+}
+
+// NewSSOService creates a new SSOService instance
+func NewSSOService(
+	configRepo *repositories.SSOConfigRepository,
+	userRepo *repositories.UserRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	auth *AuthService,
+	subscription *SubscriptionService,
+	callbackURL, stateSecret string,
+	stateTTL time.Duration,
+) *SSOService {
+	key := sha256.Sum256([]byte("sso-state:" + stateSecret))
+	// Issuers are set by restaurants, so discovery, token and key requests only go to public addresses
+	client := newPublicHTTPClient(10 * time.Second)
+	client.Transport = tracing.Transport(client.Transport)
+	return &SSOService{
+		configRepo:     configRepo,
+		userRepo:       userRepo,
+		restaurantRepo: restaurantRepo,
+		auth:           auth,
+		subscription:   subscription,
+		callbackURL:    callbackURL,
+		stateKey:       key[:],
+		stateTTL:       stateTTL,
+		client:         client,
+		providers:      make(map[string]*oidc.Provider),
+	}
+}
+
+// UpdateSSOConfigRequest sets a restaurant's identity provider
+type UpdateSSOConfigRequest struct {
+	Enabled       bool              `json:"enabled"`
+	Issuer        string            `json:"issuer" binding:"required,url"`
+	ClientID      string            `json:"client_id" binding:"required"`
+	ClientSecret  string            `json:"client_secret"` // Kept when empty; required the first time
+	GroupsClaim   string            `json:"groups_claim"`
+	RoleMappings  map[string]string `json:"role_mappings"`
+	DefaultRole   string            `json:"default_role" binding:"omitempty,oneof=Admin Staff"`
+	AutoProvision *bool             `json:"auto_provision"` // Defaults to true
+}
+
+// SSOLoginStart is the redirect that starts a login, with the state cookie to set on it
+type SSOLoginStart struct {
+	AuthURL     string
+	StateCookie string
+	ExpiresAt   time.Time
+}
+
+// ssoState is the login state kept in the cookie between the redirect to the IdP and the callback
+type ssoState struct {
+	RestaurantID uint   `json:"rid"`
+	State        string `json:"state"`    // Echoed by the IdP, must match the callback's state parameter
+	Nonce        string `json:"nonce"`    // Must match the ID token's nonce
+	Verifier     string `json:"verifier"` // PKCE code verifier
+	ExpiresAt    int64  `json:"exp"`
+}
+
+// ssoClaims are the ID token claims used to identify and provision the user
+type ssoClaims struct {
+	Email         string `json:"email"`
+	EmailVerified *bool  `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+// GetConfig returns a restaurant's SSO configuration
+func (s *SSOService) GetConfig(ctx context.Context, restaurantID uint) (*models.SSOConfig, error) {
+	config, err := s.configRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeSSONotConfigured, "SSO is not configured")
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// UpdateConfig creates or replaces a restaurant's SSO configuration
+// Enabling it checks the issuer's discovery document, so a typo doesn't lock staff out
+func (s *SSOService) UpdateConfig(ctx context.Context, restaurantID uint, req *UpdateSSOConfigRequest) (*models.SSOConfig, error) {
+	for group, role := range req.RoleMappings {
+		if _, ok := ssoRoleRank[role]; !ok {
+			return nil, apperrors.BadRequest(apperrors.CodeInvalidRole, fmt.Sprintf("group %q maps to %q; roles must be Admin or Staff", group, role))
+		}
+	}
+
+	if err := checkPublicURL(ctx, req.Issuer); err != nil {
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "issuer "+err.Error())
+	}
+
+	config, err := s.configRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		config = &models.SSOConfig{RestaurantID: restaurantID}
+	}
+
+	config.Enabled = req.Enabled
+	config.Issuer = strings.TrimRight(req.Issuer, "/")
+	config.ClientID = req.ClientID
+	if req.ClientSecret != "" {
+		config.ClientSecret = req.ClientSecret
+	}
+	if config.ClientSecret == "" {
+		return nil, apperrors.BadRequest(apperrors.CodeValidationFailed, "client_secret is required")
+	}
+	config.GroupsClaim = req.GroupsClaim
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = models.DefaultSSOGroupsClaim
+	}
+	config.RoleMappings = req.RoleMappings
+	if config.RoleMappings == nil {
+		config.RoleMappings = map[string]string{}
+	}
+	config.DefaultRole = req.DefaultRole
+	config.AutoProvision = req.AutoProvision == nil || *req.AutoProvision
+
+	if config.Enabled {
+		if _, err := s.provider(ctx, config.Issuer); err != nil {
+			return nil, apperrors.Wrap(err, http.StatusBadRequest, apperrors.CodeValidationFailed, "issuer discovery failed; check the issuer URL")
+		}
+	}
+
+	if err := s.configRepo.SaveWithContext(ctx, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// DeleteConfig removes a restaurant's SSO configuration; SSO users keep their accounts
+func (s *SSOService) DeleteConfig(ctx context.Context, restaurantID uint) error {
+	deleted, err := s.configRepo.DeleteWithContext(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return apperrors.NotFound(apperrors.CodeSSONotConfigured, "SSO is not configured")
+	}
+	return nil
+}
+
+// StartLogin builds the redirect to the restaurant's identity provider
+func (s *SSOService) StartLogin(ctx context.Context, restaurantID uint) (*SSOLoginStart, error) {
+	config, oauthConfig, _, err := s.enabledConfig(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	state := ssoState{
+		RestaurantID: config.RestaurantID,
+		State:        randomToken(),
+		Nonce:        randomToken(),
+		Verifier:     oauth2.GenerateVerifier(),
+		ExpiresAt:    time.Now().Add(s.stateTTL).Unix(),
+	}
+	cookie, err := s.signState(&state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SSOLoginStart{
+		AuthURL:     oauthConfig.AuthCodeURL(state.State, oidc.Nonce(state.Nonce), oauth2.S256ChallengeOption(state.Verifier)),
+		StateCookie: cookie,
+		ExpiresAt:   time.Unix(state.ExpiresAt, 0),
+	}, nil
+}
+
+// Callback completes a login: it exchanges the code, verifies the ID token, maps the IdP groups to a role and
+// signs the user in, creating their account on first login when the restaurant allows it
+func (s *SSOService) Callback(ctx context.Context, stateCookie, stateParam, code string, client ClientInfo) (*LoginResponse, error) {
+	state, ok := s.parseState(stateCookie)
+	if !ok || !hmac.Equal([]byte(state.State), []byte(stateParam)) {
+		return nil, apperrors.Unauthorized(apperrors.CodeSSOLoginFailed, "SSO login expired or was started in another browser; try again")
+	}
+
+	config, oauthConfig, provider, err := s.enabledConfig(ctx, state.RestaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauthConfig.Exchange(s.clientContext(ctx), code, oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		return nil, apperrors.Wrap(err, http.StatusUnauthorized, apperrors.CodeSSOLoginFailed, "identity provider rejected the login")
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, apperrors.Unauthorized(apperrors.CodeSSOLoginFailed, "identity provider returned no ID token")
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: config.ClientID}).Verify(s.clientContext(ctx), rawIDToken)
+	if err != nil {
+		return nil, apperrors.Wrap(err, http.StatusUnauthorized, apperrors.CodeSSOLoginFailed, "invalid ID token")
+	}
+	if !hmac.Equal([]byte(idToken.Nonce), []byte(state.Nonce)) {
+		return nil, apperrors.Unauthorized(apperrors.CodeSSOLoginFailed, "invalid ID token")
+	}
+
+	var claims ssoClaims
+	var rawClaims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, apperrors.Wrap(err, http.StatusUnauthorized, apperrors.CodeSSOLoginFailed, "invalid ID token claims")
+	}
+	if err := idToken.Claims(&rawClaims); err != nil {
+		return nil, apperrors.Wrap(err, http.StatusUnauthorized, apperrors.CodeSSOLoginFailed, "invalid ID token claims")
+	}
+	claims.Email = strings.TrimSpace(claims.Email)
+	if claims.Email == "" || (claims.EmailVerified != nil && !*claims.EmailVerified) {
+		return nil, apperrors.Unauthorized(apperrors.CodeSSOLoginFailed, "identity provider returned no verified email address")
+	}
+
+	role := ssoRole(config, ssoGroups(rawClaims[config.GroupsClaim]))
+	if role == "" {
+		return nil, apperrors.Forbidden(apperrors.CodeSSORoleNotMapped, "none of your groups has access to this restaurant")
+	}
+
+	user, err := s.provisionUser(ctx, config, &claims, role)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("SSO login",
+		zap.Uint("restaurant_id", config.RestaurantID),
+		zap.Uint("user_id", user.ID),
+		zap.String("role", user.Role),
+	)
+	return s.auth.CompleteLogin(ctx, user, client)
+}
+
+// provisionUser returns the restaurant user for the IdP identity with the role synced from the IdP groups,
+// creating it when the restaurant allows just-in-time provisioning
+func (s *SSOService) provisionUser(ctx context.Context, config *models.SSOConfig, claims *ssoClaims, role string) (*models.User, error) {
+	existing, err := s.userRepo.GetByEmailAnyRestaurant(ctx, claims.Email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if existing != nil {
+		// Only staff accounts of this restaurant can be taken over by the IdP: anyone can register a client
+		// account under a corporate address
+		if existing.RestaurantID != config.RestaurantID || existing.IsKAM() || existing.Role == "Client" {
+			return nil, apperrors.Conflict(apperrors.CodeEmailTaken, "this email address belongs to another account")
+		}
+		if !existing.IsActive {
+			return nil, apperrors.Forbidden(apperrors.CodeInsufficientScope, "your account is deactivated")
+		}
+		if existing.Role != role {
+			existing.Role = role
+			if err := s.userRepo.UpdateWithContext(ctx, existing); err != nil {
+				return nil, err
+			}
+		}
+		return existing, nil
+	}
+
+	if !config.AutoProvision {
+		return nil, apperrors.Forbidden(apperrors.CodeUserNotFound, "no account exists for your email address; ask an admin to invite you")
+	}
+	if err := s.subscription.CheckLimit(ctx, config.RestaurantID, PlanResourceStaffUsers); err != nil {
+		return nil, err
+	}
+
+	// SSO users sign in through the IdP; a random password they never learn keeps password login closed
+	password, err := bcrypt.GenerateFromPassword([]byte(randomToken()), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	user := &models.User{
+		RestaurantID: config.RestaurantID,
+		Email:        claims.Email,
+		PasswordHash: string(password),
+		FirstName:    claims.GivenName,
+		LastName:     claims.FamilyName,
+		Role:         role,
+		IsActive:     true,
+	}
+	if err := s.userRepo.CreateWithContext(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to provision SSO user: %w", err)
+	}
+	logger.Info("SSO user provisioned",
+		zap.Uint("restaurant_id", config.RestaurantID),
+		zap.Uint("user_id", user.ID),
+		zap.String("role", role),
+	)
+	return user, nil
+}
+
+// enabledConfig loads an enabled SSO configuration of an active restaurant with its OAuth2 client
+func (s *SSOService) enabledConfig(ctx context.Context, restaurantID uint) (*models.SSOConfig, *oauth2.Config, *oidc.Provider, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, nil, nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+	if restaurant.Status != models.RestaurantStatusActive {
+		return nil, nil, nil, apperrors.Forbidden(apperrors.CodeRestaurantInactive, "restaurant is not active")
+	}
+
+	config, err := s.configRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil || !config.Enabled {
+		return nil, nil, nil, apperrors.NotFound(apperrors.CodeSSONotConfigured, "SSO is not enabled for this restaurant")
+	}
+
+	provider, err := s.provider(ctx, config.Issuer)
+	if err != nil {
+		return nil, nil, nil, apperrors.Wrap(err, http.StatusBadGateway, apperrors.CodeSSOLoginFailed, "identity provider is unavailable")
+	}
+
+	return config, &oauth2.Config{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  s.callbackURL,
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}, provider, nil
+}
+
+// provider returns the issuer's provider, running discovery on first use
+func (s *SSOService) provider(ctx context.Context, issuer string) (*oidc.Provider, error) {
+	s.mu.Lock()
+	provider, ok := s.providers[issuer]
+	s.mu.Unlock()
+	if ok {
+		return provider, nil
+	}
+
+	// The provider keeps the context for fetching signing keys later, so it must outlive the request
+	provider, err := oidc.NewProvider(oidc.ClientContext(context.Background(), s.client), issuer)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.providers[issuer] = provider
+	s.mu.Unlock()
+	return provider, nil
+}
+
+// clientContext makes the OAuth2 and OIDC libraries use the instrumented HTTP client
+func (s *SSOService) clientContext(ctx context.Context) context.Context {
+	return oidc.ClientContext(ctx, s.client)
+}
+
+// signState encodes the login state as "<payload>.<signature>"
+func (s *SSOService) signState(state *ssoState) (string, error) {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode SSO state: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + s.stateSignature(encoded), nil
+}
+
+// parseState verifies the state cookie and returns it unless it expired
+func (s *SSOService) parseState(cookie string) (*ssoState, bool) {
+	encoded, signature, ok := strings.Cut(cookie, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.stateSignature(encoded))) {
+		return nil, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+	var state ssoState
+	if err := json.Unmarshal(payload, &state); err != nil || time.Now().Unix() > state.ExpiresAt {
+		return nil, false
+	}
+	return &state, true
+}
+
+// stateSignature is the HMAC of an encoded state
+func (s *SSOService) stateSignature(encoded string) string {
+	mac := hmac.New(sha256.New, s.stateKey)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// SSOFrontendRedirect is where the browser lands after a login: the token goes in the fragment so it
+// never reaches server logs, errors as an error code
+func SSOFrontendRedirect(frontendURL string, resp *LoginResponse, err error) string {
+	fragment := url.Values{}
+	if err != nil {
+		fragment.Set("error", string(apperrors.From(err).Code))
+	} else {
+		fragment.Set("token", resp.Token)
+	}
+	return strings.TrimRight(frontendURL, "/") + "/sso/callback#" + fragment.Encode()
+}
+
+// ssoGroups reads the groups claim, a list of names or a single name
+func ssoGroups(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		groups := make([]string, 0, len(v))
+		for _, group := range v {
+			if name, ok := group.(string); ok {
+				groups = append(groups, name)
+			}
+		}
+		return groups
+	}
+	return nil
+}
+
+// ssoRole returns the highest role the groups map to, the default role when none does
+func ssoRole(config *models.SSOConfig, groups []string) string {
+	role := ""
+	for _, group := range groups {
+		if mapped, ok := config.RoleMappings[group]; ok && ssoRoleRank[mapped] > ssoRoleRank[role] {
+			role = mapped
+		}
+	}
+	if role == "" {
+		role = config.DefaultRole
+	}
+	return role
+}
+
+// randomToken returns 32 random bytes, hex-encoded
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("crypto/rand failed: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
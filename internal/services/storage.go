@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"restaurant-backend/internal/config"
+)
+
+// Storage backends selectable via STORAGE_BACKEND
+const (
+	StorageBackendS3    = "s3"
+	StorageBackendMinIO = "minio"
+	StorageBackendLocal = "local"
+)
+
+// Storage abstracts object storage for uploaded files (images, avatars)
+// Keys are always prefixed with the restaurant ID for tenant isolation
+type Storage interface {
+	UploadFile(ctx context.Context, restaurantID uint, fileName string, fileType string, fileReader io.Reader) (string, error)
+	GeneratePresignedURL(ctx context.Context, key string, expiration time.Duration) (string, error)
+	DeleteFile(ctx context.Context, key string) error
+	Ping(ctx context.Context) error // Verifies the backend is reachable (readiness probe)
+}
+
+// NewStorage creates the storage backend selected by configuration
+func NewStorage(cfg *config.Config) (Storage, error) {
+	switch cfg.StorageBackend {
+	case StorageBackendS3, "":
+		if cfg.S3BucketName == "" {
+			return nil, errors.New("S3_BUCKET_NAME is required for s3 storage")
+		}
+		return NewS3Service(cfg)
+	case StorageBackendMinIO:
+		if cfg.S3BucketName == "" || cfg.S3Endpoint == "" {
+			return nil, errors.New("S3_BUCKET_NAME and S3_ENDPOINT are required for minio storage")
+		}
+		// MinIO does not support virtual-hosted-style bucket addressing by default
+		minioCfg := *cfg
+		minioCfg.S3UsePathStyle = true
+		return NewS3Service(&minioCfg)
+	case StorageBackendLocal:
+		return NewLocalStorage(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported storage backend: %s", cfg.StorageBackend)
+	}
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// StorageBackupService manages platform-controlled backups of tenant S3 assets
+type StorageBackupService struct {
+	backupRepo       *repositories.StorageBackupRepository
+	restaurantRepo   *repositories.RestaurantRepository
+	s3               *S3Service
+	defaultRetention int
+	replication      *ReplicationTarget
+}
+
+// NewStorageBackupService creates a new StorageBackupService instance
+func NewStorageBackupService(
+	backupRepo *repositories.StorageBackupRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+	s3 *S3Service,
+	defaultRetention int,
+	replication *ReplicationTarget,
+) *StorageBackupService {
+	return &StorageBackupService{
+		backupRepo:       backupRepo,
+		restaurantRepo:   restaurantRepo,
+		s3:               s3,
+		defaultRetention: defaultRetention,
+		replication:      replication,
+	}
+}
+
+// SetBackupPolicyRequest represents a tenant backup policy update
+type SetBackupPolicyRequest struct {
+	Tier          string `json:"tier" binding:"required,oneof=standard premium"`
+	RetentionDays int    `json:"retention_days" binding:"omitempty,min=1,max=3650"` // Defaults to the platform retention
+}
+
+// TenantStorageReport is a restaurant's storage footprint and backup status
+type TenantStorageReport struct {
+	RestaurantID   uint               `json:"restaurant_id"`
+	RestaurantName string             `json:"restaurant_name"`
+	Usage          TenantStorageUsage `json:"usage"`
+	Tier           string             `json:"tier"`
+	RetentionDays  int                `json:"retention_days"`
+	Replicated     bool               `json:"replicated"` // Replication rule is active on the bucket
+	LastAppliedAt  *time.Time         `json:"last_applied_at,omitempty"`
+	LastError      string             `json:"last_error,omitempty"`
+}
+
+// StorageReport is the storage footprint and backup status of all tenants
+type StorageReport struct {
+	VersioningEnabled bool                  `json:"versioning_enabled"`
+	GeneratedAt       time.Time             `json:"generated_at"`
+	Tenants           []TenantStorageReport `json:"tenants"`
+}
+
+// SetPolicy stores a restaurant's backup policy and re-applies the bucket configuration
+func (s *StorageBackupService) SetPolicy(ctx context.Context, restaurantID uint, req *SetBackupPolicyRequest) (*models.StorageBackupPolicy, error) {
+	if _, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID); err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	policy := &models.StorageBackupPolicy{
+		RestaurantID:  restaurantID,
+		Tier:          req.Tier,
+		RetentionDays: req.RetentionDays,
+	}
+	if policy.RetentionDays == 0 {
+		policy.RetentionDays = s.defaultRetention
+	}
+
+	if err := s.backupRepo.UpsertWithContext(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	if err := s.ApplyPolicies(ctx); err != nil {
+		return nil, apperrors.Wrap(err, http.StatusBadGateway, apperrors.CodeBackupSyncFailed, "policy saved but the bucket configuration could not be applied")
+	}
+
+	return s.backupRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// ApplyPolicies pushes versioning, lifecycle and replication rules for every restaurant to the bucket
+// Restaurants without a stored policy use the standard tier and the platform retention
+func (s *StorageBackupService) ApplyPolicies(ctx context.Context) error {
+	restaurants, err := s.restaurantRepo.ListWithContext(ctx, nil, nil)
+	if err != nil {
+		return err
+	}
+	policies, err := s.policiesByRestaurant(ctx)
+	if err != nil {
+		return err
+	}
+
+	rules := make([]TenantBackupRule, 0, len(restaurants))
+	for _, restaurant := range restaurants {
+		if models.IsPlatformOrganization(restaurant.ID) {
+			continue
+		}
+		policy := s.effectivePolicy(restaurant.ID, policies)
+		rules = append(rules, TenantBackupRule{
+			RestaurantID:  restaurant.ID,
+			RetentionDays: policy.RetentionDays,
+			Replicate:     policy.Replicated(),
+		})
+	}
+
+	applyErr := s.s3.ApplyBackupRules(ctx, rules, s.replication)
+	if err := s.backupRepo.RecordSyncWithContext(ctx, time.Now(), applyErr); err != nil {
+		logger.Warn("Failed to record storage backup sync", zap.Error(err))
+	}
+	if applyErr != nil {
+		return applyErr
+	}
+
+	logger.Info("Applied storage backup configuration",
+		zap.Int("tenants", len(rules)),
+	)
+	return nil
+}
+
+// GetReport returns the storage footprint and backup status of every tenant
+func (s *StorageBackupService) GetReport(ctx context.Context) (*StorageReport, error) {
+	restaurants, err := s.restaurantRepo.ListWithContext(ctx, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.newReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	usage, err := s.s3.StorageUsage(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	policies, err := s.policiesByRestaurant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	replicated, err := s.s3.ReplicatedTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, restaurant := range restaurants {
+		if models.IsPlatformOrganization(restaurant.ID) {
+			continue
+		}
+		report.Tenants = append(report.Tenants, s.tenantReport(&restaurant, usage, policies, replicated))
+	}
+
+	return report, nil
+}
+
+// GetTenantReport returns the storage footprint and backup status of a single restaurant
+func (s *StorageBackupService) GetTenantReport(ctx context.Context, restaurantID uint) (*TenantStorageReport, error) {
+	restaurant, err := s.restaurantRepo.GetByIDWithContext(ctx, restaurantID)
+	if err != nil {
+		return nil, apperrors.NotFound(apperrors.CodeRestaurantNotFound, "restaurant not found")
+	}
+
+	usage, err := s.s3.StorageUsage(ctx, tenantPrefix(restaurantID))
+	if err != nil {
+		return nil, err
+	}
+	policies, err := s.policiesByRestaurant(ctx)
+	if err != nil {
+		return nil, err
+	}
+	replicated, err := s.s3.ReplicatedTenants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := s.tenantReport(restaurant, usage, policies, replicated)
+	return &report, nil
+}
+
+// newReport creates an empty report with the bucket-level backup status
+func (s *StorageBackupService) newReport(ctx context.Context) (*StorageReport, error) {
+	versioning, err := s.s3.VersioningEnabled(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageReport{
+		VersioningEnabled: versioning,
+		GeneratedAt:       time.Now(),
+		Tenants:           []TenantStorageReport{},
+	}, nil
+}
+
+// tenantReport combines a restaurant's usage, policy and live replication status
+func (s *StorageBackupService) tenantReport(
+	restaurant *models.Restaurant,
+	usage map[uint]*TenantStorageUsage,
+	policies map[uint]models.StorageBackupPolicy,
+	replicated map[uint]bool,
+) TenantStorageReport {
+	policy := s.effectivePolicy(restaurant.ID, policies)
+	report := TenantStorageReport{
+		RestaurantID:   restaurant.ID,
+		RestaurantName: restaurant.Name,
+		Tier:           policy.Tier,
+		RetentionDays:  policy.RetentionDays,
+		Replicated:     replicated[restaurant.ID],
+		LastAppliedAt:  policy.LastAppliedAt,
+		LastError:      policy.LastError,
+	}
+	if tenantUsage, ok := usage[restaurant.ID]; ok {
+		report.Usage = *tenantUsage
+	}
+	return report
+}
+
+// effectivePolicy returns the stored policy of a restaurant or the platform default
+func (s *StorageBackupService) effectivePolicy(restaurantID uint, policies map[uint]models.StorageBackupPolicy) models.StorageBackupPolicy {
+	if policy, ok := policies[restaurantID]; ok {
+		return policy
+	}
+	return models.StorageBackupPolicy{
+		RestaurantID:  restaurantID,
+		Tier:          models.BackupTierStandard,
+		RetentionDays: s.defaultRetention,
+	}
+}
+
+// policiesByRestaurant loads all stored policies keyed by restaurant ID
+func (s *StorageBackupService) policiesByRestaurant(ctx context.Context) (map[uint]models.StorageBackupPolicy, error) {
+	policies, err := s.backupRepo.ListWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byRestaurant := make(map[uint]models.StorageBackupPolicy, len(policies))
+	for _, policy := range policies {
+		byRestaurant[policy.RestaurantID] = policy
+	}
+	return byRestaurant, nil
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// PlanResource is a resource capped by the subscription plan
+type PlanResource string
+
+const (
+	PlanResourceMenuItems     PlanResource = "menu_items"
+	PlanResourceStaffUsers    PlanResource = "staff_users"
+	PlanResourceMonthlyOrders PlanResource = "monthly_orders"
+)
+
+// staffRoles are the roles counted against the plan's staff user limit
+var staffRoles = []string{"Admin", "Staff"}
+
+// IsStaffRole checks if a role counts against the plan's staff user limit
+func IsStaffRole(role string) bool {
+	for _, r := range staffRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// SubscriptionService handles subscription plans and enforces their limits
+type SubscriptionService struct {
+	subscriptionRepo *repositories.SubscriptionRepository
+	menuItemRepo     *repositories.MenuItemRepository
+	userRepo         *repositories.UserRepository
+	orderRepo        *repositories.OrderRepository
+}
+
+// NewSubscriptionService creates a new SubscriptionService instance
+func NewSubscriptionService(
+	subscriptionRepo *repositories.SubscriptionRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	userRepo *repositories.UserRepository,
+	orderRepo *repositories.OrderRepository,
+) *SubscriptionService {
+	return &SubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		menuItemRepo:     menuItemRepo,
+		userRepo:         userRepo,
+		orderRepo:        orderRepo,
+	}
+}
+
+// ChangePlanRequest represents a plan upgrade/downgrade request
+type ChangePlanRequest struct {
+	Plan models.PlanCode `json:"plan" binding:"required,oneof=free standard premium"`
+}
+
+// PlanUsage is a restaurant's current usage of plan-limited resources
+type PlanUsage struct {
+	MenuItems     int64 `json:"menu_items"`
+	StaffUsers    int64 `json:"staff_users"`
+	MonthlyOrders int64 `json:"monthly_orders"`
+}
+
+// SubscriptionOverview is a restaurant's subscription with its plan and usage
+type SubscriptionOverview struct {
+	Subscription *models.Subscription `json:"subscription"`
+	Plan         models.Plan          `json:"plan"`
+	Usage        PlanUsage            `json:"usage"`
+}
+
+// ListPlans returns the plan catalog
+func (s *SubscriptionService) ListPlans() []models.Plan {
+	return models.Plans
+}
+
+// GetSubscription returns a restaurant's subscription, plan and current usage
+func (s *SubscriptionService) GetSubscription(ctx context.Context, restaurantID uint) (*SubscriptionOverview, error) {
+	subscription, plan, err := s.getSubscription(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.getUsage(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubscriptionOverview{Subscription: subscription, Plan: plan, Usage: *usage}, nil
+}
+
+// ChangePlan upgrades or downgrades a restaurant's plan
+// Downgrades are rejected while current usage exceeds the target plan's limits
+func (s *SubscriptionService) ChangePlan(ctx context.Context, restaurantID uint, req *ChangePlanRequest) (*SubscriptionOverview, error) {
+	target, ok := models.GetPlan(req.Plan)
+	if !ok {
+		return nil, apperrors.BadRequest(apperrors.CodeInvalidPlan, "unknown plan")
+	}
+
+	subscription, current, err := s.getSubscription(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := s.getUsage(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if target.Rank < current.Rank {
+		if resource, over := exceededLimit(target.Limits, usage); over {
+			return nil, apperrors.Conflict(apperrors.CodePlanDowngradeBlocked,
+				fmt.Sprintf("current %s usage exceeds the %s plan limit", resource, target.Name))
+		}
+	}
+
+	if subscription.Plan != target.Code {
+		subscription.Plan = target.Code
+		subscription.CurrentPeriodStart = time.Now()
+	}
+	// Changing plan is how a past-due or cancelled restaurant resubscribes
+	subscription.Status = models.SubscriptionStatusActive
+
+	if err := s.subscriptionRepo.SaveWithContext(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to save subscription: %w", err)
+	}
+
+	return &SubscriptionOverview{Subscription: subscription, Plan: target, Usage: *usage}, nil
+}
+
+// CheckLimit returns an error if the restaurant may not create another resource
+// Inactive subscriptions are rejected with 403, reached limits with 402
+func (s *SubscriptionService) CheckLimit(ctx context.Context, restaurantID uint, resource PlanResource) error {
+	return s.CheckCapacity(ctx, restaurantID, resource, 1)
+}
+
+// CheckCapacity returns an error if the restaurant may not create n more resources at once, like CheckLimit
+func (s *SubscriptionService) CheckCapacity(ctx context.Context, restaurantID uint, resource PlanResource, n int64) error {
+	// The platform organization is not a subscriber
+	if models.IsPlatformOrganization(restaurantID) {
+		return nil
+	}
+
+	subscription, plan, err := s.getSubscription(ctx, restaurantID)
+	if err != nil {
+		return err
+	}
+	if !subscription.IsActive() {
+		return apperrors.Forbidden(apperrors.CodeSubscriptionInactive,
+			fmt.Sprintf("subscription is %s; renew or change plan to continue", subscription.Status))
+	}
+
+	var limit int
+	var count func() (int64, error)
+	switch resource {
+	case PlanResourceMenuItems:
+		limit = plan.Limits.MaxMenuItems
+		count = func() (int64, error) { return s.menuItemRepo.CountByRestaurantIDWithContext(ctx, restaurantID) }
+	case PlanResourceStaffUsers:
+		limit = plan.Limits.MaxStaffUsers
+		count = func() (int64, error) { return s.userRepo.CountByRolesWithContext(ctx, restaurantID, staffRoles) }
+	case PlanResourceMonthlyOrders:
+		limit = plan.Limits.MaxMonthlyOrders
+		count = func() (int64, error) {
+			return s.orderRepo.CountCreatedSinceWithContext(ctx, restaurantID, repositories.UsagePeriodStart(time.Now()))
+		}
+	default:
+		return fmt.Errorf("unknown plan resource %q", resource)
+	}
+
+	if limit == 0 {
+		return nil
+	}
+
+	used, err := count()
+	if err != nil {
+		return fmt.Errorf("failed to count %s: %w", resource, err)
+	}
+	if used+n > int64(limit) {
+		return apperrors.PaymentRequired(apperrors.CodePlanLimitReached,
+			fmt.Sprintf("the %s plan allows %d %s; upgrade to add more", plan.Name, limit, resource))
+	}
+
+	return nil
+}
+
+// getSubscription returns a restaurant's subscription and plan, defaulting to the free plan
+func (s *SubscriptionService) getSubscription(ctx context.Context, restaurantID uint) (*models.Subscription, models.Plan, error) {
+	subscription, err := s.subscriptionRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, models.Plan{}, fmt.Errorf("failed to get subscription: %w", err)
+		}
+		subscription = &models.Subscription{
+			RestaurantID:       restaurantID,
+			Plan:               models.DefaultPlan,
+			Status:             models.SubscriptionStatusActive,
+			CurrentPeriodStart: time.Now(),
+		}
+	}
+
+	plan, ok := models.GetPlan(subscription.Plan)
+	if !ok {
+		return nil, models.Plan{}, fmt.Errorf("subscription %d has unknown plan %q", subscription.ID, subscription.Plan)
+	}
+
+	return subscription, plan, nil
+}
+
+// getUsage counts a restaurant's plan-limited resources
+func (s *SubscriptionService) getUsage(ctx context.Context, restaurantID uint) (*PlanUsage, error) {
+	var usage PlanUsage
+	var err error
+	if usage.MenuItems, err = s.menuItemRepo.CountByRestaurantIDWithContext(ctx, restaurantID); err != nil {
+		return nil, fmt.Errorf("failed to count menu items: %w", err)
+	}
+	if usage.StaffUsers, err = s.userRepo.CountByRolesWithContext(ctx, restaurantID, staffRoles); err != nil {
+		return nil, fmt.Errorf("failed to count staff users: %w", err)
+	}
+	if usage.MonthlyOrders, err = s.orderRepo.CountCreatedSinceWithContext(ctx, restaurantID, repositories.UsagePeriodStart(time.Now())); err != nil {
+		return nil, fmt.Errorf("failed to count monthly orders: %w", err)
+	}
+	return &usage, nil
+}
+
+// exceededLimit returns the first resource whose usage is over the limits
+// Monthly orders are not checked; they reset with the calendar month
+func exceededLimit(limits models.PlanLimits, usage *PlanUsage) (PlanResource, bool) {
+	if limits.MaxMenuItems > 0 && usage.MenuItems > int64(limits.MaxMenuItems) {
+		return PlanResourceMenuItems, true
+	}
+	if limits.MaxStaffUsers > 0 && usage.StaffUsers > int64(limits.MaxStaffUsers) {
+		return PlanResourceStaffUsers, true
+	}
+	return "", false
+}
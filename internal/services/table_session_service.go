@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// tableSessionListLimit bounds table session listings to the latest sessions
+const tableSessionListLimit = 100
+
+// TableSessionService manages the open checks of seated dine-in parties
+// A session collects the rounds a party orders over time as dine-in orders and is closed with the payment
+type TableSessionService struct {
+	sessionRepo   *repositories.TableSessionRepository
+	floorPlanRepo *repositories.FloorPlanRepository
+	orders        *OrderService
+	subscription  *SubscriptionService
+}
+
+// NewTableSessionService creates a new TableSessionService instance
+func NewTableSessionService(
+	sessionRepo *repositories.TableSessionRepository,
+	floorPlanRepo *repositories.FloorPlanRepository,
+	orders *OrderService,
+	subscription *SubscriptionService,
+) *TableSessionService {
+	return &TableSessionService{
+		sessionRepo:   sessionRepo,
+		floorPlanRepo: floorPlanRepo,
+		orders:        orders,
+		subscription:  subscription,
+	}
+}
+
+// OpenTableSessionRequest represents a request to seat a party and open its check
+type OpenTableSessionRequest struct {
+	TableNumber string `json:"table_number" binding:"required,max=20"`
+	Guests      int    `json:"guests" binding:"required,min=1,max=100"`
+	Notes       string `json:"notes" binding:"max=255"`
+}
+
+// UpdateTableSessionRequest represents a change to an open check, e.g. moving the party to another table
+type UpdateTableSessionRequest struct {
+	TableNumber *string `json:"table_number" binding:"omitempty,min=1,max=20"`
+	Guests      *int    `json:"guests" binding:"omitempty,min=1,max=100"`
+	Notes       *string `json:"notes" binding:"omitempty,max=255"`
+}
+
+// AddRoundRequest represents a round of items ordered by a seated party
+type AddRoundRequest struct {
+	Items []OrderItemRequest `json:"items" binding:"required,min=1"`
+	Notes string             `json:"notes"`
+}
+
+// CloseTableSessionRequest represents the payment that closes a check
+type CloseTableSessionRequest struct {
+	PaymentMethod string      `json:"payment_method" binding:"required,oneof=cash card other"`
+	Tip           money.Cents `json:"tip" binding:"min=0"`
+}
+
+// TableCheck is a table session with its running total: the sum of its non-cancelled rounds
+type TableCheck struct {
+	*models.TableSession
+	RunningTotal money.Cents `json:"running_total"`
+}
+
+// OpenSession seats a party at a table and opens its check
+func (s *TableSessionService) OpenSession(ctx context.Context, restaurantID, openedBy uint, req *OpenTableSessionRequest) (*TableCheck, error) {
+	tableNumber, err := s.checkTable(ctx, restaurantID, req.TableNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &models.TableSession{
+		RestaurantID:   restaurantID,
+		TableNumber:    tableNumber,
+		Guests:         req.Guests,
+		Status:         models.TableSessionStatusOpen,
+		Notes:          strings.TrimSpace(req.Notes),
+		OpenedByUserID: openedBy,
+		OpenedAt:       time.Now(),
+	}
+	if err := s.sessionRepo.CreateWithContext(ctx, session); err != nil {
+		return nil, tableSessionError(err)
+	}
+	session.Rounds = []models.Order{}
+	return newTableCheck(session), nil
+}
+
+// ListSessions lists the latest open or closed checks, newest first
+func (s *TableSessionService) ListSessions(ctx context.Context, restaurantID uint, status string) ([]TableCheck, error) {
+	if status == "" {
+		status = models.TableSessionStatusOpen
+	}
+	if status != models.TableSessionStatusOpen && status != models.TableSessionStatusClosed {
+		return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "status must be open or closed")
+	}
+
+	sessions, err := s.sessionRepo.ListWithContext(ctx, restaurantID, status, tableSessionListLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]TableCheck, 0, len(sessions))
+	for i := range sessions {
+		checks = append(checks, *newTableCheck(&sessions[i]))
+	}
+	return checks, nil
+}
+
+// GetSession returns a check with its rounds and their items
+func (s *TableSessionService) GetSession(ctx context.Context, id, restaurantID uint) (*TableCheck, error) {
+	session, err := s.getSession(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	return newTableCheck(session), nil
+}
+
+// UpdateSession moves an open check to another table or changes its guests or notes
+func (s *TableSessionService) UpdateSession(ctx context.Context, id, restaurantID uint, req *UpdateTableSessionRequest) (*TableCheck, error) {
+	session, err := s.getSession(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != models.TableSessionStatusOpen {
+		return nil, apperrors.Conflict(apperrors.CodeTableSessionClosed, "table session is already closed")
+	}
+
+	if req.TableNumber != nil {
+		tableNumber, err := s.checkTable(ctx, restaurantID, *req.TableNumber)
+		if err != nil {
+			return nil, err
+		}
+		session.TableNumber = tableNumber
+	}
+	if req.Guests != nil {
+		session.Guests = *req.Guests
+	}
+	if req.Notes != nil {
+		session.Notes = strings.TrimSpace(*req.Notes)
+	}
+
+	if err := s.sessionRepo.UpdateWithContext(ctx, session); err != nil {
+		return nil, tableSessionError(err)
+	}
+	return newTableCheck(session), nil
+}
+
+// AddRound orders a round of items for the party as a dine-in order of the session
+// The round is placed on behalf of the staff member taking it and counts toward the plan's monthly orders
+func (s *TableSessionService) AddRound(ctx context.Context, id, restaurantID, userID uint, req *AddRoundRequest) (*models.Order, error) {
+	session, err := s.getSession(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != models.TableSessionStatusOpen {
+		return nil, apperrors.Conflict(apperrors.CodeTableSessionClosed, "table session is already closed")
+	}
+	if err := s.subscription.CheckLimit(ctx, restaurantID, PlanResourceMonthlyOrders); err != nil {
+		return nil, err
+	}
+
+	return s.orders.CreateOrder(ctx, &CreateOrderRequest{
+		UserID:          userID,
+		Items:           req.Items,
+		Notes:           req.Notes,
+		FulfillmentType: models.OrderFulfillmentDineIn,
+		TableSessionID:  &session.ID,
+	}, restaurantID)
+}
+
+// CloseSession closes a check with its payment once every round is served or cancelled
+// Rounds that are ready are completed; the total is fixed from the non-cancelled rounds
+func (s *TableSessionService) CloseSession(ctx context.Context, id, restaurantID, closedBy uint, req *CloseTableSessionRequest) (*TableCheck, error) {
+	session, err := s.getSession(ctx, id, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Status != models.TableSessionStatusOpen {
+		return nil, apperrors.Conflict(apperrors.CodeTableSessionClosed, "table session is already closed")
+	}
+
+	inProgress := 0
+	for _, round := range session.Rounds {
+		switch round.Status {
+		case models.OrderStatusPending, models.OrderStatusConfirmed, models.OrderStatusPreparing:
+			inProgress++
+		}
+	}
+	if inProgress > 0 {
+		return nil, apperrors.Conflict(apperrors.CodeRoundsInProgress,
+			fmt.Sprintf("%d round(s) are still in the kitchen; serve or cancel them before closing the check", inProgress))
+	}
+
+	for _, round := range session.Rounds {
+		if round.Status != models.OrderStatusReady {
+			continue
+		}
+		if _, err := s.orders.UpdateOrderStatusWithCtx(ctx, round.ID, restaurantID, closedBy,
+			&UpdateOrderStatusRequest{Status: models.OrderStatusCompleted}); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	session.ClosedByUserID = &closedBy
+	session.ClosedAt = &now
+	session.PaymentMethod = req.PaymentMethod
+	session.Tip = req.Tip
+	if err := s.sessionRepo.CloseWithContext(ctx, session); err != nil {
+		return nil, tableSessionError(err)
+	}
+
+	return s.GetSession(ctx, id, restaurantID)
+}
+
+// getSession retrieves a table session with its rounds
+func (s *TableSessionService) getSession(ctx context.Context, id, restaurantID uint) (*models.TableSession, error) {
+	session, err := s.sessionRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeTableSessionNotFound, "table session not found")
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// checkTable normalizes a table number and rejects tables taken out of service on the floor plan
+// Restaurants without a floor plan can seat parties at any table number
+func (s *TableSessionService) checkTable(ctx context.Context, restaurantID uint, number string) (string, error) {
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return "", apperrors.BadRequest(apperrors.CodeBadRequest, "table number is required")
+	}
+
+	table, err := s.floorPlanRepo.GetTableByNumberWithContext(ctx, restaurantID, number)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return number, nil
+		}
+		return "", err
+	}
+	if !table.IsActive {
+		return "", apperrors.Conflict(apperrors.CodeTableInactive, "table is not in service")
+	}
+	return number, nil
+}
+
+// newTableCheck builds the check of a session; closed sessions keep the total fixed at close
+func newTableCheck(session *models.TableSession) *TableCheck {
+	check := &TableCheck{TableSession: session, RunningTotal: session.Total}
+	if session.Status == models.TableSessionStatusOpen {
+		check.RunningTotal = 0
+		for _, round := range session.Rounds {
+			if round.Status != models.OrderStatusCancelled {
+				check.RunningTotal += round.TotalAmount
+			}
+		}
+	}
+	return check
+}
+
+// tableSessionError maps table session repository errors to API errors
+func tableSessionError(err error) error {
+	switch {
+	case errors.Is(err, repositories.ErrTableOccupied):
+		return apperrors.Conflict(apperrors.CodeTableOccupied, "table already has an open check")
+	case errors.Is(err, repositories.ErrTableSessionClosed):
+		return apperrors.Conflict(apperrors.CodeTableSessionClosed, "table session is already closed")
+	}
+	return err
+}
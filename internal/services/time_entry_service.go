@@ -0,0 +1,340 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+
+	"gorm.io/gorm"
+)
+
+// Time entry listing bounds
+const (
+	defaultTimeEntryListDays = 7
+	maxTimeEntryListDays     = 93
+)
+
+// TimeEntryService tracks the worked hours of staff members and their hourly rates
+// Staff clock themselves in and out; admins correct entries and set rates
+type TimeEntryService struct {
+	timeEntryRepo *repositories.TimeEntryRepository
+	userRepo      *repositories.UserRepository
+	settingsRepo  *repositories.RestaurantSettingsRepository
+}
+
+// NewTimeEntryService creates a new TimeEntryService instance
+func NewTimeEntryService(
+	timeEntryRepo *repositories.TimeEntryRepository,
+	userRepo *repositories.UserRepository,
+	settingsRepo *repositories.RestaurantSettingsRepository,
+) *TimeEntryService {
+	return &TimeEntryService{
+		timeEntryRepo: timeEntryRepo,
+		userRepo:      userRepo,
+		settingsRepo:  settingsRepo,
+	}
+}
+
+// ClockRequest represents a clock-in or clock-out request
+type ClockRequest struct {
+	Notes string `json:"notes" binding:"max=255"`
+}
+
+// CreateTimeEntryRequest represents an admin's manual time entry, e.g. for a forgotten clock-in
+type CreateTimeEntryRequest struct {
+	UserID     uint         `json:"user_id" binding:"required"`
+	ClockInAt  time.Time    `json:"clock_in_at" binding:"required"`
+	ClockOutAt *time.Time   `json:"clock_out_at"`
+	HourlyRate *money.Cents `json:"hourly_rate" binding:"omitempty,min=0"` // Defaults to the staff member's rate
+	Notes      string       `json:"notes" binding:"max=255"`
+}
+
+// UpdateTimeEntryRequest represents an admin's correction of a time entry
+type UpdateTimeEntryRequest struct {
+	ClockInAt  *time.Time   `json:"clock_in_at"`
+	ClockOutAt *time.Time   `json:"clock_out_at"`
+	HourlyRate *money.Cents `json:"hourly_rate" binding:"omitempty,min=0"`
+	Notes      *string      `json:"notes" binding:"omitempty,max=255"`
+}
+
+// SetStaffRateRequest represents a request to set a staff member's hourly rate
+type SetStaffRateRequest struct {
+	HourlyRate *money.Cents `json:"hourly_rate" binding:"required,min=0"`
+}
+
+// ClockIn starts a shift for the staff member at the current hourly rate
+func (s *TimeEntryService) ClockIn(ctx context.Context, restaurantID, userID uint, req *ClockRequest) (*models.TimeEntry, error) {
+	if _, err := s.getStaffMember(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+	rate, err := s.hourlyRate(ctx, restaurantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.TimeEntry{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		ClockInAt:    time.Now(),
+		HourlyRate:   rate,
+		Notes:        strings.TrimSpace(req.Notes),
+	}
+	if err := s.timeEntryRepo.ClockInWithContext(ctx, entry); err != nil {
+		if errors.Is(err, repositories.ErrAlreadyClockedIn) {
+			return nil, apperrors.Conflict(apperrors.CodeAlreadyClockedIn, "already clocked in")
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ClockOut ends the open shift of the staff member
+func (s *TimeEntryService) ClockOut(ctx context.Context, restaurantID, userID uint, req *ClockRequest) (*models.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.ClockOutWithContext(ctx, restaurantID, userID, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.Conflict(apperrors.CodeNotClockedIn, "not clocked in")
+		}
+		return nil, err
+	}
+	if notes := strings.TrimSpace(req.Notes); notes != "" {
+		entry.Notes = notes
+		if err := s.timeEntryRepo.SaveWithContext(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+// GetCurrentEntry returns the open shift of the staff member
+func (s *TimeEntryService) GetCurrentEntry(ctx context.Context, restaurantID, userID uint) (*models.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.GetOpenWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeNotClockedIn, "not clocked in")
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListEntries lists the time entries clocked in between two dates (inclusive, in the restaurant's
+// time zone), the last week by default, optionally for one staff member
+func (s *TimeEntryService) ListEntries(ctx context.Context, restaurantID uint, userID *uint, from, to string) ([]models.TimeEntry, error) {
+	settings, err := s.settings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := dateRangeIn(settingsLocation(settings), from, to, defaultTimeEntryListDays, maxTimeEntryListDays)
+	if err != nil {
+		return nil, err
+	}
+	return s.timeEntryRepo.ListWithContext(ctx, restaurantID, repositories.TimeEntryFilter{UserID: userID}, start, end)
+}
+
+// CreateEntry records a shift on behalf of a staff member
+func (s *TimeEntryService) CreateEntry(ctx context.Context, restaurantID uint, req *CreateTimeEntryRequest) (*models.TimeEntry, error) {
+	if _, err := s.getStaffMember(ctx, req.UserID, restaurantID); err != nil {
+		return nil, err
+	}
+	if err := validateShift(req.ClockInAt, req.ClockOutAt); err != nil {
+		return nil, err
+	}
+
+	entry := &models.TimeEntry{
+		RestaurantID: restaurantID,
+		UserID:       req.UserID,
+		ClockInAt:    req.ClockInAt,
+		ClockOutAt:   req.ClockOutAt,
+		Notes:        strings.TrimSpace(req.Notes),
+	}
+	if req.HourlyRate != nil {
+		entry.HourlyRate = *req.HourlyRate
+	} else {
+		rate, err := s.hourlyRate(ctx, restaurantID, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		entry.HourlyRate = rate
+	}
+
+	if err := s.timeEntryRepo.ClockInWithContext(ctx, entry); err != nil {
+		if errors.Is(err, repositories.ErrAlreadyClockedIn) {
+			return nil, apperrors.Conflict(apperrors.CodeAlreadyClockedIn, "staff member is already clocked in")
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+// UpdateEntry corrects the times, rate or notes of a time entry
+func (s *TimeEntryService) UpdateEntry(ctx context.Context, id, restaurantID uint, req *UpdateTimeEntryRequest) (*models.TimeEntry, error) {
+	entry, err := s.timeEntryRepo.GetByIDForRestaurant(ctx, id, restaurantID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeTimeEntryNotFound, "time entry not found")
+		}
+		return nil, err
+	}
+
+	if req.ClockInAt != nil {
+		entry.ClockInAt = *req.ClockInAt
+	}
+	if req.ClockOutAt != nil {
+		entry.ClockOutAt = req.ClockOutAt
+	}
+	if req.HourlyRate != nil {
+		entry.HourlyRate = *req.HourlyRate
+	}
+	if req.Notes != nil {
+		entry.Notes = strings.TrimSpace(*req.Notes)
+	}
+	if err := validateShift(entry.ClockInAt, entry.ClockOutAt); err != nil {
+		return nil, err
+	}
+
+	if err := s.timeEntryRepo.SaveWithContext(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteEntry deletes a time entry
+func (s *TimeEntryService) DeleteEntry(ctx context.Context, id, restaurantID uint) error {
+	if err := s.timeEntryRepo.DeleteWithContext(ctx, id, restaurantID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NotFound(apperrors.CodeTimeEntryNotFound, "time entry not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// ListRates lists the hourly rates of the restaurant's staff
+func (s *TimeEntryService) ListRates(ctx context.Context, restaurantID uint) ([]models.StaffRate, error) {
+	return s.timeEntryRepo.ListRatesWithContext(ctx, restaurantID)
+}
+
+// SetRate sets the hourly rate of a staff member; it applies to shifts clocked in from now on
+func (s *TimeEntryService) SetRate(ctx context.Context, restaurantID, userID uint, req *SetStaffRateRequest) (*models.StaffRate, error) {
+	if _, err := s.getStaffMember(ctx, userID, restaurantID); err != nil {
+		return nil, err
+	}
+
+	rate := &models.StaffRate{
+		RestaurantID: restaurantID,
+		UserID:       userID,
+		HourlyRate:   *req.HourlyRate,
+		UpdatedAt:    time.Now(),
+	}
+	if err := s.timeEntryRepo.SetRateWithContext(ctx, rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+// getStaffMember retrieves an admin or staff member of the restaurant
+func (s *TimeEntryService) getStaffMember(ctx context.Context, userID, restaurantID uint) (*models.User, error) {
+	user, err := s.userRepo.GetByIDWithContext(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound(apperrors.CodeUserNotFound, "user not found")
+		}
+		return nil, err
+	}
+	if user.RestaurantID != restaurantID || !slices.Contains(staffRoles, user.Role) {
+		return nil, apperrors.NotFound(apperrors.CodeUserNotFound, "user not found")
+	}
+	return user, nil
+}
+
+// hourlyRate returns the current hourly rate of a staff member, 0 if none was set
+func (s *TimeEntryService) hourlyRate(ctx context.Context, restaurantID, userID uint) (money.Cents, error) {
+	rate, err := s.timeEntryRepo.GetRateWithContext(ctx, restaurantID, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return rate.HourlyRate, nil
+}
+
+// settings returns the restaurant's settings, falling back to the defaults
+func (s *TimeEntryService) settings(ctx context.Context, restaurantID uint) (*models.RestaurantSettings, error) {
+	settings, err := s.settingsRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		settings = defaultRestaurantSettings(restaurantID)
+	}
+	return settings, nil
+}
+
+// validateShift checks that a shift started in the past and ends after it started
+func validateShift(clockIn time.Time, clockOut *time.Time) error {
+	now := time.Now()
+	if clockIn.After(now) {
+		return apperrors.BadRequest(apperrors.CodeBadRequest, "clock-in can't be in the future")
+	}
+	if clockOut != nil {
+		if !clockOut.After(clockIn) {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "clock-out must be after clock-in")
+		}
+		if clockOut.After(now) {
+			return apperrors.BadRequest(apperrors.CodeBadRequest, "clock-out can't be in the future")
+		}
+	}
+	return nil
+}
+
+// settingsLocation returns the time zone of the restaurant's settings, UTC if it is invalid
+func settingsLocation(settings *models.RestaurantSettings) *time.Location {
+	location, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// dateRangeIn returns the [start, end) instants of the inclusive YYYY-MM-DD dates from and to in the
+// location. An empty to is today and an empty from is defaultDays before to; at most maxDays are allowed
+func dateRangeIn(location *time.Location, from, to string, defaultDays, maxDays int) (time.Time, time.Time, error) {
+	var last time.Time
+	if to == "" {
+		now := time.Now().In(location)
+		last = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	} else {
+		date, err := parseBusinessDate(to)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		last = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	}
+
+	first := last.AddDate(0, 0, 1-defaultDays)
+	if from != "" {
+		date, err := parseBusinessDate(from)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		first = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, location)
+	}
+
+	if first.After(last) {
+		return time.Time{}, time.Time{}, apperrors.BadRequest(apperrors.CodeBadRequest, "from must not be after to")
+	}
+	end := last.AddDate(0, 0, 1)
+	if first.AddDate(0, 0, maxDays).Before(end) {
+		return time.Time{}, time.Time{}, apperrors.BadRequest(apperrors.CodeBadRequest, "date range is too long")
+	}
+	return first, end, nil
+}
@@ -5,11 +5,12 @@ import (
 	"errors"
 	"fmt"
 
+	"restaurant-backend/internal/apperrors"
+	tenantctx "restaurant-backend/internal/ctx"
 	"restaurant-backend/internal/dto"
 	"restaurant-backend/internal/models"
 	"restaurant-backend/internal/repositories"
 
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -21,24 +22,28 @@ const (
 
 var (
 	// ErrUserNotFound is returned when a user is not found
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound = apperrors.NotFound(apperrors.CodeUserNotFound, "user not found")
 	// ErrUserExists is returned when a user with the email already exists
-	ErrUserExists = errors.New("user with this email already exists in this restaurant")
+	ErrUserExists = apperrors.Conflict(apperrors.CodeEmailTaken, "user with this email already exists in this restaurant")
 	// ErrInvalidRole is returned when an invalid role is provided
-	ErrInvalidRole = errors.New("invalid role")
+	ErrInvalidRole = apperrors.BadRequest(apperrors.CodeInvalidRole, "invalid role")
 	// ErrKAMRoleNotAllowed is returned when KAM role is used in non-KAM endpoints
-	ErrKAMRoleNotAllowed = errors.New("KAM role cannot be used through this endpoint")
+	ErrKAMRoleNotAllowed = apperrors.Forbidden(apperrors.CodeInvalidRole, "KAM role cannot be used through this endpoint")
+	// ErrOwnRoleChange is returned when users try to change their own role
+	ErrOwnRoleChange = apperrors.Forbidden(apperrors.CodeInvalidRole, "users cannot change their own role")
 )
 
 // UserService handles user management operations
 type UserService struct {
-	userRepo *repositories.UserRepository
+	userRepo            *repositories.UserRepository
+	subscriptionService *SubscriptionService
 }
 
 // NewUserService creates a new UserService instance
-func NewUserService(userRepo *repositories.UserRepository) *UserService {
+func NewUserService(userRepo *repositories.UserRepository, subscriptionService *SubscriptionService) *UserService {
 	return &UserService{
-		userRepo: userRepo,
+		userRepo:            userRepo,
+		subscriptionService: subscriptionService,
 	}
 }
 
@@ -86,68 +91,9 @@ func (s *UserService) GetUser(ctx context.Context, id uint, restaurantID uint) (
 	return user, nil
 }
 
-// CreateUser creates a new user with validation and password hashing
-func (s *UserService) CreateUser(ctx context.Context, createDTO *dto.CreateUserDTO, restaurantID uint) (*models.User, error) {
-	// Validate role (KAM not allowed here)
-	if err := validateRole(createDTO.Role); err != nil {
-		return nil, err
-	}
-
-	// Check email uniqueness within restaurant
-	existingUser, err := s.userRepo.GetByEmailWithContext(ctx, createDTO.Email, restaurantID)
-	if err == nil && existingUser != nil {
-		return nil, ErrUserExists
-	}
-
-	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(createDTO.Password), bcrypt.DefaultCost)
-	if err != nil {
-		return nil, fmt.Errorf("failed to hash password: %w", err)
-	}
-
-	// Set defaults for optional fields
-	timezone := createDTO.Timezone
-	if timezone == "" {
-		timezone = defaultTimezone
-	}
-
-	language := createDTO.Language
-	if language == "" {
-		language = defaultLanguage
-	}
-
-	preferences := createDTO.Preferences
-	if preferences == "" {
-		preferences = defaultPreferences
-	}
-
-	// Create user
-	user := &models.User{
-		RestaurantID: restaurantID,
-		Email:        createDTO.Email,
-		PasswordHash: string(hashedPassword),
-		FirstName:    createDTO.FirstName,
-		LastName:     createDTO.LastName,
-		Role:         createDTO.Role,
-		Phone:        createDTO.Phone,
-		Timezone:     timezone,
-		Language:     language,
-		Preferences:  preferences,
-		IsActive:     true,
-	}
-
-	if err := s.userRepo.CreateWithContext(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
-
-	// Clear password hash before returning
-	user.PasswordHash = ""
-
-	return user, nil
-}
-
 // UpdateUser updates an existing user
-func (s *UserService) UpdateUser(ctx context.Context, id uint, updateDTO *dto.UpdateUserDTO, restaurantID uint) (*models.User, error) {
+// expectedVersion is the client's If-Match version; 0 skips the precondition
+func (s *UserService) UpdateUser(ctx context.Context, id uint, updateDTO *dto.UpdateUserDTO, restaurantID uint, expectedVersion int) (*models.User, error) {
 	// Get existing user
 	user, err := s.userRepo.GetByIDWithContext(ctx, id)
 	if err != nil {
@@ -162,11 +108,24 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, updateDTO *dto.Up
 		return nil, ErrUserNotFound
 	}
 
+	if err := checkVersion(expectedVersion, user.Version); err != nil {
+		return nil, err
+	}
+
 	// Validate role if provided (KAM not allowed)
 	if updateDTO.Role != "" {
 		if err := validateRole(updateDTO.Role); err != nil {
 			return nil, err
 		}
+		if callerID, _ := tenantctx.GetUserID(ctx); callerID == user.ID && updateDTO.Role != user.Role {
+			return nil, ErrOwnRoleChange
+		}
+		// Promoting a client to staff takes a staff seat
+		if IsStaffRole(updateDTO.Role) && !IsStaffRole(user.Role) {
+			if err := s.subscriptionService.CheckLimit(ctx, restaurantID, PlanResourceStaffUsers); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Update fields
@@ -194,7 +153,7 @@ func (s *UserService) UpdateUser(ctx context.Context, id uint, updateDTO *dto.Up
 
 	// Save updated user
 	if err := s.userRepo.UpdateWithContext(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to update user: %w", err)
+		return nil, fmt.Errorf("failed to update user: %w", versionConflict(err))
 	}
 
 	// Clear password hash
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"errors"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/repositories"
+)
+
+// checkVersion rejects an update whose expected version (from If-Match) is stale
+// An expected version of 0 means the client sent no precondition
+func checkVersion(expected, current int) error {
+	if expected != 0 && expected != current {
+		return apperrors.ErrVersionConflict
+	}
+	return nil
+}
+
+// versionConflict maps a repository version conflict to its API error
+func versionConflict(err error) error {
+	if errors.Is(err, repositories.ErrVersionConflict) {
+		return apperrors.ErrVersionConflict
+	}
+	return err
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/money"
+	"restaurant-backend/internal/repositories"
+)
+
+// Waste log date ranges
+const (
+	wasteDefaultDays = 30
+	wasteMaxDays     = 366
+)
+
+// WasteService logs stock lost outside of sales and deducts it from inventory
+type WasteService struct {
+	wasteRepo      *repositories.WasteRepository
+	ingredientRepo *repositories.IngredientRepository
+	menuItemRepo   *repositories.MenuItemRepository
+	settings       *RestaurantSettingsService
+}
+
+// NewWasteService creates a new WasteService instance
+func NewWasteService(
+	wasteRepo *repositories.WasteRepository,
+	ingredientRepo *repositories.IngredientRepository,
+	menuItemRepo *repositories.MenuItemRepository,
+	settings *RestaurantSettingsService,
+) *WasteService {
+	return &WasteService{
+		wasteRepo:      wasteRepo,
+		ingredientRepo: ingredientRepo,
+		menuItemRepo:   menuItemRepo,
+		settings:       settings,
+	}
+}
+
+// WasteRequest logs waste of either an ingredient or servings of a menu item
+type WasteRequest struct {
+	IngredientID *uint   `json:"ingredient_id" binding:"required_without=MenuItemID,excluded_with=MenuItemID"`
+	MenuItemID   *uint   `json:"menu_item_id" binding:"required_without=IngredientID"`
+	Quantity     float64 `json:"quantity" binding:"required,gt=0"` // In the ingredient's unit, or servings
+	Reason       string  `json:"reason" binding:"required,oneof=spoiled expired damaged overproduction theft other"`
+	Notes        string  `json:"notes" binding:"max=500"`
+}
+
+// WasteList is a page of the waste log
+type WasteList struct {
+	Entries []models.WasteEntry `json:"entries"`
+	Total   int64               `json:"total"`
+	Limit   int                 `json:"limit"`
+	Offset  int                 `json:"offset"`
+}
+
+// WasteSummary totals the waste of a date range per ingredient and reason
+type WasteSummary struct {
+	From      string                    `json:"from"` // YYYY-MM-DD, in the restaurant's time zone
+	To        string                    `json:"to"`
+	Currency  string                    `json:"currency"`
+	TotalCost float64                   `json:"total_cost"`
+	ByReason  map[string]float64        `json:"by_reason"` // Cost per reason
+	Totals    []repositories.WasteTotal `json:"totals"`    // Per ingredient and reason, costliest first
+}
+
+// LogWaste records waste and deducts it from stock; menu items are deducted through their recipe
+// Stock doesn't go below zero, the entry still records the full wasted quantity and its cost
+func (s *WasteService) LogWaste(ctx context.Context, restaurantID, userID uint, req *WasteRequest) (*models.WasteEntry, error) {
+	// A recipe is the quantities of one serving; an ingredient is wasted directly
+	var recipe []models.MenuItemIngredient
+	if req.MenuItemID != nil {
+		if _, err := s.menuItemRepo.GetByIDForRestaurant(ctx, *req.MenuItemID, restaurantID); err != nil {
+			return nil, apperrors.NotFound(apperrors.CodeMenuItemNotFound, "menu item not found")
+		}
+		lines, err := s.ingredientRepo.GetRecipeWithContext(ctx, restaurantID, *req.MenuItemID)
+		if err != nil {
+			return nil, err
+		}
+		if len(lines) == 0 {
+			return nil, apperrors.BadRequest(apperrors.CodeBadRequest, "menu item has no recipe to deduct stock by")
+		}
+		recipe = lines
+	} else {
+		recipe = []models.MenuItemIngredient{{IngredientID: *req.IngredientID, Quantity: 1}}
+	}
+
+	ingredientIDs := make([]uint, 0, len(recipe))
+	for _, line := range recipe {
+		ingredientIDs = append(ingredientIDs, line.IngredientID)
+	}
+
+	entry := &models.WasteEntry{
+		RestaurantID: restaurantID,
+		MenuItemID:   req.MenuItemID,
+		IngredientID: req.IngredientID,
+		Quantity:     math.Round(req.Quantity*1000) / 1000,
+		Reason:       req.Reason,
+		Notes:        strings.TrimSpace(req.Notes),
+		LoggedBy:     userID,
+	}
+	err := s.wasteRepo.CreateWithLockedIngredientsWithContext(ctx, entry, ingredientIDs, func(ingredients map[uint]*models.Ingredient) error {
+		entry.Lines = make([]models.WasteEntryLine, 0, len(recipe))
+		var total float64
+		for _, line := range recipe {
+			ingredient, ok := ingredients[line.IngredientID]
+			if !ok {
+				return apperrors.NotFound(apperrors.CodeIngredientNotFound, "ingredient not found")
+			}
+			quantity := math.Round(line.Quantity*entry.Quantity*1000) / 1000
+			cost := quantity * ingredient.CostPrice
+			ingredient.StockQuantity = math.Max(0, math.Round((ingredient.StockQuantity-quantity)*1000)/1000)
+			total += cost
+			entry.Lines = append(entry.Lines, models.WasteEntryLine{
+				IngredientID: ingredient.ID,
+				Quantity:     quantity,
+				Cost:         money.FromFloat(cost),
+				Ingredient:   *ingredient,
+			})
+		}
+		entry.Cost = money.FromFloat(total)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListWaste lists the waste logged between two dates (inclusive, in the restaurant's time zone), newest first
+// Defaults to the last 30 days
+func (s *WasteService) ListWaste(ctx context.Context, restaurantID uint, reason, from, to string, limit, offset int) (*WasteList, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := dateRangeIn(settingsLocation(settings), from, to, wasteDefaultDays, wasteMaxDays)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, total, err := s.wasteRepo.ListWithContext(ctx, restaurantID, repositories.WasteFilter{
+		Reason: reason,
+		From:   start,
+		To:     end,
+	}, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []models.WasteEntry{}
+	}
+	return &WasteList{Entries: entries, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// GetSummary totals the waste between two dates (inclusive, in the restaurant's time zone) per ingredient
+// and reason, so shrinkage can be told apart from spoilage. Defaults to the last 30 days
+func (s *WasteService) GetSummary(ctx context.Context, restaurantID uint, from, to string) (*WasteSummary, error) {
+	settings, err := s.settings.GetSettings(ctx, restaurantID)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := dateRangeIn(settingsLocation(settings), from, to, wasteDefaultDays, wasteMaxDays)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.wasteRepo.GetTotalsWithContext(ctx, restaurantID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if totals == nil {
+		totals = []repositories.WasteTotal{}
+	}
+
+	summary := &WasteSummary{
+		From:     start.Format(time.DateOnly),
+		To:       end.AddDate(0, 0, -1).Format(time.DateOnly),
+		Currency: settings.Currency,
+		ByReason: make(map[string]float64),
+		Totals:   totals,
+	}
+	for _, total := range totals {
+		summary.TotalCost += total.Cost
+		summary.ByReason[total.Reason] = money.Round(summary.ByReason[total.Reason] + total.Cost)
+	}
+	summary.TotalCost = money.Round(summary.TotalCost)
+	return summary, nil
+}
@@ -0,0 +1,346 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"restaurant-backend/internal/apperrors"
+	"restaurant-backend/internal/logger"
+	"restaurant-backend/internal/models"
+	"restaurant-backend/internal/repositories"
+
+	"go.uber.org/zap"
+)
+
+// Webhook event names
+const (
+	WebhookEventMenuUpdated = "menu.updated"
+)
+
+// Menu change actions and entities included in menu.updated payloads
+const (
+	MenuChangeCreated = "created"
+	MenuChangeUpdated = "updated"
+	MenuChangeDeleted = "deleted"
+
+	MenuEntityMenuItem = "menu_item"
+	MenuEntityCategory = "category"
+)
+
+const (
+	webhookMaxAttempts     = 8
+	webhookRetryBaseDelay  = 30 * time.Second // Doubles per failed attempt, up to webhookRetryMaxDelay
+	webhookRetryMaxDelay   = time.Hour
+	webhookDeliveryTimeout = 10 * time.Second
+	webhookDeliveryLease   = 5 * time.Minute // A claimed delivery is retried after this if its attempt never finished
+	webhookDeliveryBatch   = 100             // Deliveries claimed per job run
+	webhookDeliveryWorkers = 10              // Deliveries posted in parallel
+	webhookDeliveryKeep    = 30 * 24 * time.Hour
+	webhookSignatureHeader = "X-Webhook-Signature"
+	webhookEventHeader     = "X-Webhook-Event"
+)
+
+// MenuChange summarizes a single change to a restaurant's menu
+type MenuChange struct {
+	Entity   string   `json:"entity"`
+	EntityID uint     `json:"entity_id"`
+	Action   string   `json:"action"`
+	Fields   []string `json:"fields,omitempty"` // Changed fields (updates only)
+}
+
+// WebhookEvent represents the payload delivered to webhook endpoints
+type WebhookEvent struct {
+	Event        string       `json:"event"`
+	RestaurantID uint         `json:"restaurant_id"`
+	MenuVersion  int          `json:"menu_version"`
+	OccurredAt   time.Time    `json:"occurred_at"`
+	Changes      []MenuChange `json:"changes"`
+}
+
+// WebhookService manages webhook endpoints and delivers events to them
+type WebhookService struct {
+	webhookRepo    *repositories.WebhookRepository
+	restaurantRepo *repositories.RestaurantRepository
+	httpClient     *http.Client
+}
+
+// NewWebhookService creates a new WebhookService instance
+func NewWebhookService(
+	webhookRepo *repositories.WebhookRepository,
+	restaurantRepo *repositories.RestaurantRepository,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepo:    webhookRepo,
+		restaurantRepo: restaurantRepo,
+		httpClient:     newPublicHTTPClient(webhookDeliveryTimeout),
+	}
+}
+
+// CreateWebhookRequest represents webhook endpoint creation request
+type CreateWebhookRequest struct {
+	URL         string `json:"url" binding:"required,url"`
+	Description string `json:"description"`
+}
+
+// CreateWebhook registers a new webhook endpoint and returns it with its signing secret
+// The secret is only returned once, at creation time. The URL's host must resolve to public addresses only
+func (s *WebhookService) CreateWebhook(ctx context.Context, req *CreateWebhookRequest, restaurantID uint) (*models.WebhookEndpoint, string, error) {
+	if err := checkPublicURL(ctx, req.URL); err != nil {
+		return nil, "", apperrors.BadRequest(apperrors.CodeBadRequest, "webhook url "+err.Error())
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	endpoint := &models.WebhookEndpoint{
+		RestaurantID: restaurantID,
+		URL:          req.URL,
+		Description:  req.Description,
+		Secret:       secret,
+		IsActive:     true,
+	}
+
+	if err := s.webhookRepo.CreateWithContext(ctx, endpoint); err != nil {
+		return nil, "", err
+	}
+
+	return endpoint, secret, nil
+}
+
+// ListWebhooks lists all webhook endpoints for a restaurant
+func (s *WebhookService) ListWebhooks(ctx context.Context, restaurantID uint) ([]models.WebhookEndpoint, error) {
+	return s.webhookRepo.GetByRestaurantIDWithContext(ctx, restaurantID)
+}
+
+// DeleteWebhook removes a webhook endpoint belonging to the restaurant
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id uint, restaurantID uint) error {
+	if _, err := s.webhookRepo.GetByIDForRestaurant(ctx, id, restaurantID); err != nil {
+		return apperrors.NotFound(apperrors.CodeWebhookNotFound, "webhook not found")
+	}
+
+	return s.webhookRepo.DeleteWithContext(ctx, id)
+}
+
+// NotifyMenuChange bumps the restaurant's menu version and queues a menu.updated event
+// Failures are logged and never block the caller
+func (s *WebhookService) NotifyMenuChange(ctx context.Context, restaurantID uint, change MenuChange) {
+	s.NotifyMenuChanges(ctx, restaurantID, []MenuChange{change})
+}
+
+// NotifyMenuChanges bumps the restaurant's menu version once and queues a single menu.updated event
+// carrying several changes made together (e.g. a bulk availability update)
+// The delivery job sends it to each active endpoint and retries failures with backoff
+func (s *WebhookService) NotifyMenuChanges(ctx context.Context, restaurantID uint, changes []MenuChange) {
+	if s == nil || len(changes) == 0 {
+		return
+	}
+
+	version, err := s.restaurantRepo.IncrementMenuVersionWithContext(ctx, restaurantID)
+	if err != nil {
+		logger.Warn("Failed to increment menu version",
+			zap.Uint("restaurant_id", restaurantID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	endpoints, err := s.webhookRepo.GetActiveByRestaurantIDWithContext(ctx, restaurantID)
+	if err != nil {
+		logger.Warn("Failed to load webhook endpoints",
+			zap.Uint("restaurant_id", restaurantID),
+			zap.Error(err),
+		)
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+
+	event := WebhookEvent{
+		Event:        WebhookEventMenuUpdated,
+		RestaurantID: restaurantID,
+		MenuVersion:  version,
+		OccurredAt:   time.Now().UTC(),
+		Changes:      changes,
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Warn("Failed to encode webhook payload", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	deliveries := make([]models.WebhookDelivery, len(endpoints))
+	for i, endpoint := range endpoints {
+		deliveries[i] = models.WebhookDelivery{
+			RestaurantID:  restaurantID,
+			WebhookID:     endpoint.ID,
+			Event:         event.Event,
+			Payload:       payload,
+			Status:        models.WebhookDeliveryStatusPending,
+			NextAttemptAt: now,
+		}
+	}
+	if err := s.webhookRepo.CreateDeliveriesWithContext(ctx, deliveries); err != nil {
+		logger.Warn("Failed to queue webhook deliveries",
+			zap.Uint("restaurant_id", restaurantID),
+			zap.Error(err),
+		)
+	}
+}
+
+// DeliveryJob is the scheduled job that sends due webhook deliveries and deletes finished ones after
+// webhookDeliveryKeep; a zero interval disables it
+func (s *WebhookService) DeliveryJob(interval time.Duration) Job {
+	return Job{
+		Name:     "webhook_deliveries",
+		Interval: interval,
+		Run: func(ctx context.Context) error {
+			if err := s.deliverDue(ctx); err != nil {
+				return err
+			}
+			deleted, err := s.webhookRepo.DeleteFinishedDeliveriesBeforeWithContext(ctx, time.Now().Add(-webhookDeliveryKeep))
+			if err != nil {
+				return fmt.Errorf("failed to delete old webhook deliveries: %w", err)
+			}
+			if deleted > 0 {
+				logger.Info("Deleted old webhook deliveries", zap.Int64("deleted", deleted))
+			}
+			return nil
+		},
+	}
+}
+
+// deliverDue claims a batch of due deliveries and posts them, webhookDeliveryWorkers at a time
+func (s *WebhookService) deliverDue(ctx context.Context) error {
+	deliveries, err := s.webhookRepo.ClaimDueDeliveriesWithContext(ctx, webhookDeliveryBatch, webhookDeliveryLease)
+	if err != nil {
+		return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, webhookDeliveryWorkers)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			s.deliver(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// deliver makes one attempt at a claimed delivery and records the outcome, scheduling the next attempt
+// with exponential backoff until webhookMaxAttempts
+func (s *WebhookService) deliver(ctx context.Context, delivery models.WebhookDelivery) {
+	endpoint := delivery.Webhook
+	signature := signWebhookPayload(endpoint.Secret, delivery.Payload)
+	statusCode, postErr := s.post(ctx, endpoint.URL, delivery.Event, signature, delivery.Payload)
+
+	if postErr == nil {
+		if err := s.webhookRepo.CompleteDeliveryWithContext(ctx, delivery.ID, statusCode); err != nil {
+			logger.Warn("Failed to record webhook delivery", zap.Uint("delivery_id", delivery.ID), zap.Error(err))
+		}
+	} else {
+		var nextAttemptAt *time.Time
+		if delivery.Attempts < webhookMaxAttempts {
+			next := time.Now().Add(webhookRetryDelay(delivery.Attempts))
+			nextAttemptAt = &next
+		} else {
+			logger.Warn("Webhook delivery failed",
+				zap.Uint("webhook_id", endpoint.ID),
+				zap.Uint("restaurant_id", endpoint.RestaurantID),
+				zap.String("event", delivery.Event),
+				zap.Int("attempts", delivery.Attempts),
+				zap.Error(postErr),
+			)
+		}
+		if err := s.webhookRepo.FailDeliveryWithContext(ctx, delivery.ID, statusCode, postErr.Error(), nextAttemptAt); err != nil {
+			logger.Warn("Failed to record webhook delivery", zap.Uint("delivery_id", delivery.ID), zap.Error(err))
+		}
+		if nextAttemptAt != nil {
+			return
+		}
+	}
+
+	// The endpoint shows the final outcome of its latest delivery
+	if err := s.webhookRepo.RecordDeliveryWithContext(ctx, endpoint.ID, statusCode, postErr == nil); err != nil {
+		logger.Warn("Failed to record webhook delivery", zap.Uint("webhook_id", endpoint.ID), zap.Error(err))
+	}
+}
+
+// webhookRetryDelay is the wait after the given number of failed attempts
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBaseDelay << (attempts - 1)
+	if delay <= 0 || delay > webhookRetryMaxDelay {
+		return webhookRetryMaxDelay
+	}
+	return delay
+}
+
+// post sends a single signed webhook request
+func (s *WebhookService) post(ctx context.Context, endpointURL, event, signature string, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpointURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, event)
+	req.Header.Set(webhookSignatureHeader, "sha256="+signature)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// changedFields returns the sorted column names of an updates map (diff summary)
+func changedFields(updates map[string]interface{}) []string {
+	fields := make([]string, 0, len(updates))
+	for field := range updates {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// signWebhookPayload computes the hex-encoded HMAC-SHA256 signature of a payload
+func signWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// generateWebhookSecret generates a random secret for signing webhook payloads
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}